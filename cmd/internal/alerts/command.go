@@ -0,0 +1,97 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alerts
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/googleapis/mcp-toolbox/cmd/internal"
+	"github.com/googleapis/mcp-toolbox/internal/server"
+	"github.com/spf13/cobra"
+)
+
+// alertsCmd is the command for generating Prometheus alerting rules.
+type alertsCmd struct {
+	*cobra.Command
+	output string
+}
+
+// NewCommand creates a new Command.
+func NewCommand(opts *internal.ToolboxOptions) *cobra.Command {
+	cmd := &alertsCmd{}
+	cmd.Command = &cobra.Command{
+		Use:   "gen-alerts",
+		Short: "Generate Prometheus alerting rules from tool configurations",
+		Long:  "Generate a Prometheus alerting rule file with an error rate alert for every tool, and a latency alert for the tools with a slaLatencyMs.",
+		Args:  cobra.NoArgs,
+		RunE: func(c *cobra.Command, args []string) error {
+			return run(cmd, opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	internal.ConfigFileFlags(cmd.Command, flags, opts)
+	flags.StringVarP(&cmd.output, "output", "o", "", "File to write the alerting rules to. Defaults to stdout.")
+	return cmd.Command
+}
+
+func run(cmd *alertsCmd, opts *internal.ToolboxOptions) error {
+	ctx, cancel := context.WithCancel(cmd.Context())
+	defer cancel()
+
+	ctx, shutdown, err := opts.Setup(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = shutdown(ctx)
+	}()
+
+	// gen-alerts runs offline, so unset environment variables of the sources
+	// resolve to "".
+	parser := internal.ConfigParser{AllowMissingEnvVars: true}
+	if _, err := opts.LoadConfig(ctx, &parser); err != nil {
+		return err
+	}
+
+	toolsMap, _, err := server.InitializeOfflineConfigs(ctx, opts.Cfg)
+	if err != nil {
+		errMsg := fmt.Errorf("failed to initialize resources: %w", err)
+		opts.Logger.ErrorContext(ctx, errMsg.Error())
+		return errMsg
+	}
+	if len(toolsMap) == 0 {
+		return fmt.Errorf("no tools found to generate alerts for")
+	}
+
+	content, err := generate(toolsMap)
+	if err != nil {
+		return err
+	}
+
+	if cmd.output == "" {
+		_, err := fmt.Fprint(opts.IOStreams.Out, content)
+		return err
+	}
+	if err := os.WriteFile(cmd.output, []byte(content), 0644); err != nil {
+		errMsg := fmt.Errorf("error writing alerting rules: %w", err)
+		opts.Logger.ErrorContext(ctx, errMsg.Error())
+		return errMsg
+	}
+	opts.Logger.InfoContext(ctx, fmt.Sprintf("Successfully generated alerting rules for %d tools in %s.", len(toolsMap), cmd.output))
+	return nil
+}
@@ -0,0 +1,112 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alerts
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/mcp-toolbox/cmd/internal"
+	_ "github.com/googleapis/mcp-toolbox/internal/sources/sqlite"
+	_ "github.com/googleapis/mcp-toolbox/internal/tools/sqlite/sqlitesql"
+	"github.com/spf13/cobra"
+)
+
+func invokeCommand(args []string) (string, error) {
+	parentCmd := &cobra.Command{
+		Use:           "toolbox",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	buf := new(bytes.Buffer)
+	opts := internal.NewToolboxOptions(internal.WithIOStreams(buf, buf))
+	internal.PersistentFlags(parentCmd, opts)
+
+	cmd := NewCommand(opts)
+	parentCmd.AddCommand(cmd)
+	parentCmd.SetArgs(args)
+
+	err := parentCmd.Execute()
+	return buf.String(), err
+}
+
+const toolsFileContent = `
+kind: source
+name: my-sqlite
+type: sqlite
+database: ":memory:"
+---
+kind: tool
+name: search-users
+type: sqlite-sql
+source: my-sqlite
+description: search users
+statement: SELECT * FROM users
+slaLatencyMs: 250
+---
+kind: tool
+name: count-users
+type: sqlite-sql
+source: my-sqlite
+description: count users
+statement: SELECT COUNT(*) FROM users
+`
+
+func TestGenerateAlerts(t *testing.T) {
+	dir := t.TempDir()
+	toolsFilePath := filepath.Join(dir, "tools.yaml")
+	if err := os.WriteFile(toolsFilePath, []byte(toolsFileContent), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	outputPath := filepath.Join(dir, "alerts.yaml")
+
+	if _, err := invokeCommand([]string{"gen-alerts", "--config", toolsFilePath, "--output", outputPath}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	content, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to read alerting rules: %v", err)
+	}
+
+	var got ruleFile
+	if err := yaml.Unmarshal(content, &got); err != nil {
+		t.Fatalf("alerting rules are not valid YAML: %s", err)
+	}
+	if len(got.Groups) != 1 {
+		t.Fatalf("unexpected number of groups: %d", len(got.Groups))
+	}
+	var alerts []string
+	for _, r := range got.Groups[0].Rules {
+		alerts = append(alerts, r.Alert+"/"+r.Labels["tool"])
+		if r.For != "5m" {
+			t.Errorf("unexpected pending period of %s: %q", r.Alert, r.For)
+		}
+	}
+	want := "ToolErrorRateHigh/count-users,ToolErrorRateHigh/search-users,ToolLatencyHigh/search-users"
+	if strings.Join(alerts, ",") != want {
+		t.Errorf("unexpected alerts: got %v, want %s", alerts, want)
+	}
+
+	latency := got.Groups[0].Rules[2].Expr
+	wantExpr := `histogram_quantile(0.95, sum by (le) (rate(toolbox_tool_execution_duration_seconds_bucket{gen_ai_tool_name="search-users"}[5m]))) > 0.25`
+	if latency != wantExpr {
+		t.Errorf("unexpected latency expression: got %q, want %q", latency, wantExpr)
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alerts
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+)
+
+const (
+	// durationMetric is the Prometheus name of the toolbox.tool.execution.duration
+	// histogram.
+	durationMetric = "toolbox_tool_execution_duration_seconds"
+	// errorRateThreshold is the fraction of failed invocations above which
+	// ToolErrorRateHigh fires.
+	errorRateThreshold = 0.05
+	// alertWindow is both the rate window and the pending period of the
+	// alerts.
+	alertWindow = "5m"
+)
+
+// ruleFile is a Prometheus alerting rule file.
+type ruleFile struct {
+	Groups []ruleGroup `yaml:"groups"`
+}
+
+type ruleGroup struct {
+	Name  string `yaml:"name"`
+	Rules []rule `yaml:"rules"`
+}
+
+type rule struct {
+	Alert       string            `yaml:"alert"`
+	Expr        string            `yaml:"expr"`
+	For         string            `yaml:"for"`
+	Labels      map[string]string `yaml:"labels"`
+	Annotations map[string]string `yaml:"annotations"`
+}
+
+// generate renders the alerting rules of the given tools in name order: an
+// error rate alert for every tool, and a latency alert for the tools with a
+// slaLatencyMs.
+func generate(toolsMap map[string]tools.Tool) (string, error) {
+	names := make([]string, 0, len(toolsMap))
+	for name := range toolsMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	group := ruleGroup{Name: "toolbox-tools", Rules: []rule{}}
+	for _, name := range names {
+		group.Rules = append(group.Rules, errorRateRule(name))
+		if c, ok := toolsMap[name].ToConfig().(interface{ GetSLALatencyMs() int }); ok && c.GetSLALatencyMs() > 0 {
+			group.Rules = append(group.Rules, latencyRule(name, c.GetSLALatencyMs()))
+		}
+	}
+
+	out, err := yaml.Marshal(ruleFile{Groups: []ruleGroup{group}})
+	if err != nil {
+		return "", fmt.Errorf("unable to marshal alerting rules: %w", err)
+	}
+	return string(out), nil
+}
+
+func errorRateRule(tool string) rule {
+	selector := "gen_ai_tool_name=" + strconv.Quote(tool)
+	return rule{
+		Alert: "ToolErrorRateHigh",
+		Expr: fmt.Sprintf(
+			"sum(rate(%s_count{%s,error_type!=\"\"}[%s])) / sum(rate(%s_count{%s}[%s])) > %g",
+			durationMetric, selector, alertWindow, durationMetric, selector, alertWindow, errorRateThreshold,
+		),
+		For:    alertWindow,
+		Labels: map[string]string{"severity": "warning", "tool": tool},
+		Annotations: map[string]string{
+			"summary":     fmt.Sprintf("Tool %s is failing", tool),
+			"description": fmt.Sprintf("More than %g%% of the invocations of tool %s failed over the last %s.", errorRateThreshold*100, tool, alertWindow),
+		},
+	}
+}
+
+func latencyRule(tool string, slaLatencyMs int) rule {
+	selector := "gen_ai_tool_name=" + strconv.Quote(tool)
+	return rule{
+		Alert: "ToolLatencyHigh",
+		Expr: fmt.Sprintf(
+			"histogram_quantile(0.95, sum by (le) (rate(%s_bucket{%s}[%s]))) > %g",
+			durationMetric, selector, alertWindow, float64(slaLatencyMs)/1000,
+		),
+		For:    alertWindow,
+		Labels: map[string]string{"severity": "warning", "tool": tool},
+		Annotations: map[string]string{
+			"summary":     fmt.Sprintf("Tool %s is slow", tool),
+			"description": fmt.Sprintf("The p95 latency of tool %s exceeded its SLA of %dms over the last %s.", tool, slaLatencyMs, alertWindow),
+		},
+	}
+}
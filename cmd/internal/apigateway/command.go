@@ -0,0 +1,117 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apigateway
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/googleapis/mcp-toolbox/cmd/internal"
+	"github.com/googleapis/mcp-toolbox/internal/server"
+	"github.com/spf13/cobra"
+)
+
+// apiGatewayCmd is the command for generating API Gateway specs.
+type apiGatewayCmd struct {
+	*cobra.Command
+	project string
+	backend string
+	apiName string
+	output  string
+}
+
+// NewCommand creates a new Command.
+func NewCommand(opts *internal.ToolboxOptions) *cobra.Command {
+	cmd := &apiGatewayCmd{}
+	cmd.Command = &cobra.Command{
+		Use:   "gen-api-gateway",
+		Short: "Generate an API Gateway spec exposing the tools",
+		Long:  "Generate an OpenAPI 2.0 spec for Google API Gateway and Cloud Endpoints with an endpoint invoking every tool, forwarded to the /api endpoints of a deployed Toolbox server. The rateLimit of tools becomes an API Gateway quota.",
+		Args:  cobra.NoArgs,
+		RunE: func(c *cobra.Command, args []string) error {
+			return run(cmd, opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	internal.ConfigFileFlags(cmd.Command, flags, opts)
+	flags.StringVar(&cmd.project, "project", "", "Project the API is deployed in.")
+	flags.StringVar(&cmd.backend, "backend", "", "URL of the Toolbox server the gateway forwards requests to, such as its Cloud Run URL. Falls back to the TOOLBOX_URL environment variable.")
+	flags.StringVar(&cmd.apiName, "api-name", "toolbox", "Name of the API, used in its title and Cloud Endpoints host.")
+	flags.StringVarP(&cmd.output, "output", "o", "", "File to write the spec to. Defaults to stdout.")
+	_ = cmd.MarkFlagRequired("project")
+	return cmd.Command
+}
+
+func run(cmd *apiGatewayCmd, opts *internal.ToolboxOptions) error {
+	ctx, cancel := context.WithCancel(cmd.Context())
+	defer cancel()
+
+	ctx, shutdown, err := opts.Setup(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = shutdown(ctx)
+	}()
+
+	backend := cmd.backend
+	if backend == "" {
+		backend = os.Getenv("TOOLBOX_URL")
+	}
+	if backend == "" {
+		return fmt.Errorf("the Toolbox server URL is missing, please provide it via --backend flag or TOOLBOX_URL environment variable")
+	}
+
+	// gen-api-gateway runs offline, so unset environment variables of the
+	// sources resolve to "".
+	parser := internal.ConfigParser{AllowMissingEnvVars: true}
+	if _, err := opts.LoadConfig(ctx, &parser); err != nil {
+		return err
+	}
+
+	toolsMap, _, err := server.InitializeOfflineConfigs(ctx, opts.Cfg)
+	if err != nil {
+		errMsg := fmt.Errorf("failed to initialize resources: %w", err)
+		opts.Logger.ErrorContext(ctx, errMsg.Error())
+		return errMsg
+	}
+	if len(toolsMap) == 0 {
+		return fmt.Errorf("no tools found to generate an API Gateway spec for")
+	}
+
+	content, err := generate(toolsMap, specOptions{
+		apiName: cmd.apiName,
+		project: cmd.project,
+		backend: backend,
+		version: opts.VersionNum,
+	})
+	if err != nil {
+		return err
+	}
+
+	if cmd.output == "" {
+		_, err := fmt.Fprint(opts.IOStreams.Out, content)
+		return err
+	}
+	if err := os.WriteFile(cmd.output, []byte(content), 0644); err != nil {
+		errMsg := fmt.Errorf("error writing API Gateway spec: %w", err)
+		opts.Logger.ErrorContext(ctx, errMsg.Error())
+		return errMsg
+	}
+	opts.Logger.InfoContext(ctx, fmt.Sprintf("Successfully generated an API Gateway spec for %d tools in %s.", len(toolsMap), cmd.output))
+	return nil
+}
@@ -0,0 +1,153 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apigateway
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/cmd/internal"
+	_ "github.com/googleapis/mcp-toolbox/internal/sources/sqlite"
+	_ "github.com/googleapis/mcp-toolbox/internal/tools/sqlite/sqlitesql"
+	"github.com/spf13/cobra"
+)
+
+func invokeCommand(args []string) (string, error) {
+	parentCmd := &cobra.Command{
+		Use:           "toolbox",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	buf := new(bytes.Buffer)
+	opts := internal.NewToolboxOptions(internal.WithIOStreams(buf, buf))
+	internal.PersistentFlags(parentCmd, opts)
+
+	cmd := NewCommand(opts)
+	parentCmd.AddCommand(cmd)
+	parentCmd.SetArgs(args)
+
+	err := parentCmd.Execute()
+	return buf.String(), err
+}
+
+const toolsFileContent = `
+kind: source
+name: my-sqlite
+type: sqlite
+database: ":memory:"
+---
+kind: tool
+name: search-users
+type: sqlite-sql
+source: my-sqlite
+description: search users
+statement: SELECT * FROM users WHERE name = ? AND age > ?
+rateLimit:
+  requestsPerMinute: 60
+parameters:
+  - name: name
+    type: string
+    description: name of the user
+  - name: age
+    type: float
+    description: minimum age
+    default: 0
+---
+kind: tool
+name: count-users
+type: sqlite-sql
+source: my-sqlite
+description: count users
+statement: SELECT COUNT(*) FROM users
+`
+
+func TestGenerateAPIGateway(t *testing.T) {
+	dir := t.TempDir()
+	toolsFilePath := filepath.Join(dir, "tools.yaml")
+	if err := os.WriteFile(toolsFilePath, []byte(toolsFileContent), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	outputPath := filepath.Join(dir, "api-gateway.yaml")
+
+	args := []string{"gen-api-gateway", "--config", toolsFilePath, "--project", "my-proj", "--backend", "https://toolbox.run.app", "--output", outputPath}
+	if _, err := invokeCommand(args); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	content, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to read spec: %v", err)
+	}
+
+	var got spec
+	if err := yaml.Unmarshal(content, &got); err != nil {
+		t.Fatalf("spec is not valid YAML: %s", err)
+	}
+	if got.Swagger != "2.0" || got.Host != "toolbox.endpoints.my-proj.cloud.goog" {
+		t.Errorf("unexpected swagger %q or host %q", got.Swagger, got.Host)
+	}
+	if got.Backend.Address != "https://toolbox.run.app" {
+		t.Errorf("unexpected backend: %q", got.Backend.Address)
+	}
+	var paths []string
+	for p := range got.Paths {
+		paths = append(paths, p)
+	}
+	want := []string{"/api/tool/count-users/invoke", "/api/tool/search-users/invoke"}
+	sort.Strings(paths)
+	if diff := cmp.Diff(want, paths); diff != "" {
+		t.Errorf("unexpected paths (-want +got):\n%s", diff)
+	}
+
+	search := got.Paths["/api/tool/search-users/invoke"].Post
+	if search.Quota == nil || search.Quota.MetricCosts["search-users-requests"] != 1 {
+		t.Errorf("unexpected quota of search-users: %+v", search.Quota)
+	}
+	if diff := cmp.Diff([]any{"name"}, search.Parameters[0].Schema["required"]); diff != "" {
+		t.Errorf("unexpected required parameters (-want +got):\n%s", diff)
+	}
+	if got.Paths["/api/tool/count-users/invoke"].Post.Quota != nil {
+		t.Errorf("count-users has no rateLimit but got a quota")
+	}
+	if got.Management == nil || len(got.Management.Quota.Limits) != 1 {
+		t.Fatalf("unexpected quota limits: %+v", got.Management)
+	}
+	wantLimit := limit{Name: "search-users-limit", Metric: "search-users-requests", Unit: "1/min/{project}", Values: map[string]int64{"STANDARD": 60}}
+	if diff := cmp.Diff(wantLimit, got.Management.Quota.Limits[0]); diff != "" {
+		t.Errorf("unexpected quota limit (-want +got):\n%s", diff)
+	}
+	if _, ok := got.SecurityDefinitions[apiKeyDefinition]; !ok {
+		t.Errorf("missing API key security definition")
+	}
+}
+
+func TestGenerateAPIGatewayRequiresBackend(t *testing.T) {
+	t.Setenv("TOOLBOX_URL", "")
+	dir := t.TempDir()
+	toolsFilePath := filepath.Join(dir, "tools.yaml")
+	if err := os.WriteFile(toolsFilePath, []byte(toolsFileContent), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	_, err := invokeCommand([]string{"gen-api-gateway", "--config", toolsFilePath, "--project", "my-proj"})
+	if err == nil || !strings.Contains(err.Error(), "--backend") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
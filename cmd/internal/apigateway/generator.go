@@ -0,0 +1,243 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apigateway
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+// apiKeyDefinition is the security definition of the API keys that quotas
+// are enforced per.
+const apiKeyDefinition = "api_key"
+
+// specOptions are the API-wide settings of the generated spec.
+type specOptions struct {
+	apiName string
+	project string
+	backend string
+	version string
+}
+
+// spec is an OpenAPI 2.0 document with the extensions of API Gateway.
+type spec struct {
+	Swagger             string                    `yaml:"swagger"`
+	Info                info                      `yaml:"info"`
+	Host                string                    `yaml:"host"`
+	Schemes             []string                  `yaml:"schemes"`
+	Consumes            []string                  `yaml:"consumes"`
+	Produces            []string                  `yaml:"produces"`
+	Backend             backend                   `yaml:"x-google-backend"`
+	Management          *management               `yaml:"x-google-management,omitempty"`
+	SecurityDefinitions map[string]securityScheme `yaml:"securityDefinitions,omitempty"`
+	Paths               map[string]pathItem       `yaml:"paths"`
+}
+
+type info struct {
+	Title   string `yaml:"title"`
+	Version string `yaml:"version"`
+}
+
+type backend struct {
+	Address         string `yaml:"address"`
+	PathTranslation string `yaml:"path_translation"`
+}
+
+// management declares the quota metrics of the API and their limits.
+type management struct {
+	Metrics []metric `yaml:"metrics"`
+	Quota   quota    `yaml:"quota"`
+}
+
+type metric struct {
+	Name        string `yaml:"name"`
+	DisplayName string `yaml:"displayName"`
+	ValueType   string `yaml:"valueType"`
+	MetricKind  string `yaml:"metricKind"`
+}
+
+type quota struct {
+	Limits []limit `yaml:"limits"`
+}
+
+type limit struct {
+	Name   string           `yaml:"name"`
+	Metric string           `yaml:"metric"`
+	Unit   string           `yaml:"unit"`
+	Values map[string]int64 `yaml:"values"`
+}
+
+type securityScheme struct {
+	Type string `yaml:"type"`
+	Name string `yaml:"name"`
+	In   string `yaml:"in"`
+}
+
+type pathItem struct {
+	Post operation `yaml:"post"`
+}
+
+type operation struct {
+	OperationID string                `yaml:"operationId"`
+	Summary     string                `yaml:"summary"`
+	Parameters  []parameter           `yaml:"parameters"`
+	Responses   map[string]response   `yaml:"responses"`
+	Security    []map[string][]string `yaml:"security,omitempty"`
+	Quota       *operationQuota       `yaml:"x-google-quota,omitempty"`
+}
+
+type parameter struct {
+	Name     string         `yaml:"name"`
+	In       string         `yaml:"in"`
+	Required bool           `yaml:"required"`
+	Schema   map[string]any `yaml:"schema"`
+}
+
+type response struct {
+	Description string         `yaml:"description"`
+	Schema      map[string]any `yaml:"schema,omitempty"`
+}
+
+type operationQuota struct {
+	MetricCosts map[string]int `yaml:"metricCosts"`
+}
+
+// generate renders the API Gateway spec of the given tools: an endpoint
+// forwarded to the /api/tool/{name}/invoke endpoint of every tool, and a
+// quota of requests per minute for the tools with a rateLimit.
+func generate(toolsMap map[string]tools.Tool, opts specOptions) (string, error) {
+	names := make([]string, 0, len(toolsMap))
+	for name := range toolsMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	version := opts.version
+	if version == "" {
+		version = "1.0.0"
+	}
+	doc := spec{
+		Swagger:  "2.0",
+		Info:     info{Title: opts.apiName, Version: version},
+		Host:     fmt.Sprintf("%s.endpoints.%s.cloud.goog", opts.apiName, opts.project),
+		Schemes:  []string{"https"},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Backend:  backend{Address: opts.backend, PathTranslation: "APPEND_PATH_TO_ADDRESS"},
+		Paths:    make(map[string]pathItem, len(names)),
+	}
+
+	var mgmt management
+	for _, name := range names {
+		tool := toolsMap[name]
+		op := operation{
+			OperationID: name,
+			Summary:     tool.GetDescription(),
+			Parameters: []parameter{{
+				Name:     "params",
+				In:       "body",
+				Required: true,
+				Schema:   bodySchema(tool.StaticManifest().Parameters),
+			}},
+			Responses: map[string]response{
+				"200": {
+					Description: "The result of the tool.",
+					Schema:      map[string]any{"type": "object", "properties": map[string]any{"result": map[string]any{"type": "string"}}},
+				},
+				"default": {Description: "The tool invocation failed."},
+			},
+		}
+		if c, ok := tool.ToConfig().(interface{ GetRateLimit() *tools.RateLimit }); ok && c.GetRateLimit() != nil && c.GetRateLimit().RequestsPerMinute > 0 {
+			metricName := name + "-requests"
+			mgmt.Metrics = append(mgmt.Metrics, metric{
+				Name:        metricName,
+				DisplayName: fmt.Sprintf("Invocations of tool %s", name),
+				ValueType:   "INT64",
+				MetricKind:  "DELTA",
+			})
+			mgmt.Quota.Limits = append(mgmt.Quota.Limits, limit{
+				Name:   name + "-limit",
+				Metric: metricName,
+				Unit:   "1/min/{project}",
+				Values: map[string]int64{"STANDARD": int64(c.GetRateLimit().RequestsPerMinute)},
+			})
+			op.Quota = &operationQuota{MetricCosts: map[string]int{metricName: 1}}
+			// quotas are enforced per consumer project, identified by the API key
+			op.Security = []map[string][]string{{apiKeyDefinition: {}}}
+		}
+		doc.Paths[fmt.Sprintf("/api/tool/%s/invoke", name)] = pathItem{Post: op}
+	}
+	if len(mgmt.Metrics) > 0 {
+		doc.Management = &mgmt
+		doc.SecurityDefinitions = map[string]securityScheme{
+			apiKeyDefinition: {Type: "apiKey", Name: "key", In: "query"},
+		}
+	}
+
+	out, err := yaml.Marshal(doc)
+	if err != nil {
+		return "", fmt.Errorf("unable to marshal API Gateway spec: %w", err)
+	}
+	return string(out), nil
+}
+
+// bodySchema returns the JSON schema of the request body invoking a tool
+// with the given parameters. Parameters filled in by the server, from auth
+// tokens or other parameters, are left out.
+func bodySchema(params []parameters.ParameterManifest) map[string]any {
+	properties := make(map[string]any, len(params))
+	required := []string{}
+	for _, p := range params {
+		if len(p.AuthServices) > 0 || p.ValueFromParam != "" {
+			continue
+		}
+		properties[p.Name] = paramSchema(p)
+		if p.Required {
+			required = append(required, p.Name)
+		}
+	}
+	schema := map[string]any{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// paramSchema converts the manifest of a parameter to a JSON schema.
+func paramSchema(p parameters.ParameterManifest) map[string]any {
+	schema := map[string]any{}
+	if p.Description != "" {
+		schema["description"] = p.Description
+	}
+	switch p.Type {
+	case "float":
+		schema["type"] = "number"
+	case "map":
+		schema["type"] = "object"
+		schema["additionalProperties"] = true
+	case "array":
+		schema["type"] = "array"
+		if p.Items != nil {
+			schema["items"] = paramSchema(*p.Items)
+		}
+	default:
+		schema["type"] = p.Type
+	}
+	return schema
+}
@@ -18,6 +18,7 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -30,6 +31,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/googleapis/mcp-toolbox/internal/auth/generic"
 	"github.com/googleapis/mcp-toolbox/internal/server"
+	"github.com/googleapis/mcp-toolbox/internal/sources/secrets"
 )
 
 type Config struct {
@@ -39,6 +41,12 @@ type Config struct {
 	Tools           server.ToolConfigs           `yaml:"tools"`
 	Toolsets        server.ToolsetConfigs        `yaml:"toolsets"`
 	Prompts         server.PromptConfigs         `yaml:"prompts"`
+	Resources       server.ResourceConfigs       `yaml:"resources"`
+	Jobs            server.JobConfigs            `yaml:"jobs"`
+	SchemaTools     server.SchemaToolsConfigs    `yaml:"schemaTools"`
+	DbtTools        server.DbtToolsConfigs       `yaml:"dbtTools"`
+	AccessPolicies  server.AccessPolicyConfigs   `yaml:"accessPolicies"`
+	Namespaces      server.NamespaceConfigs      `yaml:"namespaces"`
 }
 
 type ConfigParser struct {
@@ -56,7 +64,10 @@ type ConfigParser struct {
 }
 
 // parseEnv replaces environment variables ${ENV_NAME} with their values.
-// also support ${ENV_NAME:default_value}.
+// also support ${ENV_NAME:default_value}, the explicit ${env:ENV_NAME} and
+// ${env:ENV_NAME:default_value} forms, and ${file:/path}, replaced with the
+// contents of the file without their trailing newline. Every unresolved
+// reference is reported, with its position.
 func (p *ConfigParser) parseEnv(input string) (string, error) {
 	re := regexp.MustCompile(`\$\{(\w+)(:([^}]*))?\}`)
 
@@ -64,21 +75,48 @@ func (p *ConfigParser) parseEnv(input string) (string, error) {
 		p.EnvVars = make(map[string]string)
 	}
 
-	var err error
+	var errs []error
 	matches := re.FindAllStringSubmatchIndex(input, -1)
 	var output strings.Builder
 	lastIndex := 0
 	for _, match := range matches {
 		start, end := match[0], match[1]
 		output.WriteString(input[lastIndex:start])
+		lastIndex = end
 
 		variableName := input[match[2]:match[3]]
+		// references to secrets are resolved when the sources are initialized
+		if secrets.IsScheme(variableName) && match[4] != -1 {
+			output.WriteString(input[start:end])
+			continue
+		}
 		defaultValue := ""
 		defaultProvided := match[4] != -1 && match[5] != -1
 		if defaultProvided {
 			defaultValue = input[match[6]:match[7]]
 		}
 
+		switch {
+		case variableName == "file" && defaultProvided:
+			content, readErr := os.ReadFile(defaultValue)
+			if readErr == nil {
+				output.WriteString(strings.TrimSuffix(strings.TrimSuffix(string(content), "\n"), "\r"))
+			} else if p.AllowMissingEnvVars {
+				output.WriteString(defaultValue)
+			} else {
+				line, column := lineColumnAt(input, start)
+				errs = append(errs, fmt.Errorf("unable to read file %q (line %d, column %d): %w", defaultValue, line, column, readErr))
+			}
+			continue
+		case variableName == "env" && defaultProvided:
+			variableName, defaultValue, defaultProvided = strings.Cut(defaultValue, ":")
+			if variableName == "" {
+				line, column := lineColumnAt(input, start)
+				errs = append(errs, fmt.Errorf("missing environment variable name (line %d, column %d)", line, column))
+				continue
+			}
+		}
+
 		if defaultProvided {
 			p.OptionalEnvVars = append(p.OptionalEnvVars, variableName)
 		} else {
@@ -95,13 +133,11 @@ func (p *ConfigParser) parseEnv(input string) (string, error) {
 			if p.AllowMissingEnvVars {
 				p.EnvVars[variableName] = variableName
 				output.WriteString(variableName)
-			} else if err == nil {
+			} else {
 				line, column := lineColumnAt(input, start)
-				err = fmt.Errorf("environment variable not found: %q (line %d, column %d)", variableName, line, column)
+				errs = append(errs, fmt.Errorf("environment variable not found: %q (line %d, column %d)", variableName, line, column))
 			}
 		}
-
-		lastIndex = end
 	}
 	output.WriteString(input[lastIndex:])
 
@@ -114,7 +150,7 @@ func (p *ConfigParser) parseEnv(input string) (string, error) {
 	}
 	p.OptionalEnvVars = finalOptional
 
-	return output.String(), err
+	return output.String(), errors.Join(errs...)
 }
 
 // ParseConfig parses the provided yaml into appropriate configs.
@@ -154,6 +190,30 @@ func (p *ConfigParser) ParseConfig(ctx context.Context, raw []byte) (Config, err
 	if err != nil {
 		return config, err
 	}
+	config.Resources, err = server.UnmarshalResourceConfigs(ctx, raw)
+	if err != nil {
+		return config, err
+	}
+	config.Jobs, err = server.UnmarshalJobConfigs(ctx, raw)
+	if err != nil {
+		return config, err
+	}
+	config.AccessPolicies, err = server.UnmarshalAccessPolicyConfigs(ctx, raw)
+	if err != nil {
+		return config, err
+	}
+	config.Namespaces, err = server.UnmarshalNamespaceConfigs(ctx, raw)
+	if err != nil {
+		return config, err
+	}
+	config.SchemaTools, err = server.UnmarshalSchemaToolsConfigs(ctx, raw)
+	if err != nil {
+		return config, err
+	}
+	config.DbtTools, err = server.UnmarshalDbtToolsConfigs(ctx, raw)
+	if err != nil {
+		return config, err
+	}
 	return config, nil
 }
 
@@ -180,7 +240,7 @@ func ConvertConfig(raw []byte) ([]byte, error) {
 	decoder := yaml.NewDecoder(bytes.NewReader(raw), yaml.UseOrderedMap())
 	encoder := yaml.NewEncoder(&buf, yaml.UseLiteralStyleIfMultiline(true))
 
-	nestedFormatKey := []string{"sources", "authServices", "embeddingModels", "tools", "toolsets", "prompts"}
+	nestedFormatKey := []string{"sources", "authServices", "embeddingModels", "tools", "toolsets", "prompts", "resources", "parameterDefs", "openapiTools", "jobs", "accessPolicies"}
 	docIndex := 0
 	for {
 		if err := decoder.Decode(&input); err != nil {
@@ -217,6 +277,14 @@ func ConvertConfig(raw []byte) ([]byte, error) {
 					key = "toolset"
 				case "prompts":
 					key = "prompt"
+				case "resources":
+					key = "resource"
+				case "parameterDefs":
+					key = "parameterDef"
+				case "jobs":
+					key = "job"
+				case "accessPolicies":
+					key = "accessPolicy"
 				}
 				transformed, err := transformDocs(key, slice)
 				if err != nil {
@@ -316,6 +384,7 @@ func mergeConfigs(files ...Config) (Config, error) {
 		Tools:           make(server.ToolConfigs),
 		Toolsets:        make(server.ToolsetConfigs),
 		Prompts:         make(server.PromptConfigs),
+		Resources:       make(server.ResourceConfigs),
 	}
 
 	var conflicts []string
@@ -376,11 +445,80 @@ func mergeConfigs(files ...Config) (Config, error) {
 				merged.Prompts[name] = prompt
 			}
 		}
+
+		// Check for conflicts and merge resources
+		for name, resource := range file.Resources {
+			if _, exists := merged.Resources[name]; exists {
+				conflicts = append(conflicts, fmt.Sprintf("resource '%s' (file #%d)", name, fileIndex+1))
+			} else {
+				merged.Resources[name] = resource
+			}
+		}
+
+		// Check for conflicts and merge jobs
+		for name, job := range file.Jobs {
+			if _, exists := merged.Jobs[name]; exists {
+				conflicts = append(conflicts, fmt.Sprintf("job '%s' (file #%d)", name, fileIndex+1))
+				continue
+			}
+			if merged.Jobs == nil {
+				merged.Jobs = make(server.JobConfigs)
+			}
+			merged.Jobs[name] = job
+		}
+
+		// Check for conflicts and merge access policies
+		for name, policy := range file.AccessPolicies {
+			if _, exists := merged.AccessPolicies[name]; exists {
+				conflicts = append(conflicts, fmt.Sprintf("access policy '%s' (file #%d)", name, fileIndex+1))
+				continue
+			}
+			if merged.AccessPolicies == nil {
+				merged.AccessPolicies = make(server.AccessPolicyConfigs)
+			}
+			merged.AccessPolicies[name] = policy
+		}
+
+		// Check for conflicts and merge namespaces
+		for name, ns := range file.Namespaces {
+			if _, exists := merged.Namespaces[name]; exists {
+				conflicts = append(conflicts, fmt.Sprintf("namespace '%s' (file #%d)", name, fileIndex+1))
+				continue
+			}
+			if merged.Namespaces == nil {
+				merged.Namespaces = make(server.NamespaceConfigs)
+			}
+			merged.Namespaces[name] = ns
+		}
+
+		// Check for conflicts and merge schema tools
+		for name, c := range file.SchemaTools {
+			if _, exists := merged.SchemaTools[name]; exists {
+				conflicts = append(conflicts, fmt.Sprintf("schemaTools '%s' (file #%d)", name, fileIndex+1))
+				continue
+			}
+			if merged.SchemaTools == nil {
+				merged.SchemaTools = make(server.SchemaToolsConfigs)
+			}
+			merged.SchemaTools[name] = c
+		}
+
+		// Check for conflicts and merge dbt tools
+		for name, c := range file.DbtTools {
+			if _, exists := merged.DbtTools[name]; exists {
+				conflicts = append(conflicts, fmt.Sprintf("dbtTools '%s' (file #%d)", name, fileIndex+1))
+				continue
+			}
+			if merged.DbtTools == nil {
+				merged.DbtTools = make(server.DbtToolsConfigs)
+			}
+			merged.DbtTools[name] = c
+		}
 	}
 
 	// If conflicts were detected, return an error
 	if len(conflicts) > 0 {
-		return Config{}, fmt.Errorf("resource conflicts detected:\n  - %s\n\nPlease ensure each source, authService, tool, toolset and prompt has a unique name across all files", strings.Join(conflicts, "\n  - "))
+		return Config{}, fmt.Errorf("resource conflicts detected:\n  - %s\n\nPlease ensure each source, authService, tool, toolset, prompt and resource has a unique name across all files", strings.Join(conflicts, "\n  - "))
 	}
 
 	// Ensure only one authService has mcpEnabled = true
@@ -403,7 +541,7 @@ func (p *ConfigParser) LoadAndMergeConfigs(ctx context.Context, filePaths []stri
 	var configs []Config
 
 	for _, filePath := range filePaths {
-		buf, err := os.ReadFile(filePath)
+		buf, err := ReadConfigFile(ctx, filePath)
 		if err != nil {
 			return Config{}, fmt.Errorf("unable to read config file at %q: %w", filePath, err)
 		}
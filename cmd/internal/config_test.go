@@ -16,6 +16,10 @@ package internal
 
 import (
 	"fmt"
+	nethttp "net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -26,13 +30,16 @@ import (
 	"github.com/googleapis/mcp-toolbox/internal/prebuiltconfigs"
 	"github.com/googleapis/mcp-toolbox/internal/prompts"
 	"github.com/googleapis/mcp-toolbox/internal/prompts/custom"
+	"github.com/googleapis/mcp-toolbox/internal/resources"
 	"github.com/googleapis/mcp-toolbox/internal/server"
+	"github.com/googleapis/mcp-toolbox/internal/sources"
 	cloudsqlpgsrc "github.com/googleapis/mcp-toolbox/internal/sources/cloudsqlpg"
 	httpsrc "github.com/googleapis/mcp-toolbox/internal/sources/http"
 	"github.com/googleapis/mcp-toolbox/internal/testutils"
 	"github.com/googleapis/mcp-toolbox/internal/tools"
 	"github.com/googleapis/mcp-toolbox/internal/tools/http"
 	"github.com/googleapis/mcp-toolbox/internal/tools/postgres/postgressql"
+	"github.com/googleapis/mcp-toolbox/internal/util"
 	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
 )
 
@@ -114,6 +121,35 @@ func TestParseEnv(t *testing.T) {
 			want:         "project_req: my_project, project_opt: my_project",
 			wantOptional: []string{}, // Because it was marked required at least once
 		},
+		{
+			desc: "explicit env reference",
+			env: map[string]string{
+				"FOO": "bar",
+			},
+			in:   "${env:FOO}",
+			want: "bar",
+		},
+		{
+			desc:         "explicit env reference with default",
+			in:           "${env:FOO:bar}",
+			want:         "bar",
+			wantOptional: []string{"FOO"},
+		},
+		{
+			desc:      "every unresolved reference is reported",
+			in:        "user: ${USER_NAME}\npassword: ${env:PASSWORD}\ncert: ${file:/does/not/exist}",
+			want:      "user: \npassword: \ncert: ",
+			err:       true,
+			errString: "environment variable not found: \"USER_NAME\" (line 1, column 7)\nenvironment variable not found: \"PASSWORD\" (line 2, column 11)\nunable to read file \"/does/not/exist\" (line 3, column 7): open /does/not/exist: no such file or directory",
+		},
+		{
+			desc: "secret references are left to the sources",
+			in:   "password: ${secretmanager:projects/p/secrets/s/versions/latest}, user: ${USER_NAME}",
+			env: map[string]string{
+				"USER_NAME": "alice",
+			},
+			want: "password: ${secretmanager:projects/p/secrets/s/versions/latest}, user: alice",
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -147,6 +183,21 @@ func TestParseEnv(t *testing.T) {
 	}
 }
 
+func TestParseEnvFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "password")
+	if err := os.WriteFile(path, []byte("s3cret\n"), 0o600); err != nil {
+		t.Fatalf("unable to write file: %s", err)
+	}
+	parser := &ConfigParser{}
+	got, err := parser.parseEnv(fmt.Sprintf("password: ${file:%s}", path))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := "password: s3cret"; got != want {
+		t.Errorf("unexpected output: got %q, want %q", got, want)
+	}
+}
+
 func TestConvertConfig(t *testing.T) {
 	tcs := []struct {
 		desc   string
@@ -826,6 +877,50 @@ func TestParseConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			description: "resources assigned to toolset",
+			in: `
+            kind: resource
+            name: runbook
+            uri: docs://runbook
+            mimeType: text/markdown
+            path: docs/runbook.md
+---
+            kind: resource
+            name: limits
+            uri: docs://limits
+            text: Queries return at most 100 rows.
+---
+            kind: toolset
+            name: ops
+            tools: []
+            resources:
+                - runbook
+                - limits
+            `,
+			wantConfig: Config{
+				Toolsets: server.ToolsetConfigs{
+					"ops": tools.ToolsetConfig{
+						Name:          "ops",
+						ToolNames:     []string{},
+						ResourceNames: []string{"runbook", "limits"},
+					},
+				},
+				Resources: server.ResourceConfigs{
+					"runbook": resources.ResourceConfig{
+						Name:     "runbook",
+						URI:      "docs://runbook",
+						MimeType: "text/markdown",
+						Path:     "docs/runbook.md",
+					},
+					"limits": resources.ResourceConfig{
+						Name: "limits",
+						URI:  "docs://limits",
+						Text: "Queries return at most 100 rows.",
+					},
+				},
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.description, func(t *testing.T) {
@@ -849,6 +944,9 @@ func TestParseConfig(t *testing.T) {
 			if diff := cmp.Diff(tc.wantConfig.Prompts, configFile.Prompts); diff != "" {
 				t.Fatalf("incorrect prompts parse: diff %v", diff)
 			}
+			if diff := cmp.Diff(tc.wantConfig.Resources, configFile.Resources); diff != "" {
+				t.Fatalf("incorrect resources parse: diff %v", diff)
+			}
 		})
 	}
 }
@@ -922,6 +1020,125 @@ func TestParseConfigFailure(t *testing.T) {
 	}
 }
 
+func TestVerifyToolSources(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	in := `
+			kind: source
+			name: my-pg-instance
+			type: cloud-sql-postgres
+			project: my-project
+			region: my-region
+			instance: my-instance
+			database: my_db
+			user: my_user
+			password: my_pass
+---
+			kind: source
+			name: my-mysql-instance
+			type: mysql
+			host: 127.0.0.1
+			port: 3306
+			database: my_db
+			user: my_user
+			password: my_pass
+---
+			kind: source
+			name: my-sqlite-db
+			type: sqlite
+			database: ":memory:"
+---
+			kind: tool
+			name: pg-on-cloudsql
+			type: postgres-sql
+			source: my-pg-instance
+			description: some description
+			statement: SELECT 1;
+---
+			kind: tool
+			name: pg-on-mysql
+			type: postgres-sql
+			source: my-mysql-instance
+			description: some description
+			statement: SELECT 1;
+---
+			kind: tool
+			name: mysql-on-sqlite
+			type: mysql-sql
+			source: my-sqlite-db
+			description: some description
+			statement: SELECT 1;
+---
+			kind: tool
+			name: sqlite-on-pg
+			type: sqlite-sql
+			source: my-pg-instance
+			description: some description
+			statement: SELECT 1;
+			`
+	parser := ConfigParser{}
+	cfg, err := parser.ParseConfig(ctx, testutils.FormatYaml(in))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	sourceTypes := make(map[string]string)
+	for name, sc := range cfg.Sources {
+		sourceTypes[name] = sc.SourceConfigType()
+	}
+
+	tcs := []struct {
+		tool      string
+		wantError []string
+	}{
+		{
+			// cloud-sql-postgres sources implement the interface required by
+			// postgres-sql tools.
+			tool: "pg-on-cloudsql",
+		},
+		{
+			tool: "pg-on-mysql",
+			wantError: []string{
+				`tool "pg-on-mysql" of type "postgres-sql" references source "my-mysql-instance" of type "mysql", which is not compatible; compatible source types:`,
+				"cloud-sql-postgres",
+			},
+		},
+		{
+			tool: "mysql-on-sqlite",
+			wantError: []string{
+				`tool "mysql-on-sqlite" of type "mysql-sql" references source "my-sqlite-db" of type "sqlite", which is not compatible`,
+				"cloud-sql-mysql",
+			},
+		},
+		{
+			tool: "sqlite-on-pg",
+			wantError: []string{
+				`tool "sqlite-on-pg" of type "sqlite-sql" references source "my-pg-instance" of type "cloud-sql-postgres", which is not compatible; compatible source types: sqlite`,
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.tool, func(t *testing.T) {
+			err := tools.VerifySource(tc.tool, cfg.Tools[tc.tool], sourceTypes)
+			if len(tc.wantError) == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected an error, got nil")
+			}
+			for _, want := range tc.wantError {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("want error containing %q, got %q", want, err.Error())
+				}
+			}
+		})
+	}
+}
+
 func TestParseConfigWithAuth(t *testing.T) {
 	ctx, err := testutils.ContextWithNewLogger()
 	if err != nil {
@@ -1522,6 +1739,7 @@ func TestPrebuiltTools(t *testing.T) {
 	alloydb_admin_config, _ := prebuiltconfigs.Get("alloydb-postgres-admin")
 	alloydb_config, _ := prebuiltconfigs.Get("alloydb-postgres")
 	alloydbobsvconfig, _ := prebuiltconfigs.Get("alloydb-postgres-observability")
+	alloydbintrospectionconfig, _ := prebuiltconfigs.Get("alloydb-postgres-introspection")
 	bigquery_config, _ := prebuiltconfigs.Get("bigquery")
 	clickhouse_config, _ := prebuiltconfigs.Get("clickhouse")
 	cloudhealthcare_config, _ := prebuiltconfigs.Get("cloud-healthcare")
@@ -1534,6 +1752,7 @@ func TestPrebuiltTools(t *testing.T) {
 	cloudsqlpg_config, _ := prebuiltconfigs.Get("cloud-sql-postgres")
 	cloudsqlpg_admin_config, _ := prebuiltconfigs.Get("cloud-sql-postgres-admin")
 	cloudsqlpgobsvconfig, _ := prebuiltconfigs.Get("cloud-sql-postgres-observability")
+	cloudsqlpgintrospectionconfig, _ := prebuiltconfigs.Get("cloud-sql-postgres-introspection")
 	conversationalanalytics_config, _ := prebuiltconfigs.Get("conversational-analytics-with-data-agent")
 	dataplex_config, _ := prebuiltconfigs.Get("dataplex")
 	dataproc_config, _ := prebuiltconfigs.Get("dataproc")
@@ -1549,6 +1768,7 @@ func TestPrebuiltTools(t *testing.T) {
 	oceanbase_config, _ := prebuiltconfigs.Get("oceanbase")
 	oracle_config, _ := prebuiltconfigs.Get("oracledb")
 	postgresconfig, _ := prebuiltconfigs.Get("postgres")
+	postgresintrospectionconfig, _ := prebuiltconfigs.Get("postgres-introspection")
 	serverless_spark_config, _ := prebuiltconfigs.Get("serverless-spark")
 	cloudstorage_config, _ := prebuiltconfigs.Get("cloud-storage")
 	singlestore_config, _ := prebuiltconfigs.Get("singlestore")
@@ -2053,6 +2273,36 @@ func TestPrebuiltTools(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "alloydb postgres introspection prebuilt tools",
+			in:   alloydbintrospectionconfig,
+			wantToolset: server.ToolsetConfigs{
+				"introspection": tools.ToolsetConfig{
+					Name:      "introspection",
+					ToolNames: []string{"list_tables", "describe_table", "list_indexes", "get_table_sample"},
+				},
+			},
+		},
+		{
+			name: "cloudsql pg introspection prebuilt tools",
+			in:   cloudsqlpgintrospectionconfig,
+			wantToolset: server.ToolsetConfigs{
+				"introspection": tools.ToolsetConfig{
+					Name:      "introspection",
+					ToolNames: []string{"list_tables", "describe_table", "list_indexes", "get_table_sample"},
+				},
+			},
+		},
+		{
+			name: "postgres introspection prebuilt tools",
+			in:   postgresintrospectionconfig,
+			wantToolset: server.ToolsetConfigs{
+				"introspection": tools.ToolsetConfig{
+					Name:      "introspection",
+					ToolNames: []string{"list_tables", "describe_table", "list_indexes", "get_table_sample"},
+				},
+			},
+		},
 		{
 			name: "spanner prebuilt tools",
 			in:   spanner_config,
@@ -2310,6 +2560,7 @@ func TestMergeConfigs(t *testing.T) {
 				Tools:           server.ToolConfigs{"tool1": http.Config{ConfigBase: tools.ConfigBase{Name: "tool1"}}, "tool2": http.Config{ConfigBase: tools.ConfigBase{Name: "tool2"}}},
 				Toolsets:        server.ToolsetConfigs{"set1": tools.ToolsetConfig{Name: "set1"}, "set2": tools.ToolsetConfig{Name: "set2"}},
 				Prompts:         server.PromptConfigs{},
+				Resources:       server.ResourceConfigs{},
 				EmbeddingModels: server.EmbeddingModelConfigs{"model1": gemini.Config{Name: "gemini-text"}},
 			},
 			wantErr: false,
@@ -2335,6 +2586,7 @@ func TestMergeConfigs(t *testing.T) {
 				Tools:           file1.Tools,
 				Toolsets:        file1.Toolsets,
 				Prompts:         server.PromptConfigs{},
+				Resources:       server.ResourceConfigs{},
 			},
 		},
 		{
@@ -2347,6 +2599,7 @@ func TestMergeConfigs(t *testing.T) {
 				Tools:           make(server.ToolConfigs),
 				Toolsets:        make(server.ToolsetConfigs),
 				Prompts:         server.PromptConfigs{},
+				Resources:       server.ResourceConfigs{},
 			},
 		},
 	}
@@ -2462,6 +2715,41 @@ tools:
         valueFromParam: a`,
 			wantErr: false,
 		},
+		{
+			desc: "valid requiredIf",
+			params: `
+      - name: end_date
+        type: string
+        description: end
+        requiredIf:
+          mode: range
+      - name: mode
+        type: string
+        description: mode`,
+			wantErr: false,
+		},
+		{
+			desc: "invalid requiredIf missing reference",
+			params: `
+      - name: end_date
+        type: string
+        description: end
+        requiredIf:
+          mode: range`,
+			wantErr:   true,
+			errSubstr: "references \"mode\" in the 'requiredIf' field",
+		},
+		{
+			desc: "invalid requiredIf self reference",
+			params: `
+      - name: end_date
+        type: string
+        description: end
+        requiredIf:
+          end_date: "2026-01-01"`,
+			wantErr:   true,
+			errSubstr: "parameter \"end_date\" cannot be required depending on itself",
+		},
 	}
 
 	for _, tc := range tcs {
@@ -2486,3 +2774,595 @@ tools:
 		})
 	}
 }
+
+func TestParameterDefinitions(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	inlined := `
+kind: tool
+name: get-orders
+type: postgres-sql
+source: my-pg
+description: get orders
+statement: SELECT * FROM orders WHERE customer_id = $1 AND status = $2
+parameters:
+  - name: customer_id
+    type: integer
+    description: the orders' customer
+    minValue: 1
+  - name: status
+    type: string
+    description: status of the order
+`
+	parser := ConfigParser{}
+	want, err := parser.ParseConfig(ctx, []byte(inlined))
+	if err != nil {
+		t.Fatalf("unable to parse inlined config: %s", err)
+	}
+
+	tcs := []struct {
+		desc string
+		in   string
+	}{
+		{
+			desc: "flat format with description override",
+			in: `
+kind: tool
+name: get-orders
+type: postgres-sql
+source: my-pg
+description: get orders
+statement: SELECT * FROM orders WHERE customer_id = $1 AND status = $2
+parameters:
+  - $ref: customer_id
+    description: the orders' customer
+  - name: status
+    type: string
+    description: status of the order
+---
+kind: parameterDef
+name: customer_id
+type: integer
+description: a customer
+minValue: 1
+`,
+		},
+		{
+			desc: "nested format",
+			in: `
+parameterDefs:
+  customer_id:
+    type: integer
+    description: the orders' customer
+    minValue: 1
+tools:
+  get-orders:
+    kind: postgres-sql
+    source: my-pg
+    description: get orders
+    statement: SELECT * FROM orders WHERE customer_id = $1 AND status = $2
+    parameters:
+      - $ref: customer_id
+      - name: status
+        type: string
+        description: status of the order
+`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := parser.ParseConfig(ctx, []byte(tc.in))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(want.Tools, got.Tools); diff != "" {
+				t.Errorf("resolved tools differ from the inlined definition (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParameterDefinitionsFailure(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	baseYaml := `
+kind: parameterDef
+name: customer_id
+type: integer
+description: a customer
+---
+kind: tool
+name: get-orders
+type: postgres-sql
+source: my-pg
+description: get orders
+statement: SELECT * FROM orders WHERE customer_id = $1
+parameters:
+%s`
+
+	tcs := []struct {
+		desc   string
+		params string
+		extra  string
+		want   string
+	}{
+		{
+			desc:   "undefined reference",
+			params: "  - $ref: account_id\n",
+			want:   `parameters[0]: reference to undefined parameter definition "account_id"`,
+		},
+		{
+			desc:   "type override",
+			params: "  - $ref: customer_id\n    type: string\n",
+			want:   `reference to parameter definition "customer_id" cannot override its type`,
+		},
+		{
+			desc:   "unsupported override",
+			params: "  - $ref: customer_id\n    required: false\n",
+			want:   `reference to parameter definition "customer_id" cannot set "required": only "description" can be overridden`,
+		},
+		{
+			desc:   "duplicate definition",
+			params: "  - $ref: customer_id\n",
+			extra:  "---\nkind: parameterDef\nname: customer_id\ntype: string\ndescription: another customer\n",
+			want:   `parameter definition "customer_id" is defined more than once`,
+		},
+		{
+			desc:   "definition without type",
+			params: "  - $ref: customer_id\n",
+			extra:  "---\nkind: parameterDef\nname: status\ndescription: status of the order\n",
+			want:   `missing 'type' field or it is not a string`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			parser := ConfigParser{}
+			_, err := parser.ParseConfig(ctx, []byte(fmt.Sprintf(baseYaml, tc.params)+tc.extra))
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+			if !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("error %q does not contain expected substring %q", err.Error(), tc.want)
+			}
+		})
+	}
+}
+
+func TestNamespaces(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	baseYaml := `
+kind: tool
+name: team-a-orders
+type: postgres-sql
+source: team-a-db
+description: get orders
+statement: SELECT * FROM orders
+---
+kind: tool
+name: team-b-orders
+type: postgres-sql
+source: team-b-db
+description: get orders
+statement: SELECT * FROM orders
+---
+kind: namespace
+name: team-a
+%s`
+
+	tcs := []struct {
+		desc      string
+		namespace string
+		want      string
+	}{
+		{
+			desc:      "allowed source",
+			namespace: "tools: [team-a-orders]\nallowedSources: [team-a-db]\n",
+		},
+		{
+			desc:      "source of another namespace",
+			namespace: "tools: [team-a-orders, team-b-orders]\nallowedSources: [team-a-db]\n",
+			want:      `tool "team-b-orders" of namespace "team-a" references source "team-b-db", which is not in the allowedSources of the namespace: team-a-db`,
+		},
+		{
+			desc:      "no allowed sources",
+			namespace: "tools: [team-a-orders]\n",
+			want:      `tool "team-a-orders" of namespace "team-a" references source "team-a-db", which is not in the allowedSources of the namespace: none`,
+		},
+		{
+			desc:      "undefined tool",
+			namespace: "tools: [team-c-orders]\nallowedSources: [team-a-db]\n",
+			want:      `namespace "team-a" lists tool "team-c-orders", which is not defined in the same file`,
+		},
+		{
+			desc:      "tool of two namespaces",
+			namespace: "tools: [team-a-orders]\nallowedSources: [team-a-db]\n---\nkind: namespace\nname: team-b\ntools: [team-a-orders]\nallowedSources: [team-a-db]\n",
+			want:      `tool "team-a-orders" belongs to both namespaces "team-a" and "team-b"`,
+		},
+		{
+			desc:      "unknown field",
+			namespace: "tools: [team-a-orders]\nallowedSource: [team-a-db]\n",
+			want:      `unknown field "allowedSource"`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			parser := ConfigParser{}
+			_, err := parser.ParseConfig(ctx, []byte(fmt.Sprintf(baseYaml, tc.namespace)))
+			if tc.want == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+			if !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("error %q does not contain expected substring %q", err.Error(), tc.want)
+			}
+		})
+	}
+}
+
+func TestLocalizedDescriptions(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	in := `
+kind: tool
+name: search-flights
+type: postgres-sql
+source: my-pg
+description:
+  en: Search flights
+  fr: Rechercher des vols
+statement: SELECT * FROM flights WHERE city = $1 AND airline = $2
+parameters:
+  - name: city
+    type: string
+    description:
+      en: Departure city
+      fr: Ville de départ
+  - name: airline
+    type: string
+    description: Airline code
+---
+kind: toolset
+name: french
+locale: fr
+tools:
+  - search-flights
+`
+	tcs := []struct {
+		desc          string
+		defaultLocale string
+		wantDesc      string
+		wantParamDesc string
+	}{
+		{desc: "english default", wantDesc: "Search flights", wantParamDesc: "Departure city"},
+		{desc: "french default", defaultLocale: "fr", wantDesc: "Rechercher des vols", wantParamDesc: "Ville de départ"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			ctx := ctx
+			if tc.defaultLocale != "" {
+				ctx = util.WithDefaultLocale(ctx, tc.defaultLocale)
+			}
+			parser := ConfigParser{}
+			got, err := parser.ParseConfig(ctx, []byte(in))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			cfg, ok := got.Tools["search-flights"].(postgressql.Config)
+			if !ok {
+				t.Fatalf("unexpected tool config: %T", got.Tools["search-flights"])
+			}
+			if cfg.Description != tc.wantDesc {
+				t.Errorf("unexpected description: got %q, want %q", cfg.Description, tc.wantDesc)
+			}
+			if desc := cfg.Parameters[0].GetDesc(); desc != tc.wantParamDesc {
+				t.Errorf("unexpected parameter description: got %q, want %q", desc, tc.wantParamDesc)
+			}
+			wantTranslations := tools.Translations{
+				"en": {Description: "Search flights", Parameters: map[string]string{"city": "Departure city"}},
+				"fr": {Description: "Rechercher des vols", Parameters: map[string]string{"city": "Ville de départ"}},
+			}
+			if diff := cmp.Diff(wantTranslations, cfg.Translations); diff != "" {
+				t.Errorf("unexpected translations (-want +got):\n%s", diff)
+			}
+			if locale := got.Toolsets["french"].Locale; locale != "fr" {
+				t.Errorf("unexpected toolset locale: %q", locale)
+			}
+		})
+	}
+}
+
+func TestLocalizedDescriptionsFailure(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	baseYaml := `
+kind: tool
+name: search-flights
+type: postgres-sql
+source: my-pg
+statement: SELECT * FROM flights WHERE city = $1
+%s`
+
+	tcs := []struct {
+		desc string
+		in   string
+		want string
+	}{
+		{
+			desc: "no text in the default locale",
+			in:   "description:\n  fr: Rechercher des vols\n",
+			want: `tool "search-flights" config error: description has no text in the default locale "en"`,
+		},
+		{
+			desc: "text is not a string",
+			in:   "description:\n  en:\n    text: Search flights\n",
+			want: `tool "search-flights" config error: description in locale "en" is not a string`,
+		},
+		{
+			desc: "parameter without text in the default locale",
+			in:   "description: Search flights\nparameters:\n  - name: city\n    type: string\n    description:\n      fr: Ville de départ\n",
+			want: `tool "search-flights" config error: description of parameter "city" has no text in the default locale "en"`,
+		},
+		{
+			desc: "combined with translations",
+			in:   "description:\n  en: Search flights\n  fr: Rechercher des vols\ntranslations:\n  de:\n    description: Flüge suchen\n",
+			want: `translations cannot be combined with descriptions declared per locale`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			parser := ConfigParser{}
+			_, err := parser.ParseConfig(ctx, []byte(fmt.Sprintf(baseYaml, tc.in)))
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+			if !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("error %q does not contain expected substring %q", err.Error(), tc.want)
+			}
+		})
+	}
+}
+
+func TestOpenAPITools(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	handWritten := `
+kind: tool
+name: listUsers
+type: http
+source: my-http
+description: List the users.
+method: GET
+path: /users
+queryParams:
+  - name: limit
+    type: integer
+    description: Maximum number of users.
+    required: false
+---
+kind: tool
+name: getUser
+type: http
+source: my-http
+description: Get a user by id.
+method: GET
+path: "/users/{{pathEscape .userId}}"
+pathParams:
+  - name: userId
+    type: string
+    description: The user id.
+headerParams:
+  - name: X-Request-Id
+    type: string
+    description: X-Request-Id
+---
+kind: tool
+name: createUser
+type: http
+source: my-http
+description: Create a user.
+method: POST
+path: /users
+requestBody: '{"name": {{json .name}}, "roles": {{json .roles}}}'
+bodyParams:
+  - name: name
+    type: string
+    description: The name of the user.
+  - name: roles
+    type: array
+    description: roles
+    required: false
+    items:
+      name: roles
+      type: string
+      description: roles
+      allowedValues:
+        - admin
+        - viewer
+`
+	parser := ConfigParser{}
+	want, err := parser.ParseConfig(ctx, []byte(handWritten))
+	if err != nil {
+		t.Fatalf("unable to parse hand-written config: %s", err)
+	}
+
+	tcs := []struct {
+		desc string
+		in   string
+	}{
+		{
+			desc: "flat format",
+			in: `
+kind: openapiTools
+name: users-api
+source: my-http
+spec: testdata/openapi.yaml
+include: [listUsers, getUser, createUser]
+`,
+		},
+		{
+			desc: "nested format",
+			in: `
+openapiTools:
+  users-api:
+    source: my-http
+    spec: testdata/openapi.yaml
+    include: [listUsers, getUser, createUser]
+`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := parser.ParseConfig(ctx, []byte(tc.in))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(want.Tools, got.Tools); diff != "" {
+				t.Errorf("generated tools differ from the hand-written ones (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestOpenAPIToolsFailure(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	tcs := []struct {
+		desc    string
+		spec    string
+		include string
+		want    string
+	}{
+		{
+			desc:    "oneOf request body",
+			spec:    "testdata/openapi.yaml",
+			include: "[createPet]",
+			want:    `operation "createPet": requestBody: oneOf schemas are not supported`,
+		},
+		{
+			desc:    "unknown operation",
+			spec:    "testdata/openapi.yaml",
+			include: "[deleteUser]",
+			want:    `operation "deleteUser" is not in spec "testdata/openapi.yaml"`,
+		},
+		{
+			desc:    "invalid tool name",
+			spec:    "testdata/openapi.yaml",
+			include: `["check health"]`,
+			want:    `error unmarshaling tool "check health": invalid character for resource name`,
+		},
+		{
+			desc:    "missing spec",
+			spec:    "testdata/missing.yaml",
+			include: "[]",
+			want:    `unable to read spec "testdata/missing.yaml"`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			in := fmt.Sprintf("kind: openapiTools\nname: users-api\nsource: my-http\nspec: %s\ninclude: %s\n", tc.spec, tc.include)
+			_, err := (&ConfigParser{}).ParseConfig(ctx, []byte(in))
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+			if !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("error %q does not contain expected substring %q", err.Error(), tc.want)
+			}
+		})
+	}
+}
+
+// sourceMap is a tools.SourceProvider of initialized sources.
+type sourceMap map[string]sources.Source
+
+func (m sourceMap) GetSource(name string) (sources.Source, bool) {
+	s, ok := m[name]
+	return s, ok
+}
+
+func TestOpenAPIToolInvoke(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	ts := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		if r.Method != nethttp.MethodGet || r.URL.EscapedPath() != "/users/ada%20l" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.EscapedPath())
+		}
+		if got := r.Header.Get("X-Request-Id"); got != "req-1" {
+			t.Errorf("unexpected X-Request-Id header: %q", got)
+		}
+		_, _ = w.Write([]byte(`{"id": "ada l", "name": "Ada"}`))
+	}))
+	defer ts.Close()
+
+	in := fmt.Sprintf(`
+sources:
+  my-http:
+    kind: http
+    baseUrl: %s
+    allowPrivateNetworks: true
+openapiTools:
+  users-api:
+    source: my-http
+    spec: testdata/openapi.yaml
+    include: [getUser]
+`, ts.URL)
+	cfg, err := (&ConfigParser{}).ParseConfig(ctx, []byte(in))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	src, err := cfg.Sources["my-http"].Initialize(ctx, nil)
+	if err != nil {
+		t.Fatalf("unable to initialize source: %s", err)
+	}
+	tool, err := cfg.Tools["getUser"].Initialize(ctx)
+	if err != nil {
+		t.Fatalf("unable to initialize tool: %s", err)
+	}
+	provider := sourceMap{"my-http": src}
+	params, err := tool.GetParameters(provider)
+	if err != nil {
+		t.Fatalf("unable to get parameters: %s", err)
+	}
+	values, err := parameters.ParseParams(params, map[string]any{"userId": "ada l", "X-Request-Id": "req-1"}, nil)
+	if err != nil {
+		t.Fatalf("unable to parse parameters: %s", err)
+	}
+
+	got, toolErr := tool.Invoke(ctx, provider, values, "")
+	if toolErr != nil {
+		t.Fatalf("unexpected error: %s", toolErr)
+	}
+	want := map[string]any{"id": "ada l", "name": "Ada"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected result (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"google.golang.org/api/googleapi"
+	monitoring "google.golang.org/api/monitoring/v1"
+)
+
+const (
+	// Prometheus names of the pool metrics of sources.
+	connectionsMetric      = "toolbox_source_pool_connections"
+	acquiresMetric         = "toolbox_source_pool_acquires_total"
+	canceledAcquiresMetric = "toolbox_source_pool_acquires_canceled_total"
+	acquireTimeMetric      = "toolbox_source_pool_acquire_time_seconds_total"
+	// rateWindow is the window of the rates of the charts.
+	rateWindow = "5m"
+	// sourceLabel is the dashboard label holding the name of the source.
+	sourceLabel = "toolbox_source"
+)
+
+var (
+	invalidIDChars    = regexp.MustCompile(`[^a-z0-9-]+`)
+	invalidLabelChars = regexp.MustCompile(`[^a-z0-9_-]+`)
+)
+
+// dashboardID returns the ID of the dashboard of an AlloyDB source, which is
+// stable so that the dashboard is updated rather than duplicated.
+func dashboardID(source string) string {
+	id := invalidIDChars.ReplaceAllString(strings.ToLower(source), "-")
+	return "toolbox-alloydb-" + strings.Trim(id, "-")
+}
+
+// labelValue returns the name of a source as a dashboard label value, which
+// allows only lowercase letters, digits, underscores and dashes.
+func labelValue(source string) string {
+	return invalidLabelChars.ReplaceAllString(strings.ToLower(source), "_")
+}
+
+// alloyDBDashboard returns the dashboard of the connection pool of an AlloyDB
+// source in a project.
+func alloyDBDashboard(project, source string) *monitoring.Dashboard {
+	selector := fmt.Sprintf(`toolbox_source_name=%q`, source)
+	rate := func(metric string) string {
+		return fmt.Sprintf("sum(rate(%s{%s}[%s]))", metric, selector, rateWindow)
+	}
+	connections := func(state string) string {
+		return fmt.Sprintf(`sum(%s{%s,toolbox_source_pool_state=%q})`, connectionsMetric, selector, state)
+	}
+
+	charts := []struct {
+		title, query, legend, label string
+	}{
+		{
+			title: "Pool utilization",
+			query: fmt.Sprintf("%s / %s", connections("acquired"), connections("max")),
+			label: "acquired / max connections",
+		},
+		{
+			title:  "Connections by state",
+			query:  fmt.Sprintf(`sum by (toolbox_source_pool_state) (%s{%s})`, connectionsMetric, selector),
+			legend: "${labels.toolbox_source_pool_state}",
+			label:  "connections",
+		},
+		{
+			title: "Average acquire wait time",
+			query: fmt.Sprintf("%s / %s", rate(acquireTimeMetric), rate(acquiresMetric)),
+			label: "seconds",
+		},
+		{
+			title: "Acquire error rate",
+			query: fmt.Sprintf("%s / %s", rate(canceledAcquiresMetric), rate(acquiresMetric)),
+			label: "canceled / acquires",
+		},
+	}
+
+	const columns, width, height = 48, 24, 16
+	tiles := make([]*monitoring.Tile, 0, len(charts))
+	for i, c := range charts {
+		tiles = append(tiles, &monitoring.Tile{
+			XPos:   int64(i%2) * width,
+			YPos:   int64(i/2) * height,
+			Width:  width,
+			Height: height,
+			Widget: &monitoring.Widget{
+				Title: c.title,
+				XyChart: &monitoring.XyChart{
+					DataSets: []*monitoring.DataSet{{
+						TimeSeriesQuery: &monitoring.TimeSeriesQuery{PrometheusQuery: c.query},
+						PlotType:        "LINE",
+						LegendTemplate:  c.legend,
+					}},
+					YAxis: &monitoring.Axis{Label: c.label, Scale: "LINEAR"},
+				},
+			},
+		})
+	}
+
+	return &monitoring.Dashboard{
+		Name:         fmt.Sprintf("projects/%s/dashboards/%s", project, dashboardID(source)),
+		DisplayName:  fmt.Sprintf("Toolbox AlloyDB pool: %s", source),
+		Labels:       map[string]string{sourceLabel: labelValue(source)},
+		MosaicLayout: &monitoring.MosaicLayout{Columns: columns, Tiles: tiles},
+	}
+}
+
+// apply creates the dashboard in its project, or replaces it if it already
+// exists. It reports whether the dashboard was created.
+func apply(ctx context.Context, service *monitoring.Service, project string, dashboard *monitoring.Dashboard) (bool, error) {
+	existing, err := service.Projects.Dashboards.Get(dashboard.Name).Context(ctx).Do()
+	var apiErr *googleapi.Error
+	switch {
+	case errors.As(err, &apiErr) && apiErr.Code == http.StatusNotFound:
+		if _, err := service.Projects.Dashboards.Create("projects/"+project, dashboard).Context(ctx).Do(); err != nil {
+			return false, fmt.Errorf("unable to create dashboard %s: %w", dashboard.Name, err)
+		}
+		return true, nil
+	case err != nil:
+		return false, fmt.Errorf("unable to get dashboard %s: %w", dashboard.Name, err)
+	}
+	dashboard.Etag = existing.Etag
+	if _, err := service.Projects.Dashboards.Patch(dashboard.Name, dashboard).Context(ctx).Do(); err != nil {
+		return false, fmt.Errorf("unable to update dashboard %s: %w", dashboard.Name, err)
+	}
+	return false, nil
+}
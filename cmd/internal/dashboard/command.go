@@ -0,0 +1,125 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboard
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/googleapis/mcp-toolbox/cmd/internal"
+	"github.com/googleapis/mcp-toolbox/internal/sources/alloydbpg"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/spf13/cobra"
+	monitoring "google.golang.org/api/monitoring/v1"
+	"google.golang.org/api/option"
+)
+
+// alloyDBCmd is the command for creating the dashboard of an AlloyDB source.
+type alloyDBCmd struct {
+	*cobra.Command
+	source  string
+	project string
+	// clientOpts are the options of the Monitoring client.
+	clientOpts []option.ClientOption
+}
+
+// NewCommand creates a new Command.
+func NewCommand(opts *internal.ToolboxOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dashboard",
+		Short: "Create Cloud Monitoring dashboards for sources",
+		Args:  cobra.NoArgs,
+	}
+	cmd.AddCommand(newAlloyDBCommand(opts))
+	return cmd
+}
+
+func newAlloyDBCommand(opts *internal.ToolboxOptions, clientOpts ...option.ClientOption) *cobra.Command {
+	cmd := &alloyDBCmd{clientOpts: clientOpts}
+	cmd.Command = &cobra.Command{
+		Use:   "alloydb",
+		Short: "Create a Cloud Monitoring dashboard for the connection pool of an AlloyDB source",
+		Long:  "Create, or update if it exists, a Cloud Monitoring dashboard charting the pool utilization, acquire wait time and acquire error rate of an alloydb-postgres source, from its pool metrics collected by Google Cloud Managed Service for Prometheus.",
+		Args:  cobra.NoArgs,
+		RunE: func(c *cobra.Command, args []string) error {
+			return runAlloyDB(cmd, opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	internal.ConfigFileFlags(cmd.Command, flags, opts)
+	flags.StringVar(&cmd.source, "source", "", "Name of the alloydb-postgres source.")
+	flags.StringVar(&cmd.project, "project", "", "Project to create the dashboard in. Defaults to the project of the source.")
+	_ = cmd.MarkFlagRequired("source")
+	return cmd.Command
+}
+
+func runAlloyDB(cmd *alloyDBCmd, opts *internal.ToolboxOptions) error {
+	ctx, cancel := context.WithCancel(cmd.Context())
+	defer cancel()
+
+	ctx, shutdown, err := opts.Setup(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = shutdown(ctx)
+	}()
+
+	parser := internal.ConfigParser{AllowMissingEnvVars: true}
+	if _, err := opts.LoadConfig(ctx, &parser); err != nil {
+		return err
+	}
+
+	sourceConfig, ok := opts.Cfg.SourceConfigs[cmd.source]
+	if !ok {
+		return fmt.Errorf("source %q not found", cmd.source)
+	}
+	cfg, ok := sourceConfig.(alloydbpg.Config)
+	if !ok {
+		return fmt.Errorf("source %q is of type %q, not %q", cmd.source, sourceConfig.SourceConfigType(), alloydbpg.SourceType)
+	}
+	project := cmd.project
+	if project == "" {
+		project = cfg.Project
+	}
+	if project == "" {
+		return fmt.Errorf("--project is required as source %q has no project", cmd.source)
+	}
+
+	userAgent, err := util.UserAgentFromContext(util.WithUserAgent(ctx, opts.Cfg.Version))
+	if err != nil {
+		return err
+	}
+	clientOpts := append([]option.ClientOption{option.WithUserAgent(userAgent)}, cmd.clientOpts...)
+	service, err := monitoring.NewService(ctx, clientOpts...)
+	if err != nil {
+		return fmt.Errorf("unable to create Cloud Monitoring client: %w", err)
+	}
+
+	dashboard := alloyDBDashboard(project, cmd.source)
+	created, err := apply(ctx, service, project, dashboard)
+	if err != nil {
+		opts.Logger.ErrorContext(ctx, err.Error())
+		return err
+	}
+	action := "Updated"
+	if created {
+		action = "Created"
+	}
+	opts.Logger.InfoContext(ctx, fmt.Sprintf("%s dashboard %s for source %q.", action, dashboard.Name, cmd.source))
+	_, err = fmt.Fprintln(opts.IOStreams.Out, dashboard.Name)
+	return err
+}
@@ -0,0 +1,195 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboard
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/googleapis/mcp-toolbox/cmd/internal"
+	_ "github.com/googleapis/mcp-toolbox/internal/sources/alloydbpg"
+	_ "github.com/googleapis/mcp-toolbox/internal/sources/sqlite"
+	"github.com/spf13/cobra"
+	monitoring "google.golang.org/api/monitoring/v1"
+	"google.golang.org/api/option"
+)
+
+func invokeCommand(args []string, clientOpts ...option.ClientOption) (string, error) {
+	parentCmd := &cobra.Command{
+		Use:           "toolbox",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	buf := new(bytes.Buffer)
+	opts := internal.NewToolboxOptions(internal.WithIOStreams(buf, buf))
+	internal.PersistentFlags(parentCmd, opts)
+
+	cmd := NewCommand(opts)
+	cmd.RemoveCommand(cmd.Commands()...)
+	cmd.AddCommand(newAlloyDBCommand(opts, clientOpts...))
+	parentCmd.AddCommand(cmd)
+	parentCmd.SetArgs(args)
+
+	err := parentCmd.Execute()
+	return buf.String(), err
+}
+
+const toolsFileContent = `
+kind: source
+name: my-pg
+type: alloydb-postgres
+project: source-project
+region: us-central1
+cluster: my-cluster
+instance: my-instance
+database: my_db
+---
+kind: source
+name: my-sqlite
+type: sqlite
+database: ":memory:"
+`
+
+// fakeDashboards is an in-memory Monitoring Dashboard API.
+type fakeDashboards struct {
+	mu         sync.Mutex
+	dashboards map[string]*monitoring.Dashboard
+	etags      int
+}
+
+func (f *fakeDashboards) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	name := strings.TrimPrefix(r.URL.Path, "/v1/")
+	if r.Method == http.MethodGet {
+		d, ok := f.dashboards[name]
+		if !ok {
+			http.Error(w, `{"error": {"code": 404, "message": "not found"}}`, http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(d)
+		return
+	}
+
+	var d monitoring.Dashboard
+	if err := json.NewDecoder(r.Body).Decode(&d); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	switch r.Method {
+	case http.MethodPost:
+		if _, ok := f.dashboards[d.Name]; ok {
+			http.Error(w, `{"error": {"code": 409, "message": "already exists"}}`, http.StatusConflict)
+			return
+		}
+	case http.MethodPatch:
+		if existing, ok := f.dashboards[name]; !ok || existing.Etag != d.Etag {
+			http.Error(w, `{"error": {"code": 409, "message": "etag mismatch"}}`, http.StatusConflict)
+			return
+		}
+	}
+	f.etags++
+	d.Etag = strings.Repeat("e", f.etags)
+	f.dashboards[d.Name] = &d
+	_ = json.NewEncoder(w).Encode(d)
+}
+
+func TestAlloyDBDashboard(t *testing.T) {
+	dir := t.TempDir()
+	toolsFilePath := filepath.Join(dir, "tools.yaml")
+	if err := os.WriteFile(toolsFilePath, []byte(toolsFileContent), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	fake := &fakeDashboards{dashboards: map[string]*monitoring.Dashboard{}}
+	ts := httptest.NewServer(fake)
+	defer ts.Close()
+	clientOpts := []option.ClientOption{option.WithEndpoint(ts.URL + "/"), option.WithoutAuthentication()}
+
+	// creating the dashboard twice updates it rather than duplicating it
+	for range 2 {
+		out, err := invokeCommand([]string{"dashboard", "alloydb", "--config", toolsFilePath, "--source", "my-pg", "--project", "my-proj"}, clientOpts...)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if !strings.Contains(out, "projects/my-proj/dashboards/toolbox-alloydb-my-pg") {
+			t.Errorf("unexpected output: %s", out)
+		}
+	}
+	if len(fake.dashboards) != 1 {
+		t.Fatalf("unexpected number of dashboards: %d", len(fake.dashboards))
+	}
+	d := fake.dashboards["projects/my-proj/dashboards/toolbox-alloydb-my-pg"]
+	if d == nil {
+		t.Fatalf("dashboard not created: %v", fake.dashboards)
+	}
+	if d.Labels[sourceLabel] != "my-pg" {
+		t.Errorf("unexpected labels: %v", d.Labels)
+	}
+	var titles []string
+	for _, tile := range d.MosaicLayout.Tiles {
+		titles = append(titles, tile.Widget.Title)
+	}
+	want := "Pool utilization,Connections by state,Average acquire wait time,Acquire error rate"
+	if strings.Join(titles, ",") != want {
+		t.Errorf("unexpected charts: got %v, want %s", titles, want)
+	}
+	wantQuery := `sum(rate(toolbox_source_pool_acquire_time_seconds_total{toolbox_source_name="my-pg"}[5m])) / sum(rate(toolbox_source_pool_acquires_total{toolbox_source_name="my-pg"}[5m]))`
+	if got := d.MosaicLayout.Tiles[2].Widget.XyChart.DataSets[0].TimeSeriesQuery.PrometheusQuery; got != wantQuery {
+		t.Errorf("unexpected wait time query: got %q, want %q", got, wantQuery)
+	}
+}
+
+func TestAlloyDBDashboardErrors(t *testing.T) {
+	dir := t.TempDir()
+	toolsFilePath := filepath.Join(dir, "tools.yaml")
+	if err := os.WriteFile(toolsFilePath, []byte(toolsFileContent), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	tcs := []struct {
+		desc    string
+		source  string
+		wantErr string
+	}{
+		{desc: "unknown source", source: "missing", wantErr: `source "missing" not found`},
+		{desc: "not alloydb", source: "my-sqlite", wantErr: `source "my-sqlite" is of type "sqlite"`},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := invokeCommand([]string{"dashboard", "alloydb", "--config", toolsFilePath, "--source", tc.source})
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("unexpected error: got %v, want %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestDashboardID(t *testing.T) {
+	if got := dashboardID("My_PG.Source"); got != "toolbox-alloydb-my-pg-source" {
+		t.Errorf("unexpected dashboard ID: %s", got)
+	}
+	if got := labelValue("My_PG.Source"); got != "my_pg_source" {
+		t.Errorf("unexpected label value: %s", got)
+	}
+}
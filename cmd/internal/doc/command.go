@@ -0,0 +1,114 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doc
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/googleapis/mcp-toolbox/cmd/internal"
+	"github.com/googleapis/mcp-toolbox/internal/server"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/spf13/cobra"
+)
+
+// docCmd is the command for generating tool documentation.
+type docCmd struct {
+	*cobra.Command
+	output  string
+	toolset string
+}
+
+// NewCommand creates a new Command.
+func NewCommand(opts *internal.ToolboxOptions) *cobra.Command {
+	cmd := &docCmd{}
+	cmd.Command = &cobra.Command{
+		Use:   "doc",
+		Short: "Generate Markdown documentation of tool configurations",
+		Long:  "Generate Markdown documentation of tools, with their parameters and their examples rendered as code blocks.",
+		Args:  cobra.NoArgs,
+		RunE: func(c *cobra.Command, args []string) error {
+			return run(cmd, opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	internal.ConfigFileFlags(cmd.Command, flags, opts)
+	flags.StringVarP(&cmd.output, "output", "o", "", "File to write the documentation to. Defaults to stdout.")
+	flags.StringVar(&cmd.toolset, "toolset", "", "Name of the toolset to document. If not provided, all tools will be included.")
+	return cmd.Command
+}
+
+func run(cmd *docCmd, opts *internal.ToolboxOptions) error {
+	ctx, cancel := context.WithCancel(cmd.Context())
+	defer cancel()
+
+	ctx, shutdown, err := opts.Setup(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = shutdown(ctx)
+	}()
+
+	// doc runs offline, so unset environment variables of the sources
+	// resolve to "".
+	parser := internal.ConfigParser{AllowMissingEnvVars: true}
+	if _, err := opts.LoadConfig(ctx, &parser); err != nil {
+		return err
+	}
+
+	toolsMap, toolsetsMap, err := server.InitializeOfflineConfigs(ctx, opts.Cfg)
+	if err != nil {
+		errMsg := fmt.Errorf("failed to initialize resources: %w", err)
+		opts.Logger.ErrorContext(ctx, errMsg.Error())
+		return errMsg
+	}
+
+	if cmd.toolset != "" {
+		ts, ok := toolsetsMap[cmd.toolset]
+		if !ok {
+			return fmt.Errorf("toolset %q not found", cmd.toolset)
+		}
+		toolsMap = make(map[string]tools.Tool)
+		for _, t := range ts.Tools {
+			if t != nil {
+				tool := *t
+				toolsMap[tool.GetName()] = tool
+			}
+		}
+	}
+	if len(toolsMap) == 0 {
+		return fmt.Errorf("no tools found to document")
+	}
+
+	content, err := generate(toolsMap)
+	if err != nil {
+		return err
+	}
+
+	if cmd.output == "" {
+		_, err := fmt.Fprint(opts.IOStreams.Out, content)
+		return err
+	}
+	if err := os.WriteFile(cmd.output, []byte(content), 0644); err != nil {
+		errMsg := fmt.Errorf("error writing documentation: %w", err)
+		opts.Logger.ErrorContext(ctx, errMsg.Error())
+		return errMsg
+	}
+	opts.Logger.InfoContext(ctx, fmt.Sprintf("Successfully generated documentation for %d tools in %s.", len(toolsMap), cmd.output))
+	return nil
+}
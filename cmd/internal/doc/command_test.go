@@ -0,0 +1,134 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doc
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/googleapis/mcp-toolbox/cmd/internal"
+	_ "github.com/googleapis/mcp-toolbox/internal/sources/sqlite"
+	_ "github.com/googleapis/mcp-toolbox/internal/tools/sqlite/sqlitesql"
+	"github.com/spf13/cobra"
+)
+
+func invokeCommand(args []string) (string, error) {
+	parentCmd := &cobra.Command{
+		Use:           "toolbox",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	buf := new(bytes.Buffer)
+	opts := internal.NewToolboxOptions(internal.WithIOStreams(buf, buf))
+	internal.PersistentFlags(parentCmd, opts)
+
+	cmd := NewCommand(opts)
+	parentCmd.AddCommand(cmd)
+	// INFO logs share the output stream and list every tool name.
+	parentCmd.SetArgs(append([]string{"--log-level", "ERROR"}, args...))
+
+	err := parentCmd.Execute()
+	return buf.String(), err
+}
+
+const toolsFileContent = `
+kind: source
+name: my-sqlite
+type: sqlite
+database: ":memory:"
+---
+kind: tool
+name: search-users
+type: sqlite-sql
+source: my-sqlite
+description: search users by region
+statement: SELECT * FROM users WHERE region = ?
+parameters:
+  - name: region
+    type: string
+    description: region of the users
+examples:
+  - description: users in the east
+    parameters:
+      region: east
+    expectedRows:
+      - name: Alice
+        region: east
+---
+kind: tool
+name: count-users
+type: sqlite-sql
+source: my-sqlite
+description: count users
+statement: SELECT COUNT(*) FROM users
+---
+kind: toolset
+name: search
+tools:
+  - search-users
+`
+
+const wantSearchUsers = "## search-users\n\nsearch users by region\n\n" +
+	"| Parameter | Type | Required | Description |\n" +
+	"|-----------|------|----------|-------------|\n" +
+	"| region | string | true | region of the users |\n\n" +
+	"### Example 1\n\nusers in the east\n\n" +
+	"```json\n{\n  \"region\": \"east\"\n}\n```\n\n" +
+	"Returns:\n\n" +
+	"```json\n[\n  {\n    \"name\": \"Alice\",\n    \"region\": \"east\"\n  }\n]\n```\n"
+
+func TestGenerateDoc(t *testing.T) {
+	toolsFilePath := filepath.Join(t.TempDir(), "tools.yaml")
+	if err := os.WriteFile(toolsFilePath, []byte(toolsFileContent), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	t.Run("all tools", func(t *testing.T) {
+		got, err := invokeCommand([]string{"doc", "--config", toolsFilePath})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if !strings.Contains(got, wantSearchUsers) {
+			t.Errorf("output %q does not contain %q", got, wantSearchUsers)
+		}
+		if !strings.Contains(got, "## count-users\n\ncount users\n") {
+			t.Errorf("output %q does not document count-users", got)
+		}
+		if strings.Index(got, "## count-users") > strings.Index(got, "## search-users") {
+			t.Errorf("expected tools in name order: %q", got)
+		}
+	})
+
+	t.Run("toolset", func(t *testing.T) {
+		got, err := invokeCommand([]string{"doc", "--toolset", "search", "--config", toolsFilePath})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if strings.Contains(got, "count-users") {
+			t.Errorf("output %q documents a tool outside of the toolset", got)
+		}
+	})
+
+	t.Run("unknown toolset", func(t *testing.T) {
+		_, err := invokeCommand([]string{"doc", "--toolset", "missing", "--config", toolsFilePath})
+		if err == nil || !strings.Contains(err.Error(), `toolset "missing" not found`) {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
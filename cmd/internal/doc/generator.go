@@ -0,0 +1,126 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doc
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+// toolDoc holds the documentation data of a single tool.
+type toolDoc struct {
+	Name        string
+	Description string
+	Parameters  []parameters.ParameterManifest
+	Examples    []tools.Example
+}
+
+const docTemplate = `# Tools
+{{range .}}
+## {{.Name}}
+
+{{.Description}}
+{{- if .Parameters}}
+
+| Parameter | Type | Required | Description |
+|-----------|------|----------|-------------|
+{{- range .Parameters}}
+| {{.Name}} | {{.Type}} | {{.Required}} | {{cell .Description}} |
+{{- end}}
+{{- end}}
+{{- range $i, $e := .Examples}}
+
+### Example {{inc $i}}
+{{- if $e.Description}}
+
+{{$e.Description}}
+{{- end}}
+
+` + "```json" + `
+{{json $e.Parameters}}
+` + "```" + `
+{{- if $e.ExpectedRows}}
+
+Returns:
+
+` + "```json" + `
+{{json $e.ExpectedRows}}
+` + "```" + `
+{{- end}}
+{{- end}}
+{{end -}}
+`
+
+var templateFuncs = template.FuncMap{
+	"json": jsonBlock,
+	"cell": tableCell,
+	"inc":  func(i int) int { return i + 1 },
+}
+
+// generate renders the Markdown documentation of the given tools in name
+// order.
+func generate(toolsMap map[string]tools.Tool) (string, error) {
+	names := make([]string, 0, len(toolsMap))
+	for name := range toolsMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	docs := make([]toolDoc, 0, len(names))
+	for _, name := range names {
+		tool := toolsMap[name]
+		manifest := tool.StaticManifest()
+		d := toolDoc{Name: name, Description: strings.TrimSpace(manifest.Description), Parameters: manifest.Parameters}
+		if c, ok := tool.ToConfig().(interface{ GetExamples() []tools.Example }); ok {
+			d.Examples = c.GetExamples()
+		}
+		docs = append(docs, d)
+	}
+
+	tmpl, err := template.New("doc").Funcs(templateFuncs).Parse(docTemplate)
+	if err != nil {
+		return "", fmt.Errorf("error parsing doc template: %w", err)
+	}
+	var buf strings.Builder
+	if err := tmpl.Execute(&buf, docs); err != nil {
+		return "", fmt.Errorf("error executing doc template: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// jsonBlock renders v as indented JSON. Missing parameters render as an
+// empty object.
+func jsonBlock(v any) (string, error) {
+	if m, ok := v.(map[string]any); ok && m == nil {
+		v = map[string]any{}
+	}
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// tableCell escapes s for a cell of a Markdown table.
+func tableCell(s string) string {
+	s = strings.ReplaceAll(strings.TrimSpace(s), "|", `\|`)
+	return strings.Join(strings.Fields(strings.ReplaceAll(s, "\n", " ")), " ")
+}
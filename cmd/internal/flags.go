@@ -20,6 +20,11 @@ import (
 
 	"github.com/googleapis/mcp-toolbox/internal/prebuiltconfigs"
 	"github.com/googleapis/mcp-toolbox/internal/server"
+	"github.com/googleapis/mcp-toolbox/internal/server/approval"
+	"github.com/googleapis/mcp-toolbox/internal/server/responselimit"
+	"github.com/googleapis/mcp-toolbox/internal/server/resultcache"
+	"github.com/googleapis/mcp-toolbox/internal/sources/secrets"
+	"github.com/googleapis/mcp-toolbox/internal/util"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 )
@@ -43,10 +48,10 @@ func PersistentFlags(parentCmd *cobra.Command, opts *ToolboxOptions) {
 // ConfigFileFlags defines flags related to the configuration file.
 // It should be applied to any command that requires configuration loading.
 func ConfigFileFlags(parentCmd *cobra.Command, flags *pflag.FlagSet, opts *ToolboxOptions) {
-	flags.StringVar(&opts.Config, "config", "", "File path specifying the tool configuration. Cannot be used with --configs, or --config-folder.")
+	flags.StringVar(&opts.Config, "config", "", "File path or gs://, https:// or k8s:// URL specifying the tool configuration. Cannot be used with --configs, or --config-folder.")
 	flags.StringVar(&opts.Config, "tools-file", "", "File path specifying the tool configuration. Cannot be used with --tools-files, or --tools-folder.")
 	_ = flags.MarkDeprecated("tools-file", "please use --config instead") // DEPRECATED
-	flags.StringSliceVar(&opts.Configs, "configs", []string{}, "Multiple file paths specifying tool configurations. Files will be merged. Cannot be used with --config, or --config-folder.")
+	flags.StringSliceVar(&opts.Configs, "configs", []string{}, "Multiple file paths or gs://, https:// or k8s:// URLs specifying tool configurations. Files will be merged. Cannot be used with --config, or --config-folder.")
 	flags.StringSliceVar(&opts.Configs, "tools-files", []string{}, "Multiple file paths specifying tool configurations. Files will be merged. Cannot be used with --tools-file, or --tools-folder.")
 	_ = flags.MarkDeprecated("tools-files", "please use --configs instead") // DEPRECATED
 	flags.StringVar(&opts.ConfigFolder, "config-folder", "", "Directory path containing YAML tool configuration files. All .yaml and .yml files in the directory will be loaded and merged. Cannot be used with --config, or --configs.")
@@ -63,10 +68,13 @@ func ConfigFileFlags(parentCmd *cobra.Command, flags *pflag.FlagSet, opts *Toolb
 
 // ServeFlags defines flags for starting and configuring the server.
 func ServeFlags(flags *pflag.FlagSet, opts *ToolboxOptions) {
-	flags.StringVarP(&opts.Cfg.Address, "address", "a", "127.0.0.1", "Address of the interface the server will listen on.")
+	flags.StringVarP(&opts.Cfg.Address, "address", "a", "127.0.0.1", "Address of the interface the server will listen on, or unix:PATH to listen on a Unix domain socket.")
 	flags.IntVarP(&opts.Cfg.Port, "port", "p", 5000, "Port the server will listen on.")
+	flags.IntVar(&opts.Cfg.GRPCPort, "grpc-port", 0, "Port the gRPC API is served on, in addition to the HTTP server. Disabled by default.")
 	flags.StringVar(&opts.Cfg.CertFile, "tls-cert", "", "Path to TLS certificate file")
 	flags.StringVar(&opts.Cfg.KeyFile, "tls-key", "", "Path to TLS key file")
+	flags.StringSliceVar(&opts.Cfg.TLSCipherSuites, "tls-cipher-suites", []string{}, "Comma-separated names of the TLS cipher suites the server allows, such as TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256. Defaults to the cipher suites of Go.")
+	flags.BoolVar(&opts.Cfg.TLSFIPS, "tls-fips", false, "Restrict TLS to the FIPS 140 approved cipher suites. Overrides --tls-cipher-suites.")
 	flags.BoolVar(&opts.Cfg.Stdio, "stdio", false, "Listens via MCP STDIO instead of acting as a remote HTTP server.")
 	flags.BoolVar(&opts.Cfg.UI, "ui", false, "Launches the Toolbox UI web server.")
 	flags.BoolVar(&opts.Cfg.EnableAPI, "enable-api", false, "Enable the /api endpoint.")
@@ -75,5 +83,47 @@ func ServeFlags(flags *pflag.FlagSet, opts *ToolboxOptions) {
 	flags.StringSliceVar(&opts.Cfg.AllowedOrigins, "allowed-origins", []string{"*"}, "Specifies a list of origins permitted to access this server. Defaults to '*'.")
 	flags.StringSliceVar(&opts.Cfg.AllowedHosts, "allowed-hosts", []string{"*"}, "Specifies a list of hosts permitted to access this server. Defaults to '*'.")
 	flags.Int64Var(&opts.Cfg.HttpMaxRequestBytes, "http-max-request-bytes", server.DefaultHTTPMaxRequestBytes, "Maximum MCP HTTP request body size in bytes.")
+	flags.IntVar(&opts.Cfg.BatchParallelism, "batch-parallelism", server.DefaultBatchParallelism, "Maximum number of the invocations of a batch submitted to /api/batch run at once.")
 	flags.BoolVar(&opts.Cfg.EnableDraftSpecs, "enable-draft-specs", false, "Opt-in and test upcoming draft MCP specifications.")
+	flags.IntVar(&opts.Cfg.InvocationQueueDepth, "invocation-queue-depth", server.DefaultInvocationQueueDepth, "Maximum number of tool invocations processed at once over stdio. Further invocations are rejected with a server busy error. Set to 0 to disable.")
+	flags.BoolVar(&opts.Cfg.HTTPInvocationQueue, "http-invocation-queue", false, "Apply --invocation-queue-depth to tool invocations over HTTP as well.")
+	flags.StringVar(&opts.Cfg.CacheBackend, "cache-backend", "", "Cache the results of read-only tools: 'memory', 'memcached' or 'redis'. Caching is disabled by default.")
+	flags.StringSliceVar(&opts.Cfg.MemcachedAddrs, "memcached-addrs", []string{}, "Comma-separated Memcached server addresses used by --cache-backend=memcached.")
+	flags.StringVar(&opts.Cfg.RedisURL, "redis-url", "", "Redis server URL used by --cache-backend=redis, such as 'redis://localhost:6379/0'.")
+	flags.DurationVar(&opts.Cfg.CacheTTL, "cache-ttl", resultcache.DefaultTTL, "How long tool results are cached.")
+	flags.StringVar(&opts.Cfg.AuditLog, "audit-log", "", "Write an audit record of every tool invocation: 'stdout', 'file' (--audit-log-file) or 'cloud-logging' (--audit-log-project). Auditing is disabled by default.")
+	flags.StringVar(&opts.Cfg.AuditLogFile, "audit-log-file", "", "File the audit records of --audit-log=file are appended to.")
+	flags.StringVar(&opts.Cfg.AuditLogProject, "audit-log-project", "", "Google Cloud project the audit records of --audit-log=cloud-logging are written to.")
+	flags.StringSliceVar(&opts.Cfg.AuditRedactParams, "audit-redact-params", []string{}, "Comma-separated names of the parameters whose values are redacted from audit records.")
+	flags.StringVar(&opts.Cfg.ApprovalURL, "approval-url", "", "Endpoint the invocations of tools with requiresApproval are posted to, each running only once approved.")
+	flags.StringVar(&opts.Cfg.ApprovalSecret, "approval-secret", "", "Secret verifying the HMAC-SHA256 tokens of the approvals of --approval-url.")
+	flags.DurationVar(&opts.Cfg.ApprovalTimeout, "approval-timeout", approval.DefaultTimeout, "How long an invocation waits for its approval before it is rejected.")
+	flags.StringVar(&opts.Cfg.SummarizerURL, "summarizer-url", "", "Endpoint the results of tools with oversizedResponse: summarize that exceed their maxResponseBytes are posted to, returning a summary of them.")
+	flags.DurationVar(&opts.Cfg.SummarizerTimeout, "summarizer-timeout", responselimit.DefaultTimeout, "How long an invocation waits for the summary of its oversized result before it fails.")
+	flags.IntVar(&opts.Cfg.ResultPageSize, "result-page-size", 0, "Number of rows returned by a tool invocation, the rest being read with the nextPageToken of the response. Results are returned whole by default.")
+	flags.IntVar(&opts.Cfg.ListPageSize, "list-page-size", 0, "Number of tools of a page of tool listings, the rest being read with the nextPageToken or nextCursor of the response. Tools are listed at once by default.")
+	flags.StringVar(&opts.Cfg.AdminToken, "admin-token", "", "Token authenticating administrative requests in the X-Toolbox-Admin-Token header. Administrative requests are disabled by default.")
+	flags.StringVar(&opts.Cfg.ResponseSigningKey, "response-signing-key", "", "Key signing the bodies of tool invocation responses with HMAC-SHA256, in the X-Toolbox-Signature header. Responses are not signed by default.")
+	flags.StringVar(&opts.Cfg.AsyncBackend, "async-backend", server.AsyncBackendLocal, "Backend running tool invocations requested with ?async=true: 'local' runs them in the background of the server, 'cloud-tasks' delivers them through --cloud-tasks-queue.")
+	flags.StringVar(&opts.Cfg.CloudTasksQueue, "cloud-tasks-queue", "", "Resource name of the Cloud Tasks queue of --async-backend=cloud-tasks, such as projects/PROJECT/locations/LOCATION/queues/QUEUE.")
+	flags.StringVar(&opts.Cfg.CloudTasksServiceAccount, "cloud-tasks-service-account", "", "Service account Cloud Tasks signs the OIDC tokens of tasks as. The task handler rejects tasks without a token of this account.")
+	flags.StringVar(&opts.Cfg.AsyncResultsBucket, "async-results-bucket", "", "Cloud Storage bucket storing the results of asynchronous invocations. Required by --async-backend=cloud-tasks. Results are kept in memory by default.")
+	flags.StringSliceVar(&opts.Cfg.AsyncCallbackHosts, "async-callback-hosts", []string{}, "Hosts the callbackUrl of asynchronous invocations may target, which is posted their final state. Callbacks are disabled by default.")
+	flags.StringVar(&opts.Cfg.AuthBackend, "auth-backend", "", "Authenticate all requests to the server: 'iap' requires the X-Goog-IAP-JWT-Assertion header of Cloud Identity-Aware Proxy. Requests are not authenticated by default.")
+	flags.StringVar(&opts.Cfg.IAPAudience, "iap-audience", "", "Expected audience of the IAP JWT assertions of --auth-backend=iap, such as /projects/PROJECT_NUMBER/global/backendServices/SERVICE_ID.")
+	flags.BoolVar(&opts.Cfg.AccessDenyByDefault, "access-deny-by-default", false, "Deny clients the use of the toolsets and tools none of their access policies grant. By default, only the toolsets and tools granted by an access policy are restricted.")
+	flags.BoolVar(&opts.Cfg.TrustProxy, "trust-proxy", false, "Take the source address of requests, checked against the allowedCIDRs of tools, from the X-Forwarded-For header set by a trusted proxy.")
+	flags.IntVar(&opts.Cfg.TrustedProxyHops, "trusted-proxy-hops", server.DefaultTrustedProxyHops, "Number of trusted proxies in front of the server with --trust-proxy. The source address is the X-Forwarded-For entry this many entries from the right, as entries further left can be set by clients.")
+	flags.BoolVar(&opts.Cfg.UpdateSchemaSnapshots, "update-schema-snapshots", false, "Overwrite the schema snapshots of sources with their current schemas, after an intentional migration.")
+	flags.IntVar(&opts.Cfg.DefaultRateLimit.RequestsPerMinute, "tool-requests-per-minute", 0, "Number of invocations allowed per minute for each tool that doesn't set its own rateLimit. Unlimited by default.")
+	flags.IntVar(&opts.Cfg.DefaultRateLimit.MaxConcurrency, "tool-max-concurrency", 0, "Number of invocations allowed to run at once for each tool that doesn't set its own rateLimit.maxConcurrency. Unlimited by default.")
+	flags.Var(&opts.Cfg.ParamCoercion, "param-coercion", "Coercion of loosely typed parameter values, such as \"42\" for an integer: 'strict' rejects them, 'lenient' converts them to the declared type. Tools can override it with their coercion field.")
+	flags.BoolVar(&opts.Cfg.RedactErrorDetails, "redact-error-details", false, "Return the message of the error code of failed tool invocations instead of the error text, which may include the errors of database drivers. The error code, retryable flag and source error class are returned either way.")
+	flags.StringVar(&opts.Cfg.DefaultLocale, "default-locale", util.DefaultLocale, "Locale of tool descriptions declared per locale that is served when neither the Accept-Language header of a request nor its toolset selects another.")
+	flags.DurationVar(&opts.Cfg.SessionPingInterval, "session-ping-interval", 0, "How often to ping SSE sessions to detect dead clients. Pinging is disabled by default.")
+	flags.IntVar(&opts.Cfg.SessionMaxMissedPings, "session-max-missed-pings", server.DefaultSessionMaxMissedPings, "Number of pings in a row an SSE session may leave unanswered before it is closed.")
+	flags.DurationVar(&opts.Cfg.ShutdownTimeout, "shutdown-timeout", server.DefaultShutdownTimeout, "Maximum time to wait for in-flight tool invocations to complete on shutdown.")
+	flags.DurationVar(&opts.Cfg.SecretRefreshInterval, "secret-refresh-interval", secrets.DefaultRefreshInterval, "How often the secrets referenced by the sources, such as ${secretmanager:...}, are resolved again. Sources whose secrets changed are initialized again. 0 disables the refresh.")
+	flags.StringSliceVar(&opts.Cfg.PrewarmSources, "prewarm-sources", []string{}, "Sources whose connections are established and checked at startup, or '*' for every source that supports pings. Startup fails if any of them can't be reached. Other sources connect on first use.")
+	flags.DurationVar(&opts.Cfg.SourcePingInterval, "source-ping-interval", 0, "How often to ping the sources in the background. Failed pings are retried with an exponential backoff so connection pools reconnect. Pinging is disabled by default.")
 }
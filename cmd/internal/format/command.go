@@ -0,0 +1,117 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package format
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/googleapis/mcp-toolbox/cmd/internal"
+	"github.com/spf13/cobra"
+)
+
+// fmtCmd is the command for formatting the SQL statements in configuration
+// files.
+type fmtCmd struct {
+	*cobra.Command
+	check bool
+}
+
+func NewCommand(opts *internal.ToolboxOptions) *cobra.Command {
+	cmd := &fmtCmd{}
+	cmd.Command = &cobra.Command{
+		Use:   "fmt",
+		Short: "Format the SQL statements of tools in configuration files",
+		Long:  "Format the SQL statements of PostgreSQL and MySQL tools in the configuration files provided, rewriting the files in place.",
+	}
+	flags := cmd.Flags()
+	internal.ConfigFileFlags(cmd.Command, flags, opts)
+	flags.BoolVar(&cmd.check, "check", false, "Report files that are not formatted and exit with a non-zero status instead of rewriting them.")
+	cmd.RunE = func(*cobra.Command, []string) error { return runFmt(cmd, opts) }
+	return cmd.Command
+}
+
+func runFmt(cmd *fmtCmd, opts *internal.ToolboxOptions) error {
+	ctx, cancel := context.WithCancel(cmd.Context())
+	defer cancel()
+
+	ctx, shutdown, err := opts.Setup(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = shutdown(ctx)
+	}()
+
+	logger := opts.Logger
+	filePaths, _, err := opts.GetCustomConfigFiles(ctx)
+	if err != nil {
+		errMsg := fmt.Errorf("error retrieving configuration file: %w", err)
+		logger.ErrorContext(ctx, errMsg.Error())
+		return errMsg
+	}
+
+	var errs []error
+	var unformatted []string
+	for _, filePath := range filePaths {
+		buf, err := os.ReadFile(filePath)
+		if err != nil {
+			errMsg := fmt.Errorf("unable to read tool file at %q: %w", filePath, err)
+			logger.ErrorContext(ctx, errMsg.Error())
+			errs = append(errs, errMsg)
+			continue
+		}
+		newBuf, changed, err := FormatConfig(buf)
+		if err != nil {
+			errMsg := fmt.Errorf("unable to format tool file at %q: %w", filePath, err)
+			logger.ErrorContext(ctx, errMsg.Error())
+			errs = append(errs, errMsg)
+			continue
+		}
+		if len(changed) == 0 {
+			continue
+		}
+
+		if cmd.check {
+			unformatted = append(unformatted, filePath)
+			fmt.Fprintf(opts.IOStreams.Out, "%s: %s\n", filePath, strings.Join(changed, ", "))
+			continue
+		}
+		info, err := os.Stat(filePath)
+		if err != nil {
+			errMsg := fmt.Errorf("failed to stat file: %w", err)
+			logger.ErrorContext(ctx, errMsg.Error())
+			errs = append(errs, errMsg)
+			continue
+		}
+		// set the permission to the original file's permission.
+		if err := os.WriteFile(filePath, newBuf, info.Mode().Perm()); err != nil {
+			errMsg := fmt.Errorf("failed to write to file: %w", err)
+			logger.ErrorContext(ctx, errMsg.Error())
+			errs = append(errs, errMsg)
+			continue
+		}
+		logger.InfoContext(ctx, fmt.Sprintf("formatted %d tools in %s", len(changed), filePath))
+	}
+
+	if len(unformatted) > 0 {
+		errs = append(errs, fmt.Errorf("%d configuration files are not formatted; run `toolbox fmt` to format them", len(unformatted)))
+	}
+	// If errs is empty, errors.Join returns nil
+	return errors.Join(errs...)
+}
@@ -0,0 +1,123 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package format
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/googleapis/mcp-toolbox/cmd/internal"
+	"github.com/spf13/cobra"
+)
+
+func invokeCommand(args []string) (string, error) {
+	parentCmd := &cobra.Command{Use: "toolbox"}
+
+	buf := new(bytes.Buffer)
+	opts := internal.NewToolboxOptions(internal.WithIOStreams(buf, buf))
+	internal.PersistentFlags(parentCmd, opts)
+
+	cmd := NewCommand(opts)
+	parentCmd.AddCommand(cmd)
+	parentCmd.SetArgs(args)
+
+	err := parentCmd.Execute()
+	return buf.String(), err
+}
+
+const unformattedConfig = `kind: tool
+name: search_users
+type: postgres-sql
+source: my-pg-source
+statement: select * from users where name = $1
+description: Search users by name.
+`
+
+const formattedConfig = `kind: tool
+name: search_users
+type: postgres-sql
+source: my-pg-source
+statement: |
+  SELECT *
+  FROM users
+  WHERE name = $1
+description: Search users by name.
+`
+
+func TestFmt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tools.yaml")
+	if err := os.WriteFile(path, []byte(unformattedConfig), 0o600); err != nil {
+		t.Fatalf("failed to write config: %s", err)
+	}
+
+	if _, err := invokeCommand([]string{"fmt", "--config", path}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read config: %s", err)
+	}
+	if string(got) != formattedConfig {
+		t.Errorf("got:\n%s\nwant:\n%s", got, formattedConfig)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("failed to stat config: %s", err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("got permission %v, want %v", info.Mode().Perm(), os.FileMode(0o600))
+	}
+}
+
+func TestFmtCheck(t *testing.T) {
+	tcs := []struct {
+		desc    string
+		content string
+		wantErr bool
+	}{
+		{desc: "unformatted", content: unformattedConfig, wantErr: true},
+		{desc: "formatted", content: formattedConfig},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "tools.yaml")
+			if err := os.WriteFile(path, []byte(tc.content), 0o644); err != nil {
+				t.Fatalf("failed to write config: %s", err)
+			}
+			out, err := invokeCommand([]string{"fmt", "--check", "--config", path})
+			if tc.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "not formatted") {
+					t.Fatalf("got error %v, want not formatted error", err)
+				}
+				if !strings.Contains(out, path+": search_users") {
+					t.Errorf("output %q does not report the unformatted tool", out)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			// --check never rewrites the file
+			got, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("failed to read config: %s", err)
+			}
+			if string(got) != tc.content {
+				t.Errorf("--check modified the file:\n%s", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package format
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	"github.com/goccy/go-yaml/ast"
+	"github.com/goccy/go-yaml/parser"
+)
+
+// FormatConfig formats the `statement` of every supported tool in a
+// configuration file, in both the flat and the nested format. Comments and
+// the layout of the rest of the file are preserved. It returns the updated
+// file and the names of the tools whose statement changed; buf is returned
+// as-is when nothing changed.
+func FormatConfig(buf []byte) ([]byte, []string, error) {
+	file, err := parser.ParseBytes(buf, parser.ParseComments)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to parse config: %w", err)
+	}
+	var changed []string
+	for _, doc := range file.Docs {
+		names, err := formatNode(doc.Body, "")
+		if err != nil {
+			return nil, nil, err
+		}
+		changed = append(changed, names...)
+	}
+	if len(changed) == 0 {
+		return buf, nil, nil
+	}
+	return []byte(file.String()), changed, nil
+}
+
+// formatNode formats the statement of the tool defined by node, if any, and
+// recurses into its values. key is the map key node was found under, which
+// is the tool name in the nested format.
+func formatNode(node ast.Node, key string) ([]string, error) {
+	values := mappingValues(node)
+	if values == nil {
+		return nil, nil
+	}
+	fields := make(map[string]*ast.MappingValueNode, len(values))
+	for _, mv := range values {
+		fields[mv.Key.GetToken().Value] = mv
+	}
+
+	var changed []string
+	if stmt, ok := fields["statement"]; ok {
+		name := key
+		if n := scalarValue(fields["name"]); n != "" {
+			name = n
+		}
+		// flat format documents declare the tool type in `type`; the nested
+		// format declares it in `kind`.
+		toolType := scalarValue(fields["type"])
+		if toolType == "" {
+			toolType = scalarValue(fields["kind"])
+		}
+		if dialect, ok := DialectForToolType(toolType); ok {
+			updated, err := formatStatement(stmt, dialect)
+			if err != nil {
+				return nil, fmt.Errorf("unable to format statement of tool %q: %w", name, err)
+			}
+			if updated {
+				changed = append(changed, name)
+			}
+		}
+	}
+	for _, mv := range values {
+		names, err := formatNode(mv.Value, mv.Key.GetToken().Value)
+		if err != nil {
+			return nil, err
+		}
+		changed = append(changed, names...)
+	}
+	return changed, nil
+}
+
+// formatStatement replaces the statement value with its formatted version,
+// reporting whether the statement changed. Multi-line statements are written
+// as literal block scalars.
+func formatStatement(mv *ast.MappingValueNode, dialect Dialect) (bool, error) {
+	var original string
+	if err := yaml.NodeToValue(mv.Value, &original); err != nil {
+		return false, fmt.Errorf("statement is not a string: %w", err)
+	}
+	formatted, err := FormatSQL(original, dialect)
+	if err != nil {
+		return false, err
+	}
+	if strings.Contains(formatted, "\n") {
+		formatted += "\n"
+	}
+	if strings.TrimRight(original, "\n") == strings.TrimRight(formatted, "\n") {
+		return false, nil
+	}
+	node, err := yaml.ValueToNode(formatted, yaml.UseLiteralStyleIfMultiline(true), yaml.Indent(2))
+	if err != nil {
+		return false, err
+	}
+	node.AddColumn(mv.Key.GetToken().Position.Column - 1)
+	mv.Value = node
+	return true, nil
+}
+
+// mappingValues returns the key-value pairs of a mapping node. A mapping
+// with a single key is parsed as a bare MappingValueNode.
+func mappingValues(node ast.Node) []*ast.MappingValueNode {
+	switch n := node.(type) {
+	case *ast.MappingNode:
+		return n.Values
+	case *ast.MappingValueNode:
+		return []*ast.MappingValueNode{n}
+	}
+	return nil
+}
+
+func scalarValue(mv *ast.MappingValueNode) string {
+	if mv == nil {
+		return ""
+	}
+	var s string
+	if err := yaml.NodeToValue(mv.Value, &s); err != nil {
+		return ""
+	}
+	return s
+}
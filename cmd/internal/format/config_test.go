@@ -0,0 +1,150 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package format
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFormatConfig(t *testing.T) {
+	tcs := []struct {
+		desc        string
+		in          string
+		want        string
+		wantChanged []string
+	}{
+		{
+			desc: "flat format",
+			in: `# my tools
+kind: tool
+name: search_users
+type: postgres-sql
+source: my-pg-source
+# keep this comment
+statement: select * from users where name = $1
+description: Search users by name.
+---
+kind: tool
+name: list_orders
+type: mysql-sql
+source: my-mysql-source
+statement: |
+  select id from orders
+  limit 10
+description: List orders.
+`,
+			want: `# my tools
+kind: tool
+name: search_users
+type: postgres-sql
+source: my-pg-source
+# keep this comment
+statement: |
+  SELECT *
+  FROM users
+  WHERE name = $1
+description: Search users by name.
+---
+kind: tool
+name: list_orders
+type: mysql-sql
+source: my-mysql-source
+statement: |
+  SELECT id
+  FROM orders
+  LIMIT 10
+description: List orders.
+`,
+			wantChanged: []string{"search_users", "list_orders"},
+		},
+		{
+			desc: "nested format",
+			in: `tools:
+  search_users:
+    kind: postgres-sql
+    source: my-pg-source
+    statement: select * from users where id = $1
+    description: Search users by id.
+`,
+			want: `tools:
+  search_users:
+    kind: postgres-sql
+    source: my-pg-source
+    statement: |
+      SELECT *
+      FROM users
+      WHERE id = $1
+    description: Search users by id.
+`,
+			wantChanged: []string{"search_users"},
+		},
+		{
+			desc: "already formatted and unsupported tools are unchanged",
+			in: `kind: tool
+name: search_users
+type: postgres-sql
+source: my-pg-source
+statement: |
+  SELECT *
+  FROM users
+description: Search users.
+---
+kind: tool
+name: search_docs
+type: spanner-sql
+source: my-spanner-source
+statement: select * from docs
+description: Search docs.
+`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, changed, err := FormatConfig([]byte(tc.in))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			want := tc.want
+			if want == "" {
+				want = tc.in
+			}
+			if diff := cmp.Diff(want, string(got)); diff != "" {
+				t.Errorf("incorrect output: diff %s", diff)
+			}
+			if diff := cmp.Diff(tc.wantChanged, changed); diff != "" {
+				t.Errorf("incorrect changed tools: diff %s", diff)
+			}
+			// formatting the output again must be a no-op
+			if _, changed, err := FormatConfig(got); err != nil || len(changed) != 0 {
+				t.Errorf("formatting is not idempotent: changed %v, err %v", changed, err)
+			}
+		})
+	}
+}
+
+func TestFormatConfigFailure(t *testing.T) {
+	in := `kind: tool
+name: broken
+type: postgres-sql
+statement: select 'abc
+`
+	_, _, err := FormatConfig([]byte(in))
+	if err == nil || !strings.Contains(err.Error(), `tool "broken"`) {
+		t.Fatalf("got error %v, want error naming the tool", err)
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package format
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/format"
+	_ "github.com/pingcap/tidb/pkg/parser/test_driver"
+)
+
+// mysqlRestoreFlags print keywords in upper case, quote every string and
+// identifier and leave out the default character set of strings.
+const mysqlRestoreFlags = format.RestoreStringSingleQuotes | format.RestoreStringEscapeBackslash |
+	format.RestoreKeyWordUppercase | format.RestoreNameBackQuotes | format.RestoreSpacesAroundBinaryOperation |
+	format.RestoreStringWithoutDefaultCharset
+
+// mysqlKeywords holds the keywords of MySQL, reserved or not.
+var mysqlKeywords = func() map[string]bool {
+	keywords := make(map[string]bool, len(parser.Keywords))
+	for _, k := range parser.Keywords {
+		keywords[k.Word] = true
+	}
+	return keywords
+}()
+
+// mysqlHasComments reports whether a MySQL statement may have comments. The
+// parser does not expose its lexer, so any comment marker counts, even
+// within a literal.
+func mysqlHasComments(sql string) bool {
+	return strings.Contains(sql, "--") || strings.Contains(sql, "#") || strings.Contains(sql, "/*")
+}
+
+// mysqlTokens parses MySQL statements and returns the tokens of their
+// restored form.
+func mysqlTokens(sql string) ([]token, error) {
+	stmts, _, err := parser.New().Parse(sql, "", "")
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse statement: %w", err)
+	}
+	var b strings.Builder
+	for i, stmt := range stmts {
+		if i > 0 {
+			b.WriteString("; ")
+		}
+		if err := stmt.Restore(format.NewRestoreCtx(mysqlRestoreFlags, &b)); err != nil {
+			return nil, fmt.Errorf("unable to print statement: %w", err)
+		}
+	}
+	return splitRestored(b.String()), nil
+}
+
+// splitRestored splits a restored statement into tokens, unquoting the
+// identifiers that need no quotes. Since the restored form quotes every
+// string and identifier, it is enough to keep quoted runs together: any other
+// word is a keyword, a function name or a number.
+func splitRestored(s string) []token {
+	var tokens []token
+	space := false
+	for i := 0; i < len(s); {
+		c := s[i]
+		start := i
+		switch {
+		case c == ' ':
+			space = true
+			i++
+			continue
+		case c == '\'' || c == '`':
+			i++
+			for i < len(s) {
+				if c == '\'' && s[i] == '\\' {
+					i += 2
+					continue
+				}
+				if s[i] == c {
+					// a doubled quote is an escaped quote
+					if i+1 < len(s) && s[i+1] == c {
+						i += 2
+						continue
+					}
+					break
+				}
+				i++
+			}
+			i = min(i+1, len(s))
+		case isWordByte(c):
+			for i < len(s) && isWordByte(s[i]) {
+				i++
+			}
+		default:
+			i++
+		}
+		text := s[start:i]
+		if c == '`' {
+			text = unquoteName(text)
+		}
+		tokens = append(tokens, token{
+			text:        text,
+			keyword:     isWordByte(c) && strings.ToUpper(text) == text && strings.ToLower(text) != text,
+			spaceBefore: space,
+		})
+		space = false
+	}
+	return tokens
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || c == '$' || c == '@' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9'
+}
+
+// unquoteName removes the quotes of an identifier that is not a keyword and
+// needs no quoting.
+func unquoteName(quoted string) string {
+	name := strings.Trim(quoted, "`")
+	if name == "" || mysqlKeywords[strings.ToUpper(name)] || '0' <= name[0] && name[0] <= '9' {
+		return quoted
+	}
+	for i := 0; i < len(name); i++ {
+		if !isWordByte(name[i]) || name[i] == '$' || name[i] == '@' {
+			return quoted
+		}
+	}
+	return name
+}
@@ -0,0 +1,72 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package format
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pganalyze/pg_query_go/v6"
+)
+
+// postgresHasComments reports whether a PostgreSQL statement has comments.
+// Statements that cannot be scanned are left for the parser to report.
+func postgresHasComments(sql string) bool {
+	scan, err := pg_query.Scan(sql)
+	if err != nil {
+		return false
+	}
+	for _, t := range scan.Tokens {
+		if t.Token == pg_query.Token_SQL_COMMENT || t.Token == pg_query.Token_C_COMMENT {
+			return true
+		}
+	}
+	return false
+}
+
+// postgresTokens parses PostgreSQL statements and returns the tokens of
+// their deparsed form.
+func postgresTokens(sql string) ([]token, error) {
+	tree, err := pg_query.Parse(sql)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse statement: %w", err)
+	}
+	stmts := make([]string, 0, len(tree.Stmts))
+	for _, stmt := range tree.Stmts {
+		s, err := pg_query.Deparse(&pg_query.ParseResult{Version: tree.Version, Stmts: []*pg_query.RawStmt{stmt}})
+		if err != nil {
+			return nil, fmt.Errorf("unable to print statement: %w", err)
+		}
+		stmts = append(stmts, s)
+	}
+	deparsed := strings.Join(stmts, "; ")
+
+	scan, err := pg_query.Scan(deparsed)
+	if err != nil {
+		return nil, fmt.Errorf("unable to scan statement: %w", err)
+	}
+	tokens := make([]token, 0, len(scan.Tokens))
+	end := int32(0)
+	for _, t := range scan.Tokens {
+		text := deparsed[t.Start:t.End]
+		tokens = append(tokens, token{
+			text:        text,
+			keyword:     t.KeywordKind != pg_query.KeywordKind_NO_KEYWORD && text == strings.ToUpper(text),
+			spaceBefore: t.Start > end,
+		})
+		end = t.End
+	}
+	return tokens, nil
+}
@@ -0,0 +1,245 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package format
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Dialect selects the parser used to format a statement.
+type Dialect string
+
+const (
+	DialectPostgres Dialect = "postgres"
+	DialectMySQL    Dialect = "mysql"
+)
+
+// toolDialects maps the tool types whose `statement` is formatted to the
+// dialect used to format it. Tools of other types are left untouched.
+var toolDialects = map[string]Dialect{
+	"postgres-sql":    DialectPostgres,
+	"cockroachdb-sql": DialectPostgres,
+	"yugabytedb-sql":  DialectPostgres,
+	"mysql-sql":       DialectMySQL,
+	"tidb-sql":        DialectMySQL,
+	"oceanbase-sql":   DialectMySQL,
+	"singlestore-sql": DialectMySQL,
+}
+
+// DialectForToolType returns the dialect for a tool type, if the tool type
+// has a formattable statement.
+func DialectForToolType(toolType string) (Dialect, bool) {
+	d, ok := toolDialects[toolType]
+	return d, ok
+}
+
+const indentUnit = "  "
+
+// templatePlaceholder names the identifier standing in for the nth template
+// action while a statement is parsed. The trailing underscore keeps the
+// placeholder of one action from being a prefix of another's.
+const templatePlaceholder = "toolbox_template_%d_"
+
+// clauseWords start a new line at the indentation of the enclosing query.
+var clauseWords = map[string]bool{
+	"SELECT": true, "FROM": true, "WHERE": true, "GROUP": true, "HAVING": true,
+	"ORDER": true, "LIMIT": true, "OFFSET": true, "VALUES": true, "SET": true,
+	"RETURNING": true, "INSERT": true, "UPDATE": true, "DELETE": true,
+	"WITH": true, "UNION": true, "INTERSECT": true, "EXCEPT": true,
+}
+
+// joinWords start a new line when they introduce a join.
+var joinWords = map[string]bool{
+	"JOIN": true, "LEFT": true, "RIGHT": true, "INNER": true, "FULL": true,
+	"CROSS": true, "NATURAL": true, "OUTER": true, "STRAIGHT_JOIN": true,
+}
+
+// token is a token of a statement printed by the parser of its dialect.
+type token struct {
+	text string
+	// keyword records whether the token is a keyword. Parsers print keywords
+	// in upper case and quote identifiers that could be mistaken for one.
+	keyword bool
+	// spaceBefore records whether the token was preceded by whitespace.
+	spaceBefore bool
+}
+
+// FormatSQL reformats a statement. The statement is parsed with the parser of
+// its dialect, github.com/pganalyze/pg_query_go for PostgreSQL and
+// github.com/pingcap/tidb/pkg/parser for MySQL, and printed back in the
+// canonical form of that parser with each clause on its own line. Template
+// actions (e.g. `{{.tableName}}`) are kept verbatim. Statements with comments
+// are returned unchanged, since neither parser keeps them. Formatting is
+// idempotent.
+func FormatSQL(sql string, dialect Dialect) (string, error) {
+	stmt, actions, err := replaceTemplateActions(sql)
+	if err != nil {
+		return "", err
+	}
+	var tokens []token
+	switch dialect {
+	case DialectPostgres:
+		if postgresHasComments(stmt) {
+			return sql, nil
+		}
+		tokens, err = postgresTokens(stmt)
+	case DialectMySQL:
+		if mysqlHasComments(stmt) {
+			return sql, nil
+		}
+		tokens, err = mysqlTokens(stmt)
+	default:
+		return "", fmt.Errorf("unsupported dialect %q", dialect)
+	}
+	if err != nil {
+		return "", err
+	}
+	return restoreTemplateActions(render(tokens), actions), nil
+}
+
+// replaceTemplateActions replaces the template actions of a statement with
+// placeholder identifiers, so that the statement can be parsed. It returns
+// the statement and the actions replaced.
+func replaceTemplateActions(sql string) (string, []string, error) {
+	var b strings.Builder
+	var actions []string
+	for {
+		start := strings.Index(sql, "{{")
+		if start < 0 {
+			b.WriteString(sql)
+			return b.String(), actions, nil
+		}
+		end := strings.Index(sql[start+2:], "}}")
+		if end < 0 {
+			return "", nil, fmt.Errorf("unterminated template action")
+		}
+		end += start + 4
+		b.WriteString(sql[:start])
+		fmt.Fprintf(&b, templatePlaceholder, len(actions))
+		actions = append(actions, sql[start:end])
+		sql = sql[end:]
+	}
+}
+
+// restoreTemplateActions replaces the placeholders of a formatted statement
+// with the template actions they stand for. Placeholders printed as quoted
+// identifiers lose their quotes.
+func restoreTemplateActions(sql string, actions []string) string {
+	for i, action := range actions {
+		placeholder := fmt.Sprintf(templatePlaceholder, i)
+		for _, quote := range []string{"`", `"`} {
+			sql = strings.ReplaceAll(sql, quote+placeholder+quote, action)
+		}
+		sql = strings.ReplaceAll(sql, placeholder, action)
+	}
+	return sql
+}
+
+// frame tracks a parenthesized group. Query frames hold a subquery and
+// indent its clauses; other frames (function calls, lists) do not.
+type frame struct {
+	query  bool
+	indent int
+}
+
+func render(tokens []token) string {
+	var b strings.Builder
+	stack := []frame{{query: true}}
+	betweenPending := false
+	caseDepth := 0
+	newline := func(indent int) {
+		b.WriteString("\n")
+		b.WriteString(strings.Repeat(indentUnit, indent))
+	}
+
+	for i, tok := range tokens {
+		cur := stack[len(stack)-1]
+		var prev, next *token
+		if i > 0 {
+			prev = &tokens[i-1]
+		}
+		if i+1 < len(tokens) {
+			next = &tokens[i+1]
+		}
+
+		switch {
+		case prev == nil:
+		case tok.text == ")" && cur.query && len(stack) > 1:
+			newline(stack[len(stack)-2].indent)
+		case cur.query && tok.keyword && breaksBefore(tok.text, prev, next):
+			newline(cur.indent)
+		case cur.query && (tok.text == "AND" || tok.text == "OR") && tok.keyword && !betweenPending && caseDepth == 0:
+			newline(cur.indent + 1)
+		case prev.text == ";":
+			newline(0)
+		case tok.spaceBefore || prev.text == ",":
+			b.WriteString(" ")
+		}
+		b.WriteString(tok.text)
+
+		switch {
+		case tok.keyword && tok.text == "CASE":
+			caseDepth++
+		case tok.keyword && tok.text == "END" && caseDepth > 0:
+			caseDepth--
+		case tok.keyword && tok.text == "BETWEEN":
+			betweenPending = true
+		case tok.keyword && tok.text == "AND":
+			betweenPending = false
+		case tok.text == "(":
+			// a parenthesized subquery indents its clauses one level deeper
+			if next != nil && next.keyword && startsQuery(next.text) {
+				stack = append(stack, frame{query: true, indent: cur.indent + 1})
+			} else {
+				stack = append(stack, frame{indent: cur.indent})
+			}
+		case tok.text == ")" && len(stack) > 1:
+			stack = stack[:len(stack)-1]
+		}
+	}
+	return b.String()
+}
+
+func startsQuery(keyword string) bool {
+	return keyword == "SELECT" || keyword == "WITH"
+}
+
+// breaksBefore reports whether a keyword starts a new clause line.
+func breaksBefore(keyword string, prev, next *token) bool {
+	prevKeyword := ""
+	if prev != nil && prev.keyword {
+		prevKeyword = prev.text
+	}
+	switch {
+	case keyword == "SELECT" && (prevKeyword == "ALL" || prevKeyword == "UNION" || prevKeyword == "INTERSECT" || prevKeyword == "EXCEPT"):
+		return true
+	case clauseWords[keyword]:
+		// DELETE FROM, IS DISTINCT FROM, FOR UPDATE, DO UPDATE and
+		// CHARACTER SET stay on one line.
+		switch prevKeyword {
+		case "DELETE", "DISTINCT", "FOR", "DO", "CHARACTER":
+			return false
+		}
+		return true
+	case joinWords[keyword]:
+		if joinWords[prevKeyword] {
+			return false
+		}
+		// LEFT(...) and RIGHT(...) are functions in MySQL.
+		return next == nil || next.text != "(" || next.spaceBefore
+	}
+	return false
+}
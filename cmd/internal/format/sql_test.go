@@ -0,0 +1,160 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package format
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFormatSQL(t *testing.T) {
+	tcs := []struct {
+		desc    string
+		dialect Dialect
+		in      string
+		want    string
+	}{
+		{
+			desc:    "simple select",
+			dialect: DialectPostgres,
+			in:      "select id, name from users where country = $1 and active order by name limit 10;",
+			want: `SELECT id, name
+FROM users
+WHERE country = $1
+  AND active
+ORDER BY name
+LIMIT 10`,
+		},
+		{
+			desc:    "collapses whitespace",
+			dialect: DialectMySQL,
+			in:      "SELECT  a,\n\t b\nFROM   t   WHERE x=?",
+			want: `SELECT a, b
+FROM t
+WHERE x = ?`,
+		},
+		{
+			desc:    "joins",
+			dialect: DialectPostgres,
+			in:      "select * from a left outer join b on a.id = b.id join c using (id)",
+			want: `SELECT *
+FROM a
+LEFT JOIN b ON a.id = b.id
+JOIN c USING (id)`,
+		},
+		{
+			desc:    "subquery",
+			dialect: DialectPostgres,
+			in:      "select * from t where id in (select id from u where x = 1) and y between 1 and 2",
+			want: `SELECT *
+FROM t
+WHERE id IN (
+  SELECT id
+  FROM u
+  WHERE x = 1
+)
+  AND y BETWEEN 1 AND 2`,
+		},
+		{
+			desc:    "literals and templates are kept verbatim",
+			dialect: DialectPostgres,
+			in:      "select 'from where', \"Select\" from {{.tableName}} where note = 'and or' and x::text = '{{.prefix}}%'",
+			want: `SELECT 'from where', "Select"
+FROM {{.tableName}}
+WHERE note = 'and or'
+  AND x::text = '{{.prefix}}%'`,
+		},
+		{
+			desc:    "mysql functions and quoting",
+			dialect: DialectMySQL,
+			in:      "select left(name, 3), `order` from `{{.table}}` where s = \"it's\" and t.limit > 1",
+			want:    "SELECT LEFT(name, 3), `order`\nFROM {{.table}}\nWHERE s = 'it''s'\n  AND t.`limit` > 1",
+		},
+		{
+			desc:    "statements with comments are unchanged",
+			dialect: DialectPostgres,
+			in:      "select id -- the id\nfrom t",
+			want:    "select id -- the id\nfrom t",
+		},
+		{
+			desc:    "mysql statements with comments are unchanged",
+			dialect: DialectMySQL,
+			in:      "select id # the id\nfrom t",
+			want:    "select id # the id\nfrom t",
+		},
+		{
+			desc:    "functions do not break clauses",
+			dialect: DialectPostgres,
+			in:      "select extract(year from d), row_number() over (partition by a order by b) from t",
+			want: `SELECT extract ('year' FROM d), row_number() OVER (PARTITION BY a ORDER BY b)
+FROM t`,
+		},
+		{
+			desc:    "insert and upsert",
+			dialect: DialectPostgres,
+			in:      "insert into t (a, b) values ($1, $2) on conflict (a) do update set b = excluded.b returning a",
+			want: `INSERT INTO t (a, b)
+VALUES ($1, $2) ON CONFLICT (a) DO UPDATE
+SET b = excluded.b
+RETURNING a`,
+		},
+		{
+			desc:    "case expression",
+			dialect: DialectPostgres,
+			in:      "select case when a and b then 1 else 0 end from t",
+			want: `SELECT CASE WHEN a AND b THEN 1 ELSE 0 END
+FROM t`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := FormatSQL(tc.in, tc.dialect)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != tc.want {
+				t.Fatalf("got:\n%s\nwant:\n%s", got, tc.want)
+			}
+			again, err := FormatSQL(got, tc.dialect)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if again != got {
+				t.Errorf("formatting is not idempotent, got:\n%s", again)
+			}
+		})
+	}
+}
+
+func TestFormatSQLFailure(t *testing.T) {
+	tcs := []struct {
+		desc    string
+		in      string
+		wantErr string
+	}{
+		{desc: "unterminated string", in: "select 'abc", wantErr: "unterminated quoted string"},
+		{desc: "unterminated comment", in: "select /* abc", wantErr: "unterminated /* comment"},
+		{desc: "unterminated template", in: "select * from {{.t", wantErr: "unterminated template action"},
+		{desc: "syntax error", in: "select * form t", wantErr: "unable to parse statement"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := FormatSQL(tc.in, DialectPostgres)
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("got error %v, want error containing %q", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -36,13 +36,16 @@ import (
 	_ "github.com/googleapis/mcp-toolbox/internal/sources/cloudstorage"
 	_ "github.com/googleapis/mcp-toolbox/internal/sources/cockroachdb"
 	_ "github.com/googleapis/mcp-toolbox/internal/sources/couchbase"
+	_ "github.com/googleapis/mcp-toolbox/internal/sources/dataform"
 	_ "github.com/googleapis/mcp-toolbox/internal/sources/datalineage"
 	_ "github.com/googleapis/mcp-toolbox/internal/sources/dataplex"
 	_ "github.com/googleapis/mcp-toolbox/internal/sources/dataproc"
 	_ "github.com/googleapis/mcp-toolbox/internal/sources/dgraph"
+	_ "github.com/googleapis/mcp-toolbox/internal/sources/duckdb"
 	_ "github.com/googleapis/mcp-toolbox/internal/sources/elasticsearch"
 	_ "github.com/googleapis/mcp-toolbox/internal/sources/firebird"
 	_ "github.com/googleapis/mcp-toolbox/internal/sources/firestore"
+	_ "github.com/googleapis/mcp-toolbox/internal/sources/hana"
 	_ "github.com/googleapis/mcp-toolbox/internal/sources/http"
 	_ "github.com/googleapis/mcp-toolbox/internal/sources/looker"
 	_ "github.com/googleapis/mcp-toolbox/internal/sources/mindsdb"
@@ -52,6 +55,7 @@ import (
 	_ "github.com/googleapis/mcp-toolbox/internal/sources/neo4j"
 	_ "github.com/googleapis/mcp-toolbox/internal/sources/oceanbase"
 	_ "github.com/googleapis/mcp-toolbox/internal/sources/oracle"
+	_ "github.com/googleapis/mcp-toolbox/internal/sources/plugin"
 	_ "github.com/googleapis/mcp-toolbox/internal/sources/postgres"
 	_ "github.com/googleapis/mcp-toolbox/internal/sources/redis"
 	_ "github.com/googleapis/mcp-toolbox/internal/sources/scylladb"
@@ -80,15 +84,20 @@ import (
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/arcadedb/arcadedbexecutecypher"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/arcadedb/arcadedbexecutesql"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/bigquery/bigqueryanalyzecontribution"
+	_ "github.com/googleapis/mcp-toolbox/internal/tools/bigquery/bigquerycanceljob"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/bigquery/bigqueryconversationalanalytics"
+	_ "github.com/googleapis/mcp-toolbox/internal/tools/bigquery/bigquerydryrun"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/bigquery/bigqueryexecutesql"
+	_ "github.com/googleapis/mcp-toolbox/internal/tools/bigquery/bigqueryexport"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/bigquery/bigqueryforecast"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/bigquery/bigquerygetdatasetinfo"
+	_ "github.com/googleapis/mcp-toolbox/internal/tools/bigquery/bigquerygetjob"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/bigquery/bigquerygettableinfo"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/bigquery/bigquerylistdatasetids"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/bigquery/bigquerylisttableids"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/bigquery/bigquerysearchcatalog"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/bigquery/bigquerysql"
+	_ "github.com/googleapis/mcp-toolbox/internal/tools/bigquery/bigquerysubmitjob"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/bigtable"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/cassandra/cassandracql"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/clickhouse/clickhouseexecutesql"
@@ -160,7 +169,9 @@ import (
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/conversationalanalytics/conversationalanalyticsgetdataagentinfo"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/conversationalanalytics/conversationalanalyticslistaccessibledataagents"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/couchbase"
+	_ "github.com/googleapis/mcp-toolbox/internal/tools/couchbase/couchbasen1ql"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/dataform/dataformcompilelocal"
+	_ "github.com/googleapis/mcp-toolbox/internal/tools/dataform/dataforminvokeworkflow"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/datalineage/datalineagesearchlineage"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/dataplex/dataplexcheckdataquality"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/dataplex/dataplexcreatedataasset"
@@ -190,8 +201,10 @@ import (
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/dataproc/dataproclistclusters"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/dataproc/dataproclistjobs"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/dgraph"
+	_ "github.com/googleapis/mcp-toolbox/internal/tools/duckdb/duckdbsql"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/elasticsearch/elasticsearchesql"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/elasticsearch/elasticsearchexecuteesql"
+	_ "github.com/googleapis/mcp-toolbox/internal/tools/elasticsearch/elasticsearchquery"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/firebird/firebirdexecutesql"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/firebird/firebirdsql"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/firestore/firestoreadddocuments"
@@ -201,9 +214,12 @@ import (
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/firestore/firestorelistcollections"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/firestore/firestorequery"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/firestore/firestorequerycollection"
+	_ "github.com/googleapis/mcp-toolbox/internal/tools/firestore/firestorestructuredquery"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/firestore/firestoreupdatedocument"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/firestore/firestorevalidaterules"
+	_ "github.com/googleapis/mcp-toolbox/internal/tools/hana/hanasql"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/http"
+	_ "github.com/googleapis/mcp-toolbox/internal/tools/http/rest"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/looker/lookeradddashboardelement"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/looker/lookeradddashboardfilter"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/looker/lookerconversationalanalytics"
@@ -282,12 +298,16 @@ import (
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/neo4j/neo4jcypher"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/neo4j/neo4jexecutecypher"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/neo4j/neo4jschema"
+	_ "github.com/googleapis/mcp-toolbox/internal/tools/neo4j/neo4jtransaction"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/oceanbase/oceanbaseexecutesql"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/oceanbase/oceanbasesql"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/oracle/oracleexecutesql"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/oracle/oraclesql"
+	_ "github.com/googleapis/mcp-toolbox/internal/tools/plugin"
+	_ "github.com/googleapis/mcp-toolbox/internal/tools/postgres/postgrescopyinsert"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/postgres/postgresdatabaseoverview"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/postgres/postgresexecutesql"
+	_ "github.com/googleapis/mcp-toolbox/internal/tools/postgres/postgresexport"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/postgres/postgresgetcolumncardinality"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/postgres/postgreslistactivequeries"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/postgres/postgreslistavailableextensions"
@@ -310,7 +330,11 @@ import (
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/postgres/postgreslongrunningtransactions"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/postgres/postgresreplicationstats"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/postgres/postgressql"
+	_ "github.com/googleapis/mcp-toolbox/internal/tools/postgres/postgrestransaction"
+	_ "github.com/googleapis/mcp-toolbox/internal/tools/postgres/postgresvectorsearch"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/redis"
+	_ "github.com/googleapis/mcp-toolbox/internal/tools/redis/redisget"
+	_ "github.com/googleapis/mcp-toolbox/internal/tools/redis/redisset"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/scylladb/scyllacql"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/serverlessspark/serverlesssparkcancelbatch"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/serverlessspark/serverlesssparkcreatepysparkbatch"
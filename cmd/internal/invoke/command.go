@@ -18,6 +18,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"os"
 	"strings"
 
 	"github.com/googleapis/mcp-toolbox/cmd/internal"
@@ -28,25 +30,74 @@ import (
 	"github.com/spf13/cobra"
 )
 
+// invokeCmd is the command for executing a tool.
+type invokeCmd struct {
+	*cobra.Command
+	tool        string
+	params      string
+	exitOnError bool
+}
+
 func NewCommand(opts *internal.ToolboxOptions) *cobra.Command {
-	cmd := &cobra.Command{
-		Use:   "invoke <tool-name> [params]",
+	cmd := &invokeCmd{}
+	cmd.Command = &cobra.Command{
+		Use:   "invoke [tool-name] [params]",
 		Short: "Execute a tool directly",
 		Long: `Execute a tool directly with parameters.
-Params must be a JSON string.
+Params must be a JSON string. Without params, they are read from stdin
+unless it is a terminal.
 Example:
-  toolbox invoke my-tool '{"param1": "value1"}'`,
-		Args: cobra.MinimumNArgs(1),
+  toolbox invoke my-tool '{"param1": "value1"}'
+  echo '{"param1": "value1"}' | toolbox invoke --tool my-tool`,
+		Args: cobra.MaximumNArgs(2),
 		RunE: func(c *cobra.Command, args []string) error {
-			return runInvoke(c, args, opts)
+			return runInvoke(cmd, args, opts)
 		},
 	}
 	flags := cmd.Flags()
-	internal.ConfigFileFlags(cmd, flags, opts)
-	return cmd
+	internal.ConfigFileFlags(cmd.Command, flags, opts)
+	flags.StringVar(&cmd.tool, "tool", "", "Name of the tool to execute, instead of the first argument.")
+	flags.StringVar(&cmd.params, "params", "", "Parameters of the tool as a JSON string, instead of the second argument.")
+	flags.BoolVar(&cmd.exitOnError, "exit-on-error", false, "Exit with an error if the tool fails. By default, the error is printed as JSON.")
+	return cmd.Command
+}
+
+// readStdin returns the content of in, or "" if in is a terminal.
+func readStdin(in io.Reader) (string, error) {
+	if f, ok := in.(*os.File); ok {
+		info, err := f.Stat()
+		if err != nil || info.Mode()&os.ModeCharDevice != 0 {
+			return "", nil
+		}
+	}
+	b, err := io.ReadAll(in)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(b)), nil
 }
 
-func runInvoke(cmd *cobra.Command, args []string, opts *internal.ToolboxOptions) error {
+func runInvoke(cmd *invokeCmd, args []string, opts *internal.ToolboxOptions) error {
+	toolName, paramsInput := cmd.tool, cmd.params
+	if toolName == "" {
+		if len(args) == 0 {
+			return fmt.Errorf("a tool name is required, as the first argument or with --tool")
+		}
+		toolName, args = args[0], args[1:]
+	}
+	if len(args) > 0 {
+		if paramsInput != "" {
+			return fmt.Errorf("params cannot be both an argument and set with --params")
+		}
+		paramsInput = args[0]
+	}
+	if paramsInput == "" && !cmd.Flags().Changed("params") && opts.IOStreams.In != nil {
+		var err error
+		if paramsInput, err = readStdin(opts.IOStreams.In); err != nil {
+			return fmt.Errorf("unable to read params from stdin: %w", err)
+		}
+	}
+
 	ctx, cancel := context.WithCancel(cmd.Context())
 	defer cancel()
 
@@ -64,17 +115,16 @@ func runInvoke(cmd *cobra.Command, args []string, opts *internal.ToolboxOptions)
 	}
 
 	// Initialize Resources
-	sourcesMap, authServicesMap, embeddingModelsMap, toolsMap, toolsetsMap, promptsMap, promptsetsMap, err := server.InitializeConfigs(ctx, opts.Cfg)
+	sourcesMap, authServicesMap, embeddingModelsMap, toolsMap, toolsetsMap, promptsMap, promptsetsMap, resourcesMap, err := server.InitializeConfigs(ctx, opts.Cfg)
 	if err != nil {
 		errMsg := fmt.Errorf("failed to initialize resources: %w", err)
 		opts.Logger.ErrorContext(ctx, errMsg.Error())
 		return errMsg
 	}
 
-	primitiveMgr := primitives.NewPrimitiveManager(sourcesMap, authServicesMap, embeddingModelsMap, toolsMap, toolsetsMap, promptsMap, promptsetsMap)
+	primitiveMgr := primitives.NewPrimitiveManager(sourcesMap, authServicesMap, embeddingModelsMap, toolsMap, toolsetsMap, promptsMap, promptsetsMap, resourcesMap)
 
 	// Execute Tool
-	toolName := args[0]
 	tool, ok := primitiveMgr.GetTool(toolName)
 	if !ok {
 		errMsg := fmt.Errorf("tool %q not found", toolName)
@@ -82,11 +132,6 @@ func runInvoke(cmd *cobra.Command, args []string, opts *internal.ToolboxOptions)
 		return errMsg
 	}
 
-	var paramsInput string
-	if len(args) > 1 {
-		paramsInput = args[1]
-	}
-
 	params := make(map[string]any)
 	if paramsInput != "" {
 		if err := util.DecodeJSON(strings.NewReader(paramsInput), &params); err != nil {
@@ -134,7 +179,10 @@ func runInvoke(cmd *cobra.Command, args []string, opts *internal.ToolboxOptions)
 	if err != nil {
 		errMsg := fmt.Errorf("tool execution failed: %w", err)
 		opts.Logger.ErrorContext(ctx, errMsg.Error())
-		return errMsg
+		if cmd.exitOnError {
+			return errMsg
+		}
+		result = map[string]string{"error": errMsg.Error()}
 	}
 
 	// Print Result
@@ -30,10 +30,14 @@ import (
 )
 
 func invokeCommand(args []string) (string, error) {
+	return invokeCommandWithInput(args, "")
+}
+
+func invokeCommandWithInput(args []string, in string) (string, error) {
 	parentCmd := &cobra.Command{Use: "toolbox"}
 
 	buf := new(bytes.Buffer)
-	opts := internal.NewToolboxOptions(internal.WithIOStreams(buf, buf))
+	opts := internal.NewToolboxOptions(internal.WithIOStreams(buf, buf), internal.WithInput(strings.NewReader(in)))
 	internal.PersistentFlags(parentCmd, opts)
 
 	cmd := NewCommand(opts)
@@ -77,6 +81,11 @@ tools:
       - name: value
         type: integer
         description: int value
+  failing-tool:
+    kind: sqlite-sql
+    source: my-sqlite
+    description: "failing tool"
+    statement: "SELECT * FROM missing_table"
 `
 
 	toolsFilePath := filepath.Join(tmpDir, "tools.yaml")
@@ -87,6 +96,7 @@ tools:
 	tcs := []struct {
 		desc    string
 		args    []string
+		stdin   string
 		want    string
 		wantErr bool
 		errStr  string
@@ -106,6 +116,47 @@ tools:
 			args: []string{"invoke", "int-tool", `{"value": 42}`, "--tools-file", toolsFilePath},
 			want: `"val": 42`,
 		},
+		{
+			desc: "success - tool and params flags",
+			args: []string{"invoke", "--tool", "echo-tool", "--params", `{"message": "flags"}`, "--config", toolsFilePath},
+			want: `"msg": "flags"`,
+		},
+		{
+			desc:  "success - params from stdin",
+			args:  []string{"invoke", "--tool", "int-tool", "--config", toolsFilePath},
+			stdin: `{"value": 7}`,
+			want:  `"val": 7`,
+		},
+		{
+			desc:  "success - params argument over stdin",
+			args:  []string{"invoke", "echo-tool", `{"message": "arg"}`, "--config", toolsFilePath},
+			stdin: `{"message": "stdin"}`,
+			want:  `"msg": "arg"`,
+		},
+		{
+			desc: "success - tool error printed as JSON",
+			args: []string{"invoke", "failing-tool", "--config", toolsFilePath},
+			want: `"error": "tool execution failed: `,
+		},
+		{
+			desc:    "error - tool error with --exit-on-error",
+			args:    []string{"invoke", "failing-tool", "--exit-on-error", "--config", toolsFilePath},
+			wantErr: true,
+			errStr:  "tool execution failed",
+		},
+		{
+			desc:    "error - missing tool name",
+			args:    []string{"invoke", "--config", toolsFilePath},
+			wantErr: true,
+			errStr:  "a tool name is required",
+		},
+		{
+			desc:    "error - invalid JSON from stdin",
+			args:    []string{"invoke", "--tool", "echo-tool", "--config", toolsFilePath},
+			stdin:   "invalid-json",
+			wantErr: true,
+			errStr:  `params must be a valid JSON string`,
+		},
 		{
 			desc:    "error - tool not found",
 			args:    []string{"invoke", "non-existent", "--config", toolsFilePath},
@@ -122,7 +173,7 @@ tools:
 
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
-			got, err := invokeCommand(tc.args)
+			got, err := invokeCommandWithInput(tc.args, tc.stdin)
 			if (err != nil) != tc.wantErr {
 				t.Fatalf("got error %v, wantErr %v", err, tc.wantErr)
 			}
@@ -0,0 +1,263 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goccy/go-yaml"
+)
+
+// toolboxToolsPath is the API path of the ToolboxTool custom resources of a
+// namespace.
+const toolboxToolsPath = "/apis/toolbox.googleapis.com/v1alpha1/namespaces/%s/toolboxtools"
+
+// serviceAccountDir holds the credentials of the service account of the pod.
+const serviceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+
+// k8sAPIClient calls the Kubernetes API server of the cluster the server runs
+// in.
+type k8sAPIClient struct {
+	server    string
+	tokenFile string
+	client    *http.Client
+}
+
+// k8sClient returns the client of the Kubernetes API server, created on first
+// use from the service account of the pod.
+var k8sClient = sync.OnceValues(newInClusterK8sClient)
+
+func newInClusterK8sClient() (*k8sAPIClient, error) {
+	host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+	if host == "" || port == "" {
+		return nil, fmt.Errorf("k8s:// configs require running in a Kubernetes cluster: KUBERNETES_SERVICE_HOST and KUBERNETES_SERVICE_PORT are not set")
+	}
+	ca, err := os.ReadFile(filepath.Join(serviceAccountDir, "ca.crt"))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the CA certificate of the cluster: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, fmt.Errorf("invalid CA certificate in %s", filepath.Join(serviceAccountDir, "ca.crt"))
+	}
+	return &k8sAPIClient{
+		server:    "https://" + net.JoinHostPort(host, port),
+		tokenFile: filepath.Join(serviceAccountDir, "token"),
+		client: &http.Client{
+			Timeout:   30 * time.Second,
+			Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}},
+		},
+	}, nil
+}
+
+// get fetches path from the API server and decodes its JSON response into v.
+func (c *k8sAPIClient) get(ctx context.Context, path string, query url.Values, v any) error {
+	u := c.server + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	// the token is read on every request since projected tokens are rotated
+	token, err := os.ReadFile(c.tokenFile)
+	if err != nil {
+		return fmt.Errorf("unable to read the service account token: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	req.Header.Set("Accept", "application/json")
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	buf, err := readRemoteConfig(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s: %s", resp.Status, bytes.TrimSpace(buf))
+	}
+	return json.Unmarshal(buf, v)
+}
+
+// k8sObject is the part of a ConfigMap or ToolboxTool read by the server.
+type k8sObject struct {
+	Metadata struct {
+		Name string `json:"name"`
+	} `json:"metadata"`
+	Data map[string]string `json:"data"`
+	Spec map[string]any    `json:"spec"`
+}
+
+// k8sConfigRef is a parsed k8s:// URL.
+type k8sConfigRef struct {
+	namespace string
+	// resource is "configmaps" or "toolboxtools".
+	resource string
+	// name is the name of a single ConfigMap, or empty to list them.
+	name          string
+	labelSelector string
+}
+
+// parseK8sConfigURL parses a k8s:// URL of the form
+// k8s://NAMESPACE/configmaps/NAME, k8s://NAMESPACE/configmaps?labelSelector=SELECTOR
+// or k8s://NAMESPACE/toolboxtools[?labelSelector=SELECTOR].
+func parseK8sConfigURL(raw string) (k8sConfigRef, error) {
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme != "k8s" {
+		return k8sConfigRef{}, fmt.Errorf("invalid Kubernetes config URL %q", raw)
+	}
+	ref := k8sConfigRef{namespace: u.Host, labelSelector: u.Query().Get("labelSelector")}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	switch {
+	case len(parts) == 2 && parts[0] == "configmaps" && parts[1] != "":
+		ref.resource, ref.name = parts[0], parts[1]
+		if ref.labelSelector != "" {
+			return k8sConfigRef{}, fmt.Errorf("invalid Kubernetes config URL %q: labelSelector cannot be used with the name of a ConfigMap", raw)
+		}
+	case len(parts) == 1 && parts[0] == "configmaps":
+		ref.resource = parts[0]
+		if ref.labelSelector == "" {
+			return k8sConfigRef{}, fmt.Errorf("invalid Kubernetes config URL %q: listing ConfigMaps requires a labelSelector", raw)
+		}
+	case len(parts) == 1 && parts[0] == "toolboxtools":
+		ref.resource = parts[0]
+	default:
+		return k8sConfigRef{}, fmt.Errorf("invalid Kubernetes config URL %q, expected k8s://NAMESPACE/configmaps/NAME, k8s://NAMESPACE/configmaps?labelSelector=SELECTOR or k8s://NAMESPACE/toolboxtools", raw)
+	}
+	if ref.namespace == "" {
+		return k8sConfigRef{}, fmt.Errorf("invalid Kubernetes config URL %q: missing namespace", raw)
+	}
+	return ref, nil
+}
+
+// fetchK8sConfig builds a configuration file from the ConfigMaps or
+// ToolboxTool resources of a k8s:// URL. Its ETag is the hash of the built
+// file, since a list of resources has no ETag of its own.
+func fetchK8sConfig(ctx context.Context, rawURL, etag string) ([]byte, string, bool, error) {
+	ref, err := parseK8sConfigURL(rawURL)
+	if err != nil {
+		return nil, "", false, err
+	}
+	client, err := k8sClient()
+	if err != nil {
+		return nil, "", false, err
+	}
+	var objects []k8sObject
+	query := url.Values{}
+	if ref.labelSelector != "" {
+		query.Set("labelSelector", ref.labelSelector)
+	}
+	switch {
+	case ref.name != "":
+		var obj k8sObject
+		err = client.get(ctx, fmt.Sprintf("/api/v1/namespaces/%s/configmaps/%s", url.PathEscape(ref.namespace), url.PathEscape(ref.name)), nil, &obj)
+		objects = []k8sObject{obj}
+	case ref.resource == "configmaps":
+		var list struct{ Items []k8sObject }
+		err = client.get(ctx, fmt.Sprintf("/api/v1/namespaces/%s/configmaps", url.PathEscape(ref.namespace)), query, &list)
+		objects = list.Items
+	default:
+		var list struct{ Items []k8sObject }
+		err = client.get(ctx, fmt.Sprintf(toolboxToolsPath, url.PathEscape(ref.namespace)), query, &list)
+		objects = list.Items
+	}
+	if err != nil {
+		return nil, "", false, fmt.Errorf("unable to fetch %q: %w", rawURL, err)
+	}
+
+	var buf []byte
+	if ref.resource == "configmaps" {
+		buf = configMapsConfig(objects)
+	} else {
+		buf, err = toolboxToolsConfig(objects)
+		if err != nil {
+			return nil, "", false, fmt.Errorf("unable to read %q: %w", rawURL, err)
+		}
+	}
+	if len(buf) > maxRemoteConfigBytes {
+		return nil, "", false, fmt.Errorf("unable to read %q: config exceeds %d bytes", rawURL, maxRemoteConfigBytes)
+	}
+	sum := sha256.Sum256(buf)
+	newETag := "sha256:" + hex.EncodeToString(sum[:])
+	return buf, newETag, newETag != etag, nil
+}
+
+// configMapsConfig joins the .yaml and .yml keys of the ConfigMaps, ordered
+// by the names of the ConfigMaps and of their keys, into a single
+// configuration file.
+func configMapsConfig(objects []k8sObject) []byte {
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Metadata.Name < objects[j].Metadata.Name })
+	var docs []string
+	for _, obj := range objects {
+		keys := make([]string, 0, len(obj.Data))
+		for k := range obj.Data {
+			if strings.HasSuffix(k, ".yaml") || strings.HasSuffix(k, ".yml") {
+				keys = append(keys, k)
+			}
+		}
+		slices.Sort(keys)
+		for _, k := range keys {
+			docs = append(docs, strings.TrimSpace(obj.Data[k]))
+		}
+	}
+	return []byte(strings.Join(docs, "\n---\n"))
+}
+
+// toolboxToolsConfig converts ToolboxTool resources, ordered by name, into
+// tool documents named after their resources, with the fields of their spec.
+func toolboxToolsConfig(objects []k8sObject) ([]byte, error) {
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Metadata.Name < objects[j].Metadata.Name })
+	var docs []string
+	for _, obj := range objects {
+		doc := make(map[string]any, len(obj.Spec)+2)
+		for k, v := range obj.Spec {
+			doc[k] = v
+		}
+		if _, ok := doc["kind"]; ok {
+			return nil, fmt.Errorf("ToolboxTool %q: spec cannot set kind", obj.Metadata.Name)
+		}
+		if _, ok := doc["name"]; ok {
+			return nil, fmt.Errorf("ToolboxTool %q: spec cannot set name, the tool is named after the resource", obj.Metadata.Name)
+		}
+		doc["kind"] = "tool"
+		doc["name"] = obj.Metadata.Name
+		out, err := yaml.Marshal(doc)
+		if err != nil {
+			return nil, fmt.Errorf("ToolboxTool %q: %w", obj.Metadata.Name, err)
+		}
+		docs = append(docs, strings.TrimSpace(string(out)))
+	}
+	return []byte(strings.Join(docs, "\n---\n")), nil
+}
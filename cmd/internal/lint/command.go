@@ -0,0 +1,111 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/googleapis/mcp-toolbox/cmd/internal"
+	"github.com/googleapis/mcp-toolbox/internal/server"
+	"github.com/spf13/cobra"
+)
+
+// lintCmd is the command for linting tool configurations.
+type lintCmd struct {
+	*cobra.Command
+	checkLLMCompat bool
+	target         string
+}
+
+// NewCommand creates a new Command.
+func NewCommand(opts *internal.ToolboxOptions) *cobra.Command {
+	cmd := &lintCmd{}
+	cmd.Command = &cobra.Command{
+		Use:   "lint",
+		Short: "Lint tool configurations",
+		Long: `Lint tool configurations, reporting every violation and exiting with an
+error if any is found. With --check-llm-compat, tool definitions are checked
+against the limits of the LLM provider of --target.
+Example:
+  toolbox lint --config tools.yaml --check-llm-compat --target openai`,
+		Args: cobra.NoArgs,
+		RunE: func(c *cobra.Command, args []string) error {
+			return run(cmd, opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	internal.ConfigFileFlags(cmd.Command, flags, opts)
+	flags.BoolVar(&cmd.checkLLMCompat, "check-llm-compat", false, "Check tool definitions against the limits of the LLM provider of --target.")
+	flags.StringVar(&cmd.target, "target", "openai", fmt.Sprintf("LLM provider to check tool definitions against. One of: %s.", strings.Join(targetNames(), ", ")))
+	return cmd.Command
+}
+
+func run(cmd *lintCmd, opts *internal.ToolboxOptions) error {
+	target, ok := llmTargets[cmd.target]
+	if !ok {
+		return fmt.Errorf("invalid --target %q, must be one of: %s", cmd.target, strings.Join(targetNames(), ", "))
+	}
+
+	ctx, cancel := context.WithCancel(cmd.Context())
+	defer cancel()
+
+	ctx, shutdown, err := opts.Setup(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = shutdown(ctx)
+	}()
+
+	// lint runs offline, so unset environment variables of the sources
+	// resolve to "".
+	parser := internal.ConfigParser{AllowMissingEnvVars: true}
+	if _, err := opts.LoadConfig(ctx, &parser); err != nil {
+		return err
+	}
+
+	toolsMap, _, err := server.InitializeOfflineConfigs(ctx, opts.Cfg)
+	if err != nil {
+		errMsg := fmt.Errorf("failed to initialize resources: %w", err)
+		opts.Logger.ErrorContext(ctx, errMsg.Error())
+		return errMsg
+	}
+
+	if !cmd.checkLLMCompat {
+		return nil
+	}
+
+	names := make([]string, 0, len(toolsMap))
+	for name := range toolsMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var violations []string
+	for _, name := range names {
+		violations = append(violations, checkLLMCompat(target, name, toolsMap[name])...)
+	}
+	for _, v := range violations {
+		fmt.Fprintln(opts.IOStreams.Out, v)
+	}
+	if len(violations) > 0 {
+		return fmt.Errorf("found %d violations of %s limits in %d tools", len(violations), cmd.target, len(names))
+	}
+	return nil
+}
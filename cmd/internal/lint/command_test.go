@@ -0,0 +1,156 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/googleapis/mcp-toolbox/cmd/internal"
+	_ "github.com/googleapis/mcp-toolbox/internal/sources/sqlite"
+	_ "github.com/googleapis/mcp-toolbox/internal/tools/sqlite/sqlitesql"
+	"github.com/spf13/cobra"
+)
+
+func invokeCommand(args []string) (string, error) {
+	parentCmd := &cobra.Command{
+		Use:           "toolbox",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	buf := new(bytes.Buffer)
+	opts := internal.NewToolboxOptions(internal.WithIOStreams(buf, buf))
+	internal.PersistentFlags(parentCmd, opts)
+
+	cmd := NewCommand(opts)
+	parentCmd.AddCommand(cmd)
+	// INFO logs share the output stream and list every tool name.
+	parentCmd.SetArgs(append([]string{"--log-level", "ERROR"}, args...))
+
+	err := parentCmd.Execute()
+	return buf.String(), err
+}
+
+const toolsFileContent = `
+kind: source
+name: my-sqlite
+type: sqlite
+database: ":memory:"
+---
+kind: tool
+name: search-users
+type: sqlite-sql
+source: my-sqlite
+description: search users by region
+statement: SELECT * FROM users WHERE region = ?
+parameters:
+  - name: region
+    type: string
+    description: region of the users
+---
+kind: tool
+name: user-count
+type: sqlite-sql
+source: my-sqlite
+description: ` + "{{DESCRIPTION}}" + `
+statement: SELECT COUNT(*) FROM users WHERE region = ?
+parameters:
+  - name: user-region
+    type: string
+    description: " "
+`
+
+func TestLint(t *testing.T) {
+	writeConfig := func(t *testing.T, description string) string {
+		path := filepath.Join(t.TempDir(), "tools.yaml")
+		content := strings.Replace(toolsFileContent, "{{DESCRIPTION}}", description, 1)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write config: %v", err)
+		}
+		return path
+	}
+	long := strings.Repeat("a", 1025)
+
+	tcs := []struct {
+		desc        string
+		args        []string
+		description string
+		want        []string
+		wantErr     string
+	}{
+		{
+			desc:        "without llm checks",
+			args:        []string{"lint"},
+			description: long,
+		},
+		{
+			desc:        "openai",
+			args:        []string{"lint", "--check-llm-compat", "--target", "openai"},
+			description: long,
+			want: []string{
+				`tool "user-count": description is 1025 characters long, over the limit of 1024`,
+				`tool "user-count": parameter "user-region": description is empty`,
+			},
+			wantErr: "found 2 violations of openai limits in 2 tools",
+		},
+		{
+			desc:        "anthropic",
+			args:        []string{"lint", "--check-llm-compat", "--target", "anthropic"},
+			description: long,
+			want:        []string{`tool "user-count": parameter "user-region": description is empty`},
+			wantErr:     "found 1 violations of anthropic limits in 2 tools",
+		},
+		{
+			desc:        "vertex",
+			args:        []string{"lint", "--check-llm-compat", "--target", "vertex"},
+			description: "count users",
+			want: []string{
+				`tool "user-count": parameter "user-region": name must match`,
+				`tool "user-count": parameter "user-region": description is empty`,
+			},
+			wantErr: "found 2 violations of vertex limits in 2 tools",
+		},
+		{
+			desc:    "invalid target",
+			args:    []string{"lint", "--check-llm-compat", "--target", "other"},
+			wantErr: `invalid --target "other", must be one of: anthropic, openai, vertex`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			args := append(tc.args, "--config", writeConfig(t, tc.description))
+			got, err := invokeCommand(args)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+			} else if err == nil || err.Error() != tc.wantErr {
+				t.Fatalf("unexpected error: got %v, want %q", err, tc.wantErr)
+			}
+			for _, w := range tc.want {
+				if !strings.Contains(got, w) {
+					t.Errorf("output %q does not contain %q", got, w)
+				}
+			}
+			if strings.Contains(got, "search-users") {
+				t.Errorf("output %q reports a valid tool", got)
+			}
+		})
+	}
+}
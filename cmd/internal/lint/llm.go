@@ -0,0 +1,88 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+// llmTarget holds the limits a LLM provider puts on tool definitions.
+type llmTarget struct {
+	// maxDescription is the maximum length of tool descriptions, 0 if
+	// unlimited.
+	maxDescription int
+	toolName       *regexp.Regexp
+	paramName      *regexp.Regexp
+}
+
+var llmTargets = map[string]llmTarget{
+	"openai": {
+		maxDescription: 1024,
+		toolName:       regexp.MustCompile(`^[a-zA-Z0-9_-]{1,64}$`),
+		paramName:      regexp.MustCompile(`^[a-zA-Z0-9_-]+$`),
+	},
+	"anthropic": {
+		toolName:  regexp.MustCompile(`^[a-zA-Z0-9_-]{1,64}$`),
+		paramName: regexp.MustCompile(`^[a-zA-Z0-9_.-]{1,64}$`),
+	},
+	"vertex": {
+		toolName:  regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_.:-]{0,63}$`),
+		paramName: regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`),
+	},
+}
+
+// targetNames returns the names of the LLM targets in order.
+func targetNames() []string {
+	names := make([]string, 0, len(llmTargets))
+	for name := range llmTargets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// checkLLMCompat returns the violations of the tool definition against the
+// limits of target.
+func checkLLMCompat(target llmTarget, name string, tool tools.Tool) []string {
+	var violations []string
+	manifest := tool.StaticManifest()
+	if !target.toolName.MatchString(name) {
+		violations = append(violations, fmt.Sprintf("tool %q: name must match %s", name, target.toolName))
+	}
+	if target.maxDescription > 0 && len(manifest.Description) > target.maxDescription {
+		violations = append(violations, fmt.Sprintf("tool %q: description is %d characters long, over the limit of %d", name, len(manifest.Description), target.maxDescription))
+	}
+	for _, p := range manifest.Parameters {
+		violations = append(violations, checkParameter(target, name, p)...)
+	}
+	return violations
+}
+
+func checkParameter(target llmTarget, toolName string, p parameters.ParameterManifest) []string {
+	var violations []string
+	if !target.paramName.MatchString(p.Name) {
+		violations = append(violations, fmt.Sprintf("tool %q: parameter %q: name must match %s", toolName, p.Name, target.paramName))
+	}
+	if strings.TrimSpace(p.Description) == "" {
+		violations = append(violations, fmt.Sprintf("tool %q: parameter %q: description is empty", toolName, p.Name))
+	}
+	return violations
+}
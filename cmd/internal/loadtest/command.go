@@ -0,0 +1,135 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadtest
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/googleapis/mcp-toolbox/cmd/internal"
+	"github.com/googleapis/mcp-toolbox/internal/server"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/spf13/cobra"
+)
+
+// loadtestCmd is the command for generating load tests.
+type loadtestCmd struct {
+	*cobra.Command
+	format  string
+	output  string
+	url     string
+	toolset string
+}
+
+// NewCommand creates a new Command.
+func NewCommand(opts *internal.ToolboxOptions) *cobra.Command {
+	cmd := &loadtestCmd{}
+	cmd.Command = &cobra.Command{
+		Use:   "gen-loadtest",
+		Short: "Generate a load test from tool configurations",
+		Long:  "Generate a k6 or Locust load test that calls every tool through the MCP endpoint, using the examples of the tools as arguments.",
+		Args:  cobra.NoArgs,
+		RunE: func(c *cobra.Command, args []string) error {
+			return run(cmd, opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	internal.ConfigFileFlags(cmd.Command, flags, opts)
+	flags.StringVar(&cmd.format, "format", formatK6, "Format of the load test: 'k6' or 'locust'.")
+	flags.StringVarP(&cmd.output, "output", "o", "", "File to write the load test to. Defaults to stdout.")
+	flags.StringVar(&cmd.url, "url", "http://127.0.0.1:5000", "Base URL of the Toolbox server under test.")
+	flags.StringVar(&cmd.toolset, "toolset", "", "Name of the toolset to load test. If not provided, all tools will be included.")
+	return cmd.Command
+}
+
+func run(cmd *loadtestCmd, opts *internal.ToolboxOptions) error {
+	ctx, cancel := context.WithCancel(cmd.Context())
+	defer cancel()
+
+	ctx, shutdown, err := opts.Setup(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = shutdown(ctx)
+	}()
+
+	// gen-loadtest runs offline, so unset environment variables of the
+	// sources resolve to "".
+	parser := internal.ConfigParser{AllowMissingEnvVars: true}
+	if _, err := opts.LoadConfig(ctx, &parser); err != nil {
+		return err
+	}
+
+	toolsMap, toolsetsMap, err := server.InitializeOfflineConfigs(ctx, opts.Cfg)
+	if err != nil {
+		errMsg := fmt.Errorf("failed to initialize resources: %w", err)
+		opts.Logger.ErrorContext(ctx, errMsg.Error())
+		return errMsg
+	}
+
+	path := "/mcp"
+	if cmd.toolset != "" {
+		ts, ok := toolsetsMap[cmd.toolset]
+		if !ok {
+			return fmt.Errorf("toolset %q not found", cmd.toolset)
+		}
+		toolsMap = make(map[string]tools.Tool)
+		for _, t := range ts.Tools {
+			if t != nil {
+				tool := *t
+				toolsMap[tool.GetName()] = tool
+			}
+		}
+		path += "/" + cmd.toolset
+	}
+	if len(toolsMap) == 0 {
+		return fmt.Errorf("no tools found to generate a load test for")
+	}
+
+	fixtures, missing, err := collectFixtures(toolsMap)
+	if err != nil {
+		errMsg := fmt.Errorf("error collecting tool examples: %w", err)
+		opts.Logger.ErrorContext(ctx, errMsg.Error())
+		return errMsg
+	}
+	if len(missing) > 0 {
+		opts.Logger.WarnContext(ctx, fmt.Sprintf("tools without examples are called with default parameter values: %s", strings.Join(missing, ", ")))
+	}
+
+	content, err := generate(cmd.format, fixtureData{
+		URL:   strings.TrimSuffix(cmd.url, "/"),
+		Path:  path,
+		Tools: fixtures,
+	})
+	if err != nil {
+		return err
+	}
+
+	if cmd.output == "" {
+		_, err := fmt.Fprint(opts.IOStreams.Out, content)
+		return err
+	}
+	if err := os.WriteFile(cmd.output, []byte(content), 0644); err != nil {
+		errMsg := fmt.Errorf("error writing load test: %w", err)
+		opts.Logger.ErrorContext(ctx, errMsg.Error())
+		return errMsg
+	}
+	opts.Logger.InfoContext(ctx, fmt.Sprintf("Successfully generated %s load test for %d tools in %s.", cmd.format, len(fixtures), cmd.output))
+	return nil
+}
@@ -0,0 +1,172 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadtest
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/googleapis/mcp-toolbox/cmd/internal"
+	_ "github.com/googleapis/mcp-toolbox/internal/sources/sqlite"
+	_ "github.com/googleapis/mcp-toolbox/internal/tools/sqlite/sqlitesql"
+	"github.com/spf13/cobra"
+)
+
+func invokeCommand(args []string) (string, error) {
+	parentCmd := &cobra.Command{
+		Use:           "toolbox",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	buf := new(bytes.Buffer)
+	opts := internal.NewToolboxOptions(internal.WithIOStreams(buf, buf))
+	internal.PersistentFlags(parentCmd, opts)
+
+	parentCmd.SetOut(buf)
+	parentCmd.SetErr(buf)
+
+	cmd := NewCommand(opts)
+	parentCmd.AddCommand(cmd)
+	parentCmd.SetArgs(args)
+
+	err := parentCmd.Execute()
+	return buf.String(), err
+}
+
+const toolsFileContent = `
+kind: source
+name: my-sqlite
+type: sqlite
+database: ":memory:"
+---
+kind: tool
+name: search-users
+type: sqlite-sql
+source: my-sqlite
+description: search users by region
+statement: SELECT * FROM users WHERE region = ? LIMIT ?
+parameters:
+  - name: region
+    type: string
+    description: region of the users
+  - name: limit
+    type: integer
+    description: maximum number of users
+examples:
+  - description: a few users in the east
+    parameters:
+      region: east
+      limit: 10
+  - parameters:
+      region: west
+      limit: 1
+---
+kind: tool
+name: count-users
+type: sqlite-sql
+source: my-sqlite
+description: count users
+statement: SELECT COUNT(*) FROM users
+`
+
+func TestGenerateLoadTest(t *testing.T) {
+	tmpDir := t.TempDir()
+	toolsFilePath := filepath.Join(tmpDir, "tools.yaml")
+	if err := os.WriteFile(toolsFilePath, []byte(toolsFileContent), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	tcs := []struct {
+		format string
+		want   []string
+	}{
+		{
+			format: "k6",
+			want: []string{
+				"import http from 'k6/http';",
+				"const BASE_URL = __ENV.TOOLBOX_URL || \"http://127.0.0.1:5000\";",
+				"http.post(BASE_URL + \"/mcp\", payload",
+				"export function tool_count_users() {\n  callTool(\"count-users\", [{}]);\n}",
+				`callTool("search-users", [{"limit":10,"region":"east"},{"limit":1,"region":"west"}]);`,
+				"export default function () {\n  tool_count_users();\n  tool_search_users();\n}",
+			},
+		},
+		{
+			format: "locust",
+			want: []string{
+				"from locust import HttpUser, between, task",
+				`"search-users": [{"limit": 10, "region": "east"}, {"limit": 1, "region": "west"}],`,
+				`host = "http://127.0.0.1:5000"`,
+				"    @task\n    def tool_search_users(self):\n        self.call_tool(\"search-users\")\n",
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.format, func(t *testing.T) {
+			outputPath := filepath.Join(tmpDir, "loadtest."+tc.format)
+			args := []string{"gen-loadtest", "--config", toolsFilePath, "--format", tc.format, "--output", outputPath}
+			if out, err := invokeCommand(args); err != nil {
+				t.Fatalf("command failed: %v\nOutput: %s", err, out)
+			}
+			content, err := os.ReadFile(outputPath)
+			if err != nil {
+				t.Fatalf("failed to read load test: %v", err)
+			}
+			for _, want := range tc.want {
+				if !strings.Contains(string(content), want) {
+					t.Errorf("load test does not contain %q:\n%s", want, content)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateLoadTestErrors(t *testing.T) {
+	tmpDir := t.TempDir()
+	toolsFilePath := filepath.Join(tmpDir, "tools.yaml")
+	undeclared := strings.Replace(toolsFileContent, "      limit: 1\n", "      limit: 1\n      unknown: 1\n", 1)
+	if err := os.WriteFile(toolsFilePath, []byte(undeclared), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	tcs := []struct {
+		desc string
+		args []string
+		want string
+	}{
+		{
+			desc: "undeclared parameter",
+			args: []string{"gen-loadtest", "--config", toolsFilePath},
+			want: `example 1 of tool "search-users" sets undeclared parameter "unknown"`,
+		},
+		{
+			desc: "unknown toolset",
+			args: []string{"gen-loadtest", "--config", toolsFilePath, "--toolset", "missing"},
+			want: `toolset "missing" not found`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := invokeCommand(tc.args)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("unexpected error: got %v, want %q", err, tc.want)
+			}
+		})
+	}
+}
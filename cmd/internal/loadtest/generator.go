@@ -0,0 +1,359 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+)
+
+const (
+	formatK6     = "k6"
+	formatLocust = "locust"
+)
+
+// toolFixture holds the load test data of a single tool.
+type toolFixture struct {
+	// Name is the name of the tool.
+	Name string
+	// Ident is the name of the function generated for the tool.
+	Ident string
+	// Examples are the sets of arguments the tool is called with.
+	Examples []map[string]any
+}
+
+// fixtureData is the data passed to the load test templates.
+type fixtureData struct {
+	URL   string
+	Path  string
+	Tools []toolFixture
+}
+
+var nonIdentRe = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// collectFixtures builds the fixtures of the given tools in name order. The
+// arguments come from the examples of the tools; tools without examples are
+// called with the default values of their parameters and are returned in
+// missing. It is an error for an example to set an undeclared parameter.
+func collectFixtures(toolsMap map[string]tools.Tool) (fixtures []toolFixture, missing []string, err error) {
+	names := make([]string, 0, len(toolsMap))
+	for name := range toolsMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	idents := make(map[string]bool)
+	for _, name := range names {
+		tool := toolsMap[name]
+		manifest := tool.StaticManifest()
+		declared := make(map[string]bool)
+		for _, p := range manifest.Parameters {
+			declared[p.Name] = true
+		}
+
+		var examples []map[string]any
+		if c, ok := tool.ToConfig().(interface{ GetExamples() []tools.Example }); ok {
+			for i, example := range c.GetExamples() {
+				for param := range example.Parameters {
+					if !declared[param] {
+						return nil, nil, fmt.Errorf("example %d of tool %q sets undeclared parameter %q", i, name, param)
+					}
+				}
+				args := example.Parameters
+				if args == nil {
+					args = map[string]any{}
+				}
+				examples = append(examples, args)
+			}
+		}
+		if len(examples) == 0 {
+			args := map[string]any{}
+			for _, p := range manifest.Parameters {
+				if p.Default != nil {
+					args[p.Name] = p.Default
+				}
+			}
+			examples = append(examples, args)
+			missing = append(missing, name)
+		}
+
+		ident := "tool_" + nonIdentRe.ReplaceAllString(name, "_")
+		for i := 2; idents[ident]; i++ {
+			ident = fmt.Sprintf("tool_%s_%d", nonIdentRe.ReplaceAllString(name, "_"), i)
+		}
+		idents[ident] = true
+
+		fixtures = append(fixtures, toolFixture{Name: name, Ident: ident, Examples: examples})
+	}
+	return fixtures, missing, nil
+}
+
+const k6Template = `// Load test generated by ` + "`toolbox gen-loadtest`" + `.
+//
+// Run with:
+//   k6 run -e TOOLBOX_URL={{.URL}} <this file>
+//
+// Headers such as auth tokens can be passed as a JSON object in the
+// TOOLBOX_HEADERS environment variable.
+import http from 'k6/http';
+import { check } from 'k6';
+
+const BASE_URL = __ENV.TOOLBOX_URL || {{jsLiteral .URL}};
+const HEADERS = Object.assign(
+  { 'Content-Type': 'application/json' },
+  JSON.parse(__ENV.TOOLBOX_HEADERS || '{}'),
+);
+
+export const options = {
+  vus: 10,
+  duration: '30s',
+  thresholds: {
+    checks: ['rate>0.99'],
+  },
+};
+
+let requestId = 0;
+
+function callTool(name, examples) {
+  requestId++;
+  const payload = JSON.stringify({
+    jsonrpc: '2.0',
+    id: requestId,
+    method: 'tools/call',
+    params: {
+      name: name,
+      arguments: examples[Math.floor(Math.random() * examples.length)],
+    },
+  });
+  const res = http.post(BASE_URL + {{jsLiteral .Path}}, payload, {
+    headers: HEADERS,
+    tags: { tool: name },
+  });
+  check(res, {
+    [name + ' status is 200']: (r) => r.status === 200,
+    [name + ' succeeded']: (r) => {
+      try {
+        const body = r.json();
+        return !body.error && !(body.result && body.result.isError);
+      } catch (e) {
+        return false;
+      }
+    },
+  });
+}
+{{range .Tools}}
+export function {{.Ident}}() {
+  callTool({{jsLiteral .Name}}, {{jsLiteral .Examples}});
+}
+{{end}}
+export default function () {
+{{- range .Tools}}
+  {{.Ident}}();
+{{- end}}
+}
+`
+
+const locustTemplate = `# Load test generated by ` + "`toolbox gen-loadtest`" + `.
+#
+# Run with:
+#   locust -f <this file> --host {{.URL}}
+#
+# Headers such as auth tokens can be passed as a JSON object in the
+# TOOLBOX_HEADERS environment variable.
+import itertools
+import json
+import os
+import random
+
+from locust import HttpUser, between, task
+
+HEADERS = json.loads(os.environ.get("TOOLBOX_HEADERS", "{}"))
+
+EXAMPLES = {
+{{- range .Tools}}
+    {{pyLiteral .Name}}: {{pyLiteral .Examples}},
+{{- end}}
+}
+
+_request_ids = itertools.count(1)
+
+
+class ToolboxUser(HttpUser):
+    host = {{pyLiteral .URL}}
+    wait_time = between(0.5, 1.5)
+
+    def call_tool(self, name):
+        payload = {
+            "jsonrpc": "2.0",
+            "id": next(_request_ids),
+            "method": "tools/call",
+            "params": {
+                "name": name,
+                "arguments": random.choice(EXAMPLES[name]),
+            },
+        }
+        with self.client.post(
+            {{pyLiteral .Path}}, json=payload, headers=HEADERS, name=name, catch_response=True
+        ) as resp:
+            if resp.status_code != 200:
+                resp.failure("unexpected status %d" % resp.status_code)
+                return
+            try:
+                body = resp.json()
+            except ValueError:
+                resp.failure("response is not JSON")
+                return
+            if body.get("error"):
+                resp.failure(body["error"].get("message", "tool call failed"))
+            elif (body.get("result") or {}).get("isError"):
+                resp.failure("tool returned an error")
+            else:
+                resp.success()
+{{range .Tools}}
+    @task
+    def {{.Ident}}(self):
+        self.call_tool({{pyLiteral .Name}})
+{{end -}}
+`
+
+var templateFuncs = template.FuncMap{
+	"jsLiteral": jsLiteral,
+	"pyLiteral": pyLiteral,
+}
+
+// generate renders the load test of the given format.
+func generate(format string, data fixtureData) (string, error) {
+	var text string
+	switch format {
+	case formatK6:
+		text = k6Template
+	case formatLocust:
+		text = locustTemplate
+	default:
+		return "", fmt.Errorf("unsupported format %q: must be %q or %q", format, formatK6, formatLocust)
+	}
+	tmpl, err := template.New(format).Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("error parsing %s template: %w", format, err)
+	}
+	var buf strings.Builder
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("error executing %s template: %w", format, err)
+	}
+	return buf.String(), nil
+}
+
+// jsLiteral renders v as a JavaScript literal. JSON is valid JavaScript, and
+// the encoder escapes the characters that would end a script early.
+func jsLiteral(v any) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// pyLiteral renders v as a Python literal.
+func pyLiteral(v any) (string, error) {
+	var sb strings.Builder
+	if err := writePyLiteral(&sb, v); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+func writePyLiteral(sb *strings.Builder, v any) error {
+	switch v := v.(type) {
+	case nil:
+		sb.WriteString("None")
+	case bool:
+		if v {
+			sb.WriteString("True")
+		} else {
+			sb.WriteString("False")
+		}
+	case string:
+		sb.WriteString(strconv.Quote(v))
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		fmt.Fprintf(sb, "%d", v)
+	case float32:
+		return writePyLiteral(sb, float64(v))
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return fmt.Errorf("unsupported number %v", v)
+		}
+		s := strconv.FormatFloat(v, 'g', -1, 64)
+		if !strings.ContainsAny(s, ".e") {
+			s += ".0"
+		}
+		sb.WriteString(s)
+	case []map[string]any:
+		items := make([]any, len(v))
+		for i, item := range v {
+			items[i] = item
+		}
+		return writePyLiteral(sb, items)
+	case []any:
+		sb.WriteString("[")
+		for i, item := range v {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			if err := writePyLiteral(sb, item); err != nil {
+				return err
+			}
+		}
+		sb.WriteString("]")
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		sb.WriteString("{")
+		for i, k := range keys {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			sb.WriteString(strconv.Quote(k))
+			sb.WriteString(": ")
+			if err := writePyLiteral(sb, v[k]); err != nil {
+				return err
+			}
+		}
+		sb.WriteString("}")
+	default:
+		// Fall back to the JSON representation of other types, such as
+		// slices and maps of concrete types.
+		b, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		var generic any
+		if err := json.Unmarshal(b, &generic); err != nil {
+			return err
+		}
+		return writePyLiteral(sb, generic)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadtest
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPyLiteral(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   any
+		want string
+	}{
+		{desc: "none", in: nil, want: "None"},
+		{desc: "bools", in: []any{true, false}, want: "[True, False]"},
+		{desc: "string", in: "a \"quoted\"\nline", want: `"a \"quoted\"\nline"`},
+		{desc: "integers", in: []any{uint64(10), int64(-1)}, want: "[10, -1]"},
+		{desc: "floats", in: []any{2.0, 1.5, 1e21}, want: "[2.0, 1.5, 1e+21]"},
+		{desc: "sorted dict", in: map[string]any{"b": 1, "a": []string{"x"}}, want: `{"a": ["x"], "b": 1}`},
+		{desc: "examples", in: []map[string]any{{"k": "v"}, {}}, want: `[{"k": "v"}, {}]`},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := pyLiteral(tc.in)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != tc.want {
+				t.Errorf("unexpected literal: got %s, want %s", got, tc.want)
+			}
+		})
+	}
+
+	if _, err := pyLiteral(math.Inf(1)); err == nil {
+		t.Errorf("expected an error for an infinite number")
+	}
+}
+
+func TestGenerateUnsupportedFormat(t *testing.T) {
+	if _, err := generate("jmeter", fixtureData{}); err == nil {
+		t.Errorf("expected an error for an unsupported format")
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migrationguide
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/googleapis/mcp-toolbox/cmd/internal"
+	"github.com/googleapis/mcp-toolbox/internal/server"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/spf13/cobra"
+)
+
+// migrationGuideCmd is the command for generating migration guides between
+// tool configurations.
+type migrationGuideCmd struct {
+	*cobra.Command
+	before string
+	after  string
+	output string
+}
+
+// NewCommand creates a new Command.
+func NewCommand(opts *internal.ToolboxOptions) *cobra.Command {
+	cmd := &migrationGuideCmd{}
+	cmd.Command = &cobra.Command{
+		Use:   "migration-guide",
+		Short: "Generate a Markdown migration guide between tool configurations",
+		Long: `Generate a Markdown migration guide listing the breaking changes of the tools
+of --before in --after: removed tools, removed parameters, added required
+parameters, parameter type changes, and parameters renamed in paramAliases,
+with what callers need to update and example invocations.
+Example:
+  toolbox migration-guide --before old.yaml --after new.yaml --output MIGRATION.md`,
+		Args: cobra.NoArgs,
+		RunE: func(c *cobra.Command, args []string) error {
+			return run(cmd, opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&cmd.before, "before", "", "File path of the previous tool configuration.")
+	flags.StringVar(&cmd.after, "after", "", "File path of the new tool configuration.")
+	flags.StringVarP(&cmd.output, "output", "o", "", "File to write the migration guide to. Defaults to stdout.")
+	_ = cmd.MarkFlagRequired("before")
+	_ = cmd.MarkFlagRequired("after")
+	return cmd.Command
+}
+
+// loadTools returns the tools of the configuration file at path.
+func loadTools(ctx context.Context, opts *internal.ToolboxOptions, path string) (map[string]tools.Tool, error) {
+	// migration-guide runs offline, so unset environment variables of the
+	// sources resolve to placeholders.
+	parser := internal.ConfigParser{AllowMissingEnvVars: true}
+	cfg, err := parser.LoadAndMergeConfigs(ctx, []string{path})
+	if err != nil {
+		return nil, err
+	}
+	serverCfg := opts.Cfg
+	serverCfg.SourceConfigs = cfg.Sources
+	serverCfg.AuthServiceConfigs = cfg.AuthServices
+	serverCfg.EmbeddingModelConfigs = cfg.EmbeddingModels
+	serverCfg.ToolConfigs = cfg.Tools
+	serverCfg.ToolsetConfigs = cfg.Toolsets
+	toolsMap, _, err := server.InitializeOfflineConfigs(ctx, serverCfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize tools of %q: %w", path, err)
+	}
+	return toolsMap, nil
+}
+
+func run(cmd *migrationGuideCmd, opts *internal.ToolboxOptions) error {
+	ctx, cancel := context.WithCancel(cmd.Context())
+	defer cancel()
+
+	ctx, shutdown, err := opts.Setup(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = shutdown(ctx)
+	}()
+
+	before, err := loadTools(ctx, opts, cmd.before)
+	if err != nil {
+		opts.Logger.ErrorContext(ctx, err.Error())
+		return err
+	}
+	after, err := loadTools(ctx, opts, cmd.after)
+	if err != nil {
+		opts.Logger.ErrorContext(ctx, err.Error())
+		return err
+	}
+
+	content, err := generate(before, after)
+	if err != nil {
+		return err
+	}
+
+	if cmd.output == "" {
+		_, err := fmt.Fprint(opts.IOStreams.Out, content)
+		return err
+	}
+	if err := os.WriteFile(cmd.output, []byte(content), 0644); err != nil {
+		errMsg := fmt.Errorf("error writing migration guide: %w", err)
+		opts.Logger.ErrorContext(ctx, errMsg.Error())
+		return errMsg
+	}
+	opts.Logger.InfoContext(ctx, fmt.Sprintf("Successfully generated the migration guide in %s.", cmd.output))
+	return nil
+}
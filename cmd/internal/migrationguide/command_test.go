@@ -0,0 +1,178 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migrationguide
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/googleapis/mcp-toolbox/cmd/internal"
+	_ "github.com/googleapis/mcp-toolbox/internal/sources/sqlite"
+	_ "github.com/googleapis/mcp-toolbox/internal/tools/sqlite/sqlitesql"
+	"github.com/spf13/cobra"
+)
+
+func invokeCommand(args []string) (string, error) {
+	parentCmd := &cobra.Command{
+		Use:           "toolbox",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	buf := new(bytes.Buffer)
+	opts := internal.NewToolboxOptions(internal.WithIOStreams(buf, buf))
+	internal.PersistentFlags(parentCmd, opts)
+
+	cmd := NewCommand(opts)
+	parentCmd.AddCommand(cmd)
+	parentCmd.SetArgs(args)
+
+	err := parentCmd.Execute()
+	return buf.String(), err
+}
+
+const beforeContent = `
+kind: source
+name: my-sqlite
+type: sqlite
+database: ":memory:"
+---
+kind: tool
+name: search-users
+type: sqlite-sql
+source: my-sqlite
+description: search users
+statement: SELECT * FROM users WHERE region = ? AND age > ? AND team = ?
+parameters:
+  - name: region
+    type: string
+    description: region of the users
+  - name: age
+    type: string
+    description: minimum age of the users
+  - name: team
+    type: string
+    description: team of the users
+---
+kind: tool
+name: count-users
+type: sqlite-sql
+source: my-sqlite
+description: count users
+statement: SELECT COUNT(*) FROM users
+---
+kind: tool
+name: list-teams
+type: sqlite-sql
+source: my-sqlite
+description: list teams
+statement: SELECT * FROM teams
+`
+
+const afterContent = `
+kind: source
+name: my-sqlite
+type: sqlite
+database: ":memory:"
+---
+kind: tool
+name: search-users
+type: sqlite-sql
+source: my-sqlite
+description: search users
+statement: SELECT * FROM users WHERE area = ? AND age > ? AND active = ?
+paramAliases:
+  region: area
+parameters:
+  - name: area
+    type: string
+    description: area of the users
+  - name: age
+    type: integer
+    description: minimum age of the users
+  - name: active
+    type: boolean
+    description: whether the users are active
+---
+kind: tool
+name: count-users
+type: sqlite-sql
+source: my-sqlite
+description: count users
+statement: SELECT COUNT(*) FROM users WHERE region = ?
+parameters:
+  - name: region
+    type: string
+    description: region of the users
+    default: eu
+`
+
+func TestMigrationGuide(t *testing.T) {
+	dir := t.TempDir()
+	before := filepath.Join(dir, "old.yaml")
+	after := filepath.Join(dir, "new.yaml")
+	if err := os.WriteFile(before, []byte(beforeContent), 0644); err != nil {
+		t.Fatalf("unable to write config: %s", err)
+	}
+	if err := os.WriteFile(after, []byte(afterContent), 0644); err != nil {
+		t.Fatalf("unable to write config: %s", err)
+	}
+	output := filepath.Join(dir, "MIGRATION.md")
+
+	if _, err := invokeCommand([]string{"migration-guide", "--before", before, "--after", after, "--output", output}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	b, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatalf("unable to read migration guide: %s", err)
+	}
+	got := string(b)
+
+	for _, want := range []string{
+		"## `list-teams`\n\nThe tool was removed.",
+		"- Parameter `region` was renamed to `area`.",
+		"- Send `area` instead of `region`.",
+		"- Parameter `age` changed type from `string` to `integer`.",
+		"- Parameter `team` was removed.",
+		"- Required parameter `active` was added.",
+		`curl -X POST http://127.0.0.1:5000/api/tool/search-users/invoke -d '{"age":"age","region":"region","team":"team"}'`,
+		`curl -X POST http://127.0.0.1:5000/api/tool/search-users/invoke -d '{"active":true,"age":1,"area":"area"}'`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("migration guide does not contain %q:\n%s", want, got)
+		}
+	}
+	// an added parameter with a default is not a breaking change
+	if strings.Contains(got, "count-users") {
+		t.Errorf("unexpected breaking change of count-users:\n%s", got)
+	}
+}
+
+func TestMigrationGuideNoBreakingChanges(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tools.yaml")
+	if err := os.WriteFile(path, []byte(beforeContent), 0644); err != nil {
+		t.Fatalf("unable to write config: %s", err)
+	}
+	got, err := invokeCommand([]string{"migration-guide", "--before", path, "--after", path})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !strings.Contains(got, "There are no breaking changes.") {
+		t.Errorf("unexpected migration guide:\n%s", got)
+	}
+}
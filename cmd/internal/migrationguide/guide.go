@@ -0,0 +1,187 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migrationguide
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+// change is a breaking change of a tool, with what callers need to update.
+type change struct {
+	summary string
+	update  string
+}
+
+// paramAliases returns the previous names of the renamed parameters of tool
+// mapped to their current names.
+func paramAliases(tool tools.Tool) map[string]string {
+	if c, ok := tool.ToConfig().(interface{ GetParamAliases() map[string]string }); ok {
+		return c.GetParamAliases()
+	}
+	return nil
+}
+
+// paramType returns the type of p, with the type of its items for arrays.
+func paramType(p parameters.ParameterManifest) string {
+	if p.Items != nil {
+		return fmt.Sprintf("%s of %s", p.Type, paramType(*p.Items))
+	}
+	return p.Type
+}
+
+// diffParams returns the breaking changes between the parameters of a tool
+// before and after, detecting renames from the paramAliases of after.
+func diffParams(before, after []parameters.ParameterManifest, aliases map[string]string) []change {
+	afterParams := make(map[string]parameters.ParameterManifest, len(after))
+	for _, p := range after {
+		afterParams[p.Name] = p
+	}
+	beforeParams := make(map[string]bool, len(before))
+	renamedTo := make(map[string]bool)
+
+	var changes []change
+	for _, old := range before {
+		beforeParams[old.Name] = true
+		name := old.Name
+		if alias, ok := aliases[old.Name]; ok && alias != old.Name {
+			if _, ok := afterParams[alias]; ok {
+				name = alias
+				renamedTo[alias] = true
+				changes = append(changes, change{
+					summary: fmt.Sprintf("Parameter `%s` was renamed to `%s`.", old.Name, alias),
+					update:  fmt.Sprintf("Send `%s` instead of `%s`.", alias, old.Name),
+				})
+			}
+		}
+		p, ok := afterParams[name]
+		if !ok {
+			changes = append(changes, change{
+				summary: fmt.Sprintf("Parameter `%s` was removed.", old.Name),
+				update:  fmt.Sprintf("Stop sending `%s`.", old.Name),
+			})
+			continue
+		}
+		if oldType, newType := paramType(old), paramType(p); oldType != newType {
+			changes = append(changes, change{
+				summary: fmt.Sprintf("Parameter `%s` changed type from `%s` to `%s`.", name, oldType, newType),
+				update:  fmt.Sprintf("Send `%s` as a value of type `%s`.", name, newType),
+			})
+		}
+	}
+	for _, p := range after {
+		if beforeParams[p.Name] || renamedTo[p.Name] || !p.Required {
+			continue
+		}
+		changes = append(changes, change{
+			summary: fmt.Sprintf("Required parameter `%s` was added.", p.Name),
+			update:  fmt.Sprintf("Send `%s`: %s", p.Name, p.Description),
+		})
+	}
+	return changes
+}
+
+// exampleValue returns a placeholder value of the type of p.
+func exampleValue(p parameters.ParameterManifest) any {
+	switch p.Type {
+	case "integer":
+		return 1
+	case "float":
+		return 1.5
+	case "boolean":
+		return true
+	case "array":
+		if p.Items != nil {
+			return []any{exampleValue(*p.Items)}
+		}
+		return []any{}
+	case "map":
+		return map[string]any{}
+	default:
+		return p.Name
+	}
+}
+
+// exampleInvocation returns a curl command invoking the tool name with
+// placeholder values for params.
+func exampleInvocation(name string, params []parameters.ParameterManifest) (string, error) {
+	body := make(map[string]any, len(params))
+	for _, p := range params {
+		if p.Required || p.Default == nil {
+			body[p.Name] = exampleValue(p)
+		}
+	}
+	b, err := json.Marshal(body)
+	if err != nil {
+		return "", fmt.Errorf("unable to marshal example of %q: %w", name, err)
+	}
+	return fmt.Sprintf("curl -X POST http://127.0.0.1:5000/api/tool/%s/invoke -d '%s'", name, b), nil
+}
+
+// generate returns the Markdown migration guide from the tools of before to
+// the tools of after.
+func generate(before, after map[string]tools.Tool) (string, error) {
+	names := make([]string, 0, len(before))
+	for name := range before {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var sb strings.Builder
+	sb.WriteString("# Migration Guide\n")
+	breaking := 0
+	for _, name := range names {
+		oldTool := before[name]
+		newTool, ok := after[name]
+		if !ok {
+			breaking++
+			fmt.Fprintf(&sb, "\n## `%s`\n\nThe tool was removed. Callers need to stop invoking it.\n", name)
+			continue
+		}
+		oldParams := oldTool.StaticManifest().Parameters
+		newParams := newTool.StaticManifest().Parameters
+		changes := diffParams(oldParams, newParams, paramAliases(newTool))
+		if len(changes) == 0 {
+			continue
+		}
+		breaking++
+		fmt.Fprintf(&sb, "\n## `%s`\n\n### Breaking changes\n\n", name)
+		for _, c := range changes {
+			fmt.Fprintf(&sb, "- %s\n", c.summary)
+		}
+		sb.WriteString("\n### What callers need to update\n\n")
+		for _, c := range changes {
+			fmt.Fprintf(&sb, "- %s\n", c.update)
+		}
+		oldExample, err := exampleInvocation(name, oldParams)
+		if err != nil {
+			return "", err
+		}
+		newExample, err := exampleInvocation(name, newParams)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&sb, "\n### Example\n\nBefore:\n\n```bash\n%s\n```\n\nAfter:\n\n```bash\n%s\n```\n", oldExample, newExample)
+	}
+	if breaking == 0 {
+		sb.WriteString("\nThere are no breaking changes.\n")
+	}
+	return sb.String(), nil
+}
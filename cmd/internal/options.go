@@ -83,6 +83,13 @@ func WithIOStreams(out, err io.Writer) Option {
 	}
 }
 
+// WithInput updates the input stream.
+func WithInput(in io.Reader) Option {
+	return func(o *ToolboxOptions) {
+		o.IOStreams.In = in
+	}
+}
+
 // Setup create logger and telemetry instrumentations.
 func (opts *ToolboxOptions) Setup(ctx context.Context) (context.Context, func(context.Context) error, error) {
 	// If stdio, set logger's out stream (usually DEBUG and INFO logs) to
@@ -102,6 +109,7 @@ func (opts *ToolboxOptions) Setup(ctx context.Context) (context.Context, func(co
 	opts.Logger = logger
 
 	ctx = util.WithIgnoreUnknownTools(ctx, opts.Cfg.IgnoreUnknownTools)
+	ctx = util.WithDefaultLocale(ctx, opts.Cfg.DefaultLocale)
 
 	logger.InfoContext(ctx, fmt.Sprintf("Starting MCP Toolbox for Databases version %s", opts.Cfg.Version))
 
@@ -296,6 +304,12 @@ func (opts *ToolboxOptions) LoadConfig(ctx context.Context, parser *ConfigParser
 	opts.Cfg.ToolConfigs = finalConfig.Tools
 	opts.Cfg.ToolsetConfigs = finalConfig.Toolsets
 	opts.Cfg.PromptConfigs = finalConfig.Prompts
+	opts.Cfg.ResourceConfigs = finalConfig.Resources
+	opts.Cfg.JobConfigs = finalConfig.Jobs
+	opts.Cfg.AccessPolicyConfigs = finalConfig.AccessPolicies
+	opts.Cfg.NamespaceConfigs = finalConfig.Namespaces
+	opts.Cfg.SchemaToolsConfigs = finalConfig.SchemaTools
+	opts.Cfg.DbtToolsConfigs = finalConfig.DbtTools
 
 	return isCustomConfigured, nil
 }
@@ -0,0 +1,188 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/storage"
+)
+
+// maxRemoteConfigBytes bounds the size of a fetched configuration file.
+const maxRemoteConfigBytes = 32 << 20
+
+// remoteHTTPClient fetches the configuration files of https:// URLs.
+var remoteHTTPClient = &http.Client{Timeout: 30 * time.Second}
+
+var (
+	gcsClientOnce sync.Once
+	gcsClient     *storage.Client
+	gcsClientErr  error
+)
+
+// IsRemoteConfig reports whether path is the URL of a configuration file to
+// fetch, from Cloud Storage for gs:// URLs, from the Kubernetes API server for
+// k8s:// URLs or over HTTPS, rather than a path on disk.
+func IsRemoteConfig(path string) bool {
+	return strings.HasPrefix(path, "gs://") || strings.HasPrefix(path, "https://") || strings.HasPrefix(path, "k8s://")
+}
+
+// ReadConfigFile reads the configuration file at path, fetching it if path
+// is the URL of a remote configuration file.
+func ReadConfigFile(ctx context.Context, path string) ([]byte, error) {
+	if !IsRemoteConfig(path) {
+		return os.ReadFile(path)
+	}
+	buf, _, _, err := fetchRemoteConfig(ctx, path, "")
+	return buf, err
+}
+
+// fetchRemoteConfig fetches the configuration file at url along with its
+// ETag. If the file still has etag, it is not modified and its content is
+// not returned.
+func fetchRemoteConfig(ctx context.Context, url, etag string) (buf []byte, newETag string, modified bool, err error) {
+	if path, ok := strings.CutPrefix(url, "gs://"); ok {
+		bucket, object, ok := strings.Cut(path, "/")
+		if !ok || bucket == "" || object == "" {
+			return nil, "", false, fmt.Errorf("invalid Cloud Storage URL %q, expected gs://BUCKET/OBJECT", url)
+		}
+		return fetchGCSConfig(ctx, bucket, object, etag)
+	}
+	if strings.HasPrefix(url, "k8s://") {
+		return fetchK8sConfig(ctx, url, etag)
+	}
+	return fetchHTTPSConfig(ctx, url, etag)
+}
+
+// fetchGCSConfig fetches a configuration file from Cloud Storage.
+func fetchGCSConfig(ctx context.Context, bucket, object, etag string) ([]byte, string, bool, error) {
+	gcsClientOnce.Do(func() {
+		// the client outlives the context of the first fetch
+		gcsClient, gcsClientErr = storage.NewClient(context.Background())
+	})
+	if gcsClientErr != nil {
+		return nil, "", false, fmt.Errorf("unable to create Cloud Storage client: %w", gcsClientErr)
+	}
+	obj := gcsClient.Bucket(bucket).Object(object)
+	attrs, err := obj.Attrs(ctx)
+	if err != nil {
+		return nil, "", false, fmt.Errorf("unable to read gs://%s/%s: %w", bucket, object, err)
+	}
+	if etag != "" && attrs.Etag == etag {
+		return nil, etag, false, nil
+	}
+	// read the generation of the ETag, rather than a newer one written since
+	r, err := obj.Generation(attrs.Generation).NewReader(ctx)
+	if err != nil {
+		return nil, "", false, fmt.Errorf("unable to read gs://%s/%s: %w", bucket, object, err)
+	}
+	defer r.Close()
+	buf, err := readRemoteConfig(r)
+	if err != nil {
+		return nil, "", false, fmt.Errorf("unable to read gs://%s/%s: %w", bucket, object, err)
+	}
+	return buf, attrs.Etag, true, nil
+}
+
+// fetchHTTPSConfig fetches a configuration file with a conditional request.
+// Files served without an ETag are identified by the hash of their content.
+func fetchHTTPSConfig(ctx context.Context, url, etag string) ([]byte, string, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, "", false, fmt.Errorf("invalid config URL %q: %w", url, err)
+	}
+	if etag != "" && !strings.HasPrefix(etag, "sha256:") {
+		req.Header.Set("If-None-Match", etag)
+	}
+	resp, err := remoteHTTPClient.Do(req)
+	if err != nil {
+		return nil, "", false, fmt.Errorf("unable to fetch %q: %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotModified {
+		return nil, etag, false, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", false, fmt.Errorf("unable to fetch %q: unexpected status %s", url, resp.Status)
+	}
+	buf, err := readRemoteConfig(resp.Body)
+	if err != nil {
+		return nil, "", false, fmt.Errorf("unable to fetch %q: %w", url, err)
+	}
+	newETag := resp.Header.Get("ETag")
+	if newETag == "" {
+		sum := sha256.Sum256(buf)
+		newETag = "sha256:" + hex.EncodeToString(sum[:])
+	}
+	return buf, newETag, newETag != etag, nil
+}
+
+// readRemoteConfig reads a fetched configuration file, up to
+// maxRemoteConfigBytes.
+func readRemoteConfig(r io.Reader) ([]byte, error) {
+	buf, err := io.ReadAll(io.LimitReader(r, maxRemoteConfigBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(buf) > maxRemoteConfigBytes {
+		return nil, fmt.Errorf("config file exceeds %d bytes", maxRemoteConfigBytes)
+	}
+	return buf, nil
+}
+
+// RemoteConfigWatcher detects the changes of remote configuration files by
+// their ETags.
+type RemoteConfigWatcher struct {
+	urls  []string
+	etags map[string]string
+}
+
+// NewRemoteConfigWatcher returns a watcher of the remote configuration files
+// at urls.
+func NewRemoteConfigWatcher(urls []string) *RemoteConfigWatcher {
+	return &RemoteConfigWatcher{urls: urls, etags: make(map[string]string)}
+}
+
+// Changed reports whether any of the files changed since the previous call.
+// The first call records the ETags of the files and reports no change. Files
+// that can't be fetched are reported in the error, and checked again on the
+// next call.
+func (w *RemoteConfigWatcher) Changed(ctx context.Context) (bool, error) {
+	changed := false
+	var errs []error
+	for _, url := range w.urls {
+		prev, seen := w.etags[url]
+		_, etag, modified, err := fetchRemoteConfig(ctx, url, prev)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		w.etags[url] = etag
+		if seen && modified {
+			changed = true
+		}
+	}
+	return changed, errors.Join(errs...)
+}
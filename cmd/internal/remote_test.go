@@ -0,0 +1,300 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+)
+
+// configServer serves a configuration file, with an ETag if etags is set.
+type configServer struct {
+	mu      sync.Mutex
+	content string
+	etag    string
+	etags   bool
+	fetches int
+}
+
+func (c *configServer) set(content, etag string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.content, c.etag = content, etag
+}
+
+func (c *configServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.fetches++
+	if c.etags {
+		if r.Header.Get("If-None-Match") == c.etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", c.etag)
+	}
+	_, _ = w.Write([]byte(c.content))
+}
+
+func useRemoteClient(t *testing.T, ts *httptest.Server) {
+	prev := remoteHTTPClient
+	remoteHTTPClient = ts.Client()
+	t.Cleanup(func() { remoteHTTPClient = prev })
+}
+
+func TestIsRemoteConfig(t *testing.T) {
+	for path, want := range map[string]bool{
+		"gs://configs/tools.yaml":           true,
+		"https://example.com/tools.yaml":    true,
+		"http://example.com/tools.yaml":     false,
+		"tools.yaml":                        false,
+		"/etc/toolbox/gs:/tools.yaml":       false,
+		"https-configs/tools.yaml":          false,
+		"gs:/configs/tools.yaml":            false,
+		"https:/example.com/tools.yaml":     false,
+		"./https://example.com/tools.yaml":  false,
+		"GS://configs/tools.yaml":           false,
+		"gs://configs/nested/dir/tool.yaml": true,
+	} {
+		if got := IsRemoteConfig(path); got != want {
+			t.Errorf("IsRemoteConfig(%q) = %t, want %t", path, got, want)
+		}
+	}
+}
+
+func TestReadConfigFileRemote(t *testing.T) {
+	cs := &configServer{content: "kind: source\n", etag: `"v1"`, etags: true}
+	ts := httptest.NewTLSServer(cs)
+	defer ts.Close()
+	useRemoteClient(t, ts)
+
+	buf, err := ReadConfigFile(context.Background(), ts.URL+"/tools.yaml")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if string(buf) != "kind: source\n" {
+		t.Errorf("unexpected content %q", buf)
+	}
+
+	notFound := httptest.NewTLSServer(http.NotFoundHandler())
+	defer notFound.Close()
+	useRemoteClient(t, notFound)
+	if _, err := ReadConfigFile(context.Background(), notFound.URL+"/tools.yaml"); err == nil {
+		t.Errorf("expected error fetching a missing config")
+	}
+}
+
+func TestRemoteConfigWatcher(t *testing.T) {
+	for _, etags := range []bool{true, false} {
+		name := "content hash"
+		if etags {
+			name = "etag"
+		}
+		t.Run(name, func(t *testing.T) {
+			cs := &configServer{content: "v1", etag: `"v1"`, etags: etags}
+			ts := httptest.NewTLSServer(cs)
+			defer ts.Close()
+			useRemoteClient(t, ts)
+
+			ctx := context.Background()
+			w := NewRemoteConfigWatcher([]string{ts.URL + "/tools.yaml"})
+			for i, want := range []bool{false, false} {
+				changed, err := w.Changed(ctx)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if changed != want {
+					t.Fatalf("call %d: changed = %t, want %t", i, changed, want)
+				}
+			}
+
+			cs.set("v2", `"v2"`)
+			if changed, err := w.Changed(ctx); err != nil || !changed {
+				t.Fatalf("expected a change, got %t, %v", changed, err)
+			}
+			if changed, err := w.Changed(ctx); err != nil || changed {
+				t.Fatalf("expected no change, got %t, %v", changed, err)
+			}
+		})
+	}
+}
+
+func TestRemoteConfigWatcherErrors(t *testing.T) {
+	ts := httptest.NewTLSServer(http.NotFoundHandler())
+	defer ts.Close()
+	useRemoteClient(t, ts)
+
+	w := NewRemoteConfigWatcher([]string{ts.URL + "/tools.yaml"})
+	if _, err := w.Changed(context.Background()); err == nil {
+		t.Fatalf("expected error")
+	}
+	if _, err := w.Changed(context.Background()); err == nil {
+		t.Fatalf("expected error")
+	}
+
+	if _, _, _, err := fetchRemoteConfig(context.Background(), "gs://bucket-only", ""); err == nil {
+		t.Fatalf("expected error for a URL without an object")
+	}
+}
+
+// k8sServer serves Kubernetes objects at their API paths, and checks the
+// bearer token of the requests.
+type k8sServer struct {
+	mu      sync.Mutex
+	objects map[string]string
+}
+
+func (k *k8sServer) set(path, body string) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.objects[path] = body
+}
+
+func (k *k8sServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if r.Header.Get("Authorization") != "Bearer test-token" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	body, ok := k.objects[r.URL.RequestURI()]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"kind":"Status","reason":"NotFound"}`))
+		return
+	}
+	_, _ = w.Write([]byte(body))
+}
+
+func useK8sServer(t *testing.T) *k8sServer {
+	t.Helper()
+	k := &k8sServer{objects: map[string]string{}}
+	ts := httptest.NewTLSServer(k)
+	t.Cleanup(ts.Close)
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte("test-token\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	prev := k8sClient
+	k8sClient = func() (*k8sAPIClient, error) {
+		return &k8sAPIClient{server: ts.URL, tokenFile: tokenFile, client: ts.Client()}, nil
+	}
+	t.Cleanup(func() { k8sClient = prev })
+	return k
+}
+
+func TestParseK8sConfigURL(t *testing.T) {
+	tcs := []struct {
+		url     string
+		want    k8sConfigRef
+		wantErr bool
+	}{
+		{url: "k8s://apps/configmaps/tools", want: k8sConfigRef{namespace: "apps", resource: "configmaps", name: "tools"}},
+		{url: "k8s://apps/configmaps?labelSelector=team%3Dflights", want: k8sConfigRef{namespace: "apps", resource: "configmaps", labelSelector: "team=flights"}},
+		{url: "k8s://apps/toolboxtools", want: k8sConfigRef{namespace: "apps", resource: "toolboxtools"}},
+		{url: "k8s://apps/configmaps", wantErr: true},
+		{url: "k8s://apps/configmaps/tools?labelSelector=a", wantErr: true},
+		{url: "k8s://apps/secrets/tools", wantErr: true},
+		{url: "k8s:///configmaps/tools", wantErr: true},
+	}
+	for _, tc := range tcs {
+		got, err := parseK8sConfigURL(tc.url)
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("parseK8sConfigURL(%q): expected an error", tc.url)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseK8sConfigURL(%q): unexpected error: %s", tc.url, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("parseK8sConfigURL(%q) = %+v, want %+v", tc.url, got, tc.want)
+		}
+	}
+}
+
+func TestReadConfigFileK8s(t *testing.T) {
+	k := useK8sServer(t)
+	k.set("/api/v1/namespaces/apps/configmaps?labelSelector=toolbox%3Dtrue", `{"items": [
+		{"metadata": {"name": "b"}, "data": {"tools.yaml": "kind: toolset\nname: b\ntools: [b]"}},
+		{"metadata": {"name": "a"}, "data": {"2.yml": "kind: toolset\nname: a2\ntools: [a]", "1.yaml": "kind: toolset\nname: a1\ntools: [a]\n", "README": "ignored"}}
+	]}`)
+	k.set("/apis/toolbox.googleapis.com/v1alpha1/namespaces/apps/toolboxtools", `{"items": [
+		{"metadata": {"name": "search"}, "spec": {"type": "postgres-sql", "source": "db", "description": "Search."}}
+	]}`)
+	ctx := context.Background()
+
+	got, err := ReadConfigFile(ctx, "k8s://apps/configmaps?labelSelector=toolbox%3Dtrue")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := "kind: toolset\nname: a1\ntools: [a]\n---\nkind: toolset\nname: a2\ntools: [a]\n---\nkind: toolset\nname: b\ntools: [b]"
+	if string(got) != want {
+		t.Errorf("unexpected config:\n%s\nwant:\n%s", got, want)
+	}
+
+	got, err = ReadConfigFile(ctx, "k8s://apps/toolboxtools")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var doc map[string]any
+	if err := yaml.Unmarshal(got, &doc); err != nil {
+		t.Fatalf("unable to parse config %q: %s", got, err)
+	}
+	wantDoc := map[string]any{"kind": "tool", "name": "search", "type": "postgres-sql", "source": "db", "description": "Search."}
+	if diff := cmp.Diff(wantDoc, doc); diff != "" {
+		t.Errorf("unexpected tool (-want +got):\n%s", diff)
+	}
+
+	if _, err := ReadConfigFile(ctx, "k8s://apps/configmaps/missing"); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+
+	k.set("/apis/toolbox.googleapis.com/v1alpha1/namespaces/other/toolboxtools", `{"items": [
+		{"metadata": {"name": "search"}, "spec": {"name": "other", "type": "postgres-sql"}}
+	]}`)
+	if _, err := ReadConfigFile(ctx, "k8s://other/toolboxtools"); err == nil || !strings.Contains(err.Error(), "spec cannot set name") {
+		t.Errorf("expected a spec error, got %v", err)
+	}
+}
+
+func TestK8sConfigWatcher(t *testing.T) {
+	k := useK8sServer(t)
+	path := "/api/v1/namespaces/apps/configmaps/tools"
+	k.set(path, `{"metadata": {"name": "tools"}, "data": {"tools.yaml": "kind: toolset\nname: a\ntools: [a]"}}`)
+	ctx := context.Background()
+	w := NewRemoteConfigWatcher([]string{"k8s://apps/configmaps/tools"})
+	if changed, err := w.Changed(ctx); err != nil || changed {
+		t.Fatalf("first check: changed = %t, err = %v", changed, err)
+	}
+	if changed, err := w.Changed(ctx); err != nil || changed {
+		t.Fatalf("unchanged ConfigMap: changed = %t, err = %v", changed, err)
+	}
+	k.set(path, `{"metadata": {"name": "tools"}, "data": {"tools.yaml": "kind: toolset\nname: b\ntools: [b]"}}`)
+	if changed, err := w.Changed(ctx); err != nil || !changed {
+		t.Fatalf("changed ConfigMap: changed = %t, err = %v", changed, err)
+	}
+}
@@ -20,7 +20,6 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
-	"time"
 
 	"github.com/googleapis/mcp-toolbox/cmd/internal"
 	"github.com/googleapis/mcp-toolbox/internal/server"
@@ -125,12 +124,12 @@ func runServe(cmd *cobra.Command, opts *internal.ToolboxOptions) error {
 			return errMsg
 		}
 	case <-ctx.Done():
-		shutdownContext, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		shutdownContext, cancel := context.WithTimeout(context.Background(), opts.Cfg.ShutdownTimeout)
 		defer cancel()
 		opts.Logger.WarnContext(shutdownContext, "Shutting down gracefully...")
 		err := s.Shutdown(shutdownContext)
 		if err == context.DeadlineExceeded {
-			return fmt.Errorf("graceful shutdown timed out... forcing exit")
+			return fmt.Errorf("graceful shutdown timed out, in-flight invocations were canceled")
 		}
 	}
 
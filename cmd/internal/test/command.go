@@ -0,0 +1,107 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/googleapis/mcp-toolbox/cmd/internal"
+	"github.com/googleapis/mcp-toolbox/internal/server"
+	"github.com/googleapis/mcp-toolbox/internal/server/primitives"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/spf13/cobra"
+)
+
+func NewCommand(opts *internal.ToolboxOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "test [tool-name...]",
+		Short: "Run the examples of tools against their sources",
+		Long: `Run the examples of tools against their sources, comparing the rows
+they return to the expectedRows of the examples. Examples without
+expectedRows pass when the tool succeeds. Without tool names, the
+examples of every tool are run.
+Example:
+  toolbox test --config tools.yaml`,
+		RunE: func(c *cobra.Command, args []string) error {
+			return runTest(c, args, opts)
+		},
+	}
+	flags := cmd.Flags()
+	internal.ConfigFileFlags(cmd, flags, opts)
+	return cmd
+}
+
+func runTest(cmd *cobra.Command, args []string, opts *internal.ToolboxOptions) error {
+	ctx, cancel := context.WithCancel(cmd.Context())
+	defer cancel()
+
+	ctx, shutdown, err := opts.Setup(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = shutdown(ctx)
+	}()
+
+	_, err = opts.LoadConfig(ctx, &internal.ConfigParser{})
+	if err != nil {
+		return err
+	}
+
+	// Initialize Resources
+	sourcesMap, authServicesMap, embeddingModelsMap, toolsMap, toolsetsMap, promptsMap, promptsetsMap, resourcesMap, err := server.InitializeConfigs(ctx, opts.Cfg)
+	if err != nil {
+		errMsg := fmt.Errorf("failed to initialize resources: %w", err)
+		opts.Logger.ErrorContext(ctx, errMsg.Error())
+		return errMsg
+	}
+
+	primitiveMgr := primitives.NewPrimitiveManager(sourcesMap, authServicesMap, embeddingModelsMap, toolsMap, toolsetsMap, promptsMap, promptsetsMap, resourcesMap)
+
+	names := args
+	if len(names) == 0 {
+		for name := range toolsMap {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+	}
+	var selected []tools.Tool
+	for _, name := range names {
+		tool, ok := primitiveMgr.GetTool(name)
+		if !ok {
+			errMsg := fmt.Errorf("tool %q not found", name)
+			opts.Logger.ErrorContext(ctx, errMsg.Error())
+			return errMsg
+		}
+		selected = append(selected, tool)
+	}
+
+	r := &runner{mgr: primitiveMgr, sources: sourcesMap, out: opts.IOStreams.Out}
+	for _, tool := range selected {
+		r.runTool(ctx, tool)
+	}
+
+	total := r.passed + r.failed + r.skipped
+	if total == 0 {
+		return fmt.Errorf("no tool examples found to run")
+	}
+	fmt.Fprintf(opts.IOStreams.Out, "%d passed, %d failed, %d skipped\n", r.passed, r.failed, r.skipped)
+	if r.failed > 0 {
+		return fmt.Errorf("%d of %d examples failed", r.failed, total)
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/googleapis/mcp-toolbox/cmd/internal"
+	_ "github.com/googleapis/mcp-toolbox/internal/sources/sqlite"
+	_ "github.com/googleapis/mcp-toolbox/internal/tools/sqlite/sqlitesql"
+	"github.com/spf13/cobra"
+)
+
+func invokeCommand(args []string) (string, error) {
+	parentCmd := &cobra.Command{
+		Use:           "toolbox",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	buf := new(bytes.Buffer)
+	opts := internal.NewToolboxOptions(internal.WithIOStreams(buf, buf))
+	internal.PersistentFlags(parentCmd, opts)
+
+	cmd := NewCommand(opts)
+	parentCmd.AddCommand(cmd)
+	// INFO logs share the output stream and list every tool name.
+	parentCmd.SetArgs(append([]string{"--log-level", "ERROR"}, args...))
+
+	err := parentCmd.Execute()
+	return buf.String(), err
+}
+
+const toolsFileContent = `
+kind: source
+name: my-sqlite
+type: sqlite
+database: ":memory:"
+---
+kind: tool
+name: echo
+type: sqlite-sql
+source: my-sqlite
+description: echo a message
+statement: SELECT ? AS msg, ? AS times
+parameters:
+  - name: message
+    type: string
+    description: message to echo
+  - name: times
+    type: integer
+    description: number of times
+examples:
+  - description: hello
+    parameters:
+      message: hello
+      times: 2
+    expectedRows:
+      - msg: hello
+        times: 2
+  - parameters:
+      message: no expectations
+      times: 1
+---
+kind: tool
+name: broken
+type: sqlite-sql
+source: my-sqlite
+description: a tool whose example is wrong
+statement: SELECT 'actual' AS msg
+examples:
+  - expectedRows:
+      - msg: expected
+---
+kind: tool
+name: undocumented
+type: sqlite-sql
+source: my-sqlite
+description: a tool without examples
+statement: SELECT 1
+`
+
+func writeToolsFile(t *testing.T) string {
+	path := filepath.Join(t.TempDir(), "tools.yaml")
+	if err := os.WriteFile(path, []byte(toolsFileContent), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	return path
+}
+
+func TestRunExamples(t *testing.T) {
+	toolsFilePath := writeToolsFile(t)
+
+	tcs := []struct {
+		desc    string
+		args    []string
+		want    []string
+		notWant []string
+		errStr  string
+	}{
+		{
+			desc: "passing examples",
+			args: []string{"test", "echo", "--config", toolsFilePath},
+			want: []string{
+				"PASS echo example 1 (hello)",
+				"PASS echo example 2",
+				"2 passed, 0 failed, 0 skipped",
+			},
+			notWant: []string{"broken"},
+		},
+		{
+			desc: "all tools",
+			args: []string{"test", "--config", toolsFilePath},
+			want: []string{
+				"FAIL broken example 1: unexpected rows",
+				`"expected"`,
+				`"actual"`,
+				"PASS echo example 1 (hello)",
+				"2 passed, 1 failed, 0 skipped",
+			},
+			errStr: "1 of 3 examples failed",
+		},
+		{
+			desc:   "tool without examples",
+			args:   []string{"test", "undocumented", "--config", toolsFilePath},
+			errStr: "no tool examples found to run",
+		},
+		{
+			desc:   "unknown tool",
+			args:   []string{"test", "missing", "--config", toolsFilePath},
+			errStr: `tool "missing" not found`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := invokeCommand(tc.args)
+			if tc.errStr == "" && err != nil {
+				t.Fatalf("unexpected error: %s, output: %s", err, got)
+			}
+			if tc.errStr != "" && (err == nil || !strings.Contains(err.Error(), tc.errStr)) {
+				t.Fatalf("got error %v, want error containing %q", err, tc.errStr)
+			}
+			for _, w := range tc.want {
+				if !strings.Contains(got, w) {
+					t.Errorf("output %q does not contain %q", got, w)
+				}
+			}
+			for _, w := range tc.notWant {
+				if strings.Contains(got, w) {
+					t.Errorf("output %q unexpectedly contains %q", got, w)
+				}
+			}
+		})
+	}
+}
+
+func TestCompareRows(t *testing.T) {
+	expected := []map[string]any{{"id": uint64(1), "score": 1.5, "name": "a"}}
+	if err := compareRows(expected, []any{map[string]any{"name": "a", "id": int64(1), "score": float32(1.5)}}); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if err := compareRows(expected, []any{}); err == nil {
+		t.Errorf("expected rows to differ")
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/internal/server/primitives"
+	"github.com/googleapis/mcp-toolbox/internal/sources"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+// errSkipped reports an example that cannot run from the CLI.
+var errSkipped = errors.New("skipped")
+
+// runner runs the examples of tools, reporting each to out.
+type runner struct {
+	mgr     *primitives.PrimitiveManager
+	sources map[string]sources.Source
+	out     io.Writer
+
+	passed, failed, skipped int
+}
+
+// runTool runs the examples of tool.
+func (r *runner) runTool(ctx context.Context, tool tools.Tool) {
+	c, ok := tool.ToConfig().(interface{ GetExamples() []tools.Example })
+	if !ok {
+		return
+	}
+	for i, example := range c.GetExamples() {
+		label := fmt.Sprintf("%s example %d", tool.GetName(), i+1)
+		if example.Description != "" {
+			label += fmt.Sprintf(" (%s)", example.Description)
+		}
+		err := r.runExample(ctx, tool, example)
+		switch {
+		case err == nil:
+			r.passed++
+			fmt.Fprintf(r.out, "PASS %s\n", label)
+		case errors.Is(err, errSkipped):
+			r.skipped++
+			fmt.Fprintf(r.out, "SKIP %s: %s\n", label, err)
+		default:
+			r.failed++
+			fmt.Fprintf(r.out, "FAIL %s: %s\n", label, err)
+		}
+	}
+}
+
+// runExample invokes tool with the parameters of example and compares the
+// result to its expected rows.
+func (r *runner) runExample(ctx context.Context, tool tools.Tool, example tools.Example) error {
+	// Client Auth not supported for ephemeral CLI call
+	requiresAuth, err := tool.RequiresClientAuthorization(r.mgr)
+	if err != nil {
+		return fmt.Errorf("failed to check auth requirements: %w", err)
+	}
+	if requiresAuth {
+		return fmt.Errorf("client authorization is not supported: %w", errSkipped)
+	}
+
+	toolParams, err := tool.GetParameters(r.sources)
+	if err != nil {
+		return fmt.Errorf("error getting parameters for tool: %w", err)
+	}
+	// round trip the parameters through JSON, so that they reach the tool
+	// with the types of a request
+	params := make(map[string]any)
+	if err := normalize(example.Parameters, &params); err != nil {
+		return fmt.Errorf("invalid parameters: %w", err)
+	}
+	parsedParams, err := parameters.ParseParams(toolParams, params, nil)
+	if err != nil {
+		return fmt.Errorf("invalid parameters: %w", err)
+	}
+	parsedParams, err = tool.EmbedParams(ctx, parsedParams, r.mgr.GetEmbeddingModelMap())
+	if err != nil {
+		return fmt.Errorf("error embedding parameters: %w", err)
+	}
+
+	result, toolErr := tool.Invoke(ctx, r.mgr, parsedParams, "")
+	if toolErr != nil {
+		return fmt.Errorf("tool execution failed: %w", toolErr)
+	}
+	if example.ExpectedRows == nil {
+		return nil
+	}
+	return compareRows(example.ExpectedRows, result)
+}
+
+// compareRows reports how result differs from the expected rows, comparing
+// their JSON representations.
+func compareRows(expected []map[string]any, result any) error {
+	var want, got any
+	if err := normalize(expected, &want); err != nil {
+		return fmt.Errorf("invalid expectedRows: %w", err)
+	}
+	if err := normalize(result, &got); err != nil {
+		return fmt.Errorf("unable to encode result: %w", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		return fmt.Errorf("unexpected rows (-want +got):\n%s", diff)
+	}
+	return nil
+}
+
+// normalize decodes the JSON representation of v into out.
+func normalize(v any, out any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return util.DecodeJSON(bytes.NewReader(b), out)
+}
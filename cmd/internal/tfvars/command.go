@@ -0,0 +1,123 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tfvars
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/googleapis/mcp-toolbox/cmd/internal"
+	"github.com/spf13/cobra"
+)
+
+// tfvarsCmd is the command for exporting source configurations as Terraform
+// variables.
+type tfvarsCmd struct {
+	*cobra.Command
+	output        string
+	secretsOutput string
+}
+
+// NewCommand creates a new Command.
+func NewCommand(opts *internal.ToolboxOptions) *cobra.Command {
+	cmd := &tfvarsCmd{}
+	cmd.Command = &cobra.Command{
+		Use:   "export-tf-vars",
+		Short: "Export source configurations as Terraform variables",
+		Long:  "Export the fields of every source as Terraform variables in a tfvars file. Secret values, such as passwords, are written to a separate file.",
+		Args:  cobra.NoArgs,
+		RunE: func(c *cobra.Command, args []string) error {
+			return run(cmd, opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	internal.ConfigFileFlags(cmd.Command, flags, opts)
+	flags.StringVarP(&cmd.output, "output", "o", "", "File to write the variables to. Defaults to stdout.")
+	flags.StringVar(&cmd.secretsOutput, "secrets-output", "", "File to write the secret variables to. If not provided, secret values are not exported.")
+	return cmd.Command
+}
+
+func run(cmd *tfvarsCmd, opts *internal.ToolboxOptions) error {
+	ctx, cancel := context.WithCancel(cmd.Context())
+	defer cancel()
+
+	ctx, shutdown, err := opts.Setup(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = shutdown(ctx)
+	}()
+
+	// export-tf-vars runs offline, so unset environment variables of the
+	// sources resolve to "".
+	parser := internal.ConfigParser{AllowMissingEnvVars: true}
+	if _, err := opts.LoadConfig(ctx, &parser); err != nil {
+		return err
+	}
+	if len(opts.Cfg.SourceConfigs) == 0 {
+		return fmt.Errorf("no sources found to export")
+	}
+
+	plain, secret, err := export(opts.Cfg.SourceConfigs)
+	if err != nil {
+		errMsg := fmt.Errorf("error exporting sources: %w", err)
+		opts.Logger.ErrorContext(ctx, errMsg.Error())
+		return errMsg
+	}
+
+	header := "# Generated by `toolbox export-tf-vars`.\n"
+	if len(secret) > 0 {
+		header += "#\n# Secret values are not included. Reference them as variables, set in a\n# separate secrets file:\n"
+		for _, v := range secret {
+			header += "#   var." + v.Name + "\n"
+		}
+	}
+	content := render(header+"\n", plain)
+	if cmd.output == "" {
+		if _, err := fmt.Fprint(opts.IOStreams.Out, content); err != nil {
+			return err
+		}
+	} else if err := os.WriteFile(cmd.output, []byte(content), 0644); err != nil {
+		errMsg := fmt.Errorf("error writing variables: %w", err)
+		opts.Logger.ErrorContext(ctx, errMsg.Error())
+		return errMsg
+	}
+
+	if len(secret) > 0 && cmd.secretsOutput == "" {
+		names := make([]string, len(secret))
+		for i, v := range secret {
+			names[i] = v.Name
+		}
+		opts.Logger.WarnContext(ctx, fmt.Sprintf("secret values were not exported, use --secrets-output to write them: %s", strings.Join(names, ", ")))
+	}
+	if cmd.secretsOutput != "" {
+		secretsHeader := fmt.Sprintf("# Generated by `toolbox export-tf-vars`.\n#\n# This file contains secret values. Do not commit it; add it to .gitignore:\n#   echo %q >> .gitignore\n\n", filepath.Base(cmd.secretsOutput))
+		// secrets are readable by the owner only
+		if err := os.WriteFile(cmd.secretsOutput, []byte(render(secretsHeader, secret)), 0600); err != nil {
+			errMsg := fmt.Errorf("error writing secret variables: %w", err)
+			opts.Logger.ErrorContext(ctx, errMsg.Error())
+			return errMsg
+		}
+	}
+	if cmd.output != "" {
+		opts.Logger.InfoContext(ctx, fmt.Sprintf("Successfully exported %d sources to %s.", len(opts.Cfg.SourceConfigs), cmd.output))
+	}
+	return nil
+}
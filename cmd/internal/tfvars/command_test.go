@@ -0,0 +1,102 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tfvars
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/googleapis/mcp-toolbox/cmd/internal"
+	_ "github.com/googleapis/mcp-toolbox/internal/sources/postgres"
+	"github.com/spf13/cobra"
+)
+
+func invokeCommand(args []string) (string, error) {
+	parentCmd := &cobra.Command{
+		Use:           "toolbox",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	buf := new(bytes.Buffer)
+	opts := internal.NewToolboxOptions(internal.WithIOStreams(buf, buf))
+	internal.PersistentFlags(parentCmd, opts)
+
+	cmd := NewCommand(opts)
+	parentCmd.AddCommand(cmd)
+	parentCmd.SetArgs(args)
+
+	err := parentCmd.Execute()
+	return buf.String(), err
+}
+
+const toolsFileContent = `
+kind: source
+name: my-pg
+type: postgres
+host: 127.0.0.1
+port: "5432"
+database: my_db
+user: my_user
+password: my_pass
+`
+
+func TestExportTFVars(t *testing.T) {
+	dir := t.TempDir()
+	toolsFilePath := filepath.Join(dir, "tools.yaml")
+	if err := os.WriteFile(toolsFilePath, []byte(toolsFileContent), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	outputPath := filepath.Join(dir, "terraform.tfvars")
+	secretsPath := filepath.Join(dir, "secrets.tfvars")
+
+	if _, err := invokeCommand([]string{"export-tf-vars", "--config", toolsFilePath, "--output", outputPath, "--secrets-output", secretsPath}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	vars, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to read variables: %v", err)
+	}
+	for _, want := range []string{`my_pg_host `, `= "127.0.0.1"`, `my_pg_type `, `= "postgres"`, "#   var.my_pg_password\n"} {
+		if !strings.Contains(string(vars), want) {
+			t.Errorf("variables %q do not contain %q", vars, want)
+		}
+	}
+	if strings.Contains(string(vars), "my_pass") {
+		t.Errorf("variables %q contain a secret value", vars)
+	}
+
+	secrets, err := os.ReadFile(secretsPath)
+	if err != nil {
+		t.Fatalf("failed to read secret variables: %v", err)
+	}
+	if !strings.Contains(string(secrets), "my_pg_password = \"my_pass\"\n") {
+		t.Errorf("secret variables %q do not contain the password", secrets)
+	}
+	if !strings.Contains(string(secrets), `echo "secrets.tfvars" >> .gitignore`) {
+		t.Errorf("secret variables %q do not suggest ignoring the file", secrets)
+	}
+	info, err := os.Stat(secretsPath)
+	if err != nil {
+		t.Fatalf("failed to stat secret variables: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("unexpected permissions of secret variables: %o", perm)
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tfvars
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/mcp-toolbox/internal/server"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+)
+
+// variable is a Terraform variable exported from a field of a source.
+type variable struct {
+	Name  string
+	Value any
+}
+
+// export returns the variables of the fields of the sources, in source and
+// field name order, split into plain and secret variables. Empty fields are
+// skipped.
+func export(sourceConfigs server.SourceConfigs) ([]variable, []variable, error) {
+	names := make([]string, 0, len(sourceConfigs))
+	for name := range sourceConfigs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var plain, secret []variable
+	for _, name := range names {
+		out, err := yaml.Marshal(sourceConfigs[name])
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to marshal source %q: %w", name, err)
+		}
+		fields := map[string]any{}
+		if err := yaml.Unmarshal(out, &fields); err != nil {
+			return nil, nil, fmt.Errorf("unable to unmarshal source %q: %w", name, err)
+		}
+		delete(fields, "name")
+
+		keys := make([]string, 0, len(fields))
+		for k := range fields {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if isEmpty(fields[k]) {
+				continue
+			}
+			v := variable{Name: identifier(name) + "_" + snakeCase(k), Value: fields[k]}
+			if util.IsSecretField(k) {
+				secret = append(secret, v)
+			} else {
+				plain = append(plain, v)
+			}
+		}
+	}
+	return plain, secret, nil
+}
+
+func isEmpty(v any) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case []any:
+		return len(v) == 0
+	case map[string]any:
+		return len(v) == 0
+	}
+	return false
+}
+
+var nonIdentifier = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// identifier turns a source name into a Terraform identifier.
+func identifier(name string) string {
+	id := nonIdentifier.ReplaceAllString(strings.ToLower(name), "_")
+	if id == "" || !unicode.IsLetter(rune(id[0])) {
+		id = "source_" + id
+	}
+	return id
+}
+
+// snakeCase turns a camelCase field name into snake_case.
+func snakeCase(field string) string {
+	var b strings.Builder
+	for i, r := range field {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return identifier(b.String())
+}
+
+// render renders variables as a tfvars file, starting with the comment
+// header.
+func render(header string, vars []variable) string {
+	var b strings.Builder
+	b.WriteString(header)
+	width := 0
+	for _, v := range vars {
+		width = max(width, len(v.Name))
+	}
+	for _, v := range vars {
+		fmt.Fprintf(&b, "%-*s = %s\n", width, v.Name, hclValue(v.Value, ""))
+	}
+	return b.String()
+}
+
+// hclValue renders a YAML value as an HCL expression.
+func hclValue(v any, indent string) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return hclString(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case []any:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = hclValue(item, indent)
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		var b strings.Builder
+		b.WriteString("{\n")
+		for _, k := range keys {
+			fmt.Fprintf(&b, "%s  %s = %s\n", indent, hclString(k), hclValue(v[k], indent+"  "))
+		}
+		b.WriteString(indent + "}")
+		return b.String()
+	default:
+		// numbers
+		return fmt.Sprint(v)
+	}
+}
+
+var hclEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	`"`, `\"`,
+	"\n", `\n`,
+	"\r", `\r`,
+	"\t", `\t`,
+	"${", "$${",
+	"%{", "%%{",
+)
+
+// hclString renders s as an HCL string literal, escaping its template
+// sequences.
+func hclString(s string) string {
+	return `"` + hclEscaper.Replace(s) + `"`
+}
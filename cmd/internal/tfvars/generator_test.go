@@ -0,0 +1,56 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tfvars
+
+import "testing"
+
+func TestHCLValue(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   any
+		want string
+	}{
+		{desc: "string", in: "a \"quoted\"\nline", want: `"a \"quoted\"\nline"`},
+		{desc: "template sequences", in: "${var.x} %{if}", want: `"$${var.x} %%{if}"`},
+		{desc: "number", in: uint64(5), want: "5"},
+		{desc: "bool", in: true, want: "true"},
+		{desc: "list", in: []any{"a", uint64(1)}, want: `["a", 1]`},
+		{desc: "map", in: map[string]any{"b": "2", "a": "1"}, want: "{\n  \"a\" = \"1\"\n  \"b\" = \"2\"\n}"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := hclValue(tc.in, ""); got != tc.want {
+				t.Errorf("unexpected value: got %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNames(t *testing.T) {
+	tcs := []struct {
+		in, want string
+		convert  func(string) string
+	}{
+		{in: "my-pg-instance", want: "my_pg_instance", convert: identifier},
+		{in: "1st source", want: "source_1st_source", convert: identifier},
+		{in: "queryParams", want: "query_params", convert: snakeCase},
+		{in: "ipType", want: "ip_type", convert: snakeCase},
+	}
+	for _, tc := range tcs {
+		if got := tc.convert(tc.in); got != tc.want {
+			t.Errorf("unexpected name for %q: got %q, want %q", tc.in, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,415 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/goccy/go-yaml/ast"
+	"github.com/goccy/go-yaml/parser"
+	"github.com/googleapis/mcp-toolbox/cmd/internal"
+	"github.com/googleapis/mcp-toolbox/internal/sources"
+	"github.com/googleapis/mcp-toolbox/internal/sources/secrets"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Output formats of the diagnostics.
+const (
+	formatJSON = "json"
+	formatText = "text"
+)
+
+// Severities of the diagnostics.
+const (
+	severityError   = "error"
+	severityWarning = "warning"
+)
+
+// validateCmd is the command for validating tool configurations.
+type validateCmd struct {
+	*cobra.Command
+	format string
+	ping   bool
+}
+
+// NewCommand creates a new Command.
+func NewCommand(opts *internal.ToolboxOptions) *cobra.Command {
+	cmd := &validateCmd{}
+	cmd.Command = &cobra.Command{
+		Use:   "validate",
+		Short: "Validate tool configurations",
+		Long: `Validate tool configurations without starting a server. The config files are
+parsed, and the references of tools to sources, of toolsets to tools and
+resources, and of tools to auth services are checked. With --ping, every source
+is also initialized, which connects to it. Every problem found is reported as a
+diagnostic with its file and line, and the command exits with an error if any
+is an error.
+Example:
+  toolbox validate --config tools.yaml --format json`,
+		Args: cobra.NoArgs,
+		RunE: func(c *cobra.Command, args []string) error {
+			return run(cmd, opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	internal.ConfigFileFlags(cmd.Command, flags, opts)
+	flags.StringVar(&cmd.format, "format", formatText, "Format of the diagnostics: 'text' or 'json'.")
+	flags.BoolVar(&cmd.ping, "ping", false, "Initialize every source, connecting to it, to check that it is reachable.")
+	return cmd.Command
+}
+
+// diagnostic is a problem found in the configuration.
+type diagnostic struct {
+	Severity string `json:"severity"`
+	// Kind and Name identify the document of the problem, when known.
+	Kind string `json:"kind,omitempty"`
+	Name string `json:"name,omitempty"`
+	// Field is the field of the document holding the problem.
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+	File    string `json:"file,omitempty"`
+	Line    int    `json:"line,omitempty"`
+	Column  int    `json:"column,omitempty"`
+}
+
+// report is the result of a validation.
+type report struct {
+	Valid       bool         `json:"valid"`
+	Diagnostics []diagnostic `json:"diagnostics"`
+}
+
+func run(cmd *validateCmd, opts *internal.ToolboxOptions) error {
+	if cmd.format != formatJSON && cmd.format != formatText {
+		return fmt.Errorf("invalid --format %q, must be %q or %q", cmd.format, formatText, formatJSON)
+	}
+
+	ctx, cancel := context.WithCancel(cmd.Context())
+	defer cancel()
+
+	// logs go to stderr, leaving stdout to the diagnostics
+	out := opts.IOStreams.Out
+	opts.IOStreams.Out = opts.IOStreams.ErrOut
+	ctx, shutdown, err := opts.Setup(ctx)
+	opts.IOStreams.Out = out
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = shutdown(ctx)
+	}()
+
+	diags := validate(ctx, opts, cmd.ping)
+	r := report{Valid: true, Diagnostics: diags}
+	for _, d := range diags {
+		if d.Severity == severityError {
+			r.Valid = false
+		}
+	}
+
+	if cmd.format == formatJSON {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(r); err != nil {
+			return fmt.Errorf("unable to write diagnostics: %w", err)
+		}
+	} else {
+		for _, d := range diags {
+			fmt.Fprintln(out, d.String())
+		}
+	}
+	if !r.Valid {
+		return fmt.Errorf("configuration is invalid")
+	}
+	return nil
+}
+
+// String formats the diagnostic as file:line:column: severity: message.
+func (d diagnostic) String() string {
+	var b strings.Builder
+	if d.File != "" {
+		b.WriteString(d.File)
+		if d.Line > 0 {
+			fmt.Fprintf(&b, ":%d:%d", d.Line, d.Column)
+		}
+		b.WriteString(": ")
+	}
+	fmt.Fprintf(&b, "%s: ", d.Severity)
+	if d.Kind != "" {
+		fmt.Fprintf(&b, "%s %q: ", d.Kind, d.Name)
+	}
+	b.WriteString(d.Message)
+	return b.String()
+}
+
+// validate returns the diagnostics of the configuration of opts.
+func validate(ctx context.Context, opts *internal.ToolboxOptions, ping bool) []diagnostic {
+	diags := []diagnostic{}
+	paths, _, err := opts.GetCustomConfigFiles(ctx)
+	if err != nil {
+		return append(diags, diagnostic{Severity: severityError, Message: err.Error()})
+	}
+
+	// Without --ping nothing connects to the sources, so unset environment
+	// variables resolve to "".
+	newParser := func() *internal.ConfigParser { return &internal.ConfigParser{AllowMissingEnvVars: !ping} }
+
+	// Each file is parsed on its own first, to report the errors of every
+	// file with its name.
+	locs := make(locations)
+	for _, path := range paths {
+		raw, err := internal.ReadConfigFile(ctx, path)
+		if err != nil {
+			diags = append(diags, diagnostic{Severity: severityError, File: path, Message: fmt.Sprintf("unable to read config file: %s", err)})
+			continue
+		}
+		if _, err := newParser().ParseConfig(ctx, raw); err != nil {
+			diags = append(diags, parseDiagnostics(path, err)...)
+			continue
+		}
+		locs.index(path, raw)
+	}
+	if len(diags) > 0 {
+		return diags
+	}
+
+	if _, err := opts.LoadConfig(ctx, newParser()); err != nil {
+		return append(diags, diagnostic{Severity: severityError, Message: err.Error()})
+	}
+	cfg := opts.Cfg
+
+	add := func(severity, kind, name, field, message string) {
+		d := diagnostic{Severity: severity, Kind: kind, Name: name, Field: field, Message: message}
+		if l, ok := locs.find(kind, name, field); ok {
+			d.File, d.Line, d.Column = l.file, l.line, l.column
+		}
+		diags = append(diags, d)
+	}
+
+	sourceTypes := make(map[string]string, len(cfg.SourceConfigs))
+	for name, sc := range cfg.SourceConfigs {
+		sourceTypes[name] = sc.SourceConfigType()
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(cfg.ToolConfigs)) {
+		tc := cfg.ToolConfigs[name]
+		if source, ok := tools.SourceNameOf(tc); ok {
+			if _, ok := sourceTypes[source]; !ok {
+				add(severityError, "tool", name, "source", fmt.Sprintf("references source %q, which does not exist", source))
+				continue
+			}
+		}
+		if err := tools.VerifySource(name, tc, sourceTypes); err != nil {
+			add(severityError, "tool", name, "source", err.Error())
+			continue
+		}
+		if err := tools.VerifySourceParameter(name, tc, sourceTypes); err != nil {
+			add(severityError, "tool", name, "sourceParameter", err.Error())
+			continue
+		}
+		t, err := tc.Initialize(ctx)
+		if err != nil {
+			add(severityError, "tool", name, "", err.Error())
+			continue
+		}
+		for _, service := range t.GetAuthRequired() {
+			if _, ok := cfg.AuthServiceConfigs[service]; !ok {
+				add(severityError, "tool", name, "authRequired", fmt.Sprintf("requires auth service %q, which does not exist", service))
+			}
+		}
+		params, err := t.GetParameters(nil)
+		if err != nil {
+			continue
+		}
+		for _, p := range params {
+			for _, service := range p.GetAuthServices() {
+				if _, ok := cfg.AuthServiceConfigs[service.Name]; !ok {
+					add(severityError, "tool", name, "parameters", fmt.Sprintf("parameter %q is resolved from auth service %q, which does not exist", p.GetName(), service.Name))
+				}
+			}
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(cfg.ToolsetConfigs)) {
+		tc := cfg.ToolsetConfigs[name]
+		for _, tool := range tc.ToolNames {
+			if _, ok := cfg.ToolConfigs[tool]; !ok {
+				add(severityError, "toolset", name, "tools", fmt.Sprintf("references tool %q, which does not exist", tool))
+			}
+		}
+		for _, resource := range tc.ResourceNames {
+			if _, ok := cfg.ResourceConfigs[resource]; !ok {
+				add(severityError, "toolset", name, "resources", fmt.Sprintf("references resource %q, which does not exist", resource))
+			}
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(cfg.ResourceConfigs)) {
+		rc := cfg.ResourceConfigs[name]
+		if rc.Source != "" {
+			if _, ok := sourceTypes[rc.Source]; !ok {
+				add(severityError, "resource", name, "source", fmt.Sprintf("references source %q, which does not exist", rc.Source))
+			}
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(cfg.SchemaToolsConfigs)) {
+		if source := cfg.SchemaToolsConfigs[name].Source; sourceTypes[source] == "" {
+			add(severityError, "schemaTools", name, "source", fmt.Sprintf("references source %q, which does not exist", source))
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(cfg.DbtToolsConfigs)) {
+		c := cfg.DbtToolsConfigs[name]
+		if sourceTypes[c.Source] == "" {
+			add(severityError, "dbtTools", name, "source", fmt.Sprintf("references source %q, which does not exist", c.Source))
+		}
+		if _, err := os.Stat(c.Manifest); err != nil {
+			add(severityError, "dbtTools", name, "manifest", fmt.Sprintf("unable to read manifest: %s", err))
+		}
+	}
+
+	if ping {
+		instrumentation, err := util.InstrumentationFromContext(ctx)
+		if err != nil {
+			return append(diags, diagnostic{Severity: severityError, Message: err.Error()})
+		}
+		for _, name := range slices.Sorted(maps.Keys(cfg.SourceConfigs)) {
+			if err := pingSource(ctx, instrumentation.Tracer, cfg.SourceConfigs[name]); err != nil {
+				add(severityError, "source", name, "", err.Error())
+			}
+		}
+	} else if len(cfg.SourceConfigs) > 0 {
+		diags = append(diags, diagnostic{Severity: severityWarning, Message: "sources were not contacted, use --ping to check that they are reachable"})
+	}
+	return diags
+}
+
+// pingSource initializes a source, which connects to it, and closes it.
+func pingSource(ctx context.Context, tracer trace.Tracer, sc sources.SourceConfig) error {
+	sc, err := secrets.Resolve(ctx, sc)
+	if err != nil {
+		return err
+	}
+	s, err := sc.Initialize(ctx, tracer)
+	if err != nil {
+		return err
+	}
+	if c, ok := s.(sources.Closer); ok {
+		_ = c.Close()
+	}
+	return nil
+}
+
+// docLocation matches the position that the errors of the config parser
+// report.
+var docLocation = regexp.MustCompile(`\(line (\d+), column (\d+)\)`)
+
+// parseDiagnostics returns a diagnostic for each error of a config file,
+// at the position the error reports.
+func parseDiagnostics(path string, err error) []diagnostic {
+	errs := []error{err}
+	var joined interface{ Unwrap() []error }
+	if errors.As(err, &joined) {
+		errs = joined.Unwrap()
+	}
+	var diags []diagnostic
+	for _, e := range errs {
+		d := diagnostic{Severity: severityError, File: path, Message: e.Error()}
+		if m := docLocation.FindStringSubmatch(e.Error()); m != nil {
+			d.Line, _ = strconv.Atoi(m[1])
+			d.Column, _ = strconv.Atoi(m[2])
+		}
+		diags = append(diags, d)
+	}
+	return diags
+}
+
+// location is a position in a config file.
+type location struct {
+	file         string
+	line, column int
+}
+
+// locations holds the positions of the documents of the config files, keyed
+// by kind and name, and of their fields, keyed by kind, name and field.
+type locations map[string]location
+
+func locationKey(parts ...string) string {
+	return strings.Join(parts, "\x00")
+}
+
+// index records the positions of the documents of a config file. Files in
+// the legacy format, without kinds, are not indexed.
+func (l locations) index(path string, raw []byte) {
+	file, err := parser.ParseBytes(raw, 0)
+	if err != nil {
+		return
+	}
+	for _, doc := range file.Docs {
+		mapping, ok := doc.Body.(*ast.MappingNode)
+		if !ok {
+			continue
+		}
+		var kind, name string
+		var nameValue *ast.MappingValueNode
+		for _, v := range mapping.Values {
+			s, ok := v.Value.(*ast.StringNode)
+			if !ok {
+				continue
+			}
+			switch v.Key.String() {
+			case "kind":
+				kind = s.Value
+			case "name":
+				name = s.Value
+				nameValue = v
+			}
+		}
+		if kind == "" || name == "" {
+			continue
+		}
+		pos := nameValue.Key.GetToken().Position
+		l[locationKey(kind, name)] = location{file: path, line: pos.Line, column: pos.Column}
+		for _, v := range mapping.Values {
+			pos := v.Key.GetToken().Position
+			l[locationKey(kind, name, v.Key.String())] = location{file: path, line: pos.Line, column: pos.Column}
+		}
+	}
+}
+
+// find returns the position of the field of a document, or of the
+// document when the field is not set or not known.
+func (l locations) find(kind, name, field string) (location, bool) {
+	if field != "" {
+		if loc, ok := l[locationKey(kind, name, field)]; ok {
+			return loc, true
+		}
+	}
+	loc, ok := l[locationKey(kind, name)]
+	return loc, ok
+}
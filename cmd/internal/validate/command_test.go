@@ -0,0 +1,165 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/cmd/internal"
+	_ "github.com/googleapis/mcp-toolbox/internal/sources/postgres"
+	_ "github.com/googleapis/mcp-toolbox/internal/tools/postgres/postgressql"
+	"github.com/spf13/cobra"
+)
+
+func invokeCommand(args []string) (string, error) {
+	parentCmd := &cobra.Command{
+		Use:           "toolbox",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	out, errOut := new(bytes.Buffer), new(bytes.Buffer)
+	opts := internal.NewToolboxOptions(internal.WithIOStreams(out, errOut))
+	internal.PersistentFlags(parentCmd, opts)
+
+	cmd := NewCommand(opts)
+	parentCmd.AddCommand(cmd)
+	parentCmd.SetArgs(args)
+
+	err := parentCmd.Execute()
+	return out.String(), err
+}
+
+const validConfig = `kind: source
+name: my-pg
+type: postgres
+host: ${PG_HOST}
+port: "5432"
+database: shop
+user: toolbox
+password: secret
+---
+kind: tool
+name: search-users
+type: postgres-sql
+source: my-pg
+description: search users by region
+statement: SELECT * FROM users WHERE region = $1
+parameters:
+  - name: region
+    type: string
+    description: region of the users
+---
+kind: toolset
+name: users
+tools:
+  - search-users
+`
+
+const invalidConfig = `kind: tool
+name: search-users
+type: postgres-sql
+source: my-pg
+description: search users by region
+authRequired:
+  - my-google-auth
+statement: SELECT * FROM users WHERE region = $1
+---
+kind: toolset
+name: users
+tools:
+  - search-users
+  - count-users
+`
+
+func writeConfig(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "tools.yaml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	return path
+}
+
+func TestValidateJSON(t *testing.T) {
+	path := writeConfig(t, invalidConfig)
+	got, err := invokeCommand([]string{"validate", "--format", "json", "--config", path})
+	if err == nil || !strings.Contains(err.Error(), "configuration is invalid") {
+		t.Fatalf("got error %v, want the configuration to be invalid", err)
+	}
+	var r report
+	if err := json.Unmarshal([]byte(got), &r); err != nil {
+		t.Fatalf("output is not a JSON report: %s\n%s", err, got)
+	}
+	want := report{Diagnostics: []diagnostic{
+		{Severity: severityError, Kind: "tool", Name: "search-users", Field: "source", Message: `references source "my-pg", which does not exist`, File: path, Line: 4, Column: 1},
+		{Severity: severityError, Kind: "toolset", Name: "users", Field: "tools", Message: `references tool "count-users", which does not exist`, File: path, Line: 12, Column: 1},
+	}}
+	if diff := cmp.Diff(want, r); diff != "" {
+		t.Fatalf("incorrect report: diff %v", diff)
+	}
+}
+
+func TestValidateText(t *testing.T) {
+	source := "kind: source\nname: my-pg\ntype: postgres\nhost: localhost\nport: \"5432\"\ndatabase: shop\nuser: toolbox\npassword: secret\n---\n"
+	path := writeConfig(t, source+invalidConfig)
+	got, err := invokeCommand([]string{"validate", "--config", path})
+	if err == nil {
+		t.Fatalf("expected the configuration to be invalid")
+	}
+	for _, want := range []string{
+		`error: tool "search-users": requires auth service "my-google-auth", which does not exist`,
+		`error: toolset "users": references tool "count-users", which does not exist`,
+		`warning: sources were not contacted`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("got output %q, want it to contain %q", got, want)
+		}
+	}
+}
+
+func TestValidateValid(t *testing.T) {
+	got, err := invokeCommand([]string{"validate", "--format", "json", "--config", writeConfig(t, validConfig)})
+	if err != nil {
+		t.Fatalf("unexpected error: %s\n%s", err, got)
+	}
+	var r report
+	if err := json.Unmarshal([]byte(got), &r); err != nil {
+		t.Fatalf("output is not a JSON report: %s\n%s", err, got)
+	}
+	if !r.Valid || len(r.Diagnostics) != 1 || r.Diagnostics[0].Severity != severityWarning {
+		t.Fatalf("unexpected report: %+v", r)
+	}
+}
+
+func TestValidateParseError(t *testing.T) {
+	path := writeConfig(t, "kind: tool\nname: search-users\ntype: postgres-sql\nsource: my-pg\ndescription: ${MISSING_DESCRIPTION}\n")
+	got, err := invokeCommand([]string{"validate", "--format", "json", "--ping", "--config", path})
+	if err == nil {
+		t.Fatalf("expected the configuration to be invalid")
+	}
+	var r report
+	if err := json.Unmarshal([]byte(got), &r); err != nil {
+		t.Fatalf("output is not a JSON report: %s\n%s", err, got)
+	}
+	if len(r.Diagnostics) != 1 || r.Diagnostics[0].Line != 5 || r.Diagnostics[0].File != path {
+		t.Fatalf("unexpected report: %+v", r)
+	}
+}
@@ -32,13 +32,25 @@ import (
 	"github.com/fsnotify/fsnotify"
 	// Importing the cmd/internal package also import packages for side effect of registration
 	"github.com/googleapis/mcp-toolbox/cmd/internal"
+	"github.com/googleapis/mcp-toolbox/cmd/internal/alerts"
+	"github.com/googleapis/mcp-toolbox/cmd/internal/apigateway"
+	"github.com/googleapis/mcp-toolbox/cmd/internal/dashboard"
+	"github.com/googleapis/mcp-toolbox/cmd/internal/doc"
+	"github.com/googleapis/mcp-toolbox/cmd/internal/format"
 	"github.com/googleapis/mcp-toolbox/cmd/internal/invoke"
+	"github.com/googleapis/mcp-toolbox/cmd/internal/lint"
+	"github.com/googleapis/mcp-toolbox/cmd/internal/loadtest"
 	"github.com/googleapis/mcp-toolbox/cmd/internal/migrate"
+	"github.com/googleapis/mcp-toolbox/cmd/internal/migrationguide"
 	"github.com/googleapis/mcp-toolbox/cmd/internal/serve"
 	"github.com/googleapis/mcp-toolbox/cmd/internal/skills"
+	"github.com/googleapis/mcp-toolbox/cmd/internal/test"
+	"github.com/googleapis/mcp-toolbox/cmd/internal/tfvars"
+	"github.com/googleapis/mcp-toolbox/cmd/internal/validate"
 	"github.com/googleapis/mcp-toolbox/internal/auth"
 	"github.com/googleapis/mcp-toolbox/internal/embeddingmodels"
 	"github.com/googleapis/mcp-toolbox/internal/prompts"
+	"github.com/googleapis/mcp-toolbox/internal/resources"
 	"github.com/googleapis/mcp-toolbox/internal/server"
 	"github.com/googleapis/mcp-toolbox/internal/sources"
 	"github.com/googleapis/mcp-toolbox/internal/tools"
@@ -120,6 +132,7 @@ func NewCommand(opts *internal.ToolboxOptions) *cobra.Command {
 	flags.BoolVar(&opts.Cfg.DisableReload, "disable-reload", false, "Disables dynamic reloading of tools file.")
 	flags.BoolVar(&opts.Cfg.IgnoreUnknownTools, "ignore-unknown-tools", false, "Log warnings and skip unknown/unsupported tool types instead of failing to start.")
 	flags.IntVar(&opts.Cfg.PollInterval, "poll-interval", 0, "Specifies the polling frequency (seconds) for configuration file updates.")
+	flags.DurationVar(&opts.Cfg.ConfigRefreshInterval, "config-refresh-interval", time.Minute, "How often configuration files given as gs://, https:// or k8s:// URLs are checked for changes. 0 disables the refresh.")
 	// wrap RunE command so that we have access to original Command object
 	cmd.RunE = func(*cobra.Command, []string) error { return run(cmd, opts) }
 
@@ -128,6 +141,17 @@ func NewCommand(opts *internal.ToolboxOptions) *cobra.Command {
 	cmd.AddCommand(skills.NewCommand(opts))
 	cmd.AddCommand(serve.NewCommand(opts))
 	cmd.AddCommand(migrate.NewCommand(opts))
+	cmd.AddCommand(format.NewCommand(opts))
+	cmd.AddCommand(loadtest.NewCommand(opts))
+	cmd.AddCommand(test.NewCommand(opts))
+	cmd.AddCommand(doc.NewCommand(opts))
+	cmd.AddCommand(alerts.NewCommand(opts))
+	cmd.AddCommand(apigateway.NewCommand(opts))
+	cmd.AddCommand(tfvars.NewCommand(opts))
+	cmd.AddCommand(dashboard.NewCommand(opts))
+	cmd.AddCommand(lint.NewCommand(opts))
+	cmd.AddCommand(migrationguide.NewCommand(opts))
+	cmd.AddCommand(validate.NewCommand(opts))
 
 	return cmd
 }
@@ -138,22 +162,24 @@ func handleDynamicReload(ctx context.Context, toolsFile internal.Config, s *serv
 		panic(err)
 	}
 
-	sourcesMap, authServicesMap, embeddingModelsMap, toolsMap, toolsetsMap, promptsMap, promptsetsMap, err := validateReloadEdits(ctx, toolsFile)
+	sourcesMap, authServicesMap, embeddingModelsMap, toolsMap, toolsetsMap, promptsMap, promptsetsMap, resourcesMap, err := validateReloadEdits(ctx, toolsFile, s.PrimitiveMgr.GetSourcesMap())
 	if err != nil {
 		errMsg := fmt.Errorf("unable to validate reloaded edits: %w", err)
 		logger.WarnContext(ctx, errMsg.Error())
 		return err
 	}
 
-	s.PrimitiveMgr.SetPrimitives(sourcesMap, authServicesMap, embeddingModelsMap, toolsMap, toolsetsMap, promptsMap, promptsetsMap)
+	s.SwapPrimitives(ctx, sourcesMap, authServicesMap, embeddingModelsMap, toolsMap, toolsetsMap, promptsMap, promptsetsMap, resourcesMap)
+	s.SetSourceConfigs(toolsFile.Sources)
 
 	return nil
 }
 
-// validateReloadEdits checks that the reloaded config configs can initialized without failing
+// validateReloadEdits checks that the reloaded config configs can initialized without failing.
+// The current sources whose config is unchanged are carried over.
 func validateReloadEdits(
-	ctx context.Context, toolsFile internal.Config,
-) (map[string]sources.Source, map[string]auth.AuthService, map[string]embeddingmodels.EmbeddingModel, map[string]tools.Tool, map[string]tools.Toolset, map[string]prompts.Prompt, map[string]prompts.Promptset, error,
+	ctx context.Context, toolsFile internal.Config, currentSources map[string]sources.Source,
+) (map[string]sources.Source, map[string]auth.AuthService, map[string]embeddingmodels.EmbeddingModel, map[string]tools.Tool, map[string]tools.Toolset, map[string]prompts.Prompt, map[string]prompts.Promptset, map[string]resources.Resource, error,
 ) {
 	logger, err := util.LoggerFromContext(ctx)
 	if err != nil {
@@ -173,22 +199,26 @@ func validateReloadEdits(
 	reloadedConfig := server.ServerConfig{
 		Version:               versionString,
 		SourceConfigs:         toolsFile.Sources,
+		CurrentSources:        currentSources,
 		AuthServiceConfigs:    toolsFile.AuthServices,
 		EmbeddingModelConfigs: toolsFile.EmbeddingModels,
 		ToolConfigs:           toolsFile.Tools,
 		ToolsetConfigs:        toolsFile.Toolsets,
 		PromptConfigs:         toolsFile.Prompts,
+		ResourceConfigs:       toolsFile.Resources,
+		SchemaToolsConfigs:    toolsFile.SchemaTools,
+		DbtToolsConfigs:       toolsFile.DbtTools,
 		IgnoreUnknownTools:    util.IgnoreUnknownToolsFromContext(ctx),
 	}
 
-	sourcesMap, authServicesMap, embeddingModelsMap, toolsMap, toolsetsMap, promptsMap, promptsetsMap, err := server.InitializeConfigs(ctx, reloadedConfig)
+	sourcesMap, authServicesMap, embeddingModelsMap, toolsMap, toolsetsMap, promptsMap, promptsetsMap, resourcesMap, err := server.InitializeConfigs(ctx, reloadedConfig)
 	if err != nil {
 		errMsg := fmt.Errorf("unable to initialize reloaded configs: %w", err)
 		logger.WarnContext(ctx, errMsg.Error())
-		return nil, nil, nil, nil, nil, nil, nil, err
+		return nil, nil, nil, nil, nil, nil, nil, nil, err
 	}
 
-	return sourcesMap, authServicesMap, embeddingModelsMap, toolsMap, toolsetsMap, promptsMap, promptsetsMap, nil
+	return sourcesMap, authServicesMap, embeddingModelsMap, toolsMap, toolsetsMap, promptsMap, promptsetsMap, resourcesMap, nil
 }
 
 // Helper to check if a file has a newer ModTime than stored in the map
@@ -234,7 +264,8 @@ func scanWatchedFiles(watchingFolder bool, folderToWatch string, watchedFiles ma
 }
 
 // watchChanges checks for changes in the provided yaml config(s) or folder.
-func watchChanges(ctx context.Context, watchDirs map[string]bool, watchedFiles map[string]bool, s *server.Server, pollTickerSecond int) {
+// The remoteFiles are reloaded along with the watched files.
+func watchChanges(ctx context.Context, watchDirs map[string]bool, watchedFiles map[string]bool, remoteFiles []string, s *server.Server, pollTickerSecond int) {
 	logger, err := util.LoggerFromContext(ctx)
 	if err != nil {
 		panic(err)
@@ -373,6 +404,7 @@ func watchChanges(ctx context.Context, watchDirs map[string]bool, watchedFiles m
 			} else {
 				allFiles = slices.Collect(maps.Keys(watchedFiles))
 			}
+			allFiles = append(allFiles, remoteFiles...)
 			logger.DebugContext(ctx, "Reloading tools file(s).")
 			reloadedConfig, err := parser.LoadAndMergeConfigs(ctx, allFiles)
 			if err != nil {
@@ -390,6 +422,67 @@ func watchChanges(ctx context.Context, watchDirs map[string]bool, watchedFiles m
 	}
 }
 
+// watchRemoteChanges checks for changes in the remote config(s) every
+// interval, reloading all the config files on a change. The new
+// configuration replaces the current one only once it is fully loaded.
+func watchRemoteChanges(ctx context.Context, allFiles []string, remoteFiles []string, s *server.Server, interval time.Duration) {
+	logger, err := util.LoggerFromContext(ctx)
+	if err != nil {
+		panic(err)
+	}
+
+	watcher := internal.NewRemoteConfigWatcher(remoteFiles)
+	// Record the current ETags to avoid an initial spurious reload
+	if _, err := watcher.Changed(ctx); err != nil {
+		logger.WarnContext(ctx, fmt.Sprintf("error checking remote configs %s", err))
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			logger.DebugContext(ctx, "remote config watcher context cancelled")
+			return
+		case <-ticker.C:
+			changed, err := watcher.Changed(ctx)
+			if err != nil {
+				logger.WarnContext(ctx, fmt.Sprintf("error checking remote configs %s", err))
+			}
+			if !changed {
+				continue
+			}
+			logger.DebugContext(ctx, "Remote config change detected, reloading tools file(s).")
+			parser := internal.ConfigParser{}
+			reloadedConfig, err := parser.LoadAndMergeConfigs(ctx, allFiles)
+			if err != nil {
+				logger.WarnContext(ctx, fmt.Sprintf("error loading configs %s", err))
+				continue
+			}
+			if err := handleDynamicReload(ctx, reloadedConfig, s); err != nil {
+				logger.WarnContext(ctx, fmt.Sprintf("unable to reload remote configs: %s", err))
+			}
+		}
+	}
+}
+
+// splitRemoteConfigs returns all, the local and the remote config files of
+// the tools-file or tools-files flags.
+func splitRemoteConfigs(toolsFile string, toolsFiles []string) (all []string, local []string, remote []string) {
+	all = toolsFiles
+	if len(all) == 0 && toolsFile != "" {
+		all = []string{toolsFile}
+	}
+	for _, f := range all {
+		if internal.IsRemoteConfig(f) {
+			remote = append(remote, f)
+		} else {
+			local = append(local, f)
+		}
+	}
+	return all, local, remote
+}
+
 func resolveWatcherInputs(toolsFile string, toolsFiles []string, toolsFolder string) (map[string]bool, map[string]bool) {
 	var relevantFiles []string
 
@@ -406,6 +499,8 @@ func resolveWatcherInputs(toolsFile string, toolsFiles []string, toolsFolder str
 	} else {
 		relevantFiles = []string{toolsFile}
 	}
+	// remote files are watched by watchRemoteChanges
+	relevantFiles = slices.DeleteFunc(slices.Clone(relevantFiles), internal.IsRemoteConfig)
 
 	// extract parent dir for relevant files and dedup
 	for _, f := range relevantFiles {
@@ -511,7 +606,9 @@ func run(cmd *cobra.Command, opts *internal.ToolboxOptions) error {
 			return errMsg
 		}
 		opts.Logger.InfoContext(ctx, "Server ready to serve!")
-		if opts.Cfg.UI {
+		if socket, ok := strings.CutPrefix(opts.Cfg.Address, "unix:"); ok && opts.Cfg.UI {
+			opts.Logger.InfoContext(ctx, fmt.Sprintf("Toolbox UI is up and running at /ui on the Unix domain socket %s", socket))
+		} else if opts.Cfg.UI {
 			opts.Logger.InfoContext(ctx, fmt.Sprintf("Toolbox UI is up and running at: %s://%s:%d/ui", protocol, opts.Cfg.Address, opts.Cfg.Port))
 		}
 
@@ -525,9 +622,16 @@ func run(cmd *cobra.Command, opts *internal.ToolboxOptions) error {
 	}
 
 	if isCustomConfigured && !opts.Cfg.DisableReload {
-		watchDirs, watchedFiles := resolveWatcherInputs(opts.Config, opts.Configs, opts.ConfigFolder)
-		// start watching the file(s) or folder for changes to trigger dynamic reloading
-		go watchChanges(ctx, watchDirs, watchedFiles, s, opts.Cfg.PollInterval)
+		allFiles, localFiles, remoteFiles := splitRemoteConfigs(opts.Config, opts.Configs)
+		if len(localFiles) > 0 || opts.ConfigFolder != "" || len(remoteFiles) == 0 {
+			watchDirs, watchedFiles := resolveWatcherInputs(opts.Config, opts.Configs, opts.ConfigFolder)
+			// start watching the file(s) or folder for changes to trigger dynamic reloading
+			go watchChanges(ctx, watchDirs, watchedFiles, remoteFiles, s, opts.Cfg.PollInterval)
+		}
+		if len(remoteFiles) > 0 && opts.Cfg.ConfigRefreshInterval > 0 {
+			// poll the remote file(s) for changes to trigger dynamic reloading
+			go watchRemoteChanges(ctx, allFiles, remoteFiles, s, opts.Cfg.ConfigRefreshInterval)
+		}
 	}
 
 	// wait for either the server to error out or the command's context to be canceled
@@ -539,12 +643,12 @@ func run(cmd *cobra.Command, opts *internal.ToolboxOptions) error {
 			return errMsg
 		}
 	case <-ctx.Done():
-		shutdownContext, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		shutdownContext, cancel := context.WithTimeout(context.Background(), opts.Cfg.ShutdownTimeout)
 		defer cancel()
 		opts.Logger.WarnContext(shutdownContext, "Shutting down gracefully...")
 		err := s.Shutdown(shutdownContext)
 		if err == context.DeadlineExceeded {
-			return fmt.Errorf("graceful shutdown timed out... forcing exit")
+			return fmt.Errorf("graceful shutdown timed out, in-flight invocations were canceled")
 		}
 	}
 
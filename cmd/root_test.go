@@ -34,6 +34,10 @@ import (
 	"github.com/googleapis/mcp-toolbox/cmd/internal"
 	"github.com/googleapis/mcp-toolbox/internal/log"
 	"github.com/googleapis/mcp-toolbox/internal/server"
+	"github.com/googleapis/mcp-toolbox/internal/server/approval"
+	"github.com/googleapis/mcp-toolbox/internal/server/responselimit"
+	"github.com/googleapis/mcp-toolbox/internal/server/resultcache"
+	"github.com/googleapis/mcp-toolbox/internal/sources/secrets"
 	"github.com/googleapis/mcp-toolbox/internal/telemetry"
 	"github.com/googleapis/mcp-toolbox/internal/testutils"
 	"github.com/googleapis/mcp-toolbox/internal/util"
@@ -54,6 +58,9 @@ func withDefaults(c server.ServerConfig) server.ServerConfig {
 	if c.TelemetryServiceName == "" {
 		c.TelemetryServiceName = "toolbox"
 	}
+	if c.TLSCipherSuites == nil {
+		c.TLSCipherSuites = []string{}
+	}
 	if c.AllowedOrigins == nil {
 		c.AllowedOrigins = []string{"*"}
 	}
@@ -66,6 +73,54 @@ func withDefaults(c server.ServerConfig) server.ServerConfig {
 	if c.HttpMaxRequestBytes == 0 {
 		c.HttpMaxRequestBytes = server.DefaultHTTPMaxRequestBytes
 	}
+	if c.BatchParallelism == 0 {
+		c.BatchParallelism = server.DefaultBatchParallelism
+	}
+	if c.InvocationQueueDepth == 0 {
+		c.InvocationQueueDepth = server.DefaultInvocationQueueDepth
+	}
+	if c.MemcachedAddrs == nil {
+		c.MemcachedAddrs = []string{}
+	}
+	if c.AsyncBackend == "" {
+		c.AsyncBackend = server.AsyncBackendLocal
+	}
+	if c.CacheTTL == 0 {
+		c.CacheTTL = resultcache.DefaultTTL
+	}
+	if c.ApprovalTimeout == 0 {
+		c.ApprovalTimeout = approval.DefaultTimeout
+	}
+	if c.AuditRedactParams == nil {
+		c.AuditRedactParams = []string{}
+	}
+	if c.SessionMaxMissedPings == 0 {
+		c.SessionMaxMissedPings = server.DefaultSessionMaxMissedPings
+	}
+	if c.ShutdownTimeout == 0 {
+		c.ShutdownTimeout = server.DefaultShutdownTimeout
+	}
+	if c.SecretRefreshInterval == 0 {
+		c.SecretRefreshInterval = secrets.DefaultRefreshInterval
+	}
+	if c.DefaultLocale == "" {
+		c.DefaultLocale = util.DefaultLocale
+	}
+	if c.TrustedProxyHops == 0 {
+		c.TrustedProxyHops = server.DefaultTrustedProxyHops
+	}
+	if c.ConfigRefreshInterval == 0 {
+		c.ConfigRefreshInterval = time.Minute
+	}
+	if c.AsyncCallbackHosts == nil {
+		c.AsyncCallbackHosts = []string{}
+	}
+	if c.PrewarmSources == nil {
+		c.PrewarmSources = []string{}
+	}
+	if c.SummarizerTimeout == 0 {
+		c.SummarizerTimeout = responselimit.DefaultTimeout
+	}
 	return c
 }
 
@@ -629,7 +684,7 @@ func TestSingleEdit(t *testing.T) {
 	watchedFiles := map[string]bool{cleanFileToWatch: true}
 	watchDirs := map[string]bool{watchDir: true}
 
-	go watchChanges(ctx, watchDirs, watchedFiles, mockServer, 0)
+	go watchChanges(ctx, watchDirs, watchedFiles, nil, mockServer, 0)
 
 	// escape backslash so regex doesn't fail on windows filepaths
 	regexEscapedPathFile := strings.ReplaceAll(cleanFileToWatch, `\`, `\\\\*\\`)
@@ -17,6 +17,7 @@ package auth
 import (
 	"context"
 	"net/http"
+	"time"
 )
 
 // AuthServiceConfig is the interface for configuring authentication services.
@@ -43,6 +44,31 @@ type MCPAuthError struct {
 
 func (e *MCPAuthError) Error() string { return e.Message }
 
+// ErrorCodeTokenExpired is the error code reported to clients whose token
+// has expired, so that they can refresh it and retry.
+const ErrorCodeTokenExpired = "auth_token_expired"
+
+// TokenExpiredError is returned by GetClaimsFromHeader when a token is valid
+// but has expired past the clock skew tolerated by the auth service.
+type TokenExpiredError struct {
+	AuthService string
+	ExpiresAt   time.Time
+	Err         error
+}
+
+func (e *TokenExpiredError) Error() string { return e.Err.Error() }
+
+func (e *TokenExpiredError) Unwrap() error { return e.Err }
+
+// Data returns the details of the error reported to clients.
+func (e *TokenExpiredError) Data() map[string]any {
+	return map[string]any{
+		"code":        ErrorCodeTokenExpired,
+		"authService": e.AuthService,
+		"expiresAt":   e.ExpiresAt.UTC().Format(time.RFC3339),
+	}
+}
+
 // MCPAuthService is the interface for authentication services that support MCP auth.
 type MCPAuthService interface {
 	AuthService
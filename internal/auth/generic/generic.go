@@ -18,6 +18,7 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -34,6 +35,10 @@ import (
 
 const AuthServiceType string = "generic"
 
+// defaultClockSkew is the clock skew tolerated when validating the expiry
+// of a token.
+const defaultClockSkew = time.Minute
+
 // validate interface
 var _ auth.AuthServiceConfig = Config{}
 
@@ -48,6 +53,7 @@ type Config struct {
 	IntrospectionEndpoint  string   `yaml:"introspectionEndpoint"`
 	IntrospectionMethod    string   `yaml:"introspectionMethod"`
 	IntrospectionParamName string   `yaml:"introspectionParamName"`
+	ClockSkew              string   `yaml:"clockSkew"`
 }
 
 // Returns the auth service type
@@ -59,6 +65,19 @@ func (cfg Config) IsMCPEnabled() bool {
 	return cfg.McpEnabled
 }
 
+// clockSkew returns the clock skew tolerated when validating the expiry of a
+// token.
+func (cfg Config) clockSkew() (time.Duration, error) {
+	if cfg.ClockSkew == "" {
+		return defaultClockSkew, nil
+	}
+	d, err := time.ParseDuration(cfg.ClockSkew)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid clockSkew %q: must be a non-negative duration such as \"30s\"", cfg.ClockSkew)
+	}
+	return d, nil
+}
+
 // Initialize a generic auth service
 func (cfg Config) Initialize() (auth.AuthService, error) {
 	if !cfg.McpEnabled {
@@ -75,6 +94,10 @@ func (cfg Config) Initialize() (auth.AuthService, error) {
 			return nil, fmt.Errorf("`scopesRequired` is not allowed when `mcpEnabled` is false")
 		}
 	}
+	clockSkew, err := cfg.clockSkew()
+	if err != nil {
+		return nil, err
+	}
 	httpClient := newSecureHTTPClient()
 
 	// Discover OIDC endpoints
@@ -100,6 +123,7 @@ func (cfg Config) Initialize() (auth.AuthService, error) {
 		client:           httpClient,
 		introspectionURL: introspectionURL,
 		issuer:           issuer,
+		clockSkew:        clockSkew,
 	}
 	return a, nil
 }
@@ -188,6 +212,7 @@ type AuthService struct {
 	client           *http.Client
 	introspectionURL string
 	issuer           string
+	clockSkew        time.Duration
 }
 
 // Returns the auth service type
@@ -228,9 +253,15 @@ func (a AuthService) GetClaimsFromHeader(ctx context.Context, h http.Header) (ma
 	}
 
 	// Parse and verify the token signature
-	token, err := jwt.Parse(tokenString, a.kf.Keyfunc)
+	token, err := jwt.Parse(tokenString, a.kf.Keyfunc, jwt.WithLeeway(a.clockSkew))
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse and verify JWT token: %w", err)
+		err = fmt.Errorf("failed to parse and verify JWT token: %w", err)
+		if errors.Is(err, jwt.ErrTokenExpired) && token != nil {
+			if exp, expErr := token.Claims.GetExpirationTime(); expErr == nil && exp != nil {
+				return nil, &auth.TokenExpiredError{AuthService: a.Name, ExpiresAt: exp.Time, Err: err}
+			}
+		}
+		return nil, err
 	}
 
 	if !token.Valid {
@@ -305,7 +336,7 @@ func isJWTFormat(token string) bool {
 
 // validateJwtToken validates a JWT token locally
 func (a AuthService) validateJwtToken(ctx context.Context, tokenStr string) (map[string]any, error) {
-	token, err := jwt.Parse(tokenStr, a.kf.Keyfunc)
+	token, err := jwt.Parse(tokenStr, a.kf.Keyfunc, jwt.WithLeeway(a.clockSkew))
 	if err != nil || !token.Valid {
 		return nil, &MCPAuthError{Code: http.StatusUnauthorized, Message: "invalid or expired token", ScopesRequired: a.ScopesRequired}
 	}
@@ -428,9 +459,8 @@ func (a AuthService) validateOpaqueToken(ctx context.Context, tokenStr string) (
 		}
 	}
 
-	// Verify expiration (with 1 minute leeway)
-	const leeway = 60
-	if expVal > 0 && time.Now().Unix() > (expVal+leeway) {
+	// Verify expiration, tolerating the configured clock skew
+	if expVal > 0 && time.Now().Unix() > expVal+int64(a.clockSkew.Seconds()) {
 		logger.WarnContext(ctx, "token has expired: exp=%d, now=%d", expVal, time.Now().Unix())
 		return nil, &MCPAuthError{Code: http.StatusUnauthorized, Message: "token has expired", ScopesRequired: a.ScopesRequired}
 	}
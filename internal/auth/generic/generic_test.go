@@ -20,6 +20,7 @@ import (
 	"crypto/rand"
 	"crypto/rsa"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -28,6 +29,7 @@ import (
 
 	"github.com/MicahParks/jwkset"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/googleapis/mcp-toolbox/internal/auth"
 	"github.com/googleapis/mcp-toolbox/internal/log"
 	"github.com/googleapis/mcp-toolbox/internal/util"
 )
@@ -313,6 +315,80 @@ func TestGetClaimsFromHeader(t *testing.T) {
 	}
 }
 
+func TestGetClaimsFromHeaderClockSkew(t *testing.T) {
+	privateKey := generateRSAPrivateKey(t)
+	keyID := "test-key-id"
+	server := setupJWKSMockServer(t, privateKey, keyID)
+	defer server.Close()
+
+	tests := []struct {
+		name        string
+		clockSkew   string
+		expiredBy   time.Duration
+		wantExpired bool
+	}{
+		{name: "not expired", expiredBy: -time.Minute},
+		{name: "within default skew", expiredBy: 10 * time.Second},
+		{name: "past default skew", expiredBy: 2 * time.Minute, wantExpired: true},
+		{name: "within configured skew", clockSkew: "5m", expiredBy: 2 * time.Minute},
+		{name: "past configured skew", clockSkew: "5m", expiredBy: 10 * time.Minute, wantExpired: true},
+		{name: "no skew", clockSkew: "0s", expiredBy: 10 * time.Second, wantExpired: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := Config{
+				Name:                "test-generic-auth",
+				Type:                "generic",
+				Audience:            "my-audience",
+				AuthorizationServer: server.URL,
+				ClockSkew:           tc.clockSkew,
+			}
+			authService, err := cfg.Initialize()
+			if err != nil {
+				t.Fatalf("failed to initialize auth service: %v", err)
+			}
+
+			exp := time.Now().Add(-tc.expiredBy).Truncate(time.Second)
+			token := generateValidToken(t, privateKey, keyID, jwt.MapClaims{
+				"iss": "https://example.com",
+				"aud": "my-audience",
+				"exp": exp.Unix(),
+			})
+			header := http.Header{}
+			header.Set("test-generic-auth_token", token)
+
+			_, err = authService.GetClaimsFromHeader(context.Background(), header)
+			if !tc.wantExpired {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			var expiredErr *auth.TokenExpiredError
+			if !errors.As(err, &expiredErr) {
+				t.Fatalf("expected a TokenExpiredError, got %v", err)
+			}
+			if expiredErr.AuthService != "test-generic-auth" {
+				t.Errorf("unexpected auth service: %q", expiredErr.AuthService)
+			}
+			if !expiredErr.ExpiresAt.Equal(exp) {
+				t.Errorf("unexpected expiry: got %v, want %v", expiredErr.ExpiresAt, exp)
+			}
+		})
+	}
+}
+
+func TestInitializeInvalidClockSkew(t *testing.T) {
+	for _, skew := range []string{"soon", "-1m"} {
+		cfg := Config{Name: "test-generic-auth", Type: "generic", Audience: "my-audience", AuthorizationServer: "http://127.0.0.1:0", ClockSkew: skew}
+		_, err := cfg.Initialize()
+		if err == nil || !strings.Contains(err.Error(), "invalid clockSkew") {
+			t.Errorf("expected an invalid clockSkew error for %q, got %v", skew, err)
+		}
+	}
+}
+
 func TestValidateMCPAuth_Opaque(t *testing.T) {
 	tests := []struct {
 		name           string
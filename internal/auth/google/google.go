@@ -125,7 +125,11 @@ func (a AuthService) GetClaimsFromHeader(ctx context.Context, h http.Header) (ma
 	if token := h.Get(a.Name + "_token"); token != "" {
 		payload, err := idtoken.Validate(ctx, token, a.ClientID)
 		if err != nil {
-			return nil, fmt.Errorf("google ID token verification failure: %w", err)
+			err = fmt.Errorf("google ID token verification failure: %w", err)
+			if p, pErr := idtoken.ParsePayload(token); pErr == nil && p.Expires > 0 && time.Now().Unix() > p.Expires {
+				return nil, &auth.TokenExpiredError{AuthService: a.Name, ExpiresAt: time.Unix(p.Expires, 0), Err: err}
+			}
+			return nil, err
 		}
 		return payload.Claims, nil
 	}
@@ -0,0 +1,228 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/MicahParks/keyfunc/v3"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/googleapis/mcp-toolbox/internal/auth"
+	"golang.org/x/time/rate"
+)
+
+const AuthServiceType string = "oidc"
+
+const (
+	// defaultClockSkew is the clock skew tolerated when validating the
+	// expiry of a token.
+	defaultClockSkew = time.Minute
+	// defaultJwksRefreshInterval is how often the signing keys of the
+	// issuer are fetched again.
+	defaultJwksRefreshInterval = time.Hour
+	// defaultJwksMinRefreshInterval is the minimum time between two fetches
+	// of the signing keys triggered by a token signed with an unknown key.
+	defaultJwksMinRefreshInterval = 5 * time.Minute
+)
+
+// signingMethods are the algorithms accepted for the signature of tokens.
+// Symmetric algorithms are excluded since their keys can't be published in a
+// JWKS.
+var signingMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}
+
+// validate interface
+var _ auth.AuthServiceConfig = Config{}
+
+// Auth service configuration
+type Config struct {
+	Name                   string `yaml:"name" validate:"required"`
+	Type                   string `yaml:"type" validate:"required"`
+	Issuer                 string `yaml:"issuer" validate:"required"`
+	Audience               string `yaml:"audience" validate:"required"`
+	JwksUri                string `yaml:"jwksUri"`
+	JwksRefreshInterval    string `yaml:"jwksRefreshInterval"`
+	JwksMinRefreshInterval string `yaml:"jwksMinRefreshInterval"`
+	ClockSkew              string `yaml:"clockSkew"`
+}
+
+// Returns the auth service type
+func (cfg Config) AuthServiceConfigType() string {
+	return AuthServiceType
+}
+
+func (cfg Config) IsMCPEnabled() bool {
+	return false
+}
+
+// duration parses the duration configured in a field, or returns def if the
+// field is empty.
+func duration(field, value string, def time.Duration) (time.Duration, error) {
+	if value == "" {
+		return def, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid %s %q: must be a non-negative duration such as \"30s\"", field, value)
+	}
+	return d, nil
+}
+
+// Initialize an OpenID Connect auth service
+func (cfg Config) Initialize() (auth.AuthService, error) {
+	clockSkew, err := duration("clockSkew", cfg.ClockSkew, defaultClockSkew)
+	if err != nil {
+		return nil, err
+	}
+	refresh, err := duration("jwksRefreshInterval", cfg.JwksRefreshInterval, defaultJwksRefreshInterval)
+	if err != nil {
+		return nil, err
+	}
+	minRefresh, err := duration("jwksMinRefreshInterval", cfg.JwksMinRefreshInterval, defaultJwksMinRefreshInterval)
+	if err != nil {
+		return nil, err
+	}
+	if refresh == 0 {
+		return nil, fmt.Errorf("invalid jwksRefreshInterval %q: must be greater than zero", cfg.JwksRefreshInterval)
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	jwksURI := cfg.JwksUri
+	if jwksURI == "" {
+		jwksURI, err = discoverJwksURI(client, cfg.Issuer)
+		if err != nil {
+			return nil, fmt.Errorf("failed to discover the OpenID configuration of %s: %w", cfg.Issuer, err)
+		}
+	}
+	if _, err := url.ParseRequestURI(jwksURI); err != nil {
+		return nil, fmt.Errorf("invalid jwksUri %q: %w", jwksURI, err)
+	}
+
+	// The signing keys are cached and refreshed in the background, and
+	// fetched again when a token is signed with a key that isn't cached yet,
+	// such as right after a rotation, at most once every minRefresh.
+	kf, err := keyfunc.NewDefaultOverrideCtx(context.Background(), []string{jwksURI}, keyfunc.Override{
+		Client:            client,
+		RefreshInterval:   refresh,
+		RefreshUnknownKID: rate.NewLimiter(rate.Every(minRefresh), 1),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create keyfunc from JWKS URL %s: %w", jwksURI, err)
+	}
+
+	a := &AuthService{
+		Config:    cfg,
+		kf:        kf,
+		clockSkew: clockSkew,
+	}
+	return a, nil
+}
+
+// discoverJwksURI returns the JWKS URI from the OpenID configuration
+// published by the issuer.
+func discoverJwksURI(client *http.Client, issuer string) (string, error) {
+	configURL := strings.TrimSuffix(issuer, "/") + "/.well-known/openid-configuration"
+	resp, err := client.Get(configURL)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status %d from %s", resp.StatusCode, configURL)
+	}
+
+	// Limit read size to 1MB to prevent memory exhaustion
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", err
+	}
+	var config struct {
+		Issuer  string `json:"issuer"`
+		JwksUri string `json:"jwks_uri"`
+	}
+	if err := json.Unmarshal(body, &config); err != nil {
+		return "", err
+	}
+	// The issuer must match exactly the one configured, as it's the value
+	// of the iss claim of the tokens.
+	if config.Issuer != issuer {
+		return "", fmt.Errorf("issuer %q of the OpenID configuration doesn't match the configured issuer", config.Issuer)
+	}
+	if config.JwksUri == "" {
+		return "", fmt.Errorf("jwks_uri not found in the OpenID configuration")
+	}
+	return config.JwksUri, nil
+}
+
+var _ auth.AuthService = AuthService{}
+
+// struct used to store auth service info
+type AuthService struct {
+	Config
+	kf        keyfunc.Keyfunc
+	clockSkew time.Duration
+}
+
+// Returns the auth service type
+func (a AuthService) AuthServiceType() string {
+	return AuthServiceType
+}
+
+func (a AuthService) ToConfig() auth.AuthServiceConfig {
+	return a.Config
+}
+
+// Returns the name of the auth service
+func (a AuthService) GetName() string {
+	return a.Name
+}
+
+// Verifies the ID token or JWT access token inside the <name>_token header
+func (a AuthService) GetClaimsFromHeader(ctx context.Context, h http.Header) (map[string]any, error) {
+	tokenString := h.Get(a.Name + "_token")
+	if tokenString == "" {
+		return nil, nil
+	}
+
+	token, err := jwt.Parse(tokenString, a.kf.Keyfunc,
+		jwt.WithValidMethods(signingMethods),
+		jwt.WithIssuer(a.Issuer),
+		jwt.WithAudience(a.Audience),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(a.clockSkew),
+	)
+	if err != nil {
+		err = fmt.Errorf("failed to parse and verify JWT token: %w", err)
+		if errors.Is(err, jwt.ErrTokenExpired) && token != nil {
+			if exp, expErr := token.Claims.GetExpirationTime(); expErr == nil && exp != nil {
+				return nil, &auth.TokenExpiredError{AuthService: a.Name, ExpiresAt: exp.Time, Err: err}
+			}
+		}
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, fmt.Errorf("invalid JWT claims format")
+	}
+	return claims, nil
+}
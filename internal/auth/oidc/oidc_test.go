@@ -0,0 +1,255 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MicahParks/jwkset"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/googleapis/mcp-toolbox/internal/auth"
+)
+
+const testKeyID = "my-key-id"
+
+// setupIssuer starts an issuer publishing its OpenID configuration and the
+// public part of key. The issuer in the configuration is the URL of the
+// server, unless issuer is set.
+func setupIssuer(t *testing.T, key *rsa.PrivateKey, issuer string) *httptest.Server {
+	t.Helper()
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/.well-known/openid-configuration":
+			iss := issuer
+			if iss == "" {
+				iss = srv.URL
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"issuer":   iss,
+				"jwks_uri": srv.URL + "/keys",
+			})
+		case "/keys":
+			jwk, err := jwkset.NewJWKFromKey(key.Public(), jwkset.JWKOptions{Metadata: jwkset.JWKMetadataOptions{KID: testKeyID}})
+			if err != nil {
+				t.Errorf("failed to create JWK: %v", err)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"keys": []jwkset.JWKMarshal{jwk.Marshal()},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func generateRSAPrivateKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to create RSA private key: %v", err)
+	}
+	return key
+}
+
+func signToken(t *testing.T, method jwt.SigningMethod, key any, claims jwt.MapClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = testKeyID
+	s, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return s
+}
+
+func TestInitialize(t *testing.T) {
+	key := generateRSAPrivateKey(t)
+	srv := setupIssuer(t, key, "")
+	mismatched := setupIssuer(t, key, "https://other.example.com")
+
+	tcs := []struct {
+		desc    string
+		cfg     Config
+		wantErr string
+	}{
+		{
+			desc: "discovery",
+			cfg:  Config{Name: "my-oidc", Type: AuthServiceType, Issuer: srv.URL, Audience: "my-audience"},
+		},
+		{
+			desc: "jwksUri skips discovery",
+			cfg:  Config{Name: "my-oidc", Type: AuthServiceType, Issuer: "https://other.example.com", Audience: "my-audience", JwksUri: srv.URL + "/keys"},
+		},
+		{
+			desc: "jwks caching",
+			cfg:  Config{Name: "my-oidc", Type: AuthServiceType, Issuer: srv.URL, Audience: "my-audience", JwksRefreshInterval: "10m", JwksMinRefreshInterval: "30s"},
+		},
+		{
+			desc:    "mismatched issuer",
+			cfg:     Config{Name: "my-oidc", Type: AuthServiceType, Issuer: mismatched.URL, Audience: "my-audience"},
+			wantErr: "doesn't match the configured issuer",
+		},
+		{
+			desc:    "invalid refresh interval",
+			cfg:     Config{Name: "my-oidc", Type: AuthServiceType, Issuer: srv.URL, Audience: "my-audience", JwksRefreshInterval: "often"},
+			wantErr: "invalid jwksRefreshInterval",
+		},
+		{
+			desc:    "zero refresh interval",
+			cfg:     Config{Name: "my-oidc", Type: AuthServiceType, Issuer: srv.URL, Audience: "my-audience", JwksRefreshInterval: "0s"},
+			wantErr: "must be greater than zero",
+		},
+		{
+			desc:    "negative clock skew",
+			cfg:     Config{Name: "my-oidc", Type: AuthServiceType, Issuer: srv.URL, Audience: "my-audience", ClockSkew: "-1m"},
+			wantErr: "invalid clockSkew",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := tc.cfg.Initialize()
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("got error %v, want it to contain %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetClaimsFromHeader(t *testing.T) {
+	key := generateRSAPrivateKey(t)
+	srv := setupIssuer(t, key, "")
+	cfg := Config{Name: "my-oidc", Type: AuthServiceType, Issuer: srv.URL, Audience: "my-audience"}
+	a, err := cfg.Initialize()
+	if err != nil {
+		t.Fatalf("failed to initialize: %v", err)
+	}
+
+	claims := func(overrides jwt.MapClaims) jwt.MapClaims {
+		c := jwt.MapClaims{
+			"iss":   srv.URL,
+			"aud":   "my-audience",
+			"sub":   "user-1",
+			"email": "user@example.com",
+			"exp":   time.Now().Add(time.Hour).Unix(),
+		}
+		for k, v := range overrides {
+			if v == nil {
+				delete(c, k)
+				continue
+			}
+			c[k] = v
+		}
+		return c
+	}
+
+	tcs := []struct {
+		desc      string
+		token     string
+		wantEmail string
+		wantErr   bool
+	}{
+		{
+			desc:      "valid token",
+			token:     signToken(t, jwt.SigningMethodRS256, key, claims(nil)),
+			wantEmail: "user@example.com",
+		},
+		{
+			desc:      "audience in a list",
+			token:     signToken(t, jwt.SigningMethodRS256, key, claims(jwt.MapClaims{"aud": []string{"other", "my-audience"}})),
+			wantEmail: "user@example.com",
+		},
+		{
+			desc:    "wrong issuer",
+			token:   signToken(t, jwt.SigningMethodRS256, key, claims(jwt.MapClaims{"iss": "https://evil.example.com"})),
+			wantErr: true,
+		},
+		{
+			desc:    "wrong audience",
+			token:   signToken(t, jwt.SigningMethodRS256, key, claims(jwt.MapClaims{"aud": "other"})),
+			wantErr: true,
+		},
+		{
+			desc:    "missing expiry",
+			token:   signToken(t, jwt.SigningMethodRS256, key, claims(jwt.MapClaims{"exp": nil})),
+			wantErr: true,
+		},
+		{
+			desc:    "unknown key",
+			token:   signToken(t, jwt.SigningMethodRS256, generateRSAPrivateKey(t), claims(nil)),
+			wantErr: true,
+		},
+		{
+			desc:    "symmetric algorithm",
+			token:   signToken(t, jwt.SigningMethodHS256, []byte("secret"), claims(nil)),
+			wantErr: true,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			h := http.Header{}
+			h.Set("my-oidc_token", tc.token)
+			got, err := a.GetClaimsFromHeader(context.Background(), h)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got claims %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got["email"] != tc.wantEmail {
+				t.Fatalf("got email %v, want %q", got["email"], tc.wantEmail)
+			}
+		})
+	}
+
+	t.Run("expired token", func(t *testing.T) {
+		h := http.Header{}
+		h.Set("my-oidc_token", signToken(t, jwt.SigningMethodRS256, key, claims(jwt.MapClaims{"exp": time.Now().Add(-time.Hour).Unix()})))
+		_, err := a.GetClaimsFromHeader(context.Background(), h)
+		var expired *auth.TokenExpiredError
+		if !errors.As(err, &expired) {
+			t.Fatalf("got error %v, want a TokenExpiredError", err)
+		}
+	})
+
+	t.Run("missing header", func(t *testing.T) {
+		got, err := a.GetClaimsFromHeader(context.Background(), http.Header{})
+		if err != nil || got != nil {
+			t.Fatalf("got %v, %v, want no claims and no error", got, err)
+		}
+	})
+}
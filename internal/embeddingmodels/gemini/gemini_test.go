@@ -118,7 +118,7 @@ func TestFailParseFromYamlGemini(t *testing.T) {
             name: bad-model
             type: gemini
             `,
-			err: "error unmarshaling embeddingModel: unable to parse as \"bad-model\": Key: 'Config.Model' Error:Field validation for 'Model' failed on the 'required' tag",
+			err: "error unmarshaling embeddingModel: unable to parse as \"bad-model\": embeddingModels[bad-model].model is required; add a \"model\" field",
 		},
 		{
 			desc: "unknown field",
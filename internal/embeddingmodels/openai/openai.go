@@ -0,0 +1,180 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/googleapis/mcp-toolbox/internal/embeddingmodels"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+)
+
+const EmbeddingModelType string = "openai"
+
+// DefaultBaseURL is the base URL of the OpenAI API.
+const DefaultBaseURL = "https://api.openai.com/v1"
+
+// validate interface
+var _ embeddingmodels.EmbeddingModelConfig = Config{}
+
+// Config is an embedding model served by the OpenAI API or by any endpoint
+// compatible with its /embeddings method.
+type Config struct {
+	Name      string `yaml:"name" validate:"required"`
+	Type      string `yaml:"type" validate:"required"`
+	Model     string `yaml:"model" validate:"required"`
+	BaseURL   string `yaml:"baseUrl"`
+	ApiKey    string `yaml:"apiKey"`
+	Dimension int32  `yaml:"dimension"`
+	// Headers are sent with every request, e.g. the organization of the
+	// API key or the headers of a gateway.
+	Headers map[string]string `yaml:"headers"`
+}
+
+// Returns the embedding model type
+func (cfg Config) EmbeddingModelConfigType() string {
+	return EmbeddingModelType
+}
+
+// Initialize an OpenAI-compatible embedding model
+func (cfg Config) Initialize(ctx context.Context) (embeddingmodels.EmbeddingModel, error) {
+	baseURL := cfg.BaseURL
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	apiKey := cfg.ApiKey
+	if apiKey == "" {
+		apiKey = os.Getenv("OPENAI_API_KEY")
+	}
+	// Local endpoints often need no key, but the OpenAI API does.
+	if apiKey == "" && baseURL == DefaultBaseURL {
+		return nil, fmt.Errorf("missing credentials for OpenAI embedding: provide 'apiKey' in YAML or set the OPENAI_API_KEY env var")
+	}
+
+	ua, err := util.UserAgentFromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user agent from context: %w", err)
+	}
+
+	return &EmbeddingModel{
+		Config:    cfg,
+		Client:    &http.Client{},
+		url:       strings.TrimSuffix(baseURL, "/") + "/embeddings",
+		apiKey:    apiKey,
+		userAgent: ua,
+	}, nil
+}
+
+var _ embeddingmodels.EmbeddingModel = EmbeddingModel{}
+
+type EmbeddingModel struct {
+	Client *http.Client
+	Config
+	url       string
+	apiKey    string
+	userAgent string
+}
+
+// Returns the embedding model type
+func (m EmbeddingModel) EmbeddingModelType() string {
+	return EmbeddingModelType
+}
+
+func (m EmbeddingModel) ToConfig() embeddingmodels.EmbeddingModelConfig {
+	return m.Config
+}
+
+type embeddingsRequest struct {
+	Model      string   `json:"model"`
+	Input      []string `json:"input"`
+	Dimensions int32    `json:"dimensions,omitempty"`
+}
+
+type embeddingsResponse struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func (m EmbeddingModel) EmbedParameters(ctx context.Context, parameters []string) ([][]float32, error) {
+	logger, err := util.LoggerFromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get logger from ctx: %s", err)
+	}
+
+	body, err := json.Marshal(embeddingsRequest{Model: m.Model, Input: parameters, Dimensions: m.Dimension})
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal embeddings request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, m.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("unable to create embeddings request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", m.userAgent)
+	if m.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+m.apiKey)
+	}
+	for k, v := range m.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := m.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to call embeddings endpoint of model %s: %w", m.Model, err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read embeddings response: %w", err)
+	}
+	var result embeddingsResponse
+	if err := json.Unmarshal(respBody, &result); err != nil && resp.StatusCode == http.StatusOK {
+		return nil, fmt.Errorf("unable to decode embeddings response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		msg := strings.TrimSpace(string(respBody))
+		if result.Error != nil && result.Error.Message != "" {
+			msg = result.Error.Message
+		}
+		return nil, fmt.Errorf("embeddings endpoint of model %s returned %s: %s", m.Model, resp.Status, msg)
+	}
+	if len(result.Data) != len(parameters) {
+		return nil, fmt.Errorf("embeddings endpoint of model %s returned %d embeddings for %d inputs", m.Model, len(result.Data), len(parameters))
+	}
+
+	// The embeddings may be out of order; index is the position of their input.
+	sort.Slice(result.Data, func(i, j int) bool { return result.Data[i].Index < result.Data[j].Index })
+	embeddings := make([][]float32, 0, len(result.Data))
+	for _, d := range result.Data {
+		embeddings = append(embeddings, d.Embedding)
+	}
+
+	logger.DebugContext(ctx, fmt.Sprintf("Successfully embedded %d text parameters using model %s", len(parameters), m.Model))
+
+	return embeddings, nil
+}
@@ -0,0 +1,161 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/internal/embeddingmodels"
+	"github.com/googleapis/mcp-toolbox/internal/embeddingmodels/openai"
+	"github.com/googleapis/mcp-toolbox/internal/server"
+	"github.com/googleapis/mcp-toolbox/internal/testutils"
+)
+
+func TestParseFromYamlOpenAI(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		want server.EmbeddingModelConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+            kind: embeddingModel
+            name: my-openai-model
+            type: openai
+            model: text-embedding-3-small
+            `,
+			want: map[string]embeddingmodels.EmbeddingModelConfig{
+				"my-openai-model": openai.Config{
+					Name:  "my-openai-model",
+					Type:  openai.EmbeddingModelType,
+					Model: "text-embedding-3-small",
+				},
+			},
+		},
+		{
+			desc: "compatible endpoint",
+			in: `
+            kind: embeddingModel
+            name: local-model
+            type: openai
+            model: nomic-embed-text
+            baseUrl: http://localhost:11434/v1
+            apiKey: test-api-key
+            dimension: 768
+            headers:
+              X-Team: search
+            `,
+			want: map[string]embeddingmodels.EmbeddingModelConfig{
+				"local-model": openai.Config{
+					Name:      "local-model",
+					Type:      openai.EmbeddingModelType,
+					Model:     "nomic-embed-text",
+					BaseURL:   "http://localhost:11434/v1",
+					ApiKey:    "test-api-key",
+					Dimension: 768,
+					Headers:   map[string]string{"X-Team": "search"},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, _, got, _, _, _, err := server.UnmarshalPrimitiveConfig(context.Background(), testutils.FormatYaml(tc.in))
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if !cmp.Equal(tc.want, got) {
+				t.Fatalf("incorrect parse: %v", cmp.Diff(tc.want, got))
+			}
+		})
+	}
+}
+
+func TestInitializeMissingCredentials(t *testing.T) {
+	t.Setenv("OPENAI_API_KEY", "")
+	ctx := testutils.ContextWithUserAgent(context.Background(), "test")
+	cfg := openai.Config{Name: "m", Type: openai.EmbeddingModelType, Model: "text-embedding-3-small"}
+	if _, err := cfg.Initialize(ctx); err == nil || !strings.Contains(err.Error(), "missing credentials") {
+		t.Fatalf("expected a missing credentials error, got %v", err)
+	}
+	cfg.BaseURL = "http://localhost:11434/v1"
+	if _, err := cfg.Initialize(ctx); err != nil {
+		t.Fatalf("unexpected error for a compatible endpoint without a key: %s", err)
+	}
+}
+
+func TestEmbedParameters(t *testing.T) {
+	var gotReq map[string]any
+	var gotAuth string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/embeddings" {
+			http.NotFound(w, r)
+			return
+		}
+		gotAuth = r.Header.Get("Authorization")
+		if err := json.NewDecoder(r.Body).Decode(&gotReq); err != nil {
+			t.Errorf("unable to decode request: %s", err)
+		}
+		if gotReq["model"] == "bad" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error": {"message": "unknown model"}}`))
+			return
+		}
+		// out of order, as the API allows
+		_, _ = w.Write([]byte(`{"data": [{"index": 1, "embedding": [0.3, 0.4]}, {"index": 0, "embedding": [0.1, 0.2]}]}`))
+	}))
+	defer ts.Close()
+
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unable to create context: %s", err)
+	}
+	ctx = testutils.ContextWithUserAgent(ctx, "test")
+	cfg := openai.Config{Name: "m", Type: openai.EmbeddingModelType, Model: "text-embedding-3-small", BaseURL: ts.URL + "/v1/", ApiKey: "secret", Dimension: 2}
+	m, err := cfg.Initialize(ctx)
+	if err != nil {
+		t.Fatalf("unable to initialize: %s", err)
+	}
+	got, err := m.EmbedParameters(ctx, []string{"a", "b"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff := cmp.Diff([][]float32{{0.1, 0.2}, {0.3, 0.4}}, got); diff != "" {
+		t.Fatalf("incorrect embeddings (-want +got):\n%s", diff)
+	}
+	if gotAuth != "Bearer secret" {
+		t.Fatalf("unexpected authorization header: %q", gotAuth)
+	}
+	wantReq := map[string]any{"model": "text-embedding-3-small", "input": []any{"a", "b"}, "dimensions": float64(2)}
+	if diff := cmp.Diff(wantReq, gotReq); diff != "" {
+		t.Fatalf("incorrect request (-want +got):\n%s", diff)
+	}
+
+	cfg.Model = "bad"
+	m, err = cfg.Initialize(ctx)
+	if err != nil {
+		t.Fatalf("unable to initialize: %s", err)
+	}
+	if _, err := m.EmbedParameters(ctx, []string{"a"}); err == nil || !strings.Contains(err.Error(), "unknown model") {
+		t.Fatalf("expected the error of the endpoint, got %v", err)
+	}
+}
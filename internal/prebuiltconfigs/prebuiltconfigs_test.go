@@ -25,6 +25,7 @@ var expectedToolSources = []string{
 	"alloydb-omni",
 	"alloydb-postgres-admin",
 	"alloydb-postgres-observability",
+	"alloydb-postgres-introspection",
 	"alloydb-postgres",
 	"conversational-analytics-with-data-agent",
 	"bigquery",
@@ -39,6 +40,7 @@ var expectedToolSources = []string{
 	"cloud-sql-mysql",
 	"cloud-sql-postgres-admin",
 	"cloud-sql-postgres-observability",
+	"cloud-sql-postgres-introspection",
 	"cloud-sql-postgres",
 	"dataplex",
 	"dataproc",
@@ -54,6 +56,7 @@ var expectedToolSources = []string{
 	"oceanbase",
 	"oracledb",
 	"postgres",
+	"postgres-introspection",
 	"serverless-spark",
 	"singlestore",
 	"snowflake",
@@ -0,0 +1,167 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+)
+
+// DefaultMimeType is used when a resource does not declare a mimeType.
+const DefaultMimeType = "text/plain"
+
+// MaxResourceBytes caps the size of a resource's content. Resources are held
+// in memory and sent to agents verbatim, so they are meant for short
+// operational docs rather than bulk data.
+const MaxResourceBytes = 1 << 20
+
+// SchemaMimeType is the mimeType of resources describing the schema of a
+// source.
+const SchemaMimeType = "application/json"
+
+// ResourceConfig is the YAML definition of an MCP resource. Exactly one of
+// Text, Path or Source must be set.
+type ResourceConfig struct {
+	Name        string `yaml:"name" validate:"required"`
+	URI         string `yaml:"uri" validate:"required"`
+	Description string `yaml:"description"`
+	MimeType    string `yaml:"mimeType"`
+	Text        string `yaml:"text"`
+	Path        string `yaml:"path"`
+	// Source names a SQL source whose schema is the content of the resource.
+	// The schema is described again on every read.
+	Source string `yaml:"source"`
+}
+
+// LoadFunc returns the content of a resource when it is read.
+type LoadFunc func(ctx context.Context) (string, error)
+
+// Resource is an initialized resource whose content has been resolved, or
+// which loads its content when read.
+type Resource struct {
+	ResourceConfig
+	content string
+	load    LoadFunc
+}
+
+// Manifest is the representation of a resource in resources/list.
+type Manifest struct {
+	URI         string `json:"uri"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	MimeType    string `json:"mimeType,omitempty"`
+	Size        int    `json:"size"`
+}
+
+// Initialize validates the config and loads the resource content. File-backed
+// resources are read here, so they pick up changes whenever the configuration
+// is (re)loaded.
+func (r ResourceConfig) Initialize() (Resource, error) {
+	set := 0
+	for _, v := range []string{r.Text, r.Path, r.Source} {
+		if v != "" {
+			set++
+		}
+	}
+	if set > 1 {
+		return Resource{}, fmt.Errorf("`text`, `path` and `source` are mutually exclusive")
+	}
+	if set == 0 {
+		return Resource{}, fmt.Errorf("one of `text`, `path` or `source` is required")
+	}
+	u, err := url.Parse(r.URI)
+	if err != nil || u.Scheme == "" {
+		return Resource{}, fmt.Errorf("invalid uri %q: must be an absolute URI with a scheme", r.URI)
+	}
+	if r.Source != "" {
+		if r.MimeType == "" {
+			r.MimeType = SchemaMimeType
+		}
+		return Resource{ResourceConfig: r}, nil
+	}
+	if r.MimeType == "" {
+		r.MimeType = DefaultMimeType
+	}
+
+	content := r.Text
+	if r.Path != "" {
+		info, err := os.Stat(r.Path)
+		if err != nil {
+			return Resource{}, fmt.Errorf("unable to read resource file %q: %w", r.Path, err)
+		}
+		if info.Size() > MaxResourceBytes {
+			return Resource{}, fmt.Errorf("resource file %q is %d bytes, which exceeds the %d byte limit", r.Path, info.Size(), MaxResourceBytes)
+		}
+		b, err := os.ReadFile(r.Path)
+		if err != nil {
+			return Resource{}, fmt.Errorf("unable to read resource file %q: %w", r.Path, err)
+		}
+		content = string(b)
+	}
+	if len(content) > MaxResourceBytes {
+		return Resource{}, fmt.Errorf("resource content is %d bytes, which exceeds the %d byte limit", len(content), MaxResourceBytes)
+	}
+	return Resource{ResourceConfig: r, content: content}, nil
+}
+
+// ToConfig returns the config the resource was initialized from.
+func (r Resource) ToConfig() ResourceConfig {
+	return r.ResourceConfig
+}
+
+// Content returns the resolved text content of the resource.
+func (r Resource) Content() string {
+	return r.content
+}
+
+// WithLoader returns a copy of the resource that loads its content with load
+// whenever it is read.
+func (r Resource) WithLoader(load LoadFunc) Resource {
+	r.load = load
+	return r
+}
+
+// Read returns the content of the resource, loading it if the resource has a
+// loader.
+func (r Resource) Read(ctx context.Context) (string, error) {
+	if r.load == nil {
+		if r.Source != "" {
+			return "", fmt.Errorf("resource %q of source %q is not loaded", r.Name, r.Source)
+		}
+		return r.content, nil
+	}
+	content, err := r.load(ctx)
+	if err != nil {
+		return "", err
+	}
+	if len(content) > MaxResourceBytes {
+		return "", fmt.Errorf("resource content is %d bytes, which exceeds the %d byte limit", len(content), MaxResourceBytes)
+	}
+	return content, nil
+}
+
+// Manifest returns the resources/list entry for this resource. The size of
+// resources loaded when read is unknown and reported as 0.
+func (r Resource) Manifest() Manifest {
+	return Manifest{
+		URI:         r.URI,
+		Name:        r.Name,
+		Description: r.Description,
+		MimeType:    r.MimeType,
+		Size:        len(r.content),
+	}
+}
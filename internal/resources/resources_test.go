@@ -0,0 +1,186 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/googleapis/mcp-toolbox/internal/resources"
+)
+
+func TestInitialize(t *testing.T) {
+	dir := t.TempDir()
+	docPath := filepath.Join(dir, "runbook.md")
+	if err := os.WriteFile(docPath, []byte("# Runbook\nrestart the pool"), 0o644); err != nil {
+		t.Fatalf("unable to write file: %s", err)
+	}
+	bigPath := filepath.Join(dir, "big.txt")
+	if err := os.WriteFile(bigPath, make([]byte, resources.MaxResourceBytes+1), 0o644); err != nil {
+		t.Fatalf("unable to write file: %s", err)
+	}
+
+	tcs := []struct {
+		desc        string
+		cfg         resources.ResourceConfig
+		wantContent string
+		wantMime    string
+		wantErr     string
+	}{
+		{
+			desc:        "inline text",
+			cfg:         resources.ResourceConfig{Name: "limits", URI: "docs://limits", Text: "max 100 rows"},
+			wantContent: "max 100 rows",
+			wantMime:    resources.DefaultMimeType,
+		},
+		{
+			desc:        "file backed",
+			cfg:         resources.ResourceConfig{Name: "runbook", URI: "docs://runbook", MimeType: "text/markdown", Path: docPath},
+			wantContent: "# Runbook\nrestart the pool",
+			wantMime:    "text/markdown",
+		},
+		{
+			desc:    "text and path",
+			cfg:     resources.ResourceConfig{Name: "r", URI: "docs://r", Text: "a", Path: docPath},
+			wantErr: "mutually exclusive",
+		},
+		{
+			desc:    "text and source",
+			cfg:     resources.ResourceConfig{Name: "r", URI: "schema://r", Text: "a", Source: "my-pg"},
+			wantErr: "mutually exclusive",
+		},
+		{
+			desc:    "no content",
+			cfg:     resources.ResourceConfig{Name: "r", URI: "docs://r"},
+			wantErr: "one of `text`, `path` or `source` is required",
+		},
+		{
+			desc:    "relative uri",
+			cfg:     resources.ResourceConfig{Name: "r", URI: "runbook", Text: "a"},
+			wantErr: "invalid uri",
+		},
+		{
+			desc:    "missing file",
+			cfg:     resources.ResourceConfig{Name: "r", URI: "docs://r", Path: filepath.Join(dir, "missing.md")},
+			wantErr: "unable to read resource file",
+		},
+		{
+			desc:    "file too large",
+			cfg:     resources.ResourceConfig{Name: "r", URI: "docs://r", Path: bigPath},
+			wantErr: "exceeds the",
+		},
+		{
+			desc:    "inline text too large",
+			cfg:     resources.ResourceConfig{Name: "r", URI: "docs://r", Text: strings.Repeat("a", resources.MaxResourceBytes+1)},
+			wantErr: "exceeds the",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := tc.cfg.Initialize()
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("got error %v, want error containing %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got.Content() != tc.wantContent {
+				t.Errorf("got content %q, want %q", got.Content(), tc.wantContent)
+			}
+			if got.MimeType != tc.wantMime {
+				t.Errorf("got mime type %q, want %q", got.MimeType, tc.wantMime)
+			}
+			if got.Manifest().Size != len(tc.wantContent) {
+				t.Errorf("got size %d, want %d", got.Manifest().Size, len(tc.wantContent))
+			}
+		})
+	}
+}
+
+func TestInitializeRereadsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "doc.txt")
+	if err := os.WriteFile(path, []byte("v1"), 0o644); err != nil {
+		t.Fatalf("unable to write file: %s", err)
+	}
+	cfg := resources.ResourceConfig{Name: "doc", URI: "docs://doc", Path: path}
+	r, err := cfg.Initialize()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if r.Content() != "v1" {
+		t.Fatalf("got content %q, want %q", r.Content(), "v1")
+	}
+	if err := os.WriteFile(path, []byte("v2"), 0o644); err != nil {
+		t.Fatalf("unable to write file: %s", err)
+	}
+	// Re-initializing the same config (as hot reload does) picks up the change.
+	r, err = r.ToConfig().Initialize()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if r.Content() != "v2" {
+		t.Errorf("got content %q, want %q", r.Content(), "v2")
+	}
+}
+
+func TestReadSourceResource(t *testing.T) {
+	cfg := resources.ResourceConfig{Name: "schema", URI: "schema://my-pg", Source: "my-pg"}
+	r, err := cfg.Initialize()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if r.MimeType != resources.SchemaMimeType {
+		t.Errorf("got mime type %q, want %q", r.MimeType, resources.SchemaMimeType)
+	}
+	if _, err := r.Read(context.Background()); err == nil {
+		t.Fatalf("expected an error reading a resource without a loader")
+	}
+
+	calls := 0
+	r = r.WithLoader(func(context.Context) (string, error) {
+		calls++
+		return `{"tables":[]}`, nil
+	})
+	for range 2 {
+		got, err := r.Read(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if got != `{"tables":[]}` {
+			t.Errorf("got content %q, want %q", got, `{"tables":[]}`)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("got %d loads, want 2", calls)
+	}
+
+	failing := r.WithLoader(func(context.Context) (string, error) { return "", errors.New("boom") })
+	if _, err := failing.Read(context.Background()); err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Errorf("got error %v, want error containing %q", err, "boom")
+	}
+	large := r.WithLoader(func(context.Context) (string, error) {
+		return strings.Repeat("a", resources.MaxResourceBytes+1), nil
+	})
+	if _, err := large.Read(context.Background()); err == nil || !strings.Contains(err.Error(), "exceeds the") {
+		t.Errorf("got error %v, want error containing %q", err, "exceeds the")
+	}
+}
@@ -0,0 +1,339 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+
+	"github.com/googleapis/mcp-toolbox/internal/auth/generic"
+	"github.com/googleapis/mcp-toolbox/internal/server/primitives"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+)
+
+const (
+	// accessPolicyKind is the kind of the documents defining access
+	// policies.
+	accessPolicyKind = "accessPolicy"
+	// apiKeyHeader is the header carrying the API key of a client.
+	apiKeyHeader = "X-API-Key"
+)
+
+// AccessPolicyConfig grants the clients presenting one of its API keys, or a
+// token of its auth service holding its claims, the use of toolsets and
+// tools.
+type AccessPolicyConfig struct {
+	Name string `yaml:"name" validate:"required"`
+	// APIKeys are the keys clients present in the X-API-Key header.
+	APIKeys []string `yaml:"apiKeys,omitempty"`
+	// AuthService is the auth service verifying the tokens of clients,
+	// which match the policy if their claims hold the values of Claims.
+	AuthService string            `yaml:"authService,omitempty"`
+	Claims      map[string]string `yaml:"claims,omitempty"`
+	// Toolsets and Tools are what the clients of the policy may use.
+	Toolsets []string `yaml:"toolsets,omitempty"`
+	Tools    []string `yaml:"tools,omitempty"`
+}
+
+type AccessPolicyConfigs map[string]AccessPolicyConfig
+
+// UnmarshalAccessPolicyConfigs returns the access policies defined in raw.
+// The other documents are left to UnmarshalPrimitiveConfig.
+func UnmarshalAccessPolicyConfigs(ctx context.Context, raw []byte) (AccessPolicyConfigs, error) {
+	var policies AccessPolicyConfigs
+	err := forEachDocOfKind(ctx, raw, accessPolicyKind, func(name string, resource map[string]any) error {
+		c := AccessPolicyConfig{Name: name}
+		dec, err := util.NewStrictDecoderAt(resource, fmt.Sprintf("accessPolicies[%s]", name))
+		if err != nil {
+			return fmt.Errorf("error creating decoder: %w", err)
+		}
+		if err := dec.DecodeContext(ctx, &c); err != nil {
+			return err
+		}
+		if _, ok := policies[name]; ok {
+			return fmt.Errorf("access policy %q is defined more than once", name)
+		}
+		if policies == nil {
+			policies = make(AccessPolicyConfigs)
+		}
+		policies[name] = c
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return policies, nil
+}
+
+// accessControl restricts the toolsets and tools clients may use to those
+// their access policies grant. The tools and toolsets no policy mentions
+// remain open to every client, unless denyByDefault is set.
+type accessControl struct {
+	policies      []AccessPolicyConfig
+	denyByDefault bool
+}
+
+// newAccessControl validates policies against the primitives of mgr. It
+// returns nil if there are no policies and access isn't denied by default.
+func newAccessControl(policies AccessPolicyConfigs, denyByDefault bool, mgr *primitives.PrimitiveManager) (*accessControl, error) {
+	if len(policies) == 0 && !denyByDefault {
+		return nil, nil
+	}
+	ac := &accessControl{denyByDefault: denyByDefault}
+	names := make([]string, 0, len(policies))
+	for name := range policies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		p := policies[name]
+		if err := validateAccessPolicy(p, mgr); err != nil {
+			return nil, fmt.Errorf("invalid access policy %q: %w", name, err)
+		}
+		ac.policies = append(ac.policies, p)
+	}
+	return ac, nil
+}
+
+func validateAccessPolicy(p AccessPolicyConfig, mgr *primitives.PrimitiveManager) error {
+	if len(p.APIKeys) == 0 && p.AuthService == "" {
+		return fmt.Errorf("apiKeys or authService is required")
+	}
+	if len(p.Claims) > 0 && p.AuthService == "" {
+		return fmt.Errorf("claims require an authService")
+	}
+	if slices.Contains(p.APIKeys, "") {
+		return fmt.Errorf("API keys may not be empty")
+	}
+	if p.AuthService != "" {
+		if _, ok := mgr.GetAuthServiceMap()[p.AuthService]; !ok {
+			return fmt.Errorf("auth service %q does not exist", p.AuthService)
+		}
+	}
+	if len(p.Toolsets) == 0 && len(p.Tools) == 0 {
+		return fmt.Errorf("toolsets or tools is required")
+	}
+	for _, name := range p.Toolsets {
+		if _, ok := mgr.GetToolset(name); !ok {
+			return fmt.Errorf("toolset %q does not exist", name)
+		}
+	}
+	for _, name := range p.Tools {
+		if _, ok := mgr.GetTool(name); !ok {
+			return fmt.Errorf("tool %q does not exist", name)
+		}
+	}
+	return nil
+}
+
+// protectsTool reports whether a policy grants the tool name, alone or
+// through a toolset.
+func (ac *accessControl) protectsTool(mgr *primitives.PrimitiveManager, name string) bool {
+	for _, p := range ac.policies {
+		if slices.Contains(p.Tools, name) || containsTool(mgr, p.Toolsets, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// protectsToolset reports whether a policy grants the toolset name.
+func (ac *accessControl) protectsToolset(name string) bool {
+	for _, p := range ac.policies {
+		if slices.Contains(p.Toolsets, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsTool(mgr *primitives.PrimitiveManager, toolsets []string, name string) bool {
+	for _, ts := range toolsets {
+		if toolset, ok := mgr.GetToolset(ts); ok && toolset.ContainsTool(name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p AccessPolicyConfig) matchesKey(key string) bool {
+	for _, k := range p.APIKeys {
+		if subtle.ConstantTimeCompare([]byte(k), []byte(key)) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesClaims reports whether claims hold the values of the claims of the
+// policy.
+func (p AccessPolicyConfig) matchesClaims(claims map[string]any) bool {
+	return matchClaims(p.Claims, claims)
+}
+
+// matchClaims reports whether claims, nil if there is no valid token, hold
+// the values of want, either as their value or as an item of their list.
+func matchClaims(want map[string]string, claims map[string]any) bool {
+	if claims == nil {
+		return false
+	}
+	for name, want := range want {
+		switch v := claims[name].(type) {
+		case []any:
+			if !slices.ContainsFunc(v, func(item any) bool { return fmt.Sprint(item) == want }) {
+				return false
+			}
+		case nil:
+			return false
+		default:
+			if fmt.Sprint(v) != want {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// accessGrant is what the policies matching a client grant it. A nil grant
+// allows everything.
+type accessGrant struct {
+	ac       *accessControl
+	mgr      *primitives.PrimitiveManager
+	toolsets []string
+	tools    []string
+}
+
+// accessGrant returns the grant of the client of a request with header. An
+// API key matching no policy is rejected.
+func (s *Server) accessGrant(ctx context.Context, header http.Header) (*accessGrant, *util.ClientServerError) {
+	ac := s.access
+	if ac == nil {
+		return nil, nil
+	}
+	g := &accessGrant{ac: ac, mgr: s.PrimitiveMgr}
+	key := header.Get(apiKeyHeader)
+	keyMatched := false
+	// claims of each auth service, verified once per request
+	claimsOf := make(map[string]map[string]any)
+	for _, p := range ac.policies {
+		matched := key != "" && p.matchesKey(key)
+		keyMatched = keyMatched || matched
+		if !matched && p.AuthService != "" {
+			claims, ok := claimsOf[p.AuthService]
+			if !ok {
+				claims = s.authServiceClaims(ctx, header, p.AuthService)
+				claimsOf[p.AuthService] = claims
+			}
+			matched = claims != nil && p.matchesClaims(claims)
+		}
+		if matched {
+			g.toolsets = append(g.toolsets, p.Toolsets...)
+			g.tools = append(g.tools, p.Tools...)
+		}
+	}
+	if key != "" && !keyMatched {
+		return nil, util.NewClientServerError("invalid API key", http.StatusUnauthorized, nil)
+	}
+	return g, nil
+}
+
+// authServiceClaims returns the claims of the token of the auth service name
+// in header, or nil if there is no valid token.
+func (s *Server) authServiceClaims(ctx context.Context, header http.Header, name string) map[string]any {
+	aS, ok := s.PrimitiveMgr.GetAuthServiceMap()[name]
+	if !ok {
+		return nil
+	}
+	if genCfg, ok := aS.ToConfig().(generic.Config); ok && genCfg.McpEnabled {
+		return util.AuthTokenClaimsFromContext(ctx)
+	}
+	claims, err := aS.GetClaimsFromHeader(ctx, header)
+	if err != nil {
+		s.logger.DebugContext(ctx, err.Error())
+		return nil
+	}
+	return claims
+}
+
+// allowsTool reports whether the grant allows using the tool name.
+func (g *accessGrant) allowsTool(name string) bool {
+	if g == nil {
+		return true
+	}
+	if slices.Contains(g.tools, name) || containsTool(g.mgr, g.toolsets, name) {
+		return true
+	}
+	return !g.ac.denyByDefault && !g.ac.protectsTool(g.mgr, name)
+}
+
+// restrict returns toolset holding only the tools the grant allows, and
+// whether the grant allows using the toolset at all.
+func (g *accessGrant) restrict(toolset tools.Toolset) (tools.Toolset, bool) {
+	if g == nil || slices.Contains(g.toolsets, toolset.Name) {
+		return toolset, true
+	}
+	if g.ac.protectsToolset(toolset.Name) {
+		return toolset, false
+	}
+	restricted := toolset.Filter(g.allowsTool)
+	return restricted, len(restricted.Tools) > 0 || !g.ac.denyByDefault
+}
+
+// checkToolAccess returns an error if the client of a request with header
+// may not use the tool name.
+func (s *Server) checkToolAccess(ctx context.Context, header http.Header, name string) *util.ClientServerError {
+	ns, err := s.requestNamespace(ctx, header)
+	if err != nil {
+		return err
+	}
+	if !s.namespaces.allowsTool(ns, name) {
+		return util.NewClientServerError(fmt.Sprintf("invalid tool name: tool with name %q does not exist", name), http.StatusNotFound, nil)
+	}
+	g, err := s.accessGrant(ctx, header)
+	if err != nil {
+		return err
+	}
+	if !g.allowsTool(name) {
+		return util.NewClientServerError(fmt.Sprintf("access to tool %q is not granted", name), http.StatusForbidden, nil)
+	}
+	return nil
+}
+
+// restrictToolset returns the toolset restricted to the tools the client of
+// a request with header may use, or an error if it may not use the toolset.
+func (s *Server) restrictToolset(ctx context.Context, header http.Header, toolset tools.Toolset) (tools.Toolset, *util.ClientServerError) {
+	ns, err := s.requestNamespace(ctx, header)
+	if err != nil {
+		return toolset, err
+	}
+	toolset, ok := s.namespaces.restrict(ns, toolset)
+	if !ok {
+		return toolset, util.NewClientServerError(fmt.Sprintf("toolset %q does not exist", toolset.Name), http.StatusNotFound, nil)
+	}
+	g, err := s.accessGrant(ctx, header)
+	if err != nil {
+		return toolset, err
+	}
+	restricted, ok := g.restrict(toolset)
+	if !ok {
+		return toolset, util.NewClientServerError(fmt.Sprintf("access to toolset %q is not granted", toolset.Name), http.StatusForbidden, nil)
+	}
+	return restricted, nil
+}
@@ -0,0 +1,212 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/internal/testutils"
+)
+
+// withAccessPolicies restricts the server with policies.
+func withAccessPolicies(t *testing.T, policies AccessPolicyConfigs, denyByDefault bool) func(*Server) {
+	return func(s *Server) {
+		var err error
+		s.access, err = newAccessControl(policies, denyByDefault, s.PrimitiveMgr)
+		if err != nil {
+			t.Fatalf("unable to create access control: %s", err)
+		}
+	}
+}
+
+var testAccessPolicies = AccessPolicyConfigs{
+	"readers": {Name: "readers", APIKeys: []string{"reader-key"}, Toolsets: []string{"tool1_only"}},
+}
+
+func TestAccessPoliciesAPI(t *testing.T) {
+	mockTools := []testutils.MockTool{testutils.MockTool1, testutils.MockTool2}
+	tool1, tool2 := testutils.MockTool1.Name, testutils.MockTool2.Name
+	toolsMap, toolsets, _, _ := testutils.SetUpResources(t, mockTools, nil)
+
+	tcs := []struct {
+		desc          string
+		denyByDefault bool
+		key           string
+		path          string
+		body          string
+		wantStatus    int
+		wantTools     []string
+	}{
+		{desc: "open toolset lists unprotected tools", path: "/toolset/", wantStatus: http.StatusOK, wantTools: []string{tool2}},
+		{desc: "protected toolset", path: "/toolset/tool1_only", wantStatus: http.StatusForbidden},
+		{desc: "protected tool", path: "/tool/" + tool1 + "/invoke", body: `{}`, wantStatus: http.StatusForbidden},
+		{desc: "unprotected tool", path: "/tool/" + tool2 + "/invoke", body: `{"param1": 1, "param2": 2}`, wantStatus: http.StatusOK},
+		{desc: "granted toolset", key: "reader-key", path: "/toolset/tool1_only", wantStatus: http.StatusOK, wantTools: []string{tool1}},
+		{desc: "granted tool", key: "reader-key", path: "/tool/" + tool1 + "/invoke", body: `{}`, wantStatus: http.StatusOK},
+		{desc: "granted and unprotected tools", key: "reader-key", path: "/toolset/", wantStatus: http.StatusOK, wantTools: []string{tool1, tool2}},
+		{desc: "invalid key", key: "wrong", path: "/toolset/", wantStatus: http.StatusUnauthorized},
+		{desc: "deny by default", denyByDefault: true, path: "/tool/" + tool2 + "/invoke", body: `{"param1": 1, "param2": 2}`, wantStatus: http.StatusForbidden},
+		{desc: "deny by default lists granted tools", denyByDefault: true, key: "reader-key", path: "/toolset/", wantStatus: http.StatusOK, wantTools: []string{tool1}},
+		{desc: "deny by default without grants", denyByDefault: true, path: "/toolset/", wantStatus: http.StatusForbidden},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			r, shutdown := setUpServer(t, "api", toolsMap, toolsets, nil, nil, withAccessPolicies(t, testAccessPolicies, tc.denyByDefault))
+			defer shutdown()
+			ts := runServer(r, false)
+			defer ts.Close()
+
+			method := http.MethodGet
+			var body io.Reader
+			if tc.body != "" {
+				method = http.MethodPost
+				body = strings.NewReader(tc.body)
+			}
+			var header map[string]string
+			if tc.key != "" {
+				header = map[string]string{apiKeyHeader: tc.key}
+			}
+			resp, got, err := runRequest(ts, method, tc.path, body, header)
+			if err != nil {
+				t.Fatalf("unexpected error during request: %s", err)
+			}
+			if resp.StatusCode != tc.wantStatus {
+				t.Fatalf("unexpected status: got %d, want %d: %s", resp.StatusCode, tc.wantStatus, got)
+			}
+			if tc.wantTools == nil {
+				return
+			}
+			var manifest struct {
+				Tools map[string]any `json:"tools"`
+			}
+			if err := json.Unmarshal(got, &manifest); err != nil {
+				t.Fatalf("unable to decode manifest: %s", err)
+			}
+			names := make([]string, 0, len(manifest.Tools))
+			for name := range manifest.Tools {
+				names = append(names, name)
+			}
+			slices.Sort(names)
+			if diff := cmp.Diff(tc.wantTools, names); diff != "" {
+				t.Fatalf("incorrect tools (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAccessPoliciesMCP(t *testing.T) {
+	mockTools := []testutils.MockTool{testutils.MockTool1, testutils.MockTool2}
+	toolsMap, toolsets, _, _ := testutils.SetUpResources(t, mockTools, nil)
+	r, shutdown := setUpServer(t, "mcp", toolsMap, toolsets, nil, nil, withAccessPolicies(t, testAccessPolicies, false))
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	listTools := `{"jsonrpc": "2.0", "id": "list", "method": "tools/list"}`
+	resp, body, err := runRequest(ts, http.MethodPost, "/", strings.NewReader(listTools), nil)
+	if err != nil {
+		t.Fatalf("unexpected error during request: %s", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected status: %d: %s", resp.StatusCode, body)
+	}
+	if strings.Contains(string(body), testutils.MockTool1.Name) || !strings.Contains(string(body), testutils.MockTool2.Name) {
+		t.Fatalf("expected only the unprotected tool to be listed: %s", body)
+	}
+
+	callTool := `{"jsonrpc": "2.0", "id": "call", "method": "tools/call", "params": {"name": "` + testutils.MockTool1.Name + `", "arguments": {}}}`
+	_, body, err = runRequest(ts, http.MethodPost, "/", strings.NewReader(callTool), nil)
+	if err != nil {
+		t.Fatalf("unexpected error during request: %s", err)
+	}
+	if !strings.Contains(string(body), "does not exist") {
+		t.Fatalf("expected the protected tool to be hidden: %s", body)
+	}
+
+	resp, body, err = runRequest(ts, http.MethodPost, "/tool1_only", strings.NewReader(listTools), nil)
+	if err != nil {
+		t.Fatalf("unexpected error during request: %s", err)
+	}
+	if resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected the protected toolset to be forbidden, got %d: %s", resp.StatusCode, body)
+	}
+
+	resp, body, err = runRequest(ts, http.MethodPost, "/tool1_only", strings.NewReader(listTools), map[string]string{apiKeyHeader: "reader-key"})
+	if err != nil {
+		t.Fatalf("unexpected error during request: %s", err)
+	}
+	if resp.StatusCode != http.StatusOK || !strings.Contains(string(body), testutils.MockTool1.Name) {
+		t.Fatalf("expected the granted toolset to be listed, got %d: %s", resp.StatusCode, body)
+	}
+}
+
+func TestUnmarshalAccessPolicyConfigs(t *testing.T) {
+	raw := `
+kind: accessPolicy
+name: support
+authService: my-auth
+claims:
+  hd: example.com
+tools:
+  - search
+`
+	got, err := UnmarshalAccessPolicyConfigs(context.Background(), []byte(raw))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := AccessPolicyConfigs{
+		"support": {Name: "support", AuthService: "my-auth", Claims: map[string]string{"hd": "example.com"}, Tools: []string{"search"}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect policies (-want +got):\n%s", diff)
+	}
+}
+
+func TestNewAccessControlValidation(t *testing.T) {
+	mockTools := []testutils.MockTool{testutils.MockTool1, testutils.MockTool2}
+	toolsMap, toolsets, _, _ := testutils.SetUpResources(t, mockTools, nil)
+	var s *Server
+	_, shutdown := setUpServer(t, "api", toolsMap, toolsets, nil, nil, func(srv *Server) { s = srv })
+	defer shutdown()
+
+	tcs := []struct {
+		desc   string
+		policy AccessPolicyConfig
+		want   string
+	}{
+		{desc: "no clients", policy: AccessPolicyConfig{Tools: []string{testutils.MockTool1.Name}}, want: "apiKeys or authService is required"},
+		{desc: "claims without auth service", policy: AccessPolicyConfig{APIKeys: []string{"k"}, Claims: map[string]string{"a": "b"}, Tools: []string{testutils.MockTool1.Name}}, want: "claims require an authService"},
+		{desc: "unknown auth service", policy: AccessPolicyConfig{AuthService: "missing", Tools: []string{testutils.MockTool1.Name}}, want: `auth service "missing" does not exist`},
+		{desc: "no grants", policy: AccessPolicyConfig{APIKeys: []string{"k"}}, want: "toolsets or tools is required"},
+		{desc: "unknown toolset", policy: AccessPolicyConfig{APIKeys: []string{"k"}, Toolsets: []string{"missing"}}, want: `toolset "missing" does not exist`},
+		{desc: "unknown tool", policy: AccessPolicyConfig{APIKeys: []string{"k"}, Tools: []string{"missing"}}, want: `tool "missing" does not exist`},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			tc.policy.Name = "p"
+			_, err := newAccessControl(AccessPolicyConfigs{"p": tc.policy}, false, s.PrimitiveMgr)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("expected an error containing %q, got %v", tc.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+)
+
+// hideDisabledParam is the query parameter that hides disabled tools from
+// manifests instead of marking them disabled.
+const hideDisabledParam = "hideDisabled"
+
+// toolEnabledRequest is the body of requests enabling or disabling a tool.
+type toolEnabledRequest struct {
+	Enabled *bool `json:"enabled"`
+}
+
+// toolEnabledResponse reports the state of a tool.
+type toolEnabledResponse struct {
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+}
+
+// toolEnabledHandler enables or disables a tool at runtime. It requires the
+// admin token.
+func toolEnabledHandler(s *Server, w http.ResponseWriter, r *http.Request) {
+	toolName := chi.URLParam(r, "toolName")
+	if !s.isAdmin(r.Header) {
+		err := fmt.Errorf("a valid %s header is required", adminTokenHeader)
+		_ = render.Render(w, r, newErrResponse(err, http.StatusForbidden))
+		return
+	}
+
+	var req toolEnabledRequest
+	if err := util.DecodeJSON(r.Body, &req); err != nil || req.Enabled == nil {
+		err = fmt.Errorf(`request body must be a JSON object such as {"enabled": false}`)
+		_ = render.Render(w, r, newErrResponse(err, http.StatusBadRequest))
+		return
+	}
+	if !s.PrimitiveMgr.SetToolEnabled(toolName, *req.Enabled) {
+		err := fmt.Errorf("invalid tool name: tool with name %q does not exist", toolName)
+		_ = render.Render(w, r, newErrResponse(err, http.StatusNotFound))
+		return
+	}
+	if *req.Enabled {
+		s.logger.WarnContext(r.Context(), fmt.Sprintf("tool %q enabled by administrator", toolName))
+	} else {
+		s.logger.WarnContext(r.Context(), fmt.Sprintf("tool %q disabled by administrator", toolName))
+	}
+	render.JSON(w, r, toolEnabledResponse{Name: toolName, Enabled: *req.Enabled})
+}
+
+// resetPlanHashResponse reports the tool whose plan hash was reset.
+type resetPlanHashResponse struct {
+	Name  string `json:"name"`
+	Reset bool   `json:"reset"`
+}
+
+// resetPlanHashHandler forgets the stored query plan hash of a tool, so that
+// its next invocation stores a new one. It requires the admin token.
+func resetPlanHashHandler(s *Server, w http.ResponseWriter, r *http.Request) {
+	toolName := chi.URLParam(r, "toolName")
+	if !s.isAdmin(r.Header) {
+		err := fmt.Errorf("a valid %s header is required", adminTokenHeader)
+		_ = render.Render(w, r, newErrResponse(err, http.StatusForbidden))
+		return
+	}
+
+	tool, ok := s.PrimitiveMgr.GetTool(toolName)
+	if !ok {
+		err := fmt.Errorf("invalid tool name: tool with name %q does not exist", toolName)
+		_ = render.Render(w, r, newErrResponse(err, http.StatusNotFound))
+		return
+	}
+	monitor, ok := tools.As[tools.PlanMonitor](tool)
+	if !ok || !monitor.ResetPlanHash() {
+		err := fmt.Errorf("tool %q does not monitor its query plan", toolName)
+		_ = render.Render(w, r, newErrResponse(err, http.StatusBadRequest))
+		return
+	}
+	s.logger.InfoContext(r.Context(), fmt.Sprintf("query plan hash of tool %q reset by administrator", toolName))
+	render.JSON(w, r, resetPlanHashResponse{Name: toolName, Reset: true})
+}
+
+// markDisabledTools marks the disabled tools of manifests, or removes them
+// if the request asks to hide them.
+func markDisabledTools(s *Server, r *http.Request, manifests map[string]tools.Manifest) {
+	hide := r.URL.Query().Get(hideDisabledParam) == "true"
+	for name, m := range manifests {
+		if !s.PrimitiveMgr.IsToolDisabled(name) {
+			continue
+		}
+		if hide {
+			delete(manifests, name)
+			continue
+		}
+		m.Disabled = true
+		manifests[name] = m
+	}
+}
@@ -0,0 +1,314 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/internal/server/pagination"
+	"github.com/googleapis/mcp-toolbox/internal/testutils"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+)
+
+func newAdminTestTools(t *testing.T) (map[string]tools.Tool, map[string]tools.Toolset) {
+	t.Helper()
+	toolsMap := map[string]tools.Tool{
+		"tool_a": testutils.NewMockTool("tool_a", "Tool A", nil, false, false),
+		"tool_b": testutils.NewMockTool("tool_b", "Tool B", nil, false, false),
+	}
+	toolset, err := tools.ToolsetConfig{Name: "", ToolNames: []string{"tool_a", "tool_b"}}.Initialize(testutils.MockVersionString, toolsMap)
+	if err != nil {
+		t.Fatalf("unable to initialize toolset: %s", err)
+	}
+	return toolsMap, map[string]tools.Toolset{"": toolset}
+}
+
+func TestToolEnabledHandler(t *testing.T) {
+	toolsMap, toolsets := newAdminTestTools(t)
+	withAdminToken := func(s *Server) { s.adminToken = "secret" }
+	r, shutdown := setUpServer(t, "api", toolsMap, toolsets, nil, nil, withAdminToken)
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	admin := map[string]string{adminTokenHeader: "secret"}
+	tcs := []struct {
+		desc       string
+		path       string
+		body       string
+		header     map[string]string
+		wantStatus int
+	}{
+		{desc: "missing admin token", path: "/admin/tools/tool_a/enabled", body: `{"enabled": false}`, wantStatus: http.StatusForbidden},
+		{desc: "wrong admin token", path: "/admin/tools/tool_a/enabled", body: `{"enabled": false}`, header: map[string]string{adminTokenHeader: "wrong"}, wantStatus: http.StatusForbidden},
+		{desc: "missing enabled", path: "/admin/tools/tool_a/enabled", body: `{}`, header: admin, wantStatus: http.StatusBadRequest},
+		{desc: "invalid body", path: "/admin/tools/tool_a/enabled", body: `{"enabled": "no"}`, header: admin, wantStatus: http.StatusBadRequest},
+		{desc: "unknown tool", path: "/admin/tools/missing/enabled", body: `{"enabled": false}`, header: admin, wantStatus: http.StatusNotFound},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			resp, body, err := runRequest(ts, http.MethodPut, tc.path, strings.NewReader(tc.body), tc.header)
+			if err != nil {
+				t.Fatalf("unexpected error during request: %s", err)
+			}
+			if resp.StatusCode != tc.wantStatus {
+				t.Fatalf("unexpected status: got %d, want %d, body: %s", resp.StatusCode, tc.wantStatus, body)
+			}
+		})
+	}
+
+	resp, body, err := runRequest(ts, http.MethodGet, "/debug/config", nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error during request: %s", err)
+	}
+	if resp.StatusCode != http.StatusOK || !strings.Contains(string(body), `"disabledTools":[]`) {
+		t.Errorf("expected no disabled tools after failed requests, got %d: %s", resp.StatusCode, body)
+	}
+}
+
+func TestDisabledToolAPI(t *testing.T) {
+	toolsMap, toolsets := newAdminTestTools(t)
+	withAdminToken := func(s *Server) { s.adminToken = "secret" }
+	r, shutdown := setUpServer(t, "api", toolsMap, toolsets, nil, nil, withAdminToken)
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	setEnabled := func(t *testing.T, enabled bool) {
+		t.Helper()
+		body := `{"enabled": false}`
+		if enabled {
+			body = `{"enabled": true}`
+		}
+		resp, got, err := runRequest(ts, http.MethodPut, "/admin/tools/tool_a/enabled", strings.NewReader(body), map[string]string{adminTokenHeader: "secret"})
+		if err != nil {
+			t.Fatalf("unexpected error during request: %s", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("unexpected status: %d, body: %s", resp.StatusCode, got)
+		}
+		var res toolEnabledResponse
+		if err := json.Unmarshal(got, &res); err != nil {
+			t.Fatalf("unable to decode response: %s", err)
+		}
+		if diff := cmp.Diff(toolEnabledResponse{Name: "tool_a", Enabled: enabled}, res); diff != "" {
+			t.Errorf("unexpected response (-want +got):\n%s", diff)
+		}
+	}
+	toolset := func(t *testing.T, path string) tools.ToolsetManifest {
+		t.Helper()
+		resp, body, err := runRequest(ts, http.MethodGet, path, nil, nil)
+		if err != nil {
+			t.Fatalf("unexpected error during request: %s", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("unexpected status: %d, body: %s", resp.StatusCode, body)
+		}
+		var m tools.ToolsetManifest
+		if err := json.Unmarshal(body, &m); err != nil {
+			t.Fatalf("unable to decode response: %s", err)
+		}
+		return m
+	}
+
+	setEnabled(t, false)
+
+	resp, body, err := runRequest(ts, http.MethodPost, "/tool/tool_a/invoke", strings.NewReader(`{}`), nil)
+	if err != nil {
+		t.Fatalf("unexpected error during request: %s", err)
+	}
+	if resp.StatusCode != http.StatusServiceUnavailable || !strings.Contains(string(body), "tool disabled by administrator") {
+		t.Errorf("expected disabled tool error, got %d: %s", resp.StatusCode, body)
+	}
+	resp, body, err = runRequest(ts, http.MethodPost, "/tool/tool_b/invoke", strings.NewReader(`{}`), nil)
+	if err != nil {
+		t.Fatalf("unexpected error during request: %s", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected other tools to stay enabled, got %d: %s", resp.StatusCode, body)
+	}
+
+	m := toolset(t, "/toolset/")
+	if !m.ToolsManifest["tool_a"].Disabled || m.ToolsManifest["tool_b"].Disabled {
+		t.Errorf("unexpected disabled annotations: %+v", m.ToolsManifest)
+	}
+	m = toolset(t, "/toolset/?hideDisabled=true")
+	if _, ok := m.ToolsManifest["tool_a"]; ok {
+		t.Errorf("expected disabled tool to be hidden: %+v", m.ToolsManifest)
+	}
+	if _, ok := m.ToolsManifest["tool_b"]; !ok {
+		t.Errorf("expected enabled tool to be listed: %+v", m.ToolsManifest)
+	}
+	if m := toolset(t, "/tool/tool_a"); !m.ToolsManifest["tool_a"].Disabled {
+		t.Errorf("expected tool manifest to be marked disabled: %+v", m.ToolsManifest)
+	}
+
+	resp, body, err = runRequest(ts, http.MethodGet, "/debug/config", nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error during request: %s", err)
+	}
+	var cfg debugConfigResponse
+	if err := json.Unmarshal(body, &cfg); err != nil {
+		t.Fatalf("unable to decode response: %s", err)
+	}
+	if diff := cmp.Diff(debugConfigResponse{DisabledTools: []string{"tool_a"}}, cfg); diff != "" {
+		t.Errorf("unexpected config (-want +got):\n%s", diff)
+	}
+
+	setEnabled(t, true)
+
+	resp, body, err = runRequest(ts, http.MethodPost, "/tool/tool_a/invoke", strings.NewReader(`{}`), nil)
+	if err != nil {
+		t.Fatalf("unexpected error during request: %s", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected re-enabled tool to run, got %d: %s", resp.StatusCode, body)
+	}
+	if m := toolset(t, "/toolset/"); m.ToolsManifest["tool_a"].Disabled {
+		t.Errorf("expected re-enabled tool not to be marked disabled")
+	}
+}
+
+func TestDisabledToolMCP(t *testing.T) {
+	toolsMap, toolsets := newAdminTestTools(t)
+	var server *Server
+	r, shutdown := setUpServer(t, "mcp", toolsMap, toolsets, nil, nil, func(s *Server) { server = s })
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	server.PrimitiveMgr.SetToolEnabled("tool_a", false)
+
+	resp, body, err := runRequest(ts, http.MethodPost, mcpExportCallPath, strings.NewReader(`{"name": "tool_a", "arguments": {}}`), nil)
+	if err != nil {
+		t.Fatalf("unexpected error during request: %s", err)
+	}
+	if resp.StatusCode != http.StatusServiceUnavailable || !strings.Contains(string(body), "tool disabled by administrator") {
+		t.Errorf("expected disabled tool error, got %d: %s", resp.StatusCode, body)
+	}
+
+	listTools := func(t *testing.T, path string) map[string]map[string]any {
+		t.Helper()
+		resp, body, err := runRequest(ts, http.MethodPost, path, strings.NewReader(`{"jsonrpc": "2.0", "id": "list", "method": "tools/list"}`), nil)
+		if err != nil {
+			t.Fatalf("unexpected error during request: %s", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("unexpected status: %d, body: %s", resp.StatusCode, body)
+		}
+		var got struct {
+			Result struct {
+				Tools []struct {
+					Name string         `json:"name"`
+					Meta map[string]any `json:"_meta"`
+				} `json:"tools"`
+			} `json:"result"`
+		}
+		if err := json.Unmarshal(body, &got); err != nil {
+			t.Fatalf("unable to decode response: %s", err)
+		}
+		meta := make(map[string]map[string]any)
+		for _, tool := range got.Result.Tools {
+			meta[tool.Name] = tool.Meta
+		}
+		return meta
+	}
+	meta := listTools(t, "/")
+	if meta["tool_a"]["toolbox/disabled"] != true {
+		t.Errorf("expected disabled tool to be marked, got %+v", meta["tool_a"])
+	}
+	if _, ok := meta["tool_b"]["toolbox/disabled"]; ok {
+		t.Errorf("expected enabled tool not to be marked, got %+v", meta["tool_b"])
+	}
+	meta = listTools(t, "/?hideDisabled=true")
+	if _, ok := meta["tool_a"]; ok {
+		t.Errorf("expected disabled tool to be hidden")
+	}
+	if _, ok := meta["tool_b"]; !ok {
+		t.Errorf("expected enabled tool to be listed")
+	}
+
+	server.PrimitiveMgr.SetToolEnabled("tool_a", true)
+
+	resp, body, err = runRequest(ts, http.MethodPost, mcpExportCallPath, strings.NewReader(`{"name": "tool_a", "arguments": {}}`), nil)
+	if err != nil {
+		t.Fatalf("unexpected error during request: %s", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected re-enabled tool to run, got %d: %s", resp.StatusCode, body)
+	}
+}
+
+// planMonitorTool is a mock tool monitoring its query plan.
+type planMonitorTool struct {
+	testutils.MockTool
+	resets *int
+}
+
+func (t planMonitorTool) ResetPlanHash() bool {
+	*t.resets++
+	return true
+}
+
+func TestResetPlanHashHandler(t *testing.T) {
+	toolsMap, _ := newAdminTestTools(t)
+	var resets int
+	toolsMap["tool_c"] = planMonitorTool{MockTool: testutils.NewMockTool("tool_c", "Tool C", nil, false, false), resets: &resets}
+	// the plan monitor of a wrapped tool is found through its wrappers
+	wrapped := map[string]tools.Tool{"tool_d": planMonitorTool{MockTool: testutils.NewMockTool("tool_d", "Tool D", nil, false, false), resets: &resets}}
+	wrapped = tools.WrapRateLimits(pagination.Wrap(wrapped, nil), tools.RateLimit{RequestsPerMinute: 60})
+	toolsMap["tool_d"] = wrapped["tool_d"]
+	toolset, err := tools.ToolsetConfig{Name: "", ToolNames: []string{"tool_a", "tool_b", "tool_c", "tool_d"}}.Initialize(testutils.MockVersionString, toolsMap)
+	if err != nil {
+		t.Fatalf("unable to initialize toolset: %s", err)
+	}
+	withAdminToken := func(s *Server) { s.adminToken = "secret" }
+	r, shutdown := setUpServer(t, "api", toolsMap, map[string]tools.Toolset{"": toolset}, nil, nil, withAdminToken)
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	admin := map[string]string{adminTokenHeader: "secret"}
+	tcs := []struct {
+		desc       string
+		path       string
+		header     map[string]string
+		wantStatus int
+	}{
+		{desc: "missing admin token", path: "/admin/tools/tool_c/reset-plan-hash", wantStatus: http.StatusForbidden},
+		{desc: "unknown tool", path: "/admin/tools/missing/reset-plan-hash", header: admin, wantStatus: http.StatusNotFound},
+		{desc: "tool without plan monitoring", path: "/admin/tools/tool_a/reset-plan-hash", header: admin, wantStatus: http.StatusBadRequest},
+		{desc: "reset", path: "/admin/tools/tool_c/reset-plan-hash", header: admin, wantStatus: http.StatusOK},
+		{desc: "reset wrapped tool", path: "/admin/tools/tool_d/reset-plan-hash", header: admin, wantStatus: http.StatusOK},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			resp, body, err := runRequest(ts, http.MethodPost, tc.path, nil, tc.header)
+			if err != nil {
+				t.Fatalf("unexpected error during request: %s", err)
+			}
+			if resp.StatusCode != tc.wantStatus {
+				t.Fatalf("unexpected status: got %d, want %d, body: %s", resp.StatusCode, tc.wantStatus, body)
+			}
+		})
+	}
+	if resets != 2 {
+		t.Errorf("unexpected number of resets: got %d, want 2", resets)
+	}
+}
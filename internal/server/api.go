@@ -15,15 +15,26 @@
 package server
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
+	"github.com/googleapis/mcp-toolbox/internal/auth"
 	"github.com/googleapis/mcp-toolbox/internal/auth/generic"
+	mcputil "github.com/googleapis/mcp-toolbox/internal/server/mcp/util"
+	"github.com/googleapis/mcp-toolbox/internal/server/pagination"
+	"github.com/googleapis/mcp-toolbox/internal/server/resultformat"
 	"github.com/googleapis/mcp-toolbox/internal/tools"
 	"github.com/googleapis/mcp-toolbox/internal/util"
 	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
@@ -35,18 +46,31 @@ import (
 func apiRouter(s *Server) (chi.Router, error) {
 	r := chi.NewRouter()
 
-	r.Use(middleware.AllowContentType("application/json"))
+	r.Use(middleware.AllowContentType("application/json", markdownContentType))
 	r.Use(middleware.StripSlashes)
 	r.Use(render.SetContentType(render.ContentTypeJSON))
+	r.Use(sourceIPMiddleware(s))
 
 	r.Get("/toolset", func(w http.ResponseWriter, r *http.Request) { toolsetHandler(s, w, r) })
 	r.Get("/toolset/{toolsetName}", func(w http.ResponseWriter, r *http.Request) { toolsetHandler(s, w, r) })
 
 	r.Route("/tool/{toolName}", func(r chi.Router) {
 		r.Get("/", func(w http.ResponseWriter, r *http.Request) { toolGetHandler(s, w, r) })
-		r.Post("/invoke", func(w http.ResponseWriter, r *http.Request) { toolInvokeHandler(s, w, r) })
+		r.With(drainMiddleware(s), signingMiddleware(s)).Post("/invoke", func(w http.ResponseWriter, r *http.Request) { toolInvokeHandler(s, w, r) })
 	})
 
+	r.With(drainMiddleware(s), signingMiddleware(s)).Post("/batch", func(w http.ResponseWriter, r *http.Request) { batchInvokeHandler(s, w, r) })
+	r.Get("/tools/{toolName}/params/{paramName}/suggestions", func(w http.ResponseWriter, r *http.Request) { suggestionsHandler(s, w, r) })
+	r.Get("/job/{jobID}", func(w http.ResponseWriter, r *http.Request) { asyncResultHandler(s, w, r) })
+	r.Get("/operations/{jobID}", func(w http.ResponseWriter, r *http.Request) { asyncResultHandler(s, w, r) })
+	r.Get("/page/{pageToken}", func(w http.ResponseWriter, r *http.Request) { pageHandler(s, w, r) })
+	r.Get("/usage", func(w http.ResponseWriter, r *http.Request) { usageHandler(s, w, r) })
+	r.Get("/debug/schema-drift", func(w http.ResponseWriter, r *http.Request) { schemaDriftHandler(s, w, r) })
+	r.Get("/debug/config", func(w http.ResponseWriter, r *http.Request) { debugConfigHandler(s, w, r) })
+
+	r.Put("/admin/tools/{toolName}/enabled", func(w http.ResponseWriter, r *http.Request) { toolEnabledHandler(s, w, r) })
+	r.Post("/admin/tools/{toolName}/reset-plan-hash", func(w http.ResponseWriter, r *http.Request) { resetPlanHashHandler(s, w, r) })
+
 	return r, nil
 }
 
@@ -73,6 +97,13 @@ func toolsetHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 		_ = render.Render(w, r, newErrResponse(err, http.StatusNotFound))
 		return
 	}
+	toolset, accessErr := s.restrictToolset(ctx, r.Header, toolset)
+	if accessErr != nil {
+		err = accessErr
+		s.logger.DebugContext(ctx, err.Error())
+		_ = render.Render(w, r, newErrResponse(err, accessErr.Code))
+		return
+	}
 
 	manifest, err := toolset.BuildManifest(s.PrimitiveMgr.GetSourcesMap())
 	if err != nil {
@@ -80,10 +111,77 @@ func toolsetHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 		_ = render.Render(w, r, newErrResponse(err, http.StatusInternalServerError))
 		return
 	}
+	locales := s.requestLocales(r.Header, toolset)
+	for _, tool := range toolset.Tools {
+		name := (*tool).GetName()
+		manifest.ToolsManifest[name] = tools.LocalizeManifest(manifest.ToolsManifest[name], tools.Localize(*tool, locales))
+	}
+	markDisabledTools(s, r, manifest.ToolsManifest)
+	if err = pageToolsManifest(s, r, &manifest); err != nil {
+		s.logger.DebugContext(ctx, err.Error())
+		_ = render.Render(w, r, newErrResponse(err, http.StatusBadRequest))
+		return
+	}
+	w.Header().Add("Vary", "Accept-Language")
 
 	render.JSON(w, r, manifest)
 }
 
+// pageToolsManifest keeps the page of the tools of manifest requested by the
+// pageToken and pageSize query parameters, the page size defaulting to
+// --list-page-size. Tools are listed in the order of their names.
+func pageToolsManifest(s *Server, r *http.Request, manifest *tools.ToolsetManifest) error {
+	size := s.listPageSize
+	if v := r.URL.Query().Get("pageSize"); v != "" {
+		var err error
+		size, err = strconv.Atoi(v)
+		if err != nil || size < 0 {
+			return fmt.Errorf("invalid page size %q", v)
+		}
+	}
+	names := slices.Sorted(maps.Keys(manifest.ToolsManifest))
+	page, next, err := pagination.Page(names, r.URL.Query().Get("pageToken"), size)
+	if err != nil {
+		return err
+	}
+	if len(page) < len(names) {
+		toolsManifest := make(map[string]tools.Manifest, len(page))
+		for _, name := range page {
+			toolsManifest[name] = manifest.ToolsManifest[name]
+		}
+		manifest.ToolsManifest = toolsManifest
+	}
+	manifest.NextPageToken = next
+	return nil
+}
+
+// pageHandler handles the requests for the next page of a tool result split
+// into pages.
+func pageHandler(s *Server, w http.ResponseWriter, r *http.Request) {
+	token := chi.URLParam(r, "pageToken")
+	if s.pages == nil {
+		_ = render.Render(w, r, newErrResponse(pagination.ErrInvalidToken, http.StatusNotFound))
+		return
+	}
+	rows, _, next, err := s.pages.Page(token)
+	if err != nil {
+		_ = render.Render(w, r, newErrResponse(err, http.StatusNotFound))
+		return
+	}
+	resMarshal, err := json.Marshal(rows)
+	if err != nil {
+		err = fmt.Errorf("unable to marshal result: %w", err)
+		s.logger.DebugContext(r.Context(), err.Error())
+		_ = render.Render(w, r, newErrResponse(err, http.StatusInternalServerError))
+		return
+	}
+	var metadata map[string]any
+	if next != "" {
+		metadata = map[string]any{mcputil.NextPageTokenMetaKey: next}
+	}
+	_ = render.Render(w, r, &resultResponse{Result: string(resMarshal), Metadata: metadata})
+}
+
 // toolGetHandler handles requests for a single Tool.
 func toolGetHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 	ctx, span := s.instrumentation.Tracer.Start(r.Context(), "toolbox/server/tool/get")
@@ -107,6 +205,12 @@ func toolGetHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 		_ = render.Render(w, r, newErrResponse(err, http.StatusNotFound))
 		return
 	}
+	if accessErr := s.checkToolAccess(ctx, r.Header, toolName); accessErr != nil {
+		err = accessErr
+		s.logger.DebugContext(ctx, err.Error())
+		_ = render.Render(w, r, newErrResponse(err, accessErr.Code))
+		return
+	}
 	toolManifest, err := tool.Manifest(s.PrimitiveMgr.GetSourcesMap())
 	if err != nil {
 		err = fmt.Errorf("error generating manifest for tool %q: %w", toolName, err)
@@ -114,6 +218,8 @@ func toolGetHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 		_ = render.Render(w, r, newErrResponse(err, http.StatusInternalServerError))
 		return
 	}
+	toolManifest = tools.LocalizeManifest(toolManifest, tools.Localize(tool, s.requestLocales(r.Header, tools.Toolset{})))
+	w.Header().Add("Vary", "Accept-Language")
 	// TODO: this can be optimized later with some caching
 	m := tools.ToolsetManifest{
 		ServerVersion: s.version,
@@ -121,19 +227,61 @@ func toolGetHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 			toolName: toolManifest,
 		},
 	}
+	markDisabledTools(s, r, m.ToolsManifest)
 
 	render.JSON(w, r, m)
 }
 
+// verifiedClaims returns the claims of the tokens of a request verified by
+// the auth services of the server, by name of auth service, and the first
+// token rejected for having expired.
+func (s *Server) verifiedClaims(ctx context.Context, header http.Header) (map[string]map[string]any, *auth.TokenExpiredError) {
+	claimsFromAuth := make(map[string]map[string]any)
+	var expiredErr *auth.TokenExpiredError
+	for _, aS := range s.PrimitiveMgr.GetAuthServiceMap() {
+		var claims map[string]any
+		var err error
+
+		cfg := aS.ToConfig()
+		if genCfg, ok := cfg.(generic.Config); ok && genCfg.McpEnabled {
+			claims = util.AuthTokenClaimsFromContext(ctx)
+		} else {
+			claims, err = aS.GetClaimsFromHeader(ctx, header)
+			if err != nil {
+				s.logger.DebugContext(ctx, err.Error())
+				if expiredErr == nil {
+					errors.As(err, &expiredErr)
+				}
+				continue
+			}
+		}
+
+		if claims == nil {
+			// authService not present in header
+			continue
+		}
+		claimsFromAuth[aS.GetName()] = claims
+	}
+	return claimsFromAuth, expiredErr
+}
+
 // toolInvokeHandler handles the API request to invoke a specific Tool.
 func toolInvokeHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 	ctx, span := s.instrumentation.Tracer.Start(r.Context(), "toolbox/server/tool/invoke")
 	r = r.WithContext(ctx)
 	ctx = util.WithLogger(r.Context(), s.logger)
+	ctx = s.withToolVariant(ctx, r.Header)
+	ctx = util.WithReplicaLag(ctx, &util.ReplicaLag{})
+	ctx = util.WithInvocationCost(ctx, &util.InvocationCost{})
+	ctx = util.WithResultPage(ctx, &util.ResultPage{})
+	ctx = util.WithParamCoercion(ctx, s.paramCoercion)
+	ctx = util.WithRedactErrorDetails(ctx, s.redactErrorDetails)
 
 	toolName := chi.URLParam(r, "toolName")
 	s.logger.DebugContext(ctx, fmt.Sprintf("tool name: %s", toolName))
 	span.SetAttributes(attribute.String("tool_name", toolName))
+	// sources read the tool name to label the queries they run
+	ctx = util.WithGenAIMetricAttrs(ctx, &util.GenAIMetricAttrs{ToolName: toolName})
 	var err error
 	defer func() {
 		if err != nil {
@@ -149,6 +297,39 @@ func toolInvokeHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 		_ = render.Render(w, r, newErrResponse(err, http.StatusNotFound))
 		return
 	}
+	if accessErr := s.checkToolAccess(ctx, r.Header, toolName); accessErr != nil {
+		err = accessErr
+		s.logger.DebugContext(ctx, err.Error())
+		_ = render.Render(w, r, newErrResponse(err, accessErr.Code))
+		return
+	}
+	if disabledErr := s.PrimitiveMgr.CheckToolEnabled(toolName); disabledErr != nil {
+		err = disabledErr
+		s.logger.DebugContext(ctx, err.Error())
+		_ = render.Render(w, r, newErrResponse(err, disabledErr.Code))
+		return
+	}
+	phases := tools.TraceInvocation(ctx, tool)
+	defer func() { phases.End(err) }()
+
+	w.Header().Add("Vary", "Accept")
+	negotiator := NewContentNegotiator(resultformat.SupportedFormats(tool))
+	outputFormat, ok := negotiator.Negotiate(r.Header.Get("Accept"))
+	if !ok {
+		err = fmt.Errorf("none of the formats of tool %q is acceptable: %s", toolName, strings.Join(negotiator.formats, ", "))
+		s.logger.DebugContext(ctx, err.Error())
+		_ = render.Render(w, r, newErrResponse(err, http.StatusNotAcceptable))
+		return
+	}
+
+	format := responseFormat(tool)
+	toolUseID := r.Header.Get(toolUseIDHeader)
+	if format == tools.ResponseFormatAnthropicContentBlocks && toolUseID == "" {
+		err = fmt.Errorf("the %s header is required by tools with responseFormat %q", toolUseIDHeader, format)
+		s.logger.DebugContext(ctx, err.Error())
+		_ = render.Render(w, r, newErrResponse(err, http.StatusBadRequest))
+		return
+	}
 
 	// Extract OAuth access token from the "Authorization" header (currently for
 	// BigQuery end-user credentials usage only)
@@ -172,29 +353,13 @@ func toolInvokeHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Tool authentication
-	// claimsFromAuth maps the name of the authservice to the claims retrieved from it.
-	claimsFromAuth := make(map[string]map[string]any)
-	for _, aS := range s.PrimitiveMgr.GetAuthServiceMap() {
-		var claims map[string]any
-		var err error
-
-		cfg := aS.ToConfig()
-		if genCfg, ok := cfg.(generic.Config); ok && genCfg.McpEnabled {
-			claims = util.AuthTokenClaimsFromContext(ctx)
-		} else {
-			claims, err = aS.GetClaimsFromHeader(ctx, r.Header)
-			if err != nil {
-				s.logger.DebugContext(ctx, err.Error())
-				continue
-			}
-		}
+	ctx = phases.Phase(ctx, tools.PhaseAuthorize)
+	// claimsFromAuth maps the name of the authservice to the claims retrieved
+	// from it. expiredErr is the first token rejected for having expired,
+	// reported instead of a generic error if the invocation is not authorized.
+	claimsFromAuth, expiredErr := s.verifiedClaims(ctx, r.Header)
 
-		if claims == nil {
-			// authService not present in header
-			continue
-		}
-		claimsFromAuth[aS.GetName()] = claims
-	}
+	ctx = util.WithAuthServiceClaims(ctx, claimsFromAuth)
 
 	// Tool authorization check
 	verifiedAuthServices := make([]string, len(claimsFromAuth))
@@ -207,18 +372,44 @@ func toolInvokeHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 	// Check if any of the specified auth services is verified
 	isAuthorized := tool.Authorized(verifiedAuthServices)
 	if !isAuthorized {
+		if expiredErr != nil {
+			s.logger.DebugContext(ctx, fmt.Sprintf("auth error: %v", expiredErr))
+			_ = render.Render(w, r, newTokenExpiredResponse(expiredErr))
+			return
+		}
 		err = fmt.Errorf("tool invocation not authorized. Please make sure you specify correct auth headers")
 		s.logger.DebugContext(ctx, err.Error())
 		_ = render.Render(w, r, newErrResponse(err, http.StatusUnauthorized))
 		return
 	}
+	if intentErr := tools.CheckIntent(tool, claimsFromAuth); intentErr != nil {
+		err = intentErr
+		s.logger.DebugContext(ctx, err.Error())
+		_ = render.Render(w, r, newErrResponse(err, http.StatusForbidden))
+		return
+	}
+	if cidrErr := tools.CheckAllowedCIDRs(ctx, tool); cidrErr != nil {
+		err = cidrErr
+		s.logger.DebugContext(ctx, err.Error())
+		_ = render.Render(w, r, newErrResponse(err, http.StatusForbidden))
+		return
+	}
 	s.logger.DebugContext(ctx, "tool invocation authorized")
 
+	ctx = phases.Phase(ctx, tools.PhaseParseParams)
 	limit := s.httpMaxRequestBytes
 	r.Body = http.MaxBytesReader(w, r.Body, limit)
 
 	var data map[string]any
-	if err = util.DecodeJSON(r.Body, &data); err != nil {
+	// the body of a Markdown invocation, below its frontmatter
+	var markdownBody string
+	markdown := isMarkdown(r)
+	if markdown {
+		data, markdownBody, err = decodeMarkdown(r.Body)
+	} else {
+		err = util.DecodeJSON(r.Body, &data)
+	}
+	if err != nil {
 		var maxErr *http.MaxBytesError
 		if errors.As(err, &maxErr) {
 			err = fmt.Errorf("request body exceeds %d bytes", limit)
@@ -227,7 +418,11 @@ func toolInvokeHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		render.Status(r, http.StatusBadRequest)
-		err = fmt.Errorf("request body was invalid JSON: %w", err)
+		if markdown {
+			err = fmt.Errorf("request body was invalid Markdown: %w", err)
+		} else {
+			err = fmt.Errorf("request body was invalid JSON: %w", err)
+		}
 		s.logger.DebugContext(ctx, err.Error())
 		_ = render.Render(w, r, newErrResponse(err, http.StatusBadRequest))
 		return
@@ -240,6 +435,10 @@ func toolInvokeHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 		_ = render.Render(w, r, newErrResponse(err, http.StatusInternalServerError))
 		return
 	}
+	if markdown {
+		data = withMarkdownPrompt(toolParams, data, markdownBody)
+	}
+	data = tools.CoerceParams(ctx, tool, toolParams, data)
 	params, err := parameters.ParseParams(toolParams, data, claimsFromAuth)
 	if err != nil {
 		var clientServerErr *util.ClientServerError
@@ -247,6 +446,10 @@ func toolInvokeHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 		// Return 401 Authentication errors
 		if errors.As(err, &clientServerErr) && clientServerErr.Code == http.StatusUnauthorized {
 			s.logger.DebugContext(ctx, fmt.Sprintf("auth error: %v", err))
+			if expiredErr != nil {
+				_ = render.Render(w, r, newTokenExpiredResponse(expiredErr))
+				return
+			}
 			_ = render.Render(w, r, newErrResponse(err, http.StatusUnauthorized))
 			return
 		}
@@ -254,10 +457,15 @@ func toolInvokeHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 		var agentErr *util.AgentError
 		if errors.As(err, &agentErr) {
 			s.logger.DebugContext(ctx, fmt.Sprintf("agent validation error: %v", err))
-			errMap := map[string]string{"error": err.Error()}
+			info := util.ClassifyError(err)
+			if info.Code == util.ErrorCodeExecutionFailed {
+				// the tool rejected the parameter values of the agent
+				info.Code = util.ErrorCodeInvalidArgument
+			}
+			errMap := map[string]string{"error": util.ClientErrorText(ctx, err, info)}
 			errMarshal, _ := json.Marshal(errMap)
 
-			_ = render.Render(w, r, &resultResponse{Result: string(errMarshal)})
+			_ = render.Render(w, r, &resultResponse{Result: string(errMarshal), Metadata: map[string]any{"error": info.Data()}})
 			return
 		}
 
@@ -266,7 +474,38 @@ func toolInvokeHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 		_ = render.Render(w, r, newErrResponse(err, http.StatusInternalServerError))
 		return
 	}
-	s.logger.DebugContext(ctx, fmt.Sprintf("invocation params: %s", params))
+	s.logger.DebugContext(ctx, fmt.Sprintf("invocation params: %s", parameters.Redact(toolParams, params)))
+
+	if async, _ := strconv.ParseBool(r.URL.Query().Get("async")); async {
+		if s.async == nil || clientAuth {
+			err = fmt.Errorf("tool %q cannot be invoked asynchronously", toolName)
+			s.logger.DebugContext(ctx, err.Error())
+			_ = render.Render(w, r, newErrResponse(err, http.StatusBadRequest))
+			return
+		}
+		callback := r.URL.Query().Get("callbackUrl")
+		if callback != "" {
+			if err = s.async.checkCallback(callback); err != nil {
+				s.logger.DebugContext(ctx, err.Error())
+				_ = render.Render(w, r, newErrResponse(err, http.StatusBadRequest))
+				return
+			}
+		}
+		var id string
+		id, err = s.enqueueAsync(ctx, toolName, params, callback)
+		if err != nil {
+			status := http.StatusInternalServerError
+			if errors.Is(err, errShuttingDown) {
+				status = http.StatusServiceUnavailable
+			}
+			err = fmt.Errorf("unable to enqueue asynchronous invocation: %w", err)
+			s.logger.ErrorContext(ctx, err.Error())
+			_ = render.Render(w, r, newErrResponse(err, status))
+			return
+		}
+		_ = render.Render(w, r, asyncResult{ID: id, Tool: toolName, Status: asyncStatusPending})
+		return
+	}
 
 	params, err = tool.EmbedParams(ctx, params, s.PrimitiveMgr.GetEmbeddingModelMap())
 	if err != nil {
@@ -276,10 +515,54 @@ func toolInvokeHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	res, err := tool.Invoke(ctx, s.PrimitiveMgr, params, accessToken)
+	// a dry run returns the statement the invocation would run as its
+	// result, in JSON
+	dryRun := mcputil.IsDryRun(r.Header)
+	if dryRun {
+		outputFormat = "json"
+	}
+
+	ctx = phases.Phase(ctx, tools.PhaseExecute)
+	executionStart := time.Now()
+	var res any
+	var stream *ndjsonStream
+	streamer, streams := tool.(tools.RowStreamer)
+	switch {
+	case dryRun:
+		res, err = mcputil.DryRun(ctx, tool, s.PrimitiveMgr, params, accessToken)
+	case streams && outputFormat == "ndjson":
+		stream = &ndjsonStream{w: w}
+		err = streamer.StreamRows(ctx, s.PrimitiveMgr, params, accessToken, stream.write)
+	default:
+		res, err = tool.Invoke(ctx, s.PrimitiveMgr, params, accessToken)
+	}
+	phases.End(err)
+	// A streamed result that failed before its first row is reported like
+	// any other failed invocation; once rows are sent, the status is too.
+	if stream != nil && (err == nil || stream.started) {
+		usageRecorder{s: s, toolset: directToolset}.record(ctx, toolName, stream.rows, err, time.Since(executionStart).Seconds())
+		if err != nil {
+			s.logger.DebugContext(ctx, fmt.Sprintf("Tool invocation failed after streaming %d rows: %v", stream.rows, err))
+		}
+		stream.finish(err)
+		return
+	}
+	if !dryRun {
+		usageRecorder{s: s, toolset: directToolset}.RecordInvocation(ctx, toolName, res, err, time.Since(executionStart).Seconds())
+	}
 
 	// Determine what error to return to the users.
+	var agentErr error
+	// agentErrInfo describes agentErr in the metadata of the response.
+	var agentErrInfo util.ErrorInfo
 	if err != nil {
+		var limitErr *tools.RateLimitedError
+		if errors.As(err, &limitErr) {
+			s.logger.DebugContext(ctx, fmt.Sprintf("Tool invocation rate limited: %v", err))
+			w.Header().Set("Retry-After", strconv.Itoa(limitErr.RetryAfterSeconds()))
+			_ = render.Render(w, r, newRateLimitedResponse(limitErr))
+			return
+		}
 		var tbErr util.ToolboxError
 
 		if errors.As(err, &tbErr) {
@@ -287,8 +570,9 @@ func toolInvokeHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 			case util.CategoryAgent:
 				// Agent Errors -> 200 OK
 				s.logger.DebugContext(ctx, fmt.Sprintf("Tool invocation agent error: %v", err))
+				agentErr, agentErrInfo = err, util.ClassifyError(err)
 				res = map[string]string{
-					"error": err.Error(),
+					"error": util.ClientErrorText(ctx, err, agentErrInfo),
 				}
 
 			case util.CategoryServer:
@@ -307,7 +591,7 @@ func toolInvokeHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 					if clientAuth {
 						// Token error, pass through 401/403
 						s.logger.DebugContext(ctx, fmt.Sprintf("Client credentials lack authorization: %v", err))
-						_ = render.Render(w, r, newErrResponse(err, statusCode))
+						_ = render.Render(w, r, newInvocationErrResponse(ctx, err, statusCode))
 						return
 					}
 					// ADC/Config error, return 500
@@ -315,15 +599,48 @@ func toolInvokeHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 				}
 
 				s.logger.ErrorContext(ctx, fmt.Sprintf("Tool invocation server error: %v", err))
-				_ = render.Render(w, r, newErrResponse(err, statusCode))
+				_ = render.Render(w, r, newInvocationErrResponse(ctx, err, statusCode))
 				return
 			}
 		} else {
 			// Unknown error -> 500
 			s.logger.ErrorContext(ctx, fmt.Sprintf("Tool invocation unknown error: %v", err))
+			_ = render.Render(w, r, newInvocationErrResponse(ctx, err, http.StatusInternalServerError))
+			return
+		}
+	}
+
+	ctx = phases.Phase(ctx, tools.PhaseMarshal)
+	if outputFormat != "json" && agentErr == nil {
+		var buf bytes.Buffer
+		if encErr := resultformat.Encode(&buf, outputFormat, res); encErr != nil {
+			err = fmt.Errorf("unable to encode result as %s: %w", outputFormat, encErr)
+			s.logger.DebugContext(ctx, err.Error())
+			_ = render.Render(w, r, newErrResponse(err, http.StatusInternalServerError))
+			return
+		}
+		w.Header().Set("Content-Type", resultformat.MediaTypes[outputFormat])
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(buf.Bytes())
+		return
+	}
+
+	if format == tools.ResponseFormatAnthropicContentBlocks {
+		var block *toolResultBlock
+		var blockErr error
+		if agentErr != nil {
+			block, blockErr = newToolResultBlock(toolUseID, util.ClientErrorText(ctx, agentErr, agentErrInfo), true)
+		} else {
+			block, blockErr = newToolResultBlock(toolUseID, res, false)
+		}
+		if blockErr != nil {
+			err = blockErr
+			s.logger.DebugContext(ctx, err.Error())
 			_ = render.Render(w, r, newErrResponse(err, http.StatusInternalServerError))
 			return
 		}
+		_ = render.Render(w, r, block)
+		return
 	}
 
 	resMarshal, err := json.Marshal(res)
@@ -334,14 +651,22 @@ func toolInvokeHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	_ = render.Render(w, r, &resultResponse{Result: string(resMarshal)})
+	var meta map[string]any
+	if agentErr != nil {
+		meta = map[string]any{"error": agentErrInfo.Data()}
+	}
+	_ = render.Render(w, r, &resultResponse{
+		Result:   string(resMarshal),
+		Metadata: mcputil.AddNextPageTokenMeta(ctx, mcputil.AddReplicaLagMeta(ctx, mcputil.AddToolVariantMeta(ctx, meta))),
+	})
 }
 
 var _ render.Renderer = &resultResponse{} // Renderer interface for managing response payloads.
 
 // resultResponse is the response sent back when the tool was invocated successfully.
 type resultResponse struct {
-	Result string `json:"result"` // result of tool invocation
+	Result   string         `json:"result"`             // result of tool invocation
+	Metadata map[string]any `json:"metadata,omitempty"` // metadata of the invocation, such as the variant the tool ran
 }
 
 // Render renders a single payload and respond to the client request.
@@ -363,13 +688,42 @@ func newErrResponse(err error, code int) *errResponse {
 	}
 }
 
+// newInvocationErrResponse returns the response sent back when a tool
+// invocation fails with a server error, described by its error code.
+func newInvocationErrResponse(ctx context.Context, err error, code int) *errResponse {
+	info := util.ClassifyError(err)
+	resp := newErrResponse(err, code)
+	resp.ErrorText = util.ClientErrorText(ctx, err, info)
+	resp.Code = string(info.Code)
+	resp.Data = info.Data()
+	return resp
+}
+
+// newTokenExpiredResponse returns the response sent back when the invocation
+// is not authorized because a token has expired.
+func newTokenExpiredResponse(err *auth.TokenExpiredError) *errResponse {
+	resp := newErrResponse(err, http.StatusUnauthorized)
+	resp.Code = auth.ErrorCodeTokenExpired
+	resp.Data = err.Data()
+	return resp
+}
+
+func newRateLimitedResponse(err *tools.RateLimitedError) *errResponse {
+	resp := newErrResponse(err, http.StatusTooManyRequests)
+	resp.Code = tools.ErrorCodeRateLimited
+	resp.Data = err.Data()
+	return resp
+}
+
 // errResponse is the response sent back when an error has been encountered.
 type errResponse struct {
 	Err            error `json:"-"` // low-level runtime error
 	HTTPStatusCode int   `json:"-"` // http response status code
 
-	StatusText string `json:"status"`          // user-level status message
-	ErrorText  string `json:"error,omitempty"` // application-level error message, for debugging
+	StatusText string         `json:"status"`          // user-level status message
+	ErrorText  string         `json:"error,omitempty"` // application-level error message, for debugging
+	Code       string         `json:"code,omitempty"`  // machine-readable error code
+	Data       map[string]any `json:"data,omitempty"`  // details of the error
 }
 
 func (e *errResponse) Render(w http.ResponseWriter, r *http.Request) error {
@@ -297,6 +297,26 @@ func TestToolInvokeEndpoint(t *testing.T) {
 	}
 }
 
+func TestToolInvokeDryRunUnsupported(t *testing.T) {
+	mockTools := []testutils.MockTool{testutils.MockTool1, testutils.MockTool2}
+	toolsMap, toolsets, _, _ := testutils.SetUpResources(t, mockTools, nil)
+	r, shutdown := setUpServer(t, "api", toolsMap, toolsets, nil, nil)
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	resp, body, err := runRequest(ts, http.MethodPost, fmt.Sprintf("/tool/%s/invoke", testutils.MockTool1.Name), bytes.NewBuffer([]byte(`{}`)), map[string]string{"X-Toolbox-Dry-Run": "true"})
+	if err != nil {
+		t.Fatalf("unexpected error during request: %s", err)
+	}
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("got status %d, want %d: %s", resp.StatusCode, http.StatusBadRequest, body)
+	}
+	if !strings.Contains(string(body), "does not support dry runs") {
+		t.Errorf("unexpected body: %s", body)
+	}
+}
+
 func TestApiRequestBodyLimit(t *testing.T) {
 	mockTools := []testutils.MockTool{testutils.MockTool1, testutils.MockTool2}
 	toolsMap, toolsets, _, _ := testutils.SetUpResources(t, mockTools, nil)
@@ -0,0 +1,233 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package approval gates the invocations of tools on the approval of an
+// external endpoint, such as a human review queue or a policy service.
+package approval
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+// DefaultTimeout is how long an invocation waits for its approval before it
+// is rejected.
+const DefaultTimeout = 5 * time.Minute
+
+// maxResponseBytes bounds the responses read from the approval endpoint.
+const maxResponseBytes = 1 << 20
+
+// Request is posted to the approval endpoint for an invocation of a tool.
+type Request struct {
+	// ID identifies the invocation. The token approving it signs it.
+	ID   string    `json:"id"`
+	Tool string    `json:"tool"`
+	Time time.Time `json:"time"`
+	// Caller is the identity the invocation was authenticated as, if any.
+	Caller string         `json:"caller,omitempty"`
+	Params map[string]any `json:"params"`
+	// Statement is what the invocation would run, for the tools that can
+	// report it.
+	Statement *tools.DryRunResult `json:"statement,omitempty"`
+}
+
+// Response is the decision of the approval endpoint on an invocation.
+type Response struct {
+	Approved bool `json:"approved"`
+	// Token is the hex encoded HMAC-SHA256 of the ID of the request, keyed
+	// by the secret shared with the server. It is required for an approval.
+	Token string `json:"token,omitempty"`
+	// Reason explains a rejection to the agent.
+	Reason string `json:"reason,omitempty"`
+}
+
+// Sign returns the token approving the invocation with the given ID.
+func Sign(secret []byte, id string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(id))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Approver requests the approval of invocations from an endpoint.
+type Approver struct {
+	url     string
+	secret  []byte
+	timeout time.Duration
+	client  *http.Client
+}
+
+// New returns an approver posting invocations to url and verifying their
+// approvals with secret. An invocation not decided within timeout is
+// rejected.
+func New(url, secret string, timeout time.Duration) (*Approver, error) {
+	if url == "" {
+		return nil, fmt.Errorf("an approval endpoint is required")
+	}
+	if secret == "" {
+		return nil, fmt.Errorf("an approval secret is required to verify approvals")
+	}
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &Approver{url: url, secret: []byte(secret), timeout: timeout, client: &http.Client{}}, nil
+}
+
+// approve returns nil once the endpoint approves the invocation of tool, or
+// the reason it was not approved.
+func (a *Approver) approve(ctx context.Context, tool tools.Tool, sp tools.SourceProvider, params parameters.ParamValues, token tools.AccessToken) util.ToolboxError {
+	req, tbErr := a.request(ctx, tool, sp, params, token)
+	if tbErr != nil {
+		return tbErr
+	}
+	body, err := json.Marshal(req)
+	if err != nil {
+		return util.NewClientServerError("unable to encode approval request", http.StatusInternalServerError, err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, a.timeout)
+	defer cancel()
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, a.url, bytes.NewReader(body))
+	if err != nil {
+		return util.NewClientServerError("unable to create approval request", http.StatusInternalServerError, err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	resp, err := a.client.Do(httpReq)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return util.NewAgentError(fmt.Sprintf("invocation of tool %q was rejected: not approved within %s", req.Tool, a.timeout), nil)
+		}
+		return util.NewClientServerError("unable to reach approval endpoint", http.StatusBadGateway, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return util.NewClientServerError(fmt.Sprintf("approval endpoint returned status %d", resp.StatusCode), http.StatusBadGateway, nil)
+	}
+	var decision Response
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseBytes)).Decode(&decision); err != nil {
+		return util.NewClientServerError("invalid response from approval endpoint", http.StatusBadGateway, err)
+	}
+	if !decision.Approved {
+		msg := fmt.Sprintf("invocation of tool %q was rejected", req.Tool)
+		if decision.Reason != "" {
+			msg += ": " + decision.Reason
+		}
+		return util.NewAgentError(msg, nil)
+	}
+	if !hmac.Equal([]byte(decision.Token), []byte(Sign(a.secret, req.ID))) {
+		return util.NewClientServerError(fmt.Sprintf("approval of the invocation of tool %q has an invalid token", req.Tool), http.StatusBadGateway, nil)
+	}
+	return nil
+}
+
+// request returns the approval request of an invocation of tool.
+func (a *Approver) request(ctx context.Context, tool tools.Tool, sp tools.SourceProvider, params parameters.ParamValues, token tools.AccessToken) (Request, util.ToolboxError) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return Request{}, util.NewClientServerError("unable to generate approval request ID", http.StatusInternalServerError, err)
+	}
+	req := Request{
+		ID:     hex.EncodeToString(id),
+		Tool:   tool.GetName(),
+		Time:   time.Now().UTC(),
+		Params: params.AsMap(),
+	}
+	req.Caller, _ = util.IdentityFromContext(ctx)
+	if _, ok := tool.(tools.DryRunner); ok {
+		statement, err := tools.DryRun(ctx, tool, sp, params, token)
+		if err != nil {
+			return Request{}, err
+		}
+		req.Statement = statement
+	}
+	return req, nil
+}
+
+// requiresApproval reports whether the invocations of a tool must be
+// approved.
+func requiresApproval(cfg tools.ToolConfig) bool {
+	c, ok := cfg.(interface{ GetRequiresApproval() bool })
+	return ok && c.GetRequiresApproval()
+}
+
+// Wrap returns the tools with the invocations of the tools requiring
+// approval run only once approved by a. A nil a is an error if any tool
+// requires approval.
+func Wrap(toolsMap map[string]tools.Tool, a *Approver) (map[string]tools.Tool, error) {
+	wrapped := make(map[string]tools.Tool, len(toolsMap))
+	for name, t := range toolsMap {
+		if !requiresApproval(t.ToConfig()) {
+			wrapped[name] = t
+			continue
+		}
+		if a == nil {
+			return nil, fmt.Errorf("tool %q requires approval, but the server has no approval endpoint", name)
+		}
+		gated := gatedTool{Tool: t, approver: a}
+		if streamer, ok := t.(tools.RowStreamer); ok {
+			wrapped[name] = gatedStreamer{gatedTool: gated, streamer: streamer}
+			continue
+		}
+		wrapped[name] = gated
+	}
+	return wrapped, nil
+}
+
+// gatedTool is a tool whose invocations run once approved.
+type gatedTool struct {
+	tools.Tool
+	approver *Approver
+}
+
+// Unwrap implements tools.Wrapper.
+func (t gatedTool) Unwrap() tools.Tool { return t.Tool }
+
+func (t gatedTool) Invoke(ctx context.Context, sp tools.SourceProvider, params parameters.ParamValues, token tools.AccessToken) (any, util.ToolboxError) {
+	if err := t.approver.approve(ctx, t.Tool, sp, params, token); err != nil {
+		return nil, err
+	}
+	return t.Tool.Invoke(ctx, sp, params, token)
+}
+
+// DryRun implements tools.DryRunner. Dry runs are not approved, since they
+// do not run the tool.
+func (t gatedTool) DryRun(ctx context.Context, sp tools.SourceProvider, params parameters.ParamValues, token tools.AccessToken) (*tools.DryRunResult, util.ToolboxError) {
+	return tools.DryRun(ctx, t.Tool, sp, params, token)
+}
+
+// gatedStreamer is a tool requiring approval that streams its rows.
+type gatedStreamer struct {
+	gatedTool
+	streamer tools.RowStreamer
+}
+
+func (t gatedStreamer) StreamRows(ctx context.Context, sp tools.SourceProvider, params parameters.ParamValues, token tools.AccessToken, emit func(rows []any) error) util.ToolboxError {
+	if err := t.approver.approve(ctx, t.Tool, sp, params, token); err != nil {
+		return err
+	}
+	return t.streamer.StreamRows(ctx, sp, params, token, emit)
+}
@@ -0,0 +1,173 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package approval
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/googleapis/mcp-toolbox/internal/testutils"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+const secret = "s3cret"
+
+type writeConfig struct {
+	tools.ConfigBase
+}
+
+func (writeConfig) ToolConfigType() string { return "write" }
+func (writeConfig) Initialize(context.Context) (tools.Tool, error) {
+	return nil, nil
+}
+
+// writeTool deletes a row, reporting the statement it runs in dry runs.
+type writeTool struct {
+	testutils.MockTool
+	cfg writeConfig
+}
+
+func (t writeTool) Invoke(context.Context, tools.SourceProvider, parameters.ParamValues, tools.AccessToken) (any, util.ToolboxError) {
+	return "deleted", nil
+}
+
+func (t writeTool) DryRun(_ context.Context, _ tools.SourceProvider, params parameters.ParamValues, _ tools.AccessToken) (*tools.DryRunResult, util.ToolboxError) {
+	return &tools.DryRunResult{Statement: "DELETE FROM orders WHERE id = $1", Params: []any{params.AsMap()["id"]}}, nil
+}
+
+func (t writeTool) ToConfig() tools.ToolConfig { return t.cfg }
+
+func newWriteTool(requiresApproval bool) tools.Tool {
+	return writeTool{
+		MockTool: testutils.MockTool{Name: "delete_order"},
+		cfg:      writeConfig{tools.ConfigBase{Name: "delete_order", RequiresApproval: requiresApproval}},
+	}
+}
+
+func TestWrap(t *testing.T) {
+	tcs := []struct {
+		name     string
+		decide   func(w http.ResponseWriter, req Request)
+		want     any
+		agentErr string
+		wantErr  bool
+	}{
+		{
+			name: "approved",
+			decide: func(w http.ResponseWriter, req Request) {
+				_ = json.NewEncoder(w).Encode(Response{Approved: true, Token: Sign([]byte(secret), req.ID)})
+			},
+			want: "deleted",
+		},
+		{
+			name: "rejected",
+			decide: func(w http.ResponseWriter, req Request) {
+				_ = json.NewEncoder(w).Encode(Response{Reason: "orders are never deleted"})
+			},
+			agentErr: `invocation of tool "delete_order" was rejected: orders are never deleted`,
+		},
+		{
+			name: "token of another invocation",
+			decide: func(w http.ResponseWriter, req Request) {
+				_ = json.NewEncoder(w).Encode(Response{Approved: true, Token: Sign([]byte(secret), "other")})
+			},
+			wantErr: true,
+		},
+		{
+			name: "timeout",
+			decide: func(w http.ResponseWriter, req Request) {
+				time.Sleep(200 * time.Millisecond)
+			},
+			agentErr: `invocation of tool "delete_order" was rejected: not approved within 50ms`,
+		},
+		{
+			name: "endpoint failure",
+			decide: func(w http.ResponseWriter, req Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			requests := make(chan Request, 1)
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var req Request
+				if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+					t.Errorf("invalid approval request: %s", err)
+				}
+				requests <- req
+				tc.decide(w, req)
+			}))
+			defer srv.Close()
+			a, err := New(srv.URL, secret, 50*time.Millisecond)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			wrapped, err := Wrap(map[string]tools.Tool{"delete_order": newWriteTool(true)}, a)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			params := parameters.ParamValues{{Name: "id", Value: 42}}
+			res, tbErr := wrapped["delete_order"].Invoke(context.Background(), nil, params, "")
+
+			got := <-requests
+			if got.Tool != "delete_order" || got.ID == "" || got.Params["id"] != float64(42) {
+				t.Errorf("unexpected approval request: %+v", got)
+			}
+			if got.Statement == nil || !strings.HasPrefix(got.Statement.Statement, "DELETE FROM orders") {
+				t.Errorf("approval request does not have the statement of the invocation: %+v", got.Statement)
+			}
+			var agentErr *util.AgentError
+			switch {
+			case tc.agentErr != "":
+				if !errors.As(tbErr, &agentErr) || tbErr.Error() != tc.agentErr {
+					t.Fatalf("got error %v, want agent error %q", tbErr, tc.agentErr)
+				}
+			case tc.wantErr:
+				if tbErr == nil || errors.As(tbErr, &agentErr) {
+					t.Fatalf("got error %v, want a server error", tbErr)
+				}
+			case tbErr != nil:
+				t.Fatalf("unexpected error: %s", tbErr)
+			case res != tc.want:
+				t.Fatalf("got %v, want %v", res, tc.want)
+			}
+		})
+	}
+}
+
+func TestWrapWithoutApprover(t *testing.T) {
+	toolsMap := map[string]tools.Tool{"delete_order": newWriteTool(false)}
+	wrapped, err := Wrap(toolsMap, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, ok := wrapped["delete_order"].(gatedTool); ok {
+		t.Errorf("tool not requiring approval is gated")
+	}
+	toolsMap["delete_order"] = newWriteTool(true)
+	if _, err := Wrap(toolsMap, nil); err == nil {
+		t.Errorf("expected an error for a tool requiring approval without an approver")
+	}
+}
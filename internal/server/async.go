@@ -0,0 +1,604 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+	"github.com/googleapis/mcp-toolbox/pkg/sdk"
+	cloudtasks "google.golang.org/api/cloudtasks/v2"
+	"google.golang.org/api/idtoken"
+)
+
+const (
+	// AsyncBackendLocal runs asynchronous invocations in the background of
+	// the server that received them.
+	AsyncBackendLocal = "local"
+	// AsyncBackendCloudTasks delivers asynchronous invocations through a
+	// Cloud Tasks queue, which retries them until they succeed.
+	AsyncBackendCloudTasks = "cloud-tasks"
+
+	// taskHandlerPath is the path Cloud Tasks delivers tasks to.
+	taskHandlerPath = "/internal/task-handler"
+
+	// maxLocalAsyncResults bounds the results kept in memory.
+	maxLocalAsyncResults = 1000
+
+	// maxCallbackAttempts bounds the deliveries of the state of an
+	// invocation to its callback URL.
+	maxCallbackAttempts = 3
+	// callbackTimeout bounds each delivery to a callback URL.
+	callbackTimeout = 30 * time.Second
+)
+
+// callbackRetryDelay is the delay before the second delivery to a callback
+// URL, doubled for each of the next ones.
+var callbackRetryDelay = time.Second
+
+const (
+	asyncStatusPending = "pending"
+	asyncStatusDone    = "done"
+	asyncStatusFailed  = "failed"
+)
+
+// asyncTask is an asynchronous invocation of a tool, with its parsed
+// parameter values.
+type asyncTask struct {
+	ID     string         `json:"id"`
+	Tool   string         `json:"tool"`
+	Params map[string]any `json:"params"`
+	// Callback is the URL the final state of the invocation is posted to.
+	Callback string `json:"callback,omitempty"`
+	// Claims are the claims of the auth services the invocation was verified
+	// by, for sources impersonating its principal.
+	Claims map[string]map[string]any `json:"claims,omitempty"`
+}
+
+// asyncResult is the state of an asynchronous invocation.
+type asyncResult struct {
+	ID     string `json:"id"`
+	Tool   string `json:"tool"`
+	Status string `json:"status"`
+	Result string `json:"result,omitempty"`
+	Error  string `json:"error,omitempty"`
+	// ErrorCode, Retryable and SourceErrorClass describe Error.
+	ErrorCode        string `json:"errorCode,omitempty"`
+	Retryable        bool   `json:"retryable,omitempty"`
+	SourceErrorClass string `json:"sourceErrorClass,omitempty"`
+}
+
+// Render renders the state of an asynchronous invocation.
+func (ar asyncResult) Render(w http.ResponseWriter, r *http.Request) error {
+	if ar.Status == asyncStatusPending {
+		render.Status(r, http.StatusAccepted)
+	} else {
+		render.Status(r, http.StatusOK)
+	}
+	return nil
+}
+
+// errAsyncResultNotFound is returned for unknown invocation IDs.
+var errAsyncResultNotFound = errors.New("asynchronous invocation not found")
+
+// asyncResultStore stores the states of asynchronous invocations.
+type asyncResultStore interface {
+	Put(ctx context.Context, result asyncResult) error
+	Get(ctx context.Context, id string) (asyncResult, error)
+}
+
+// asyncBackend delivers asynchronous invocations to be run.
+type asyncBackend interface {
+	Enqueue(ctx context.Context, task asyncTask) error
+}
+
+// asyncInvoker runs the asynchronous invocations of a server.
+type asyncInvoker struct {
+	backend asyncBackend
+	results asyncResultStore
+	// callbackHosts are the hosts callback URLs may target. Empty disables
+	// callbacks.
+	callbackHosts []string
+	client        *http.Client
+}
+
+// newAsyncInvoker returns the asynchronous invoker of the backend of cfg.
+func newAsyncInvoker(ctx context.Context, s *Server, cfg ServerConfig) (*asyncInvoker, error) {
+	var results asyncResultStore = newMemoryResultStore(maxLocalAsyncResults)
+	if cfg.AsyncResultsBucket != "" {
+		client, err := storage.NewClient(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create storage client: %w", err)
+		}
+		results = gcsResultStore{bucket: client.Bucket(cfg.AsyncResultsBucket)}
+	}
+
+	switch cfg.AsyncBackend {
+	case "", AsyncBackendLocal:
+		return &asyncInvoker{backend: localBackend{s: s, results: results}, results: results, callbackHosts: cfg.AsyncCallbackHosts, client: &http.Client{Timeout: callbackTimeout}}, nil
+	case AsyncBackendCloudTasks:
+		if cfg.CloudTasksQueue == "" {
+			return nil, fmt.Errorf("async backend %q requires --cloud-tasks-queue", AsyncBackendCloudTasks)
+		}
+		if cfg.CloudTasksServiceAccount == "" {
+			return nil, fmt.Errorf("async backend %q requires --cloud-tasks-service-account to authenticate tasks", AsyncBackendCloudTasks)
+		}
+		if cfg.AsyncResultsBucket == "" {
+			return nil, fmt.Errorf("async backend %q requires --async-results-bucket to share results between instances", AsyncBackendCloudTasks)
+		}
+		if cfg.ToolboxUrl == "" {
+			return nil, fmt.Errorf("async backend %q requires --toolbox-url for Cloud Tasks to deliver tasks to", AsyncBackendCloudTasks)
+		}
+		svc, err := cloudtasks.NewService(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create Cloud Tasks client: %w", err)
+		}
+		backend := cloudTasksBackend{
+			tasks:          svc,
+			queue:          cfg.CloudTasksQueue,
+			url:            strings.TrimSuffix(cfg.ToolboxUrl, "/") + taskHandlerPath,
+			serviceAccount: cfg.CloudTasksServiceAccount,
+			results:        results,
+		}
+		return &asyncInvoker{backend: backend, results: results, callbackHosts: cfg.AsyncCallbackHosts, client: &http.Client{Timeout: callbackTimeout}}, nil
+	default:
+		return nil, fmt.Errorf("invalid async backend %q: must be %q or %q", cfg.AsyncBackend, AsyncBackendLocal, AsyncBackendCloudTasks)
+	}
+}
+
+// localBackend runs asynchronous invocations in goroutines of the server.
+type localBackend struct {
+	s       *Server
+	results asyncResultStore
+}
+
+func (b localBackend) Enqueue(ctx context.Context, task asyncTask) error {
+	// the invocation outlives the request, and is drained on shutdown as
+	// the synchronous ones are
+	taskCtx, done, ok := b.s.invocations.start(context.WithoutCancel(ctx))
+	if !ok {
+		return errShuttingDown
+	}
+	if err := b.results.Put(ctx, asyncResult{ID: task.ID, Tool: task.Tool, Status: asyncStatusPending}); err != nil {
+		done()
+		return err
+	}
+	// the task is serialized as for the other backends, for its parameter
+	// values to be restored the same way
+	body, err := json.Marshal(task)
+	if err != nil {
+		done()
+		return fmt.Errorf("unable to encode task: %w", err)
+	}
+	var decoded asyncTask
+	if err := util.DecodeJSON(bytes.NewReader(body), &decoded); err != nil {
+		done()
+		return fmt.Errorf("unable to decode task: %w", err)
+	}
+	go func() {
+		defer done()
+		_ = b.s.runAsyncTask(taskCtx, decoded)
+	}()
+	return nil
+}
+
+// cloudTasksBackend creates a Cloud Tasks task per asynchronous invocation,
+// delivered to the task handler of the server.
+type cloudTasksBackend struct {
+	tasks *cloudtasks.Service
+	// queue is the resource name of the queue, such as
+	// projects/PROJECT/locations/LOCATION/queues/QUEUE.
+	queue string
+	// url is the URL of the task handler.
+	url string
+	// serviceAccount is the service account Cloud Tasks signs the OIDC
+	// tokens of the tasks as.
+	serviceAccount string
+	results        asyncResultStore
+}
+
+func (b cloudTasksBackend) Enqueue(ctx context.Context, task asyncTask) error {
+	body, err := json.Marshal(task)
+	if err != nil {
+		return fmt.Errorf("unable to encode task: %w", err)
+	}
+	req := &cloudtasks.HttpRequest{
+		HttpMethod: http.MethodPost,
+		Url:        b.url,
+		Headers:    map[string]string{"Content-Type": "application/json"},
+		Body:       base64.StdEncoding.EncodeToString(body),
+		OidcToken:  &cloudtasks.OidcToken{ServiceAccountEmail: b.serviceAccount, Audience: b.url},
+	}
+	if err := b.results.Put(ctx, asyncResult{ID: task.ID, Tool: task.Tool, Status: asyncStatusPending}); err != nil {
+		return err
+	}
+	_, err = b.tasks.Projects.Locations.Queues.Tasks.Create(b.queue, &cloudtasks.CreateTaskRequest{
+		Task: &cloudtasks.Task{HttpRequest: req},
+	}).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("unable to create Cloud Tasks task: %w", err)
+	}
+	return nil
+}
+
+// memoryResultStore keeps the latest results in memory.
+type memoryResultStore struct {
+	mu      sync.Mutex
+	max     int
+	order   []string
+	results map[string]asyncResult
+}
+
+func newMemoryResultStore(size int) *memoryResultStore {
+	return &memoryResultStore{max: size, results: make(map[string]asyncResult)}
+}
+
+func (m *memoryResultStore) Put(_ context.Context, result asyncResult) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.results[result.ID]; !ok {
+		m.order = append(m.order, result.ID)
+		if len(m.order) > m.max {
+			delete(m.results, m.order[0])
+			m.order = m.order[1:]
+		}
+	}
+	m.results[result.ID] = result
+	return nil
+}
+
+func (m *memoryResultStore) Get(_ context.Context, id string) (asyncResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	result, ok := m.results[id]
+	if !ok {
+		return asyncResult{}, errAsyncResultNotFound
+	}
+	return result, nil
+}
+
+// gcsResultStore stores results as JSON objects of a Cloud Storage bucket,
+// shared by every instance of the server.
+type gcsResultStore struct {
+	bucket *storage.BucketHandle
+}
+
+// object returns the object of the result of the invocation id.
+func (g gcsResultStore) object(id string) *storage.ObjectHandle {
+	return g.bucket.Object("toolbox-async/" + id + ".json")
+}
+
+func (g gcsResultStore) Put(ctx context.Context, result asyncResult) error {
+	w := g.object(result.ID).NewWriter(ctx)
+	w.ContentType = "application/json"
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		_ = w.Close()
+		return fmt.Errorf("unable to write result of %q: %w", result.ID, err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("unable to write result of %q: %w", result.ID, err)
+	}
+	return nil
+}
+
+func (g gcsResultStore) Get(ctx context.Context, id string) (asyncResult, error) {
+	r, err := g.object(id).NewReader(ctx)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return asyncResult{}, errAsyncResultNotFound
+	}
+	if err != nil {
+		return asyncResult{}, fmt.Errorf("unable to read result of %q: %w", id, err)
+	}
+	defer r.Close()
+	var result asyncResult
+	if err := json.NewDecoder(r).Decode(&result); err != nil {
+		return asyncResult{}, fmt.Errorf("unable to decode result of %q: %w", id, err)
+	}
+	return result, nil
+}
+
+// checkCallback returns an error if the state of invocations may not be
+// posted to the callback URL raw.
+func (a *asyncInvoker) checkCallback(raw string) error {
+	if len(a.callbackHosts) == 0 {
+		return errors.New("callbacks are disabled; allow the hosts of callback URLs with --async-callback-hosts")
+	}
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid callback URL %q: must be an absolute http or https URL", raw)
+	}
+	if !slices.ContainsFunc(a.callbackHosts, func(h string) bool { return strings.EqualFold(h, u.Hostname()) }) {
+		return fmt.Errorf("host %q of the callback URL is not allowed by --async-callback-hosts", u.Hostname())
+	}
+	return nil
+}
+
+// enqueueAsync enqueues an invocation of the tool toolName with params and
+// returns its ID. The final state of the invocation is posted to callback,
+// unless empty.
+func (s *Server) enqueueAsync(ctx context.Context, toolName string, params parameters.ParamValues, callback string) (string, error) {
+	task := asyncTask{
+		ID:       uuid.New().String(),
+		Tool:     toolName,
+		Params:   params.AsMap(),
+		Callback: callback,
+		Claims:   util.AuthServiceClaimsFromContext(ctx),
+	}
+	if err := s.async.backend.Enqueue(ctx, task); err != nil {
+		return "", err
+	}
+	return task.ID, nil
+}
+
+// runAsyncTask invokes the tool of task and stores its result. It returns
+// an error, for the task to be retried, only on server errors of tools with
+// read intent, or when the result cannot be stored.
+func (s *Server) runAsyncTask(ctx context.Context, task asyncTask) error {
+	ctx = util.WithLogger(ctx, s.logger)
+	ctx = util.WithParamCoercion(ctx, s.paramCoercion)
+	ctx = util.WithRedactErrorDetails(ctx, s.redactErrorDetails)
+	ctx = util.WithGenAIMetricAttrs(ctx, &util.GenAIMetricAttrs{ToolName: task.Tool})
+	ctx = util.WithInvocationCost(ctx, &util.InvocationCost{})
+	result := asyncResult{ID: task.ID, Tool: task.Tool, Status: asyncStatusDone}
+
+	res, err := s.invokeAsyncTask(ctx, task)
+	if err != nil {
+		info := util.ClassifyError(err)
+		result.Error = util.ClientErrorText(ctx, err, info)
+		result.ErrorCode, result.Retryable, result.SourceErrorClass = string(info.Code), info.Retryable, info.SourceClass
+		var tbErr util.ToolboxError
+		if !errors.As(err, &tbErr) || tbErr.Category() != util.CategoryAgent {
+			result.Status = asyncStatusFailed
+		}
+	} else {
+		b, mErr := json.Marshal(res)
+		if mErr != nil {
+			err = fmt.Errorf("unable to marshal result: %w", mErr)
+			result.Status, result.Error = asyncStatusFailed, err.Error()
+		} else {
+			result.Result = string(b)
+		}
+	}
+	if putErr := s.async.results.Put(ctx, result); putErr != nil {
+		s.logger.ErrorContext(ctx, fmt.Sprintf("unable to store result of asynchronous invocation %q: %v", task.ID, putErr))
+		return putErr
+	}
+	if task.Callback != "" {
+		s.postCallback(ctx, task.Callback, result)
+	}
+	if result.Status == asyncStatusFailed {
+		s.logger.ErrorContext(ctx, fmt.Sprintf("asynchronous invocation %q of tool %q failed: %v", task.ID, task.Tool, err))
+		if tool, ok := s.PrimitiveMgr.GetTool(task.Tool); ok && tools.IsWrite(tool) {
+			// the failed result is stored; retrying a write may write twice
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// postCallback posts result to the callback URL of its invocation, signed
+// with the response signing key of the server, if any. Failed deliveries
+// are retried with a growing delay, then logged. Retries stop once ctx is
+// done.
+func (s *Server) postCallback(ctx context.Context, callback string, result asyncResult) {
+	body, err := json.Marshal(result)
+	if err != nil {
+		s.logger.ErrorContext(ctx, fmt.Sprintf("unable to encode callback of asynchronous invocation %q: %v", result.ID, err))
+		return
+	}
+	delay := callbackRetryDelay
+	for attempt := 1; ; attempt++ {
+		err = s.deliverCallback(ctx, callback, body)
+		if err == nil {
+			return
+		}
+		if attempt == maxCallbackAttempts {
+			break
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			s.logger.ErrorContext(ctx, fmt.Sprintf("unable to deliver callback of asynchronous invocation %q: %v", result.ID, err))
+			return
+		case <-timer.C:
+		}
+		delay *= 2
+	}
+	s.logger.ErrorContext(ctx, fmt.Sprintf("unable to deliver callback of asynchronous invocation %q after %d attempts: %v", result.ID, maxCallbackAttempts, err))
+}
+
+func (s *Server) deliverCallback(ctx context.Context, callback string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, callback, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(s.responseSigningKey) > 0 {
+		req.Header.Set(sdk.SignatureHeader, sdk.Sign(s.responseSigningKey, body))
+	}
+	resp, err := s.async.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("callback returned %s", resp.Status)
+	}
+	return nil
+}
+
+// invokeAsyncTask restores the parameter values of task, decoded from JSON,
+// to their declared types and invokes its tool.
+func (s *Server) invokeAsyncTask(ctx context.Context, task asyncTask) (any, error) {
+	tool, ok := s.PrimitiveMgr.GetTool(task.Tool)
+	if !ok {
+		return nil, util.NewClientServerError(fmt.Sprintf("tool %q does not exist", task.Tool), http.StatusNotFound, nil)
+	}
+	toolParams, err := tool.GetParameters(s.PrimitiveMgr.GetSourcesMap())
+	if err != nil {
+		return nil, util.NewClientServerError("error getting parameters for tool", http.StatusInternalServerError, err)
+	}
+	params := make(parameters.ParamValues, 0, len(toolParams))
+	for _, p := range toolParams {
+		v, ok := task.Params[p.GetName()]
+		if ok && v != nil {
+			v, err = p.Parse(v)
+			if err != nil {
+				return nil, util.NewClientServerError(fmt.Sprintf("unable to restore parameter %q", p.GetName()), http.StatusBadRequest, err)
+			}
+		}
+		params = append(params, parameters.ParamValue{Name: p.GetName(), Value: v})
+	}
+	params, err = tool.EmbedParams(ctx, params, s.PrimitiveMgr.GetEmbeddingModelMap())
+	if err != nil {
+		return nil, util.NewClientServerError("error embedding parameters", http.StatusBadRequest, err)
+	}
+	// asynchronous results are stored whole, rather than split into pages
+	ctx = util.WithResultPage(ctx, nil)
+	claims := task.Claims
+	if claims == nil {
+		claims = make(map[string]map[string]any)
+	}
+	ctx = util.WithAuthServiceClaims(ctx, claims)
+	executionStart := time.Now()
+	res, err := tool.Invoke(ctx, s.PrimitiveMgr, params, "")
+	usageRecorder{s: s, toolset: directToolset}.RecordInvocation(ctx, task.Tool, res, err, time.Since(executionStart).Seconds())
+	return res, err
+}
+
+// taskHandler runs the tasks Cloud Tasks delivers, after verifying their
+// OIDC token was signed for the service account of the tasks.
+func taskHandler(s *Server, serviceAccount, audience string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok {
+			http.Error(w, "missing OIDC token", http.StatusUnauthorized)
+			return
+		}
+		payload, err := idtoken.Validate(ctx, token, audience)
+		if err != nil {
+			s.logger.DebugContext(ctx, fmt.Sprintf("invalid task OIDC token: %v", err))
+			http.Error(w, "invalid OIDC token", http.StatusUnauthorized)
+			return
+		}
+		if email, _ := payload.Claims["email"].(string); email != serviceAccount {
+			http.Error(w, "OIDC token of an unexpected service account", http.StatusForbidden)
+			return
+		}
+		runTask(s, w, r)
+	}
+}
+
+// runTask runs the task of a verified Cloud Tasks delivery. Its status tells
+// Cloud Tasks whether to retry the task.
+func runTask(s *Server, w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, s.httpMaxRequestBytes))
+	if err != nil {
+		http.Error(w, "unable to read task", http.StatusBadRequest)
+		return
+	}
+	var task asyncTask
+	if err := util.DecodeJSON(bytes.NewReader(body), &task); err != nil || task.ID == "" {
+		// malformed tasks are acknowledged, retrying cannot fix them
+		s.logger.ErrorContext(ctx, fmt.Sprintf("dropping malformed task: %v", err))
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if err := s.runAsyncTask(ctx, task); err != nil {
+		// a failure status makes Cloud Tasks retry the task
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// asyncResultHandler handles the requests for the state of an asynchronous
+// invocation. They are authorized as invocations of its tool.
+func asyncResultHandler(s *Server, w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := chi.URLParam(r, "jobID")
+	if s.async == nil {
+		_ = render.Render(w, r, newErrResponse(fmt.Errorf("asynchronous invocation %q not found", id), http.StatusNotFound))
+		return
+	}
+	result, err := s.async.results.Get(r.Context(), id)
+	if errors.Is(err, errAsyncResultNotFound) {
+		_ = render.Render(w, r, newErrResponse(fmt.Errorf("asynchronous invocation %q not found", id), http.StatusNotFound))
+		return
+	}
+	if err != nil {
+		s.logger.ErrorContext(ctx, err.Error())
+		_ = render.Render(w, r, newErrResponse(err, http.StatusInternalServerError))
+		return
+	}
+
+	tool, ok := s.PrimitiveMgr.GetTool(result.Tool)
+	if !ok {
+		_ = render.Render(w, r, newErrResponse(fmt.Errorf("asynchronous invocation %q not found", id), http.StatusNotFound))
+		return
+	}
+	if accessErr := s.checkToolAccess(ctx, r.Header, result.Tool); accessErr != nil {
+		s.logger.DebugContext(ctx, accessErr.Error())
+		_ = render.Render(w, r, newErrResponse(accessErr, accessErr.Code))
+		return
+	}
+	claimsFromAuth, expiredErr := s.verifiedClaims(ctx, r.Header)
+	if !tool.Authorized(slices.Collect(maps.Keys(claimsFromAuth))) {
+		if expiredErr != nil {
+			s.logger.DebugContext(ctx, fmt.Sprintf("auth error: %v", expiredErr))
+			_ = render.Render(w, r, newTokenExpiredResponse(expiredErr))
+			return
+		}
+		err = fmt.Errorf("asynchronous invocation not authorized. Please make sure you specify correct auth headers")
+		s.logger.DebugContext(ctx, err.Error())
+		_ = render.Render(w, r, newErrResponse(err, http.StatusUnauthorized))
+		return
+	}
+	if err := tools.CheckIntent(tool, claimsFromAuth); err != nil {
+		s.logger.DebugContext(ctx, err.Error())
+		_ = render.Render(w, r, newErrResponse(err, http.StatusForbidden))
+		return
+	}
+	if err := tools.CheckAllowedCIDRs(ctx, tool); err != nil {
+		s.logger.DebugContext(ctx, err.Error())
+		_ = render.Render(w, r, newErrResponse(err, http.StatusForbidden))
+		return
+	}
+	_ = render.Render(w, r, result)
+}
@@ -0,0 +1,409 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/googleapis/mcp-toolbox/internal/auth"
+	"github.com/googleapis/mcp-toolbox/internal/prompts"
+	"github.com/googleapis/mcp-toolbox/internal/sources/sessionrole"
+	"github.com/googleapis/mcp-toolbox/internal/testutils"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+func TestAsyncInvocation(t *testing.T) {
+	unauthorizedTool := testutils.NewMockTool("unauthorized_tool", "", nil, true, false)
+	mockTools := []testutils.MockTool{testutils.MockTool2, testutils.MockTool5, unauthorizedTool}
+	toolsMap, toolsets, _, _ := testutils.SetUpResources(t, mockTools, nil)
+	var srv *Server
+	withAsync := func(s *Server) {
+		srv = s
+		var err error
+		s.async, err = newAsyncInvoker(context.Background(), s, ServerConfig{AsyncBackend: AsyncBackendLocal})
+		if err != nil {
+			t.Fatalf("unable to create async invoker: %s", err)
+		}
+	}
+	r, shutdown := setUpServer(t, "api", toolsMap, toolsets, nil, nil, withAsync)
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	path := fmt.Sprintf("/tool/%s/invoke?async=true", testutils.MockTool2.Name)
+	resp, body, err := runRequest(ts, http.MethodPost, path, bytes.NewBufferString(`{"param1": 1, "param2": 2}`), nil)
+	if err != nil {
+		t.Fatalf("unexpected error during request: %s", err)
+	}
+	if resp.StatusCode != http.StatusAccepted {
+		t.Fatalf("unexpected status: got %d, want %d: %s", resp.StatusCode, http.StatusAccepted, body)
+	}
+	var pending asyncResult
+	if err := json.Unmarshal(body, &pending); err != nil {
+		t.Fatalf("unexpected error unmarshalling body: %s", err)
+	}
+	if pending.ID == "" || pending.Status != asyncStatusPending {
+		t.Fatalf("unexpected response: %s", body)
+	}
+
+	var got asyncResult
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		resp, body, err = runRequest(ts, http.MethodGet, "/job/"+pending.ID, nil, nil)
+		if err != nil {
+			t.Fatalf("unexpected error during request: %s", err)
+		}
+		if err := json.Unmarshal(body, &got); err != nil {
+			t.Fatalf("unexpected error unmarshalling body: %s", err)
+		}
+		if got.Status != asyncStatusPending {
+			break
+		}
+	}
+	if resp.StatusCode != http.StatusOK || got.Status != asyncStatusDone {
+		t.Fatalf("unexpected result: status %d: %s", resp.StatusCode, body)
+	}
+	if !strings.Contains(got.Result, testutils.MockTool2.Name) {
+		t.Fatalf("unexpected result: got %q", got.Result)
+	}
+
+	t.Run("client authorization", func(t *testing.T) {
+		path := fmt.Sprintf("/tool/%s/invoke?async=true", testutils.MockTool5.Name)
+		resp, _, err := runRequest(ts, http.MethodPost, path, bytes.NewBufferString(`{}`), map[string]string{"Authorization": "Bearer token"})
+		if err != nil {
+			t.Fatalf("unexpected error during request: %s", err)
+		}
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Fatalf("unexpected status: got %d, want %d", resp.StatusCode, http.StatusBadRequest)
+		}
+	})
+
+	t.Run("unauthorized poll", func(t *testing.T) {
+		// the state of an invocation is authorized as invoking its tool
+		result := asyncResult{ID: "unauthorized-job", Tool: unauthorizedTool.Name, Status: asyncStatusDone, Result: `"secret"`}
+		if err := srv.async.results.Put(context.Background(), result); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		for _, path := range []string{"/job/", "/operations/"} {
+			resp, body, err := runRequest(ts, http.MethodGet, path+result.ID, nil, nil)
+			if err != nil {
+				t.Fatalf("unexpected error during request: %s", err)
+			}
+			if resp.StatusCode != http.StatusUnauthorized || bytes.Contains(body, []byte("secret")) {
+				t.Fatalf("unexpected response: status %d: %s", resp.StatusCode, body)
+			}
+		}
+	})
+
+	t.Run("unknown job", func(t *testing.T) {
+		resp, _, err := runRequest(ts, http.MethodGet, "/job/unknown", nil, nil)
+		if err != nil {
+			t.Fatalf("unexpected error during request: %s", err)
+		}
+		if resp.StatusCode != http.StatusNotFound {
+			t.Fatalf("unexpected status: got %d, want %d", resp.StatusCode, http.StatusNotFound)
+		}
+	})
+}
+
+func TestMemoryResultStoreEviction(t *testing.T) {
+	ctx := context.Background()
+	store := newMemoryResultStore(2)
+	for _, id := range []string{"a", "b", "c"} {
+		if err := store.Put(ctx, asyncResult{ID: id, Status: asyncStatusDone}); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+	if _, err := store.Get(ctx, "a"); !errors.Is(err, errAsyncResultNotFound) {
+		t.Fatalf("expected the oldest result to be evicted, got %v", err)
+	}
+	for _, id := range []string{"b", "c"} {
+		if _, err := store.Get(ctx, id); err != nil {
+			t.Fatalf("unexpected error getting %q: %s", id, err)
+		}
+	}
+}
+
+func TestNewAsyncInvokerValidation(t *testing.T) {
+	tcs := []struct {
+		desc string
+		cfg  ServerConfig
+		want string
+	}{
+		{desc: "invalid backend", cfg: ServerConfig{AsyncBackend: "pubsub"}, want: `invalid async backend "pubsub"`},
+		{desc: "missing queue", cfg: ServerConfig{AsyncBackend: AsyncBackendCloudTasks}, want: "requires --cloud-tasks-queue"},
+		{
+			desc: "missing service account",
+			cfg:  ServerConfig{AsyncBackend: AsyncBackendCloudTasks, CloudTasksQueue: "projects/p/locations/l/queues/q"},
+			want: "requires --cloud-tasks-service-account",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := newAsyncInvoker(context.Background(), &Server{}, tc.cfg)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("unexpected error: got %v, want %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestAsyncCallback(t *testing.T) {
+	defer func(d time.Duration) { callbackRetryDelay = d }(callbackRetryDelay)
+	callbackRetryDelay = time.Millisecond
+
+	calls := make(chan []byte, 2)
+	attempts := 0
+	callbackSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		body, _ := io.ReadAll(r.Body)
+		// the first delivery fails, to be retried
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		calls <- body
+	}))
+	defer callbackSrv.Close()
+
+	mockTools := []testutils.MockTool{testutils.MockTool1, testutils.MockTool2}
+	toolsMap, toolsets, _, _ := testutils.SetUpResources(t, mockTools, nil)
+	withAsync := func(s *Server) {
+		var err error
+		s.async, err = newAsyncInvoker(context.Background(), s, ServerConfig{AsyncBackend: AsyncBackendLocal, AsyncCallbackHosts: []string{"127.0.0.1"}})
+		if err != nil {
+			t.Fatalf("unable to create async invoker: %s", err)
+		}
+	}
+	r, shutdown := setUpServer(t, "api", toolsMap, toolsets, nil, nil, withAsync)
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	path := fmt.Sprintf("/tool/%s/invoke?async=true&callbackUrl=%s", testutils.MockTool2.Name, url.QueryEscape(callbackSrv.URL+"/done"))
+	resp, body, err := runRequest(ts, http.MethodPost, path, bytes.NewBufferString(`{"param1": 1, "param2": 2}`), nil)
+	if err != nil {
+		t.Fatalf("unexpected error during request: %s", err)
+	}
+	if resp.StatusCode != http.StatusAccepted {
+		t.Fatalf("unexpected status: got %d, want %d: %s", resp.StatusCode, http.StatusAccepted, body)
+	}
+	var pending asyncResult
+	if err := json.Unmarshal(body, &pending); err != nil {
+		t.Fatalf("unexpected error unmarshalling body: %s", err)
+	}
+
+	var got asyncResult
+	select {
+	case b := <-calls:
+		if err := json.Unmarshal(b, &got); err != nil {
+			t.Fatalf("unexpected error unmarshalling callback: %s", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("callback was not delivered")
+	}
+	if got.ID != pending.ID || got.Status != asyncStatusDone || !strings.Contains(got.Result, testutils.MockTool2.Name) {
+		t.Fatalf("unexpected callback: %+v", got)
+	}
+
+	resp, body, err = runRequest(ts, http.MethodGet, "/operations/"+pending.ID, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error during request: %s", err)
+	}
+	if resp.StatusCode != http.StatusOK || !strings.Contains(string(body), asyncStatusDone) {
+		t.Fatalf("unexpected operation: status %d: %s", resp.StatusCode, body)
+	}
+
+	for _, callback := range []string{"https://example.com/done", "file:///etc/passwd"} {
+		path := fmt.Sprintf("/tool/%s/invoke?async=true&callbackUrl=%s", testutils.MockTool2.Name, url.QueryEscape(callback))
+		resp, body, err := runRequest(ts, http.MethodPost, path, bytes.NewBufferString(`{"param1": 1, "param2": 2}`), nil)
+		if err != nil {
+			t.Fatalf("unexpected error during request: %s", err)
+		}
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Fatalf("unexpected status for callback %q: got %d, want %d: %s", callback, resp.StatusCode, http.StatusBadRequest, body)
+		}
+	}
+}
+
+type intentConfig struct {
+	tools.ConfigBase
+}
+
+func (intentConfig) ToolConfigType() string { return "intent" }
+func (intentConfig) Initialize(context.Context) (tools.Tool, error) {
+	return nil, nil
+}
+
+// brokenTool is a mock tool of an intent whose invocations fail on a
+// server error.
+type brokenTool struct {
+	testutils.MockTool
+	intent string
+}
+
+func (t brokenTool) ToConfig() tools.ToolConfig {
+	return intentConfig{tools.ConfigBase{Name: t.Name, Intent: t.intent}}
+}
+
+func (t brokenTool) Invoke(context.Context, tools.SourceProvider, parameters.ParamValues, tools.AccessToken) (any, util.ToolboxError) {
+	return nil, util.NewClientServerError("connection reset", http.StatusInternalServerError, nil)
+}
+
+func TestRunTaskIntent(t *testing.T) {
+	toolsMap, toolsets, _, _ := testutils.SetUpResources(t, []testutils.MockTool{testutils.MockTool1, testutils.MockTool2}, nil)
+	for _, intent := range []string{tools.IntentRead, tools.IntentWrite, tools.IntentAdmin} {
+		name := intent + "_tool"
+		toolsMap[name] = brokenTool{MockTool: testutils.NewMockTool(name, "", nil, false, false), intent: intent}
+	}
+	var srv *Server
+	withAsync := func(s *Server) {
+		srv = s
+		var err error
+		s.async, err = newAsyncInvoker(context.Background(), s, ServerConfig{AsyncBackend: AsyncBackendLocal})
+		if err != nil {
+			t.Fatalf("unable to create async invoker: %s", err)
+		}
+	}
+	_, shutdown := setUpServer(t, "api", toolsMap, toolsets, nil, nil, withAsync)
+	defer shutdown()
+
+	tcs := []struct {
+		intent string
+		want   int
+	}{
+		// failed reads are retried, failed writes are not
+		{intent: tools.IntentRead, want: http.StatusInternalServerError},
+		{intent: tools.IntentWrite, want: http.StatusNoContent},
+		{intent: tools.IntentAdmin, want: http.StatusNoContent},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.intent, func(t *testing.T) {
+			task, err := json.Marshal(asyncTask{ID: tc.intent + "-task", Tool: tc.intent + "_tool", Params: map[string]any{}})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			w := httptest.NewRecorder()
+			runTask(srv, w, httptest.NewRequest(http.MethodPost, "/tasks", bytes.NewReader(task)))
+			if w.Code != tc.want {
+				t.Fatalf("unexpected status: got %d, want %d", w.Code, tc.want)
+			}
+			result, err := srv.async.results.Get(context.Background(), tc.intent+"-task")
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if result.Status != asyncStatusFailed {
+				t.Fatalf("unexpected status of result: got %q, want %q", result.Status, asyncStatusFailed)
+			}
+		})
+	}
+}
+
+// emailAuthService verifies the email of its token header as its claim.
+type emailAuthService struct{}
+
+func (emailAuthService) AuthServiceType() string          { return "mock-email" }
+func (emailAuthService) GetName() string                  { return "my-auth" }
+func (emailAuthService) ToConfig() auth.AuthServiceConfig { return nil }
+func (emailAuthService) GetClaimsFromHeader(_ context.Context, h http.Header) (map[string]any, error) {
+	email := h.Get("my-auth_token")
+	if email == "" {
+		return nil, nil
+	}
+	return map[string]any{"email": email}, nil
+}
+
+// impersonatingTool returns the role its source impersonates.
+type impersonatingTool struct {
+	testutils.MockTool
+}
+
+func (t impersonatingTool) Invoke(ctx context.Context, _ tools.SourceProvider, _ parameters.ParamValues, _ tools.AccessToken) (any, util.ToolboxError) {
+	role, err := (&sessionrole.Config{AuthService: "my-auth"}).Role(ctx)
+	if err != nil {
+		return nil, util.NewClientServerError("unable to impersonate", http.StatusInternalServerError, err)
+	}
+	return role, nil
+}
+
+func TestAsyncImpersonation(t *testing.T) {
+	toolsMap := map[string]tools.Tool{
+		"impersonating_tool": impersonatingTool{testutils.NewMockTool("impersonating_tool", "", nil, false, false)},
+	}
+	toolset, err := tools.ToolsetConfig{Name: "", ToolNames: []string{"impersonating_tool"}}.Initialize(testutils.MockVersionString, toolsMap)
+	if err != nil {
+		t.Fatalf("unable to initialize toolset: %s", err)
+	}
+	toolsets := map[string]tools.Toolset{"": toolset}
+	withAsync := func(s *Server) {
+		s.PrimitiveMgr.SetPrimitives(nil, map[string]auth.AuthService{"my-auth": emailAuthService{}}, nil, toolsMap, toolsets, nil, map[string]prompts.Promptset{"": {}}, nil)
+		var err error
+		s.async, err = newAsyncInvoker(context.Background(), s, ServerConfig{AsyncBackend: AsyncBackendLocal})
+		if err != nil {
+			t.Fatalf("unable to create async invoker: %s", err)
+		}
+	}
+	r, shutdown := setUpServer(t, "api", toolsMap, toolsets, nil, nil, withAsync)
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	invoke := func(headers map[string]string) asyncResult {
+		resp, body, err := runRequest(ts, http.MethodPost, "/tool/impersonating_tool/invoke?async=true", bytes.NewBufferString(`{}`), headers)
+		if err != nil {
+			t.Fatalf("unexpected error during request: %s", err)
+		}
+		if resp.StatusCode != http.StatusAccepted {
+			t.Fatalf("unexpected status: got %d, want %d: %s", resp.StatusCode, http.StatusAccepted, body)
+		}
+		var got asyncResult
+		if err := json.Unmarshal(body, &got); err != nil {
+			t.Fatalf("unexpected error unmarshalling body: %s", err)
+		}
+		for deadline := time.Now().Add(5 * time.Second); got.Status == asyncStatusPending && time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+			_, body, err = runRequest(ts, http.MethodGet, "/job/"+got.ID, nil, headers)
+			if err != nil {
+				t.Fatalf("unexpected error during request: %s", err)
+			}
+			if err := json.Unmarshal(body, &got); err != nil {
+				t.Fatalf("unexpected error unmarshalling body: %s", err)
+			}
+		}
+		return got
+	}
+
+	// the task runs as the principal the invocation was authenticated as
+	got := invoke(map[string]string{"my-auth_token": "alice@example.com"})
+	if got.Status != asyncStatusDone || got.Result != `"alice@example.com"` {
+		t.Fatalf("unexpected result of authenticated invocation: %+v", got)
+	}
+	// rather than falling back to the user of the source
+	got = invoke(nil)
+	if got.Status != asyncStatusFailed {
+		t.Fatalf("unexpected result of unauthenticated invocation: %+v", got)
+	}
+}
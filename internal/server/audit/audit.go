@@ -0,0 +1,338 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package audit writes a structured record of every tool invocation.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/logging"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+const (
+	// SinkStdout writes records to stdout, one JSON object per line.
+	SinkStdout = "stdout"
+	// SinkFile appends records to a file, one JSON object per line.
+	SinkFile = "file"
+	// SinkCloudLogging writes records to Cloud Logging.
+	SinkCloudLogging = "cloud-logging"
+)
+
+// LogID is the Cloud Logging log records are written to.
+const LogID = "toolbox-audit"
+
+// RedactedValue replaces the values of redacted parameters in records.
+const RedactedValue = "[REDACTED]"
+
+// Record is the audit record of a tool invocation.
+type Record struct {
+	Time time.Time `json:"time"`
+	Tool string    `json:"tool"`
+	// Caller is the identity the invocation was authenticated as: the IAP
+	// user, or else the email or subject of the first verified auth service.
+	Caller string `json:"caller,omitempty"`
+	// AuthServices maps the auth services the invocation was verified by to
+	// the email or subject of their token.
+	AuthServices map[string]string `json:"authServices,omitempty"`
+	// Statements are the statements the invocation ran on its source, for
+	// the sources that report them.
+	Statements      []util.ExecutedStatement `json:"statements,omitempty"`
+	Params          map[string]any           `json:"params"`
+	DurationSeconds float64                  `json:"durationSeconds"`
+	Rows            int                      `json:"rows"`
+	Error           string                   `json:"error,omitempty"`
+}
+
+// Sink stores audit records.
+type Sink interface {
+	Write(ctx context.Context, r Record) error
+	Close() error
+}
+
+// NewSink returns the sink of the given type. path is the file of the file
+// sink and project the Google Cloud project of the cloud-logging sink.
+func NewSink(ctx context.Context, sinkType, path, project string) (Sink, error) {
+	switch sinkType {
+	case SinkStdout:
+		return &jsonSink{w: os.Stdout}, nil
+	case SinkFile:
+		if path == "" {
+			return nil, fmt.Errorf("audit log sink %q requires a file", SinkFile)
+		}
+		f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+		if err != nil {
+			return nil, fmt.Errorf("unable to open audit log file: %w", err)
+		}
+		return &jsonSink{w: f, c: f}, nil
+	case SinkCloudLogging:
+		if project == "" {
+			return nil, fmt.Errorf("audit log sink %q requires a project", SinkCloudLogging)
+		}
+		client, err := logging.NewClient(ctx, project)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create Cloud Logging client: %w", err)
+		}
+		return &cloudLoggingSink{client: client, logger: client.Logger(LogID)}, nil
+	default:
+		return nil, fmt.Errorf("invalid audit log sink %q: must be %q, %q or %q", sinkType, SinkStdout, SinkFile, SinkCloudLogging)
+	}
+}
+
+// jsonSink writes records to w, one JSON object per line.
+type jsonSink struct {
+	mu sync.Mutex
+	w  io.Writer
+	c  io.Closer
+}
+
+func (s *jsonSink) Write(ctx context.Context, r Record) error {
+	b, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.w.Write(append(b, '\n'))
+	return err
+}
+
+func (s *jsonSink) Close() error {
+	if s.c == nil {
+		return nil
+	}
+	return s.c.Close()
+}
+
+// cloudLoggingSink writes records to Cloud Logging. Entries are buffered and
+// sent in the background, and flushed on Close.
+type cloudLoggingSink struct {
+	client *logging.Client
+	logger *logging.Logger
+}
+
+func (s *cloudLoggingSink) Write(ctx context.Context, r Record) error {
+	severity := logging.Notice
+	if r.Error != "" {
+		severity = logging.Error
+	}
+	s.logger.Log(logging.Entry{
+		Timestamp: r.Time,
+		Severity:  severity,
+		Payload:   r,
+		Labels:    map[string]string{"tool": r.Tool},
+	})
+	return nil
+}
+
+func (s *cloudLoggingSink) Close() error {
+	return s.client.Close()
+}
+
+// Auditor writes the audit records of tool invocations to a sink.
+type Auditor struct {
+	sink   Sink
+	redact map[string]bool
+}
+
+// New returns an auditor writing records to sink, with the values of the
+// parameters named in redactParams replaced by RedactedValue.
+func New(sink Sink, redactParams []string) *Auditor {
+	redact := make(map[string]bool, len(redactParams))
+	for _, name := range redactParams {
+		redact[name] = true
+	}
+	return &Auditor{sink: sink, redact: redact}
+}
+
+// Close closes the sink of the auditor.
+func (a *Auditor) Close() error {
+	return a.sink.Close()
+}
+
+// record writes the record of an invocation of toolName that started at
+// start. Failing to write it is logged, and does not fail the invocation.
+func (a *Auditor) record(ctx context.Context, toolName string, params parameters.ParamValues, sensitive map[string]bool, statements *util.StatementLog, start time.Time, rows int, err error) {
+	r := Record{
+		Time:            start.UTC(),
+		Tool:            toolName,
+		Params:          params.AsMap(),
+		Statements:      statements.Statements(),
+		DurationSeconds: time.Since(start).Seconds(),
+		Rows:            rows,
+	}
+	if err != nil {
+		r.Error = err.Error()
+	}
+	r.Caller, r.AuthServices = caller(ctx)
+	a.redactRecord(&r, sensitive)
+	if err := a.sink.Write(ctx, r); err != nil {
+		if l, lerr := util.LoggerFromContext(ctx); lerr == nil {
+			l.WarnContext(ctx, fmt.Sprintf("unable to write audit record of tool %q: %s", toolName, err))
+		}
+	}
+}
+
+// redactRecord replaces the values of the redacted parameters, and the
+// statement parameters equal to them, with RedactedValue. The values of the
+// sensitive parameters of the tool are replaced by their fingerprint, also
+// where they appear in the error.
+func (a *Auditor) redactRecord(r *Record, sensitive map[string]bool) {
+	type redaction struct {
+		value       any
+		replacement string
+	}
+	var redacted []redaction
+	for name, v := range r.Params {
+		switch {
+		case a.redact[name]:
+			redacted = append(redacted, redaction{v, RedactedValue})
+			r.Params[name] = RedactedValue
+		case sensitive[name] && v != nil:
+			fingerprint := parameters.RedactValue(v)
+			redacted = append(redacted, redaction{v, fingerprint})
+			r.Params[name] = fingerprint
+			if s, ok := v.(string); ok && s != "" {
+				r.Error = strings.ReplaceAll(r.Error, s, fingerprint)
+			}
+		}
+	}
+	if len(redacted) == 0 {
+		return
+	}
+	for i, s := range r.Statements {
+		params := make([]any, len(s.Params))
+		for j, p := range s.Params {
+			params[j] = p
+			for _, v := range redacted {
+				if reflect.DeepEqual(p, v.value) {
+					params[j] = v.replacement
+					break
+				}
+			}
+		}
+		r.Statements[i].Params = params
+	}
+}
+
+// caller returns the identity an invocation was authenticated as, and the
+// subjects of the auth services it was verified by.
+func caller(ctx context.Context) (string, map[string]string) {
+	claims := util.AuthServiceClaimsFromContext(ctx)
+	var names []string
+	var subjects map[string]string
+	if len(claims) > 0 {
+		subjects = make(map[string]string, len(claims))
+	}
+	for name, c := range claims {
+		subject, _ := c["email"].(string)
+		if subject == "" {
+			subject, _ = c["sub"].(string)
+		}
+		subjects[name] = subject
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if id, ok := util.IdentityFromContext(ctx); ok {
+		return id, subjects
+	}
+	for _, name := range names {
+		if subjects[name] != "" {
+			return subjects[name], subjects
+		}
+	}
+	return "", subjects
+}
+
+// Wrap returns the tools with every invocation audited by a.
+func Wrap(toolsMap map[string]tools.Tool, a *Auditor) map[string]tools.Tool {
+	wrapped := make(map[string]tools.Tool, len(toolsMap))
+	for name, t := range toolsMap {
+		at := auditedTool{Tool: t, auditor: a}
+		if ps, err := t.GetParameters(nil); err == nil {
+			at.sensitive = parameters.SensitiveNames(ps)
+		}
+		if streamer, ok := t.(tools.RowStreamer); ok {
+			wrapped[name] = auditedStreamer{auditedTool: at, streamer: streamer}
+			continue
+		}
+		wrapped[name] = at
+	}
+	return wrapped
+}
+
+// auditedTool is a tool whose invocations are audited.
+type auditedTool struct {
+	tools.Tool
+	auditor *Auditor
+	// sensitive are the names of the sensitive parameters of the tool.
+	sensitive map[string]bool
+}
+
+// Unwrap implements tools.Wrapper.
+func (t auditedTool) Unwrap() tools.Tool { return t.Tool }
+
+func (t auditedTool) Invoke(ctx context.Context, sp tools.SourceProvider, params parameters.ParamValues, token tools.AccessToken) (any, util.ToolboxError) {
+	statements := &util.StatementLog{}
+	ctx = util.WithStatementLog(ctx, statements)
+	start := time.Now()
+	res, tbErr := t.Tool.Invoke(ctx, sp, params, token)
+	var err error
+	if tbErr != nil {
+		err = tbErr
+	}
+	t.auditor.record(ctx, t.GetName(), params, t.sensitive, statements, start, tools.CountRows(res), err)
+	return res, tbErr
+}
+
+// DryRun implements tools.DryRunner. Dry runs are not audited, since they do
+// not run the tool.
+func (t auditedTool) DryRun(ctx context.Context, sp tools.SourceProvider, params parameters.ParamValues, token tools.AccessToken) (*tools.DryRunResult, util.ToolboxError) {
+	return tools.DryRun(ctx, t.Tool, sp, params, token)
+}
+
+// auditedStreamer is an audited tool that streams its rows.
+type auditedStreamer struct {
+	auditedTool
+	streamer tools.RowStreamer
+}
+
+func (t auditedStreamer) StreamRows(ctx context.Context, sp tools.SourceProvider, params parameters.ParamValues, token tools.AccessToken, emit func(rows []any) error) util.ToolboxError {
+	statements := &util.StatementLog{}
+	ctx = util.WithStatementLog(ctx, statements)
+	start := time.Now()
+	rows := 0
+	tbErr := t.streamer.StreamRows(ctx, sp, params, token, func(batch []any) error {
+		rows += len(batch)
+		return emit(batch)
+	})
+	var err error
+	if tbErr != nil {
+		err = tbErr
+	}
+	t.auditor.record(ctx, t.GetName(), params, t.sensitive, statements, start, rows, err)
+	return tbErr
+}
@@ -0,0 +1,239 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/googleapis/mcp-toolbox/internal/testutils"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+// memorySink keeps the records written to it.
+type memorySink struct {
+	mu      sync.Mutex
+	records []Record
+}
+
+func (s *memorySink) Write(_ context.Context, r Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records = append(s.records, r)
+	return nil
+}
+
+func (s *memorySink) Close() error { return nil }
+
+// sqlTool runs a statement with its ssn parameter, and fails when asked to.
+type sqlTool struct {
+	testutils.MockTool
+}
+
+func (t sqlTool) Invoke(ctx context.Context, _ tools.SourceProvider, params parameters.ParamValues, _ tools.AccessToken) (any, util.ToolboxError) {
+	m := params.AsMap()
+	util.RecordStatement(ctx, "SELECT * FROM people WHERE ssn = $1 AND age > $2", []any{m["ssn"], m["age"]})
+	if m["fail"] == true {
+		return nil, util.NewAgentError("invalid ssn", nil)
+	}
+	return []any{map[string]any{"name": "Jane"}, map[string]any{"name": "John"}}, nil
+}
+
+// streamingTool streams two batches of rows.
+type streamingTool struct {
+	sqlTool
+}
+
+func (t streamingTool) StreamRows(ctx context.Context, _ tools.SourceProvider, _ parameters.ParamValues, _ tools.AccessToken, emit func([]any) error) util.ToolboxError {
+	util.RecordStatement(ctx, "SELECT * FROM people", nil)
+	for _, batch := range [][]any{{1, 2}, {3}} {
+		if err := emit(batch); err != nil {
+			return util.ProcessGeneralError(err)
+		}
+	}
+	return nil
+}
+
+var ignoreTiming = cmpopts.IgnoreFields(Record{}, "Time", "DurationSeconds")
+
+func TestWrap(t *testing.T) {
+	sink := &memorySink{}
+	a := New(sink, []string{"ssn"})
+	toolsMap := Wrap(map[string]tools.Tool{
+		"find_person": sqlTool{MockTool: testutils.MockTool{Name: "find_person"}},
+		"list_people": streamingTool{sqlTool{MockTool: testutils.MockTool{Name: "list_people"}}},
+	}, a)
+
+	ctx := util.WithAuthServiceClaims(context.Background(), map[string]map[string]any{
+		"my-google-auth": {"email": "jane@example.com", "sub": "123"},
+		"my-oidc":        {"sub": "456"},
+	})
+	params := parameters.ParamValues{{Name: "ssn", Value: "123-45-6789"}, {Name: "age", Value: 30}}
+	res, err := toolsMap["find_person"].Invoke(ctx, nil, params, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(res.([]any)) != 2 {
+		t.Fatalf("unexpected result: %v", res)
+	}
+
+	failing := append(params, parameters.ParamValue{Name: "fail", Value: true})
+	if _, err := toolsMap["find_person"].Invoke(util.WithIdentity(ctx, "iap-user@example.com"), nil, failing, ""); err == nil {
+		t.Fatalf("expected an error")
+	}
+
+	streamer, ok := toolsMap["list_people"].(tools.RowStreamer)
+	if !ok {
+		t.Fatalf("audited streaming tool does not stream")
+	}
+	if err := streamer.StreamRows(context.Background(), nil, nil, "", func([]any) error { return nil }); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	subjects := map[string]string{"my-google-auth": "jane@example.com", "my-oidc": "456"}
+	redactedStatement := []util.ExecutedStatement{{Statement: "SELECT * FROM people WHERE ssn = $1 AND age > $2", Params: []any{RedactedValue, 30}}}
+	want := []Record{
+		{
+			Tool:         "find_person",
+			Caller:       "jane@example.com",
+			AuthServices: subjects,
+			Statements:   redactedStatement,
+			Params:       map[string]any{"ssn": RedactedValue, "age": 30},
+			Rows:         2,
+		},
+		{
+			Tool:         "find_person",
+			Caller:       "iap-user@example.com",
+			AuthServices: subjects,
+			Statements:   redactedStatement,
+			Params:       map[string]any{"ssn": RedactedValue, "age": 30, "fail": true},
+			Error:        "invalid ssn",
+		},
+		{
+			Tool:       "list_people",
+			Statements: []util.ExecutedStatement{{Statement: "SELECT * FROM people"}},
+			Params:     map[string]any{},
+			Rows:       3,
+		},
+	}
+	if diff := cmp.Diff(want, sink.records, ignoreTiming); diff != "" {
+		t.Errorf("unexpected records (-want +got):\n%s", diff)
+	}
+}
+
+// emailTool runs a statement with its email parameter, and fails with an
+// error quoting it when asked to.
+type emailTool struct {
+	testutils.MockTool
+}
+
+func (t emailTool) Invoke(ctx context.Context, _ tools.SourceProvider, params parameters.ParamValues, _ tools.AccessToken) (any, util.ToolboxError) {
+	m := params.AsMap()
+	util.RecordStatement(ctx, "SELECT * FROM customers WHERE email = $1", []any{m["email"]})
+	if m["fail"] == true {
+		return nil, util.NewAgentError(fmt.Sprintf("duplicate key value (email)=(%s)", m["email"]), nil)
+	}
+	return []any{}, nil
+}
+
+func TestWrapSensitiveParams(t *testing.T) {
+	sink := &memorySink{}
+	email := parameters.NewStringParameter("email", "the email of the customer")
+	email.Sensitive = true
+	toolsMap := Wrap(map[string]tools.Tool{
+		"find_customer": emailTool{MockTool: testutils.MockTool{Name: "find_customer", Params: []parameters.Parameter{email}}},
+	}, New(sink, nil))
+
+	params := parameters.ParamValues{{Name: "email", Value: "jane@example.com"}, {Name: "fail", Value: true}}
+	if _, err := toolsMap["find_customer"].Invoke(context.Background(), nil, params, ""); err == nil {
+		t.Fatalf("expected an error")
+	}
+
+	fingerprint := parameters.RedactValue("jane@example.com")
+	want := []Record{{
+		Tool:       "find_customer",
+		Statements: []util.ExecutedStatement{{Statement: "SELECT * FROM customers WHERE email = $1", Params: []any{fingerprint}}},
+		Params:     map[string]any{"email": fingerprint, "fail": true},
+		Error:      fmt.Sprintf("duplicate key value (email)=(%s)", fingerprint),
+	}}
+	if diff := cmp.Diff(want, sink.records, ignoreTiming); diff != "" {
+		t.Errorf("unexpected records (-want +got):\n%s", diff)
+	}
+}
+
+func TestJSONSink(t *testing.T) {
+	var buf bytes.Buffer
+	s := &jsonSink{w: &buf}
+	r := Record{Time: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), Tool: "find_person", Params: map[string]any{"id": 1}, Rows: 1}
+	if err := s.Write(context.Background(), r); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := `{"time":"2026-01-02T03:04:05Z","tool":"find_person","params":{"id":1},"durationSeconds":0,"rows":1}` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("unexpected output:\ngot:  %s\nwant: %s", got, want)
+	}
+}
+
+func TestFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	if _, err := NewSink(context.Background(), SinkFile, "", ""); err == nil {
+		t.Fatalf("expected an error for a file sink without a file")
+	}
+	for i := 0; i < 2; i++ {
+		// the file is appended to by every sink opening it
+		s, err := NewSink(context.Background(), SinkFile, path, "")
+		if err != nil {
+			t.Fatalf("unable to create sink: %s", err)
+		}
+		if err := s.Write(context.Background(), Record{Tool: "find_person"}); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if err := s.Close(); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unable to read audit log: %s", err)
+	}
+	lines := bytes.Split(bytes.TrimSpace(b), []byte("\n"))
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 records, got %d:\n%s", len(lines), b)
+	}
+	var r Record
+	if err := json.Unmarshal(lines[1], &r); err != nil || r.Tool != "find_person" {
+		t.Errorf("unexpected record %s: %v", lines[1], err)
+	}
+}
+
+func TestNewSinkInvalid(t *testing.T) {
+	if _, err := NewSink(context.Background(), "syslog", "", ""); err == nil {
+		t.Errorf("expected an error for an invalid sink")
+	}
+	if _, err := NewSink(context.Background(), SinkCloudLogging, "", ""); err == nil {
+		t.Errorf("expected an error for a cloud-logging sink without a project")
+	}
+}
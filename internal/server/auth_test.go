@@ -0,0 +1,157 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/internal/auth"
+	"github.com/googleapis/mcp-toolbox/internal/prompts"
+	"github.com/googleapis/mcp-toolbox/internal/testutils"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+)
+
+// expiringAuthService rejects every token it is sent as expired.
+type expiringAuthService struct {
+	expiresAt time.Time
+}
+
+func (s expiringAuthService) AuthServiceType() string          { return "mock-expiring" }
+func (s expiringAuthService) GetName() string                  { return "my-auth" }
+func (s expiringAuthService) ToConfig() auth.AuthServiceConfig { return nil }
+func (s expiringAuthService) GetClaimsFromHeader(ctx context.Context, h http.Header) (map[string]any, error) {
+	if h.Get("my-auth_token") == "" {
+		return nil, nil
+	}
+	return nil, &auth.TokenExpiredError{
+		AuthService: s.GetName(),
+		ExpiresAt:   s.expiresAt,
+		Err:         fmt.Errorf("token has invalid claims: token is expired"),
+	}
+}
+
+func TestTokenExpired(t *testing.T) {
+	expiresAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	toolsMap := map[string]tools.Tool{
+		"auth_tool": testutils.NewMockTool("auth_tool", "", nil, true, false),
+	}
+	toolset, err := tools.ToolsetConfig{Name: "", ToolNames: []string{"auth_tool"}}.Initialize(testutils.MockVersionString, toolsMap)
+	if err != nil {
+		t.Fatalf("unable to initialize toolset: %s", err)
+	}
+	toolsets := map[string]tools.Toolset{"": toolset}
+	withAuth := func(s *Server) {
+		s.PrimitiveMgr.SetPrimitives(nil, map[string]auth.AuthService{"my-auth": expiringAuthService{expiresAt: expiresAt}}, nil, toolsMap, toolsets, nil, map[string]prompts.Promptset{"": {}}, nil)
+	}
+	wantData := map[string]any{
+		"code":        auth.ErrorCodeTokenExpired,
+		"authService": "my-auth",
+		"expiresAt":   "2026-01-01T12:00:00Z",
+	}
+
+	t.Run("api", func(t *testing.T) {
+		r, shutdown := setUpServer(t, "api", toolsMap, toolsets, nil, nil, withAuth)
+		defer shutdown()
+		ts := runServer(r, false)
+		defer ts.Close()
+
+		tcs := []struct {
+			desc     string
+			header   map[string]string
+			wantCode string
+			wantData map[string]any
+		}{
+			{desc: "expired token", header: map[string]string{"my-auth_token": "expired"}, wantCode: auth.ErrorCodeTokenExpired, wantData: wantData},
+			{desc: "missing token"},
+		}
+		for _, tc := range tcs {
+			t.Run(tc.desc, func(t *testing.T) {
+				resp, body, err := runRequest(ts, http.MethodPost, "/tool/auth_tool/invoke", strings.NewReader(`{}`), tc.header)
+				if err != nil {
+					t.Fatalf("unexpected error during request: %s", err)
+				}
+				if resp.StatusCode != http.StatusUnauthorized {
+					t.Fatalf("unexpected status: %d, body: %s", resp.StatusCode, body)
+				}
+				var got struct {
+					Code string         `json:"code"`
+					Data map[string]any `json:"data"`
+				}
+				if err := json.Unmarshal(body, &got); err != nil {
+					t.Fatalf("unable to decode response: %s", err)
+				}
+				if got.Code != tc.wantCode {
+					t.Errorf("unexpected code: got %q, want %q", got.Code, tc.wantCode)
+				}
+				if diff := cmp.Diff(tc.wantData, got.Data); diff != "" {
+					t.Errorf("unexpected data (-want +got):\n%s", diff)
+				}
+			})
+		}
+	})
+
+	t.Run("mcp", func(t *testing.T) {
+		r, shutdown := setUpServer(t, "mcp", toolsMap, toolsets, nil, nil, withAuth)
+		defer shutdown()
+		ts := runServer(r, false)
+		defer ts.Close()
+
+		tcs := []struct {
+			protocolVersion string
+			// field is the field of the result holding the details of the
+			// error.
+			field string
+		}{
+			{protocolVersion: "2024-11-05", field: "_meta"},
+			{protocolVersion: "2025-06-18", field: "structuredContent"},
+		}
+		for _, tc := range tcs {
+			t.Run(tc.protocolVersion, func(t *testing.T) {
+				header := map[string]string{"my-auth_token": "expired", "Mcp-Protocol-Version": tc.protocolVersion}
+				reqBody := `{"jsonrpc": "2.0", "id": "call", "method": "tools/call", "params": {"name": "auth_tool", "arguments": {}}}`
+				resp, body, err := runRequest(ts, http.MethodPost, "/", strings.NewReader(reqBody), header)
+				if err != nil {
+					t.Fatalf("unexpected error during request: %s", err)
+				}
+				if resp.StatusCode != http.StatusOK {
+					t.Fatalf("unexpected status: %d, body: %s", resp.StatusCode, body)
+				}
+				var got struct {
+					Result map[string]any `json:"result"`
+				}
+				if err := json.Unmarshal(body, &got); err != nil {
+					t.Fatalf("unable to decode response: %s", err)
+				}
+				if got.Result["isError"] != true {
+					t.Errorf("expected isError to be set, got %s", body)
+				}
+				data, _ := got.Result[tc.field].(map[string]any)
+				if tc.field == "_meta" {
+					data, _ = data["error"].(map[string]any)
+				}
+				if diff := cmp.Diff(wantData, data); diff != "" {
+					t.Errorf("unexpected error details (-want +got):\n%s", diff)
+				}
+			})
+		}
+	})
+}
@@ -0,0 +1,177 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// DefaultBatchParallelism is the default number of the invocations of a
+// batch run at once.
+const DefaultBatchParallelism = 8
+
+// maxBatchInvocations bounds the invocations of a batch.
+const maxBatchInvocations = 100
+
+// batchRequest is the body of a batch of tool invocations.
+type batchRequest struct {
+	Invocations []batchInvocation `json:"invocations"`
+}
+
+// batchInvocation is an invocation of a batch.
+type batchInvocation struct {
+	Tool   string         `json:"tool"`
+	Params map[string]any `json:"params"`
+}
+
+// batchResult is the result of an invocation of a batch: the status and body
+// that invoking the tool on its own would have returned.
+type batchResult struct {
+	Tool     string          `json:"tool"`
+	Status   int             `json:"status"`
+	Response json.RawMessage `json:"response"`
+}
+
+// batchResponse holds the results of a batch, in the order of its
+// invocations.
+type batchResponse struct {
+	Results []batchResult `json:"results"`
+}
+
+// Render renders a batch response.
+func (b batchResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	render.Status(r, http.StatusOK)
+	return nil
+}
+
+// batchResponseWriter buffers the response of an invocation of a batch.
+type batchResponseWriter struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (w *batchResponseWriter) Header() http.Header { return w.header }
+
+func (w *batchResponseWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.body.Write(b)
+}
+
+func (w *batchResponseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+}
+
+// batchInvokeHandler handles the API request to invoke several tools at once.
+// Each invocation is handled as by toolInvokeHandler, with the headers of the
+// batch, and at most batchParallelism of them run at once. An invocation
+// failing does not fail the batch.
+func batchInvokeHandler(s *Server, w http.ResponseWriter, r *http.Request) {
+	ctx, span := s.instrumentation.Tracer.Start(r.Context(), "toolbox/server/tool/batch")
+	defer span.End()
+	r = r.WithContext(ctx)
+
+	var req batchRequest
+	if err := util.DecodeJSON(http.MaxBytesReader(w, r.Body, s.httpMaxRequestBytes), &req); err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			err = fmt.Errorf("request body exceeds %d bytes", s.httpMaxRequestBytes)
+			_ = render.Render(w, r, newErrResponse(err, http.StatusRequestEntityTooLarge))
+			return
+		}
+		err = fmt.Errorf("request body was invalid JSON: %w", err)
+		_ = render.Render(w, r, newErrResponse(err, http.StatusBadRequest))
+		return
+	}
+	if len(req.Invocations) == 0 {
+		_ = render.Render(w, r, newErrResponse(fmt.Errorf("a batch requires at least one invocation"), http.StatusBadRequest))
+		return
+	}
+	if len(req.Invocations) > maxBatchInvocations {
+		err := fmt.Errorf("a batch has at most %d invocations, got %d", maxBatchInvocations, len(req.Invocations))
+		_ = render.Render(w, r, newErrResponse(err, http.StatusBadRequest))
+		return
+	}
+	span.SetAttributes(attribute.Int("toolbox.batch.size", len(req.Invocations)))
+
+	parallelism := s.batchParallelism
+	if parallelism <= 0 {
+		parallelism = DefaultBatchParallelism
+	}
+	results := make([]batchResult, len(req.Invocations))
+	slots := make(chan struct{}, parallelism)
+	var wg sync.WaitGroup
+	for i, inv := range req.Invocations {
+		slots <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			results[i] = s.invokeBatched(ctx, r, inv)
+		}()
+	}
+	wg.Wait()
+	_ = render.Render(w, r, batchResponse{Results: results})
+}
+
+// invokeBatched handles an invocation of the batch r as toolInvokeHandler
+// does, and returns its response.
+func (s *Server) invokeBatched(ctx context.Context, r *http.Request, inv batchInvocation) batchResult {
+	params := inv.Params
+	if params == nil {
+		params = map[string]any{}
+	}
+	body, err := json.Marshal(params)
+	if err != nil {
+		resp, _ := json.Marshal(newErrResponse(fmt.Errorf("invalid parameters: %w", err), http.StatusBadRequest))
+		return batchResult{Tool: inv.Tool, Status: http.StatusBadRequest, Response: resp}
+	}
+
+	routeCtx := chi.NewRouteContext()
+	routeCtx.URLParams.Add("toolName", inv.Tool)
+	item := r.Clone(context.WithValue(ctx, chi.RouteCtxKey, routeCtx))
+	item.Body = io.NopCloser(bytes.NewReader(body))
+	item.ContentLength = int64(len(body))
+	// invocations of a batch return JSON and run synchronously
+	item.Header.Set("Content-Type", "application/json")
+	item.Header.Set("Accept", "application/json")
+	item.URL.RawQuery = ""
+
+	rw := &batchResponseWriter{header: http.Header{}}
+	toolInvokeHandler(s, rw, item)
+	resp := bytes.TrimSpace(rw.body.Bytes())
+	if len(resp) == 0 {
+		resp = []byte("null")
+	}
+	return batchResult{Tool: inv.Tool, Status: rw.status, Response: resp}
+}
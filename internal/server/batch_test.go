@@ -0,0 +1,111 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/googleapis/mcp-toolbox/internal/testutils"
+)
+
+func TestBatchInvokeEndpoint(t *testing.T) {
+	mockTools := []testutils.MockTool{testutils.MockTool1, testutils.MockTool2}
+	toolsMap, toolsets, _, _ := testutils.SetUpResources(t, mockTools, nil)
+	r, shutdown := setUpServer(t, "api", toolsMap, toolsets, nil, nil)
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	reqBody := fmt.Sprintf(`{"invocations": [
+		{"tool": %q},
+		{"tool": "some_imaginary_tool"},
+		{"tool": %q, "params": {"param1": 1, "param2": 2}}
+	]}`, testutils.MockTool1.Name, testutils.MockTool2.Name)
+	resp, body, err := runRequest(ts, http.MethodPost, "/batch", strings.NewReader(reqBody), nil)
+	if err != nil {
+		t.Fatalf("unexpected error during request: %s", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("got status %d, want %d: %s", resp.StatusCode, http.StatusOK, body)
+	}
+	var got batchResponse
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatalf("unable to decode response: %s", err)
+	}
+
+	want := []struct {
+		tool   string
+		status int
+		result string
+	}{
+		{testutils.MockTool1.Name, http.StatusOK, `["no_params"]`},
+		{"some_imaginary_tool", http.StatusNotFound, ""},
+		{testutils.MockTool2.Name, http.StatusOK, `["some_params"]`},
+	}
+	if len(got.Results) != len(want) {
+		t.Fatalf("got %d results, want %d: %s", len(got.Results), len(want), body)
+	}
+	for i, w := range want {
+		res := got.Results[i]
+		if res.Tool != w.tool || res.Status != w.status {
+			t.Errorf("result %d: got tool %q with status %d, want %q with status %d", i, res.Tool, res.Status, w.tool, w.status)
+			continue
+		}
+		if w.result == "" {
+			continue
+		}
+		var r resultResponse
+		if err := json.Unmarshal(res.Response, &r); err != nil {
+			t.Fatalf("result %d: unable to decode response %s: %s", i, res.Response, err)
+		}
+		if r.Result != w.result {
+			t.Errorf("result %d: got %s, want %s", i, r.Result, w.result)
+		}
+	}
+}
+
+func TestBatchInvokeEndpointErrors(t *testing.T) {
+	mockTools := []testutils.MockTool{testutils.MockTool1, testutils.MockTool2}
+	toolsMap, toolsets, _, _ := testutils.SetUpResources(t, mockTools, nil)
+	r, shutdown := setUpServer(t, "api", toolsMap, toolsets, nil, nil)
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	tooMany := make([]string, maxBatchInvocations+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf(`{"tool": %q}`, testutils.MockTool1.Name)
+	}
+	for name, reqBody := range map[string]string{
+		"empty":    `{"invocations": []}`,
+		"too many": `{"invocations": [` + strings.Join(tooMany, ",") + `]}`,
+		"invalid":  `{"invocations": `,
+	} {
+		t.Run(name, func(t *testing.T) {
+			resp, body, err := runRequest(ts, http.MethodPost, "/batch", bytes.NewBufferString(reqBody), nil)
+			if err != nil {
+				t.Fatalf("unexpected error during request: %s", err)
+			}
+			if resp.StatusCode != http.StatusBadRequest {
+				t.Errorf("got status %d, want %d: %s", resp.StatusCode, http.StatusBadRequest, body)
+			}
+		})
+	}
+}
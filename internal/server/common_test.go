@@ -39,7 +39,7 @@ var (
 )
 
 // setUpServer create a new server with tools, toolsets, prompts, and promptsets.
-func setUpServer(t *testing.T, router string, tools map[string]tools.Tool, toolsets map[string]tools.Toolset, prompts map[string]prompts.Prompt, promptsets map[string]prompts.Promptset, opts ...func(*Server)) (chi.Router, func()) {
+func setUpServer(t *testing.T, router string, tools map[string]tools.Tool, toolsets map[string]tools.Toolset, promptsMap map[string]prompts.Prompt, promptsets map[string]prompts.Promptset, opts ...func(*Server)) (chi.Router, func()) {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	testLogger, err := log.NewStdLogger(os.Stdout, os.Stderr, "info")
@@ -59,13 +59,22 @@ func setUpServer(t *testing.T, router string, tools map[string]tools.Tool, tools
 
 	sseManager := newSseManager(ctx)
 
-	primitiveManager := primitives.NewPrimitiveManager(nil, nil, nil, tools, toolsets, prompts, promptsets)
+	// servers always have a default promptset, even without prompts
+	if _, ok := promptsets[""]; !ok {
+		withDefault := map[string]prompts.Promptset{"": {}}
+		for name, ps := range promptsets {
+			withDefault[name] = ps
+		}
+		promptsets = withDefault
+	}
+	primitiveManager := primitives.NewPrimitiveManager(nil, nil, nil, tools, toolsets, promptsMap, promptsets, nil)
 
 	server := Server{
 		version:         testutils.MockVersionString,
 		logger:          testLogger,
 		instrumentation: instrumentation,
 		sseManager:      sseManager,
+		streamable:      newStreamableManager(ctx),
 		PrimitiveMgr:    primitiveManager,
 	}
 	for _, opt := range opts {
@@ -0,0 +1,110 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+)
+
+func TestNegotiateEncoding(t *testing.T) {
+	tcs := []struct {
+		desc           string
+		acceptEncoding string
+		want           string
+	}{
+		{desc: "none", acceptEncoding: "", want: ""},
+		{desc: "gzip", acceptEncoding: "gzip", want: "gzip"},
+		{desc: "brotli", acceptEncoding: "br", want: "br"},
+		{desc: "brotli preferred", acceptEncoding: "gzip, br", want: "br"},
+		{desc: "quality", acceptEncoding: "gzip;q=1.0, br;q=0.9", want: "gzip"},
+		{desc: "quality brotli", acceptEncoding: "gzip;q=0.9, br;q=1.0", want: "br"},
+		{desc: "refused", acceptEncoding: "br;q=0, gzip", want: "gzip"},
+		{desc: "wildcard", acceptEncoding: "*", want: "br"},
+		{desc: "wildcard refused", acceptEncoding: "gzip, *;q=0", want: "gzip"},
+		{desc: "unsupported", acceptEncoding: "deflate, identity", want: ""},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := negotiateEncoding(tc.acceptEncoding); got != tc.want {
+				t.Fatalf("unexpected encoding: got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCompress(t *testing.T) {
+	const body = `{"result":"compressed"}`
+	handler := compress(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, body)
+	}))
+	tcs := []struct {
+		acceptEncoding string
+		decode         func(io.Reader) (io.Reader, error)
+	}{
+		{acceptEncoding: "", decode: func(r io.Reader) (io.Reader, error) { return r, nil }},
+		{acceptEncoding: "gzip", decode: func(r io.Reader) (io.Reader, error) { return gzip.NewReader(r) }},
+		{acceptEncoding: "br", decode: func(r io.Reader) (io.Reader, error) { return brotli.NewReader(r), nil }},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.acceptEncoding, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Accept-Encoding", tc.acceptEncoding)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if got := rec.Header().Get("Content-Encoding"); got != tc.acceptEncoding {
+				t.Fatalf("unexpected Content-Encoding: got %q, want %q", got, tc.acceptEncoding)
+			}
+			if got := rec.Header().Get("Vary"); got != "Accept-Encoding" {
+				t.Fatalf("unexpected Vary: got %q", got)
+			}
+			r, err := tc.decode(rec.Body)
+			if err != nil {
+				t.Fatalf("unable to decode body: %s", err)
+			}
+			got, err := io.ReadAll(r)
+			if err != nil {
+				t.Fatalf("unable to read body: %s", err)
+			}
+			if string(got) != body {
+				t.Fatalf("unexpected body: got %q, want %q", got, body)
+			}
+		})
+	}
+}
+
+func TestCompressSkipsEventStreams(t *testing.T) {
+	handler := compress(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = io.WriteString(w, "data: {}\n\n")
+	}))
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept-Encoding", "br, gzip")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if got := rec.Header().Get("Content-Encoding"); got != "" {
+		t.Fatalf("unexpected Content-Encoding: got %q", got)
+	}
+	if got := rec.Body.String(); got != "data: {}\n\n" {
+		t.Fatalf("unexpected body: got %q", got)
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	yaml "github.com/goccy/go-yaml"
 	"github.com/goccy/go-yaml/ast"
@@ -28,12 +29,16 @@ import (
 	"github.com/googleapis/mcp-toolbox/internal/auth"
 	"github.com/googleapis/mcp-toolbox/internal/auth/generic"
 	"github.com/googleapis/mcp-toolbox/internal/auth/google"
+	"github.com/googleapis/mcp-toolbox/internal/auth/oidc"
 	"github.com/googleapis/mcp-toolbox/internal/embeddingmodels"
 	"github.com/googleapis/mcp-toolbox/internal/embeddingmodels/gemini"
+	"github.com/googleapis/mcp-toolbox/internal/embeddingmodels/openai"
 	"github.com/googleapis/mcp-toolbox/internal/prompts"
+	"github.com/googleapis/mcp-toolbox/internal/resources"
 	"github.com/googleapis/mcp-toolbox/internal/sources"
 	"github.com/googleapis/mcp-toolbox/internal/tools"
 	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
 )
 
 type ServerConfig struct {
@@ -43,12 +48,25 @@ type ServerConfig struct {
 	Address string
 	// Port is the port the server will listen on.
 	Port int
+	// GRPCPort is the port the gRPC API is served on. 0 disables it.
+	GRPCPort int
 	// CertFile is the path to tls certificate file
 	CertFile string
 	// KeyFile is the path to TLS key file
 	KeyFile string
+	// TLSCipherSuites are the names of the TLS cipher suites the server
+	// allows. Empty allows the defaults of Go.
+	TLSCipherSuites []string
+	// TLSFIPS restricts TLS to the FIPS approved cipher suites, overriding
+	// TLSCipherSuites.
+	TLSFIPS bool
 	// SourceConfigs defines what sources of data are available for tools.
 	SourceConfigs SourceConfigs
+	// CurrentSources are the sources of the running server when its
+	// configuration is reloaded. Sources whose config is unchanged are
+	// carried over instead of initialized again, keeping their connection
+	// pools warm.
+	CurrentSources map[string]sources.Source
 	// AuthServiceConfigs defines what sources of authentication are available for tools.
 	AuthServiceConfigs AuthServiceConfigs
 	// EmbeddingModelConfigs defines a models used to embed parameters.
@@ -61,6 +79,16 @@ type ServerConfig struct {
 	PromptConfigs PromptConfigs
 	// PromptsetConfigs defines what prompts are available
 	PromptsetConfigs PromptsetConfigs
+	// ResourceConfigs defines what static resources are available
+	ResourceConfigs ResourceConfigs
+	// JobConfigs defines the jobs invoking tools on a schedule.
+	JobConfigs JobConfigs
+	// SchemaToolsConfigs defines the tools generated from the tables of
+	// sources when they are initialized.
+	SchemaToolsConfigs SchemaToolsConfigs
+	// DbtToolsConfigs defines the tools generated from the models and
+	// analyses of dbt projects when sources are initialized.
+	DbtToolsConfigs DbtToolsConfigs
 	// IgnoreUnknownTools logs warnings and skips unknown/unsupported tool types instead of failing to start.
 	IgnoreUnknownTools bool
 	// LoggingFormat defines whether structured loggings are used.
@@ -97,10 +125,139 @@ type ServerConfig struct {
 	UserAgentMetadata []string
 	// PollInterval sets the polling frequency for configuration file updates.
 	PollInterval int
+	// ConfigRefreshInterval is how often the remote configuration files,
+	// gs:// and https:// URLs, are checked for changes. Zero disables it.
+	ConfigRefreshInterval time.Duration
 	// HttpMaxRequestBytes caps MCP HTTP request bodies. Zero uses the default.
 	HttpMaxRequestBytes int64
+	// BatchParallelism is the number of the invocations of a batch run at
+	// once. Zero uses the default.
+	BatchParallelism int
 	// EnableDraftSpecs allow users to opt-in and test upcoming draft MCP specs.
 	EnableDraftSpecs bool
+	// ShutdownTimeout is how long shutdown waits for in-flight invocations.
+	ShutdownTimeout time.Duration
+	// SecretRefreshInterval is how often the secrets referenced by the
+	// source configs are resolved again. 0 disables the refresh.
+	SecretRefreshInterval time.Duration
+	// PrewarmSources names the sources whose connections are established
+	// and checked at startup, "*" for every source that supports pings.
+	// Startup fails if any of them can't be reached.
+	PrewarmSources []string
+	// SourcePingInterval is how often the sources that support pings are
+	// pinged in the background. 0 disables the pings.
+	SourcePingInterval time.Duration
+	// InvocationQueueDepth bounds the tool invocations processed at once by
+	// the stdio transport. Zero disables the bound.
+	InvocationQueueDepth int
+	// HTTPInvocationQueue applies InvocationQueueDepth to HTTP transports.
+	HTTPInvocationQueue bool
+	// CacheBackend enables caching the results of read-only tools. Empty
+	// disables caching.
+	CacheBackend string
+	// MemcachedAddrs are the Memcached servers of the memcached cache backend.
+	MemcachedAddrs []string
+	// RedisURL is the Redis server of the redis cache backend.
+	RedisURL string
+	// CacheTTL is how long tool results are cached.
+	CacheTTL time.Duration
+	// AuditLog writes an audit record of every tool invocation to a sink,
+	// "stdout", "file" or "cloud-logging". Empty disables auditing.
+	AuditLog string
+	// AuditLogFile is the file of the file audit log sink.
+	AuditLogFile string
+	// AuditLogProject is the Google Cloud project of the cloud-logging audit
+	// log sink.
+	AuditLogProject string
+	// AuditRedactParams are the names of the parameters whose values are
+	// redacted from audit records.
+	AuditRedactParams []string
+	// ApprovalURL is the endpoint approving the invocations of the tools
+	// requiring approval.
+	ApprovalURL string
+	// ApprovalSecret verifies the tokens of the approvals of ApprovalURL.
+	ApprovalSecret string
+	// ApprovalTimeout is how long an invocation waits for its approval
+	// before it is rejected.
+	ApprovalTimeout time.Duration
+	// SummarizerURL is the endpoint summarizing the oversized results of
+	// the tools with oversizedResponse: summarize.
+	SummarizerURL string
+	// SummarizerTimeout is how long an invocation waits for the summary of
+	// its result.
+	SummarizerTimeout time.Duration
+	// ResultPageSize is the number of rows of the first page of a tool
+	// result, the rest being read with its next page token. Zero returns
+	// whole results.
+	ResultPageSize int
+	// ListPageSize is the number of tools of a page of tool listings. Zero
+	// lists every tool at once.
+	ListPageSize int
+	// AdminToken authenticates administrative requests, such as pinning the
+	// variant of a tool. Empty disables them.
+	AdminToken string
+	// ResponseSigningKey is the HMAC-SHA256 key signing the bodies of tool
+	// invocation responses. Empty disables signing.
+	ResponseSigningKey string
+	// TrustProxy takes the source address of requests, checked against the
+	// allowed CIDR blocks of tools, from their X-Forwarded-For header.
+	TrustProxy bool
+	// TrustedProxyHops is the number of trusted proxies in front of the
+	// server. The source address is the entry of the X-Forwarded-For header
+	// this many entries from its right.
+	TrustedProxyHops int
+	// AuthBackend authenticates all requests to the server. "iap" requires
+	// a Cloud IAP JWT assertion. Empty leaves requests unauthenticated.
+	AuthBackend string
+	// IAPAudience is the expected audience of IAP JWT assertions. Empty
+	// accepts any audience.
+	IAPAudience string
+	// AccessPolicyConfigs grant API keys and auth service claims the use of
+	// toolsets and tools.
+	AccessPolicyConfigs AccessPolicyConfigs
+	// AccessDenyByDefault denies the use of the toolsets and tools no
+	// access policy of a client grants, instead of those of other clients.
+	AccessDenyByDefault bool
+	// NamespaceConfigs isolate the tools and toolsets of each namespace from
+	// the clients of the others.
+	NamespaceConfigs NamespaceConfigs
+	// AsyncBackend runs asynchronous tool invocations, "local" or
+	// "cloud-tasks".
+	AsyncBackend string
+	// CloudTasksQueue is the resource name of the Cloud Tasks queue of the
+	// cloud-tasks async backend.
+	CloudTasksQueue string
+	// CloudTasksServiceAccount is the service account signing the OIDC
+	// tokens of Cloud Tasks tasks, verified by the task handler.
+	CloudTasksServiceAccount string
+	// AsyncResultsBucket is the Cloud Storage bucket storing the results of
+	// asynchronous invocations. Empty keeps them in memory.
+	AsyncResultsBucket string
+	// AsyncCallbackHosts are the hosts the callback URLs of asynchronous
+	// invocations may target. Empty disables callbacks.
+	AsyncCallbackHosts []string
+	// UpdateSchemaSnapshots overwrites the schema snapshots of the sources
+	// with their current schemas.
+	UpdateSchemaSnapshots bool
+	// SessionPingInterval is how often the server pings SSE sessions to
+	// detect dead clients. Zero disables pinging.
+	SessionPingInterval time.Duration
+	// SessionMaxMissedPings is how many pings in a row a session may leave
+	// unanswered before it is reclaimed.
+	SessionMaxMissedPings int
+	// ParamCoercion is the coercion mode of the parameter values of tools,
+	// unless a tool sets its own.
+	ParamCoercion CoercionMode
+	// DefaultLocale is the locale of tool descriptions served when neither
+	// the request nor the toolset selects one.
+	DefaultLocale string
+	// DefaultRateLimit limits the invocations of the tools that don't set
+	// the limits of their own rateLimit.
+	DefaultRateLimit tools.RateLimit
+	// RedactErrorDetails replaces the text of invocation errors returned to
+	// clients with the message of their error code, withholding the errors
+	// of drivers.
+	RedactErrorDetails bool
 }
 
 type logFormat string
@@ -129,6 +286,34 @@ func (f *logFormat) Type() string {
 	return "logFormat"
 }
 
+// CoercionMode is the coercion mode of parameter values, "strict" or
+// "lenient".
+type CoercionMode string
+
+// String is used by both fmt.Print and by Cobra in help text
+func (c *CoercionMode) String() string {
+	if string(*c) != "" {
+		return strings.ToLower(string(*c))
+	}
+	return parameters.CoercionStrict
+}
+
+// validate coercion mode flag
+func (c *CoercionMode) Set(v string) error {
+	switch strings.ToLower(v) {
+	case parameters.CoercionStrict, parameters.CoercionLenient:
+		*c = CoercionMode(strings.ToLower(v))
+		return nil
+	default:
+		return fmt.Errorf(`parameter coercion must be one of "strict", or "lenient"`)
+	}
+}
+
+// Type is used in Cobra help text
+func (c *CoercionMode) Type() string {
+	return "coercionMode"
+}
+
 type StringLevel string
 
 // String is used by both fmt.Print and by Cobra in help text
@@ -162,6 +347,39 @@ type ToolConfigs map[string]tools.ToolConfig
 type ToolsetConfigs map[string]tools.ToolsetConfig
 type PromptConfigs map[string]prompts.PromptConfig
 type PromptsetConfigs map[string]prompts.PromptsetConfig
+type ResourceConfigs map[string]resources.ResourceConfig
+
+// forEachDocOfKind calls fn with the name and the fields, but its kind, of
+// each document of raw of the given kind. Documents of other kinds and
+// malformed documents, reported by UnmarshalPrimitiveConfig, are skipped.
+func forEachDocOfKind(ctx context.Context, raw []byte, kind string, fn func(name string, resource map[string]any) error) error {
+	file, err := parser.ParseBytes(raw, 0)
+	if err != nil {
+		return fmt.Errorf("unable to parse YAML: %s", yaml.FormatError(err, false, false))
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(raw))
+	for index, doc := range file.Docs {
+		if doc == nil || doc.Body == nil {
+			continue
+		}
+		var resource map[string]any
+		if err := decoder.DecodeFromNodeContext(ctx, doc.Body, &resource); err != nil {
+			continue
+		}
+		name, ok := resource["name"].(string)
+		if k, _ := resource["kind"].(string); k != kind || !ok {
+			continue
+		}
+		delete(resource, "kind")
+		if err := fn(name, resource); err != nil {
+			if len(file.Docs) > 1 {
+				return fmt.Errorf("document %d: error unmarshaling %s %q: %w", index+1, kind, name, err)
+			}
+			return fmt.Errorf("error unmarshaling %s: %w", kind, err)
+		}
+	}
+	return nil
+}
 
 func UnmarshalPrimitiveConfig(ctx context.Context, raw []byte) (SourceConfigs, AuthServiceConfigs, EmbeddingModelConfigs, ToolConfigs, ToolsetConfigs, PromptConfigs, error) {
 	// prepare configs map
@@ -171,6 +389,7 @@ func UnmarshalPrimitiveConfig(ctx context.Context, raw []byte) (SourceConfigs, A
 	var toolConfigs ToolConfigs
 	var toolsetConfigs ToolsetConfigs
 	var promptConfigs PromptConfigs
+	var namespaces NamespaceConfigs
 	// promptset configs is not yet supported
 
 	file, err := parser.ParseBytes(raw, 0)
@@ -179,6 +398,33 @@ func UnmarshalPrimitiveConfig(ctx context.Context, raw []byte) (SourceConfigs, A
 	}
 
 	decoder := yaml.NewDecoder(bytes.NewReader(raw))
+	orderedDecoder := yaml.NewDecoder(bytes.NewReader(raw), yaml.UseOrderedMap())
+
+	// Parameter definitions are collected first, so that tools can reference
+	// definitions declared further down the file. Malformed documents are
+	// reported by the main pass.
+	paramDefs := make(parameterDefs)
+	for index, doc := range file.Docs {
+		if doc == nil || doc.Body == nil {
+			continue
+		}
+		var resource map[string]any
+		if err := decoder.DecodeFromNodeContext(ctx, doc.Body, &resource); err != nil {
+			continue
+		}
+		name, ok := resource["name"].(string)
+		if kind, _ := resource["kind"].(string); kind != parameterDefKind || !ok {
+			continue
+		}
+		delete(resource, "kind")
+		if err := paramDefs.add(name, resource); err != nil {
+			if len(file.Docs) > 1 {
+				return nil, nil, nil, nil, nil, nil, fmt.Errorf("document %d: error unmarshaling %s %q: %w", index+1, parameterDefKind, name, err)
+			}
+			return nil, nil, nil, nil, nil, nil, fmt.Errorf("error unmarshaling %s: %w", parameterDefKind, err)
+		}
+	}
+
 	for index, doc := range file.Docs {
 		if doc == nil || doc.Body == nil {
 			continue
@@ -237,8 +483,42 @@ func UnmarshalPrimitiveConfig(ctx context.Context, raw []byte) (SourceConfigs, A
 				authServiceConfigs = make(AuthServiceConfigs)
 			}
 			authServiceConfigs[name] = c
+		case parameterDefKind:
+			// collected and validated above
+		case jobKind:
+			// collected by UnmarshalJobConfigs
+		case schemaToolsKind:
+			// collected by UnmarshalSchemaToolsConfigs
+		case dbtToolsKind:
+			// collected by UnmarshalDbtToolsConfigs
+		case accessPolicyKind:
+			// collected by UnmarshalAccessPolicyConfigs
+		case resourceKind:
+			// collected by UnmarshalResourceConfigs
+		case namespaceKind:
+			c, err := unmarshalYAMLNamespaceConfig(ctx, name, resource)
+			if err == nil && namespaces[name].Name != "" {
+				err = fmt.Errorf("namespace %q is defined more than once", name)
+			}
+			if err != nil {
+				if len(file.Docs) > 1 {
+					return nil, nil, nil, nil, nil, nil, fmt.Errorf("document %d: error unmarshaling %s %q: %w", docIndex, kind, name, err)
+				}
+				return nil, nil, nil, nil, nil, nil, fmt.Errorf("error unmarshaling %s: %w", kind, err)
+			}
+			if namespaces == nil {
+				namespaces = make(NamespaceConfigs)
+			}
+			namespaces[name] = c
 		case "tool":
-			c, err := UnmarshalYAMLToolConfig(ctx, name, resource)
+			err := keepKeyOrder(ctx, orderedDecoder, doc.Body, resource)
+			if err == nil {
+				err = resolveParameterRefs(resource, paramDefs)
+			}
+			var c tools.ToolConfig
+			if err == nil {
+				c, err = UnmarshalYAMLToolConfig(ctx, name, resource)
+			}
 			if err != nil {
 				if len(file.Docs) > 1 {
 					return nil, nil, nil, nil, nil, nil, fmt.Errorf("document %d: error unmarshaling %s %q: %w", docIndex, kind, name, err)
@@ -252,6 +532,31 @@ func UnmarshalPrimitiveConfig(ctx context.Context, raw []byte) (SourceConfigs, A
 				toolConfigs = make(ToolConfigs)
 			}
 			toolConfigs[name] = c
+		case openapiToolsKind:
+			generated, err := generateOpenAPITools(ctx, name, resource)
+			if err != nil {
+				if len(file.Docs) > 1 {
+					return nil, nil, nil, nil, nil, nil, fmt.Errorf("document %d: error unmarshaling %s %q: %w", docIndex, kind, name, err)
+				}
+				return nil, nil, nil, nil, nil, nil, fmt.Errorf("error unmarshaling %s: %w", kind, err)
+			}
+			for _, g := range generated {
+				// generated tools are reported under their operationId
+				c, err := UnmarshalYAMLToolConfig(ctx, g.name, g.resource)
+				if err != nil {
+					if len(file.Docs) > 1 {
+						return nil, nil, nil, nil, nil, nil, fmt.Errorf("document %d: error unmarshaling tool %q: %w", docIndex, g.name, err)
+					}
+					return nil, nil, nil, nil, nil, nil, fmt.Errorf("error unmarshaling tool %q: %w", g.name, err)
+				}
+				if c == nil {
+					continue
+				}
+				if toolConfigs == nil {
+					toolConfigs = make(ToolConfigs)
+				}
+				toolConfigs[g.name] = c
+			}
 		case "toolset":
 			c, err := UnmarshalYAMLToolsetConfig(ctx, name, resource)
 			if err != nil {
@@ -295,6 +600,9 @@ func UnmarshalPrimitiveConfig(ctx context.Context, raw []byte) (SourceConfigs, A
 			return nil, nil, nil, nil, nil, nil, fmt.Errorf("invalid kind %s", kind)
 		}
 	}
+	if err := namespaces.verify(toolConfigs); err != nil {
+		return nil, nil, nil, nil, nil, nil, err
+	}
 	return sourceConfigs, authServiceConfigs, embeddingModelConfigs, toolConfigs, toolsetConfigs, promptConfigs, nil
 }
 
@@ -303,7 +611,7 @@ func UnmarshalYAMLSourceConfig(ctx context.Context, name string, r map[string]an
 	if !ok {
 		return nil, fmt.Errorf("missing 'type' field or it is not a string")
 	}
-	dec, err := util.NewStrictDecoder(r)
+	dec, err := util.NewStrictDecoderAt(r, fmt.Sprintf("sources[%s]", name))
 	if err != nil {
 		return nil, fmt.Errorf("error creating decoder: %w", err)
 	}
@@ -319,7 +627,7 @@ func UnmarshalYAMLAuthServiceConfig(ctx context.Context, name string, r map[stri
 	if !ok {
 		return nil, fmt.Errorf("missing 'type' field or it is not a string")
 	}
-	dec, err := util.NewStrictDecoder(r)
+	dec, err := util.NewStrictDecoderAt(r, fmt.Sprintf("authServices[%s]", name))
 	if err != nil {
 		return nil, fmt.Errorf("error creating decoder: %s", err)
 	}
@@ -359,6 +667,12 @@ func UnmarshalYAMLAuthServiceConfig(ctx context.Context, name string, r map[stri
 			}
 		}
 		return actual, nil
+	case oidc.AuthServiceType:
+		actual := oidc.Config{Name: name}
+		if err := dec.DecodeContext(ctx, &actual); err != nil {
+			return nil, fmt.Errorf("unable to parse as %s: %w", name, err)
+		}
+		return actual, nil
 	default:
 		return nil, fmt.Errorf("%s is not a valid type of auth service", resourceType)
 	}
@@ -369,18 +683,46 @@ func UnmarshalYAMLEmbeddingModelConfig(ctx context.Context, name string, r map[s
 	if !ok {
 		return nil, fmt.Errorf("missing 'type' field or it is not a string")
 	}
-	if resourceType != gemini.EmbeddingModelType {
-		return nil, fmt.Errorf("%s is not a valid type of embedding model", resourceType)
-	}
-	dec, err := util.NewStrictDecoder(r)
+	dec, err := util.NewStrictDecoderAt(r, fmt.Sprintf("embeddingModels[%s]", name))
 	if err != nil {
 		return nil, fmt.Errorf("error creating decoder: %s", err)
 	}
-	actual := gemini.Config{Name: name}
-	if err := dec.DecodeContext(ctx, &actual); err != nil {
-		return nil, fmt.Errorf("unable to parse as %q: %w", name, err)
+	switch resourceType {
+	case gemini.EmbeddingModelType:
+		actual := gemini.Config{Name: name}
+		if err := dec.DecodeContext(ctx, &actual); err != nil {
+			return nil, fmt.Errorf("unable to parse as %q: %w", name, err)
+		}
+		return actual, nil
+	case openai.EmbeddingModelType:
+		actual := openai.Config{Name: name}
+		if err := dec.DecodeContext(ctx, &actual); err != nil {
+			return nil, fmt.Errorf("unable to parse as %q: %w", name, err)
+		}
+		return actual, nil
+	default:
+		return nil, fmt.Errorf("%s is not a valid type of embedding model", resourceType)
 	}
-	return actual, nil
+}
+
+// inspectedToolFields are the fields of a tool that the server reads as
+// map[string]any before the tool decodes its config.
+var inspectedToolFields = map[string]bool{"description": true, "parameters": true, "templateParameters": true}
+
+// keepKeyOrder replaces the other fields of resource with their values
+// decoded from node with ordered maps, so that tools receive the nested
+// mappings, e.g. the $sort stages of a MongoDB pipeline, in file order.
+func keepKeyOrder(ctx context.Context, decoder *yaml.Decoder, node ast.Node, resource map[string]any) error {
+	var ordered map[string]any
+	if err := decoder.DecodeFromNodeContext(ctx, node, &ordered); err != nil {
+		return err
+	}
+	for k, v := range ordered {
+		if _, ok := resource[k]; ok && !inspectedToolFields[k] {
+			resource[k] = v
+		}
+	}
+	return nil
 }
 
 func UnmarshalYAMLToolConfig(ctx context.Context, name string, r map[string]any) (tools.ToolConfig, error) {
@@ -416,6 +758,10 @@ func UnmarshalYAMLToolConfig(ctx context.Context, name string, r map[string]any)
 		}
 	}
 
+	if err := splitLocalizedDescriptions(name, r, util.DefaultLocaleFromContext(ctx)); err != nil {
+		return nil, err
+	}
+
 	// validify parameter references
 	if rawParams, ok := r["parameters"]; ok {
 		if paramsList, ok := rawParams.([]any); ok {
@@ -450,11 +796,21 @@ func UnmarshalYAMLToolConfig(ctx context.Context, name string, r map[string]any)
 						return nil, fmt.Errorf("tool %q config error: parameter %q cannot copy value from itself", name, pName)
 					}
 				}
+
+				requiredIf, _ := pMap["requiredIf"].(map[string]any)
+				for sibling := range requiredIf {
+					if !validParamNames[sibling] {
+						return nil, fmt.Errorf("tool %q config error: parameter %q (index %d) references %q in the 'requiredIf' field, which is not a defined parameter", name, pName, i, sibling)
+					}
+					if sibling == pName {
+						return nil, fmt.Errorf("tool %q config error: parameter %q cannot be required depending on itself", name, pName)
+					}
+				}
 			}
 		}
 	}
 
-	dec, err := util.NewStrictDecoder(r)
+	dec, err := util.NewStrictDecoderAt(r, fmt.Sprintf("tools[%s]", name))
 	if err != nil {
 		return nil, fmt.Errorf("error creating decoder: %s", err)
 	}
@@ -472,6 +828,85 @@ func UnmarshalYAMLToolConfig(ctx context.Context, name string, r map[string]any)
 	return toolCfg, nil
 }
 
+// splitLocalizedDescriptions replaces the descriptions of a tool and of its
+// parameters that are declared as maps of locale to text with their text in
+// the default locale, and collects all of them in the translations of the
+// tool.
+func splitLocalizedDescriptions(name string, r map[string]any, defaultLocale string) error {
+	translations := make(map[string]map[string]any)
+	translation := func(locale string) map[string]any {
+		t, ok := translations[locale]
+		if !ok {
+			t = make(map[string]any)
+			translations[locale] = t
+		}
+		return t
+	}
+
+	if rawDesc, ok := r["description"].(map[string]any); ok {
+		text, err := localizedText(rawDesc, defaultLocale)
+		if err != nil {
+			return fmt.Errorf("tool %q config error: description %w", name, err)
+		}
+		r["description"] = text
+		for locale, v := range rawDesc {
+			translation(locale)["description"] = v
+		}
+	}
+	if paramsList, ok := r["parameters"].([]any); ok {
+		for _, rawP := range paramsList {
+			pMap, ok := rawP.(map[string]any)
+			if !ok {
+				continue
+			}
+			rawDesc, ok := pMap["description"].(map[string]any)
+			if !ok {
+				continue
+			}
+			pName, _ := pMap["name"].(string)
+			text, err := localizedText(rawDesc, defaultLocale)
+			if err != nil {
+				return fmt.Errorf("tool %q config error: description of parameter %q %w", name, pName, err)
+			}
+			pMap["description"] = text
+			for locale, v := range rawDesc {
+				t := translation(locale)
+				params, _ := t["parameters"].(map[string]any)
+				if params == nil {
+					params = make(map[string]any)
+					t["parameters"] = params
+				}
+				params[pName] = v
+			}
+		}
+	}
+
+	if len(translations) == 0 {
+		return nil
+	}
+	if _, ok := r["translations"]; ok {
+		return fmt.Errorf("tool %q config error: translations cannot be combined with descriptions declared per locale", name)
+	}
+	r["translations"] = translations
+	return nil
+}
+
+// localizedText returns the text of a description declared per locale in
+// the default locale.
+func localizedText(desc map[string]any, defaultLocale string) (string, error) {
+	for locale, v := range desc {
+		if _, ok := v.(string); !ok {
+			return "", fmt.Errorf("in locale %q is not a string", locale)
+		}
+	}
+	for locale, v := range desc {
+		if strings.EqualFold(locale, defaultLocale) {
+			return v.(string), nil
+		}
+	}
+	return "", fmt.Errorf("has no text in the default locale %q", defaultLocale)
+}
+
 func UnmarshalYAMLToolsetConfig(ctx context.Context, name string, r map[string]any) (tools.ToolsetConfig, error) {
 	var toolsetConfig tools.ToolsetConfig
 	toolList, ok := r["tools"].([]any)
@@ -479,6 +914,18 @@ func UnmarshalYAMLToolsetConfig(ctx context.Context, name string, r map[string]a
 		return toolsetConfig, fmt.Errorf("tools is missing or not a list of strings: %v", r)
 	}
 	justTools := map[string]any{"tools": toolList}
+	if resourceList, ok := r["resources"]; ok {
+		if _, ok := resourceList.([]any); !ok {
+			return toolsetConfig, fmt.Errorf("resources is not a list of strings: %v", r)
+		}
+		justTools["resources"] = resourceList
+	}
+	var locale string
+	if rawLocale, ok := r["locale"]; ok {
+		if locale, ok = rawLocale.(string); !ok {
+			return toolsetConfig, fmt.Errorf("locale is not a string: %v", r)
+		}
+	}
 	dec, err := util.NewStrictDecoder(justTools)
 	if err != nil {
 		return toolsetConfig, fmt.Errorf("error creating decoder: %s", err)
@@ -487,7 +934,7 @@ func UnmarshalYAMLToolsetConfig(ctx context.Context, name string, r map[string]a
 	if err := dec.DecodeContext(ctx, &raw); err != nil {
 		return toolsetConfig, fmt.Errorf("unable to unmarshal tools: %s", err)
 	}
-	return tools.ToolsetConfig{Name: name, ToolNames: raw["tools"]}, nil
+	return tools.ToolsetConfig{Name: name, ToolNames: raw["tools"], ResourceNames: raw["resources"], Locale: locale}, nil
 }
 
 func UnmarshalYAMLPromptConfig(ctx context.Context, name string, r map[string]any) (prompts.PromptConfig, error) {
@@ -501,7 +948,7 @@ func UnmarshalYAMLPromptConfig(ctx context.Context, name string, r map[string]an
 			return nil, fmt.Errorf("invalid 'type' field for prompt %q (must be a string)", name)
 		}
 	}
-	dec, err := util.NewStrictDecoder(r)
+	dec, err := util.NewStrictDecoderAt(r, fmt.Sprintf("prompts[%s]", name))
 	if err != nil {
 		return nil, fmt.Errorf("error creating decoder: %s", err)
 	}
@@ -514,6 +961,45 @@ func UnmarshalYAMLPromptConfig(ctx context.Context, name string, r map[string]an
 	return promptCfg, nil
 }
 
+// resourceKind is the kind of the documents defining MCP resources.
+const resourceKind = "resource"
+
+// UnmarshalResourceConfigs returns the MCP resources defined in raw. The
+// other documents are left to UnmarshalPrimitiveConfig.
+func UnmarshalResourceConfigs(ctx context.Context, raw []byte) (ResourceConfigs, error) {
+	var resourceConfigs ResourceConfigs
+	err := forEachDocOfKind(ctx, raw, resourceKind, func(name string, resource map[string]any) error {
+		c, err := UnmarshalYAMLResourceConfig(ctx, name, resource)
+		if err != nil {
+			return err
+		}
+		if resourceConfigs == nil {
+			resourceConfigs = make(ResourceConfigs)
+		}
+		resourceConfigs[name] = c
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resourceConfigs, nil
+}
+
+func UnmarshalYAMLResourceConfig(ctx context.Context, name string, r map[string]any) (resources.ResourceConfig, error) {
+	if err := NameValidation(name); err != nil {
+		return resources.ResourceConfig{}, err
+	}
+	dec, err := util.NewStrictDecoderAt(r, fmt.Sprintf("resources[%s]", name))
+	if err != nil {
+		return resources.ResourceConfig{}, fmt.Errorf("error creating decoder: %s", err)
+	}
+	actual := resources.ResourceConfig{Name: name}
+	if err := dec.DecodeContext(ctx, &actual); err != nil {
+		return resources.ResourceConfig{}, fmt.Errorf("unable to parse as %q: %w", name, err)
+	}
+	return actual, nil
+}
+
 // Tools naming validation is added in the MCP v2025-11-25, but we'll be
 // implementing it across Toolbox
 // Tool names SHOULD be between 1 and 128 characters in length (inclusive).
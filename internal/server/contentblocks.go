@@ -0,0 +1,92 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"github.com/go-chi/render"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+)
+
+// toolUseIDHeader carries the id of the Anthropic tool_use block a tool is
+// invoked for.
+const toolUseIDHeader = "X-Tool-Use-ID"
+
+// responseFormat returns the response format of a tool, which is empty for
+// the default JSON string.
+func responseFormat(tool tools.Tool) string {
+	if c, ok := tool.ToConfig().(interface{ GetResponseFormat() string }); ok {
+		return c.GetResponseFormat()
+	}
+	return ""
+}
+
+// textBlock is an Anthropic text content block.
+type textBlock struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+var _ render.Renderer = &toolResultBlock{}
+
+// toolResultBlock is an Anthropic tool_result block, which can be relayed
+// as is in the next message to the model.
+type toolResultBlock struct {
+	Type      string      `json:"type"`
+	ToolUseID string      `json:"tool_use_id"`
+	Content   []textBlock `json:"content"`
+	IsError   bool        `json:"is_error,omitempty"`
+}
+
+func (b *toolResultBlock) Render(w http.ResponseWriter, r *http.Request) error {
+	render.Status(r, http.StatusOK)
+	return nil
+}
+
+// newToolResultBlock wraps each row of a result in a text content block
+// holding its JSON encoding. A result that is not a list is a single block.
+func newToolResultBlock(toolUseID string, res any, isError bool) (*toolResultBlock, error) {
+	var rows []any
+	if v := reflect.ValueOf(res); res != nil && (v.Kind() == reflect.Slice || v.Kind() == reflect.Array) && v.Type().Elem().Kind() != reflect.Uint8 {
+		for i := 0; i < v.Len(); i++ {
+			rows = append(rows, v.Index(i).Interface())
+		}
+	} else {
+		rows = []any{res}
+	}
+
+	content := make([]textBlock, 0, len(rows))
+	for _, row := range rows {
+		text, ok := row.(string)
+		if !ok {
+			b, err := json.Marshal(row)
+			if err != nil {
+				return nil, fmt.Errorf("unable to marshal result: %w", err)
+			}
+			text = string(b)
+		}
+		content = append(content, textBlock{Type: "text", Text: text})
+	}
+	return &toolResultBlock{
+		Type:      "tool_result",
+		ToolUseID: toolUseID,
+		Content:   content,
+		IsError:   isError,
+	}, nil
+}
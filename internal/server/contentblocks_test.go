@@ -0,0 +1,116 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/internal/testutils"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+// contentBlocksTool is a mock tool formatting its results as Anthropic
+// content blocks.
+type contentBlocksTool struct {
+	testutils.MockTool
+	fail bool
+}
+
+func (t contentBlocksTool) ToConfig() tools.ToolConfig {
+	return localizedConfig{tools.ConfigBase{Name: t.Name, ResponseFormat: tools.ResponseFormatAnthropicContentBlocks}}
+}
+
+func (t contentBlocksTool) Invoke(context.Context, tools.SourceProvider, parameters.ParamValues, tools.AccessToken) (any, util.ToolboxError) {
+	if t.fail {
+		return nil, util.NewAgentError("invalid query", nil)
+	}
+	return []any{map[string]any{"id": 1}, map[string]any{"id": 2}}, nil
+}
+
+func TestAnthropicContentBlocks(t *testing.T) {
+	toolsMap := map[string]tools.Tool{
+		"rows":    contentBlocksTool{MockTool: testutils.NewMockTool("rows", "", nil, false, false)},
+		"failing": contentBlocksTool{MockTool: testutils.NewMockTool("failing", "", nil, false, false), fail: true},
+	}
+	r, shutdown := setUpServer(t, "api", toolsMap, map[string]tools.Toolset{}, nil, nil)
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	tcs := []struct {
+		desc       string
+		tool       string
+		header     map[string]string
+		wantStatus int
+		want       *toolResultBlock
+	}{
+		{
+			desc:       "rows",
+			tool:       "rows",
+			header:     map[string]string{toolUseIDHeader: "toolu_01"},
+			wantStatus: http.StatusOK,
+			want: &toolResultBlock{
+				Type:      "tool_result",
+				ToolUseID: "toolu_01",
+				Content:   []textBlock{{Type: "text", Text: `{"id":1}`}, {Type: "text", Text: `{"id":2}`}},
+			},
+		},
+		{
+			desc:       "agent error",
+			tool:       "failing",
+			header:     map[string]string{toolUseIDHeader: "toolu_02"},
+			wantStatus: http.StatusOK,
+			want: &toolResultBlock{
+				Type:      "tool_result",
+				ToolUseID: "toolu_02",
+				Content:   []textBlock{{Type: "text", Text: "invalid query"}},
+				IsError:   true,
+			},
+		},
+		{
+			desc:       "missing tool use id",
+			tool:       "rows",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			resp, body, err := runRequest(ts, http.MethodPost, "/tool/"+tc.tool+"/invoke", strings.NewReader(`{}`), tc.header)
+			if err != nil {
+				t.Fatalf("unexpected error during request: %s", err)
+			}
+			if resp.StatusCode != tc.wantStatus {
+				t.Fatalf("unexpected status: got %d, want %d, body: %s", resp.StatusCode, tc.wantStatus, body)
+			}
+			if tc.want == nil {
+				return
+			}
+			var got toolResultBlock
+			if err := json.Unmarshal(body, &got); err != nil {
+				t.Fatalf("unable to decode response: %s", err)
+			}
+			if diff := cmp.Diff(*tc.want, got); diff != "" {
+				t.Errorf("unexpected tool result (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,304 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/googleapis/mcp-toolbox/internal/sources"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+)
+
+// dbtToolsKind is the kind of the documents generating tools from the models
+// and analyses of a dbt project when the server starts.
+const dbtToolsKind = "dbtTools"
+
+// DbtToolsConfig selects the nodes of a dbt manifest to generate tools for.
+// Each model, seed and snapshot gets a list_<name> tool paging through its
+// relation, and each compiled analysis gets a tool named after it running its
+// query. Descriptions are taken from the documentation of the nodes.
+type DbtToolsConfig struct {
+	Name   string `yaml:"name" validate:"required"`
+	Source string `yaml:"source" validate:"required"`
+	// Manifest is the path of the manifest.json written by dbt.
+	Manifest string `yaml:"manifest" validate:"required"`
+	// Select lists the nodes to generate tools for, by name or unique_id.
+	// Every enabled node is selected when empty.
+	Select []string `yaml:"select"`
+	// Tags restricts the nodes to the ones with at least one of the tags.
+	Tags []string `yaml:"tags"`
+	// MaxLimit caps the rows a list tool returns at once. Defaults to 100.
+	MaxLimit int `yaml:"maxLimit" validate:"gte=0"`
+}
+
+type DbtToolsConfigs map[string]DbtToolsConfig
+
+// UnmarshalDbtToolsConfigs returns the dbt tools defined in raw. The other
+// documents are left to UnmarshalPrimitiveConfig.
+func UnmarshalDbtToolsConfigs(ctx context.Context, raw []byte) (DbtToolsConfigs, error) {
+	var configs DbtToolsConfigs
+	err := forEachDocOfKind(ctx, raw, dbtToolsKind, func(name string, resource map[string]any) error {
+		c := DbtToolsConfig{Name: name}
+		dec, err := util.NewStrictDecoderAt(resource, fmt.Sprintf("dbtTools[%s]", name))
+		if err != nil {
+			return fmt.Errorf("error creating decoder: %w", err)
+		}
+		if err := dec.DecodeContext(ctx, &c); err != nil {
+			return err
+		}
+		if _, ok := configs[name]; ok {
+			return fmt.Errorf("dbtTools %q is defined more than once", name)
+		}
+		if configs == nil {
+			configs = make(DbtToolsConfigs)
+		}
+		configs[name] = c
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return configs, nil
+}
+
+// dbtManifest is the part of a dbt manifest.json that tools are generated
+// from.
+type dbtManifest struct {
+	Metadata struct {
+		AdapterType string `json:"adapter_type"`
+	} `json:"metadata"`
+	Nodes map[string]dbtNode `json:"nodes"`
+}
+
+type dbtNode struct {
+	UniqueID     string               `json:"unique_id"`
+	ResourceType string               `json:"resource_type"`
+	Name         string               `json:"name"`
+	Description  string               `json:"description"`
+	RelationName string               `json:"relation_name"`
+	CompiledCode string               `json:"compiled_code"`
+	Tags         []string             `json:"tags"`
+	Columns      map[string]dbtColumn `json:"columns"`
+	Config       struct {
+		Enabled      *bool  `json:"enabled"`
+		Materialized string `json:"materialized"`
+	} `json:"config"`
+}
+
+type dbtColumn struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	DataType    string `json:"data_type"`
+}
+
+// dbtAdapterTools are the tool types of the dbt adapters whose SQL the
+// dialects write.
+var dbtAdapterTools = map[string]string{
+	"postgres":  postgresDialect.toolType,
+	"alloydb":   postgresDialect.toolType,
+	"mysql":     mysqlDialect.toolType,
+	"sqlserver": sqlServerDialect.toolType,
+	"sqlite":    sqliteDialect.toolType,
+	"bigquery":  bigqueryDialect.toolType,
+}
+
+// queryable reports whether the node is a relation that list tools can read.
+func (n dbtNode) queryable() bool {
+	switch n.ResourceType {
+	case "model", "seed", "snapshot":
+		return n.RelationName != ""
+	}
+	return false
+}
+
+func (n dbtNode) enabled() bool {
+	return n.Config.Enabled == nil || *n.Config.Enabled
+}
+
+// generateDbtTools reads the manifest of each config and returns the raw
+// configs of the tools generated for its nodes, in the same form as
+// hand-written tools.
+func generateDbtTools(ctx context.Context, configs DbtToolsConfigs, sourcesMap map[string]sources.Source) ([]generatedTool, error) {
+	var generated []generatedTool
+	for _, name := range slices.Sorted(maps.Keys(configs)) {
+		cfg := configs[name]
+		s, ok := sourcesMap[cfg.Source]
+		if !ok {
+			return nil, fmt.Errorf("dbtTools %q: source %q not found", name, cfg.Source)
+		}
+		dialect, ok := sqlDialects[s.SourceType()]
+		if !ok {
+			return nil, fmt.Errorf("dbtTools %q: tools cannot be generated for sources of type %q", name, s.SourceType())
+		}
+		raw, err := os.ReadFile(cfg.Manifest)
+		if err != nil {
+			return nil, fmt.Errorf("dbtTools %q: unable to read manifest: %w", name, err)
+		}
+		var manifest dbtManifest
+		if err := json.Unmarshal(raw, &manifest); err != nil {
+			return nil, fmt.Errorf("dbtTools %q: unable to parse manifest %q: %w", name, cfg.Manifest, err)
+		}
+		tools, err := dbtTools(cfg, dialect, &manifest)
+		if err != nil {
+			return nil, fmt.Errorf("dbtTools %q: %w", name, err)
+		}
+		generated = append(generated, tools...)
+	}
+	return generated, nil
+}
+
+// dbtTools returns the raw configs of the tools of the nodes of the manifest
+// selected by cfg.
+func dbtTools(cfg DbtToolsConfig, dialect sqlDialect, manifest *dbtManifest) ([]generatedTool, error) {
+	adapter := manifest.Metadata.AdapterType
+	if toolType, ok := dbtAdapterTools[adapter]; ok && toolType != dialect.toolType {
+		return nil, fmt.Errorf("manifest was compiled for %q, which does not match source %q", adapter, cfg.Source)
+	}
+	nodes, err := selectDbtNodes(cfg, manifest)
+	if err != nil {
+		return nil, err
+	}
+	maxLimit := cfg.MaxLimit
+	if maxLimit == 0 {
+		maxLimit = defaultSchemaToolsMaxLimit
+	}
+
+	var generated []generatedTool
+	for _, n := range nodes {
+		suffix := strings.Trim(toolNamePart.ReplaceAllString(n.Name, "_"), "_")
+		if n.ResourceType == "analysis" {
+			generated = append(generated, generatedTool{
+				name: suffix,
+				resource: map[string]any{
+					"type":        dialect.toolType,
+					"source":      cfg.Source,
+					"description": dbtDescription(fmt.Sprintf("Runs the %s analysis.", n.Name), n),
+					"statement":   strings.TrimSuffix(strings.TrimSpace(n.CompiledCode), ";"),
+					"annotations": map[string]any{"readOnlyHint": true},
+				},
+			})
+			continue
+		}
+		generated = append(generated, generatedTool{
+			name: fmt.Sprintf("list_%s", suffix),
+			resource: map[string]any{
+				"type":        dialect.toolType,
+				"source":      cfg.Source,
+				"description": dbtDescription(fmt.Sprintf("Lists the rows of the %s %s, a page at a time.", n.Name, n.ResourceType), n),
+				"statement": fmt.Sprintf("SELECT * FROM %s %s", n.RelationName,
+					dialect.page("", dialect.placeholder(1, "limit"), dialect.placeholder(2, "offset"))),
+				"parameters": []any{
+					map[string]any{"name": "limit", "type": "integer", "description": fmt.Sprintf("The number of rows to return, at most %d.", maxLimit), "default": maxLimit, "minValue": 1, "maxValue": maxLimit},
+					map[string]any{"name": "offset", "type": "integer", "description": "The number of rows to skip.", "default": 0, "minValue": 0},
+				},
+				"annotations": map[string]any{"readOnlyHint": true},
+			},
+		})
+	}
+	for _, g := range generated {
+		g.resource["name"] = g.name
+	}
+	return generated, nil
+}
+
+// selectDbtNodes returns the nodes of the manifest selected by cfg, sorted
+// by unique_id.
+func selectDbtNodes(cfg DbtToolsConfig, manifest *dbtManifest) ([]dbtNode, error) {
+	candidate := func(n dbtNode) bool {
+		if !n.enabled() {
+			return false
+		}
+		if len(cfg.Tags) > 0 && !slices.ContainsFunc(n.Tags, func(t string) bool { return slices.Contains(cfg.Tags, t) }) {
+			return false
+		}
+		return n.queryable() || (n.ResourceType == "analysis" && n.CompiledCode != "")
+	}
+
+	var nodes []dbtNode
+	if len(cfg.Select) == 0 {
+		for _, id := range slices.Sorted(maps.Keys(manifest.Nodes)) {
+			if n := manifest.Nodes[id]; candidate(n) {
+				nodes = append(nodes, n)
+			}
+		}
+		if len(nodes) == 0 {
+			return nil, fmt.Errorf("manifest %q has no models or compiled analyses to generate tools for", cfg.Manifest)
+		}
+		return nodes, nil
+	}
+
+	for _, entry := range cfg.Select {
+		var found []dbtNode
+		for _, id := range slices.Sorted(maps.Keys(manifest.Nodes)) {
+			n := manifest.Nodes[id]
+			if id == entry || (n.Name == entry && (n.queryable() || n.ResourceType == "analysis")) {
+				found = append(found, n)
+			}
+		}
+		switch {
+		case len(found) == 0:
+			return nil, fmt.Errorf("node %q not found in manifest %q", entry, cfg.Manifest)
+		case len(found) > 1:
+			return nil, fmt.Errorf("node %q is ambiguous, select it by its unique_id", entry)
+		}
+		n := found[0]
+		switch {
+		case n.ResourceType == "analysis" && n.CompiledCode == "":
+			return nil, fmt.Errorf("analysis %q has no compiled code, run `dbt compile` first", entry)
+		case n.ResourceType != "analysis" && !n.queryable():
+			return nil, fmt.Errorf("node %q is not a relation tools can read", entry)
+		case candidate(n):
+			nodes = append(nodes, n)
+		}
+	}
+	return nodes, nil
+}
+
+// dbtDescription appends the documentation of the node and of its columns to
+// description.
+func dbtDescription(description string, n dbtNode) string {
+	var b strings.Builder
+	b.WriteString(description)
+	if n.Description != "" {
+		b.WriteString(" ")
+		b.WriteString(n.Description)
+	}
+	var columns []string
+	for _, key := range slices.Sorted(maps.Keys(n.Columns)) {
+		c := n.Columns[key]
+		if c.Description == "" && c.DataType == "" {
+			continue
+		}
+		line := "- " + c.Name
+		if c.DataType != "" {
+			line += " (" + c.DataType + ")"
+		}
+		if c.Description != "" {
+			line += ": " + c.Description
+		}
+		columns = append(columns, line)
+	}
+	if len(columns) > 0 {
+		b.WriteString("\n\nColumns:\n")
+		b.WriteString(strings.Join(columns, "\n"))
+	}
+	return b.String()
+}
@@ -0,0 +1,225 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/internal/sources"
+	"github.com/googleapis/mcp-toolbox/internal/testutils"
+)
+
+const testDbtManifest = `{
+	"metadata": {"adapter_type": "postgres"},
+	"nodes": {
+		"model.shop.orders": {
+			"unique_id": "model.shop.orders",
+			"resource_type": "model",
+			"name": "orders",
+			"description": "One row per order.",
+			"relation_name": "\"analytics\".\"marts\".\"orders\"",
+			"tags": ["agent"],
+			"columns": {
+				"order_id": {"name": "order_id", "description": "Primary key.", "data_type": "bigint"},
+				"status": {"name": "status", "description": "Fulfilment status."},
+				"raw": {"name": "raw"}
+			},
+			"config": {"enabled": true, "materialized": "table"}
+		},
+		"model.shop.stg_orders": {
+			"unique_id": "model.shop.stg_orders",
+			"resource_type": "model",
+			"name": "stg_orders",
+			"relation_name": "\"analytics\".\"staging\".\"stg_orders\"",
+			"config": {"materialized": "view"}
+		},
+		"model.shop.int_orders": {
+			"unique_id": "model.shop.int_orders",
+			"resource_type": "model",
+			"name": "int_orders",
+			"config": {"materialized": "ephemeral"}
+		},
+		"model.shop.legacy": {
+			"unique_id": "model.shop.legacy",
+			"resource_type": "model",
+			"name": "legacy",
+			"relation_name": "\"analytics\".\"marts\".\"legacy\"",
+			"config": {"enabled": false}
+		},
+		"analysis.shop.top_customers": {
+			"unique_id": "analysis.shop.top_customers",
+			"resource_type": "analysis",
+			"name": "top_customers",
+			"description": "The ten customers with the most orders.",
+			"compiled_code": "select customer_id, count(*) from \"analytics\".\"marts\".\"orders\" group by 1 order by 2 desc limit 10;\n",
+			"tags": ["agent"]
+		},
+		"analysis.shop.draft": {
+			"unique_id": "analysis.shop.draft",
+			"resource_type": "analysis",
+			"name": "draft",
+			"raw_code": "select {{ ref('orders') }}"
+		},
+		"test.shop.not_null_orders_order_id": {
+			"unique_id": "test.shop.not_null_orders_order_id",
+			"resource_type": "test",
+			"name": "not_null_orders_order_id"
+		}
+	}
+}`
+
+func parseTestDbtManifest(t *testing.T) *dbtManifest {
+	t.Helper()
+	var m dbtManifest
+	if err := json.Unmarshal([]byte(testDbtManifest), &m); err != nil {
+		t.Fatalf("unable to parse manifest: %s", err)
+	}
+	return &m
+}
+
+func TestDbtTools(t *testing.T) {
+	manifest := parseTestDbtManifest(t)
+	got, err := dbtTools(DbtToolsConfig{Name: "shop", Source: "my-pg", Manifest: "manifest.json"}, postgresDialect, manifest)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	statements := make(map[string]string)
+	for _, g := range got {
+		if g.resource["name"] != g.name || g.resource["type"] != "postgres-sql" || g.resource["source"] != "my-pg" {
+			t.Errorf("unexpected config of tool %q: %v", g.name, g.resource)
+		}
+		statements[g.name] = g.resource["statement"].(string)
+	}
+	want := map[string]string{
+		"top_customers":   `select customer_id, count(*) from "analytics"."marts"."orders" group by 1 order by 2 desc limit 10`,
+		"list_orders":     `SELECT * FROM "analytics"."marts"."orders" LIMIT $1 OFFSET $2`,
+		"list_stg_orders": `SELECT * FROM "analytics"."staging"."stg_orders" LIMIT $1 OFFSET $2`,
+	}
+	if diff := cmp.Diff(want, statements); diff != "" {
+		t.Fatalf("incorrect statements (-want +got):\n%s", diff)
+	}
+
+	wantDescription := "Lists the rows of the orders model, a page at a time. One row per order.\n\nColumns:\n- order_id (bigint): Primary key.\n- status: Fulfilment status."
+	if got := got[1].resource["description"]; got != wantDescription {
+		t.Errorf("incorrect description:\ngot  %q\nwant %q", got, wantDescription)
+	}
+}
+
+func TestDbtToolsSelection(t *testing.T) {
+	manifest := parseTestDbtManifest(t)
+	names := func(cfg DbtToolsConfig) []string {
+		t.Helper()
+		got, err := dbtTools(cfg, postgresDialect, manifest)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		var names []string
+		for _, g := range got {
+			names = append(names, g.name)
+		}
+		return names
+	}
+	if diff := cmp.Diff([]string{"top_customers", "list_orders"}, names(DbtToolsConfig{Source: "my-pg", Tags: []string{"agent"}})); diff != "" {
+		t.Errorf("incorrect tools selected by tag (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"list_stg_orders", "top_customers"}, names(DbtToolsConfig{Source: "my-pg", Select: []string{"model.shop.stg_orders", "top_customers"}})); diff != "" {
+		t.Errorf("incorrect tools selected by name (-want +got):\n%s", diff)
+	}
+
+	tcs := []struct {
+		desc    string
+		cfg     DbtToolsConfig
+		dialect sqlDialect
+		want    string
+	}{
+		{desc: "missing node", cfg: DbtToolsConfig{Select: []string{"customers"}}, dialect: postgresDialect, want: `node "customers" not found`},
+		{desc: "ephemeral model", cfg: DbtToolsConfig{Select: []string{"model.shop.int_orders"}}, dialect: postgresDialect, want: "not a relation"},
+		{desc: "uncompiled analysis", cfg: DbtToolsConfig{Select: []string{"draft"}}, dialect: postgresDialect, want: "dbt compile"},
+		{desc: "adapter mismatch", cfg: DbtToolsConfig{Source: "my-mysql"}, dialect: mysqlDialect, want: `compiled for "postgres"`},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := dbtTools(tc.cfg, tc.dialect, manifest)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("got error %v, want error containing %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestGenerateDbtTools(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "manifest.json")
+	if err := os.WriteFile(path, []byte(testDbtManifest), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	sourcesMap := map[string]sources.Source{
+		"my-pg":   &fakePostgresSource{},
+		"my-fake": &fakeSchemaSource{},
+	}
+	got, err := generateDbtTools(context.Background(), DbtToolsConfigs{"shop": {Source: "my-pg", Manifest: path, Tags: []string{"agent"}}}, sourcesMap)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("got %d tools, want 2", len(got))
+	}
+
+	tcs := []struct {
+		desc string
+		cfg  DbtToolsConfig
+		want string
+	}{
+		{desc: "missing source", cfg: DbtToolsConfig{Source: "missing", Manifest: path}, want: `source "missing" not found`},
+		{desc: "unsupported source", cfg: DbtToolsConfig{Source: "my-fake", Manifest: path}, want: `sources of type "fake"`},
+		{desc: "missing manifest", cfg: DbtToolsConfig{Source: "my-pg", Manifest: path + ".missing"}, want: "unable to read manifest"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := generateDbtTools(context.Background(), DbtToolsConfigs{"shop": tc.cfg}, sourcesMap)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("got error %v, want error containing %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestUnmarshalDbtToolsConfigs(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	in := testutils.FormatYaml(`
+	kind: dbtTools
+	name: shop
+	source: my-pg
+	manifest: target/manifest.json
+	tags:
+		- agent
+	`)
+	got, err := UnmarshalDbtToolsConfigs(ctx, in)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := DbtToolsConfigs{"shop": {Name: "shop", Source: "my-pg", Manifest: "target/manifest.json", Tags: []string{"agent"}}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect configs (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/go-chi/render"
+	"github.com/googleapis/mcp-toolbox/internal/sources/schemasnapshot"
+)
+
+// schemaDriftResponse lists the sources whose schema drifted from their
+// snapshot.
+type schemaDriftResponse struct {
+	Drift []schemasnapshot.Drift `json:"drift"`
+}
+
+// schemaDriftHandler reports the schema drift found when the sources were
+// initialized.
+func schemaDriftHandler(s *Server, w http.ResponseWriter, r *http.Request) {
+	res := schemaDriftResponse{Drift: []schemasnapshot.Drift{}}
+	for _, source := range s.PrimitiveMgr.GetSourcesMap() {
+		reporter, ok := source.(schemasnapshot.Reporter)
+		if !ok {
+			continue
+		}
+		if d := reporter.SchemaDrift(); d != nil {
+			res.Drift = append(res.Drift, *d)
+		}
+	}
+	sort.Slice(res.Drift, func(i, j int) bool { return res.Drift[i].Source < res.Drift[j].Source })
+	render.JSON(w, r, res)
+}
+
+// debugConfigResponse reports the runtime state of the configuration.
+type debugConfigResponse struct {
+	// DisabledTools are the tools disabled by an administrator.
+	DisabledTools []string `json:"disabledTools"`
+}
+
+// debugConfigHandler reports the runtime state of the configuration, which
+// resets when the server restarts.
+func debugConfigHandler(s *Server, w http.ResponseWriter, r *http.Request) {
+	render.JSON(w, r, debugConfigResponse{DisabledTools: s.PrimitiveMgr.GetDisabledTools()})
+}
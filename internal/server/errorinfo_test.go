@@ -0,0 +1,151 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/internal/testutils"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+// sqlStateError is a driver error reporting a SQLSTATE.
+type sqlStateError struct{ state string }
+
+func (e sqlStateError) Error() string    { return `relation "orders" does not exist` }
+func (e sqlStateError) SQLState() string { return e.state }
+
+// sourceErrorTool fails every invocation with the error of a source.
+type sourceErrorTool struct {
+	testutils.MockTool
+	err util.ToolboxError
+}
+
+func (t sourceErrorTool) Invoke(context.Context, tools.SourceProvider, parameters.ParamValues, tools.AccessToken) (any, util.ToolboxError) {
+	return nil, t.err
+}
+
+func TestInvocationErrorDetails(t *testing.T) {
+	toolsMap := map[string]tools.Tool{
+		"bad_query": sourceErrorTool{
+			MockTool: testutils.NewMockTool("bad_query", "", nil, false, false),
+			err:      util.NewAgentError("unable to execute query", sqlStateError{state: "42P01"}),
+		},
+		"busy": sourceErrorTool{
+			MockTool: testutils.NewMockTool("busy", "", nil, false, false),
+			err:      util.ProcessGeneralError(&util.SourceBusyError{Source: "my-pg-instance"}),
+		},
+	}
+	toolset, err := tools.ToolsetConfig{Name: "", ToolNames: []string{"bad_query", "busy"}}.Initialize(testutils.MockVersionString, toolsMap)
+	if err != nil {
+		t.Fatalf("unable to initialize toolset: %s", err)
+	}
+	toolsets := map[string]tools.Toolset{"": toolset}
+
+	for _, redact := range []bool{false, true} {
+		suffix, wantText := "", `unable to execute query: relation "orders" does not exist`
+		if redact {
+			suffix, wantText = " redacted", "unable to execute query"
+		}
+		setRedact := func(s *Server) { s.redactErrorDetails = redact }
+
+		t.Run("api"+suffix, func(t *testing.T) {
+			r, shutdown := setUpServer(t, "api", toolsMap, toolsets, nil, nil, setRedact)
+			defer shutdown()
+			ts := runServer(r, false)
+			defer ts.Close()
+
+			resp, body, err := runRequest(ts, http.MethodPost, "/tool/bad_query/invoke", strings.NewReader(`{}`), nil)
+			if err != nil {
+				t.Fatalf("unexpected error during request: %s", err)
+			}
+			if resp.StatusCode != http.StatusOK {
+				t.Fatalf("unexpected status: %d, body: %s", resp.StatusCode, body)
+			}
+			var res resultResponse
+			if err := json.Unmarshal(body, &res); err != nil {
+				t.Fatalf("unable to decode response: %s", err)
+			}
+			var got map[string]any
+			if err := json.Unmarshal([]byte(res.Result), &got); err != nil {
+				t.Fatalf("unable to decode result: %s", err)
+			}
+			if diff := cmp.Diff(map[string]any{"error": wantText}, got); diff != "" {
+				t.Errorf("unexpected agent error (-want +got):\n%s", diff)
+			}
+			want := map[string]any{"code": "invalid_query", "retryable": false, "sourceErrorClass": "42P01", "message": "unable to execute query"}
+			if diff := cmp.Diff(want, res.Metadata["error"]); diff != "" {
+				t.Errorf("unexpected error metadata (-want +got):\n%s", diff)
+			}
+
+			resp, body, err = runRequest(ts, http.MethodPost, "/tool/busy/invoke", strings.NewReader(`{}`), nil)
+			if err != nil {
+				t.Fatalf("unexpected error during request: %s", err)
+			}
+			if resp.StatusCode != http.StatusServiceUnavailable {
+				t.Fatalf("unexpected status: %d, body: %s", resp.StatusCode, body)
+			}
+			var errResp errResponse
+			if err := json.Unmarshal(body, &errResp); err != nil {
+				t.Fatalf("unable to decode response: %s", err)
+			}
+			if errResp.Code != "unavailable" || errResp.Data["retryable"] != true {
+				t.Errorf("unexpected server error: %s", body)
+			}
+			if redact && errResp.ErrorText != "source is busy" {
+				t.Errorf("expected redacted error text, got %q", errResp.ErrorText)
+			}
+		})
+
+		t.Run("mcp"+suffix, func(t *testing.T) {
+			r, shutdown := setUpServer(t, "mcp", toolsMap, toolsets, nil, nil, setRedact)
+			defer shutdown()
+			ts := runServer(r, false)
+			defer ts.Close()
+
+			header := map[string]string{"Mcp-Protocol-Version": "2025-06-18"}
+			_, body, err := runRequest(ts, http.MethodPost, "/", strings.NewReader(`{"jsonrpc": "2.0", "id": "call", "method": "tools/call", "params": {"name": "bad_query", "arguments": {}}}`), header)
+			if err != nil {
+				t.Fatalf("unexpected error during request: %s", err)
+			}
+			var got struct {
+				Result struct {
+					Content []struct {
+						Text string `json:"text"`
+					} `json:"content"`
+					IsError           bool           `json:"isError"`
+					StructuredContent map[string]any `json:"structuredContent"`
+				} `json:"result"`
+			}
+			if err := json.Unmarshal(body, &got); err != nil {
+				t.Fatalf("unable to decode response: %s", err)
+			}
+			if !got.Result.IsError || len(got.Result.Content) != 1 || got.Result.Content[0].Text != wantText {
+				t.Fatalf("unexpected result: %s", body)
+			}
+			want := map[string]any{"code": "invalid_query", "retryable": false, "sourceErrorClass": "42P01", "message": "unable to execute query"}
+			if diff := cmp.Diff(want, got.Result.StructuredContent); diff != "" {
+				t.Errorf("unexpected structured content (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
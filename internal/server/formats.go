@@ -0,0 +1,73 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/googleapis/mcp-toolbox/internal/server/resultformat"
+)
+
+// ndjsonStream writes the rows of a streamed result as lines of JSON,
+// flushing each batch of rows to the client.
+type ndjsonStream struct {
+	w http.ResponseWriter
+	// started reports whether the response was started, and rows counts the
+	// rows written.
+	started bool
+	rows    int
+}
+
+func (s *ndjsonStream) start() {
+	if s.started {
+		return
+	}
+	s.w.Header().Set("Content-Type", resultformat.MediaTypes["ndjson"])
+	s.w.WriteHeader(http.StatusOK)
+	s.started = true
+}
+
+// write writes a batch of rows and flushes it.
+func (s *ndjsonStream) write(rows []any) error {
+	s.start()
+	for _, row := range rows {
+		b, err := json.Marshal(row)
+		if err != nil {
+			return fmt.Errorf("unable to marshal row: %w", err)
+		}
+		if _, err := fmt.Fprintf(s.w, "%s\n", b); err != nil {
+			return err
+		}
+		s.rows++
+	}
+	if err := http.NewResponseController(s.w).Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	return nil
+}
+
+// finish ends the stream, with a last line holding the error the stream
+// failed with, if any.
+func (s *ndjsonStream) finish(err error) {
+	s.start()
+	if err == nil {
+		return
+	}
+	b, _ := json.Marshal(map[string]string{"error": err.Error()})
+	_, _ = fmt.Fprintf(s.w, "%s\n", b)
+}
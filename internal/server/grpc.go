@@ -0,0 +1,544 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/googleapis/mcp-toolbox/internal/auth"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+	toolboxv1 "github.com/googleapis/mcp-toolbox/pkg/grpc/toolbox/v1"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// grpcService serves the ToolboxService of the gRPC API, which mirrors the
+// toolset and tool invocation endpoints of the HTTP API.
+type grpcService struct {
+	toolboxv1.UnimplementedToolboxServiceServer
+	s *Server
+}
+
+// newGRPCServer returns a gRPC server serving the ToolboxService of s. The
+// connections are secured with tlsConfig unless it is nil.
+func newGRPCServer(s *Server, tlsConfig *tls.Config) *grpc.Server {
+	opts := []grpc.ServerOption{grpc.MaxRecvMsgSize(int(s.httpMaxRequestBytes))}
+	if tlsConfig != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+	srv := grpc.NewServer(opts...)
+	toolboxv1.RegisterToolboxServiceServer(srv, &grpcService{s: s})
+	return srv
+}
+
+// grpcHeader returns the metadata of an incoming gRPC request as HTTP
+// headers, for the auth services and helpers shared with the HTTP API.
+func grpcHeader(ctx context.Context) http.Header {
+	h := http.Header{}
+	md, _ := metadata.FromIncomingContext(ctx)
+	for k, vs := range md {
+		for _, v := range vs {
+			h.Add(k, v)
+		}
+	}
+	return h
+}
+
+// grpcSourceIP returns the address a gRPC request was received from, as
+// sourceIP does for HTTP requests.
+func (s *Server) grpcSourceIP(ctx context.Context, header http.Header) string {
+	if s.trustProxy {
+		if ip := forwardedIP(header, s.trustedProxyHops); ip != "" {
+			return ip
+		}
+	}
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+// ListTools returns the tools of a toolset.
+func (g *grpcService) ListTools(ctx context.Context, req *toolboxv1.ListToolsRequest) (_ *toolboxv1.ListToolsResponse, err error) {
+	s := g.s
+	ctx, span := s.instrumentation.Tracer.Start(ctx, "toolbox/server/grpc/toolset/get")
+	span.SetAttributes(attribute.String("toolset.name", req.GetToolset()))
+	defer func() {
+		if err != nil {
+			span.SetStatus(otelcodes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	toolset, ok := s.PrimitiveMgr.GetToolset(req.GetToolset())
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "toolset %q does not exist", req.GetToolset())
+	}
+	toolset, accessErr := s.restrictToolset(ctx, grpcHeader(ctx), toolset)
+	if accessErr != nil {
+		return nil, status.Error(grpcCode(accessErr.Code), accessErr.Error())
+	}
+	manifest, err := toolset.BuildManifest(s.PrimitiveMgr.GetSourcesMap())
+	if err != nil {
+		s.logger.DebugContext(ctx, err.Error())
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	locales := s.requestLocales(grpcHeader(ctx), toolset)
+	resp := &toolboxv1.ListToolsResponse{ServerVersion: s.version}
+	for _, tool := range toolset.Tools {
+		name := (*tool).GetName()
+		m := tools.LocalizeManifest(manifest.ToolsManifest[name], tools.Localize(*tool, locales))
+		m.Disabled = s.PrimitiveMgr.IsToolDisabled(name)
+		resp.Tools = append(resp.Tools, toolProto(name, m))
+	}
+	slices.SortFunc(resp.Tools, func(a, b *toolboxv1.Tool) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+	return resp, nil
+}
+
+// toolProto converts the manifest of a tool to its message of the gRPC API.
+func toolProto(name string, m tools.Manifest) *toolboxv1.Tool {
+	t := &toolboxv1.Tool{
+		Name:         name,
+		Description:  m.Description,
+		AuthRequired: m.AuthRequired,
+		Disabled:     m.Disabled,
+	}
+	for _, p := range m.Parameters {
+		t.Parameters = append(t.Parameters, parameterProto(p))
+	}
+	return t
+}
+
+func parameterProto(m parameters.ParameterManifest) *toolboxv1.Parameter {
+	p := &toolboxv1.Parameter{
+		Name:         m.Name,
+		Type:         m.Type,
+		Description:  m.Description,
+		Required:     m.Required,
+		AuthServices: m.AuthServices,
+	}
+	if m.Items != nil {
+		p.Items = parameterProto(*m.Items)
+	}
+	if m.Default != nil {
+		// defaults that do not convert to JSON are left out
+		p.Default, _ = valueProto(m.Default)
+	}
+	return p
+}
+
+// valueProto converts v to a protobuf value through its JSON encoding, as
+// results are encoded by the HTTP API.
+func valueProto(v any) (*structpb.Value, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	res := &structpb.Value{}
+	if err := protojson.Unmarshal(b, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// grpcInvocation is an authorized invocation of a tool with parsed
+// parameters.
+type grpcInvocation struct {
+	ctx         context.Context
+	tool        tools.Tool
+	name        string
+	params      parameters.ParamValues
+	accessToken tools.AccessToken
+	clientAuth  bool
+	// phases traces the phases of the invocation, up to the parsing of its
+	// parameters when prepared.
+	phases *tools.InvocationTrace
+}
+
+// prepare authorizes the invocation of a tool and parses its parameters,
+// following toolInvokeHandler. An error the agent can act on is returned as
+// a *util.AgentError, any other one as a gRPC status.
+func (g *grpcService) prepare(ctx context.Context, req *toolboxv1.InvokeToolRequest) (_ *grpcInvocation, err error) {
+	s := g.s
+	header := grpcHeader(ctx)
+	toolName := req.GetTool()
+	ctx = util.WithLogger(ctx, s.logger)
+	ctx = s.withToolVariant(ctx, header)
+	ctx = util.WithReplicaLag(ctx, &util.ReplicaLag{})
+	ctx = util.WithInvocationCost(ctx, &util.InvocationCost{})
+	ctx = util.WithParamCoercion(ctx, s.paramCoercion)
+	ctx = util.WithSourceIP(ctx, s.grpcSourceIP(ctx, header))
+	ctx = util.WithGenAIMetricAttrs(ctx, &util.GenAIMetricAttrs{ToolName: toolName})
+
+	tool, ok := s.PrimitiveMgr.GetTool(toolName)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "invalid tool name: tool with name %q does not exist", toolName)
+	}
+	if err := s.checkToolAccess(ctx, header, toolName); err != nil {
+		return nil, status.Error(grpcCode(err.Code), err.Error())
+	}
+	if err := s.PrimitiveMgr.CheckToolEnabled(toolName); err != nil {
+		return nil, status.Error(grpcCode(err.Code), err.Error())
+	}
+	phases := tools.TraceInvocation(ctx, tool)
+	defer func() {
+		if err != nil {
+			phases.End(err)
+		}
+	}()
+
+	accessToken := tools.AccessToken(header.Get("Authorization"))
+	clientAuth, err := tool.RequiresClientAuthorization(s.PrimitiveMgr)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "error during invocation: %s", err)
+	}
+	if clientAuth && accessToken == "" {
+		return nil, status.Error(codes.Unauthenticated, "tool requires client authorization but access token is missing from the request metadata")
+	}
+
+	ctx = phases.Phase(ctx, tools.PhaseAuthorize)
+	claimsFromAuth := make(map[string]map[string]any)
+	var expiredErr *auth.TokenExpiredError
+	for _, aS := range s.PrimitiveMgr.GetAuthServiceMap() {
+		claims, err := aS.GetClaimsFromHeader(ctx, header)
+		if err != nil {
+			s.logger.DebugContext(ctx, err.Error())
+			if expiredErr == nil {
+				errors.As(err, &expiredErr)
+			}
+			continue
+		}
+		if claims == nil {
+			continue
+		}
+		claimsFromAuth[aS.GetName()] = claims
+	}
+	ctx = util.WithAuthServiceClaims(ctx, claimsFromAuth)
+	verifiedAuthServices := make([]string, 0, len(claimsFromAuth))
+	for k := range claimsFromAuth {
+		verifiedAuthServices = append(verifiedAuthServices, k)
+	}
+	if !tool.Authorized(verifiedAuthServices) {
+		if expiredErr != nil {
+			return nil, status.Error(codes.Unauthenticated, expiredErr.Error())
+		}
+		return nil, status.Error(codes.Unauthenticated, "tool invocation not authorized. Please make sure you specify correct auth metadata")
+	}
+	if err := tools.CheckIntent(tool, claimsFromAuth); err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+	if err := tools.CheckAllowedCIDRs(ctx, tool); err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	// The parameters are decoded from their JSON encoding, for numbers to be
+	// parsed as by the HTTP API.
+	ctx = phases.Phase(ctx, tools.PhaseParseParams)
+	data := map[string]any{}
+	if req.GetParams() != nil {
+		b, err := protojson.Marshal(req.GetParams())
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid parameters: %s", err)
+		}
+		if err := util.DecodeJSON(bytes.NewReader(b), &data); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid parameters: %s", err)
+		}
+	}
+	toolParams, err := tool.GetParameters(s.PrimitiveMgr.GetSourcesMap())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error getting parameters for tool: %s", err)
+	}
+	data = tools.CoerceParams(ctx, tool, toolParams, data)
+	params, err := parameters.ParseParams(toolParams, data, claimsFromAuth)
+	if err != nil {
+		var clientServerErr *util.ClientServerError
+		if errors.As(err, &clientServerErr) && clientServerErr.Code == http.StatusUnauthorized {
+			if expiredErr != nil {
+				return nil, status.Error(codes.Unauthenticated, expiredErr.Error())
+			}
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+		var agentErr *util.AgentError
+		if errors.As(err, &agentErr) {
+			return nil, agentErr
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	params, err = tool.EmbedParams(ctx, params, s.PrimitiveMgr.GetEmbeddingModelMap())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "error embedding parameters: %s", err)
+	}
+	return &grpcInvocation{
+		ctx:         ctx,
+		tool:        tool,
+		name:        toolName,
+		params:      params,
+		accessToken: accessToken,
+		clientAuth:  clientAuth,
+		phases:      phases,
+	}, nil
+}
+
+// InvokeTool invokes a tool and returns its result.
+func (g *grpcService) InvokeTool(ctx context.Context, req *toolboxv1.InvokeToolRequest) (_ *toolboxv1.InvokeToolResponse, err error) {
+	s := g.s
+	ctx, span := s.instrumentation.Tracer.Start(ctx, "toolbox/server/grpc/tool/invoke")
+	span.SetAttributes(attribute.String("tool_name", req.GetTool()))
+	defer func() {
+		if err != nil {
+			span.SetStatus(otelcodes.Error, err.Error())
+		}
+		span.End()
+	}()
+	ctx, done, ok := s.invocations.start(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unavailable, errShuttingDown.Error())
+	}
+	defer done()
+	ctx = util.WithRedactErrorDetails(ctx, s.redactErrorDetails)
+
+	inv, err := g.prepare(ctx, req)
+	if err != nil {
+		var agentErr *util.AgentError
+		if errors.As(err, &agentErr) {
+			return &toolboxv1.InvokeToolResponse{Error: agentErrorText(ctx, err)}, nil
+		}
+		return nil, err
+	}
+	defer inv.phases.End(nil)
+
+	execCtx := inv.phases.Phase(inv.ctx, tools.PhaseExecute)
+	executionStart := time.Now()
+	res, invokeErr := inv.tool.Invoke(execCtx, s.PrimitiveMgr, inv.params, inv.accessToken)
+	usageRecorder{s: s, toolset: directToolset}.RecordInvocation(execCtx, inv.name, res, invokeErr, time.Since(executionStart).Seconds())
+	inv.phases.End(invokeErr)
+	if invokeErr != nil {
+		if isAgentError(invokeErr) {
+			return &toolboxv1.InvokeToolResponse{Error: agentErrorText(inv.ctx, invokeErr)}, nil
+		}
+		s.logger.ErrorContext(ctx, fmt.Sprintf("Tool invocation server error: %v", invokeErr))
+		return nil, grpcInvocationError(inv.ctx, invokeErr, inv.clientAuth)
+	}
+	inv.phases.Phase(inv.ctx, tools.PhaseMarshal)
+	result, err := valueProto(res)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to marshal result: %s", err)
+	}
+	return &toolboxv1.InvokeToolResponse{Result: result}, nil
+}
+
+// StreamInvokeTool invokes a tool and streams its result, one row per
+// response for the tools that support streaming.
+func (g *grpcService) StreamInvokeTool(req *toolboxv1.InvokeToolRequest, stream grpc.ServerStreamingServer[toolboxv1.StreamInvokeToolResponse]) (err error) {
+	s := g.s
+	ctx, span := s.instrumentation.Tracer.Start(stream.Context(), "toolbox/server/grpc/tool/stream")
+	span.SetAttributes(attribute.String("tool_name", req.GetTool()))
+	defer func() {
+		if err != nil {
+			span.SetStatus(otelcodes.Error, err.Error())
+		}
+		span.End()
+	}()
+	ctx, done, ok := s.invocations.start(ctx)
+	if !ok {
+		return status.Error(codes.Unavailable, errShuttingDown.Error())
+	}
+	defer done()
+	ctx = util.WithRedactErrorDetails(ctx, s.redactErrorDetails)
+
+	sendError := func(err error) error {
+		return stream.Send(&toolboxv1.StreamInvokeToolResponse{
+			Response: &toolboxv1.StreamInvokeToolResponse_Error{Error: agentErrorText(ctx, err)},
+		})
+	}
+	inv, err := g.prepare(ctx, req)
+	if err != nil {
+		var agentErr *util.AgentError
+		if errors.As(err, &agentErr) {
+			return sendError(err)
+		}
+		return err
+	}
+	defer inv.phases.End(nil)
+
+	// the rows of a streamed result are marshaled as they are sent, within
+	// the execution of the tool
+	execCtx := inv.phases.Phase(inv.ctx, tools.PhaseExecute)
+	executionStart := time.Now()
+	streamer, ok := inv.tool.(tools.RowStreamer)
+	if !ok {
+		res, invokeErr := inv.tool.Invoke(execCtx, s.PrimitiveMgr, inv.params, inv.accessToken)
+		usageRecorder{s: s, toolset: directToolset}.RecordInvocation(execCtx, inv.name, res, invokeErr, time.Since(executionStart).Seconds())
+		inv.phases.End(invokeErr)
+		if invokeErr != nil {
+			if isAgentError(invokeErr) {
+				return sendError(invokeErr)
+			}
+			s.logger.ErrorContext(ctx, fmt.Sprintf("Tool invocation server error: %v", invokeErr))
+			return grpcInvocationError(inv.ctx, invokeErr, inv.clientAuth)
+		}
+		inv.phases.Phase(inv.ctx, tools.PhaseMarshal)
+		result, err := valueProto(res)
+		if err != nil {
+			return status.Errorf(codes.Internal, "unable to marshal result: %s", err)
+		}
+		return stream.Send(&toolboxv1.StreamInvokeToolResponse{
+			Response: &toolboxv1.StreamInvokeToolResponse_Result{Result: result},
+		})
+	}
+
+	rows := 0
+	var invokeErr error
+	if tbErr := streamer.StreamRows(execCtx, s.PrimitiveMgr, inv.params, inv.accessToken, func(batch []any) error {
+		for _, row := range batch {
+			v, err := valueProto(row)
+			if err != nil {
+				return fmt.Errorf("unable to marshal row: %w", err)
+			}
+			if err := stream.Send(&toolboxv1.StreamInvokeToolResponse{
+				Response: &toolboxv1.StreamInvokeToolResponse_Row{Row: v},
+			}); err != nil {
+				return err
+			}
+			rows++
+		}
+		return nil
+	}); tbErr != nil {
+		invokeErr = tbErr
+	}
+	usageRecorder{s: s, toolset: directToolset}.record(execCtx, inv.name, rows, invokeErr, time.Since(executionStart).Seconds())
+	inv.phases.End(invokeErr)
+	if invokeErr != nil {
+		if isAgentError(invokeErr) {
+			return sendError(invokeErr)
+		}
+		s.logger.ErrorContext(ctx, fmt.Sprintf("Tool invocation failed after streaming %d rows: %v", rows, invokeErr))
+		return grpcInvocationError(inv.ctx, invokeErr, inv.clientAuth)
+	}
+	return nil
+}
+
+// isAgentError reports whether err is an error for the agent to act on,
+// returned in the response rather than as a gRPC status.
+func isAgentError(err error) bool {
+	var tbErr util.ToolboxError
+	return errors.As(err, &tbErr) && tbErr.Category() == util.CategoryAgent
+}
+
+// agentErrorText returns the text of an agent error returned in the
+// response of an invocation.
+func agentErrorText(ctx context.Context, err error) string {
+	return util.ClientErrorText(ctx, err, util.ClassifyError(err))
+}
+
+// grpcInvocationError converts the error of a tool invocation to a gRPC
+// status, as toolInvokeHandler converts it to an HTTP status. The status is
+// detailed with the error code of the invocation.
+func grpcInvocationError(ctx context.Context, err error, clientAuth bool) error {
+	var limitErr *tools.RateLimitedError
+	if errors.As(err, &limitErr) {
+		st := status.New(codes.ResourceExhausted, err.Error())
+		if detailed, detailErr := st.WithDetails(&errdetails.RetryInfo{
+			RetryDelay: durationpb.New(limitErr.RetryAfter),
+		}); detailErr == nil {
+			st = detailed
+		}
+		return st.Err()
+	}
+	code := codes.Internal
+	var clientServerErr *util.ClientServerError
+	if errors.As(err, &clientServerErr) && clientServerErr.Code != 0 {
+		code = grpcCode(clientServerErr.Code)
+	}
+	// Only client credentials are passed through, an authorization error
+	// of the server's own credentials is a misconfiguration.
+	if (code == codes.Unauthenticated || code == codes.PermissionDenied) && !clientAuth {
+		code = codes.Internal
+	}
+	info := util.ClassifyError(err)
+	st := status.New(code, util.ClientErrorText(ctx, err, info))
+	metadata := map[string]string{"retryable": strconv.FormatBool(info.Retryable)}
+	if info.SourceClass != "" {
+		metadata["sourceErrorClass"] = info.SourceClass
+	}
+	if detailed, detailErr := st.WithDetails(&errdetails.ErrorInfo{
+		Reason:   string(info.Code),
+		Domain:   grpcErrorDomain,
+		Metadata: metadata,
+	}); detailErr == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+// grpcErrorDomain is the domain of the error codes detailing the statuses
+// of failed invocations.
+const grpcErrorDomain = "mcp-toolbox"
+
+// grpcCode returns the gRPC code of an HTTP status code.
+func grpcCode(httpCode int) codes.Code {
+	switch httpCode {
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.Aborted
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusNotImplemented:
+		return codes.Unimplemented
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	case http.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
+	default:
+		return codes.Internal
+	}
+}
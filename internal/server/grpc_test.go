@@ -0,0 +1,160 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/internal/testutils"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	toolboxv1 "github.com/googleapis/mcp-toolbox/pkg/grpc/toolbox/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func grpcTestClient(t *testing.T) toolboxv1.ToolboxServiceClient {
+	toolsMap := map[string]tools.Tool{
+		"tool_a":       testutils.NewMockTool("tool_a", "Tool A.", nil, false, false),
+		"failing_tool": failingTool{MockTool: testutils.NewMockTool("failing_tool", "Always fails.", nil, false, false)},
+	}
+	toolsets := map[string]tools.Toolset{}
+	for name, toolNames := range map[string][]string{
+		"":       {"tool_a", "failing_tool"},
+		"only_a": {"tool_a"},
+	} {
+		ts, err := tools.ToolsetConfig{Name: name, ToolNames: toolNames}.Initialize(testutils.MockVersionString, toolsMap)
+		if err != nil {
+			t.Fatalf("unable to initialize toolset: %s", err)
+		}
+		toolsets[name] = ts
+	}
+	var s *Server
+	_, shutdown := setUpServer(t, "api", toolsMap, toolsets, nil, nil, func(srv *Server) { s = srv })
+	t.Cleanup(shutdown)
+
+	ln := bufconn.Listen(1 << 20)
+	srv := newGRPCServer(s, nil)
+	go func() { _ = srv.Serve(ln) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return ln.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("unable to dial gRPC server: %s", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return toolboxv1.NewToolboxServiceClient(conn)
+}
+
+func TestGRPCListTools(t *testing.T) {
+	client := grpcTestClient(t)
+	ctx := context.Background()
+
+	resp, err := client.ListTools(ctx, &toolboxv1.ListToolsRequest{Toolset: "only_a"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := &toolboxv1.ListToolsResponse{
+		ServerVersion: testutils.MockVersionString,
+		Tools:         []*toolboxv1.Tool{{Name: "tool_a", Description: "Tool A."}},
+	}
+	if diff := cmp.Diff(want, resp, protocmp.Transform()); diff != "" {
+		t.Errorf("unexpected response (-want +got):\n%s", diff)
+	}
+
+	resp, err = client.ListTools(ctx, &toolboxv1.ListToolsRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var names []string
+	for _, tool := range resp.GetTools() {
+		names = append(names, tool.GetName())
+	}
+	if diff := cmp.Diff([]string{"failing_tool", "tool_a"}, names); diff != "" {
+		t.Errorf("unexpected tools (-want +got):\n%s", diff)
+	}
+
+	_, err = client.ListTools(ctx, &toolboxv1.ListToolsRequest{Toolset: "missing"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound, got %v", err)
+	}
+}
+
+func TestGRPCInvokeTool(t *testing.T) {
+	client := grpcTestClient(t)
+	ctx := context.Background()
+
+	resp, err := client.InvokeTool(ctx, &toolboxv1.InvokeToolRequest{Tool: "tool_a"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := structpb.NewListValue(&structpb.ListValue{Values: []*structpb.Value{structpb.NewStringValue("tool_a")}})
+	if diff := cmp.Diff(want, resp.GetResult(), protocmp.Transform()); diff != "" {
+		t.Errorf("unexpected result (-want +got):\n%s", diff)
+	}
+
+	resp, err = client.InvokeTool(ctx, &toolboxv1.InvokeToolRequest{Tool: "failing_tool"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if resp.GetError() != "invalid query" || resp.GetResult() != nil {
+		t.Errorf("expected the agent error in the response, got %v", resp)
+	}
+
+	_, err = client.InvokeTool(ctx, &toolboxv1.InvokeToolRequest{Tool: "missing"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound, got %v", err)
+	}
+}
+
+func TestGRPCStreamInvokeTool(t *testing.T) {
+	client := grpcTestClient(t)
+
+	stream, err := client.StreamInvokeTool(context.Background(), &toolboxv1.InvokeToolRequest{Tool: "tool_a"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var got []*toolboxv1.StreamInvokeToolResponse
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		got = append(got, resp)
+	}
+	// tools that do not stream send their whole result at once
+	want := []*toolboxv1.StreamInvokeToolResponse{{
+		Response: &toolboxv1.StreamInvokeToolResponse_Result{
+			Result: structpb.NewListValue(&structpb.ListValue{Values: []*structpb.Value{structpb.NewStringValue("tool_a")}}),
+		},
+	}}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("unexpected responses (-want +got):\n%s", diff)
+	}
+}
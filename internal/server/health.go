@@ -0,0 +1,167 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-chi/render"
+	"github.com/googleapis/mcp-toolbox/internal/sources"
+	"github.com/googleapis/mcp-toolbox/internal/telemetry"
+)
+
+// sourcePingTimeout bounds the ping of each source by the source health
+// endpoint.
+const sourcePingTimeout = 5 * time.Second
+
+// Statuses of sources reported by the source health endpoint.
+const (
+	sourceStatusOK      = "ok"
+	sourceStatusError   = "error"
+	sourceStatusUnknown = "unknown"
+)
+
+// sourcePings records the latency of the last successful ping of each
+// source.
+type sourcePings struct {
+	mu        sync.Mutex
+	latencies map[string]time.Duration
+}
+
+// ping pings the source name, recording the latency of the ping if it
+// succeeds.
+func (p *sourcePings) ping(ctx context.Context, name string, pinger sources.Pinger) (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(ctx, sourcePingTimeout)
+	defer cancel()
+	start := time.Now()
+	err := pinger.Ping(ctx)
+	latency := time.Since(start)
+	if err != nil {
+		return latency, err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.latencies == nil {
+		p.latencies = make(map[string]time.Duration)
+	}
+	p.latencies[name] = latency
+	return latency, nil
+}
+
+// latency returns the latency of the last successful ping of the source
+// name, nil if it was never pinged.
+func (p *sourcePings) latency(name string) *time.Duration {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	latency, ok := p.latencies[name]
+	if !ok {
+		return nil
+	}
+	return &latency
+}
+
+// sourceStats returns the statistics of the sources of the server, observed
+// as metrics.
+func (s *Server) sourceStats() []telemetry.SourceStats {
+	var stats []telemetry.SourceStats
+	for name, src := range s.PrimitiveMgr.GetSourcesMap() {
+		st := telemetry.SourceStats{
+			Name:        name,
+			Type:        src.SourceType(),
+			PingLatency: s.sourcePings.latency(name),
+		}
+		if reporter, ok := src.(sources.PoolReporter); ok {
+			pool := reporter.PoolStats()
+			st.Pool = &pool
+		}
+		stats = append(stats, st)
+	}
+	return stats
+}
+
+type poolHealth struct {
+	Acquired         int64   `json:"acquired"`
+	Idle             int64   `json:"idle"`
+	Total            int64   `json:"total"`
+	Max              int64   `json:"max"`
+	Acquires         int64   `json:"acquires"`
+	CanceledAcquires int64   `json:"canceledAcquires"`
+	AcquireSeconds   float64 `json:"acquireSeconds"`
+}
+
+type sourceHealth struct {
+	Type          string      `json:"type"`
+	Status        string      `json:"status"`
+	Error         string      `json:"error,omitempty"`
+	PingLatencyMs *float64    `json:"pingLatencyMs,omitempty"`
+	Pool          *poolHealth `json:"pool,omitempty"`
+}
+
+type sourcesHealthResponse struct {
+	Status  string                   `json:"status"`
+	Sources map[string]*sourceHealth `json:"sources"`
+}
+
+// sourcesHealthHandler pings the sources that support it and reports their
+// status along with the statistics of their connection pools. It responds
+// with 503 Service Unavailable if any ping fails.
+func sourcesHealthHandler(s *Server, w http.ResponseWriter, r *http.Request) {
+	res := sourcesHealthResponse{Status: sourceStatusOK, Sources: make(map[string]*sourceHealth)}
+	var wg sync.WaitGroup
+	for name, src := range s.PrimitiveMgr.GetSourcesMap() {
+		h := &sourceHealth{Type: src.SourceType(), Status: sourceStatusUnknown}
+		res.Sources[name] = h
+		if reporter, ok := src.(sources.PoolReporter); ok {
+			st := reporter.PoolStats()
+			h.Pool = &poolHealth{
+				Acquired:         st.Acquired,
+				Idle:             st.Idle,
+				Total:            st.Total,
+				Max:              st.Max,
+				Acquires:         st.Acquires,
+				CanceledAcquires: st.CanceledAcquires,
+				AcquireSeconds:   st.AcquireTime.Seconds(),
+			}
+		}
+		pinger, ok := src.(sources.Pinger)
+		if !ok {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			latency, err := s.sourcePings.ping(r.Context(), name, pinger)
+			if err != nil {
+				h.Status = sourceStatusError
+				h.Error = err.Error()
+				return
+			}
+			ms := float64(latency) / float64(time.Millisecond)
+			h.Status = sourceStatusOK
+			h.PingLatencyMs = &ms
+		}()
+	}
+	wg.Wait()
+	for _, h := range res.Sources {
+		if h.Status == sourceStatusError {
+			res.Status = "degraded"
+			render.Status(r, http.StatusServiceUnavailable)
+		}
+	}
+	render.JSON(w, r, res)
+}
@@ -0,0 +1,93 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/googleapis/mcp-toolbox/internal/server/primitives"
+	"github.com/googleapis/mcp-toolbox/internal/sources"
+	"github.com/googleapis/mcp-toolbox/internal/telemetry"
+)
+
+// pingableSource is a source with a connection pool that answers pings
+// with err.
+type pingableSource struct {
+	fakeSource
+	err error
+}
+
+func (s *pingableSource) Ping(context.Context) error { return s.err }
+
+func (s *pingableSource) PoolStats() telemetry.PoolStats {
+	return telemetry.PoolStats{Acquired: 2, Idle: 3, Total: 5, Max: 10}
+}
+
+func TestSourcesHealth(t *testing.T) {
+	healthy := &pingableSource{}
+	broken := &pingableSource{err: errors.New("connection refused")}
+	s := &Server{
+		PrimitiveMgr: primitives.NewPrimitiveManager(map[string]sources.Source{
+			"healthy":  healthy,
+			"broken":   broken,
+			"unpinged": &fakeSource{},
+		}, nil, nil, nil, nil, nil, nil, nil),
+	}
+
+	get := func() (int, sourcesHealthResponse) {
+		rec := httptest.NewRecorder()
+		sourcesHealthHandler(s, rec, httptest.NewRequest(http.MethodGet, "/health/sources", nil))
+		var res sourcesHealthResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &res); err != nil {
+			t.Fatalf("unable to decode response: %s", err)
+		}
+		return rec.Code, res
+	}
+
+	code, res := get()
+	if code != http.StatusServiceUnavailable || res.Status != "degraded" {
+		t.Fatalf("got %d %q, want 503 \"degraded\"", code, res.Status)
+	}
+	if h := res.Sources["healthy"]; h.Status != sourceStatusOK || h.PingLatencyMs == nil || h.Pool == nil || h.Pool.Acquired != 2 || h.Pool.Max != 10 {
+		t.Errorf("unexpected health of healthy source: %+v", h)
+	}
+	if h := res.Sources["broken"]; h.Status != sourceStatusError || h.Error != "connection refused" || h.PingLatencyMs != nil {
+		t.Errorf("unexpected health of broken source: %+v", h)
+	}
+	if h := res.Sources["unpinged"]; h.Status != sourceStatusUnknown || h.Pool != nil {
+		t.Errorf("unexpected health of unpinged source: %+v", h)
+	}
+
+	stats := make(map[string]telemetry.SourceStats)
+	for _, st := range s.sourceStats() {
+		stats[st.Name] = st
+	}
+	if st := stats["healthy"]; st.PingLatency == nil || st.Pool == nil {
+		t.Errorf("healthy source reports no ping latency or pool: %+v", st)
+	}
+	if st := stats["broken"]; st.PingLatency != nil {
+		t.Errorf("broken source reports a ping latency: %+v", st)
+	}
+
+	broken.err = nil
+	if code, res := get(); code != http.StatusOK || res.Status != sourceStatusOK {
+		t.Errorf("got %d %q once every source is healthy, want 200 \"ok\"", code, res.Status)
+	}
+}
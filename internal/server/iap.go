@@ -0,0 +1,89 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/MicahParks/keyfunc/v3"
+	"github.com/go-chi/httplog/v3"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+)
+
+const (
+	// AuthBackendIAP authenticates requests with the JWT assertions Cloud
+	// Identity-Aware Proxy adds to the requests it forwards.
+	AuthBackendIAP = "iap"
+
+	// iapHeader is the header carrying the IAP JWT assertion.
+	iapHeader = "X-Goog-IAP-JWT-Assertion"
+	// iapIssuer is the issuer of IAP JWT assertions.
+	iapIssuer = "https://cloud.google.com/iap"
+	// iapJWKSURL serves the public keys signing IAP JWT assertions.
+	iapJWKSURL = "https://www.gstatic.com/iap/verify/public_key-jwk"
+)
+
+// newIAPMiddleware fetches the public keys of IAP and returns the middleware
+// authenticating requests with their IAP JWT assertion.
+func newIAPMiddleware(s *Server, audience string) (func(http.Handler) http.Handler, error) {
+	kf, err := keyfunc.NewDefault([]string{iapJWKSURL})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the IAP public keys from %s: %w", iapJWKSURL, err)
+	}
+	return iapMiddleware(s, kf.Keyfunc, audience), nil
+}
+
+// iapMiddleware rejects requests without a valid IAP JWT assertion, signed by
+// a key of keys and issued for audience unless it is empty. The email of the
+// authenticated user is added into the context of the accepted requests and
+// to their request log.
+func iapMiddleware(s *Server, keys jwt.Keyfunc, audience string) func(http.Handler) http.Handler {
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{"ES256"}),
+		jwt.WithIssuer(iapIssuer),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+	}
+	if audience != "" {
+		opts = append(opts, jwt.WithAudience(audience))
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			assertion := r.Header.Get(iapHeader)
+			if assertion == "" {
+				http.Error(w, "missing IAP JWT assertion", http.StatusUnauthorized)
+				return
+			}
+			claims := jwt.MapClaims{}
+			if _, err := jwt.ParseWithClaims(assertion, claims, keys, opts...); err != nil {
+				s.logger.DebugContext(ctx, fmt.Sprintf("invalid IAP JWT assertion: %v", err))
+				http.Error(w, "invalid IAP JWT assertion", http.StatusUnauthorized)
+				return
+			}
+			email, _ := claims["email"].(string)
+			if email == "" {
+				http.Error(w, "IAP JWT assertion without an email claim", http.StatusUnauthorized)
+				return
+			}
+			httplog.SetAttrs(ctx, slog.String("iap.email", email))
+			ctx = util.WithIdentity(ctx, email)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
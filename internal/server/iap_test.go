@@ -0,0 +1,106 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/googleapis/mcp-toolbox/internal/log"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+)
+
+func TestIAPMiddleware(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unable to generate key: %s", err)
+	}
+	other, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unable to generate key: %s", err)
+	}
+	keys := func(*jwt.Token) (any, error) { return &key.PublicKey, nil }
+
+	const audience = "/projects/123/global/backendServices/456"
+	sign := func(k *ecdsa.PrivateKey, claims jwt.MapClaims) string {
+		s, err := jwt.NewWithClaims(jwt.SigningMethodES256, claims).SignedString(k)
+		if err != nil {
+			t.Fatalf("unable to sign token: %s", err)
+		}
+		return s
+	}
+	claims := func(modify func(jwt.MapClaims)) jwt.MapClaims {
+		c := jwt.MapClaims{
+			"iss":   iapIssuer,
+			"aud":   audience,
+			"iat":   time.Now().Unix(),
+			"exp":   time.Now().Add(time.Minute).Unix(),
+			"email": "alice@example.com",
+		}
+		if modify != nil {
+			modify(c)
+		}
+		return c
+	}
+
+	testLogger, err := log.NewStdLogger(io.Discard, io.Discard, "info")
+	if err != nil {
+		t.Fatalf("unable to initialize logger: %s", err)
+	}
+	s := &Server{logger: testLogger}
+	var identity string
+	handler := iapMiddleware(s, keys, audience)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		identity, _ = util.IdentityFromContext(r.Context())
+	}))
+
+	tcs := []struct {
+		desc       string
+		assertion  string
+		wantStatus int
+	}{
+		{desc: "valid", assertion: sign(key, claims(nil)), wantStatus: http.StatusOK},
+		{desc: "missing", assertion: "", wantStatus: http.StatusUnauthorized},
+		{desc: "malformed", assertion: "not-a-jwt", wantStatus: http.StatusUnauthorized},
+		{desc: "wrong key", assertion: sign(other, claims(nil)), wantStatus: http.StatusUnauthorized},
+		{desc: "expired", assertion: sign(key, claims(func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Minute).Unix() })), wantStatus: http.StatusUnauthorized},
+		{desc: "wrong issuer", assertion: sign(key, claims(func(c jwt.MapClaims) { c["iss"] = "https://accounts.google.com" })), wantStatus: http.StatusUnauthorized},
+		{desc: "wrong audience", assertion: sign(key, claims(func(c jwt.MapClaims) { c["aud"] = "/projects/123/apps/other" })), wantStatus: http.StatusUnauthorized},
+		{desc: "no email", assertion: sign(key, claims(func(c jwt.MapClaims) { delete(c, "email") })), wantStatus: http.StatusUnauthorized},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			identity = ""
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tc.assertion != "" {
+				req.Header.Set(iapHeader, tc.assertion)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tc.wantStatus {
+				t.Fatalf("unexpected status: got %d, want %d: %s", rec.Code, tc.wantStatus, rec.Body.String())
+			}
+			if tc.wantStatus == http.StatusOK && identity != "alice@example.com" {
+				t.Fatalf("unexpected identity: got %q", identity)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/googleapis/mcp-toolbox/internal/server/scheduler"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+// jobKind is the kind of the documents defining scheduled jobs.
+const jobKind = "job"
+
+type JobConfigs map[string]scheduler.Config
+
+// UnmarshalJobConfigs returns the jobs defined in raw. The other documents
+// are left to UnmarshalPrimitiveConfig.
+func UnmarshalJobConfigs(ctx context.Context, raw []byte) (JobConfigs, error) {
+	var jobs JobConfigs
+	err := forEachDocOfKind(ctx, raw, jobKind, func(name string, resource map[string]any) error {
+		c, err := unmarshalYAMLJobConfig(ctx, name, resource)
+		if err != nil {
+			return err
+		}
+		if _, ok := jobs[name]; ok {
+			return fmt.Errorf("job %q is defined more than once", name)
+		}
+		if jobs == nil {
+			jobs = make(JobConfigs)
+		}
+		jobs[name] = c
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+func unmarshalYAMLJobConfig(ctx context.Context, name string, r map[string]any) (scheduler.Config, error) {
+	c := scheduler.Config{Name: name}
+	dec, err := util.NewStrictDecoderAt(r, fmt.Sprintf("jobs[%s]", name))
+	if err != nil {
+		return c, fmt.Errorf("error creating decoder: %w", err)
+	}
+	if err := dec.DecodeContext(ctx, &c); err != nil {
+		return c, err
+	}
+	return c, nil
+}
+
+// startJobs starts running the jobs on their schedules.
+func (s *Server) startJobs(ctx context.Context, jobs JobConfigs) error {
+	if len(jobs) == 0 {
+		return nil
+	}
+	toolExists := func(name string) bool {
+		_, ok := s.PrimitiveMgr.GetTool(name)
+		return ok
+	}
+	sched, err := scheduler.New(jobs, s.invokeScheduled, toolExists, s.logger)
+	if err != nil {
+		return fmt.Errorf("unable to initialize jobs: %w", err)
+	}
+	s.scheduler = sched
+	sched.Start(ctx)
+	s.logger.InfoContext(ctx, fmt.Sprintf("Scheduled %d jobs", sched.Len()))
+	return nil
+}
+
+// invokeScheduled invokes the tool toolName of a job with the parameter
+// values of its configuration.
+func (s *Server) invokeScheduled(ctx context.Context, toolName string, data map[string]any) (any, error) {
+	ctx = util.WithLogger(ctx, s.logger)
+	ctx = util.WithParamCoercion(ctx, s.paramCoercion)
+	ctx = util.WithRedactErrorDetails(ctx, s.redactErrorDetails)
+	ctx = util.WithGenAIMetricAttrs(ctx, &util.GenAIMetricAttrs{ToolName: toolName})
+	ctx = util.WithInvocationCost(ctx, &util.InvocationCost{})
+	tool, ok := s.PrimitiveMgr.GetTool(toolName)
+	if !ok {
+		return nil, util.NewClientServerError(fmt.Sprintf("tool %q does not exist", toolName), http.StatusNotFound, nil)
+	}
+	// jobs run without the credentials of a client
+	if !tool.Authorized(nil) {
+		return nil, util.NewClientServerError(fmt.Sprintf("tool %q requires an authorized invocation", toolName), http.StatusUnauthorized, nil)
+	}
+	toolParams, err := tool.GetParameters(s.PrimitiveMgr.GetSourcesMap())
+	if err != nil {
+		return nil, util.NewClientServerError("error getting parameters for tool", http.StatusInternalServerError, err)
+	}
+	params, err := parameters.ParseParams(toolParams, data, nil)
+	if err != nil {
+		return nil, util.NewAgentError("provided parameters were invalid", err)
+	}
+	params, err = tool.EmbedParams(ctx, params, s.PrimitiveMgr.GetEmbeddingModelMap())
+	if err != nil {
+		return nil, util.NewClientServerError("error embedding parameters", http.StatusBadRequest, err)
+	}
+	// the results of jobs are written whole, rather than split into pages
+	ctx = util.WithResultPage(ctx, nil)
+	ctx = util.WithAuthServiceClaims(ctx, make(map[string]map[string]any))
+	executionStart := time.Now()
+	res, err := tool.Invoke(ctx, s.PrimitiveMgr, params, "")
+	usageRecorder{s: s, toolset: directToolset}.RecordInvocation(ctx, toolName, res, err, time.Since(executionStart).Seconds())
+	return res, err
+}
@@ -0,0 +1,96 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/internal/server/scheduler"
+	"github.com/googleapis/mcp-toolbox/internal/testutils"
+)
+
+func TestUnmarshalJobConfigs(t *testing.T) {
+	raw := `
+kind: tool
+name: count
+type: postgres-sql
+source: db
+description: Counts the open orders.
+statement: SELECT count(*) FROM orders
+---
+kind: job
+name: nightly-count
+schedule: "0 2 * * *"
+timezone: Europe/Paris
+tool: count
+parameters:
+  status: open
+sink:
+  type: file
+  path: /var/log/counts.jsonl
+`
+	got, err := UnmarshalJobConfigs(context.Background(), []byte(raw))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := JobConfigs{
+		"nightly-count": scheduler.Config{
+			Name:       "nightly-count",
+			Schedule:   "0 2 * * *",
+			Timezone:   "Europe/Paris",
+			Tool:       "count",
+			Parameters: map[string]any{"status": "open"},
+			Sink:       &scheduler.SinkConfig{Type: scheduler.SinkFile, Path: "/var/log/counts.jsonl"},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect jobs (-want +got):\n%s", diff)
+	}
+
+	for desc, raw := range map[string]string{
+		"unknown field": "kind: job\nname: j\nschedule: '@daily'\ntool: count\ncron: '@daily'\n",
+		"duplicate":     "kind: job\nname: j\nschedule: '@daily'\ntool: count\n---\nkind: job\nname: j\nschedule: '@hourly'\ntool: count\n",
+	} {
+		t.Run(desc, func(t *testing.T) {
+			if _, err := UnmarshalJobConfigs(context.Background(), []byte(raw)); err == nil {
+				t.Fatalf("expected an error")
+			}
+		})
+	}
+}
+
+func TestInvokeScheduled(t *testing.T) {
+	mockTools := []testutils.MockTool{testutils.MockTool1, testutils.MockTool2}
+	toolsMap, toolsets, _, _ := testutils.SetUpResources(t, mockTools, nil)
+	var s *Server
+	_, shutdown := setUpServer(t, "api", toolsMap, toolsets, nil, nil, func(srv *Server) { s = srv })
+	defer shutdown()
+
+	res, err := s.invokeScheduled(context.Background(), testutils.MockTool2.Name, map[string]any{"param1": 1, "param2": 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !strings.Contains(fmt.Sprint(res), testutils.MockTool2.Name) {
+		t.Fatalf("unexpected result: %v", res)
+	}
+
+	if _, err := s.invokeScheduled(context.Background(), "unknown", nil); err == nil {
+		t.Fatalf("expected an error for an unknown tool")
+	}
+}
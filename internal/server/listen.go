@@ -0,0 +1,111 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// unixAddressPrefix prefixes the --address of a Unix domain socket,
+	// such as unix:/run/toolbox.sock.
+	unixAddressPrefix = "unix:"
+	// unixSocketMode is the mode of the Unix domain sockets the server
+	// creates, restricting them to the user and group of the server.
+	unixSocketMode = 0o660
+	// listenFDsStart is the first file descriptor systemd passes to the
+	// processes it activates with sockets.
+	listenFDsStart = 3
+)
+
+// listenAddr returns the network and the address of the listener of address
+// and port. A unix: address is the path of a Unix domain socket, and port is
+// ignored.
+func listenAddr(address string, port int) (network, addr string) {
+	if path, ok := strings.CutPrefix(address, unixAddressPrefix); ok {
+		return "unix", path
+	}
+	return "tcp", net.JoinHostPort(address, strconv.Itoa(port))
+}
+
+// listenUnix listens on the Unix domain socket at path, removing the socket
+// a previous server left behind.
+func listenUnix(ctx context.Context, lc net.ListenConfig, path string) (net.Listener, error) {
+	if fi, err := os.Lstat(path); err == nil && fi.Mode()&fs.ModeSocket != 0 {
+		d := net.Dialer{Timeout: time.Second}
+		if conn, err := d.DialContext(ctx, "unix", path); err == nil {
+			conn.Close()
+			return nil, fmt.Errorf("socket %q is in use by another process", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("unable to remove stale socket %q: %w", path, err)
+		}
+	}
+	ln, err := lc.Listen(ctx, "unix", path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(path, unixSocketMode); err != nil {
+		ln.Close()
+		return nil, fmt.Errorf("unable to set the mode of socket %q: %w", path, err)
+	}
+	return ln, nil
+}
+
+// systemdListeners returns the listeners of the sockets systemd passed to
+// the process with socket activation, in the order of the ListenStream
+// directives of its socket unit. It returns nil if the process wasn't
+// activated with sockets.
+func systemdListeners() ([]net.Listener, error) {
+	if pid, err := strconv.Atoi(os.Getenv("LISTEN_PID")); err != nil || pid != os.Getpid() {
+		return nil, nil
+	}
+	n, err := strconv.Atoi(os.Getenv("LISTEN_FDS"))
+	if err != nil || n <= 0 {
+		return nil, nil
+	}
+	// the sockets are not meant for the processes the server starts
+	for _, v := range []string{"LISTEN_PID", "LISTEN_FDS", "LISTEN_FDNAMES"} {
+		os.Unsetenv(v)
+	}
+	lns := make([]net.Listener, 0, n)
+	var errs []error
+	for fd := listenFDsStart; fd < listenFDsStart+n; fd++ {
+		f := os.NewFile(uintptr(fd), fmt.Sprintf("LISTEN_FD_%d", fd))
+		// FileListener duplicates the descriptor, which is closed either way
+		ln, err := net.FileListener(f)
+		f.Close()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("socket %d passed by systemd: %w", fd, err))
+			continue
+		}
+		lns = append(lns, ln)
+	}
+	if err := errors.Join(errs...); err != nil {
+		for _, ln := range lns {
+			ln.Close()
+		}
+		return nil, err
+	}
+	return lns, nil
+}
@@ -0,0 +1,129 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/googleapis/mcp-toolbox/internal/log"
+)
+
+func TestListenAddr(t *testing.T) {
+	tcs := []struct {
+		address     string
+		port        int
+		wantNetwork string
+		wantAddr    string
+	}{
+		{address: "127.0.0.1", port: 5000, wantNetwork: "tcp", wantAddr: "127.0.0.1:5000"},
+		{address: "::1", port: 5000, wantNetwork: "tcp", wantAddr: "[::1]:5000"},
+		{address: "unix:/run/toolbox.sock", port: 5000, wantNetwork: "unix", wantAddr: "/run/toolbox.sock"},
+	}
+	for _, tc := range tcs {
+		network, addr := listenAddr(tc.address, tc.port)
+		if network != tc.wantNetwork || addr != tc.wantAddr {
+			t.Errorf("listenAddr(%q, %d) = %q, %q, want %q, %q", tc.address, tc.port, network, addr, tc.wantNetwork, tc.wantAddr)
+		}
+	}
+}
+
+// newUnixTestServer returns a server answering every request on the Unix
+// domain socket at path.
+func newUnixTestServer(t *testing.T, path string) *Server {
+	t.Helper()
+	logger, err := log.NewStdLogger(io.Discard, io.Discard, "info")
+	if err != nil {
+		t.Fatalf("unable to create logger: %s", err)
+	}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	})
+	return &Server{srv: &http.Server{Addr: path, Handler: handler}, network: "unix", logger: logger}
+}
+
+func TestListenUnixSocket(t *testing.T) {
+	// socket paths are limited to about a hundred bytes
+	dir, err := os.MkdirTemp("", "toolbox")
+	if err != nil {
+		t.Fatalf("unable to create directory: %s", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "toolbox.sock")
+
+	// a socket left behind by a previous server is replaced
+	stale, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatalf("unable to listen: %s", err)
+	}
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	stale.Close()
+
+	ctx := context.Background()
+	s := newUnixTestServer(t, path)
+	if err := s.Listen(ctx, "", ""); err != nil {
+		t.Fatalf("unable to listen: %s", err)
+	}
+	go func() { _ = s.Serve(ctx) }()
+	defer s.srv.Close()
+
+	fi, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("unable to stat socket: %s", err)
+	}
+	if mode := fi.Mode().Perm(); mode != unixSocketMode {
+		t.Errorf("unexpected socket mode: got %o, want %o", mode, unixSocketMode)
+	}
+
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", path)
+		},
+	}}
+	resp, err := client.Get("http://toolbox/")
+	if err != nil {
+		t.Fatalf("unable to send request: %s", err)
+	}
+	defer resp.Body.Close()
+	if body, _ := io.ReadAll(resp.Body); string(body) != "ok" {
+		t.Fatalf("unexpected response: %q", body)
+	}
+
+	// a socket in use is not taken over
+	err = newUnixTestServer(t, path).Listen(ctx, "", "")
+	if err == nil || !strings.Contains(err.Error(), "in use") {
+		t.Fatalf("expected the socket in use to be rejected, got %v", err)
+	}
+}
+
+func TestSystemdListenersNotActivated(t *testing.T) {
+	t.Setenv("LISTEN_PID", strconv.Itoa(os.Getpid()+1))
+	t.Setenv("LISTEN_FDS", "1")
+	lns, err := systemdListeners()
+	if err != nil || lns != nil {
+		t.Fatalf("expected no listeners for another process, got %v, %v", lns, err)
+	}
+	if os.Getenv("LISTEN_FDS") != "1" {
+		t.Fatalf("expected the environment of another process to be kept")
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/googleapis/mcp-toolbox/internal/server/mcp/jsonrpc"
+)
+
+// DefaultSessionMaxMissedPings is how many server pings in a row a session
+// may leave unanswered before it is reclaimed.
+const DefaultSessionMaxMissedPings = 3
+
+// pingIdPrefix prefixes the ids of the pings sent by the server, to tell
+// their responses apart from other messages of the client.
+const pingIdPrefix = "toolbox-ping-"
+
+// errSessionDead ends the sessions that stopped answering pings.
+var errSessionDead = errors.New("session stopped answering pings")
+
+// startPinging pings the sse sessions every interval until ctx is done,
+// reclaiming the sessions that leave maxMissed pings in a row unanswered.
+func (m *sseManager) startPinging(ctx context.Context, interval time.Duration, maxMissed int) {
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				m.pingRound(maxMissed)
+			}
+		}
+	}()
+}
+
+// pingRound sends a ping to every session. A session whose previous ping is
+// still unanswered misses it, and is evicted once it missed maxMissed pings
+// in a row. pingRound returns the ids of the evicted sessions.
+func (m *sseManager) pingRound(maxMissed int) []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var evicted []string
+	for id, sess := range m.sseSessions {
+		if sess == nil {
+			continue
+		}
+		if sess.pendingPing != "" {
+			sess.missedPings++
+			if sess.missedPings >= maxMissed {
+				delete(m.sseSessions, id)
+				close(sess.evicted)
+				evicted = append(evicted, id)
+				continue
+			}
+		}
+		m.pingSeq++
+		pingId := fmt.Sprintf("%s%d", pingIdPrefix, m.pingSeq)
+		data, err := json.Marshal(jsonrpc.JSONRPCRequest{
+			Jsonrpc: jsonrpc.JSONRPC_VERSION,
+			Id:      pingId,
+			Request: jsonrpc.Request{Method: "ping"},
+		})
+		if err != nil {
+			continue
+		}
+		select {
+		case sess.eventQueue <- fmt.Sprintf("event: message\ndata: %s\n\n", data):
+			sess.pendingPing = pingId
+		default:
+			// The client is not even draining its events; the unanswered
+			// previous ping, if any, keeps counting against it.
+		}
+	}
+	return evicted
+}
+
+// pong records body as the response of the client of session id to a ping,
+// if it is one.
+func (m *sseManager) pong(id string, body []byte) bool {
+	var msg struct {
+		Method string            `json:"method"`
+		Id     jsonrpc.RequestId `json:"id"`
+	}
+	if err := json.Unmarshal(body, &msg); err != nil || msg.Method != "" {
+		return false
+	}
+	pingId, ok := msg.Id.(string)
+	if !ok || !strings.HasPrefix(pingId, pingIdPrefix) {
+		return false
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if sess, ok := m.sseSessions[id]; ok && sess != nil {
+		// A late response still shows the client is alive.
+		sess.pendingPing = ""
+		sess.missedPings = 0
+	}
+	return true
+}
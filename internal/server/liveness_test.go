@@ -0,0 +1,191 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+)
+
+func newFakeSseSession() *sseSession {
+	return &sseSession{
+		done:       make(chan struct{}),
+		eventQueue: make(chan string, 100),
+		evicted:    make(chan struct{}),
+	}
+}
+
+// answerPing responds to the ping queued for session id, like a live client.
+func answerPing(t *testing.T, m *sseManager, id string, sess *sseSession) {
+	t.Helper()
+	event := <-sess.eventQueue
+	var ping struct {
+		Id     string `json:"id"`
+		Method string `json:"method"`
+	}
+	if err := json.Unmarshal([]byte(strings.TrimSuffix(strings.TrimPrefix(event, "event: message\ndata: "), "\n\n")), &ping); err != nil {
+		t.Fatalf("unable to decode ping event %q: %s", event, err)
+	}
+	if ping.Method != "ping" {
+		t.Fatalf("unexpected event: %s", event)
+	}
+	if !m.pong(id, []byte(fmt.Sprintf(`{"jsonrpc": "2.0", "id": %q, "result": {}}`, ping.Id))) {
+		t.Fatalf("expected the response to the ping to be recorded")
+	}
+}
+
+func TestSseManagerPingRound(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	m := newSseManager(ctx)
+	alive, dead := newFakeSseSession(), newFakeSseSession()
+	m.add("alive", alive)
+	m.add("dead", dead)
+
+	for round := 1; round <= 4; round++ {
+		evicted := m.pingRound(3)
+		// the dead session misses the pings of rounds 1 to 3
+		if round < 4 && len(evicted) != 0 {
+			t.Fatalf("unexpected eviction in round %d: %v", round, evicted)
+		}
+		if round == 4 && (len(evicted) != 1 || evicted[0] != "dead") {
+			t.Fatalf("expected the dead session to be evicted in round 4, got %v", evicted)
+		}
+		answerPing(t, m, "alive", alive)
+	}
+
+	select {
+	case <-dead.evicted:
+	default:
+		t.Errorf("expected the dead session to be notified of its eviction")
+	}
+	if _, ok := m.get("dead"); ok {
+		t.Errorf("expected the dead session to be removed")
+	}
+	if _, ok := m.get("alive"); !ok {
+		t.Errorf("expected the live session to be kept")
+	}
+}
+
+func TestSseManagerPong(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	m := newSseManager(ctx)
+	m.add("session", newFakeSseSession())
+
+	tcs := []struct {
+		desc string
+		body string
+		want bool
+	}{
+		{desc: "ping response", body: `{"jsonrpc": "2.0", "id": "toolbox-ping-1", "result": {}}`, want: true},
+		{desc: "ping error", body: `{"jsonrpc": "2.0", "id": "toolbox-ping-1", "error": {"code": -32601, "message": "not found"}}`, want: true},
+		{desc: "request", body: `{"jsonrpc": "2.0", "id": "toolbox-ping-1", "method": "ping"}`, want: false},
+		{desc: "other response", body: `{"jsonrpc": "2.0", "id": 1, "result": {}}`, want: false},
+		{desc: "invalid json", body: `{`, want: false},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := m.pong("session", []byte(tc.body)); got != tc.want {
+				t.Errorf("unexpected pong: got %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSseSessionLiveness(t *testing.T) {
+	var srv *Server
+	r, shutdown := setUpServer(t, "mcp", map[string]tools.Tool{}, map[string]tools.Toolset{}, nil, nil, func(s *Server) { srv = s })
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	resp, err := runSseRequest(ts, "/sse", "")
+	if err != nil {
+		t.Fatalf("unable to open sse session: %s", err)
+	}
+	defer resp.Body.Close()
+	events := bufio.NewReader(resp.Body)
+	readEvent := func() (string, error) {
+		var event strings.Builder
+		for {
+			line, err := events.ReadString('\n')
+			if err != nil {
+				return event.String(), err
+			}
+			if line == "\n" {
+				return event.String(), nil
+			}
+			event.WriteString(line)
+		}
+	}
+
+	event, err := readEvent()
+	if err != nil {
+		t.Fatalf("unable to read endpoint event: %s", err)
+	}
+	endpoint, err := url.Parse(strings.TrimSpace(strings.TrimPrefix(event, "event: endpoint\ndata: ")))
+	if err != nil {
+		t.Fatalf("unable to parse endpoint event %q: %s", event, err)
+	}
+	sessionId := endpoint.Query().Get("sessionId")
+
+	// a live client answers the ping of the server
+	srv.sseManager.pingRound(2)
+	if event, err = readEvent(); err != nil || !strings.Contains(event, `"method":"ping"`) {
+		t.Fatalf("expected a ping event, got %q, %v", event, err)
+	}
+	pong := fmt.Sprintf(`{"jsonrpc": "2.0", "id": "%s1", "result": {}}`, pingIdPrefix)
+	pongResp, _, err := runRequest(ts, http.MethodPost, "/?sessionId="+sessionId, strings.NewReader(pong), nil)
+	if err != nil {
+		t.Fatalf("unable to send pong: %s", err)
+	}
+	if pongResp.StatusCode != http.StatusAccepted {
+		t.Fatalf("unexpected status of pong: %d", pongResp.StatusCode)
+	}
+
+	// then stops responding, and is reclaimed after two missed pings
+	for range 3 {
+		srv.sseManager.pingRound(2)
+	}
+	done := make(chan error, 1)
+	go func() {
+		_, err := io.ReadAll(events)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("unexpected error reading the stream: %s", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expected the sse stream of the dead session to end")
+	}
+	if _, ok := srv.sseManager.get(sessionId); ok {
+		t.Errorf("expected the dead session to be removed")
+	}
+}
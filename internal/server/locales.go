@@ -0,0 +1,27 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"net/http"
+
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+)
+
+// requestLocales returns the locales to serve the tool descriptions of a
+// request for toolset in, most preferred first.
+func (s *Server) requestLocales(header http.Header, toolset tools.Toolset) []string {
+	return tools.RequestLocales(header.Get("Accept-Language"), toolset.Locale, s.defaultLocale)
+}
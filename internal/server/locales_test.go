@@ -0,0 +1,158 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/googleapis/mcp-toolbox/internal/testutils"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+type localizedConfig struct {
+	tools.ConfigBase
+}
+
+func (localizedConfig) ToolConfigType() string { return "localized" }
+func (localizedConfig) Initialize(context.Context) (tools.Tool, error) {
+	return nil, nil
+}
+
+// localizedTool is a mock tool with translated descriptions.
+type localizedTool struct {
+	testutils.MockTool
+	translations tools.Translations
+}
+
+func (t localizedTool) ToConfig() tools.ToolConfig {
+	return localizedConfig{tools.ConfigBase{Name: t.Name, Description: t.Description, Translations: t.translations}}
+}
+
+func TestLocalizedDescriptions(t *testing.T) {
+	tool := localizedTool{
+		MockTool: testutils.NewMockTool("search_flights", "Search flights", []parameters.Parameter{
+			parameters.NewStringParameter("city", "Departure city"),
+		}, false, false),
+		translations: tools.Translations{
+			"en": {Description: "Search flights", Parameters: map[string]string{"city": "Departure city"}},
+			"fr": {Description: "Rechercher des vols", Parameters: map[string]string{"city": "Ville de départ"}},
+		},
+	}
+	toolsMap := map[string]tools.Tool{tool.Name: tool}
+	toolsets := make(map[string]tools.Toolset)
+	for _, cfg := range []tools.ToolsetConfig{
+		{Name: "", ToolNames: []string{tool.Name}},
+		{Name: "french", ToolNames: []string{tool.Name}, Locale: "fr"},
+	} {
+		toolset, err := cfg.Initialize(testutils.MockVersionString, toolsMap)
+		if err != nil {
+			t.Fatalf("unable to initialize toolset: %s", err)
+		}
+		toolsets[cfg.Name] = toolset
+	}
+	withDefaultLocale := func(s *Server) { s.defaultLocale = "en" }
+
+	tcs := []struct {
+		desc           string
+		toolset        string
+		acceptLanguage string
+		wantDesc       string
+		wantParamDesc  string
+	}{
+		{desc: "default locale", wantDesc: "Search flights", wantParamDesc: "Departure city"},
+		{desc: "toolset locale", toolset: "french", wantDesc: "Rechercher des vols", wantParamDesc: "Ville de départ"},
+		{desc: "header locale", acceptLanguage: "fr-FR, en;q=0.5", wantDesc: "Rechercher des vols", wantParamDesc: "Ville de départ"},
+		{desc: "header overrides toolset", toolset: "french", acceptLanguage: "en", wantDesc: "Search flights", wantParamDesc: "Departure city"},
+		{desc: "untranslated header locale", toolset: "french", acceptLanguage: "de", wantDesc: "Rechercher des vols", wantParamDesc: "Ville de départ"},
+	}
+
+	t.Run("api", func(t *testing.T) {
+		r, shutdown := setUpServer(t, "api", toolsMap, toolsets, nil, nil, withDefaultLocale)
+		defer shutdown()
+		ts := runServer(r, false)
+		defer ts.Close()
+
+		for _, tc := range tcs {
+			t.Run(tc.desc, func(t *testing.T) {
+				resp, body, err := runRequest(ts, http.MethodGet, "/toolset/"+tc.toolset, nil, map[string]string{"Accept-Language": tc.acceptLanguage})
+				if err != nil {
+					t.Fatalf("unexpected error during request: %s", err)
+				}
+				if resp.StatusCode != http.StatusOK {
+					t.Fatalf("unexpected status: %d, body: %s", resp.StatusCode, body)
+				}
+				if got := resp.Header.Get("Vary"); got != "Accept-Language" {
+					t.Errorf("unexpected Vary header: %q", got)
+				}
+				var got tools.ToolsetManifest
+				if err := json.Unmarshal(body, &got); err != nil {
+					t.Fatalf("unable to decode response: %s", err)
+				}
+				m := got.ToolsManifest[tool.Name]
+				if m.Description != tc.wantDesc {
+					t.Errorf("unexpected description: got %q, want %q", m.Description, tc.wantDesc)
+				}
+				if len(m.Parameters) != 1 || m.Parameters[0].Description != tc.wantParamDesc {
+					t.Errorf("unexpected parameters: got %+v, want description %q", m.Parameters, tc.wantParamDesc)
+				}
+			})
+		}
+	})
+
+	t.Run("mcp", func(t *testing.T) {
+		r, shutdown := setUpServer(t, "mcp", toolsMap, toolsets, nil, nil, withDefaultLocale)
+		defer shutdown()
+		ts := runServer(r, false)
+		defer ts.Close()
+
+		for _, tc := range tcs {
+			t.Run(tc.desc, func(t *testing.T) {
+				resp, body, err := runRequest(ts, http.MethodGet, mcpExportToolsPath+"?toolset="+tc.toolset, nil, map[string]string{"Accept-Language": tc.acceptLanguage})
+				if err != nil {
+					t.Fatalf("unexpected error during request: %s", err)
+				}
+				if resp.StatusCode != http.StatusOK {
+					t.Fatalf("unexpected status: %d, body: %s", resp.StatusCode, body)
+				}
+				var got struct {
+					Tools []struct {
+						Description string `json:"description"`
+						InputSchema struct {
+							Properties map[string]struct {
+								Description string `json:"description"`
+							} `json:"properties"`
+						} `json:"inputSchema"`
+					} `json:"tools"`
+				}
+				if err := json.Unmarshal(body, &got); err != nil {
+					t.Fatalf("unable to decode response: %s", err)
+				}
+				if len(got.Tools) != 1 {
+					t.Fatalf("unexpected tools: %s", body)
+				}
+				if got.Tools[0].Description != tc.wantDesc {
+					t.Errorf("unexpected description: got %q, want %q", got.Tools[0].Description, tc.wantDesc)
+				}
+				if got := got.Tools[0].InputSchema.Properties["city"].Description; got != tc.wantParamDesc {
+					t.Errorf("unexpected parameter description: got %q, want %q", got, tc.wantParamDesc)
+				}
+			})
+		}
+	})
+}
@@ -0,0 +1,103 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+// markdownContentType is the content type of tool invocations whose YAML
+// frontmatter holds the parameters of the tool.
+const markdownContentType = "text/markdown"
+
+// markdownPromptParam is the parameter the body of a Markdown invocation is
+// passed as, when the tool declares it.
+const markdownPromptParam = "prompt"
+
+// isMarkdown reports whether the body of a request is Markdown.
+func isMarkdown(r *http.Request) bool {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return err == nil && mediaType == markdownContentType
+}
+
+// decodeMarkdown decodes a Markdown document into the parameter values held
+// by its YAML frontmatter, delimited by "---" lines, and the body following
+// it. A document without frontmatter is all body.
+func decodeMarkdown(r io.Reader) (map[string]any, string, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, "", err
+	}
+	doc := strings.ReplaceAll(string(b), "\r\n", "\n")
+	data := map[string]any{}
+
+	rest, ok := strings.CutPrefix(doc, "---\n")
+	if !ok {
+		return data, doc, nil
+	}
+	var frontmatter, body string
+	if strings.HasPrefix(rest, "---\n") || rest == "---" {
+		body = strings.TrimPrefix(rest, "---")
+	} else {
+		end := strings.Index(rest, "\n---\n")
+		switch {
+		case end >= 0:
+			frontmatter, body = rest[:end], rest[end+len("\n---"):]
+		case strings.HasSuffix(rest, "\n---"):
+			frontmatter = strings.TrimSuffix(rest, "\n---")
+		default:
+			return nil, "", fmt.Errorf("frontmatter is not closed by a --- line")
+		}
+	}
+
+	js, err := yaml.YAMLToJSON([]byte(frontmatter))
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid frontmatter: %w", err)
+	}
+	if err := util.DecodeJSON(bytes.NewReader(js), &data); err != nil {
+		return nil, "", fmt.Errorf("frontmatter must be a mapping of parameter names to values: %w", err)
+	}
+	if data == nil {
+		data = map[string]any{}
+	}
+	return data, strings.TrimPrefix(body, "\n"), nil
+}
+
+// withMarkdownPrompt passes the body of a Markdown invocation as the prompt
+// parameter, if the tool declares it and the frontmatter does not set it.
+func withMarkdownPrompt(params parameters.Parameters, data map[string]any, body string) map[string]any {
+	if strings.TrimSpace(body) == "" {
+		return data
+	}
+	if _, ok := data[markdownPromptParam]; ok {
+		return data
+	}
+	for _, p := range params {
+		if p.GetName() == markdownPromptParam {
+			data[markdownPromptParam] = body
+			break
+		}
+	}
+	return data
+}
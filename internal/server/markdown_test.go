@@ -0,0 +1,171 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/internal/testutils"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+func TestDecodeMarkdown(t *testing.T) {
+	tcs := []struct {
+		desc     string
+		in       string
+		wantData map[string]any
+		wantBody string
+		wantErr  bool
+	}{
+		{
+			desc:     "frontmatter and body",
+			in:       "---\ncity: Paris\nlimit: 5\n---\nFind flights.\n",
+			wantData: map[string]any{"city": "Paris", "limit": json.Number("5")},
+			wantBody: "Find flights.\n",
+		},
+		{
+			desc:     "crlf",
+			in:       "---\r\ncity: Paris\r\n---\r\nFind flights.",
+			wantData: map[string]any{"city": "Paris"},
+			wantBody: "Find flights.",
+		},
+		{
+			desc:     "frontmatter only",
+			in:       "---\ncity: Paris\n---",
+			wantData: map[string]any{"city": "Paris"},
+		},
+		{
+			desc:     "empty frontmatter",
+			in:       "---\n---\nFind flights.",
+			wantData: map[string]any{},
+			wantBody: "Find flights.",
+		},
+		{
+			desc:     "no frontmatter",
+			in:       "Find flights.",
+			wantData: map[string]any{},
+			wantBody: "Find flights.",
+		},
+		{desc: "unclosed frontmatter", in: "---\ncity: Paris\n", wantErr: true},
+		{desc: "not a mapping", in: "---\n- Paris\n---\n", wantErr: true},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			data, body, err := decodeMarkdown(strings.NewReader(tc.in))
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got data %v", data)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.wantData, data); diff != "" {
+				t.Errorf("unexpected data (-want +got):\n%s", diff)
+			}
+			if body != tc.wantBody {
+				t.Errorf("unexpected body: got %q, want %q", body, tc.wantBody)
+			}
+		})
+	}
+}
+
+func TestMarkdownInvocation(t *testing.T) {
+	withPrompt := testutils.NewMockTool("with_prompt", "", []parameters.Parameter{
+		parameters.NewStringParameter("city", "Departure city"),
+		parameters.NewStringParameter("prompt", "Prompt", parameters.WithStringRequired(false)),
+	}, false, false)
+	withPrompt.ReturnParamsInInvoke = true
+	withoutPrompt := testutils.NewMockTool("without_prompt", "", []parameters.Parameter{
+		parameters.NewStringParameter("city", "Departure city"),
+	}, false, false)
+	withoutPrompt.ReturnParamsInInvoke = true
+	toolsMap := map[string]tools.Tool{"with_prompt": withPrompt, "without_prompt": withoutPrompt}
+	toolset, err := tools.ToolsetConfig{Name: "", ToolNames: []string{"with_prompt", "without_prompt"}}.Initialize(testutils.MockVersionString, toolsMap)
+	if err != nil {
+		t.Fatalf("unable to initialize toolset: %s", err)
+	}
+
+	r, shutdown := setUpServer(t, "api", toolsMap, map[string]tools.Toolset{"": toolset}, nil, nil)
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	tcs := []struct {
+		desc       string
+		tool       string
+		body       string
+		wantStatus int
+		want       string
+	}{
+		{
+			desc:       "body as prompt",
+			tool:       "with_prompt",
+			body:       "---\ncity: Paris\n---\nFind flights.",
+			wantStatus: http.StatusOK,
+			want:       `["with_prompt","Paris","Find flights."]`,
+		},
+		{
+			desc:       "frontmatter prompt",
+			tool:       "with_prompt",
+			body:       "---\ncity: Paris\nprompt: Find hotels.\n---\nFind flights.",
+			wantStatus: http.StatusOK,
+			want:       `["with_prompt","Paris","Find hotels."]`,
+		},
+		{
+			desc:       "undeclared prompt",
+			tool:       "without_prompt",
+			body:       "---\ncity: Paris\n---\nFind flights.",
+			wantStatus: http.StatusOK,
+			want:       `["without_prompt","Paris"]`,
+		},
+		{
+			desc:       "invalid frontmatter",
+			tool:       "with_prompt",
+			body:       "---\ncity: Paris\n",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			header := map[string]string{"Content-Type": "text/markdown; charset=utf-8"}
+			resp, body, err := runRequest(ts, http.MethodPost, "/tool/"+tc.tool+"/invoke", strings.NewReader(tc.body), header)
+			if err != nil {
+				t.Fatalf("unexpected error during request: %s", err)
+			}
+			if resp.StatusCode != tc.wantStatus {
+				t.Fatalf("unexpected status: got %d, want %d, body: %s", resp.StatusCode, tc.wantStatus, body)
+			}
+			if tc.want == "" {
+				return
+			}
+			var got struct {
+				Result string `json:"result"`
+			}
+			if err := json.Unmarshal(body, &got); err != nil {
+				t.Fatalf("unable to decode response: %s", err)
+			}
+			if got.Result != tc.want {
+				t.Errorf("unexpected result: got %s, want %s", got.Result, tc.want)
+			}
+		})
+	}
+}
@@ -54,12 +54,24 @@ type sseSession struct {
 	done       chan struct{}
 	eventQueue chan string
 	lastActive time.Time
+	// evicted is closed when the session is reclaimed for not answering
+	// pings.
+	evicted chan struct{}
+	// pendingPing is the id of the last ping sent to the client, until it
+	// responds.
+	pendingPing string
+	missedPings int
 }
 
 // sseManager manages and control access to sse sessions
 type sseManager struct {
 	mu          sync.Mutex
 	sseSessions map[string]*sseSession
+	// closing is closed when the server shuts down to end all sessions.
+	closing   chan struct{}
+	closeOnce sync.Once
+	// pingSeq numbers the pings sent to the sessions.
+	pingSeq uint64
 }
 
 func (m *sseManager) get(id string) (*sseSession, bool) {
@@ -81,6 +93,7 @@ func newSseManager(ctx context.Context) *sseManager {
 	sseM := &sseManager{
 		mu:          sync.Mutex{},
 		sseSessions: make(map[string]*sseSession),
+		closing:     make(chan struct{}),
 	}
 	go sseM.cleanupRoutine(ctx)
 	return sseM
@@ -99,6 +112,11 @@ func (m *sseManager) remove(id string) {
 	m.mu.Unlock()
 }
 
+// closeAll ends all open sessions with a close event.
+func (m *sseManager) closeAll() {
+	m.closeOnce.Do(func() { close(m.closing) })
+}
+
 func (m *sseManager) cleanupRoutine(ctx context.Context) {
 	timeout := 10 * time.Minute
 	ticker := time.NewTicker(timeout)
@@ -128,6 +146,8 @@ type stdioSession struct {
 	server   *Server
 	reader   *bufio.Reader
 	writer   io.Writer
+	writeMu  sync.Mutex
+	queue    *invocationQueue
 }
 
 // traceContextCarrier implements propagation.TextMapCarrier for extracting trace context from _meta
@@ -155,10 +175,11 @@ func extractMeta(ctx context.Context, body []byte) (string, context.Context) {
 	var req struct {
 		Params struct {
 			Meta struct {
-				Traceparent     string            `json:"traceparent,omitempty"`
-				Tracestate      string            `json:"tracestate,omitempty"`
-				TelemetryAttrs  map[string]string `json:"dev.mcp-toolbox/telemetry,omitempty"`
-				ProtocolVersion string            `json:"io.modelcontextprotocol/protocolVersion"`
+				Traceparent     string                `json:"traceparent,omitempty"`
+				Tracestate      string                `json:"tracestate,omitempty"`
+				TelemetryAttrs  map[string]string     `json:"dev.mcp-toolbox/telemetry,omitempty"`
+				ProtocolVersion string                `json:"io.modelcontextprotocol/protocolVersion"`
+				ProgressToken   jsonrpc.ProgressToken `json:"progressToken,omitempty"`
 			} `json:"_meta,omitempty"`
 		} `json:"params,omitempty"`
 	}
@@ -190,14 +211,37 @@ func extractMeta(ctx context.Context, body []byte) (string, context.Context) {
 		ctx = util.WithTelemetryAttributes(ctx, ta)
 	}
 
+	// Remember the progress token so that the transport can deliver progress
+	// notifications for this request.
+	if req.Params.Meta.ProgressToken != nil {
+		ctx = context.WithValue(ctx, progressTokenKey{}, req.Params.Meta.ProgressToken)
+	}
+
 	return req.Params.Meta.ProtocolVersion, ctx
 }
 
+// progressTokenKey is the context key of the progress token of a request.
+type progressTokenKey struct{}
+
+// withProgressNotifications attaches a progress reporter that delivers
+// notifications/progress messages through send, if the request carries a
+// progress token.
+func withProgressNotifications(ctx context.Context, send func(notification any)) context.Context {
+	token := ctx.Value(progressTokenKey{})
+	if token == nil {
+		return ctx
+	}
+	return util.WithProgressReporter(ctx, func(progress, total float64, message string) {
+		send(jsonrpc.NewProgressNotification(token, progress, total, message))
+	})
+}
+
 func NewStdioSession(s *Server, stdin io.Reader, stdout io.Writer) *stdioSession {
 	stdioSession := &stdioSession{
 		server: s,
 		reader: bufio.NewReader(stdin),
 		writer: stdout,
+		queue:  newInvocationQueue(s.invocationQueueDepth, s.instrumentation, "pipe"),
 	}
 	return stdioSession
 }
@@ -242,6 +286,11 @@ func (s *stdioSession) readInputStream(ctx context.Context) error {
 		s.server.instrumentation.McpSessionDuration.Record(ctx, sessionDuration, metric.WithAttributes(durationAttrs...))
 	}()
 
+	// Wait for the tool invocations still running, so that their responses
+	// are written before the session ends.
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
 	for {
 		if err = ctx.Err(); err != nil {
 			return err
@@ -256,50 +305,87 @@ func (s *stdioSession) readInputStream(ctx context.Context) error {
 			return err
 		}
 
-		if err := func() error {
-			// This ensures the transport span becomes a child of the client span
-			metaProtocolVersion, msgCtx := extractMeta(ctx, []byte(line))
-
-			// Create span for STDIO transport
-			msgCtx, span := s.server.instrumentation.Tracer.Start(msgCtx, "toolbox/server/mcp/stdio",
-				trace.WithSpanKind(trace.SpanKindServer),
-			)
-			defer span.End()
-
-			protocol := s.protocol
-			// if protocol version was found in meta, it takes precedence
-			// the metaProtocolVersion does not replace existing protocol
-			// version if initialize method took place
-			if protocol == "" && metaProtocolVersion != "" {
-				protocol = metaProtocolVersion
-			}
-
-			var v string
-			var res any
-			v, res, err = processMcpMessage(msgCtx, []byte(line), s.server, protocol, "", "", nil, "")
-			if err != nil {
-				// errors during the processing of message will generate a valid MCP Error response.
-				// server can continue to run.
-				s.server.logger.ErrorContext(msgCtx, err.Error())
-				span.SetStatus(codes.Error, err.Error())
-			}
-
-			if v != "" {
-				s.protocol = v
-			}
-			// no responses for notifications
-			if res != nil {
-				if err = s.write(msgCtx, res); err != nil {
+		// Tool invocations run concurrently, bounded by the queue, so that
+		// a slow database does not hold up the rest of the session.
+		if id, ok := peekToolCall([]byte(line)); ok && s.queue != nil {
+			if !s.queue.tryAcquire(ctx) {
+				if err = s.write(ctx, s.queue.busyError(id)); err != nil {
 					return err
 				}
+				continue
 			}
-			return nil
-		}(); err != nil {
+			protocol := s.protocol
+			wg.Go(func() {
+				defer s.queue.release(ctx)
+				if _, err := s.processLine(ctx, line, protocol); err != nil {
+					s.server.logger.ErrorContext(ctx, fmt.Sprintf("unable to write response: %s", err))
+				}
+			})
+			continue
+		}
+
+		var v string
+		if v, err = s.processLine(ctx, line, s.protocol); err != nil {
 			return err
 		}
+		if v != "" {
+			s.protocol = v
+		}
 	}
 }
 
+// processLine processes a single message and writes the response. It
+// returns the negotiated protocol version, if the message negotiated one,
+// and an error if the response could not be written.
+func (s *stdioSession) processLine(ctx context.Context, line, sessionProtocol string) (string, error) {
+	// This ensures the transport span becomes a child of the client span
+	metaProtocolVersion, msgCtx := extractMeta(ctx, []byte(line))
+
+	// Messages are drained on shutdown rather than canceled with the
+	// session context.
+	msgCtx, done, ok := s.server.invocations.start(context.WithoutCancel(msgCtx))
+	if !ok {
+		return "", errShuttingDown
+	}
+	defer done()
+
+	msgCtx = withProgressNotifications(msgCtx, func(notification any) {
+		if err := s.write(msgCtx, notification); err != nil {
+			s.server.logger.DebugContext(msgCtx, fmt.Sprintf("unable to write progress notification: %s", err))
+		}
+	})
+
+	// Create span for STDIO transport
+	msgCtx, span := s.server.instrumentation.Tracer.Start(msgCtx, "toolbox/server/mcp/stdio",
+		trace.WithSpanKind(trace.SpanKindServer),
+	)
+	defer span.End()
+
+	protocol := sessionProtocol
+	// if protocol version was found in meta, it takes precedence
+	// the metaProtocolVersion does not replace existing protocol
+	// version if initialize method took place
+	if protocol == "" && metaProtocolVersion != "" {
+		protocol = metaProtocolVersion
+	}
+
+	v, res, err := processMcpMessage(msgCtx, []byte(line), s.server, protocol, "", "", nil, "")
+	if err != nil {
+		// errors during the processing of message will generate a valid MCP Error response.
+		// server can continue to run.
+		s.server.logger.ErrorContext(msgCtx, err.Error())
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	// no responses for notifications
+	if res != nil {
+		if err := s.write(msgCtx, res); err != nil {
+			return v, err
+		}
+	}
+	return v, nil
+}
+
 // readLine process each line within the input stream.
 func (s *stdioSession) readLine(ctx context.Context) (string, error) {
 	readChan := make(chan string, 1)
@@ -350,6 +436,9 @@ func (s *stdioSession) write(_ context.Context, response any) error {
 		return fmt.Errorf("failed to marshal response to JSON: %w", err)
 	}
 
+	// responses of concurrent invocations must not interleave
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
 	_, err = fmt.Fprintf(s.writer, "%s\n", res)
 	return err
 }
@@ -369,17 +458,23 @@ func mcpRouter(s *Server) (chi.Router, error) {
 		})
 	})
 	r.Use(mcpAuthMiddleware(s))
+	r.Use(sourceIPMiddleware(s))
 
 	r.Get("/sse", func(w http.ResponseWriter, r *http.Request) { sseHandler(s, w, r) })
-	r.Get("/", func(w http.ResponseWriter, r *http.Request) { methodNotAllowed(s, w, r) })
-	r.Post("/", func(w http.ResponseWriter, r *http.Request) { httpHandler(s, w, r) })
-	r.Delete("/", func(w http.ResponseWriter, r *http.Request) {})
+	r.Get("/", func(w http.ResponseWriter, r *http.Request) { streamableGetHandler(s, w, r) })
+	r.With(drainMiddleware(s), signingMiddleware(s)).Post("/", func(w http.ResponseWriter, r *http.Request) { httpHandler(s, w, r) })
+	r.Delete("/", func(w http.ResponseWriter, r *http.Request) { streamableDeleteHandler(s, w, r) })
+
+	r.Get(mcpExportToolsPath, func(w http.ResponseWriter, r *http.Request) { mcpListToolsHandler(s, w, r) })
+	r.Get(llamaIndexSpecPath, func(w http.ResponseWriter, r *http.Request) { toolSpecHandler(s, w, r, llamaIndexSpec) })
+	r.Get(haystackToolsPath, func(w http.ResponseWriter, r *http.Request) { toolSpecHandler(s, w, r, haystackTools(s.baseURL(r))) })
+	r.With(drainMiddleware(s), signingMiddleware(s)).Post(mcpExportCallPath, func(w http.ResponseWriter, r *http.Request) { mcpCallToolHandler(s, w, r) })
 
 	r.Route("/{toolsetName}", func(r chi.Router) {
 		r.Get("/sse", func(w http.ResponseWriter, r *http.Request) { sseHandler(s, w, r) })
-		r.Get("/", func(w http.ResponseWriter, r *http.Request) { methodNotAllowed(s, w, r) })
-		r.Post("/", func(w http.ResponseWriter, r *http.Request) { httpHandler(s, w, r) })
-		r.Delete("/", func(w http.ResponseWriter, r *http.Request) {})
+		r.Get("/", func(w http.ResponseWriter, r *http.Request) { streamableGetHandler(s, w, r) })
+		r.With(drainMiddleware(s), signingMiddleware(s)).Post("/", func(w http.ResponseWriter, r *http.Request) { httpHandler(s, w, r) })
+		r.Delete("/", func(w http.ResponseWriter, r *http.Request) { streamableDeleteHandler(s, w, r) })
 	})
 
 	return r, nil
@@ -393,6 +488,13 @@ func sseHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 	)
 	r = r.WithContext(ctx)
 
+	if s.invocations.isDraining() {
+		s.logger.DebugContext(ctx, errShuttingDown.Error())
+		_ = render.Render(w, r, newErrResponse(errShuttingDown, http.StatusServiceUnavailable))
+		span.End()
+		return
+	}
+
 	sessionId := uuid.New().String()
 	toolsetName := chi.URLParam(r, "toolsetName")
 	s.logger.DebugContext(ctx, fmt.Sprintf("toolset name: %s", toolsetName))
@@ -444,6 +546,7 @@ func sseHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 		flusher:    flusher,
 		done:       make(chan struct{}),
 		eventQueue: make(chan string, 100),
+		evicted:    make(chan struct{}),
 	}
 	s.sseManager.add(sessionId, session)
 	defer s.sseManager.remove(sessionId)
@@ -466,7 +569,7 @@ func sseHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 	// attach url query params to message endpoint
 	q := r.URL.Query()
 	q.Set("sessionId", sessionId)
-	messageEndpoint := fmt.Sprintf("%s://%s/mcp%s?%s", proto, r.Host, toolsetURL, q.Encode())
+	messageEndpoint := fmt.Sprintf("%s://%s%s/mcp%s?%s", proto, r.Host, namespacePrefix(ctx), toolsetURL, q.Encode())
 	s.logger.DebugContext(ctx, fmt.Sprintf("sending endpoint event: %s", messageEndpoint))
 	fmt.Fprintf(w, "event: endpoint\ndata: %s\n\n", messageEndpoint)
 	flusher.Flush()
@@ -484,6 +587,23 @@ func sseHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 			close(session.done)
 			s.logger.DebugContext(ctx, "client disconnected")
 			return
+		// the client stopped answering pings
+		case <-session.evicted:
+			close(session.done)
+			err = errSessionDead
+			s.logger.DebugContext(ctx, "closing sse session", "error", err)
+			return
+		// let the client know the stream ends rather than resetting it
+		case <-s.sseManager.closing:
+			close(session.done)
+			// flush responses of the invocations drained during shutdown
+			for len(session.eventQueue) > 0 {
+				fmt.Fprint(w, <-session.eventQueue)
+			}
+			fmt.Fprint(w, "event: close\ndata: server shutting down\n\n")
+			flusher.Flush()
+			s.logger.DebugContext(ctx, "closing sse session for shutdown")
+			return
 		}
 	}
 }
@@ -563,13 +683,37 @@ func httpHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 			_ = render.Render(w, r, newErrResponse(err, http.StatusBadRequest))
 			return
 		}
+		// responses to the pings of the server are consumed here
+		if s.sseManager.pong(sessionId, body) {
+			w.WriteHeader(http.StatusAccepted)
+			span.End()
+			return
+		}
 	}
 
-	// check if client have `Mcp-Session-Id` header
-	// `Mcp-Session-Id` is only set for v2025-03-26 in Toolbox
-	headerSessionId := r.Header.Get("Mcp-Session-Id")
+	toolsetName := chi.URLParam(r, "toolsetName")
+	promptsetName := chi.URLParam(r, "promptsetName")
+
+	// check if client have `Mcp-Session-Id` header of a streamable HTTP
+	// session, which carries the protocol version negotiated at
+	// initialization, and the toolset used by the requests without one
+	var stream *streamableSession
+	headerSessionId := r.Header.Get(mcpSessionHeader)
 	if headerSessionId != "" {
 		protocolVersion = v20250326.PROTOCOL_VERSION
+		if s.streamable != nil {
+			var ok bool
+			stream, ok = s.streamable.get(headerSessionId)
+			if !ok {
+				span.End()
+				sessionNotFound(s, w, r, headerSessionId)
+				return
+			}
+			if toolsetName == "" {
+				toolsetName = stream.toolset
+			}
+			protocolVersion = stream.protocolVersion
+		}
 	}
 
 	// check if client have `MCP-Protocol-Version` header
@@ -579,8 +723,6 @@ func httpHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 		protocolVersion = headerProtocolVersion
 	}
 
-	toolsetName := chi.URLParam(r, "toolsetName")
-	promptsetName := chi.URLParam(r, "promptsetName")
 	s.logger.DebugContext(ctx, fmt.Sprintf("toolset name: %s", toolsetName))
 	span.SetAttributes(attribute.String("toolset.name", toolsetName))
 
@@ -593,6 +735,41 @@ func httpHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 
 	networkProtocolVersion := fmt.Sprintf("%d.%d", r.ProtoMajor, r.ProtoMinor)
 
+	// Progress notifications need a channel to the client besides the
+	// response, which is only available for SSE sessions.
+	if session != nil {
+		ctx = withProgressNotifications(ctx, func(notification any) {
+			data, err := json.Marshal(notification)
+			if err != nil {
+				return
+			}
+			select {
+			case session.eventQueue <- fmt.Sprintf("event: message\ndata: %s\n\n", data):
+			default:
+				s.logger.DebugContext(ctx, "sse event queue is full, dropping progress notification")
+			}
+		})
+	}
+
+	if s.httpQueue != nil {
+		if id, ok := peekToolCall(body); ok {
+			if !s.httpQueue.tryAcquire(ctx) {
+				err = fmt.Errorf("invocation queue is full")
+				s.logger.DebugContext(ctx, err.Error())
+				render.Status(r, http.StatusServiceUnavailable)
+				render.JSON(w, r, s.httpQueue.busyError(id))
+				return
+			}
+			defer s.httpQueue.release(ctx)
+		}
+	}
+
+	method, isRequest := peekRequest(body)
+	if stream != nil && isRequest && acceptsEventStream(r) {
+		streamResponse(ctx, s, w, r, stream, body, protocolVersion, toolsetName, promptsetName, networkProtocolVersion)
+		return
+	}
+
 	v, res, err := processMcpMessage(ctx, body, s, protocolVersion, toolsetName, promptsetName, r.Header, networkProtocolVersion)
 	if err != nil {
 		s.logger.DebugContext(ctx, fmt.Errorf("error processing message: %w", err).Error())
@@ -606,10 +783,11 @@ func httpHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// for v20250326, add the `Mcp-Session-Id` header
-	if v == v20250326.PROTOCOL_VERSION {
-		sessionId = uuid.New().String()
-		w.Header().Set("Mcp-Session-Id", sessionId)
+	// a successful initialize over streamable HTTP starts a session
+	_, isErr := res.(jsonrpc.JSONRPCError)
+	if method == "initialize" && !isErr && session == nil && v != v20241105.PROTOCOL_VERSION && s.streamable != nil {
+		sessionId = s.streamable.create(toolsetName, v).id
+		w.Header().Set(mcpSessionHeader, sessionId)
 	}
 
 	if session != nil {
@@ -726,6 +904,12 @@ func processMcpMessage(ctx context.Context, body []byte, s *Server, protocolVers
 		NetworkProtocolVersion: networkProtocolVersion,
 	}
 	ctx = util.WithGenAIMetricAttrs(ctx, genAIAttrs)
+	ctx = s.withToolVariant(ctx, header)
+	ctx = util.WithReplicaLag(ctx, &util.ReplicaLag{})
+	ctx = util.WithResultPage(ctx, &util.ResultPage{})
+	ctx = util.WithParamCoercion(ctx, s.paramCoercion)
+	ctx = util.WithRedactErrorDetails(ctx, s.redactErrorDetails)
+	ctx = s.withUsageRecorder(ctx, toolsetName)
 
 	// Record operation duration metric on function exit
 	defer func() {
@@ -820,6 +1004,10 @@ func processMcpMessage(ctx context.Context, body []byte, s *Server, protocolVers
 	ctx = util.WithInstrumentation(ctx, s.instrumentation)
 	ctx = util.WithToolboxVersionKey(ctx, s.version)
 	ctx = util.WithEnableDraftSpecs(ctx, s.enableDraftSpecs)
+	ctx = util.WithListPageSize(ctx, s.listPageSize)
+	if s.pages != nil {
+		ctx = util.WithResultPager(ctx, s.pages)
+	}
 	// Process the method
 	switch baseMessage.Method {
 	// This is only used for <v2026
@@ -863,6 +1051,14 @@ func processMcpMessage(ctx context.Context, body []byte, s *Server, protocolVers
 			span.SetAttributes(attribute.String("error.type", metricErrorType))
 			return "", rpcErr, err
 		}
+		toolset, accessErr := s.restrictToolset(ctx, header, toolset)
+		if accessErr != nil {
+			rpcErr := jsonrpc.NewError(baseMessage.Id, jsonrpc.INVALID_REQUEST, accessErr.Error(), nil)
+			metricErrorType = rpcErr.Error.String()
+			span.SetStatus(codes.Error, accessErr.Error())
+			span.SetAttributes(attribute.String("error.type", metricErrorType))
+			return "", rpcErr, accessErr
+		}
 		promptset, ok := s.PrimitiveMgr.GetPromptset(promptsetName)
 		if !ok {
 			err := fmt.Errorf("promptset does not exist")
@@ -872,6 +1068,7 @@ func processMcpMessage(ctx context.Context, body []byte, s *Server, protocolVers
 			span.SetAttributes(attribute.String("error.type", metricErrorType))
 			return "", rpcErr, err
 		}
+		ctx = util.WithLocales(ctx, s.requestLocales(header, toolset))
 		result, err := mcp.ProcessMethod(ctx, protocolVersion, baseMessage.Id, baseMessage.Method, toolset, promptset, s.PrimitiveMgr, body, header)
 		if err != nil {
 			span.SetStatus(codes.Error, err.Error())
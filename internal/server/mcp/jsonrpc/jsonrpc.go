@@ -33,6 +33,8 @@ const (
 	HEADER_MISMATCH                    = -32020
 	MISSING_REQUIRED_CLIENT_CAPABILITY = -32021
 	UNSUPPORTED_PROTOCOL_VERSION       = -32022
+	RESOURCE_NOT_FOUND                 = -32002
+	SERVER_BUSY                        = -32005
 
 	// Custom auth error codes
 	UNAUTHORIZED = -401
@@ -83,6 +85,40 @@ type JSONRPCNotification struct {
 	Notification
 }
 
+// PROGRESS_NOTIFICATION is the method of progress notifications.
+const PROGRESS_NOTIFICATION = "notifications/progress"
+
+// ProgressNotification informs the client about the progress of a
+// long-running request that was sent with a progress token.
+type ProgressNotification struct {
+	Jsonrpc string         `json:"jsonrpc"`
+	Method  string         `json:"method"`
+	Params  ProgressParams `json:"params"`
+}
+
+// ProgressParams are the parameters of a progress notification.
+type ProgressParams struct {
+	ProgressToken ProgressToken `json:"progressToken"`
+	Progress      float64       `json:"progress"`
+	Total         float64       `json:"total,omitempty"`
+	Message       string        `json:"message,omitempty"`
+}
+
+// NewProgressNotification returns a progress notification for the request
+// identified by token.
+func NewProgressNotification(token ProgressToken, progress, total float64, message string) ProgressNotification {
+	return ProgressNotification{
+		Jsonrpc: JSONRPC_VERSION,
+		Method:  PROGRESS_NOTIFICATION,
+		Params: ProgressParams{
+			ProgressToken: token,
+			Progress:      progress,
+			Total:         total,
+			Message:       message,
+		},
+	}
+}
+
 // Result represents a response for the request query.
 type Result struct {
 	// This result property is reserved by the protocol to allow clients and
@@ -0,0 +1,49 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+// DryRunHeader requests the dry run of a tool invocation: the statement it
+// would run is returned as its result, and it is not run.
+const DryRunHeader = "X-Toolbox-Dry-Run"
+
+// IsDryRun reports whether header requests a dry run. Requests without
+// headers, such as on stdio, never do.
+func IsDryRun(header http.Header) bool {
+	if header == nil {
+		return false
+	}
+	dryRun, _ := strconv.ParseBool(header.Get(DryRunHeader))
+	return dryRun
+}
+
+// DryRun returns what the invocation of tool would run, as the result of the
+// invocation.
+func DryRun(ctx context.Context, tool tools.Tool, sp tools.SourceProvider, params parameters.ParamValues, token tools.AccessToken) (any, util.ToolboxError) {
+	res, err := tools.DryRun(ctx, tool, sp, params, token)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,58 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/googleapis/mcp-toolbox/internal/server/resultformat"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+)
+
+// FormatMetaKey is the `_meta` key of a tools/call request selecting the
+// output format of its result.
+const FormatMetaKey = "format"
+
+// ResultFormat returns the output format requested for the result of a call
+// of tool, which is JSON if none is. Other formats must be listed in the
+// supportedFormats of the tool, and be text formats, since results are
+// returned as text content.
+func ResultFormat(tool tools.Tool, requested any) (string, error) {
+	if requested == nil || requested == "" || requested == "json" {
+		return "json", nil
+	}
+	format, ok := requested.(string)
+	if !ok {
+		return "", fmt.Errorf("invalid _meta.%s: must be a string", FormatMetaKey)
+	}
+	if !slices.Contains(resultformat.SupportedFormats(tool), format) {
+		return "", fmt.Errorf("invalid _meta.%s: tool %q does not support format %q", FormatMetaKey, tool.GetName(), format)
+	}
+	if !resultformat.IsText(format) {
+		return "", fmt.Errorf("invalid _meta.%s: format %q is binary, and can only be requested from /api/tool/%s/invoke", FormatMetaKey, format, tool.GetName())
+	}
+	return format, nil
+}
+
+// EncodeResult encodes the result of a tool call in a text output format.
+func EncodeResult(format string, res any) (string, error) {
+	var b strings.Builder
+	if err := resultformat.Encode(&b, format, res); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
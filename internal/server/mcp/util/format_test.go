@@ -0,0 +1,89 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"testing"
+
+	"github.com/googleapis/mcp-toolbox/internal/testutils"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+)
+
+// formatConfig is the config of a tool with supportedFormats.
+type formatConfig struct {
+	tools.ConfigBase
+}
+
+func (formatConfig) ToolConfigType() string { return "mock" }
+
+func (formatConfig) Initialize(context.Context) (tools.Tool, error) { return nil, nil }
+
+// formatTool is a tool with supportedFormats.
+type formatTool struct {
+	testutils.MockTool
+	formats []string
+}
+
+func (t formatTool) ToConfig() tools.ToolConfig {
+	return formatConfig{ConfigBase: tools.ConfigBase{Name: t.Name, SupportedFormats: t.formats}}
+}
+
+func TestResultFormat(t *testing.T) {
+	tool := formatTool{MockTool: testutils.NewMockTool("export", "", nil, false, false), formats: []string{"json", "csv", "markdown", "arrow"}}
+	tcs := []struct {
+		desc      string
+		tool      tools.Tool
+		requested any
+		want      string
+		err       string
+	}{
+		{desc: "none requested", tool: tool, want: "json"},
+		{desc: "json", tool: tool, requested: "json", want: "json"},
+		{desc: "markdown", tool: tool, requested: "markdown", want: "markdown"},
+		{desc: "csv", tool: tool, requested: "csv", want: "csv"},
+		{desc: "not a string", tool: tool, requested: 1, err: "invalid _meta.format: must be a string"},
+		{desc: "unsupported", tool: tool, requested: "ndjson", err: `invalid _meta.format: tool "export" does not support format "ndjson"`},
+		{desc: "binary", tool: tool, requested: "arrow", err: `invalid _meta.format: format "arrow" is binary, and can only be requested from /api/tool/export/invoke`},
+		{desc: "json only tool", tool: testutils.NewMockTool("plain", "", nil, false, false), requested: "csv", err: `invalid _meta.format: tool "plain" does not support format "csv"`},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := ResultFormat(tc.tool, tc.requested)
+			if tc.err != "" {
+				if err == nil || err.Error() != tc.err {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != tc.want {
+				t.Errorf("unexpected format: got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestEncodeResult(t *testing.T) {
+	got, err := EncodeResult("csv", []any{map[string]any{"id": 1}, map[string]any{"id": 2}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := "id\n1\n2\n"; got != want {
+		t.Errorf("unexpected result: got %q, want %q", got, want)
+	}
+}
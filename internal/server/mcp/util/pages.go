@@ -0,0 +1,77 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/googleapis/mcp-toolbox/internal/server/pagination"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+)
+
+// NextPageTokenMetaKey is the `_meta` key of the token of the next page of a
+// tool result split into pages.
+const NextPageTokenMetaKey = "nextPageToken"
+
+// AddNextPageTokenMeta returns meta with the token of the next page of the
+// result of the invocation of ctx, if it was split into pages.
+func AddNextPageTokenMeta(ctx context.Context, meta map[string]any) map[string]any {
+	page := util.ResultPageFromContext(ctx)
+	if page == nil || page.NextPageToken == "" {
+		return meta
+	}
+	if meta == nil {
+		meta = make(map[string]any)
+	}
+	meta[NextPageTokenMetaKey] = page.NextPageToken
+	return meta
+}
+
+// resultPage is the content of the resource of a page of a tool result.
+type resultPage struct {
+	Rows          []any  `json:"rows"`
+	NextPageToken string `json:"nextPageToken,omitempty"`
+}
+
+// IsResultPageURI reports whether uri is the URI of a page of a tool result.
+func IsResultPageURI(uri string) bool {
+	return strings.HasPrefix(uri, pagination.ResultURIPrefix)
+}
+
+// ReadResultPage returns the JSON of the page of a tool result with the given
+// URI. Pages of the results of tools outside the toolset are treated as
+// missing.
+func ReadResultPage(ctx context.Context, toolset tools.Toolset, uri string) (string, error) {
+	pager := util.ResultPagerFromContext(ctx)
+	if pager == nil {
+		return "", pagination.ErrInvalidToken
+	}
+	rows, toolName, next, err := pager.Page(strings.TrimPrefix(uri, pagination.ResultURIPrefix))
+	if err != nil {
+		return "", err
+	}
+	if !toolset.ContainsTool(toolName) {
+		return "", pagination.ErrInvalidToken
+	}
+	b, err := json.Marshal(resultPage{Rows: rows, NextPageToken: next})
+	if err != nil {
+		return "", fmt.Errorf("unable to marshal result page: %w", err)
+	}
+	return string(b), nil
+}
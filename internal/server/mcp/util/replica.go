@@ -0,0 +1,39 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+
+	"github.com/googleapis/mcp-toolbox/internal/util"
+)
+
+// ReplicaLagMetaKey is the `_meta` key reporting the replication lag of the
+// replica a tool read from.
+const ReplicaLagMetaKey = "replicaLagSeconds"
+
+// AddReplicaLagMeta returns meta with the replication lag measured for the
+// invocation of ctx, if any.
+func AddReplicaLagMeta(ctx context.Context, meta map[string]any) map[string]any {
+	rl := util.ReplicaLagFromContext(ctx)
+	if rl == nil || !rl.Measured {
+		return meta
+	}
+	if meta == nil {
+		meta = make(map[string]any)
+	}
+	meta[ReplicaLagMetaKey] = rl.Seconds
+	return meta
+}
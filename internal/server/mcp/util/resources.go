@@ -0,0 +1,33 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"github.com/googleapis/mcp-toolbox/internal/resources"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+)
+
+// FindResource returns the resource with the given URI, provided it is
+// exposed by the toolset. Resources outside the toolset are treated as
+// missing so that toolsets cannot read each other's resources.
+func FindResource(toolset tools.Toolset, resourcesMap map[string]resources.Resource, uri string) (resources.Resource, bool) {
+	for _, name := range toolset.ResourceNames {
+		r, ok := resourcesMap[name]
+		if ok && r.URI == uri {
+			return r, true
+		}
+	}
+	return resources.Resource{}, false
+}
@@ -38,6 +38,10 @@ func (m mockParameter) GetRequired() bool                              { return
 func (m mockParameter) GetAuthServices() []parameters.ParamAuthService { return nil }
 func (m mockParameter) GetEmbeddedBy() string                          { return "" }
 func (m mockParameter) GetValueFromParam() string                      { return "" }
+func (m mockParameter) GetRequiredIf() map[string]any                  { return nil }
+func (m mockParameter) GetSuggestions() *parameters.ParamSuggestions   { return nil }
+func (m mockParameter) GetSensitive() bool                             { return false }
+func (m mockParameter) GetFromClaim() *parameters.ClaimBinding         { return nil }
 func (m mockParameter) Parse(any) (any, error)                         { return nil, nil }
 func (m mockParameter) Manifest() parameters.ParameterManifest         { return parameters.ParameterManifest{} }
 func (m mockParameter) McpManifest() (parameters.ParameterMcpManifest, []string) {
@@ -0,0 +1,51 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// ToolVariantMetaKey is the `_meta` key reporting the variant a tool ran.
+const ToolVariantMetaKey = "variant"
+
+// ToolVariantAttr is the metric attribute reporting the variant a tool ran.
+const ToolVariantAttr = "toolbox.tool.variant"
+
+// AddToolVariantMeta returns meta with the variant selected for the
+// invocation of ctx, if any.
+func AddToolVariantMeta(ctx context.Context, meta map[string]any) map[string]any {
+	tv := util.ToolVariantFromContext(ctx)
+	if tv == nil || tv.Selected == "" {
+		return meta
+	}
+	if meta == nil {
+		meta = make(map[string]any)
+	}
+	meta[ToolVariantMetaKey] = tv.Selected
+	return meta
+}
+
+// AddToolVariantAttr returns attrs with the variant selected for the
+// invocation of ctx, if any.
+func AddToolVariantAttr(ctx context.Context, attrs []attribute.KeyValue) []attribute.KeyValue {
+	if tv := util.ToolVariantFromContext(ctx); tv != nil && tv.Selected != "" {
+		attrs = append(attrs, attribute.String(ToolVariantAttr, tv.Selected))
+	}
+	return attrs
+}
@@ -168,7 +168,7 @@ func TestToolsListHandler(t *testing.T) {
 	// Initialize tools using provided testutils mock instances
 	mockTools := []testutils.MockTool{testutils.MockTool1, testutils.MockTool2}
 	toolsMap, toolsets, promptsMap, promptsets := testutils.SetUpResources(t, mockTools, nil)
-	primitiveMgr := primitives.NewPrimitiveManager(nil, nil, nil, toolsMap, toolsets, promptsMap, promptsets)
+	primitiveMgr := primitives.NewPrimitiveManager(nil, nil, nil, toolsMap, toolsets, promptsMap, promptsets, nil)
 
 	tests := []struct {
 		name        string
@@ -257,7 +257,7 @@ func TestToolsCallHandler(t *testing.T) {
 		testutils.MockTool5,
 	}
 	toolsMap, toolsets, promptsMap, promptsets := testutils.SetUpResources(t, mockTools, nil)
-	primitiveMgr := primitives.NewPrimitiveManager(nil, nil, nil, toolsMap, toolsets, promptsMap, promptsets)
+	primitiveMgr := primitives.NewPrimitiveManager(nil, nil, nil, toolsMap, toolsets, promptsMap, promptsets, nil)
 
 	tests := []struct {
 		name        string
@@ -283,6 +283,7 @@ func TestToolsCallHandler(t *testing.T) {
 				Params: struct {
 					Name      string         `json:"name"`
 					Arguments map[string]any `json:"arguments,omitempty"`
+					Meta      map[string]any `json:"_meta,omitempty"`
 				}{
 					Name: "no_params",
 				},
@@ -300,6 +301,7 @@ func TestToolsCallHandler(t *testing.T) {
 				Params: struct {
 					Name      string         `json:"name"`
 					Arguments map[string]any `json:"arguments,omitempty"`
+					Meta      map[string]any `json:"_meta,omitempty"`
 				}{
 					Name: "unknown_tool",
 				},
@@ -317,6 +319,7 @@ func TestToolsCallHandler(t *testing.T) {
 				Params: struct {
 					Name      string         `json:"name"`
 					Arguments map[string]any `json:"arguments,omitempty"`
+					Meta      map[string]any `json:"_meta,omitempty"`
 				}{
 					Name: "require_client_auth_tool",
 				},
@@ -334,6 +337,7 @@ func TestToolsCallHandler(t *testing.T) {
 				Params: struct {
 					Name      string         `json:"name"`
 					Arguments map[string]any `json:"arguments,omitempty"`
+					Meta      map[string]any `json:"_meta,omitempty"`
 				}{
 					Name: "no_params",
 				},
@@ -385,7 +389,7 @@ func TestPromptsListHandler(t *testing.T) {
 	// Initialize prompts
 	mockPrompts := []testutils.MockPrompt{testutils.MockPrompt1, testutils.MockPrompt2}
 	toolsMap, toolsets, promptsMap, promptsets := testutils.SetUpResources(t, nil, mockPrompts)
-	primitiveMgr := primitives.NewPrimitiveManager(nil, nil, nil, toolsMap, toolsets, promptsMap, promptsets)
+	primitiveMgr := primitives.NewPrimitiveManager(nil, nil, nil, toolsMap, toolsets, promptsMap, promptsets, nil)
 	tests := []struct {
 		name        string
 		body        ListPromptsRequest
@@ -454,7 +458,7 @@ func TestPromptsGetHandler(t *testing.T) {
 	// Initialize prompts
 	mockPrompts := []testutils.MockPrompt{testutils.MockPrompt1, testutils.MockPrompt2}
 	toolsMap, toolsets, promptsMap, promptsets := testutils.SetUpResources(t, nil, mockPrompts)
-	primitiveMgr := primitives.NewPrimitiveManager(nil, nil, nil, toolsMap, toolsets, promptsMap, promptsets)
+	primitiveMgr := primitives.NewPrimitiveManager(nil, nil, nil, toolsMap, toolsets, promptsMap, promptsets, nil)
 	tests := []struct {
 		name        string
 		body        GetPromptRequest
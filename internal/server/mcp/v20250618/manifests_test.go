@@ -249,7 +249,7 @@ func TestGenerateListToolsResult(t *testing.T) {
 		t.Fatalf("unable to initialize toolset %q: %s", "test-toolset", err)
 	}
 
-	got, err := GenerateListToolsResult(nil, toolset, toolsMap, nil)
+	got, err := GenerateListToolsResult(nil, toolset, toolsMap, nil, nil)
 	if err != nil {
 		t.Fatalf("unable to generate list tools result: %s", err)
 	}
@@ -34,6 +34,9 @@ const (
 	TOOLS_CALL   = "tools/call"
 	PROMPTS_LIST = "prompts/list"
 	PROMPTS_GET  = "prompts/get"
+
+	RESOURCES_LIST = "resources/list"
+	RESOURCES_READ = "resources/read"
 )
 
 /* Initialization */
@@ -99,8 +102,9 @@ type ClientCapabilities struct {
 // capabilities are defined here, in this schema, but this is not a closed set: any
 // server can define its own, additional capabilities.
 type ServerCapabilities struct {
-	Tools   *ListChanged `json:"tools,omitempty"`
-	Prompts *ListChanged `json:"prompts,omitempty"`
+	Tools     *ListChanged `json:"tools,omitempty"`
+	Prompts   *ListChanged `json:"prompts,omitempty"`
+	Resources *ListChanged `json:"resources,omitempty"`
 }
 
 // Base interface for metadata with name (identifier) and title (display name) properties.
@@ -168,6 +172,9 @@ type Tool struct {
 	Description string `json:"description,omitempty"`
 	// A JSON Schema object defining the expected parameters for the tool.
 	ToolInputSchema InputSchema `json:"inputSchema,omitempty"`
+	// An optional JSON Schema object defining the structure of the
+	// structuredContent of the results of the tool.
+	OutputSchema map[string]any `json:"outputSchema,omitempty"`
 	// Optional additional tool information.
 	Annotations *ToolAnnotations `json:"annotations,omitempty"`
 	// See [General fields: `_meta`](/specification/2025-06-18/basic/index#_meta) for notes on `_meta` usage.
@@ -186,6 +193,9 @@ type CallToolRequest struct {
 	Params struct {
 		Name      string         `json:"name"`
 		Arguments map[string]any `json:"arguments,omitempty"`
+		// Meta may select the output format of the result with the
+		// mcputil.FormatMetaKey key.
+		Meta map[string]any `json:"_meta,omitempty"`
 	} `json:"params,omitempty"`
 }
 
@@ -343,3 +353,54 @@ type PromptMessage struct {
 	Role    string      `json:"role"`
 	Content TextContent `json:"content"`
 }
+
+/* Resources */
+
+// Sent from the client to request a list of resources the server has.
+type ListResourcesRequest struct {
+	PaginatedRequest
+}
+
+// The server's response to a resources/list request from the client.
+type ListResourcesResult struct {
+	PaginatedResult
+	Resources []Resource `json:"resources"`
+}
+
+// A known resource that the server is capable of reading.
+type Resource struct {
+	BaseMetadata
+	// The URI of this resource.
+	URI string `json:"uri"`
+	// A description of what this resource represents.
+	Description string `json:"description,omitempty"`
+	// The MIME type of this resource, if known.
+	MimeType string `json:"mimeType,omitempty"`
+	// The size of the raw resource content, in bytes, if known.
+	Size int `json:"size,omitempty"`
+}
+
+// Sent from the client to the server, to read a specific resource URI.
+type ReadResourceRequest struct {
+	jsonrpc.Request
+	Params struct {
+		// The URI of the resource to read.
+		URI string `json:"uri"`
+	} `json:"params"`
+}
+
+// The server's response to a resources/read request from the client.
+type ReadResourceResult struct {
+	jsonrpc.Result
+	Contents []TextResourceContents `json:"contents"`
+}
+
+// The text contents of a specific resource.
+type TextResourceContents struct {
+	// The URI of this resource.
+	URI string `json:"uri"`
+	// The MIME type of this resource, if known.
+	MimeType string `json:"mimeType,omitempty"`
+	// The text of the item.
+	Text string `json:"text"`
+}
@@ -22,6 +22,7 @@ import (
 	"testing"
 
 	"github.com/googleapis/mcp-toolbox/internal/log"
+	"github.com/googleapis/mcp-toolbox/internal/resources"
 	"github.com/googleapis/mcp-toolbox/internal/server/mcp/jsonrpc"
 	"github.com/googleapis/mcp-toolbox/internal/server/primitives"
 	"github.com/googleapis/mcp-toolbox/internal/testutils"
@@ -168,7 +169,7 @@ func TestToolsListHandler(t *testing.T) {
 	// Initialize tools using provided testutils mock instances
 	mockTools := []testutils.MockTool{testutils.MockTool1, testutils.MockTool2}
 	toolsMap, toolsets, promptsMap, promptsets := testutils.SetUpResources(t, mockTools, nil)
-	primitiveMgr := primitives.NewPrimitiveManager(nil, nil, nil, toolsMap, toolsets, promptsMap, promptsets)
+	primitiveMgr := primitives.NewPrimitiveManager(nil, nil, nil, toolsMap, toolsets, promptsMap, promptsets, nil)
 
 	tests := []struct {
 		name        string
@@ -257,7 +258,7 @@ func TestToolsCallHandler(t *testing.T) {
 		testutils.MockTool5,
 	}
 	toolsMap, toolsets, promptsMap, promptsets := testutils.SetUpResources(t, mockTools, nil)
-	primitiveMgr := primitives.NewPrimitiveManager(nil, nil, nil, toolsMap, toolsets, promptsMap, promptsets)
+	primitiveMgr := primitives.NewPrimitiveManager(nil, nil, nil, toolsMap, toolsets, promptsMap, promptsets, nil)
 
 	tests := []struct {
 		name        string
@@ -283,6 +284,7 @@ func TestToolsCallHandler(t *testing.T) {
 				Params: struct {
 					Name      string         `json:"name"`
 					Arguments map[string]any `json:"arguments,omitempty"`
+					Meta      map[string]any `json:"_meta,omitempty"`
 				}{
 					Name: "no_params",
 				},
@@ -300,6 +302,7 @@ func TestToolsCallHandler(t *testing.T) {
 				Params: struct {
 					Name      string         `json:"name"`
 					Arguments map[string]any `json:"arguments,omitempty"`
+					Meta      map[string]any `json:"_meta,omitempty"`
 				}{
 					Name: "unknown_tool",
 				},
@@ -317,6 +320,7 @@ func TestToolsCallHandler(t *testing.T) {
 				Params: struct {
 					Name      string         `json:"name"`
 					Arguments map[string]any `json:"arguments,omitempty"`
+					Meta      map[string]any `json:"_meta,omitempty"`
 				}{
 					Name: "require_client_auth_tool",
 				},
@@ -334,6 +338,7 @@ func TestToolsCallHandler(t *testing.T) {
 				Params: struct {
 					Name      string         `json:"name"`
 					Arguments map[string]any `json:"arguments,omitempty"`
+					Meta      map[string]any `json:"_meta,omitempty"`
 				}{
 					Name: "no_params",
 				},
@@ -385,7 +390,7 @@ func TestPromptsListHandler(t *testing.T) {
 	// Initialize prompts
 	mockPrompts := []testutils.MockPrompt{testutils.MockPrompt1, testutils.MockPrompt2}
 	toolsMap, toolsets, promptsMap, promptsets := testutils.SetUpResources(t, nil, mockPrompts)
-	primitiveMgr := primitives.NewPrimitiveManager(nil, nil, nil, toolsMap, toolsets, promptsMap, promptsets)
+	primitiveMgr := primitives.NewPrimitiveManager(nil, nil, nil, toolsMap, toolsets, promptsMap, promptsets, nil)
 	tests := []struct {
 		name        string
 		body        ListPromptsRequest
@@ -454,7 +459,7 @@ func TestPromptsGetHandler(t *testing.T) {
 	// Initialize prompts
 	mockPrompts := []testutils.MockPrompt{testutils.MockPrompt1, testutils.MockPrompt2}
 	toolsMap, toolsets, promptsMap, promptsets := testutils.SetUpResources(t, nil, mockPrompts)
-	primitiveMgr := primitives.NewPrimitiveManager(nil, nil, nil, toolsMap, toolsets, promptsMap, promptsets)
+	primitiveMgr := primitives.NewPrimitiveManager(nil, nil, nil, toolsMap, toolsets, promptsMap, promptsets, nil)
 	tests := []struct {
 		name        string
 		body        GetPromptRequest
@@ -549,3 +554,157 @@ func TestPromptsGetHandler(t *testing.T) {
 		})
 	}
 }
+
+// setUpResourceToolsets returns a resources map with a single runbook resource,
+// a "docs" toolset that exposes it and an "other" toolset that does not.
+func setUpResourceToolsets(t *testing.T) (map[string]resources.Resource, map[string]tools.Toolset) {
+	t.Helper()
+	r, err := resources.ResourceConfig{
+		Name:     "runbook",
+		URI:      "docs://runbook",
+		MimeType: "text/markdown",
+		Text:     "# Runbook",
+	}.Initialize()
+	if err != nil {
+		t.Fatalf("unable to initialize resource: %s", err)
+	}
+	resourcesMap := map[string]resources.Resource{"runbook": r}
+	toolsets := make(map[string]tools.Toolset)
+	for _, tc := range []tools.ToolsetConfig{
+		{Name: "docs", ResourceNames: []string{"runbook"}},
+		{Name: "other"},
+	} {
+		ts, err := tc.Initialize(fakeVersionString, nil)
+		if err != nil {
+			t.Fatalf("unable to initialize toolset: %s", err)
+		}
+		toolsets[tc.Name] = ts
+	}
+	return resourcesMap, toolsets
+}
+
+func TestResourcesListHandler(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	testLogger, err := log.NewStdLogger(os.Stdout, os.Stderr, "info")
+	if err != nil {
+		t.Fatalf("unable to initialize logger: %s", err)
+	}
+	ctx = util.WithLogger(ctx, testLogger)
+	resourcesMap, toolsets := setUpResourceToolsets(t)
+	primitiveMgr := primitives.NewPrimitiveManager(nil, nil, nil, nil, toolsets, nil, nil, resourcesMap)
+
+	tests := []struct {
+		name      string
+		toolset   string
+		wantNames []string
+	}{
+		{
+			name:      "toolset with resource",
+			toolset:   "docs",
+			wantNames: []string{"runbook"},
+		},
+		{
+			name:      "toolset without resource",
+			toolset:   "other",
+			wantNames: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := json.Marshal(ListResourcesRequest{
+				PaginatedRequest: PaginatedRequest{
+					Request: jsonrpc.Request{Method: RESOURCES_LIST},
+				},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error during marshaling")
+			}
+			got, err := resourcesListHandler(ctx, dummyID, primitiveMgr, toolsets[tt.toolset], body)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			result := got.(jsonrpc.JSONRPCResponse).Result.(ListResourcesResult)
+			gotNames := make([]string, 0, len(result.Resources))
+			for _, r := range result.Resources {
+				gotNames = append(gotNames, r.Name)
+			}
+			if strings.Join(gotNames, ",") != strings.Join(tt.wantNames, ",") {
+				t.Errorf("got resources %v, want %v", gotNames, tt.wantNames)
+			}
+		})
+	}
+}
+
+func TestResourcesReadHandler(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	testLogger, err := log.NewStdLogger(os.Stdout, os.Stderr, "info")
+	if err != nil {
+		t.Fatalf("unable to initialize logger: %s", err)
+	}
+	ctx = util.WithLogger(ctx, testLogger)
+	resourcesMap, toolsets := setUpResourceToolsets(t)
+	primitiveMgr := primitives.NewPrimitiveManager(nil, nil, nil, nil, toolsets, nil, nil, resourcesMap)
+
+	tests := []struct {
+		name     string
+		toolset  string
+		uri      string
+		wantText string
+		wantErr  bool
+	}{
+		{
+			name:     "success",
+			toolset:  "docs",
+			uri:      "docs://runbook",
+			wantText: "# Runbook",
+		},
+		{
+			name:    "unknown uri",
+			toolset: "docs",
+			uri:     "docs://missing",
+			wantErr: true,
+		},
+		{
+			name:    "resource not in toolset",
+			toolset: "other",
+			uri:     "docs://runbook",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := ReadResourceRequest{Request: jsonrpc.Request{Method: RESOURCES_READ}}
+			req.Params.URI = tt.uri
+			body, err := json.Marshal(req)
+			if err != nil {
+				t.Fatalf("unexpected error during marshaling")
+			}
+			got, err := resourcesReadHandler(ctx, dummyID, primitiveMgr, toolsets[tt.toolset], body)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got nil")
+				}
+				rpcErr, ok := got.(jsonrpc.JSONRPCError)
+				if !ok || rpcErr.Error.Code != jsonrpc.RESOURCE_NOT_FOUND {
+					t.Errorf("got %v, want error code %d", got, jsonrpc.RESOURCE_NOT_FOUND)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			result := got.(jsonrpc.JSONRPCResponse).Result.(ReadResourceResult)
+			if len(result.Contents) != 1 {
+				t.Fatalf("got %d contents, want 1", len(result.Contents))
+			}
+			if result.Contents[0].Text != tt.wantText {
+				t.Errorf("got text %q, want %q", result.Contents[0].Text, tt.wantText)
+			}
+			if result.Contents[0].MimeType != "text/markdown" {
+				t.Errorf("got mime type %q, want %q", result.Contents[0].MimeType, "text/markdown")
+			}
+		})
+	}
+}
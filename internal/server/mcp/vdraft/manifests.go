@@ -18,6 +18,7 @@ import (
 	"fmt"
 
 	"github.com/googleapis/mcp-toolbox/internal/prompts"
+	"github.com/googleapis/mcp-toolbox/internal/resources"
 	"github.com/googleapis/mcp-toolbox/internal/sources"
 	"github.com/googleapis/mcp-toolbox/internal/tools"
 	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
@@ -63,8 +64,9 @@ func generateParamManifest(ps parameters.Parameters, urlParams map[string]string
 	authParam := make(map[string][]string)
 
 	for _, p := range ps {
-		// If the parameter is sourced from another param, skip it in the MCP manifest
-		if p.GetValueFromParam() != "" {
+		// If the parameter is sourced from another param or from a claim,
+		// skip it in the MCP manifest
+		if parameters.IsHidden(p) {
 			continue
 		}
 
@@ -97,8 +99,9 @@ func generateParamManifest(ps parameters.Parameters, urlParams map[string]string
 	}, authParam
 }
 
-// GenerateListToolsResult generates tools/list method result according to mcp schema
-func GenerateListToolsResult(srcs map[string]sources.Source, t tools.Toolset, toolsMap map[string]tools.Tool, urlParams map[string]string) (ListToolsResult, error) {
+// GenerateListToolsResult generates tools/list method result according to mcp schema,
+// with the descriptions of tools in the first of locales they are translated to
+func GenerateListToolsResult(srcs map[string]sources.Source, t tools.Toolset, toolsMap map[string]tools.Tool, urlParams map[string]string, locales []string) (ListToolsResult, error) {
 	mcpManifest := make([]Tool, 0, len(t.ToolNames))
 	for _, toolName := range t.ToolNames {
 		tool, ok := toolsMap[toolName]
@@ -109,7 +112,15 @@ func GenerateListToolsResult(srcs map[string]sources.Source, t tools.Toolset, to
 		if err != nil {
 			return ListToolsResult{}, fmt.Errorf("error getting parameters for tool %q: %w", toolName, err)
 		}
-		toolManifest := generateToolManifest(toolName, tool.GetDescription(), tool.GetAuthRequired(), params, tool.GetAnnotations(), urlParams)
+		text := tools.Localize(tool, locales)
+		toolManifest := generateToolManifest(toolName, text.Description, tool.GetAuthRequired(), params, tool.GetAnnotations(), urlParams)
+		toolManifest.OutputSchema = tools.MCPOutputSchemaOf(tool)
+		for name, desc := range text.Parameters {
+			if p, ok := toolManifest.ToolInputSchema.Properties[name]; ok {
+				p.Description = desc
+				toolManifest.ToolInputSchema.Properties[name] = p
+			}
+		}
 		mcpManifest = append(mcpManifest, toolManifest)
 	}
 	res := ListToolsResult{
@@ -166,3 +177,34 @@ func GenerateListPromptsResult(p prompts.Promptset, promptsMap map[string]prompt
 	}
 	return res, nil
 }
+
+// GenerateListResourcesResult generates the resources/list result for the
+// resources exposed by the toolset.
+func GenerateListResourcesResult(t tools.Toolset, resourcesMap map[string]resources.Resource) (ListResourcesResult, error) {
+	mcpManifest := make([]Resource, 0, len(t.ResourceNames))
+	for _, resourceName := range t.ResourceNames {
+		r, ok := resourcesMap[resourceName]
+		if !ok {
+			return ListResourcesResult{}, fmt.Errorf("resource does not exist: %s", resourceName)
+		}
+		m := r.Manifest()
+		mcpManifest = append(mcpManifest, Resource{
+			BaseMetadata: BaseMetadata{Name: m.Name},
+			URI:          m.URI,
+			Description:  m.Description,
+			MimeType:     m.MimeType,
+			Size:         m.Size,
+		})
+	}
+	res := ListResourcesResult{
+		Resources: mcpManifest,
+		Result: Result{
+			ResultType: resultTypeComplete,
+		},
+		CacheableResult: CacheableResult{
+			TtlMs:      300000, // 5 minutes
+			CacheScope: cacheScopePublic,
+		},
+	}
+	return res, nil
+}
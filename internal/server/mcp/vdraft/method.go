@@ -27,6 +27,7 @@ import (
 	"github.com/googleapis/mcp-toolbox/internal/prompts"
 	"github.com/googleapis/mcp-toolbox/internal/server/mcp/jsonrpc"
 	mcputil "github.com/googleapis/mcp-toolbox/internal/server/mcp/util"
+	"github.com/googleapis/mcp-toolbox/internal/server/pagination"
 	"github.com/googleapis/mcp-toolbox/internal/server/primitives"
 	"github.com/googleapis/mcp-toolbox/internal/tools"
 	"github.com/googleapis/mcp-toolbox/internal/util"
@@ -49,6 +50,10 @@ func ProcessMethod(ctx context.Context, id jsonrpc.RequestId, method string, too
 		return promptsListHandler(ctx, id, primitiveMgr, promptset, body, header)
 	case PROMPTS_GET:
 		return promptsGetHandler(ctx, id, promptset, primitiveMgr, body, header)
+	case RESOURCES_LIST:
+		return resourcesListHandler(ctx, id, primitiveMgr, toolset, body, header)
+	case RESOURCES_READ:
+		return resourcesReadHandler(ctx, id, primitiveMgr, toolset, body, header)
 	default:
 		err := fmt.Errorf("invalid method %s", method)
 		return jsonrpc.NewError(id, jsonrpc.METHOD_NOT_FOUND, err.Error(), nil), err
@@ -181,6 +186,7 @@ func serverDiscoverHandler(ctx context.Context, id jsonrpc.RequestId, body []byt
 
 	toolsListChanged := false
 	promptsListChanged := false
+	resourcesListChanged := false
 	meta, err := getResultMetadata(ctx, nil)
 	if err != nil {
 		return jsonrpc.NewError(id, jsonrpc.INTERNAL_ERROR, err.Error(), nil), err
@@ -200,6 +206,9 @@ func serverDiscoverHandler(ctx context.Context, id jsonrpc.RequestId, body []byt
 			Prompts: &ListChanged{
 				ListChanged: &promptsListChanged,
 			},
+			Resources: &ListChanged{
+				ListChanged: &resourcesListChanged,
+			},
 		},
 	}
 	res := jsonrpc.JSONRPCResponse{
@@ -227,11 +236,17 @@ func toolsListHandler(ctx context.Context, id jsonrpc.RequestId, primitiveMgr *p
 
 	urlParams, _ := util.UrlParamsFromContext(ctx)
 	toolsMap := primitiveMgr.GetToolsMap()
-	listToolsResult, err := GenerateListToolsResult(primitiveMgr.GetSourcesMap(), toolset, toolsMap, urlParams)
+	listToolsResult, err := GenerateListToolsResult(primitiveMgr.GetSourcesMap(), toolset, toolsMap, urlParams, util.LocalesFromContext(ctx))
 	if err != nil {
 		err = fmt.Errorf("error generating manifest: %w", err)
 		return jsonrpc.NewError(id, jsonrpc.INTERNAL_ERROR, err.Error(), nil), err
 	}
+	listToolsResult.Tools = markDisabledTools(listToolsResult.Tools, primitiveMgr, urlParams)
+	page, next, err := pagination.Page(listToolsResult.Tools, string(req.Params.Cursor), util.ListPageSizeFromContext(ctx))
+	if err != nil {
+		return jsonrpc.NewError(id, jsonrpc.INVALID_PARAMS, err.Error(), nil), err
+	}
+	listToolsResult.Tools, listToolsResult.NextCursor = page, Cursor(next)
 	meta, err := getResultMetadata(ctx, listToolsResult.Meta)
 	if err != nil {
 		return jsonrpc.NewError(id, jsonrpc.INTERNAL_ERROR, err.Error(), nil), err
@@ -244,6 +259,26 @@ func toolsListHandler(ctx context.Context, id jsonrpc.RequestId, primitiveMgr *p
 	}, nil
 }
 
+// markDisabledTools marks the tools disabled by an administrator in their
+// metadata, or removes them if the hideDisabled URL parameter is "true".
+func markDisabledTools(list []Tool, primitiveMgr *primitives.PrimitiveManager, urlParams map[string]string) []Tool {
+	hide := urlParams["hideDisabled"] == "true"
+	out := make([]Tool, 0, len(list))
+	for _, t := range list {
+		if primitiveMgr.IsToolDisabled(t.Name) {
+			if hide {
+				continue
+			}
+			if t.Metadata == nil {
+				t.Metadata = make(map[string]any)
+			}
+			t.Metadata["toolbox/disabled"] = true
+		}
+		out = append(out, t)
+	}
+	return out
+}
+
 // toolsCallHandler generate a response for tools call.
 func toolsCallHandler(ctx context.Context, id jsonrpc.RequestId, toolset tools.Toolset, primitiveMgr *primitives.PrimitiveManager, body []byte, header http.Header) (any, error) {
 	authServices := primitiveMgr.GetAuthServiceMap()
@@ -295,6 +330,19 @@ func toolsCallHandler(ctx context.Context, id jsonrpc.RequestId, toolset tools.T
 		err = fmt.Errorf("invalid tool name: tool with name %q does not exist", toolName)
 		return jsonrpc.NewError(id, jsonrpc.INVALID_PARAMS, err.Error(), nil), err
 	}
+	if err := primitiveMgr.CheckToolEnabled(toolName); err != nil {
+		return jsonrpc.NewError(id, jsonrpc.INVALID_REQUEST, err.Error(), nil), err
+	}
+	var requestedFormat string
+	if req.Params.Meta != nil {
+		requestedFormat = req.Params.Meta.Format
+	}
+	format, err := mcputil.ResultFormat(tool, requestedFormat)
+	if err != nil {
+		return jsonrpc.NewError(id, jsonrpc.INVALID_PARAMS, err.Error(), nil), err
+	}
+	phases := tools.TraceInvocation(ctx, tool)
+	defer phases.End(nil)
 
 	// Populate gen_ai attributes for operation duration metric
 	if genAIAttrs := util.GenAIMetricAttrsFromContext(ctx); genAIAttrs != nil {
@@ -349,8 +397,12 @@ func toolsCallHandler(ctx context.Context, id jsonrpc.RequestId, toolset tools.T
 	}
 
 	// Tool authentication
+	ctx = phases.Phase(ctx, tools.PhaseAuthorize)
 	// claimsFromAuth maps the name of the authservice to the claims retrieved from it.
 	claimsFromAuth := make(map[string]map[string]any)
+	// expiredErr is the first token rejected for having expired, reported
+	// instead of a generic error if the call is not authorized.
+	var expiredErr *auth.TokenExpiredError
 
 	// if using stdio, header will be nil and auth will not be supported
 	if header != nil {
@@ -364,6 +416,9 @@ func toolsCallHandler(ctx context.Context, id jsonrpc.RequestId, toolset tools.T
 				claims, err = aS.GetClaimsFromHeader(ctx, header)
 				if err != nil {
 					logger.DebugContext(ctx, err.Error())
+					if expiredErr == nil {
+						errors.As(err, &expiredErr)
+					}
 					continue
 				}
 			}
@@ -376,6 +431,8 @@ func toolsCallHandler(ctx context.Context, id jsonrpc.RequestId, toolset tools.T
 		}
 	}
 
+	ctx = util.WithAuthServiceClaims(ctx, claimsFromAuth)
+
 	// Tool authorization check
 	verifiedAuthServices := make([]string, len(claimsFromAuth))
 	i := 0
@@ -387,6 +444,9 @@ func toolsCallHandler(ctx context.Context, id jsonrpc.RequestId, toolset tools.T
 	// Check if any of the specified auth services is verified
 	isAuthorized := tool.Authorized(verifiedAuthServices)
 	if !isAuthorized {
+		if expiredErr != nil {
+			return tokenExpiredResult(id, meta, expiredErr), expiredErr
+		}
 		err = util.NewClientServerError(
 			"unauthorized Tool call: Please make sure you specify correct auth headers",
 			http.StatusUnauthorized,
@@ -399,7 +459,14 @@ func toolsCallHandler(ctx context.Context, id jsonrpc.RequestId, toolset tools.T
 	if err := mcputil.ValidateScopes(ctx, tool.GetScopesRequired(), authServices); err != nil {
 		return jsonrpc.NewError(id, jsonrpc.INVALID_REQUEST, err.Error(), nil), err
 	}
+	if err := tools.CheckIntent(tool, claimsFromAuth); err != nil {
+		return jsonrpc.NewError(id, jsonrpc.INVALID_REQUEST, err.Error(), nil), err
+	}
+	if err := tools.CheckAllowedCIDRs(ctx, tool); err != nil {
+		return jsonrpc.NewError(id, jsonrpc.INVALID_REQUEST, err.Error(), nil), err
+	}
 
+	ctx = phases.Phase(ctx, tools.PhaseParseParams)
 	toolParams, err := tool.GetParameters(primitiveMgr.GetSourcesMap())
 	if err != nil {
 		err = fmt.Errorf("error getting parameters for tool: %w", err)
@@ -409,12 +476,17 @@ func toolsCallHandler(ctx context.Context, id jsonrpc.RequestId, toolset tools.T
 	// Auto-populate arguments from URL parameters
 	data = mcputil.PopulateUrlParams(ctx, data, toolParams)
 
+	data = tools.CoerceParams(ctx, tool, toolParams, data)
 	params, err := parameters.ParseParams(toolParams, data, claimsFromAuth)
 	if err != nil {
+		var clientServerErr *util.ClientServerError
+		if expiredErr != nil && errors.As(err, &clientServerErr) && clientServerErr.Code == http.StatusUnauthorized {
+			return tokenExpiredResult(id, meta, expiredErr), expiredErr
+		}
 		err = fmt.Errorf("provided parameters were invalid: %w", err)
 		return jsonrpc.NewError(id, jsonrpc.INVALID_PARAMS, err.Error(), nil), err
 	}
-	logger.DebugContext(ctx, fmt.Sprintf("invocation params: %s", params))
+	logger.DebugContext(ctx, fmt.Sprintf("invocation params: %s", parameters.Redact(toolParams, params)))
 
 	embeddingModels := primitiveMgr.GetEmbeddingModelMap()
 	params, err = tool.EmbedParams(ctx, params, embeddingModels)
@@ -427,9 +499,18 @@ func toolsCallHandler(ctx context.Context, id jsonrpc.RequestId, toolset tools.T
 	instrumentation, instrumentationErr := util.InstrumentationFromContext(ctx)
 
 	// run tool invocation and generate response.
+	ctx = phases.Phase(ctx, tools.PhaseExecute)
 	executionStart := time.Now()
-	results, err := tool.Invoke(ctx, primitiveMgr, params, accessToken)
+	// a dry run returns the statement the call would run as its result
+	dryRun := mcputil.IsDryRun(header)
+	var results any
+	if dryRun {
+		results, err = mcputil.DryRun(ctx, tool, primitiveMgr, params, accessToken)
+	} else {
+		results, err = tool.Invoke(ctx, primitiveMgr, params, accessToken)
+	}
 	executionDuration := time.Since(executionStart).Seconds()
+	phases.End(err)
 
 	// Record tool execution duration metric
 	if instrumentationErr == nil {
@@ -448,20 +529,31 @@ func toolsCallHandler(ctx context.Context, id jsonrpc.RequestId, toolset tools.T
 		if err != nil {
 			execAttrs = append(execAttrs, attribute.String("error.type", err.Error()))
 		}
+		execAttrs = mcputil.AddToolVariantAttr(ctx, execAttrs)
 		instrumentation.ToolExecutionDuration.Record(ctx, executionDuration, metric.WithAttributes(execAttrs...))
 	}
+	if r := util.UsageRecorderFromContext(ctx); r != nil && !dryRun {
+		r.RecordInvocation(ctx, toolName, results, err, executionDuration)
+	}
 
 	if err != nil {
+		var limitErr *tools.RateLimitedError
+		if errors.As(err, &limitErr) {
+			return rateLimitedResult(id, meta, limitErr), limitErr
+		}
+		info := util.ClassifyError(err)
+		errText := util.ClientErrorText(ctx, err, info)
 		var tbErr util.ToolboxError
 
 		if errors.As(err, &tbErr) {
 			switch tbErr.Category() {
 			case util.CategoryAgent:
 				// MCP - Tool execution error
-				// Return SUCCESS but with IsError: true
+				// Return SUCCESS but with IsError: true, describing the error
+				// in the structured content of the result
 				text := TextContent{
 					Type: "text",
-					Text: err.Error(),
+					Text: errText,
 				}
 				return jsonrpc.JSONRPCResponse{
 					Jsonrpc: jsonrpc.JSONRPC_VERSION,
@@ -473,8 +565,9 @@ func toolsCallHandler(ctx context.Context, id jsonrpc.RequestId, toolset tools.T
 								Meta: meta,
 							},
 						},
-						Content: []TextContent{text},
-						IsError: true,
+						Content:           []TextContent{text},
+						IsError:           true,
+						StructuredContent: info.Data(),
 					},
 				}, nil
 
@@ -493,20 +586,31 @@ func toolsCallHandler(ctx context.Context, id jsonrpc.RequestId, toolset tools.T
 						}
 					}
 				}
-				return jsonrpc.NewError(id, rpcCode, err.Error(), nil), err
+				return jsonrpc.NewError(id, rpcCode, errText, info.Data()), err
 			}
 		} else {
 			// Unknown error -> 500
-			return jsonrpc.NewError(id, jsonrpc.INTERNAL_ERROR, err.Error(), nil), err
+			return jsonrpc.NewError(id, jsonrpc.INTERNAL_ERROR, errText, info.Data()), err
 		}
 	}
 
+	ctx = phases.Phase(ctx, tools.PhaseMarshal)
 	content := make([]TextContent, 0)
 
 	sliceRes, ok := results.([]any)
 	if !ok {
 		sliceRes = []any{results}
 	}
+	if format != "json" && !dryRun {
+		// results in another format are returned as a single text
+		encoded, err := mcputil.EncodeResult(format, results)
+		if err != nil {
+			err = fmt.Errorf("unable to encode result as %s: %w", format, err)
+			return jsonrpc.NewError(id, jsonrpc.INTERNAL_ERROR, err.Error(), nil), err
+		}
+		sliceRes = nil
+		content = append(content, TextContent{Type: "text", Text: encoded})
+	}
 
 	for _, d := range sliceRes {
 		text := TextContent{Type: "text"}
@@ -519,6 +623,13 @@ func toolsCallHandler(ctx context.Context, id jsonrpc.RequestId, toolset tools.T
 		content = append(content, text)
 	}
 
+	// tools declaring an output schema also return their results as
+	// structured content conforming to it
+	var structured map[string]any
+	if !dryRun && tools.OutputSchemaOf(tool) != nil {
+		structured = map[string]any{"result": results}
+	}
+
 	return jsonrpc.JSONRPCResponse{
 		Jsonrpc: jsonrpc.JSONRPC_VERSION,
 		Id:      id,
@@ -526,14 +637,53 @@ func toolsCallHandler(ctx context.Context, id jsonrpc.RequestId, toolset tools.T
 			Result: Result{
 				ResultType: resultTypeComplete,
 				Result: jsonrpc.Result{
-					Meta: meta,
+					Meta: mcputil.AddNextPageTokenMeta(ctx, mcputil.AddReplicaLagMeta(ctx, mcputil.AddToolVariantMeta(ctx, meta))),
 				},
 			},
-			Content: content,
+			Content:           content,
+			StructuredContent: structured,
 		},
 	}, nil
 }
 
+// tokenExpiredResult returns the result of a tool call that is not authorized
+// because a token has expired. The details of the error are returned as
+// structured content so that clients can refresh the token and retry.
+func tokenExpiredResult(id jsonrpc.RequestId, meta map[string]any, err *auth.TokenExpiredError) jsonrpc.JSONRPCResponse {
+	return jsonrpc.JSONRPCResponse{
+		Jsonrpc: jsonrpc.JSONRPC_VERSION,
+		Id:      id,
+		Result: CallToolResult{
+			Result: Result{
+				ResultType: resultTypeComplete,
+				Result:     jsonrpc.Result{Meta: meta},
+			},
+			Content:           []TextContent{{Type: "text", Text: err.Error()}},
+			IsError:           true,
+			StructuredContent: err.Data(),
+		},
+	}
+}
+
+// rateLimitedResult returns the result of a tool call rejected by the limits
+// of the tool. The details of the error are returned as structured content so
+// that clients can retry it later.
+func rateLimitedResult(id jsonrpc.RequestId, meta map[string]any, err *tools.RateLimitedError) jsonrpc.JSONRPCResponse {
+	return jsonrpc.JSONRPCResponse{
+		Jsonrpc: jsonrpc.JSONRPC_VERSION,
+		Id:      id,
+		Result: CallToolResult{
+			Result: Result{
+				ResultType: resultTypeComplete,
+				Result:     jsonrpc.Result{Meta: meta},
+			},
+			Content:           []TextContent{{Type: "text", Text: err.Error()}},
+			IsError:           true,
+			StructuredContent: err.Data(),
+		},
+	}
+}
+
 // promptsListHandler handles the "prompts/list" method.
 func promptsListHandler(ctx context.Context, id jsonrpc.RequestId, primitiveMgr *primitives.PrimitiveManager, promptset prompts.Promptset, body []byte, header http.Header) (any, error) {
 	// retrieve logger from context
@@ -679,3 +829,113 @@ func promptsGetHandler(ctx context.Context, id jsonrpc.RequestId, promptset prom
 		Result:  result,
 	}, nil
 }
+
+// resourcesListHandler handles the "resources/list" method.
+func resourcesListHandler(ctx context.Context, id jsonrpc.RequestId, primitiveMgr *primitives.PrimitiveManager, toolset tools.Toolset, body []byte, header http.Header) (any, error) {
+	// retrieve logger from context
+	logger, err := util.LoggerFromContext(ctx)
+	if err != nil {
+		return jsonrpc.NewError(id, jsonrpc.INTERNAL_ERROR, err.Error(), nil), err
+	}
+	logger.DebugContext(ctx, "handling resources/list request")
+
+	var req ListResourcesRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		err = fmt.Errorf("invalid mcp resources list request: %w", err)
+		return jsonrpc.NewError(id, jsonrpc.INVALID_REQUEST, err.Error(), nil), err
+	}
+	validateHeaderErr, err := validateHeader(id, header, RESOURCES_LIST, "")
+	if err != nil {
+		return validateHeaderErr, err
+	}
+	validateErr, err := validateMetadata(id, req.Params.RequestParams, header == nil)
+	if err != nil {
+		return validateErr, err
+	}
+
+	listResourcesResult, err := GenerateListResourcesResult(toolset, primitiveMgr.GetResourcesMap())
+	if err != nil {
+		err = fmt.Errorf("error generating manifest: %w", err)
+		return jsonrpc.NewError(id, jsonrpc.INTERNAL_ERROR, err.Error(), nil), err
+	}
+	logger.DebugContext(ctx, fmt.Sprintf("returning %d resources", len(listResourcesResult.Resources)))
+	meta, err := getResultMetadata(ctx, listResourcesResult.Meta)
+	if err != nil {
+		return jsonrpc.NewError(id, jsonrpc.INTERNAL_ERROR, err.Error(), nil), err
+	}
+	listResourcesResult.Meta = meta
+	return jsonrpc.JSONRPCResponse{
+		Jsonrpc: jsonrpc.JSONRPC_VERSION,
+		Id:      id,
+		Result:  listResourcesResult,
+	}, nil
+}
+
+// resourcesReadHandler handles the "resources/read" method.
+func resourcesReadHandler(ctx context.Context, id jsonrpc.RequestId, primitiveMgr *primitives.PrimitiveManager, toolset tools.Toolset, body []byte, header http.Header) (any, error) {
+	// retrieve logger from context
+	logger, err := util.LoggerFromContext(ctx)
+	if err != nil {
+		return jsonrpc.NewError(id, jsonrpc.INTERNAL_ERROR, err.Error(), nil), err
+	}
+	logger.DebugContext(ctx, "handling resources/read request")
+
+	var req ReadResourceRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		err = fmt.Errorf("invalid mcp resources/read request: %w", err)
+		return jsonrpc.NewError(id, jsonrpc.INVALID_REQUEST, err.Error(), nil), err
+	}
+	uri := req.Params.URI
+	validateHeaderErr, err := validateHeader(id, header, RESOURCES_READ, uri)
+	if err != nil {
+		return validateHeaderErr, err
+	}
+	validateErr, err := validateMetadata(id, req.Params.RequestParams, header == nil)
+	if err != nil {
+		return validateErr, err
+	}
+
+	span := trace.SpanFromContext(ctx)
+	span.SetName(fmt.Sprintf("%s %s", RESOURCES_READ, uri))
+	span.SetAttributes(attribute.String("mcp.resource.uri", uri))
+
+	var contents TextResourceContents
+	if mcputil.IsResultPageURI(uri) {
+		text, err := mcputil.ReadResultPage(ctx, toolset, uri)
+		if err != nil {
+			err = fmt.Errorf("resource with uri %q does not exist: %w", uri, err)
+			return jsonrpc.NewError(id, jsonrpc.RESOURCE_NOT_FOUND, err.Error(), map[string]string{"uri": uri}), err
+		}
+		contents = TextResourceContents{URI: uri, MimeType: "application/json", Text: text}
+	} else {
+		resource, ok := mcputil.FindResource(toolset, primitiveMgr.GetResourcesMap(), uri)
+		if !ok {
+			err := fmt.Errorf("resource with uri %q does not exist", uri)
+			return jsonrpc.NewError(id, jsonrpc.RESOURCE_NOT_FOUND, err.Error(), map[string]string{"uri": uri}), err
+		}
+		text, err := resource.Read(ctx)
+		if err != nil {
+			err = fmt.Errorf("unable to read resource with uri %q: %w", uri, err)
+			return jsonrpc.NewError(id, jsonrpc.INTERNAL_ERROR, err.Error(), nil), err
+		}
+		contents = TextResourceContents{URI: resource.URI, MimeType: resource.MimeType, Text: text}
+	}
+
+	meta, err := getResultMetadata(ctx, nil)
+	if err != nil {
+		return jsonrpc.NewError(id, jsonrpc.INTERNAL_ERROR, err.Error(), nil), err
+	}
+	return jsonrpc.JSONRPCResponse{
+		Jsonrpc: jsonrpc.JSONRPC_VERSION,
+		Id:      id,
+		Result: ReadResourceResult{
+			Result: Result{
+				ResultType: resultTypeComplete,
+				Result: jsonrpc.Result{
+					Meta: meta,
+				},
+			},
+			Contents: []TextResourceContents{contents},
+		},
+	}, nil
+}
@@ -0,0 +1,171 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/googleapis/mcp-toolbox/internal/auth"
+	"github.com/googleapis/mcp-toolbox/internal/server/mcp/jsonrpc"
+	mcputil "github.com/googleapis/mcp-toolbox/internal/server/mcp/util"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+)
+
+// The export endpoints expose the MCP tool definitions and tool calls as
+// plain HTTP resources, for clients that consume MCP tool definitions
+// without speaking JSON-RPC. The toolset is selected with the `toolset`
+// query parameter and defaults to all tools.
+const (
+	mcpExportToolsPath = "/tools"
+	mcpExportCallPath  = "/call"
+)
+
+// mcpExportRequestId is the JSON-RPC id of the messages built for the export
+// endpoints. It is never sent to clients.
+const mcpExportRequestId = "export"
+
+// mcpListToolsHandler returns the tools of a toolset as an MCP
+// ListToolsResult.
+func mcpListToolsHandler(s *Server, w http.ResponseWriter, r *http.Request) {
+	toolSpecHandler(s, w, r, nil)
+}
+
+// mcpCallToolHandler routes an MCP CallToolRequest to its tool and returns
+// the CallToolResult. The body is either the full request, with `method`
+// and `params`, or only its params.
+func mcpCallToolHandler(s *Server, w http.ResponseWriter, r *http.Request) {
+	// A toolset named like the endpoint keeps its JSON-RPC endpoint.
+	if _, ok := s.PrimitiveMgr.GetToolset(mcpExportCallPath[1:]); ok {
+		chi.RouteContext(r.Context()).URLParams.Add("toolsetName", mcpExportCallPath[1:])
+		httpHandler(s, w, r)
+		return
+	}
+
+	limit := s.httpMaxRequestBytes
+	raw, err := io.ReadAll(http.MaxBytesReader(w, r.Body, limit))
+	if err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			err = fmt.Errorf("request body exceeds %d bytes", limit)
+		}
+		_ = render.Render(w, r, newErrResponse(err, http.StatusBadRequest))
+		return
+	}
+
+	var req struct {
+		Method string          `json:"method"`
+		Params json.RawMessage `json:"params"`
+	}
+	if err := json.Unmarshal(raw, &req); err != nil {
+		_ = render.Render(w, r, newErrResponse(fmt.Errorf("invalid CallToolRequest: %w", err), http.StatusBadRequest))
+		return
+	}
+	params := req.Params
+	switch {
+	case req.Method != "" && req.Method != "tools/call":
+		_ = render.Render(w, r, newErrResponse(fmt.Errorf("unsupported method %q: must be \"tools/call\"", req.Method), http.StatusBadRequest))
+		return
+	case len(params) == 0:
+		params = raw
+	}
+
+	body, err := json.Marshal(map[string]any{
+		"jsonrpc": jsonrpc.JSONRPC_VERSION,
+		"id":      mcpExportRequestId,
+		"method":  "tools/call",
+		"params":  params,
+	})
+	if err != nil {
+		_ = render.Render(w, r, newErrResponse(err, http.StatusInternalServerError))
+		return
+	}
+
+	if s.httpQueue != nil {
+		if !s.httpQueue.tryAcquire(r.Context()) {
+			render.Status(r, http.StatusServiceUnavailable)
+			render.JSON(w, r, s.httpQueue.busyError(nil).Error)
+			return
+		}
+		defer s.httpQueue.release(r.Context())
+	}
+	processMcpExport(s, w, r, body, nil)
+}
+
+// processMcpExport processes a JSON-RPC message built for an export endpoint
+// and writes its result, converted by convert if set, or its error with a
+// matching HTTP status.
+func processMcpExport(s *Server, w http.ResponseWriter, r *http.Request, body []byte, convert func(any) (any, error)) {
+	ctx := r.Context()
+	ctx = util.WithUserAgent(ctx, s.version)
+	ctx = util.WithSQLCommenterEnabled(ctx, s.sqlCommenterEnabled)
+
+	toolsetName := r.URL.Query().Get("toolset")
+	if _, ok := s.PrimitiveMgr.GetToolset(toolsetName); !ok {
+		err := fmt.Errorf("toolset %q does not exist", toolsetName)
+		s.logger.DebugContext(ctx, err.Error())
+		_ = render.Render(w, r, newErrResponse(err, http.StatusNotFound))
+		return
+	}
+	networkProtocolVersion := fmt.Sprintf("%d.%d", r.ProtoMajor, r.ProtoMinor)
+	_, res, err := processMcpMessage(ctx, body, s, mcputil.LATEST_PROTOCOL_VERSION, toolsetName, "", r.Header, networkProtocolVersion)
+	if err != nil {
+		s.logger.DebugContext(ctx, fmt.Errorf("error processing message: %w", err).Error())
+	}
+
+	switch res := res.(type) {
+	case jsonrpc.JSONRPCResponse:
+		if convert == nil {
+			render.JSON(w, r, res.Result)
+			return
+		}
+		result, err := convert(res.Result)
+		if err != nil {
+			_ = render.Render(w, r, newErrResponse(err, http.StatusInternalServerError))
+			return
+		}
+		render.JSON(w, r, result)
+	case jsonrpc.JSONRPCError:
+		render.Status(r, mcpExportErrorStatus(res, err))
+		render.JSON(w, r, res.Error)
+	default:
+		_ = render.Render(w, r, newErrResponse(fmt.Errorf("unexpected response to %s", mcpExportRequestId), http.StatusInternalServerError))
+	}
+}
+
+// mcpExportErrorStatus maps a JSON-RPC error to an HTTP status.
+func mcpExportErrorStatus(res jsonrpc.JSONRPCError, err error) int {
+	var clientServerErr *util.ClientServerError
+	if errors.As(err, &clientServerErr) {
+		return clientServerErr.Code
+	}
+	var mcpErr *auth.MCPAuthError
+	if errors.As(err, &mcpErr) {
+		return mcpErr.Code
+	}
+	switch res.Error.Code {
+	case jsonrpc.INTERNAL_ERROR:
+		return http.StatusInternalServerError
+	case jsonrpc.METHOD_NOT_FOUND:
+		return http.StatusNotFound
+	default:
+		return http.StatusBadRequest
+	}
+}
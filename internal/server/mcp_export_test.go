@@ -0,0 +1,207 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/internal/testutils"
+)
+
+func TestMcpExportEndpoints(t *testing.T) {
+	mockTools := []testutils.MockTool{testutils.MockTool1, testutils.MockTool2, testutils.MockTool3}
+	toolsMap, toolsets, promptsMap, promptsets := testutils.SetUpResources(t, mockTools, nil)
+	r, shutdown := setUpServer(t, "mcp", toolsMap, toolsets, promptsMap, promptsets)
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	type listToolsResult struct {
+		Tools []struct {
+			Name        string         `json:"name"`
+			InputSchema map[string]any `json:"inputSchema"`
+		} `json:"tools"`
+	}
+	type callToolResult struct {
+		Content []struct {
+			Type string `json:"type"`
+			Text string `json:"text"`
+		} `json:"content"`
+		IsError bool `json:"isError"`
+	}
+
+	t.Run("list tools", func(t *testing.T) {
+		tcs := []struct {
+			path string
+			want []string
+		}{
+			{path: "/tools", want: []string{"no_params", "some_params", "array_param"}},
+			{path: "/tools?toolset=tool1_only", want: []string{"no_params"}},
+		}
+		for _, tc := range tcs {
+			resp, body, err := runRequest(ts, http.MethodGet, tc.path, nil, nil)
+			if err != nil {
+				t.Fatalf("unexpected error during request: %s", err)
+			}
+			if resp.StatusCode != http.StatusOK {
+				t.Fatalf("%s: unexpected status: %d, body: %s", tc.path, resp.StatusCode, body)
+			}
+			var got listToolsResult
+			if err := json.Unmarshal(body, &got); err != nil {
+				t.Fatalf("%s: unable to decode ListToolsResult: %s", tc.path, err)
+			}
+			var names []string
+			for _, tool := range got.Tools {
+				if tool.InputSchema == nil {
+					t.Errorf("%s: tool %q has no input schema", tc.path, tool.Name)
+				}
+				names = append(names, tool.Name)
+			}
+			if diff := cmp.Diff(tc.want, names); diff != "" {
+				t.Errorf("%s: unexpected tools (-want +got):\n%s", tc.path, diff)
+			}
+		}
+	})
+
+	t.Run("llamaindex spec", func(t *testing.T) {
+		resp, body, err := runRequest(ts, http.MethodGet, "/tools/llamaindex-spec?toolset=tool1_only", nil, nil)
+		if err != nil {
+			t.Fatalf("unexpected error during request: %s", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("unexpected status: %d, body: %s", resp.StatusCode, body)
+		}
+		var got []map[string]any
+		if err := json.Unmarshal(body, &got); err != nil {
+			t.Fatalf("unable to decode tool specs: %s", err)
+		}
+		want := []map[string]any{{
+			"name":        "no_params",
+			"description": "",
+			"parameters":  basicInputSchema,
+		}}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("unexpected tool specs (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("haystack tools", func(t *testing.T) {
+		resp, body, err := runRequest(ts, http.MethodGet, "/tools/haystack-tools?toolset=tool1_only", nil, nil)
+		if err != nil {
+			t.Fatalf("unexpected error during request: %s", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("unexpected status: %d, body: %s", resp.StatusCode, body)
+		}
+		var got []map[string]any
+		if err := json.Unmarshal(body, &got); err != nil {
+			t.Fatalf("unable to decode tools: %s", err)
+		}
+		want := []map[string]any{{
+			"name":        "no_params",
+			"description": "",
+			"parameters":  basicInputSchema,
+			"function":    ts.URL + "/api/tool/no_params/invoke",
+		}}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("unexpected tools (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("call tool", func(t *testing.T) {
+		bodies := map[string]string{
+			"params only":  `{"name":"no_params","arguments":{}}`,
+			"full request": `{"method":"tools/call","params":{"name":"no_params","arguments":{}}}`,
+		}
+		for desc, reqBody := range bodies {
+			resp, body, err := runRequest(ts, http.MethodPost, "/call", bytes.NewBufferString(reqBody), nil)
+			if err != nil {
+				t.Fatalf("unexpected error during request: %s", err)
+			}
+			if resp.StatusCode != http.StatusOK {
+				t.Fatalf("%s: unexpected status: %d, body: %s", desc, resp.StatusCode, body)
+			}
+			var got callToolResult
+			if err := json.Unmarshal(body, &got); err != nil {
+				t.Fatalf("%s: unable to decode CallToolResult: %s", desc, err)
+			}
+			if got.IsError || len(got.Content) != 1 || got.Content[0].Text != `"no_params"` {
+				t.Errorf("%s: unexpected CallToolResult: %s", desc, body)
+			}
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		tcs := []struct {
+			desc   string
+			method string
+			path   string
+			body   string
+			status int
+			want   string
+		}{
+			{
+				desc:   "unknown toolset",
+				method: http.MethodGet,
+				path:   "/tools?toolset=missing",
+				status: http.StatusNotFound,
+				want:   `toolset \"missing\" does not exist`,
+			},
+			{
+				desc:   "unsupported method",
+				method: http.MethodPost,
+				path:   "/call",
+				body:   `{"method":"tools/list"}`,
+				status: http.StatusBadRequest,
+				want:   `unsupported method \"tools/list\"`,
+			},
+			{
+				desc:   "tool outside of toolset",
+				method: http.MethodPost,
+				path:   "/call?toolset=tool1_only",
+				body:   `{"name":"some_params","arguments":{}}`,
+				status: http.StatusBadRequest,
+				want:   "some_params",
+			},
+			{
+				desc:   "invalid body",
+				method: http.MethodPost,
+				path:   "/call",
+				body:   `{`,
+				status: http.StatusBadRequest,
+				want:   "invalid CallToolRequest",
+			},
+		}
+		for _, tc := range tcs {
+			t.Run(tc.desc, func(t *testing.T) {
+				resp, body, err := runRequest(ts, tc.method, tc.path, bytes.NewBufferString(tc.body), nil)
+				if err != nil {
+					t.Fatalf("unexpected error during request: %s", err)
+				}
+				if resp.StatusCode != tc.status {
+					t.Errorf("unexpected status: got %d, want %d, body: %s", resp.StatusCode, tc.status, body)
+				}
+				if !strings.Contains(string(body), tc.want) {
+					t.Errorf("unexpected body: got %s, want it to contain %q", body, tc.want)
+				}
+			})
+		}
+	})
+}
@@ -0,0 +1,268 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/googleapis/mcp-toolbox/internal/server/primitives"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+)
+
+// namespaceKind is the kind of the documents defining namespaces.
+const namespaceKind = "namespace"
+
+// NamespaceConfig is a namespace of tools, whose tools may only reference
+// the sources it allows. The tools and toolsets of a namespace are only
+// visible to its clients.
+type NamespaceConfig struct {
+	Name           string   `yaml:"name" validate:"required"`
+	Tools          []string `yaml:"tools"`
+	AllowedSources []string `yaml:"allowedSources"`
+	// Toolsets are the toolsets of the namespace, which may only hold its
+	// tools.
+	Toolsets []string `yaml:"toolsets,omitempty"`
+	// AuthService is the auth service verifying the tokens of the clients of
+	// the namespace, whose claims hold the values of Claims.
+	AuthService string            `yaml:"authService,omitempty"`
+	Claims      map[string]string `yaml:"claims,omitempty"`
+}
+
+// NamespaceConfigs are the namespaces of a config file, keyed by name.
+type NamespaceConfigs map[string]NamespaceConfig
+
+// UnmarshalNamespaceConfigs returns the namespaces defined in raw, which
+// UnmarshalPrimitiveConfig verifies against the tools of raw.
+func UnmarshalNamespaceConfigs(ctx context.Context, raw []byte) (NamespaceConfigs, error) {
+	var namespaces NamespaceConfigs
+	err := forEachDocOfKind(ctx, raw, namespaceKind, func(name string, resource map[string]any) error {
+		c, err := unmarshalYAMLNamespaceConfig(ctx, name, resource)
+		if err != nil {
+			return err
+		}
+		if _, ok := namespaces[name]; ok {
+			return fmt.Errorf("namespace %q is defined more than once", name)
+		}
+		if namespaces == nil {
+			namespaces = make(NamespaceConfigs)
+		}
+		namespaces[name] = c
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return namespaces, nil
+}
+
+// unmarshalYAMLNamespaceConfig decodes the namespace name.
+func unmarshalYAMLNamespaceConfig(ctx context.Context, name string, r map[string]any) (NamespaceConfig, error) {
+	var c NamespaceConfig
+	dec, err := util.NewStrictDecoderAt(r, fmt.Sprintf("namespaces[%s]", name))
+	if err != nil {
+		return c, fmt.Errorf("error creating decoder: %w", err)
+	}
+	if err := dec.DecodeContext(ctx, &c); err != nil {
+		return c, err
+	}
+	return c, nil
+}
+
+// verify checks that every tool of a namespace is defined in toolConfigs,
+// belongs to no other namespace, and only references the allowed sources of
+// its namespace.
+func (n NamespaceConfigs) verify(toolConfigs ToolConfigs) error {
+	owners := make(map[string]string)
+	for _, name := range slices.Sorted(maps.Keys(n)) {
+		ns := n[name]
+		for _, toolName := range ns.Tools {
+			if owner, ok := owners[toolName]; ok {
+				return fmt.Errorf("tool %q belongs to both namespaces %q and %q", toolName, owner, name)
+			}
+			owners[toolName] = name
+			tc, ok := toolConfigs[toolName]
+			if !ok {
+				return fmt.Errorf("namespace %q lists tool %q, which is not defined in the same file", name, toolName)
+			}
+			source, ok := tools.SourceNameOf(tc)
+			if !ok || slices.Contains(ns.AllowedSources, source) {
+				continue
+			}
+			allowed := "none"
+			if len(ns.AllowedSources) > 0 {
+				allowed = strings.Join(ns.AllowedSources, ", ")
+			}
+			return fmt.Errorf("tool %q of namespace %q references source %q, which is not in the allowedSources of the namespace: %s", toolName, name, source, allowed)
+		}
+	}
+	return nil
+}
+
+// namespaceContextKey is the context key of the namespace of the URL prefix
+// of a request.
+type namespaceContextKey struct{}
+
+// namespaces isolates the tools and toolsets of each namespace from the
+// clients of the others. The tools and toolsets of no namespace are only
+// visible to the clients of no namespace.
+type namespaces struct {
+	// configs are the namespaces, sorted by name.
+	configs      []NamespaceConfig
+	byName       NamespaceConfigs
+	toolOwner    map[string]string
+	toolsetOwner map[string]string
+}
+
+// newNamespaces validates cfgs against the primitives of mgr. It returns nil
+// if there are no namespaces.
+func newNamespaces(cfgs NamespaceConfigs, mgr *primitives.PrimitiveManager) (*namespaces, error) {
+	if len(cfgs) == 0 {
+		return nil, nil
+	}
+	n := &namespaces{
+		byName:       cfgs,
+		toolOwner:    make(map[string]string),
+		toolsetOwner: make(map[string]string),
+	}
+	for _, name := range slices.Sorted(maps.Keys(cfgs)) {
+		if err := n.add(cfgs[name], mgr); err != nil {
+			return nil, fmt.Errorf("invalid namespace %q: %w", name, err)
+		}
+	}
+	return n, nil
+}
+
+func (n *namespaces) add(c NamespaceConfig, mgr *primitives.PrimitiveManager) error {
+	if len(c.Claims) > 0 && c.AuthService == "" {
+		return fmt.Errorf("claims require an authService")
+	}
+	if c.AuthService != "" {
+		if _, ok := mgr.GetAuthServiceMap()[c.AuthService]; !ok {
+			return fmt.Errorf("auth service %q does not exist", c.AuthService)
+		}
+	}
+	for _, name := range c.Tools {
+		if owner, ok := n.toolOwner[name]; ok {
+			return fmt.Errorf("tool %q also belongs to namespace %q", name, owner)
+		}
+		n.toolOwner[name] = c.Name
+	}
+	for _, name := range c.Toolsets {
+		if name == "" {
+			return fmt.Errorf("the default toolset may not belong to a namespace")
+		}
+		toolset, ok := mgr.GetToolset(name)
+		if !ok {
+			return fmt.Errorf("toolset %q does not exist", name)
+		}
+		if owner, ok := n.toolsetOwner[name]; ok {
+			return fmt.Errorf("toolset %q also belongs to namespace %q", name, owner)
+		}
+		for _, tool := range toolset.ToolNames {
+			if n.toolOwner[tool] != c.Name {
+				return fmt.Errorf("toolset %q holds tool %q, which is not a tool of the namespace", name, tool)
+			}
+		}
+		n.toolsetOwner[name] = c.Name
+	}
+	n.configs = append(n.configs, c)
+	return nil
+}
+
+// allowsTool reports whether the clients of the namespace ns may see the
+// tool name.
+func (n *namespaces) allowsTool(ns, name string) bool {
+	return n == nil || n.toolOwner[name] == ns
+}
+
+// restrict returns toolset holding only the tools of the namespace ns, and
+// whether its clients may see the toolset at all: the default toolset, or
+// one of the namespace.
+func (n *namespaces) restrict(ns string, toolset tools.Toolset) (tools.Toolset, bool) {
+	if n == nil {
+		return toolset, true
+	}
+	if toolset.Name != "" && n.toolsetOwner[toolset.Name] != ns {
+		return toolset, false
+	}
+	return toolset.Filter(func(name string) bool { return n.allowsTool(ns, name) }), true
+}
+
+// namespaceMiddleware selects the namespace of the URL prefix of requests.
+func namespaceMiddleware(s *Server) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			name := chi.URLParam(r, "namespace")
+			if _, ok := s.namespaces.byName[name]; !ok {
+				err := fmt.Errorf("namespace %q does not exist", name)
+				_ = render.Render(w, r, newErrResponse(err, http.StatusNotFound))
+				return
+			}
+			ctx := context.WithValue(r.Context(), namespaceContextKey{}, name)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// namespacePrefix returns the URL prefix of the namespace of the request
+// with ctx, or empty if it has none.
+func namespacePrefix(ctx context.Context) string {
+	if name, ok := ctx.Value(namespaceContextKey{}).(string); ok {
+		return "/ns/" + name
+	}
+	return ""
+}
+
+// requestNamespace returns the namespace of the client of a request with
+// header: that of the URL prefix of the request, whose claims the token of
+// the client must hold if it has an auth service, or else the first whose
+// claims it holds. Empty is no namespace.
+func (s *Server) requestNamespace(ctx context.Context, header http.Header) (string, *util.ClientServerError) {
+	n := s.namespaces
+	if n == nil {
+		return "", nil
+	}
+	if name, ok := ctx.Value(namespaceContextKey{}).(string); ok {
+		c := n.byName[name]
+		if c.AuthService != "" && !matchClaims(c.Claims, s.authServiceClaims(ctx, header, c.AuthService)) {
+			return "", util.NewClientServerError(fmt.Sprintf("access to namespace %q is not granted", name), http.StatusForbidden, nil)
+		}
+		return name, nil
+	}
+	// claims of each auth service, verified once per request
+	claimsOf := make(map[string]map[string]any)
+	for _, c := range n.configs {
+		if c.AuthService == "" {
+			continue
+		}
+		claims, ok := claimsOf[c.AuthService]
+		if !ok {
+			claims = s.authServiceClaims(ctx, header, c.AuthService)
+			claimsOf[c.AuthService] = claims
+		}
+		if matchClaims(c.Claims, claims) {
+			return c.Name, nil
+		}
+	}
+	return "", nil
+}
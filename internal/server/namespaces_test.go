@@ -0,0 +1,170 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/internal/server/primitives"
+	"github.com/googleapis/mcp-toolbox/internal/testutils"
+)
+
+func TestNewNamespaces(t *testing.T) {
+	mockTools := []testutils.MockTool{testutils.MockTool1, testutils.MockTool2}
+	tool1, tool2 := testutils.MockTool1.Name, testutils.MockTool2.Name
+	toolsMap, toolsets, _, _ := testutils.SetUpResources(t, mockTools, nil)
+	mgr := primitives.NewPrimitiveManager(nil, nil, nil, toolsMap, toolsets, nil, nil, nil)
+
+	tcs := []struct {
+		desc string
+		cfgs NamespaceConfigs
+		want string
+	}{
+		{
+			desc: "valid",
+			cfgs: NamespaceConfigs{"team-a": {Name: "team-a", Tools: []string{tool1}, Toolsets: []string{"tool1_only"}}},
+		},
+		{
+			desc: "default toolset",
+			cfgs: NamespaceConfigs{"team-a": {Name: "team-a", Tools: []string{tool1}, Toolsets: []string{""}}},
+			want: "the default toolset may not belong to a namespace",
+		},
+		{
+			desc: "toolset with a tool of another namespace",
+			cfgs: NamespaceConfigs{"team-a": {Name: "team-a", Tools: []string{tool1}, Toolsets: []string{"tool2_only"}}},
+			want: `toolset "tool2_only" holds tool "` + tool2 + `", which is not a tool of the namespace`,
+		},
+		{
+			desc: "undefined toolset",
+			cfgs: NamespaceConfigs{"team-a": {Name: "team-a", Toolsets: []string{"missing"}}},
+			want: `toolset "missing" does not exist`,
+		},
+		{
+			desc: "tool of two namespaces",
+			cfgs: NamespaceConfigs{
+				"team-a": {Name: "team-a", Tools: []string{tool1}},
+				"team-b": {Name: "team-b", Tools: []string{tool1}},
+			},
+			want: `invalid namespace "team-b": tool "` + tool1 + `" also belongs to namespace "team-a"`,
+		},
+		{
+			desc: "claims without auth service",
+			cfgs: NamespaceConfigs{"team-a": {Name: "team-a", Claims: map[string]string{"team": "a"}}},
+			want: "claims require an authService",
+		},
+		{
+			desc: "undefined auth service",
+			cfgs: NamespaceConfigs{"team-a": {Name: "team-a", AuthService: "missing"}},
+			want: `auth service "missing" does not exist`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := newNamespaces(tc.cfgs, mgr)
+			if tc.want == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("unexpected error: got %v, want %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestNamespacesAPI(t *testing.T) {
+	mockTools := []testutils.MockTool{testutils.MockTool1, testutils.MockTool2}
+	tool1, tool2 := testutils.MockTool1.Name, testutils.MockTool2.Name
+	toolsMap, toolsets, _, _ := testutils.SetUpResources(t, mockTools, nil)
+	cfgs := NamespaceConfigs{"team-a": {Name: "team-a", Tools: []string{tool1}, Toolsets: []string{"tool1_only"}}}
+
+	var srv *Server
+	r, shutdown := setUpServer(t, "api", toolsMap, toolsets, nil, nil, func(s *Server) {
+		var err error
+		s.namespaces, err = newNamespaces(cfgs, s.PrimitiveMgr)
+		if err != nil {
+			t.Fatalf("unable to create namespaces: %s", err)
+		}
+		srv = s
+	})
+	defer shutdown()
+	root := chi.NewRouter()
+	root.Mount("/", r)
+	root.With(namespaceMiddleware(srv)).Mount("/ns/{namespace}", r)
+	ts := runServer(root, false)
+	defer ts.Close()
+
+	tcs := []struct {
+		desc       string
+		path       string
+		body       string
+		wantStatus int
+		wantTools  []string
+	}{
+		{desc: "default toolset hides namespaced tools", path: "/toolset/", wantStatus: http.StatusOK, wantTools: []string{tool2}},
+		{desc: "toolset of a namespace", path: "/toolset/tool1_only", wantStatus: http.StatusNotFound},
+		{desc: "tool of a namespace", path: "/tool/" + tool1 + "/invoke", body: `{}`, wantStatus: http.StatusNotFound},
+		{desc: "tool of no namespace", path: "/tool/" + tool2 + "/invoke", body: `{"param1": 1, "param2": 2}`, wantStatus: http.StatusOK},
+		{desc: "default toolset of a namespace", path: "/ns/team-a/toolset/", wantStatus: http.StatusOK, wantTools: []string{tool1}},
+		{desc: "own toolset", path: "/ns/team-a/toolset/tool1_only", wantStatus: http.StatusOK, wantTools: []string{tool1}},
+		{desc: "toolset of no namespace", path: "/ns/team-a/toolset/tool2_only", wantStatus: http.StatusNotFound},
+		{desc: "own tool", path: "/ns/team-a/tool/" + tool1 + "/invoke", body: `{}`, wantStatus: http.StatusOK},
+		{desc: "tool of no namespace from a namespace", path: "/ns/team-a/tool/" + tool2 + "/invoke", body: `{"param1": 1, "param2": 2}`, wantStatus: http.StatusNotFound},
+		{desc: "undefined namespace", path: "/ns/team-b/toolset/", wantStatus: http.StatusNotFound},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			method := http.MethodGet
+			var body io.Reader
+			if tc.body != "" {
+				method = http.MethodPost
+				body = strings.NewReader(tc.body)
+			}
+			resp, got, err := runRequest(ts, method, tc.path, body, nil)
+			if err != nil {
+				t.Fatalf("unexpected error during request: %s", err)
+			}
+			if resp.StatusCode != tc.wantStatus {
+				t.Fatalf("unexpected status: got %d, want %d: %s", resp.StatusCode, tc.wantStatus, got)
+			}
+			if tc.wantTools == nil {
+				return
+			}
+			var manifest struct {
+				Tools map[string]any `json:"tools"`
+			}
+			if err := json.Unmarshal(got, &manifest); err != nil {
+				t.Fatalf("unable to decode manifest: %s", err)
+			}
+			names := make([]string, 0, len(manifest.Tools))
+			for name := range manifest.Tools {
+				names = append(names, name)
+			}
+			slices.Sort(names)
+			if diff := cmp.Diff(tc.wantTools, names); diff != "" {
+				t.Fatalf("incorrect tools (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/googleapis/mcp-toolbox/internal/server/resultformat"
+)
+
+// defaultOutputFormats are the output formats of tools without
+// supportedFormats.
+var defaultOutputFormats = []string{"json"}
+
+// ContentNegotiator selects the output format of a tool result from the
+// Accept header of a request.
+type ContentNegotiator struct {
+	// formats are the formats the tool supports, most preferred first.
+	formats []string
+}
+
+// NewContentNegotiator returns a negotiator between the given formats, most
+// preferred first, or JSON only if there are none.
+func NewContentNegotiator(formats []string) ContentNegotiator {
+	if len(formats) == 0 {
+		formats = defaultOutputFormats
+	}
+	return ContentNegotiator{formats: formats}
+}
+
+// Negotiate returns the supported format with the highest quality in accept,
+// preferring earlier formats on ties, and false if accept allows none. An
+// empty accept allows every format.
+func (n ContentNegotiator) Negotiate(accept string) (string, bool) {
+	if strings.TrimSpace(accept) == "" {
+		return n.formats[0], true
+	}
+	best, bestQ := "", 0.0
+	for _, format := range n.formats {
+		if q := quality(accept, resultformat.MediaTypes[format]); q > bestQ {
+			best, bestQ = format, q
+		}
+	}
+	return best, bestQ > 0
+}
+
+// quality returns the quality accept gives to mediaType: the quality of the
+// most specific media range matching it, or 0 if none does.
+func quality(accept, mediaType string) float64 {
+	typ, _, _ := strings.Cut(mediaType, "/")
+	q, specificity := 0.0, -1
+	for _, part := range strings.Split(accept, ",") {
+		mediaRange, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		mediaRange = strings.ToLower(strings.TrimSpace(mediaRange))
+
+		var s int
+		switch mediaRange {
+		case mediaType:
+			s = 2
+		case typ + "/*":
+			s = 1
+		case "*/*":
+			s = 0
+		default:
+			continue
+		}
+		if s <= specificity {
+			continue
+		}
+		specificity, q = s, 1.0
+		for _, param := range strings.Split(params, ";") {
+			if v, ok := strings.CutPrefix(strings.TrimSpace(param), "q="); ok {
+				parsed, err := strconv.ParseFloat(v, 64)
+				if err != nil {
+					parsed = 0
+				}
+				q = parsed
+			}
+		}
+	}
+	return q
+}
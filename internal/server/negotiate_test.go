@@ -0,0 +1,77 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNegotiate(t *testing.T) {
+	all := []string{"json", "csv", "markdown", "parquet", "arrow", "ndjson"}
+	tcs := []struct {
+		desc    string
+		formats []string
+		accept  string
+		want    string
+		wantOK  bool
+	}{
+		{desc: "no accept header", formats: all, want: "json", wantOK: true},
+		{desc: "default formats", accept: "application/json", want: "json", wantOK: true},
+		{desc: "default formats, not acceptable", accept: "text/csv", wantOK: false},
+		{desc: "exact match", formats: all, accept: "text/csv", want: "csv", wantOK: true},
+		{desc: "quality", formats: all, accept: "application/json;q=0.5, application/x-ndjson", want: "ndjson", wantOK: true},
+		{desc: "wildcard prefers tool order", formats: []string{"csv", "json"}, accept: "*/*", want: "csv", wantOK: true},
+		{desc: "type wildcard", formats: all, accept: "text/*", want: "csv", wantOK: true},
+		{desc: "specific range wins over wildcard", formats: all, accept: "*/*;q=0.9, application/json;q=0", want: "csv", wantOK: true},
+		{desc: "refused", formats: all, accept: "application/json;q=0", wantOK: false},
+		{desc: "unsupported", formats: []string{"json"}, accept: "application/xml", wantOK: false},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, ok := NewContentNegotiator(tc.formats).Negotiate(tc.accept)
+			if ok != tc.wantOK || got != tc.want {
+				t.Errorf("unexpected format: got (%q, %t), want (%q, %t)", got, ok, tc.want, tc.wantOK)
+			}
+		})
+	}
+}
+
+func TestNDJSONStream(t *testing.T) {
+	rec := httptest.NewRecorder()
+	stream := &ndjsonStream{w: rec}
+	if err := stream.write([]any{map[string]any{"id": 1}, map[string]any{"id": 2}}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !rec.Flushed {
+		t.Errorf("batch was not flushed")
+	}
+	if err := stream.write([]any{map[string]any{"id": 3}}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	stream.finish(errors.New("connection reset"))
+
+	if stream.rows != 3 {
+		t.Errorf("counted %d rows, want 3", stream.rows)
+	}
+	if got := rec.Header().Get("Content-Type"); got != "application/x-ndjson" {
+		t.Errorf("unexpected content type %q", got)
+	}
+	want := "{\"id\":1}\n{\"id\":2}\n{\"id\":3}\n{\"error\":\"connection reset\"}\n"
+	if got := rec.Body.String(); got != want {
+		t.Errorf("unexpected output: got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,403 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+)
+
+// openapiToolsKind is the kind of the documents generating http tools from
+// the operations of an OpenAPI document.
+const openapiToolsKind = "openapiTools"
+
+// openapiToolsConfig selects the operations of an OpenAPI document that
+// become http tools of a source.
+type openapiToolsConfig struct {
+	Name   string `yaml:"name" validate:"required"`
+	Source string `yaml:"source" validate:"required"`
+	// Spec is the path of the OpenAPI 3 document, in YAML or JSON.
+	Spec string `yaml:"spec" validate:"required"`
+	// Include lists the operationIds of the operations to generate tools
+	// for. Every operation with an operationId is included when empty.
+	Include []string `yaml:"include"`
+}
+
+// openapiMethods are the operations of a path item that become tools.
+var openapiMethods = []string{"get", "put", "post", "delete", "patch"}
+
+// openapiPathParam matches the parameters of an OpenAPI path template.
+var openapiPathParam = regexp.MustCompile(`\{([^{}]+)\}`)
+
+// templateIdentifier matches the names that a Go template can reference as
+// fields.
+var templateIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// templateField returns the Go template expression of the parameter name.
+func templateField(name string) string {
+	if templateIdentifier.MatchString(name) {
+		return "." + name
+	}
+	return fmt.Sprintf("(index . %q)", name)
+}
+
+// generatedTool is the raw config of a tool generated from an operation,
+// named after its operationId.
+type generatedTool struct {
+	name     string
+	resource map[string]any
+}
+
+// openapiOperation is an operation of an OpenAPI document.
+type openapiOperation struct {
+	path   string
+	method string
+	// item is the path item declaring the operation, whose parameters apply
+	// to it.
+	item map[string]any
+	op   map[string]any
+}
+
+// generateOpenAPITools returns the raw configs of the http tools generated
+// from an openapiTools document, in the same form as hand-written tools.
+func generateOpenAPITools(ctx context.Context, name string, r map[string]any) ([]generatedTool, error) {
+	dec, err := util.NewStrictDecoder(r)
+	if err != nil {
+		return nil, fmt.Errorf("error creating decoder: %w", err)
+	}
+	cfg := openapiToolsConfig{Name: name}
+	if err := dec.DecodeContext(ctx, &cfg); err != nil {
+		return nil, err
+	}
+
+	raw, err := os.ReadFile(cfg.Spec)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read spec %q: %w", cfg.Spec, err)
+	}
+	var doc map[string]any
+	if err := yaml.Unmarshal(raw, &doc); err != nil {
+		return nil, fmt.Errorf("unable to parse spec %q: %s", cfg.Spec, yaml.FormatError(err, false, false))
+	}
+	if v, _ := doc["openapi"].(string); !strings.HasPrefix(v, "3.") {
+		return nil, fmt.Errorf("spec %q is not an OpenAPI 3 document", cfg.Spec)
+	}
+
+	ops, err := openapiOperations(doc)
+	if err != nil {
+		return nil, fmt.Errorf("spec %q: %w", cfg.Spec, err)
+	}
+	include := cfg.Include
+	if len(include) == 0 {
+		for id := range ops {
+			include = append(include, id)
+		}
+		slices.Sort(include)
+	}
+
+	generated := make([]generatedTool, 0, len(include))
+	for _, id := range include {
+		op, ok := ops[id]
+		if !ok {
+			return nil, fmt.Errorf("operation %q is not in spec %q", id, cfg.Spec)
+		}
+		resource, err := openapiTool(doc, cfg.Source, op)
+		if err != nil {
+			return nil, fmt.Errorf("operation %q: %w", id, err)
+		}
+		resource["name"] = id
+		generated = append(generated, generatedTool{name: id, resource: resource})
+	}
+	return generated, nil
+}
+
+// openapiOperations returns the operations of doc keyed by operationId.
+// Operations without an operationId are skipped.
+func openapiOperations(doc map[string]any) (map[string]openapiOperation, error) {
+	paths, _ := doc["paths"].(map[string]any)
+	ops := make(map[string]openapiOperation)
+	for path, rawItem := range paths {
+		item, ok := rawItem.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("path %q is not an object", path)
+		}
+		for _, method := range openapiMethods {
+			op, ok := item[method].(map[string]any)
+			if !ok {
+				continue
+			}
+			id, _ := op["operationId"].(string)
+			if id == "" {
+				continue
+			}
+			if _, ok := ops[id]; ok {
+				return nil, fmt.Errorf("operationId %q is used by more than one operation", id)
+			}
+			ops[id] = openapiOperation{path: path, method: method, item: item, op: op}
+		}
+	}
+	return ops, nil
+}
+
+// openapiTool returns the raw config of the http tool of op.
+func openapiTool(doc map[string]any, source string, op openapiOperation) (map[string]any, error) {
+	description, _ := op.op["summary"].(string)
+	if description == "" {
+		description, _ = op.op["description"].(string)
+	}
+	if description == "" {
+		description = op.op["operationId"].(string)
+	}
+
+	path := openapiPathParam.ReplaceAllStringFunc(op.path, func(m string) string {
+		return fmt.Sprintf("{{pathEscape %s}}", templateField(m[1:len(m)-1]))
+	})
+
+	resource := map[string]any{
+		"type":        "http",
+		"source":      source,
+		"description": description,
+		"method":      strings.ToUpper(op.method),
+		"path":        path,
+	}
+
+	params, err := openapiParameters(doc, op)
+	if err != nil {
+		return nil, err
+	}
+	for _, field := range []string{"pathParams", "queryParams", "headerParams"} {
+		if len(params[field]) > 0 {
+			resource[field] = params[field]
+		}
+	}
+
+	if rawBody, ok := op.op["requestBody"]; ok {
+		bodyParams, requestBody, err := openapiRequestBody(doc, rawBody)
+		if err != nil {
+			return nil, err
+		}
+		resource["bodyParams"] = bodyParams
+		resource["requestBody"] = requestBody
+	}
+	return resource, nil
+}
+
+// openapiParameters returns the parameters of op keyed by the field of the
+// http tool they belong to. Parameters of the operation override those of
+// its path item with the same name and location.
+func openapiParameters(doc map[string]any, op openapiOperation) (map[string][]any, error) {
+	type key struct{ name, in string }
+	var order []key
+	byKey := make(map[key]map[string]any)
+	for _, rawParams := range []any{op.item["parameters"], op.op["parameters"]} {
+		list, _ := rawParams.([]any)
+		for _, rawP := range list {
+			p, err := resolveOpenAPIRef(doc, rawP)
+			if err != nil {
+				return nil, err
+			}
+			name, _ := p["name"].(string)
+			in, _ := p["in"].(string)
+			k := key{name, in}
+			if _, ok := byKey[k]; !ok {
+				order = append(order, k)
+			}
+			byKey[k] = p
+		}
+	}
+
+	fields := map[string]string{"path": "pathParams", "query": "queryParams", "header": "headerParams"}
+	params := make(map[string][]any)
+	for _, k := range order {
+		p := byKey[k]
+		field, ok := fields[k.in]
+		if !ok {
+			return nil, fmt.Errorf("parameter %q: %q parameters are not supported", k.name, k.in)
+		}
+		schema, err := resolveOpenAPIRef(doc, p["schema"])
+		if err != nil {
+			return nil, fmt.Errorf("parameter %q: %w", k.name, err)
+		}
+		description, _ := p["description"].(string)
+		param, err := openapiParameter(doc, k.name, description, schema, k.in != "path")
+		if err != nil {
+			return nil, fmt.Errorf("parameter %q: %w", k.name, err)
+		}
+		if param["type"] == "map" {
+			return nil, fmt.Errorf("parameter %q: object parameters are only supported in request bodies", k.name)
+		}
+		required, _ := p["required"].(bool)
+		if k.in != "path" && !required {
+			param["required"] = false
+		}
+		params[field] = append(params[field], param)
+	}
+	return params, nil
+}
+
+// openapiRequestBody returns the body parameters and the request body
+// template of a JSON request body whose schema is an object.
+func openapiRequestBody(doc map[string]any, rawBody any) ([]any, string, error) {
+	body, err := resolveOpenAPIRef(doc, rawBody)
+	if err != nil {
+		return nil, "", fmt.Errorf("requestBody: %w", err)
+	}
+	content, _ := body["content"].(map[string]any)
+	media, ok := content["application/json"].(map[string]any)
+	if !ok {
+		return nil, "", fmt.Errorf("requestBody: only application/json request bodies are supported")
+	}
+	schema, err := resolveOpenAPIRef(doc, media["schema"])
+	if err != nil {
+		return nil, "", fmt.Errorf("requestBody: %w", err)
+	}
+	if err := checkOpenAPIComposition(schema); err != nil {
+		return nil, "", fmt.Errorf("requestBody: %w", err)
+	}
+	if t, _ := schema["type"].(string); t != "object" {
+		return nil, "", fmt.Errorf("requestBody: only object request bodies are supported")
+	}
+	properties, _ := schema["properties"].(map[string]any)
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	required, _ := schema["required"].([]any)
+
+	bodyParams := make([]any, 0, len(names))
+	fields := make([]string, 0, len(names))
+	for _, name := range names {
+		propSchema, err := resolveOpenAPIRef(doc, properties[name])
+		if err != nil {
+			return nil, "", fmt.Errorf("requestBody: property %q: %w", name, err)
+		}
+		param, err := openapiParameter(doc, name, "", propSchema, true)
+		if err != nil {
+			return nil, "", fmt.Errorf("requestBody: property %q: %w", name, err)
+		}
+		if !slices.Contains(required, any(name)) {
+			param["required"] = false
+		}
+		bodyParams = append(bodyParams, param)
+		fields = append(fields, fmt.Sprintf("%q: {{json %s}}", name, templateField(name)))
+	}
+	return bodyParams, "{" + strings.Join(fields, ", ") + "}", nil
+}
+
+// openapiParameter converts a schema to a raw tool parameter. allowArrays
+// reports whether the schema may be an array.
+func openapiParameter(doc map[string]any, name, description string, schema map[string]any, allowArrays bool) (map[string]any, error) {
+	if err := checkOpenAPIComposition(schema); err != nil {
+		return nil, err
+	}
+	if description == "" {
+		description, _ = schema["description"].(string)
+	}
+	if description == "" {
+		description = name
+	}
+	param := map[string]any{"name": name, "description": description}
+
+	switch t, _ := schema["type"].(string); t {
+	case "string", "integer", "boolean":
+		param["type"] = t
+	case "number":
+		param["type"] = "float"
+	case "object":
+		param["type"] = "map"
+	case "array":
+		if !allowArrays {
+			return nil, fmt.Errorf("array path parameters are not supported")
+		}
+		itemsSchema, err := resolveOpenAPIRef(doc, schema["items"])
+		if err != nil {
+			return nil, fmt.Errorf("items: %w", err)
+		}
+		items, err := openapiParameter(doc, name, "", itemsSchema, false)
+		if err != nil {
+			return nil, fmt.Errorf("items: %w", err)
+		}
+		if items["type"] == "map" {
+			return nil, fmt.Errorf("arrays of objects are not supported")
+		}
+		param["type"] = "array"
+		param["items"] = items
+	case "":
+		return nil, fmt.Errorf("schema has no type")
+	default:
+		return nil, fmt.Errorf("unsupported type %q", t)
+	}
+
+	if enum, ok := schema["enum"].([]any); ok && param["type"] != "array" {
+		param["allowedValues"] = enum
+	}
+	if def, ok := schema["default"]; ok {
+		param["default"] = def
+	}
+	return param, nil
+}
+
+// checkOpenAPIComposition rejects the schemas composed of other schemas,
+// which tool parameters cannot represent.
+func checkOpenAPIComposition(schema map[string]any) error {
+	for _, keyword := range []string{"oneOf", "anyOf", "allOf", "not"} {
+		if _, ok := schema[keyword]; ok {
+			return fmt.Errorf("%s schemas are not supported", keyword)
+		}
+	}
+	return nil
+}
+
+// resolveOpenAPIRef returns v, or the object it references with a local
+// "$ref" such as "#/components/schemas/User".
+func resolveOpenAPIRef(doc map[string]any, v any) (map[string]any, error) {
+	obj, ok := v.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("expected an object, got %T", v)
+	}
+	// references can chain, but not loop forever
+	for range 32 {
+		ref, ok := obj["$ref"].(string)
+		if !ok {
+			return obj, nil
+		}
+		pointer, ok := strings.CutPrefix(ref, "#/")
+		if !ok {
+			return nil, fmt.Errorf("reference %q is not local to the document", ref)
+		}
+		var cur any = doc
+		for _, token := range strings.Split(pointer, "/") {
+			token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+			m, ok := cur.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("unable to resolve reference %q", ref)
+			}
+			if cur, ok = m[token]; !ok {
+				return nil, fmt.Errorf("unable to resolve reference %q", ref)
+			}
+		}
+		if obj, ok = cur.(map[string]any); !ok {
+			return nil, fmt.Errorf("reference %q is not an object", ref)
+		}
+	}
+	return nil, fmt.Errorf("too many nested references")
+}
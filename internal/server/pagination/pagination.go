@@ -0,0 +1,219 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package pagination splits long tool results and listings into pages that
+// clients read one at a time with a page token.
+package pagination
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+// DefaultTTL is how long the pages of a result can be read.
+const DefaultTTL = 10 * time.Minute
+
+// DefaultMaxResults is the number of results whose pages are kept at once.
+// Storing another result evicts the one expiring first.
+const DefaultMaxResults = 1000
+
+// ResultURIPrefix prefixes the page tokens of results in the URIs of the MCP
+// resources reading them.
+const ResultURIPrefix = "toolbox://results/"
+
+// ErrInvalidToken rejects a page token that is malformed, or whose result
+// expired.
+var ErrInvalidToken = errors.New("invalid or expired page token")
+
+// Page returns the page of size items starting at cursor, an empty cursor
+// starting at the first item, and the cursor of the next page, empty if it is
+// the last one. A size of zero returns every item.
+func Page[T any](items []T, cursor string, size int) ([]T, string, error) {
+	offset := 0
+	if cursor != "" {
+		b, err := base64.RawURLEncoding.DecodeString(cursor)
+		if err != nil {
+			return nil, "", ErrInvalidToken
+		}
+		offset, err = strconv.Atoi(string(b))
+		if err != nil || offset < 0 || offset > len(items) {
+			return nil, "", ErrInvalidToken
+		}
+	}
+	if size <= 0 || offset+size >= len(items) {
+		return items[offset:], "", nil
+	}
+	next := base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(offset + size)))
+	return items[offset : offset+size], next, nil
+}
+
+// result is a result split into pages.
+type result struct {
+	tool    string
+	rows    []any
+	expires time.Time
+}
+
+// Store keeps the rows of the results split into pages until they expire.
+type Store struct {
+	pageSize int
+	ttl      time.Duration
+	now      func() time.Time
+
+	mu      sync.Mutex
+	results map[string]*result
+}
+
+// New returns a store splitting results into pages of pageSize rows, readable
+// for ttl.
+func New(pageSize int, ttl time.Duration) *Store {
+	return &Store{pageSize: pageSize, ttl: ttl, now: time.Now, results: make(map[string]*result)}
+}
+
+var _ util.ResultPager = &Store{}
+
+// split returns the first page of rows, and the token of the next page if
+// rows do not fit in one.
+func (s *Store) split(tool string, rows []any) ([]any, string, error) {
+	if len(rows) <= s.pageSize {
+		return rows, "", nil
+	}
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return nil, "", fmt.Errorf("unable to generate page token: %w", err)
+	}
+	id := hex.EncodeToString(b)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.evict()
+	s.results[id] = &result{tool: tool, rows: rows, expires: s.now().Add(s.ttl)}
+	return rows[:s.pageSize], pageToken(id, s.pageSize), nil
+}
+
+// evict removes the expired results, and the result expiring first if the
+// store is still full.
+func (s *Store) evict() {
+	now := s.now()
+	var first string
+	for id, r := range s.results {
+		if now.After(r.expires) {
+			delete(s.results, id)
+			continue
+		}
+		if first == "" || r.expires.Before(s.results[first].expires) {
+			first = id
+		}
+	}
+	if len(s.results) >= DefaultMaxResults {
+		delete(s.results, first)
+	}
+}
+
+func pageToken(id string, offset int) string {
+	return id + "." + strconv.Itoa(offset)
+}
+
+// Page implements util.ResultPager. Tokens can be read again until their
+// result expires.
+func (s *Store) Page(token string) ([]any, string, string, error) {
+	id, offsetStr, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, "", "", ErrInvalidToken
+	}
+	offset, err := strconv.Atoi(offsetStr)
+	if err != nil || offset < 0 {
+		return nil, "", "", ErrInvalidToken
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r, ok := s.results[id]
+	if !ok || s.now().After(r.expires) || offset >= len(r.rows) {
+		return nil, "", "", ErrInvalidToken
+	}
+	end := offset + s.pageSize
+	if end >= len(r.rows) {
+		return r.rows[offset:], r.tool, "", nil
+	}
+	return r.rows[offset:end], r.tool, pageToken(id, end), nil
+}
+
+// Wrap returns the tools with the results of their invocations split into
+// pages stored in s, for the invocations whose context has a
+// util.ResultPage. Streamed results are not split.
+func Wrap(toolsMap map[string]tools.Tool, s *Store) map[string]tools.Tool {
+	wrapped := make(map[string]tools.Tool, len(toolsMap))
+	for name, t := range toolsMap {
+		pt := pagedTool{Tool: t, store: s}
+		if streamer, ok := t.(tools.RowStreamer); ok {
+			wrapped[name] = pagedStreamer{pagedTool: pt, streamer: streamer}
+			continue
+		}
+		wrapped[name] = pt
+	}
+	return wrapped
+}
+
+// pagedTool is a tool whose results are split into pages.
+type pagedTool struct {
+	tools.Tool
+	store *Store
+}
+
+// Unwrap implements tools.Wrapper.
+func (t pagedTool) Unwrap() tools.Tool { return t.Tool }
+
+func (t pagedTool) Invoke(ctx context.Context, sp tools.SourceProvider, params parameters.ParamValues, token tools.AccessToken) (any, util.ToolboxError) {
+	res, tbErr := t.Tool.Invoke(ctx, sp, params, token)
+	page := util.ResultPageFromContext(ctx)
+	rows, ok := res.([]any)
+	if tbErr != nil || page == nil || !ok {
+		return res, tbErr
+	}
+	first, next, err := t.store.split(t.GetName(), rows)
+	if err != nil {
+		return nil, util.NewClientServerError("unable to split result into pages", http.StatusInternalServerError, err)
+	}
+	page.NextPageToken = next
+	return first, nil
+}
+
+// DryRun implements tools.DryRunner.
+func (t pagedTool) DryRun(ctx context.Context, sp tools.SourceProvider, params parameters.ParamValues, token tools.AccessToken) (*tools.DryRunResult, util.ToolboxError) {
+	return tools.DryRun(ctx, t.Tool, sp, params, token)
+}
+
+// pagedStreamer is a paged tool that streams its rows whole.
+type pagedStreamer struct {
+	pagedTool
+	streamer tools.RowStreamer
+}
+
+func (t pagedStreamer) StreamRows(ctx context.Context, sp tools.SourceProvider, params parameters.ParamValues, token tools.AccessToken, emit func(rows []any) error) util.ToolboxError {
+	return t.streamer.StreamRows(ctx, sp, params, token, emit)
+}
@@ -0,0 +1,121 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pagination
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/internal/testutils"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+func TestPage(t *testing.T) {
+	items := []string{"a", "b", "c", "d", "e"}
+	var got []string
+	cursor := ""
+	pages := 0
+	for {
+		page, next, err := Page(items, cursor, 2)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		got = append(got, page...)
+		pages++
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+	if diff := cmp.Diff(items, got); diff != "" {
+		t.Errorf("unexpected items (-want +got):\n%s", diff)
+	}
+	if pages != 3 {
+		t.Errorf("unexpected number of pages: got %d, want 3", pages)
+	}
+
+	if page, next, err := Page(items, "", 0); err != nil || next != "" || len(page) != len(items) {
+		t.Errorf("expected a size of zero to return every item, got %v, %q, %v", page, next, err)
+	}
+	if _, _, err := Page(items, "not a cursor!", 2); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("expected an invalid cursor to fail, got %v", err)
+	}
+}
+
+// rowsTool returns n rows.
+type rowsTool struct {
+	testutils.MockTool
+	n int
+}
+
+func (t rowsTool) Invoke(context.Context, tools.SourceProvider, parameters.ParamValues, tools.AccessToken) (any, util.ToolboxError) {
+	rows := make([]any, t.n)
+	for i := range rows {
+		rows[i] = i
+	}
+	return rows, nil
+}
+
+func TestWrap(t *testing.T) {
+	store := New(2, time.Minute)
+	wrapped := Wrap(map[string]tools.Tool{
+		"list": rowsTool{MockTool: testutils.NewMockTool("list", "", nil, false, false), n: 5},
+	}, store)
+
+	// results are whole without a ResultPage
+	res, err := wrapped["list"].Invoke(context.Background(), nil, nil, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(res.([]any)) != 5 {
+		t.Fatalf("expected the whole result, got %v", res)
+	}
+
+	page := &util.ResultPage{}
+	res, err = wrapped["list"].Invoke(util.WithResultPage(context.Background(), page), nil, nil, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	got := res.([]any)
+	token := page.NextPageToken
+	for token != "" {
+		rows, tool, next, err := store.Page(token)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if tool != "list" {
+			t.Errorf("unexpected tool: got %q, want %q", tool, "list")
+		}
+		got = append(got, rows...)
+		token = next
+	}
+	if diff := cmp.Diff([]any{0, 1, 2, 3, 4}, got); diff != "" {
+		t.Errorf("unexpected rows (-want +got):\n%s", diff)
+	}
+
+	// expired results cannot be read
+	store.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+	if _, _, _, err := store.Page(page.NextPageToken); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("expected an expired token to fail, got %v", err)
+	}
+	if _, _, _, err := store.Page("unknown.2"); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("expected an unknown token to fail, got %v", err)
+	}
+}
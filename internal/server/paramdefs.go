@@ -0,0 +1,117 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"fmt"
+	"maps"
+	"sort"
+	"strings"
+)
+
+// parameterDefKind is the kind of the documents defining reusable parameters.
+const parameterDefKind = "parameterDef"
+
+// parameterRefKey is the key a tool parameter uses to reference a parameter
+// definition.
+const parameterRefKey = "$ref"
+
+// parameterRefOverrides are the fields a parameter reference may set on top
+// of the referenced definition.
+var parameterRefOverrides = map[string]bool{
+	"description": true,
+}
+
+// parameterDefs holds the parameter definitions of a config file, keyed by
+// name. Each definition is the raw parameter, including its name.
+type parameterDefs map[string]map[string]any
+
+// add validates a parameter definition and adds it.
+func (d parameterDefs) add(name string, def map[string]any) error {
+	if _, ok := d[name]; ok {
+		return fmt.Errorf("parameter definition %q is defined more than once", name)
+	}
+	if _, ok := def["type"].(string); !ok {
+		return fmt.Errorf("missing 'type' field or it is not a string")
+	}
+	if _, ok := def[parameterRefKey]; ok {
+		return fmt.Errorf("a parameter definition cannot reference another definition")
+	}
+	d[name] = def
+	return nil
+}
+
+// resolveParameterRefs replaces the parameters of a tool that reference a
+// parameter definition with the definition, so that the tool is decoded as
+// if the definition were inlined.
+func resolveParameterRefs(r map[string]any, defs parameterDefs) error {
+	for _, field := range []string{"parameters", "templateParameters"} {
+		params, ok := r[field].([]any)
+		if !ok {
+			continue
+		}
+		resolved := make([]any, len(params))
+		for i, rawP := range params {
+			p, ok := rawP.(map[string]any)
+			if !ok {
+				resolved[i] = rawP
+				continue
+			}
+			rp, err := resolveParameterRef(p, defs)
+			if err != nil {
+				return fmt.Errorf("%s[%d]: %w", field, i, err)
+			}
+			resolved[i] = rp
+		}
+		r[field] = resolved
+	}
+	return nil
+}
+
+// resolveParameterRef returns the parameter p references, with the overrides
+// of p applied, or p itself if it has no reference.
+func resolveParameterRef(p map[string]any, defs parameterDefs) (map[string]any, error) {
+	rawRef, ok := p[parameterRefKey]
+	if !ok {
+		return p, nil
+	}
+	ref, ok := rawRef.(string)
+	if !ok || ref == "" {
+		return nil, fmt.Errorf("%q must be the name of a parameter definition", parameterRefKey)
+	}
+	def, ok := defs[ref]
+	if !ok {
+		return nil, fmt.Errorf("reference to undefined parameter definition %q", ref)
+	}
+
+	resolved := maps.Clone(def)
+	for k, v := range p {
+		switch {
+		case k == parameterRefKey:
+		case k == "type":
+			return nil, fmt.Errorf("reference to parameter definition %q cannot override its type", ref)
+		case parameterRefOverrides[k]:
+			resolved[k] = v
+		default:
+			allowed := make([]string, 0, len(parameterRefOverrides))
+			for o := range parameterRefOverrides {
+				allowed = append(allowed, fmt.Sprintf("%q", o))
+			}
+			sort.Strings(allowed)
+			return nil, fmt.Errorf("reference to parameter definition %q cannot set %q: only %s can be overridden", ref, k, strings.Join(allowed, ", "))
+		}
+	}
+	return resolved, nil
+}
@@ -0,0 +1,152 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/googleapis/mcp-toolbox/internal/sources"
+)
+
+// PrewarmAllSources prewarms every source that supports pings when listed in
+// ServerConfig.PrewarmSources.
+const PrewarmAllSources = "*"
+
+// sourcePingInitialBackoff is the delay before pinging again a source whose
+// background ping failed for the first time. The delay doubles with each
+// failure, up to the ping interval.
+const sourcePingInitialBackoff = time.Second
+
+// prewarmSources pings the sources named in names, or every source that
+// supports pings when names holds "*", so that their connections are
+// established and checked before the server starts serving. It fails if any
+// of the sources can't be reached.
+func (s *Server) prewarmSources(ctx context.Context, names []string) error {
+	srcs := s.PrimitiveMgr.GetSourcesMap()
+	pingers := make(map[string]sources.Pinger)
+	if slices.Contains(names, PrewarmAllSources) {
+		for name, src := range srcs {
+			if pinger, ok := src.(sources.Pinger); ok {
+				pingers[name] = pinger
+			}
+		}
+	} else {
+		for _, name := range names {
+			src, ok := srcs[name]
+			if !ok {
+				return fmt.Errorf("unable to prewarm source %q: source not found", name)
+			}
+			pinger, ok := src.(sources.Pinger)
+			if !ok {
+				return fmt.Errorf("unable to prewarm source %q: source does not support pings", name)
+			}
+			pingers[name] = pinger
+		}
+	}
+
+	errs := make([]error, 0, len(pingers))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, pinger := range pingers {
+		wg.Go(func() {
+			latency, err := s.sourcePings.ping(ctx, name, pinger)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, fmt.Errorf("source %q failed its startup check: %w", name, err))
+				return
+			}
+			s.logger.DebugContext(ctx, fmt.Sprintf("Prewarmed source %q in %s", name, latency))
+		})
+	}
+	wg.Wait()
+	slices.SortFunc(errs, func(a, b error) int { return strings.Compare(a.Error(), b.Error()) })
+	return errors.Join(errs...)
+}
+
+// sourceWatch is the state of the background pings of a source.
+type sourceWatch struct {
+	// next is when the source is pinged next.
+	next time.Time
+	// failures counts the pings that failed in a row.
+	failures int
+}
+
+// pingSources pings the sources that support pings every interval until ctx
+// is done. A source whose ping fails is pinged again sooner, with an
+// exponential backoff, which lets connection pools replace the connections
+// they lost.
+func (s *Server) pingSources(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(min(interval, sourcePingInitialBackoff))
+	defer ticker.Stop()
+	watches := make(map[string]*sourceWatch)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			s.pingDueSources(ctx, now, interval, watches)
+		}
+	}
+}
+
+// pingDueSources pings the sources that are due at now, updating their
+// watches. Sources removed by a reload are forgotten.
+func (s *Server) pingDueSources(ctx context.Context, now time.Time, interval time.Duration, watches map[string]*sourceWatch) {
+	srcs := s.PrimitiveMgr.GetSourcesMap()
+	for name := range watches {
+		if _, ok := srcs[name]; !ok {
+			delete(watches, name)
+		}
+	}
+
+	var wg sync.WaitGroup
+	for name, src := range srcs {
+		pinger, ok := src.(sources.Pinger)
+		if !ok {
+			continue
+		}
+		w, ok := watches[name]
+		if !ok {
+			w = &sourceWatch{next: now.Add(interval)}
+			watches[name] = w
+			continue
+		}
+		if now.Before(w.next) {
+			continue
+		}
+		wg.Go(func() {
+			if _, err := s.sourcePings.ping(ctx, name, pinger); err != nil {
+				w.failures++
+				backoff := min(sourcePingInitialBackoff<<min(w.failures-1, 30), interval)
+				w.next = now.Add(backoff)
+				s.logger.WarnContext(ctx, fmt.Sprintf("source %q failed %d ping(s) in a row, retrying in %s: %s", name, w.failures, backoff, err))
+				return
+			}
+			if w.failures > 0 {
+				s.logger.InfoContext(ctx, fmt.Sprintf("source %q is reachable again after %d failed ping(s)", name, w.failures))
+			}
+			w.failures = 0
+			w.next = now.Add(interval)
+		})
+	}
+	wg.Wait()
+}
@@ -0,0 +1,130 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/googleapis/mcp-toolbox/internal/log"
+	"github.com/googleapis/mcp-toolbox/internal/server/primitives"
+	"github.com/googleapis/mcp-toolbox/internal/sources"
+)
+
+func TestPrewarmSources(t *testing.T) {
+	logger, err := log.NewStdLogger(io.Discard, io.Discard, "info")
+	if err != nil {
+		t.Fatalf("unable to initialize logger: %s", err)
+	}
+	healthy := &pingableSource{}
+	broken := &pingableSource{err: errors.New("connection refused")}
+	s := &Server{
+		logger: logger,
+		PrimitiveMgr: primitives.NewPrimitiveManager(map[string]sources.Source{
+			"healthy":  healthy,
+			"broken":   broken,
+			"unpinged": &fakeSource{},
+		}, nil, nil, nil, nil, nil, nil, nil),
+	}
+	ctx := context.Background()
+
+	tcs := []struct {
+		desc    string
+		names   []string
+		wantErr string
+	}{
+		{desc: "healthy source", names: []string{"healthy"}},
+		{desc: "broken source", names: []string{"healthy", "broken"}, wantErr: `source "broken" failed its startup check: connection refused`},
+		{desc: "every source", names: []string{"*"}, wantErr: `source "broken" failed its startup check`},
+		{desc: "unknown source", names: []string{"missing"}, wantErr: `unable to prewarm source "missing": source not found`},
+		{desc: "source without pings", names: []string{"unpinged"}, wantErr: `unable to prewarm source "unpinged": source does not support pings`},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := s.prewarmSources(ctx, tc.names)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("got error %v, want %q", err, tc.wantErr)
+			}
+		})
+	}
+	if s.sourcePings.latency("healthy") == nil {
+		t.Errorf("prewarming didn't record the ping latency of the healthy source")
+	}
+}
+
+func TestPingDueSources(t *testing.T) {
+	logger, err := log.NewStdLogger(io.Discard, io.Discard, "info")
+	if err != nil {
+		t.Fatalf("unable to initialize logger: %s", err)
+	}
+	src := &pingableSource{err: errors.New("connection refused")}
+	s := &Server{
+		logger: logger,
+		PrimitiveMgr: primitives.NewPrimitiveManager(map[string]sources.Source{
+			"db": src,
+		}, nil, nil, nil, nil, nil, nil, nil),
+	}
+	ctx := context.Background()
+	interval := 5 * time.Second
+	watches := make(map[string]*sourceWatch)
+	now := time.Unix(0, 0)
+
+	s.pingDueSources(ctx, now, interval, watches)
+	w := watches["db"]
+	if w == nil || !w.next.Equal(now.Add(interval)) || w.failures != 0 {
+		t.Fatalf("unexpected watch of a new source: %+v", w)
+	}
+
+	// Failed pings are retried after 1s, 2s, 4s, then every interval.
+	for _, wantBackoff := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, interval, interval} {
+		now = w.next
+		s.pingDueSources(ctx, now, interval, watches)
+		if got := w.next.Sub(now); got != wantBackoff {
+			t.Fatalf("got backoff %s after %d failures, want %s", got, w.failures, wantBackoff)
+		}
+	}
+	if w.failures != 5 {
+		t.Errorf("got %d failures, want 5", w.failures)
+	}
+
+	// Sources aren't pinged before they are due.
+	s.pingDueSources(ctx, now.Add(time.Second), interval, watches)
+	if w.failures != 5 {
+		t.Errorf("source pinged before it was due")
+	}
+
+	src.err = nil
+	now = w.next
+	s.pingDueSources(ctx, now, interval, watches)
+	if w.failures != 0 || !w.next.Equal(now.Add(interval)) {
+		t.Errorf("unexpected watch of a recovered source: %+v", w)
+	}
+
+	s.PrimitiveMgr.SetSources(map[string]sources.Source{})
+	s.pingDueSources(ctx, now, interval, watches)
+	if len(watches) != 0 {
+		t.Errorf("watch of a removed source kept: %v", watches)
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package primitives
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/googleapis/mcp-toolbox/internal/util"
+)
+
+// SetToolEnabled enables or disables the tool of the given name, reporting
+// false if there is no such tool. The state is kept in memory only: it
+// survives reloads of the configuration but not restarts of the server.
+func (r *PrimitiveManager) SetToolEnabled(toolName string, enabled bool) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.tools[toolName]; !ok {
+		return false
+	}
+	if enabled {
+		delete(r.disabledTools, toolName)
+		return true
+	}
+	if r.disabledTools == nil {
+		r.disabledTools = make(map[string]bool)
+	}
+	r.disabledTools[toolName] = true
+	return true
+}
+
+// IsToolDisabled reports whether the tool of the given name was disabled.
+func (r *PrimitiveManager) IsToolDisabled(toolName string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.disabledTools[toolName]
+}
+
+// GetDisabledTools returns the names of the disabled tools in name order.
+func (r *PrimitiveManager) GetDisabledTools() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.disabledTools))
+	for name := range r.disabledTools {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// CheckToolEnabled returns the error of invoking the tool of the given name
+// while it is disabled, or nil if it is enabled.
+func (r *PrimitiveManager) CheckToolEnabled(toolName string) *util.ClientServerError {
+	if !r.IsToolDisabled(toolName) {
+		return nil
+	}
+	return util.NewClientServerError("tool disabled by administrator", http.StatusServiceUnavailable, nil)
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/googleapis/mcp-toolbox/internal/auth"
 	"github.com/googleapis/mcp-toolbox/internal/embeddingmodels"
 	"github.com/googleapis/mcp-toolbox/internal/prompts"
+	"github.com/googleapis/mcp-toolbox/internal/resources"
 	"github.com/googleapis/mcp-toolbox/internal/sources"
 	"github.com/googleapis/mcp-toolbox/internal/tools"
 )
@@ -34,6 +35,9 @@ type PrimitiveManager struct {
 	toolsets        map[string]tools.Toolset
 	prompts         map[string]prompts.Prompt
 	promptsets      map[string]prompts.Promptset
+	resources       map[string]resources.Resource
+	// disabledTools are the tools disabled at runtime by an administrator.
+	disabledTools map[string]bool
 }
 
 func NewPrimitiveManager(
@@ -42,7 +46,7 @@ func NewPrimitiveManager(
 	embeddingModelsMap map[string]embeddingmodels.EmbeddingModel,
 	toolsMap map[string]tools.Tool, toolsetsMap map[string]tools.Toolset,
 	promptsMap map[string]prompts.Prompt, promptsetsMap map[string]prompts.Promptset,
-
+	resourcesMap map[string]resources.Resource,
 ) *PrimitiveManager {
 	primitiveMgr := &PrimitiveManager{
 		mu:              sync.RWMutex{},
@@ -53,6 +57,7 @@ func NewPrimitiveManager(
 		toolsets:        toolsetsMap,
 		prompts:         promptsMap,
 		promptsets:      promptsetsMap,
+		resources:       resourcesMap,
 	}
 
 	return primitiveMgr
@@ -107,7 +112,14 @@ func (r *PrimitiveManager) GetPromptset(promptsetName string) (prompts.Promptset
 	return promptset, ok
 }
 
-func (r *PrimitiveManager) SetPrimitives(sourcesMap map[string]sources.Source, authServicesMap map[string]auth.AuthService, embeddingModelsMap map[string]embeddingmodels.EmbeddingModel, toolsMap map[string]tools.Tool, toolsetsMap map[string]tools.Toolset, promptsMap map[string]prompts.Prompt, promptsetsMap map[string]prompts.Promptset) {
+func (r *PrimitiveManager) GetResource(resourceName string) (resources.Resource, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	resource, ok := r.resources[resourceName]
+	return resource, ok
+}
+
+func (r *PrimitiveManager) SetPrimitives(sourcesMap map[string]sources.Source, authServicesMap map[string]auth.AuthService, embeddingModelsMap map[string]embeddingmodels.EmbeddingModel, toolsMap map[string]tools.Tool, toolsetsMap map[string]tools.Toolset, promptsMap map[string]prompts.Prompt, promptsetsMap map[string]prompts.Promptset, resourcesMap map[string]resources.Resource) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	r.sources = sourcesMap
@@ -117,6 +129,14 @@ func (r *PrimitiveManager) SetPrimitives(sourcesMap map[string]sources.Source, a
 	r.toolsets = toolsetsMap
 	r.prompts = promptsMap
 	r.promptsets = promptsetsMap
+	r.resources = resourcesMap
+}
+
+// SetSources replaces the sources, leaving the other primitives as they are.
+func (r *PrimitiveManager) SetSources(sourcesMap map[string]sources.Source) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sources = sourcesMap
 }
 
 func (r *PrimitiveManager) GetSourcesMap() map[string]sources.Source {
@@ -168,3 +188,13 @@ func (r *PrimitiveManager) GetPromptsMap() map[string]prompts.Prompt {
 	}
 	return copiedMap
 }
+
+func (r *PrimitiveManager) GetResourcesMap() map[string]resources.Resource {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	copiedMap := make(map[string]resources.Resource, len(r.resources))
+	for k, v := range r.resources {
+		copiedMap[k] = v
+	}
+	return copiedMap
+}
@@ -18,12 +18,15 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/googleapis/mcp-toolbox/internal/auth"
 	"github.com/googleapis/mcp-toolbox/internal/embeddingmodels"
 	"github.com/googleapis/mcp-toolbox/internal/prompts"
 	"github.com/googleapis/mcp-toolbox/internal/server/primitives"
 	"github.com/googleapis/mcp-toolbox/internal/sources"
 	"github.com/googleapis/mcp-toolbox/internal/sources/alloydbpg"
+	"github.com/googleapis/mcp-toolbox/internal/sources/queryguard"
+	"github.com/googleapis/mcp-toolbox/internal/sources/schemasnapshot"
 	"github.com/googleapis/mcp-toolbox/internal/tools"
 )
 
@@ -56,10 +59,10 @@ func TestUpdateServer(t *testing.T) {
 			Prompts: []*prompts.Prompt{},
 		},
 	}
-	primMgr := primitives.NewPrimitiveManager(newSources, newAuth, newEmbeddingModels, newTools, newToolsets, newPrompts, newPromptsets)
+	primMgr := primitives.NewPrimitiveManager(newSources, newAuth, newEmbeddingModels, newTools, newToolsets, newPrompts, newPromptsets, nil)
 
 	gotSource, _ := primMgr.GetSource("example-source")
-	if diff := cmp.Diff(gotSource, newSources["example-source"]); diff != "" {
+	if diff := cmp.Diff(gotSource, newSources["example-source"], cmpopts.IgnoreUnexported(alloydbpg.Source{}, schemasnapshot.Report{}, queryguard.Guard{})); diff != "" {
 		t.Errorf("error updating server, sources (-want +got):\n%s", diff)
 	}
 
@@ -97,9 +100,43 @@ func TestUpdateServer(t *testing.T) {
 		},
 	}
 
-	primMgr.SetPrimitives(updateSource, newAuth, newEmbeddingModels, newTools, newToolsets, newPrompts, newPromptsets)
+	primMgr.SetPrimitives(updateSource, newAuth, newEmbeddingModels, newTools, newToolsets, newPrompts, newPromptsets, nil)
 	gotSource, _ = primMgr.GetSource("example-source2")
-	if diff := cmp.Diff(gotSource, updateSource["example-source2"]); diff != "" {
+	if diff := cmp.Diff(gotSource, updateSource["example-source2"], cmpopts.IgnoreUnexported(alloydbpg.Source{}, schemasnapshot.Report{}, queryguard.Guard{})); diff != "" {
 		t.Errorf("error updating server, sources (-want +got):\n%s", diff)
 	}
 }
+
+func TestSetToolEnabled(t *testing.T) {
+	toolsMap := map[string]tools.Tool{"tool-a": nil, "tool-b": nil}
+	primMgr := primitives.NewPrimitiveManager(nil, nil, nil, toolsMap, nil, nil, nil, nil)
+
+	if primMgr.SetToolEnabled("missing", false) {
+		t.Fatalf("expected disabling an unknown tool to fail")
+	}
+	for _, name := range []string{"tool-b", "tool-a"} {
+		if !primMgr.SetToolEnabled(name, false) {
+			t.Fatalf("unable to disable %q", name)
+		}
+	}
+	if diff := cmp.Diff([]string{"tool-a", "tool-b"}, primMgr.GetDisabledTools()); diff != "" {
+		t.Errorf("unexpected disabled tools (-want +got):\n%s", diff)
+	}
+	if err := primMgr.CheckToolEnabled("tool-a"); err == nil || err.Error() != "tool disabled by administrator" {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// the state survives reloads of the configuration
+	primMgr.SetPrimitives(nil, nil, nil, toolsMap, nil, nil, nil, nil)
+	if !primMgr.IsToolDisabled("tool-a") {
+		t.Errorf("expected tool-a to stay disabled across reloads")
+	}
+
+	primMgr.SetToolEnabled("tool-a", true)
+	if primMgr.IsToolDisabled("tool-a") || primMgr.CheckToolEnabled("tool-a") != nil {
+		t.Errorf("expected tool-a to be enabled")
+	}
+	if diff := cmp.Diff([]string{"tool-b"}, primMgr.GetDisabledTools()); diff != "" {
+		t.Errorf("unexpected disabled tools (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/googleapis/mcp-toolbox/internal/server/mcp/jsonrpc"
+	"github.com/googleapis/mcp-toolbox/internal/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// DefaultInvocationQueueDepth is the default number of tool invocations a
+// transport processes at once.
+const DefaultInvocationQueueDepth = 64
+
+// toolsCallMethod is the MCP method of tool invocations, which are the only
+// requests subject to the invocation queue.
+const toolsCallMethod = "tools/call"
+
+// invocationQueue bounds the number of tool invocations a transport
+// processes at once. Invocations that do not fit are rejected immediately
+// with a "server busy" error rather than waiting for a free slot.
+type invocationQueue struct {
+	slots chan struct{}
+	gauge metric.Int64UpDownCounter
+	attrs metric.MeasurementOption
+}
+
+// newInvocationQueue returns a queue admitting depth invocations, or nil if
+// depth is not positive, which disables queueing.
+func newInvocationQueue(depth int, instrumentation *telemetry.Instrumentation, transport string) *invocationQueue {
+	if depth <= 0 {
+		return nil
+	}
+	q := &invocationQueue{
+		slots: make(chan struct{}, depth),
+		attrs: metric.WithAttributes(attribute.String("network.transport", transport)),
+	}
+	if instrumentation != nil {
+		q.gauge = instrumentation.InvocationQueueDepth
+	}
+	return q
+}
+
+// tryAcquire admits an invocation, reporting false if the queue is full.
+func (q *invocationQueue) tryAcquire(ctx context.Context) bool {
+	select {
+	case q.slots <- struct{}{}:
+		if q.gauge != nil {
+			q.gauge.Add(ctx, 1, q.attrs)
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+// release frees the slot of an admitted invocation.
+func (q *invocationQueue) release(ctx context.Context) {
+	<-q.slots
+	if q.gauge != nil {
+		q.gauge.Add(ctx, -1, q.attrs)
+	}
+}
+
+// busyError is the response to invocations rejected by a full queue.
+func (q *invocationQueue) busyError(id jsonrpc.RequestId) jsonrpc.JSONRPCError {
+	return jsonrpc.NewError(id, jsonrpc.SERVER_BUSY, "server busy: too many tool invocations in progress, retry later", map[string]any{
+		"queueDepth": cap(q.slots),
+	})
+}
+
+// peekToolCall reports whether body is a tools/call request and returns its
+// id.
+func peekToolCall(body []byte) (jsonrpc.RequestId, bool) {
+	var req struct {
+		Id     jsonrpc.RequestId `json:"id"`
+		Method string            `json:"method"`
+	}
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, false
+	}
+	return req.Id, req.Method == toolsCallMethod && req.Id != nil
+}
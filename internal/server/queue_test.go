@@ -0,0 +1,317 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/googleapis/mcp-toolbox/internal/log"
+	"github.com/googleapis/mcp-toolbox/internal/prompts"
+	"github.com/googleapis/mcp-toolbox/internal/server/mcp/jsonrpc"
+	"github.com/googleapis/mcp-toolbox/internal/server/primitives"
+	"github.com/googleapis/mcp-toolbox/internal/telemetry"
+	"github.com/googleapis/mcp-toolbox/internal/testutils"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+// gatedTool blocks every invocation until the gate is opened.
+type gatedTool struct {
+	testutils.MockTool
+	started chan struct{}
+	gate    chan struct{}
+}
+
+func newGatedTool() gatedTool {
+	return gatedTool{
+		MockTool: testutils.NewMockTool("gated_tool", "a slow tool", nil, false, false),
+		started:  make(chan struct{}, 16),
+		gate:     make(chan struct{}),
+	}
+}
+
+func (t gatedTool) Invoke(ctx context.Context, _ tools.SourceProvider, _ parameters.ParamValues, _ tools.AccessToken) (any, util.ToolboxError) {
+	t.started <- struct{}{}
+	select {
+	case <-t.gate:
+		return "finished", nil
+	case <-ctx.Done():
+		return nil, util.NewClientServerError("invocation canceled", http.StatusInternalServerError, ctx.Err())
+	}
+}
+
+func waitStarted(t *testing.T, tool gatedTool, n int) {
+	for i := 0; i < n; i++ {
+		select {
+		case <-tool.started:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("invocation %d did not start", i+1)
+		}
+	}
+}
+
+func toolsCallRequest(id int, name string) string {
+	return fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"method":"tools/call","params":{"name":%q,"arguments":{}}}`, id, name)
+}
+
+type rpcResponse struct {
+	Id     any             `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code int `json:"code"`
+	} `json:"error"`
+}
+
+func TestInvocationQueue(t *testing.T) {
+	ctx := context.Background()
+	q := newInvocationQueue(2, nil, "pipe")
+	if !q.tryAcquire(ctx) || !q.tryAcquire(ctx) {
+		t.Fatalf("expected queue to admit two invocations")
+	}
+	if q.tryAcquire(ctx) {
+		t.Fatalf("expected full queue to reject the invocation")
+	}
+	q.release(ctx)
+	if !q.tryAcquire(ctx) {
+		t.Fatalf("expected queue to admit an invocation after a release")
+	}
+
+	if q := newInvocationQueue(0, nil, "pipe"); q != nil {
+		t.Errorf("expected a zero depth to disable the queue")
+	}
+}
+
+func TestPeekToolCall(t *testing.T) {
+	tcs := []struct {
+		desc string
+		body string
+		want bool
+	}{
+		{desc: "tool call", body: toolsCallRequest(1, "t"), want: true},
+		{desc: "other method", body: `{"jsonrpc":"2.0","id":1,"method":"tools/list"}`},
+		{desc: "notification", body: `{"jsonrpc":"2.0","method":"tools/call"}`},
+		{desc: "invalid json", body: `{`},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			if _, got := peekToolCall([]byte(tc.body)); got != tc.want {
+				t.Errorf("unexpected result: got %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
+
+// newGatedToolServer returns a server serving the tool, with an invocation
+// queue of the given depth.
+func newGatedToolServer(t *testing.T, ctx context.Context, tool gatedTool, depth int) *Server {
+	toolsMap := map[string]tools.Tool{tool.Name: tool}
+	toolset, err := tools.ToolsetConfig{Name: "", ToolNames: []string{tool.Name}}.Initialize(testutils.MockVersionString, toolsMap)
+	if err != nil {
+		t.Fatalf("unable to initialize toolset: %s", err)
+	}
+
+	testLogger, err := log.NewStdLogger(io.Discard, io.Discard, "warn")
+	if err != nil {
+		t.Fatalf("unable to initialize logger: %s", err)
+	}
+	instrumentation, err := telemetry.CreateTelemetryInstrumentation(testutils.MockVersionString)
+	if err != nil {
+		t.Fatalf("unable to create custom metrics: %s", err)
+	}
+	return &Server{
+		version:              testutils.MockVersionString,
+		logger:               testLogger,
+		instrumentation:      instrumentation,
+		sseManager:           newSseManager(ctx),
+		PrimitiveMgr:         primitives.NewPrimitiveManager(nil, nil, nil, toolsMap, map[string]tools.Toolset{"": toolset}, nil, map[string]prompts.Promptset{"": {}}, nil),
+		invocationQueueDepth: depth,
+	}
+}
+
+func TestStdioSessionInvocationQueue(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	tool := newGatedTool()
+	s := newGatedToolServer(t, ctx, tool, 2)
+
+	inR, inW := io.Pipe()
+	outR, outW := io.Pipe()
+	session := NewStdioSession(s, inR, outW)
+	go func() {
+		_ = session.Start(ctx)
+	}()
+	defer inW.Close()
+
+	responses := make(chan rpcResponse, 8)
+	go func() {
+		scanner := bufio.NewScanner(outR)
+		for scanner.Scan() {
+			var res rpcResponse
+			if err := json.Unmarshal(scanner.Bytes(), &res); err == nil {
+				responses <- res
+			}
+		}
+	}()
+	send := func(id int) {
+		if _, err := fmt.Fprintln(inW, toolsCallRequest(id, tool.Name)); err != nil {
+			t.Fatalf("unable to write request: %s", err)
+		}
+	}
+	receive := func() rpcResponse {
+		select {
+		case res := <-responses:
+			return res
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for a response")
+			return rpcResponse{}
+		}
+	}
+
+	// saturate the queue
+	send(1)
+	send(2)
+	waitStarted(t, tool, 2)
+
+	send(3)
+	busy := receive()
+	if busy.Error == nil || busy.Error.Code != jsonrpc.SERVER_BUSY {
+		t.Fatalf("expected server busy error, got %+v", busy)
+	}
+	if busy.Id != float64(3) {
+		t.Errorf("unexpected id for busy error: got %v, want 3", busy.Id)
+	}
+
+	// the queue recovers once the in-flight invocations complete
+	close(tool.gate)
+	for i := 0; i < 2; i++ {
+		if res := receive(); res.Error != nil || !bytes.Contains(res.Result, []byte("finished")) {
+			t.Fatalf("unexpected response for in-flight invocation: %+v", res)
+		}
+	}
+	send(4)
+	if res := receive(); res.Error != nil || res.Id != float64(4) {
+		t.Fatalf("unexpected response after recovery: %+v", res)
+	}
+}
+
+func TestStdioSessionWaitsForInvocations(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	tool := newGatedTool()
+	s := newGatedToolServer(t, ctx, tool, 2)
+
+	in := strings.NewReader(toolsCallRequest(1, tool.Name) + "\n")
+	var out bytes.Buffer
+	session := NewStdioSession(s, in, &out)
+	done := make(chan error, 1)
+	go func() {
+		done <- session.Start(ctx)
+	}()
+
+	// the session reads the end of its input while the invocation runs
+	waitStarted(t, tool, 1)
+	select {
+	case err := <-done:
+		t.Fatalf("session ended before the invocation completed: %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(tool.gate)
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("session did not end")
+	}
+	if !bytes.Contains(out.Bytes(), []byte("finished")) {
+		t.Errorf("expected the response of the invocation, got %q", out.String())
+	}
+}
+
+func TestHttpInvocationQueue(t *testing.T) {
+	tool := newGatedTool()
+	toolsMap := map[string]tools.Tool{tool.Name: tool}
+	toolset, err := tools.ToolsetConfig{Name: "", ToolNames: []string{tool.Name}}.Initialize(testutils.MockVersionString, toolsMap)
+	if err != nil {
+		t.Fatalf("unable to initialize toolset: %s", err)
+	}
+	r, shutdown := setUpServer(t, "mcp", toolsMap, map[string]tools.Toolset{"": toolset}, nil, nil, func(s *Server) {
+		s.httpQueue = newInvocationQueue(1, s.instrumentation, "tcp")
+	})
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	call := func(id int) <-chan invokeResult {
+		ch := make(chan invokeResult, 1)
+		go func() {
+			resp, body, err := runRequest(ts, http.MethodPost, "/", bytes.NewBufferString(toolsCallRequest(id, tool.Name)), nil)
+			if err != nil {
+				ch <- invokeResult{err: err}
+				return
+			}
+			ch <- invokeResult{status: resp.StatusCode, body: string(body)}
+		}()
+		return ch
+	}
+
+	inFlight := call(1)
+	waitStarted(t, tool, 1)
+
+	busy := <-call(2)
+	if busy.err != nil {
+		t.Fatalf("unexpected error: %s", busy.err)
+	}
+	if busy.status != http.StatusServiceUnavailable {
+		t.Errorf("unexpected status: got %d, want %d", busy.status, http.StatusServiceUnavailable)
+	}
+	var res rpcResponse
+	if err := json.Unmarshal([]byte(busy.body), &res); err != nil {
+		t.Fatalf("unable to decode response %q: %s", busy.body, err)
+	}
+	if res.Error == nil || res.Error.Code != jsonrpc.SERVER_BUSY {
+		t.Errorf("expected server busy error, got %s", busy.body)
+	}
+
+	close(tool.gate)
+	if res := <-inFlight; res.err != nil || res.status != http.StatusOK {
+		t.Fatalf("unexpected in-flight result: %+v", res)
+	}
+	if res := <-call(3); res.err != nil || res.status != http.StatusOK {
+		t.Fatalf("unexpected result after recovery: %+v", res)
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/googleapis/mcp-toolbox/internal/auth"
+	"github.com/googleapis/mcp-toolbox/internal/embeddingmodels"
+	"github.com/googleapis/mcp-toolbox/internal/prompts"
+	"github.com/googleapis/mcp-toolbox/internal/resources"
+	"github.com/googleapis/mcp-toolbox/internal/server/audit"
+	"github.com/googleapis/mcp-toolbox/internal/server/pagination"
+	"github.com/googleapis/mcp-toolbox/internal/sources"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+)
+
+// reusableSource returns current if it can be carried over to a reloaded
+// configuration where its config is sc. Sources linked to other sources are
+// always initialized again, since the sources they link to may have changed.
+func reusableSource(current sources.Source, sc sources.SourceConfig) (sources.Source, bool) {
+	if current == nil {
+		return nil, false
+	}
+	if _, ok := current.(sources.Linker); ok {
+		return nil, false
+	}
+	if reflect.ValueOf(current).Kind() != reflect.Pointer {
+		return nil, false
+	}
+	return current, reflect.DeepEqual(current.ToConfig(), sc)
+}
+
+// sameSource reports whether a and b are the same source instance.
+func sameSource(a, b sources.Source) bool {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	return va.Kind() == reflect.Pointer && va.Type() == vb.Type() && va.Pointer() == vb.Pointer()
+}
+
+// SwapPrimitives replaces the primitives of the server with those of a
+// reloaded configuration. Requests in flight keep running against the
+// sources they started with: the sources that are not carried over to the
+// new configuration are closed once these requests complete.
+func (s *Server) SwapPrimitives(ctx context.Context, sourcesMap map[string]sources.Source, authServicesMap map[string]auth.AuthService, embeddingModelsMap map[string]embeddingmodels.EmbeddingModel, toolsMap map[string]tools.Tool, toolsetsMap map[string]tools.Toolset, promptsMap map[string]prompts.Prompt, promptsetsMap map[string]prompts.Promptset, resourcesMap map[string]resources.Resource) {
+	if s.auditor != nil {
+		toolsMap = audit.Wrap(toolsMap, s.auditor)
+	}
+	if s.pages != nil {
+		toolsMap = pagination.Wrap(toolsMap, s.pages)
+	}
+	previous := s.PrimitiveMgr.GetSourcesMap()
+	s.PrimitiveMgr.SetPrimitives(sourcesMap, authServicesMap, embeddingModelsMap, toolsMap, toolsetsMap, promptsMap, promptsetsMap, resourcesMap)
+	s.retireSources(ctx, previous, sourcesMap)
+}
+
+// retireSources closes the sources of previous that are not in current once
+// the requests in flight complete.
+func (s *Server) retireSources(ctx context.Context, previous, current map[string]sources.Source) {
+	retired := make(map[string]sources.Source)
+	for name, src := range previous {
+		if cur, ok := current[name]; !ok || !sameSource(cur, src) {
+			retired[name] = src
+		}
+	}
+	if len(retired) == 0 {
+		return
+	}
+	names := strings.Join(slices.Sorted(maps.Keys(retired)), ", ")
+	settled := s.invocations.settle()
+	go func() {
+		<-settled
+		s.logger.DebugContext(ctx, fmt.Sprintf("closing %d replaced sources: %s", len(retired), names))
+		s.closeSources(retired)
+	}()
+}
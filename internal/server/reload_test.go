@@ -0,0 +1,155 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/googleapis/mcp-toolbox/internal/log"
+	"github.com/googleapis/mcp-toolbox/internal/server/primitives"
+	"github.com/googleapis/mcp-toolbox/internal/sources"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type fakeSourceConfig struct {
+	Name string
+	Host string
+}
+
+func (c fakeSourceConfig) SourceConfigType() string { return "fake" }
+
+func (c fakeSourceConfig) Initialize(context.Context, trace.Tracer) (sources.Source, error) {
+	return &fakeSource{Config: c, closed: make(chan struct{})}, nil
+}
+
+// fakeSource is a source that reports when it is closed.
+type fakeSource struct {
+	Config fakeSourceConfig
+	closed chan struct{}
+}
+
+func (s *fakeSource) SourceType() string             { return "fake" }
+func (s *fakeSource) ToConfig() sources.SourceConfig { return s.Config }
+func (s *fakeSource) Close() error {
+	close(s.closed)
+	return nil
+}
+
+type fakeLinkedSource struct{ fakeSource }
+
+func (s *fakeLinkedSource) Link(map[string]sources.Source) error { return nil }
+
+func isClosed(ch <-chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
+
+func waitClosed(ch <-chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	case <-time.After(5 * time.Second):
+		return false
+	}
+}
+
+func TestReusableSource(t *testing.T) {
+	cfg := fakeSourceConfig{Name: "my-source", Host: "127.0.0.1"}
+	current := &fakeSource{Config: cfg}
+	tcs := []struct {
+		desc    string
+		current sources.Source
+		cfg     sources.SourceConfig
+		want    bool
+	}{
+		{desc: "unchanged", current: current, cfg: cfg, want: true},
+		{desc: "changed", current: current, cfg: fakeSourceConfig{Name: "my-source", Host: "10.0.0.1"}, want: false},
+		{desc: "new", current: nil, cfg: cfg, want: false},
+		{desc: "linked", current: &fakeLinkedSource{fakeSource{Config: cfg}}, cfg: cfg, want: false},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, ok := reusableSource(tc.current, tc.cfg)
+			if ok != tc.want {
+				t.Fatalf("unexpected reuse: got %t, want %t", ok, tc.want)
+			}
+			if ok && got != tc.current {
+				t.Fatalf("reused source is not the current one")
+			}
+		})
+	}
+}
+
+func TestInvocationTrackerSettle(t *testing.T) {
+	var tracker invocationTracker
+	if settled := tracker.settle(); !waitClosed(settled) {
+		t.Fatalf("settle without requests in flight did not complete")
+	}
+
+	_, doneA, _ := tracker.start(context.Background())
+	settledA := tracker.settle()
+	_, doneB, _ := tracker.start(context.Background())
+	settledB := tracker.settle()
+	_, doneC, _ := tracker.start(context.Background())
+	defer doneC()
+
+	doneB()
+	if isClosed(settledA) || isClosed(settledB) {
+		t.Fatalf("settled before the first request completed")
+	}
+	doneA()
+	if !waitClosed(settledA) || !waitClosed(settledB) {
+		t.Fatalf("did not settle once the requests in flight completed")
+	}
+}
+
+func TestSwapPrimitivesClosesRetiredSources(t *testing.T) {
+	testLogger, err := log.NewStdLogger(io.Discard, io.Discard, "info")
+	if err != nil {
+		t.Fatalf("unable to initialize logger: %s", err)
+	}
+	kept := &fakeSource{Config: fakeSourceConfig{Name: "kept"}, closed: make(chan struct{})}
+	removed := &fakeSource{Config: fakeSourceConfig{Name: "removed"}, closed: make(chan struct{})}
+	changed := &fakeSource{Config: fakeSourceConfig{Name: "changed"}, closed: make(chan struct{})}
+	s := &Server{
+		logger:       testLogger,
+		PrimitiveMgr: primitives.NewPrimitiveManager(map[string]sources.Source{"kept": kept, "removed": removed, "changed": changed}, nil, nil, nil, nil, nil, nil, nil),
+	}
+
+	_, done, _ := s.invocations.start(context.Background())
+	replacement := &fakeSource{Config: fakeSourceConfig{Name: "changed", Host: "10.0.0.1"}, closed: make(chan struct{})}
+	s.SwapPrimitives(context.Background(), map[string]sources.Source{"kept": kept, "changed": replacement}, nil, nil, nil, nil, nil, nil, nil)
+
+	if src, _ := s.PrimitiveMgr.GetSource("changed"); src != replacement {
+		t.Fatalf("source was not swapped")
+	}
+	if isClosed(removed.closed) || isClosed(changed.closed) {
+		t.Fatalf("retired sources were closed under an in-flight request")
+	}
+	done()
+	if !waitClosed(removed.closed) || !waitClosed(changed.closed) {
+		t.Fatalf("retired sources were not closed once the request completed")
+	}
+	if isClosed(kept.closed) || isClosed(replacement.closed) {
+		t.Fatalf("sources of the new configuration were closed")
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/googleapis/mcp-toolbox/internal/testutils"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+// replicaTool records the replication lag of a fake replica it reads from.
+type replicaTool struct {
+	testutils.MockTool
+	lag float64
+}
+
+func (t replicaTool) Invoke(ctx context.Context, _ tools.SourceProvider, _ parameters.ParamValues, _ tools.AccessToken) (any, util.ToolboxError) {
+	if rl := util.ReplicaLagFromContext(ctx); rl != nil {
+		rl.Measured = true
+		rl.Seconds = t.lag
+	}
+	return []any{t.Name}, nil
+}
+
+func TestReplicaLagMeta(t *testing.T) {
+	toolsMap := map[string]tools.Tool{
+		"replica_tool": replicaTool{MockTool: testutils.NewMockTool("replica_tool", "", nil, false, false), lag: 2.5},
+		"primary_tool": testutils.NewMockTool("primary_tool", "", nil, false, false),
+	}
+	toolset, err := tools.ToolsetConfig{Name: "", ToolNames: []string{"replica_tool", "primary_tool"}}.Initialize(testutils.MockVersionString, toolsMap)
+	if err != nil {
+		t.Fatalf("unable to initialize toolset: %s", err)
+	}
+	toolsets := map[string]tools.Toolset{"": toolset}
+	tcs := []struct {
+		tool    string
+		wantLag any
+	}{
+		{tool: "replica_tool", wantLag: 2.5},
+		{tool: "primary_tool"},
+	}
+
+	t.Run("api", func(t *testing.T) {
+		r, shutdown := setUpServer(t, "api", toolsMap, toolsets, nil, nil)
+		defer shutdown()
+		ts := runServer(r, false)
+		defer ts.Close()
+
+		for _, tc := range tcs {
+			t.Run(tc.tool, func(t *testing.T) {
+				resp, body, err := runRequest(ts, http.MethodPost, "/tool/"+tc.tool+"/invoke", strings.NewReader(`{}`), nil)
+				if err != nil {
+					t.Fatalf("unexpected error during request: %s", err)
+				}
+				if resp.StatusCode != http.StatusOK {
+					t.Fatalf("unexpected status: %d, body: %s", resp.StatusCode, body)
+				}
+				var got resultResponse
+				if err := json.Unmarshal(body, &got); err != nil {
+					t.Fatalf("unable to decode response: %s", err)
+				}
+				if lag := got.Metadata["replicaLagSeconds"]; lag != tc.wantLag {
+					t.Errorf("unexpected replica lag: got %v, want %v", lag, tc.wantLag)
+				}
+			})
+		}
+	})
+
+	t.Run("mcp", func(t *testing.T) {
+		r, shutdown := setUpServer(t, "mcp", toolsMap, toolsets, nil, nil)
+		defer shutdown()
+		ts := runServer(r, false)
+		defer ts.Close()
+
+		for _, tc := range tcs {
+			t.Run(tc.tool, func(t *testing.T) {
+				resp, body, err := runRequest(ts, http.MethodPost, mcpExportCallPath, strings.NewReader(`{"name": "`+tc.tool+`", "arguments": {}}`), nil)
+				if err != nil {
+					t.Fatalf("unexpected error during request: %s", err)
+				}
+				if resp.StatusCode != http.StatusOK {
+					t.Fatalf("unexpected status: %d, body: %s", resp.StatusCode, body)
+				}
+				var got struct {
+					Meta map[string]any `json:"_meta"`
+				}
+				if err := json.Unmarshal(body, &got); err != nil {
+					t.Fatalf("unable to decode response: %s", err)
+				}
+				if lag := got.Meta["replicaLagSeconds"]; lag != tc.wantLag {
+					t.Errorf("unexpected replica lag: got %v, want %v", lag, tc.wantLag)
+				}
+			})
+		}
+	})
+}
@@ -0,0 +1,278 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package responselimit bounds the size of the results of tools, truncating
+// oversized results, failing their invocations, or compressing them with an
+// external summarizer endpoint.
+package responselimit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+	"unicode/utf8"
+
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+// What happens to a result larger than the maxResponseBytes of its tool.
+const (
+	// ModeTruncate drops the trailing rows of the result, or the end of its
+	// text, and marks it as truncated.
+	ModeTruncate = "truncate"
+	// ModeFail fails the invocation with a response_too_large error.
+	ModeFail = "fail"
+	// ModeSummarize posts the result to the summarizer endpoint and returns
+	// its summary instead.
+	ModeSummarize = "summarize"
+)
+
+// DefaultTimeout is how long an invocation waits for the summary of its
+// result.
+const DefaultTimeout = 30 * time.Second
+
+// maxSummaryResponseBytes bounds the responses read from the summarizer
+// endpoint.
+const maxSummaryResponseBytes = 16 << 20
+
+// TruncationMarker is appended to the rows of a truncated result.
+type TruncationMarker struct {
+	Truncated bool `json:"truncated"`
+	// ReturnedRows counts the rows kept in the result.
+	ReturnedRows int `json:"returnedRows"`
+	// TotalRows counts the rows of the result before its truncation.
+	TotalRows int `json:"totalRows"`
+	// TotalBytes is the size of the JSON encoded result before its
+	// truncation.
+	TotalBytes int `json:"totalBytes"`
+}
+
+// SummarizeRequest is posted to the summarizer endpoint for an oversized
+// result.
+type SummarizeRequest struct {
+	Tool string `json:"tool"`
+	// MaxBytes is the size the JSON encoded summary must fit in.
+	MaxBytes int `json:"maxBytes"`
+	// Size is the size of the JSON encoded result.
+	Size   int `json:"size"`
+	Result any `json:"result"`
+}
+
+// SummarizeResponse is the summary of a result returned by the summarizer
+// endpoint.
+type SummarizeResponse struct {
+	Result any `json:"result"`
+}
+
+// Summarizer compresses oversized results with an endpoint.
+type Summarizer struct {
+	url     string
+	timeout time.Duration
+	client  *http.Client
+}
+
+// NewSummarizer returns a summarizer posting oversized results to url. A
+// result not summarized within timeout fails its invocation.
+func NewSummarizer(url string, timeout time.Duration) (*Summarizer, error) {
+	if url == "" {
+		return nil, fmt.Errorf("a summarizer endpoint is required")
+	}
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &Summarizer{url: url, timeout: timeout, client: &http.Client{}}, nil
+}
+
+// summarize returns the summary of result, a result of tool of size bytes.
+func (s *Summarizer) summarize(ctx context.Context, tool string, maxBytes, size int, result any) (any, util.ToolboxError) {
+	body, err := json.Marshal(SummarizeRequest{Tool: tool, MaxBytes: maxBytes, Size: size, Result: result})
+	if err != nil {
+		return nil, util.NewClientServerError("unable to encode summarize request", http.StatusInternalServerError, err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, util.NewClientServerError("unable to create summarize request", http.StatusInternalServerError, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, util.NewClientServerError(fmt.Sprintf("result of tool %q was not summarized within %s", tool, s.timeout), http.StatusGatewayTimeout, err)
+		}
+		return nil, util.NewClientServerError("unable to reach summarizer endpoint", http.StatusBadGateway, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, util.NewClientServerError(fmt.Sprintf("summarizer endpoint returned status %d", resp.StatusCode), http.StatusBadGateway, nil)
+	}
+	var summary SummarizeResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxSummaryResponseBytes)).Decode(&summary); err != nil {
+		return nil, util.NewClientServerError("invalid response from summarizer endpoint", http.StatusBadGateway, err)
+	}
+	return summary.Result, nil
+}
+
+// limitOf returns the maxResponseBytes of a tool config and what happens to
+// the results exceeding it.
+func limitOf(cfg tools.ToolConfig) (int, string) {
+	c, ok := cfg.(interface {
+		GetMaxResponseBytes() int
+		GetOversizedResponse() string
+	})
+	if !ok {
+		return 0, ""
+	}
+	mode := c.GetOversizedResponse()
+	if mode == "" {
+		mode = ModeTruncate
+	}
+	return c.GetMaxResponseBytes(), mode
+}
+
+// Wrap returns the tools with the results of the tools that have a
+// maxResponseBytes bounded to it. A nil s is an error if any tool
+// summarizes its oversized results.
+//
+// The results of the bounded tools are not streamed, as their size is only
+// known once complete.
+func Wrap(toolsMap map[string]tools.Tool, s *Summarizer) (map[string]tools.Tool, error) {
+	wrapped := make(map[string]tools.Tool, len(toolsMap))
+	for name, t := range toolsMap {
+		maxBytes, mode := limitOf(t.ToConfig())
+		if maxBytes == 0 {
+			wrapped[name] = t
+			continue
+		}
+		if maxBytes < 0 {
+			return nil, fmt.Errorf("invalid maxResponseBytes %d of tool %q: must be positive", maxBytes, name)
+		}
+		switch mode {
+		case ModeTruncate, ModeFail:
+		case ModeSummarize:
+			if s == nil {
+				return nil, fmt.Errorf("tool %q summarizes its oversized results, but the server has no summarizer endpoint", name)
+			}
+		default:
+			return nil, fmt.Errorf("invalid oversizedResponse %q of tool %q: must be one of %q, %q or %q", mode, name, ModeTruncate, ModeFail, ModeSummarize)
+		}
+		wrapped[name] = limitedTool{Tool: t, maxBytes: maxBytes, mode: mode, summarizer: s}
+	}
+	return wrapped, nil
+}
+
+// limitedTool is a tool whose results are bounded to maxBytes.
+type limitedTool struct {
+	tools.Tool
+	maxBytes   int
+	mode       string
+	summarizer *Summarizer
+}
+
+// Unwrap implements tools.Wrapper.
+func (t limitedTool) Unwrap() tools.Tool { return t.Tool }
+
+func (t limitedTool) Invoke(ctx context.Context, sp tools.SourceProvider, params parameters.ParamValues, token tools.AccessToken) (any, util.ToolboxError) {
+	result, err := t.Tool.Invoke(ctx, sp, params, token)
+	if err != nil {
+		return nil, err
+	}
+	encoded, mErr := json.Marshal(result)
+	if mErr != nil || len(encoded) <= t.maxBytes {
+		// Results that can't be encoded fail later, when they are returned.
+		return result, nil
+	}
+	size := len(encoded)
+	switch t.mode {
+	case ModeFail:
+		return nil, util.NewAgentError(fmt.Sprintf("result of tool %q is too large", t.GetName()), &util.ResponseTooLargeError{Size: size, Max: t.maxBytes})
+	case ModeSummarize:
+		summary, err := t.summarizer.summarize(ctx, t.GetName(), t.maxBytes, size, result)
+		if err != nil {
+			return nil, err
+		}
+		// A summary that is still too large is truncated.
+		if encoded, mErr := json.Marshal(summary); mErr == nil && len(encoded) > t.maxBytes {
+			return Truncate(summary, len(encoded), t.maxBytes), nil
+		}
+		return summary, nil
+	}
+	return Truncate(result, size, t.maxBytes), nil
+}
+
+// DryRun implements tools.DryRunner. Dry runs return no result to bound.
+func (t limitedTool) DryRun(ctx context.Context, sp tools.SourceProvider, params parameters.ParamValues, token tools.AccessToken) (*tools.DryRunResult, util.ToolboxError) {
+	return tools.DryRun(ctx, t.Tool, sp, params, token)
+}
+
+// Truncate returns result, whose JSON encoding is size bytes, cut to fit in
+// maxBytes. Rows are dropped from the end of a slice of rows, which is
+// followed by a TruncationMarker. Any other result is returned as the
+// beginning of its JSON encoding, followed by a note.
+func Truncate(result any, size, maxBytes int) any {
+	rows, ok := result.([]any)
+	if !ok {
+		return truncateText(result, size, maxBytes)
+	}
+	marker := TruncationMarker{Truncated: true, ReturnedRows: len(rows), TotalRows: len(rows), TotalBytes: size}
+	// The marker is sized with every row returned, which has the most
+	// digits, and the brackets of the slice.
+	encodedMarker, _ := json.Marshal(marker)
+	used := len(encodedMarker) + 2
+	n := 0
+	for ; n < len(rows); n++ {
+		encoded, err := json.Marshal(rows[n])
+		if err != nil || used+len(encoded)+1 > maxBytes {
+			break
+		}
+		used += len(encoded) + 1
+	}
+	marker.ReturnedRows = n
+	truncated := make([]any, 0, n+1)
+	truncated = append(truncated, rows[:n]...)
+	return append(truncated, marker)
+}
+
+// truncateText returns the beginning of the JSON encoding of result that
+// fits in maxBytes once encoded as a JSON string, followed by a note.
+func truncateText(result any, size, maxBytes int) string {
+	text, ok := result.(string)
+	if !ok {
+		encoded, _ := json.Marshal(result)
+		text = string(encoded)
+	}
+	note := fmt.Sprintf("... [truncated: result of %d bytes exceeds the limit of %d bytes]", size, maxBytes)
+	cut := min(len(text), max(maxBytes-len(note)-2, 0))
+	for {
+		for cut > 0 && cut < len(text) && !utf8.RuneStart(text[cut]) {
+			cut--
+		}
+		truncated := text[:cut] + note
+		encoded, _ := json.Marshal(truncated)
+		if len(encoded) <= maxBytes || cut == 0 {
+			return truncated
+		}
+		// Escaped characters take more bytes once encoded.
+		cut = max(cut-(len(encoded)-maxBytes), 0)
+	}
+}
@@ -0,0 +1,195 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package responselimit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/internal/testutils"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+type listConfig struct {
+	tools.ConfigBase
+}
+
+func (listConfig) ToolConfigType() string { return "list" }
+func (listConfig) Initialize(context.Context) (tools.Tool, error) {
+	return nil, nil
+}
+
+// listTool returns its result.
+type listTool struct {
+	testutils.MockTool
+	cfg    listConfig
+	result any
+}
+
+func (t listTool) Invoke(context.Context, tools.SourceProvider, parameters.ParamValues, tools.AccessToken) (any, util.ToolboxError) {
+	return t.result, nil
+}
+
+func (t listTool) ToConfig() tools.ToolConfig { return t.cfg }
+
+func newListTool(maxBytes int, mode string, result any) tools.Tool {
+	return listTool{
+		MockTool: testutils.MockTool{Name: "list_orders"},
+		cfg:      listConfig{tools.ConfigBase{Name: "list_orders", MaxResponseBytes: maxBytes, OversizedResponse: mode}},
+		result:   result,
+	}
+}
+
+// orders returns n rows of about 30 bytes each.
+func orders(n int) []any {
+	rows := make([]any, n)
+	for i := range rows {
+		rows[i] = map[string]any{"id": i, "status": "shipped"}
+	}
+	return rows
+}
+
+func encodedSize(t *testing.T, v any) int {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("unable to encode %v: %s", v, err)
+	}
+	return len(b)
+}
+
+func TestTruncate(t *testing.T) {
+	rows := orders(100)
+	size := encodedSize(t, rows)
+	got := Truncate(rows, size, 500).([]any)
+	if n := encodedSize(t, got); n > 500 {
+		t.Errorf("truncated rows are %d bytes, want at most 500", n)
+	}
+	marker, ok := got[len(got)-1].(TruncationMarker)
+	if !ok {
+		t.Fatalf("last row is %v, want a truncation marker", got[len(got)-1])
+	}
+	want := TruncationMarker{Truncated: true, ReturnedRows: len(got) - 1, TotalRows: 100, TotalBytes: size}
+	if diff := cmp.Diff(want, marker); diff != "" {
+		t.Errorf("unexpected marker (-want +got):\n%s", diff)
+	}
+	if marker.ReturnedRows == 0 || !cmp.Equal(got[:marker.ReturnedRows], rows[:marker.ReturnedRows]) {
+		t.Errorf("truncated rows aren't the first rows: %v", got)
+	}
+
+	text := strings.Repeat("é\"", 200)
+	got2, ok := Truncate(text, encodedSize(t, text), 120).(string)
+	if !ok {
+		t.Fatalf("truncated text isn't a string")
+	}
+	if n := encodedSize(t, got2); n > 120 {
+		t.Errorf("truncated text is %d bytes, want at most 120", n)
+	}
+	if !strings.HasPrefix(got2, "é\"") || !strings.Contains(got2, "[truncated: result of") {
+		t.Errorf("unexpected truncated text %q", got2)
+	}
+}
+
+func TestWrap(t *testing.T) {
+	summaries := make(chan SummarizeRequest, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req SummarizeRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		summaries <- req
+		_ = json.NewEncoder(w).Encode(SummarizeResponse{Result: "100 shipped orders"})
+	}))
+	defer srv.Close()
+	summarizer, err := NewSummarizer(srv.URL, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	ctx := context.Background()
+
+	t.Run("small result", func(t *testing.T) {
+		wrapped, err := Wrap(map[string]tools.Tool{"list_orders": newListTool(1<<20, ModeFail, orders(10))}, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		got, tbErr := wrapped["list_orders"].Invoke(ctx, nil, nil, "")
+		if tbErr != nil || len(got.([]any)) != 10 {
+			t.Errorf("got %v, %v, want the result unchanged", got, tbErr)
+		}
+	})
+
+	t.Run("truncate", func(t *testing.T) {
+		wrapped, err := Wrap(map[string]tools.Tool{"list_orders": newListTool(500, "", orders(100))}, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		got, tbErr := wrapped["list_orders"].Invoke(ctx, nil, nil, "")
+		if tbErr != nil {
+			t.Fatalf("unexpected error: %s", tbErr)
+		}
+		if n := encodedSize(t, got); n > 500 {
+			t.Errorf("result is %d bytes, want at most 500", n)
+		}
+	})
+
+	t.Run("fail", func(t *testing.T) {
+		wrapped, err := Wrap(map[string]tools.Tool{"list_orders": newListTool(500, ModeFail, orders(100))}, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		_, tbErr := wrapped["list_orders"].Invoke(ctx, nil, nil, "")
+		if tbErr == nil || tbErr.Category() != util.CategoryAgent || !errors.Is(tbErr, util.ErrResponseTooLarge) {
+			t.Fatalf("got error %v, want a response too large agent error", tbErr)
+		}
+		if info := util.ClassifyError(tbErr); info.Code != util.ErrorCodeResponseTooLarge {
+			t.Errorf("got error code %q, want %q", info.Code, util.ErrorCodeResponseTooLarge)
+		}
+	})
+
+	t.Run("summarize", func(t *testing.T) {
+		rows := orders(100)
+		wrapped, err := Wrap(map[string]tools.Tool{"list_orders": newListTool(500, ModeSummarize, rows)}, summarizer)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		got, tbErr := wrapped["list_orders"].Invoke(ctx, nil, nil, "")
+		if tbErr != nil {
+			t.Fatalf("unexpected error: %s", tbErr)
+		}
+		if got != "100 shipped orders" {
+			t.Errorf("got %v, want the summary", got)
+		}
+		req := <-summaries
+		if req.Tool != "list_orders" || req.MaxBytes != 500 || req.Size != encodedSize(t, rows) || len(req.Result.([]any)) != 100 {
+			t.Errorf("unexpected summarize request: %+v", req)
+		}
+	})
+
+	t.Run("summarize without summarizer", func(t *testing.T) {
+		_, err := Wrap(map[string]tools.Tool{"list_orders": newListTool(500, ModeSummarize, nil)}, nil)
+		if err == nil || !strings.Contains(err.Error(), "no summarizer endpoint") {
+			t.Errorf("got error %v, want a missing summarizer error", err)
+		}
+	})
+}
@@ -0,0 +1,110 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resultcache
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/googleapis/mcp-toolbox/internal/log"
+)
+
+// retryInterval is how long the fallback serves from the in-process LRU
+// before trying the remote backend again.
+const retryInterval = 30 * time.Second
+
+// remote is a backend shared by servers, such as Memcached or Redis.
+type remote interface {
+	Backend
+	fmt.Stringer
+	// ping checks that the backend is reachable.
+	ping(ctx context.Context) error
+}
+
+var _ Backend = &fallback{}
+
+// fallback serves from a remote backend, and from an in-process LRU while the
+// remote backend is unavailable. The remote backend is retried every
+// retryInterval.
+type fallback struct {
+	primary   remote
+	secondary Backend
+	logger    log.Logger
+
+	mu      sync.Mutex
+	retryAt time.Time
+}
+
+func newFallback(ctx context.Context, primary remote, secondary Backend, logger log.Logger) *fallback {
+	f := &fallback{primary: primary, secondary: secondary, logger: logger}
+	if err := primary.ping(ctx); err != nil {
+		f.fail(ctx, err)
+	}
+	return f
+}
+
+// usePrimary reports whether the remote backend should be tried.
+func (f *fallback) usePrimary() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.retryAt.IsZero() || !time.Now().Before(f.retryAt)
+}
+
+// fail switches to the in-process LRU, warning when the remote backend was
+// available.
+func (f *fallback) fail(ctx context.Context, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.retryAt.IsZero() {
+		f.logger.WarnContext(ctx, fmt.Sprintf("%s; falling back to the in-process result cache", err))
+	}
+	f.retryAt = time.Now().Add(retryInterval)
+}
+
+// recover switches back to the remote backend after it was unavailable.
+func (f *fallback) recover(ctx context.Context) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !f.retryAt.IsZero() {
+		f.logger.InfoContext(ctx, fmt.Sprintf("%s is available again", f.primary))
+		f.retryAt = time.Time{}
+	}
+}
+
+func (f *fallback) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	if f.usePrimary() {
+		value, ok, err := f.primary.Get(ctx, key)
+		if err == nil {
+			f.recover(ctx)
+			return value, ok, nil
+		}
+		f.fail(ctx, err)
+	}
+	return f.secondary.Get(ctx, key)
+}
+
+func (f *fallback) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if f.usePrimary() {
+		err := f.primary.Set(ctx, key, value, ttl)
+		if err == nil {
+			f.recover(ctx)
+			return nil
+		}
+		f.fail(ctx, err)
+	}
+	return f.secondary.Set(ctx, key, value, ttl)
+}
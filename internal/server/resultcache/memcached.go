@@ -0,0 +1,74 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resultcache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bradfitz/gomemcache/memcache"
+)
+
+var _ remote = &memcached{}
+
+// memcached is a backend storing results in Memcached.
+type memcached struct {
+	addrs  string
+	client *memcache.Client
+}
+
+func newMemcached(addrs []string) *memcached {
+	return &memcached{
+		addrs:  strings.Join(addrs, ","),
+		client: memcache.New(addrs...),
+	}
+}
+
+func (m *memcached) String() string {
+	return "memcached at " + m.addrs
+}
+
+func (m *memcached) ping(context.Context) error {
+	if err := m.client.Ping(); err != nil {
+		return fmt.Errorf("unable to reach memcached at %s: %w", m.addrs, err)
+	}
+	return nil
+}
+
+func (m *memcached) Get(_ context.Context, key string) ([]byte, bool, error) {
+	item, err := m.client.Get(key)
+	if errors.Is(err, memcache.ErrCacheMiss) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("unable to get from memcached at %s: %w", m.addrs, err)
+	}
+	return item.Value, true, nil
+}
+
+func (m *memcached) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	err := m.client.Set(&memcache.Item{
+		Key:        key,
+		Value:      value,
+		Expiration: int32(ttl.Round(time.Second) / time.Second),
+	})
+	if err != nil {
+		return fmt.Errorf("unable to set in memcached at %s: %w", m.addrs, err)
+	}
+	return nil
+}
@@ -0,0 +1,284 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package resultcache caches the results of read-only tool invocations.
+package resultcache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/googleapis/mcp-toolbox/internal/log"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+const (
+	// BackendMemory caches results in an in-process LRU.
+	BackendMemory = "memory"
+	// BackendMemcached caches results in Memcached, falling back to an
+	// in-process LRU while Memcached is unavailable.
+	BackendMemcached = "memcached"
+	// BackendRedis caches results in Redis, falling back to an in-process
+	// LRU while Redis is unavailable.
+	BackendRedis = "redis"
+)
+
+// DefaultTTL is how long results are cached by default.
+const DefaultTTL = 5 * time.Minute
+
+// Backend stores encoded tool results.
+type Backend interface {
+	// Get returns the value stored under key, and whether there was one.
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set stores value under key for ttl.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+}
+
+// NewBackend returns the backend of the given type. memcachedAddrs is only
+// used by the memcached backend, and redisURL by the redis backend.
+func NewBackend(ctx context.Context, backendType string, memcachedAddrs []string, redisURL string, logger log.Logger) (Backend, error) {
+	switch backendType {
+	case BackendMemory:
+		return NewLRU(DefaultLRUSize), nil
+	case BackendMemcached:
+		if len(memcachedAddrs) == 0 {
+			return nil, fmt.Errorf("cache backend %q requires at least one memcached address", BackendMemcached)
+		}
+		return newFallback(ctx, newMemcached(memcachedAddrs), NewLRU(DefaultLRUSize), logger), nil
+	case BackendRedis:
+		if redisURL == "" {
+			return nil, fmt.Errorf("cache backend %q requires a redis URL", BackendRedis)
+		}
+		r, err := newRedis(redisURL)
+		if err != nil {
+			return nil, err
+		}
+		return newFallback(ctx, r, NewLRU(DefaultLRUSize), logger), nil
+	default:
+		return nil, fmt.Errorf("invalid cache backend %q: must be %q, %q or %q", backendType, BackendMemory, BackendMemcached, BackendRedis)
+	}
+}
+
+// Key returns the cache key of an invocation of a tool:
+// `toolbox:{toolName}:{SHA256(params)}`.
+func Key(toolName string, params parameters.ParamValues) (string, error) {
+	b, err := json.Marshal(params)
+	if err != nil {
+		return "", fmt.Errorf("unable to encode parameters: %w", err)
+	}
+	sum := sha256.Sum256(b)
+	return fmt.Sprintf("toolbox:%s:%s", toolName, hex.EncodeToString(sum[:])), nil
+}
+
+// entry is the encoded form of a tool result. Results are cached as the JSON
+// they are sent to clients as, so a cached result is returned as
+// json.RawMessage values.
+type entry struct {
+	Items  []json.RawMessage `json:"items"`
+	Single bool              `json:"single,omitempty"`
+}
+
+func encode(result any) ([]byte, error) {
+	var e entry
+	items, ok := result.([]any)
+	if !ok {
+		items = []any{result}
+		e.Single = true
+	}
+	e.Items = make([]json.RawMessage, 0, len(items))
+	for _, item := range items {
+		b, err := json.Marshal(item)
+		if err != nil {
+			return nil, err
+		}
+		e.Items = append(e.Items, b)
+	}
+	return json.Marshal(e)
+}
+
+func decode(b []byte) (any, error) {
+	var e entry
+	if err := json.Unmarshal(b, &e); err != nil {
+		return nil, err
+	}
+	if e.Single {
+		if len(e.Items) != 1 {
+			return nil, fmt.Errorf("cached result has %d items, want 1", len(e.Items))
+		}
+		return e.Items[0], nil
+	}
+	items := make([]any, len(e.Items))
+	for i, item := range e.Items {
+		items[i] = item
+	}
+	return items, nil
+}
+
+// Cache caches tool results in a backend.
+type Cache struct {
+	backend Backend
+	ttl     time.Duration
+}
+
+// New returns a cache storing results in backend for ttl.
+func New(backend Backend, ttl time.Duration) *Cache {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	return &Cache{backend: backend, ttl: ttl}
+}
+
+func (c *Cache) get(ctx context.Context, key string) (any, bool) {
+	b, ok, err := c.backend.Get(ctx, key)
+	if err != nil {
+		logDebug(ctx, "unable to read cached result %q: %s", key, err)
+		return nil, false
+	}
+	if !ok {
+		return nil, false
+	}
+	res, err := decode(b)
+	if err != nil {
+		logDebug(ctx, "unable to decode cached result %q: %s", key, err)
+		return nil, false
+	}
+	return res, true
+}
+
+func (c *Cache) set(ctx context.Context, key string, result any, ttl time.Duration) {
+	b, err := encode(result)
+	if err != nil {
+		logDebug(ctx, "unable to encode result %q: %s", key, err)
+		return
+	}
+	if ttl <= 0 {
+		ttl = c.ttl
+	}
+	if err := c.backend.Set(ctx, key, b, ttl); err != nil {
+		logDebug(ctx, "unable to cache result %q: %s", key, err)
+	}
+}
+
+func logDebug(ctx context.Context, format string, args ...any) {
+	if l, err := util.LoggerFromContext(ctx); err == nil {
+		l.DebugContext(ctx, fmt.Sprintf(format, args...))
+	}
+}
+
+// cacheOf returns the cache settings of a tool config, or nil if it has none.
+func cacheOf(cfg tools.ToolConfig) *tools.Cache {
+	if c, ok := cfg.(interface{ GetCache() *tools.Cache }); ok {
+		return c.GetCache()
+	}
+	return nil
+}
+
+// WrapReadOnly returns the tools with every read-only tool, as declared by
+// its readOnlyHint annotation, and every tool enabling its cache caching its
+// results in c. Tools disabling their cache are never cached.
+func WrapReadOnly(toolsMap map[string]tools.Tool, c *Cache) (map[string]tools.Tool, error) {
+	return wrap(toolsMap, c, true)
+}
+
+// WrapEnabled returns the tools with every tool enabling its cache caching
+// its results in c.
+func WrapEnabled(toolsMap map[string]tools.Tool, c *Cache) (map[string]tools.Tool, error) {
+	return wrap(toolsMap, c, false)
+}
+
+// Enabled reports whether any of the tools enables its cache.
+func Enabled(toolsMap map[string]tools.Tool) bool {
+	for _, t := range toolsMap {
+		if settings := cacheOf(t.ToConfig()); settings != nil && settings.Enabled != nil && *settings.Enabled {
+			return true
+		}
+	}
+	return false
+}
+
+func wrap(toolsMap map[string]tools.Tool, c *Cache, readOnly bool) (map[string]tools.Tool, error) {
+	wrapped := make(map[string]tools.Tool, len(toolsMap))
+	for name, t := range toolsMap {
+		enabled := false
+		if readOnly {
+			a := t.GetAnnotations()
+			enabled = a != nil && a.ReadOnlyHint != nil && *a.ReadOnlyHint
+		}
+		var ttl time.Duration
+		if settings := cacheOf(t.ToConfig()); settings != nil {
+			if settings.Enabled != nil {
+				enabled = *settings.Enabled
+			}
+			if settings.TTL != "" {
+				d, err := time.ParseDuration(settings.TTL)
+				if err != nil || d <= 0 {
+					return nil, fmt.Errorf("invalid cache ttl %q of tool %q: must be a positive duration such as \"10m\"", settings.TTL, name)
+				}
+				ttl = d
+			}
+		}
+		if enabled {
+			t = cachedTool{Tool: t, cache: c, ttl: ttl}
+		}
+		wrapped[name] = t
+	}
+	return wrapped, nil
+}
+
+// cachedTool is a tool whose results are cached.
+type cachedTool struct {
+	tools.Tool
+	cache *Cache
+	// ttl is how long the results of the tool are cached, or 0 for the TTL
+	// of the cache.
+	ttl time.Duration
+}
+
+// Unwrap implements tools.Wrapper.
+func (t cachedTool) Unwrap() tools.Tool { return t.Tool }
+
+// Invoke returns the cached result of an identical invocation, or invokes
+// the tool and caches its result. Invocations with a client access token or
+// authenticated by an auth service are never cached, since their results
+// depend on the caller, such as on sources impersonating it.
+func (t cachedTool) Invoke(ctx context.Context, sp tools.SourceProvider, params parameters.ParamValues, token tools.AccessToken) (any, util.ToolboxError) {
+	if token != "" || len(util.AuthServiceClaimsFromContext(ctx)) > 0 {
+		return t.Tool.Invoke(ctx, sp, params, token)
+	}
+	key, err := Key(t.GetName(), params)
+	if err != nil {
+		logDebug(ctx, "unable to compute cache key of tool %q: %s", t.GetName(), err)
+		return t.Tool.Invoke(ctx, sp, params, token)
+	}
+	if res, ok := t.cache.get(ctx, key); ok {
+		return res, nil
+	}
+	res, tbErr := t.Tool.Invoke(ctx, sp, params, token)
+	if tbErr != nil {
+		return res, tbErr
+	}
+	t.cache.set(ctx, key, res, t.ttl)
+	return res, nil
+}
+
+// DryRun implements tools.DryRunner, bypassing the cache.
+func (t cachedTool) DryRun(ctx context.Context, sp tools.SourceProvider, params parameters.ParamValues, token tools.AccessToken) (*tools.DryRunResult, util.ToolboxError) {
+	return tools.DryRun(ctx, t.Tool, sp, params, token)
+}
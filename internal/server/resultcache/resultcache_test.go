@@ -0,0 +1,252 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resultcache
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/googleapis/mcp-toolbox/internal/log"
+	"github.com/googleapis/mcp-toolbox/internal/testutils"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+// countingTool counts its invocations.
+type countingTool struct {
+	testutils.MockTool
+	readOnly bool
+	calls    *int
+	cache    *tools.Cache
+}
+
+// countingConfig is the config of a countingTool.
+type countingConfig struct {
+	tools.ConfigBase
+}
+
+func (countingConfig) ToolConfigType() string { return "counting" }
+func (countingConfig) Initialize(context.Context) (tools.Tool, error) {
+	return nil, nil
+}
+
+func (t countingTool) ToConfig() tools.ToolConfig {
+	return countingConfig{tools.ConfigBase{Name: t.Name, Cache: t.cache}}
+}
+
+func (t countingTool) GetAnnotations() *tools.ToolAnnotations {
+	if t.readOnly {
+		return tools.NewReadOnlyAnnotations()
+	}
+	return tools.NewWriteAnnotations()
+}
+
+func (t countingTool) Invoke(_ context.Context, _ tools.SourceProvider, params parameters.ParamValues, _ tools.AccessToken) (any, util.ToolboxError) {
+	*t.calls++
+	return []any{map[string]any{"call": *t.calls, "params": params.AsMap()}}, nil
+}
+
+func TestKey(t *testing.T) {
+	params := parameters.ParamValues{{Name: "id", Value: 1}}
+	key, err := Key("get-user", params)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !regexp.MustCompile(`^toolbox:get-user:[0-9a-f]{64}$`).MatchString(key) {
+		t.Errorf("unexpected key format: %s", key)
+	}
+	other, err := Key("get-user", parameters.ParamValues{{Name: "id", Value: 2}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if key == other {
+		t.Errorf("expected different parameters to have different keys")
+	}
+}
+
+func TestEncodeDecode(t *testing.T) {
+	for _, result := range []any{
+		[]any{map[string]any{"a": 1}, "b"},
+		map[string]any{"a": 1},
+		[]any{},
+	} {
+		b, err := encode(result)
+		if err != nil {
+			t.Fatalf("unable to encode %v: %s", result, err)
+		}
+		got, err := decode(b)
+		if err != nil {
+			t.Fatalf("unable to decode %v: %s", result, err)
+		}
+		want, _ := json.Marshal(result)
+		if gotJSON, _ := json.Marshal(got); !bytes.Equal(gotJSON, want) {
+			t.Errorf("unexpected round trip: got %s, want %s", gotJSON, want)
+		}
+	}
+}
+
+func TestLRU(t *testing.T) {
+	ctx := context.Background()
+	c := NewLRU(2)
+	_ = c.Set(ctx, "a", []byte("1"), time.Minute)
+	_ = c.Set(ctx, "b", []byte("2"), time.Minute)
+	// a becomes the most recently used, so c evicts b
+	if _, ok, _ := c.Get(ctx, "a"); !ok {
+		t.Fatalf("expected a to be cached")
+	}
+	_ = c.Set(ctx, "c", []byte("3"), time.Minute)
+	if _, ok, _ := c.Get(ctx, "b"); ok {
+		t.Errorf("expected b to be evicted")
+	}
+	if v, ok, _ := c.Get(ctx, "c"); !ok || string(v) != "3" {
+		t.Errorf("unexpected value for c: %q, %t", v, ok)
+	}
+
+	_ = c.Set(ctx, "expired", []byte("4"), -time.Second)
+	if _, ok, _ := c.Get(ctx, "expired"); ok {
+		t.Errorf("expected expired value to be missing")
+	}
+}
+
+func TestWrapReadOnly(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var readCalls, writeCalls int
+	toolsMap := map[string]tools.Tool{
+		"read":  countingTool{MockTool: testutils.NewMockTool("read", "", nil, false, false), readOnly: true, calls: &readCalls},
+		"write": countingTool{MockTool: testutils.NewMockTool("write", "", nil, false, false), calls: &writeCalls},
+	}
+	wrapped, err := WrapReadOnly(toolsMap, New(NewLRU(DefaultLRUSize), time.Minute))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	invoke := func(name string, id int, token tools.AccessToken) string {
+		res, err := wrapped[name].Invoke(ctx, nil, parameters.ParamValues{{Name: "id", Value: id}}, token)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		b, _ := json.Marshal(res)
+		return string(b)
+	}
+
+	first := invoke("read", 1, "")
+	if got := invoke("read", 1, ""); got != first {
+		t.Errorf("expected cached result %s, got %s", first, got)
+	}
+	invoke("read", 2, "")
+	invoke("read", 1, "token")
+	if readCalls != 3 {
+		t.Errorf("unexpected read-only tool invocations: got %d, want 3", readCalls)
+	}
+	authCtx := util.WithAuthServiceClaims(ctx, map[string]map[string]any{"my-google-auth": {"email": "alice@example.com"}})
+	if _, err := wrapped["read"].Invoke(authCtx, nil, parameters.ParamValues{{Name: "id", Value: 1}}, ""); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if readCalls != 4 {
+		t.Errorf("expected authenticated invocation to bypass the cache: got %d invocations, want 4", readCalls)
+	}
+
+	invoke("write", 1, "")
+	invoke("write", 1, "")
+	if writeCalls != 2 {
+		t.Errorf("unexpected write tool invocations: got %d, want 2", writeCalls)
+	}
+}
+
+func TestWrapToolCache(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	enabled, disabled := true, false
+	var optOutCalls, optInCalls, defaultCalls int
+	toolsMap := map[string]tools.Tool{
+		"opt-out": countingTool{MockTool: testutils.NewMockTool("opt-out", "", nil, false, false), readOnly: true, calls: &optOutCalls, cache: &tools.Cache{Enabled: &disabled}},
+		"opt-in":  countingTool{MockTool: testutils.NewMockTool("opt-in", "", nil, false, false), calls: &optInCalls, cache: &tools.Cache{Enabled: &enabled, TTL: "1h"}},
+		"default": countingTool{MockTool: testutils.NewMockTool("default", "", nil, false, false), readOnly: true, calls: &defaultCalls},
+	}
+	if !Enabled(toolsMap) {
+		t.Errorf("expected a tool to enable its cache")
+	}
+
+	backend := NewLRU(DefaultLRUSize)
+	wrapped, err := WrapEnabled(toolsMap, New(backend, time.Minute))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for _, name := range []string{"opt-out", "opt-in", "default"} {
+		for range 2 {
+			if _, err := wrapped[name].Invoke(ctx, nil, parameters.ParamValues{{Name: "id", Value: 1}}, ""); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		}
+	}
+	if optOutCalls != 2 || optInCalls != 1 || defaultCalls != 2 {
+		t.Errorf("unexpected invocations: opt-out %d, opt-in %d, default %d; want 2, 1, 2", optOutCalls, optInCalls, defaultCalls)
+	}
+	key, _ := Key("opt-in", parameters.ParamValues{{Name: "id", Value: 1}})
+	if item, ok := backend.items[key]; !ok || time.Until(item.Value.(*lruItem).expiresAt) < 59*time.Minute {
+		t.Errorf("expected the result of opt-in to be cached for its ttl")
+	}
+
+	toolsMap["opt-in"] = countingTool{MockTool: testutils.NewMockTool("opt-in", "", nil, false, false), calls: &optInCalls, cache: &tools.Cache{Enabled: &enabled, TTL: "soon"}}
+	if _, err := WrapReadOnly(toolsMap, New(backend, time.Minute)); err == nil {
+		t.Errorf("expected an error for an invalid ttl")
+	}
+}
+
+func TestMemcachedFallback(t *testing.T) {
+	ctx := context.Background()
+	var logs strings.Builder
+	logger, err := log.NewStdLogger(io.Discard, &logs, "info")
+	if err != nil {
+		t.Fatalf("unable to initialize logger: %s", err)
+	}
+
+	// nothing listens on port 1
+	backend, err := NewBackend(ctx, BackendMemcached, []string{"127.0.0.1:1"}, "", logger)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !strings.Contains(logs.String(), "falling back to the in-process result cache") {
+		t.Errorf("expected a fallback warning, got logs: %s", logs.String())
+	}
+	if err := backend.Set(ctx, "key", []byte("value"), time.Minute); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if v, ok, err := backend.Get(ctx, "key"); err != nil || !ok || string(v) != "value" {
+		t.Errorf("expected value from the in-process cache, got %q, %t, %v", v, ok, err)
+	}
+
+	if _, err := NewBackend(ctx, BackendMemcached, nil, "", logger); err == nil {
+		t.Errorf("expected an error without memcached addresses")
+	}
+	if _, err := NewBackend(ctx, BackendRedis, nil, "", logger); err == nil {
+		t.Errorf("expected an error without a redis URL")
+	}
+	if _, err := NewBackend(ctx, "couchbase", nil, "", logger); err == nil {
+		t.Errorf("expected an error for an invalid backend")
+	}
+}
@@ -0,0 +1,330 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package resultformat encodes tool results in the output formats clients
+// can request instead of JSON.
+package resultformat
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+)
+
+// MediaTypes are the media types of the output formats of tool results.
+var MediaTypes = map[string]string{
+	"json":     "application/json",
+	"csv":      "text/csv",
+	"markdown": "text/markdown",
+	"parquet":  "application/vnd.apache.parquet",
+	"arrow":    "application/vnd.apache.arrow.stream",
+	"ndjson":   "application/x-ndjson",
+}
+
+// IsText reports whether format encodes results as text, as opposed to
+// binary formats such as Arrow.
+func IsText(format string) bool {
+	return format != "parquet" && format != "arrow"
+}
+
+// SupportedFormats returns the supportedFormats of a tool.
+func SupportedFormats(tool tools.Tool) []string {
+	if c, ok := tool.ToConfig().(interface{ GetSupportedFormats() []string }); ok {
+		return c.GetSupportedFormats()
+	}
+	return nil
+}
+
+// table is a tool result as rows of columns. Nested values are kept as their
+// JSON encoding.
+type table struct {
+	columns []string
+	rows    []map[string]json.RawMessage
+}
+
+// tabulate turns a tool result into a table: a list of objects is a row per
+// object, with the keys of the objects as columns in the order they are first
+// seen; any other result is a single row with a "value" column.
+func tabulate(res any) (*table, error) {
+	b, err := json.Marshal(res)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal result: %w", err)
+	}
+	var items []json.RawMessage
+	if err := json.Unmarshal(b, &items); err != nil {
+		items = []json.RawMessage{b}
+	}
+
+	t := &table{}
+	seen := map[string]bool{}
+	for _, item := range items {
+		keys, row, err := decodeObject(item)
+		if err != nil {
+			keys, row = []string{"value"}, map[string]json.RawMessage{"value": item}
+		}
+		for _, k := range keys {
+			if !seen[k] {
+				seen[k] = true
+				t.columns = append(t.columns, k)
+			}
+		}
+		t.rows = append(t.rows, row)
+	}
+	return t, nil
+}
+
+// decodeObject decodes a JSON object, returning its keys in order.
+func decodeObject(b json.RawMessage) ([]string, map[string]json.RawMessage, error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil, nil, fmt.Errorf("not an object")
+	}
+	var keys []string
+	row := map[string]json.RawMessage{}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, nil, err
+		}
+		key := tok.(string)
+		var v json.RawMessage
+		if err := dec.Decode(&v); err != nil {
+			return nil, nil, err
+		}
+		if _, ok := row[key]; !ok {
+			keys = append(keys, key)
+		}
+		row[key] = v
+	}
+	return keys, row, nil
+}
+
+// text returns the text of a JSON value: strings unquoted, null empty, and
+// anything else as is.
+func text(v json.RawMessage) (string, bool) {
+	if len(v) == 0 || string(v) == "null" {
+		return "", false
+	}
+	var s string
+	if err := json.Unmarshal(v, &s); err == nil {
+		return s, true
+	}
+	return string(v), true
+}
+
+// Encode writes a tool result in a non-JSON output format.
+func Encode(w io.Writer, format string, res any) error {
+	if format == "ndjson" {
+		return encodeNDJSON(w, res)
+	}
+	t, err := tabulate(res)
+	if err != nil {
+		return err
+	}
+	switch format {
+	case "csv":
+		return t.encodeCSV(w)
+	case "markdown":
+		return t.encodeMarkdown(w)
+	case "arrow":
+		return t.encodeArrow(w, false)
+	case "parquet":
+		return t.encodeArrow(w, true)
+	}
+	return fmt.Errorf("unsupported output format %q", format)
+}
+
+// encodeNDJSON writes each row of a list result, or a single result, as a
+// line of JSON.
+func encodeNDJSON(w io.Writer, res any) error {
+	b, err := json.Marshal(res)
+	if err != nil {
+		return fmt.Errorf("unable to marshal result: %w", err)
+	}
+	var items []json.RawMessage
+	if err := json.Unmarshal(b, &items); err != nil {
+		items = []json.RawMessage{b}
+	}
+	for _, item := range items {
+		if _, err := fmt.Fprintf(w, "%s\n", item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *table) encodeCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(t.columns); err != nil {
+		return err
+	}
+	record := make([]string, len(t.columns))
+	for _, row := range t.rows {
+		for i, c := range t.columns {
+			record[i], _ = text(row[c])
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// columnType infers the Arrow type of a column from its values: boolean,
+// int64 or float64 when every value is one, and string otherwise.
+func (t *table) columnType(column string) arrow.DataType {
+	allBool, allInt, allNumber := true, true, true
+	for _, row := range t.rows {
+		v, ok := row[column]
+		if !ok || string(v) == "null" {
+			continue
+		}
+		var x any
+		dec := json.NewDecoder(bytes.NewReader(v))
+		dec.UseNumber()
+		if err := dec.Decode(&x); err != nil {
+			return arrow.BinaryTypes.String
+		}
+		switch x := x.(type) {
+		case bool:
+			allInt, allNumber = false, false
+		case json.Number:
+			allBool = false
+			if _, err := x.Int64(); err != nil {
+				allInt = false
+			}
+		default:
+			return arrow.BinaryTypes.String
+		}
+	}
+	switch {
+	case allBool && !allNumber:
+		return arrow.FixedWidthTypes.Boolean
+	case allInt && allNumber && !allBool:
+		return arrow.PrimitiveTypes.Int64
+	case allNumber && !allBool:
+		return arrow.PrimitiveTypes.Float64
+	}
+	return arrow.BinaryTypes.String
+}
+
+// encodeArrow writes the table as an Arrow IPC stream, or as a Parquet file.
+func (t *table) encodeArrow(w io.Writer, asParquet bool) error {
+	fields := make([]arrow.Field, len(t.columns))
+	for i, c := range t.columns {
+		fields[i] = arrow.Field{Name: c, Type: t.columnType(c), Nullable: true}
+	}
+	schema := arrow.NewSchema(fields, nil)
+
+	builder := array.NewRecordBuilder(memory.DefaultAllocator, schema)
+	defer builder.Release()
+	for _, row := range t.rows {
+		for i, c := range t.columns {
+			if err := appendValue(builder.Field(i), row[c]); err != nil {
+				return fmt.Errorf("unable to convert column %q: %w", c, err)
+			}
+		}
+	}
+	record := builder.NewRecord()
+	defer record.Release()
+
+	if asParquet {
+		fw, err := pqarrow.NewFileWriter(schema, w, parquet.NewWriterProperties(), pqarrow.DefaultWriterProps())
+		if err != nil {
+			return err
+		}
+		if err := fw.Write(record); err != nil {
+			return err
+		}
+		return fw.Close()
+	}
+	iw := ipc.NewWriter(w, ipc.WithSchema(schema))
+	if err := iw.Write(record); err != nil {
+		return err
+	}
+	return iw.Close()
+}
+
+func appendValue(b array.Builder, v json.RawMessage) error {
+	s, ok := text(v)
+	if !ok {
+		b.AppendNull()
+		return nil
+	}
+	switch b := b.(type) {
+	case *array.BooleanBuilder:
+		var x bool
+		if err := json.Unmarshal(v, &x); err != nil {
+			return err
+		}
+		b.Append(x)
+	case *array.Int64Builder:
+		var x int64
+		if err := json.Unmarshal(v, &x); err != nil {
+			return err
+		}
+		b.Append(x)
+	case *array.Float64Builder:
+		var x float64
+		if err := json.Unmarshal(v, &x); err != nil {
+			return err
+		}
+		b.Append(x)
+	case *array.StringBuilder:
+		b.Append(s)
+	}
+	return nil
+}
+
+// encodeMarkdown writes the table as a Markdown table, for inclusion in
+// prompts. Pipes are escaped and line breaks are replaced by spaces, so that
+// every row stays on a line.
+func (t *table) encodeMarkdown(w io.Writer) error {
+	cell := strings.NewReplacer("|", "\\|", "\r\n", " ", "\n", " ", "\r", " ")
+	var b strings.Builder
+	writeRow := func(values []string) {
+		b.WriteString("|")
+		for _, v := range values {
+			b.WriteString(" ")
+			b.WriteString(cell.Replace(v))
+			b.WriteString(" |")
+		}
+		b.WriteString("\n")
+	}
+	writeRow(t.columns)
+	b.WriteString("|")
+	b.WriteString(strings.Repeat(" --- |", len(t.columns)))
+	b.WriteString("\n")
+	record := make([]string, len(t.columns))
+	for _, row := range t.rows {
+		for i, c := range t.columns {
+			record[i], _ = text(row[c])
+		}
+		writeRow(record)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,83 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resultformat_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/googleapis/mcp-toolbox/internal/server/resultformat"
+)
+
+func TestEncode(t *testing.T) {
+	res := []any{
+		map[string]any{"id": 1, "name": "Alice", "tags": []string{"a"}},
+		map[string]any{"id": 2, "name": nil, "active": true},
+	}
+
+	tcs := []struct {
+		format string
+		want   string
+	}{
+		{format: "csv", want: "id,name,tags,active\n1,Alice,\"[\"\"a\"\"]\",\n2,,,true\n"},
+		{format: "markdown", want: "| id | name | tags | active |\n| --- | --- | --- | --- |\n| 1 | Alice | [\"a\"] |  |\n| 2 |  |  | true |\n"},
+		{format: "ndjson", want: "{\"id\":1,\"name\":\"Alice\",\"tags\":[\"a\"]}\n{\"active\":true,\"id\":2,\"name\":null}\n"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.format, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := resultformat.Encode(&buf, tc.format, res); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got := buf.String(); got != tc.want {
+				t.Errorf("unexpected output: got %q, want %q", got, tc.want)
+			}
+		})
+	}
+
+	t.Run("arrow", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := resultformat.Encode(&buf, "arrow", res); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		reader, err := ipc.NewReader(&buf, ipc.WithAllocator(memory.DefaultAllocator))
+		if err != nil {
+			t.Fatalf("unable to read arrow stream: %s", err)
+		}
+		defer reader.Release()
+		if got := reader.Schema().String(); got != "schema:\n  fields: 4\n    - id: type=int64, nullable\n    - name: type=utf8, nullable\n    - tags: type=utf8, nullable\n    - active: type=bool, nullable" {
+			t.Errorf("unexpected schema: %s", got)
+		}
+		rows := 0
+		for reader.Next() {
+			rows += int(reader.Record().NumRows())
+		}
+		if rows != 2 {
+			t.Errorf("unexpected number of rows: %d", rows)
+		}
+	})
+}
+
+func TestEncodeMarkdownEscapes(t *testing.T) {
+	var buf bytes.Buffer
+	if err := resultformat.Encode(&buf, "markdown", []any{map[string]any{"note": "a|b\nc"}}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got, want := buf.String(), "| note |\n| --- |\n| a\\|b c |\n"; got != want {
+		t.Errorf("unexpected output: got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseScheduleNext(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone database unavailable: %s", err)
+	}
+	from := time.Date(2026, time.March, 14, 10, 17, 30, 0, time.UTC) // a Saturday
+	tcs := []struct {
+		desc string
+		expr string
+		loc  *time.Location
+		want time.Time
+	}{
+		{
+			desc: "every minute",
+			expr: "* * * * *",
+			want: time.Date(2026, time.March, 14, 10, 18, 0, 0, time.UTC),
+		},
+		{
+			desc: "step of minutes",
+			expr: "*/15 * * * *",
+			want: time.Date(2026, time.March, 14, 10, 30, 0, 0, time.UTC),
+		},
+		{
+			desc: "list and range of hours",
+			expr: "0 8-9,12 * * *",
+			want: time.Date(2026, time.March, 14, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			desc: "weekdays only",
+			expr: "30 9 * * mon-fri",
+			want: time.Date(2026, time.March, 16, 9, 30, 0, 0, time.UTC),
+		},
+		{
+			desc: "day of month or day of week",
+			expr: "0 0 20 * sun",
+			want: time.Date(2026, time.March, 15, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			desc: "month name",
+			expr: "0 0 1 jun *",
+			want: time.Date(2026, time.June, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			desc: "descriptor",
+			expr: "@daily",
+			want: time.Date(2026, time.March, 15, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			desc: "every duration",
+			expr: "@every 90s",
+			want: from.Add(90 * time.Second),
+		},
+		{
+			desc: "time zone",
+			expr: "0 6 * * *",
+			loc:  newYork,
+			want: time.Date(2026, time.March, 15, 6, 0, 0, 0, newYork),
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			loc := tc.loc
+			if loc == nil {
+				loc = time.UTC
+			}
+			s, err := ParseSchedule(tc.expr, loc)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got := s.Next(from); !got.Equal(tc.want) {
+				t.Fatalf("incorrect next time: got %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseScheduleErrors(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"* * * foo *",
+		"@sometimes",
+		"@every 10ms",
+		"@every soon",
+	} {
+		if _, err := ParseSchedule(expr, time.UTC); err == nil {
+			t.Errorf("expected an error for %q", expr)
+		}
+	}
+}
@@ -0,0 +1,205 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package scheduler runs the jobs declared in the configuration, which
+// invoke a tool with fixed parameters on a cron schedule and write its
+// results to a sink.
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/googleapis/mcp-toolbox/internal/log"
+)
+
+// Config is a job invoking a tool on a schedule.
+type Config struct {
+	Name string `yaml:"name" validate:"required"`
+	// Schedule is a cron expression of five fields, a descriptor such as
+	// @daily, or "@every <duration>".
+	Schedule string `yaml:"schedule" validate:"required"`
+	// Timezone is the IANA time zone of the cron expression. Defaults to
+	// UTC.
+	Timezone string `yaml:"timezone,omitempty"`
+	// Tool is the name of the tool invoked.
+	Tool string `yaml:"tool" validate:"required"`
+	// Parameters are the parameter values the tool is invoked with.
+	Parameters map[string]any `yaml:"parameters,omitempty"`
+	// Timeout bounds how long a run may take, as a duration such as "10m".
+	Timeout string `yaml:"timeout,omitempty"`
+	// Sink is where the results of the runs are written. Defaults to the
+	// log of the server.
+	Sink *SinkConfig `yaml:"sink,omitempty"`
+}
+
+// Invoker invokes the tool named tool with the values of params, decoded
+// from the configuration, as a scheduled job would.
+type Invoker func(ctx context.Context, tool string, params map[string]any) (any, error)
+
+// Run is the outcome of a run of a job, as written to its sink.
+type Run struct {
+	Job             string    `json:"job"`
+	Tool            string    `json:"tool"`
+	StartTime       time.Time `json:"startTime"`
+	DurationSeconds float64   `json:"durationSeconds"`
+	Result          any       `json:"result,omitempty"`
+	Error           string    `json:"error,omitempty"`
+}
+
+// job is a compiled Config.
+type job struct {
+	cfg      Config
+	schedule Schedule
+	timeout  time.Duration
+	sink     sink
+	// running is held while the job runs, so that runs do not overlap.
+	running sync.Mutex
+}
+
+// Scheduler runs jobs on their schedules.
+type Scheduler struct {
+	jobs   []*job
+	invoke Invoker
+	logger log.Logger
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// New validates the jobs and returns their scheduler. toolExists reports
+// whether a tool is configured.
+func New(jobs map[string]Config, invoke Invoker, toolExists func(string) bool, logger log.Logger) (*Scheduler, error) {
+	s := &Scheduler{invoke: invoke, logger: logger}
+	names := make([]string, 0, len(jobs))
+	for name := range jobs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		cfg := jobs[name]
+		j, err := s.compile(cfg, toolExists)
+		if err != nil {
+			return nil, fmt.Errorf("invalid job %q: %w", name, err)
+		}
+		s.jobs = append(s.jobs, j)
+	}
+	return s, nil
+}
+
+func (s *Scheduler) compile(cfg Config, toolExists func(string) bool) (*job, error) {
+	loc := time.UTC
+	if cfg.Timezone != "" {
+		var err error
+		if loc, err = time.LoadLocation(cfg.Timezone); err != nil {
+			return nil, fmt.Errorf("invalid timezone %q: %w", cfg.Timezone, err)
+		}
+	}
+	schedule, err := ParseSchedule(cfg.Schedule, loc)
+	if err != nil {
+		return nil, err
+	}
+	if !toolExists(cfg.Tool) {
+		return nil, fmt.Errorf("tool %q does not exist", cfg.Tool)
+	}
+	j := &job{cfg: cfg, schedule: schedule}
+	if cfg.Timeout != "" {
+		if j.timeout, err = time.ParseDuration(cfg.Timeout); err != nil || j.timeout <= 0 {
+			return nil, fmt.Errorf("invalid timeout %q: must be a positive duration such as \"10m\"", cfg.Timeout)
+		}
+	}
+	if j.sink, err = newSink(cfg.Sink, s.invoke, toolExists); err != nil {
+		return nil, err
+	}
+	return j, nil
+}
+
+// Len returns the number of jobs.
+func (s *Scheduler) Len() int {
+	return len(s.jobs)
+}
+
+// Start runs the jobs on their schedules until Stop is called.
+func (s *Scheduler) Start(ctx context.Context) {
+	ctx, s.cancel = context.WithCancel(context.WithoutCancel(ctx))
+	for _, j := range s.jobs {
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.loop(ctx, j)
+		}()
+	}
+}
+
+// Stop stops scheduling runs, cancels the running ones and waits for them
+// to return.
+func (s *Scheduler) Stop() {
+	if s.cancel == nil {
+		return
+	}
+	s.cancel()
+	s.wg.Wait()
+}
+
+// loop runs j at each time of its schedule until ctx is done.
+func (s *Scheduler) loop(ctx context.Context, j *job) {
+	next := j.schedule.Next(time.Now())
+	for !next.IsZero() {
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		if j.running.TryLock() {
+			s.wg.Add(1)
+			go func() {
+				defer s.wg.Done()
+				defer j.running.Unlock()
+				s.run(ctx, j)
+			}()
+		} else {
+			s.logger.WarnContext(ctx, fmt.Sprintf("skipping run of job %q: the previous run is still running", j.cfg.Name))
+		}
+		next = j.schedule.Next(next)
+	}
+	s.logger.WarnContext(ctx, fmt.Sprintf("job %q is never scheduled again", j.cfg.Name))
+}
+
+// run runs j once and writes its outcome to its sink.
+func (s *Scheduler) run(ctx context.Context, j *job) Run {
+	if j.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, j.timeout)
+		defer cancel()
+	}
+	run := Run{Job: j.cfg.Name, Tool: j.cfg.Tool, StartTime: time.Now().UTC()}
+	s.logger.DebugContext(ctx, fmt.Sprintf("running job %q", j.cfg.Name))
+	res, err := s.invoke(ctx, j.cfg.Tool, j.cfg.Parameters)
+	run.DurationSeconds = time.Since(run.StartTime).Seconds()
+	if err != nil {
+		run.Error = err.Error()
+		s.logger.ErrorContext(ctx, fmt.Sprintf("job %q failed: %s", j.cfg.Name, err))
+	} else {
+		run.Result = res
+	}
+	if err := j.sink.write(ctx, s.logger, run); err != nil {
+		s.logger.ErrorContext(ctx, fmt.Sprintf("unable to write the result of job %q to its sink: %s", j.cfg.Name, err))
+	}
+	return run
+}
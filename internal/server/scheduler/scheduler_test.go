@@ -0,0 +1,210 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/googleapis/mcp-toolbox/internal/log"
+)
+
+type invocation struct {
+	tool   string
+	params map[string]any
+}
+
+// fakeTools returns an Invoker recording its invocations, which fails for
+// the tool "broken".
+func fakeTools(calls *[]invocation) Invoker {
+	return func(_ context.Context, tool string, params map[string]any) (any, error) {
+		*calls = append(*calls, invocation{tool: tool, params: params})
+		if tool == "broken" {
+			return nil, errors.New("boom")
+		}
+		return []any{map[string]any{"n": 1}}, nil
+	}
+}
+
+func toolExists(name string) bool {
+	return name != "missing"
+}
+
+func newTestScheduler(t *testing.T, cfg Config, invoke Invoker) *Scheduler {
+	t.Helper()
+	logger, err := log.NewStdLogger(io.Discard, io.Discard, "info")
+	if err != nil {
+		t.Fatalf("unable to create logger: %s", err)
+	}
+	s, err := New(map[string]Config{cfg.Name: cfg}, invoke, toolExists, logger)
+	if err != nil {
+		t.Fatalf("unable to create scheduler: %s", err)
+	}
+	return s
+}
+
+func TestRunFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "runs.jsonl")
+	var calls []invocation
+	s := newTestScheduler(t, Config{
+		Name:       "nightly",
+		Schedule:   "@daily",
+		Tool:       "count",
+		Parameters: map[string]any{"status": "open"},
+		Sink:       &SinkConfig{Type: SinkFile, Path: path},
+	}, fakeTools(&calls))
+
+	for range 2 {
+		if run := s.run(context.Background(), s.jobs[0]); run.Error != "" {
+			t.Fatalf("unexpected error: %s", run.Error)
+		}
+	}
+	if len(calls) != 2 || calls[0].tool != "count" || calls[0].params["status"] != "open" {
+		t.Fatalf("unexpected invocations: %v", calls)
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unable to read sink file: %s", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 runs in the sink file, got %d", len(lines))
+	}
+	var run Run
+	if err := json.Unmarshal([]byte(lines[0]), &run); err != nil {
+		t.Fatalf("unable to decode run: %s", err)
+	}
+	if run.Job != "nightly" || run.Tool != "count" || run.Result == nil {
+		t.Fatalf("unexpected run: %+v", run)
+	}
+}
+
+func TestRunToolSink(t *testing.T) {
+	var calls []invocation
+	s := newTestScheduler(t, Config{
+		Name:     "report",
+		Schedule: "@hourly",
+		Tool:     "count",
+		Sink: &SinkConfig{
+			Type:       SinkTool,
+			Tool:       "insert-report",
+			Parameter:  "body",
+			Parameters: map[string]any{"channel": "ops"},
+		},
+	}, fakeTools(&calls))
+
+	s.run(context.Background(), s.jobs[0])
+	if len(calls) != 2 {
+		t.Fatalf("expected the job and sink tools to be invoked, got %v", calls)
+	}
+	sinkCall := calls[1]
+	if sinkCall.tool != "insert-report" || sinkCall.params["channel"] != "ops" || sinkCall.params["body"] != `[{"n":1}]` {
+		t.Fatalf("unexpected sink invocation: %v", sinkCall)
+	}
+}
+
+func TestRunFailureWebhookSink(t *testing.T) {
+	var got Run
+	var auth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	var calls []invocation
+	s := newTestScheduler(t, Config{
+		Name:     "cleanup",
+		Schedule: "0 3 * * *",
+		Tool:     "broken",
+		Sink: &SinkConfig{
+			Type:    SinkWebhook,
+			URL:     srv.URL,
+			Headers: map[string]string{"Authorization": "Bearer secret"},
+		},
+	}, fakeTools(&calls))
+
+	run := s.run(context.Background(), s.jobs[0])
+	if run.Error != "boom" {
+		t.Fatalf("expected the error of the tool, got %q", run.Error)
+	}
+	if got.Job != "cleanup" || got.Error != "boom" || got.Result != nil {
+		t.Fatalf("unexpected run posted to the webhook: %+v", got)
+	}
+	if auth != "Bearer secret" {
+		t.Fatalf("expected the configured headers, got %q", auth)
+	}
+}
+
+func TestNewErrors(t *testing.T) {
+	logger, err := log.NewStdLogger(io.Discard, io.Discard, "info")
+	if err != nil {
+		t.Fatalf("unable to create logger: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		cfg  Config
+		want string
+	}{
+		{
+			desc: "unknown tool",
+			cfg:  Config{Name: "j", Schedule: "@daily", Tool: "missing"},
+			want: `tool "missing" does not exist`,
+		},
+		{
+			desc: "invalid schedule",
+			cfg:  Config{Name: "j", Schedule: "daily", Tool: "count"},
+			want: "daily",
+		},
+		{
+			desc: "invalid timezone",
+			cfg:  Config{Name: "j", Schedule: "@daily", Timezone: "Mars/Olympus", Tool: "count"},
+			want: "invalid timezone",
+		},
+		{
+			desc: "invalid timeout",
+			cfg:  Config{Name: "j", Schedule: "@daily", Tool: "count", Timeout: "-1s"},
+			want: "invalid timeout",
+		},
+		{
+			desc: "unknown sink tool",
+			cfg:  Config{Name: "j", Schedule: "@daily", Tool: "count", Sink: &SinkConfig{Type: SinkTool, Tool: "missing", Parameter: "body"}},
+			want: `tool "missing" of the sink does not exist`,
+		},
+		{
+			desc: "webhook without url",
+			cfg:  Config{Name: "j", Schedule: "@daily", Tool: "count", Sink: &SinkConfig{Type: SinkWebhook}},
+			want: "requires a url",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			var calls []invocation
+			_, err := New(map[string]Config{tc.cfg.Name: tc.cfg}, fakeTools(&calls), toolExists, logger)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("expected an error containing %q, got %v", tc.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/googleapis/mcp-toolbox/internal/log"
+)
+
+const (
+	// SinkLog logs the outcome of the runs.
+	SinkLog = "log"
+	// SinkWebhook posts the outcome of each run as JSON to a URL.
+	SinkWebhook = "webhook"
+	// SinkFile appends the outcome of each run to a file, one JSON object
+	// per line.
+	SinkFile = "file"
+	// SinkTool invokes another tool with the result of each successful run.
+	SinkTool = "tool"
+)
+
+// SinkConfig is where the outcome of the runs of a job is written.
+type SinkConfig struct {
+	Type string `yaml:"type" validate:"required,oneof=log webhook file tool"`
+	// URL and Headers are the endpoint and the additional headers of the
+	// webhook sink.
+	URL     string            `yaml:"url,omitempty"`
+	Headers map[string]string `yaml:"headers,omitempty"`
+	// Path is the file of the file sink.
+	Path string `yaml:"path,omitempty"`
+	// Tool is the tool of the tool sink, invoked with the JSON encoding of
+	// the result as the value of Parameter, along with Parameters.
+	Tool       string         `yaml:"tool,omitempty"`
+	Parameter  string         `yaml:"parameter,omitempty"`
+	Parameters map[string]any `yaml:"parameters,omitempty"`
+}
+
+// webhookTimeout bounds the requests of the webhook sink.
+const webhookTimeout = 30 * time.Second
+
+// sink writes the outcome of the runs of a job.
+type sink interface {
+	write(ctx context.Context, logger log.Logger, run Run) error
+}
+
+func newSink(cfg *SinkConfig, invoke Invoker, toolExists func(string) bool) (sink, error) {
+	if cfg == nil {
+		return logSink{}, nil
+	}
+	switch cfg.Type {
+	case SinkLog:
+		return logSink{}, nil
+	case SinkWebhook:
+		if cfg.URL == "" {
+			return nil, fmt.Errorf("sink %q requires a url", cfg.Type)
+		}
+		return webhookSink{url: cfg.URL, headers: cfg.Headers, client: &http.Client{Timeout: webhookTimeout}}, nil
+	case SinkFile:
+		if cfg.Path == "" {
+			return nil, fmt.Errorf("sink %q requires a path", cfg.Type)
+		}
+		return &fileSink{path: cfg.Path}, nil
+	case SinkTool:
+		if cfg.Tool == "" || cfg.Parameter == "" {
+			return nil, fmt.Errorf("sink %q requires a tool and a parameter", cfg.Type)
+		}
+		if !toolExists(cfg.Tool) {
+			return nil, fmt.Errorf("tool %q of the sink does not exist", cfg.Tool)
+		}
+		return toolSink{tool: cfg.Tool, parameter: cfg.Parameter, params: cfg.Parameters, invoke: invoke}, nil
+	default:
+		return nil, fmt.Errorf("invalid sink type %q", cfg.Type)
+	}
+}
+
+// logSink logs the outcome of the runs, without their results.
+type logSink struct{}
+
+func (logSink) write(ctx context.Context, logger log.Logger, run Run) error {
+	if run.Error == "" {
+		logger.InfoContext(ctx, fmt.Sprintf("job %q completed in %.3fs", run.Job, run.DurationSeconds))
+	}
+	return nil
+}
+
+type webhookSink struct {
+	url     string
+	headers map[string]string
+	client  *http.Client
+}
+
+func (s webhookSink) write(ctx context.Context, _ log.Logger, run Run) error {
+	body, err := json.Marshal(run)
+	if err != nil {
+		return fmt.Errorf("unable to marshal run: %w", err)
+	}
+	// the run may have been canceled by its timeout, which shouldn't
+	// prevent reporting it
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), webhookTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range s.headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %s", resp.Status)
+	}
+	return nil
+}
+
+type fileSink struct {
+	path string
+	mu   sync.Mutex
+}
+
+func (s *fileSink) write(_ context.Context, _ log.Logger, run Run) error {
+	line, err := json.Marshal(run)
+	if err != nil {
+		return fmt.Errorf("unable to marshal run: %w", err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+type toolSink struct {
+	tool      string
+	parameter string
+	params    map[string]any
+	invoke    Invoker
+}
+
+func (s toolSink) write(ctx context.Context, _ log.Logger, run Run) error {
+	if run.Error != "" {
+		// failed runs have no result to pass on
+		return nil
+	}
+	result, err := json.Marshal(run.Result)
+	if err != nil {
+		return fmt.Errorf("unable to marshal result: %w", err)
+	}
+	params := maps.Clone(s.params)
+	if params == nil {
+		params = make(map[string]any, 1)
+	}
+	params[s.parameter] = string(result)
+	if _, err := s.invoke(ctx, s.tool, params); err != nil {
+		return fmt.Errorf("tool %q failed: %w", s.tool, err)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/googleapis/mcp-toolbox/internal/resources"
+	"github.com/googleapis/mcp-toolbox/internal/sources"
+	"github.com/googleapis/mcp-toolbox/internal/sources/schemadoc"
+)
+
+// withSchemaLoader makes the resource describe the schema of its source
+// whenever it is read.
+func withSchemaLoader(r resources.Resource, sourcesMap map[string]sources.Source) (resources.Resource, error) {
+	s, ok := sourcesMap[r.Source]
+	if !ok {
+		return resources.Resource{}, fmt.Errorf("source %q not found", r.Source)
+	}
+	describer, ok := s.(schemadoc.Describer)
+	if !ok {
+		return resources.Resource{}, fmt.Errorf("source %q of type %q cannot describe its schema", r.Source, s.SourceType())
+	}
+	return r.WithLoader(func(ctx context.Context) (string, error) {
+		schema, err := describer.DescribeSchema(ctx)
+		if err != nil {
+			return "", err
+		}
+		b, err := json.MarshalIndent(schema, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("unable to encode schema: %w", err)
+		}
+		return string(b), nil
+	}), nil
+}
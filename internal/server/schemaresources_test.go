@@ -0,0 +1,75 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/googleapis/mcp-toolbox/internal/resources"
+	"github.com/googleapis/mcp-toolbox/internal/sources"
+	"github.com/googleapis/mcp-toolbox/internal/sources/schemadoc"
+)
+
+// fakeSchemaSource is a source describing a fixed schema.
+type fakeSchemaSource struct {
+	fakeSource
+	schema *schemadoc.Schema
+}
+
+func (s *fakeSchemaSource) DescribeSchema(context.Context) (*schemadoc.Schema, error) {
+	return s.schema, nil
+}
+
+func TestWithSchemaLoader(t *testing.T) {
+	schema := &schemadoc.Schema{Tables: []schemadoc.Table{{
+		Name:    "public.orders",
+		Comment: "Customer orders",
+		Columns: []schemadoc.Column{{Name: "id", Type: "bigint"}},
+	}}}
+	sourcesMap := map[string]sources.Source{
+		"my-pg":   &fakeSchemaSource{schema: schema},
+		"my-fake": &fakeSource{},
+	}
+	newResource := func(source string) resources.Resource {
+		r, err := resources.ResourceConfig{Name: "schema", URI: "schema://" + source, Source: source}.Initialize()
+		if err != nil {
+			t.Fatalf("unable to initialize resource: %s", err)
+		}
+		return r
+	}
+
+	r, err := withSchemaLoader(newResource("my-pg"), sourcesMap)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	got, err := r.Read(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for _, want := range []string{`"name": "public.orders"`, `"comment": "Customer orders"`, `"type": "bigint"`} {
+		if !strings.Contains(got, want) {
+			t.Errorf("got content %s, want it to contain %s", got, want)
+		}
+	}
+
+	if _, err := withSchemaLoader(newResource("my-fake"), sourcesMap); err == nil || !strings.Contains(err.Error(), "cannot describe its schema") {
+		t.Errorf("got error %v, want error containing %q", err, "cannot describe its schema")
+	}
+	if _, err := withSchemaLoader(newResource("missing"), sourcesMap); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("got error %v, want error containing %q", err, "not found")
+	}
+}
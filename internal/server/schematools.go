@@ -0,0 +1,404 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/googleapis/mcp-toolbox/internal/sources"
+	"github.com/googleapis/mcp-toolbox/internal/sources/schemadoc"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+)
+
+// schemaToolsKind is the kind of the documents generating tools from the
+// tables of a source when the server starts.
+const schemaToolsKind = "schemaTools"
+
+// defaultSchemaToolsMaxLimit is the largest page of the list tools when the
+// config does not set maxLimit.
+const defaultSchemaToolsMaxLimit = 100
+
+// SchemaToolsConfig selects the tables of a source to generate tools for.
+// Each table gets a get_<table>_by_pk tool when it has a primary key, a
+// list_<table> tool, and an insert_<table> tool when Insert is set.
+type SchemaToolsConfig struct {
+	Name   string `yaml:"name" validate:"required"`
+	Source string `yaml:"source" validate:"required"`
+	// Tables lists the tables to generate tools for, either by their full
+	// name in the schema of the source or by their name without a schema.
+	Tables []string `yaml:"tables" validate:"required,min=1"`
+	// Insert also generates insert tools.
+	Insert bool `yaml:"insert"`
+	// MaxLimit caps the rows a list tool returns at once. Defaults to 100.
+	MaxLimit int `yaml:"maxLimit" validate:"gte=0"`
+}
+
+type SchemaToolsConfigs map[string]SchemaToolsConfig
+
+// UnmarshalSchemaToolsConfigs returns the schema tools defined in raw. The
+// other documents are left to UnmarshalPrimitiveConfig.
+func UnmarshalSchemaToolsConfigs(ctx context.Context, raw []byte) (SchemaToolsConfigs, error) {
+	var configs SchemaToolsConfigs
+	err := forEachDocOfKind(ctx, raw, schemaToolsKind, func(name string, resource map[string]any) error {
+		c := SchemaToolsConfig{Name: name}
+		dec, err := util.NewStrictDecoderAt(resource, fmt.Sprintf("schemaTools[%s]", name))
+		if err != nil {
+			return fmt.Errorf("error creating decoder: %w", err)
+		}
+		if err := dec.DecodeContext(ctx, &c); err != nil {
+			return err
+		}
+		if _, ok := configs[name]; ok {
+			return fmt.Errorf("schemaTools %q is defined more than once", name)
+		}
+		if configs == nil {
+			configs = make(SchemaToolsConfigs)
+		}
+		configs[name] = c
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return configs, nil
+}
+
+// sqlDialect is how the tools of a source type write their statements.
+type sqlDialect struct {
+	// toolType is the type of the generated tools.
+	toolType string
+	// quote quotes an identifier.
+	quote func(string) string
+	// placeholder returns the placeholder of the n-th parameter, counting
+	// from 1, named name.
+	placeholder func(n int, name string) string
+	// page returns the clauses ordering the rows by orderBy, which may be
+	// empty, and returning limit rows after the first offset ones.
+	page func(orderBy, limit, offset string) string
+	// insert returns the statement inserting values into the columns of
+	// table, returning the inserted row when the database can.
+	insert func(table string, columns, values []string) string
+}
+
+func quoteWith(open, close string) func(string) string {
+	return func(id string) string {
+		return open + strings.ReplaceAll(id, close, close+close) + close
+	}
+}
+
+func ordinalPlaceholder(prefix string) func(int, string) string {
+	return func(n int, _ string) string { return fmt.Sprintf("%s%d", prefix, n) }
+}
+
+func limitOffsetPage(orderBy, limit, offset string) string {
+	page := fmt.Sprintf("LIMIT %s OFFSET %s", limit, offset)
+	if orderBy == "" {
+		return page
+	}
+	return fmt.Sprintf("ORDER BY %s %s", orderBy, page)
+}
+
+// insertReturning returns an insert function appending suffix, such as a
+// RETURNING clause, to the statement.
+func insertReturning(suffix string) func(string, []string, []string) string {
+	return func(table string, columns, values []string) string {
+		stmt := fmt.Sprintf("INSERT INTO %s DEFAULT VALUES", table)
+		if len(columns) > 0 {
+			stmt = fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", table, strings.Join(columns, ", "), strings.Join(values, ", "))
+		}
+		if suffix != "" {
+			stmt += " " + suffix
+		}
+		return stmt
+	}
+}
+
+var (
+	postgresDialect = sqlDialect{
+		toolType:    "postgres-sql",
+		quote:       quoteWith(`"`, `"`),
+		placeholder: ordinalPlaceholder("$"),
+		page:        limitOffsetPage,
+		insert:      insertReturning("RETURNING *"),
+	}
+	mysqlDialect = sqlDialect{
+		toolType:    "mysql-sql",
+		quote:       quoteWith("`", "`"),
+		placeholder: func(int, string) string { return "?" },
+		page:        limitOffsetPage,
+		insert: func(table string, columns, values []string) string {
+			return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", table, strings.Join(columns, ", "), strings.Join(values, ", "))
+		},
+	}
+	// The parameters of SQL Server statements are named, since a parameter
+	// named p would otherwise match the @p1 placeholder.
+	sqlServerDialect = sqlDialect{
+		toolType:    "mssql-sql",
+		quote:       quoteWith("[", "]"),
+		placeholder: func(_ int, name string) string { return "@" + name },
+		page: func(orderBy, limit, offset string) string {
+			if orderBy == "" {
+				orderBy = "(SELECT NULL)"
+			}
+			return fmt.Sprintf("ORDER BY %s OFFSET %s ROWS FETCH NEXT %s ROWS ONLY", orderBy, offset, limit)
+		},
+		insert: func(table string, columns, values []string) string {
+			if len(columns) == 0 {
+				return fmt.Sprintf("INSERT INTO %s OUTPUT INSERTED.* DEFAULT VALUES", table)
+			}
+			return fmt.Sprintf("INSERT INTO %s (%s) OUTPUT INSERTED.* VALUES (%s)", table, strings.Join(columns, ", "), strings.Join(values, ", "))
+		},
+	}
+	bigqueryDialect = sqlDialect{
+		toolType:    "bigquery-sql",
+		quote:       quoteWith("`", "`"),
+		placeholder: func(_ int, name string) string { return "@" + name },
+		page:        limitOffsetPage,
+		insert: func(table string, columns, values []string) string {
+			return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", table, strings.Join(columns, ", "), strings.Join(values, ", "))
+		},
+	}
+	sqliteDialect = sqlDialect{
+		toolType:    "sqlite-sql",
+		quote:       quoteWith(`"`, `"`),
+		placeholder: func(int, string) string { return "?" },
+		page:        limitOffsetPage,
+		insert:      insertReturning("RETURNING *"),
+	}
+)
+
+// sqlDialects are the dialects of the source types that tools can be
+// generated for.
+var sqlDialects = map[string]sqlDialect{
+	"postgres":           postgresDialect,
+	"alloydb-postgres":   postgresDialect,
+	"cloud-sql-postgres": postgresDialect,
+	"mysql":              mysqlDialect,
+	"cloud-sql-mysql":    mysqlDialect,
+	"mssql":              sqlServerDialect,
+	"cloud-sql-mssql":    sqlServerDialect,
+	"sqlite":             sqliteDialect,
+	"bigquery":           bigqueryDialect,
+}
+
+// toolNamePart replaces the characters that tool names cannot contain.
+var toolNamePart = regexp.MustCompile(`[^A-Za-z0-9_]+`)
+
+// generateSchemaTools describes the schema of the source of each config and
+// returns the raw configs of the tools generated for their tables, in the
+// same form as hand-written tools.
+func generateSchemaTools(ctx context.Context, configs SchemaToolsConfigs, sourcesMap map[string]sources.Source) ([]generatedTool, error) {
+	var generated []generatedTool
+	for _, name := range slices.Sorted(maps.Keys(configs)) {
+		cfg := configs[name]
+		s, ok := sourcesMap[cfg.Source]
+		if !ok {
+			return nil, fmt.Errorf("schemaTools %q: source %q not found", name, cfg.Source)
+		}
+		dialect, ok := sqlDialects[s.SourceType()]
+		if !ok {
+			return nil, fmt.Errorf("schemaTools %q: tools cannot be generated for sources of type %q", name, s.SourceType())
+		}
+		describer, ok := s.(schemadoc.Describer)
+		if !ok {
+			return nil, fmt.Errorf("schemaTools %q: source %q cannot describe its schema", name, cfg.Source)
+		}
+		schema, err := describer.DescribeSchema(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("schemaTools %q: %w", name, err)
+		}
+		tools, err := schemaTools(cfg, dialect, schema)
+		if err != nil {
+			return nil, fmt.Errorf("schemaTools %q: %w", name, err)
+		}
+		generated = append(generated, tools...)
+	}
+	return generated, nil
+}
+
+// schemaTools returns the raw configs of the tools of the tables of cfg.
+func schemaTools(cfg SchemaToolsConfig, dialect sqlDialect, schema *schemadoc.Schema) ([]generatedTool, error) {
+	maxLimit := cfg.MaxLimit
+	if maxLimit == 0 {
+		maxLimit = defaultSchemaToolsMaxLimit
+	}
+	var generated []generatedTool
+	for _, entry := range cfg.Tables {
+		table, err := findTable(schema, entry)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range table.Columns {
+			if !templateIdentifier.MatchString(c.Name) {
+				return nil, fmt.Errorf("column %q of table %q is not a valid parameter name", c.Name, table.Name)
+			}
+		}
+		suffix := strings.Trim(toolNamePart.ReplaceAllString(entry, "_"), "_")
+		quoted := quoteTable(dialect, table.Name)
+		var pk []schemadoc.Column
+		for _, c := range table.Columns {
+			if c.PrimaryKey {
+				pk = append(pk, c)
+			}
+		}
+
+		if len(pk) > 0 {
+			conditions := make([]string, 0, len(pk))
+			params := make([]any, 0, len(pk))
+			for i, c := range pk {
+				conditions = append(conditions, fmt.Sprintf("%s = %s", dialect.quote(c.Name), dialect.placeholder(i+1, c.Name)))
+				params = append(params, columnParameter(c))
+			}
+			generated = append(generated, generatedTool{
+				name: fmt.Sprintf("get_%s_by_pk", suffix),
+				resource: map[string]any{
+					"type":        dialect.toolType,
+					"source":      cfg.Source,
+					"description": tableDescription(fmt.Sprintf("Gets the row of the %s table with the given primary key.", table.Name), table),
+					"statement":   fmt.Sprintf("SELECT * FROM %s WHERE %s", quoted, strings.Join(conditions, " AND ")),
+					"parameters":  params,
+					"annotations": map[string]any{"readOnlyHint": true},
+				},
+			})
+		}
+
+		orderBy := make([]string, 0, len(pk))
+		for _, c := range pk {
+			orderBy = append(orderBy, dialect.quote(c.Name))
+		}
+		generated = append(generated, generatedTool{
+			name: fmt.Sprintf("list_%s", suffix),
+			resource: map[string]any{
+				"type":        dialect.toolType,
+				"source":      cfg.Source,
+				"description": tableDescription(fmt.Sprintf("Lists the rows of the %s table, a page at a time.", table.Name), table),
+				"statement": fmt.Sprintf("SELECT * FROM %s %s", quoted,
+					dialect.page(strings.Join(orderBy, ", "), dialect.placeholder(1, "limit"), dialect.placeholder(2, "offset"))),
+				"parameters": []any{
+					map[string]any{"name": "limit", "type": "integer", "description": fmt.Sprintf("The number of rows to return, at most %d.", maxLimit), "default": maxLimit, "minValue": 1, "maxValue": maxLimit},
+					map[string]any{"name": "offset", "type": "integer", "description": "The number of rows to skip.", "default": 0, "minValue": 0},
+				},
+				"annotations": map[string]any{"readOnlyHint": true},
+			},
+		})
+
+		if !cfg.Insert {
+			continue
+		}
+		var columns, values []string
+		var params []any
+		for _, c := range table.Columns {
+			// columns assigned by the database are left to it
+			if c.HasDefault {
+				continue
+			}
+			columns = append(columns, dialect.quote(c.Name))
+			values = append(values, dialect.placeholder(len(values)+1, c.Name))
+			param := columnParameter(c)
+			if c.Nullable {
+				param["required"] = false
+			}
+			params = append(params, param)
+		}
+		if len(columns) == 0 && dialect.toolType == mysqlDialect.toolType {
+			return nil, fmt.Errorf("table %q has no columns to insert", table.Name)
+		}
+		generated = append(generated, generatedTool{
+			name: fmt.Sprintf("insert_%s", suffix),
+			resource: map[string]any{
+				"type":        dialect.toolType,
+				"source":      cfg.Source,
+				"description": tableDescription(fmt.Sprintf("Inserts a row into the %s table.", table.Name), table),
+				"statement":   dialect.insert(quoted, columns, values),
+				"parameters":  params,
+				"annotations": map[string]any{"readOnlyHint": false, "destructiveHint": false},
+			},
+		})
+	}
+	for _, g := range generated {
+		g.resource["name"] = g.name
+	}
+	return generated, nil
+}
+
+// findTable returns the table of the schema named entry, or whose name
+// without its schema is entry.
+func findTable(schema *schemadoc.Schema, entry string) (schemadoc.Table, error) {
+	var found []schemadoc.Table
+	for _, t := range schema.Tables {
+		if t.Name == entry {
+			return t, nil
+		}
+		if i := strings.LastIndex(t.Name, "."); i >= 0 && t.Name[i+1:] == entry {
+			found = append(found, t)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return schemadoc.Table{}, fmt.Errorf("table %q not found", entry)
+	case 1:
+		return found[0], nil
+	default:
+		return schemadoc.Table{}, fmt.Errorf("table %q is ambiguous, qualify it with its schema", entry)
+	}
+}
+
+// quoteTable quotes each part of a table name qualified with its schema.
+func quoteTable(dialect sqlDialect, name string) string {
+	parts := strings.Split(name, ".")
+	for i, p := range parts {
+		parts[i] = dialect.quote(p)
+	}
+	return strings.Join(parts, ".")
+}
+
+func tableDescription(description string, table schemadoc.Table) string {
+	if table.Comment == "" {
+		return description
+	}
+	return description + " " + table.Comment
+}
+
+// columnParameter returns the raw parameter of a column, typed after its
+// SQL type. Exact numeric types are strings so that they are not rounded.
+func columnParameter(c schemadoc.Column) map[string]any {
+	description := c.Comment
+	if description == "" {
+		description = fmt.Sprintf("The %s column, of type %s.", c.Name, c.Type)
+	}
+	return map[string]any{"name": c.Name, "type": columnParameterType(c.Type), "description": description}
+}
+
+func columnParameterType(sqlType string) string {
+	base := strings.ToLower(strings.TrimSpace(sqlType))
+	if i := strings.IndexAny(base, "( "); i >= 0 {
+		base = base[:i]
+	}
+	switch base {
+	case "int", "integer", "bigint", "smallint", "tinyint", "mediumint", "int2", "int4", "int8", "serial", "bigserial", "smallserial":
+		return "integer"
+	case "real", "float", "float4", "float8", "double":
+		return "float"
+	case "bool", "boolean", "bit":
+		return "boolean"
+	default:
+		return "string"
+	}
+}
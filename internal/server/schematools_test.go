@@ -0,0 +1,177 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/internal/sources"
+	"github.com/googleapis/mcp-toolbox/internal/sources/schemadoc"
+)
+
+// fakePostgresSource is a Postgres source describing a fixed schema.
+type fakePostgresSource struct{ fakeSchemaSource }
+
+func (s *fakePostgresSource) SourceType() string { return "postgres" }
+
+var crudSchema = &schemadoc.Schema{Tables: []schemadoc.Table{
+	{Name: "public.orders", Comment: "Customer orders", Columns: []schemadoc.Column{
+		{Name: "id", Type: "bigint", PrimaryKey: true, HasDefault: true},
+		{Name: "customer", Type: "character varying(64)"},
+		{Name: "total", Type: "numeric(10,2)"},
+		{Name: "note", Type: "text", Nullable: true, Comment: "Free-form note"},
+	}},
+	{Name: "audit.events", Columns: []schemadoc.Column{
+		{Name: "at", Type: "timestamp with time zone"},
+	}},
+}}
+
+func TestSchemaTools(t *testing.T) {
+	cfg := SchemaToolsConfig{Name: "crud", Source: "my-pg", Tables: []string{"orders", "audit.events"}, Insert: true, MaxLimit: 50}
+	got, err := schemaTools(cfg, postgresDialect, crudSchema)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	statements := make(map[string]string)
+	for _, g := range got {
+		if g.resource["name"] != g.name || g.resource["type"] != "postgres-sql" || g.resource["source"] != "my-pg" {
+			t.Errorf("unexpected config of tool %q: %v", g.name, g.resource)
+		}
+		statements[g.name] = g.resource["statement"].(string)
+	}
+	want := map[string]string{
+		"get_orders_by_pk":    `SELECT * FROM "public"."orders" WHERE "id" = $1`,
+		"list_orders":         `SELECT * FROM "public"."orders" ORDER BY "id" LIMIT $1 OFFSET $2`,
+		"insert_orders":       `INSERT INTO "public"."orders" ("customer", "total", "note") VALUES ($1, $2, $3) RETURNING *`,
+		"list_audit_events":   `SELECT * FROM "audit"."events" LIMIT $1 OFFSET $2`,
+		"insert_audit_events": `INSERT INTO "audit"."events" ("at") VALUES ($1) RETURNING *`,
+	}
+	if diff := cmp.Diff(want, statements); diff != "" {
+		t.Fatalf("incorrect statements: diff %v", diff)
+	}
+
+	insert := got[2].resource
+	wantParams := []any{
+		map[string]any{"name": "customer", "type": "string", "description": "The customer column, of type character varying(64)."},
+		map[string]any{"name": "total", "type": "string", "description": "The total column, of type numeric(10,2)."},
+		map[string]any{"name": "note", "type": "string", "description": "Free-form note", "required": false},
+	}
+	if diff := cmp.Diff(wantParams, insert["parameters"]); diff != "" {
+		t.Fatalf("incorrect insert parameters: diff %v", diff)
+	}
+	if desc := insert["description"].(string); !strings.HasSuffix(desc, "Customer orders") {
+		t.Errorf("got description %q, want the table comment", desc)
+	}
+	limit := got[1].resource["parameters"].([]any)[0].(map[string]any)
+	if limit["maxValue"] != 50 || limit["default"] != 50 {
+		t.Errorf("unexpected limit parameter: %v", limit)
+	}
+}
+
+func TestSchemaToolsDialects(t *testing.T) {
+	schema := &schemadoc.Schema{Tables: []schemadoc.Table{{Name: "dbo.items", Columns: []schemadoc.Column{
+		{Name: "sku", Type: "nvarchar", PrimaryKey: true},
+		{Name: "qty", Type: "int"},
+	}}}}
+	cfg := SchemaToolsConfig{Source: "my-db", Tables: []string{"items"}, Insert: true}
+	tcs := []struct {
+		dialect sqlDialect
+		want    []string
+	}{
+		{
+			dialect: sqlServerDialect,
+			want: []string{
+				"SELECT * FROM [dbo].[items] WHERE [sku] = @sku",
+				"SELECT * FROM [dbo].[items] ORDER BY [sku] OFFSET @offset ROWS FETCH NEXT @limit ROWS ONLY",
+				"INSERT INTO [dbo].[items] ([sku], [qty]) OUTPUT INSERTED.* VALUES (@sku, @qty)",
+			},
+		},
+		{
+			dialect: mysqlDialect,
+			want: []string{
+				"SELECT * FROM `dbo`.`items` WHERE `sku` = ?",
+				"SELECT * FROM `dbo`.`items` ORDER BY `sku` LIMIT ? OFFSET ?",
+				"INSERT INTO `dbo`.`items` (`sku`, `qty`) VALUES (?, ?)",
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.dialect.toolType, func(t *testing.T) {
+			got, err := schemaTools(cfg, tc.dialect, schema)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			var statements []string
+			for _, g := range got {
+				statements = append(statements, g.resource["statement"].(string))
+			}
+			if diff := cmp.Diff(tc.want, statements); diff != "" {
+				t.Fatalf("incorrect statements: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateSchemaToolsErrors(t *testing.T) {
+	sourcesMap := map[string]sources.Source{
+		"my-pg":   &fakePostgresSource{fakeSchemaSource{schema: crudSchema}},
+		"my-fake": &fakeSchemaSource{schema: crudSchema},
+	}
+	tcs := []struct {
+		desc string
+		cfg  SchemaToolsConfig
+		want string
+	}{
+		{desc: "missing source", cfg: SchemaToolsConfig{Source: "missing", Tables: []string{"orders"}}, want: `source "missing" not found`},
+		{desc: "unsupported source", cfg: SchemaToolsConfig{Source: "my-fake", Tables: []string{"orders"}}, want: `sources of type "fake"`},
+		{desc: "missing table", cfg: SchemaToolsConfig{Source: "my-pg", Tables: []string{"customers"}}, want: `table "customers" not found`},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := generateSchemaTools(context.Background(), SchemaToolsConfigs{"crud": tc.cfg}, sourcesMap)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("got error %v, want error containing %q", err, tc.want)
+			}
+		})
+	}
+
+	got, err := generateSchemaTools(context.Background(), SchemaToolsConfigs{"crud": {Source: "my-pg", Tables: []string{"public.orders"}}}, sourcesMap)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("got %d tools, want the get and list tools", len(got))
+	}
+}
+
+func TestColumnParameterType(t *testing.T) {
+	for sqlType, want := range map[string]string{
+		"bigint":           "integer",
+		"int(11) unsigned": "integer",
+		"INTEGER":          "integer",
+		"double precision": "float",
+		"boolean":          "boolean",
+		"numeric(10,2)":    "string",
+		"interval":         "string",
+		"uuid":             "string",
+	} {
+		if got := columnParameterType(sqlType); got != want {
+			t.Errorf("got type %q for %q, want %q", got, sqlType, want)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/googleapis/mcp-toolbox/internal/sources"
+	"github.com/googleapis/mcp-toolbox/internal/sources/secrets"
+)
+
+// SetSourceConfigs records the configs of the sources of a reloaded
+// configuration, whose secrets are then refreshed.
+func (s *Server) SetSourceConfigs(configs SourceConfigs) {
+	s.secretsMu.Lock()
+	defer s.secretsMu.Unlock()
+	s.sourceConfigs = configs
+}
+
+// refreshSecrets resolves the secrets of the sources every interval, until
+// ctx is done.
+func (s *Server) refreshSecrets(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.reinitializeRotatedSources(ctx); err != nil {
+				s.logger.WarnContext(ctx, fmt.Sprintf("unable to refresh the secrets of the sources: %s", err))
+			}
+		}
+	}
+}
+
+// reinitializeRotatedSources initializes again the sources whose secrets
+// changed, along with the sources linked to other sources, and swaps them
+// in. The replaced sources are closed once the requests using them
+// complete. Nothing is swapped if any source fails to initialize.
+func (s *Server) reinitializeRotatedSources(ctx context.Context) error {
+	s.secretsMu.Lock()
+	defer s.secretsMu.Unlock()
+
+	previous := s.PrimitiveMgr.GetSourcesMap()
+	var rotated []string
+	resolved := make(map[string]sources.SourceConfig)
+	for name, sc := range s.sourceConfigs {
+		if !secrets.HasRefs(sc) {
+			continue
+		}
+		rsc, err := secrets.Resolve(ctx, sc)
+		if err != nil {
+			return fmt.Errorf("source %q: %w", name, err)
+		}
+		if _, ok := reusableSource(previous[name], rsc); ok {
+			continue
+		}
+		rotated = append(rotated, name)
+		resolved[name] = rsc
+	}
+	if len(rotated) == 0 {
+		return nil
+	}
+	slices.Sort(rotated)
+	s.logger.InfoContext(ctx, fmt.Sprintf("secrets of %d sources changed, initializing them again: %s", len(rotated), strings.Join(rotated, ", ")))
+
+	// The linked sources refer to the instances they were linked with.
+	for name, src := range previous {
+		if _, ok := src.(sources.Linker); ok && resolved[name] == nil {
+			if sc, ok := s.sourceConfigs[name]; ok {
+				rsc, err := secrets.Resolve(ctx, sc)
+				if err != nil {
+					return fmt.Errorf("source %q: %w", name, err)
+				}
+				resolved[name] = rsc
+			}
+		}
+	}
+	current := maps.Clone(previous)
+	fresh := make(map[string]sources.Source)
+	for name, sc := range resolved {
+		src, err := initializeSource(ctx, s.instrumentation, name, sc)
+		if err != nil {
+			s.closeSources(fresh)
+			return err
+		}
+		fresh[name] = src
+		current[name] = src
+	}
+	for _, name := range slices.Sorted(maps.Keys(current)) {
+		if l, ok := current[name].(sources.Linker); ok {
+			if err := l.Link(current); err != nil {
+				s.closeSources(fresh)
+				return fmt.Errorf("unable to initialize source %q: %w", name, err)
+			}
+		}
+	}
+	s.PrimitiveMgr.SetSources(current)
+	s.retireSources(ctx, previous, current)
+	return nil
+}
@@ -21,12 +21,14 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"net"
 	"net/http"
 	"os"
 	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-chi/chi/v5"
@@ -38,14 +40,28 @@ import (
 	"github.com/googleapis/mcp-toolbox/internal/embeddingmodels"
 	"github.com/googleapis/mcp-toolbox/internal/log"
 	"github.com/googleapis/mcp-toolbox/internal/prompts"
+	"github.com/googleapis/mcp-toolbox/internal/resources"
+	"github.com/googleapis/mcp-toolbox/internal/server/approval"
+	"github.com/googleapis/mcp-toolbox/internal/server/audit"
 	"github.com/googleapis/mcp-toolbox/internal/server/mcp/jsonrpc"
+	mcputil "github.com/googleapis/mcp-toolbox/internal/server/mcp/util"
+	"github.com/googleapis/mcp-toolbox/internal/server/pagination"
 	"github.com/googleapis/mcp-toolbox/internal/server/primitives"
+	"github.com/googleapis/mcp-toolbox/internal/server/responselimit"
+	"github.com/googleapis/mcp-toolbox/internal/server/resultcache"
+	"github.com/googleapis/mcp-toolbox/internal/server/scheduler"
 	"github.com/googleapis/mcp-toolbox/internal/sources"
+	"github.com/googleapis/mcp-toolbox/internal/sources/schemasnapshot"
+	"github.com/googleapis/mcp-toolbox/internal/sources/secrets"
 	"github.com/googleapis/mcp-toolbox/internal/telemetry"
 	"github.com/googleapis/mcp-toolbox/internal/tools"
 	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/pkg/sdk"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/net/http2"
+	"google.golang.org/grpc"
 )
 
 // Server contains info for running an instance of Toolbox. Should be instantiated with NewServer().
@@ -55,14 +71,100 @@ type Server struct {
 	toolboxUrl          string
 	srv                 *http.Server
 	listener            net.Listener
+	// network is the network of the address of srv, "tcp" or "unix".
+	network string
+	// grpcAddr is the address the gRPC API is served on. Empty disables it.
+	grpcAddr            string
+	grpcSrv             *grpc.Server
+	grpcListener        net.Listener
 	root                chi.Router
 	logger              log.Logger
 	instrumentation     *telemetry.Instrumentation
 	sseManager          *sseManager
+	streamable          *streamableManager
 	PrimitiveMgr        *primitives.PrimitiveManager
 	mcpPrmFile          string
 	httpMaxRequestBytes int64
-	enableDraftSpecs    bool
+	// batchParallelism is the number of the invocations of a batch run at
+	// once.
+	batchParallelism int
+	enableDraftSpecs bool
+	invocations      invocationTracker
+	// invocationQueueDepth bounds the concurrent tool invocations of the
+	// stdio transport; httpQueue optionally bounds those of HTTP transports.
+	invocationQueueDepth int
+	httpQueue            *invocationQueue
+	// adminToken authenticates administrative requests. Empty disables them.
+	adminToken string
+	// responseSigningKey signs the bodies of tool invocation responses.
+	// Empty disables signing.
+	responseSigningKey []byte
+	// trustProxy takes the source address of requests from their
+	// X-Forwarded-For header.
+	trustProxy bool
+	// trustedProxyHops is the number of trusted proxies appending to the
+	// X-Forwarded-For header of requests.
+	trustedProxyHops int
+	// tlsOptions restrict the TLS connections of the server.
+	tlsOptions tlsOptions
+	// paramCoercion is the coercion mode of parameter values of the tools
+	// that do not set their own.
+	paramCoercion string
+	// redactErrorDetails withholds the text of invocation errors from
+	// clients.
+	redactErrorDetails bool
+	// defaultLocale is the locale of tool descriptions served when neither
+	// the request nor the toolset selects one.
+	defaultLocale string
+	// usage aggregates the usage of tools per toolset.
+	usage usageStats
+	// async runs the tool invocations requested with ?async=true.
+	async *asyncInvoker
+	// access restricts the toolsets and tools clients may use. Nil allows
+	// every client to use everything.
+	access *accessControl
+	// namespaces isolates the tools and toolsets of namespaces. Nil if there
+	// are none.
+	namespaces *namespaces
+	// scheduler runs the jobs of the configuration, if any.
+	scheduler *scheduler.Scheduler
+	// suggestions caches the suggested values of tool parameters.
+	suggestions suggestionCache
+	// sourcePings records the latency of the last ping of each source by
+	// the source health endpoint.
+	sourcePings sourcePings
+	// sourceMetrics reports the statistics of the sources as metrics.
+	sourceMetrics metric.Registration
+	// sourceConfigs are the configs of the sources, before their secrets
+	// are resolved. secretsMu serializes the refreshes of the secrets.
+	secretsMu     sync.Mutex
+	sourceConfigs SourceConfigs
+	// auditor writes the audit records of tool invocations. Nil disables
+	// auditing.
+	auditor *audit.Auditor
+	// pages keeps the rest of the tool results split into pages. Nil returns
+	// whole results.
+	pages *pagination.Store
+	// listPageSize is the number of tools of a page of tool listings. Zero
+	// lists every tool at once.
+	listPageSize int
+}
+
+// initializeSource initializes the source of the given name from its config,
+// whose secrets are resolved.
+func initializeSource(ctx context.Context, instrumentation *telemetry.Instrumentation, name string, sc sources.SourceConfig) (sources.Source, error) {
+	childCtx, span := instrumentation.Tracer.Start(
+		ctx,
+		"toolbox/server/source/init",
+		trace.WithAttributes(attribute.String("source_type", sc.SourceConfigType())),
+		trace.WithAttributes(attribute.String("source_name", name)),
+	)
+	defer span.End()
+	s, err := sc.Initialize(childCtx, instrumentation.Tracer)
+	if err != nil {
+		return nil, fmt.Errorf("unable to initialize source %q: %w", name, err)
+	}
+	return s, nil
 }
 
 func InitializeConfigs(ctx context.Context, cfg ServerConfig) (
@@ -73,59 +175,109 @@ func InitializeConfigs(ctx context.Context, cfg ServerConfig) (
 	map[string]tools.Toolset,
 	map[string]prompts.Prompt,
 	map[string]prompts.Promptset,
+	map[string]resources.Resource,
 	error,
 ) {
 	if cfg.EnableAPI {
 		for _, sc := range cfg.AuthServiceConfigs {
 			if sc.IsMCPEnabled() {
-				return nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("MCP Auth cannot be enabled together with the legacy HTTP API (EnableAPI)")
+				return nil, nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("MCP Auth cannot be enabled together with the legacy HTTP API (EnableAPI)")
 			}
 		}
 	}
 
+	if err := verifyToolSources(cfg); err != nil {
+		return nil, nil, nil, nil, nil, nil, nil, nil, err
+	}
+
 	metadataStr := cfg.Version
 	if len(cfg.UserAgentMetadata) > 0 {
 		metadataStr += "+" + strings.Join(cfg.UserAgentMetadata, "+")
 	}
 	ctx = util.WithUserAgent(ctx, metadataStr)
+	ctx = schemasnapshot.WithUpdate(ctx, cfg.UpdateSchemaSnapshots)
 	instrumentation, err := util.InstrumentationFromContext(ctx)
 	if err != nil {
-		return nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("failed to get instrumentation from context: %w", err)
+		return nil, nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("failed to get instrumentation from context: %w", err)
 	}
 
 	l, err := util.LoggerFromContext(ctx)
 	if err != nil {
-		return nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("failed to get logger from context: %w", err)
+		return nil, nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("failed to get logger from context: %w", err)
 	}
 
 	// initialize and validate the sources from configs
 	sourcesMap := make(map[string]sources.Source)
 	for name, sc := range cfg.SourceConfigs {
-		s, err := func() (sources.Source, error) {
-			childCtx, span := instrumentation.Tracer.Start(
-				ctx,
-				"toolbox/server/source/init",
-				trace.WithAttributes(attribute.String("source_type", sc.SourceConfigType())),
-				trace.WithAttributes(attribute.String("source_name", name)),
-			)
-			defer span.End()
-			s, err := sc.Initialize(childCtx, instrumentation.Tracer)
-			if err != nil {
-				return nil, fmt.Errorf("unable to initialize source %q: %w", name, err)
-			}
-			return s, nil
-		}()
+		// The secrets are resolved first, so that a source whose secrets
+		// were rotated is initialized again.
+		sc, err := secrets.Resolve(ctx, sc)
+		if err != nil {
+			return nil, nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("unable to initialize source %q: %w", name, err)
+		}
+		if s, ok := reusableSource(cfg.CurrentSources[name], sc); ok {
+			l.DebugContext(ctx, fmt.Sprintf("config of source %q is unchanged, reusing it", name))
+			sourcesMap[name] = s
+			continue
+		}
+		s, err := initializeSource(ctx, instrumentation, name, sc)
 		if err != nil {
-			return nil, nil, nil, nil, nil, nil, nil, err
+			return nil, nil, nil, nil, nil, nil, nil, nil, err
 		}
 		sourcesMap[name] = s
 	}
+	for _, name := range slices.Sorted(maps.Keys(sourcesMap)) {
+		if l, ok := sourcesMap[name].(sources.Linker); ok {
+			if err := l.Link(sourcesMap); err != nil {
+				return nil, nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("unable to initialize source %q: %w", name, err)
+			}
+		}
+	}
 	sourceNames := make([]string, 0, len(sourcesMap))
 	for name := range sourcesMap {
 		sourceNames = append(sourceNames, name)
 	}
 	l.InfoContext(ctx, fmt.Sprintf("Initialized %d sources: %s", len(sourcesMap), strings.Join(sourceNames, ", ")))
 
+	// generate the tools of the tables of sources and of dbt projects
+	var generated []generatedTool
+	if len(cfg.SchemaToolsConfigs) > 0 {
+		fromSchema, err := generateSchemaTools(ctx, cfg.SchemaToolsConfigs, sourcesMap)
+		if err != nil {
+			return nil, nil, nil, nil, nil, nil, nil, nil, err
+		}
+		generated = append(generated, fromSchema...)
+		l.InfoContext(ctx, fmt.Sprintf("Generated %d tools from the schema of sources", len(fromSchema)))
+	}
+	if len(cfg.DbtToolsConfigs) > 0 {
+		fromDbt, err := generateDbtTools(ctx, cfg.DbtToolsConfigs, sourcesMap)
+		if err != nil {
+			return nil, nil, nil, nil, nil, nil, nil, nil, err
+		}
+		generated = append(generated, fromDbt...)
+		l.InfoContext(ctx, fmt.Sprintf("Generated %d tools from dbt manifests", len(fromDbt)))
+	}
+	if len(generated) > 0 {
+		toolConfigs := maps.Clone(cfg.ToolConfigs)
+		if toolConfigs == nil {
+			toolConfigs = make(ToolConfigs)
+		}
+		for _, g := range generated {
+			if _, ok := toolConfigs[g.name]; ok {
+				return nil, nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("generated tool %q conflicts with a tool of the same name", g.name)
+			}
+			c, err := UnmarshalYAMLToolConfig(ctx, g.name, g.resource)
+			if err != nil {
+				return nil, nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("unable to generate tool %q: %w", g.name, err)
+			}
+			if c == nil {
+				continue
+			}
+			toolConfigs[g.name] = c
+		}
+		cfg.ToolConfigs = toolConfigs
+	}
+
 	// initialize and validate the auth services from configs
 	authServicesMap := make(map[string]auth.AuthService)
 	for name, sc := range cfg.AuthServiceConfigs {
@@ -144,7 +296,7 @@ func InitializeConfigs(ctx context.Context, cfg ServerConfig) (
 			return a, nil
 		}()
 		if err != nil {
-			return nil, nil, nil, nil, nil, nil, nil, err
+			return nil, nil, nil, nil, nil, nil, nil, nil, err
 		}
 		authServicesMap[name] = a
 	}
@@ -173,7 +325,7 @@ func InitializeConfigs(ctx context.Context, cfg ServerConfig) (
 			return em, nil
 		}()
 		if err != nil {
-			return nil, nil, nil, nil, nil, nil, nil, err
+			return nil, nil, nil, nil, nil, nil, nil, nil, err
 		}
 		embeddingModelsMap[name] = em
 	}
@@ -185,12 +337,107 @@ func InitializeConfigs(ctx context.Context, cfg ServerConfig) (
 
 	toolsMap, err := initializeTools(ctx, cfg, instrumentation, l)
 	if err != nil {
-		return nil, nil, nil, nil, nil, nil, nil, err
+		return nil, nil, nil, nil, nil, nil, nil, nil, err
+	}
+	if err := validateToolSources(ctx, sourcesMap, toolsMap); err != nil {
+		return nil, nil, nil, nil, nil, nil, nil, nil, err
+	}
+	toolsMap, err = tools.WrapSourceParameters(toolsMap)
+	if err != nil {
+		return nil, nil, nil, nil, nil, nil, nil, nil, err
+	}
+	toolsMap, err = tools.WrapTransforms(toolsMap)
+	if err != nil {
+		return nil, nil, nil, nil, nil, nil, nil, nil, err
+	}
+	toolsMap, err = tools.WrapOutputSchemas(toolsMap)
+	if err != nil {
+		return nil, nil, nil, nil, nil, nil, nil, nil, err
+	}
+	toolsMap, err = tools.WrapTimeouts(toolsMap)
+	if err != nil {
+		return nil, nil, nil, nil, nil, nil, nil, nil, err
+	}
+	var approver *approval.Approver
+	if cfg.ApprovalURL != "" {
+		approver, err = approval.New(cfg.ApprovalURL, cfg.ApprovalSecret, cfg.ApprovalTimeout)
+		if err != nil {
+			return nil, nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("unable to initialize approvals: %w", err)
+		}
+	}
+	toolsMap, err = approval.Wrap(toolsMap, approver)
+	if err != nil {
+		return nil, nil, nil, nil, nil, nil, nil, nil, err
+	}
+	var summarizer *responselimit.Summarizer
+	if cfg.SummarizerURL != "" {
+		summarizer, err = responselimit.NewSummarizer(cfg.SummarizerURL, cfg.SummarizerTimeout)
+		if err != nil {
+			return nil, nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("unable to initialize the summarizer: %w", err)
+		}
+	}
+	toolsMap, err = responselimit.Wrap(toolsMap, summarizer)
+	if err != nil {
+		return nil, nil, nil, nil, nil, nil, nil, nil, err
+	}
+	toolsMap = tools.MarkReadOnly(toolsMap)
+	toolsMap = tools.MarkWriteIntent(toolsMap)
+	toolsMap = tools.WrapRateLimits(toolsMap, cfg.DefaultRateLimit)
+	if cfg.CacheBackend != "" {
+		backend, err := resultcache.NewBackend(ctx, cfg.CacheBackend, cfg.MemcachedAddrs, cfg.RedisURL, l)
+		if err != nil {
+			return nil, nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("unable to initialize result cache: %w", err)
+		}
+		toolsMap, err = resultcache.WrapReadOnly(toolsMap, resultcache.New(backend, cfg.CacheTTL))
+		if err != nil {
+			return nil, nil, nil, nil, nil, nil, nil, nil, err
+		}
+		l.InfoContext(ctx, fmt.Sprintf("Caching results of read-only tools in %s for %s", cfg.CacheBackend, cfg.CacheTTL))
+	} else if resultcache.Enabled(toolsMap) {
+		// Tools enabling their cache are cached in process without a backend.
+		toolsMap, err = resultcache.WrapEnabled(toolsMap, resultcache.New(resultcache.NewLRU(resultcache.DefaultLRUSize), cfg.CacheTTL))
+		if err != nil {
+			return nil, nil, nil, nil, nil, nil, nil, nil, err
+		}
+		l.InfoContext(ctx, fmt.Sprintf("Caching results of the tools enabling their cache in %s for %s", resultcache.BackendMemory, cfg.CacheTTL))
 	}
 
-	toolsetsMap, err := initializeToolsets(ctx, cfg, toolsMap, instrumentation, l)
+	// initialize and validate the resources from configs
+	resourcesMap := make(map[string]resources.Resource)
+	for name, rc := range cfg.ResourceConfigs {
+		r, err := func() (resources.Resource, error) {
+			_, span := instrumentation.Tracer.Start(
+				ctx,
+				"toolbox/server/resource/init",
+				trace.WithAttributes(attribute.String("resource_name", name)),
+			)
+			defer span.End()
+			r, err := rc.Initialize()
+			if err != nil {
+				return resources.Resource{}, fmt.Errorf("unable to initialize resource %q: %w", name, err)
+			}
+			if rc.Source != "" {
+				r, err = withSchemaLoader(r, sourcesMap)
+				if err != nil {
+					return resources.Resource{}, fmt.Errorf("unable to initialize resource %q: %w", name, err)
+				}
+			}
+			return r, nil
+		}()
+		if err != nil {
+			return nil, nil, nil, nil, nil, nil, nil, nil, err
+		}
+		resourcesMap[name] = r
+	}
+	resourceNames := make([]string, 0, len(resourcesMap))
+	for name := range resourcesMap {
+		resourceNames = append(resourceNames, name)
+	}
+	l.InfoContext(ctx, fmt.Sprintf("Initialized %d resources: %s", len(resourcesMap), strings.Join(resourceNames, ", ")))
+
+	toolsetsMap, err := initializeToolsets(ctx, cfg, toolsMap, resourcesMap, instrumentation, l)
 	if err != nil {
-		return nil, nil, nil, nil, nil, nil, nil, err
+		return nil, nil, nil, nil, nil, nil, nil, nil, err
 	}
 
 	// initialize and validate the prompts from configs
@@ -211,7 +458,7 @@ func InitializeConfigs(ctx context.Context, cfg ServerConfig) (
 			return p, nil
 		}()
 		if err != nil {
-			return nil, nil, nil, nil, nil, nil, nil, err
+			return nil, nil, nil, nil, nil, nil, nil, nil, err
 		}
 		promptsMap[name] = p
 	}
@@ -248,7 +495,7 @@ func InitializeConfigs(ctx context.Context, cfg ServerConfig) (
 			return p, err
 		}()
 		if err != nil {
-			return nil, nil, nil, nil, nil, nil, nil, err
+			return nil, nil, nil, nil, nil, nil, nil, nil, err
 		}
 		promptsetsMap[name] = p
 	}
@@ -262,7 +509,7 @@ func InitializeConfigs(ctx context.Context, cfg ServerConfig) (
 	}
 	l.InfoContext(ctx, fmt.Sprintf("Initialized %d promptsets: %s", len(promptsetsMap), strings.Join(promptsetNames, ", ")))
 
-	return sourcesMap, authServicesMap, embeddingModelsMap, toolsMap, toolsetsMap, promptsMap, promptsetsMap, nil
+	return sourcesMap, authServicesMap, embeddingModelsMap, toolsMap, toolsetsMap, promptsMap, promptsetsMap, resourcesMap, nil
 }
 
 // InitializeOfflineConfigs initializes only tools and toolsets from the config,
@@ -283,12 +530,20 @@ func InitializeOfflineConfigs(ctx context.Context, cfg ServerConfig) (
 		return nil, nil, fmt.Errorf("failed to get logger from context: %w", err)
 	}
 
+	if err := verifyToolSources(cfg); err != nil {
+		return nil, nil, err
+	}
+
 	toolsMap, err := initializeTools(ctx, cfg, instrumentation, l)
 	if err != nil {
 		return nil, nil, err
 	}
+	toolsMap, err = tools.WrapSourceParameters(toolsMap)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	toolsetsMap, err := initializeToolsets(ctx, cfg, toolsMap, instrumentation, l)
+	toolsetsMap, err := initializeToolsets(ctx, cfg, toolsMap, nil, instrumentation, l)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -296,6 +551,48 @@ func InitializeOfflineConfigs(ctx context.Context, cfg ServerConfig) (
 	return toolsMap, toolsetsMap, nil
 }
 
+// verifyToolSources checks that every tool references a source of a type
+// it supports, so that a misconfigured tool fails at load time rather than
+// at invocation.
+func verifyToolSources(cfg ServerConfig) error {
+	sourceTypes := make(map[string]string, len(cfg.SourceConfigs))
+	for name, sc := range cfg.SourceConfigs {
+		sourceTypes[name] = sc.SourceConfigType()
+	}
+	for _, name := range slices.Sorted(maps.Keys(cfg.ToolConfigs)) {
+		if err := tools.VerifySource(name, cfg.ToolConfigs[name], sourceTypes); err != nil {
+			return err
+		}
+		if err := tools.VerifySourceParameter(name, cfg.ToolConfigs[name], sourceTypes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sourceMap provides the sources of a map to tools.
+type sourceMap map[string]sources.Source
+
+func (m sourceMap) GetSource(sourceName string) (sources.Source, bool) {
+	s, ok := m[sourceName]
+	return s, ok
+}
+
+// validateToolSources checks the resources that tools implementing
+// tools.SourceValidator use in their sources.
+func validateToolSources(ctx context.Context, sourcesMap map[string]sources.Source, toolsMap map[string]tools.Tool) error {
+	for _, name := range slices.Sorted(maps.Keys(toolsMap)) {
+		v, ok := toolsMap[name].(tools.SourceValidator)
+		if !ok {
+			continue
+		}
+		if err := v.ValidateSource(ctx, sourceMap(sourcesMap)); err != nil {
+			return fmt.Errorf("unable to validate tool %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
 // initializeTools initializes and validates the tools from the config.
 func initializeTools(ctx context.Context, cfg ServerConfig, instrumentation *telemetry.Instrumentation, l log.Logger) (map[string]tools.Tool, error) {
 	toolsMap := make(map[string]tools.Tool)
@@ -327,19 +624,25 @@ func initializeTools(ctx context.Context, cfg ServerConfig, instrumentation *tel
 	return toolsMap, nil
 }
 
-// initializeToolsets seeds a default toolset containing all tools, then
-// initializes and validates the toolsets from the config.
-func initializeToolsets(ctx context.Context, cfg ServerConfig, toolsMap map[string]tools.Tool, instrumentation *telemetry.Instrumentation, l log.Logger) (map[string]tools.Toolset, error) {
-	// create a default toolset that contains all tools
+// initializeToolsets seeds a default toolset containing all tools and
+// resources, then initializes and validates the toolsets from the config.
+// Resource references are only validated when resourcesMap is non-nil.
+func initializeToolsets(ctx context.Context, cfg ServerConfig, toolsMap map[string]tools.Tool, resourcesMap map[string]resources.Resource, instrumentation *telemetry.Instrumentation, l log.Logger) (map[string]tools.Toolset, error) {
+	// create a default toolset that contains all tools and resources
 	allToolNames := make([]string, 0, len(toolsMap))
 	for name := range toolsMap {
 		allToolNames = append(allToolNames, name)
 	}
 	slices.Sort(allToolNames)
+	var allResourceNames []string
+	for name := range resourcesMap {
+		allResourceNames = append(allResourceNames, name)
+	}
+	slices.Sort(allResourceNames)
 	if cfg.ToolsetConfigs == nil {
 		cfg.ToolsetConfigs = make(ToolsetConfigs)
 	}
-	cfg.ToolsetConfigs[""] = tools.ToolsetConfig{Name: "", ToolNames: allToolNames}
+	cfg.ToolsetConfigs[""] = tools.ToolsetConfig{Name: "", ToolNames: allToolNames, ResourceNames: allResourceNames}
 
 	toolsetsMap := make(map[string]tools.Toolset)
 	for name, tc := range cfg.ToolsetConfigs {
@@ -367,6 +670,13 @@ func initializeToolsets(ctx context.Context, cfg ServerConfig, toolsMap map[stri
 			if err != nil {
 				return tools.Toolset{}, fmt.Errorf("unable to initialize toolset %q: %w", name, err)
 			}
+			if resourcesMap != nil {
+				for _, resourceName := range tc.ResourceNames {
+					if _, ok := resourcesMap[resourceName]; !ok {
+						return tools.Toolset{}, fmt.Errorf("unable to initialize toolset %q: resource does not exist: %s", name, resourceName)
+					}
+				}
+			}
 			return t, err
 		}()
 		if err != nil {
@@ -440,18 +750,50 @@ func NewServer(ctx context.Context, cfg ServerConfig) (*Server, error) {
 	}
 	logger := l.SlogLogger()
 	r.Use(httplog.RequestLogger(logger, httpOpts))
+	r.Use(compress)
+
+	tlsOpts, err := newTLSOptions(cfg.TLSCipherSuites, cfg.TLSFIPS)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.TLSFIPS && len(cfg.TLSCipherSuites) > 0 {
+		l.WarnContext(ctx, "--tls-fips restricts the TLS cipher suites to the FIPS approved ones, --tls-cipher-suites is ignored")
+	}
 
-	sourcesMap, authServicesMap, embeddingModelsMap, toolsMap, toolsetsMap, promptsMap, promptsetsMap, err := InitializeConfigs(ctx, cfg)
+	sourcesMap, authServicesMap, embeddingModelsMap, toolsMap, toolsetsMap, promptsMap, promptsetsMap, resourcesMap, err := InitializeConfigs(ctx, cfg)
 	if err != nil {
 		return nil, fmt.Errorf("unable to initialize configs: %w", err)
 	}
 
-	addr := net.JoinHostPort(cfg.Address, strconv.Itoa(cfg.Port))
+	var auditor *audit.Auditor
+	if cfg.AuditLog != "" {
+		if cfg.Stdio && cfg.AuditLog == audit.SinkStdout {
+			return nil, fmt.Errorf("audit log sink %q cannot be used with --stdio", audit.SinkStdout)
+		}
+		sink, err := audit.NewSink(ctx, cfg.AuditLog, cfg.AuditLogFile, cfg.AuditLogProject)
+		if err != nil {
+			return nil, fmt.Errorf("unable to initialize audit log: %w", err)
+		}
+		auditor = audit.New(sink, cfg.AuditRedactParams)
+		toolsMap = audit.Wrap(toolsMap, auditor)
+		l.InfoContext(ctx, fmt.Sprintf("Writing audit records of tool invocations to %s", cfg.AuditLog))
+	}
+
+	var pages *pagination.Store
+	if cfg.ResultPageSize > 0 {
+		pages = pagination.New(cfg.ResultPageSize, pagination.DefaultTTL)
+		toolsMap = pagination.Wrap(toolsMap, pages)
+	}
+
+	network, addr := listenAddr(cfg.Address, cfg.Port)
+	if network == "unix" && cfg.GRPCPort != 0 {
+		return nil, fmt.Errorf("--grpc-port requires a TCP --address, not a Unix domain socket")
+	}
 	srv := &http.Server{Addr: addr, Handler: r}
 
 	sseManager := newSseManager(ctx)
 
-	primitiveManager := primitives.NewPrimitiveManager(sourcesMap, authServicesMap, embeddingModelsMap, toolsMap, toolsetsMap, promptsMap, promptsetsMap)
+	primitiveManager := primitives.NewPrimitiveManager(sourcesMap, authServicesMap, embeddingModelsMap, toolsMap, toolsetsMap, promptsMap, promptsetsMap, resourcesMap)
 
 	limit := cfg.HttpMaxRequestBytes
 	if limit <= 0 {
@@ -459,18 +801,77 @@ func NewServer(ctx context.Context, cfg ServerConfig) (*Server, error) {
 	}
 
 	s := &Server{
-		version:             cfg.Version,
-		sqlCommenterEnabled: cfg.SQLCommenter,
-		srv:                 srv,
-		root:                r,
-		logger:              l,
-		instrumentation:     instrumentation,
-		sseManager:          sseManager,
-		PrimitiveMgr:        primitiveManager,
-		toolboxUrl:          cfg.ToolboxUrl,
-		mcpPrmFile:          cfg.McpPrmFile,
-		httpMaxRequestBytes: limit,
-		enableDraftSpecs:    cfg.EnableDraftSpecs,
+		version:              cfg.Version,
+		sqlCommenterEnabled:  cfg.SQLCommenter,
+		srv:                  srv,
+		network:              network,
+		root:                 r,
+		logger:               l,
+		instrumentation:      instrumentation,
+		sseManager:           sseManager,
+		streamable:           newStreamableManager(ctx),
+		PrimitiveMgr:         primitiveManager,
+		toolboxUrl:           cfg.ToolboxUrl,
+		mcpPrmFile:           cfg.McpPrmFile,
+		httpMaxRequestBytes:  limit,
+		batchParallelism:     cfg.BatchParallelism,
+		enableDraftSpecs:     cfg.EnableDraftSpecs,
+		invocationQueueDepth: cfg.InvocationQueueDepth,
+		adminToken:           cfg.AdminToken,
+		responseSigningKey:   []byte(cfg.ResponseSigningKey),
+		trustProxy:           cfg.TrustProxy,
+		trustedProxyHops:     cfg.TrustedProxyHops,
+		tlsOptions:           tlsOpts,
+		paramCoercion:        cfg.ParamCoercion.String(),
+		redactErrorDetails:   cfg.RedactErrorDetails,
+		defaultLocale:        cfg.DefaultLocale,
+		auditor:              auditor,
+		pages:                pages,
+		listPageSize:         cfg.ListPageSize,
+	}
+	if s.defaultLocale == "" {
+		s.defaultLocale = util.DefaultLocale
+	}
+	s.sourceConfigs = cfg.SourceConfigs
+	if cfg.SecretRefreshInterval > 0 {
+		go s.refreshSecrets(ctx, cfg.SecretRefreshInterval)
+	}
+	if len(cfg.PrewarmSources) > 0 {
+		if err := s.prewarmSources(ctx, cfg.PrewarmSources); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.SourcePingInterval > 0 {
+		go s.pingSources(ctx, cfg.SourcePingInterval)
+	}
+	if cfg.GRPCPort != 0 {
+		s.grpcAddr = net.JoinHostPort(cfg.Address, strconv.Itoa(cfg.GRPCPort))
+	}
+	s.access, err = newAccessControl(cfg.AccessPolicyConfigs, cfg.AccessDenyByDefault, s.PrimitiveMgr)
+	if err != nil {
+		return nil, err
+	}
+	s.namespaces, err = newNamespaces(cfg.NamespaceConfigs, s.PrimitiveMgr)
+	if err != nil {
+		return nil, err
+	}
+	s.async, err = newAsyncInvoker(ctx, s, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("unable to initialize asynchronous invocations: %w", err)
+	}
+	s.sourceMetrics, err = instrumentation.ObserveSources(s.sourceStats)
+	if err != nil {
+		return nil, fmt.Errorf("unable to observe sources: %w", err)
+	}
+	if cfg.HTTPInvocationQueue {
+		s.httpQueue = newInvocationQueue(cfg.InvocationQueueDepth, instrumentation, "tcp")
+	}
+	if cfg.SessionPingInterval > 0 {
+		maxMissed := cfg.SessionMaxMissedPings
+		if maxMissed <= 0 {
+			maxMissed = DefaultSessionMaxMissedPings
+		}
+		sseManager.startPinging(ctx, cfg.SessionPingInterval, maxMissed)
 	}
 
 	if s.enableDraftSpecs {
@@ -485,9 +886,9 @@ func NewServer(ctx context.Context, cfg ServerConfig) (*Server, error) {
 		AllowedOrigins:   cfg.AllowedOrigins,
 		AllowedMethods:   []string{"GET", "POST", "DELETE", "OPTIONS"},
 		AllowCredentials: true, // required since Toolbox uses auth headers
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token", "Mcp-Session-Id", "MCP-Protocol-Version"},
-		ExposedHeaders:   []string{"Mcp-Session-Id"}, // headers that are sent to clients
-		MaxAge:           300,                        // cache preflight results for 5 minutes
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token", "Mcp-Session-Id", "MCP-Protocol-Version", "Last-Event-ID", mcputil.DryRunHeader},
+		ExposedHeaders:   []string{"Mcp-Session-Id", sdk.SignatureHeader}, // headers that are sent to clients
+		MaxAge:           300,                                             // cache preflight results for 5 minutes
 	}
 	r.Use(cors.Handler(corsOpts))
 	// validate hosts for DNS rebinding attacks
@@ -504,6 +905,18 @@ func NewServer(ctx context.Context, cfg ServerConfig) (*Server, error) {
 	}
 	r.Use(hostCheck(allowedHostsMap))
 
+	switch cfg.AuthBackend {
+	case "":
+	case AuthBackendIAP:
+		iap, err := newIAPMiddleware(s, cfg.IAPAudience)
+		if err != nil {
+			return nil, err
+		}
+		r.Use(iap)
+	default:
+		return nil, fmt.Errorf("unknown auth backend %q, must be %q", cfg.AuthBackend, AuthBackendIAP)
+	}
+
 	// Host OAuth Protected Resource Metadata endpoint
 	mcpAuthEnabled := false
 	for _, authSvc := range s.PrimitiveMgr.GetAuthServiceMap() {
@@ -552,12 +965,30 @@ func NewServer(ctx context.Context, cfg ServerConfig) (*Server, error) {
 	}
 
 	r.Mount("/mcp", mcpR)
+	if s.namespaces != nil {
+		nsMcpR, err := mcpRouter(s)
+		if err != nil {
+			return nil, err
+		}
+		r.With(namespaceMiddleware(s)).Mount("/ns/{namespace}/mcp", nsMcpR)
+	}
+	if cfg.AsyncBackend == AsyncBackendCloudTasks {
+		url := strings.TrimSuffix(cfg.ToolboxUrl, "/") + taskHandlerPath
+		r.Post(taskHandlerPath, taskHandler(s, cfg.CloudTasksServiceAccount, url))
+	}
 	if cfg.EnableAPI {
 		apiR, err := apiRouter(s)
 		if err != nil {
 			return nil, err
 		}
 		r.Mount("/api", apiR)
+		if s.namespaces != nil {
+			nsApiR, err := apiRouter(s)
+			if err != nil {
+				return nil, err
+			}
+			r.With(namespaceMiddleware(s)).Mount("/ns/{namespace}/api", nsApiR)
+		}
 	} else {
 		r.Handle("/api/*", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			err := errors.New("/api native endpoints are disabled by default. Please use the standard /mcp JSON-RPC endpoint")
@@ -571,11 +1002,16 @@ func NewServer(ctx context.Context, cfg ServerConfig) (*Server, error) {
 		}
 		r.Mount("/ui", webR)
 	}
+	r.Get("/health/sources", func(w http.ResponseWriter, r *http.Request) { sourcesHealthHandler(s, w, r) })
 	// default endpoint for validating server is running
 	r.Get("/", func(w http.ResponseWriter, r *http.Request) {
 		_, _ = w.Write([]byte("🧰 Hello, World! 🧰"))
 	})
 
+	if err := s.startJobs(ctx, cfg.JobConfigs); err != nil {
+		return nil, err
+	}
+
 	return s, nil
 }
 
@@ -642,10 +1078,35 @@ func (s *Server) Listen(ctx context.Context, certFile, keyFile string) error {
 		return fmt.Errorf("server is already listening: %s", s.listener.Addr().String())
 	}
 	lc := net.ListenConfig{KeepAlive: 30 * time.Second}
-	ln, err := lc.Listen(ctx, "tcp", s.srv.Addr)
+	activated, err := systemdListeners()
+	if err != nil {
+		return fmt.Errorf("failed to use the sockets passed by systemd: %w", err)
+	}
+	var ln, grpcLn net.Listener
+	switch {
+	case len(activated) > 0:
+		// the first socket serves HTTP, and the second one gRPC, if enabled
+		ln = activated[0]
+		s.srv.Addr = ln.Addr().String()
+		s.logger.InfoContext(ctx, fmt.Sprintf("using %d sockets passed by systemd", len(activated)))
+	case s.network == "unix":
+		ln, err = listenUnix(ctx, lc, s.srv.Addr)
+	default:
+		ln, err = lc.Listen(ctx, "tcp", s.srv.Addr)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to open listener for %q: %w", s.srv.Addr, err)
 	}
+	if len(activated) > 1 && s.grpcAddr != "" {
+		grpcLn = activated[1]
+		activated = activated[2:]
+	} else if len(activated) > 0 {
+		activated = activated[1:]
+	}
+	for _, extra := range activated {
+		s.logger.WarnContext(ctx, fmt.Sprintf("ignoring socket %s passed by systemd", extra.Addr()))
+		extra.Close()
+	}
 
 	if certFile != "" || keyFile != "" {
 		// Load the certificates
@@ -654,21 +1115,61 @@ func (s *Server) Listen(ctx context.Context, certFile, keyFile string) error {
 			ln.Close()
 			return fmt.Errorf("failed to load TLS key pair (cert: %q, key: %q): %w", certFile, keyFile, err)
 		}
-		// Wrap the listener with TLS
-		config := &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}
-		s.listener = tls.NewListener(ln, config)
+		// Wrap the listener with TLS. HTTP/2 is negotiated through ALPN, with
+		// HTTP/1.1 as a fallback for clients that do not support it. Server
+		// push is never initiated by the handlers. HTTP/2 is disabled if the
+		// allowed cipher suites exclude the ones it requires.
+		s.srv.TLSConfig = s.tlsOptions.config(cert)
+		if s.tlsOptions.http2Capable() {
+			if err := http2.ConfigureServer(s.srv, &http2.Server{}); err != nil {
+				ln.Close()
+				return fmt.Errorf("failed to configure HTTP/2: %w", err)
+			}
+		} else {
+			s.srv.TLSNextProto = map[string]func(*http.Server, *tls.Conn, http.Handler){}
+			s.logger.WarnContext(ctx, "the allowed TLS cipher suites exclude the ones required by HTTP/2, only HTTP/1.1 is served")
+		}
+		s.listener = tls.NewListener(ln, s.srv.TLSConfig)
 		s.logger.DebugContext(ctx, fmt.Sprintf("secure server listening on %s", s.srv.Addr))
 	} else {
 		s.listener = ln
 		s.logger.DebugContext(ctx, fmt.Sprintf("server listening on %s", s.srv.Addr))
 	}
+
+	if s.grpcAddr != "" {
+		if grpcLn == nil {
+			grpcLn, err = lc.Listen(ctx, "tcp", s.grpcAddr)
+			if err != nil {
+				s.listener.Close()
+				s.listener = nil
+				return fmt.Errorf("failed to open gRPC listener for %q: %w", s.grpcAddr, err)
+			}
+		}
+		// The gRPC API shares the TLS configuration of the HTTP server.
+		s.grpcSrv = newGRPCServer(s, s.srv.TLSConfig)
+		s.grpcListener = grpcLn
+		s.logger.DebugContext(ctx, fmt.Sprintf("gRPC server listening on %s", grpcLn.Addr()))
+	}
 	return nil
 }
 
-// Serve starts an HTTP server for the given Server instance.
+// Serve starts an HTTP server for the given Server instance, and the gRPC
+// server if it is enabled. It returns once either of them stops.
 func (s *Server) Serve(ctx context.Context) error {
-	s.logger.DebugContext(ctx, "Starting a HTTP server.")
-	return s.srv.Serve(s.listener)
+	if s.grpcSrv == nil {
+		s.logger.DebugContext(ctx, "Starting a HTTP server.")
+		return s.srv.Serve(s.listener)
+	}
+	errCh := make(chan error, 2)
+	go func() {
+		s.logger.DebugContext(ctx, "Starting a gRPC server.")
+		errCh <- s.grpcSrv.Serve(s.grpcListener)
+	}()
+	go func() {
+		s.logger.DebugContext(ctx, "Starting a HTTP server.")
+		errCh <- s.srv.Serve(s.listener)
+	}()
+	return <-errCh
 }
 
 // ServeStdio starts a new stdio session for mcp.
@@ -677,13 +1178,6 @@ func (s *Server) ServeStdio(ctx context.Context, stdin io.Reader, stdout io.Writ
 	return stdioServer.Start(ctx)
 }
 
-// Shutdown gracefully shuts down the server without interrupting any active
-// connections. It uses http.Server.Shutdown() and has the same functionality.
-func (s *Server) Shutdown(ctx context.Context) error {
-	s.logger.DebugContext(ctx, "shutting down the server.")
-	return s.srv.Shutdown(ctx)
-}
-
 func (s *Server) Addr() string {
 	return s.listener.Addr().String()
 }
@@ -38,6 +38,7 @@ import (
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/googleapis/mcp-toolbox/internal/auth"
 	"github.com/googleapis/mcp-toolbox/internal/auth/generic"
 	"github.com/googleapis/mcp-toolbox/internal/embeddingmodels"
@@ -46,11 +47,14 @@ import (
 	"github.com/googleapis/mcp-toolbox/internal/server"
 	"github.com/googleapis/mcp-toolbox/internal/sources"
 	"github.com/googleapis/mcp-toolbox/internal/sources/alloydbpg"
+	"github.com/googleapis/mcp-toolbox/internal/sources/queryguard"
+	"github.com/googleapis/mcp-toolbox/internal/sources/schemasnapshot"
 	"github.com/googleapis/mcp-toolbox/internal/telemetry"
 	"github.com/googleapis/mcp-toolbox/internal/testutils"
 	"github.com/googleapis/mcp-toolbox/internal/tools"
 	"github.com/googleapis/mcp-toolbox/internal/util"
 	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/net/http2"
 )
 
 // Helper function to create temporary self-signed certs for the test
@@ -215,6 +219,93 @@ func TestServe(t *testing.T) {
 
 }
 
+func TestServeHTTP2(t *testing.T) {
+	certFile, keyFile, cleanupCerts := generateTestCerts(t)
+	defer cleanupCerts()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	otelShutdown, err := telemetry.SetupOTel(ctx, "0.0.0", "", false, "", "toolbox")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer func() {
+		err := otelShutdown(ctx)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}()
+
+	testLogger, err := log.NewStdLogger(os.Stdout, os.Stderr, "info")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	ctx = util.WithLogger(ctx, testLogger)
+
+	cfg := server.ServerConfig{
+		Version:      "0.0.0",
+		Address:      "127.0.0.1",
+		Port:         5003,
+		AllowedHosts: []string{"*"},
+	}
+	instrumentation, err := telemetry.CreateTelemetryInstrumentation(cfg.Version)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	ctx = util.WithInstrumentation(ctx, instrumentation)
+
+	s, err := server.NewServer(ctx, cfg)
+	if err != nil {
+		t.Fatalf("unable to initialize server: %v", err)
+	}
+	if err := s.Listen(ctx, certFile, keyFile); err != nil {
+		t.Fatalf("unable to start server: %v", err)
+	}
+	go func() {
+		if err := s.Serve(ctx); err != nil && err != http.ErrServerClosed {
+			t.Errorf("server serve error: %v", err)
+		}
+	}()
+	defer func() {
+		_ = s.Shutdown(context.Background())
+	}()
+
+	url := fmt.Sprintf("https://%s:%d/", cfg.Address, cfg.Port)
+	tcs := []struct {
+		desc      string
+		transport http.RoundTripper
+		wantProto int
+	}{
+		{
+			desc:      "HTTP/2 client",
+			transport: &http2.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}},
+			wantProto: 2,
+		},
+		{
+			desc:      "HTTP/1.1 client",
+			transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}},
+			wantProto: 1,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			client := &http.Client{Transport: tc.transport}
+			resp, err := client.Get(url)
+			if err != nil {
+				t.Fatalf("error when sending a request: %s", err)
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != http.StatusOK {
+				t.Fatalf("response status code is not 200, got %d", resp.StatusCode)
+			}
+			if resp.ProtoMajor != tc.wantProto {
+				t.Fatalf("unexpected protocol: got %s, want HTTP/%d", resp.Proto, tc.wantProto)
+			}
+		})
+	}
+}
+
 func TestUpdateServer(t *testing.T) {
 	ctx, err := testutils.ContextWithNewLogger()
 	if err != nil {
@@ -268,13 +359,13 @@ func TestUpdateServer(t *testing.T) {
 			Prompts: []*prompts.Prompt{},
 		},
 	}
-	s.PrimitiveMgr.SetPrimitives(newSources, newAuth, newEmbeddingModels, newTools, newToolsets, newPrompts, newPromptsets)
+	s.PrimitiveMgr.SetPrimitives(newSources, newAuth, newEmbeddingModels, newTools, newToolsets, newPrompts, newPromptsets, nil)
 	if err != nil {
 		t.Errorf("error updating server: %s", err)
 	}
 
 	gotSource, _ := s.PrimitiveMgr.GetSource("example-source")
-	if diff := cmp.Diff(gotSource, newSources["example-source"]); diff != "" {
+	if diff := cmp.Diff(gotSource, newSources["example-source"], cmpopts.IgnoreUnexported(alloydbpg.Source{}, schemasnapshot.Report{}, queryguard.Guard{})); diff != "" {
 		t.Errorf("error updating server, sources (-want +got):\n%s", diff)
 	}
 
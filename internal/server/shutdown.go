@@ -0,0 +1,241 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/go-chi/render"
+	"github.com/googleapis/mcp-toolbox/internal/sources"
+)
+
+// DefaultShutdownTimeout is how long the server waits for in-flight
+// invocations to complete on shutdown.
+const DefaultShutdownTimeout = 30 * time.Second
+
+// errShuttingDown is returned for requests that arrive once the server has
+// started to shut down.
+var errShuttingDown = errors.New("server is shutting down")
+
+// invocationTracker keeps track of in-flight requests so that they can be
+// drained on shutdown. The zero value is ready to use.
+type invocationTracker struct {
+	mu       sync.Mutex
+	draining bool
+	wg       sync.WaitGroup
+	// abort is closed when draining gives up on in-flight requests.
+	abort     chan struct{}
+	abortOnce sync.Once
+	// epoch tracks the requests started since the last call to settle, and
+	// settled is closed once the requests started before it complete.
+	epoch   *sync.WaitGroup
+	settled chan struct{}
+}
+
+// start registers a new in-flight request. The returned context is canceled
+// if the request is still running when draining times out, and done must be
+// called once the request completes. ok is false if the server is draining
+// and the request must be refused.
+func (t *invocationTracker) start(ctx context.Context) (_ context.Context, done func(), ok bool) {
+	t.mu.Lock()
+	if t.draining {
+		t.mu.Unlock()
+		return ctx, nil, false
+	}
+	t.wg.Add(1)
+	if t.epoch == nil {
+		t.epoch = &sync.WaitGroup{}
+	}
+	epoch := t.epoch
+	epoch.Add(1)
+	abort := t.abortChLocked()
+	t.mu.Unlock()
+
+	ctx, cancel := context.WithCancel(ctx)
+	go func() {
+		select {
+		case <-abort:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, func() {
+		cancel()
+		epoch.Done()
+		t.wg.Done()
+	}, true
+}
+
+// settle returns a channel that is closed once the requests in flight at the
+// time of the call complete. Requests started afterwards are not waited for.
+func (t *invocationTracker) settle() <-chan struct{} {
+	t.mu.Lock()
+	prev, epoch := t.settled, t.epoch
+	settled := make(chan struct{})
+	t.settled, t.epoch = settled, nil
+	t.mu.Unlock()
+
+	go func() {
+		if prev != nil {
+			<-prev
+		}
+		if epoch != nil {
+			epoch.Wait()
+		}
+		close(settled)
+	}()
+	return settled
+}
+
+// isDraining reports whether the server stopped accepting requests.
+func (t *invocationTracker) isDraining() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.draining
+}
+
+// drain stops accepting new requests and waits for the in-flight ones to
+// complete. If ctx is done first, the in-flight requests are canceled and
+// ctx's error is returned.
+func (t *invocationTracker) drain(ctx context.Context) error {
+	t.mu.Lock()
+	t.draining = true
+	abort := t.abortChLocked()
+	t.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		t.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		select {
+		case <-done:
+			return nil
+		default:
+		}
+		t.abortOnce.Do(func() { close(abort) })
+		return ctx.Err()
+	}
+}
+
+func (t *invocationTracker) abortChLocked() chan struct{} {
+	if t.abort == nil {
+		t.abort = make(chan struct{})
+	}
+	return t.abort
+}
+
+// drainMiddleware refuses requests once the server is shutting down and
+// tracks the others as in-flight invocations.
+func drainMiddleware(s *Server) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, done, ok := s.invocations.start(r.Context())
+			if !ok {
+				w.Header().Set("Connection", "close")
+				_ = render.Render(w, r, newErrResponse(errShuttingDown, http.StatusServiceUnavailable))
+				return
+			}
+			defer done()
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// Shutdown gracefully shuts down the server. It stops accepting new requests
+// and waits for in-flight invocations to complete until ctx is done, after
+// which the remaining invocations are canceled. Open SSE sessions then
+// receive a close event, the gRPC and HTTP servers are shut down and finally
+// the sources are closed in name order.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.logger.DebugContext(ctx, "shutting down the server.")
+
+	if s.scheduler != nil {
+		s.scheduler.Stop()
+	}
+	drainErr := s.invocations.drain(ctx)
+	if drainErr != nil {
+		s.logger.WarnContext(context.Background(), "in-flight invocations did not complete in time, canceling them")
+	}
+
+	s.sseManager.closeAll()
+	if s.streamable != nil {
+		s.streamable.closeAll()
+	}
+
+	if s.grpcSrv != nil {
+		if drainErr != nil {
+			s.grpcSrv.Stop()
+		} else {
+			s.grpcSrv.GracefulStop()
+		}
+	}
+
+	var srvErr error
+	if s.srv != nil {
+		if drainErr != nil {
+			// There is no time left to wait for connections.
+			srvErr = s.srv.Close()
+		} else {
+			srvErr = s.srv.Shutdown(ctx)
+		}
+	}
+
+	if s.sourceMetrics != nil {
+		_ = s.sourceMetrics.Unregister()
+	}
+	if s.PrimitiveMgr != nil {
+		s.closeSources(s.PrimitiveMgr.GetSourcesMap())
+	}
+	if s.auditor != nil {
+		if err := s.auditor.Close(); err != nil {
+			s.logger.WarnContext(context.Background(), fmt.Sprintf("unable to close audit log: %s", err))
+		}
+	}
+
+	if drainErr != nil {
+		return drainErr
+	}
+	return srvErr
+}
+
+// closeSources closes the sources that hold resources such as connection
+// pools.
+func (s *Server) closeSources(sourcesMap map[string]sources.Source) {
+	names := make([]string, 0, len(sourcesMap))
+	for name := range sourcesMap {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		closer, ok := sourcesMap[name].(sources.Closer)
+		if !ok {
+			continue
+		}
+		if err := closer.Close(); err != nil {
+			s.logger.WarnContext(context.Background(), fmt.Sprintf("unable to close source %q: %s", name, err))
+		}
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/googleapis/mcp-toolbox/internal/testutils"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+// slowTool blocks its invocations until released or canceled.
+type slowTool struct {
+	testutils.MockTool
+	started chan struct{}
+	release chan struct{}
+}
+
+func (t slowTool) Invoke(ctx context.Context, _ tools.SourceProvider, _ parameters.ParamValues, _ tools.AccessToken) (any, util.ToolboxError) {
+	close(t.started)
+	select {
+	case <-t.release:
+		return "finished", nil
+	case <-ctx.Done():
+		return nil, util.NewClientServerError("invocation canceled", http.StatusInternalServerError, ctx.Err())
+	}
+}
+
+type invokeResult struct {
+	status int
+	body   string
+	err    error
+}
+
+func setUpSlowToolServer(t *testing.T) (*Server, slowTool, func(), func() <-chan invokeResult) {
+	tool := slowTool{
+		MockTool: testutils.NewMockTool("slow_tool", "a slow tool", nil, false, false),
+		started:  make(chan struct{}),
+		release:  make(chan struct{}),
+	}
+	toolsMap := map[string]tools.Tool{tool.Name: tool}
+	toolset, err := tools.ToolsetConfig{Name: "", ToolNames: []string{tool.Name}}.Initialize(testutils.MockVersionString, toolsMap)
+	if err != nil {
+		t.Fatalf("unable to initialize toolset: %s", err)
+	}
+
+	var s *Server
+	r, shutdown := setUpServer(t, "api", toolsMap, map[string]tools.Toolset{"": toolset}, nil, nil, func(srv *Server) { s = srv })
+	ts := runServer(r, false)
+
+	invoke := func() <-chan invokeResult {
+		ch := make(chan invokeResult, 1)
+		go func() {
+			resp, body, err := runRequest(ts, http.MethodPost, fmt.Sprintf("/tool/%s/invoke", tool.Name), bytes.NewBufferString(`{}`), nil)
+			if err != nil {
+				ch <- invokeResult{err: err}
+				return
+			}
+			ch <- invokeResult{status: resp.StatusCode, body: string(body)}
+		}()
+		return ch
+	}
+	cleanup := func() {
+		ts.Close()
+		shutdown()
+	}
+	return s, tool, cleanup, invoke
+}
+
+func waitForDraining(t *testing.T, s *Server) {
+	deadline := time.Now().Add(5 * time.Second)
+	for !s.invocations.isDraining() {
+		if time.Now().After(deadline) {
+			t.Fatalf("server did not start draining")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestShutdownDrainsInFlightInvocations(t *testing.T) {
+	s, tool, cleanup, invoke := setUpSlowToolServer(t)
+	defer cleanup()
+
+	inFlight := invoke()
+	<-tool.started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	shutdownErr := make(chan error, 1)
+	go func() { shutdownErr <- s.Shutdown(ctx) }()
+	waitForDraining(t, s)
+
+	// requests received after shutdown started are refused
+	refused := <-invoke()
+	if refused.err != nil {
+		t.Fatalf("unexpected error: %s", refused.err)
+	}
+	if refused.status != http.StatusServiceUnavailable {
+		t.Errorf("unexpected status for request during shutdown: got %d, want %d", refused.status, http.StatusServiceUnavailable)
+	}
+
+	select {
+	case err := <-shutdownErr:
+		t.Fatalf("shutdown returned before the invocation finished: %v", err)
+	default:
+	}
+
+	close(tool.release)
+	res := <-inFlight
+	if res.err != nil {
+		t.Fatalf("unexpected error: %s", res.err)
+	}
+	if res.status != http.StatusOK || !strings.Contains(res.body, "finished") {
+		t.Errorf("in-flight invocation did not finish: status %d, body %s", res.status, res.body)
+	}
+	if err := <-shutdownErr; err != nil {
+		t.Errorf("unexpected shutdown error: %s", err)
+	}
+}
+
+func TestShutdownCancelsInvocationsAfterTimeout(t *testing.T) {
+	s, tool, cleanup, invoke := setUpSlowToolServer(t)
+	defer cleanup()
+
+	inFlight := invoke()
+	<-tool.started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if err := s.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("unexpected shutdown error: got %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	res := <-inFlight
+	if res.err != nil {
+		t.Fatalf("unexpected error: %s", res.err)
+	}
+	if res.status == http.StatusOK {
+		t.Errorf("expected in-flight invocation to be canceled, got body %s", res.body)
+	}
+}
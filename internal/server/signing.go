@@ -0,0 +1,100 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/googleapis/mcp-toolbox/pkg/sdk"
+)
+
+// signedResponseWriter buffers a response so that its body can be signed
+// before it is sent. Event streams, whose body is never complete, are sent
+// as they are written, unsigned.
+type signedResponseWriter struct {
+	w      http.ResponseWriter
+	status int
+	body   bytes.Buffer
+	// stream is set once the response is found to be an event stream.
+	stream bool
+}
+
+func (w *signedResponseWriter) Header() http.Header { return w.w.Header() }
+
+func (w *signedResponseWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.stream {
+		return w.w.Write(b)
+	}
+	return w.body.Write(b)
+}
+
+func (w *signedResponseWriter) WriteHeader(status int) {
+	if w.status != 0 {
+		return
+	}
+	w.status = status
+	if strings.HasPrefix(w.w.Header().Get("Content-Type"), "text/event-stream") {
+		w.stream = true
+		w.w.WriteHeader(status)
+	}
+}
+
+// Flush sends the events written so far of an event stream. Other responses
+// are sent whole once signed.
+func (w *signedResponseWriter) Flush() {
+	if w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.stream {
+		_ = http.NewResponseController(w.w).Flush()
+	}
+}
+
+func (w *signedResponseWriter) Unwrap() http.ResponseWriter { return w.w }
+
+// signingMiddleware signs the bodies of responses with the response signing
+// key of the server, in the X-Toolbox-Signature header. Responses are then
+// buffered rather than streamed, except for event streams, which are not
+// signed.
+func signingMiddleware(s *Server) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if len(s.responseSigningKey) == 0 {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			sw := &signedResponseWriter{w: w}
+			next.ServeHTTP(sw, r)
+			if sw.stream {
+				return
+			}
+			if sw.status == 0 {
+				sw.status = http.StatusOK
+			}
+			body := sw.body.Bytes()
+			w.Header().Set(sdk.SignatureHeader, sdk.Sign(s.responseSigningKey, body))
+			w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+			w.WriteHeader(sw.status)
+			if _, err := w.Write(body); err != nil {
+				s.logger.ErrorContext(r.Context(), "unable to write signed response", "error", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/googleapis/mcp-toolbox/internal/testutils"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/pkg/sdk"
+)
+
+func TestResponseSigning(t *testing.T) {
+	key := []byte("my-signing-key")
+	toolsMap := map[string]tools.Tool{
+		"my_tool": testutils.NewMockTool("my_tool", "", nil, false, false),
+	}
+	toolset, err := tools.ToolsetConfig{Name: "", ToolNames: []string{"my_tool"}}.Initialize(testutils.MockVersionString, toolsMap)
+	if err != nil {
+		t.Fatalf("unable to initialize toolset: %s", err)
+	}
+	toolsets := map[string]tools.Toolset{"": toolset}
+
+	tcs := []struct {
+		desc    string
+		router  string
+		key     []byte
+		path    string
+		body    string
+		header  map[string]string
+		wantSig bool
+	}{
+		{desc: "api", router: "api", key: key, path: "/tool/my_tool/invoke", body: `{}`, wantSig: true},
+		{
+			desc:    "mcp",
+			router:  "mcp",
+			key:     key,
+			path:    "/",
+			body:    `{"jsonrpc": "2.0", "id": "call", "method": "tools/call", "params": {"name": "my_tool", "arguments": {}}}`,
+			header:  map[string]string{"Mcp-Protocol-Version": "2025-06-18"},
+			wantSig: true,
+		},
+		{desc: "unsigned", router: "api", path: "/tool/my_tool/invoke", body: `{}`},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			r, shutdown := setUpServer(t, tc.router, toolsMap, toolsets, nil, nil, func(s *Server) { s.responseSigningKey = tc.key })
+			defer shutdown()
+			ts := runServer(r, false)
+			defer ts.Close()
+
+			resp, body, err := runRequest(ts, http.MethodPost, tc.path, strings.NewReader(tc.body), tc.header)
+			if err != nil {
+				t.Fatalf("unexpected error during request: %s", err)
+			}
+			if resp.StatusCode != http.StatusOK {
+				t.Fatalf("unexpected status: %d, body: %s", resp.StatusCode, body)
+			}
+			sig := resp.Header.Get(sdk.SignatureHeader)
+			if !tc.wantSig {
+				if sig != "" {
+					t.Fatalf("unexpected signature %q", sig)
+				}
+				return
+			}
+			if !sdk.VerifySignature(key, body, sig) {
+				t.Errorf("signature %q does not match body %s", sig, body)
+			}
+		})
+	}
+}
+
+func TestResponseSigningEventStream(t *testing.T) {
+	s := &Server{responseSigningKey: []byte("my-signing-key")}
+	handler := signingMiddleware(s)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = w.Write([]byte("event: message\ndata: {}\n\n"))
+		if err := http.NewResponseController(w).Flush(); err != nil {
+			t.Errorf("unable to flush the event stream: %s", err)
+		}
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", nil))
+	if !rec.Flushed {
+		t.Errorf("expected the event stream to be flushed")
+	}
+	if sig := rec.Header().Get(sdk.SignatureHeader); sig != "" {
+		t.Errorf("unexpected signature %q of an event stream", sig)
+	}
+	if got, want := rec.Body.String(), "event: message\ndata: {}\n\n"; got != want {
+		t.Errorf("got body %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/googleapis/mcp-toolbox/internal/util"
+)
+
+// DefaultTrustedProxyHops is the number of trusted proxies in front of the
+// server, each appending to the X-Forwarded-For header of requests.
+const DefaultTrustedProxyHops = 1
+
+// sourceIP returns the address r was received from: the address added to
+// the X-Forwarded-For header by the trusted proxies if the server trusts
+// them, or else the remote address of the connection.
+func (s *Server) sourceIP(r *http.Request) string {
+	if s.trustProxy {
+		if ip := forwardedIP(r.Header, s.trustedProxyHops); ip != "" {
+			return ip
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// forwardedIP returns the entry of the X-Forwarded-For header hops entries
+// from its right. Each proxy appends the address it received the request
+// from, so entries further left are set by the client and can be spoofed.
+// The leftmost entry is returned if there are fewer entries than hops.
+func forwardedIP(header http.Header, hops int) string {
+	var entries []string
+	for _, v := range header.Values("X-Forwarded-For") {
+		for _, e := range strings.Split(v, ",") {
+			if e = strings.TrimSpace(e); e != "" {
+				entries = append(entries, e)
+			}
+		}
+	}
+	if len(entries) == 0 {
+		return ""
+	}
+	return entries[max(len(entries)-max(hops, 1), 0)]
+}
+
+// sourceIPMiddleware adds the source address of requests into their context,
+// where the allowed CIDR blocks of tools are checked against it.
+func sourceIPMiddleware(s *Server) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := util.WithSourceIP(r.Context(), s.sourceIP(r))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSourceIP(t *testing.T) {
+	tcs := []struct {
+		desc       string
+		trustProxy bool
+		hops       int
+		xff        []string
+		want       string
+	}{
+		{
+			desc: "proxy not trusted",
+			xff:  []string{"203.0.113.7"},
+			want: "192.0.2.1",
+		},
+		{
+			desc:       "address appended by the proxy",
+			trustProxy: true,
+			hops:       1,
+			xff:        []string{"203.0.113.7"},
+			want:       "203.0.113.7",
+		},
+		{
+			desc:       "spoofed leftmost entry",
+			trustProxy: true,
+			hops:       1,
+			xff:        []string{"10.0.0.1, 203.0.113.7"},
+			want:       "203.0.113.7",
+		},
+		{
+			desc:       "spoofed leftmost header",
+			trustProxy: true,
+			hops:       1,
+			xff:        []string{"10.0.0.1", "203.0.113.7"},
+			want:       "203.0.113.7",
+		},
+		{
+			desc:       "two trusted proxies",
+			trustProxy: true,
+			hops:       2,
+			xff:        []string{"10.0.0.1, 203.0.113.7, 198.51.100.2"},
+			want:       "203.0.113.7",
+		},
+		{
+			desc:       "fewer entries than trusted proxies",
+			trustProxy: true,
+			hops:       3,
+			xff:        []string{"203.0.113.7, 198.51.100.2"},
+			want:       "203.0.113.7",
+		},
+		{
+			desc:       "no header",
+			trustProxy: true,
+			hops:       1,
+			want:       "192.0.2.1",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			s := &Server{trustProxy: tc.trustProxy, trustedProxyHops: tc.hops}
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.RemoteAddr = "192.0.2.1:1234"
+			for _, v := range tc.xff {
+				r.Header.Add("X-Forwarded-For", v)
+			}
+			if got := s.sourceIP(r); got != tc.want {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
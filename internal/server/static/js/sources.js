@@ -0,0 +1,148 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import { escapeHtml } from "./sanitize.js";
+
+let currentSources = {};
+
+/**
+ * These functions runs after the browser finishes loading and parsing HTML structure.
+ * This ensures that elements can be safely accessed.
+ */
+document.addEventListener('DOMContentLoaded', () => {
+    const sourceDisplayArea = document.getElementById('source-display-area');
+    const secondaryPanelContent = document.getElementById('secondary-panel-content');
+
+    if (!secondaryPanelContent || !sourceDisplayArea) {
+        console.error('Required DOM elements not found.');
+        return;
+    }
+
+    loadSources(secondaryPanelContent, sourceDisplayArea);
+});
+
+/**
+ * Fetches the sources and their status from the /health/sources endpoint and
+ * initiates creating the source list.
+ * @param {!HTMLElement} secondNavContent The HTML element where the source list will be rendered.
+ * @param {!HTMLElement} sourceDisplayArea The HTML element where the details of a selected source will be displayed.
+ * @returns {!Promise<void>} A promise that resolves when the sources are loaded and rendered.
+ */
+async function loadSources(secondNavContent, sourceDisplayArea) {
+    secondNavContent.innerHTML = '<p>Fetching sources...</p>';
+    try {
+        const response = await fetch('/health/sources');
+        // a degraded source responds with 503, but still lists the sources
+        if (!response.ok && response.status !== 503) {
+            throw new Error(`HTTP error! status: ${response.status}`);
+        }
+        const apiResponse = await response.json();
+        renderSourceList(apiResponse, secondNavContent, sourceDisplayArea);
+    } catch (error) {
+        console.error('Failed to load sources:', error);
+        secondNavContent.innerHTML = `<p class="error">Failed to load sources: <pre><code>${escapeHtml(String(error))}</code></pre></p>`;
+    }
+}
+
+/**
+ * Renders the list of sources as buttons within the provided HTML element.
+ * @param {Object} apiResponse The response of the /health/sources endpoint.
+ * @param {!HTMLElement} secondNavContent The HTML element to render the source list into.
+ * @param {!HTMLElement} sourceDisplayArea The HTML element for displaying source details.
+ */
+function renderSourceList(apiResponse, secondNavContent, sourceDisplayArea) {
+    secondNavContent.innerHTML = '';
+
+    if (!apiResponse || typeof apiResponse.sources !== 'object') {
+        console.error('Error: Expected a response with a "sources" object, but received:', apiResponse);
+        secondNavContent.textContent = 'Error: Invalid response format from sources API.';
+        return;
+    }
+
+    currentSources = apiResponse.sources;
+    const names = Object.keys(currentSources).sort();
+    if (names.length === 0) {
+        secondNavContent.textContent = 'No sources found.';
+        return;
+    }
+
+    const ul = document.createElement('ul');
+    names.forEach(name => {
+        const li = document.createElement('li');
+        const button = document.createElement('button');
+        button.textContent = name;
+        button.dataset.sourcename = name;
+        button.classList.add('tool-button');
+        button.addEventListener('click', (event) => handleSourceClick(event, secondNavContent, sourceDisplayArea));
+        li.appendChild(button);
+        ul.appendChild(li);
+    });
+    secondNavContent.appendChild(ul);
+}
+
+/**
+ * Handles the click event on a source button.
+ * @param {!Event} event The click event object.
+ * @param {!HTMLElement} secondNavContent The parent element containing the source buttons.
+ * @param {!HTMLElement} sourceDisplayArea The HTML element where source details will be shown.
+ */
+function handleSourceClick(event, secondNavContent, sourceDisplayArea) {
+    const sourceName = event.target.dataset.sourcename;
+    if (!sourceName) {
+        return;
+    }
+    const currentActive = secondNavContent.querySelector('.tool-button.active');
+    if (currentActive) {
+        currentActive.classList.remove('active');
+    }
+    event.target.classList.add('active');
+    renderSourceDetails(sourceName, sourceDisplayArea);
+}
+
+/**
+ * Renders the type, status and connection pool of a source.
+ * @param {string} sourceName The name of the source to render details for.
+ * @param {!HTMLElement} sourceDisplayArea The HTML element to display the details in.
+ */
+function renderSourceDetails(sourceName, sourceDisplayArea) {
+    const source = currentSources[sourceName];
+    if (!source) {
+        sourceDisplayArea.innerHTML = `<p class="error">Source "${escapeHtml(sourceName)}" data not found.</p>`;
+        return;
+    }
+
+    const rows = [
+        ['Type', source.type],
+        ['Status', source.status],
+    ];
+    if (source.error) {
+        rows.push(['Error', source.error]);
+    }
+    if (source.pingLatencyMs !== undefined) {
+        rows.push(['Ping latency', `${source.pingLatencyMs.toFixed(1)} ms`]);
+    }
+    if (source.pool) {
+        rows.push(['Connections', `${source.pool.acquired} in use, ${source.pool.idle} idle, ${source.pool.total} open of ${source.pool.max}`]);
+        rows.push(['Acquires', `${source.pool.acquires} (${source.pool.canceledAcquires} canceled)`]);
+    }
+
+    sourceDisplayArea.innerHTML = `
+      <div class="resource-instructions">
+        <h1 class="resource-title">${escapeHtml(sourceName)}</h1>
+        <table class="source-details">
+          ${rows.map(([label, value]) => `<tr><th>${escapeHtml(label)}</th><td>${escapeHtml(value)}</td></tr>`).join('')}
+        </table>
+      </div>
+    `;
+}
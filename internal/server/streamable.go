@@ -0,0 +1,413 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"github.com/googleapis/mcp-toolbox/internal/server/mcp/jsonrpc"
+)
+
+const (
+	// mcpSessionHeader carries the id of a streamable HTTP session.
+	mcpSessionHeader = "Mcp-Session-Id"
+	// lastEventIDHeader carries the id of the last event a client received
+	// when it resumes a stream.
+	lastEventIDHeader = "Last-Event-ID"
+	// maxSessionEvents bounds the events kept per session for resumption.
+	maxSessionEvents = 256
+	// streamableSessionTimeout is the idle time after which a session is
+	// reclaimed.
+	streamableSessionTimeout = 10 * time.Minute
+)
+
+// streamEvent is an SSE event of a streamable HTTP session.
+type streamEvent struct {
+	id   uint64
+	data []byte
+}
+
+// write writes the event to w. Priming events have no data.
+func (e streamEvent) write(w http.ResponseWriter) error {
+	if _, err := fmt.Fprintf(w, "id: %d\ndata: %s\n\n", e.id, e.data); err != nil {
+		return err
+	}
+	if f, ok := w.(http.Flusher); ok {
+		f.Flush()
+	}
+	return nil
+}
+
+// streamableSession is a session of the streamable HTTP transport. It keeps
+// the last events sent to the client so that a client reconnecting with
+// Last-Event-ID receives the responses it missed.
+type streamableSession struct {
+	id              string
+	toolset         string
+	protocolVersion string
+
+	mu         sync.Mutex
+	lastActive time.Time
+	lastID     uint64
+	events     []streamEvent
+	// listener receives the events that could not be delivered on the
+	// stream of their request, while a GET stream is open.
+	listener chan streamEvent
+	closed   chan struct{}
+}
+
+// nextID reserves the id of an event.
+func (s *streamableSession) nextID() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastID++
+	return s.lastID
+}
+
+// publish records an event with data, and delivers it with deliver. The
+// events deliver fails to write are forwarded to the open GET stream, if any.
+func (s *streamableSession) publish(data []byte, deliver func(streamEvent) error) {
+	s.mu.Lock()
+	s.lastID++
+	e := streamEvent{id: s.lastID, data: data}
+	s.events = append(s.events, e)
+	if len(s.events) > maxSessionEvents {
+		s.events = s.events[len(s.events)-maxSessionEvents:]
+	}
+	s.mu.Unlock()
+
+	if deliver != nil && deliver(e) == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.listener == nil {
+		return
+	}
+	select {
+	case s.listener <- e:
+	default:
+		// the event stays available for resumption
+	}
+}
+
+// listen opens the GET stream of the session, replacing the previous one,
+// and returns the recorded events after lastID.
+func (s *streamableSession) listen(lastID uint64) (chan streamEvent, []streamEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.listener != nil {
+		close(s.listener)
+	}
+	s.listener = make(chan streamEvent, maxSessionEvents)
+	var replay []streamEvent
+	for _, e := range s.events {
+		if e.id > lastID {
+			replay = append(replay, e)
+		}
+	}
+	return s.listener, replay
+}
+
+// unlisten closes the GET stream of the session if it is still ch.
+func (s *streamableSession) unlisten(ch chan streamEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.listener == ch {
+		close(s.listener)
+		s.listener = nil
+	}
+}
+
+// streamableManager tracks the sessions of the streamable HTTP transport.
+type streamableManager struct {
+	mu       sync.Mutex
+	sessions map[string]*streamableSession
+	// closing is closed when the server shuts down to end the GET streams.
+	closing   chan struct{}
+	closeOnce sync.Once
+}
+
+func newStreamableManager(ctx context.Context) *streamableManager {
+	m := &streamableManager{
+		sessions: make(map[string]*streamableSession),
+		closing:  make(chan struct{}),
+	}
+	go m.cleanupRoutine(ctx)
+	return m
+}
+
+// create starts a session bound to a toolset.
+func (m *streamableManager) create(toolset, protocolVersion string) *streamableSession {
+	s := &streamableSession{
+		id:              uuid.New().String(),
+		toolset:         toolset,
+		protocolVersion: protocolVersion,
+		lastActive:      time.Now(),
+		closed:          make(chan struct{}),
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sessions[s.id] = s
+	return s
+}
+
+func (m *streamableManager) get(id string) (*streamableSession, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, ok := m.sessions[id]
+	if !ok {
+		return nil, false
+	}
+	s.mu.Lock()
+	s.lastActive = time.Now()
+	s.mu.Unlock()
+	return s, true
+}
+
+// remove terminates a session, ending its GET stream.
+func (m *streamableManager) remove(id string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, ok := m.sessions[id]
+	if !ok {
+		return false
+	}
+	delete(m.sessions, id)
+	close(s.closed)
+	return true
+}
+
+// closeAll ends the GET streams of all sessions.
+func (m *streamableManager) closeAll() {
+	m.closeOnce.Do(func() { close(m.closing) })
+}
+
+// cleanupRoutine reclaims the sessions idle for streamableSessionTimeout.
+// Sessions with an open GET stream are not idle.
+func (m *streamableManager) cleanupRoutine(ctx context.Context) {
+	ticker := time.NewTicker(streamableSessionTimeout / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.reap(time.Now())
+		}
+	}
+}
+
+func (m *streamableManager) reap(now time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for id, s := range m.sessions {
+		s.mu.Lock()
+		idle := s.listener == nil && now.Sub(s.lastActive) > streamableSessionTimeout
+		s.mu.Unlock()
+		if idle {
+			delete(m.sessions, id)
+			close(s.closed)
+		}
+	}
+}
+
+// peekRequest returns the method of a JSON-RPC message, and whether it is a
+// request expecting a response rather than a notification.
+func peekRequest(body []byte) (string, bool) {
+	var req struct {
+		Id     jsonrpc.RequestId `json:"id"`
+		Method string            `json:"method"`
+	}
+	if err := json.Unmarshal(body, &req); err != nil {
+		return "", false
+	}
+	return req.Method, req.Id != nil
+}
+
+// acceptsEventStream reports whether the client accepts SSE responses.
+func acceptsEventStream(r *http.Request) bool {
+	for _, accept := range r.Header.Values("Accept") {
+		for _, t := range strings.Split(accept, ",") {
+			if strings.TrimSpace(strings.SplitN(t, ";", 2)[0]) == "text/event-stream" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// sessionNotFound responds to a request for an unknown or terminated session.
+// Clients re-initialize on a 404.
+func sessionNotFound(s *Server, w http.ResponseWriter, r *http.Request, id string) {
+	err := fmt.Errorf("session %q not found", id)
+	s.logger.DebugContext(r.Context(), err.Error())
+	render.Status(r, http.StatusNotFound)
+	render.JSON(w, r, jsonrpc.NewError(nil, jsonrpc.INVALID_REQUEST, err.Error(), nil))
+}
+
+// streamableGetHandler opens the SSE stream of a session, on which the
+// server sends the events the client missed after Last-Event-ID and the
+// responses that could not be delivered on the stream of their request.
+func streamableGetHandler(s *Server, w http.ResponseWriter, r *http.Request) {
+	id := r.Header.Get(mcpSessionHeader)
+	if id == "" || s.streamable == nil {
+		methodNotAllowed(s, w, r)
+		return
+	}
+	session, ok := s.streamable.get(id)
+	if !ok {
+		sessionNotFound(s, w, r, id)
+		return
+	}
+	var lastID uint64
+	if v := r.Header.Get(lastEventIDHeader); v != "" {
+		var err error
+		if lastID, err = strconv.ParseUint(v, 10, 64); err != nil {
+			err = fmt.Errorf("invalid %s header %q", lastEventIDHeader, v)
+			_ = render.Render(w, r, newErrResponse(err, http.StatusBadRequest))
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set(mcpSessionHeader, id)
+	w.WriteHeader(http.StatusOK)
+
+	events, replay := session.listen(lastID)
+	defer session.unlisten(events)
+	s.logger.DebugContext(r.Context(), fmt.Sprintf("resuming session %s after event %d with %d events", id, lastID, len(replay)))
+	for _, e := range replay {
+		if err := e.write(w); err != nil {
+			return
+		}
+	}
+	if f, ok := w.(http.Flusher); ok {
+		f.Flush()
+	}
+	for {
+		select {
+		case e, ok := <-events:
+			if !ok {
+				// replaced by a newer stream of the session
+				return
+			}
+			if err := e.write(w); err != nil {
+				return
+			}
+		case <-r.Context().Done():
+			return
+		case <-session.closed:
+			return
+		case <-s.streamable.closing:
+			fmt.Fprint(w, "event: close\ndata: server shutting down\n\n")
+			if f, ok := w.(http.Flusher); ok {
+				f.Flush()
+			}
+			return
+		}
+	}
+}
+
+// streamableDeleteHandler terminates a session.
+func streamableDeleteHandler(s *Server, w http.ResponseWriter, r *http.Request) {
+	id := r.Header.Get(mcpSessionHeader)
+	if id == "" || s.streamable == nil {
+		return
+	}
+	if !s.streamable.remove(id) {
+		sessionNotFound(s, w, r, id)
+		return
+	}
+	s.logger.DebugContext(r.Context(), fmt.Sprintf("terminated session %s", id))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// sseResponseWriter writes the events of a POST request answered with an SSE
+// stream. Writes fail once the client disconnected or the request ended.
+type sseResponseWriter struct {
+	mu       sync.Mutex
+	w        http.ResponseWriter
+	done     <-chan struct{}
+	finished bool
+}
+
+func (s *sseResponseWriter) write(e streamEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.finished {
+		return fmt.Errorf("request ended")
+	}
+	select {
+	case <-s.done:
+		return fmt.Errorf("client disconnected")
+	default:
+	}
+	return e.write(s.w)
+}
+
+func (s *sseResponseWriter) finish() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.finished = true
+}
+
+// streamResponse answers a request of a session with an SSE stream. The
+// request is processed to completion even if the client disconnects, and
+// its response is recorded so that the client can resume the stream with
+// Last-Event-ID after reconnecting.
+func streamResponse(ctx context.Context, s *Server, w http.ResponseWriter, r *http.Request, stream *streamableSession, body []byte, protocolVersion, toolsetName, promptsetName, networkProtocolVersion string) {
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set(mcpSessionHeader, stream.id)
+	w.WriteHeader(http.StatusOK)
+
+	out := &sseResponseWriter{w: w, done: r.Context().Done()}
+	defer out.finish()
+	// prime the client with an event id to resume from
+	_ = out.write(streamEvent{id: stream.nextID()})
+
+	ctx = context.WithoutCancel(ctx)
+	ctx = withProgressNotifications(ctx, func(notification any) {
+		data, err := json.Marshal(notification)
+		if err != nil {
+			return
+		}
+		stream.publish(data, out.write)
+	})
+	_, res, err := processMcpMessage(ctx, body, s, protocolVersion, toolsetName, promptsetName, r.Header, networkProtocolVersion)
+	if err != nil {
+		s.logger.DebugContext(ctx, fmt.Errorf("error processing message: %w", err).Error())
+	}
+	if res == nil {
+		return
+	}
+	data, err := json.Marshal(res)
+	if err != nil {
+		s.logger.DebugContext(ctx, fmt.Sprintf("unable to marshal response: %s", err))
+		return
+	}
+	stream.publish(data, out.write)
+}
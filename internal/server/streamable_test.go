@@ -0,0 +1,181 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/googleapis/mcp-toolbox/internal/testutils"
+)
+
+// readEvents reads n SSE events from the body, returning their ids and data.
+func readEvents(t *testing.T, resp *http.Response, n int) ([]string, []string) {
+	t.Helper()
+	var ids, data []string
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 1<<20), 1<<20)
+	var id string
+	for len(data) < n && scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "id: "):
+			id = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "data: ") || line == "data:":
+			ids = append(ids, id)
+			data = append(data, strings.TrimSpace(strings.TrimPrefix(line, "data:")))
+		}
+	}
+	if len(data) < n {
+		t.Fatalf("got %d events, want %d: %v", len(data), n, scanner.Err())
+	}
+	return ids, data
+}
+
+func TestStreamableSessions(t *testing.T) {
+	mockTools := []testutils.MockTool{testutils.MockTool1, testutils.MockTool2}
+	toolsMap, toolsets, _, _ := testutils.SetUpResources(t, mockTools, nil)
+	r, shutdown := setUpServer(t, "mcp", toolsMap, toolsets, nil, nil)
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	initialize := `{"jsonrpc": "2.0", "id": "init", "method": "initialize", "params": {"protocolVersion": "2025-06-18"}}`
+	resp, body, err := runRequest(ts, http.MethodPost, "/tool1_only", strings.NewReader(initialize), nil)
+	if err != nil {
+		t.Fatalf("unexpected error during request: %s", err)
+	}
+	sessionID := resp.Header.Get(mcpSessionHeader)
+	if resp.StatusCode != http.StatusOK || sessionID == "" {
+		t.Fatalf("expected a session, got status %d and header %q: %s", resp.StatusCode, sessionID, body)
+	}
+	header := map[string]string{mcpSessionHeader: sessionID, "Mcp-Protocol-Version": "2025-06-18"}
+
+	// requests without a toolset use the toolset of the session
+	listTools := `{"jsonrpc": "2.0", "id": "list", "method": "tools/list"}`
+	_, body, err = runRequest(ts, http.MethodPost, "/", strings.NewReader(listTools), header)
+	if err != nil {
+		t.Fatalf("unexpected error during request: %s", err)
+	}
+	if !strings.Contains(string(body), testutils.MockTool1.Name) || strings.Contains(string(body), testutils.MockTool2.Name) {
+		t.Fatalf("expected only the tools of the session toolset: %s", body)
+	}
+
+	// responses are streamed to clients accepting SSE, after a priming event
+	streamHeader := map[string]string{"Accept": "application/json, text/event-stream"}
+	for k, v := range header {
+		streamHeader[k] = v
+	}
+	resp, err = streamRequest(ts.URL+"/", listTools, streamHeader)
+	if err != nil {
+		t.Fatalf("unexpected error during request: %s", err)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("got content type %q, want text/event-stream", ct)
+	}
+	ids, data := readEvents(t, resp, 2)
+	resp.Body.Close()
+	if data[0] != "" || !strings.Contains(data[1], `"id":"list"`) {
+		t.Fatalf("unexpected events: %q", data)
+	}
+
+	// a client resuming after the priming event receives the response again
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL+"/", nil)
+	if err != nil {
+		t.Fatalf("unable to create request: %s", err)
+	}
+	req.Header.Set(mcpSessionHeader, sessionID)
+	req.Header.Set(lastEventIDHeader, ids[0])
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error during request: %s", err)
+	}
+	resumedIDs, resumed := readEvents(t, resp, 1)
+	cancel()
+	resp.Body.Close()
+	if resumedIDs[0] != ids[1] || resumed[0] != data[1] {
+		t.Fatalf("got resumed event %s %q, want %s %q", resumedIDs[0], resumed[0], ids[1], data[1])
+	}
+
+	// terminated sessions are not found
+	resp, _, err = runRequest(ts, http.MethodDelete, "/", nil, header)
+	if err != nil || resp.StatusCode != http.StatusNoContent {
+		t.Fatalf("unexpected response to delete: %v, %v", resp, err)
+	}
+	resp, _, err = runRequest(ts, http.MethodPost, "/", strings.NewReader(listTools), header)
+	if err != nil || resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("unexpected response for a terminated session: %v, %v", resp, err)
+	}
+}
+
+func streamRequest(url, body string, header map[string]string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodPost, url, strings.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	return http.DefaultClient.Do(req)
+}
+
+func TestStreamableSessionPublish(t *testing.T) {
+	m := &streamableManager{sessions: make(map[string]*streamableSession), closing: make(chan struct{})}
+	s := m.create("", "2025-06-18")
+
+	// events delivered on their request are not forwarded
+	events, _ := s.listen(0)
+	s.publish([]byte("delivered"), func(streamEvent) error { return nil })
+	s.publish([]byte("undelivered"), func(streamEvent) error { return errors.New("client disconnected") })
+	select {
+	case e := <-events:
+		if string(e.data) != "undelivered" {
+			t.Fatalf("got forwarded event %q, want %q", e.data, "undelivered")
+		}
+	default:
+		t.Fatalf("expected the undelivered event to be forwarded")
+	}
+
+	// a new stream replaces the previous one and replays the events
+	_, replay := s.listen(1)
+	if len(replay) != 1 || string(replay[0].data) != "undelivered" {
+		t.Fatalf("unexpected replay: %v", replay)
+	}
+	if _, ok := <-events; ok {
+		t.Fatalf("expected the replaced stream to be closed")
+	}
+
+	for range maxSessionEvents + 10 {
+		s.publish([]byte("x"), nil)
+	}
+	if len(s.events) != maxSessionEvents {
+		t.Fatalf("got %d recorded events, want %d", len(s.events), maxSessionEvents)
+	}
+
+	// idle sessions without a stream are reclaimed
+	s.unlisten(s.listener)
+	m.reap(time.Now().Add(2 * streamableSessionTimeout))
+	if _, ok := m.get(s.id); ok {
+		t.Fatalf("expected the idle session to be reclaimed")
+	}
+}
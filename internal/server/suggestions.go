@@ -0,0 +1,225 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/orderedmap"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+const (
+	// suggestionsQueryParam is the parameter passed to suggestions tools
+	// with the prefix typed so far.
+	suggestionsQueryParam = "q"
+	// maxSuggestions is the number of suggestions returned.
+	maxSuggestions = 10
+	// suggestionsTTL is how long suggestions are cached.
+	suggestionsTTL = 30 * time.Second
+	// maxCachedSuggestions bounds the number of cached suggestion lists.
+	maxCachedSuggestions = 1000
+)
+
+// suggestionsResponse is the body of suggestion responses.
+type suggestionsResponse struct {
+	Suggestions []string `json:"suggestions"`
+}
+
+type cachedSuggestions struct {
+	values  []string
+	expires time.Time
+}
+
+// suggestionCache caches the suggestions of parameters for suggestionsTTL.
+type suggestionCache struct {
+	mu      sync.Mutex
+	entries map[string]cachedSuggestions
+}
+
+func (c *suggestionCache) get(key string, now time.Time) ([]string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok || now.After(e.expires) {
+		return nil, false
+	}
+	return e.values, true
+}
+
+func (c *suggestionCache) put(key string, values []string, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.entries == nil {
+		c.entries = make(map[string]cachedSuggestions)
+	}
+	if len(c.entries) >= maxCachedSuggestions {
+		for k, e := range c.entries {
+			if now.After(e.expires) {
+				delete(c.entries, k)
+			}
+		}
+		if len(c.entries) >= maxCachedSuggestions {
+			clear(c.entries)
+		}
+	}
+	c.entries[key] = cachedSuggestions{values: values, expires: now.Add(suggestionsTTL)}
+}
+
+// suggestionsHandler returns the suggested values of a tool parameter that
+// start with the q query parameter, listed by the suggestionsTool of the
+// parameter.
+func suggestionsHandler(s *Server, w http.ResponseWriter, r *http.Request) {
+	ctx := util.WithLogger(r.Context(), s.logger)
+	toolName := chi.URLParam(r, "toolName")
+	paramName := chi.URLParam(r, "paramName")
+	q := r.URL.Query().Get(suggestionsQueryParam)
+
+	tool, ok := s.PrimitiveMgr.GetTool(toolName)
+	if !ok {
+		err := fmt.Errorf("invalid tool name: tool with name %q does not exist", toolName)
+		_ = render.Render(w, r, newErrResponse(err, http.StatusNotFound))
+		return
+	}
+	if accessErr := s.checkToolAccess(ctx, r.Header, toolName); accessErr != nil {
+		_ = render.Render(w, r, newErrResponse(accessErr, accessErr.Code))
+		return
+	}
+	toolParams, err := tool.GetParameters(s.PrimitiveMgr.GetSourcesMap())
+	if err != nil {
+		err = fmt.Errorf("error getting parameters for tool: %w", err)
+		_ = render.Render(w, r, newErrResponse(err, http.StatusInternalServerError))
+		return
+	}
+	i := slices.IndexFunc(toolParams, func(p parameters.Parameter) bool { return p.GetName() == paramName })
+	if i < 0 || toolParams[i].GetSuggestions() == nil {
+		err := fmt.Errorf("tool %q has no parameter %q with suggestions", toolName, paramName)
+		_ = render.Render(w, r, newErrResponse(err, http.StatusNotFound))
+		return
+	}
+
+	key := toolName + "\x00" + paramName + "\x00" + q
+	if values, ok := s.suggestions.get(key, time.Now()); ok {
+		render.JSON(w, r, suggestionsResponse{Suggestions: values})
+		return
+	}
+	values, err := s.listSuggestions(ctx, toolParams[i].GetSuggestions().Tool, q)
+	if err != nil {
+		err = fmt.Errorf("unable to list suggestions of parameter %q: %w", paramName, err)
+		s.logger.DebugContext(ctx, err.Error())
+		_ = render.Render(w, r, newErrResponse(err, http.StatusInternalServerError))
+		return
+	}
+	s.suggestions.put(key, values, time.Now())
+	render.JSON(w, r, suggestionsResponse{Suggestions: values})
+}
+
+// listSuggestions invokes the suggestions tool with q, and returns up to
+// maxSuggestions of the distinct values it lists that start with q. The
+// suggestions tool cannot require authentication, as the request is not
+// authenticated.
+func (s *Server) listSuggestions(ctx context.Context, toolName, q string) ([]string, error) {
+	tool, ok := s.PrimitiveMgr.GetTool(toolName)
+	if !ok {
+		return nil, fmt.Errorf("suggestions tool %q does not exist", toolName)
+	}
+	if disabledErr := s.PrimitiveMgr.CheckToolEnabled(toolName); disabledErr != nil {
+		return nil, disabledErr
+	}
+	clientAuth, err := tool.RequiresClientAuthorization(s.PrimitiveMgr)
+	if err != nil {
+		return nil, err
+	}
+	if clientAuth || len(tool.GetAuthRequired()) > 0 {
+		return nil, fmt.Errorf("suggestions tool %q cannot require authentication", toolName)
+	}
+	toolParams, err := tool.GetParameters(s.PrimitiveMgr.GetSourcesMap())
+	if err != nil {
+		return nil, err
+	}
+	params, err := parameters.ParseParams(toolParams, map[string]any{suggestionsQueryParam: q}, nil)
+	if err != nil {
+		return nil, err
+	}
+	params, err = tool.EmbedParams(ctx, params, s.PrimitiveMgr.GetEmbeddingModelMap())
+	if err != nil {
+		return nil, err
+	}
+	ctx = util.WithInvocationCost(ctx, &util.InvocationCost{})
+	ctx = util.WithAuthServiceClaims(ctx, make(map[string]map[string]any))
+	executionStart := time.Now()
+	res, tbErr := tool.Invoke(ctx, s.PrimitiveMgr, params, "")
+	usageRecorder{s: s, toolset: directToolset}.RecordInvocation(ctx, toolName, res, tbErr, time.Since(executionStart).Seconds())
+	if tbErr != nil {
+		return nil, tbErr
+	}
+	return matchSuggestions(res, q), nil
+}
+
+// matchSuggestions returns up to maxSuggestions of the distinct values of
+// res that start with q, ignoring case. res is a list of values, or of rows
+// whose first column is the value.
+func matchSuggestions(res any, q string) []string {
+	items, _ := res.([]any)
+	prefix := strings.ToLower(q)
+	values := []string{}
+	for _, item := range items {
+		v, ok := suggestionValue(item)
+		if !ok || !strings.HasPrefix(strings.ToLower(v), prefix) || slices.Contains(values, v) {
+			continue
+		}
+		values = append(values, v)
+		if len(values) == maxSuggestions {
+			break
+		}
+	}
+	return values
+}
+
+func suggestionValue(item any) (string, bool) {
+	switch v := item.(type) {
+	case nil:
+		return "", false
+	case orderedmap.Row:
+		if len(v.Columns) == 0 || v.Columns[0].Value == nil {
+			return "", false
+		}
+		return fmt.Sprint(v.Columns[0].Value), true
+	case map[string]any:
+		// rows decoded from JSON lose the order of their columns, so
+		// only rows of a single column have an unambiguous value
+		if len(v) != 1 {
+			return "", false
+		}
+		for _, value := range v {
+			if value == nil {
+				return "", false
+			}
+			return fmt.Sprint(value), true
+		}
+		return "", false
+	default:
+		return fmt.Sprint(v), true
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/internal/testutils"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/orderedmap"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+// listTool is a mock tool listing rows of ids, counting its invocations.
+type listTool struct {
+	testutils.MockTool
+	ids   []string
+	calls *int
+}
+
+func (t listTool) Invoke(context.Context, tools.SourceProvider, parameters.ParamValues, tools.AccessToken) (any, util.ToolboxError) {
+	*t.calls++
+	rows := []any{}
+	for _, id := range t.ids {
+		row := orderedmap.Row{}
+		row.Add("id", id)
+		row.Add("name", "Customer "+id)
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func TestSuggestionsHandler(t *testing.T) {
+	customerID := parameters.NewStringParameter("customer_id", "id of the customer")
+	customerID.Suggestions = &parameters.ParamSuggestions{Tool: "list_customers"}
+	getOrders := testutils.NewMockTool("get_orders", "Get orders", parameters.Parameters{customerID, parameters.NewStringParameter("status", "status")}, false, false)
+
+	q := parameters.NewStringParameter("q", "prefix")
+	var calls int
+	listCustomers := listTool{
+		MockTool: testutils.NewMockTool("list_customers", "List customers", parameters.Parameters{q}, false, false),
+		ids:      []string{"ACME-1", "acme-2", "beta-1", "acme-2", "acme-3"},
+		calls:    &calls,
+	}
+	toolsMap := map[string]tools.Tool{"get_orders": getOrders, "list_customers": listCustomers}
+	toolset, err := tools.ToolsetConfig{Name: "", ToolNames: []string{"get_orders", "list_customers"}}.Initialize(testutils.MockVersionString, toolsMap)
+	if err != nil {
+		t.Fatalf("unable to initialize toolset: %s", err)
+	}
+	r, shutdown := setUpServer(t, "api", toolsMap, map[string]tools.Toolset{"": toolset}, nil, nil)
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	tcs := []struct {
+		desc       string
+		path       string
+		wantStatus int
+		want       []string
+	}{
+		{desc: "prefix", path: "/tools/get_orders/params/customer_id/suggestions?q=acme", wantStatus: http.StatusOK, want: []string{"ACME-1", "acme-2", "acme-3"}},
+		{desc: "no prefix", path: "/tools/get_orders/params/customer_id/suggestions", wantStatus: http.StatusOK, want: []string{"ACME-1", "acme-2", "beta-1", "acme-3"}},
+		{desc: "no match", path: "/tools/get_orders/params/customer_id/suggestions?q=zeta", wantStatus: http.StatusOK, want: []string{}},
+		{desc: "parameter without suggestions", path: "/tools/get_orders/params/status/suggestions", wantStatus: http.StatusNotFound},
+		{desc: "unknown parameter", path: "/tools/get_orders/params/missing/suggestions", wantStatus: http.StatusNotFound},
+		{desc: "unknown tool", path: "/tools/missing/params/customer_id/suggestions", wantStatus: http.StatusNotFound},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			resp, body, err := runRequest(ts, http.MethodGet, tc.path, nil, nil)
+			if err != nil {
+				t.Fatalf("unexpected error during request: %s", err)
+			}
+			if resp.StatusCode != tc.wantStatus {
+				t.Fatalf("unexpected status: got %d, want %d, body: %s", resp.StatusCode, tc.wantStatus, body)
+			}
+			if tc.wantStatus != http.StatusOK {
+				return
+			}
+			var got suggestionsResponse
+			if err := json.Unmarshal(body, &got); err != nil {
+				t.Fatalf("unable to decode response: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Suggestions); diff != "" {
+				t.Errorf("unexpected suggestions (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	before := calls
+	if _, _, err := runRequest(ts, http.MethodGet, "/tools/get_orders/params/customer_id/suggestions?q=acme", nil, nil); err != nil {
+		t.Fatalf("unexpected error during request: %s", err)
+	}
+	if calls != before {
+		t.Errorf("expected cached suggestions not to invoke the suggestions tool")
+	}
+}
+
+func TestMatchSuggestions(t *testing.T) {
+	res := []any{}
+	for i := range 15 {
+		res = append(res, map[string]any{"id": i})
+	}
+	res = append(res, nil, map[string]any{"id": 1, "name": "ambiguous"})
+	got := matchSuggestions(res, "1")
+	want := []string{"1", "10", "11", "12", "13", "14"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected suggestions (-want +got):\n%s", diff)
+	}
+	if got := matchSuggestions(res, ""); len(got) != maxSuggestions {
+		t.Errorf("expected %d suggestions, got %d", maxSuggestions, len(got))
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"crypto/fips140"
+	"crypto/tls"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// fipsCipherSuites are the FIPS 140 approved cipher suites of TLS 1.2.
+var fipsCipherSuites = []uint16{
+	tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+	tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+}
+
+// tlsOptions restrict the TLS connections of the server.
+type tlsOptions struct {
+	cipherSuites []uint16
+	maxVersion   uint16
+	curves       []tls.CurveID
+}
+
+// newTLSOptions resolves the names of the allowed cipher suites, as listed by
+// tls.CipherSuites. The cipher suites of TLS 1.3 cannot be selected in Go, so
+// TLS 1.3 is allowed only if one of its suites is listed. With fips, only the
+// FIPS approved suites and curves are allowed, and names is ignored; TLS 1.3
+// is then allowed only if Go runs in FIPS 140 mode, which restricts its
+// suites.
+func newTLSOptions(names []string, fips bool) (tlsOptions, error) {
+	if fips {
+		opts := tlsOptions{
+			cipherSuites: fipsCipherSuites,
+			curves:       []tls.CurveID{tls.CurveP256, tls.CurveP384},
+		}
+		if !fips140.Enabled() {
+			opts.maxVersion = tls.VersionTLS12
+		}
+		return opts, nil
+	}
+	if len(names) == 0 {
+		return tlsOptions{}, nil
+	}
+
+	byName := map[string]*tls.CipherSuite{}
+	var valid []string
+	for _, c := range tls.CipherSuites() {
+		byName[c.Name] = c
+		valid = append(valid, c.Name)
+	}
+	opts := tlsOptions{maxVersion: tls.VersionTLS12}
+	for _, name := range names {
+		c, ok := byName[strings.TrimSpace(name)]
+		if !ok {
+			return tlsOptions{}, fmt.Errorf("invalid TLS cipher suite %q, valid cipher suites are: %s", name, strings.Join(valid, ", "))
+		}
+		if slices.Contains(c.SupportedVersions, tls.VersionTLS13) {
+			opts.maxVersion = 0
+		}
+		if slices.Contains(c.SupportedVersions, tls.VersionTLS12) {
+			opts.cipherSuites = append(opts.cipherSuites, c.ID)
+		}
+	}
+	return opts, nil
+}
+
+// http2Capable reports whether HTTP/2 can be served with the allowed cipher
+// suites, which must include one of the AES_128_GCM_SHA256 suites of TLS 1.2
+// required by HTTP/2.
+func (o tlsOptions) http2Capable() bool {
+	if len(o.cipherSuites) == 0 {
+		return true
+	}
+	return slices.Contains(o.cipherSuites, tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256) ||
+		slices.Contains(o.cipherSuites, tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256)
+}
+
+// config returns the TLS configuration of the server with the certificate.
+func (o tlsOptions) config(cert tls.Certificate) *tls.Config {
+	return &tls.Config{
+		Certificates:     []tls.Certificate{cert},
+		MinVersion:       tls.VersionTLS12,
+		MaxVersion:       o.maxVersion,
+		CipherSuites:     o.cipherSuites,
+		CurvePreferences: o.curves,
+	}
+}
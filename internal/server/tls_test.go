@@ -0,0 +1,135 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server_test
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/googleapis/mcp-toolbox/internal/log"
+	"github.com/googleapis/mcp-toolbox/internal/server"
+	"github.com/googleapis/mcp-toolbox/internal/telemetry"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+)
+
+func TestTLSCipherSuites(t *testing.T) {
+	certFile, keyFile, cleanupCerts := generateTestCerts(t)
+	defer cleanupCerts()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	testLogger, err := log.NewStdLogger(os.Stdout, os.Stderr, "info")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	ctx = util.WithLogger(ctx, testLogger)
+	instrumentation, err := telemetry.CreateTelemetryInstrumentation("0.0.0")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	ctx = util.WithInstrumentation(ctx, instrumentation)
+
+	tcs := []struct {
+		desc         string
+		cipherSuites []string
+		fips         bool
+		// clientSuites are the cipher suites offered by the client, all
+		// of Go's if empty.
+		clientSuites []uint16
+		want         uint16
+		wantErr      bool
+	}{
+		{
+			desc:         "restricted",
+			cipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384"},
+			want:         tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+		},
+		{
+			desc:         "not offered by the client",
+			cipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384"},
+			clientSuites: []uint16{tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256},
+			wantErr:      true,
+		},
+		{
+			desc:         "fips",
+			fips:         true,
+			cipherSuites: []string{"TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256"},
+			clientSuites: []uint16{tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256, tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256},
+			want:         tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := server.ServerConfig{
+				Version:         "0.0.0",
+				Address:         "127.0.0.1",
+				AllowedHosts:    []string{"*"},
+				TLSCipherSuites: tc.cipherSuites,
+				TLSFIPS:         tc.fips,
+			}
+			s, err := server.NewServer(ctx, cfg)
+			if err != nil {
+				t.Fatalf("unable to initialize server: %v", err)
+			}
+			if err := s.Listen(ctx, certFile, keyFile); err != nil {
+				t.Fatalf("unable to start server: %v", err)
+			}
+			go func() {
+				if err := s.Serve(ctx); err != nil && err != http.ErrServerClosed {
+					t.Errorf("server serve error: %v", err)
+				}
+			}()
+			defer func() {
+				_ = s.Shutdown(context.Background())
+			}()
+
+			// TLS 1.3 suites cannot be restricted, so the client offers
+			// TLS 1.2 only.
+			client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{
+				InsecureSkipVerify: true,
+				MaxVersion:         tls.VersionTLS12,
+				CipherSuites:       tc.clientSuites,
+			}}}
+			resp, err := client.Get(fmt.Sprintf("https://%s/", s.Addr()))
+			if tc.wantErr {
+				if err == nil {
+					resp.Body.Close()
+					t.Fatalf("expected the handshake to fail, negotiated %s", tls.CipherSuiteName(resp.TLS.CipherSuite))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("error when sending a request: %s", err)
+			}
+			defer resp.Body.Close()
+			if resp.TLS.CipherSuite != tc.want {
+				t.Errorf("unexpected cipher suite: got %s, want %s", tls.CipherSuiteName(resp.TLS.CipherSuite), tls.CipherSuiteName(tc.want))
+			}
+		})
+	}
+
+	t.Run("invalid name", func(t *testing.T) {
+		cfg := server.ServerConfig{Version: "0.0.0", TLSCipherSuites: []string{"TLS_RSA_WITH_RC4_128_SHA"}}
+		_, err := server.NewServer(ctx, cfg)
+		if err == nil || !strings.Contains(err.Error(), "valid cipher suites are: ") || !strings.Contains(err.Error(), "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256") {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
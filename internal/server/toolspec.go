@@ -0,0 +1,134 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-chi/render"
+	"github.com/googleapis/mcp-toolbox/internal/server/mcp/jsonrpc"
+)
+
+// The tool spec endpoints export the MCP tool definitions in the tool formats
+// of agent frameworks, reusing the input schemas of tools/list.
+const (
+	llamaIndexSpecPath = mcpExportToolsPath + "/llamaindex-spec"
+	haystackToolsPath  = mcpExportToolsPath + "/haystack-tools"
+)
+
+// exportedTool is a tool of a ListToolsResult.
+type exportedTool struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	InputSchema json.RawMessage `json:"inputSchema"`
+}
+
+// exportedTools reads the tools of a ListToolsResult.
+func exportedTools(result any) ([]exportedTool, error) {
+	b, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal tools: %w", err)
+	}
+	var list struct {
+		Tools []exportedTool `json:"tools"`
+	}
+	if err := json.Unmarshal(b, &list); err != nil {
+		return nil, fmt.Errorf("unable to read tools: %w", err)
+	}
+	return list.Tools, nil
+}
+
+// llamaIndexToolSpec is the metadata of a LlamaIndex FunctionTool, with its
+// parameters as a JSON Schema.
+type llamaIndexToolSpec struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Parameters  json.RawMessage `json:"parameters"`
+}
+
+// llamaIndexSpec converts a ListToolsResult into LlamaIndex tool specs.
+func llamaIndexSpec(result any) (any, error) {
+	tools, err := exportedTools(result)
+	if err != nil {
+		return nil, err
+	}
+	specs := make([]llamaIndexToolSpec, 0, len(tools))
+	for _, t := range tools {
+		specs = append(specs, llamaIndexToolSpec{Name: t.Name, Description: t.Description, Parameters: t.InputSchema})
+	}
+	return specs, nil
+}
+
+// haystackTool is a Haystack Tool, whose function is the URL invoking the
+// tool with the parameters as JSON body.
+type haystackTool struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Parameters  json.RawMessage `json:"parameters"`
+	Function    string          `json:"function"`
+}
+
+// haystackTools returns a conversion of a ListToolsResult into Haystack
+// tools, invoked through the /api endpoint of the server at baseURL.
+func haystackTools(baseURL string) func(any) (any, error) {
+	return func(result any) (any, error) {
+		tools, err := exportedTools(result)
+		if err != nil {
+			return nil, err
+		}
+		specs := make([]haystackTool, 0, len(tools))
+		for _, t := range tools {
+			specs = append(specs, haystackTool{
+				Name:        t.Name,
+				Description: t.Description,
+				Parameters:  t.InputSchema,
+				Function:    fmt.Sprintf("%s/api/tool/%s/invoke", baseURL, url.PathEscape(t.Name)),
+			})
+		}
+		return specs, nil
+	}
+}
+
+// baseURL returns the URL of the server: --toolbox-url if set, or the URL
+// the request was sent to.
+func (s *Server) baseURL(r *http.Request) string {
+	if s.toolboxUrl != "" {
+		return strings.TrimSuffix(s.toolboxUrl, "/")
+	}
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host
+}
+
+// toolSpecHandler returns the tools of a toolset converted by spec, or as a
+// ListToolsResult if spec is nil.
+func toolSpecHandler(s *Server, w http.ResponseWriter, r *http.Request, spec func(any) (any, error)) {
+	body, err := json.Marshal(jsonrpc.JSONRPCRequest{
+		Jsonrpc: jsonrpc.JSONRPC_VERSION,
+		Id:      mcpExportRequestId,
+		Request: jsonrpc.Request{Method: "tools/list"},
+	})
+	if err != nil {
+		_ = render.Render(w, r, newErrResponse(err, http.StatusInternalServerError))
+		return
+	}
+	processMcpExport(s, w, r, body, spec)
+}
@@ -0,0 +1,294 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/go-chi/render"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	// directToolset is the toolset the usage of tools invoked through
+	// /api/tool is attributed to.
+	directToolset = "direct"
+	// defaultToolset is the toolset the usage of tools invoked through the
+	// default toolset is attributed to.
+	defaultToolset = "default"
+)
+
+const (
+	// usageBucketWidth is the granularity of the time windows the usage can
+	// be filtered by.
+	usageBucketWidth = time.Minute
+	// usageRetention is how long the usage is kept for time windows. The
+	// usage since the server started is kept regardless.
+	usageRetention = 24 * time.Hour
+)
+
+// invocationUsage is the usage of a tool, or of the tools invoked through a
+// toolset.
+type invocationUsage struct {
+	Invocations int64 `json:"invocations"`
+	Errors      int64 `json:"errors"`
+	// ErrorRate is the fraction of the invocations that failed.
+	ErrorRate float64 `json:"errorRate"`
+	// Rows is the total number of rows the invocations returned.
+	Rows int64 `json:"rows"`
+	// ExecutionSeconds is the cumulative execution time of the invocations.
+	ExecutionSeconds float64 `json:"executionSeconds"`
+	// BytesBilled is the total number of bytes billed by the sources of the
+	// invocations that report it, such as BigQuery.
+	BytesBilled int64 `json:"bytesBilled"`
+}
+
+func (u *invocationUsage) add(o invocationUsage) {
+	u.Invocations += o.Invocations
+	u.Errors += o.Errors
+	u.Rows += o.Rows
+	u.ExecutionSeconds += o.ExecutionSeconds
+	u.BytesBilled += o.BytesBilled
+}
+
+// usageTotals is the usage of the tools and toolsets over a period.
+type usageTotals struct {
+	toolsets map[string]*invocationUsage
+	tools    map[string]*invocationUsage
+}
+
+func (t *usageTotals) add(toolset, tool string, u invocationUsage) {
+	if t.toolsets == nil {
+		t.toolsets = make(map[string]*invocationUsage)
+		t.tools = make(map[string]*invocationUsage)
+	}
+	addUsage(t.toolsets, toolset, u)
+	addUsage(t.tools, tool, u)
+}
+
+// merge adds the usage of o to t.
+func (t *usageTotals) merge(o usageTotals) {
+	if t.toolsets == nil {
+		t.toolsets = make(map[string]*invocationUsage)
+		t.tools = make(map[string]*invocationUsage)
+	}
+	for name, u := range o.toolsets {
+		addUsage(t.toolsets, name, *u)
+	}
+	for name, u := range o.tools {
+		addUsage(t.tools, name, *u)
+	}
+}
+
+func addUsage(m map[string]*invocationUsage, name string, u invocationUsage) {
+	entry, ok := m[name]
+	if !ok {
+		entry = &invocationUsage{}
+		m[name] = entry
+	}
+	entry.add(u)
+}
+
+// usageBucket is the usage of the tools and toolsets over usageBucketWidth.
+type usageBucket struct {
+	start time.Time
+	usageTotals
+}
+
+// usageStats aggregates the usage of tools and toolsets since the server
+// started, and over the last usageRetention by usageBucketWidth.
+type usageStats struct {
+	mu    sync.Mutex
+	total usageTotals
+	// buckets are ordered by start time.
+	buckets []*usageBucket
+	// now returns the current time, and is overridden in tests.
+	now func() time.Time
+}
+
+func (u *usageStats) clock() time.Time {
+	if u.now != nil {
+		return u.now()
+	}
+	return time.Now()
+}
+
+func (u *usageStats) record(toolset, tool string, usage invocationUsage) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.total.add(toolset, tool, usage)
+
+	start := u.clock().Truncate(usageBucketWidth)
+	if n := len(u.buckets); n == 0 || u.buckets[n-1].start.Before(start) {
+		u.buckets = append(u.buckets, &usageBucket{start: start})
+		// drop the buckets past the retention
+		expired := 0
+		for expired < len(u.buckets) && !u.buckets[expired].start.After(start.Add(-usageRetention)) {
+			expired++
+		}
+		u.buckets = slices.Delete(u.buckets, 0, expired)
+	}
+	u.buckets[len(u.buckets)-1].add(toolset, tool, usage)
+}
+
+// snapshot returns a copy of the usage of every tool and toolset between
+// since and until, where zero times leave the window open. The window is
+// rounded to usageBucketWidth, and covers the usage since the server started
+// when both times are zero.
+func (u *usageStats) snapshot(since, until time.Time) usageResponse {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	totals := &u.total
+	if !since.IsZero() || !until.IsZero() {
+		totals = &usageTotals{}
+		for _, b := range u.buckets {
+			if (!since.IsZero() && b.start.Before(since.Truncate(usageBucketWidth))) || (!until.IsZero() && !b.start.Before(until)) {
+				continue
+			}
+			totals.merge(b.usageTotals)
+		}
+	}
+	return usageResponse{Toolsets: copyUsage(totals.toolsets), Tools: copyUsage(totals.tools)}
+}
+
+// copyUsage returns a copy of usage with the error rates computed.
+func copyUsage(usage map[string]*invocationUsage) map[string]invocationUsage {
+	res := make(map[string]invocationUsage, len(usage))
+	for name, u := range usage {
+		c := *u
+		if c.Invocations > 0 {
+			c.ErrorRate = float64(c.Errors) / float64(c.Invocations)
+		}
+		res[name] = c
+	}
+	return res
+}
+
+// usageRecorder attributes the invocations of a request to the toolset named
+// in the request.
+type usageRecorder struct {
+	s       *Server
+	toolset string
+}
+
+var _ util.UsageRecorder = usageRecorder{}
+
+func (r usageRecorder) RecordInvocation(ctx context.Context, toolName string, result any, err error, seconds float64) {
+	r.record(ctx, toolName, tools.CountRows(result), err, seconds)
+}
+
+// record records an invocation of toolName that returned rows rows, along
+// with the bytes billed recorded in the invocation cost of ctx.
+func (r usageRecorder) record(ctx context.Context, toolName string, rows int, err error, seconds float64) {
+	usage := invocationUsage{Invocations: 1, Rows: int64(rows), ExecutionSeconds: seconds}
+	if err != nil {
+		usage.Errors = 1
+	}
+	if cost := util.InvocationCostFromContext(ctx); cost != nil {
+		usage.BytesBilled = cost.TakeBytesBilled()
+	}
+	r.s.usage.record(r.toolset, toolName, usage)
+
+	if r.s.instrumentation == nil {
+		return
+	}
+	attrs := []attribute.KeyValue{
+		attribute.String("toolset.name", r.toolset),
+		attribute.String("gen_ai.tool.name", toolName),
+	}
+	if err != nil {
+		errType := "unknown"
+		var tbErr util.ToolboxError
+		if errors.As(err, &tbErr) {
+			errType = string(tbErr.Category())
+		}
+		attrs = append(attrs, attribute.String("error.type", errType))
+	}
+	opt := metric.WithAttributes(attrs...)
+	r.s.instrumentation.ToolsetInvocations.Add(ctx, 1, opt)
+	r.s.instrumentation.ToolsetRows.Add(ctx, int64(rows), opt)
+	r.s.instrumentation.ToolsetExecutionTime.Add(ctx, seconds, opt)
+	if usage.BytesBilled > 0 {
+		r.s.instrumentation.ToolsetBytesBilled.Add(ctx, usage.BytesBilled, opt)
+	}
+}
+
+// withUsageRecorder attributes the invocations of ctx to the toolset, where
+// the empty name is the default toolset.
+func (s *Server) withUsageRecorder(ctx context.Context, toolset string) context.Context {
+	if toolset == "" {
+		toolset = defaultToolset
+	}
+	ctx = util.WithInvocationCost(ctx, &util.InvocationCost{})
+	return util.WithUsageRecorder(ctx, usageRecorder{s: s, toolset: toolset})
+}
+
+// usageResponse reports the usage of tools per toolset and per tool.
+type usageResponse struct {
+	Toolsets map[string]invocationUsage `json:"toolsets"`
+	Tools    map[string]invocationUsage `json:"tools"`
+}
+
+// usageWindow parses the time window of a usage request: either a window
+// duration up to now, or since and until times in RFC 3339 format.
+func usageWindow(q url.Values, now time.Time) (since, until time.Time, err error) {
+	if w := q.Get("window"); w != "" {
+		if q.Has("since") || q.Has("until") {
+			return since, until, fmt.Errorf("window cannot be used with since or until")
+		}
+		d, err := time.ParseDuration(w)
+		if err != nil || d <= 0 {
+			return since, until, fmt.Errorf("invalid window %q: must be a positive duration such as \"1h\"", w)
+		}
+		return now.Add(-d), time.Time{}, nil
+	}
+	for _, p := range []struct {
+		name string
+		t    *time.Time
+	}{{"since", &since}, {"until", &until}} {
+		v := q.Get(p.name)
+		if v == "" {
+			continue
+		}
+		if *p.t, err = time.Parse(time.RFC3339, v); err != nil {
+			return since, until, fmt.Errorf("invalid %s %q: must be an RFC 3339 time", p.name, v)
+		}
+	}
+	if !since.IsZero() && !until.IsZero() && !since.Before(until) {
+		return since, until, fmt.Errorf("since must be before until")
+	}
+	return since, until, nil
+}
+
+// usageHandler reports the usage of tools per toolset and per tool, since the
+// server started or within the time window of the request.
+func usageHandler(s *Server, w http.ResponseWriter, r *http.Request) {
+	since, until, err := usageWindow(r.URL.Query(), time.Now())
+	if err != nil {
+		_ = render.Render(w, r, newErrResponse(err, http.StatusBadRequest))
+		return
+	}
+	render.JSON(w, r, s.usage.snapshot(since, until))
+}
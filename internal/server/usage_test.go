@@ -0,0 +1,257 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/googleapis/mcp-toolbox/internal/testutils"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+// failingTool fails every invocation.
+type failingTool struct {
+	testutils.MockTool
+}
+
+func (t failingTool) Invoke(context.Context, tools.SourceProvider, parameters.ParamValues, tools.AccessToken) (any, util.ToolboxError) {
+	return nil, util.NewAgentError("invalid query", nil)
+}
+
+func usageTestServer(t *testing.T, router string) (*Server, func(string, string, map[string]string) []byte, func()) {
+	toolsMap := map[string]tools.Tool{
+		"shared_tool":  testutils.NewMockTool("shared_tool", "", nil, false, false),
+		"failing_tool": failingTool{MockTool: testutils.NewMockTool("failing_tool", "", nil, false, false)},
+	}
+	toolsets := map[string]tools.Toolset{}
+	for name, toolNames := range map[string][]string{
+		"":          {"shared_tool", "failing_tool"},
+		"analytics": {"shared_tool", "failing_tool"},
+		"reporting": {"shared_tool"},
+	} {
+		ts, err := tools.ToolsetConfig{Name: name, ToolNames: toolNames}.Initialize(testutils.MockVersionString, toolsMap)
+		if err != nil {
+			t.Fatalf("unable to initialize toolset: %s", err)
+		}
+		toolsets[name] = ts
+	}
+
+	var srv *Server
+	r, shutdown := setUpServer(t, router, toolsMap, toolsets, nil, nil, func(s *Server) { srv = s })
+	ts := runServer(r, false)
+	request := func(path, body string, header map[string]string) []byte {
+		method := http.MethodPost
+		if body == "" {
+			method = http.MethodGet
+		}
+		resp, respBody, err := runRequest(ts, method, path, strings.NewReader(body), header)
+		if err != nil {
+			t.Fatalf("unexpected error during request: %s", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("unexpected status: %d, body: %s", resp.StatusCode, respBody)
+		}
+		return respBody
+	}
+	return srv, request, func() {
+		ts.Close()
+		shutdown()
+	}
+}
+
+func TestUsageAttribution(t *testing.T) {
+	ignoreTime := cmpopts.IgnoreFields(invocationUsage{}, "ExecutionSeconds")
+
+	t.Run("api", func(t *testing.T) {
+		srv, request, cleanup := usageTestServer(t, "api")
+		defer cleanup()
+
+		request("/tool/shared_tool/invoke", `{}`, nil)
+		request("/tool/shared_tool/invoke", `{}`, nil)
+		request("/tool/failing_tool/invoke", `{}`, nil)
+
+		want := map[string]invocationUsage{
+			directToolset: {Invocations: 3, Errors: 1, ErrorRate: 1.0 / 3, Rows: 2},
+		}
+		if diff := cmp.Diff(want, srv.usage.snapshot(time.Time{}, time.Time{}).Toolsets, ignoreTime); diff != "" {
+			t.Fatalf("unexpected usage (-want +got):\n%s", diff)
+		}
+
+		var got usageResponse
+		if err := json.Unmarshal(request("/usage", "", nil), &got); err != nil {
+			t.Fatalf("unable to decode response: %s", err)
+		}
+		if diff := cmp.Diff(want, got.Toolsets, ignoreTime); diff != "" {
+			t.Errorf("unexpected usage response (-want +got):\n%s", diff)
+		}
+		wantTools := map[string]invocationUsage{
+			"shared_tool":  {Invocations: 2, Rows: 2},
+			"failing_tool": {Invocations: 1, Errors: 1, ErrorRate: 1},
+		}
+		if diff := cmp.Diff(wantTools, got.Tools, ignoreTime); diff != "" {
+			t.Errorf("unexpected tool usage response (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("mcp", func(t *testing.T) {
+		srv, request, cleanup := usageTestServer(t, "mcp")
+		defer cleanup()
+
+		header := map[string]string{"Mcp-Protocol-Version": "2025-06-18"}
+		call := func(path, tool string) {
+			request(path, `{"jsonrpc": "2.0", "id": "call", "method": "tools/call", "params": {"name": "`+tool+`", "arguments": {}}}`, header)
+		}
+		// shared_tool belongs to every toolset, and is attributed to the
+		// toolset of the path it is invoked through.
+		call("/", "shared_tool")
+		call("/analytics", "shared_tool")
+		call("/analytics", "failing_tool")
+		call("/reporting", "shared_tool")
+		call("/reporting", "shared_tool")
+
+		want := map[string]invocationUsage{
+			defaultToolset: {Invocations: 1, Rows: 1},
+			"analytics":    {Invocations: 2, Errors: 1, ErrorRate: 0.5, Rows: 1},
+			"reporting":    {Invocations: 2, Rows: 2},
+		}
+		if diff := cmp.Diff(want, srv.usage.snapshot(time.Time{}, time.Time{}).Toolsets, ignoreTime); diff != "" {
+			t.Errorf("unexpected usage (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestUsageTimeWindows(t *testing.T) {
+	start := time.Date(2026, 3, 1, 12, 0, 30, 0, time.UTC)
+	now := start
+	u := &usageStats{now: func() time.Time { return now }}
+
+	u.record("analytics", "query", invocationUsage{Invocations: 1, Rows: 10, BytesBilled: 100})
+	now = start.Add(10 * time.Minute)
+	u.record("analytics", "query", invocationUsage{Invocations: 1, Errors: 1, BytesBilled: 50})
+	u.record("reporting", "report", invocationUsage{Invocations: 1, Rows: 3})
+	// past the retention, the first bucket is dropped
+	now = start.Add(usageRetention + 5*time.Minute)
+	u.record("reporting", "report", invocationUsage{Invocations: 1, Rows: 1})
+
+	tcs := []struct {
+		desc         string
+		since, until time.Time
+		want         usageResponse
+	}{
+		{
+			desc: "since the server started",
+			want: usageResponse{
+				Toolsets: map[string]invocationUsage{
+					"analytics": {Invocations: 2, Errors: 1, ErrorRate: 0.5, Rows: 10, BytesBilled: 150},
+					"reporting": {Invocations: 2, Rows: 4},
+				},
+				Tools: map[string]invocationUsage{
+					"query":  {Invocations: 2, Errors: 1, ErrorRate: 0.5, Rows: 10, BytesBilled: 150},
+					"report": {Invocations: 2, Rows: 4},
+				},
+			},
+		},
+		{
+			desc:  "bounded window",
+			since: start.Add(5 * time.Minute),
+			until: start.Add(15 * time.Minute),
+			want: usageResponse{
+				Toolsets: map[string]invocationUsage{
+					"analytics": {Invocations: 1, Errors: 1, ErrorRate: 1, BytesBilled: 50},
+					"reporting": {Invocations: 1, Rows: 3},
+				},
+				Tools: map[string]invocationUsage{
+					"query":  {Invocations: 1, Errors: 1, ErrorRate: 1, BytesBilled: 50},
+					"report": {Invocations: 1, Rows: 3},
+				},
+			},
+		},
+		{
+			desc:  "window past the retention",
+			since: start,
+			want: usageResponse{
+				Toolsets: map[string]invocationUsage{
+					"analytics": {Invocations: 1, Errors: 1, ErrorRate: 1, BytesBilled: 50},
+					"reporting": {Invocations: 2, Rows: 4},
+				},
+				Tools: map[string]invocationUsage{
+					"query":  {Invocations: 1, Errors: 1, ErrorRate: 1, BytesBilled: 50},
+					"report": {Invocations: 2, Rows: 4},
+				},
+			},
+		},
+		{
+			desc:  "empty window",
+			until: start.Add(-time.Hour),
+			want:  usageResponse{Toolsets: map[string]invocationUsage{}, Tools: map[string]invocationUsage{}},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, u.snapshot(tc.since, tc.until)); diff != "" {
+				t.Errorf("unexpected usage (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUsageWindow(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	tcs := []struct {
+		desc         string
+		query        string
+		since, until time.Time
+		err          string
+	}{
+		{desc: "no window"},
+		{desc: "window", query: "window=1h", since: now.Add(-time.Hour)},
+		{desc: "since and until", query: "since=2026-02-01T00:00:00Z&until=2026-03-01T00:00:00Z", since: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), until: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{desc: "invalid window", query: "window=soon", err: `invalid window "soon": must be a positive duration such as "1h"`},
+		{desc: "window with since", query: "window=1h&since=2026-02-01T00:00:00Z", err: "window cannot be used with since or until"},
+		{desc: "invalid since", query: "since=yesterday", err: `invalid since "yesterday": must be an RFC 3339 time`},
+		{desc: "since after until", query: "since=2026-03-01T00:00:00Z&until=2026-02-01T00:00:00Z", err: "since must be before until"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			q, err := url.ParseQuery(tc.query)
+			if err != nil {
+				t.Fatalf("unable to parse query: %s", err)
+			}
+			since, until, err := usageWindow(q, now)
+			if tc.err != "" {
+				if err == nil || err.Error() != tc.err {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !since.Equal(tc.since) || !until.Equal(tc.until) {
+				t.Errorf("unexpected window: got [%s, %s), want [%s, %s)", since, until, tc.since, tc.until)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+
+	"github.com/googleapis/mcp-toolbox/internal/util"
+)
+
+const (
+	// adminTokenHeader carries the admin token of administrative requests.
+	adminTokenHeader = "X-Toolbox-Admin-Token"
+	// forceVariantHeader pins the variant a tool runs. It requires the
+	// admin token.
+	forceVariantHeader = "X-Toolbox-Force-Variant"
+)
+
+// isAdmin reports whether header carries the admin token of the server.
+func (s *Server) isAdmin(header http.Header) bool {
+	if s.adminToken == "" || header == nil {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(header.Get(adminTokenHeader)), []byte(s.adminToken)) == 1
+}
+
+// withToolVariant adds a util.ToolVariant to ctx recording the variant a tool
+// runs, pinned to the variant of the force variant header of admin requests.
+func (s *Server) withToolVariant(ctx context.Context, header http.Header) context.Context {
+	tv := &util.ToolVariant{}
+	if forced := header.Get(forceVariantHeader); forced != "" {
+		if s.isAdmin(header) {
+			tv.Forced = forced
+		} else {
+			s.logger.WarnContext(ctx, fmt.Sprintf("ignoring the %s header of a request without a valid %s header", forceVariantHeader, adminTokenHeader))
+		}
+	}
+	return util.WithToolVariant(ctx, tv)
+}
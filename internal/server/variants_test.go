@@ -0,0 +1,128 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/googleapis/mcp-toolbox/internal/testutils"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+// variantTool returns the statement of the variant it selects.
+type variantTool struct {
+	testutils.MockTool
+	variants tools.Variants
+}
+
+func (t variantTool) Invoke(ctx context.Context, _ tools.SourceProvider, _ parameters.ParamValues, _ tools.AccessToken) (any, util.ToolboxError) {
+	statement, err := t.variants.Select(ctx, "SELECT 'control'")
+	if err != nil {
+		return nil, util.NewAgentError("unable to select a variant", err)
+	}
+	return []any{statement}, nil
+}
+
+func TestToolVariants(t *testing.T) {
+	tool := variantTool{
+		MockTool: testutils.NewMockTool("variant_tool", "", nil, false, false),
+		variants: tools.Variants{
+			{Name: "control", Weight: 100},
+			{Name: "candidate", Statement: "SELECT 'candidate'", Weight: 0},
+		},
+	}
+	toolsMap := map[string]tools.Tool{tool.Name: tool}
+	toolset, err := tools.ToolsetConfig{Name: "", ToolNames: []string{tool.Name}}.Initialize(testutils.MockVersionString, toolsMap)
+	if err != nil {
+		t.Fatalf("unable to initialize toolset: %s", err)
+	}
+	toolsets := map[string]tools.Toolset{"": toolset}
+	withAdminToken := func(s *Server) { s.adminToken = "secret" }
+
+	forced := map[string]string{forceVariantHeader: "candidate", adminTokenHeader: "secret"}
+	tcs := []struct {
+		desc        string
+		header      map[string]string
+		wantVariant string
+	}{
+		{desc: "weighted", wantVariant: "control"},
+		{desc: "forced", header: forced, wantVariant: "candidate"},
+		{desc: "forced without admin token", header: map[string]string{forceVariantHeader: "candidate"}, wantVariant: "control"},
+		{desc: "forced with wrong admin token", header: map[string]string{forceVariantHeader: "candidate", adminTokenHeader: "wrong"}, wantVariant: "control"},
+	}
+
+	t.Run("api", func(t *testing.T) {
+		r, shutdown := setUpServer(t, "api", toolsMap, toolsets, nil, nil, withAdminToken)
+		defer shutdown()
+		ts := runServer(r, false)
+		defer ts.Close()
+
+		for _, tc := range tcs {
+			t.Run(tc.desc, func(t *testing.T) {
+				resp, body, err := runRequest(ts, http.MethodPost, "/tool/variant_tool/invoke", strings.NewReader(`{}`), tc.header)
+				if err != nil {
+					t.Fatalf("unexpected error during request: %s", err)
+				}
+				if resp.StatusCode != http.StatusOK {
+					t.Fatalf("unexpected status: %d, body: %s", resp.StatusCode, body)
+				}
+				var got resultResponse
+				if err := json.Unmarshal(body, &got); err != nil {
+					t.Fatalf("unable to decode response: %s", err)
+				}
+				if got.Metadata["variant"] != tc.wantVariant {
+					t.Errorf("unexpected variant: got %v, want %q", got.Metadata["variant"], tc.wantVariant)
+				}
+				if !strings.Contains(got.Result, tc.wantVariant) {
+					t.Errorf("expected result of variant %q, got %s", tc.wantVariant, got.Result)
+				}
+			})
+		}
+	})
+
+	t.Run("mcp", func(t *testing.T) {
+		r, shutdown := setUpServer(t, "mcp", toolsMap, toolsets, nil, nil, withAdminToken)
+		defer shutdown()
+		ts := runServer(r, false)
+		defer ts.Close()
+
+		for _, tc := range tcs {
+			t.Run(tc.desc, func(t *testing.T) {
+				resp, body, err := runRequest(ts, http.MethodPost, mcpExportCallPath, strings.NewReader(`{"name": "variant_tool", "arguments": {}}`), tc.header)
+				if err != nil {
+					t.Fatalf("unexpected error during request: %s", err)
+				}
+				if resp.StatusCode != http.StatusOK {
+					t.Fatalf("unexpected status: %d, body: %s", resp.StatusCode, body)
+				}
+				var got struct {
+					Meta map[string]any `json:"_meta"`
+				}
+				if err := json.Unmarshal(body, &got); err != nil {
+					t.Fatalf("unable to decode response: %s", err)
+				}
+				if got.Meta["variant"] != tc.wantVariant {
+					t.Errorf("unexpected variant: got %v, want %q", got.Meta["variant"], tc.wantVariant)
+				}
+			})
+		}
+	})
+}
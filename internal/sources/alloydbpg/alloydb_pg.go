@@ -18,16 +18,31 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"net/http"
+	"os"
 	"strings"
 
 	"cloud.google.com/go/alloydbconn"
 	"github.com/goccy/go-yaml"
 	"github.com/googleapis/mcp-toolbox/internal/sources"
+	"github.com/googleapis/mcp-toolbox/internal/sources/appname"
+	"github.com/googleapis/mcp-toolbox/internal/sources/certwatch"
+	"github.com/googleapis/mcp-toolbox/internal/sources/iamauth"
+	"github.com/googleapis/mcp-toolbox/internal/sources/pgpool"
+	"github.com/googleapis/mcp-toolbox/internal/sources/queryguard"
+	"github.com/googleapis/mcp-toolbox/internal/sources/schemadoc"
+	"github.com/googleapis/mcp-toolbox/internal/sources/schemasnapshot"
+	"github.com/googleapis/mcp-toolbox/internal/sources/sessionrole"
 	"github.com/googleapis/mcp-toolbox/internal/sources/sqlcommenter"
+	"github.com/googleapis/mcp-toolbox/internal/sources/statementcache"
+	"github.com/googleapis/mcp-toolbox/internal/telemetry"
 	"github.com/googleapis/mcp-toolbox/internal/util"
 	"github.com/googleapis/mcp-toolbox/internal/util/orderedmap"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
 )
 
 const SourceType string = "alloydb-postgres"
@@ -36,7 +51,7 @@ const SourceType string = "alloydb-postgres"
 var _ sources.SourceConfig = Config{}
 
 func init() {
-	if !sources.Register(SourceType, newConfig) {
+	if !sources.Register[*Source](SourceType, newConfig) {
 		panic(fmt.Sprintf("source type %q already registered", SourceType))
 	}
 }
@@ -46,9 +61,60 @@ func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources
 	if err := decoder.DecodeContext(ctx, &actual); err != nil {
 		return nil, err
 	}
+	switch actual.SSLMode {
+	case "", SSLModeVerifyFull:
+	case SSLModeVerifyCA:
+		if actual.CustomCA == "" {
+			return nil, fmt.Errorf("sslMode %q requires customCA to be set", SSLModeVerifyCA)
+		}
+	default:
+		return nil, fmt.Errorf("invalid sslMode %q: must be %q or %q", actual.SSLMode, SSLModeVerifyFull, SSLModeVerifyCA)
+	}
+	if actual.CustomCA != "" {
+		if _, err := actual.rootCAs(); err != nil {
+			return nil, err
+		}
+	}
+	if err := actual.validateCapacityUnits(); err != nil {
+		return nil, err
+	}
+	if actual.ConnectorServiceAccount != "" && !iamauth.IsServiceAccount(actual.ConnectorServiceAccount) {
+		return nil, fmt.Errorf("invalid connectorServiceAccount %q: must be a service account email", actual.ConnectorServiceAccount)
+	}
+	if err := actual.Login.Validate(actual.User, actual.Password); err != nil {
+		return nil, err
+	}
+	if sa := actual.ImpersonateServiceAccount; sa != "" && actual.ConnectorServiceAccount != "" && sa != actual.ConnectorServiceAccount {
+		return nil, fmt.Errorf("impersonateServiceAccount %q and connectorServiceAccount %q must be the same service account", sa, actual.ConnectorServiceAccount)
+	}
 	return actual, nil
 }
 
+// validateCapacityUnits checks that minCapacityUnits and maxCapacityUnits
+// are set together, with 0 < min <= max.
+func (r Config) validateCapacityUnits() error {
+	if r.MinCapacityUnits == nil && r.MaxCapacityUnits == nil {
+		return nil
+	}
+	if r.MinCapacityUnits == nil || r.MaxCapacityUnits == nil {
+		return fmt.Errorf("minCapacityUnits and maxCapacityUnits must be set together")
+	}
+	if *r.MinCapacityUnits <= 0 {
+		return fmt.Errorf("invalid minCapacityUnits %d: must be greater than 0", *r.MinCapacityUnits)
+	}
+	if *r.MaxCapacityUnits < *r.MinCapacityUnits {
+		return fmt.Errorf("invalid maxCapacityUnits %d: must be at least minCapacityUnits %d", *r.MaxCapacityUnits, *r.MinCapacityUnits)
+	}
+	return nil
+}
+
+const (
+	// SSLModeVerifyFull trusts the custom CA in addition to the system roots.
+	SSLModeVerifyFull = "verify-full"
+	// SSLModeVerifyCA trusts only the custom CA.
+	SSLModeVerifyCA = "verify-ca"
+)
+
 type Config struct {
 	Name         string         `yaml:"name" validate:"required"`
 	Type         string         `yaml:"type" validate:"required"`
@@ -61,26 +127,170 @@ type Config struct {
 	Password     string         `yaml:"password"`
 	Database     string         `yaml:"database" validate:"required"`
 	SQLCommenter *bool          `yaml:"sqlCommenter"`
+	CustomCA     string         `yaml:"customCA"`
+	SSLMode      string         `yaml:"sslMode"`
+	// ConnectorServiceAccount is the email of a service account the
+	// connector impersonates to call the AlloyDB APIs and, with IAM
+	// authentication, to log in as.
+	ConnectorServiceAccount string `yaml:"connectorServiceAccount"`
+	// Login optionally requires IAM authentication. Its
+	// impersonateServiceAccount is the connectorServiceAccount when that is
+	// unset.
+	iamauth.Login `yaml:",inline"`
+	// ValidateOnStartup checks that the cluster and instance exist through
+	// the AlloyDB Admin API before connecting.
+	ValidateOnStartup bool `yaml:"validateOnStartup"`
+	// WarnOnValidationFailure logs a failed startup validation instead of
+	// failing to initialize the source.
+	WarnOnValidationFailure bool `yaml:"warnOnValidationFailure"`
+	// MinCapacityUnits and MaxCapacityUnits optionally describe the capacity
+	// expected of the instance. They are reported at startup and, with
+	// ValidateOnStartup, compared against the instance, but do not change
+	// how the source connects.
+	MinCapacityUnits *int `yaml:"minCapacityUnits"`
+	MaxCapacityUnits *int `yaml:"maxCapacityUnits"`
+	// SchemaSnapshot optionally compares the schema of the database against
+	// a snapshot at startup.
+	SchemaSnapshot *schemasnapshot.Config `yaml:"schemaSnapshot"`
+	// ReadOnly rejects the statements that may write, and runs every
+	// transaction read-only.
+	ReadOnly bool `yaml:"readOnly"`
+	// QueryTimeout bounds how long a statement may run, as a duration such
+	// as "30s", through the statement_timeout of the connections.
+	QueryTimeout string `yaml:"queryTimeout"`
+	// Patterns optionally restrict the statements that tools may run.
+	queryguard.Patterns `yaml:",inline"`
+	// Capacities optionally size the statement caches of the connections.
+	statementcache.Capacities `yaml:",inline"`
+	// Options optionally name the connections in pg_stat_activity.
+	appname.Options `yaml:",inline"`
+	// Sizing optionally sizes the connection pool and defers connecting.
+	pgpool.Sizing `yaml:",inline"`
+	// Impersonation optionally runs the queries of authenticated invocations
+	// as a database role mapped from their principal.
+	Impersonation *sessionrole.Config `yaml:"impersonation"`
 }
 
 func (r Config) SourceConfigType() string {
 	return SourceType
 }
 
+// customCAFile returns the path of the file of the custom CA, or "" if
+// CustomCA holds PEM-encoded certificates.
+func (r Config) customCAFile() string {
+	if strings.Contains(r.CustomCA, "-----BEGIN") {
+		return ""
+	}
+	return r.CustomCA
+}
+
+// rootCAs returns the certificates trusted by the connector: the custom CA,
+// added to the system roots unless sslMode is verify-ca. CustomCA holds
+// either PEM-encoded certificates or the path to a file containing them.
+func (r Config) rootCAs() (*certwatch.Roots, error) {
+	pem := []byte(r.CustomCA)
+	if path := r.customCAFile(); path != "" {
+		var err error
+		if pem, err = os.ReadFile(path); err != nil {
+			return nil, fmt.Errorf("unable to read customCA: %w", err)
+		}
+	}
+	roots, err := certwatch.NewRoots(pem, r.SSLMode != SSLModeVerifyCA)
+	if err != nil {
+		return nil, fmt.Errorf("invalid customCA: %w", err)
+	}
+	return roots, nil
+}
+
+// newCustomCAClient returns an HTTP client for the connector, authenticated
+// with ts or else the default credentials, that verifies server certificates
+// against roots.
+func newCustomCAClient(ctx context.Context, roots *certwatch.Roots, ts oauth2.TokenSource) (*http.Client, error) {
+	if ts == nil {
+		creds, err := google.FindDefaultCredentials(ctx, sources.CloudPlatformScope)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find default credentials: %w", err)
+		}
+		ts = creds.TokenSource
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = roots.TLSConfig()
+	return &http.Client{
+		Transport: &oauth2.Transport{Source: ts, Base: transport},
+	}, nil
+}
+
 func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
-	pool, err := initAlloyDBPgConnectionPool(ctx, tracer, r.Name, r.Project, r.Region, r.Cluster, r.Instance, r.IPType.String(), r.User, r.Password, r.Database)
+	guard, err := r.Patterns.Compile(r.Name)
 	if err != nil {
-		return nil, fmt.Errorf("unable to create pool: %w", err)
+		return nil, err
+	}
+	if err := r.Sizing.Validate(); err != nil {
+		return nil, err
 	}
 
-	err = pool.Ping(ctx)
+	if r.MinCapacityUnits != nil {
+		logger, err := util.LoggerFromContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		logger.InfoContext(ctx, fmt.Sprintf("source %q expects %d to %d capacity units", r.Name, *r.MinCapacityUnits, *r.MaxCapacityUnits))
+	}
+
+	if r.ValidateOnStartup {
+		instance, err := validateResources(ctx, r)
+		if err != nil {
+			if !r.WarnOnValidationFailure {
+				return nil, fmt.Errorf("unable to validate source %q: %w", r.Name, err)
+			}
+			logger, lerr := util.LoggerFromContext(ctx)
+			if lerr != nil {
+				return nil, lerr
+			}
+			logger.WarnContext(ctx, fmt.Sprintf("unable to validate source %q: %s", r.Name, err))
+		} else if msg := capacityMismatch(r, instance); msg != "" {
+			logger, lerr := util.LoggerFromContext(ctx)
+			if lerr != nil {
+				return nil, lerr
+			}
+			logger.WarnContext(ctx, fmt.Sprintf("source %q: %s", r.Name, msg))
+		}
+	}
+
+	pool, roots, err := initAlloyDBPgConnectionPool(ctx, tracer, r)
 	if err != nil {
-		return nil, fmt.Errorf("unable to connect successfully: %w", err)
+		return nil, fmt.Errorf("unable to create pool: %w", err)
+	}
+
+	if !r.LazyConnect {
+		err = pool.Ping(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to connect successfully: %w", err)
+		}
 	}
 
 	s := &Source{
 		Config: r,
 		Pool:   pool,
+		roots:  roots,
+	}
+	s.Guard = guard.WithReadOnly(r.ReadOnly, queryguard.Postgres)
+	if path := r.customCAFile(); roots != nil && path != "" {
+		if err := roots.Watch(ctx, r.Name, path); err != nil {
+			return nil, err
+		}
+	}
+	if instrumentation, err := util.InstrumentationFromContext(ctx); err == nil {
+		if roots != nil {
+			s.certExpiry, err = instrumentation.ObserveCertExpiry(r.Name, SourceType, roots.Expiry)
+			if err != nil {
+				return nil, fmt.Errorf("unable to observe certificate expiry: %w", err)
+			}
+		}
+	}
+	s.Report, err = schemasnapshot.Check(ctx, r.Name, r.SchemaSnapshot, schemasnapshot.PostgresQuery, s.RunSQL)
+	if err != nil {
+		return nil, fmt.Errorf("unable to check schema snapshot: %w", err)
 	}
 	return s, nil
 }
@@ -89,7 +299,14 @@ var _ sources.Source = &Source{}
 
 type Source struct {
 	Config
+	schemasnapshot.Report
+	queryguard.Guard
 	Pool *pgxpool.Pool
+	// roots are the custom CA certificates trusted by the connector, if
+	// any, reloaded when their file changes.
+	roots *certwatch.Roots
+	// certExpiry reports the expiry of roots, if instrumented.
+	certExpiry metric.Registration
 }
 
 func (s *Source) SourceType() string {
@@ -104,35 +321,88 @@ func (s *Source) PostgresPool() *pgxpool.Pool {
 	return s.Pool
 }
 
+// Close closes the connection pool of the source.
+func (s *Source) Close() error {
+	if s.certExpiry != nil {
+		_ = s.certExpiry.Unregister()
+	}
+	if s.roots != nil {
+		_ = s.roots.Close()
+	}
+	s.Pool.Close()
+	return nil
+}
+
+// Ping checks the connection of the source to the database.
+func (s *Source) Ping(ctx context.Context) error {
+	return s.Pool.Ping(ctx)
+}
+
+// PoolStats returns the statistics of the connection pool of the source.
+func (s *Source) PoolStats() telemetry.PoolStats {
+	return telemetry.PgxPoolStats(s.Pool.Stat())
+}
+
+// DescribeSchema returns the tables, columns and comments of the source.
+func (s *Source) DescribeSchema(ctx context.Context) (*schemadoc.Schema, error) {
+	return schemadoc.Describe(ctx, schemadoc.PostgresQuery, s.RunSQL)
+}
+
 func (s *Source) RunSQL(ctx context.Context, statement string, params []any) (any, error) {
+	out := []any{}
+	err := s.StreamSQL(ctx, statement, params, func(row orderedmap.Row) error {
+		out = append(out, row)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// StreamSQL runs statement like RunSQL, passing each row to emit as it is
+// read rather than buffering the result. It stops at the first error emit
+// returns, and returns it.
+func (s *Source) StreamSQL(ctx context.Context, statement string, params []any, emit func(orderedmap.Row) error) error {
 	statement = sqlcommenter.PrependComment(ctx, statement, SourceType, s.SQLCommenter)
+	util.RecordStatement(ctx, statement, params)
 	results, err := s.Pool.Query(ctx, statement, params...)
 	if err != nil {
-		return nil, fmt.Errorf("unable to execute query: %w", err)
+		return fmt.Errorf("unable to execute query: %w", err)
 	}
 	defer results.Close()
 
 	fields := results.FieldDescriptions()
-	out := []any{}
 	for results.Next() {
 		v, err := results.Values()
 		if err != nil {
-			return nil, fmt.Errorf("unable to parse row: %w", err)
+			return fmt.Errorf("unable to parse row: %w", err)
 		}
 		row := orderedmap.Row{}
 		for i, f := range fields {
 			row.Add(f.Name, v[i])
 		}
-		out = append(out, row)
+		if err := emit(row); err != nil {
+			return err
+		}
 	}
 	// this will catch actual query execution errors
 	if err := results.Err(); err != nil {
-		return nil, fmt.Errorf("unable to execute query: %w", err)
+		return fmt.Errorf("unable to execute query: %w", err)
 	}
-	return out, nil
+	return nil
+}
+
+// connectorServiceAccount returns the service account the connector
+// impersonates, if any.
+func (r Config) connectorServiceAccount() string {
+	if r.ConnectorServiceAccount != "" {
+		return r.ConnectorServiceAccount
+	}
+	return r.ImpersonateServiceAccount
 }
 
-func getOpts(ipType, userAgent string, useIAM bool) ([]alloydbconn.Option, error) {
+func getOpts(ipType, userAgent string, useIAM bool, httpClient *http.Client, apiTS, loginTS oauth2.TokenSource) ([]alloydbconn.Option, error) {
 	opts := []alloydbconn.Option{alloydbconn.WithUserAgent(userAgent)}
 	switch strings.ToLower(ipType) {
 	case "private":
@@ -148,6 +418,15 @@ func getOpts(ipType, userAgent string, useIAM bool) ([]alloydbconn.Option, error
 	if useIAM {
 		opts = append(opts, alloydbconn.WithIAMAuthN())
 	}
+	if apiTS != nil {
+		opts = append(opts, alloydbconn.WithTokenSource(apiTS))
+		if useIAM {
+			opts = append(opts, alloydbconn.WithIAMAuthNTokenSource(loginTS))
+		}
+	}
+	if httpClient != nil {
+		opts = append(opts, alloydbconn.WithHTTPClient(httpClient))
+	}
 	return opts, nil
 }
 
@@ -184,36 +463,77 @@ func getConnectionConfig(ctx context.Context, user, pass, dbname string) (string
 	return dsn, useIAM, nil
 }
 
-func initAlloyDBPgConnectionPool(ctx context.Context, tracer trace.Tracer, name, project, region, cluster, instance, ipType, user, pass, dbname string) (*pgxpool.Pool, error) {
+// initAlloyDBPgConnectionPool returns the connection pool of the source, and
+// the custom CA certificates trusted by its connector if any.
+func initAlloyDBPgConnectionPool(ctx context.Context, tracer trace.Tracer, r Config) (*pgxpool.Pool, *certwatch.Roots, error) {
 	//nolint:all // Reassigned ctx
-	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceType, name)
+	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceType, r.Name)
 	defer span.End()
 
-	dsn, useIAM, err := getConnectionConfig(ctx, user, pass, dbname)
+	sa := r.connectorServiceAccount()
+	user := r.User
+	if user == "" && r.Password == "" && sa != "" {
+		// log in as the impersonated service account rather than the ADC
+		// principal
+		user = iamauth.DatabaseUser(sa, "postgres")
+	}
+	dsn, useIAM, err := getConnectionConfig(ctx, user, r.Password, r.Database)
 	if err != nil {
-		return nil, fmt.Errorf("unable to get AlloyDB connection config: %w", err)
+		return nil, nil, fmt.Errorf("unable to get AlloyDB connection config: %w", err)
+	}
+	statementTimeout, err := sources.PostgresStatementTimeout(r.QueryTimeout)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	config, err := pgxpool.ParseConfig(dsn)
 	if err != nil {
-		return nil, fmt.Errorf("unable to parse connection uri: %w", err)
+		return nil, nil, fmt.Errorf("unable to parse connection uri: %w", err)
+	}
+	r.Capacities.Apply(config.ConnConfig)
+	r.Options.Apply(config)
+	r.Sizing.Apply(config)
+	r.Impersonation.Apply(config)
+	if r.ReadOnly {
+		// the database rejects the writes the statement check misses, such
+		// as the ones of functions
+		config.ConnConfig.RuntimeParams["default_transaction_read_only"] = "on"
+	}
+	if statementTimeout != "" {
+		config.ConnConfig.RuntimeParams["statement_timeout"] = statementTimeout
 	}
 	// Create a new dialer with options
 	userAgent, err := util.UserAgentFromContext(ctx)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	var apiTS, loginTS oauth2.TokenSource
+	if sa != "" {
+		if apiTS, loginTS, err = iamauth.TokenSources(ctx, sa, iamauth.AlloyDBLoginScope); err != nil {
+			return nil, nil, err
+		}
+	}
+	var httpClient *http.Client
+	var roots *certwatch.Roots
+	if r.CustomCA != "" {
+		if roots, err = r.rootCAs(); err != nil {
+			return nil, nil, err
+		}
+		if httpClient, err = newCustomCAClient(ctx, roots, apiTS); err != nil {
+			return nil, nil, err
+		}
 	}
-	opts, err := getOpts(ipType, userAgent, useIAM)
+	opts, err := getOpts(r.IPType.String(), userAgent, useIAM, httpClient, apiTS, loginTS)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	d, err := alloydbconn.NewDialer(ctx, opts...)
 	if err != nil {
-		return nil, fmt.Errorf("unable to parse connection uri: %w", err)
+		return nil, nil, fmt.Errorf("unable to parse connection uri: %w", err)
 	}
 
 	// Tell the driver to use the AlloyDB Go Connector to create connections
-	i := fmt.Sprintf("projects/%s/locations/%s/clusters/%s/instances/%s", project, region, cluster, instance)
+	i := fmt.Sprintf("projects/%s/locations/%s/clusters/%s/instances/%s", r.Project, r.Region, r.Cluster, r.Instance)
 	config.ConnConfig.DialFunc = func(ctx context.Context, _ string, instance string) (net.Conn, error) {
 		return d.Dial(ctx, i)
 	}
@@ -221,7 +541,7 @@ func initAlloyDBPgConnectionPool(ctx context.Context, tracer trace.Tracer, name,
 	// Interact with the driver directly as you normally would
 	pool, err := pgxpool.NewWithConfig(ctx, config)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	return pool, nil
+	return pool, roots, nil
 }
@@ -16,7 +16,18 @@ package alloydbpg_test
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/googleapis/mcp-toolbox/internal/server"
@@ -60,6 +71,64 @@ func TestParseFromYamlAlloyDBPg(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "validate on startup",
+			in: `
+			kind: source
+			name: my-pg-instance
+			type: alloydb-postgres
+			project: my-project
+			region: my-region
+			cluster: my-cluster
+			instance: my-instance
+			database: my_db
+			validateOnStartup: true
+			warnOnValidationFailure: true
+			`,
+			want: map[string]sources.SourceConfig{
+				"my-pg-instance": alloydbpg.Config{
+					Name:                    "my-pg-instance",
+					Type:                    alloydbpg.SourceType,
+					Project:                 "my-project",
+					Region:                  "my-region",
+					Cluster:                 "my-cluster",
+					Instance:                "my-instance",
+					IPType:                  "public",
+					Database:                "my_db",
+					ValidateOnStartup:       true,
+					WarnOnValidationFailure: true,
+				},
+			},
+		},
+		{
+			desc: "capacity units",
+			in: `
+			kind: source
+			name: my-pg-instance
+			type: alloydb-postgres
+			project: my-project
+			region: my-region
+			cluster: my-cluster
+			instance: my-instance
+			database: my_db
+			minCapacityUnits: 2
+			maxCapacityUnits: 8
+			`,
+			want: map[string]sources.SourceConfig{
+				"my-pg-instance": alloydbpg.Config{
+					Name:             "my-pg-instance",
+					Type:             alloydbpg.SourceType,
+					Project:          "my-project",
+					Region:           "my-region",
+					Cluster:          "my-cluster",
+					Instance:         "my-instance",
+					IPType:           "public",
+					Database:         "my_db",
+					MinCapacityUnits: intPtr(2),
+					MaxCapacityUnits: intPtr(8),
+				},
+			},
+		},
 		{
 			desc: "public ipType",
 			in: `
@@ -90,6 +159,33 @@ func TestParseFromYamlAlloyDBPg(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "connector service account",
+			in: `
+			kind: source
+			name: my-pg-instance
+			type: alloydb-postgres
+			project: my-project
+			region: my-region
+			cluster: my-cluster
+			instance: my-instance
+			database: my_db
+			connectorServiceAccount: toolbox@my-project.iam.gserviceaccount.com
+			`,
+			want: map[string]sources.SourceConfig{
+				"my-pg-instance": alloydbpg.Config{
+					Name:                    "my-pg-instance",
+					Type:                    alloydbpg.SourceType,
+					Project:                 "my-project",
+					Region:                  "my-region",
+					Cluster:                 "my-cluster",
+					Instance:                "my-instance",
+					IPType:                  "public",
+					Database:                "my_db",
+					ConnectorServiceAccount: "toolbox@my-project.iam.gserviceaccount.com",
+				},
+			},
+		},
 		{
 			desc: "private ipType",
 			in: `
@@ -135,12 +231,139 @@ func TestParseFromYamlAlloyDBPg(t *testing.T) {
 	}
 }
 
+// testCA returns a PEM-encoded self-signed CA certificate.
+func testCA(t *testing.T) string {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unable to generate key: %s", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("unable to create certificate: %s", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func TestParseCustomCA(t *testing.T) {
+	ca := testCA(t)
+	caPath := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caPath, []byte(ca), 0o600); err != nil {
+		t.Fatalf("unable to write CA: %s", err)
+	}
+	inlineCA := "|\n  " + strings.ReplaceAll(strings.TrimSpace(ca), "\n", "\n  ")
+
+	tcs := []struct {
+		desc     string
+		customCA string
+		sslMode  string
+		want     string
+		err      string
+	}{
+		{
+			desc:     "file path",
+			customCA: caPath,
+			want:     caPath,
+		},
+		{
+			desc:     "inline PEM with verify-ca",
+			customCA: inlineCA,
+			sslMode:  "verify-ca",
+			want:     ca,
+		},
+		{
+			desc:    "verify-ca without customCA",
+			sslMode: "verify-ca",
+			err:     `sslMode "verify-ca" requires customCA to be set`,
+		},
+		{
+			desc:    "invalid sslMode",
+			sslMode: "disable",
+			err:     `invalid sslMode "disable": must be "verify-full" or "verify-ca"`,
+		},
+		{
+			desc:     "invalid PEM",
+			customCA: "-----BEGIN CERTIFICATE-----",
+			err:      "invalid customCA: no valid PEM certificates",
+		},
+		{
+			desc:     "missing file",
+			customCA: filepath.Join(t.TempDir(), "missing.pem"),
+			err:      "unable to read customCA",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			lines := []string{
+				"kind: source",
+				"name: my-pg-instance",
+				"type: alloydb-postgres",
+				"project: my-project",
+				"region: my-region",
+				"cluster: my-cluster",
+				"instance: my-instance",
+				"database: my_db",
+			}
+			if tc.customCA != "" {
+				lines = append(lines, "customCA: "+tc.customCA)
+			}
+			if tc.sslMode != "" {
+				lines = append(lines, "sslMode: "+tc.sslMode)
+			}
+			got, _, _, _, _, _, err := server.UnmarshalPrimitiveConfig(context.Background(), []byte(strings.Join(lines, "\n")))
+			if tc.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			cfg := got["my-pg-instance"].(alloydbpg.Config)
+			if cfg.CustomCA != tc.want || cfg.SSLMode != tc.sslMode {
+				t.Errorf("unexpected config: got customCA %q and sslMode %q", cfg.CustomCA, cfg.SSLMode)
+			}
+		})
+	}
+}
+
 func TestFailParseFromYaml(t *testing.T) {
 	tcs := []struct {
 		desc string
 		in   string
 		err  string
 	}{
+		{
+			desc: "different impersonated service accounts",
+			in: `
+			kind: source
+			name: my-pg-instance
+			type: alloydb-postgres
+			project: my-project
+			region: my-region
+			cluster: my-cluster
+			instance: my-instance
+			database: my_db
+			auth: iam
+			connectorServiceAccount: toolbox@my-project.iam.gserviceaccount.com
+			impersonateServiceAccount: other@my-project.iam.gserviceaccount.com
+			`,
+			err: "error unmarshaling source: unable to parse source \"my-pg-instance\" as \"alloydb-postgres\": impersonateServiceAccount \"other@my-project.iam.gserviceaccount.com\" and connectorServiceAccount \"toolbox@my-project.iam.gserviceaccount.com\" must be the same service account",
+		},
 		{
 			desc: "invalid ipType",
 			in: `
@@ -175,6 +398,68 @@ func TestFailParseFromYaml(t *testing.T) {
 			`,
 			err: "error unmarshaling source: unable to parse source \"my-pg-instance\" as \"alloydb-postgres\": [3:1] unknown field \"foo\"\n   1 | cluster: my-cluster\n   2 | database: my_db\n>  3 | foo: bar\n       ^\n   4 | instance: my-instance\n   5 | name: my-pg-instance\n   6 | password: my_pass\n   7 | ",
 		},
+		{
+			desc: "invalid connectorServiceAccount",
+			in: `
+			kind: source
+			name: my-pg-instance
+			type: alloydb-postgres
+			project: my-project
+			region: my-region
+			cluster: my-cluster
+			instance: my-instance
+			database: my_db
+			connectorServiceAccount: user@example.com
+			`,
+			err: "error unmarshaling source: unable to parse source \"my-pg-instance\" as \"alloydb-postgres\": invalid connectorServiceAccount \"user@example.com\": must be a service account email",
+		},
+		{
+			desc: "zero minCapacityUnits",
+			in: `
+			kind: source
+			name: my-pg-instance
+			type: alloydb-postgres
+			project: my-project
+			region: my-region
+			cluster: my-cluster
+			instance: my-instance
+			database: my_db
+			minCapacityUnits: 0
+			maxCapacityUnits: 4
+			`,
+			err: "error unmarshaling source: unable to parse source \"my-pg-instance\" as \"alloydb-postgres\": invalid minCapacityUnits 0: must be greater than 0",
+		},
+		{
+			desc: "maxCapacityUnits below minCapacityUnits",
+			in: `
+			kind: source
+			name: my-pg-instance
+			type: alloydb-postgres
+			project: my-project
+			region: my-region
+			cluster: my-cluster
+			instance: my-instance
+			database: my_db
+			minCapacityUnits: 4
+			maxCapacityUnits: 2
+			`,
+			err: "error unmarshaling source: unable to parse source \"my-pg-instance\" as \"alloydb-postgres\": invalid maxCapacityUnits 2: must be at least minCapacityUnits 4",
+		},
+		{
+			desc: "minCapacityUnits without maxCapacityUnits",
+			in: `
+			kind: source
+			name: my-pg-instance
+			type: alloydb-postgres
+			project: my-project
+			region: my-region
+			cluster: my-cluster
+			instance: my-instance
+			database: my_db
+			minCapacityUnits: 4
+			`,
+			err: "error unmarshaling source: unable to parse source \"my-pg-instance\" as \"alloydb-postgres\": minCapacityUnits and maxCapacityUnits must be set together",
+		},
 		{
 			desc: "missing required field",
 			in: `
@@ -188,7 +473,7 @@ func TestFailParseFromYaml(t *testing.T) {
 			user: my_user
 			password: my_pass
 			`,
-			err: "error unmarshaling source: unable to parse source \"my-pg-instance\" as \"alloydb-postgres\": Key: 'Config.Project' Error:Field validation for 'Project' failed on the 'required' tag",
+			err: "error unmarshaling source: unable to parse source \"my-pg-instance\" as \"alloydb-postgres\": sources[my-pg-instance].project is required; add a \"project\" field",
 		},
 	}
 	for _, tc := range tcs {
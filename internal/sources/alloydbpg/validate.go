@@ -0,0 +1,125 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alloydbpg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/googleapis/mcp-toolbox/internal/sources"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"golang.org/x/oauth2/google"
+	alloydbrestapi "google.golang.org/api/alloydb/v1"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+)
+
+// getResource gets an AlloyDB resource by its resource name.
+type getResource func(ctx context.Context, name string) error
+
+// getInstance gets an AlloyDB instance by its resource name.
+type getInstance func(ctx context.Context, name string) (*alloydbrestapi.Instance, error)
+
+// validateResources confirms through the AlloyDB Admin API that the cluster
+// and instance of the source exist and that the server is allowed to see
+// them, with the Application Default Credentials the connector uses. It
+// returns the instance.
+func validateResources(ctx context.Context, r Config) (*alloydbrestapi.Instance, error) {
+	userAgent, err := util.UserAgentFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	creds, err := google.FindDefaultCredentials(ctx, sources.CloudPlatformScope)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find default credentials: %w", err)
+	}
+	service, err := alloydbrestapi.NewService(ctx, option.WithCredentials(creds), option.WithUserAgent(userAgent))
+	if err != nil {
+		return nil, fmt.Errorf("unable to create AlloyDB Admin API client: %w", err)
+	}
+	return checkResources(ctx, r,
+		func(ctx context.Context, name string) error {
+			_, err := service.Projects.Locations.Clusters.Get(name).Context(ctx).Do()
+			return err
+		},
+		func(ctx context.Context, name string) (*alloydbrestapi.Instance, error) {
+			return service.Projects.Locations.Clusters.Instances.Get(name).Context(ctx).Do()
+		},
+	)
+}
+
+// checkResources gets the cluster then the instance of the source, explaining
+// the failure of either.
+func checkResources(ctx context.Context, r Config, getCluster getResource, getInstance getInstance) (*alloydbrestapi.Instance, error) {
+	cluster := fmt.Sprintf("projects/%s/locations/%s/clusters/%s", r.Project, r.Region, r.Cluster)
+	if err := getCluster(ctx, cluster); err != nil {
+		return nil, resourceError("cluster", r.Cluster, cluster, err)
+	}
+	name := fmt.Sprintf("%s/instances/%s", cluster, r.Instance)
+	instance, err := getInstance(ctx, name)
+	if err != nil {
+		return nil, resourceError("instance", r.Instance, name, err)
+	}
+	return instance, nil
+}
+
+// liveCapacity returns the capacity of an instance that the configured
+// capacity units are compared against: the node count of a read pool, or the
+// vCPU count of any other instance, as the AlloyDB Admin API reports no
+// capacity units. It reports false if the instance has neither.
+func liveCapacity(instance *alloydbrestapi.Instance) (int64, bool) {
+	if instance == nil {
+		return 0, false
+	}
+	if instance.InstanceType == "READ_POOL" && instance.ReadPoolConfig != nil {
+		return instance.ReadPoolConfig.NodeCount, true
+	}
+	if instance.MachineConfig != nil && instance.MachineConfig.CpuCount > 0 {
+		return instance.MachineConfig.CpuCount, true
+	}
+	return 0, false
+}
+
+// capacityMismatch describes how the capacity of the instance falls outside
+// the configured capacity units, or returns "" if it does not or either is
+// unknown.
+func capacityMismatch(r Config, instance *alloydbrestapi.Instance) string {
+	if r.MinCapacityUnits == nil || r.MaxCapacityUnits == nil {
+		return ""
+	}
+	capacity, ok := liveCapacity(instance)
+	if !ok {
+		return ""
+	}
+	if capacity >= int64(*r.MinCapacityUnits) && capacity <= int64(*r.MaxCapacityUnits) {
+		return ""
+	}
+	return fmt.Sprintf("AlloyDB instance %q has a capacity of %d, outside the configured capacity units %d to %d", r.Instance, capacity, *r.MinCapacityUnits, *r.MaxCapacityUnits)
+}
+
+func resourceError(kind, id, name string, err error) error {
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) {
+		switch apiErr.Code {
+		case http.StatusNotFound:
+			return fmt.Errorf("AlloyDB %s %q does not exist (%s)", kind, id, name)
+		case http.StatusForbidden:
+			return fmt.Errorf("permission denied getting AlloyDB %s %s: %w", kind, name, err)
+		}
+	}
+	return fmt.Errorf("unable to get AlloyDB %s %s: %w", kind, name, err)
+}
@@ -0,0 +1,139 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alloydbpg
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	alloydbrestapi "google.golang.org/api/alloydb/v1"
+	"google.golang.org/api/googleapi"
+)
+
+func TestCheckResources(t *testing.T) {
+	cfg := Config{Project: "my-project", Region: "us-central1", Cluster: "my-cluster", Instance: "my-instance"}
+	const (
+		clusterName  = "projects/my-project/locations/us-central1/clusters/my-cluster"
+		instanceName = clusterName + "/instances/my-instance"
+	)
+	// get fails with errs[name], and succeeds for any other name.
+	get := func(errs map[string]error) getResource {
+		return func(_ context.Context, name string) error {
+			return errs[name]
+		}
+	}
+
+	tcs := []struct {
+		desc    string
+		errs    map[string]error
+		wantErr string
+	}{
+		{desc: "exists"},
+		{
+			desc:    "missing cluster",
+			errs:    map[string]error{clusterName: &googleapi.Error{Code: http.StatusNotFound}},
+			wantErr: `AlloyDB cluster "my-cluster" does not exist`,
+		},
+		{
+			desc:    "missing instance",
+			errs:    map[string]error{instanceName: &googleapi.Error{Code: http.StatusNotFound}},
+			wantErr: `AlloyDB instance "my-instance" does not exist`,
+		},
+		{
+			desc:    "permission denied",
+			errs:    map[string]error{clusterName: &googleapi.Error{Code: http.StatusForbidden}},
+			wantErr: "permission denied getting AlloyDB cluster " + clusterName,
+		},
+		{
+			desc:    "other error",
+			errs:    map[string]error{instanceName: fmt.Errorf("connection reset")},
+			wantErr: "unable to get AlloyDB instance " + instanceName + ": connection reset",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			getInst := func(ctx context.Context, name string) (*alloydbrestapi.Instance, error) {
+				if err := get(tc.errs)(ctx, name); err != nil {
+					return nil, err
+				}
+				return &alloydbrestapi.Instance{Name: name}, nil
+			}
+			_, err := checkResources(context.Background(), cfg, get(tc.errs), getInst)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("unexpected error: got %v, want %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestCapacityMismatch(t *testing.T) {
+	two, four := 2, 4
+	cfg := Config{Instance: "my-instance", MinCapacityUnits: &two, MaxCapacityUnits: &four}
+
+	tcs := []struct {
+		desc     string
+		cfg      Config
+		instance *alloydbrestapi.Instance
+		want     string
+	}{
+		{
+			desc:     "vcpus within range",
+			cfg:      cfg,
+			instance: &alloydbrestapi.Instance{InstanceType: "PRIMARY", MachineConfig: &alloydbrestapi.MachineConfig{CpuCount: 4}},
+		},
+		{
+			desc:     "vcpus outside range",
+			cfg:      cfg,
+			instance: &alloydbrestapi.Instance{InstanceType: "PRIMARY", MachineConfig: &alloydbrestapi.MachineConfig{CpuCount: 8}},
+			want:     `AlloyDB instance "my-instance" has a capacity of 8, outside the configured capacity units 2 to 4`,
+		},
+		{
+			desc: "read pool nodes outside range",
+			cfg:  cfg,
+			instance: &alloydbrestapi.Instance{
+				InstanceType:   "READ_POOL",
+				MachineConfig:  &alloydbrestapi.MachineConfig{CpuCount: 2},
+				ReadPoolConfig: &alloydbrestapi.ReadPoolConfig{NodeCount: 1},
+			},
+			want: `AlloyDB instance "my-instance" has a capacity of 1, outside the configured capacity units 2 to 4`,
+		},
+		{
+			desc:     "unknown capacity",
+			cfg:      cfg,
+			instance: &alloydbrestapi.Instance{InstanceType: "PRIMARY"},
+		},
+		{
+			desc:     "no capacity units",
+			cfg:      Config{Instance: "my-instance"},
+			instance: &alloydbrestapi.Instance{InstanceType: "PRIMARY", MachineConfig: &alloydbrestapi.MachineConfig{CpuCount: 8}},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := capacityMismatch(tc.cfg, tc.instance); got != tc.want {
+				t.Fatalf("unexpected mismatch: got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package appname configures the application_name that identifies the
+// connections of a PostgreSQL source in pg_stat_activity.
+package appname
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const param = "application_name"
+
+// Options are the applicationName and includeToolName fields of a PostgreSQL
+// source. Sources embed it inline in their config.
+type Options struct {
+	// ApplicationName overrides the application name of the connections,
+	// which defaults to the user agent of Toolbox.
+	ApplicationName string `yaml:"applicationName"`
+	// IncludeToolName appends the name of the tool running a query to the
+	// application name of its connection.
+	IncludeToolName bool `yaml:"includeToolName"`
+}
+
+// Apply sets the application name of the connections of config. When
+// IncludeToolName is set, connections are renamed after the tool of the
+// context they are acquired with, which costs a round trip whenever a
+// connection changes tools.
+func (c Options) Apply(config *pgxpool.Config) {
+	if c.ApplicationName != "" {
+		config.ConnConfig.RuntimeParams[param] = c.ApplicationName
+	}
+	if !c.IncludeToolName {
+		return
+	}
+	base := config.ConnConfig.RuntimeParams[param]
+	config.PrepareConn = func(ctx context.Context, conn *pgx.Conn) (bool, error) {
+		name := ForTool(ctx, base)
+		if conn.PgConn().ParameterStatus(param) == name {
+			return true, nil
+		}
+		if _, err := conn.Exec(ctx, "SELECT set_config('application_name', $1, false)", name); err != nil {
+			return true, fmt.Errorf("unable to set %s: %w", param, err)
+		}
+		return true, nil
+	}
+}
+
+// ForTool returns the application name base followed by the name of the tool
+// invoked in ctx, if any.
+func ForTool(ctx context.Context, base string) string {
+	if attrs := util.GenAIMetricAttrsFromContext(ctx); attrs != nil && attrs.ToolName != "" {
+		return base + ":" + attrs.ToolName
+	}
+	return base
+}
@@ -48,7 +48,7 @@ var arcadeHTTPClient = &http.Client{
 var _ sources.SourceConfig = Config{}
 
 func init() {
-	if !sources.Register(SourceType, newConfig) {
+	if !sources.Register[*Source](SourceType, newConfig) {
 		panic(fmt.Sprintf("source type %q already registered", SourceType))
 	}
 }
@@ -125,7 +125,7 @@ func TestFailParseFromYamlArcadeDB(t *testing.T) {
 			database: my_db
 			user: my_user
 			`,
-			err: "error unmarshaling source: unable to parse source \"my-arcadedb-instance\" as \"arcadedb\": Key: 'Config.Password' Error:Field validation for 'Password' failed on the 'required' tag",
+			err: "error unmarshaling source: unable to parse source \"my-arcadedb-instance\" as \"arcadedb\": sources[my-arcadedb-instance].password is required; add a \"password\" field",
 		},
 		{
 			desc: "missing database",
@@ -137,7 +137,7 @@ func TestFailParseFromYamlArcadeDB(t *testing.T) {
 			user: my_user
 			password: my_pass
 			`,
-			err: "error unmarshaling source: unable to parse source \"my-arcadedb-instance\" as \"arcadedb\": Key: 'Config.Database' Error:Field validation for 'Database' failed on the 'required' tag",
+			err: "error unmarshaling source: unable to parse source \"my-arcadedb-instance\" as \"arcadedb\": sources[my-arcadedb-instance].database is required; add a \"database\" field",
 		},
 	}
 	for _, tc := range tcs {
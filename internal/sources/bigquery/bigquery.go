@@ -16,6 +16,7 @@ package bigquery
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
 	"net/http"
@@ -66,7 +67,7 @@ type BigQuerySessionProvider func(ctx context.Context) (*Session, error)
 type DataplexClientCreator func(tokenString string) (*dataplexapi.CatalogClient, error)
 
 func init() {
-	if !sources.Register(SourceType, newConfig) {
+	if !sources.Register[*Source](SourceType, newConfig) {
 		panic(fmt.Sprintf("source type %q already registered", SourceType))
 	}
 }
@@ -93,6 +94,12 @@ type Config struct {
 	Scopes                    StringOrStringSlice `yaml:"scopes"`
 	MaxQueryResultRows        int                 `yaml:"maxQueryResultRows"`
 	MaximumBytesBilled        int64               `yaml:"maximumBytesBilled" validate:"gte=0"`
+	// MaxRetries is the number of times a query failing with a rateLimitExceeded
+	// or backendError error is retried. Defaults to sources.DefaultMaxRetries.
+	MaxRetries *int `yaml:"maxRetries" validate:"omitempty,gte=0"`
+	// QueryTimeout bounds how long a query job may run, as a duration such
+	// as "30s". BigQuery cancels the jobs running past it.
+	QueryTimeout string `yaml:"queryTimeout"`
 }
 
 // StringOrStringSlice is a custom type that can unmarshal both a single string
@@ -154,8 +161,20 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 	var clientCreator BigqueryClientCreator
 	var err error
 
+	maxRetries := sources.DefaultMaxRetries
+	if r.MaxRetries != nil {
+		maxRetries = *r.MaxRetries
+	}
+
+	queryTimeout, err := sources.ParseQueryTimeout(r.QueryTimeout)
+	if err != nil {
+		return nil, err
+	}
+
 	s := &Source{
 		Config:              r,
+		retry:               sources.NewRetryPolicy(maxRetries, isRetryableError),
+		queryTimeout:        queryTimeout,
 		Client:              client,
 		RestService:         restService,
 		TokenSource:         tokenSource,
@@ -302,6 +321,11 @@ type Source struct {
 	makeDataplexCatalogClient func() (*dataplexapi.CatalogClient, DataplexClientCreator, error)
 	SessionProvider           BigQuerySessionProvider
 	Session                   *Session
+	// retry retries queries failing with transient errors.
+	retry sources.RetryPolicy
+	// queryTimeout is the job timeout of the queries, zero leaving them
+	// unbounded.
+	queryTimeout time.Duration
 
 	// Caches for OAuth clients
 	bqClientCache *sources.Cache
@@ -576,9 +600,33 @@ func (s *Source) RetrieveClientAndService(accessToken tools.AccessToken) (*bigqu
 	return bqClient, restService, nil
 }
 
+// jobTimeout returns the timeout of the query jobs run with ctx, the earlier
+// of the queryTimeout of the source and the deadline of ctx, such as the
+// timeout of the tool, so that BigQuery cancels the job rather than letting
+// it run once the invocation gave up. Zero leaves the job unbounded.
+func (s *Source) jobTimeout(ctx context.Context) time.Duration {
+	timeout := s.queryTimeout
+	if deadline, ok := ctx.Deadline(); ok {
+		if remaining := time.Until(deadline); timeout == 0 || remaining < timeout {
+			timeout = max(remaining, time.Millisecond)
+		}
+	}
+	return timeout
+}
+
 func (s *Source) RunSQL(ctx context.Context, bqClient *bigqueryapi.Client, statement, statementType string, params []bigqueryapi.QueryParameter, connProps []*bigqueryapi.ConnectionProperty, labels map[string]string) (any, error) {
+	return s.RunSQLInDataset(ctx, bqClient, "", statement, statementType, params, connProps, labels)
+}
+
+// RunSQLInDataset runs statement like RunSQL, resolving its unqualified
+// table names in dataset of the project of bqClient if set.
+func (s *Source) RunSQLInDataset(ctx context.Context, bqClient *bigqueryapi.Client, dataset, statement, statementType string, params []bigqueryapi.QueryParameter, connProps []*bigqueryapi.ConnectionProperty, labels map[string]string) (any, error) {
 	query := bqClient.Query(statement)
 	query.Location = bqClient.Location
+	if dataset != "" {
+		query.DefaultProjectID = bqClient.Project()
+		query.DefaultDatasetID = dataset
+	}
 	if params != nil {
 		query.Parameters = params
 	}
@@ -591,23 +639,56 @@ func (s *Source) RunSQL(ctx context.Context, bqClient *bigqueryapi.Client, state
 	if s.MaximumBytesBilled > 0 {
 		query.MaxBytesBilled = s.MaximumBytesBilled
 	}
+	query.JobTimeout = s.jobTimeout(ctx)
 
 	// This block handles SELECT statements, which return a row set.
 	// We iterate through the results, convert each row into a map of
 	// column names to values, and return the collection of rows.
-	job, err := query.Run(ctx)
+	var it *bigqueryapi.RowIterator
+	err := s.retry.Do(ctx, s.Name, func() error {
+		job, err := query.Run(ctx)
+		if err != nil {
+			return fmt.Errorf("unable to execute query: %w", err)
+		}
+		it, err = job.Read(ctx)
+		if err != nil {
+			return fmt.Errorf("unable to read query results: %w", err)
+		}
+		recordBytesBilled(ctx, job)
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("unable to execute query: %w", err)
+		return nil, err
 	}
-	it, err := job.Read(ctx)
+
+	out, err := s.readRows(it)
 	if err != nil {
-		return nil, fmt.Errorf("unable to read query results: %w", err)
+		return nil, err
+	}
+	// If the query returned any rows, return them directly.
+	if len(out) > 0 {
+		return out, nil
+	}
+
+	// This handles the standard case for a SELECT query that successfully
+	// executes but returns zero rows.
+	if statementType == "SELECT" {
+		return "The query returned 0 rows.", nil
 	}
+	// This is the fallback for a successful query that doesn't return content.
+	// In most cases, this will be for DML/DDL statements like INSERT, UPDATE, CREATE, etc.
+	// However, it is also possible that this was a query that was expected to return rows
+	// but returned none, a case that we cannot distinguish here.
+	return "Query executed successfully and returned no content.", nil
+}
 
+// readRows reads the rows of it, up to the maximum number of rows returned
+// by the source, converting each row into a map of column names to values.
+func (s *Source) readRows(it *bigqueryapi.RowIterator) ([]any, error) {
 	out := []any{}
 	for s.MaxQueryResultRows <= 0 || len(out) < s.MaxQueryResultRows {
 		var val []bigqueryapi.Value
-		err = it.Next(&val)
+		err := it.Next(&val)
 		if err == iterator.Done {
 			break
 		}
@@ -621,21 +702,87 @@ func (s *Source) RunSQL(ctx context.Context, bqClient *bigqueryapi.Client, state
 		}
 		out = append(out, row)
 	}
-	// If the query returned any rows, return them directly.
-	if len(out) > 0 {
-		return out, nil
+	return out, nil
+}
+
+// SubmitSQL starts a query job running statement without waiting for it to
+// finish. Unlike RunSQL, the job is bounded by the queryTimeout of the source
+// only, as it outlives the invocation submitting it.
+func (s *Source) SubmitSQL(ctx context.Context, bqClient *bigqueryapi.Client, statement string, connProps []*bigqueryapi.ConnectionProperty, labels map[string]string) (*bigqueryapi.Job, error) {
+	query := bqClient.Query(statement)
+	query.Location = bqClient.Location
+	query.ConnectionProperties = connProps
+	query.Labels = labels
+	if s.MaximumBytesBilled > 0 {
+		query.MaxBytesBilled = s.MaximumBytesBilled
 	}
+	query.JobTimeout = s.queryTimeout
 
-	// This handles the standard case for a SELECT query that successfully
-	// executes but returns zero rows.
-	if statementType == "SELECT" {
-		return "The query returned 0 rows.", nil
+	var job *bigqueryapi.Job
+	err := s.retry.Do(ctx, s.Name, func() error {
+		var err error
+		job, err = query.Run(ctx)
+		if err != nil {
+			return fmt.Errorf("unable to submit query: %w", err)
+		}
+		return nil
+	})
+	return job, err
+}
+
+// ReadJobRows reads the results of the finished query job, up to the maximum
+// number of rows returned by the source.
+func (s *Source) ReadJobRows(ctx context.Context, job *bigqueryapi.Job) ([]any, error) {
+	it, err := job.Read(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read query results: %w", err)
 	}
-	// This is the fallback for a successful query that doesn't return content.
-	// In most cases, this will be for DML/DDL statements like INSERT, UPDATE, CREATE, etc.
-	// However, it is also possible that this was a query that was expected to return rows
-	// but returned none, a case that we cannot distinguish here.
-	return "Query executed successfully and returned no content.", nil
+	recordBytesBilled(ctx, job)
+	return s.readRows(it)
+}
+
+// recordBytesBilled records the bytes billed for the finished query job in
+// the invocation cost of ctx, if any, for usage accounting.
+func recordBytesBilled(ctx context.Context, job *bigqueryapi.Job) {
+	if util.InvocationCostFromContext(ctx) == nil {
+		return
+	}
+	status := job.LastStatus()
+	if status == nil || status.Statistics == nil {
+		var err error
+		if status, err = job.Status(ctx); err != nil || status.Statistics == nil {
+			return
+		}
+	}
+	if stats, ok := status.Statistics.Details.(*bigqueryapi.QueryStatistics); ok {
+		util.RecordBytesBilled(ctx, stats.TotalBytesBilled)
+	}
+}
+
+// isRetryableError reports whether err is a transient BigQuery error: a
+// burst of requests over the rate limits, or an internal error.
+func isRetryableError(err error) bool {
+	retryable := func(reason string) bool {
+		return reason == "rateLimitExceeded" || reason == "backendError"
+	}
+	// jobs that failed report the reason of their error
+	var jobErr *bigqueryapi.Error
+	if errors.As(err, &jobErr) {
+		return retryable(jobErr.Reason)
+	}
+	var gerr *googleapi.Error
+	if !errors.As(err, &gerr) {
+		return false
+	}
+	if gerr.Code == http.StatusTooManyRequests {
+		return true
+	}
+	for _, e := range gerr.Errors {
+		if retryable(e.Reason) {
+			return true
+		}
+	}
+	return false
 }
 
 // NormalizeValue converts BigQuery specific types to standard JSON-compatible types.
@@ -319,7 +319,7 @@ func TestFailParseFromYaml(t *testing.T) {
 			type: bigquery
 			location: us
 			`,
-			err: "error unmarshaling source: unable to parse source \"my-instance\" as \"bigquery\": Key: 'Config.Project' Error:Field validation for 'Project' failed on the 'required' tag",
+			err: "error unmarshaling source: unable to parse source \"my-instance\" as \"bigquery\": sources[my-instance].project is required; add a \"project\" field",
 		},
 		{
 			desc: "negative maximum bytes billed",
@@ -330,7 +330,7 @@ func TestFailParseFromYaml(t *testing.T) {
 			project: my-project
 			maximumBytesBilled: -1
 			`,
-			err: "error unmarshaling source: unable to parse source \"my-instance\" as \"bigquery\": [1:21] Key: 'Config.MaximumBytesBilled' Error:Field validation for 'MaximumBytesBilled' failed on the 'gte' tag\n>  1 | maximumBytesBilled: -1\n                           ^\n   2 | name: my-instance\n   3 | project: my-project\n   4 | type: bigquery",
+			err: "error unmarshaling source: unable to parse source \"my-instance\" as \"bigquery\": sources[my-instance].maximumBytesBilled has invalid value \"-1\": must be at least 0; set it to 0 or more",
 		},
 	}
 	for _, tc := range tcs {
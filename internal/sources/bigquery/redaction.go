@@ -0,0 +1,73 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigquery
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	bigqueryapi "cloud.google.com/go/bigquery"
+	"google.golang.org/api/googleapi"
+)
+
+// policyTagDenial matches the columns named in the error BigQuery returns
+// when the caller lacks the Fine-Grained Reader role on the policy tag of a
+// column, e.g. `User does not have permission to access policy tag
+// "projects/p/locations/us/taxonomies/1/policyTags/2" on column p.d.t.ssn.`
+var policyTagDenial = regexp.MustCompile(`permission to access policy tags? .*? on columns? ((?:[\w-]+\.)*[\w-]+(?:,\s*(?:[\w-]+\.)*[\w-]+)*)`)
+
+// DeniedColumns returns the names of the columns a query was denied access to
+// by column-level security, or nil if err is not such a denial.
+func DeniedColumns(err error) []string {
+	var message string
+	var gerr *googleapi.Error
+	var bqErr *bigqueryapi.Error
+	switch {
+	case errors.As(err, &gerr) && gerr.Code == http.StatusForbidden:
+		message = gerr.Message
+	case errors.As(err, &bqErr) && bqErr.Reason == "accessDenied":
+		message = bqErr.Message
+	default:
+		return nil
+	}
+
+	var columns []string
+	seen := map[string]bool{}
+	for _, m := range policyTagDenial.FindAllStringSubmatch(message, -1) {
+		for _, qualified := range strings.Split(m[1], ",") {
+			parts := strings.Split(strings.TrimSpace(qualified), ".")
+			column := parts[len(parts)-1]
+			if column != "" && !seen[column] {
+				seen[column] = true
+				columns = append(columns, column)
+			}
+		}
+	}
+	return columns
+}
+
+// RedactColumns rewrites a query to exclude the given columns from its
+// result.
+func RedactColumns(statement string, columns []string) string {
+	quoted := make([]string, len(columns))
+	for i, c := range columns {
+		quoted[i] = "`" + c + "`"
+	}
+	statement = strings.TrimRight(strings.TrimSpace(statement), ";")
+	return fmt.Sprintf("SELECT * EXCEPT (%s) FROM (\n%s\n)", strings.Join(quoted, ", "), statement)
+}
@@ -0,0 +1,80 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigquery
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	bigqueryapi "cloud.google.com/go/bigquery"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/googleapi"
+)
+
+func TestDeniedColumns(t *testing.T) {
+	denial := `Access Denied: BigQuery BigQuery: User does not have permission to access policy tag "projects/p/locations/us/taxonomies/1/policyTags/2" on column p.d.t.ssn.`
+	tcs := []struct {
+		desc string
+		err  error
+		want []string
+	}{
+		{
+			desc: "api error",
+			err:  fmt.Errorf("unable to execute query: %w", &googleapi.Error{Code: http.StatusForbidden, Message: denial}),
+			want: []string{"ssn"},
+		},
+		{
+			desc: "job error",
+			err:  fmt.Errorf("unable to read query results: %w", &bigqueryapi.Error{Reason: "accessDenied", Message: denial}),
+			want: []string{"ssn"},
+		},
+		{
+			desc: "multiple columns",
+			err: &googleapi.Error{
+				Code:    http.StatusForbidden,
+				Message: `User does not have permission to access policy tags "projects/p/locations/us/taxonomies/1/policyTags/2" on columns p.d.t.ssn, p.d.t.salary, p.d.t.ssn.`,
+			},
+			want: []string{"ssn", "salary"},
+		},
+		{
+			desc: "table access denied",
+			err:  &googleapi.Error{Code: http.StatusForbidden, Message: "Access Denied: Table p:d.t: User does not have permission to query table p:d.t."},
+		},
+		{
+			desc: "not a denial",
+			err:  &googleapi.Error{Code: http.StatusBadRequest, Message: denial},
+		},
+		{
+			desc: "other error",
+			err:  fmt.Errorf("%s", denial),
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, DeniedColumns(tc.err)); diff != "" {
+				t.Errorf("unexpected columns (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRedactColumns(t *testing.T) {
+	got := RedactColumns("  SELECT * FROM t;\n", []string{"ssn", "salary"})
+	want := "SELECT * EXCEPT (`ssn`, `salary`) FROM (\nSELECT * FROM t\n)"
+	if got != want {
+		t.Errorf("unexpected statement: got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigquery
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	bigqueryapi "cloud.google.com/go/bigquery"
+	"google.golang.org/api/googleapi"
+)
+
+func TestIsRetryableError(t *testing.T) {
+	tcs := []struct {
+		desc string
+		err  error
+		want bool
+	}{
+		{
+			desc: "rate limit exceeded",
+			err:  &googleapi.Error{Code: http.StatusForbidden, Errors: []googleapi.ErrorItem{{Reason: "rateLimitExceeded"}}},
+			want: true,
+		},
+		{
+			desc: "too many requests",
+			err:  &googleapi.Error{Code: http.StatusTooManyRequests},
+			want: true,
+		},
+		{
+			desc: "wrapped backend error",
+			err:  fmt.Errorf("unable to execute query: %w", &googleapi.Error{Code: http.StatusInternalServerError, Errors: []googleapi.ErrorItem{{Reason: "backendError"}}}),
+			want: true,
+		},
+		{
+			desc: "failed job",
+			err:  fmt.Errorf("unable to read query results: %w", &bigqueryapi.Error{Reason: "rateLimitExceeded"}),
+			want: true,
+		},
+		{
+			desc: "invalid query",
+			err:  &googleapi.Error{Code: http.StatusBadRequest, Errors: []googleapi.ErrorItem{{Reason: "invalidQuery"}}},
+		},
+		{
+			desc: "failed job with invalid query",
+			err:  &bigqueryapi.Error{Reason: "invalidQuery"},
+		},
+		{
+			desc: "other error",
+			err:  errors.New("connection refused"),
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := isRetryableError(tc.err); got != tc.want {
+				t.Errorf("isRetryableError(%v) = %t, want %t", tc.err, got, tc.want)
+			}
+		})
+	}
+}
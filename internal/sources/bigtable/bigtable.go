@@ -33,7 +33,7 @@ const SourceType string = "bigtable"
 var _ sources.SourceConfig = Config{}
 
 func init() {
-	if !sources.Register(SourceType, newConfig) {
+	if !sources.Register[*Source](SourceType, newConfig) {
 		panic(fmt.Sprintf("source type %q already registered", SourceType))
 	}
 }
@@ -90,7 +90,7 @@ func TestFailParseFromYaml(t *testing.T) {
 			type: bigtable
 			project: my-project
 			`,
-			err: "error unmarshaling source: unable to parse source \"my-bigtable-instance\" as \"bigtable\": Key: 'Config.Instance' Error:Field validation for 'Instance' failed on the 'required' tag",
+			err: "error unmarshaling source: unable to parse source \"my-bigtable-instance\" as \"bigtable\": sources[my-bigtable-instance].instance is required; add a \"instance\" field",
 		},
 	}
 	for _, tc := range tcs {
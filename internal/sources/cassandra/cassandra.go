@@ -28,7 +28,7 @@ import (
 const SourceType string = "cassandra"
 
 func init() {
-	if !sources.Register(SourceType, newConfig) {
+	if !sources.Register[*Source](SourceType, newConfig) {
 		panic(fmt.Sprintf("source type %q already registered", SourceType))
 	}
 }
@@ -42,17 +42,19 @@ func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources
 }
 
 type Config struct {
-	Name                   string   `yaml:"name" validate:"required"`
-	Type                   string   `yaml:"type" validate:"required"`
-	Hosts                  []string `yaml:"hosts" validate:"required"`
-	Keyspace               string   `yaml:"keyspace"`
-	ProtoVersion           int      `yaml:"protoVersion"`
-	Username               string   `yaml:"username"`
-	Password               string   `yaml:"password"`
-	CAPath                 string   `yaml:"caPath"`
-	CertPath               string   `yaml:"certPath"`
-	KeyPath                string   `yaml:"keyPath"`
-	EnableHostVerification bool     `yaml:"enableHostVerification"`
+	Name         string   `yaml:"name" validate:"required"`
+	Type         string   `yaml:"type" validate:"required"`
+	Hosts        []string `yaml:"hosts" validate:"required"`
+	Keyspace     string   `yaml:"keyspace"`
+	ProtoVersion int      `yaml:"protoVersion"`
+	Username     string   `yaml:"username"`
+	Password     string   `yaml:"password"`
+	// LocalDC routes queries to the nodes of this datacenter, token-aware.
+	LocalDC                string `yaml:"localDC"`
+	CAPath                 string `yaml:"caPath"`
+	CertPath               string `yaml:"certPath"`
+	KeyPath                string `yaml:"keyPath"`
+	EnableHostVerification bool   `yaml:"enableHostVerification"`
 }
 
 // Initialize implements sources.SourceConfig.
@@ -132,6 +134,14 @@ func initCassandraSession(ctx context.Context, tracer trace.Tracer, c Config) (*
 	cluster.ProtoVersion = c.ProtoVersion
 	cluster.Keyspace = c.Keyspace
 
+	// Configure DC-aware token-aware host selection policy, routing queries
+	// to the replicas of the local datacenter.
+	if c.LocalDC != "" {
+		cluster.PoolConfig.HostSelectionPolicy = gocql.TokenAwareHostPolicy(
+			gocql.DCAwareRoundRobinPolicy(c.LocalDC),
+		)
+	}
+
 	// Configure authentication if username is provided
 	if c.Username != "" {
 		cluster.Authenticator = gocql.PasswordAuthenticator{
@@ -70,6 +70,7 @@ func TestParseFromYamlCassandra(t *testing.T) {
 			password: "pass"
 			keyspace: "example_keyspace"
 			protoVersion: 4
+			localDC: "dc1"
 			caPath: "path/to/ca.crt"
 			certPath: "path/to/cert"
 			keyPath: "path/to/key"
@@ -84,6 +85,7 @@ func TestParseFromYamlCassandra(t *testing.T) {
 					Password:               "pass",
 					Keyspace:               "example_keyspace",
 					ProtoVersion:           4,
+					LocalDC:                "dc1",
 					CAPath:                 "path/to/ca.crt",
 					CertPath:               "path/to/cert",
 					KeyPath:                "path/to/key",
@@ -131,7 +133,7 @@ func TestFailParseFromYaml(t *testing.T) {
 			name: my-cassandra-instance
 			type: cassandra
 			`,
-			err: "error unmarshaling source: unable to parse source \"my-cassandra-instance\" as \"cassandra\": Key: 'Config.Hosts' Error:Field validation for 'Hosts' failed on the 'required' tag",
+			err: "error unmarshaling source: unable to parse source \"my-cassandra-instance\" as \"cassandra\": sources[my-cassandra-instance].hosts is required; add a \"hosts\" field",
 		},
 	}
 
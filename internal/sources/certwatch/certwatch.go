@@ -0,0 +1,244 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package certwatch reloads the CA certificates trusted by the TLS
+// connections of sources when the files containing them change.
+package certwatch
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+)
+
+// Roots are the CA certificates trusted by the TLS connections of a source.
+// Reloading them applies to new connections without replacing the TLS
+// configurations that use them.
+type Roots struct {
+	withSystem bool
+	pool       atomic.Pointer[x509.CertPool]
+	// expiry is the earliest expiry of the certificates, in Unix seconds.
+	expiry atomic.Int64
+
+	mu      sync.Mutex
+	pem     []byte
+	watcher *fsnotify.Watcher
+}
+
+// NewRoots returns the roots trusting the PEM-encoded certificates of data,
+// in addition to the system roots if withSystem is set.
+func NewRoots(data []byte, withSystem bool) (*Roots, error) {
+	r := &Roots{withSystem: withSystem}
+	if err := r.Reload(data); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Pool returns the certificates currently trusted.
+func (r *Roots) Pool() *x509.CertPool {
+	return r.pool.Load()
+}
+
+// Expiry returns the earliest expiry of the certificates currently trusted.
+func (r *Roots) Expiry() time.Time {
+	return time.Unix(r.expiry.Load(), 0)
+}
+
+// Reload validates the PEM-encoded certificates of data, then trusts them
+// instead of the current ones. The current certificates are kept if data is
+// invalid.
+func (r *Roots) Reload(data []byte) error {
+	pool, expiry, err := Validate(data, r.withSystem, time.Now())
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.pem = data
+	r.pool.Store(pool)
+	r.expiry.Store(expiry.Unix())
+	return nil
+}
+
+// Validate parses the PEM-encoded CA certificates of data, and checks that
+// each is valid at now and chains to a trusted root: one of data, or of the
+// system roots if withSystem is set. It returns the pool of trusted roots and
+// the earliest expiry of the certificates.
+func Validate(data []byte, withSystem bool, now time.Time) (*x509.CertPool, time.Time, error) {
+	var certs []*x509.Certificate
+	for rest := data; ; {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, time.Time{}, fmt.Errorf("unable to parse certificate: %w", err)
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, time.Time{}, errors.New("no valid PEM certificates")
+	}
+
+	pool, anchors := x509.NewCertPool(), x509.NewCertPool()
+	if withSystem {
+		if system, err := x509.SystemCertPool(); err == nil {
+			pool, anchors = system, system.Clone()
+		}
+	}
+	// Chains are verified up to the self-signed certificates of data, or
+	// the system roots; the other certificates must chain to them.
+	bundle := x509.NewCertPool()
+	for _, c := range certs {
+		pool.AddCert(c)
+		bundle.AddCert(c)
+		if bytes.Equal(c.RawSubject, c.RawIssuer) && c.CheckSignatureFrom(c) == nil {
+			anchors.AddCert(c)
+		}
+	}
+	expiry := certs[0].NotAfter
+	for _, c := range certs {
+		subject := c.Subject.String()
+		if now.After(c.NotAfter) {
+			return nil, time.Time{}, fmt.Errorf("certificate %q expired on %s", subject, c.NotAfter.Format(time.RFC3339))
+		}
+		if now.Before(c.NotBefore) {
+			return nil, time.Time{}, fmt.Errorf("certificate %q is not valid before %s", subject, c.NotBefore.Format(time.RFC3339))
+		}
+		opts := x509.VerifyOptions{
+			Roots:         anchors,
+			Intermediates: bundle,
+			CurrentTime:   now,
+			KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+		}
+		if _, err := c.Verify(opts); err != nil {
+			return nil, time.Time{}, fmt.Errorf("certificate %q does not chain to a trusted root: %w", subject, err)
+		}
+		if c.NotAfter.Before(expiry) {
+			expiry = c.NotAfter
+		}
+	}
+	return pool, expiry, nil
+}
+
+// TLSConfig returns a client TLS configuration that verifies the certificates
+// of servers against the current roots.
+func (r *Roots) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		// The certificates are verified by VerifyConnection instead, as
+		// RootCAs cannot change once the configuration is in use.
+		InsecureSkipVerify: true, //nolint:gosec
+		VerifyConnection: func(cs tls.ConnectionState) error {
+			if len(cs.PeerCertificates) == 0 {
+				return errors.New("server presented no certificate")
+			}
+			opts := x509.VerifyOptions{
+				Roots:         r.Pool(),
+				DNSName:       cs.ServerName,
+				Intermediates: x509.NewCertPool(),
+			}
+			for _, c := range cs.PeerCertificates[1:] {
+				opts.Intermediates.AddCert(c)
+			}
+			_, err := cs.PeerCertificates[0].Verify(opts)
+			return err
+		},
+	}
+}
+
+// Watch reloads the roots from the file at path whenever it changes, until
+// Close is called. Invalid certificates are logged and ignored.
+func (r *Roots) Watch(ctx context.Context, sourceName, path string) error {
+	logger, err := util.LoggerFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("unable to watch %s: %w", path, err)
+	}
+	// Watch the directory, as files are often replaced rather than written,
+	// such as the secrets Kubernetes mounts.
+	if err := w.Add(filepath.Dir(path)); err != nil {
+		_ = w.Close()
+		return fmt.Errorf("unable to watch %s: %w", path, err)
+	}
+	r.mu.Lock()
+	r.watcher = w
+	r.mu.Unlock()
+
+	go func() {
+		for {
+			select {
+			case _, ok := <-w.Events:
+				if !ok {
+					return
+				}
+				data, err := os.ReadFile(path)
+				if err != nil {
+					logger.WarnContext(ctx, fmt.Sprintf("unable to read the certificates of source %q: %s", sourceName, err))
+					continue
+				}
+				r.mu.Lock()
+				unchanged := bytes.Equal(data, r.pem)
+				r.mu.Unlock()
+				if unchanged {
+					continue
+				}
+				if err := r.Reload(data); err != nil {
+					logger.WarnContext(ctx, fmt.Sprintf("keeping the current certificates of source %q: %s", sourceName, err))
+					continue
+				}
+				logger.InfoContext(ctx, fmt.Sprintf("reloaded the certificates of source %q from %s, expiring on %s", sourceName, path, r.Expiry().UTC().Format(time.RFC3339)))
+			case err, ok := <-w.Errors:
+				if !ok {
+					return
+				}
+				logger.WarnContext(ctx, fmt.Sprintf("error watching the certificates of source %q: %s", sourceName, err))
+			}
+		}
+	}()
+	return nil
+}
+
+// Close stops watching the file of the roots.
+func (r *Roots) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.watcher == nil {
+		return nil
+	}
+	err := r.watcher.Close()
+	r.watcher = nil
+	return err
+}
@@ -0,0 +1,192 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certwatch
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/googleapis/mcp-toolbox/internal/log"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+)
+
+// testCert returns a PEM-encoded CA certificate valid from notBefore to
+// notAfter, signed by parent or else self-signed, and its key.
+func testCert(t *testing.T, name string, notBefore, notAfter time.Time, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) ([]byte, *x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unable to generate key: %s", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	if parent == nil {
+		parent, parentKey = tmpl, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatalf("unable to create certificate: %s", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("unable to parse certificate: %s", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), cert, key
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Now()
+	valid, root, rootKey := testCert(t, "root", now.Add(-time.Hour), now.Add(48*time.Hour), nil, nil)
+	intermediate, _, _ := testCert(t, "intermediate", now.Add(-time.Hour), now.Add(24*time.Hour), root, rootKey)
+	expired, _, _ := testCert(t, "expired", now.Add(-2*time.Hour), now.Add(-time.Hour), nil, nil)
+	future, _, _ := testCert(t, "future", now.Add(time.Hour), now.Add(2*time.Hour), nil, nil)
+
+	tcs := []struct {
+		desc       string
+		data       []byte
+		wantExpiry time.Time
+		wantErr    string
+	}{
+		{desc: "self-signed", data: valid, wantExpiry: root.NotAfter},
+		{desc: "intermediate with its root", data: append(append([]byte{}, valid...), intermediate...), wantExpiry: now.Add(24 * time.Hour)},
+		{desc: "intermediate without its root", data: intermediate, wantErr: `certificate "CN=intermediate" does not chain to a trusted root`},
+		{desc: "expired", data: expired, wantErr: `certificate "CN=expired" expired on`},
+		{desc: "not yet valid", data: future, wantErr: `certificate "CN=future" is not valid before`},
+		{desc: "no certificates", data: []byte("-----BEGIN CERTIFICATE-----"), wantErr: "no valid PEM certificates"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, expiry, err := Validate(tc.data, false, now)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !expiry.Equal(tc.wantExpiry.Truncate(time.Second)) {
+				t.Errorf("unexpected expiry: got %s, want %s", expiry, tc.wantExpiry)
+			}
+		})
+	}
+}
+
+func TestWatch(t *testing.T) {
+	logger, err := log.NewStdLogger(os.Stdout, os.Stderr, "info")
+	if err != nil {
+		t.Fatalf("unable to create logger: %s", err)
+	}
+	ctx := util.WithLogger(context.Background(), logger)
+
+	now := time.Now()
+	first, _, _ := testCert(t, "first", now.Add(-time.Hour), now.Add(24*time.Hour), nil, nil)
+	second, secondCert, _ := testCert(t, "second", now.Add(-time.Hour), now.Add(72*time.Hour), nil, nil)
+	expired, _, _ := testCert(t, "expired", now.Add(-2*time.Hour), now.Add(-time.Hour), nil, nil)
+
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(path, first, 0o600); err != nil {
+		t.Fatalf("unable to write certificate: %s", err)
+	}
+	roots, err := NewRoots(first, false)
+	if err != nil {
+		t.Fatalf("unable to create roots: %s", err)
+	}
+	if err := roots.Watch(ctx, "my-source", path); err != nil {
+		t.Fatalf("unable to watch: %s", err)
+	}
+	defer roots.Close()
+
+	// an invalid certificate is ignored
+	if err := os.WriteFile(path, expired, 0o600); err != nil {
+		t.Fatalf("unable to write certificate: %s", err)
+	}
+	time.Sleep(200 * time.Millisecond)
+	if got := roots.Expiry(); !got.Equal(now.Add(24 * time.Hour).Truncate(time.Second)) {
+		t.Fatalf("expected an expired certificate to be ignored, got expiry %s", got)
+	}
+
+	// replace the file, as Kubernetes does for mounted secrets
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, second, 0o600); err != nil {
+		t.Fatalf("unable to write certificate: %s", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		t.Fatalf("unable to replace certificate: %s", err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for !roots.Expiry().Equal(secondCert.NotAfter) {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the certificate to be reloaded, got expiry %s", roots.Expiry())
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	if _, err := secondCert.Verify(x509.VerifyOptions{Roots: roots.Pool()}); err != nil {
+		t.Errorf("expected the reloaded certificate to be trusted: %s", err)
+	}
+}
+
+func TestTLSConfig(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	defer ts.Close()
+	serverCA := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ts.Certificate().Raw})
+	now := time.Now()
+	other, _, _ := testCert(t, "other", now.Add(-time.Hour), now.Add(time.Hour), nil, nil)
+
+	roots, err := NewRoots(other, false)
+	if err != nil {
+		t.Fatalf("unable to create roots: %s", err)
+	}
+	get := func() error {
+		// a new transport for each request, so that connections are not reused
+		transport := &http.Transport{TLSClientConfig: roots.TLSConfig()}
+		defer transport.CloseIdleConnections()
+		resp, err := (&http.Client{Transport: transport}).Get(ts.URL)
+		if err != nil {
+			return err
+		}
+		return resp.Body.Close()
+	}
+	if err := get(); err == nil {
+		t.Fatalf("expected a server certificate of an untrusted CA to be rejected")
+	}
+	if err := roots.Reload(serverCA); err != nil {
+		t.Fatalf("unable to reload roots: %s", err)
+	}
+	if err := get(); err != nil {
+		t.Fatalf("expected the server certificate to be trusted after reloading: %s", err)
+	}
+}
@@ -34,7 +34,7 @@ const SourceType string = "clickhouse"
 var _ sources.SourceConfig = Config{}
 
 func init() {
-	if !sources.Register(SourceType, newConfig) {
+	if !sources.Register[*Source](SourceType, newConfig) {
 		panic(fmt.Sprintf("source type %q already registered", SourceType))
 	}
 }
@@ -100,6 +100,11 @@ func (s *Source) ClickHousePool() *sql.DB {
 	return s.Pool
 }
 
+// Close closes the connection pool of the source.
+func (s *Source) Close() error {
+	return s.Pool.Close()
+}
+
 func (s *Source) RunSQL(ctx context.Context, statement string, params parameters.ParamValues) (any, error) {
 	var sliceParams []any
 	if params != nil {
@@ -164,14 +169,34 @@ func (s *Source) RunSQL(ctx context.Context, statement string, params parameters
 }
 
 func validateConfig(protocol string) error {
-	validProtocols := map[string]bool{"http": true, "https": true}
+	validProtocols := map[string]bool{"http": true, "https": true, "native": true}
 
 	if protocol != "" && !validProtocols[protocol] {
-		return fmt.Errorf("invalid protocol: %s, must be one of: http, https", protocol)
+		return fmt.Errorf("invalid protocol: %s, must be one of: http, https, native", protocol)
 	}
 	return nil
 }
 
+// clickHouseDSN returns the DSN of a connection over protocol. The native
+// protocol uses the clickhouse scheme, and TLS when secure.
+func clickHouseDSN(host, port, user, pass, dbname, protocol string, secure bool) string {
+	encodedUser := url.QueryEscape(user)
+	encodedPass := url.QueryEscape(pass)
+
+	scheme := protocol
+	switch {
+	case protocol == "native":
+		scheme = "clickhouse"
+	case protocol == "http" && secure:
+		scheme = "https"
+	}
+	dsn := fmt.Sprintf("%s://%s:%s@%s:%s/%s", scheme, encodedUser, encodedPass, host, port, dbname)
+	if scheme == "https" || (scheme == "clickhouse" && secure) {
+		dsn += "?secure=true&skip_verify=false"
+	}
+	return dsn
+}
+
 func initClickHouseConnectionPool(ctx context.Context, tracer trace.Tracer, name, host, port, user, pass, dbname, protocol string, secure bool) (*sql.DB, error) {
 	//nolint:all // Reassigned ctx
 	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceType, name)
@@ -185,20 +210,7 @@ func initClickHouseConnectionPool(ctx context.Context, tracer trace.Tracer, name
 		return nil, err
 	}
 
-	encodedUser := url.QueryEscape(user)
-	encodedPass := url.QueryEscape(pass)
-
-	var dsn string
-	scheme := protocol
-	if protocol == "http" && secure {
-		scheme = "https"
-	}
-	dsn = fmt.Sprintf("%s://%s:%s@%s:%s/%s", scheme, encodedUser, encodedPass, host, port, dbname)
-	if scheme == "https" {
-		dsn += "?secure=true&skip_verify=false"
-	}
-
-	pool, err := sql.Open("clickhouse", dsn)
+	pool, err := sql.Open("clickhouse", clickHouseDSN(host, port, user, pass, dbname, protocol, secure))
 	if err != nil {
 		return nil, fmt.Errorf("sql.Open: %w", err)
 	}
@@ -0,0 +1,127 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package clienttls configures the TLS connections of a source to its
+// database: the CA certificates trusted, the client certificate presented for
+// mutual TLS, and how the certificate of the server is verified.
+package clienttls
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// The modes of the TLS connections, named after the sslmode of libpq.
+const (
+	// ModeDisable connects without TLS.
+	ModeDisable = "disable"
+	// ModeRequire connects with TLS without verifying the server.
+	ModeRequire = "require"
+	// ModeVerifyCA verifies that the certificate of the server is signed
+	// by a trusted CA.
+	ModeVerifyCA = "verify-ca"
+	// ModeVerifyFull also verifies that the certificate of the server
+	// matches its host name.
+	ModeVerifyFull = "verify-full"
+)
+
+// Config is the tls block of a source.
+type Config struct {
+	// Mode defaults to verify-full.
+	Mode string `yaml:"mode" validate:"omitempty,oneof=disable require verify-ca verify-full"`
+	// CAFile is a PEM bundle of the CA certificates trusted instead of the
+	// system ones.
+	CAFile string `yaml:"caFile"`
+	// CertFile and KeyFile are the PEM-encoded client certificate and key
+	// presented for mutual TLS.
+	CertFile string `yaml:"certFile"`
+	KeyFile  string `yaml:"keyFile"`
+	// ServerName overrides the host name verified in verify-full mode.
+	ServerName string `yaml:"serverName"`
+}
+
+// Enabled reports whether the connections use TLS. A nil Config is
+// disabled, so that sources keep their defaults without a tls block.
+func (c *Config) Enabled() bool {
+	return c != nil && c.Mode != ModeDisable
+}
+
+// ClientConfig returns the TLS configuration of the connections to host, or
+// nil if TLS is disabled.
+func (c *Config) ClientConfig(host string) (*tls.Config, error) {
+	if !c.Enabled() {
+		return nil, nil
+	}
+	if (c.CertFile == "") != (c.KeyFile == "") {
+		return nil, errors.New("tls: certFile and keyFile must be set together")
+	}
+
+	cfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: host,
+	}
+	if c.ServerName != "" {
+		cfg.ServerName = c.ServerName
+	}
+	if c.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("tls: unable to load client certificate: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	if c.CAFile != "" {
+		data, err := os.ReadFile(c.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("tls: unable to read caFile: %w", err)
+		}
+		cfg.RootCAs = x509.NewCertPool()
+		if !cfg.RootCAs.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("tls: no valid PEM certificates in caFile %q", c.CAFile)
+		}
+	}
+
+	switch c.Mode {
+	case ModeRequire:
+		cfg.InsecureSkipVerify = true //nolint:gosec
+	case ModeVerifyCA:
+		// The chain is verified by VerifyConnection instead, without the
+		// host name.
+		cfg.InsecureSkipVerify = true //nolint:gosec
+		cfg.VerifyConnection = func(cs tls.ConnectionState) error {
+			return verifyChain(cs, cfg.RootCAs)
+		}
+	}
+	return cfg, nil
+}
+
+// verifyChain verifies that the certificate of the server chains to roots,
+// or to the system roots if nil.
+func verifyChain(cs tls.ConnectionState, roots *x509.CertPool) error {
+	if len(cs.PeerCertificates) == 0 {
+		return errors.New("server presented no certificate")
+	}
+	opts := x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: x509.NewCertPool(),
+	}
+	for _, c := range cs.PeerCertificates[1:] {
+		opts.Intermediates.AddCert(c)
+	}
+	_, err := cs.PeerCertificates[0].Verify(opts)
+	return err
+}
@@ -0,0 +1,219 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clienttls
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCert(t *testing.T, tmpl *x509.Certificate, parent *testCert) testCert {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unable to generate key: %s", err)
+	}
+	tmpl.SerialNumber = big.NewInt(time.Now().UnixNano())
+	tmpl.NotBefore = time.Now().Add(-time.Hour)
+	tmpl.NotAfter = time.Now().Add(time.Hour)
+	signer, signerKey := tmpl, key
+	if parent != nil {
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatalf("unable to create certificate: %s", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("unable to parse certificate: %s", err)
+	}
+	return testCert{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+func (c testCert) tlsCertificate() tls.Certificate {
+	return tls.Certificate{Certificate: [][]byte{c.cert.Raw}, PrivateKey: c.key}
+}
+
+func writeFile(t *testing.T, dir, name string, data []byte) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("unable to write %s: %s", name, err)
+	}
+	return path
+}
+
+func TestClientConfig(t *testing.T) {
+	ca := newTestCert(t, &x509.Certificate{
+		Subject:               pkix.Name{CommonName: "test ca"},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, nil)
+	server := newTestCert(t, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "db.internal"},
+		DNSNames:    []string{"db.internal"},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, &ca)
+	client := newTestCert(t, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "toolbox"},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, &ca)
+
+	dir := t.TempDir()
+	caFile := writeFile(t, dir, "ca.pem", ca.pem)
+	certFile := writeFile(t, dir, "client.pem", client.pem)
+	keyDER, err := x509.MarshalECPrivateKey(client.key)
+	if err != nil {
+		t.Fatalf("unable to marshal key: %s", err)
+	}
+	keyFile := writeFile(t, dir, "client.key", pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+
+	// the server requires a client certificate signed by the CA
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(ca.cert)
+	ln, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+		Certificates: []tls.Certificate{server.tlsCertificate()},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCAs,
+	})
+	if err != nil {
+		t.Fatalf("unable to listen: %s", err)
+	}
+	defer ln.Close()
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				_ = conn.(*tls.Conn).Handshake()
+			}()
+		}
+	}()
+
+	tcs := []struct {
+		desc    string
+		cfg     Config
+		host    string
+		wantErr string
+	}{
+		{
+			desc: "verify-full",
+			cfg:  Config{CAFile: caFile, CertFile: certFile, KeyFile: keyFile},
+			host: "db.internal",
+		},
+		{
+			desc:    "verify-full with another host name",
+			cfg:     Config{CAFile: caFile, CertFile: certFile, KeyFile: keyFile},
+			host:    "127.0.0.1",
+			wantErr: "cannot validate certificate for 127.0.0.1",
+		},
+		{
+			desc: "verify-full with a server name",
+			cfg:  Config{CAFile: caFile, CertFile: certFile, KeyFile: keyFile, ServerName: "db.internal"},
+			host: "127.0.0.1",
+		},
+		{
+			desc: "verify-ca",
+			cfg:  Config{Mode: ModeVerifyCA, CAFile: caFile, CertFile: certFile, KeyFile: keyFile},
+			host: "127.0.0.1",
+		},
+		{
+			desc:    "verify-ca without the CA",
+			cfg:     Config{Mode: ModeVerifyCA, CertFile: certFile, KeyFile: keyFile},
+			host:    "127.0.0.1",
+			wantErr: "certificate signed by unknown authority",
+		},
+		{
+			desc: "require",
+			cfg:  Config{Mode: ModeRequire, CertFile: certFile, KeyFile: keyFile},
+			host: "127.0.0.1",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg, err := tc.cfg.ClientConfig(tc.host)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			conn, err := tls.Dial("tcp", ln.Addr().String(), cfg)
+			if err == nil {
+				err = conn.Handshake()
+				conn.Close()
+			}
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected handshake error: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("unexpected handshake error: got %v, want %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestClientConfigDisabled(t *testing.T) {
+	for _, c := range []*Config{nil, {Mode: ModeDisable}} {
+		cfg, err := c.ClientConfig("db.internal")
+		if err != nil || cfg != nil {
+			t.Errorf("expected no TLS configuration, got %v, %v", cfg, err)
+		}
+	}
+}
+
+func TestClientConfigErrors(t *testing.T) {
+	dir := t.TempDir()
+	notPEM := writeFile(t, dir, "ca.pem", []byte("not a certificate"))
+	tcs := []struct {
+		desc    string
+		cfg     Config
+		wantErr string
+	}{
+		{desc: "cert without key", cfg: Config{CertFile: "client.pem"}, wantErr: "certFile and keyFile must be set together"},
+		{desc: "missing CA file", cfg: Config{CAFile: filepath.Join(dir, "missing.pem")}, wantErr: "unable to read caFile"},
+		{desc: "invalid CA file", cfg: Config{CAFile: notPEM}, wantErr: "no valid PEM certificates"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := tc.cfg.ClientConfig("db.internal")
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("unexpected error: got %v, want %q", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -51,7 +51,7 @@ var _ sources.SourceConfig = Config{}
 type HealthcareServiceCreator func(tokenString string) (*healthcare.Service, error)
 
 func init() {
-	if !sources.Register(SourceType, newConfig) {
+	if !sources.Register[*Source](SourceType, newConfig) {
 		panic(fmt.Sprintf("source type %q already registered", SourceType))
 	}
 }
@@ -142,7 +142,7 @@ func TestFailParseFromYaml(t *testing.T) {
 			project: my-project
 			region: us-central1
 			`,
-			err: "error unmarshaling source: unable to parse source \"my-instance\" as \"cloud-healthcare\": Key: 'Config.Dataset' Error:Field validation for 'Dataset' failed on the 'required' tag",
+			err: "error unmarshaling source: unable to parse source \"my-instance\" as \"cloud-healthcare\": sources[my-instance].dataset is required; add a \"dataset\" field",
 		},
 	}
 	for _, tc := range tcs {
@@ -119,7 +119,7 @@ func TestFailParseFromYaml(t *testing.T) {
 			name: my-instance
 			type: cloud-logging-admin
 			`,
-			err: "error unmarshaling source: unable to parse source \"my-instance\" as \"cloud-logging-admin\": Key: 'Config.Project' Error:Field validation for 'Project' failed on the 'required' tag",
+			err: "error unmarshaling source: unable to parse source \"my-instance\" as \"cloud-logging-admin\": sources[my-instance].project is required; add a \"project\" field",
 		},
 	}
 	for _, tc := range tcs {
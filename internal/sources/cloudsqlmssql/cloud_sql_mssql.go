@@ -17,6 +17,7 @@ package cloudsqlmssql
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"net/url"
 	"slices"
@@ -24,6 +25,11 @@ import (
 	"cloud.google.com/go/cloudsqlconn/sqlserver/mssql"
 	"github.com/goccy/go-yaml"
 	"github.com/googleapis/mcp-toolbox/internal/sources"
+	"github.com/googleapis/mcp-toolbox/internal/sources/queryguard"
+	"github.com/googleapis/mcp-toolbox/internal/sources/readreplica"
+	"github.com/googleapis/mcp-toolbox/internal/sources/schemadoc"
+	"github.com/googleapis/mcp-toolbox/internal/sources/schemasnapshot"
+	"github.com/googleapis/mcp-toolbox/internal/telemetry"
 	"github.com/googleapis/mcp-toolbox/internal/util"
 	"github.com/googleapis/mcp-toolbox/internal/util/orderedmap"
 	"go.opentelemetry.io/otel/trace"
@@ -35,7 +41,7 @@ const SourceType string = "cloud-sql-mssql"
 var _ sources.SourceConfig = Config{}
 
 func init() {
-	if !sources.Register(SourceType, newConfig) {
+	if !sources.Register[*Source](SourceType, newConfig) {
 		panic(fmt.Sprintf("source type %q already registered", SourceType))
 	}
 }
@@ -59,6 +65,23 @@ type Config struct {
 	User     string         `yaml:"user" validate:"required"`
 	Password string         `yaml:"password" validate:"required"`
 	Database string         `yaml:"database" validate:"required"`
+	// SchemaSnapshot optionally compares the schema of the database against
+	// a snapshot at startup.
+	SchemaSnapshot *schemasnapshot.Config `yaml:"schemaSnapshot"`
+	// ReadOnly rejects the statements that may write.
+	ReadOnly bool `yaml:"readOnly"`
+	// Patterns optionally restrict the statements that tools may run.
+	queryguard.Patterns `yaml:",inline"`
+	// ReadReplicas optionally serve the invocations of read-only tools.
+	ReadReplicas []ReadReplica `yaml:"readReplicas"`
+}
+
+// ReadReplica is a Cloud SQL read replica of the instance, which shares its
+// credentials.
+type ReadReplica struct {
+	Instance string `yaml:"instance" validate:"required"`
+	// Region defaults to the region of the source.
+	Region string `yaml:"region"`
 }
 
 func (r Config) SourceConfigType() string {
@@ -67,6 +90,11 @@ func (r Config) SourceConfigType() string {
 }
 
 func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
+	guard, err := r.Patterns.Compile(r.Name)
+	if err != nil {
+		return nil, err
+	}
+
 	// Initializes a Cloud SQL MSSQL source
 	db, err := initCloudSQLMssqlConnection(ctx, tracer, r.Name, r.Project, r.Region, r.Instance, r.IPType.String(), r.User, r.Password, r.Database)
 	if err != nil {
@@ -79,9 +107,28 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 		return nil, fmt.Errorf("unable to connect successfully: %w", err)
 	}
 
+	replicas := make([]*readreplica.Replica, 0, len(r.ReadReplicas))
+	for _, rep := range r.ReadReplicas {
+		region := rep.Region
+		if region == "" {
+			region = r.Region
+		}
+		replicaDB, err := initCloudSQLMssqlConnection(ctx, tracer, r.Name, r.Project, region, rep.Instance, r.IPType.String(), r.User, r.Password, r.Database)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create db connection to read replica %q: %w", rep.Instance, err)
+		}
+		replicas = append(replicas, &readreplica.Replica{Name: fmt.Sprintf("%s:%s:%s", r.Project, region, rep.Instance), DB: replicaDB})
+	}
+
 	s := &Source{
-		Config: r,
-		Db:     db,
+		Config:   r,
+		Db:       db,
+		replicas: readreplica.New(db, replicas),
+	}
+	s.Guard = guard.WithReadOnly(r.ReadOnly, queryguard.SQLServer)
+	s.Report, err = schemasnapshot.Check(ctx, r.Name, r.SchemaSnapshot, schemasnapshot.SQLServerQuery, s.RunSQL)
+	if err != nil {
+		return nil, fmt.Errorf("unable to check schema snapshot: %w", err)
 	}
 	return s, nil
 }
@@ -90,7 +137,12 @@ var _ sources.Source = &Source{}
 
 type Source struct {
 	Config
+	schemasnapshot.Report
+	queryguard.Guard
 	Db *sql.DB
+	// replicas routes the queries of read-only invocations to the read
+	// replicas.
+	replicas *readreplica.Router
 }
 
 func (s *Source) SourceType() string {
@@ -107,8 +159,29 @@ func (s *Source) MSSQLDB() *sql.DB {
 	return s.Db
 }
 
+// Close closes the connection pool of the source.
+func (s *Source) Close() error {
+	return errors.Join(s.replicas.Close(), s.Db.Close())
+}
+
+// Ping checks the connection of the source to the database.
+func (s *Source) Ping(ctx context.Context) error {
+	return s.Db.PingContext(ctx)
+}
+
+// PoolStats returns the statistics of the connection pool of the source.
+func (s *Source) PoolStats() telemetry.PoolStats {
+	return telemetry.DBStats(s.Db.Stats())
+}
+
+// DescribeSchema returns the tables, columns and comments of the source.
+func (s *Source) DescribeSchema(ctx context.Context) (*schemadoc.Schema, error) {
+	return schemadoc.Describe(ctx, schemadoc.SQLServerQuery, s.RunSQL)
+}
+
 func (s *Source) RunSQL(ctx context.Context, statement string, params []any) (any, error) {
-	results, err := s.MSSQLDB().QueryContext(ctx, statement, params...)
+	util.RecordStatement(ctx, statement, params)
+	results, err := s.replicas.QueryContext(ctx, statement, params...)
 	if err != nil {
 		return nil, fmt.Errorf("unable to execute query: %w", err)
 	}
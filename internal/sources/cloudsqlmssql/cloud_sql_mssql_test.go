@@ -58,6 +58,41 @@ func TestParseFromYamlCloudSQLMssql(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "with read replicas",
+			in: `
+			kind: source
+			name: my-instance
+			type: cloud-sql-mssql
+			project: my-project
+			region: my-region
+			instance: my-instance
+			database: my_db
+			user: my_user
+			password: my_pass
+			readReplicas:
+				- instance: my-replica
+				- instance: my-other-replica
+				  region: my-other-region
+			`,
+			want: map[string]sources.SourceConfig{
+				"my-instance": cloudsqlmssql.Config{
+					Name:     "my-instance",
+					Type:     cloudsqlmssql.SourceType,
+					Project:  "my-project",
+					Region:   "my-region",
+					Instance: "my-instance",
+					IPType:   "public",
+					Database: "my_db",
+					User:     "my_user",
+					Password: "my_pass",
+					ReadReplicas: []cloudsqlmssql.ReadReplica{
+						{Instance: "my-replica"},
+						{Instance: "my-other-replica", Region: "my-other-region"},
+					},
+				},
+			},
+		},
 		{
 			desc: "psc ipType",
 			in: `
@@ -151,7 +186,7 @@ func TestFailParseFromYaml(t *testing.T) {
 			user: my_user
 			password: my_pass
 			`,
-			err: "error unmarshaling source: unable to parse source \"my-instance\" as \"cloud-sql-mssql\": Key: 'Config.Project' Error:Field validation for 'Project' failed on the 'required' tag",
+			err: "error unmarshaling source: unable to parse source \"my-instance\" as \"cloud-sql-mssql\": sources[my-instance].project is required; add a \"project\" field",
 		},
 	}
 	for _, tc := range tcs {
@@ -21,10 +21,16 @@ import (
 	"net/url"
 	"slices"
 
+	"cloud.google.com/go/cloudsqlconn"
 	"cloud.google.com/go/cloudsqlconn/mysql/mysql"
 	"github.com/goccy/go-yaml"
 	"github.com/googleapis/mcp-toolbox/internal/sources"
+	"github.com/googleapis/mcp-toolbox/internal/sources/iamauth"
+	"github.com/googleapis/mcp-toolbox/internal/sources/queryguard"
+	"github.com/googleapis/mcp-toolbox/internal/sources/schemadoc"
+	"github.com/googleapis/mcp-toolbox/internal/sources/schemasnapshot"
 	"github.com/googleapis/mcp-toolbox/internal/sources/sqlcommenter"
+	"github.com/googleapis/mcp-toolbox/internal/telemetry"
 	"github.com/googleapis/mcp-toolbox/internal/tools/mysql/mysqlcommon"
 	"github.com/googleapis/mcp-toolbox/internal/util"
 	"github.com/googleapis/mcp-toolbox/internal/util/orderedmap"
@@ -37,7 +43,7 @@ const SourceType string = "cloud-sql-mysql"
 var _ sources.SourceConfig = Config{}
 
 func init() {
-	if !sources.Register(SourceType, newConfig) {
+	if !sources.Register[*Source](SourceType, newConfig) {
 		panic(fmt.Sprintf("source type %q already registered", SourceType))
 	}
 }
@@ -47,6 +53,9 @@ func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources
 	if err := decoder.DecodeContext(ctx, &actual); err != nil {
 		return nil, err
 	}
+	if err := actual.Login.Validate(actual.User, actual.Password); err != nil {
+		return nil, err
+	}
 	return actual, nil
 }
 
@@ -61,6 +70,17 @@ type Config struct {
 	Password     string         `yaml:"password"`
 	Database     string         `yaml:"database"`
 	SQLCommenter *bool          `yaml:"sqlCommenter"`
+	// Login optionally requires IAM authentication, as the principal of the
+	// Application Default Credentials or an impersonated service account.
+	iamauth.Login `yaml:",inline"`
+	// SchemaSnapshot optionally compares the schema of the database against
+	// a snapshot at startup.
+	SchemaSnapshot *schemasnapshot.Config `yaml:"schemaSnapshot"`
+	// ReadOnly rejects the statements that may write, and runs every
+	// transaction read-only.
+	ReadOnly bool `yaml:"readOnly"`
+	// Patterns optionally restrict the statements that tools may run.
+	queryguard.Patterns `yaml:",inline"`
 }
 
 func (r Config) SourceConfigType() string {
@@ -68,7 +88,12 @@ func (r Config) SourceConfigType() string {
 }
 
 func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
-	pool, err := initCloudSQLMySQLConnectionPool(ctx, tracer, r.Name, r.Project, r.Region, r.Instance, r.IPType.String(), r.User, r.Password, r.Database)
+	guard, err := r.Patterns.Compile(r.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	pool, err := initCloudSQLMySQLConnectionPool(ctx, tracer, r.Name, r.Project, r.Region, r.Instance, r.IPType.String(), r.Login.User(r.User, "mysql"), r.Password, r.Database, r.ImpersonateServiceAccount, r.ReadOnly)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create pool: %w", err)
 	}
@@ -82,6 +107,11 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 		Config: r,
 		Pool:   pool,
 	}
+	s.Guard = guard.WithReadOnly(r.ReadOnly, queryguard.MySQL)
+	s.Report, err = schemasnapshot.Check(ctx, r.Name, r.SchemaSnapshot, schemasnapshot.MySQLQuery, s.RunSQL)
+	if err != nil {
+		return nil, fmt.Errorf("unable to check schema snapshot: %w", err)
+	}
 	return s, nil
 }
 
@@ -89,6 +119,8 @@ var _ sources.Source = &Source{}
 
 type Source struct {
 	Config
+	schemasnapshot.Report
+	queryguard.Guard
 	Pool *sql.DB
 }
 
@@ -104,6 +136,21 @@ func (s *Source) MySQLPool() *sql.DB {
 	return s.Pool
 }
 
+// Close closes the connection pool of the source.
+func (s *Source) Close() error {
+	return s.Pool.Close()
+}
+
+// Ping checks the connection of the source to the database.
+func (s *Source) Ping(ctx context.Context) error {
+	return s.Pool.PingContext(ctx)
+}
+
+// PoolStats returns the statistics of the connection pool of the source.
+func (s *Source) PoolStats() telemetry.PoolStats {
+	return telemetry.DBStats(s.Pool.Stats())
+}
+
 func (s *Source) MySQLDatabase() string {
 	return s.Database
 }
@@ -124,8 +171,14 @@ func (s *Source) RetrieveSourceVersion(ctx context.Context) (string, error) {
 	return version, nil
 }
 
+// DescribeSchema returns the tables, columns and comments of the source.
+func (s *Source) DescribeSchema(ctx context.Context) (*schemadoc.Schema, error) {
+	return schemadoc.Describe(ctx, schemadoc.MySQLQuery, s.RunSQL)
+}
+
 func (s *Source) RunSQL(ctx context.Context, statement string, params []any) (any, error) {
 	statement = sqlcommenter.PrependComment(ctx, statement, SourceType, s.SQLCommenter)
+	util.RecordStatement(ctx, statement, params)
 	results, err := s.MySQLPool().QueryContext(ctx, statement, params...)
 	if err != nil {
 		return nil, fmt.Errorf("unable to execute query: %w", err)
@@ -205,7 +258,7 @@ func getConnectionConfig(ctx context.Context, user, pass string) (string, string
 	return user, pass, useIAM, nil
 }
 
-func initCloudSQLMySQLConnectionPool(ctx context.Context, tracer trace.Tracer, name, project, region, instance, ipType, user, pass, dbname string) (*sql.DB, error) {
+func initCloudSQLMySQLConnectionPool(ctx context.Context, tracer trace.Tracer, name, project, region, instance, ipType, user, pass, dbname, impersonateServiceAccount string, readOnly bool) (*sql.DB, error) {
 	//nolint:all // Reassigned ctx
 	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceType, name)
 	defer span.End()
@@ -225,6 +278,15 @@ func initCloudSQLMySQLConnectionPool(ctx context.Context, tracer trace.Tracer, n
 	if err != nil {
 		return nil, err
 	}
+	if impersonateServiceAccount != "" {
+		// log in as the impersonated service account rather than the ADC
+		// principal
+		apiTS, loginTS, err := iamauth.TokenSources(ctx, impersonateServiceAccount, iamauth.CloudSQLLoginScope)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, cloudsqlconn.WithIAMAuthNTokenSources(apiTS, loginTS))
+	}
 
 	// Use a unique driver name based on the source name.
 	driverName := fmt.Sprintf("cloudsql-mysql-%s", name)
@@ -260,6 +322,12 @@ func initCloudSQLMySQLConnectionPool(ctx context.Context, tracer trace.Tracer, n
 		)
 	}
 
+	if readOnly {
+		// the database rejects the writes the statement check misses, such
+		// as the ones of functions
+		dsn += "&transaction_read_only=1"
+	}
+
 	db, err := sql.Open(
 		driverName,
 		dsn,
@@ -22,6 +22,7 @@ import (
 	"github.com/googleapis/mcp-toolbox/internal/server"
 	"github.com/googleapis/mcp-toolbox/internal/sources"
 	"github.com/googleapis/mcp-toolbox/internal/sources/cloudsqlmysql"
+	"github.com/googleapis/mcp-toolbox/internal/sources/iamauth"
 	"github.com/googleapis/mcp-toolbox/internal/testutils"
 )
 
@@ -56,6 +57,30 @@ func TestParseFromYamlCloudSQLMySQL(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "iam auth",
+			in: `
+			kind: source
+			name: my-mysql-instance
+			type: cloud-sql-mysql
+			project: my-project
+			region: my-region
+			instance: my-instance
+			auth: iam
+			impersonateServiceAccount: toolbox@my-project.iam.gserviceaccount.com
+			`,
+			want: map[string]sources.SourceConfig{
+				"my-mysql-instance": cloudsqlmysql.Config{
+					Name:     "my-mysql-instance",
+					Type:     cloudsqlmysql.SourceType,
+					Project:  "my-project",
+					Region:   "my-region",
+					Instance: "my-instance",
+					IPType:   "public",
+					Login:    iamauth.Login{Auth: iamauth.IAM, ImpersonateServiceAccount: "toolbox@my-project.iam.gserviceaccount.com"},
+				},
+			},
+		},
 		{
 			desc: "public ipType and database",
 			in: `
@@ -161,6 +186,20 @@ func TestFailParseFromYaml(t *testing.T) {
 		in   string
 		err  string
 	}{
+		{
+			desc: "password auth without password",
+			in: `
+			kind: source
+			name: my-mysql-instance
+			type: cloud-sql-mysql
+			project: my-project
+			region: my-region
+			instance: my-instance
+			auth: password
+			user: my_user
+			`,
+			err: "error unmarshaling source: unable to parse source \"my-mysql-instance\" as \"cloud-sql-mysql\": auth \"password\" requires both user and password",
+		},
 		{
 			desc: "invalid ipType",
 			in: `
@@ -205,7 +244,7 @@ func TestFailParseFromYaml(t *testing.T) {
 			user: my_user
 			password: my_pass
 			`,
-			err: "error unmarshaling source: unable to parse source \"my-mysql-instance\" as \"cloud-sql-mysql\": Key: 'Config.Project' Error:Field validation for 'Project' failed on the 'required' tag",
+			err: "error unmarshaling source: unable to parse source \"my-mysql-instance\" as \"cloud-sql-mysql\": sources[my-mysql-instance].project is required; add a \"project\" field",
 		},
 	}
 	for _, tc := range tcs {
@@ -18,11 +18,21 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"slices"
 
 	"cloud.google.com/go/cloudsqlconn"
 	"github.com/goccy/go-yaml"
 	"github.com/googleapis/mcp-toolbox/internal/sources"
+	"github.com/googleapis/mcp-toolbox/internal/sources/appname"
+	"github.com/googleapis/mcp-toolbox/internal/sources/iamauth"
+	"github.com/googleapis/mcp-toolbox/internal/sources/pgpool"
+	"github.com/googleapis/mcp-toolbox/internal/sources/queryguard"
+	"github.com/googleapis/mcp-toolbox/internal/sources/schemadoc"
+	"github.com/googleapis/mcp-toolbox/internal/sources/schemasnapshot"
+	"github.com/googleapis/mcp-toolbox/internal/sources/sessionrole"
 	"github.com/googleapis/mcp-toolbox/internal/sources/sqlcommenter"
+	"github.com/googleapis/mcp-toolbox/internal/sources/statementcache"
+	"github.com/googleapis/mcp-toolbox/internal/telemetry"
 	"github.com/googleapis/mcp-toolbox/internal/util"
 	"github.com/googleapis/mcp-toolbox/internal/util/orderedmap"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -35,7 +45,7 @@ const SourceType string = "cloud-sql-postgres"
 var _ sources.SourceConfig = Config{}
 
 func init() {
-	if !sources.Register(SourceType, newConfig) {
+	if !sources.Register[*Source](SourceType, newConfig) {
 		panic(fmt.Sprintf("source type %q already registered", SourceType))
 	}
 }
@@ -45,20 +55,53 @@ func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources
 	if err := decoder.DecodeContext(ctx, &actual); err != nil {
 		return nil, err
 	}
+	if err := actual.Login.Validate(actual.User, actual.Password); err != nil {
+		return nil, err
+	}
 	return actual, nil
 }
 
 type Config struct {
-	Name         string         `yaml:"name" validate:"required"`
-	Type         string         `yaml:"type" validate:"required"`
-	Project      string         `yaml:"project" validate:"required"`
-	Region       string         `yaml:"region" validate:"required"`
-	Instance     string         `yaml:"instance" validate:"required"`
-	IPType       sources.IPType `yaml:"ipType" validate:"required"`
-	Database     string         `yaml:"database" validate:"required"`
-	User         string         `yaml:"user"`
-	Password     string         `yaml:"password"`
-	SQLCommenter *bool          `yaml:"sqlCommenter"`
+	Name     string         `yaml:"name" validate:"required"`
+	Type     string         `yaml:"type" validate:"required"`
+	Project  string         `yaml:"project" validate:"required"`
+	Region   string         `yaml:"region" validate:"required"`
+	Instance string         `yaml:"instance" validate:"required"`
+	IPType   sources.IPType `yaml:"ipType" validate:"required"`
+	Database string         `yaml:"database" validate:"required"`
+	// Databases lists further databases of the instance that tools can
+	// select. Each database gets its own connection pool, since PostgreSQL
+	// cannot switch the database of an open connection.
+	Databases    []string `yaml:"databases"`
+	User         string   `yaml:"user"`
+	Password     string   `yaml:"password"`
+	SQLCommenter *bool    `yaml:"sqlCommenter"`
+	// Login optionally requires IAM authentication, as the principal of the
+	// Application Default Credentials or an impersonated service account.
+	iamauth.Login `yaml:",inline"`
+	// ConnectTimeout optionally bounds how long a single connection attempt may
+	// take, in seconds.
+	ConnectTimeout *int `yaml:"connectTimeout" validate:"omitempty,gte=1"`
+	// SchemaSnapshot optionally compares the schema of the database against
+	// a snapshot at startup.
+	SchemaSnapshot *schemasnapshot.Config `yaml:"schemaSnapshot"`
+	// ReadOnly rejects the statements that may write, and runs every
+	// transaction read-only.
+	ReadOnly bool `yaml:"readOnly"`
+	// QueryTimeout bounds how long a statement may run, as a duration such
+	// as "30s", through the statement_timeout of the connections.
+	QueryTimeout string `yaml:"queryTimeout"`
+	// Patterns optionally restrict the statements that tools may run.
+	queryguard.Patterns `yaml:",inline"`
+	// Capacities optionally size the statement caches of the connections.
+	statementcache.Capacities `yaml:",inline"`
+	// Options optionally name the connections in pg_stat_activity.
+	appname.Options `yaml:",inline"`
+	// Sizing optionally sizes the connection pool and defers connecting.
+	pgpool.Sizing `yaml:",inline"`
+	// Impersonation optionally runs the queries of authenticated invocations
+	// as a database role mapped from their principal.
+	Impersonation *sessionrole.Config `yaml:"impersonation"`
 }
 
 func (r Config) SourceConfigType() string {
@@ -66,27 +109,46 @@ func (r Config) SourceConfigType() string {
 }
 
 func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
-	pool, err := initCloudSQLPgConnectionPool(ctx, tracer, r.Name, r.Project, r.Region, r.Instance, r.IPType.String(), r.User, r.Password, r.Database)
+	guard, err := r.Patterns.Compile(r.Name)
 	if err != nil {
-		return nil, fmt.Errorf("unable to create pool: %w", err)
+		return nil, err
 	}
-
-	err = pool.Ping(ctx)
-	if err != nil {
-		pool.Close()
-		return nil, fmt.Errorf("unable to connect successfully: %w", err)
+	if err := r.Sizing.Validate(); err != nil {
+		return nil, err
 	}
 
-	var res int
-	err = pool.QueryRow(ctx, "SELECT 1").Scan(&res)
+	pools, err := initCloudSQLPgConnectionPools(ctx, tracer, r)
 	if err != nil {
-		pool.Close()
-		return nil, fmt.Errorf("failed to execute 'SELECT 1' after connection: %w", err)
+		return nil, fmt.Errorf("unable to create pool: %w", err)
 	}
-
 	s := &Source{
 		Config: r,
-		Pool:   pool,
+		Pool:   pools[r.Database],
+		pools:  pools,
+	}
+
+	for db, pool := range pools {
+		if r.LazyConnect {
+			break
+		}
+		err = pool.Ping(ctx)
+		if err != nil {
+			_ = s.Close()
+			return nil, fmt.Errorf("unable to connect successfully to database %q: %w", db, err)
+		}
+
+		var res int
+		err = pool.QueryRow(ctx, "SELECT 1").Scan(&res)
+		if err != nil {
+			_ = s.Close()
+			return nil, fmt.Errorf("failed to execute 'SELECT 1' after connection to database %q: %w", db, err)
+		}
+	}
+
+	s.Guard = guard.WithReadOnly(r.ReadOnly, queryguard.Postgres)
+	s.Report, err = schemasnapshot.Check(ctx, r.Name, r.SchemaSnapshot, schemasnapshot.PostgresQuery, s.RunSQL)
+	if err != nil {
+		return nil, fmt.Errorf("unable to check schema snapshot: %w", err)
 	}
 	return s, nil
 }
@@ -95,7 +157,12 @@ var _ sources.Source = &Source{}
 
 type Source struct {
 	Config
+	schemasnapshot.Report
+	queryguard.Guard
+	// Pool is the connection pool of the default database.
 	Pool *pgxpool.Pool
+	// pools are the connection pools of every database, by name.
+	pools map[string]*pgxpool.Pool
 }
 
 func (s *Source) SourceType() string {
@@ -110,35 +177,94 @@ func (s *Source) PostgresPool() *pgxpool.Pool {
 	return s.Pool
 }
 
+// Close closes the connection pools of the source.
+func (s *Source) Close() error {
+	for _, pool := range s.pools {
+		pool.Close()
+	}
+	return nil
+}
+
+// Ping checks the connection of the source to the database.
+func (s *Source) Ping(ctx context.Context) error {
+	return s.Pool.Ping(ctx)
+}
+
+// PoolStats returns the statistics of the connection pool of the source.
+func (s *Source) PoolStats() telemetry.PoolStats {
+	return telemetry.PgxPoolStats(s.Pool.Stat())
+}
+
+// DescribeSchema returns the tables, columns and comments of the source.
+func (s *Source) DescribeSchema(ctx context.Context) (*schemadoc.Schema, error) {
+	return schemadoc.Describe(ctx, schemadoc.PostgresQuery, s.RunSQL)
+}
+
+// RunSQL runs statement on the default database.
 func (s *Source) RunSQL(ctx context.Context, statement string, params []any) (any, error) {
+	return s.runSQL(ctx, s.Pool, statement, params)
+}
+
+// StreamSQL runs statement on the default database like RunSQL, passing
+// each row to emit as it is read rather than buffering the result. It stops
+// at the first error emit returns, and returns it.
+func (s *Source) StreamSQL(ctx context.Context, statement string, params []any, emit func(orderedmap.Row) error) error {
+	return s.streamSQL(ctx, s.Pool, statement, params, emit)
+}
+
+// RunSQLOnDatabase runs statement on database, which must be the default
+// database or one of the databases of the source.
+func (s *Source) RunSQLOnDatabase(ctx context.Context, database, statement string, params []any) (any, error) {
+	pool, ok := s.pools[database]
+	if !ok {
+		return nil, fmt.Errorf("database %q is not one of the databases of source %q", database, s.Name)
+	}
+	return s.runSQL(ctx, pool, statement, params)
+}
+
+func (s *Source) runSQL(ctx context.Context, pool *pgxpool.Pool, statement string, params []any) (any, error) {
+	out := []any{}
+	err := s.streamSQL(ctx, pool, statement, params, func(row orderedmap.Row) error {
+		out = append(out, row)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (s *Source) streamSQL(ctx context.Context, pool *pgxpool.Pool, statement string, params []any, emit func(orderedmap.Row) error) error {
 	statement = sqlcommenter.PrependComment(ctx, statement, SourceType, s.SQLCommenter)
-	results, err := s.PostgresPool().Query(ctx, statement, params...)
+	util.RecordStatement(ctx, statement, params)
+	results, err := pool.Query(ctx, statement, params...)
 	if err != nil {
-		return nil, fmt.Errorf("unable to execute query: %w", err)
+		return fmt.Errorf("unable to execute query: %w", err)
 	}
 	defer results.Close()
 
 	fields := results.FieldDescriptions()
-	out := []any{}
 	for results.Next() {
 		values, err := results.Values()
 		if err != nil {
-			return nil, fmt.Errorf("unable to parse row: %w", err)
+			return fmt.Errorf("unable to parse row: %w", err)
 		}
 		row := orderedmap.Row{}
 		for i, f := range fields {
 			row.Add(f.Name, values[i])
 		}
-		out = append(out, row)
+		if err := emit(row); err != nil {
+			return err
+		}
 	}
 	// this will catch actual query execution errors
 	if err := results.Err(); err != nil {
-		return nil, fmt.Errorf("unable to execute query: %w", err)
+		return fmt.Errorf("unable to execute query: %w", err)
 	}
-	return out, nil
+	return nil
 }
 
-func getConnectionConfig(ctx context.Context, user, pass, dbname string) (string, bool, error) {
+func getConnectionConfig(ctx context.Context, user, pass, dbname string, connectTimeout *int) (string, bool, error) {
 	userAgent, err := util.UserAgentFromContext(ctx)
 	if err != nil {
 		userAgent = "genai-toolbox"
@@ -149,7 +275,7 @@ func getConnectionConfig(ctx context.Context, user, pass, dbname string) (string
 	if user != "" && pass != "" {
 		dsn := fmt.Sprintf("user=%s password=%s dbname=%s sslmode=disable application_name=%s", user, pass, dbname, userAgent)
 		useIAM = false
-		return dsn, useIAM, nil
+		return withConnectTimeout(dsn, connectTimeout), useIAM, nil
 	}
 
 	// If username is empty, fetch email from ADC
@@ -168,49 +294,111 @@ func getConnectionConfig(ctx context.Context, user, pass, dbname string) (string
 
 	// Construct IAM connection string with username
 	dsn := fmt.Sprintf("user=%s dbname=%s sslmode=disable application_name=%s", user, dbname, userAgent)
-	return dsn, useIAM, nil
+	return withConnectTimeout(dsn, connectTimeout), useIAM, nil
 }
 
-func initCloudSQLPgConnectionPool(ctx context.Context, tracer trace.Tracer, name, project, region, instance, ipType, user, pass, dbname string) (*pgxpool.Pool, error) {
-	//nolint:all // Reassigned ctx
-	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceType, name)
-	defer span.End()
-
-	// Configure the driver to connect to the database
-	dsn, useIAM, err := getConnectionConfig(ctx, user, pass, dbname)
-	if err != nil {
-		return nil, fmt.Errorf("unable to get Cloud SQL connection config: %w", err)
+func withConnectTimeout(dsn string, connectTimeout *int) string {
+	if connectTimeout == nil {
+		return dsn
 	}
+	return fmt.Sprintf("%s connect_timeout=%d", dsn, *connectTimeout)
+}
 
-	config, err := pgxpool.ParseConfig(dsn)
-	if err != nil {
-		return nil, fmt.Errorf("unable to parse connection uri: %w", err)
+// databases returns the default database followed by the further databases
+// of the source, without duplicates.
+func (r Config) databases() []string {
+	dbs := []string{r.Database}
+	for _, db := range r.Databases {
+		if !slices.Contains(dbs, db) {
+			dbs = append(dbs, db)
+		}
 	}
+	return dbs
+}
+
+// initCloudSQLPgConnectionPools returns a connection pool for each database
+// of r. The pools share a single Cloud SQL dialer.
+func initCloudSQLPgConnectionPools(ctx context.Context, tracer trace.Tracer, r Config) (map[string]*pgxpool.Pool, error) {
+	//nolint:all // Reassigned ctx
+	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceType, r.Name)
+	defer span.End()
 
-	// Create a new dialer with options
 	userAgent, err := util.UserAgentFromContext(ctx)
 	if err != nil {
 		return nil, err
 	}
-	opts, err := sources.GetCloudSQLOpts(ipType, userAgent, useIAM)
+	statementTimeout, err := sources.PostgresStatementTimeout(r.QueryTimeout)
 	if err != nil {
 		return nil, err
 	}
-	d, err := cloudsqlconn.NewDialer(ctx, opts...)
-	if err != nil {
-		return nil, fmt.Errorf("unable to parse connection uri: %w", err)
-	}
+	i := fmt.Sprintf("%s:%s:%s", r.Project, r.Region, r.Instance)
 
-	// Tell the driver to use the Cloud SQL Go Connector to create connections
-	i := fmt.Sprintf("%s:%s:%s", project, region, instance)
-	config.ConnConfig.DialFunc = func(ctx context.Context, _ string, instance string) (net.Conn, error) {
-		return d.Dial(ctx, i)
+	var d *cloudsqlconn.Dialer
+	pools := make(map[string]*pgxpool.Pool)
+	closeAll := func() {
+		for _, pool := range pools {
+			pool.Close()
+		}
 	}
+	for _, dbname := range r.databases() {
+		// Configure the driver to connect to the database
+		dsn, useIAM, err := getConnectionConfig(ctx, r.Login.User(r.User, "postgres"), r.Password, dbname, r.ConnectTimeout)
+		if err != nil {
+			closeAll()
+			return nil, fmt.Errorf("unable to get Cloud SQL connection config: %w", err)
+		}
 
-	// Interact with the driver directly as you normally would
-	pool, err := pgxpool.NewWithConfig(ctx, config)
-	if err != nil {
-		return nil, err
+		config, err := pgxpool.ParseConfig(dsn)
+		if err != nil {
+			closeAll()
+			return nil, fmt.Errorf("unable to parse connection uri: %w", err)
+		}
+		r.Capacities.Apply(config.ConnConfig)
+		r.Options.Apply(config)
+		r.Sizing.Apply(config)
+		r.Impersonation.Apply(config)
+		if r.ReadOnly {
+			// the database rejects the writes the statement check misses,
+			// such as the ones of functions
+			config.ConnConfig.RuntimeParams["default_transaction_read_only"] = "on"
+		}
+		if statementTimeout != "" {
+			config.ConnConfig.RuntimeParams["statement_timeout"] = statementTimeout
+		}
+
+		if d == nil {
+			// Create a new dialer with options
+			opts, err := sources.GetCloudSQLOpts(r.IPType.String(), userAgent, useIAM)
+			if err != nil {
+				return nil, err
+			}
+			if r.ImpersonateServiceAccount != "" {
+				// log in as the impersonated service account rather than
+				// the ADC principal
+				apiTS, loginTS, err := iamauth.TokenSources(ctx, r.ImpersonateServiceAccount, iamauth.CloudSQLLoginScope)
+				if err != nil {
+					return nil, err
+				}
+				opts = append(opts, cloudsqlconn.WithIAMAuthNTokenSources(apiTS, loginTS))
+			}
+			d, err = cloudsqlconn.NewDialer(ctx, opts...)
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse connection uri: %w", err)
+			}
+		}
+
+		// Tell the driver to use the Cloud SQL Go Connector to create connections
+		config.ConnConfig.DialFunc = func(ctx context.Context, _ string, instance string) (net.Conn, error) {
+			return d.Dial(ctx, i)
+		}
+
+		// Interact with the driver directly as you normally would
+		pool, err := pgxpool.NewWithConfig(ctx, config)
+		if err != nil {
+			closeAll()
+			return nil, err
+		}
+		pools[dbname] = pool
 	}
-	return pool, nil
+	return pools, nil
 }
@@ -22,10 +22,12 @@ import (
 	"github.com/googleapis/mcp-toolbox/internal/server"
 	"github.com/googleapis/mcp-toolbox/internal/sources"
 	"github.com/googleapis/mcp-toolbox/internal/sources/cloudsqlpg"
+	"github.com/googleapis/mcp-toolbox/internal/sources/iamauth"
 	"github.com/googleapis/mcp-toolbox/internal/testutils"
 )
 
 func TestParseFromYamlCloudSQLPg(t *testing.T) {
+	connectTimeout := 5
 	tcs := []struct {
 		desc string
 		in   string
@@ -58,6 +60,65 @@ func TestParseFromYamlCloudSQLPg(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "iam auth with impersonation",
+			in: `
+			kind: source
+			name: my-pg-instance
+			type: cloud-sql-postgres
+			project: my-project
+			region: my-region
+			instance: my-instance
+			database: my_db
+			auth: iam
+			impersonateServiceAccount: toolbox@my-project.iam.gserviceaccount.com
+			`,
+			want: map[string]sources.SourceConfig{
+				"my-pg-instance": cloudsqlpg.Config{
+					Name:     "my-pg-instance",
+					Type:     cloudsqlpg.SourceType,
+					Project:  "my-project",
+					Region:   "my-region",
+					Instance: "my-instance",
+					IPType:   "public",
+					Database: "my_db",
+					Login:    iamauth.Login{Auth: iamauth.IAM, ImpersonateServiceAccount: "toolbox@my-project.iam.gserviceaccount.com"},
+				},
+			},
+		},
+		{
+			desc: "multiple databases",
+			in: `
+			kind: source
+			name: my-pg-instance
+			type: cloud-sql-postgres
+			project: my-project
+			region: my-region
+			instance: my-instance
+			database: my_db
+			databases:
+				- reporting
+				- billing
+			connectTimeout: 5
+			user: my_user
+			password: my_pass
+			`,
+			want: map[string]sources.SourceConfig{
+				"my-pg-instance": cloudsqlpg.Config{
+					Name:           "my-pg-instance",
+					Type:           cloudsqlpg.SourceType,
+					Project:        "my-project",
+					Region:         "my-region",
+					Instance:       "my-instance",
+					IPType:         "public",
+					Database:       "my_db",
+					Databases:      []string{"reporting", "billing"},
+					User:           "my_user",
+					Password:       "my_pass",
+					ConnectTimeout: &connectTimeout,
+				},
+			},
+		},
 		{
 			desc: "public ipType",
 			in: `
@@ -163,6 +224,36 @@ func TestFailParseFromYaml(t *testing.T) {
 		in   string
 		err  string
 	}{
+		{
+			desc: "password with iam auth",
+			in: `
+			kind: source
+			name: my-pg-instance
+			type: cloud-sql-postgres
+			project: my-project
+			region: my-region
+			instance: my-instance
+			database: my_db
+			auth: iam
+			user: my_user
+			password: my_pass
+			`,
+			err: "error unmarshaling source: unable to parse source \"my-pg-instance\" as \"cloud-sql-postgres\": password cannot be set with auth \"iam\"",
+		},
+		{
+			desc: "invalid impersonated service account",
+			in: `
+			kind: source
+			name: my-pg-instance
+			type: cloud-sql-postgres
+			project: my-project
+			region: my-region
+			instance: my-instance
+			database: my_db
+			impersonateServiceAccount: someone@example.com
+			`,
+			err: "error unmarshaling source: unable to parse source \"my-pg-instance\" as \"cloud-sql-postgres\": invalid impersonateServiceAccount \"someone@example.com\": must be a service account email",
+		},
 		{
 			desc: "invalid ipType",
 			in: `
@@ -195,6 +286,20 @@ func TestFailParseFromYaml(t *testing.T) {
 			`,
 			err: "error unmarshaling source: unable to parse source \"my-pg-instance\" as \"cloud-sql-postgres\": [2:1] unknown field \"foo\"\n   1 | database: my_db\n>  2 | foo: bar\n       ^\n   3 | instance: my-instance\n   4 | name: my-pg-instance\n   5 | password: my_pass\n   6 | ",
 		},
+		{
+			desc: "invalid connectTimeout",
+			in: `
+			kind: source
+			name: my-pg-instance
+			type: cloud-sql-postgres
+			project: my-project
+			region: my-region
+			instance: my-instance
+			database: my_db
+			connectTimeout: 0
+			`,
+			err: "error unmarshaling source: unable to parse source \"my-pg-instance\" as \"cloud-sql-postgres\": sources[my-pg-instance].connectTimeout has invalid value \"0\": must be at least 1; set it to 1 or more",
+		},
 		{
 			desc: "missing required field",
 			in: `
@@ -207,7 +312,7 @@ func TestFailParseFromYaml(t *testing.T) {
 			user: my_user
 			password: my_pass
 			`,
-			err: "error unmarshaling source: unable to parse source \"my-pg-instance\" as \"cloud-sql-postgres\": Key: 'Config.Project' Error:Field validation for 'Project' failed on the 'required' tag",
+			err: "error unmarshaling source: unable to parse source \"my-pg-instance\" as \"cloud-sql-postgres\": sources[my-pg-instance].project is required; add a \"project\" field",
 		},
 	}
 	for _, tc := range tcs {
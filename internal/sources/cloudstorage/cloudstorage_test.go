@@ -109,7 +109,7 @@ func TestFailParseFromYaml(t *testing.T) {
 			name: my-gcs
 			type: cloud-storage
 			`,
-			err: "error unmarshaling source: unable to parse source \"my-gcs\" as \"cloud-storage\": Key: 'Config.Project' Error:Field validation for 'Project' failed on the 'required' tag",
+			err: "error unmarshaling source: unable to parse source \"my-gcs\" as \"cloud-storage\": sources[my-gcs].project is required; add a \"project\" field",
 		},
 	}
 	for _, tc := range tcs {
@@ -28,6 +28,7 @@ import (
 	crdbpgx "github.com/cockroachdb/cockroach-go/v2/crdb/crdbpgxv5"
 	"github.com/goccy/go-yaml"
 	"github.com/googleapis/mcp-toolbox/internal/sources"
+	"github.com/googleapis/mcp-toolbox/internal/sources/pgpool"
 	"github.com/googleapis/mcp-toolbox/internal/util"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -39,7 +40,7 @@ const SourceType string = "cockroachdb"
 var _ sources.SourceConfig = Config{}
 
 func init() {
-	if !sources.Register(SourceType, newConfig) {
+	if !sources.Register[*Source](SourceType, newConfig) {
 		panic(fmt.Sprintf("source type %q already registered", SourceType))
 	}
 }
@@ -91,6 +92,9 @@ type Config struct {
 	EnableTelemetry  bool   `yaml:"enableTelemetry"`  // Default: true
 	TelemetryVerbose bool   `yaml:"telemetryVerbose"` // Default: false
 	ClusterID        string `yaml:"clusterID"`        // Optional cluster identifier for telemetry
+
+	// Sizing optionally sizes the connection pool and defers connecting.
+	pgpool.Sizing `yaml:",inline"`
 }
 
 func (r Config) SourceConfigType() string {
@@ -102,8 +106,11 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 	if err != nil {
 		return nil, fmt.Errorf("invalid retryBaseDelay: %w", err)
 	}
+	if err := r.Sizing.Validate(); err != nil {
+		return nil, err
+	}
 
-	pool, err := initCockroachDBConnectionPoolWithRetry(ctx, tracer, r.Name, r.Host, r.Port, r.User, r.Password, r.Database, r.QueryParams, r.MaxRetries, retryBaseDelay)
+	pool, err := initCockroachDBConnectionPoolWithRetry(ctx, tracer, r.Name, r.Host, r.Port, r.User, r.Password, r.Database, r.QueryParams, r.MaxRetries, retryBaseDelay, r.Sizing)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create pool: %w", err)
 	}
@@ -134,6 +141,12 @@ func (s *Source) CockroachDBPool() *pgxpool.Pool {
 	return s.Pool
 }
 
+// Close closes the connection pool of the source.
+func (s *Source) Close() error {
+	s.Pool.Close()
+	return nil
+}
+
 func (s *Source) PostgresPool() *pgxpool.Pool {
 	return s.Pool
 }
@@ -368,7 +381,7 @@ func (s *Source) EmitTelemetry(ctx context.Context, event TelemetryEvent) {
 	}
 }
 
-func initCockroachDBConnectionPoolWithRetry(ctx context.Context, tracer trace.Tracer, name, host, port, user, pass, dbname string, queryParams map[string]string, maxRetries int, baseDelay time.Duration) (*pgxpool.Pool, error) {
+func initCockroachDBConnectionPoolWithRetry(ctx context.Context, tracer trace.Tracer, name, host, port, user, pass, dbname string, queryParams map[string]string, maxRetries int, baseDelay time.Duration, sizing pgpool.Sizing) (*pgxpool.Pool, error) {
 	//nolint:all
 	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceType, name)
 	defer span.End()
@@ -392,9 +405,18 @@ func initCockroachDBConnectionPoolWithRetry(ctx context.Context, tracer trace.Tr
 		RawQuery: ConvertParamMapToRawQuery(queryParams),
 	}
 
+	config, err := pgxpool.ParseConfig(connURL.String())
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse connection uri: %w", err)
+	}
+	sizing.Apply(config)
+
 	var pool *pgxpool.Pool
 	for attempt := 0; attempt <= maxRetries; attempt++ {
-		pool, err = pgxpool.New(ctx, connURL.String())
+		pool, err = pgxpool.NewWithConfig(ctx, config)
+		if err == nil && sizing.LazyConnect {
+			return pool, nil
+		}
 		if err == nil {
 			err = pool.Ping(ctx)
 		}
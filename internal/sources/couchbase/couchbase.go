@@ -35,7 +35,7 @@ const SourceType string = "couchbase"
 var _ sources.SourceConfig = Config{}
 
 func init() {
-	if !sources.Register(SourceType, newConfig) {
+	if !sources.Register[*Source](SourceType, newConfig) {
 		panic(fmt.Sprintf("source type %q already registered", SourceType))
 	}
 }
@@ -54,6 +54,7 @@ type Config struct {
 	ConnectionString     string `yaml:"connectionString" validate:"required"`
 	Bucket               string `yaml:"bucket" validate:"required"`
 	Scope                string `yaml:"scope" validate:"required"`
+	Collection           string `yaml:"collection"`
 	Username             string `yaml:"username"`
 	Password             string `yaml:"password"`
 	ClientCert           string `yaml:"clientCert"`
@@ -108,6 +109,12 @@ func (s *Source) CouchbaseScope() *gocb.Scope {
 	return s.Scope
 }
 
+// CouchbaseCollection returns the name of the default collection configured
+// for the source, or an empty string if none is set.
+func (s *Source) CouchbaseCollection() string {
+	return s.Collection
+}
+
 func (s *Source) CouchbaseQueryScanConsistency() uint {
 	return s.QueryScanConsistency
 }
@@ -133,6 +140,35 @@ func (s *Source) RunSQL(statement string, params parameters.ParamValues) (any, e
 	return out, nil
 }
 
+// RunN1QL executes a N1QL statement within the source's scope, binding params
+// as named parameters. When readOnly is set, the query service rejects
+// statements that modify data.
+func (s *Source) RunN1QL(ctx context.Context, statement string, params map[string]any, readOnly bool) (any, error) {
+	results, err := s.CouchbaseScope().Query(statement, &gocb.QueryOptions{
+		ScanConsistency: gocb.QueryScanConsistency(s.CouchbaseQueryScanConsistency()),
+		NamedParameters: params,
+		Readonly:        readOnly,
+		Context:         ctx,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to execute query: %w", err)
+	}
+	defer results.Close()
+
+	out := []any{}
+	for results.Next() {
+		var result json.RawMessage
+		if err := results.Row(&result); err != nil {
+			return nil, fmt.Errorf("error processing row: %w", err)
+		}
+		out = append(out, result)
+	}
+	if err := results.Err(); err != nil {
+		return nil, fmt.Errorf("error reading query results: %w", err)
+	}
+	return out, nil
+}
+
 func (r Config) createCouchbaseOptions() (gocb.ClusterOptions, error) {
 	cbOpts := gocb.ClusterOptions{}
 
@@ -55,6 +55,28 @@ func TestParseFromYamlCouchbase(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "with collection",
+			in: `
+			kind: source
+			name: my-couchbase-instance
+			type: couchbase
+			connectionString: localhost
+			bucket: travel-sample
+			scope: inventory
+			collection: hotel
+			`,
+			want: map[string]sources.SourceConfig{
+				"my-couchbase-instance": couchbase.Config{
+					Name:             "my-couchbase-instance",
+					Type:             couchbase.SourceType,
+					ConnectionString: "localhost",
+					Bucket:           "travel-sample",
+					Scope:            "inventory",
+					Collection:       "hotel",
+				},
+			},
+		},
 		{
 			desc: "with TLS configuration",
 			in: `
@@ -135,7 +157,7 @@ func TestFailParseFromYaml(t *testing.T) {
 			bucket: travel-sample
 			scope: inventory
 			`,
-			err: "error unmarshaling source: unable to parse source \"my-couchbase-instance\" as \"couchbase\": Key: 'Config.ConnectionString' Error:Field validation for 'ConnectionString' failed on the 'required' tag",
+			err: "error unmarshaling source: unable to parse source \"my-couchbase-instance\" as \"couchbase\": sources[my-couchbase-instance].connectionString is required; add a \"connectionString\" field",
 		},
 	}
 	for _, tc := range tcs {
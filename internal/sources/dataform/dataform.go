@@ -0,0 +1,263 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataform
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	dataform "cloud.google.com/go/dataform/apiv1beta1"
+	"cloud.google.com/go/dataform/apiv1beta1/dataformpb"
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/mcp-toolbox/internal/sources"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/impersonate"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+)
+
+const SourceType string = "dataform"
+
+// pollInterval is how often a running workflow invocation is polled for
+// completion.
+const pollInterval = 5 * time.Second
+
+// validate interface
+var _ sources.SourceConfig = Config{}
+
+func init() {
+	if !sources.Register[*Source](SourceType, newConfig) {
+		panic(fmt.Sprintf("source type %q already registered", SourceType))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name           string `yaml:"name" validate:"required"`
+	Type           string `yaml:"type" validate:"required"`
+	Project        string `yaml:"project" validate:"required"`
+	Location       string `yaml:"location" validate:"required"`
+	RepositoryID   string `yaml:"repositoryId" validate:"required"`
+	ServiceAccount string `yaml:"serviceAccount" validate:"omitempty,email"`
+}
+
+func (r Config) SourceConfigType() string {
+	return SourceType
+}
+
+func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
+	client, err := initDataformConnection(ctx, tracer, r.Name, r.Project, r.ServiceAccount)
+	if err != nil {
+		return nil, err
+	}
+	s := &Source{
+		Config: r,
+		Client: client,
+	}
+	return s, nil
+}
+
+var _ sources.Source = &Source{}
+
+type Source struct {
+	Config
+	Client *dataform.Client
+}
+
+func (s *Source) SourceType() string {
+	return SourceType
+}
+
+func (s *Source) ToConfig() sources.SourceConfig {
+	return s.Config
+}
+
+func (s *Source) Close() error {
+	return s.Client.Close()
+}
+
+// RepositoryName returns the resource name of the repository of the source.
+func (s *Source) RepositoryName() string {
+	return fmt.Sprintf("projects/%s/locations/%s/repositories/%s", s.Project, s.Location, s.RepositoryID)
+}
+
+func initDataformConnection(
+	ctx context.Context,
+	tracer trace.Tracer,
+	name string,
+	project string,
+	serviceAccount string,
+) (*dataform.Client, error) {
+	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceType, name)
+	defer span.End()
+
+	userAgent, err := util.UserAgentFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []option.ClientOption{option.WithUserAgent(userAgent)}
+	if serviceAccount != "" {
+		ts, err := impersonate.CredentialsTokenSource(ctx, impersonate.CredentialsConfig{
+			TargetPrincipal: serviceAccount,
+			Scopes:          []string{sources.CloudPlatformScope},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create impersonated credentials for %q for project %q: %w", serviceAccount, project, err)
+		}
+		opts = append(opts, option.WithTokenSource(ts))
+	} else {
+		cred, err := google.FindDefaultCredentials(ctx, sources.CloudPlatformScope)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find default Google Cloud credentials for project %q: %w", project, err)
+		}
+		opts = append(opts, option.WithCredentials(cred))
+	}
+
+	client, err := dataform.NewClient(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Dataform client for project %q: %w", project, err)
+	}
+	return client, nil
+}
+
+// WorkflowRun is the outcome of a workflow invocation.
+type WorkflowRun struct {
+	Name          string   `json:"name"`
+	State         string   `json:"state"`
+	FailedActions []string `json:"failedActions"`
+}
+
+// CompilationResultName returns the resource name of a compilation result of
+// the repository, given either its ID or its resource name.
+func (s *Source) CompilationResultName(compilationResult string) (string, error) {
+	prefix := s.RepositoryName() + "/compilationResults/"
+	if !strings.Contains(compilationResult, "/") {
+		return prefix + compilationResult, nil
+	}
+	if !strings.HasPrefix(compilationResult, prefix) || len(compilationResult) == len(prefix) {
+		return "", fmt.Errorf("compilation result %q is not a compilation result of repository %q", compilationResult, s.RepositoryName())
+	}
+	return compilationResult, nil
+}
+
+// InvokeWorkflow runs the compilation result of the repository, polls the
+// invocation until it completes, and returns its state and the names of the
+// actions that failed.
+func (s *Source) InvokeWorkflow(ctx context.Context, compilationResult string) (*WorkflowRun, error) {
+	name, err := s.CompilationResultName(compilationResult)
+	if err != nil {
+		return nil, err
+	}
+	invocation, err := s.Client.CreateWorkflowInvocation(ctx, &dataformpb.CreateWorkflowInvocationRequest{
+		Parent: s.RepositoryName(),
+		WorkflowInvocation: &dataformpb.WorkflowInvocation{
+			CompilationSource: &dataformpb.WorkflowInvocation_CompilationResult{CompilationResult: name},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create workflow invocation: %w", err)
+	}
+
+	invocation, err = waitForInvocation(ctx, invocation, pollInterval, func(ctx context.Context, name string) (*dataformpb.WorkflowInvocation, error) {
+		return s.Client.GetWorkflowInvocation(ctx, &dataformpb.GetWorkflowInvocationRequest{Name: name})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	failed, err := s.failedActions(ctx, invocation.GetName())
+	if err != nil {
+		return nil, err
+	}
+	return &WorkflowRun{
+		Name:          invocation.GetName(),
+		State:         invocation.GetState().String(),
+		FailedActions: failed,
+	}, nil
+}
+
+// waitForInvocation polls a workflow invocation every interval until it is no
+// longer running or being cancelled.
+func waitForInvocation(
+	ctx context.Context,
+	invocation *dataformpb.WorkflowInvocation,
+	interval time.Duration,
+	get func(context.Context, string) (*dataformpb.WorkflowInvocation, error),
+) (*dataformpb.WorkflowInvocation, error) {
+	for running(invocation) {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("workflow invocation %q did not complete: %w", invocation.GetName(), ctx.Err())
+		case <-time.After(interval):
+		}
+		var err error
+		invocation, err = get(ctx, invocation.GetName())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get workflow invocation: %w", err)
+		}
+	}
+	return invocation, nil
+}
+
+func running(invocation *dataformpb.WorkflowInvocation) bool {
+	switch invocation.GetState() {
+	case dataformpb.WorkflowInvocation_RUNNING, dataformpb.WorkflowInvocation_CANCELING:
+		return true
+	}
+	return false
+}
+
+// failedActions returns the names of the targets of the actions of a workflow
+// invocation that failed.
+func (s *Source) failedActions(ctx context.Context, name string) ([]string, error) {
+	failed := []string{}
+	it := s.Client.QueryWorkflowInvocationActions(ctx, &dataformpb.QueryWorkflowInvocationActionsRequest{Name: name})
+	for {
+		action, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to query workflow invocation actions: %w", err)
+		}
+		if action.GetState() == dataformpb.WorkflowInvocationAction_FAILED {
+			failed = append(failed, targetName(action.GetTarget()))
+		}
+	}
+	return failed, nil
+}
+
+// targetName returns the dotted name of a target, omitting its empty parts.
+func targetName(t *dataformpb.Target) string {
+	var parts []string
+	for _, p := range []string{t.GetDatabase(), t.GetSchema(), t.GetName()} {
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return strings.Join(parts, ".")
+}
@@ -0,0 +1,166 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataform_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/internal/server"
+	"github.com/googleapis/mcp-toolbox/internal/sources"
+	"github.com/googleapis/mcp-toolbox/internal/sources/dataform"
+	"github.com/googleapis/mcp-toolbox/internal/testutils"
+)
+
+func TestParseFromYamlDataform(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		want server.SourceConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			kind: source
+			name: my-dataform
+			type: dataform
+			project: my-project
+			location: us-central1
+			repositoryId: my-repo
+			`,
+			want: map[string]sources.SourceConfig{
+				"my-dataform": dataform.Config{
+					Name:         "my-dataform",
+					Type:         dataform.SourceType,
+					Project:      "my-project",
+					Location:     "us-central1",
+					RepositoryID: "my-repo",
+				},
+			},
+		},
+		{
+			desc: "with service account",
+			in: `
+			kind: source
+			name: my-dataform
+			type: dataform
+			project: my-project
+			location: us-central1
+			repositoryId: my-repo
+			serviceAccount: runner@my-project.iam.gserviceaccount.com
+			`,
+			want: map[string]sources.SourceConfig{
+				"my-dataform": dataform.Config{
+					Name:           "my-dataform",
+					Type:           dataform.SourceType,
+					Project:        "my-project",
+					Location:       "us-central1",
+					RepositoryID:   "my-repo",
+					ServiceAccount: "runner@my-project.iam.gserviceaccount.com",
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, _, _, _, _, _, err := server.UnmarshalPrimitiveConfig(context.Background(), testutils.FormatYaml(tc.in))
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if !cmp.Equal(tc.want, got) {
+				t.Fatalf("incorrect parse: want %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestFailParseFromYaml(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		err  string
+	}{
+		{
+			desc: "missing repository",
+			in: `
+			kind: source
+			name: my-dataform
+			type: dataform
+			project: my-project
+			location: us-central1
+			`,
+			err: "repositoryId is required",
+		},
+		{
+			desc: "invalid service account",
+			in: `
+			kind: source
+			name: my-dataform
+			type: dataform
+			project: my-project
+			location: us-central1
+			repositoryId: my-repo
+			serviceAccount: runner
+			`,
+			err: `serviceAccount has invalid value "runner": fails the "email" validation`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, _, _, _, _, _, err := server.UnmarshalPrimitiveConfig(context.Background(), testutils.FormatYaml(tc.in))
+			if err == nil {
+				t.Fatalf("expect parsing to fail")
+			}
+			if !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("unexpected error: got %q, want it to contain %q", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestCompilationResultName(t *testing.T) {
+	s := &dataform.Source{Config: dataform.Config{Project: "p", Location: "us-central1", RepositoryID: "repo"}}
+	repo := "projects/p/locations/us-central1/repositories/repo"
+	tcs := []struct {
+		desc    string
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{desc: "id", in: "abc", want: repo + "/compilationResults/abc"},
+		{desc: "resource name", in: repo + "/compilationResults/abc", want: repo + "/compilationResults/abc"},
+		{desc: "other repository", in: "projects/p/locations/us-central1/repositories/other/compilationResults/abc", wantErr: true},
+		{desc: "missing id", in: repo + "/compilationResults/", wantErr: true},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := s.CompilationResultName(tc.in)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != tc.want {
+				t.Errorf("unexpected name: got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataform
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/dataform/apiv1beta1/dataformpb"
+)
+
+func TestWaitForInvocation(t *testing.T) {
+	states := []dataformpb.WorkflowInvocation_State{
+		dataformpb.WorkflowInvocation_RUNNING,
+		dataformpb.WorkflowInvocation_FAILED,
+	}
+	calls := 0
+	get := func(_ context.Context, name string) (*dataformpb.WorkflowInvocation, error) {
+		state := states[calls]
+		calls++
+		return &dataformpb.WorkflowInvocation{Name: name, State: state}, nil
+	}
+	start := &dataformpb.WorkflowInvocation{Name: "inv", State: dataformpb.WorkflowInvocation_RUNNING}
+
+	got, err := waitForInvocation(context.Background(), start, time.Millisecond, get)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got.GetState() != dataformpb.WorkflowInvocation_FAILED {
+		t.Errorf("unexpected state: %s", got.GetState())
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 polls, got %d", calls)
+	}
+}
+
+func TestWaitForInvocationCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	get := func(context.Context, string) (*dataformpb.WorkflowInvocation, error) {
+		t.Fatal("unexpected poll")
+		return nil, nil
+	}
+	start := &dataformpb.WorkflowInvocation{Name: "inv", State: dataformpb.WorkflowInvocation_RUNNING}
+	if _, err := waitForInvocation(ctx, start, time.Hour, get); err == nil {
+		t.Fatal("expected an error")
+	}
+}
+
+func TestTargetName(t *testing.T) {
+	got := targetName(&dataformpb.Target{Database: "my-project", Schema: "reporting", Name: "daily_sales"})
+	if want := "my-project.reporting.daily_sales"; got != want {
+		t.Errorf("unexpected target name: got %q, want %q", got, want)
+	}
+	if got := targetName(&dataformpb.Target{Name: "assertion"}); got != "assertion" {
+		t.Errorf("unexpected target name: got %q", got)
+	}
+}
@@ -85,7 +85,7 @@ func TestFailParseFromYaml(t *testing.T) {
 			name: my-instance
 			type: datalineage
 			`,
-			err: "error unmarshaling source: unable to parse source \"my-instance\" as \"datalineage\": Key: 'Config.Project' Error:Field validation for 'Project' failed on the 'required' tag",
+			err: "error unmarshaling source: unable to parse source \"my-instance\" as \"datalineage\": sources[my-instance].project is required; add a \"project\" field",
 		},
 	}
 	for _, tc := range tcs {
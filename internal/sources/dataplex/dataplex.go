@@ -50,7 +50,7 @@ var operationNameRegex = regexp.MustCompile(`^projects/[^/]+/locations/[^/]+/ope
 var _ sources.SourceConfig = Config{}
 
 func init() {
-	if !sources.Register(SourceType, newConfig) {
+	if !sources.Register[*Source](SourceType, newConfig) {
 		panic(fmt.Sprintf("source type %q already registered", SourceType))
 	}
 }
@@ -104,7 +104,7 @@ func TestFailParseFromYaml(t *testing.T) {
 			name: my-instance
 			type: dataplex
 			`,
-			err: "error unmarshaling source: unable to parse source \"my-instance\" as \"dataplex\": Key: 'Config.Project' Error:Field validation for 'Project' failed on the 'required' tag",
+			err: "error unmarshaling source: unable to parse source \"my-instance\" as \"dataplex\": sources[my-instance].project is required; add a \"project\" field",
 		},
 	}
 	for _, tc := range tcs {
@@ -89,7 +89,7 @@ func TestFailParseFromYaml(t *testing.T) {
 				type: dataproc
 				region: my-region
 			`,
-			err: "error unmarshaling source: unable to parse source \"my-instance\" as \"dataproc\": Key: 'Config.Project' Error:Field validation for 'Project' failed on the 'required' tag",
+			err: "error unmarshaling source: unable to parse source \"my-instance\" as \"dataproc\": sources[my-instance].project is required; add a \"project\" field",
 		},
 		{
 			desc: "missing required field region",
@@ -99,7 +99,7 @@ func TestFailParseFromYaml(t *testing.T) {
 				type: dataproc
 				project: my-project
 			`,
-			err: "error unmarshaling source: unable to parse source \"my-instance\" as \"dataproc\": Key: 'Config.Region' Error:Field validation for 'Region' failed on the 'required' tag",
+			err: "error unmarshaling source: unable to parse source \"my-instance\" as \"dataproc\": sources[my-instance].region is required; add a \"region\" field",
 		},
 	}
 	for _, tc := range tcs {
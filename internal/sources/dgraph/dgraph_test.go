@@ -112,7 +112,7 @@ func TestFailParseFromYaml(t *testing.T) {
 			name: my-dgraph-instance
 			type: dgraph
 			`,
-			err: "error unmarshaling source: unable to parse source \"my-dgraph-instance\" as \"dgraph\": Key: 'Config.DgraphUrl' Error:Field validation for 'DgraphUrl' failed on the 'required' tag",
+			err: "error unmarshaling source: unable to parse source \"my-dgraph-instance\" as \"dgraph\": sources[my-dgraph-instance].dgraphUrl is required; add a \"dgraphUrl\" field",
 		},
 	}
 	for _, tc := range tcs {
@@ -0,0 +1,305 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"path"
+	"reflect"
+	"strings"
+	"time"
+
+	_ "github.com/duckdb/duckdb-go/v2" // DuckDB driver
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/mcp-toolbox/internal/sources"
+	"github.com/googleapis/mcp-toolbox/internal/sources/queryguard"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/orderedmap"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const SourceType string = "duckdb"
+
+// Formats of the files a source attaches.
+const (
+	FormatParquet = "parquet"
+	FormatCSV     = "csv"
+	FormatJSON    = "json"
+)
+
+// readFunctions are the table functions reading the files of each format.
+var readFunctions = map[string]string{
+	FormatParquet: "read_parquet",
+	FormatCSV:     "read_csv_auto",
+	FormatJSON:    "read_json_auto",
+}
+
+// validate interface
+var _ sources.SourceConfig = Config{}
+
+func init() {
+	if !sources.Register[*Source](SourceType, newConfig) {
+		panic(fmt.Sprintf("source type %q already registered", SourceType))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+// File is a file, or a glob of files, that a source attaches as a view.
+type File struct {
+	// Name is the name of the view of the file.
+	Name string `yaml:"name" validate:"required"`
+	// Path is the local path or gs:// URL of the file. It may contain globs,
+	// such as "gs://my-bucket/exports/*.parquet".
+	Path string `yaml:"path" validate:"required"`
+	// Format is the format of the file: "parquet", "csv" or "json". Defaults
+	// to the format of the extension of the path.
+	Format string `yaml:"format" validate:"omitempty,oneof=parquet csv json"`
+}
+
+// format returns the format of f.
+func (f File) format() (string, error) {
+	if f.Format != "" {
+		return f.Format, nil
+	}
+	p := strings.ToLower(strings.TrimSuffix(f.Path, ".gz"))
+	switch path.Ext(p) {
+	case ".parquet", ".parq":
+		return FormatParquet, nil
+	case ".csv", ".tsv":
+		return FormatCSV, nil
+	case ".json", ".jsonl", ".ndjson":
+		return FormatJSON, nil
+	}
+	return "", fmt.Errorf("unable to infer the format of file %q from its extension: set its format", f.Name)
+}
+
+// GCSConfig is an HMAC key reading gs:// paths.
+type GCSConfig struct {
+	KeyID  string `yaml:"keyId" validate:"required"`
+	Secret string `yaml:"secret" validate:"required"`
+}
+
+type Config struct {
+	Name string `yaml:"name" validate:"required"`
+	Type string `yaml:"type" validate:"required"`
+	// Database is the path of the database file. Defaults to an in-memory
+	// database.
+	Database string `yaml:"database"`
+	// Files are attached as views of the database.
+	Files []File `yaml:"files" validate:"dive"`
+	// GCS optionally authenticates the reads of gs:// paths.
+	GCS *GCSConfig `yaml:"gcs"`
+	// Threads bounds the threads DuckDB runs queries with. Defaults to the
+	// number of cores.
+	Threads  int  `yaml:"threads" validate:"omitempty,gte=1"`
+	ReadOnly bool `yaml:"readOnly"`
+	// Patterns optionally restrict the statements that tools may run.
+	queryguard.Patterns `yaml:",inline"`
+}
+
+func (r Config) SourceConfigType() string {
+	return SourceType
+}
+
+func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
+	guard, err := r.Patterns.Compile(r.Name)
+	if err != nil {
+		return nil, err
+	}
+	setup, err := r.setupStatements()
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := initDuckDBConnection(ctx, tracer, r.Name, r.Database)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create db connection: %w", err)
+	}
+	for _, stmt := range setup {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("unable to set up database: %w", err)
+		}
+	}
+
+	s := &Source{
+		Config: r,
+		Db:     db,
+	}
+	s.Guard = guard.WithReadOnly(r.ReadOnly, queryguard.DuckDB)
+	return s, nil
+}
+
+// setupStatements returns the statements setting up the database of the
+// source: its settings, the secret reading GCS and the views of its files.
+func (r Config) setupStatements() ([]string, error) {
+	var stmts []string
+	if r.Threads > 0 {
+		stmts = append(stmts, fmt.Sprintf("SET threads = %d", r.Threads))
+	}
+	if r.GCS != nil {
+		stmts = append(stmts, fmt.Sprintf("CREATE OR REPLACE SECRET toolbox_gcs (TYPE gcs, KEY_ID %s, SECRET %s)", quoteLiteral(r.GCS.KeyID), quoteLiteral(r.GCS.Secret)))
+	}
+	names := make(map[string]bool, len(r.Files))
+	for _, f := range r.Files {
+		if names[f.Name] {
+			return nil, fmt.Errorf("source %q attaches file %q twice", r.Name, f.Name)
+		}
+		names[f.Name] = true
+		format, err := f.format()
+		if err != nil {
+			return nil, fmt.Errorf("source %q: %w", r.Name, err)
+		}
+		stmts = append(stmts, fmt.Sprintf("CREATE OR REPLACE VIEW %s AS SELECT * FROM %s(%s)", quoteIdentifier(f.Name), readFunctions[format], quoteLiteral(f.Path)))
+	}
+	return stmts, nil
+}
+
+func quoteLiteral(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+func quoteIdentifier(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
+
+var _ sources.Source = &Source{}
+
+type Source struct {
+	Config
+	queryguard.Guard
+	Db *sql.DB
+}
+
+func (s *Source) SourceType() string {
+	return SourceType
+}
+
+func (s *Source) ToConfig() sources.SourceConfig {
+	return s.Config
+}
+
+func (s *Source) DuckDB() *sql.DB {
+	return s.Db
+}
+
+// Close closes the database of the source.
+func (s *Source) Close() error {
+	return s.Db.Close()
+}
+
+// Ping checks the connection of the source to its database.
+func (s *Source) Ping(ctx context.Context) error {
+	return s.Db.PingContext(ctx)
+}
+
+func (s *Source) RunSQL(ctx context.Context, statement string, params []any) (any, error) {
+	util.RecordStatement(ctx, statement, params)
+	rows, err := s.DuckDB().QueryContext(ctx, statement, params...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	cols, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("unable to get column names: %w", err)
+	}
+	rawValues := make([]any, len(cols))
+	values := make([]any, len(cols))
+	for i := range rawValues {
+		values[i] = &rawValues[i]
+	}
+
+	out := []any{}
+	for rows.Next() {
+		if err := rows.Scan(values...); err != nil {
+			return nil, fmt.Errorf("unable to scan row: %w", err)
+		}
+		row := orderedmap.Row{}
+		for i, name := range cols {
+			row.Add(name, convertValue(rawValues[i]))
+		}
+		out = append(out, row)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+	return out, nil
+}
+
+// convertValue converts the values DuckDB returns that have no JSON form,
+// such as decimals, UUIDs and maps with non-string keys.
+func convertValue(v any) any {
+	switch v := v.(type) {
+	case nil, bool, string, []byte, time.Time:
+		return v
+	case interface{ Float64() float64 }:
+		// decimals
+		return v.Float64()
+	case fmt.Stringer:
+		// UUIDs
+		return v.String()
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = convertValue(item)
+		}
+		return out
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, item := range v {
+			out[k] = convertValue(item)
+		}
+		return out
+	}
+	// MAP values, whose keys may be of any type
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Map {
+		out := make(map[string]any, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			out[fmt.Sprint(iter.Key().Interface())] = convertValue(iter.Value().Interface())
+		}
+		return out
+	}
+	return v
+}
+
+func initDuckDBConnection(ctx context.Context, tracer trace.Tracer, name, database string) (*sql.DB, error) {
+	//nolint:all // Reassigned ctx
+	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceType, name)
+	defer span.End()
+
+	// The connections of a database share its catalog, so the views of its
+	// files are visible to every invocation.
+	db, err := sql.Open("duckdb", database)
+	if err != nil {
+		return nil, fmt.Errorf("sql.Open: %w", err)
+	}
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("unable to connect successfully: %w", err)
+	}
+	return db, nil
+}
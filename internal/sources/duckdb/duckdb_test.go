@@ -0,0 +1,178 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package duckdb_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/internal/server"
+	"github.com/googleapis/mcp-toolbox/internal/sources"
+	"github.com/googleapis/mcp-toolbox/internal/sources/duckdb"
+	"github.com/googleapis/mcp-toolbox/internal/testutils"
+	"github.com/googleapis/mcp-toolbox/internal/util/orderedmap"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestParseFromYamlDuckDB(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		want server.SourceConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+            kind: source
+            name: my-duckdb
+            type: duckdb
+            `,
+			want: map[string]sources.SourceConfig{
+				"my-duckdb": duckdb.Config{
+					Name: "my-duckdb",
+					Type: duckdb.SourceType,
+				},
+			},
+		},
+		{
+			desc: "files on gcs",
+			in: `
+            kind: source
+            name: my-duckdb
+            type: duckdb
+            database: /data/analytics.duckdb
+            readOnly: true
+            threads: 4
+            files:
+                - name: sales
+                  path: gs://my-bucket/exports/sales/*.parquet
+                - name: regions
+                  path: /data/regions.txt
+                  format: csv
+            gcs:
+                keyId: my-key
+                secret: my-secret
+            `,
+			want: map[string]sources.SourceConfig{
+				"my-duckdb": duckdb.Config{
+					Name:     "my-duckdb",
+					Type:     duckdb.SourceType,
+					Database: "/data/analytics.duckdb",
+					ReadOnly: true,
+					Threads:  4,
+					Files: []duckdb.File{
+						{Name: "sales", Path: "gs://my-bucket/exports/sales/*.parquet"},
+						{Name: "regions", Path: "/data/regions.txt", Format: "csv"},
+					},
+					GCS: &duckdb.GCSConfig{KeyID: "my-key", Secret: "my-secret"},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, _, _, _, _, _, err := server.UnmarshalPrimitiveConfig(context.Background(), testutils.FormatYaml(tc.in))
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestFailParseFromYamlDuckDB(t *testing.T) {
+	in := `
+            kind: source
+            name: my-duckdb
+            type: duckdb
+            files:
+                - name: sales
+                  path: /data/sales.xlsx
+                  format: xlsx
+            `
+	if _, _, _, _, _, _, err := server.UnmarshalPrimitiveConfig(context.Background(), testutils.FormatYaml(in)); err == nil {
+		t.Fatalf("expect parsing to fail")
+	}
+}
+
+func initSource(t *testing.T, cfg duckdb.Config) *duckdb.Source {
+	t.Helper()
+	s, err := cfg.Initialize(context.Background(), noop.NewTracerProvider().Tracer("test"))
+	if err != nil {
+		t.Fatalf("unable to initialize source: %s", err)
+	}
+	t.Cleanup(func() {
+		_ = s.(*duckdb.Source).Close()
+	})
+	return s.(*duckdb.Source)
+}
+
+func TestFiles(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	csvPath := filepath.Join(dir, "sales.csv")
+	if err := os.WriteFile(csvPath, []byte("region,amount\neu,10\nus,20\neu,5\n"), 0o600); err != nil {
+		t.Fatalf("unable to write file: %s", err)
+	}
+	s := initSource(t, duckdb.Config{
+		Name:  "my-duckdb",
+		Type:  duckdb.SourceType,
+		Files: []duckdb.File{{Name: "sales", Path: csvPath}},
+	})
+
+	got, err := s.RunSQL(ctx, "SELECT region, CAST(sum(amount) AS INTEGER) AS total FROM sales WHERE region = $1 GROUP BY region", []any{"eu"})
+	if err != nil {
+		t.Fatalf("unable to query: %s", err)
+	}
+	want := []any{orderedmap.Row{Columns: []orderedmap.Column{{Name: "region", Value: "eu"}, {Name: "total", Value: int32(15)}}}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected rows (-want +got):\n%s", diff)
+	}
+
+	// Parquet written from the view is attached like the CSV file.
+	parquetPath := filepath.Join(dir, "sales.parquet")
+	if _, err := s.RunSQL(ctx, "COPY sales TO '"+parquetPath+"' (FORMAT parquet)", nil); err != nil {
+		t.Fatalf("unable to write parquet: %s", err)
+	}
+	p := initSource(t, duckdb.Config{
+		Name:  "my-parquet",
+		Type:  duckdb.SourceType,
+		Files: []duckdb.File{{Name: "sales", Path: filepath.Join(dir, "*.parquet")}},
+	})
+	got, err = p.RunSQL(ctx, "SELECT count(*) AS n FROM sales", nil)
+	if err != nil {
+		t.Fatalf("unable to query: %s", err)
+	}
+	want = []any{orderedmap.Row{Columns: []orderedmap.Column{{Name: "n", Value: int64(3)}}}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected rows (-want +got):\n%s", diff)
+	}
+}
+
+func TestInitializeUnknownFormat(t *testing.T) {
+	cfg := duckdb.Config{
+		Name:  "my-duckdb",
+		Type:  duckdb.SourceType,
+		Files: []duckdb.File{{Name: "sales", Path: "/data/sales.xlsx"}},
+	}
+	if _, err := cfg.Initialize(context.Background(), noop.NewTracerProvider().Tracer("test")); err == nil {
+		t.Fatalf("expected initialization to fail")
+	}
+}
@@ -19,7 +19,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"strings"
 
 	"github.com/elastic/elastic-transport-go/v8/elastictransport"
 	"github.com/elastic/go-elasticsearch/v9"
@@ -32,11 +35,17 @@ import (
 
 const SourceType string = "elasticsearch"
 
+// Distributions of the clusters the source connects to.
+const (
+	DistributionElasticsearch = "elasticsearch"
+	DistributionOpenSearch    = "opensearch"
+)
+
 // validate interface
 var _ sources.SourceConfig = Config{}
 
 func init() {
-	if !sources.Register(SourceType, newConfig) {
+	if !sources.Register[*Source](SourceType, newConfig) {
 		panic(fmt.Sprintf("source type %q already registered", SourceType))
 	}
 }
@@ -56,6 +65,10 @@ type Config struct {
 	Username  string   `yaml:"username"`
 	Password  string   `yaml:"password"`
 	APIKey    string   `yaml:"apikey"`
+	// Distribution is "elasticsearch", the default, or "opensearch".
+	// OpenSearch clusters are connected to without the product check of the
+	// Elasticsearch client, and do not support ES|QL.
+	Distribution string `yaml:"distribution" validate:"omitempty,oneof=elasticsearch opensearch"`
 }
 
 func (c Config) SourceConfigType() string {
@@ -113,7 +126,12 @@ func (c Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 		return nil, fmt.Errorf("elasticsearch source %q requires either username/password or an API key", c.Name)
 	}
 
-	client, err := elasticsearch.NewBaseClient(cfg)
+	var client EsClient
+	if c.Distribution == DistributionOpenSearch {
+		client, err = newOpenSearchClient(cfg)
+	} else {
+		client, err = elasticsearch.NewBaseClient(cfg)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -152,6 +170,80 @@ func (s *Source) ElasticsearchClient() EsClient {
 	return s.Client
 }
 
+// newOpenSearchClient returns a client of the transport underlying the
+// Elasticsearch client, which does not check that the cluster is
+// Elasticsearch.
+func newOpenSearchClient(cfg elasticsearch.Config) (EsClient, error) {
+	urls := make([]*url.URL, 0, len(cfg.Addresses))
+	for _, addr := range cfg.Addresses {
+		u, err := url.Parse(strings.TrimRight(addr, "/"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid address %q: %w", addr, err)
+		}
+		urls = append(urls, u)
+	}
+	return elastictransport.New(elastictransport.Config{
+		URLs:            urls,
+		Username:        cfg.Username,
+		Password:        cfg.Password,
+		APIKey:          cfg.APIKey,
+		Header:          cfg.Header,
+		Instrumentation: cfg.Instrumentation,
+	})
+}
+
+// SearchResult is the result of a search: its hits, and its aggregations if
+// the query requested any.
+type SearchResult struct {
+	Hits         []any          `json:"hits"`
+	Aggregations map[string]any `json:"aggregations,omitempty"`
+}
+
+// Search runs a Query DSL request against the given indices. The hits are
+// returned as a list, unless the request has aggregations.
+func (s *Source) Search(ctx context.Context, indices []string, body string) (any, error) {
+	res, err := esapi.SearchRequest{
+		Index:      indices,
+		Body:       strings.NewReader(body),
+		FilterPath: []string{"hits.hits", "aggregations"},
+		Instrument: s.ElasticsearchClient().InstrumentationEnabled(),
+	}.Do(ctx, s.ElasticsearchClient())
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		b, _ := io.ReadAll(res.Body)
+		return nil, fmt.Errorf("%s search error: status %s: %s", s.distribution(), res.Status(), b)
+	}
+
+	var resp struct {
+		Hits struct {
+			Hits []any `json:"hits"`
+		} `json:"hits"`
+		Aggregations map[string]any `json:"aggregations"`
+	}
+	if err := util.DecodeJSON(res.Body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to decode response body: %w", err)
+	}
+	hits := resp.Hits.Hits
+	if hits == nil {
+		hits = []any{}
+	}
+	if resp.Aggregations != nil {
+		return SearchResult{Hits: hits, Aggregations: resp.Aggregations}, nil
+	}
+	return hits, nil
+}
+
+func (s *Source) distribution() string {
+	if s.Distribution == DistributionOpenSearch {
+		return "opensearch"
+	}
+	return "elasticsearch"
+}
+
 type EsqlColumn struct {
 	Name string `json:"name"`
 	Type string `json:"type"`
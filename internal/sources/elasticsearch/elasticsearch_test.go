@@ -51,6 +51,29 @@ func TestParseFromYamlElasticsearch(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "opensearch",
+			in: `
+			kind: source
+			name: my-os-instance
+			type: elasticsearch
+			distribution: opensearch
+			addresses:
+				- https://localhost:9200
+			username: admin
+			password: secret
+			`,
+			want: map[string]sources.SourceConfig{
+				"my-os-instance": elasticsearch.Config{
+					Name:         "my-os-instance",
+					Type:         elasticsearch.SourceType,
+					Addresses:    []string{"https://localhost:9200"},
+					Username:     "admin",
+					Password:     "secret",
+					Distribution: elasticsearch.DistributionOpenSearch,
+				},
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -32,7 +32,7 @@ const SourceType string = "firebird"
 var _ sources.SourceConfig = Config{}
 
 func init() {
-	if !sources.Register(SourceType, newConfig) {
+	if !sources.Register[*Source](SourceType, newConfig) {
 		panic(fmt.Sprintf("source type %q already registered", SourceType))
 	}
 }
@@ -96,6 +96,11 @@ func (s *Source) FirebirdDB() *sql.DB {
 	return s.Db
 }
 
+// Close closes the connection pool of the source.
+func (s *Source) Close() error {
+	return s.Db.Close()
+}
+
 func (s *Source) RunSQL(ctx context.Context, statement string, params []any) (any, error) {
 	rows, err := s.FirebirdDB().QueryContext(ctx, statement, params...)
 	if err != nil {
@@ -102,7 +102,7 @@ func TestFailParseFromYaml(t *testing.T) {
 			database: my_db
 			user: my_user
 			`,
-			err: "error unmarshaling source: unable to parse source \"my-fdb-instance\" as \"firebird\": Key: 'Config.Password' Error:Field validation for 'Password' failed on the 'required' tag",
+			err: "error unmarshaling source: unable to parse source \"my-fdb-instance\" as \"firebird\": sources[my-fdb-instance].password is required; add a \"password\" field",
 		},
 	}
 	for _, tc := range tcs {
@@ -105,7 +105,7 @@ func TestFailParseFromYaml(t *testing.T) {
 			name: my-firestore
 			type: firestore
 			`,
-			err: "error unmarshaling source: unable to parse source \"my-firestore\" as \"firestore\": Key: 'Config.Project' Error:Field validation for 'Project' failed on the 'required' tag",
+			err: "error unmarshaling source: unable to parse source \"my-firestore\" as \"firestore\": sources[my-firestore].project is required; add a \"project\" field",
 		},
 	}
 	for _, tc := range tcs {
@@ -0,0 +1,208 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hana
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strconv"
+	"time"
+
+	_ "github.com/SAP/go-hdb/driver"
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/mcp-toolbox/internal/sources"
+	"github.com/googleapis/mcp-toolbox/internal/util/orderedmap"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const SourceType string = "hana"
+
+// validate interface
+var _ sources.SourceConfig = Config{}
+
+func init() {
+	if !sources.Register[*Source](SourceType, newConfig) {
+		panic(fmt.Sprintf("source type %q already registered", SourceType))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name     string `yaml:"name" validate:"required"`
+	Type     string `yaml:"type" validate:"required"`
+	Host     string `yaml:"host" validate:"required"`
+	Port     string `yaml:"port" validate:"required"`
+	User     string `yaml:"user" validate:"required"`
+	Password string `yaml:"password" validate:"required"`
+	// Database is the tenant database of a multitenant system, connected to
+	// through the system database listening on Port.
+	Database string `yaml:"database"`
+	// Schema is the default schema of the connections, instead of the
+	// schema of the user.
+	Schema string `yaml:"schema"`
+	// QueryTimeout bounds how long the connections wait for the database,
+	// as a duration such as "30s".
+	QueryTimeout string `yaml:"queryTimeout"`
+}
+
+func (r Config) SourceConfigType() string {
+	return SourceType
+}
+
+func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
+	pool, err := initHanaConnectionPool(ctx, tracer, r)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create pool: %w", err)
+	}
+
+	err = pool.PingContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect successfully: %w", err)
+	}
+
+	s := &Source{
+		Config: r,
+		Pool:   pool,
+	}
+	return s, nil
+}
+
+var _ sources.Source = &Source{}
+
+type Source struct {
+	Config
+	Pool *sql.DB
+}
+
+func (s *Source) SourceType() string {
+	return SourceType
+}
+
+func (s *Source) ToConfig() sources.SourceConfig {
+	return s.Config
+}
+
+func (s *Source) HanaPool() *sql.DB {
+	return s.Pool
+}
+
+// Close closes the connection pool of the source.
+func (s *Source) Close() error {
+	return s.Pool.Close()
+}
+
+func (s *Source) RunSQL(ctx context.Context, statement string, params []any) (any, error) {
+	results, err := s.HanaPool().QueryContext(ctx, statement, params...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to execute query: %w", err)
+	}
+	defer results.Close()
+
+	cols, err := results.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve rows column name: %w", err)
+	}
+	colTypes, err := results.ColumnTypes()
+	if err != nil {
+		return nil, fmt.Errorf("unable to get column types: %w", err)
+	}
+
+	// create an array of values for each column, which can be re-used to scan each row
+	rawValues := make([]any, len(cols))
+	values := make([]any, len(cols))
+	for i := range rawValues {
+		values[i] = &rawValues[i]
+	}
+
+	out := []any{}
+	for results.Next() {
+		if err := results.Scan(values...); err != nil {
+			return nil, fmt.Errorf("unable to parse row: %w", err)
+		}
+		row := orderedmap.Row{Columns: make([]orderedmap.Column, 0, len(cols))}
+		for i, name := range cols {
+			row.Add(name, convertValue(colTypes[i], rawValues[i]))
+		}
+		out = append(out, row)
+	}
+
+	if err := results.Err(); err != nil {
+		return nil, fmt.Errorf("errors encountered during row iteration: %w", err)
+	}
+
+	// DML and DDL statements return no rows, which cannot be told apart
+	// from a query matching no rows.
+	return out, nil
+}
+
+// convertValue converts a value scanned from go-hdb to a JSON-friendly
+// value.
+func convertValue(colType *sql.ColumnType, v any) any {
+	switch val := v.(type) {
+	case []byte:
+		// binary types other than the LOBs
+		return string(val)
+	case *big.Rat:
+		// decimals are formatted to their scale, keeping their precision
+		if _, scale, ok := colType.DecimalSize(); ok && scale >= 0 {
+			return val.FloatString(int(scale))
+		}
+		return val.RatString()
+	}
+	return v
+}
+
+func initHanaConnectionPool(ctx context.Context, tracer trace.Tracer, r Config) (*sql.DB, error) {
+	_, span := sources.InitConnectionSpan(ctx, tracer, SourceType, r.Name)
+	defer span.End()
+
+	query := url.Values{}
+	if r.Database != "" {
+		query.Set("databaseName", r.Database)
+	}
+	if r.Schema != "" {
+		query.Set("defaultSchema", r.Schema)
+	}
+	if r.QueryTimeout != "" {
+		timeout, err := time.ParseDuration(r.QueryTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid queryTimeout %q: %w", r.QueryTimeout, err)
+		}
+		// the driver takes the timeout in seconds
+		query.Set("timeout", strconv.Itoa(max(1, int(timeout.Seconds()))))
+	}
+	dsn := &url.URL{
+		Scheme:   "hdb",
+		User:     url.UserPassword(r.User, r.Password),
+		Host:     fmt.Sprintf("%s:%s", r.Host, r.Port),
+		RawQuery: query.Encode(),
+	}
+
+	pool, err := sql.Open("hdb", dsn.String())
+	if err != nil {
+		return nil, fmt.Errorf("sql.Open: %w", err)
+	}
+	return pool, nil
+}
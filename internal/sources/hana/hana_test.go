@@ -0,0 +1,143 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hana_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/internal/server"
+	"github.com/googleapis/mcp-toolbox/internal/sources"
+	"github.com/googleapis/mcp-toolbox/internal/sources/hana"
+	"github.com/googleapis/mcp-toolbox/internal/testutils"
+)
+
+func TestParseFromYamlHana(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		want server.SourceConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			kind: source
+			name: my-hana-instance
+			type: hana
+			host: 0.0.0.0
+			port: 30015
+			user: hana_user
+			password: hana_pass
+			`,
+			want: map[string]sources.SourceConfig{
+				"my-hana-instance": hana.Config{
+					Name:     "my-hana-instance",
+					Type:     hana.SourceType,
+					Host:     "0.0.0.0",
+					Port:     "30015",
+					User:     "hana_user",
+					Password: "hana_pass",
+				},
+			},
+		},
+		{
+			desc: "tenant database with schema and query timeout",
+			in: `
+			kind: source
+			name: my-hana-instance
+			type: hana
+			host: 0.0.0.0
+			port: 30013
+			user: hana_user
+			password: hana_pass
+			database: HXE
+			schema: SALES
+			queryTimeout: 30s
+			`,
+			want: map[string]sources.SourceConfig{
+				"my-hana-instance": hana.Config{
+					Name:         "my-hana-instance",
+					Type:         hana.SourceType,
+					Host:         "0.0.0.0",
+					Port:         "30013",
+					User:         "hana_user",
+					Password:     "hana_pass",
+					Database:     "HXE",
+					Schema:       "SALES",
+					QueryTimeout: "30s",
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, _, _, _, _, _, err := server.UnmarshalPrimitiveConfig(context.Background(), testutils.FormatYaml(tc.in))
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if !cmp.Equal(tc.want, got) {
+				t.Fatalf("incorrect parse: want %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestFailParseFromYamlHana(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		err  string
+	}{
+		{
+			desc: "extra field",
+			in: `
+			kind: source
+			name: my-hana-instance
+			type: hana
+			host: 0.0.0.0
+			port: 30015
+			user: hana_user
+			password: hana_pass
+			foo: bar
+			`,
+			err: "error unmarshaling source: unable to parse source \"my-hana-instance\" as \"hana\": [1:1] unknown field \"foo\"\n>  1 | foo: bar\n       ^\n   2 | host: 0.0.0.0\n   3 | name: my-hana-instance\n   4 | password: hana_pass\n   5 | ",
+		},
+		{
+			desc: "missing required field",
+			in: `
+			kind: source
+			name: my-hana-instance
+			type: hana
+			port: 30015
+			user: hana_user
+			password: hana_pass
+			`,
+			err: "error unmarshaling source: unable to parse source \"my-hana-instance\" as \"hana\": sources[my-hana-instance].host is required; add a \"host\" field",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, _, _, _, _, _, err := server.UnmarshalPrimitiveConfig(context.Background(), testutils.FormatYaml(tc.in))
+			if err == nil {
+				t.Fatalf("expect parsing to fail")
+			}
+			errStr := err.Error()
+			if errStr != tc.err {
+				t.Fatalf("unexpected error: got %q, want %q", errStr, tc.err)
+			}
+		})
+	}
+}
@@ -30,6 +30,8 @@ import (
 	"github.com/googleapis/mcp-toolbox/internal/sources"
 	"github.com/googleapis/mcp-toolbox/internal/util"
 	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
 )
 
 const SourceType string = "http"
@@ -53,7 +55,7 @@ func mustParseCIDR(cidr string) *net.IPNet {
 var _ sources.SourceConfig = Config{}
 
 func init() {
-	if !sources.Register(SourceType, newConfig) {
+	if !sources.Register[*Source](SourceType, newConfig) {
 		panic(fmt.Sprintf("source type %q already registered", SourceType))
 	}
 }
@@ -78,6 +80,98 @@ type Config struct {
 	AllowedIPRanges        []string          `yaml:"allowedIpRanges"`
 	CustomBlockedIPRanges  []string          `yaml:"customBlockedIpRanges"`
 	AllowPrivateNetworks   bool              `yaml:"allowPrivateNetworks"`
+	Auth                   *AuthConfig       `yaml:"auth"`
+}
+
+const (
+	AuthTypeBearer                  = "bearer"
+	AuthTypeBasic                   = "basic"
+	AuthTypeOAuth2ClientCredentials = "oauth2ClientCredentials"
+)
+
+// AuthConfig configures the credentials attached to every request the source
+// sends.
+type AuthConfig struct {
+	Type string `yaml:"type" validate:"required"`
+	// Token is the static token used by the bearer auth type.
+	Token string `yaml:"token"`
+	// Username and Password are used by the basic auth type.
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+	// The remaining fields configure the OAuth 2.0 client credentials flow.
+	TokenURL       string            `yaml:"tokenUrl"`
+	ClientID       string            `yaml:"clientId"`
+	ClientSecret   string            `yaml:"clientSecret"`
+	Scopes         []string          `yaml:"scopes"`
+	EndpointParams map[string]string `yaml:"endpointParams"`
+}
+
+func (a *AuthConfig) validate() error {
+	switch a.Type {
+	case AuthTypeBearer:
+		if a.Token == "" {
+			return fmt.Errorf("auth type %q requires token", a.Type)
+		}
+	case AuthTypeBasic:
+		if a.Username == "" {
+			return fmt.Errorf("auth type %q requires username", a.Type)
+		}
+	case AuthTypeOAuth2ClientCredentials:
+		if a.TokenURL == "" || a.ClientID == "" || a.ClientSecret == "" {
+			return fmt.Errorf("auth type %q requires tokenUrl, clientId and clientSecret", a.Type)
+		}
+		if _, err := url.ParseRequestURI(a.TokenURL); err != nil {
+			return fmt.Errorf("invalid tokenUrl: %w", err)
+		}
+	default:
+		return fmt.Errorf("unsupported auth type %q: must be one of %q, %q or %q", a.Type, AuthTypeBearer, AuthTypeBasic, AuthTypeOAuth2ClientCredentials)
+	}
+	return nil
+}
+
+// transport wraps base so that every request carries the configured
+// credentials. Credentials are applied to a clone of the request, as required
+// by the http.RoundTripper contract.
+func (a *AuthConfig) transport(base http.RoundTripper, timeout time.Duration) http.RoundTripper {
+	switch a.Type {
+	case AuthTypeBearer:
+		return authTransport{base: base, apply: func(req *http.Request) {
+			req.Header.Set("Authorization", "Bearer "+a.Token)
+		}}
+	case AuthTypeBasic:
+		return authTransport{base: base, apply: func(req *http.Request) {
+			req.SetBasicAuth(a.Username, a.Password)
+		}}
+	case AuthTypeOAuth2ClientCredentials:
+		params := url.Values{}
+		for k, v := range a.EndpointParams {
+			params.Set(k, v)
+		}
+		cc := &clientcredentials.Config{
+			ClientID:       a.ClientID,
+			ClientSecret:   a.ClientSecret,
+			TokenURL:       a.TokenURL,
+			Scopes:         a.Scopes,
+			EndpointParams: params,
+		}
+		// The token endpoint is operator configured, so it is reached with a
+		// plain client rather than the SSRF-guarded one used for tool calls.
+		// The token source outlives Initialize, so it must not capture its ctx.
+		tokenCtx := context.WithValue(context.Background(), oauth2.HTTPClient, &http.Client{Timeout: timeout})
+		return &oauth2.Transport{Source: cc.TokenSource(tokenCtx), Base: base}
+	}
+	return base
+}
+
+type authTransport struct {
+	base  http.RoundTripper
+	apply func(*http.Request)
+}
+
+func (t authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	t.apply(req)
+	return t.base.RoundTrip(req)
 }
 
 func (r Config) SourceConfigType() string {
@@ -144,6 +238,12 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 	if err != nil {
 		return nil, fmt.Errorf("failed to create secure HTTP client: %w", err)
 	}
+	if r.Auth != nil {
+		if err := r.Auth.validate(); err != nil {
+			return nil, fmt.Errorf("invalid auth for HTTP source %s: %w", r.Name, err)
+		}
+		client.Transport = r.Auth.transport(client.Transport, duration)
+	}
 
 	ua, err := util.UserAgentFromContext(ctx)
 	if err != nil {
@@ -86,6 +86,37 @@ func TestParseFromYamlHttp(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "oauth2 client credentials auth",
+			in: `
+			kind: source
+			name: my-http-instance
+			type: http
+			baseUrl: http://test_server/
+			auth:
+				type: oauth2ClientCredentials
+				tokenUrl: http://auth_server/token
+				clientId: my-client
+				clientSecret: my-secret
+				scopes:
+					- read
+			`,
+			want: map[string]sources.SourceConfig{
+				"my-http-instance": Config{
+					Name:    "my-http-instance",
+					Type:    SourceType,
+					BaseURL: "http://test_server/",
+					Timeout: "30s",
+					Auth: &AuthConfig{
+						Type:         AuthTypeOAuth2ClientCredentials,
+						TokenURL:     "http://auth_server/token",
+						ClientID:     "my-client",
+						ClientSecret: "my-secret",
+						Scopes:       []string{"read"},
+					},
+				},
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -230,6 +261,112 @@ func TestRunRequestIncludesErrorBodyWhenEnabled(t *testing.T) {
 	}
 }
 
+func TestSourceAuth(t *testing.T) {
+	tokenServer := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("failed to parse token request: %v", err)
+		}
+		if got := r.Form.Get("grant_type"); got != "client_credentials" {
+			t.Errorf("unexpected grant_type %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"issued-token","token_type":"Bearer","expires_in":3600}`))
+	}))
+	defer tokenServer.Close()
+
+	var gotAuth string
+	server := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	logger, err := log.NewLogger("standard", log.Debug, &bytes.Buffer{}, &bytes.Buffer{})
+	if err != nil {
+		t.Fatalf("failed to create logger: %v", err)
+	}
+	ctx := util.WithLogger(context.Background(), logger)
+
+	tcs := []struct {
+		desc string
+		auth *AuthConfig
+		want string
+	}{
+		{
+			desc: "bearer",
+			auth: &AuthConfig{Type: AuthTypeBearer, Token: "static-token"},
+			want: "Bearer static-token",
+		},
+		{
+			desc: "basic",
+			auth: &AuthConfig{Type: AuthTypeBasic, Username: "user", Password: "pass"},
+			want: "Basic dXNlcjpwYXNz",
+		},
+		{
+			desc: "oauth2 client credentials",
+			auth: &AuthConfig{
+				Type:         AuthTypeOAuth2ClientCredentials,
+				TokenURL:     tokenServer.URL,
+				ClientID:     "id",
+				ClientSecret: "secret",
+			},
+			want: "Bearer issued-token",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			gotAuth = ""
+			sourceConfig := Config{
+				Name:                 "test-http",
+				Type:                 SourceType,
+				BaseURL:              server.URL,
+				Timeout:              "30s",
+				AllowPrivateNetworks: true,
+				Auth:                 tc.auth,
+			}
+			initialized, err := sourceConfig.Initialize(ctx, nil)
+			if err != nil {
+				t.Fatalf("failed to initialize source: %v", err)
+			}
+			req, err := nethttp.NewRequestWithContext(ctx, nethttp.MethodGet, server.URL, nil)
+			if err != nil {
+				t.Fatalf("failed to build request: %v", err)
+			}
+			if _, err := initialized.(*Source).RunRequest(ctx, req); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if gotAuth != tc.want {
+				t.Fatalf("incorrect Authorization header: want %q, got %q", tc.want, gotAuth)
+			}
+		})
+	}
+}
+
+func TestSourceAuthInvalid(t *testing.T) {
+	logger, err := log.NewLogger("standard", log.Debug, &bytes.Buffer{}, &bytes.Buffer{})
+	if err != nil {
+		t.Fatalf("failed to create logger: %v", err)
+	}
+	ctx := util.WithLogger(context.Background(), logger)
+
+	for _, auth := range []*AuthConfig{
+		{Type: "digest"},
+		{Type: AuthTypeBearer},
+		{Type: AuthTypeOAuth2ClientCredentials, ClientID: "id"},
+	} {
+		sourceConfig := Config{
+			Name:    "test-http",
+			Type:    SourceType,
+			BaseURL: "https://example.com",
+			Timeout: "30s",
+			Auth:    auth,
+		}
+		if _, err := sourceConfig.Initialize(ctx, nil); err == nil {
+			t.Errorf("expected error for auth %+v", auth)
+		}
+	}
+}
+
 type mockResolver struct {
 	lookupFunc func(ctx context.Context, host string) ([]string, error)
 }
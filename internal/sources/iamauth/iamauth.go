@@ -0,0 +1,139 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package iamauth configures how the Cloud SQL and AlloyDB sources log in to
+// their databases: with a password, or as an IAM principal with tokens that
+// the connectors mint and refresh.
+package iamauth
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/googleapis/mcp-toolbox/internal/sources"
+	"golang.org/x/oauth2"
+	"google.golang.org/api/impersonate"
+)
+
+const (
+	// IAM logs in as an IAM principal. No password is stored: the
+	// connector mints a short-lived token for each new connection.
+	IAM = "iam"
+	// Password logs in with the user and password of the source.
+	Password = "password"
+)
+
+const (
+	// CloudSQLLoginScope is the scope of the tokens used to log in to Cloud
+	// SQL as an IAM database user.
+	CloudSQLLoginScope = "https://www.googleapis.com/auth/sqlservice.login"
+	// AlloyDBLoginScope is the scope of the tokens used to log in to AlloyDB
+	// as an IAM database user.
+	AlloyDBLoginScope = "https://www.googleapis.com/auth/alloydb.login"
+)
+
+// serviceAccountEmail matches the emails of service accounts, such as
+// name@project.iam.gserviceaccount.com.
+var serviceAccountEmail = regexp.MustCompile(`^[a-zA-Z0-9-]+@[a-zA-Z0-9.-]+\.gserviceaccount\.com$`)
+
+// IsServiceAccount reports whether email is the email of a service account.
+func IsServiceAccount(email string) bool {
+	return serviceAccountEmail.MatchString(email)
+}
+
+// Login is the auth and impersonateServiceAccount fields of a Cloud SQL or
+// AlloyDB source. Sources embed it inline in their config.
+type Login struct {
+	// Auth is "iam" or "password". Unset, a source logs in with a password
+	// when both its user and password are set, and as an IAM principal
+	// otherwise.
+	Auth string `yaml:"auth"`
+	// ImpersonateServiceAccount is the email of a service account to log in
+	// as with IAM authentication, instead of the principal of the
+	// Application Default Credentials.
+	ImpersonateServiceAccount string `yaml:"impersonateServiceAccount"`
+}
+
+// Validate checks the auth of a source against its user and password.
+func (l Login) Validate(user, password string) error {
+	switch l.Auth {
+	case "":
+	case IAM:
+		if password != "" {
+			return fmt.Errorf("password cannot be set with auth %q", IAM)
+		}
+	case Password:
+		if user == "" || password == "" {
+			return fmt.Errorf("auth %q requires both user and password", Password)
+		}
+	default:
+		return fmt.Errorf("invalid auth %q: must be %q or %q", l.Auth, IAM, Password)
+	}
+	if l.ImpersonateServiceAccount == "" {
+		return nil
+	}
+	if !IsServiceAccount(l.ImpersonateServiceAccount) {
+		return fmt.Errorf("invalid impersonateServiceAccount %q: must be a service account email", l.ImpersonateServiceAccount)
+	}
+	if password != "" {
+		return fmt.Errorf("impersonateServiceAccount cannot be used with a password")
+	}
+	return nil
+}
+
+// User returns the database user to log in as: user if set, otherwise the
+// database user of the impersonated service account for dbType, "mysql" or
+// "postgres". It returns "" when the source logs in as the principal of the
+// Application Default Credentials.
+func (l Login) User(user, dbType string) string {
+	if user != "" || l.ImpersonateServiceAccount == "" {
+		return user
+	}
+	return DatabaseUser(l.ImpersonateServiceAccount, dbType)
+}
+
+// DatabaseUser returns the name of the database user of the IAM principal
+// email for dbType, "mysql" or "postgres". MySQL users are named after the
+// part of the email before the @, and PostgreSQL users after the email
+// without the .gserviceaccount.com suffix of service accounts.
+func DatabaseUser(email, dbType string) string {
+	if strings.ToLower(dbType) == "mysql" {
+		name, _, _ := strings.Cut(email, "@")
+		return name
+	}
+	return strings.TrimSuffix(email, ".gserviceaccount.com")
+}
+
+// TokenSources returns the token sources of the service account, for the
+// APIs of the connectors and for IAM database logins with loginScope. Their
+// tokens are refreshed as they expire.
+func TokenSources(ctx context.Context, serviceAccount, loginScope string) (api, login oauth2.TokenSource, err error) {
+	api, err = impersonate.CredentialsTokenSource(ctx, impersonate.CredentialsConfig{
+		TargetPrincipal: serviceAccount,
+		Scopes:          []string{sources.CloudPlatformScope},
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to impersonate %q: %w", serviceAccount, err)
+	}
+	login, err = impersonate.CredentialsTokenSource(ctx, impersonate.CredentialsConfig{
+		TargetPrincipal: serviceAccount,
+		Scopes:          []string{loginScope},
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to impersonate %q: %w", serviceAccount, err)
+	}
+	return api, login, nil
+}
@@ -0,0 +1,74 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iamauth
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	const sa = "toolbox@my-project.iam.gserviceaccount.com"
+	tcs := []struct {
+		desc     string
+		login    Login
+		user     string
+		password string
+		wantErr  string
+	}{
+		{desc: "implicit password", user: "u", password: "p"},
+		{desc: "implicit iam"},
+		{desc: "iam", login: Login{Auth: IAM}, user: "u"},
+		{desc: "iam as service account", login: Login{Auth: IAM, ImpersonateServiceAccount: sa}},
+		{desc: "password", login: Login{Auth: Password}, user: "u", password: "p"},
+		{desc: "iam with password", login: Login{Auth: IAM}, user: "u", password: "p", wantErr: "password cannot be set"},
+		{desc: "password without password", login: Login{Auth: Password}, user: "u", wantErr: "requires both user and password"},
+		{desc: "unknown auth", login: Login{Auth: "kerberos"}, wantErr: `invalid auth "kerberos"`},
+		{desc: "invalid service account", login: Login{ImpersonateServiceAccount: "someone@example.com"}, wantErr: "must be a service account email"},
+		{desc: "service account with password", login: Login{ImpersonateServiceAccount: sa}, user: "u", password: "p", wantErr: "cannot be used with a password"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := tc.login.Validate(tc.user, tc.password)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestUser(t *testing.T) {
+	login := Login{ImpersonateServiceAccount: "toolbox@my-project.iam.gserviceaccount.com"}
+	for _, tc := range []struct {
+		user, dbType, want string
+	}{
+		{user: "", dbType: "postgres", want: "toolbox@my-project.iam"},
+		{user: "", dbType: "mysql", want: "toolbox"},
+		{user: "analyst@example.com", dbType: "postgres", want: "analyst@example.com"},
+	} {
+		if got := login.User(tc.user, tc.dbType); got != tc.want {
+			t.Errorf("User(%q, %q) = %q, want %q", tc.user, tc.dbType, got, tc.want)
+		}
+	}
+	if got := (Login{}).User("", "postgres"); got != "" {
+		t.Errorf("User without impersonation = %q, want the ADC principal", got)
+	}
+}
@@ -139,7 +139,7 @@ func TestFailParseFromYaml(t *testing.T) {
 			type: looker
 			client_id: jasdl;k;tjl
 			`,
-			err: "error unmarshaling source: unable to parse source \"my-looker-instance\" as \"looker\": Key: 'Config.BaseURL' Error:Field validation for 'BaseURL' failed on the 'required' tag",
+			err: "error unmarshaling source: unable to parse source \"my-looker-instance\" as \"looker\": sources[my-looker-instance].base_url is required; add a \"base_url\" field",
 		},
 	}
 	for _, tc := range tcs {
@@ -33,7 +33,7 @@ const SourceType string = "mindsdb"
 var _ sources.SourceConfig = Config{}
 
 func init() {
-	if !sources.Register(SourceType, newConfig) {
+	if !sources.Register[*Source](SourceType, newConfig) {
 		panic(fmt.Sprintf("source type %q already registered", SourceType))
 	}
 }
@@ -98,6 +98,11 @@ func (s *Source) MindsDBPool() *sql.DB {
 	return s.Pool
 }
 
+// Close closes the connection pool of the source.
+func (s *Source) Close() error {
+	return s.Pool.Close()
+}
+
 func (s *Source) MySQLPool() *sql.DB {
 	return s.Pool
 }
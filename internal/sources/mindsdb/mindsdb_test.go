@@ -128,7 +128,7 @@ func TestFailParseFromYaml(t *testing.T) {
 			user: my_user
 			password: my_pass
 			`,
-			err: "error unmarshaling source: unable to parse source \"my-mindsdb-instance\" as \"mindsdb\": Key: 'Config.Host' Error:Field validation for 'Host' failed on the 'required' tag",
+			err: "error unmarshaling source: unable to parse source \"my-mindsdb-instance\" as \"mindsdb\": sources[my-mindsdb-instance].host is required; add a \"host\" field",
 		},
 	}
 	for _, tc := range tcs {
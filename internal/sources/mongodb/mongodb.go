@@ -19,6 +19,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/goccy/go-yaml"
 	"github.com/googleapis/mcp-toolbox/internal/sources"
@@ -35,7 +36,7 @@ const SourceType string = "mongodb"
 var _ sources.SourceConfig = Config{}
 
 func init() {
-	if !sources.Register(SourceType, newConfig) {
+	if !sources.Register[*Source](SourceType, newConfig) {
 		panic(fmt.Sprintf("source type %q already registered", SourceType))
 	}
 }
@@ -96,6 +97,11 @@ func (s *Source) MongoClient() *mongo.Client {
 	return s.Client
 }
 
+// Ping checks the connection of the source to the database.
+func (s *Source) Ping(ctx context.Context) error {
+	return s.Client.Ping(ctx, nil)
+}
+
 func parseData(ctx context.Context, cur *mongo.Cursor) ([]any, error) {
 	var data = []any{}
 	err := cur.All(ctx, &data)
@@ -115,7 +121,7 @@ func parseData(ctx context.Context, cur *mongo.Cursor) ([]any, error) {
 	return final, err
 }
 
-func (s *Source) Aggregate(ctx context.Context, pipelineString string, canonical, readOnly bool, database, collection string) ([]any, error) {
+func (s *Source) Aggregate(ctx context.Context, pipelineString string, canonical, readOnly bool, database, collection string, opts *options.AggregateOptionsBuilder) ([]any, error) {
 	var pipeline = []bson.M{}
 	err := bson.UnmarshalExtJSON([]byte(pipelineString), canonical, &pipeline)
 	if err != nil {
@@ -133,7 +139,7 @@ func (s *Source) Aggregate(ctx context.Context, pipelineString string, canonical
 		}
 	}
 
-	cur, err := s.MongoClient().Database(database).Collection(collection).Aggregate(ctx, pipeline)
+	cur, err := s.MongoClient().Database(database).Collection(collection).Aggregate(ctx, pipeline, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -148,6 +154,61 @@ func (s *Source) Aggregate(ctx context.Context, pipelineString string, canonical
 	return res, err
 }
 
+// AggregatePipeline runs a pipeline of BSON stages and returns the resulting
+// documents, with ObjectIDs and dates rendered as strings.
+func (s *Source) AggregatePipeline(ctx context.Context, pipeline bson.A, database, collection string, opts *options.AggregateOptionsBuilder) ([]any, error) {
+	cur, err := s.MongoClient().Database(database).Collection(collection).Aggregate(ctx, pipeline, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var docs []bson.D
+	if err := cur.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	res := make([]any, 0, len(docs))
+	for _, doc := range docs {
+		res = append(res, simplifyValue(doc))
+	}
+	return res, nil
+}
+
+// simplifyValue converts a decoded BSON value to plain JSON-friendly values,
+// rendering ObjectIDs as hex strings and dates as RFC 3339 strings.
+func simplifyValue(v any) any {
+	switch v := v.(type) {
+	case bson.D:
+		m := make(map[string]any, len(v))
+		for _, e := range v {
+			m[e.Key] = simplifyValue(e.Value)
+		}
+		return m
+	case bson.M:
+		m := make(map[string]any, len(v))
+		for k, e := range v {
+			m[k] = simplifyValue(e)
+		}
+		return m
+	case bson.A:
+		a := make([]any, len(v))
+		for i, e := range v {
+			a[i] = simplifyValue(e)
+		}
+		return a
+	case bson.ObjectID:
+		return v.Hex()
+	case bson.DateTime:
+		return v.Time().UTC().Format(time.RFC3339Nano)
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano)
+	case bson.Decimal128:
+		return v.String()
+	default:
+		return v
+	}
+}
+
 func (s *Source) Find(ctx context.Context, filterString, database, collection string, opts *options.FindOptionsBuilder) ([]any, error) {
 	var filter = bson.D{}
 	err := bson.UnmarshalExtJSON([]byte(filterString), false, &filter)
@@ -86,7 +86,7 @@ func TestFailParseFromYaml(t *testing.T) {
 			name: mongo-db
 			type: mongodb
 			`,
-			err: "error unmarshaling source: unable to parse source \"mongo-db\" as \"mongodb\": Key: 'Config.Uri' Error:Field validation for 'Uri' failed on the 'required' tag",
+			err: "error unmarshaling source: unable to parse source \"mongo-db\" as \"mongodb\": sources[mongo-db].uri is required; add a \"uri\" field",
 		},
 	}
 	for _, tc := range tcs {
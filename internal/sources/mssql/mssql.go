@@ -17,14 +17,22 @@ package mssql
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"net/url"
 
 	"github.com/goccy/go-yaml"
 	"github.com/googleapis/mcp-toolbox/internal/sources"
+	"github.com/googleapis/mcp-toolbox/internal/sources/clienttls"
+	"github.com/googleapis/mcp-toolbox/internal/sources/queryguard"
+	"github.com/googleapis/mcp-toolbox/internal/sources/readreplica"
+	"github.com/googleapis/mcp-toolbox/internal/sources/schemadoc"
+	"github.com/googleapis/mcp-toolbox/internal/sources/schemasnapshot"
+	"github.com/googleapis/mcp-toolbox/internal/telemetry"
 	"github.com/googleapis/mcp-toolbox/internal/util"
 	"github.com/googleapis/mcp-toolbox/internal/util/orderedmap"
-	_ "github.com/microsoft/go-mssqldb"
+	mssql "github.com/microsoft/go-mssqldb"
+	"github.com/microsoft/go-mssqldb/msdsn"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -34,7 +42,7 @@ const SourceType string = "mssql"
 var _ sources.SourceConfig = Config{}
 
 func init() {
-	if !sources.Register(SourceType, newConfig) {
+	if !sources.Register[*Source](SourceType, newConfig) {
 		panic(fmt.Sprintf("source type %q already registered", SourceType))
 	}
 }
@@ -57,6 +65,26 @@ type Config struct {
 	Password string `yaml:"password" validate:"required"`
 	Database string `yaml:"database" validate:"required"`
 	Encrypt  string `yaml:"encrypt"`
+	// TLS optionally secures the connections with a custom CA bundle and a
+	// client certificate, overriding Encrypt.
+	TLS *clienttls.Config `yaml:"tls"`
+	// SchemaSnapshot optionally compares the schema of the database against
+	// a snapshot at startup.
+	SchemaSnapshot *schemasnapshot.Config `yaml:"schemaSnapshot"`
+	// ReadOnly rejects the statements that may write.
+	ReadOnly bool `yaml:"readOnly"`
+	// Patterns optionally restrict the statements that tools may run.
+	queryguard.Patterns `yaml:",inline"`
+	// ReadReplicas optionally serve the invocations of read-only tools.
+	ReadReplicas []ReadReplica `yaml:"readReplicas"`
+}
+
+// ReadReplica is a read replica of the database, such as a readable
+// secondary of an availability group, which shares its credentials.
+type ReadReplica struct {
+	Host string `yaml:"host" validate:"required"`
+	// Port defaults to the port of the source.
+	Port string `yaml:"port"`
 }
 
 func (r Config) SourceConfigType() string {
@@ -65,8 +93,13 @@ func (r Config) SourceConfigType() string {
 }
 
 func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
+	guard, err := r.Patterns.Compile(r.Name)
+	if err != nil {
+		return nil, err
+	}
+
 	// Initializes a MSSQL source
-	db, err := initMssqlConnection(ctx, tracer, r.Name, r.Host, r.Port, r.User, r.Password, r.Database, r.Encrypt)
+	db, err := initMssqlConnection(ctx, tracer, r.Name, r.Host, r.Port, r.User, r.Password, r.Database, r.Encrypt, r.TLS, false)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create db connection: %w", err)
 	}
@@ -77,9 +110,28 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 		return nil, fmt.Errorf("unable to connect successfully: %w", err)
 	}
 
+	replicas := make([]*readreplica.Replica, 0, len(r.ReadReplicas))
+	for _, rep := range r.ReadReplicas {
+		port := rep.Port
+		if port == "" {
+			port = r.Port
+		}
+		replicaDB, err := initMssqlConnection(ctx, tracer, r.Name, rep.Host, port, r.User, r.Password, r.Database, r.Encrypt, r.TLS, true)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create db connection to read replica %q: %w", rep.Host, err)
+		}
+		replicas = append(replicas, &readreplica.Replica{Name: fmt.Sprintf("%s:%s", rep.Host, port), DB: replicaDB})
+	}
+
 	s := &Source{
-		Config: r,
-		Db:     db,
+		Config:   r,
+		Db:       db,
+		replicas: readreplica.New(db, replicas),
+	}
+	s.Guard = guard.WithReadOnly(r.ReadOnly, queryguard.SQLServer)
+	s.Report, err = schemasnapshot.Check(ctx, r.Name, r.SchemaSnapshot, schemasnapshot.SQLServerQuery, s.RunSQL)
+	if err != nil {
+		return nil, fmt.Errorf("unable to check schema snapshot: %w", err)
 	}
 	return s, nil
 }
@@ -88,7 +140,12 @@ var _ sources.Source = &Source{}
 
 type Source struct {
 	Config
+	schemasnapshot.Report
+	queryguard.Guard
 	Db *sql.DB
+	// replicas routes the queries of read-only invocations to the read
+	// replicas.
+	replicas *readreplica.Router
 }
 
 func (s *Source) SourceType() string {
@@ -105,8 +162,29 @@ func (s *Source) MSSQLDB() *sql.DB {
 	return s.Db
 }
 
+// Close closes the connection pool of the source.
+func (s *Source) Close() error {
+	return errors.Join(s.replicas.Close(), s.Db.Close())
+}
+
+// Ping checks the connection of the source to the database.
+func (s *Source) Ping(ctx context.Context) error {
+	return s.Db.PingContext(ctx)
+}
+
+// PoolStats returns the statistics of the connection pool of the source.
+func (s *Source) PoolStats() telemetry.PoolStats {
+	return telemetry.DBStats(s.Db.Stats())
+}
+
+// DescribeSchema returns the tables, columns and comments of the source.
+func (s *Source) DescribeSchema(ctx context.Context) (*schemadoc.Schema, error) {
+	return schemadoc.Describe(ctx, schemadoc.SQLServerQuery, s.RunSQL)
+}
+
 func (s *Source) RunSQL(ctx context.Context, statement string, params []any) (any, error) {
-	results, err := s.MSSQLDB().QueryContext(ctx, statement, params...)
+	util.RecordStatement(ctx, statement, params)
+	results, err := s.replicas.QueryContext(ctx, statement, params...)
 	if err != nil {
 		return nil, fmt.Errorf("unable to execute query: %w", err)
 	}
@@ -151,6 +229,8 @@ func initMssqlConnection(
 	ctx context.Context,
 	tracer trace.Tracer,
 	name, host, port, user, pass, dbname, encrypt string,
+	tlsOpts *clienttls.Config,
+	readOnlyIntent bool,
 ) (
 	*sql.DB,
 	error,
@@ -170,6 +250,9 @@ func initMssqlConnection(
 	if encrypt != "" {
 		query.Add("encrypt", encrypt)
 	}
+	if readOnlyIntent {
+		query.Add("ApplicationIntent", "ReadOnly")
+	}
 
 	url := &url.URL{
 		Scheme:   "sqlserver",
@@ -178,6 +261,20 @@ func initMssqlConnection(
 		RawQuery: query.Encode(),
 	}
 
+	if tlsOpts.Enabled() {
+		tlsConfig, err := tlsOpts.ClientConfig(host)
+		if err != nil {
+			return nil, err
+		}
+		config, err := msdsn.Parse(url.String())
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse DSN: %w", err)
+		}
+		config.Encryption = msdsn.EncryptionRequired
+		config.TLSConfig = tlsConfig
+		return sql.OpenDB(mssql.NewConnectorConfig(config)), nil
+	}
+
 	// Open database connection
 	db, err := sql.Open("sqlserver", url.String())
 	if err != nil {
@@ -81,6 +81,38 @@ func TestParseFromYamlMssql(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "with read replicas",
+			in: `
+			kind: source
+			name: my-mssql-instance
+			type: mssql
+			host: 0.0.0.0
+			port: my-port
+			database: my_db
+			user: my_user
+			password: my_pass
+			readReplicas:
+				- host: 0.0.0.1
+				- host: 0.0.0.2
+				  port: my-other-port
+			`,
+			want: map[string]sources.SourceConfig{
+				"my-mssql-instance": mssql.Config{
+					Name:     "my-mssql-instance",
+					Type:     mssql.SourceType,
+					Host:     "0.0.0.0",
+					Port:     "my-port",
+					Database: "my_db",
+					User:     "my_user",
+					Password: "my_pass",
+					ReadReplicas: []mssql.ReadReplica{
+						{Host: "0.0.0.1"},
+						{Host: "0.0.0.2", Port: "my-other-port"},
+					},
+				},
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -127,7 +159,7 @@ func TestFailParseFromYaml(t *testing.T) {
 			database: my_db
 			user: my_user
 			`,
-			err: "error unmarshaling source: unable to parse source \"my-mssql-instance\" as \"mssql\": Key: 'Config.Password' Error:Field validation for 'Password' failed on the 'required' tag",
+			err: "error unmarshaling source: unable to parse source \"my-mssql-instance\" as \"mssql\": sources[my-mssql-instance].password is required; add a \"password\" field",
 		},
 	}
 	for _, tc := range tcs {
@@ -18,12 +18,18 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"maps"
 	"time"
 
 	driver "github.com/go-sql-driver/mysql"
 	"github.com/goccy/go-yaml"
 	"github.com/googleapis/mcp-toolbox/internal/sources"
+	"github.com/googleapis/mcp-toolbox/internal/sources/clienttls"
+	"github.com/googleapis/mcp-toolbox/internal/sources/queryguard"
+	"github.com/googleapis/mcp-toolbox/internal/sources/schemadoc"
+	"github.com/googleapis/mcp-toolbox/internal/sources/schemasnapshot"
 	"github.com/googleapis/mcp-toolbox/internal/sources/sqlcommenter"
+	"github.com/googleapis/mcp-toolbox/internal/telemetry"
 	"github.com/googleapis/mcp-toolbox/internal/tools/mysql/mysqlcommon"
 	"github.com/googleapis/mcp-toolbox/internal/util"
 	"github.com/googleapis/mcp-toolbox/internal/util/orderedmap"
@@ -36,7 +42,7 @@ const SourceType string = "mysql"
 var _ sources.SourceConfig = Config{}
 
 func init() {
-	if !sources.Register(SourceType, newConfig) {
+	if !sources.Register[*Source](SourceType, newConfig) {
 		panic(fmt.Sprintf("source type %q already registered", SourceType))
 	}
 }
@@ -60,6 +66,17 @@ type Config struct {
 	QueryTimeout string            `yaml:"queryTimeout"`
 	QueryParams  map[string]string `yaml:"queryParams"`
 	SQLCommenter *bool             `yaml:"sqlCommenter"`
+	// TLS optionally secures the connections with a custom CA bundle and a
+	// client certificate, overriding the tls of QueryParams.
+	TLS *clienttls.Config `yaml:"tls"`
+	// SchemaSnapshot optionally compares the schema of the database against
+	// a snapshot at startup.
+	SchemaSnapshot *schemasnapshot.Config `yaml:"schemaSnapshot"`
+	// ReadOnly rejects the statements that may write, and runs every
+	// transaction read-only.
+	ReadOnly bool `yaml:"readOnly"`
+	// Patterns optionally restrict the statements that tools may run.
+	queryguard.Patterns `yaml:",inline"`
 }
 
 func (r Config) SourceConfigType() string {
@@ -67,7 +84,20 @@ func (r Config) SourceConfigType() string {
 }
 
 func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
-	pool, err := initMySQLConnectionPool(ctx, tracer, r.Name, r.Host, r.Port, r.User, r.Password, r.Database, r.QueryTimeout, r.QueryParams)
+	guard, err := r.Patterns.Compile(r.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	queryParams := r.QueryParams
+	if r.ReadOnly {
+		// the database rejects the writes the statement check misses, such
+		// as the ones of functions
+		queryParams = make(map[string]string, len(r.QueryParams)+1)
+		maps.Copy(queryParams, r.QueryParams)
+		queryParams["transaction_read_only"] = "1"
+	}
+	pool, err := initMySQLConnectionPool(ctx, tracer, r.Name, r.Host, r.Port, r.User, r.Password, r.Database, r.QueryTimeout, queryParams, r.TLS)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create pool: %w", err)
 	}
@@ -81,6 +111,11 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 		Config: r,
 		Pool:   pool,
 	}
+	s.Guard = guard.WithReadOnly(r.ReadOnly, queryguard.MySQL)
+	s.Report, err = schemasnapshot.Check(ctx, r.Name, r.SchemaSnapshot, schemasnapshot.MySQLQuery, s.RunSQL)
+	if err != nil {
+		return nil, fmt.Errorf("unable to check schema snapshot: %w", err)
+	}
 	return s, nil
 }
 
@@ -88,6 +123,8 @@ var _ sources.Source = &Source{}
 
 type Source struct {
 	Config
+	schemasnapshot.Report
+	queryguard.Guard
 	Pool *sql.DB
 }
 
@@ -103,6 +140,21 @@ func (s *Source) MySQLPool() *sql.DB {
 	return s.Pool
 }
 
+// Close closes the connection pool of the source.
+func (s *Source) Close() error {
+	return s.Pool.Close()
+}
+
+// Ping checks the connection of the source to the database.
+func (s *Source) Ping(ctx context.Context) error {
+	return s.Pool.PingContext(ctx)
+}
+
+// PoolStats returns the statistics of the connection pool of the source.
+func (s *Source) PoolStats() telemetry.PoolStats {
+	return telemetry.DBStats(s.Pool.Stats())
+}
+
 func (s *Source) MySQLDatabase() string {
 	return s.Database
 }
@@ -123,8 +175,14 @@ func (s *Source) RetrieveSourceVersion(ctx context.Context) (string, error) {
 	return version, nil
 }
 
+// DescribeSchema returns the tables, columns and comments of the source.
+func (s *Source) DescribeSchema(ctx context.Context) (*schemadoc.Schema, error) {
+	return schemadoc.Describe(ctx, schemadoc.MySQLQuery, s.RunSQL)
+}
+
 func (s *Source) RunSQL(ctx context.Context, statement string, params []any) (any, error) {
 	statement = sqlcommenter.PrependComment(ctx, statement, SourceType, s.SQLCommenter)
+	util.RecordStatement(ctx, statement, params)
 	results, err := s.MySQLPool().QueryContext(ctx, statement, params...)
 	if err != nil {
 		return nil, fmt.Errorf("unable to execute query: %w", err)
@@ -178,7 +236,7 @@ func (s *Source) RunSQL(ctx context.Context, statement string, params []any) (an
 	return out, nil
 }
 
-func initMySQLConnectionPool(ctx context.Context, tracer trace.Tracer, name, host, port, user, pass, dbname, queryTimeout string, queryParams map[string]string) (*sql.DB, error) {
+func initMySQLConnectionPool(ctx context.Context, tracer trace.Tracer, name, host, port, user, pass, dbname, queryTimeout string, queryParams map[string]string, tlsOpts *clienttls.Config) (*sql.DB, error) {
 	//nolint:all // Reassigned ctx
 	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceType, name)
 	defer span.End()
@@ -221,6 +279,25 @@ func initMySQLConnectionPool(ctx context.Context, tracer trace.Tracer, name, hos
 	config.ConnectionAttributes = fmt.Sprintf("program_name:%s", userAgent)
 	dsn := config.FormatDSN()
 
+	if tlsOpts.Enabled() {
+		tlsConfig, err := tlsOpts.ClientConfig(host)
+		if err != nil {
+			return nil, err
+		}
+		// a custom tls config can't be passed through the DSN, so parse it
+		// back to apply the parameters such as parseTime
+		parsed, err := driver.ParseDSN(dsn)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse DSN: %w", err)
+		}
+		parsed.TLS = tlsConfig
+		connector, err := driver.NewConnector(parsed)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create connector: %w", err)
+		}
+		return sql.OpenDB(connector), nil
+	}
+
 	// Interact with the driver directly as you normally would
 	pool, err := sql.Open("mysql", dsn)
 	if err != nil {
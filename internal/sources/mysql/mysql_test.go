@@ -158,7 +158,7 @@ func TestFailParseFromYaml(t *testing.T) {
 			user: my_user
 			password: my_pass
 			`,
-			err: "error unmarshaling source: unable to parse source \"my-mysql-instance\" as \"mysql\": Key: 'Config.Host' Error:Field validation for 'Host' failed on the 'required' tag",
+			err: "error unmarshaling source: unable to parse source \"my-mysql-instance\" as \"mysql\": sources[my-mysql-instance].host is required; add a \"host\" field",
 		},
 		{
 			desc: "invalid query params type",
@@ -37,7 +37,7 @@ var sourceClassifier *classifier.QueryClassifier = classifier.NewQueryClassifier
 var _ sources.SourceConfig = Config{}
 
 func init() {
-	if !sources.Register(SourceType, newConfig) {
+	if !sources.Register[*Source](SourceType, newConfig) {
 		panic(fmt.Sprintf("source type %q already registered", SourceType))
 	}
 }
@@ -162,6 +162,77 @@ func (s *Source) RunQuery(ctx context.Context, cypherStr string, params map[stri
 	return out, nil
 }
 
+// StatementResult is the result of a statement of a transaction.
+type StatementResult struct {
+	Records  []map[string]any `json:"records"`
+	Counters map[string]int   `json:"counters"`
+}
+
+// RunTransaction runs the statements in one managed transaction, which the
+// driver retries on transient errors, and returns a StatementResult for each
+// statement. Every statement receives all the params. A read-only transaction
+// rejects the statements that write and runs on a reader.
+func (s *Source) RunTransaction(ctx context.Context, statements []string, params map[string]any, readOnly bool) (any, error) {
+	for i, statement := range statements {
+		cf := sourceClassifier.Classify(statement)
+		if cf.Error != nil {
+			return nil, fmt.Errorf("statement %d: %w", i, cf.Error)
+		}
+		if cf.Type == classifier.WriteQuery && readOnly {
+			return nil, fmt.Errorf("statement %d: this tool is read-only and cannot execute write queries", i)
+		}
+	}
+
+	accessMode := neo4j.AccessModeWrite
+	if readOnly {
+		accessMode = neo4j.AccessModeRead
+	}
+	session := s.Neo4jDriver().NewSession(ctx, neo4j.SessionConfig{DatabaseName: s.Neo4jDatabase(), AccessMode: accessMode})
+	defer session.Close(context.WithoutCancel(ctx))
+
+	work := func(tx neo4j.ManagedTransaction) (any, error) {
+		results := make([]StatementResult, len(statements))
+		for i, statement := range statements {
+			result, err := tx.Run(ctx, statement, params)
+			if err != nil {
+				return nil, fmt.Errorf("statement %d: unable to execute query: %w", i, err)
+			}
+			records, err := result.Collect(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("statement %d: unable to read records: %w", i, err)
+			}
+			summary, err := result.Consume(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("statement %d: unable to read summary: %w", i, err)
+			}
+			out := []map[string]any{}
+			for _, record := range records {
+				vMap := make(map[string]any)
+				for col, value := range record.Values {
+					vMap[record.Keys[col]] = helpers.ConvertValue(value)
+				}
+				out = append(out, vMap)
+			}
+			c := summary.Counters()
+			results[i] = StatementResult{
+				Records: out,
+				Counters: map[string]int{
+					"nodesCreated":         c.NodesCreated(),
+					"nodesDeleted":         c.NodesDeleted(),
+					"relationshipsCreated": c.RelationshipsCreated(),
+					"relationshipsDeleted": c.RelationshipsDeleted(),
+					"propertiesSet":        c.PropertiesSet(),
+				},
+			}
+		}
+		return results, nil
+	}
+	if readOnly {
+		return session.ExecuteRead(ctx, work)
+	}
+	return session.ExecuteWrite(ctx, work)
+}
+
 // Recursive function to add plan children
 func addPlanChildren(p neo4j.Plan) []map[string]any {
 	var children []map[string]any
@@ -98,7 +98,7 @@ func TestFailParseFromYaml(t *testing.T) {
 			database: my_db
 			user: my_user
 			`,
-			err: "error unmarshaling source: unable to parse source \"my-neo4j-instance\" as \"neo4j\": Key: 'Config.Password' Error:Field validation for 'Password' failed on the 'required' tag",
+			err: "error unmarshaling source: unable to parse source \"my-neo4j-instance\" as \"neo4j\": sources[my-neo4j-instance].password is required; add a \"password\" field",
 		},
 	}
 	for _, tc := range tcs {
@@ -129,7 +129,7 @@ func TestFailParseFromYamlOceanBase(t *testing.T) {
 			user: ob_user
 			password: ob_pass
 			`,
-			err: "error unmarshaling source: unable to parse source \"my-oceanbase-instance\" as \"oceanbase\": Key: 'Config.Host' Error:Field validation for 'Host' failed on the 'required' tag",
+			err: "error unmarshaling source: unable to parse source \"my-oceanbase-instance\" as \"oceanbase\": sources[my-oceanbase-instance].host is required; add a \"host\" field",
 		},
 	}
 	for _, tc := range tcs {
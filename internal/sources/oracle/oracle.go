@@ -25,7 +25,7 @@ const SourceType string = "oracle"
 var _ sources.SourceConfig = Config{}
 
 func init() {
-	if !sources.Register(SourceType, newConfig) {
+	if !sources.Register[*Source](SourceType, newConfig) {
 		panic(fmt.Sprintf("source type %q already registered", SourceType))
 	}
 }
@@ -137,6 +137,11 @@ func (s *Source) OracleDB() *sql.DB {
 	return s.DB
 }
 
+// Close closes the connection pool of the source.
+func (s *Source) Close() error {
+	return s.DB.Close()
+}
+
 func (s *Source) RunSQL(ctx context.Context, statement string, params []any, readOnly bool) (any, error) {
 	if !readOnly {
 		result, err := s.OracleDB().ExecContext(ctx, statement, params...)
@@ -197,7 +197,7 @@ func TestFailParseFromYaml(t *testing.T) {
 			serviceName: ORCL
 			user: my_user
 			`,
-			err: "error unmarshaling source: unable to parse source \"my-oracle-instance\" as \"oracle\": Key: 'Config.Password' Error:Field validation for 'Password' failed on the 'required' tag",
+			err: "error unmarshaling source: unable to parse source \"my-oracle-instance\" as \"oracle\": sources[my-oracle-instance].password is required; add a \"password\" field",
 		},
 		{
 			desc: "missing connection method fields (validate fails)",
@@ -0,0 +1,104 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package pgpool configures the size of the connection pool of a
+// PostgreSQL-family source, so that many Toolbox replicas sharing a database
+// stay within its max_connections.
+package pgpool
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Sizing are the minConns, maxConns, maxConnIdleTime, maxConnLifetime and
+// lazyConnect options of a PostgreSQL-family source. Sources embed it inline
+// in their config. Options left unset keep the defaults of the driver.
+type Sizing struct {
+	// MinConns is the number of connections the pool keeps open, even when
+	// idle.
+	MinConns *int32 `yaml:"minConns" validate:"omitempty,gte=0"`
+	// MaxConns is the most connections the pool opens at once.
+	MaxConns *int32 `yaml:"maxConns" validate:"omitempty,gte=1"`
+	// MaxConnIdleTime is how long a connection may stay idle, as a duration
+	// such as "5m", before it is closed.
+	MaxConnIdleTime string `yaml:"maxConnIdleTime"`
+	// MaxConnLifetime is how long a connection may stay open, as a duration
+	// such as "1h", before it is closed once released.
+	MaxConnLifetime string `yaml:"maxConnLifetime"`
+	// LazyConnect skips connecting to the database at startup, opening the
+	// first connection when a tool first needs one.
+	LazyConnect bool `yaml:"lazyConnect"`
+}
+
+func duration(field, value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid %s %q: must be a positive duration such as \"5m\"", field, value)
+	}
+	return d, nil
+}
+
+// Validate checks the durations of s, and that MinConns does not exceed
+// MaxConns.
+func (s Sizing) Validate() error {
+	if _, err := duration("maxConnIdleTime", s.MaxConnIdleTime); err != nil {
+		return err
+	}
+	if _, err := duration("maxConnLifetime", s.MaxConnLifetime); err != nil {
+		return err
+	}
+	if s.MinConns != nil && s.MaxConns != nil && *s.MinConns > *s.MaxConns {
+		return fmt.Errorf("minConns (%d) cannot exceed maxConns (%d)", *s.MinConns, *s.MaxConns)
+	}
+	return nil
+}
+
+// IdleTime returns MaxConnIdleTime, or 0 when it is unset or invalid.
+func (s Sizing) IdleTime() time.Duration {
+	d, _ := duration("maxConnIdleTime", s.MaxConnIdleTime)
+	return d
+}
+
+// Lifetime returns MaxConnLifetime, or 0 when it is unset or invalid.
+func (s Sizing) Lifetime() time.Duration {
+	d, _ := duration("maxConnLifetime", s.MaxConnLifetime)
+	return d
+}
+
+// Apply sets the pool sizing of config. s must have been validated. When
+// only MinConns is set above the default MaxConns of the driver, MaxConns is
+// raised to match.
+func (s Sizing) Apply(config *pgxpool.Config) {
+	if s.MaxConns != nil {
+		config.MaxConns = *s.MaxConns
+	}
+	if s.MinConns != nil {
+		config.MinConns = *s.MinConns
+		if config.MinConns > config.MaxConns {
+			config.MaxConns = config.MinConns
+		}
+	}
+	if d := s.IdleTime(); d > 0 {
+		config.MaxConnIdleTime = d
+	}
+	if d := s.Lifetime(); d > 0 {
+		config.MaxConnLifetime = d
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pgpool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func ptr(v int32) *int32 { return &v }
+
+func TestValidate(t *testing.T) {
+	tcs := []struct {
+		desc    string
+		sizing  Sizing
+		wantErr bool
+	}{
+		{desc: "empty", sizing: Sizing{}},
+		{desc: "valid", sizing: Sizing{MinConns: ptr(2), MaxConns: ptr(10), MaxConnIdleTime: "5m", MaxConnLifetime: "1h"}},
+		{desc: "min above max", sizing: Sizing{MinConns: ptr(5), MaxConns: ptr(2)}, wantErr: true},
+		{desc: "bad idle time", sizing: Sizing{MaxConnIdleTime: "soon"}, wantErr: true},
+		{desc: "negative lifetime", sizing: Sizing{MaxConnLifetime: "-1h"}, wantErr: true},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := tc.sizing.Validate()
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	config, err := pgxpool.ParseConfig("postgres://u:p@localhost:5432/db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defaults := *config
+
+	Sizing{}.Apply(config)
+	if config.MaxConns != defaults.MaxConns || config.MinConns != defaults.MinConns || config.MaxConnIdleTime != defaults.MaxConnIdleTime || config.MaxConnLifetime != defaults.MaxConnLifetime {
+		t.Fatalf("empty sizing changed the driver defaults")
+	}
+
+	Sizing{MinConns: ptr(1), MaxConns: ptr(3), MaxConnIdleTime: "90s", MaxConnLifetime: "2h"}.Apply(config)
+	if config.MinConns != 1 || config.MaxConns != 3 || config.MaxConnIdleTime != 90*time.Second || config.MaxConnLifetime != 2*time.Hour {
+		t.Fatalf("unexpected sizing: min %d, max %d, idle %s, lifetime %s", config.MinConns, config.MaxConns, config.MaxConnIdleTime, config.MaxConnLifetime)
+	}
+
+	Sizing{MinConns: ptr(1000)}.Apply(config)
+	if config.MaxConns != 1000 {
+		t.Fatalf("expected maxConns raised to minConns, got %d", config.MaxConns)
+	}
+}
@@ -0,0 +1,280 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/mcp-toolbox/internal/sources"
+	"github.com/googleapis/mcp-toolbox/pkg/plugin"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const SourceType string = "plugin"
+
+// closeTimeout is how long the program is given to exit once its stdin is
+// closed, before it is killed.
+const closeTimeout = 5 * time.Second
+
+// validate interface
+var _ sources.SourceConfig = Config{}
+
+func init() {
+	if !sources.Register[*Source](SourceType, newConfig) {
+		panic(fmt.Sprintf("source type %q already registered", SourceType))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
+	actual := Config{Name: name, StartupTimeout: "30s"}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name           string            `yaml:"name" validate:"required"`
+	Type           string            `yaml:"type" validate:"required"`
+	Command        string            `yaml:"command" validate:"required"`
+	Args           []string          `yaml:"args"`
+	Env            map[string]string `yaml:"env"`
+	Settings       map[string]any    `yaml:"settings"`
+	StartupTimeout string            `yaml:"startupTimeout"`
+}
+
+func (r Config) SourceConfigType() string {
+	return SourceType
+}
+
+// Initialize starts the plugin program and initializes it.
+func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
+	startupTimeout, err := time.ParseDuration(r.StartupTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse startupTimeout %q: %w", r.StartupTimeout, err)
+	}
+
+	cmd := exec.Command(r.Command, r.Args...)
+	cmd.Env = os.Environ()
+	keys := make([]string, 0, len(r.Env))
+	for k := range r.Env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		cmd.Env = append(cmd.Env, k+"="+r.Env[k])
+	}
+	cmd.Stderr = os.Stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("unable to create stdin of plugin %q: %w", r.Name, err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("unable to create stdout of plugin %q: %w", r.Name, err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("unable to start plugin %q: %w", r.Name, err)
+	}
+
+	s := &Source{
+		Config:  r,
+		cmd:     cmd,
+		stdin:   stdin,
+		pending: make(map[uint64]chan plugin.Response),
+		done:    make(chan struct{}),
+	}
+	go s.read(stdout)
+
+	initCtx, cancel := context.WithTimeout(ctx, startupTimeout)
+	defer cancel()
+	raw, err := s.call(initCtx, plugin.MethodInitialize, plugin.InitializeParams{
+		ProtocolVersion: plugin.ProtocolVersion,
+		Source:          r.Name,
+		Settings:        r.Settings,
+	})
+	if err == nil {
+		var result plugin.InitializeResult
+		if err = json.Unmarshal(raw, &result); err == nil && result.ProtocolVersion != plugin.ProtocolVersion {
+			err = fmt.Errorf("plugin implements protocol version %d, want %d", result.ProtocolVersion, plugin.ProtocolVersion)
+		}
+	}
+	if err != nil {
+		_ = s.Close()
+		return nil, fmt.Errorf("unable to initialize plugin %q: %w", r.Name, err)
+	}
+	return s, nil
+}
+
+var _ sources.Source = &Source{}
+
+// Source is a running plugin program.
+type Source struct {
+	Config
+	cmd   *exec.Cmd
+	stdin io.WriteCloser
+
+	// writeMu serializes the requests written to stdin.
+	writeMu sync.Mutex
+
+	mu      sync.Mutex
+	nextID  uint64
+	pending map[uint64]chan plugin.Response
+	// exitErr is set when the program has exited, before done is closed.
+	exitErr error
+	done    chan struct{}
+}
+
+func (s *Source) SourceType() string {
+	return SourceType
+}
+
+func (s *Source) ToConfig() sources.SourceConfig {
+	return s.Config
+}
+
+// InvokePlugin sends an invocation to the plugin program and returns its
+// result. Errors returned by the program are *plugin.Error.
+func (s *Source) InvokePlugin(ctx context.Context, params plugin.InvokeParams) (any, error) {
+	raw, err := s.call(ctx, plugin.MethodInvoke, params)
+	if err != nil {
+		return nil, err
+	}
+	var result any
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return nil, fmt.Errorf("invalid result from plugin %q: %w", s.Name, err)
+	}
+	return result, nil
+}
+
+// Ping checks that the plugin program is running and answering requests.
+func (s *Source) Ping(ctx context.Context) error {
+	_, err := s.call(ctx, plugin.MethodPing, nil)
+	return err
+}
+
+// Close closes the stdin of the plugin program and waits for it to exit,
+// killing it if it does not within closeTimeout.
+func (s *Source) Close() error {
+	s.writeMu.Lock()
+	err := s.stdin.Close()
+	s.writeMu.Unlock()
+	select {
+	case <-s.done:
+	case <-time.After(closeTimeout):
+		_ = s.cmd.Process.Kill()
+		<-s.done
+	}
+	if errors.Is(err, os.ErrClosed) {
+		return nil
+	}
+	return err
+}
+
+func (s *Source) call(ctx context.Context, method string, params any) (json.RawMessage, error) {
+	req := plugin.Request{Method: method}
+	if params != nil {
+		b, err := json.Marshal(params)
+		if err != nil {
+			return nil, fmt.Errorf("unable to encode %s request: %w", method, err)
+		}
+		req.Params = b
+	}
+
+	ch := make(chan plugin.Response, 1)
+	s.mu.Lock()
+	if s.exitErr != nil {
+		s.mu.Unlock()
+		return nil, s.exitErr
+	}
+	s.nextID++
+	req.ID = s.nextID
+	s.pending[req.ID] = ch
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.pending, req.ID)
+		s.mu.Unlock()
+	}()
+
+	line, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode %s request: %w", method, err)
+	}
+	s.writeMu.Lock()
+	_, err = s.stdin.Write(append(line, '\n'))
+	s.writeMu.Unlock()
+	if err != nil {
+		return nil, fmt.Errorf("unable to send %s request to plugin %q: %w", method, s.Name, err)
+	}
+
+	select {
+	case resp := <-ch:
+		if resp.Error != nil {
+			return nil, resp.Error
+		}
+		return resp.Result, nil
+	case <-s.done:
+		return nil, s.exitErr
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// read dispatches the responses of the program to the pending calls until
+// the program exits.
+func (s *Source) read(stdout io.Reader) {
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64<<20)
+	for scanner.Scan() {
+		var resp plugin.Response
+		if err := json.Unmarshal(scanner.Bytes(), &resp); err != nil {
+			// The output is not a response, e.g. a stray print.
+			continue
+		}
+		s.mu.Lock()
+		ch, ok := s.pending[resp.ID]
+		s.mu.Unlock()
+		if ok {
+			select {
+			case ch <- resp:
+			default:
+				// A duplicate response for the same request.
+			}
+		}
+	}
+	// Drain the output so that the program is not blocked writing to it.
+	_, _ = io.Copy(io.Discard, stdout)
+	waitErr := s.cmd.Wait()
+	s.mu.Lock()
+	if waitErr != nil {
+		s.exitErr = fmt.Errorf("plugin %q exited: %w", s.Name, waitErr)
+	} else {
+		s.exitErr = fmt.Errorf("plugin %q exited", s.Name)
+	}
+	s.mu.Unlock()
+	close(s.done)
+}
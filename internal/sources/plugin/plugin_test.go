@@ -0,0 +1,201 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/internal/server"
+	"github.com/googleapis/mcp-toolbox/internal/sources"
+	sourceplugin "github.com/googleapis/mcp-toolbox/internal/sources/plugin"
+	"github.com/googleapis/mcp-toolbox/internal/testutils"
+	"github.com/googleapis/mcp-toolbox/pkg/plugin"
+)
+
+// pluginEnv makes the test binary run as a plugin program.
+const pluginEnv = "TOOLBOX_TEST_PLUGIN"
+
+func TestMain(m *testing.M) {
+	if os.Getenv(pluginEnv) == "1" {
+		if err := plugin.Serve(context.Background(), testHandler{}, os.Stdin, os.Stdout); err != nil {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+type testHandler struct{}
+
+func (testHandler) Initialize(ctx context.Context, params plugin.InitializeParams) error {
+	if params.Settings["fail"] == true {
+		return errors.New("bad settings")
+	}
+	return nil
+}
+
+func (testHandler) Invoke(ctx context.Context, params plugin.InvokeParams) (any, error) {
+	switch params.Operation {
+	case "echo":
+		return map[string]any{"tool": params.Tool, "params": params.Params, "settings": params.Settings}, nil
+	case "reject":
+		return nil, plugin.AgentError("invalid id %v", params.Params["id"])
+	case "exit":
+		os.Exit(3)
+	}
+	return nil, errors.New("unknown operation")
+}
+
+func TestParseFromYamlPlugin(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		want server.SourceConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			kind: source
+			name: my-plugin
+			type: plugin
+			command: /usr/local/bin/ledger-plugin
+			`,
+			want: map[string]sources.SourceConfig{
+				"my-plugin": sourceplugin.Config{
+					Name:           "my-plugin",
+					Type:           sourceplugin.SourceType,
+					Command:        "/usr/local/bin/ledger-plugin",
+					StartupTimeout: "30s",
+				},
+			},
+		},
+		{
+			desc: "advanced example",
+			in: `
+			kind: source
+			name: my-plugin
+			type: plugin
+			command: /usr/local/bin/ledger-plugin
+			args: ["--verbose"]
+			env:
+			  LEDGER_TOKEN: secret
+			settings:
+			  host: ledger.internal
+			  port: 7000
+			startupTimeout: 1m
+			`,
+			want: map[string]sources.SourceConfig{
+				"my-plugin": sourceplugin.Config{
+					Name:           "my-plugin",
+					Type:           sourceplugin.SourceType,
+					Command:        "/usr/local/bin/ledger-plugin",
+					Args:           []string{"--verbose"},
+					Env:            map[string]string{"LEDGER_TOKEN": "secret"},
+					Settings:       map[string]any{"host": "ledger.internal", "port": uint64(7000)},
+					StartupTimeout: "1m",
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, _, _, _, _, _, err := server.UnmarshalPrimitiveConfig(context.Background(), testutils.FormatYaml(tc.in))
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func newTestSource(t *testing.T, settings map[string]any) (*sourceplugin.Source, error) {
+	t.Helper()
+	exe, err := os.Executable()
+	if err != nil {
+		t.Fatalf("unable to find test binary: %s", err)
+	}
+	cfg := sourceplugin.Config{
+		Name:           "my-plugin",
+		Type:           sourceplugin.SourceType,
+		Command:        exe,
+		Env:            map[string]string{pluginEnv: "1"},
+		Settings:       settings,
+		StartupTimeout: "10s",
+	}
+	s, err := cfg.Initialize(context.Background(), nil)
+	if err != nil {
+		return nil, err
+	}
+	t.Cleanup(func() { _ = s.(*sourceplugin.Source).Close() })
+	return s.(*sourceplugin.Source), nil
+}
+
+func TestInvokePlugin(t *testing.T) {
+	s, err := newTestSource(t, map[string]any{"host": "ledger.internal"})
+	if err != nil {
+		t.Fatalf("unable to initialize: %s", err)
+	}
+	ctx := context.Background()
+
+	if err := s.Ping(ctx); err != nil {
+		t.Fatalf("unexpected ping error: %s", err)
+	}
+
+	got, err := s.InvokePlugin(ctx, plugin.InvokeParams{
+		Tool:      "get_entry",
+		Operation: "echo",
+		Settings:  map[string]any{"table": "entries"},
+		Params:    map[string]any{"id": "42"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected invoke error: %s", err)
+	}
+	want := map[string]any{
+		"tool":     "get_entry",
+		"params":   map[string]any{"id": "42"},
+		"settings": map[string]any{"table": "entries"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect result: diff %v", diff)
+	}
+
+	_, err = s.InvokePlugin(ctx, plugin.InvokeParams{Tool: "get_entry", Operation: "reject", Params: map[string]any{"id": "x"}})
+	var pluginErr *plugin.Error
+	if !errors.As(err, &pluginErr) || !pluginErr.Agent || pluginErr.Message != "invalid id x" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = s.InvokePlugin(ctx, plugin.InvokeParams{Tool: "get_entry", Operation: "exit"})
+	if err == nil || !strings.Contains(err.Error(), "exited") {
+		t.Fatalf("expected exit error, got %v", err)
+	}
+	if err := s.Ping(ctx); err == nil {
+		t.Fatalf("expected ping error after exit")
+	}
+}
+
+func TestInitializePluginError(t *testing.T) {
+	_, err := newTestSource(t, map[string]any{"fail": true})
+	if err == nil || !strings.Contains(err.Error(), "bad settings") {
+		t.Fatalf("expected initialize error, got %v", err)
+	}
+}
@@ -16,28 +16,45 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
 	"net"
 	"net/url"
 	"time"
 
 	"github.com/goccy/go-yaml"
 	"github.com/googleapis/mcp-toolbox/internal/sources"
+	"github.com/googleapis/mcp-toolbox/internal/sources/appname"
+	"github.com/googleapis/mcp-toolbox/internal/sources/clienttls"
+	"github.com/googleapis/mcp-toolbox/internal/sources/pgpool"
+	"github.com/googleapis/mcp-toolbox/internal/sources/queryguard"
+	"github.com/googleapis/mcp-toolbox/internal/sources/schemadoc"
+	"github.com/googleapis/mcp-toolbox/internal/sources/schemasnapshot"
+	"github.com/googleapis/mcp-toolbox/internal/sources/sessionrole"
 	"github.com/googleapis/mcp-toolbox/internal/sources/sqlcommenter"
+	"github.com/googleapis/mcp-toolbox/internal/sources/statementcache"
+	"github.com/googleapis/mcp-toolbox/internal/telemetry"
 	"github.com/googleapis/mcp-toolbox/internal/util"
 	"github.com/googleapis/mcp-toolbox/internal/util/orderedmap"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/trace"
 )
 
 const SourceType string = "postgres"
 
+// defaultAcquireTimeout is how long an invocation waits for a connection of
+// the pool to become free.
+const defaultAcquireTimeout = 30 * time.Second
+
 // validate interface
 var _ sources.SourceConfig = Config{}
 
 func init() {
-	if !sources.Register(SourceType, newConfig) {
+	if !sources.Register[*Source](SourceType, newConfig) {
 		panic(fmt.Sprintf("source type %q already registered", SourceType))
 	}
 }
@@ -65,26 +82,125 @@ type Config struct {
 	// take, in seconds. When unset, no timeout is applied and connection behavior
 	// is unchanged.
 	ConnectTimeout *int `yaml:"connectTimeout" validate:"omitempty,gte=1"`
+	// AcquireTimeout bounds how long an invocation waits for a connection of
+	// the pool to become free, as a duration such as "10s". Defaults to 30s.
+	AcquireTimeout string `yaml:"acquireTimeout"`
+	// QueryTimeout bounds how long a statement may run, as a duration such
+	// as "30s", through the statement_timeout of the connections.
+	QueryTimeout string `yaml:"queryTimeout"`
+	// Role is "primary" or "replica". Replicas can report their replication
+	// lag and fail over to a primary.
+	Role string `yaml:"role" validate:"omitempty,oneof=primary replica"`
+	// ReportReplicaLag attaches the replication lag of a replica to the
+	// results of the invocations reading from it.
+	ReportReplicaLag bool `yaml:"reportReplicaLag"`
+	// MaxReplicaLag is the replication lag, such as "30s", past which
+	// invocations fail over to FailoverSource.
+	MaxReplicaLag string `yaml:"maxReplicaLag"`
+	// FailoverSource is the postgres source invocations fail over to.
+	FailoverSource string `yaml:"failoverSource"`
+	// SchemaSnapshot optionally compares the schema of the database against
+	// a snapshot at startup.
+	SchemaSnapshot *schemasnapshot.Config `yaml:"schemaSnapshot"`
+	// ReadOnly rejects the statements that may write, and runs every
+	// transaction read-only.
+	ReadOnly bool `yaml:"readOnly"`
+	// Patterns optionally restrict the statements that tools may run.
+	queryguard.Patterns `yaml:",inline"`
+	// Capacities optionally size the statement caches of the connections.
+	statementcache.Capacities `yaml:",inline"`
+	// Options optionally name the connections in pg_stat_activity.
+	appname.Options `yaml:",inline"`
+	// Sizing optionally sizes the connection pool and defers connecting.
+	pgpool.Sizing `yaml:",inline"`
+	// Impersonation optionally runs the queries of authenticated invocations
+	// as a database role mapped from their principal.
+	Impersonation *sessionrole.Config `yaml:"impersonation"`
+	// TLS optionally configures the TLS connections to the database,
+	// overriding the sslmode of QueryParams.
+	TLS *clienttls.Config `yaml:"tls"`
 }
 
 func (r Config) SourceConfigType() string {
 	return SourceType
 }
 
+func (r Config) acquireTimeout() (time.Duration, error) {
+	if r.AcquireTimeout == "" {
+		return defaultAcquireTimeout, nil
+	}
+	d, err := time.ParseDuration(r.AcquireTimeout)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid acquireTimeout %q: must be a positive duration such as \"10s\"", r.AcquireTimeout)
+	}
+	return d, nil
+}
+
 func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
-	pool, err := initPostgresConnectionPool(ctx, tracer, r.Name, r.Host, r.Port, r.User, r.Password, r.Database, r.QueryParams, r.QueryExecMode, r.ConnectTimeout)
+	guard, err := r.Patterns.Compile(r.Name)
 	if err != nil {
-		return nil, fmt.Errorf("unable to create pool: %w", err)
+		return nil, err
+	}
+	acquireTimeout, err := r.acquireTimeout()
+	if err != nil {
+		return nil, err
+	}
+	if err := r.validateReplica(); err != nil {
+		return nil, err
+	}
+	if err := r.Capacities.Validate(r.QueryExecMode); err != nil {
+		return nil, err
+	}
+	if err := r.Sizing.Validate(); err != nil {
+		return nil, err
+	}
+	maxReplicaLag, err := r.maxReplicaLag()
+	if err != nil {
+		return nil, err
 	}
 
-	err = pool.Ping(ctx)
+	statementTimeout, err := sources.PostgresStatementTimeout(r.QueryTimeout)
 	if err != nil {
-		return nil, fmt.Errorf("unable to connect successfully: %w", err)
+		return nil, err
+	}
+
+	queryParams := r.QueryParams
+	if r.ReadOnly || statementTimeout != "" {
+		queryParams = make(map[string]string, len(r.QueryParams)+2)
+		maps.Copy(queryParams, r.QueryParams)
+	}
+	if r.ReadOnly {
+		// the database rejects the writes the statement check misses, such
+		// as the ones of functions
+		queryParams["default_transaction_read_only"] = "on"
+	}
+	if statementTimeout != "" {
+		queryParams["statement_timeout"] = statementTimeout
+	}
+	pool, err := initPostgresConnectionPool(ctx, tracer, r.Name, r.Host, r.Port, r.User, r.Password, r.Database, queryParams, r.QueryExecMode, r.ConnectTimeout, r.Capacities, r.Options, r.Sizing, r.Impersonation, r.TLS)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create pool: %w", err)
+	}
+
+	if !r.LazyConnect {
+		err = pool.Ping(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to connect successfully: %w", err)
+		}
 	}
 
 	s := &Source{
-		Config: r,
-		Pool:   pool,
+		Config:         r,
+		Pool:           pool,
+		acquireTimeout: acquireTimeout,
+	}
+	s.Guard = guard.WithReadOnly(r.ReadOnly, queryguard.Postgres)
+	s.Report, err = schemasnapshot.Check(ctx, r.Name, r.SchemaSnapshot, schemasnapshot.PostgresQuery, s.RunSQL)
+	if err != nil {
+		return nil, fmt.Errorf("unable to check schema snapshot: %w", err)
+	}
+	if r.ReportReplicaLag {
+		s.replica = newReplicaLag(s.measureReplicaLag, maxReplicaLag)
 	}
 	return s, nil
 }
@@ -93,7 +209,12 @@ var _ sources.Source = &Source{}
 
 type Source struct {
 	Config
-	Pool *pgxpool.Pool
+	schemasnapshot.Report
+	queryguard.Guard
+	Pool           *pgxpool.Pool
+	acquireTimeout time.Duration
+	// replica measures the replication lag of replicas reporting it.
+	replica *replicaLag
 }
 
 func (s *Source) SourceType() string {
@@ -108,35 +229,133 @@ func (s *Source) PostgresPool() *pgxpool.Pool {
 	return s.Pool
 }
 
+// Close closes the connection pool of the source.
+func (s *Source) Close() error {
+	s.Pool.Close()
+	return nil
+}
+
+// Ping checks the connection of the source to the database.
+func (s *Source) Ping(ctx context.Context) error {
+	return s.Pool.Ping(ctx)
+}
+
+// PoolStats returns the statistics of the connection pool of the source.
+func (s *Source) PoolStats() telemetry.PoolStats {
+	return telemetry.PgxPoolStats(s.Pool.Stat())
+}
+
+// Acquire returns a connection of the pool, waiting at most the acquire
+// timeout of the source for one to become free. When every connection stays
+// in use, the error is a *util.SourceBusyError.
+func (s *Source) Acquire(ctx context.Context) (*pgxpool.Conn, error) {
+	timeout := s.acquireTimeout
+	if timeout == 0 {
+		timeout = defaultAcquireTimeout
+	}
+	acquireCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	conn, err := s.Pool.Acquire(acquireCtx)
+	recordAcquireDuration(ctx, s.Name, time.Since(start), err == nil)
+	if err == nil {
+		return conn, nil
+	}
+	stat := s.Pool.Stat()
+	// the acquire timed out, rather than the caller giving up, while the pool
+	// was full
+	exhausted := stat.TotalConns() >= stat.MaxConns() && stat.IdleConns() == 0
+	if ctx.Err() == nil && errors.Is(acquireCtx.Err(), context.DeadlineExceeded) && exhausted {
+		return nil, &util.SourceBusyError{
+			Source:   s.Name,
+			Timeout:  timeout,
+			Total:    stat.TotalConns(),
+			Idle:     stat.IdleConns(),
+			Acquired: stat.AcquiredConns(),
+			Max:      stat.MaxConns(),
+		}
+	}
+	return nil, fmt.Errorf("unable to acquire connection: %w", err)
+}
+
+// recordAcquireDuration records how long acquiring a connection of the pool
+// of source took.
+func recordAcquireDuration(ctx context.Context, source string, d time.Duration, acquired bool) {
+	instrumentation, err := util.InstrumentationFromContext(ctx)
+	if err != nil || instrumentation.PoolAcquireDuration == nil {
+		return
+	}
+	instrumentation.PoolAcquireDuration.Record(ctx, d.Seconds(), metric.WithAttributes(
+		attribute.String("toolbox.source.name", source),
+		attribute.String("toolbox.source.type", SourceType),
+		attribute.Bool("toolbox.source.acquired", acquired),
+	))
+}
+
+// DescribeSchema returns the tables, columns and comments of the source.
+func (s *Source) DescribeSchema(ctx context.Context) (*schemadoc.Schema, error) {
+	return schemadoc.Describe(ctx, schemadoc.PostgresQuery, s.RunSQL)
+}
+
+// RunSQL runs statement on the source, or on its failover source when the
+// replication lag of the source exceeds its threshold.
 func (s *Source) RunSQL(ctx context.Context, statement string, params []any) (any, error) {
+	out := []any{}
+	err := s.StreamSQL(ctx, statement, params, func(row orderedmap.Row) error {
+		out = append(out, row)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// StreamSQL runs statement like RunSQL, passing each row to emit as it is
+// read rather than buffering the result. It stops at the first error emit
+// returns, and returns it.
+func (s *Source) StreamSQL(ctx context.Context, statement string, params []any, emit func(orderedmap.Row) error) error {
+	if s.replica != nil {
+		if failover := s.replica.route(ctx, s.Name); failover != nil {
+			return failover.StreamSQL(ctx, statement, params, emit)
+		}
+	}
 	statement = sqlcommenter.PrependComment(ctx, statement, SourceType, s.SQLCommenter)
-	results, err := s.PostgresPool().Query(ctx, statement, params...)
+	util.RecordStatement(ctx, statement, params)
+	conn, err := s.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+	results, err := conn.Query(ctx, statement, params...)
 	if err != nil {
-		return nil, fmt.Errorf("unable to execute query: %w", err)
+		return fmt.Errorf("unable to execute query: %w", err)
 	}
 	defer results.Close()
 
 	fields := results.FieldDescriptions()
-	out := []any{}
 	for results.Next() {
 		values, err := results.Values()
 		if err != nil {
-			return nil, fmt.Errorf("unable to parse row: %w", err)
+			return fmt.Errorf("unable to parse row: %w", err)
 		}
 		row := orderedmap.Row{}
 		for i, f := range fields {
 			row.Add(f.Name, values[i])
 		}
-		out = append(out, row)
+		if err := emit(row); err != nil {
+			return err
+		}
 	}
 	// this will catch actual query execution errors
 	if err := results.Err(); err != nil {
-		return nil, fmt.Errorf("unable to execute query: %w", err)
+		return fmt.Errorf("unable to execute query: %w", err)
 	}
-	return out, nil
+	return nil
 }
 
-func initPostgresConnectionPool(ctx context.Context, tracer trace.Tracer, name, host, port, user, pass, dbname string, queryParams map[string]string, queryExecMode string, connectTimeout *int) (*pgxpool.Pool, error) {
+func initPostgresConnectionPool(ctx context.Context, tracer trace.Tracer, name, host, port, user, pass, dbname string, queryParams map[string]string, queryExecMode string, connectTimeout *int, cache statementcache.Capacities, appName appname.Options, sizing pgpool.Sizing, impersonation *sessionrole.Config, tlsOpts *clienttls.Config) (*pgxpool.Pool, error) {
 	//nolint:all // Reassigned ctx
 	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceType, name)
 	defer span.End()
@@ -162,6 +381,19 @@ func initPostgresConnectionPool(ctx context.Context, tracer trace.Tracer, name,
 		return nil, err
 	}
 	config.ConnConfig.DefaultQueryExecMode = execMode
+	cache.Apply(config.ConnConfig)
+	appName.Apply(config)
+	sizing.Apply(config)
+	impersonation.Apply(config)
+
+	if tlsOpts != nil {
+		tlsConfig, err := tlsOpts.ClientConfig(host)
+		if err != nil {
+			return nil, err
+		}
+		config.ConnConfig.TLSConfig = tlsConfig
+		config.ConnConfig.Fallbacks = nil
+	}
 
 	if connectTimeout != nil {
 		config.ConnConfig.ConnectTimeout = time.Duration(*connectTimeout) * time.Second
@@ -21,7 +21,14 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/googleapis/mcp-toolbox/internal/server"
 	"github.com/googleapis/mcp-toolbox/internal/sources"
+	"github.com/googleapis/mcp-toolbox/internal/sources/appname"
+	"github.com/googleapis/mcp-toolbox/internal/sources/clienttls"
+	"github.com/googleapis/mcp-toolbox/internal/sources/pgpool"
 	"github.com/googleapis/mcp-toolbox/internal/sources/postgres"
+	"github.com/googleapis/mcp-toolbox/internal/sources/queryguard"
+	"github.com/googleapis/mcp-toolbox/internal/sources/schemasnapshot"
+	"github.com/googleapis/mcp-toolbox/internal/sources/sessionrole"
+	"github.com/googleapis/mcp-toolbox/internal/sources/statementcache"
 	"github.com/googleapis/mcp-toolbox/internal/testutils"
 	"github.com/jackc/pgx/v5"
 )
@@ -87,6 +94,41 @@ func TestParseFromYamlPostgres(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "example with tls",
+			in: `
+			kind: source
+			name: my-pg-instance
+			type: postgres
+			host: my-host
+			port: my-port
+			database: my_db
+			user: my_user
+			password: my_pass
+			tls:
+				mode: verify-ca
+				caFile: /tmp/ca.crt
+				certFile: /tmp/client.crt
+				keyFile: /tmp/client.key
+			`,
+			want: map[string]sources.SourceConfig{
+				"my-pg-instance": postgres.Config{
+					Name:     "my-pg-instance",
+					Type:     postgres.SourceType,
+					Host:     "my-host",
+					Port:     "my-port",
+					Database: "my_db",
+					User:     "my_user",
+					Password: "my_pass",
+					TLS: &clienttls.Config{
+						Mode:     clienttls.ModeVerifyCA,
+						CAFile:   "/tmp/ca.crt",
+						CertFile: "/tmp/client.crt",
+						KeyFile:  "/tmp/client.key",
+					},
+				},
+			},
+		},
 		{
 			desc: "example with query exec mode",
 			in: `
@@ -139,6 +181,256 @@ func TestParseFromYamlPostgres(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "example with acquire and query timeouts",
+			in: `
+			kind: source
+			name: my-pg-instance
+			type: postgres
+			host: my-host
+			port: my-port
+			database: my_db
+			user: my_user
+			password: my_pass
+			acquireTimeout: 5s
+			queryTimeout: 30s
+			`,
+			want: map[string]sources.SourceConfig{
+				"my-pg-instance": postgres.Config{
+					Name:           "my-pg-instance",
+					Type:           postgres.SourceType,
+					Host:           "my-host",
+					Port:           "my-port",
+					Database:       "my_db",
+					User:           "my_user",
+					Password:       "my_pass",
+					AcquireTimeout: "5s",
+					QueryTimeout:   "30s",
+				},
+			},
+		},
+		{
+			desc: "example with statement cache disabled",
+			in: `
+			kind: source
+			name: my-pg-instance
+			type: postgres
+			host: my-host
+			port: my-port
+			database: my_db
+			user: my_user
+			password: my_pass
+			statementCacheCapacity: 0
+			descriptionCacheCapacity: 256
+			`,
+			want: map[string]sources.SourceConfig{
+				"my-pg-instance": postgres.Config{
+					Name:     "my-pg-instance",
+					Type:     postgres.SourceType,
+					Host:     "my-host",
+					Port:     "my-port",
+					Database: "my_db",
+					User:     "my_user",
+					Password: "my_pass",
+					Capacities: statementcache.Capacities{
+						StatementCacheCapacity:   intPtr(0),
+						DescriptionCacheCapacity: intPtr(256),
+					},
+				},
+			},
+		},
+		{
+			desc: "example with application name",
+			in: `
+			kind: source
+			name: my-pg-instance
+			type: postgres
+			host: my-host
+			port: my-port
+			database: my_db
+			user: my_user
+			password: my_pass
+			applicationName: reporting
+			includeToolName: true
+			`,
+			want: map[string]sources.SourceConfig{
+				"my-pg-instance": postgres.Config{
+					Name:     "my-pg-instance",
+					Type:     postgres.SourceType,
+					Host:     "my-host",
+					Port:     "my-port",
+					Database: "my_db",
+					User:     "my_user",
+					Password: "my_pass",
+					Options: appname.Options{
+						ApplicationName: "reporting",
+						IncludeToolName: true,
+					},
+				},
+			},
+		},
+		{
+			desc: "example with pool sizing",
+			in: `
+			kind: source
+			name: my-pg-instance
+			type: postgres
+			host: my-host
+			port: my-port
+			database: my_db
+			user: my_user
+			password: my_pass
+			minConns: 1
+			maxConns: 5
+			maxConnIdleTime: 5m
+			maxConnLifetime: 1h
+			lazyConnect: true
+			`,
+			want: map[string]sources.SourceConfig{
+				"my-pg-instance": postgres.Config{
+					Name:     "my-pg-instance",
+					Type:     postgres.SourceType,
+					Host:     "my-host",
+					Port:     "my-port",
+					Database: "my_db",
+					User:     "my_user",
+					Password: "my_pass",
+					Sizing: pgpool.Sizing{
+						MinConns:        int32Ptr(1),
+						MaxConns:        int32Ptr(5),
+						MaxConnIdleTime: "5m",
+						MaxConnLifetime: "1h",
+						LazyConnect:     true,
+					},
+				},
+			},
+		},
+		{
+			desc: "example with impersonation",
+			in: `
+			kind: source
+			name: my-pg-instance
+			type: postgres
+			host: my-host
+			port: my-port
+			database: my_db
+			user: my_user
+			password: my_pass
+			impersonation:
+				authService: my-google-auth
+				roles:
+					alice@example.com: analyst
+			`,
+			want: map[string]sources.SourceConfig{
+				"my-pg-instance": postgres.Config{
+					Name:     "my-pg-instance",
+					Type:     postgres.SourceType,
+					Host:     "my-host",
+					Port:     "my-port",
+					Database: "my_db",
+					User:     "my_user",
+					Password: "my_pass",
+					Impersonation: &sessionrole.Config{
+						AuthService: "my-google-auth",
+						Roles:       map[string]string{"alice@example.com": "analyst"},
+					},
+				},
+			},
+		},
+		{
+			desc: "example with replica",
+			in: `
+			kind: source
+			name: my-pg-replica
+			type: postgres
+			host: my-host
+			port: my-port
+			database: my_db
+			user: my_user
+			password: my_pass
+			role: replica
+			reportReplicaLag: true
+			maxReplicaLag: 30s
+			failoverSource: my-pg-instance
+			`,
+			want: map[string]sources.SourceConfig{
+				"my-pg-replica": postgres.Config{
+					Name:             "my-pg-replica",
+					Type:             postgres.SourceType,
+					Host:             "my-host",
+					Port:             "my-port",
+					Database:         "my_db",
+					User:             "my_user",
+					Password:         "my_pass",
+					Role:             postgres.RoleReplica,
+					ReportReplicaLag: true,
+					MaxReplicaLag:    "30s",
+					FailoverSource:   "my-pg-instance",
+				},
+			},
+		},
+		{
+			desc: "example with schema snapshot",
+			in: `
+			kind: source
+			name: my-pg-instance
+			type: postgres
+			host: my-host
+			port: my-port
+			database: my_db
+			user: my_user
+			password: my_pass
+			schemaSnapshot:
+				path: ./snapshot.yaml
+				warnOnDrift: true
+			`,
+			want: map[string]sources.SourceConfig{
+				"my-pg-instance": postgres.Config{
+					Name:           "my-pg-instance",
+					Type:           postgres.SourceType,
+					Host:           "my-host",
+					Port:           "my-port",
+					Database:       "my_db",
+					User:           "my_user",
+					Password:       "my_pass",
+					SchemaSnapshot: &schemasnapshot.Config{Path: "./snapshot.yaml", WarnOnDrift: true},
+				},
+			},
+		},
+		{
+			desc: "example with query patterns",
+			in: `
+			kind: source
+			name: my-pg-instance
+			type: postgres
+			host: my-host
+			port: my-port
+			database: my_db
+			user: my_user
+			password: my_pass
+			denyPatterns:
+				- label: drop-table
+				  pattern: (?i)drop\s+table
+			allowPatterns:
+				- label: select
+				  pattern: (?i)^\s*select\b
+			`,
+			want: map[string]sources.SourceConfig{
+				"my-pg-instance": postgres.Config{
+					Name:     "my-pg-instance",
+					Type:     postgres.SourceType,
+					Host:     "my-host",
+					Port:     "my-port",
+					Database: "my_db",
+					User:     "my_user",
+					Password: "my_pass",
+					Patterns: queryguard.Patterns{
+						DenyPatterns:  []queryguard.Pattern{{Label: "drop-table", Pattern: `(?i)drop\s+table`}},
+						AllowPatterns: []queryguard.Pattern{{Label: "select", Pattern: `(?i)^\s*select\b`}},
+					},
+				},
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -186,7 +478,7 @@ func TestFailParseFromYaml(t *testing.T) {
 			database: my_db
 			user: my_user
 			`,
-			err: "error unmarshaling source: unable to parse source \"my-pg-instance\" as \"postgres\": Key: 'Config.Password' Error:Field validation for 'Password' failed on the 'required' tag",
+			err: "error unmarshaling source: unable to parse source \"my-pg-instance\" as \"postgres\": sources[my-pg-instance].password is required; add a \"password\" field",
 		},
 		{
 			desc: "invalid query exec mode",
@@ -201,7 +493,7 @@ func TestFailParseFromYaml(t *testing.T) {
 			password: my_pass
 			queryExecMode: invalid_mode
 			`,
-			err: "error unmarshaling source: unable to parse source \"my-pg-instance\" as \"postgres\": [6:16] Key: 'Config.QueryExecMode' Error:Field validation for 'QueryExecMode' failed on the 'oneof' tag\n   3 | name: my-pg-instance\n   4 | password: my_pass\n   5 | port: my-port\n>  6 | queryExecMode: invalid_mode\n                      ^\n   7 | type: postgres\n   8 | user: my_user",
+			err: "error unmarshaling source: unable to parse source \"my-pg-instance\" as \"postgres\": sources[my-pg-instance].queryExecMode has invalid value \"invalid_mode\": must be one of: cache_statement, cache_describe, describe_exec, exec, simple_protocol; set it to one of these values",
 		},
 		{
 			desc: "connect timeout below minimum",
@@ -216,7 +508,7 @@ func TestFailParseFromYaml(t *testing.T) {
 			password: my_pass
 			connectTimeout: 0
 			`,
-			err: "error unmarshaling source: unable to parse source \"my-pg-instance\" as \"postgres\": [1:17] Key: 'Config.ConnectTimeout' Error:Field validation for 'ConnectTimeout' failed on the 'gte' tag\n>  1 | connectTimeout: 0\n                       ^\n   2 | database: my_db\n   3 | host: my-host\n   4 | name: my-pg-instance\n   5 | ",
+			err: "error unmarshaling source: unable to parse source \"my-pg-instance\" as \"postgres\": sources[my-pg-instance].connectTimeout has invalid value \"0\": must be at least 1; set it to 1 or more",
 		},
 	}
 	for _, tc := range tcs {
@@ -237,6 +529,10 @@ func intPtr(v int) *int {
 	return &v
 }
 
+func int32Ptr(v int32) *int32 {
+	return &v
+}
+
 func TestBuildPostgresURL(t *testing.T) {
 	tcs := []struct {
 		desc        string
@@ -0,0 +1,163 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/googleapis/mcp-toolbox/internal/sources"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+)
+
+// The roles of a source in a replicated deployment.
+const (
+	RolePrimary = "primary"
+	RoleReplica = "replica"
+)
+
+// replicaLagTTL is how long a measurement of the replication lag is reused.
+const replicaLagTTL = time.Second
+
+// replicaLagQuery returns the replication lag in seconds, or 0 on a server
+// that is not replaying.
+const replicaLagQuery = `SELECT COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0)::float8`
+
+func (r Config) maxReplicaLag() (time.Duration, error) {
+	if r.MaxReplicaLag == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(r.MaxReplicaLag)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid maxReplicaLag %q: must be a positive duration such as \"30s\"", r.MaxReplicaLag)
+	}
+	return d, nil
+}
+
+// validateReplica checks the replica fields of the configuration.
+func (r Config) validateReplica() error {
+	if r.ReportReplicaLag && r.Role != RoleReplica {
+		return fmt.Errorf("reportReplicaLag requires role %q", RoleReplica)
+	}
+	if (r.MaxReplicaLag == "") != (r.FailoverSource == "") {
+		return fmt.Errorf("maxReplicaLag and failoverSource must be set together")
+	}
+	if r.MaxReplicaLag != "" && !r.ReportReplicaLag {
+		return fmt.Errorf("maxReplicaLag requires reportReplicaLag")
+	}
+	if r.FailoverSource == r.Name && r.Name != "" {
+		return fmt.Errorf("failoverSource cannot be the source itself")
+	}
+	_, err := r.maxReplicaLag()
+	return err
+}
+
+// replicaLag measures the replication lag of a replica, reusing a
+// measurement for replicaLagTTL, and picks the source invocations run on.
+type replicaLag struct {
+	measure func(context.Context) (float64, error)
+	now     func() time.Time
+	// max is the lag past which invocations fail over, or 0 to never fail
+	// over.
+	max      time.Duration
+	failover *Source
+
+	mu         sync.Mutex
+	measuredAt time.Time
+	seconds    float64
+}
+
+func newReplicaLag(measure func(context.Context) (float64, error), maxLag time.Duration) *replicaLag {
+	return &replicaLag{measure: measure, now: time.Now, max: maxLag}
+}
+
+// lag returns the replication lag in seconds.
+func (l *replicaLag) lag(ctx context.Context) (float64, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	if !l.measuredAt.IsZero() && now.Sub(l.measuredAt) < replicaLagTTL {
+		return l.seconds, nil
+	}
+	seconds, err := l.measure(ctx)
+	if err != nil {
+		return 0, err
+	}
+	l.measuredAt, l.seconds = now, seconds
+	return seconds, nil
+}
+
+// route returns the failover source if the lag of the replica exceeds the
+// threshold, or else nil after recording the lag in the util.ReplicaLag of
+// ctx. A lag that cannot be measured is logged and leaves the invocation on
+// the replica.
+func (l *replicaLag) route(ctx context.Context, name string) *Source {
+	seconds, err := l.lag(ctx)
+	if err != nil {
+		if logger, lErr := util.LoggerFromContext(ctx); lErr == nil {
+			logger.WarnContext(ctx, fmt.Sprintf("unable to measure the replication lag of source %q: %s", name, err))
+		}
+		return nil
+	}
+	if l.failover != nil && seconds > l.max.Seconds() {
+		if logger, lErr := util.LoggerFromContext(ctx); lErr == nil {
+			logger.WarnContext(ctx, fmt.Sprintf("replication lag of source %q is %.1fs, failing over to source %q", name, seconds, l.failover.Name))
+		}
+		return l.failover
+	}
+	if rl := util.ReplicaLagFromContext(ctx); rl != nil {
+		rl.Measured = true
+		rl.Seconds = seconds
+	}
+	return nil
+}
+
+// measureReplicaLag queries the replication lag of the source.
+func (s *Source) measureReplicaLag(ctx context.Context) (float64, error) {
+	conn, err := s.Acquire(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Release()
+	var seconds float64
+	if err := conn.QueryRow(ctx, replicaLagQuery).Scan(&seconds); err != nil {
+		return 0, fmt.Errorf("unable to query replication lag: %w", err)
+	}
+	return seconds, nil
+}
+
+var _ sources.Linker = &Source{}
+
+// Link resolves the failover source of a replica.
+func (s *Source) Link(sourcesMap map[string]sources.Source) error {
+	if s.replica == nil || s.FailoverSource == "" {
+		return nil
+	}
+	src, ok := sourcesMap[s.FailoverSource]
+	if !ok {
+		return fmt.Errorf("failoverSource %q does not exist", s.FailoverSource)
+	}
+	failover, ok := src.(*Source)
+	if !ok {
+		return fmt.Errorf("failoverSource %q must be a %q source", s.FailoverSource, SourceType)
+	}
+	if failover.Role == RoleReplica {
+		return fmt.Errorf("failoverSource %q must not be a replica", s.FailoverSource)
+	}
+	s.replica.failover = failover
+	return nil
+}
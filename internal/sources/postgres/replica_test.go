@@ -0,0 +1,201 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgres
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/googleapis/mcp-toolbox/internal/sources"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+)
+
+// fakeLag returns a replicaLag measuring the lags in turn, and counts the
+// measurements.
+func fakeLag(maxLag time.Duration, lags ...float64) (*replicaLag, *int) {
+	calls := 0
+	l := newReplicaLag(func(context.Context) (float64, error) {
+		lag := lags[min(calls, len(lags)-1)]
+		calls++
+		return lag, nil
+	}, maxLag)
+	return l, &calls
+}
+
+func TestReplicaLagRoute(t *testing.T) {
+	primary := &Source{Config: Config{Name: "my-primary"}}
+	tcs := []struct {
+		desc         string
+		max          time.Duration
+		failover     *Source
+		lag          float64
+		wantFailover bool
+	}{
+		{desc: "report only", lag: 12.5},
+		{desc: "under threshold", max: 10 * time.Second, failover: primary, lag: 2},
+		{desc: "at threshold", max: 10 * time.Second, failover: primary, lag: 10},
+		{desc: "over threshold", max: 10 * time.Second, failover: primary, lag: 12.5, wantFailover: true},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			l, _ := fakeLag(tc.max, tc.lag)
+			l.failover = tc.failover
+			rl := &util.ReplicaLag{}
+			ctx := util.WithReplicaLag(context.Background(), rl)
+
+			got := l.route(ctx, "my-replica")
+			if tc.wantFailover {
+				if got != primary {
+					t.Fatalf("expected failover to the primary, got %v", got)
+				}
+				if rl.Measured {
+					t.Errorf("expected no lag to be reported for a failed over invocation")
+				}
+				return
+			}
+			if got != nil {
+				t.Fatalf("unexpected failover to %q", got.Name)
+			}
+			if !rl.Measured || rl.Seconds != tc.lag {
+				t.Errorf("unexpected reported lag: %+v, want %v", rl, tc.lag)
+			}
+		})
+	}
+}
+
+func TestReplicaLagCached(t *testing.T) {
+	l, calls := fakeLag(0, 1, 5)
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	l.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	for _, want := range []float64{1, 1} {
+		got, err := l.lag(ctx)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if got != want {
+			t.Errorf("unexpected lag: got %v, want %v", got, want)
+		}
+		now = now.Add(500 * time.Millisecond)
+	}
+	if *calls != 1 {
+		t.Errorf("expected 1 measurement within a second, got %d", *calls)
+	}
+
+	now = now.Add(time.Second)
+	if got, _ := l.lag(ctx); got != 5 {
+		t.Errorf("expected a new measurement after a second, got %v", got)
+	}
+	if *calls != 2 {
+		t.Errorf("expected 2 measurements, got %d", *calls)
+	}
+}
+
+func TestReplicaLagMeasureError(t *testing.T) {
+	l := newReplicaLag(func(context.Context) (float64, error) {
+		return 0, errors.New("connection refused")
+	}, time.Second)
+	l.failover = &Source{Config: Config{Name: "my-primary"}}
+	rl := &util.ReplicaLag{}
+	ctx := util.WithReplicaLag(context.Background(), rl)
+	if got := l.route(ctx, "my-replica"); got != nil {
+		t.Errorf("expected the invocation to stay on the replica, got %q", got.Name)
+	}
+	if rl.Measured {
+		t.Errorf("expected no lag to be reported")
+	}
+}
+
+func TestValidateReplica(t *testing.T) {
+	tcs := []struct {
+		desc    string
+		cfg     Config
+		wantErr string
+	}{
+		{desc: "primary", cfg: Config{Name: "pg", Role: RolePrimary}},
+		{desc: "report lag", cfg: Config{Name: "pg", Role: RoleReplica, ReportReplicaLag: true}},
+		{desc: "failover", cfg: Config{Name: "pg", Role: RoleReplica, ReportReplicaLag: true, MaxReplicaLag: "30s", FailoverSource: "primary"}},
+		{desc: "report lag of primary", cfg: Config{Name: "pg", ReportReplicaLag: true}, wantErr: "reportReplicaLag requires role"},
+		{desc: "threshold without failover", cfg: Config{Name: "pg", Role: RoleReplica, ReportReplicaLag: true, MaxReplicaLag: "30s"}, wantErr: "must be set together"},
+		{desc: "failover without report", cfg: Config{Name: "pg", Role: RoleReplica, MaxReplicaLag: "30s", FailoverSource: "primary"}, wantErr: "requires reportReplicaLag"},
+		{desc: "failover to itself", cfg: Config{Name: "pg", Role: RoleReplica, ReportReplicaLag: true, MaxReplicaLag: "30s", FailoverSource: "pg"}, wantErr: "the source itself"},
+		{desc: "invalid threshold", cfg: Config{Name: "pg", Role: RoleReplica, ReportReplicaLag: true, MaxReplicaLag: "soon", FailoverSource: "primary"}, wantErr: "invalid maxReplicaLag"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := tc.cfg.validateReplica()
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+// otherSource is a source of another type.
+type otherSource struct{}
+
+func (otherSource) SourceType() string             { return "other" }
+func (otherSource) ToConfig() sources.SourceConfig { return nil }
+
+func TestLink(t *testing.T) {
+	primary := &Source{Config: Config{Name: "primary"}}
+	other := &Source{Config: Config{Name: "other-replica", Role: RoleReplica}}
+	sourcesMap := map[string]sources.Source{
+		"primary":       primary,
+		"other-replica": other,
+		"other-type":    otherSource{},
+	}
+	tcs := []struct {
+		desc     string
+		failover string
+		wantErr  string
+	}{
+		{desc: "primary", failover: "primary"},
+		{desc: "missing", failover: "missing", wantErr: "does not exist"},
+		{desc: "other type", failover: "other-type", wantErr: `must be a "postgres" source`},
+		{desc: "replica", failover: "other-replica", wantErr: "must not be a replica"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			s := &Source{
+				Config:  Config{Name: "replica", Role: RoleReplica, ReportReplicaLag: true, MaxReplicaLag: "1s", FailoverSource: tc.failover},
+				replica: newReplicaLag(nil, time.Second),
+			}
+			err := s.Link(sourcesMap)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if s.replica.failover != primary {
+					t.Errorf("expected the failover source to be linked")
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package queryguard
+
+import (
+	"strings"
+
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	_ "github.com/pingcap/tidb/pkg/parser/test_driver"
+)
+
+// mysqlWriteKeyword parses MySQL statements and returns the first keyword of
+// them that may write, or "" if they only read.
+func mysqlWriteKeyword(statement string) (string, error) {
+	stmts, _, err := parser.New().Parse(statement, "", "")
+	if err != nil {
+		return "", err
+	}
+	for _, stmt := range stmts {
+		switch stmt.(type) {
+		case *ast.SelectStmt, *ast.SetOprStmt, *ast.ShowStmt, *ast.ExplainStmt:
+		default:
+			text := stmt.Text()
+			if text == "" {
+				text = statement
+			}
+			return leadingKeyword(text, MySQL), nil
+		}
+		finder := &mysqlWriteFinder{}
+		stmt.Accept(finder)
+		if finder.keyword != "" {
+			return finder.keyword, nil
+		}
+	}
+	return "", nil
+}
+
+// mysqlWriteFinder finds the first node of a statement that may write, such
+// as a statement of EXPLAIN ANALYZE, SELECT ... INTO, a locking read or a
+// function taking a lock.
+type mysqlWriteFinder struct {
+	keyword string
+}
+
+func (f *mysqlWriteFinder) Enter(n ast.Node) (ast.Node, bool) {
+	switch n := n.(type) {
+	case *ast.InsertStmt:
+		f.keyword = "INSERT"
+		if n.IsReplace {
+			f.keyword = "REPLACE"
+		}
+	case *ast.UpdateStmt:
+		f.keyword = "UPDATE"
+	case *ast.DeleteStmt:
+		f.keyword = "DELETE"
+	case *ast.SelectStmt:
+		if n.SelectIntoOpt != nil {
+			f.keyword = "INTO"
+		} else if n.LockInfo != nil && n.LockInfo.LockType != ast.SelectLockNone {
+			f.keyword = strings.ToUpper(n.LockInfo.LockType.String())
+		}
+	case *ast.FuncCallExpr:
+		if name := strings.ToUpper(n.FnName.L); writeFunctions[name] {
+			f.keyword = name
+		}
+	}
+	return n, f.keyword != ""
+}
+
+func (f *mysqlWriteFinder) Leave(n ast.Node) (ast.Node, bool) {
+	return n, f.keyword == ""
+}
@@ -0,0 +1,101 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package queryguard
+
+import (
+	"strings"
+
+	"github.com/pganalyze/pg_query_go/v6"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// postgresLocks are the keywords of the row locks of SELECT statements,
+// which read-only transactions reject.
+var postgresLocks = map[pg_query.LockClauseStrength]string{
+	pg_query.LockClauseStrength_LCS_FORKEYSHARE:    "FOR KEY SHARE",
+	pg_query.LockClauseStrength_LCS_FORSHARE:       "FOR SHARE",
+	pg_query.LockClauseStrength_LCS_FORNOKEYUPDATE: "FOR NO KEY UPDATE",
+	pg_query.LockClauseStrength_LCS_FORUPDATE:      "FOR UPDATE",
+}
+
+// postgresWriteKeyword parses PostgreSQL statements and returns the first
+// keyword of them that may write, or "" if they only read.
+func postgresWriteKeyword(statement string) (string, error) {
+	tree, err := pg_query.Parse(statement)
+	if err != nil {
+		return "", err
+	}
+	for _, raw := range tree.Stmts {
+		switch raw.GetStmt().GetNode().(type) {
+		case *pg_query.Node_SelectStmt, *pg_query.Node_ExplainStmt, *pg_query.Node_VariableShowStmt:
+		default:
+			return leadingKeyword(postgresStatementText(statement, raw), Postgres), nil
+		}
+		if keyword := postgresNodeWriteKeyword(raw.GetStmt().ProtoReflect()); keyword != "" {
+			return keyword, nil
+		}
+	}
+	return "", nil
+}
+
+// postgresStatementText returns the text of the parsed statement raw.
+func postgresStatementText(statement string, raw *pg_query.RawStmt) string {
+	start := min(int(raw.GetStmtLocation()), len(statement))
+	if raw.GetStmtLen() == 0 {
+		return statement[start:]
+	}
+	return statement[start:min(start+int(raw.GetStmtLen()), len(statement))]
+}
+
+// postgresNodeWriteKeyword returns the keyword of the first node of the tree
+// of m that may write, such as the data-modifying statements of a WITH
+// clause, SELECT ... INTO or a function changing the session, or "".
+func postgresNodeWriteKeyword(m protoreflect.Message) string {
+	switch n := m.Interface().(type) {
+	case *pg_query.InsertStmt:
+		return "INSERT"
+	case *pg_query.UpdateStmt:
+		return "UPDATE"
+	case *pg_query.DeleteStmt:
+		return "DELETE"
+	case *pg_query.MergeStmt:
+		return "MERGE"
+	case *pg_query.IntoClause:
+		return "INTO"
+	case *pg_query.LockingClause:
+		return postgresLocks[n.GetStrength()]
+	case *pg_query.FuncCall:
+		if names := n.GetFuncname(); len(names) > 0 {
+			name := strings.ToUpper(names[len(names)-1].GetString_().GetSval())
+			if writeFunctions[name] {
+				return name
+			}
+		}
+	}
+	var keyword string
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.Message() == nil || fd.IsMap():
+		case fd.IsList():
+			for i, l := 0, v.List(); i < l.Len() && keyword == ""; i++ {
+				keyword = postgresNodeWriteKeyword(l.Get(i).Message())
+			}
+		default:
+			keyword = postgresNodeWriteKeyword(v.Message())
+		}
+		return keyword == ""
+	})
+	return keyword
+}
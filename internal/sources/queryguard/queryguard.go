@@ -0,0 +1,156 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package queryguard restricts the statements that tools may run on a SQL
+// source with regular expressions. It is a blunt guardrail: patterns see the
+// statement as text, not parsed SQL. Read-only sources also reject the
+// statements that may write, found by parsing statements, or for dialects
+// without a parser, by splitting them into words.
+package queryguard
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Pattern is a regular expression matched against statements. The label
+// names the pattern in policy errors, so that they do not disclose it.
+type Pattern struct {
+	Label   string `yaml:"label" validate:"required"`
+	Pattern string `yaml:"pattern" validate:"required"`
+}
+
+// Patterns are the denyPatterns and allowPatterns options of a SQL source.
+// Sources embed them inline in their config.
+type Patterns struct {
+	// DenyPatterns reject the statements matching any of them.
+	DenyPatterns []Pattern `yaml:"denyPatterns" validate:"dive"`
+	// AllowPatterns, if any, reject the statements matching none of them.
+	AllowPatterns []Pattern `yaml:"allowPatterns" validate:"dive"`
+}
+
+type compiledPattern struct {
+	label string
+	re    *regexp.Regexp
+}
+
+// Guard checks statements against the compiled patterns of a source. The
+// zero value allows every statement. Sources embed it.
+type Guard struct {
+	source string
+	deny   []compiledPattern
+	allow  []compiledPattern
+	// readOnly rejects the statements of dialect that may write.
+	readOnly bool
+	dialect  Dialect
+}
+
+// Compile compiles the patterns of the source sourceName.
+func (p Patterns) Compile(sourceName string) (Guard, error) {
+	g := Guard{source: sourceName}
+	var err error
+	if g.deny, err = compile(sourceName, "denyPatterns", p.DenyPatterns); err != nil {
+		return Guard{}, err
+	}
+	if g.allow, err = compile(sourceName, "allowPatterns", p.AllowPatterns); err != nil {
+		return Guard{}, err
+	}
+	return g, nil
+}
+
+func compile(sourceName, field string, patterns []Pattern) ([]compiledPattern, error) {
+	compiled := make([]compiledPattern, 0, len(patterns))
+	seen := make(map[string]bool)
+	for _, p := range patterns {
+		if seen[p.Label] {
+			return nil, fmt.Errorf("%s of source %q has more than one pattern labeled %q", field, sourceName, p.Label)
+		}
+		seen[p.Label] = true
+		re, err := regexp.Compile(p.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("pattern %q in %s of source %q is invalid: %w", p.Label, field, sourceName, err)
+		}
+		compiled = append(compiled, compiledPattern{label: p.Label, re: re})
+	}
+	return compiled, nil
+}
+
+// PolicyError rejects a statement that violates the patterns of a source.
+type PolicyError struct {
+	Source string
+	// Label is the deny pattern matching the statement. It is empty when the
+	// statement matches none of the allow patterns.
+	Label string
+	// Allowed are the labels of the allow patterns, when the statement
+	// matches none of them.
+	Allowed []string
+}
+
+func (e *PolicyError) Error() string {
+	if e.Label != "" {
+		return fmt.Sprintf("statement matches deny pattern %q of source %q", e.Label, e.Source)
+	}
+	return fmt.Sprintf("statement matches none of the allow patterns of source %q (%s)", e.Source, strings.Join(e.Allowed, ", "))
+}
+
+// CheckReadOnly returns a *WriteError if statement may write, or a
+// *ParseError if it cannot tell, whether or not the source is read-only.
+// Read-only tools pass their name as tool; the source passes "".
+func (g Guard) CheckReadOnly(tool, statement string) error {
+	keyword, err := writeKeyword(statement, g.dialect)
+	if err != nil {
+		return &ParseError{Source: g.source, Tool: tool, Err: err}
+	}
+	if keyword != "" {
+		return &WriteError{Source: g.source, Tool: tool, Keyword: keyword}
+	}
+	return nil
+}
+
+// WithReadOnly returns the guard rejecting, if readOnly is set, the
+// statements of dialect that may write.
+func (g Guard) WithReadOnly(readOnly bool, dialect Dialect) Guard {
+	g.readOnly = readOnly
+	g.dialect = dialect
+	return g
+}
+
+// CheckStatement returns a *WriteError if the source is read-only and
+// statement may write, or a *ParseError if it cannot tell, and a
+// *PolicyError if statement matches a deny pattern, or matches none of the
+// allow patterns.
+func (g Guard) CheckStatement(statement string) error {
+	if g.readOnly {
+		if err := g.CheckReadOnly("", statement); err != nil {
+			return err
+		}
+	}
+	for _, p := range g.deny {
+		if p.re.MatchString(statement) {
+			return &PolicyError{Source: g.source, Label: p.label}
+		}
+	}
+	if len(g.allow) == 0 {
+		return nil
+	}
+	labels := make([]string, 0, len(g.allow))
+	for _, p := range g.allow {
+		if p.re.MatchString(statement) {
+			return nil
+		}
+		labels = append(labels, p.label)
+	}
+	return &PolicyError{Source: g.source, Allowed: labels}
+}
@@ -0,0 +1,114 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package queryguard
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCheckStatement(t *testing.T) {
+	guard, err := Patterns{
+		DenyPatterns: []Pattern{
+			{Label: "drop-table", Pattern: `(?i)drop\s+table`},
+			{Label: "terminate-backend", Pattern: `pg_terminate_backend`},
+		},
+		AllowPatterns: []Pattern{
+			{Label: "select", Pattern: `(?i)^\s*select\b`},
+			{Label: "explain", Pattern: `(?i)^\s*explain\b`},
+		},
+	}.Compile("my-source")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	tcs := []struct {
+		desc      string
+		statement string
+		wantLabel string
+		wantAllow bool
+	}{
+		{desc: "allowed", statement: "SELECT * FROM flights"},
+		{desc: "allowed multiline", statement: "\n  explain\n  SELECT *\n  FROM flights"},
+		{desc: "denied", statement: "SELECT pg_terminate_backend(42)", wantLabel: "terminate-backend"},
+		{desc: "denied multiline", statement: "SELECT 1;\nDROP\n\tTABLE flights;", wantLabel: "drop-table"},
+		{desc: "allow miss", statement: "DELETE FROM flights", wantAllow: true},
+		{desc: "allow must match the start", statement: "DELETE FROM flights WHERE id IN (SELECT id FROM old)", wantAllow: true},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := guard.CheckStatement(tc.statement)
+			if tc.wantLabel == "" && !tc.wantAllow {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			var policyErr *PolicyError
+			if !errors.As(err, &policyErr) {
+				t.Fatalf("expected a policy error, got %v", err)
+			}
+			if policyErr.Source != "my-source" || policyErr.Label != tc.wantLabel {
+				t.Errorf("unexpected policy error: %+v", policyErr)
+			}
+			if tc.wantAllow && strings.Join(policyErr.Allowed, ",") != "select,explain" {
+				t.Errorf("unexpected allowed labels: %v", policyErr.Allowed)
+			}
+			if strings.Contains(err.Error(), `\s`) {
+				t.Errorf("expected the error to name the label rather than the pattern, got %q", err)
+			}
+		})
+	}
+}
+
+func TestZeroGuard(t *testing.T) {
+	var guard Guard
+	if err := guard.CheckStatement("DROP TABLE flights"); err != nil {
+		t.Errorf("expected the zero guard to allow every statement, got %s", err)
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	tcs := []struct {
+		desc     string
+		patterns Patterns
+		err      string
+	}{
+		{
+			desc:     "invalid deny pattern",
+			patterns: Patterns{DenyPatterns: []Pattern{{Label: "broken", Pattern: `drop(`}}},
+			err:      `pattern "broken" in denyPatterns of source "my-source" is invalid: error parsing regexp: missing closing ): ` + "`drop(`",
+		},
+		{
+			desc:     "invalid allow pattern",
+			patterns: Patterns{AllowPatterns: []Pattern{{Label: "broken", Pattern: `[a-`}}},
+			err:      `pattern "broken" in allowPatterns of source "my-source" is invalid: error parsing regexp: missing closing ]: ` + "`[a-`",
+		},
+		{
+			desc:     "duplicate label",
+			patterns: Patterns{DenyPatterns: []Pattern{{Label: "drop", Pattern: `drop`}, {Label: "drop", Pattern: `truncate`}}},
+			err:      `denyPatterns of source "my-source" has more than one pattern labeled "drop"`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := tc.patterns.Compile("my-source")
+			if err == nil || err.Error() != tc.err {
+				t.Fatalf("unexpected error: got %v, want %q", err, tc.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,325 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package queryguard
+
+import (
+	"fmt"
+	"strings"
+)
+
+// readStatements are the leading keywords of the statements allowed on a
+// read-only source.
+var readStatements = map[string]bool{
+	"SELECT":   true,
+	"WITH":     true,
+	"VALUES":   true,
+	"TABLE":    true,
+	"SHOW":     true,
+	"EXPLAIN":  true,
+	"DESCRIBE": true,
+	"DESC":     true,
+}
+
+// writeWords are the keywords and functions that write when they appear
+// anywhere in a statement, such as the data-modifying statements of a WITH
+// clause, SELECT ... INTO, SELECT ... FOR UPDATE, or a second statement of a
+// batch without a semicolon.
+var writeWords = map[string]bool{
+	"INSERT":   true,
+	"UPDATE":   true,
+	"DELETE":   true,
+	"MERGE":    true,
+	"UPSERT":   true,
+	"INTO":     true,
+	"CREATE":   true,
+	"ALTER":    true,
+	"DROP":     true,
+	"TRUNCATE": true,
+	"GRANT":    true,
+	"REVOKE":   true,
+	"RENAME":   true,
+	"COPY":     true,
+	"CALL":     true,
+	"EXEC":     true,
+	"EXECUTE":  true,
+	"SET":      true,
+}
+
+// writeFunctions are the functions writing data, changing the session or
+// taking locks.
+var writeFunctions = map[string]bool{
+	"NEXTVAL":              true,
+	"SETVAL":               true,
+	"SET_CONFIG":           true,
+	"PG_TERMINATE_BACKEND": true,
+	"PG_CANCEL_BACKEND":    true,
+	"PG_RELOAD_CONF":       true,
+	"LO_IMPORT":            true,
+	"LO_EXPORT":            true,
+	"LO_UNLINK":            true,
+	"DBLINK_EXEC":          true,
+	"GET_LOCK":             true,
+}
+
+// ParseError rejects a statement of a read-only source or tool that cannot be
+// parsed, so that it cannot be told to only read.
+type ParseError struct {
+	Source string
+	// Tool is set if the tool, rather than the source, is read-only.
+	Tool string
+	Err  error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("statement cannot be parsed to check that it only reads, as %s requires: %v", readOnlyOwner(e.Source, e.Tool), e.Err)
+}
+
+func (e *ParseError) Unwrap() error { return e.Err }
+
+// WriteError rejects a statement that may write on a read-only source or
+// tool.
+type WriteError struct {
+	Source string
+	// Tool is set if the tool, rather than the source, is read-only.
+	Tool string
+	// Keyword is the keyword of the statement that may write.
+	Keyword string
+}
+
+func (e *WriteError) Error() string {
+	return fmt.Sprintf("statement uses %s, which is not allowed on %s", e.Keyword, readOnlyOwner(e.Source, e.Tool))
+}
+
+// readOnlyOwner names the read-only tool if set, or else the source.
+func readOnlyOwner(source, tool string) string {
+	if tool != "" {
+		return fmt.Sprintf("read-only tool %q", tool)
+	}
+	return fmt.Sprintf("read-only source %q", source)
+}
+
+// Dialect selects the parser of statements, or for dialects without one, the
+// rules to quote literals and identifiers and to comment that statements are
+// split into words with.
+type Dialect int
+
+const (
+	Postgres Dialect = iota
+	MySQL
+	SQLServer
+	SQLite
+	// DuckDB follows the rules of Postgres, without its parser.
+	DuckDB
+)
+
+// lexRules are the rules of a dialect.
+type lexRules struct {
+	// backslashes escape quotes in literals.
+	backslashes bool
+	// escapeStrings are the E'...' literals whose backslashes escape quotes.
+	escapeStrings bool
+	// dollarQuotes delimit literals, such as $tag$...$tag$.
+	dollarQuotes bool
+	// nestedComments nest block comments.
+	nestedComments bool
+	// hashComments start line comments with #.
+	hashComments bool
+	// executableComments run the content of /*! ... */ comments.
+	executableComments bool
+	// backticks and brackets delimit identifiers.
+	backticks bool
+	brackets  bool
+}
+
+var dialectRules = map[Dialect]lexRules{
+	Postgres:  {escapeStrings: true, dollarQuotes: true, nestedComments: true},
+	MySQL:     {backslashes: true, hashComments: true, executableComments: true, backticks: true},
+	SQLServer: {nestedComments: true, brackets: true},
+	SQLite:    {backticks: true, brackets: true},
+	DuckDB:    {escapeStrings: true, dollarQuotes: true, nestedComments: true},
+}
+
+// writeKeyword returns the first keyword of statement that may write, or ""
+// if it only reads. Statements of dialects with a parser are parsed, and
+// fail to be checked if they cannot be; the others are split into words.
+func writeKeyword(statement string, dialect Dialect) (string, error) {
+	switch dialect {
+	case Postgres:
+		return postgresWriteKeyword(statement)
+	case MySQL:
+		return mysqlWriteKeyword(statement)
+	}
+	for _, words := range splitWords(statement, dialectRules[dialect]) {
+		if len(words) == 0 {
+			continue
+		}
+		if !readStatements[words[0]] {
+			return words[0], nil
+		}
+		for _, w := range words[1:] {
+			if writeWords[w] || writeFunctions[w] {
+				return w, nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// leadingKeyword returns the first word of statement, which names the kind
+// of statement it is.
+func leadingKeyword(statement string, dialect Dialect) string {
+	for _, words := range splitWords(statement, dialectRules[dialect]) {
+		if len(words) > 0 {
+			return words[0]
+		}
+	}
+	return "statement"
+}
+
+// splitWords splits statement into the statements separated by semicolons,
+// each as its unquoted words in upper case. Literals, quoted identifiers and
+// comments are skipped, following rules. Unterminated ones extend to the end
+// of the statement.
+func splitWords(statement string, rules lexRules) [][]string {
+	var statements [][]string
+	var words []string
+	s := statement
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == ';':
+			statements = append(statements, words)
+			words = nil
+			i++
+		case isWordStart(c):
+			j := i + 1
+			for j < len(s) && isWordPart(s[j]) {
+				j++
+			}
+			if rules.escapeStrings && j == i+1 && (c == 'E' || c == 'e') && j < len(s) && s[j] == '\'' {
+				i = skipQuoted(s, j, '\'', true)
+				continue
+			}
+			words = append(words, strings.ToUpper(s[i:j]))
+			i = j
+		case c == '-' && strings.HasPrefix(s[i:], "--"), rules.hashComments && c == '#':
+			i = skipLine(s, i)
+		case rules.executableComments && strings.HasPrefix(s[i:], "/*!"):
+			// skip the marker and version of the comment, then read its
+			// content as part of the statement
+			i += 3
+			for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+				i++
+			}
+		case c == '/' && strings.HasPrefix(s[i:], "/*"):
+			i = skipBlockComment(s, i, rules.nestedComments)
+		case c == '\'' || c == '"':
+			i = skipQuoted(s, i, c, rules.backslashes)
+		case rules.backticks && c == '`':
+			i = skipQuoted(s, i, '`', false)
+		case rules.brackets && c == '[':
+			i = skipQuoted(s, i, ']', false)
+		case rules.dollarQuotes && c == '$':
+			i = skipDollarQuoted(s, i)
+		case c >= '0' && c <= '9':
+			// numbers, so that 1e5 or 0x1F aren't read as words
+			for i < len(s) && isWordPart(s[i]) {
+				i++
+			}
+		default:
+			i++
+		}
+	}
+	return append(statements, words)
+}
+
+func isWordStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c >= 0x80
+}
+
+func isWordPart(c byte) bool {
+	return isWordStart(c) || (c >= '0' && c <= '9') || c == '$'
+}
+
+// skipLine returns the index of the end of the line starting at i.
+func skipLine(s string, i int) int {
+	if j := strings.IndexByte(s[i:], '\n'); j >= 0 {
+		return i + j + 1
+	}
+	return len(s)
+}
+
+// skipBlockComment returns the index past the block comment starting at i.
+func skipBlockComment(s string, i int, nested bool) int {
+	depth := 0
+	for i < len(s) {
+		switch {
+		case strings.HasPrefix(s[i:], "/*"):
+			if depth == 0 || nested {
+				depth++
+			}
+			i += 2
+		case strings.HasPrefix(s[i:], "*/"):
+			depth--
+			i += 2
+			if depth == 0 {
+				return i
+			}
+		default:
+			i++
+		}
+	}
+	return len(s)
+}
+
+// skipQuoted returns the index past the literal or identifier starting at i
+// and ending with q. Doubled q, and with backslashes set, escaped characters
+// are part of it.
+func skipQuoted(s string, i int, q byte, backslashes bool) int {
+	for i++; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			if backslashes {
+				i++
+			}
+		case q:
+			if i+1 < len(s) && s[i+1] == q {
+				i++
+				continue
+			}
+			return i + 1
+		}
+	}
+	return len(s)
+}
+
+// skipDollarQuoted returns the index past the dollar-quoted string starting
+// at i, or past the $ if it doesn't start one, such as in $1.
+func skipDollarQuoted(s string, i int) int {
+	j := i + 1
+	if j < len(s) && isWordStart(s[j]) {
+		for j < len(s) && isWordPart(s[j]) && s[j] != '$' {
+			j++
+		}
+	}
+	if j >= len(s) || s[j] != '$' {
+		return i + 1
+	}
+	tag := s[i : j+1]
+	if end := strings.Index(s[j+1:], tag); end >= 0 {
+		return j + 1 + end + len(tag)
+	}
+	return len(s)
+}
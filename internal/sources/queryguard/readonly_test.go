@@ -0,0 +1,140 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package queryguard
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestReadOnly(t *testing.T) {
+	tcs := []struct {
+		desc        string
+		dialect     Dialect
+		statement   string
+		wantKeyword string
+		wantParse   bool
+	}{
+		{desc: "select", statement: "SELECT * FROM flights WHERE id = $1"},
+		{desc: "cte", statement: "WITH f AS (SELECT * FROM flights) SELECT count(*) FROM f"},
+		{desc: "parenthesized", statement: "(SELECT 1) UNION (SELECT 2)"},
+		{desc: "explain", statement: "EXPLAIN ANALYZE SELECT * FROM flights"},
+		{desc: "show", dialect: MySQL, statement: "SHOW TABLES"},
+		{desc: "trailing semicolon", statement: "SELECT 1;\n"},
+		{desc: "keywords in literals", statement: "SELECT 'DELETE FROM t', \"update\" FROM flights"},
+		{desc: "keywords in nested comments", statement: "-- DROP TABLE flights\nSELECT 1 /* DELETE /* nested */ INSERT */"},
+		{desc: "keywords in dollar quotes", statement: "SELECT $tag$; DELETE FROM flights$tag$"},
+		{desc: "keywords in escape strings", statement: `SELECT E'it\'s; DELETE FROM flights'`},
+		{desc: "keywords in identifiers", statement: "SELECT updated_at, deleted, insert_count FROM flights"},
+		{desc: "keywords in backticks", dialect: MySQL, statement: "SELECT `drop` FROM flights # DELETE"},
+		{desc: "keywords in brackets", dialect: SQLServer, statement: "SELECT [update], [a]]; DELETE] FROM flights"},
+		{desc: "keywords as column names", statement: "SELECT execute, rename FROM jobs"},
+		{desc: "mysql placeholders", dialect: MySQL, statement: "SELECT * FROM flights WHERE id = ?"},
+		{desc: "mysql describe", dialect: MySQL, statement: "DESCRIBE flights"},
+		{desc: "insert", statement: "INSERT INTO flights VALUES (1)", wantKeyword: "INSERT"},
+		{desc: "lower case ddl", statement: "drop table flights", wantKeyword: "DROP"},
+		{desc: "second statement", statement: "SELECT 1; DELETE FROM flights", wantKeyword: "DELETE"},
+		{desc: "batch without semicolon", dialect: SQLServer, statement: "SELECT 1 DROP TABLE flights", wantKeyword: "DROP"},
+		{desc: "data-modifying cte", statement: "WITH d AS (DELETE FROM flights RETURNING *) SELECT * FROM d", wantKeyword: "DELETE"},
+		{desc: "select into", statement: "SELECT * INTO backup FROM flights", wantKeyword: "INTO"},
+		{desc: "select for update", statement: "SELECT * FROM flights FOR UPDATE", wantKeyword: "FOR UPDATE"},
+		{desc: "select for share", statement: "SELECT * FROM flights FOR SHARE", wantKeyword: "FOR SHARE"},
+		{desc: "explain analyze write", statement: "EXPLAIN ANALYZE DELETE FROM flights", wantKeyword: "DELETE"},
+		{desc: "sequence", statement: "SELECT nextval('flight_ids')", wantKeyword: "NEXTVAL"},
+		{desc: "unparsable", statement: "SELEC * FROM flights", wantParse: true},
+		{desc: "session change", statement: "SET default_transaction_read_only = off", wantKeyword: "SET"},
+		{desc: "side effect function", statement: "SELECT set_config('default_transaction_read_only', 'off', false)", wantKeyword: "SET_CONFIG"},
+		{desc: "transaction", statement: "BEGIN READ WRITE", wantKeyword: "BEGIN"},
+		{desc: "standard string", statement: `SELECT 'a\'; DELETE FROM flights; -- '`, wantKeyword: "DELETE"},
+		{desc: "doubled quote", statement: "SELECT 'it''s'; DELETE FROM flights", wantKeyword: "DELETE"},
+		{desc: "mysql escaped quote", dialect: MySQL, statement: `SELECT 'a\'', 1; DELETE FROM flights; -- '`, wantKeyword: "DELETE"},
+		{desc: "mysql executable comment", dialect: MySQL, statement: "SELECT 1 /*!50000 ; DELETE FROM flights */", wantKeyword: "DELETE"},
+		{desc: "mysql comments don't nest", dialect: MySQL, statement: "SELECT 1 /* /* */ DELETE FROM flights */", wantParse: true},
+		{desc: "mysql second statement", dialect: MySQL, statement: "SELECT 1; delete from flights", wantKeyword: "DELETE"},
+		{desc: "mysql replace", dialect: MySQL, statement: "REPLACE INTO flights VALUES (1)", wantKeyword: "REPLACE"},
+		{desc: "mysql into outfile", dialect: MySQL, statement: "SELECT * FROM flights INTO OUTFILE '/tmp/flights'", wantKeyword: "INTO"},
+		{desc: "mysql locking read", dialect: MySQL, statement: "SELECT * FROM flights LOCK IN SHARE MODE", wantKeyword: "FOR SHARE"},
+		{desc: "mysql lock function", dialect: MySQL, statement: "SELECT GET_LOCK('flights', 10)", wantKeyword: "GET_LOCK"},
+		{desc: "duckdb second statement", dialect: DuckDB, statement: "SELECT 1; DELETE FROM flights", wantKeyword: "DELETE"},
+		{desc: "sqlite unterminated bracket", dialect: SQLite, statement: "DELETE FROM [flights", wantKeyword: "DELETE"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			guard, err := Patterns{}.Compile("my-source")
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			err = guard.WithReadOnly(true, tc.dialect).CheckStatement(tc.statement)
+			if tc.wantParse {
+				var parseErr *ParseError
+				if !errors.As(err, &parseErr) {
+					t.Fatalf("expected a parse error, got %v", err)
+				}
+				return
+			}
+			if tc.wantKeyword == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			var writeErr *WriteError
+			if !errors.As(err, &writeErr) {
+				t.Fatalf("expected a write error, got %v", err)
+			}
+			if writeErr.Source != "my-source" || writeErr.Keyword != tc.wantKeyword {
+				t.Errorf("unexpected write error: %+v", writeErr)
+			}
+		})
+	}
+}
+
+func TestNotReadOnly(t *testing.T) {
+	guard, err := Patterns{}.Compile("my-source")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := guard.WithReadOnly(false, Postgres).CheckStatement("DELETE FROM flights"); err != nil {
+		t.Errorf("expected a guard that isn't read-only to allow writes, got %s", err)
+	}
+}
+
+func TestCheckReadOnly(t *testing.T) {
+	guard, err := Patterns{}.Compile("my-source")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	guard = guard.WithReadOnly(false, Postgres)
+	if err := guard.CheckReadOnly("my-tool", "SELECT * FROM flights"); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	err = guard.CheckReadOnly("my-tool", "DELETE FROM flights")
+	var writeErr *WriteError
+	if !errors.As(err, &writeErr) {
+		t.Fatalf("expected a write error, got %v", err)
+	}
+	if writeErr.Tool != "my-tool" || writeErr.Keyword != "DELETE" {
+		t.Errorf("unexpected write error: %+v", writeErr)
+	}
+	if want := `statement uses DELETE, which is not allowed on read-only tool "my-tool"`; err.Error() != want {
+		t.Errorf("unexpected message: got %q, want %q", err.Error(), want)
+	}
+
+	var parseErr *ParseError
+	if err := guard.CheckReadOnly("my-tool", "SELEC 1"); !errors.As(err, &parseErr) || parseErr.Tool != "my-tool" {
+		t.Errorf("expected a parse error of tool my-tool, got %v", err)
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package readreplica routes the queries of read-only invocations across the
+// read replicas of a database/sql source, failing over to the primary while a
+// replica is unreachable and failing back once it recovers.
+package readreplica
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/googleapis/mcp-toolbox/internal/util"
+)
+
+// Cooldown is how long an unreachable replica is skipped before it is tried
+// again.
+const Cooldown = 30 * time.Second
+
+// Replica is a read replica of a source.
+type Replica struct {
+	// Name identifies the replica in logs.
+	Name string
+	DB   *sql.DB
+
+	// downUntil is when an unreachable replica is tried again.
+	downUntil time.Time
+}
+
+// Router picks the connection pool a query runs on.
+type Router struct {
+	primary  *sql.DB
+	replicas []*Replica
+	cooldown time.Duration
+	now      func() time.Time
+
+	mu   sync.Mutex
+	next int
+}
+
+// New returns a Router over the primary and its replicas.
+func New(primary *sql.DB, replicas []*Replica) *Router {
+	return &Router{primary: primary, replicas: replicas, cooldown: Cooldown, now: time.Now}
+}
+
+// pick returns the next replica that is not down, in turn, or nil if every
+// replica is down.
+func (r *Router) pick() *Replica {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.now()
+	for range r.replicas {
+		rep := r.replicas[r.next]
+		r.next = (r.next + 1) % len(r.replicas)
+		if !now.Before(rep.downUntil) {
+			return rep
+		}
+	}
+	return nil
+}
+
+// markDown skips the replica for the cooldown.
+func (r *Router) markDown(rep *Replica) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rep.downUntil = r.now().Add(r.cooldown)
+}
+
+// QueryContext runs the query on a replica if ctx is the invocation of a
+// read-only tool, or on the primary otherwise. A query failing on a replica
+// that no longer answers a ping is retried on the primary, and the replica is
+// skipped until the cooldown passes.
+func (r *Router) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	if len(r.replicas) == 0 || !util.ReadOnlyInvocationFromContext(ctx) {
+		return r.primary.QueryContext(ctx, query, args...)
+	}
+	rep := r.pick()
+	if rep == nil {
+		return r.primary.QueryContext(ctx, query, args...)
+	}
+	rows, err := rep.DB.QueryContext(ctx, query, args...)
+	if err == nil || ctx.Err() != nil {
+		return rows, err
+	}
+	if pingErr := rep.DB.PingContext(ctx); pingErr == nil {
+		// The replica is up, so the query itself failed.
+		return nil, err
+	}
+	r.markDown(rep)
+	if logger, lErr := util.LoggerFromContext(ctx); lErr == nil {
+		logger.WarnContext(ctx, fmt.Sprintf("read replica %q is unreachable, failing over to the primary for %s: %s", rep.Name, r.cooldown, err))
+	}
+	return r.primary.QueryContext(ctx, query, args...)
+}
+
+// Close closes the connection pools of the replicas.
+func (r *Router) Close() error {
+	var errs []error
+	for _, rep := range r.replicas {
+		if err := rep.DB.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("unable to close read replica %q: %w", rep.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
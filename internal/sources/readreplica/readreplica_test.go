@@ -0,0 +1,161 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package readreplica
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/googleapis/mcp-toolbox/internal/util"
+)
+
+// fakeDriver opens connections to fake databases named by their DSN, which
+// count the queries they run and fail while down.
+type fakeDriver struct {
+	mu      sync.Mutex
+	down    map[string]bool
+	queries map[string]int
+}
+
+var fake = &fakeDriver{down: map[string]bool{}, queries: map[string]int{}}
+
+func init() {
+	sql.Register("readreplicatest", fake)
+}
+
+func (d *fakeDriver) Open(name string) (driver.Conn, error) {
+	return fakeConn{name: name}, nil
+}
+
+func (d *fakeDriver) set(name string, down bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.down[name] = down
+}
+
+func (d *fakeDriver) count(name string) int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.queries[name]
+}
+
+type fakeConn struct{ name string }
+
+func (c fakeConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (c fakeConn) Close() error                        { return nil }
+func (c fakeConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+
+func (c fakeConn) Ping(context.Context) error {
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	if fake.down[c.name] {
+		return errors.New("connection refused")
+	}
+	return nil
+}
+
+func (c fakeConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if err := c.Ping(ctx); err != nil {
+		return nil, err
+	}
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	fake.queries[c.name]++
+	return fakeRows{}, nil
+}
+
+type fakeRows struct{}
+
+func (fakeRows) Columns() []string         { return nil }
+func (fakeRows) Close() error              { return nil }
+func (fakeRows) Next([]driver.Value) error { return io.EOF }
+
+func open(t *testing.T, name string) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("readreplicatest", name)
+	if err != nil {
+		t.Fatalf("unable to open %q: %s", name, err)
+	}
+	db.SetMaxIdleConns(0)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestRouter(t *testing.T) {
+	primary := open(t, t.Name()+"/primary")
+	replicas := []*Replica{
+		{Name: "r1", DB: open(t, t.Name()+"/r1")},
+		{Name: "r2", DB: open(t, t.Name()+"/r2")},
+	}
+	r := New(primary, replicas)
+	now := time.Now()
+	r.now = func() time.Time { return now }
+	readOnly := util.WithReadOnlyInvocation(context.Background())
+	query := func(ctx context.Context) {
+		t.Helper()
+		rows, err := r.QueryContext(ctx, "SELECT 1")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		rows.Close()
+	}
+	counts := func() [3]int {
+		return [3]int{fake.count(t.Name() + "/primary"), fake.count(t.Name() + "/r1"), fake.count(t.Name() + "/r2")}
+	}
+
+	query(context.Background())
+	if got, want := counts(), [3]int{1, 0, 0}; got != want {
+		t.Fatalf("writes must run on the primary: got %v, want %v", got, want)
+	}
+
+	query(readOnly)
+	query(readOnly)
+	if got, want := counts(), [3]int{1, 1, 1}; got != want {
+		t.Fatalf("reads must alternate between replicas: got %v, want %v", got, want)
+	}
+
+	// r1 fails, so its read fails over to the primary and r1 is skipped.
+	fake.set(t.Name()+"/r1", true)
+	query(readOnly)
+	query(readOnly)
+	query(readOnly)
+	if got, want := counts(), [3]int{2, 1, 3}; got != want {
+		t.Fatalf("reads must skip the unreachable replica: got %v, want %v", got, want)
+	}
+
+	// Every replica fails, so reads run on the primary.
+	fake.set(t.Name()+"/r2", true)
+	query(readOnly)
+	query(readOnly)
+	if got, want := counts(), [3]int{4, 1, 3}; got != want {
+		t.Fatalf("reads must fail over to the primary: got %v, want %v", got, want)
+	}
+
+	// The replicas recover and are tried again past the cooldown.
+	fake.set(t.Name()+"/r1", false)
+	fake.set(t.Name()+"/r2", false)
+	now = now.Add(Cooldown)
+	query(readOnly)
+	query(readOnly)
+	if got, want := counts(), [3]int{4, 2, 4}; got != want {
+		t.Fatalf("reads must fail back to the replicas: got %v, want %v", got, want)
+	}
+}
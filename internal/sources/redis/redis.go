@@ -21,6 +21,7 @@ import (
 
 	"github.com/goccy/go-yaml"
 	"github.com/googleapis/mcp-toolbox/internal/sources"
+	"github.com/googleapis/mcp-toolbox/internal/sources/clienttls"
 	"github.com/redis/go-redis/v9"
 	"go.opentelemetry.io/otel/trace"
 )
@@ -31,7 +32,7 @@ const SourceType string = "redis"
 var _ sources.SourceConfig = Config{}
 
 func init() {
-	if !sources.Register(SourceType, newConfig) {
+	if !sources.Register[*Source](SourceType, newConfig) {
 		panic(fmt.Sprintf("source type %q already registered", SourceType))
 	}
 }
@@ -54,11 +55,34 @@ type Config struct {
 	UseGCPIAM      bool      `yaml:"useGCPIAM"`
 	ClusterEnabled bool      `yaml:"clusterEnabled"`
 	TLS            TLSConfig `yaml:"tls"`
+	// SentinelMasterName connects to the master of this name through the
+	// Sentinels listed in Address.
+	SentinelMasterName string `yaml:"sentinelMasterName"`
+	SentinelUsername   string `yaml:"sentinelUsername"`
+	SentinelPassword   string `yaml:"sentinelPassword"`
 }
 
 type TLSConfig struct {
 	Enabled            bool `yaml:"enabled"`
 	InsecureSkipVerify bool `yaml:"insecureSkipVerify"`
+	// Config optionally sets the CA certificates trusted, the client
+	// certificate and the verification mode.
+	clienttls.Config `yaml:",inline"`
+}
+
+// clientConfig returns the TLS configuration of the connections, or nil if
+// TLS is not enabled.
+func (t TLSConfig) clientConfig() (*tls.Config, error) {
+	if !t.Enabled {
+		return nil, nil
+	}
+	c := t.Config
+	if c.Mode == "" && t.InsecureSkipVerify {
+		c.Mode = clienttls.ModeRequire
+	}
+	// the server name is left to the dialer, which sets it to the host of
+	// each address
+	return c.ClientConfig("")
 }
 
 func (r Config) SourceConfigType() string {
@@ -74,6 +98,14 @@ var _ RedisClient = (*redis.Client)(nil)
 var _ RedisClient = (*redis.ClusterClient)(nil)
 
 func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
+	if r.SentinelMasterName != "" {
+		if r.ClusterEnabled {
+			return nil, fmt.Errorf("clusterEnabled and sentinelMasterName are mutually exclusive")
+		}
+		if r.UseGCPIAM {
+			return nil, fmt.Errorf("useGCPIAM is not supported with sentinelMasterName")
+		}
+	}
 	client, err := initRedisClient(ctx, r)
 	if err != nil {
 		return nil, fmt.Errorf("error initializing Redis client: %s", err)
@@ -98,15 +130,12 @@ func initRedisClient(ctx context.Context, r Config) (RedisClient, error) {
 		}
 	}
 
-	var tlsConfig *tls.Config
-	if r.TLS.Enabled {
-		tlsConfig = &tls.Config{
-			InsecureSkipVerify: r.TLS.InsecureSkipVerify,
-		}
+	tlsConfig, err := r.TLS.clientConfig()
+	if err != nil {
+		return nil, err
 	}
 
 	var client RedisClient
-	var err error
 	if r.ClusterEnabled {
 		// Create a new Redis Cluster client
 		clusterClient := redis.NewClusterClient(&redis.ClusterOptions{
@@ -130,6 +159,28 @@ func initRedisClient(ctx context.Context, r Config) (RedisClient, error) {
 		return client, nil
 	}
 
+	if r.SentinelMasterName != "" {
+		// Create a Redis client following the master elected by the Sentinels
+		failoverClient := redis.NewFailoverClient(&redis.FailoverOptions{
+			MasterName:       r.SentinelMasterName,
+			SentinelAddrs:    r.Address,
+			SentinelUsername: r.SentinelUsername,
+			SentinelPassword: r.SentinelPassword,
+			PoolSize:         10,
+			ConnMaxIdleTime:  60 * time.Second,
+			MinIdleConns:     1,
+			DB:               r.Database,
+			Username:         r.Username,
+			Password:         r.Password,
+			TLSConfig:        tlsConfig,
+		})
+		if err = failoverClient.Ping(ctx).Err(); err != nil {
+			return nil, fmt.Errorf("unable to connect to redis master %q through sentinels: %s", r.SentinelMasterName, err)
+		}
+		client = failoverClient
+		return client, nil
+	}
+
 	// Create a new Redis client
 	standaloneClient := redis.NewClient(&redis.Options{
 		Addr:                       r.Address[0],
@@ -194,6 +245,56 @@ func (s *Source) RunCommand(ctx context.Context, cmds [][]any) (any, error) {
 	return out, nil
 }
 
+// maxKeyElements caps the elements of lists, sorted sets and streams
+// returned by GetKey.
+const maxKeyElements = 1000
+
+// GetKey returns the type and value of key, read with the command matching
+// its type, or nil if the key does not exist. Lists, sorted sets and streams
+// are truncated to their first maxKeyElements elements.
+func (s *Source) GetKey(ctx context.Context, key string) (any, error) {
+	keyType, err := s.RedisClient().Do(ctx, "TYPE", key).Text()
+	if err != nil {
+		return nil, fmt.Errorf("unable to get type of key %q: %s", key, err)
+	}
+	var cmd []any
+	switch keyType {
+	case "none":
+		return nil, nil
+	case "string":
+		cmd = []any{"GET", key}
+	case "hash":
+		cmd = []any{"HGETALL", key}
+	case "list":
+		cmd = []any{"LRANGE", key, 0, maxKeyElements - 1}
+	case "set":
+		cmd = []any{"SMEMBERS", key}
+	case "zset":
+		cmd = []any{"ZRANGE", key, 0, maxKeyElements - 1, "WITHSCORES"}
+	case "stream":
+		cmd = []any{"XRANGE", key, "-", "+", "COUNT", maxKeyElements}
+	default:
+		return nil, fmt.Errorf("key %q has unsupported type %q", key, keyType)
+	}
+	val, err := s.RedisClient().Do(ctx, cmd...).Result()
+	if err != nil && err != redis.Nil {
+		return nil, fmt.Errorf("unable to read key %q: %s", key, err)
+	}
+	return map[string]any{"key": key, "type": keyType, "value": convertRedisResult(val)}, nil
+}
+
+// SetKey sets key to the string value, expiring after ttl unless ttl is 0.
+func (s *Source) SetKey(ctx context.Context, key, value string, ttl time.Duration) error {
+	cmd := []any{"SET", key, value}
+	if ttl > 0 {
+		cmd = append(cmd, "PX", ttl.Milliseconds())
+	}
+	if err := s.RedisClient().Do(ctx, cmd...).Err(); err != nil {
+		return fmt.Errorf("unable to set key %q: %s", key, err)
+	}
+	return nil
+}
+
 // convertRedisResult recursively converts redis results (map[any]any) to be
 // JSON-marshallable (map[string]any).
 // It converts map[any]any to map[string]any and handles nested structures.
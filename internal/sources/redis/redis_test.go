@@ -22,6 +22,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/googleapis/mcp-toolbox/internal/server"
 	"github.com/googleapis/mcp-toolbox/internal/sources"
+	"github.com/googleapis/mcp-toolbox/internal/sources/clienttls"
 	"github.com/googleapis/mcp-toolbox/internal/sources/redis"
 	"github.com/googleapis/mcp-toolbox/internal/testutils"
 )
@@ -83,6 +84,62 @@ func TestParseFromYamlRedis(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "tls with client certificate",
+			in: `
+			kind: source
+			name: my-redis-instance
+			type: redis
+			address:
+			  - 127.0.0.1
+			tls:
+			  enabled: true
+			  mode: verify-full
+			  caFile: /tmp/ca.crt
+			  certFile: /tmp/client.crt
+			  keyFile: /tmp/client.key
+			`,
+			want: map[string]sources.SourceConfig{
+				"my-redis-instance": redis.Config{
+					Name:    "my-redis-instance",
+					Type:    redis.SourceType,
+					Address: []string{"127.0.0.1"},
+					TLS: redis.TLSConfig{
+						Enabled: true,
+						Config: clienttls.Config{
+							Mode:     clienttls.ModeVerifyFull,
+							CAFile:   "/tmp/ca.crt",
+							CertFile: "/tmp/client.crt",
+							KeyFile:  "/tmp/client.key",
+						},
+					},
+				},
+			},
+		},
+		{
+			desc: "sentinel example",
+			in: `
+			kind: source
+			name: my-redis-instance
+			type: redis
+			address:
+			  - 10.0.0.1:26379
+			  - 10.0.0.2:26379
+			sentinelMasterName: mymaster
+			sentinelPassword: sentinel-pass
+			password: my-pass
+			`,
+			want: map[string]sources.SourceConfig{
+				"my-redis-instance": redis.Config{
+					Name:               "my-redis-instance",
+					Type:               redis.SourceType,
+					Address:            []string{"10.0.0.1:26379", "10.0.0.2:26379"},
+					Password:           "my-pass",
+					SentinelMasterName: "mymaster",
+					SentinelPassword:   "sentinel-pass",
+				},
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -138,7 +195,7 @@ func TestFailParseFromYaml(t *testing.T) {
 			name: my-redis-instance
 			type: redis
 			`,
-			err: "error unmarshaling source: unable to parse source \"my-redis-instance\" as \"redis\": Key: 'Config.Address' Error:Field validation for 'Address' failed on the 'required' tag",
+			err: "error unmarshaling source: unable to parse source \"my-redis-instance\" as \"redis\": sources[my-redis-instance].address is required; add a \"address\" field",
 		},
 	}
 	for _, tc := range tcs {
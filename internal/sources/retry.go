@@ -0,0 +1,94 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sources
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/googleapis/mcp-toolbox/internal/util"
+)
+
+// DefaultMaxRetries is the number of times sources retry an operation that
+// failed with a transient error, unless configured otherwise.
+const DefaultMaxRetries = 3
+
+// RetryPolicy retries the operations of a source that fail with transient
+// errors, such as rate limits, with exponential backoff and full jitter.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt. Zero
+	// disables retries.
+	MaxRetries int
+	// BaseDelay bounds the delay before the first retry. The bound doubles
+	// with every retry, up to MaxDelay.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	// Retryable reports whether an error is transient.
+	Retryable func(error) bool
+}
+
+// NewRetryPolicy returns a policy retrying the errors retryable accepts up
+// to maxRetries times, with delays starting under a second and capped at 32
+// seconds.
+func NewRetryPolicy(maxRetries int, retryable func(error) bool) RetryPolicy {
+	return RetryPolicy{
+		MaxRetries: maxRetries,
+		BaseDelay:  time.Second,
+		MaxDelay:   32 * time.Second,
+		Retryable:  retryable,
+	}
+}
+
+// Do runs op until it succeeds, fails with an error that is not retryable,
+// or fails after MaxRetries retries, in which case the last error is
+// returned. Retries are logged at DEBUG level under the name of the source.
+// The operations of tools with write intent are never retried, as a failed
+// attempt may still have written.
+func (p RetryPolicy) Do(ctx context.Context, sourceName string, op func() error) error {
+	for attempt := 0; ; attempt++ {
+		err := op()
+		if err == nil || attempt >= p.MaxRetries || p.Retryable == nil || !p.Retryable(err) || util.WriteInvocationFromContext(ctx) {
+			return err
+		}
+		delay := p.delay(attempt)
+		if logger, lerr := util.LoggerFromContext(ctx); lerr == nil {
+			logger.DebugContext(ctx, fmt.Sprintf("source %q retrying in %s after attempt %d of %d failed: %s", sourceName, delay, attempt+1, p.MaxRetries+1, err))
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// delay returns a random delay under the exponential bound of attempt.
+func (p RetryPolicy) delay(attempt int) time.Duration {
+	bound := p.BaseDelay
+	for i := 0; i < attempt && bound < p.MaxDelay; i++ {
+		bound *= 2
+	}
+	if bound > p.MaxDelay {
+		bound = p.MaxDelay
+	}
+	if bound <= 0 {
+		return 0
+	}
+	return rand.N(bound)
+}
@@ -0,0 +1,93 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sources_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/googleapis/mcp-toolbox/internal/sources"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+)
+
+var errTransient = errors.New("transient")
+
+func TestRetryPolicy(t *testing.T) {
+	errPermanent := errors.New("permanent")
+	tcs := []struct {
+		desc         string
+		maxRetries   int
+		errs         []error
+		write        bool
+		wantErr      error
+		wantAttempts int
+	}{
+		{desc: "success", maxRetries: 3, wantAttempts: 1},
+		{desc: "retried until success", maxRetries: 3, errs: []error{errTransient, errTransient}, wantAttempts: 3},
+		{desc: "retries exhausted", maxRetries: 2, errs: []error{errTransient, errTransient, errTransient, errTransient}, wantErr: errTransient, wantAttempts: 3},
+		{desc: "not retryable", maxRetries: 3, errs: []error{errPermanent}, wantErr: errPermanent, wantAttempts: 1},
+		{desc: "retries disabled", maxRetries: 0, errs: []error{errTransient}, wantErr: errTransient, wantAttempts: 1},
+		{desc: "write invocation", maxRetries: 3, write: true, errs: []error{errTransient}, wantErr: errTransient, wantAttempts: 1},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			p := sources.RetryPolicy{
+				MaxRetries: tc.maxRetries,
+				BaseDelay:  time.Millisecond,
+				MaxDelay:   5 * time.Millisecond,
+				Retryable:  func(err error) bool { return errors.Is(err, errTransient) },
+			}
+			ctx := context.Background()
+			if tc.write {
+				ctx = util.WithWriteInvocation(ctx)
+			}
+			attempts := 0
+			err := p.Do(ctx, "my-source", func() error {
+				attempts++
+				if attempts <= len(tc.errs) {
+					return tc.errs[attempts-1]
+				}
+				return nil
+			})
+			if !errors.Is(err, tc.wantErr) {
+				t.Errorf("unexpected error: got %v, want %v", err, tc.wantErr)
+			}
+			if attempts != tc.wantAttempts {
+				t.Errorf("unexpected attempts: got %d, want %d", attempts, tc.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	p := sources.RetryPolicy{
+		MaxRetries: 3,
+		BaseDelay:  time.Hour,
+		MaxDelay:   time.Hour,
+		Retryable:  func(error) bool { return true },
+	}
+	attempts := 0
+	err := p.Do(ctx, "my-source", func() error {
+		attempts++
+		cancel()
+		return errTransient
+	})
+	if !errors.Is(err, errTransient) || attempts != 1 {
+		t.Errorf("expected canceled retries to return the last error after 1 attempt, got %v after %d", err, attempts)
+	}
+}
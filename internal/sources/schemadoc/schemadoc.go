@@ -0,0 +1,217 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package schemadoc describes the tables, columns and comments of SQL sources
+// so that they can be published as MCP resources.
+package schemadoc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/googleapis/mcp-toolbox/internal/util/orderedmap"
+)
+
+// Introspection queries returning one row per column, with the columns
+// table_name, table_comment, column_name, column_type, nullable,
+// column_comment, primary_key and has_default, ordered by table and column
+// position.
+const (
+	PostgresQuery = `SELECT n.nspname || '.' || c.relname AS table_name,
+	obj_description(c.oid, 'pg_class') AS table_comment,
+	a.attname AS column_name,
+	format_type(a.atttypid, a.atttypmod) AS column_type,
+	NOT a.attnotnull AS nullable,
+	col_description(c.oid, a.attnum) AS column_comment,
+	EXISTS (SELECT 1 FROM pg_catalog.pg_index AS i
+		WHERE i.indrelid = c.oid AND i.indisprimary AND a.attnum = ANY(i.indkey)) AS primary_key,
+	a.atthasdef OR a.attidentity <> '' OR a.attgenerated <> '' AS has_default
+FROM pg_catalog.pg_class AS c
+JOIN pg_catalog.pg_namespace AS n ON n.oid = c.relnamespace
+JOIN pg_catalog.pg_attribute AS a ON a.attrelid = c.oid
+WHERE c.relkind IN ('r', 'p', 'v', 'm', 'f') AND a.attnum > 0 AND NOT a.attisdropped
+	AND n.nspname NOT IN ('pg_catalog', 'information_schema') AND n.nspname NOT LIKE 'pg_toast%'
+ORDER BY n.nspname, c.relname, a.attnum`
+
+	MySQLQuery = `SELECT c.table_name AS table_name, t.table_comment AS table_comment,
+	c.column_name AS column_name, c.column_type AS column_type,
+	c.is_nullable = 'YES' AS nullable, c.column_comment AS column_comment,
+	c.column_key = 'PRI' AS primary_key,
+	c.column_default IS NOT NULL OR c.extra LIKE '%auto_increment%' OR c.extra LIKE '%GENERATED%' AS has_default
+FROM information_schema.columns AS c
+JOIN information_schema.tables AS t ON t.table_schema = c.table_schema AND t.table_name = c.table_name
+WHERE c.table_schema = DATABASE()
+ORDER BY c.table_name, c.ordinal_position`
+
+	SQLServerQuery = `SELECT c.TABLE_SCHEMA + '.' + c.TABLE_NAME AS table_name,
+	CAST(tp.value AS NVARCHAR(MAX)) AS table_comment,
+	c.COLUMN_NAME AS column_name, c.DATA_TYPE AS column_type,
+	CASE WHEN c.IS_NULLABLE = 'YES' THEN 1 ELSE 0 END AS nullable,
+	CAST(cp.value AS NVARCHAR(MAX)) AS column_comment,
+	CASE WHEN EXISTS (SELECT 1 FROM INFORMATION_SCHEMA.TABLE_CONSTRAINTS AS tc
+		JOIN INFORMATION_SCHEMA.KEY_COLUMN_USAGE AS k
+			ON k.CONSTRAINT_SCHEMA = tc.CONSTRAINT_SCHEMA AND k.CONSTRAINT_NAME = tc.CONSTRAINT_NAME
+		WHERE tc.CONSTRAINT_TYPE = 'PRIMARY KEY' AND tc.TABLE_SCHEMA = c.TABLE_SCHEMA
+			AND tc.TABLE_NAME = c.TABLE_NAME AND k.COLUMN_NAME = c.COLUMN_NAME) THEN 1 ELSE 0 END AS primary_key,
+	CASE WHEN c.COLUMN_DEFAULT IS NOT NULL
+		OR COLUMNPROPERTY(OBJECT_ID(QUOTENAME(c.TABLE_SCHEMA) + '.' + QUOTENAME(c.TABLE_NAME)), c.COLUMN_NAME, 'IsIdentity') = 1
+		OR COLUMNPROPERTY(OBJECT_ID(QUOTENAME(c.TABLE_SCHEMA) + '.' + QUOTENAME(c.TABLE_NAME)), c.COLUMN_NAME, 'IsComputed') = 1
+		THEN 1 ELSE 0 END AS has_default
+FROM INFORMATION_SCHEMA.COLUMNS AS c
+LEFT JOIN sys.extended_properties AS tp
+	ON tp.class = 1 AND tp.name = 'MS_Description' AND tp.minor_id = 0
+	AND tp.major_id = OBJECT_ID(QUOTENAME(c.TABLE_SCHEMA) + '.' + QUOTENAME(c.TABLE_NAME))
+LEFT JOIN sys.extended_properties AS cp
+	ON cp.class = 1 AND cp.name = 'MS_Description'
+	AND cp.major_id = OBJECT_ID(QUOTENAME(c.TABLE_SCHEMA) + '.' + QUOTENAME(c.TABLE_NAME))
+	AND cp.minor_id = COLUMNPROPERTY(OBJECT_ID(QUOTENAME(c.TABLE_SCHEMA) + '.' + QUOTENAME(c.TABLE_NAME)), c.COLUMN_NAME, 'ColumnId')
+ORDER BY c.TABLE_SCHEMA, c.TABLE_NAME, c.ORDINAL_POSITION`
+
+	// SQLite has no comments. An INTEGER PRIMARY KEY column is an alias of
+	// the rowid, which is assigned when it is not given.
+	SQLiteQuery = `SELECT m.name AS table_name, NULL AS table_comment,
+	p.name AS column_name, p.type AS column_type,
+	p."notnull" = 0 AS nullable, NULL AS column_comment,
+	p.pk > 0 AS primary_key,
+	p.dflt_value IS NOT NULL OR (p.pk = 1 AND upper(p.type) = 'INTEGER') AS has_default
+FROM sqlite_master AS m JOIN pragma_table_info(m.name) AS p
+WHERE m.type IN ('table', 'view') AND m.name NOT LIKE 'sqlite_%'
+ORDER BY m.name, p.cid`
+)
+
+// Describer is implemented by sources that can describe their schema.
+type Describer interface {
+	DescribeSchema(ctx context.Context) (*Schema, error)
+}
+
+// Schema is the description of the tables of a source.
+type Schema struct {
+	Tables []Table `json:"tables"`
+}
+
+// Table is a table or view and its columns.
+type Table struct {
+	Name    string   `json:"name"`
+	Comment string   `json:"comment,omitempty"`
+	Columns []Column `json:"columns"`
+}
+
+// Column is a column of a table.
+type Column struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Nullable bool   `json:"nullable"`
+	Comment  string `json:"comment,omitempty"`
+	// PrimaryKey reports whether the column is part of the primary key.
+	PrimaryKey bool `json:"primaryKey,omitempty"`
+	// HasDefault reports whether the database assigns the column when an
+	// insert does not, as for defaults and identity or generated columns.
+	HasDefault bool `json:"hasDefault,omitempty"`
+}
+
+// RunFunc runs a statement on a source, returning its rows.
+type RunFunc func(ctx context.Context, statement string, params []any) (any, error)
+
+// Describe runs the introspection query on a source and returns its schema.
+func Describe(ctx context.Context, query string, run RunFunc) (*Schema, error) {
+	res, err := run(ctx, query, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to introspect schema: %w", err)
+	}
+	schema, err := SchemaFromRows(res)
+	if err != nil {
+		return nil, fmt.Errorf("unable to introspect schema: %w", err)
+	}
+	return schema, nil
+}
+
+// SchemaFromRows builds the schema from the rows of an introspection query,
+// keeping the order of the rows.
+func SchemaFromRows(result any) (*Schema, error) {
+	rows, ok := result.([]any)
+	if !ok {
+		return nil, fmt.Errorf("unexpected introspection result of type %T", result)
+	}
+	schema := &Schema{Tables: []Table{}}
+	index := make(map[string]int)
+	for _, row := range rows {
+		values := make(map[string]any)
+		switch r := row.(type) {
+		case orderedmap.Row:
+			for _, col := range r.Columns {
+				values[col.Name] = col.Value
+			}
+		case map[string]any:
+			values = r
+		default:
+			return nil, fmt.Errorf("unexpected introspection row of type %T", row)
+		}
+		table, column := text(values["table_name"]), text(values["column_name"])
+		if table == "" || column == "" {
+			return nil, fmt.Errorf("introspection row is missing the table_name or column_name column")
+		}
+		i, ok := index[table]
+		if !ok {
+			i = len(schema.Tables)
+			index[table] = i
+			schema.Tables = append(schema.Tables, Table{Name: table, Comment: text(values["table_comment"]), Columns: []Column{}})
+		}
+		schema.Tables[i].Columns = append(schema.Tables[i].Columns, Column{
+			Name:       column,
+			Type:       text(values["column_type"]),
+			Nullable:   truthy(values["nullable"]),
+			Comment:    text(values["column_comment"]),
+			PrimaryKey: truthy(values["primary_key"]),
+			HasDefault: truthy(values["has_default"]),
+		})
+	}
+	return schema, nil
+}
+
+func text(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// truthy reports whether a boolean column is true. Databases without a
+// boolean type return it as a number or a string.
+func truthy(v any) bool {
+	switch v := v.(type) {
+	case bool:
+		return v
+	case int64:
+		return v != 0
+	case int32:
+		return v != 0
+	case int:
+		return v != 0
+	case float64:
+		return v != 0
+	default:
+		switch strings.ToLower(text(v)) {
+		case "1", "t", "true", "yes":
+			return true
+		}
+		return false
+	}
+}
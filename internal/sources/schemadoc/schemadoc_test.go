@@ -0,0 +1,100 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemadoc
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/internal/util/orderedmap"
+)
+
+func row(values ...any) orderedmap.Row {
+	names := []string{"table_name", "table_comment", "column_name", "column_type", "nullable", "column_comment", "primary_key", "has_default"}
+	var r orderedmap.Row
+	for i, v := range values {
+		r.Add(names[i], v)
+	}
+	return r
+}
+
+func TestSchemaFromRows(t *testing.T) {
+	rows := []any{
+		row("public.orders", "Customer orders", "id", "bigint", false, nil, true, true),
+		row("public.orders", "Customer orders", "note", "text", true, "Free-form note"),
+		map[string]any{"table_name": "items", "table_comment": []byte(""), "column_name": "sku", "column_type": "varchar(32)", "nullable": int64(1), "column_comment": ""},
+	}
+	got, err := SchemaFromRows(rows)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := &Schema{Tables: []Table{
+		{Name: "public.orders", Comment: "Customer orders", Columns: []Column{
+			{Name: "id", Type: "bigint", PrimaryKey: true, HasDefault: true},
+			{Name: "note", Type: "text", Nullable: true, Comment: "Free-form note"},
+		}},
+		{Name: "items", Columns: []Column{
+			{Name: "sku", Type: "varchar(32)", Nullable: true},
+		}},
+	}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect schema: diff %v", diff)
+	}
+}
+
+func TestSchemaFromRowsErrors(t *testing.T) {
+	tcs := []struct {
+		desc   string
+		result any
+		want   string
+	}{
+		{desc: "not rows", result: "rows", want: "unexpected introspection result"},
+		{desc: "bad row", result: []any{42}, want: "unexpected introspection row"},
+		{desc: "missing column", result: []any{map[string]any{"table_name": "t"}}, want: "missing the table_name or column_name"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := SchemaFromRows(tc.result)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("got error %v, want error containing %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	run := func(_ context.Context, statement string, _ []any) (any, error) {
+		if statement != SQLiteQuery {
+			t.Errorf("got statement %q, want the SQLite query", statement)
+		}
+		return []any{row("t", nil, "a", "INTEGER", int64(0), nil)}, nil
+	}
+	got, err := Describe(context.Background(), SQLiteQuery, run)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := &Schema{Tables: []Table{{Name: "t", Columns: []Column{{Name: "a", Type: "INTEGER"}}}}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect schema: diff %v", diff)
+	}
+
+	failing := func(context.Context, string, []any) (any, error) { return nil, errors.New("boom") }
+	if _, err := Describe(context.Background(), SQLiteQuery, failing); err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Fatalf("got error %v, want error containing %q", err, "boom")
+	}
+}
@@ -0,0 +1,276 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package schemasnapshot compares the schema of a SQL source against a
+// snapshot stored in a file, to detect the schema drifting from what the
+// prompts of agents assume.
+package schemasnapshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sort"
+
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/orderedmap"
+)
+
+// Introspection queries listing the columns of the tables of a database.
+// Each returns table_name, column_name and column_type columns.
+const (
+	PostgresQuery = `SELECT table_schema || '.' || table_name AS table_name, column_name, data_type AS column_type
+FROM information_schema.columns
+WHERE table_schema NOT IN ('pg_catalog', 'information_schema')`
+
+	MySQLQuery = `SELECT table_name AS table_name, column_name AS column_name, column_type AS column_type
+FROM information_schema.columns
+WHERE table_schema = DATABASE()`
+
+	SQLServerQuery = `SELECT TABLE_SCHEMA + '.' + TABLE_NAME AS table_name, COLUMN_NAME AS column_name, DATA_TYPE AS column_type
+FROM INFORMATION_SCHEMA.COLUMNS`
+
+	SQLiteQuery = `SELECT m.name AS table_name, p.name AS column_name, p.type AS column_type
+FROM sqlite_master AS m JOIN pragma_table_info(m.name) AS p
+WHERE m.type = 'table' AND m.name NOT LIKE 'sqlite_%'`
+)
+
+// Config is the schemaSnapshot option of a SQL source.
+type Config struct {
+	// Path is the snapshot file. It is created from the current schema when
+	// it does not exist.
+	Path string `yaml:"path" validate:"required"`
+	// WarnOnDrift logs the drift of the schema from the snapshot.
+	WarnOnDrift bool `yaml:"warnOnDrift"`
+}
+
+// Schema maps the tables of a database to the types of their columns.
+type Schema map[string]map[string]string
+
+// snapshot is the content of a snapshot file.
+type snapshot struct {
+	Tables Schema `yaml:"tables"`
+}
+
+// Load reads the snapshot file at path. It reports false if there is none.
+func Load(path string) (Schema, bool, error) {
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("unable to read schema snapshot: %w", err)
+	}
+	var s snapshot
+	if err := yaml.Unmarshal(b, &s); err != nil {
+		return nil, false, fmt.Errorf("unable to parse schema snapshot %q: %w", path, err)
+	}
+	return s.Tables, true, nil
+}
+
+// Save writes schema to the snapshot file at path.
+func Save(path string, schema Schema) error {
+	b, err := yaml.Marshal(snapshot{Tables: schema})
+	if err != nil {
+		return fmt.Errorf("unable to encode schema snapshot: %w", err)
+	}
+	if err := os.WriteFile(path, b, 0o644); err != nil {
+		return fmt.Errorf("unable to write schema snapshot: %w", err)
+	}
+	return nil
+}
+
+// SchemaFromRows builds a schema from the result of an introspection query.
+func SchemaFromRows(result any) (Schema, error) {
+	rows, ok := result.([]any)
+	if !ok {
+		return nil, fmt.Errorf("unexpected introspection result of type %T", result)
+	}
+	schema := make(Schema)
+	for _, row := range rows {
+		values := make(map[string]any)
+		switch r := row.(type) {
+		case orderedmap.Row:
+			for _, col := range r.Columns {
+				values[col.Name] = col.Value
+			}
+		case map[string]any:
+			values = r
+		default:
+			return nil, fmt.Errorf("unexpected introspection row of type %T", row)
+		}
+		table, column, typ := text(values["table_name"]), text(values["column_name"]), text(values["column_type"])
+		if table == "" || column == "" {
+			return nil, fmt.Errorf("introspection row is missing the table_name or column_name column")
+		}
+		if schema[table] == nil {
+			schema[table] = make(map[string]string)
+		}
+		schema[table][column] = typ
+	}
+	return schema, nil
+}
+
+func text(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// Column is a column of a table, and its type.
+type Column struct {
+	Table  string `json:"table"`
+	Column string `json:"column"`
+	Type   string `json:"type"`
+	// PreviousType is the type of a retyped column in the snapshot.
+	PreviousType string `json:"previousType,omitempty"`
+}
+
+// Drift is the difference between the schema of a source and its snapshot.
+type Drift struct {
+	Source   string   `json:"source"`
+	Snapshot string   `json:"snapshot"`
+	Added    []Column `json:"added"`
+	Removed  []Column `json:"removed"`
+	Retyped  []Column `json:"retyped"`
+}
+
+// Empty reports whether the schema matches the snapshot.
+func (d Drift) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Retyped) == 0
+}
+
+// Diff compares the current schema of a source against its snapshot. The
+// columns of the drift are sorted by table and column.
+func Diff(snapshot, current Schema) Drift {
+	d := Drift{Added: []Column{}, Removed: []Column{}, Retyped: []Column{}}
+	for table, columns := range current {
+		for column, typ := range columns {
+			prev, ok := snapshot[table][column]
+			switch {
+			case !ok:
+				d.Added = append(d.Added, Column{Table: table, Column: column, Type: typ})
+			case prev != typ:
+				d.Retyped = append(d.Retyped, Column{Table: table, Column: column, Type: typ, PreviousType: prev})
+			}
+		}
+	}
+	for table, columns := range snapshot {
+		for column, typ := range columns {
+			if _, ok := current[table][column]; !ok {
+				d.Removed = append(d.Removed, Column{Table: table, Column: column, Type: typ})
+			}
+		}
+	}
+	for _, cols := range [][]Column{d.Added, d.Removed, d.Retyped} {
+		sort.Slice(cols, func(i, j int) bool {
+			if cols[i].Table != cols[j].Table {
+				return cols[i].Table < cols[j].Table
+			}
+			return cols[i].Column < cols[j].Column
+		})
+	}
+	return d
+}
+
+// Reporter is implemented by sources reporting the drift of their schema.
+type Reporter interface {
+	// SchemaDrift returns the drift found when the source was initialized,
+	// or nil if the source has no snapshot or its schema matches it.
+	SchemaDrift() *Drift
+}
+
+// Report implements Reporter. Sources embed it.
+type Report struct {
+	drift *Drift
+}
+
+func (r Report) SchemaDrift() *Drift {
+	return r.drift
+}
+
+type updateKey struct{}
+
+// WithUpdate returns a context in which Check overwrites the snapshots with
+// the current schemas, for intentional migrations.
+func WithUpdate(ctx context.Context, update bool) context.Context {
+	return context.WithValue(ctx, updateKey{}, update)
+}
+
+func updateFromContext(ctx context.Context) bool {
+	update, _ := ctx.Value(updateKey{}).(bool)
+	return update
+}
+
+// RunFunc runs an introspection query, like the RunSQL method of sources.
+type RunFunc func(ctx context.Context, statement string, params []any) (any, error)
+
+// Check introspects the schema of the source sourceName with query and
+// compares it against its snapshot. The snapshot is written instead when it
+// does not exist, or when ctx was returned by WithUpdate. A nil cfg disables
+// the check.
+func Check(ctx context.Context, sourceName string, cfg *Config, query string, run RunFunc) (Report, error) {
+	if cfg == nil {
+		return Report{}, nil
+	}
+	logger, err := util.LoggerFromContext(ctx)
+	if err != nil {
+		return Report{}, err
+	}
+	res, err := run(ctx, query, nil)
+	if err != nil {
+		return Report{}, fmt.Errorf("unable to introspect schema: %w", err)
+	}
+	current, err := SchemaFromRows(res)
+	if err != nil {
+		return Report{}, fmt.Errorf("unable to introspect schema: %w", err)
+	}
+
+	stored, ok, err := Load(cfg.Path)
+	if err != nil {
+		return Report{}, err
+	}
+	if !ok || updateFromContext(ctx) {
+		if err := Save(cfg.Path, current); err != nil {
+			return Report{}, err
+		}
+		logger.InfoContext(ctx, fmt.Sprintf("Wrote schema snapshot of source %q to %s", sourceName, cfg.Path))
+		return Report{}, nil
+	}
+
+	drift := Diff(stored, current)
+	if drift.Empty() {
+		return Report{}, nil
+	}
+	drift.Source = sourceName
+	drift.Snapshot = cfg.Path
+	if cfg.WarnOnDrift {
+		logger.WarnContext(ctx, fmt.Sprintf("schema of source %q drifted from its snapshot %s", sourceName, cfg.Path),
+			"added", drift.Added,
+			"removed", drift.Removed,
+			"retyped", drift.Retyped,
+		)
+	}
+	return Report{drift: &drift}, nil
+}
@@ -0,0 +1,189 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemasnapshot
+
+import (
+	"context"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/internal/log"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/orderedmap"
+)
+
+func mustLoad(t *testing.T, path string) Schema {
+	t.Helper()
+	schema, ok, err := Load(path)
+	if err != nil || !ok {
+		t.Fatalf("unable to load schema %q: %t, %v", path, ok, err)
+	}
+	return schema
+}
+
+func loadFixture(t *testing.T, name string) Schema {
+	t.Helper()
+	return mustLoad(t, filepath.Join("testdata", name))
+}
+
+func TestDiff(t *testing.T) {
+	v1 := loadFixture(t, "schema_v1.yaml")
+	v2 := loadFixture(t, "schema_v2.yaml")
+
+	tcs := []struct {
+		desc     string
+		snapshot Schema
+		current  Schema
+		want     Drift
+	}{
+		{
+			desc:     "no drift",
+			snapshot: v1,
+			current:  v1,
+			want:     Drift{Added: []Column{}, Removed: []Column{}, Retyped: []Column{}},
+		},
+		{
+			desc:     "drift",
+			snapshot: v1,
+			current:  v2,
+			want: Drift{
+				Added: []Column{
+					{Table: "public.flights", Column: "gate", Type: "text"},
+					{Table: "public.seats", Column: "id", Type: "integer"},
+				},
+				Removed: []Column{
+					{Table: "public.tickets", Column: "id", Type: "integer"},
+					{Table: "public.tickets", Column: "price", Type: "integer"},
+				},
+				Retyped: []Column{
+					{Table: "public.flights", Column: "flight_number", Type: "integer", PreviousType: "text"},
+				},
+			},
+		},
+		{
+			desc:     "empty snapshot",
+			snapshot: Schema{},
+			current:  Schema{"t": {"c": "int"}},
+			want: Drift{
+				Added:   []Column{{Table: "t", Column: "c", Type: "int"}},
+				Removed: []Column{},
+				Retyped: []Column{},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := Diff(tc.snapshot, tc.current)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("unexpected drift (-want +got):\n%s", diff)
+			}
+			if got.Empty() != (tc.desc == "no drift") {
+				t.Errorf("unexpected Empty: %t", got.Empty())
+			}
+		})
+	}
+}
+
+func TestSchemaFromRows(t *testing.T) {
+	var row orderedmap.Row
+	row.Add("table_name", "public.flights")
+	row.Add("column_name", "airline")
+	row.Add("column_type", "text")
+	rows := []any{
+		row,
+		map[string]any{"table_name": []byte("public.flights"), "column_name": "gate", "column_type": nil},
+	}
+	got, err := SchemaFromRows(rows)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := Schema{"public.flights": {"airline": "text", "gate": ""}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected schema (-want +got):\n%s", diff)
+	}
+
+	if _, err := SchemaFromRows([]any{map[string]any{"column_name": "gate"}}); err == nil {
+		t.Errorf("expected an error for a row without table_name")
+	}
+}
+
+func TestCheck(t *testing.T) {
+	var logs strings.Builder
+	logger, err := log.NewStdLogger(io.Discard, &logs, "info")
+	if err != nil {
+		t.Fatalf("unable to initialize logger: %s", err)
+	}
+	ctx := util.WithLogger(context.Background(), logger)
+
+	schema := loadFixture(t, "schema_v1.yaml")
+	run := func(context.Context, string, []any) (any, error) {
+		var rows []any
+		for table, columns := range schema {
+			for column, typ := range columns {
+				rows = append(rows, map[string]any{"table_name": table, "column_name": column, "column_type": typ})
+			}
+		}
+		return rows, nil
+	}
+	cfg := &Config{Path: filepath.Join(t.TempDir(), "snapshot.yaml"), WarnOnDrift: true}
+
+	// the first check writes the snapshot
+	report, err := Check(ctx, "my-source", cfg, PostgresQuery, run)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if report.SchemaDrift() != nil {
+		t.Fatalf("expected no drift when writing the snapshot")
+	}
+	if stored := mustLoad(t, cfg.Path); !cmp.Equal(stored, schema) {
+		t.Fatalf("unexpected snapshot: %v", stored)
+	}
+
+	schema = loadFixture(t, "schema_v2.yaml")
+	report, err = Check(ctx, "my-source", cfg, PostgresQuery, run)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	drift := report.SchemaDrift()
+	if drift == nil || drift.Source != "my-source" || len(drift.Retyped) != 1 {
+		t.Fatalf("unexpected drift: %+v", drift)
+	}
+	if !strings.Contains(logs.String(), `schema of source \"my-source\" drifted from its snapshot`) {
+		t.Errorf("expected a drift warning, got logs: %s", logs.String())
+	}
+
+	// updating the snapshot accepts the drift
+	report, err = Check(WithUpdate(ctx, true), "my-source", cfg, PostgresQuery, run)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if report.SchemaDrift() != nil {
+		t.Errorf("expected no drift when updating the snapshot")
+	}
+	report, err = Check(ctx, "my-source", cfg, PostgresQuery, run)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if report.SchemaDrift() != nil {
+		t.Errorf("expected no drift after updating the snapshot, got %+v", report.SchemaDrift())
+	}
+
+	if report, err := Check(ctx, "my-source", nil, PostgresQuery, run); err != nil || report.SchemaDrift() != nil {
+		t.Errorf("expected a nil config to disable the check, got %+v, %v", report.SchemaDrift(), err)
+	}
+}
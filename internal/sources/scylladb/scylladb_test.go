@@ -135,7 +135,7 @@ func TestFailParseFromYaml(t *testing.T) {
 			name: my-scylladb-instance
 			type: scylladb
 			`,
-			err: "error unmarshaling source: unable to parse source \"my-scylladb-instance\" as \"scylladb\": Key: 'Config.Hosts' Error:Field validation for 'Hosts' failed on the 'required' tag",
+			err: "error unmarshaling source: unable to parse source \"my-scylladb-instance\" as \"scylladb\": sources[my-scylladb-instance].hosts is required; add a \"hosts\" field",
 		},
 	}
 
@@ -0,0 +1,82 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secrets
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"sync"
+
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"google.golang.org/api/option"
+	secretmanagerapi "google.golang.org/api/secretmanager/v1"
+)
+
+// SecretManagerScheme is the scheme of the references to the secrets of
+// Google Cloud Secret Manager, named by their version, as in
+// ${secretmanager:projects/p/secrets/s/versions/latest}.
+const SecretManagerScheme = "secretmanager"
+
+func init() {
+	if !Register(SecretManagerScheme, &secretManager{}) {
+		panic(fmt.Sprintf("secret scheme %q already registered", SecretManagerScheme))
+	}
+}
+
+// secretManager resolves secrets with the Secret Manager API, authenticated
+// with the Application Default Credentials.
+type secretManager struct {
+	mu      sync.Mutex
+	service *secretmanagerapi.Service
+}
+
+func (m *secretManager) client(ctx context.Context) (*secretmanagerapi.Service, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.service != nil {
+		return m.service, nil
+	}
+	var opts []option.ClientOption
+	if userAgent, err := util.UserAgentFromContext(ctx); err == nil {
+		opts = append(opts, option.WithUserAgent(userAgent))
+	}
+	// the client outlives the context of the source it is first created for
+	service, err := secretmanagerapi.NewService(context.WithoutCancel(ctx), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create Secret Manager client: %w", err)
+	}
+	m.service = service
+	return service, nil
+}
+
+func (m *secretManager) Resolve(ctx context.Context, name string) (string, error) {
+	service, err := m.client(ctx)
+	if err != nil {
+		return "", err
+	}
+	resp, err := service.Projects.Secrets.Versions.Access(name).Context(ctx).Do()
+	if err != nil {
+		return "", err
+	}
+	if resp.Payload == nil {
+		return "", fmt.Errorf("secret version %q has no payload", name)
+	}
+	data, err := base64.StdEncoding.DecodeString(resp.Payload.Data)
+	if err != nil {
+		return "", fmt.Errorf("unable to decode secret version %q: %w", name, err)
+	}
+	return string(data), nil
+}
@@ -0,0 +1,215 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package secrets resolves the references to secrets in source configs, such
+// as ${secretmanager:projects/p/secrets/s/versions/latest}, so that
+// credentials need not be written in the configuration files.
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultRefreshInterval is how often the secrets of the sources are resolved
+// again, to pick up rotated credentials.
+const DefaultRefreshInterval = 10 * time.Minute
+
+// Resolver resolves the secrets of a scheme.
+type Resolver interface {
+	// Resolve returns the value of the secret of the given name, which is
+	// the part of the reference following the scheme.
+	Resolve(ctx context.Context, name string) (string, error)
+}
+
+var (
+	mu        sync.RWMutex
+	resolvers = make(map[string]Resolver)
+)
+
+// Register registers the resolver of the references of a scheme. It returns
+// false if the scheme is already registered.
+func Register(scheme string, r Resolver) bool {
+	mu.Lock()
+	defer mu.Unlock()
+	if _, exists := resolvers[scheme]; exists {
+		return false
+	}
+	resolvers[scheme] = r
+	return true
+}
+
+// IsScheme reports whether name is the scheme of a registered resolver, for
+// the parsing of environment variables to leave its references alone.
+func IsScheme(name string) bool {
+	_, ok := lookup(name)
+	return ok
+}
+
+func lookup(scheme string) (Resolver, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	r, ok := resolvers[scheme]
+	return r, ok
+}
+
+// refPattern matches a reference to a secret, ${scheme:name}.
+var refPattern = regexp.MustCompile(`\$\{(\w+):([^}]+)\}`)
+
+// HasRefs reports whether a string of v, such as a field of a config struct,
+// references a secret of a registered scheme.
+func HasRefs(v any) bool {
+	found := false
+	walkStrings(reflect.ValueOf(v), func(s string) {
+		for _, m := range refPattern.FindAllStringSubmatch(s, -1) {
+			if IsScheme(m[1]) {
+				found = true
+			}
+		}
+	})
+	return found
+}
+
+// Resolve returns a copy of v in which the references to secrets in its
+// strings are replaced by the values of the secrets. v is returned as is if
+// it references none.
+func Resolve[T any](ctx context.Context, v T) (T, error) {
+	if !HasRefs(v) {
+		return v, nil
+	}
+	r := &refResolver{ctx: ctx}
+	resolved := r.value(reflect.ValueOf(&v).Elem())
+	if r.err != nil {
+		var zero T
+		return zero, r.err
+	}
+	return resolved.Interface().(T), nil
+}
+
+// refResolver copies values, resolving the references in their strings. The
+// first error is kept in err.
+type refResolver struct {
+	ctx context.Context
+	err error
+}
+
+func (r *refResolver) string(s string) string {
+	return refPattern.ReplaceAllStringFunc(s, func(ref string) string {
+		m := refPattern.FindStringSubmatch(ref)
+		resolver, ok := lookup(m[1])
+		if !ok || r.err != nil {
+			return ref
+		}
+		secret, err := resolver.Resolve(r.ctx, m[2])
+		if err != nil {
+			r.err = fmt.Errorf("unable to resolve secret %q: %w", strings.Trim(ref, "${}"), err)
+			return ref
+		}
+		return secret
+	})
+}
+
+// value returns a copy of v with its strings resolved. Unexported fields of
+// structs are copied as is.
+func (r *refResolver) value(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.String:
+		nv := reflect.New(v.Type()).Elem()
+		nv.SetString(r.string(v.String()))
+		return nv
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		nv := reflect.New(v.Type().Elem())
+		nv.Elem().Set(r.value(v.Elem()))
+		return nv
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		nv := reflect.New(v.Type()).Elem()
+		nv.Set(r.value(v.Elem()))
+		return nv
+	case reflect.Struct:
+		nv := reflect.New(v.Type()).Elem()
+		nv.Set(v)
+		for i := range v.NumField() {
+			if v.Type().Field(i).IsExported() {
+				nv.Field(i).Set(r.value(v.Field(i)))
+			}
+		}
+		return nv
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		nv := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := range v.Len() {
+			nv.Index(i).Set(r.value(v.Index(i)))
+		}
+		return nv
+	case reflect.Array:
+		nv := reflect.New(v.Type()).Elem()
+		for i := range v.Len() {
+			nv.Index(i).Set(r.value(v.Index(i)))
+		}
+		return nv
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		nv := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			nv.SetMapIndex(iter.Key(), r.value(iter.Value()))
+		}
+		return nv
+	default:
+		return v
+	}
+}
+
+// walkStrings calls f with the strings of v, following the exported fields
+// of structs, pointers, interfaces, slices and the values of maps.
+func walkStrings(v reflect.Value, f func(string)) {
+	switch v.Kind() {
+	case reflect.String:
+		f(v.String())
+	case reflect.Pointer, reflect.Interface:
+		if !v.IsNil() {
+			walkStrings(v.Elem(), f)
+		}
+	case reflect.Struct:
+		for i := range v.NumField() {
+			if v.Type().Field(i).IsExported() {
+				walkStrings(v.Field(i), f)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := range v.Len() {
+			walkStrings(v.Index(i), f)
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			walkStrings(iter.Value(), f)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// fakeResolver resolves the secrets of its map, counting the calls.
+type fakeResolver struct {
+	values map[string]string
+	calls  int
+}
+
+func (r *fakeResolver) Resolve(_ context.Context, name string) (string, error) {
+	r.calls++
+	v, ok := r.values[name]
+	if !ok {
+		return "", fmt.Errorf("secret %q not found", name)
+	}
+	return v, nil
+}
+
+type nested struct {
+	Headers map[string]string
+	Hosts   []string
+}
+
+type config struct {
+	Name     string
+	Password string
+	Token    *string
+	Nested   nested
+	Extra    any
+	hidden   string
+}
+
+func TestResolve(t *testing.T) {
+	fake := &fakeResolver{values: map[string]string{
+		"db/password": "s3cr3t",
+		"db/token":    "t0k3n",
+		"api/key":     "k3y",
+	}}
+	if !Register("fake", fake) {
+		t.Fatalf("scheme already registered")
+	}
+	if Register("fake", fake) {
+		t.Errorf("expected a second registration to be refused")
+	}
+
+	token := "${fake:db/token}"
+	cfg := config{
+		Name:     "my-source",
+		Password: "${fake:db/password}",
+		Token:    &token,
+		Nested: nested{
+			Headers: map[string]string{"Authorization": "Bearer ${fake:api/key}"},
+			Hosts:   []string{"${unknown:a}", "host-b"},
+		},
+		Extra:  []any{"${fake:api/key}"},
+		hidden: "${fake:db/password}",
+	}
+	if !HasRefs(cfg) {
+		t.Fatalf("expected the config to reference secrets")
+	}
+	got, err := Resolve(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	resolvedToken := "t0k3n"
+	want := config{
+		Name:     "my-source",
+		Password: "s3cr3t",
+		Token:    &resolvedToken,
+		Nested: nested{
+			Headers: map[string]string{"Authorization": "Bearer k3y"},
+			Hosts:   []string{"${unknown:a}", "host-b"},
+		},
+		Extra:  []any{"k3y"},
+		hidden: "${fake:db/password}",
+	}
+	if diff := cmp.Diff(want, got, cmp.AllowUnexported(config{})); diff != "" {
+		t.Errorf("unexpected config (-want +got):\n%s", diff)
+	}
+	// the original is left untouched
+	if cfg.Password != "${fake:db/password}" || token != "${fake:db/token}" || cfg.Nested.Headers["Authorization"] != "Bearer ${fake:api/key}" {
+		t.Errorf("the original config was modified: %+v", cfg)
+	}
+
+	calls := fake.calls
+	plain := config{Name: "plain", Nested: nested{Hosts: []string{"${unknown:a}"}}}
+	if HasRefs(plain) {
+		t.Errorf("expected references of unknown schemes to be ignored")
+	}
+	if _, err := Resolve(context.Background(), plain); err != nil || fake.calls != calls {
+		t.Errorf("expected a config without references to be returned as is, got err %v", err)
+	}
+
+	_, err = Resolve(context.Background(), config{Password: "${fake:missing}"})
+	if err == nil || err.Error() != `unable to resolve secret "fake:missing": secret "missing" not found` {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
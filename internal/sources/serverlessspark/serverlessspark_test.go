@@ -90,7 +90,7 @@ func TestFailParseFromYaml(t *testing.T) {
 				type: serverless-spark
 				location: my-location
 			`,
-			err: "error unmarshaling source: unable to parse source \"my-instance\" as \"serverless-spark\": Key: 'Config.Project' Error:Field validation for 'Project' failed on the 'required' tag",
+			err: "error unmarshaling source: unable to parse source \"my-instance\" as \"serverless-spark\": sources[my-instance].project is required; add a \"project\" field",
 		},
 		{
 			desc: "missing required field location",
@@ -100,7 +100,7 @@ func TestFailParseFromYaml(t *testing.T) {
 				type: serverless-spark
 				project: my-project
 			`,
-			err: "error unmarshaling source: unable to parse source \"my-instance\" as \"serverless-spark\": Key: 'Config.Location' Error:Field validation for 'Location' failed on the 'required' tag",
+			err: "error unmarshaling source: unable to parse source \"my-instance\" as \"serverless-spark\": sources[my-instance].location is required; add a \"location\" field",
 		},
 	}
 	for _, tc := range tcs {
@@ -0,0 +1,128 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sessionrole runs the queries of a PostgreSQL source as a database
+// role mapped from the end user authenticated by the tool invoked, so that
+// row level security and grants see the end user rather than the shared
+// account of the source.
+package sessionrole
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// defaultClaim is the claim naming the role when the config does not set one.
+const defaultClaim = "email"
+
+// Config is the impersonation field of a PostgreSQL source, mapping the
+// principals of an auth service to database roles.
+type Config struct {
+	// AuthService is the auth service authenticating the principals.
+	AuthService string `yaml:"authService" validate:"required"`
+	// Claim is the claim of the token identifying the principal. Defaults to
+	// "email".
+	Claim string `yaml:"claim"`
+	// Roles maps the values of the claim to database roles. When unset, the
+	// value of the claim is the role.
+	Roles map[string]string `yaml:"roles"`
+}
+
+// Role returns the role to run the queries of ctx as. Contexts that are not
+// tool invocations, such as the health checks of the source, carry no claims
+// and run as the user of the source, returning "". It fails for invocations
+// not authenticated by the auth service of c, rather than running them as the
+// user of the source, and if the principal has no role.
+func (c *Config) Role(ctx context.Context) (string, error) {
+	invocationClaims := util.AuthServiceClaimsFromContext(ctx)
+	if invocationClaims == nil {
+		return "", nil
+	}
+	claims, ok := invocationClaims[c.AuthService]
+	if !ok {
+		return "", fmt.Errorf("the invocation must be authenticated by auth service %q to impersonate its principal", c.AuthService)
+	}
+	claim := c.Claim
+	if claim == "" {
+		claim = defaultClaim
+	}
+	principal, _ := claims[claim].(string)
+	if principal == "" {
+		return "", fmt.Errorf("the token of auth service %q has no %q claim to impersonate", c.AuthService, claim)
+	}
+	if c.Roles == nil {
+		return principal, nil
+	}
+	role, ok := c.Roles[principal]
+	if !ok {
+		return "", fmt.Errorf("no database role is mapped to %q", principal)
+	}
+	return role, nil
+}
+
+// Apply makes the connections of config switch to the role of the context
+// they are acquired with, and back to the user of the source for contexts
+// without one. Switching costs a round trip whenever a connection changes
+// roles. A nil config leaves config unchanged.
+func (c *Config) Apply(config *pgxpool.Config) {
+	if c == nil {
+		return
+	}
+	// roles holds the role each connection was switched to.
+	var roles sync.Map
+
+	prepare := config.PrepareConn
+	config.PrepareConn = func(ctx context.Context, conn *pgx.Conn) (bool, error) {
+		if prepare != nil {
+			if ok, err := prepare(ctx, conn); !ok || err != nil {
+				return ok, err
+			}
+		}
+		role, err := c.Role(ctx)
+		if err != nil {
+			return true, err
+		}
+		current, _ := roles.Load(conn)
+		if current == nil {
+			current = ""
+		}
+		if current == role {
+			return true, nil
+		}
+		statement := "RESET ROLE"
+		if role != "" {
+			statement = "SET ROLE " + pgx.Identifier{role}.Sanitize()
+		}
+		if _, err := conn.Exec(ctx, statement); err != nil {
+			// the role of the connection is unknown, so it is not reused
+			roles.Delete(conn)
+			return false, fmt.Errorf("unable to switch to role %q: %w", role, err)
+		}
+		roles.Store(conn, role)
+		return true, nil
+	}
+
+	beforeClose := config.BeforeClose
+	config.BeforeClose = func(conn *pgx.Conn) {
+		roles.Delete(conn)
+		if beforeClose != nil {
+			beforeClose(conn)
+		}
+	}
+}
@@ -190,7 +190,7 @@ func TestFailParseFromYaml(t *testing.T) {
 			user: my_user
 			password: my_pass
 			`,
-			err: "error unmarshaling source: unable to parse source \"my-s2-instance\" as \"singlestore\": Key: 'Config.Host' Error:Field validation for 'Host' failed on the 'required' tag",
+			err: "error unmarshaling source: unable to parse source \"my-s2-instance\" as \"singlestore\": sources[my-s2-instance].host is required; add a \"host\" field",
 		},
 	}
 	for _, tc := range tcs {
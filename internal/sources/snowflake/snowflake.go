@@ -16,12 +16,20 @@ package snowflake
 
 import (
 	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
 	"fmt"
+	"net/url"
+	"os"
 
 	"github.com/goccy/go-yaml"
 	"github.com/googleapis/mcp-toolbox/internal/sources"
 	"github.com/jmoiron/sqlx"
 	_ "github.com/snowflakedb/gosnowflake/v2"
+	"github.com/youmark/pkcs8"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -31,7 +39,7 @@ const SourceType string = "snowflake"
 var _ sources.SourceConfig = Config{}
 
 func init() {
-	if !sources.Register(SourceType, newConfig) {
+	if !sources.Register[*Source](SourceType, newConfig) {
 		panic(fmt.Sprintf("source type %q already registered", SourceType))
 	}
 }
@@ -41,19 +49,96 @@ func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources
 	if err := decoder.DecodeContext(ctx, &actual); err != nil {
 		return nil, err
 	}
+	if err := actual.validateAuth(); err != nil {
+		return nil, err
+	}
 	return actual, nil
 }
 
+// validateAuth checks that the source authenticates either with a password
+// or with a valid private key.
+func (r Config) validateAuth() error {
+	set := 0
+	for _, v := range []string{r.Password, r.PrivateKey, r.PrivateKeyFile} {
+		if v != "" {
+			set++
+		}
+	}
+	switch {
+	case set == 0:
+		return errors.New("one of password, privateKey or privateKeyFile is required")
+	case set > 1:
+		return errors.New("only one of password, privateKey or privateKeyFile can be set")
+	case r.PrivateKeyPassphrase != "" && r.Password != "":
+		return errors.New("privateKeyPassphrase requires privateKey or privateKeyFile to be set")
+	}
+	if r.Password == "" {
+		if _, err := r.privateKey(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// privateKey parses the RSA private key of key-pair authentication, from a
+// PKCS #1 or PKCS #8 PEM block. Encrypted PKCS #8 keys are decrypted with
+// privateKeyPassphrase.
+func (r Config) privateKey() (*rsa.PrivateKey, error) {
+	field, data := "privateKey", []byte(r.PrivateKey)
+	if r.PrivateKeyFile != "" {
+		field = "privateKeyFile"
+		var err error
+		if data, err = os.ReadFile(r.PrivateKeyFile); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", field, err)
+		}
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("invalid %s: no PEM private key", field)
+	}
+	var key any
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "ENCRYPTED PRIVATE KEY":
+		if r.PrivateKeyPassphrase == "" {
+			return nil, fmt.Errorf("invalid %s: the key is encrypted, set privateKeyPassphrase", field)
+		}
+		key, err = pkcs8.ParsePKCS8PrivateKey(block.Bytes, []byte(r.PrivateKeyPassphrase))
+	default:
+		return nil, fmt.Errorf("invalid %s: unsupported PEM block %q", field, block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", field, err)
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("invalid %s: Snowflake key-pair authentication requires an RSA key, got %T", field, key)
+	}
+	return rsaKey, nil
+}
+
 type Config struct {
 	Name      string `yaml:"name" validate:"required"`
 	Type      string `yaml:"type" validate:"required"`
 	Account   string `yaml:"account" validate:"required"`
 	User      string `yaml:"user" validate:"required"`
-	Password  string `yaml:"password" validate:"required"`
+	Password  string `yaml:"password"`
 	Database  string `yaml:"database" validate:"required"`
 	Schema    string `yaml:"schema" validate:"required"`
 	Warehouse string `yaml:"warehouse"`
 	Role      string `yaml:"role"`
+	// PrivateKey is the PEM encoded RSA private key of key-pair
+	// authentication, used instead of a password.
+	PrivateKey string `yaml:"privateKey"`
+	// PrivateKeyFile is the path of a PEM file holding the private key, as an
+	// alternative to PrivateKey.
+	PrivateKeyFile string `yaml:"privateKeyFile"`
+	// PrivateKeyPassphrase decrypts an encrypted PKCS #8 private key.
+	PrivateKeyPassphrase string `yaml:"privateKeyPassphrase"`
 }
 
 func (r Config) SourceConfigType() string {
@@ -61,7 +146,11 @@ func (r Config) SourceConfigType() string {
 }
 
 func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
-	db, err := initSnowflakeConnection(ctx, tracer, r.Name, r.Account, r.User, r.Password, r.Database, r.Schema, r.Warehouse, r.Role)
+	dsn, err := r.dsn()
+	if err != nil {
+		return nil, err
+	}
+	db, err := initSnowflakeConnection(ctx, tracer, r.Name, dsn)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create connection: %w", err)
 	}
@@ -135,21 +224,45 @@ func (s *Source) RunSQL(ctx context.Context, statement string, params []any) (an
 	return out, nil
 }
 
-func initSnowflakeConnection(ctx context.Context, tracer trace.Tracer, name, account, user, password, database, schema, warehouse, role string) (*sqlx.DB, error) {
-	//nolint:all // Reassigned ctx
-	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceType, name)
-	defer span.End()
-
+// dsn returns the Snowflake DSN of the source, in the format
+// user[:password]@account/database/schema?warehouse=warehouse&role=role. With
+// key-pair authentication, the private key is passed as the base64url
+// encoding of its unencrypted PKCS #8 form instead of a password.
+func (r Config) dsn() (string, error) {
 	// Set defaults for optional parameters
+	warehouse, role := r.Warehouse, r.Role
 	if warehouse == "" {
 		warehouse = "COMPUTE_WH"
 	}
 	if role == "" {
 		role = "ACCOUNTADMIN"
 	}
+	query := url.Values{}
+	query.Set("warehouse", warehouse)
+	query.Set("role", role)
+
+	userinfo := r.User + ":" + r.Password
+	if r.Password == "" {
+		key, err := r.privateKey()
+		if err != nil {
+			return "", err
+		}
+		der, err := x509.MarshalPKCS8PrivateKey(key)
+		if err != nil {
+			return "", fmt.Errorf("unable to encode private key: %w", err)
+		}
+		userinfo = r.User
+		query.Set("authenticator", "SNOWFLAKE_JWT")
+		query.Set("privateKey", base64.URLEncoding.EncodeToString(der))
+	}
+	return fmt.Sprintf("%s@%s/%s/%s?%s", userinfo, r.Account, r.Database, r.Schema, query.Encode()), nil
+}
+
+func initSnowflakeConnection(ctx context.Context, tracer trace.Tracer, name, dsn string) (*sqlx.DB, error) {
+	//nolint:all // Reassigned ctx
+	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceType, name)
+	defer span.End()
 
-	// Snowflake DSN format: user:password@account/database/schema?warehouse=warehouse&role=role
-	dsn := fmt.Sprintf("%s:%s@%s/%s/%s?warehouse=%s&role=%s", user, password, account, database, schema, warehouse, role)
 	db, err := sqlx.ConnectContext(ctx, "snowflake", dsn)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create connection: %w", err)
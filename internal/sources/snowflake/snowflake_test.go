@@ -16,6 +16,15 @@ package snowflake_test
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -72,6 +81,97 @@ func TestParseFromYamlSnowflake(t *testing.T) {
 
 }
 
+// writeKeyFile writes key as a PKCS #8 PEM file and returns its path.
+func writeKeyFile(t *testing.T, key any) string {
+	t.Helper()
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("unable to marshal key: %s", err)
+	}
+	path := filepath.Join(t.TempDir(), "rsa_key.p8")
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0600); err != nil {
+		t.Fatalf("unable to write key: %s", err)
+	}
+	return path
+}
+
+func TestParseFromYamlSnowflakeKeyPair(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("unable to generate key: %s", err)
+	}
+	keyFile := writeKeyFile(t, key)
+	in := `
+		kind: source
+		name: my-snowflake-instance
+		type: snowflake
+		account: my-account
+		user: my_user
+		privateKeyFile: ` + keyFile + `
+		database: my_db
+		schema: my_schema
+		warehouse: my_wh
+		role: ANALYST
+	`
+	want := server.SourceConfigs{
+		"my-snowflake-instance": snowflake.Config{
+			Name:           "my-snowflake-instance",
+			Type:           snowflake.SourceType,
+			Account:        "my-account",
+			User:           "my_user",
+			PrivateKeyFile: keyFile,
+			Database:       "my_db",
+			Schema:         "my_schema",
+			Warehouse:      "my_wh",
+			Role:           "ANALYST",
+		},
+	}
+	got, _, _, _, _, _, err := server.UnmarshalPrimitiveConfig(context.Background(), testutils.FormatYaml(in))
+	if err != nil {
+		t.Fatalf("unable to unmarshal: %s", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect parse (-want +got):\n%s", diff)
+	}
+}
+
+func TestFailParseKeyPair(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unable to generate key: %s", err)
+	}
+	ecKeyFile := writeKeyFile(t, ecKey)
+	notPEM := filepath.Join(t.TempDir(), "key.txt")
+	if err := os.WriteFile(notPEM, []byte("not a key"), 0600); err != nil {
+		t.Fatalf("unable to write key: %s", err)
+	}
+
+	tcs := []struct {
+		desc string
+		auth string
+		err  string
+	}{
+		{desc: "no credentials", auth: "", err: "one of password, privateKey or privateKeyFile is required"},
+		{desc: "password and key", auth: "password: my_pass\nprivateKeyFile: " + ecKeyFile, err: "only one of password, privateKey or privateKeyFile can be set"},
+		{desc: "passphrase with password", auth: "password: my_pass\nprivateKeyPassphrase: secret", err: "privateKeyPassphrase requires privateKey or privateKeyFile to be set"},
+		{desc: "not PEM", auth: "privateKeyFile: " + notPEM, err: "invalid privateKeyFile: no PEM private key"},
+		{desc: "not RSA", auth: "privateKeyFile: " + ecKeyFile, err: "invalid privateKeyFile: Snowflake key-pair authentication requires an RSA key"},
+		{desc: "missing file", auth: "privateKeyFile: /does/not/exist.p8", err: "invalid privateKeyFile: open /does/not/exist.p8"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			in := "kind: source\nname: my-snowflake-instance\ntype: snowflake\naccount: my-account\nuser: my_user\ndatabase: my_db\nschema: my_schema\n" + tc.auth
+			_, _, _, _, _, _, err := server.UnmarshalPrimitiveConfig(context.Background(), []byte(in))
+			if err == nil {
+				t.Fatalf("expect parsing to fail")
+			}
+			if !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("unexpected error: got %q, want it to contain %q", err, tc.err)
+			}
+		})
+	}
+}
+
 func TestFailParseFromYaml(t *testing.T) {
 	tcs := []struct {
 		desc string
@@ -104,7 +204,7 @@ func TestFailParseFromYaml(t *testing.T) {
 				password: my_pass
 				database: my_db
 			`,
-			err: "error unmarshaling source: unable to parse source \"my-snowflake-instance\" as \"snowflake\": Key: 'Config.Schema' Error:Field validation for 'Schema' failed on the 'required' tag",
+			err: "error unmarshaling source: unable to parse source \"my-snowflake-instance\" as \"snowflake\": sources[my-snowflake-instance].schema is required; add a \"schema\" field",
 		},
 	}
 	for _, tc := range tcs {
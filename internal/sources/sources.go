@@ -16,10 +16,12 @@ package sources
 
 import (
 	"context"
-
 	"fmt"
+	"reflect"
+	"slices"
 
 	"github.com/goccy/go-yaml"
+	"github.com/googleapis/mcp-toolbox/internal/telemetry"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 )
@@ -32,17 +34,47 @@ type SourceConfigFactory func(ctx context.Context, name string, decoder *yaml.De
 
 var sourceRegistry = make(map[string]SourceConfigFactory)
 
-// Register registers a new source type with its factory.
+// sourceImplementations holds the Go type of the sources created for each
+// source type, so that tools can check their compatibility at load time.
+var sourceImplementations = make(map[string]reflect.Type)
+
+// Register registers a new source type with its factory. S is the type of
+// the sources the factory's configs initialize, typically *Source.
 // It returns false if the type is already registered.
-func Register(sourceType string, factory SourceConfigFactory) bool {
+func Register[S Source](sourceType string, factory SourceConfigFactory) bool {
 	if _, exists := sourceRegistry[sourceType]; exists {
 		// Source with this type already exists, do not overwrite.
 		return false
 	}
 	sourceRegistry[sourceType] = factory
+	sourceImplementations[sourceType] = reflect.TypeFor[S]()
 	return true
 }
 
+// Implements reports whether the sources of the given type implement the
+// capability interface iface. known is false if the source type is not
+// registered.
+func Implements(sourceType string, iface reflect.Type) (implements, known bool) {
+	impl, ok := sourceImplementations[sourceType]
+	if !ok {
+		return false, false
+	}
+	return impl.Implements(iface), true
+}
+
+// TypesImplementing returns the sorted source types whose sources implement
+// the capability interface iface.
+func TypesImplementing(iface reflect.Type) []string {
+	var types []string
+	for sourceType, impl := range sourceImplementations {
+		if impl.Implements(iface) {
+			types = append(types, sourceType)
+		}
+	}
+	slices.Sort(types)
+	return types
+}
+
 // DecodeConfig decodes a source configuration using the registered factory for the given type.
 func DecodeConfig(ctx context.Context, sourceType string, name string, decoder *yaml.Decoder) (SourceConfig, error) {
 	factory, found := sourceRegistry[sourceType]
@@ -68,6 +100,30 @@ type Source interface {
 	ToConfig() SourceConfig
 }
 
+// Closer is implemented by sources that hold resources, such as connection
+// pools, which are released when the server shuts down.
+type Closer interface {
+	Close() error
+}
+
+// Pinger is implemented by sources that can check their connection to the
+// database, such as for health checks.
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// PoolReporter is implemented by sources that hold a connection pool, to
+// report its statistics.
+type PoolReporter interface {
+	PoolStats() telemetry.PoolStats
+}
+
+// Linker is implemented by sources that refer to other sources, which are
+// resolved once every source is initialized.
+type Linker interface {
+	Link(sourcesMap map[string]Source) error
+}
+
 // InitConnectionSpan adds a span for database pool connection initialization
 func InitConnectionSpan(ctx context.Context, tracer trace.Tracer, sourceType, sourceName string) (context.Context, trace.Span) {
 	ctx, span := tracer.Start(
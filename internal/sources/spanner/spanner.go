@@ -21,6 +21,7 @@ import (
 
 	dataplexapi "cloud.google.com/go/dataplex/apiv1"
 	"cloud.google.com/go/spanner"
+	sppb "cloud.google.com/go/spanner/apiv1/spannerpb"
 	"github.com/goccy/go-yaml"
 	"github.com/googleapis/mcp-toolbox/internal/sources"
 	"github.com/googleapis/mcp-toolbox/internal/sources/dataplex/searchcatalog"
@@ -36,7 +37,7 @@ const SourceType string = "spanner"
 var _ sources.SourceConfig = Config{}
 
 func init() {
-	if !sources.Register(SourceType, newConfig) {
+	if !sources.Register[*Source](SourceType, newConfig) {
 		panic(fmt.Sprintf("source type %q already registered", SourceType))
 	}
 }
@@ -106,6 +107,15 @@ func (s *Source) SpannerClient() *spanner.Client {
 	return s.Client
 }
 
+// Ping checks the connection of the source to the database by running
+// SELECT 1, which every dialect supports.
+func (s *Source) Ping(ctx context.Context) error {
+	iter := s.Client.Single().Query(ctx, spanner.Statement{SQL: "SELECT 1"})
+	defer iter.Stop()
+	_, err := iter.Next()
+	return err
+}
+
 func (s *Source) DatabaseDialect() string {
 	return s.Dialect.String()
 }
@@ -164,6 +174,13 @@ func processRows(iter *spanner.RowIterator) ([]any, error) {
 }
 
 func (s *Source) RunSQL(ctx context.Context, readOnly bool, statement string, params map[string]any) (any, error) {
+	return s.RunSQLWithOptions(ctx, readOnly, statement, params, spanner.StrongRead(), spanner.QueryOptions{})
+}
+
+// RunSQLWithOptions runs a statement with the options of a tool. The bound
+// sets the staleness of read-only statements, and the priority of opts also
+// applies to the commit of read-write ones.
+func (s *Source) RunSQLWithOptions(ctx context.Context, readOnly bool, statement string, params map[string]any, bound spanner.TimestampBound, opts spanner.QueryOptions) (any, error) {
 	var results []any
 	var err error
 	var opErr error
@@ -175,17 +192,18 @@ func (s *Source) RunSQL(ctx context.Context, readOnly bool, statement string, pa
 	}
 
 	if readOnly {
-		iter := s.SpannerClient().Single().Query(ctx, stmt)
+		iter := s.SpannerClient().Single().WithTimestampBound(bound).QueryWithOptions(ctx, stmt, opts)
 		results, opErr = processRows(iter)
 	} else {
-		_, opErr = s.SpannerClient().ReadWriteTransaction(ctx, func(ctx context.Context, txn *spanner.ReadWriteTransaction) error {
-			iter := txn.Query(ctx, stmt)
+		txOpts := spanner.TransactionOptions{CommitPriority: opts.Priority}
+		_, opErr = s.SpannerClient().ReadWriteTransactionWithOptions(ctx, func(ctx context.Context, txn *spanner.ReadWriteTransaction) error {
+			iter := txn.QueryWithOptions(ctx, stmt, opts)
 			results, err = processRows(iter)
 			if err != nil {
 				return err
 			}
 			return nil
-		})
+		}, txOpts)
 	}
 
 	if opErr != nil {
@@ -195,6 +213,73 @@ func (s *Source) RunSQL(ctx context.Context, readOnly bool, statement string, pa
 	return results, nil
 }
 
+// RunGQL runs a Spanner Graph query. GQL queries are read-only and run in a
+// single-use read-only transaction, through the same API as SQL queries.
+// Graph elements and paths, which queries return with SAFE_TO_JSON or
+// TO_JSON, are decoded to objects holding their labels and properties.
+func (s *Source) RunGQL(ctx context.Context, statement string, params map[string]any) (any, error) {
+	return s.RunGQLWithOptions(ctx, statement, params, spanner.StrongRead(), spanner.QueryOptions{})
+}
+
+// RunGQLWithOptions runs a Spanner Graph query with the staleness and query
+// options of a tool.
+func (s *Source) RunGQLWithOptions(ctx context.Context, statement string, params map[string]any, bound spanner.TimestampBound, opts spanner.QueryOptions) (any, error) {
+	if dialect := s.DatabaseDialect(); dialect != "googlesql" {
+		return nil, fmt.Errorf("graph queries require the \"googlesql\" dialect, source %q uses %q", s.Name, dialect)
+	}
+	stmt := spanner.Statement{
+		SQL: statement,
+	}
+	if params != nil {
+		stmt.Params = params
+	}
+	results, err := processGraphRows(s.SpannerClient().Single().WithTimestampBound(bound).QueryWithOptions(ctx, stmt, opts))
+	if err != nil {
+		return nil, fmt.Errorf("unable to execute client: %w", err)
+	}
+	return results, nil
+}
+
+// processGraphRows converts the rows of a graph query to maps, decoding JSON
+// columns.
+func processGraphRows(iter *spanner.RowIterator) ([]any, error) {
+	out := []any{}
+	defer iter.Stop()
+
+	for {
+		row, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse row: %w", err)
+		}
+
+		rowMap := orderedmap.Row{}
+		for i, c := range row.ColumnNames() {
+			var col spanner.GenericColumnValue
+			if err := row.Column(i, &col); err != nil {
+				return nil, fmt.Errorf("unable to parse column %q: %w", c, err)
+			}
+			if col.Type.GetCode() != sppb.TypeCode_JSON {
+				rowMap.Add(c, row.ColumnValue(i))
+				continue
+			}
+			var j spanner.NullJSON
+			if err := col.Decode(&j); err != nil {
+				return nil, fmt.Errorf("unable to decode JSON column %q: %w", c, err)
+			}
+			if !j.Valid {
+				rowMap.Add(c, nil)
+				continue
+			}
+			rowMap.Add(c, j.Value)
+		}
+		out = append(out, rowMap)
+	}
+	return out, nil
+}
+
 func initSpannerClient(ctx context.Context, tracer trace.Tracer, name, project, instance, dbname string) (*spanner.Client, error) {
 	//nolint:all // Reassigned ctx
 	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceType, name)
@@ -153,7 +153,7 @@ func TestFailParseFromYaml(t *testing.T) {
 			project: my-project
 			instance: my-instance
 			`,
-			err: "error unmarshaling source: unable to parse source \"my-spanner-instance\" as \"spanner\": Key: 'Config.Database' Error:Field validation for 'Database' failed on the 'required' tag",
+			err: "error unmarshaling source: unable to parse source \"my-spanner-instance\" as \"spanner\": sources[my-spanner-instance].database is required; add a \"database\" field",
 		},
 	}
 	for _, tc := range tcs {
@@ -19,10 +19,20 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"maps"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/goccy/go-yaml"
 	"github.com/googleapis/mcp-toolbox/internal/sources"
+	"github.com/googleapis/mcp-toolbox/internal/sources/queryguard"
+	"github.com/googleapis/mcp-toolbox/internal/sources/schemadoc"
+	"github.com/googleapis/mcp-toolbox/internal/sources/schemasnapshot"
 	"github.com/googleapis/mcp-toolbox/internal/sources/sqlcommenter"
+	"github.com/googleapis/mcp-toolbox/internal/util"
 	"github.com/googleapis/mcp-toolbox/internal/util/orderedmap"
 	"go.opentelemetry.io/otel/trace"
 	_ "modernc.org/sqlite" // Pure Go SQLite driver
@@ -30,11 +40,18 @@ import (
 
 const SourceType string = "sqlite"
 
+// defaultBusyTimeout is how long a statement waits for a lock held by
+// another connection before failing with SQLITE_BUSY.
+const defaultBusyTimeout = 5 * time.Second
+
+// aliasPattern matches the schema aliases that attached databases may use.
+var aliasPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
 // validate interface
 var _ sources.SourceConfig = Config{}
 
 func init() {
-	if !sources.Register(SourceType, newConfig) {
+	if !sources.Register[*Source](SourceType, newConfig) {
 		panic(fmt.Sprintf("source type %q already registered", SourceType))
 	}
 }
@@ -44,14 +61,93 @@ func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources
 	if err := decoder.DecodeContext(ctx, &actual); err != nil {
 		return nil, err
 	}
+	if _, err := actual.busyTimeout(); err != nil {
+		return nil, err
+	}
+	for alias := range actual.Attach {
+		if !aliasPattern.MatchString(alias) || strings.EqualFold(alias, "main") || strings.EqualFold(alias, "temp") {
+			return nil, fmt.Errorf("invalid attach alias %q: must be an identifier other than \"main\" and \"temp\"", alias)
+		}
+	}
 	return actual, nil
 }
 
 type Config struct {
 	Name         string `yaml:"name" validate:"required"`
 	Type         string `yaml:"type" validate:"required"`
-	Database     string `yaml:"database" validate:"required"` // Path to SQLite database file
+	Database     string `yaml:"database" validate:"required"` // Path to SQLite database file, or ":memory:"
+	ReadOnly     bool   `yaml:"readOnly"`
+	BusyTimeout  string `yaml:"busyTimeout"` // Duration such as "5s", defaults to 5s
 	SQLCommenter *bool  `yaml:"sqlCommenter"`
+	// Attach optionally attaches more database files to every connection,
+	// keyed by the schema alias their tables are qualified with.
+	Attach map[string]string `yaml:"attach"`
+	// InitScripts are SQL script files run in order at startup, such as to
+	// seed a ":memory:" database.
+	InitScripts []string `yaml:"initScripts"`
+	// SchemaSnapshot optionally compares the schema of the database against
+	// a snapshot at startup.
+	SchemaSnapshot *schemasnapshot.Config `yaml:"schemaSnapshot"`
+	// Patterns optionally restrict the statements that tools may run.
+	queryguard.Patterns `yaml:",inline"`
+}
+
+func (r Config) busyTimeout() (time.Duration, error) {
+	if r.BusyTimeout == "" {
+		return defaultBusyTimeout, nil
+	}
+	d, err := time.ParseDuration(r.BusyTimeout)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid busyTimeout %q: must be a non-negative duration such as \"5s\"", r.BusyTimeout)
+	}
+	return d, nil
+}
+
+// dsn returns the data source name of the database, with the pragmas that
+// apply the busy timeout and read-only settings to every connection. With
+// init scripts, the read-only setting is applied once they have run instead.
+func (r Config) dsn() (string, error) {
+	timeout, err := r.busyTimeout()
+	if err != nil {
+		return "", err
+	}
+	pragmas := []string{fmt.Sprintf("_pragma=busy_timeout(%d)", timeout.Milliseconds())}
+	if r.ReadOnly && len(r.InitScripts) == 0 {
+		pragmas = append(pragmas, "_pragma=query_only(1)")
+	}
+	sep := "?"
+	if strings.Contains(r.Database, "?") {
+		sep = "&"
+	}
+	return r.Database + sep + strings.Join(pragmas, "&"), nil
+}
+
+// prepare attaches the databases and runs the init scripts of the source.
+// As the pool has a single connection that is never recycled, they apply to
+// every invocation.
+func (r Config) prepare(ctx context.Context, db *sql.DB) error {
+	aliases := slices.Sorted(maps.Keys(r.Attach))
+	for _, alias := range aliases {
+		// aliases are validated as identifiers when parsed
+		if _, err := db.ExecContext(ctx, fmt.Sprintf("ATTACH DATABASE ? AS %q", alias), r.Attach[alias]); err != nil {
+			return fmt.Errorf("unable to attach %q as %q: %w", r.Attach[alias], alias, err)
+		}
+	}
+	for _, path := range r.InitScripts {
+		script, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("unable to read init script: %w", err)
+		}
+		if _, err := db.ExecContext(ctx, string(script)); err != nil {
+			return fmt.Errorf("unable to run init script %q: %w", path, err)
+		}
+	}
+	if r.ReadOnly && len(r.InitScripts) > 0 {
+		if _, err := db.ExecContext(ctx, "PRAGMA query_only = 1"); err != nil {
+			return fmt.Errorf("unable to make the database read-only: %w", err)
+		}
+	}
+	return nil
 }
 
 func (r Config) SourceConfigType() string {
@@ -59,7 +155,16 @@ func (r Config) SourceConfigType() string {
 }
 
 func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
-	db, err := initSQLiteConnection(ctx, tracer, r.Name, r.Database)
+	guard, err := r.Patterns.Compile(r.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	dsn, err := r.dsn()
+	if err != nil {
+		return nil, err
+	}
+	db, err := initSQLiteConnection(ctx, tracer, r.Name, dsn)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create db connection: %w", err)
 	}
@@ -68,11 +173,20 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 	if err != nil {
 		return nil, fmt.Errorf("unable to connect successfully: %w", err)
 	}
+	if err := r.prepare(ctx, db); err != nil {
+		db.Close()
+		return nil, err
+	}
 
 	s := &Source{
 		Config: r,
 		Db:     db,
 	}
+	s.Guard = guard.WithReadOnly(r.ReadOnly, queryguard.SQLite)
+	s.Report, err = schemasnapshot.Check(ctx, r.Name, r.SchemaSnapshot, schemasnapshot.SQLiteQuery, s.RunSQL)
+	if err != nil {
+		return nil, fmt.Errorf("unable to check schema snapshot: %w", err)
+	}
 	return s, nil
 }
 
@@ -80,6 +194,8 @@ var _ sources.Source = &Source{}
 
 type Source struct {
 	Config
+	schemasnapshot.Report
+	queryguard.Guard
 	Db *sql.DB
 }
 
@@ -95,9 +211,20 @@ func (s *Source) SQLiteDB() *sql.DB {
 	return s.Db
 }
 
+// Close closes the connection pool of the source.
+func (s *Source) Close() error {
+	return s.Db.Close()
+}
+
+// DescribeSchema returns the tables, columns and comments of the source.
+func (s *Source) DescribeSchema(ctx context.Context) (*schemadoc.Schema, error) {
+	return schemadoc.Describe(ctx, schemadoc.SQLiteQuery, s.RunSQL)
+}
+
 func (s *Source) RunSQL(ctx context.Context, statement string, params []any) (any, error) {
 	// Execute the SQL query with parameters
 	statement = sqlcommenter.PrependComment(ctx, statement, SourceType, s.SQLCommenter)
+	util.RecordStatement(ctx, statement, params)
 	rows, err := s.SQLiteDB().QueryContext(ctx, statement, params...)
 	if err != nil {
 		return nil, fmt.Errorf("unable to execute query: %w", err)
@@ -156,20 +283,24 @@ func (s *Source) RunSQL(ctx context.Context, statement string, params []any) (an
 	return out, nil
 }
 
-func initSQLiteConnection(ctx context.Context, tracer trace.Tracer, name, dbPath string) (*sql.DB, error) {
+func initSQLiteConnection(ctx context.Context, tracer trace.Tracer, name, dsn string) (*sql.DB, error) {
 	//nolint:all // Reassigned ctx
 	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceType, name)
 	defer span.End()
 
 	// Open database connection
-	db, err := sql.Open("sqlite", dbPath)
+	db, err := sql.Open("sqlite", dsn)
 	if err != nil {
 		return nil, fmt.Errorf("sql.Open: %w", err)
 	}
 
-	// Set some reasonable defaults for SQLite
-	db.SetMaxOpenConns(1) // SQLite only supports one writer at a time
+	// SQLite only supports one writer at a time. A single connection that is
+	// never recycled also keeps a ":memory:" database, which lives only as
+	// long as its connection, shared by all invocations.
+	db.SetMaxOpenConns(1)
 	db.SetMaxIdleConns(1)
+	db.SetConnMaxLifetime(0)
+	db.SetConnMaxIdleTime(0)
 
 	return db, nil
 }
@@ -16,6 +16,8 @@ package sqlite_test
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -23,6 +25,8 @@ import (
 	"github.com/googleapis/mcp-toolbox/internal/sources"
 	"github.com/googleapis/mcp-toolbox/internal/sources/sqlite"
 	"github.com/googleapis/mcp-toolbox/internal/testutils"
+	"github.com/googleapis/mcp-toolbox/internal/util/orderedmap"
+	"go.opentelemetry.io/otel/trace/noop"
 )
 
 func TestParseFromYamlSQLite(t *testing.T) {
@@ -47,6 +51,50 @@ func TestParseFromYamlSQLite(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "read-only with busy timeout",
+			in: `
+            kind: source
+            name: my-sqlite-db
+            type: sqlite
+            database: /path/to/database.db
+            readOnly: true
+            busyTimeout: 10s
+            `,
+			want: map[string]sources.SourceConfig{
+				"my-sqlite-db": sqlite.Config{
+					Name:        "my-sqlite-db",
+					Type:        sqlite.SourceType,
+					Database:    "/path/to/database.db",
+					ReadOnly:    true,
+					BusyTimeout: "10s",
+				},
+			},
+		},
+		{
+			desc: "attached databases and init scripts",
+			in: `
+            kind: source
+            name: my-sqlite-db
+            type: sqlite
+            database: ":memory:"
+            attach:
+              sales: /path/to/sales.db
+              hr: /path/to/hr.db
+            initScripts:
+              - schema.sql
+              - fixtures.sql
+            `,
+			want: map[string]sources.SourceConfig{
+				"my-sqlite-db": sqlite.Config{
+					Name:        "my-sqlite-db",
+					Type:        sqlite.SourceType,
+					Database:    ":memory:",
+					Attach:      map[string]string{"sales": "/path/to/sales.db", "hr": "/path/to/hr.db"},
+					InitScripts: []string{"schema.sql", "fixtures.sql"},
+				},
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -85,7 +133,30 @@ func TestFailParseFromYaml(t *testing.T) {
             name: my-sqlite-db
             type: sqlite
             `,
-			err: "error unmarshaling source: unable to parse source \"my-sqlite-db\" as \"sqlite\": Key: 'Config.Database' Error:Field validation for 'Database' failed on the 'required' tag",
+			err: "error unmarshaling source: unable to parse source \"my-sqlite-db\" as \"sqlite\": sources[my-sqlite-db].database is required; add a \"database\" field",
+		},
+		{
+			desc: "invalid busy timeout",
+			in: `
+            kind: source
+            name: my-sqlite-db
+            type: sqlite
+            database: /path/to/database.db
+            busyTimeout: soon
+            `,
+			err: "error unmarshaling source: unable to parse source \"my-sqlite-db\" as \"sqlite\": invalid busyTimeout \"soon\": must be a non-negative duration such as \"5s\"",
+		},
+		{
+			desc: "invalid attach alias",
+			in: `
+            kind: source
+            name: my-sqlite-db
+            type: sqlite
+            database: /path/to/database.db
+            attach:
+              main: /path/to/other.db
+            `,
+			err: "error unmarshaling source: unable to parse source \"my-sqlite-db\" as \"sqlite\": invalid attach alias \"main\": must be an identifier other than \"main\" and \"temp\"",
 		},
 	}
 	for _, tc := range tcs {
@@ -101,3 +172,97 @@ func TestFailParseFromYaml(t *testing.T) {
 		})
 	}
 }
+
+func initSource(t *testing.T, cfg sqlite.Config) *sqlite.Source {
+	t.Helper()
+	s, err := cfg.Initialize(context.Background(), noop.NewTracerProvider().Tracer("test"))
+	if err != nil {
+		t.Fatalf("unable to initialize source: %s", err)
+	}
+	t.Cleanup(func() {
+		_ = s.(*sqlite.Source).Close()
+	})
+	return s.(*sqlite.Source)
+}
+
+func TestMemoryDatabaseIsShared(t *testing.T) {
+	ctx := context.Background()
+	s := initSource(t, sqlite.Config{Name: "my-sqlite-db", Type: sqlite.SourceType, Database: ":memory:"})
+
+	if _, err := s.RunSQL(ctx, "CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT)", nil); err != nil {
+		t.Fatalf("unable to create table: %s", err)
+	}
+	if _, err := s.RunSQL(ctx, "INSERT INTO users (name) VALUES (?)", []any{"Alice"}); err != nil {
+		t.Fatalf("unable to insert row: %s", err)
+	}
+	got, err := s.RunSQL(ctx, "SELECT name FROM users", nil)
+	if err != nil {
+		t.Fatalf("unable to query: %s", err)
+	}
+	want := []any{orderedmap.Row{Columns: []orderedmap.Column{{Name: "name", Value: "Alice"}}}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected rows (-want +got):\n%s", diff)
+	}
+}
+
+func TestReadOnly(t *testing.T) {
+	ctx := context.Background()
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+	rw := initSource(t, sqlite.Config{Name: "rw", Type: sqlite.SourceType, Database: dbPath})
+	if _, err := rw.RunSQL(ctx, "CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT)", nil); err != nil {
+		t.Fatalf("unable to create table: %s", err)
+	}
+
+	ro := initSource(t, sqlite.Config{Name: "ro", Type: sqlite.SourceType, Database: dbPath, ReadOnly: true, BusyTimeout: "1s"})
+	if _, err := ro.RunSQL(ctx, "SELECT * FROM users", nil); err != nil {
+		t.Fatalf("unexpected error reading from a read-only source: %s", err)
+	}
+	if _, err := ro.RunSQL(ctx, "INSERT INTO users (name) VALUES ('Bob')", nil); err == nil {
+		t.Fatalf("expected writing to a read-only source to fail")
+	}
+}
+
+func TestAttachAndInitScripts(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	salesPath := filepath.Join(dir, "sales.db")
+	sales := initSource(t, sqlite.Config{Name: "sales", Type: sqlite.SourceType, Database: salesPath})
+	if _, err := sales.RunSQL(ctx, "CREATE TABLE orders (id INTEGER PRIMARY KEY, user_id INTEGER)", nil); err != nil {
+		t.Fatalf("unable to create table: %s", err)
+	}
+	if _, err := sales.RunSQL(ctx, "INSERT INTO orders (user_id) VALUES (1), (1)", nil); err != nil {
+		t.Fatalf("unable to insert rows: %s", err)
+	}
+
+	script := filepath.Join(dir, "fixtures.sql")
+	fixtures := "CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT);\nINSERT INTO users (name) VALUES ('Alice');\n"
+	if err := os.WriteFile(script, []byte(fixtures), 0o600); err != nil {
+		t.Fatalf("unable to write script: %s", err)
+	}
+
+	s := initSource(t, sqlite.Config{
+		Name:        "my-sqlite-db",
+		Type:        sqlite.SourceType,
+		Database:    ":memory:",
+		ReadOnly:    true,
+		Attach:      map[string]string{"sales": salesPath},
+		InitScripts: []string{script},
+	})
+	got, err := s.RunSQL(ctx, "SELECT u.name, COUNT(*) AS orders FROM users u JOIN sales.orders o ON o.user_id = u.id GROUP BY u.name", nil)
+	if err != nil {
+		t.Fatalf("unable to query: %s", err)
+	}
+	want := []any{orderedmap.Row{Columns: []orderedmap.Column{{Name: "name", Value: "Alice"}, {Name: "orders", Value: int64(2)}}}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected rows (-want +got):\n%s", diff)
+	}
+	if _, err := s.RunSQL(ctx, "INSERT INTO users (name) VALUES ('Bob')", nil); err == nil {
+		t.Fatalf("expected writing to a read-only source to fail")
+	}
+
+	_, err = sqlite.Config{Name: "bad", Type: sqlite.SourceType, Database: ":memory:", InitScripts: []string{filepath.Join(dir, "missing.sql")}}.
+		Initialize(ctx, noop.NewTracerProvider().Tracer("test"))
+	if err == nil {
+		t.Fatalf("expected a missing init script to fail")
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package statementcache configures the prepared statement and statement
+// description caches that pgx keeps for each connection of a PostgreSQL
+// source.
+package statementcache
+
+import (
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// DefaultCapacity is the capacity of each cache when it is not configured.
+const DefaultCapacity = 512
+
+// Capacities are the statementCacheCapacity and descriptionCacheCapacity options
+// of a PostgreSQL source. Sources embed it inline in their config. A capacity
+// of 0 disables the cache, as connection poolers such as PgBouncer in
+// transaction mode require.
+type Capacities struct {
+	StatementCacheCapacity   *int `yaml:"statementCacheCapacity" validate:"omitempty,gte=0"`
+	DescriptionCacheCapacity *int `yaml:"descriptionCacheCapacity" validate:"omitempty,gte=0"`
+}
+
+func capacity(c *int) int {
+	if c == nil {
+		return DefaultCapacity
+	}
+	return *c
+}
+
+// Validate checks that the query execution mode queryExecMode, if set, does
+// not rely on a disabled cache.
+func (c Capacities) Validate(queryExecMode string) error {
+	switch {
+	case queryExecMode == "cache_statement" && capacity(c.StatementCacheCapacity) == 0:
+		return fmt.Errorf("queryExecMode %q requires statementCacheCapacity to be greater than 0", queryExecMode)
+	case queryExecMode == "cache_describe" && capacity(c.DescriptionCacheCapacity) == 0:
+		return fmt.Errorf("queryExecMode %q requires descriptionCacheCapacity to be greater than 0", queryExecMode)
+	}
+	return nil
+}
+
+// Apply sets the capacities of the caches of config. When the query
+// execution mode of config relies on a disabled cache, it falls back to the
+// mode caching the most that does not.
+func (c Capacities) Apply(config *pgx.ConnConfig) {
+	config.StatementCacheCapacity = capacity(c.StatementCacheCapacity)
+	config.DescriptionCacheCapacity = capacity(c.DescriptionCacheCapacity)
+
+	if config.DefaultQueryExecMode == pgx.QueryExecModeCacheStatement && config.StatementCacheCapacity == 0 {
+		config.DefaultQueryExecMode = pgx.QueryExecModeCacheDescribe
+	}
+	if config.DefaultQueryExecMode == pgx.QueryExecModeCacheDescribe && config.DescriptionCacheCapacity == 0 {
+		config.DefaultQueryExecMode = pgx.QueryExecModeDescribeExec
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statementcache
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func intPtr(i int) *int { return &i }
+
+func TestApply(t *testing.T) {
+	tcs := []struct {
+		desc          string
+		cfg           Capacities
+		mode          pgx.QueryExecMode
+		wantStatement int
+		wantDescribe  int
+		wantMode      pgx.QueryExecMode
+	}{
+		{desc: "defaults", mode: pgx.QueryExecModeCacheStatement, wantStatement: 512, wantDescribe: 512, wantMode: pgx.QueryExecModeCacheStatement},
+		{desc: "capacities", cfg: Capacities{StatementCacheCapacity: intPtr(64), DescriptionCacheCapacity: intPtr(32)}, mode: pgx.QueryExecModeCacheStatement, wantStatement: 64, wantDescribe: 32, wantMode: pgx.QueryExecModeCacheStatement},
+		{desc: "statement cache disabled", cfg: Capacities{StatementCacheCapacity: intPtr(0)}, mode: pgx.QueryExecModeCacheStatement, wantStatement: 0, wantDescribe: 512, wantMode: pgx.QueryExecModeCacheDescribe},
+		{desc: "both caches disabled", cfg: Capacities{StatementCacheCapacity: intPtr(0), DescriptionCacheCapacity: intPtr(0)}, mode: pgx.QueryExecModeCacheStatement, wantStatement: 0, wantDescribe: 0, wantMode: pgx.QueryExecModeDescribeExec},
+		{desc: "mode without cache", cfg: Capacities{StatementCacheCapacity: intPtr(0)}, mode: pgx.QueryExecModeSimpleProtocol, wantStatement: 0, wantDescribe: 512, wantMode: pgx.QueryExecModeSimpleProtocol},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			config := &pgx.ConnConfig{DefaultQueryExecMode: tc.mode}
+			tc.cfg.Apply(config)
+			if config.StatementCacheCapacity != tc.wantStatement || config.DescriptionCacheCapacity != tc.wantDescribe {
+				t.Errorf("unexpected capacities: got %d and %d, want %d and %d", config.StatementCacheCapacity, config.DescriptionCacheCapacity, tc.wantStatement, tc.wantDescribe)
+			}
+			if config.DefaultQueryExecMode != tc.wantMode {
+				t.Errorf("unexpected query exec mode: got %s, want %s", config.DefaultQueryExecMode, tc.wantMode)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	disabled := Capacities{StatementCacheCapacity: intPtr(0), DescriptionCacheCapacity: intPtr(0)}
+	for _, mode := range []string{"", "describe_exec", "exec", "simple_protocol"} {
+		if err := disabled.Validate(mode); err != nil {
+			t.Errorf("unexpected error for mode %q: %s", mode, err)
+		}
+	}
+	for _, mode := range []string{"cache_statement", "cache_describe"} {
+		err := disabled.Validate(mode)
+		if err == nil || !strings.Contains(err.Error(), "requires") {
+			t.Errorf("expected error for mode %q, got %v", mode, err)
+		}
+	}
+	if err := (Capacities{}).Validate("cache_statement"); err != nil {
+		t.Errorf("unexpected error with default capacities: %s", err)
+	}
+}
@@ -156,7 +156,7 @@ func TestFailParseFromYaml(t *testing.T) {
 			password: my_pass
 			ssl: false
 			`,
-			err: "error unmarshaling source: unable to parse source \"my-tidb-instance\" as \"tidb\": Key: 'Config.Host' Error:Field validation for 'Host' failed on the 'required' tag",
+			err: "error unmarshaling source: unable to parse source \"my-tidb-instance\" as \"tidb\": sources[my-tidb-instance].host is required; add a \"host\" field",
 		},
 	}
 	for _, tc := range tcs {
@@ -20,7 +20,9 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"cloud.google.com/go/cloudsqlconn"
 	"golang.org/x/oauth2/google"
@@ -126,3 +128,27 @@ func GetIAMAccessToken(ctx context.Context) (string, error) {
 	}
 	return token.AccessToken, nil
 }
+
+// ParseQueryTimeout returns the queryTimeout of a source, a duration such as
+// "30s", or zero if it is empty.
+func ParseQueryTimeout(queryTimeout string) (time.Duration, error) {
+	if queryTimeout == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(queryTimeout)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid queryTimeout %q: must be a positive duration such as \"30s\"", queryTimeout)
+	}
+	return d, nil
+}
+
+// PostgresStatementTimeout returns the statement_timeout runtime parameter of
+// PostgreSQL, in milliseconds, bounding the statements of the connections of
+// a source with a queryTimeout, or an empty string if it has none.
+func PostgresStatementTimeout(queryTimeout string) (string, error) {
+	d, err := ParseQueryTimeout(queryTimeout)
+	if err != nil || d == 0 {
+		return "", err
+	}
+	return strconv.FormatInt(d.Milliseconds(), 10), nil
+}
@@ -20,6 +20,7 @@ import (
 
 	"github.com/goccy/go-yaml"
 	"github.com/googleapis/mcp-toolbox/internal/sources"
+	"github.com/googleapis/mcp-toolbox/internal/sources/clienttls"
 	"github.com/valkey-io/valkey-go"
 	"go.opentelemetry.io/otel/trace"
 )
@@ -30,7 +31,7 @@ const SourceType string = "valkey"
 var _ sources.SourceConfig = Config{}
 
 func init() {
-	if !sources.Register(SourceType, newConfig) {
+	if !sources.Register[*Source](SourceType, newConfig) {
 		panic(fmt.Sprintf("source type %q already registered", SourceType))
 	}
 }
@@ -52,6 +53,9 @@ type Config struct {
 	Database     int      `yaml:"database"`
 	UseGCPIAM    bool     `yaml:"useGCPIAM"`
 	DisableCache bool     `yaml:"disableCache"`
+	// TLS optionally secures the connections with a custom CA bundle and a
+	// client certificate.
+	TLS *clienttls.Config `yaml:"tls"`
 }
 
 func (r Config) SourceConfigType() string {
@@ -85,6 +89,13 @@ func initValkeyClient(ctx context.Context, r Config) (valkey.Client, error) {
 		}
 	}
 
+	// the server name is left to the dialer, which sets it to the host of
+	// each address
+	tlsConfig, err := r.TLS.ClientConfig("")
+	if err != nil {
+		return nil, err
+	}
+
 	client, err := valkey.NewClient(valkey.ClientOption{
 		InitAddress:       r.Address,
 		SelectDB:          r.Database,
@@ -92,6 +103,7 @@ func initValkeyClient(ctx context.Context, r Config) (valkey.Client, error) {
 		Password:          r.Password,
 		AuthCredentialsFn: authFn,
 		DisableCache:      r.DisableCache,
+		TLSConfig:         tlsConfig,
 	})
 
 	if err != nil {
@@ -135,7 +135,7 @@ func TestFailParseFromYaml(t *testing.T) {
 			name: my-valkey-instance
 			type: valkey
 			`,
-			err: "error unmarshaling source: unable to parse source \"my-valkey-instance\" as \"valkey\": Key: 'Config.Address' Error:Field validation for 'Address' failed on the 'required' tag",
+			err: "error unmarshaling source: unable to parse source \"my-valkey-instance\" as \"valkey\": sources[my-valkey-instance].address is required; add a \"address\" field",
 		},
 	}
 	for _, tc := range tcs {
@@ -20,6 +20,7 @@ import (
 
 	"github.com/goccy/go-yaml"
 	"github.com/googleapis/mcp-toolbox/internal/sources"
+	"github.com/googleapis/mcp-toolbox/internal/sources/pgpool"
 	"github.com/yugabyte/pgx/v5/pgxpool"
 	"go.opentelemetry.io/otel/trace"
 )
@@ -30,7 +31,7 @@ const SourceType string = "yugabytedb"
 var _ sources.SourceConfig = Config{}
 
 func init() {
-	if !sources.Register(SourceType, newConfig) {
+	if !sources.Register[*Source](SourceType, newConfig) {
 		panic(fmt.Sprintf("source type %q already registered", SourceType))
 	}
 }
@@ -56,6 +57,8 @@ type Config struct {
 	YBServersRefreshInterval        string `yaml:"ybServersRefreshInterval"`
 	FallBackToTopologyKeysOnly      string `yaml:"fallbackToTopologyKeysOnly"`
 	FailedHostReconnectDelaySeconds string `yaml:"failedHostReconnectDelaySecs"`
+	// Sizing optionally sizes the connection pool and defers connecting.
+	pgpool.Sizing `yaml:",inline"`
 }
 
 func (r Config) SourceConfigType() string {
@@ -63,14 +66,19 @@ func (r Config) SourceConfigType() string {
 }
 
 func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
-	pool, err := initYugabyteDBConnectionPool(ctx, tracer, r.Name, r.Host, r.Port, r.User, r.Password, r.Database, r.LoadBalance, r.TopologyKeys, r.YBServersRefreshInterval, r.FallBackToTopologyKeysOnly, r.FailedHostReconnectDelaySeconds)
+	if err := r.Sizing.Validate(); err != nil {
+		return nil, err
+	}
+	pool, err := initYugabyteDBConnectionPool(ctx, tracer, r.Name, r.Host, r.Port, r.User, r.Password, r.Database, r.LoadBalance, r.TopologyKeys, r.YBServersRefreshInterval, r.FallBackToTopologyKeysOnly, r.FailedHostReconnectDelaySeconds, r.Sizing)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create pool: %w", err)
 	}
 
-	err = pool.Ping(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("unable to connect successfully: %w", err)
+	if !r.LazyConnect {
+		err = pool.Ping(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to connect successfully: %w", err)
+		}
 	}
 
 	s := &Source{
@@ -99,6 +107,12 @@ func (s *Source) YugabyteDBPool() *pgxpool.Pool {
 	return s.Pool
 }
 
+// Close closes the connection pool of the source.
+func (s *Source) Close() error {
+	s.Pool.Close()
+	return nil
+}
+
 func (s *Source) RunSQL(ctx context.Context, statement string, params []any) (any, error) {
 	results, err := s.YugabyteDBPool().Query(ctx, statement, params...)
 	if err != nil {
@@ -128,7 +142,7 @@ func (s *Source) RunSQL(ctx context.Context, statement string, params []any) (an
 	return out, nil
 }
 
-func initYugabyteDBConnectionPool(ctx context.Context, tracer trace.Tracer, name, host, port, user, pass, dbname, loadBalance, topologyKeys, refreshInterval, explicitFallback, failedHostTTL string) (*pgxpool.Pool, error) {
+func initYugabyteDBConnectionPool(ctx context.Context, tracer trace.Tracer, name, host, port, user, pass, dbname, loadBalance, topologyKeys, refreshInterval, explicitFallback, failedHostTTL string, sizing pgpool.Sizing) (*pgxpool.Pool, error) {
 	//nolint:all // Reassigned ctx
 	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceType, name)
 	defer span.End()
@@ -149,7 +163,26 @@ func initYugabyteDBConnectionPool(ctx context.Context, tracer trace.Tracer, name
 			i = fmt.Sprintf("%s&failed_host_reconnect_delay_secs=%s", i, failedHostTTL)
 		}
 	}
-	pool, err := pgxpool.New(ctx, i)
+	config, err := pgxpool.ParseConfig(i)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse connection uri: %w", err)
+	}
+	// The pool of the YugabyteDB driver is a fork of the pgx one, so the
+	// sizing is applied field by field.
+	if sizing.MaxConns != nil {
+		config.MaxConns = *sizing.MaxConns
+	}
+	if sizing.MinConns != nil {
+		config.MinConns = *sizing.MinConns
+		config.MaxConns = max(config.MaxConns, config.MinConns)
+	}
+	if d := sizing.IdleTime(); d > 0 {
+		config.MaxConnIdleTime = d
+	}
+	if d := sizing.Lifetime(); d > 0 {
+		config.MaxConnLifetime = d
+	}
+	pool, err := pgxpool.NewWithConfig(ctx, config)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create connection pool: %w", err)
 	}
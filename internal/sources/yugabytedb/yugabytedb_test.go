@@ -253,7 +253,7 @@ func TestFailParseFromYamlYugabyteDB(t *testing.T) {
 			database: yb_db
 			user: yb_user
 			`,
-			err: "error unmarshaling source: unable to parse source \"my-yb-source\" as \"yugabytedb\": Key: 'Config.Password' Error:Field validation for 'Password' failed on the 'required' tag",
+			err: "error unmarshaling source: unable to parse source \"my-yb-source\" as \"yugabytedb\": sources[my-yb-source].password is required; add a \"password\" field",
 		},
 		{
 			desc: "missing required field (host)",
@@ -266,7 +266,7 @@ func TestFailParseFromYamlYugabyteDB(t *testing.T) {
 			user: yb_user
 			password: yb_pass
 			`,
-			err: "error unmarshaling source: unable to parse source \"my-yb-source\" as \"yugabytedb\": Key: 'Config.Host' Error:Field validation for 'Host' failed on the 'required' tag",
+			err: "error unmarshaling source: unable to parse source \"my-yb-source\" as \"yugabytedb\": sources[my-yb-source].host is required; add a \"host\" field",
 		},
 	}
 	for _, tc := range tcs {
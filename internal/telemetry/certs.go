@@ -0,0 +1,36 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// ObserveCertExpiry reports the days until expiry returns each time metrics
+// are collected, until the returned registration is unregistered.
+func (i *Instrumentation) ObserveCertExpiry(sourceName, sourceType string, expiry func() time.Time) (metric.Registration, error) {
+	opt := metric.WithAttributes(
+		attribute.String("toolbox.source.name", sourceName),
+		attribute.String("toolbox.source.type", sourceType),
+	)
+	return i.meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		o.ObserveFloat64(i.sslCertExpiry, time.Until(expiry()).Hours()/24, opt)
+		return nil
+	}, i.sslCertExpiry)
+}
@@ -31,6 +31,18 @@ const (
 	mcpSessionDurationName    = "mcp.server.session.duration"
 	mcpActiveSessionsName     = "toolbox.server.mcp.active_sessions"
 	toolExecutionDurationName = "toolbox.tool.execution.duration"
+	invocationQueueDepthName  = "toolbox.server.invocation_queue.depth"
+	poolAcquireDurationName   = "toolbox.source.pool.acquire.duration"
+	poolConnectionsName       = "toolbox.source.pool.connections"
+	poolAcquiresName          = "toolbox.source.pool.acquires"
+	poolCanceledAcquiresName  = "toolbox.source.pool.acquires.canceled"
+	poolAcquireTimeName       = "toolbox.source.pool.acquire.time"
+	sourcePingLatencyName     = "toolbox.source.ping.latency"
+	toolsetInvocationsName    = "toolbox.toolset.invocations"
+	toolsetRowsName           = "toolbox.toolset.rows"
+	toolsetExecutionTimeName  = "toolbox.toolset.execution.time"
+	toolsetBytesBilledName    = "toolbox.toolset.bytes.billed"
+	sslCertExpiryName         = "toolbox.ssl.cert.expiry"
 )
 
 // Instrumentation defines the telemetry instrumentation for toolbox
@@ -41,6 +53,21 @@ type Instrumentation struct {
 	McpSessionDuration    metric.Float64Histogram
 	McpActiveSessions     metric.Int64UpDownCounter
 	ToolExecutionDuration metric.Float64Histogram
+	InvocationQueueDepth  metric.Int64UpDownCounter
+	PoolAcquireDuration   metric.Float64Histogram
+	ToolsetInvocations    metric.Int64Counter
+	ToolsetRows           metric.Int64Counter
+	ToolsetExecutionTime  metric.Float64Counter
+	ToolsetBytesBilled    metric.Int64Counter
+
+	// observed through ObserveSources
+	poolConnections      metric.Int64ObservableGauge
+	poolAcquires         metric.Int64ObservableCounter
+	poolCanceledAcquires metric.Int64ObservableCounter
+	poolAcquireTime      metric.Float64ObservableCounter
+	sourcePingLatency    metric.Float64ObservableGauge
+	// observed through ObserveCertExpiry
+	sslCertExpiry metric.Float64ObservableGauge
 }
 
 func CreateTelemetryInstrumentation(versionString string) (*Instrumentation, error) {
@@ -87,6 +114,114 @@ func CreateTelemetryInstrumentation(versionString string) (*Instrumentation, err
 		return nil, fmt.Errorf("unable to create %s metric: %w", toolExecutionDurationName, err)
 	}
 
+	invocationQueueDepth, err := meter.Int64UpDownCounter(
+		invocationQueueDepthName,
+		metric.WithDescription("Current count of tool invocations admitted to the invocation queue."),
+		metric.WithUnit("{invocation}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create %s metric: %w", invocationQueueDepthName, err)
+	}
+
+	poolAcquireDuration, err := meter.Float64Histogram(
+		poolAcquireDurationName,
+		metric.WithDescription("Time spent waiting to acquire a connection from the pool of a source."),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create %s metric: %w", poolAcquireDurationName, err)
+	}
+
+	toolsetInvocations, err := meter.Int64Counter(
+		toolsetInvocationsName,
+		metric.WithDescription("Count of tool invocations, attributed to the toolset they were invoked through."),
+		metric.WithUnit("{invocation}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create %s metric: %w", toolsetInvocationsName, err)
+	}
+
+	toolsetRows, err := meter.Int64Counter(
+		toolsetRowsName,
+		metric.WithDescription("Count of rows returned by tool invocations, attributed to the toolset they were invoked through."),
+		metric.WithUnit("{row}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create %s metric: %w", toolsetRowsName, err)
+	}
+
+	toolsetExecutionTime, err := meter.Float64Counter(
+		toolsetExecutionTimeName,
+		metric.WithDescription("Cumulative execution time of tool invocations, attributed to the toolset they were invoked through."),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create %s metric: %w", toolsetExecutionTimeName, err)
+	}
+
+	toolsetBytesBilled, err := meter.Int64Counter(
+		toolsetBytesBilledName,
+		metric.WithDescription("Count of the bytes billed by the sources of tool invocations, such as BigQuery, attributed to the toolset they were invoked through."),
+		metric.WithUnit("By"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create %s metric: %w", toolsetBytesBilledName, err)
+	}
+
+	poolConnections, err := meter.Int64ObservableGauge(
+		poolConnectionsName,
+		metric.WithDescription("Count of the connections of the pool of a source, by state."),
+		metric.WithUnit("{connection}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create %s metric: %w", poolConnectionsName, err)
+	}
+
+	poolAcquires, err := meter.Int64ObservableCounter(
+		poolAcquiresName,
+		metric.WithDescription("Count of the connections acquired from the pool of a source."),
+		metric.WithUnit("{acquire}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create %s metric: %w", poolAcquiresName, err)
+	}
+
+	poolCanceledAcquires, err := meter.Int64ObservableCounter(
+		poolCanceledAcquiresName,
+		metric.WithDescription("Count of the acquisitions of connections from the pool of a source canceled before a connection was available."),
+		metric.WithUnit("{acquire}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create %s metric: %w", poolCanceledAcquiresName, err)
+	}
+
+	poolAcquireTime, err := meter.Float64ObservableCounter(
+		poolAcquireTimeName,
+		metric.WithDescription("Cumulative time spent acquiring connections from the pool of a source."),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create %s metric: %w", poolAcquireTimeName, err)
+	}
+
+	sourcePingLatency, err := meter.Float64ObservableGauge(
+		sourcePingLatencyName,
+		metric.WithDescription("Latency of the last successful ping of a source."),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create %s metric: %w", sourcePingLatencyName, err)
+	}
+
+	sslCertExpiry, err := meter.Float64ObservableGauge(
+		sslCertExpiryName,
+		metric.WithDescription("Days until the earliest expiry of the certificates trusted by a source."),
+		metric.WithUnit("d"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create %s metric: %w", sslCertExpiryName, err)
+	}
+
 	instrumentation := &Instrumentation{
 		Tracer:                tracer,
 		meter:                 meter,
@@ -94,6 +229,18 @@ func CreateTelemetryInstrumentation(versionString string) (*Instrumentation, err
 		McpSessionDuration:    mcpSessionDuration,
 		McpActiveSessions:     mcpActiveSessions,
 		ToolExecutionDuration: toolExecutionDuration,
+		InvocationQueueDepth:  invocationQueueDepth,
+		PoolAcquireDuration:   poolAcquireDuration,
+		ToolsetInvocations:    toolsetInvocations,
+		ToolsetRows:           toolsetRows,
+		ToolsetExecutionTime:  toolsetExecutionTime,
+		ToolsetBytesBilled:    toolsetBytesBilled,
+		poolConnections:       poolConnections,
+		poolAcquires:          poolAcquires,
+		poolCanceledAcquires:  poolCanceledAcquires,
+		poolAcquireTime:       poolAcquireTime,
+		sourcePingLatency:     sourcePingLatency,
+		sslCertExpiry:         sslCertExpiry,
 	}
 	return instrumentation, nil
 }
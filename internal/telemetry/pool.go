@@ -0,0 +1,114 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// PoolStats are the statistics of the connection pool of a source.
+type PoolStats struct {
+	// Acquired, Idle and Total count the connections of the pool, and Max
+	// is its capacity.
+	Acquired int64
+	Idle     int64
+	Total    int64
+	Max      int64
+	// Acquires counts the connections acquired from the pool, and
+	// CanceledAcquires the acquisitions canceled before a connection was
+	// available.
+	Acquires         int64
+	CanceledAcquires int64
+	// AcquireTime is the cumulative time spent acquiring connections.
+	AcquireTime time.Duration
+}
+
+// PgxPoolStats returns the statistics of a pgx connection pool.
+func PgxPoolStats(stat *pgxpool.Stat) PoolStats {
+	return PoolStats{
+		Acquired:         int64(stat.AcquiredConns()),
+		Idle:             int64(stat.IdleConns()),
+		Total:            int64(stat.TotalConns()),
+		Max:              int64(stat.MaxConns()),
+		Acquires:         stat.AcquireCount(),
+		CanceledAcquires: stat.CanceledAcquireCount(),
+		AcquireTime:      stat.AcquireDuration(),
+	}
+}
+
+// DBStats returns the statistics of a database/sql connection pool. Its
+// waits for a connection count as acquisitions, as database/sql does not
+// count the acquisitions served right away.
+func DBStats(stats sql.DBStats) PoolStats {
+	return PoolStats{
+		Acquired:    int64(stats.InUse),
+		Idle:        int64(stats.Idle),
+		Total:       int64(stats.OpenConnections),
+		Max:         int64(stats.MaxOpenConnections),
+		Acquires:    stats.WaitCount,
+		AcquireTime: stats.WaitDuration,
+	}
+}
+
+// SourceStats are the statistics of a source observed through
+// ObserveSources.
+type SourceStats struct {
+	Name string
+	Type string
+	// Pool are the statistics of the connection pool of the source, nil if
+	// it has none.
+	Pool *PoolStats
+	// PingLatency is the latency of the last successful ping of the
+	// source, nil if it was never pinged.
+	PingLatency *time.Duration
+}
+
+// ObserveSources reports the statistics of the sources stats returns each
+// time metrics are collected, until the returned registration is
+// unregistered.
+func (i *Instrumentation) ObserveSources(stats func() []SourceStats) (metric.Registration, error) {
+	return i.meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		for _, st := range stats() {
+			source := []attribute.KeyValue{
+				attribute.String("toolbox.source.name", st.Name),
+				attribute.String("toolbox.source.type", st.Type),
+			}
+			opt := metric.WithAttributes(source...)
+			if st.PingLatency != nil {
+				o.ObserveFloat64(i.sourcePingLatency, st.PingLatency.Seconds(), opt)
+			}
+			if st.Pool == nil {
+				continue
+			}
+			withState := func(state string) metric.ObserveOption {
+				return metric.WithAttributes(append(source, attribute.String("toolbox.source.pool.state", state))...)
+			}
+			o.ObserveInt64(i.poolConnections, st.Pool.Acquired, withState("acquired"))
+			o.ObserveInt64(i.poolConnections, st.Pool.Idle, withState("idle"))
+			o.ObserveInt64(i.poolConnections, st.Pool.Total, withState("total"))
+			o.ObserveInt64(i.poolConnections, st.Pool.Max, withState("max"))
+			o.ObserveInt64(i.poolAcquires, st.Pool.Acquires, opt)
+			o.ObserveInt64(i.poolCanceledAcquires, st.Pool.CanceledAcquires, opt)
+			o.ObserveFloat64(i.poolAcquireTime, st.Pool.AcquireTime.Seconds(), opt)
+		}
+		return nil
+	}, i.poolConnections, i.poolAcquires, i.poolCanceledAcquires, i.poolAcquireTime, i.sourcePingLatency)
+}
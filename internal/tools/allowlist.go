@@ -0,0 +1,65 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/netip"
+
+	"github.com/googleapis/mcp-toolbox/internal/util"
+)
+
+// GetAllowedCIDRs returns the CIDR blocks tool may be invoked from, or nil if
+// it may be invoked from any address.
+func GetAllowedCIDRs(tool Tool) []string {
+	if c, ok := tool.ToConfig().(interface{ GetAllowedCIDRs() []string }); ok {
+		return c.GetAllowedCIDRs()
+	}
+	return nil
+}
+
+// CheckAllowedCIDRs returns an error if the source address of the request
+// in ctx is outside of the allowed CIDR blocks of tool. Invocations without
+// a source address, such as over stdio, are not restricted.
+func CheckAllowedCIDRs(ctx context.Context, tool Tool) util.ToolboxError {
+	cidrs := GetAllowedCIDRs(tool)
+	if len(cidrs) == 0 {
+		return nil
+	}
+	source, ok := util.SourceIPFromContext(ctx)
+	if !ok {
+		return nil
+	}
+	forbidden := util.NewClientServerError(
+		fmt.Sprintf("tool %q may not be invoked from %s", tool.GetName(), source),
+		http.StatusForbidden,
+		nil,
+	)
+	addr, err := netip.ParseAddr(source)
+	if err != nil {
+		return forbidden
+	}
+	addr = addr.Unmap()
+	for _, cidr := range cidrs {
+		// the CIDR blocks were validated when the configuration was loaded
+		prefix, err := netip.ParsePrefix(cidr)
+		if err == nil && prefix.Contains(addr) {
+			return nil
+		}
+	}
+	return forbidden
+}
@@ -0,0 +1,69 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+)
+
+func newAllowlistTool(cidrs []string) tools.Tool {
+	cfg := stubConfig{ConfigBase: tools.ConfigBase{Name: "delete_flight", AllowedCIDRs: cidrs}}
+	return localizedTool{stubTool{tools.NewBaseTool(cfg, nil, tools.Manifest{}, nil)}}
+}
+
+func TestCheckAllowedCIDRs(t *testing.T) {
+	cidrs := []string{"10.0.0.0/8", "2001:db8::/32"}
+	tcs := []struct {
+		desc    string
+		cidrs   []string
+		source  string
+		wantErr bool
+	}{
+		{desc: "no restriction", source: "203.0.113.7"},
+		{desc: "no source address", cidrs: cidrs},
+		{desc: "allowed IPv4", cidrs: cidrs, source: "10.1.2.3"},
+		{desc: "allowed IPv4-mapped IPv6", cidrs: cidrs, source: "::ffff:10.1.2.3"},
+		{desc: "allowed IPv6", cidrs: cidrs, source: "2001:db8::1"},
+		{desc: "disallowed IPv4", cidrs: cidrs, source: "203.0.113.7", wantErr: true},
+		{desc: "disallowed IPv6", cidrs: cidrs, source: "2001:db9::1", wantErr: true},
+		{desc: "invalid source address", cidrs: cidrs, source: "unknown", wantErr: true},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.source != "" {
+				ctx = util.WithSourceIP(ctx, tc.source)
+			}
+			err := tools.CheckAllowedCIDRs(ctx, newAllowlistTool(tc.cidrs))
+			if !tc.wantErr {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			if cse, ok := err.(*util.ClientServerError); !ok || cse.Code != http.StatusForbidden {
+				t.Errorf("expected a forbidden error, got %#v", err)
+			}
+		})
+	}
+}
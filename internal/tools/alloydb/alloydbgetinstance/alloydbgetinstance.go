@@ -29,7 +29,7 @@ import (
 const resourceType string = "alloydb-get-instance"
 
 func init() {
-	if !tools.Register(resourceType, newConfig) {
+	if !tools.Register[compatibleSource](resourceType, newConfig) {
 		panic(fmt.Sprintf("tool type %q already registered", resourceType))
 	}
 }
@@ -0,0 +1,76 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+// AsOfParameterName is the name of the parameter selecting the point in time
+// the reads of a tool are made as of.
+const AsOfParameterName = "asOfTimestamp"
+
+// AsOfConfig lets the callers of a tool read data as it was at a point in
+// time, for reproducible answers. Tool configs embed it inline.
+type AsOfConfig struct {
+	// AsOfTimestamp adds the optional asOfTimestamp parameter to the tool,
+	// an RFC 3339 timestamp in the past the tool reads data as of.
+	AsOfTimestamp bool `yaml:"asOfTimestamp,omitempty"`
+}
+
+// WithAsOfParameter returns params and manifest with the asOfTimestamp
+// parameter appended, if the config enables it.
+func (c AsOfConfig) WithAsOfParameter(toolName string, params parameters.Parameters, manifest []parameters.ParameterManifest) (parameters.Parameters, []parameters.ParameterManifest, error) {
+	if !c.AsOfTimestamp {
+		return params, manifest, nil
+	}
+	if slices.ContainsFunc(params, func(p parameters.Parameter) bool { return p.GetName() == AsOfParameterName }) {
+		return nil, nil, fmt.Errorf("tool %q: asOfTimestamp cannot be enabled along with a parameter named %q", toolName, AsOfParameterName)
+	}
+	p := parameters.NewStringParameter(AsOfParameterName,
+		"Optional RFC 3339 timestamp in the past, such as 2024-01-02T15:04:05Z, to read the data as it was at that time. Defaults to the current data.",
+		parameters.WithStringRequired(false),
+	)
+	params = append(slices.Clone(params), p)
+	manifest = append(slices.Clone(manifest), p.Manifest())
+	return params, manifest, nil
+}
+
+// AsOf returns the asOfTimestamp of the invocation with params, and whether
+// the caller set it. It must not be later than now.
+func AsOf(params parameters.ParamValues, now time.Time) (time.Time, bool, util.ToolboxError) {
+	var raw string
+	for _, p := range params {
+		if p.Name == AsOfParameterName {
+			raw, _ = p.Value.(string)
+		}
+	}
+	if raw == "" {
+		return time.Time{}, false, nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, raw)
+	if err != nil {
+		return time.Time{}, false, util.NewAgentError(fmt.Sprintf("invalid %s %q: must be an RFC 3339 timestamp such as 2024-01-02T15:04:05Z", AsOfParameterName, raw), err)
+	}
+	if t.After(now) {
+		return time.Time{}, false, util.NewAgentError(fmt.Sprintf("invalid %s %q: must not be in the future", AsOfParameterName, raw), nil)
+	}
+	return t, true, nil
+}
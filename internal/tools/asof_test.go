@@ -0,0 +1,87 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+func TestWithAsOfParameter(t *testing.T) {
+	params := parameters.Parameters{parameters.NewStringParameter("id", "the id")}
+	got, manifest, err := tools.AsOfConfig{}.WithAsOfParameter("tool", params, params.Manifest())
+	if err != nil || len(got) != 1 || len(manifest) != 1 {
+		t.Fatalf("expected the parameters unchanged, got %d, %d, %v", len(got), len(manifest), err)
+	}
+
+	got, manifest, err = tools.AsOfConfig{AsOfTimestamp: true}.WithAsOfParameter("tool", params, params.Manifest())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(got) != 2 || got[1].GetName() != tools.AsOfParameterName || got[1].GetRequired() {
+		t.Fatalf("expected an optional %s parameter, got %v", tools.AsOfParameterName, got)
+	}
+	if len(manifest) != 2 || manifest[1].Name != tools.AsOfParameterName {
+		t.Fatalf("expected %s in the manifest, got %v", tools.AsOfParameterName, manifest)
+	}
+	if len(params) != 1 {
+		t.Fatalf("expected the parameters of the config to be left unchanged")
+	}
+
+	clash := parameters.Parameters{parameters.NewStringParameter(tools.AsOfParameterName, "clash")}
+	if _, _, err := (tools.AsOfConfig{AsOfTimestamp: true}).WithAsOfParameter("tool", clash, clash.Manifest()); err == nil {
+		t.Fatal("expected an error for a parameter named asOfTimestamp")
+	}
+}
+
+func TestAsOf(t *testing.T) {
+	now := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+	tcs := []struct {
+		desc   string
+		value  any
+		want   time.Time
+		wantOk bool
+		err    string
+	}{
+		{desc: "unset", value: nil},
+		{desc: "empty", value: ""},
+		{desc: "past", value: "2024-01-01T00:00:00Z", want: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), wantOk: true},
+		{desc: "offset", value: "2024-01-02T10:04:05-05:00", want: now, wantOk: true},
+		{desc: "future", value: "2024-01-03T00:00:00Z", err: "must not be in the future"},
+		{desc: "invalid", value: "yesterday", err: "must be an RFC 3339 timestamp"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			params := parameters.ParamValues{{Name: "id", Value: 1}, {Name: tools.AsOfParameterName, Value: tc.value}}
+			got, ok, err := tools.AsOf(params, now)
+			if tc.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("got error %v, want error containing %q", err, tc.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if ok != tc.wantOk || !got.Equal(tc.want) {
+				t.Fatalf("got %v, %t, want %v, %t", got, ok, tc.want, tc.wantOk)
+			}
+		})
+	}
+}
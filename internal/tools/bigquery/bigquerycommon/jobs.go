@@ -0,0 +1,190 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigquerycommon
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	bigqueryapi "cloud.google.com/go/bigquery"
+	bigqueryds "github.com/googleapis/mcp-toolbox/internal/sources/bigquery"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	bigqueryrestapi "google.golang.org/api/bigquery/v2"
+)
+
+// ToolLabel is the label of the BigQuery jobs naming the tool type that
+// started them.
+const ToolLabel = "mcp-toolbox-tool"
+
+// SubmitJobToolType is the type of the tool submitting query jobs, whose
+// jobs are the only ones the job management tools look up.
+const SubmitJobToolType = "bigquery-submit-job"
+
+// CheckWriteMode returns an error if the statement validated by dryRunJob is
+// not allowed in writeMode. In protected mode, session is the BigQuery
+// session the statement runs in.
+func CheckWriteMode(writeMode string, dryRunJob *bigqueryrestapi.Job, session *bigqueryds.Session) util.ToolboxError {
+	switch writeMode {
+	case bigqueryds.WriteModeBlocked:
+		if dryRunJob.Statistics.Query.StatementType != "SELECT" {
+			return util.NewAgentError("write mode is 'blocked', only SELECT statements are allowed", nil)
+		}
+	case bigqueryds.WriteModeProtected:
+		if dryRunJob.Configuration != nil && dryRunJob.Configuration.Query != nil {
+			if dest := dryRunJob.Configuration.Query.DestinationTable; dest != nil && dest.DatasetId != session.DatasetID {
+				return util.NewAgentError(fmt.Sprintf("protected write mode only supports SELECT statements, or write operations in the anonymous "+
+					"dataset of a BigQuery session, but destination was %q", dest.DatasetId), nil)
+			}
+		}
+	}
+	return nil
+}
+
+// CheckAllowedDatasets returns an error if sql, validated by dryRunJob,
+// accesses a dataset for which isAllowed is false, or runs a statement whose
+// accesses cannot be analyzed. projectID is the default project of sql.
+func CheckAllowedDatasets(dryRunJob *bigqueryrestapi.Job, sql, projectID string, isAllowed func(projectID, datasetID string) bool) util.ToolboxError {
+	statementType := dryRunJob.Statistics.Query.StatementType
+	switch statementType {
+	case "CREATE_SCHEMA", "DROP_SCHEMA", "ALTER_SCHEMA":
+		return util.NewAgentError(fmt.Sprintf("dataset-level operations like '%s' are not allowed when dataset restrictions are in place", statementType), nil)
+	case "CREATE_FUNCTION", "CREATE_TABLE_FUNCTION", "CREATE_PROCEDURE":
+		return util.NewAgentError(fmt.Sprintf("creating stored routines ('%s') is not allowed when dataset restrictions are in place, as their contents cannot be safely analyzed", statementType), nil)
+	case "CALL":
+		return util.NewAgentError(fmt.Sprintf("calling stored procedures ('%s') is not allowed when dataset restrictions are in place, as their contents cannot be safely analyzed", statementType), nil)
+	}
+
+	// Use a map to avoid duplicate table names.
+	tableIDSet := make(map[string]struct{})
+
+	// Get all tables from the dry run result. This is the most reliable method.
+	queryStats := dryRunJob.Statistics.Query
+	if queryStats != nil {
+		for _, tableRef := range queryStats.ReferencedTables {
+			tableIDSet[fmt.Sprintf("%s.%s.%s", tableRef.ProjectId, tableRef.DatasetId, tableRef.TableId)] = struct{}{}
+		}
+		if tableRef := queryStats.DdlTargetTable; tableRef != nil {
+			tableIDSet[fmt.Sprintf("%s.%s.%s", tableRef.ProjectId, tableRef.DatasetId, tableRef.TableId)] = struct{}{}
+		}
+		if tableRef := queryStats.DdlDestinationTable; tableRef != nil {
+			tableIDSet[fmt.Sprintf("%s.%s.%s", tableRef.ProjectId, tableRef.DatasetId, tableRef.TableId)] = struct{}{}
+		}
+	}
+
+	// Always run the parser to ensure we catch views/tables that the dry run might bypass
+	parsedTables, parseErr := TableParser(sql, projectID)
+	if parseErr != nil {
+		return util.NewAgentError("could not parse tables from query to validate against allowed datasets", parseErr)
+	}
+	for _, tableID := range parsedTables {
+		tableIDSet[tableID] = struct{}{}
+	}
+
+	for tableID := range tableIDSet {
+		parts := strings.Split(tableID, ".")
+		if len(parts) == 3 {
+			projectID, datasetID := parts[0], parts[1]
+			if !isAllowed(projectID, datasetID) {
+				return util.NewAgentError(fmt.Sprintf("query accesses dataset '%s.%s', which is not in the allowed list", projectID, datasetID), nil)
+			}
+		}
+	}
+	return nil
+}
+
+// JobStatus is the status of a BigQuery job, returned by the job management
+// tools.
+type JobStatus struct {
+	JobID               string     `json:"jobId"`
+	Location            string     `json:"location"`
+	State               string     `json:"state"`
+	Error               string     `json:"error,omitempty"`
+	CreationTime        *time.Time `json:"creationTime,omitempty"`
+	StartTime           *time.Time `json:"startTime,omitempty"`
+	EndTime             *time.Time `json:"endTime,omitempty"`
+	TotalBytesProcessed int64      `json:"totalBytesProcessed"`
+	// Rows are the results of a finished query job.
+	Rows []any `json:"rows,omitempty"`
+}
+
+// NewJobStatus returns the status of job. A nil status is reported as
+// pending.
+func NewJobStatus(job *bigqueryapi.Job, status *bigqueryapi.JobStatus) JobStatus {
+	s := JobStatus{
+		JobID:    job.ID(),
+		Location: job.Location(),
+		State:    jobState(bigqueryapi.Pending),
+	}
+	if status == nil {
+		return s
+	}
+	s.State = jobState(status.State)
+	if err := status.Err(); err != nil {
+		s.Error = err.Error()
+	}
+	if stats := status.Statistics; stats != nil {
+		s.CreationTime = nonZeroTime(stats.CreationTime)
+		s.StartTime = nonZeroTime(stats.StartTime)
+		s.EndTime = nonZeroTime(stats.EndTime)
+		s.TotalBytesProcessed = stats.TotalBytesProcessed
+	}
+	return s
+}
+
+func jobState(state bigqueryapi.State) string {
+	switch state {
+	case bigqueryapi.Pending:
+		return "PENDING"
+	case bigqueryapi.Running:
+		return "RUNNING"
+	case bigqueryapi.Done:
+		return "DONE"
+	default:
+		return "UNSPECIFIED"
+	}
+}
+
+func nonZeroTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+// GetSubmittedJob returns the query job with the ID, in location if set,
+// that was started by the submit job tool. Other jobs of the project are
+// reported as not found, so that the job management tools cannot read or
+// cancel jobs bypassing the restrictions of the source.
+func GetSubmittedJob(ctx context.Context, client *bigqueryapi.Client, jobID, location string) (*bigqueryapi.Job, util.ToolboxError) {
+	if location == "" {
+		location = client.Location
+	}
+	job, err := client.JobFromIDLocation(ctx, jobID, location)
+	if err != nil {
+		return nil, util.ProcessGcpError(err)
+	}
+	cfg, err := job.Config()
+	if err != nil {
+		return nil, util.NewClientServerError("unable to get job configuration", http.StatusInternalServerError, err)
+	}
+	queryCfg, ok := cfg.(*bigqueryapi.QueryConfig)
+	if !ok || queryCfg.Labels[ToolLabel] != SubmitJobToolType {
+		return nil, util.NewAgentError(fmt.Sprintf("job %q was not found among the jobs submitted with a %s tool", jobID, SubmitJobToolType), nil)
+	}
+	return job, nil
+}
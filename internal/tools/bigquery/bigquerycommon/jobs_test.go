@@ -0,0 +1,112 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigquerycommon_test
+
+import (
+	"strings"
+	"testing"
+
+	bigqueryds "github.com/googleapis/mcp-toolbox/internal/sources/bigquery"
+	"github.com/googleapis/mcp-toolbox/internal/tools/bigquery/bigquerycommon"
+	bigqueryrestapi "google.golang.org/api/bigquery/v2"
+)
+
+func dryRunJob(statementType string, tables ...*bigqueryrestapi.TableReference) *bigqueryrestapi.Job {
+	return &bigqueryrestapi.Job{
+		Statistics: &bigqueryrestapi.JobStatistics{
+			Query: &bigqueryrestapi.JobStatistics2{
+				StatementType:    statementType,
+				ReferencedTables: tables,
+			},
+		},
+	}
+}
+
+func TestCheckWriteMode(t *testing.T) {
+	tcs := []struct {
+		desc      string
+		writeMode string
+		job       *bigqueryrestapi.Job
+		wantErr   string
+	}{
+		{desc: "allowed", writeMode: bigqueryds.WriteModeAllowed, job: dryRunJob("INSERT")},
+		{desc: "blocked select", writeMode: bigqueryds.WriteModeBlocked, job: dryRunJob("SELECT")},
+		{desc: "blocked insert", writeMode: bigqueryds.WriteModeBlocked, job: dryRunJob("INSERT"), wantErr: "only SELECT statements are allowed"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := bigquerycommon.CheckWriteMode(tc.writeMode, tc.job, nil)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("unexpected error: got %v, want %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckAllowedDatasets(t *testing.T) {
+	isAllowed := func(projectID, datasetID string) bool {
+		return projectID == "p" && datasetID == "allowed"
+	}
+	tcs := []struct {
+		desc    string
+		sql     string
+		job     *bigqueryrestapi.Job
+		wantErr string
+	}{
+		{
+			desc: "allowed dataset",
+			sql:  "SELECT * FROM allowed.t",
+			job:  dryRunJob("SELECT", &bigqueryrestapi.TableReference{ProjectId: "p", DatasetId: "allowed", TableId: "t"}),
+		},
+		{
+			desc:    "referenced table outside the allowed datasets",
+			sql:     "SELECT * FROM v",
+			job:     dryRunJob("SELECT", &bigqueryrestapi.TableReference{ProjectId: "p", DatasetId: "other", TableId: "t"}),
+			wantErr: "query accesses dataset 'p.other'",
+		},
+		{
+			desc:    "parsed table outside the allowed datasets",
+			sql:     "SELECT * FROM other.t",
+			job:     dryRunJob("SELECT"),
+			wantErr: "query accesses dataset 'p.other'",
+		},
+		{
+			desc:    "stored procedure",
+			sql:     "CALL allowed.proc()",
+			job:     dryRunJob("CALL"),
+			wantErr: "calling stored procedures",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := bigquerycommon.CheckAllowedDatasets(tc.job, tc.sql, "p", isAllowed)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("unexpected error: got %v, want %q", err, tc.wantErr)
+			}
+		})
+	}
+}
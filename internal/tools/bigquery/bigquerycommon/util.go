@@ -53,6 +53,12 @@ func ValidColumnName(s string) bool {
 
 // DryRunQuery performs a dry run of the SQL query to validate it and get metadata.
 func DryRunQuery(ctx context.Context, restService *bigqueryrestapi.Service, projectID string, location string, sql string, params []*bigqueryrestapi.QueryParameter, connProps []*bigqueryapi.ConnectionProperty, maximumBytesBilled int64) (*bigqueryrestapi.Job, error) {
+	return DryRunQueryInDataset(ctx, restService, projectID, location, "", sql, params, connProps, maximumBytesBilled)
+}
+
+// DryRunQueryInDataset performs a dry run of the SQL query, resolving the
+// unqualified table names of the query in dataset of projectID if set.
+func DryRunQueryInDataset(ctx context.Context, restService *bigqueryrestapi.Service, projectID, location, dataset, sql string, params []*bigqueryrestapi.QueryParameter, connProps []*bigqueryapi.ConnectionProperty, maximumBytesBilled int64) (*bigqueryrestapi.Job, error) {
 	useLegacySql := false
 
 	restConnProps := make([]*bigqueryrestapi.ConnectionProperty, len(connProps))
@@ -76,6 +82,9 @@ func DryRunQuery(ctx context.Context, restService *bigqueryrestapi.Service, proj
 			},
 		},
 	}
+	if dataset != "" {
+		jobToInsert.Configuration.Query.DefaultDataset = &bigqueryrestapi.DatasetReference{ProjectId: projectID, DatasetId: dataset}
+	}
 
 	insertResponse, err := restService.Jobs.Insert(projectID, jobToInsert).Context(ctx).Do()
 	if err != nil {
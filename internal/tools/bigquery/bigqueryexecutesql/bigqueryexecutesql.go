@@ -35,7 +35,7 @@ import (
 const resourceType string = "bigquery-execute-sql"
 
 func init() {
-	if !tools.Register(resourceType, newConfig) {
+	if !tools.Register[compatibleSource](resourceType, newConfig) {
 		panic(fmt.Sprintf("tool type %q already registered", resourceType))
 	}
 }
@@ -145,64 +145,12 @@ func (t Tool) Invoke(ctx context.Context, primitiveMgr tools.SourceProvider, par
 
 	statementType := dryRunJob.Statistics.Query.StatementType
 
-	switch source.BigQueryWriteMode() {
-	case bigqueryds.WriteModeBlocked:
-		if statementType != "SELECT" {
-			return nil, util.NewAgentError("write mode is 'blocked', only SELECT statements are allowed", nil)
-		}
-	case bigqueryds.WriteModeProtected:
-		if dryRunJob.Configuration != nil && dryRunJob.Configuration.Query != nil {
-			if dest := dryRunJob.Configuration.Query.DestinationTable; dest != nil && dest.DatasetId != session.DatasetID {
-				return nil, util.NewAgentError(fmt.Sprintf("protected write mode only supports SELECT statements, or write operations in the anonymous "+
-					"dataset of a BigQuery session, but destination was %q", dest.DatasetId), nil)
-			}
-		}
+	if tbErr := bqutil.CheckWriteMode(source.BigQueryWriteMode(), dryRunJob, session); tbErr != nil {
+		return nil, tbErr
 	}
-
 	if len(source.BigQueryAllowedDatasets()) > 0 {
-		switch statementType {
-		case "CREATE_SCHEMA", "DROP_SCHEMA", "ALTER_SCHEMA":
-			return nil, util.NewAgentError(fmt.Sprintf("dataset-level operations like '%s' are not allowed when dataset restrictions are in place", statementType), nil)
-		case "CREATE_FUNCTION", "CREATE_TABLE_FUNCTION", "CREATE_PROCEDURE":
-			return nil, util.NewAgentError(fmt.Sprintf("creating stored routines ('%s') is not allowed when dataset restrictions are in place, as their contents cannot be safely analyzed", statementType), nil)
-		case "CALL":
-			return nil, util.NewAgentError(fmt.Sprintf("calling stored procedures ('%s') is not allowed when dataset restrictions are in place, as their contents cannot be safely analyzed", statementType), nil)
-		}
-
-		// Use a map to avoid duplicate table names.
-		tableIDSet := make(map[string]struct{})
-
-		// Get all tables from the dry run result. This is the most reliable method.
-		queryStats := dryRunJob.Statistics.Query
-		if queryStats != nil {
-			for _, tableRef := range queryStats.ReferencedTables {
-				tableIDSet[fmt.Sprintf("%s.%s.%s", tableRef.ProjectId, tableRef.DatasetId, tableRef.TableId)] = struct{}{}
-			}
-			if tableRef := queryStats.DdlTargetTable; tableRef != nil {
-				tableIDSet[fmt.Sprintf("%s.%s.%s", tableRef.ProjectId, tableRef.DatasetId, tableRef.TableId)] = struct{}{}
-			}
-			if tableRef := queryStats.DdlDestinationTable; tableRef != nil {
-				tableIDSet[fmt.Sprintf("%s.%s.%s", tableRef.ProjectId, tableRef.DatasetId, tableRef.TableId)] = struct{}{}
-			}
-		}
-
-		// Always run the parser to ensure we catch views/tables that the dry run might bypass
-		parsedTables, parseErr := bqutil.TableParser(sql, bqClient.Project())
-		if parseErr != nil {
-			return nil, util.NewAgentError("could not parse tables from query to validate against allowed datasets", parseErr)
-		}
-		for _, tableID := range parsedTables {
-			tableIDSet[tableID] = struct{}{}
-		}
-
-		for tableID := range tableIDSet {
-			parts := strings.Split(tableID, ".")
-			if len(parts) == 3 {
-				projectID, datasetID := parts[0], parts[1]
-				if !source.IsDatasetAllowed(projectID, datasetID) {
-					return nil, util.NewAgentError(fmt.Sprintf("query accesses dataset '%s.%s', which is not in the allowed list", projectID, datasetID), nil)
-				}
-			}
+		if tbErr := bqutil.CheckAllowedDatasets(dryRunJob, sql, bqClient.Project(), source.IsDatasetAllowed); tbErr != nil {
+			return nil, tbErr
 		}
 	}
 
@@ -0,0 +1,337 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigqueryexport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"time"
+
+	bigqueryapi "cloud.google.com/go/bigquery"
+	"cloud.google.com/go/storage"
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/mcp-toolbox/internal/embeddingmodels"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/tools/gcsexport"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+	"golang.org/x/oauth2"
+	bigqueryrestapi "google.golang.org/api/bigquery/v2"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+)
+
+const resourceType string = "bigquery-export"
+
+// exportSteps is the number of steps reported through progress notifications:
+// running the query, extracting the results and collecting statistics.
+const exportSteps = 3
+
+func init() {
+	if !tools.Register[compatibleSource](resourceType, newConfig) {
+		panic(fmt.Sprintf("tool type %q already registered", resourceType))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{ConfigBase: tools.ConfigBase{Name: name}}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type compatibleSource interface {
+	UseClientAuthorization() bool
+	GetAuthTokenHeaderName() string
+	GetMaximumBytesBilled() int64
+	BigQueryTokenSource() oauth2.TokenSource
+	RetrieveClientAndService(tools.AccessToken) (*bigqueryapi.Client, *bigqueryrestapi.Service, error)
+}
+
+type Config struct {
+	tools.ConfigBase   `yaml:",inline"`
+	Type               string                 `yaml:"type" validate:"required"`
+	Source             string                 `yaml:"source" validate:"required"`
+	Statement          string                 `yaml:"statement" validate:"required"`
+	Destination        gcsexport.Destination  `yaml:"destination" validate:"required"`
+	Format             string                 `yaml:"format"`
+	Parameters         parameters.Parameters  `yaml:"parameters"`
+	TemplateParameters parameters.Parameters  `yaml:"templateParameters"`
+	Annotations        *tools.ToolAnnotations `yaml:"annotations,omitempty"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigType() string {
+	return resourceType
+}
+
+func (cfg Config) Initialize(context.Context) (tools.Tool, error) {
+	if cfg.Description == "" {
+		return nil, fmt.Errorf("description is required for tool %q", cfg.Name)
+	}
+	if cfg.Format == "" {
+		cfg.Format = gcsexport.FormatCSV
+	}
+	if err := cfg.Destination.Validate(cfg.Format, gcsexport.FormatCSV, gcsexport.FormatParquet); err != nil {
+		return nil, fmt.Errorf("invalid configuration for tool %q: %w", cfg.Name, err)
+	}
+
+	allParameters, paramManifest, err := parameters.ProcessParameters(cfg.TemplateParameters, cfg.Parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	return Tool{
+		BaseTool: tools.NewBaseTool(
+			cfg,
+			tools.GetAnnotationsOrDefault(cfg.Annotations, tools.NewReadOnlyAnnotations),
+			tools.Manifest{Description: cfg.Description, Parameters: paramManifest, AuthRequired: cfg.AuthRequired},
+			allParameters,
+		),
+	}, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	tools.BaseTool[Config]
+}
+
+func (t Tool) ToConfig() tools.ToolConfig {
+	return t.Cfg
+}
+
+func (t Tool) Invoke(ctx context.Context, primitiveMgr tools.SourceProvider, params parameters.ParamValues, accessToken tools.AccessToken) (any, util.ToolboxError) {
+	source, err := tools.GetCompatibleSource[compatibleSource](primitiveMgr, t.Cfg.Source, t.Cfg.Name, t.Cfg.Type)
+	if err != nil {
+		return nil, util.NewClientServerError("source used is not compatible with the tool", http.StatusInternalServerError, err)
+	}
+
+	paramsMap := params.AsMap()
+	newStatement, err := parameters.ResolveTemplateParams(t.Cfg.TemplateParameters, t.Cfg.Statement, paramsMap)
+	if err != nil {
+		return nil, util.NewAgentError("unable to extract template params", err)
+	}
+
+	queryParams, tbErr := buildQueryParameters(t.Cfg.Parameters, paramsMap, newStatement)
+	if tbErr != nil {
+		return nil, tbErr
+	}
+
+	bqClient, _, err := source.RetrieveClientAndService(accessToken)
+	if err != nil {
+		return nil, util.NewClientServerError("failed to retrieve BigQuery client", http.StatusInternalServerError, err)
+	}
+	labels := map[string]string{"mcp-toolbox-tool": resourceType}
+
+	util.ReportProgress(ctx, 0, exportSteps, "running query")
+	query := bqClient.Query(newStatement)
+	query.Parameters = queryParams
+	query.Location = bqClient.Location
+	query.Labels = labels
+	if maxBytes := source.GetMaximumBytesBilled(); maxBytes > 0 {
+		query.MaxBytesBilled = maxBytes
+	}
+	job, err := query.Run(ctx)
+	if err != nil {
+		return nil, util.ProcessGcpError(err)
+	}
+	if err := waitForJob(ctx, job); err != nil {
+		return nil, util.ProcessGcpError(err)
+	}
+	table, err := queryDestination(job)
+	if err != nil {
+		return nil, util.NewClientServerError("unable to get query results table", http.StatusInternalServerError, err)
+	}
+
+	util.ReportProgress(ctx, 1, exportSteps, "extracting results")
+	object := t.Cfg.Destination.ObjectName(t.Cfg.Name, time.Now())
+	// Extract jobs shard exports larger than 1 GB, which requires a wildcard.
+	uri := t.Cfg.Destination.URI(object + "-*" + gcsexport.Extension(t.Cfg.Format))
+	extractor := newExtractor(table, uri, t.Cfg.Format)
+	extractor.Location = job.Location()
+	extractor.Labels = labels
+	extractJob, err := extractor.Run(ctx)
+	if err != nil {
+		return nil, util.ProcessGcpError(err)
+	}
+	if err := waitForJob(ctx, extractJob); err != nil {
+		return nil, util.ProcessGcpError(err)
+	}
+
+	util.ReportProgress(ctx, 2, exportSteps, "collecting export statistics")
+	md, err := table.Metadata(ctx)
+	if err != nil {
+		return nil, util.ProcessGcpError(err)
+	}
+
+	var tokenSource oauth2.TokenSource
+	if source.UseClientAuthorization() {
+		tokenStr, err := accessToken.ParseBearerToken()
+		if err != nil {
+			return nil, util.NewClientServerError("error parsing access token", http.StatusUnauthorized, err)
+		}
+		tokenSource = oauth2.StaticTokenSource(&oauth2.Token{AccessToken: tokenStr})
+	} else {
+		tokenSource = source.BigQueryTokenSource()
+	}
+	uris, size, err := listObjects(ctx, tokenSource, t.Cfg.Destination, object+"-")
+	if err != nil {
+		return nil, util.ProcessGcpError(err)
+	}
+	util.ReportProgress(ctx, exportSteps, exportSteps, fmt.Sprintf("exported %d rows", md.NumRows))
+
+	return gcsexport.Result{
+		URIs:     uris,
+		Format:   t.Cfg.Format,
+		RowCount: int64(md.NumRows),
+		ByteSize: size,
+	}, nil
+}
+
+// newExtractor returns an extractor that exports table to the objects
+// matching uri in the given format.
+func newExtractor(table *bigqueryapi.Table, uri, format string) *bigqueryapi.Extractor {
+	dst := bigqueryapi.NewGCSReference(uri)
+	switch format {
+	case gcsexport.FormatParquet:
+		dst.DestinationFormat = bigqueryapi.Parquet
+	default:
+		dst.DestinationFormat = bigqueryapi.CSV
+	}
+	return table.ExtractorTo(dst)
+}
+
+// waitForJob waits for job to finish, returning the job error if it failed.
+func waitForJob(ctx context.Context, job *bigqueryapi.Job) error {
+	status, err := job.Wait(ctx)
+	if err != nil {
+		return err
+	}
+	return status.Err()
+}
+
+// queryDestination returns the table holding the results of a finished query
+// job. Queries without an explicit destination write to an anonymous table.
+func queryDestination(job *bigqueryapi.Job) (*bigqueryapi.Table, error) {
+	cfg, err := job.Config()
+	if err != nil {
+		return nil, err
+	}
+	queryCfg, ok := cfg.(*bigqueryapi.QueryConfig)
+	if !ok || queryCfg.Dst == nil {
+		return nil, errors.New("query job has no destination table")
+	}
+	return queryCfg.Dst, nil
+}
+
+// listObjects returns the URIs and total size of the objects in the
+// destination bucket whose names start with prefix.
+func listObjects(ctx context.Context, tokenSource oauth2.TokenSource, dst gcsexport.Destination, prefix string) ([]string, int64, error) {
+	var opts []option.ClientOption
+	if tokenSource != nil {
+		opts = append(opts, option.WithTokenSource(tokenSource))
+	}
+	client, err := storage.NewClient(ctx, opts...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("unable to create Cloud Storage client: %w", err)
+	}
+	defer client.Close()
+
+	var uris []string
+	var size int64
+	it := client.Bucket(dst.Bucket).Objects(ctx, &storage.Query{Prefix: prefix})
+	for {
+		attrs, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+		uris = append(uris, dst.URI(attrs.Name))
+		size += attrs.Size
+	}
+	return uris, size, nil
+}
+
+// buildQueryParameters converts the tool parameters to BigQuery query
+// parameters. Parameters referenced as @name in the statement are passed as
+// named parameters, all others positionally.
+func buildQueryParameters(paramsMetadata parameters.Parameters, paramsMap map[string]any, statement string) ([]bigqueryapi.QueryParameter, util.ToolboxError) {
+	queryParams := make([]bigqueryapi.QueryParameter, 0, len(paramsMetadata))
+	for _, p := range paramsMetadata {
+		name := p.GetName()
+		value := paramsMap[name]
+
+		if arrayParam, ok := p.(*parameters.ArrayParameter); ok && value != nil {
+			if arrayParamValue, ok := value.([]any); ok {
+				var err error
+				value, err = parameters.ConvertAnySliceToTyped(arrayParamValue, arrayParam.GetItems().GetType())
+				if err != nil {
+					return nil, util.NewAgentError(fmt.Sprintf("unable to convert parameter `%s` from []any to typed slice", name), err)
+				}
+			}
+		}
+
+		// BigQuery requires typed NULLs for optional parameters.
+		if value == nil {
+			switch p.GetType() {
+			case parameters.TypeString:
+				value = bigqueryapi.NullString{}
+			case parameters.TypeInt:
+				value = bigqueryapi.NullInt64{}
+			case parameters.TypeFloat:
+				value = bigqueryapi.NullFloat64{}
+			case parameters.TypeBool:
+				value = bigqueryapi.NullBool{}
+			}
+		}
+
+		var paramName string
+		if isNamed, _ := regexp.MatchString("@"+name+"\\b", statement); isNamed {
+			paramName = name
+		}
+		queryParams = append(queryParams, bigqueryapi.QueryParameter{Name: paramName, Value: value})
+	}
+	return queryParams, nil
+}
+
+func (t Tool) EmbedParams(ctx context.Context, paramValues parameters.ParamValues, embeddingModelsMap map[string]embeddingmodels.EmbeddingModel) (parameters.ParamValues, error) {
+	return parameters.EmbedParams(ctx, t.StaticParameters, paramValues, embeddingModelsMap, nil)
+}
+
+func (t Tool) RequiresClientAuthorization(primitiveMgr tools.SourceProvider) (bool, error) {
+	source, err := tools.GetCompatibleSource[compatibleSource](primitiveMgr, t.Cfg.Source, t.Cfg.Name, t.Cfg.Type)
+	if err != nil {
+		return false, err
+	}
+	return source.UseClientAuthorization(), nil
+}
+
+func (t Tool) GetAuthTokenHeaderName(primitiveMgr tools.SourceProvider) (string, error) {
+	source, err := tools.GetCompatibleSource[compatibleSource](primitiveMgr, t.Cfg.Source, t.Cfg.Name, t.Cfg.Type)
+	if err != nil {
+		return "", err
+	}
+	return source.GetAuthTokenHeaderName(), nil
+}
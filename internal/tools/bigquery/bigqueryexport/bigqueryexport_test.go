@@ -0,0 +1,124 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigqueryexport
+
+import (
+	"testing"
+
+	bigqueryapi "cloud.google.com/go/bigquery"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/internal/server"
+	"github.com/googleapis/mcp-toolbox/internal/testutils"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/tools/gcsexport"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+func TestParseFromYamlBigQueryExport(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	in := `
+            kind: tool
+            name: export_events
+            type: bigquery-export
+            source: my-bq
+            description: some description
+            statement: SELECT * FROM events WHERE day = @day
+            destination:
+                bucket: my-exports
+            format: parquet
+            parameters:
+                - name: day
+                  type: string
+                  description: some description
+			`
+	want := server.ToolConfigs{
+		"export_events": Config{
+			ConfigBase: tools.ConfigBase{
+				Name:         "export_events",
+				Description:  "some description",
+				AuthRequired: []string{},
+			},
+			Type:        "bigquery-export",
+			Source:      "my-bq",
+			Statement:   "SELECT * FROM events WHERE day = @day",
+			Destination: gcsexport.Destination{Bucket: "my-exports"},
+			Format:      "parquet",
+			Parameters: []parameters.Parameter{
+				parameters.NewStringParameter("day", "some description"),
+			},
+		},
+	}
+	_, _, _, got, _, _, err := server.UnmarshalPrimitiveConfig(ctx, testutils.FormatYaml(in))
+	if err != nil {
+		t.Fatalf("unable to unmarshal: %s", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect parse: diff %v", diff)
+	}
+}
+
+func TestNewExtractor(t *testing.T) {
+	table := &bigqueryapi.Table{ProjectID: "p", DatasetID: "_anon", TableID: "anon123"}
+	tcs := []struct {
+		desc       string
+		format     string
+		wantFormat bigqueryapi.DataFormat
+	}{
+		{desc: "csv", format: gcsexport.FormatCSV, wantFormat: bigqueryapi.CSV},
+		{desc: "parquet", format: gcsexport.FormatParquet, wantFormat: bigqueryapi.Parquet},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			uri := "gs://bucket/exports/tool-20260101T000000Z-abcd1234-*." + tc.format
+			e := newExtractor(table, uri, tc.format)
+			if e.Src != table {
+				t.Errorf("incorrect source table: got %v, want %v", e.Src, table)
+			}
+			if e.Dst == nil {
+				t.Fatalf("missing destination")
+			}
+			if diff := cmp.Diff([]string{uri}, e.Dst.URIs); diff != "" {
+				t.Errorf("incorrect destination URIs: diff %v", diff)
+			}
+			if e.Dst.DestinationFormat != tc.wantFormat {
+				t.Errorf("incorrect destination format: got %q, want %q", e.Dst.DestinationFormat, tc.wantFormat)
+			}
+			if e.DisableHeader {
+				t.Errorf("header should be enabled")
+			}
+		})
+	}
+}
+
+func TestBuildQueryParameters(t *testing.T) {
+	params := parameters.Parameters{
+		parameters.NewStringParameter("region", "region"),
+		parameters.NewIntParameter("limit", "limit"),
+	}
+	got, err := buildQueryParameters(params, map[string]any{"region": "EU"}, "SELECT * FROM t WHERE region = @region LIMIT ?")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := []bigqueryapi.QueryParameter{
+		{Name: "region", Value: "EU"},
+		{Name: "", Value: bigqueryapi.NullInt64{}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("incorrect query parameters: diff %v", diff)
+	}
+}
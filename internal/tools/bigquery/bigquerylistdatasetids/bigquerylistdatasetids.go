@@ -33,7 +33,7 @@ const resourceType string = "bigquery-list-dataset-ids"
 const projectKey string = "project"
 
 func init() {
-	if !tools.Register(resourceType, newConfig) {
+	if !tools.Register[compatibleSource](resourceType, newConfig) {
 		panic(fmt.Sprintf("tool type %q already registered", resourceType))
 	}
 }
@@ -20,7 +20,10 @@ import (
 	"net/http"
 	"reflect"
 	"regexp"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 
 	bigqueryapi "cloud.google.com/go/bigquery"
 	yaml "github.com/goccy/go-yaml"
@@ -36,7 +39,7 @@ import (
 const resourceType string = "bigquery-sql"
 
 func init() {
-	if !tools.Register(resourceType, newConfig) {
+	if !tools.Register[compatibleSource](resourceType, newConfig) {
 		panic(fmt.Sprintf("tool type %q already registered", resourceType))
 	}
 }
@@ -56,16 +59,27 @@ type compatibleSource interface {
 	GetMaximumBytesBilled() int64
 	RetrieveClientAndService(tools.AccessToken) (*bigqueryapi.Client, *bigqueryrestapi.Service, error)
 	RunSQL(context.Context, *bigqueryapi.Client, string, string, []bigqueryapi.QueryParameter, []*bigqueryapi.ConnectionProperty, map[string]string) (any, error)
+	RunSQLInDataset(context.Context, *bigqueryapi.Client, string, string, string, []bigqueryapi.QueryParameter, []*bigqueryapi.ConnectionProperty, map[string]string) (any, error)
 }
 
 type Config struct {
 	tools.ConfigBase   `yaml:",inline"`
+	tools.ColumnConfig `yaml:",inline"`
+	tools.AsOfConfig   `yaml:",inline"`
 	Type               string                 `yaml:"type" validate:"required"`
 	Source             string                 `yaml:"source" validate:"required"`
 	Statement          string                 `yaml:"statement" validate:"required"`
+	Variants           tools.Variants         `yaml:"variants,omitempty"`
 	Parameters         parameters.Parameters  `yaml:"parameters"`
 	TemplateParameters parameters.Parameters  `yaml:"templateParameters"`
 	Annotations        *tools.ToolAnnotations `yaml:"annotations,omitempty"`
+	// AllowColumnRedaction re-runs a query without the columns the caller is
+	// denied access to by policy tags, instead of failing it.
+	AllowColumnRedaction bool `yaml:"allowColumnRedaction"`
+	// AuthorizedView is an authorized view, as dataset.view_name, of the
+	// project of the source. Queries run in the dataset of the view, so that
+	// they read the view rather than the tables it authorizes.
+	AuthorizedView string `yaml:"authorizedView,omitempty"`
 }
 
 // validate interface
@@ -85,6 +99,33 @@ func (cfg Config) Initialize(context.Context) (tools.Tool, error) {
 		return nil, err
 	}
 
+	if err := cfg.Variants.Validate(cfg.Name); err != nil {
+		return nil, err
+	}
+
+	columns, err := cfg.NewColumnShaper(cfg.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.AuthorizedView != "" {
+		if _, _, err := splitAuthorizedView(cfg.AuthorizedView); err != nil {
+			return nil, fmt.Errorf("tool %q: %w", cfg.Name, err)
+		}
+	}
+
+	if cfg.AsOfTimestamp {
+		for _, statement := range cfg.statements() {
+			if !asOfPattern.MatchString(statement) {
+				return nil, fmt.Errorf("tool %q: asOfTimestamp requires every statement to read its tables FOR SYSTEM_TIME AS OF @%s", cfg.Name, tools.AsOfParameterName)
+			}
+		}
+	}
+	allParameters, paramManifest, err = cfg.WithAsOfParameter(cfg.Name, allParameters, paramManifest)
+	if err != nil {
+		return nil, err
+	}
+
 	return Tool{
 		BaseTool: tools.NewBaseTool(
 			cfg,
@@ -92,6 +133,7 @@ func (cfg Config) Initialize(context.Context) (tools.Tool, error) {
 			tools.Manifest{Description: cfg.Description, Parameters: paramManifest, AuthRequired: cfg.AuthRequired},
 			allParameters,
 		),
+		columns: columns,
 	}, nil
 }
 
@@ -100,23 +142,108 @@ var _ tools.Tool = Tool{}
 
 type Tool struct {
 	tools.BaseTool[Config]
+	columns *tools.ColumnShaper
 }
 
 func (t Tool) ToConfig() tools.ToolConfig {
 	return t.Cfg
 }
 
+// statements returns the statement of the tool and those of its variants.
+func (cfg Config) statements() []string {
+	statements := []string{cfg.Statement}
+	for _, v := range cfg.Variants {
+		if v.Statement != "" {
+			statements = append(statements, v.Statement)
+		}
+	}
+	return statements
+}
+
+// asOfPattern matches the references of a statement to the asOfTimestamp
+// parameter.
+var asOfPattern = regexp.MustCompile(`@` + tools.AsOfParameterName + `\b`)
+
+// injectAsOf replaces the references of statement to @asOfTimestamp with the
+// timestamp the caller set, validated, or else the current timestamp.
+func injectAsOf(statement string, params parameters.ParamValues) (string, util.ToolboxError) {
+	t, ok, err := tools.AsOf(params, time.Now())
+	if err != nil {
+		return "", err
+	}
+	value := "CURRENT_TIMESTAMP()"
+	if ok {
+		value = fmt.Sprintf("TIMESTAMP %q", t.UTC().Format(time.RFC3339Nano))
+	}
+	return asOfPattern.ReplaceAllLiteralString(statement, value), nil
+}
+
+// splitAuthorizedView splits an authorized view into its dataset and view.
+func splitAuthorizedView(view string) (string, string, error) {
+	dataset, name, ok := strings.Cut(view, ".")
+	if !ok || !bqutil.ValidTableID(view) || strings.Contains(name, ".") {
+		return "", "", fmt.Errorf("invalid authorizedView %q: must be of the form dataset.view_name", view)
+	}
+	return dataset, name, nil
+}
+
+// ValidateSource checks that the authorized view of the tool, if any, is a
+// view of the project of the source. Sources using client authorization are
+// not checked, as no credentials are available at startup.
+func (t Tool) ValidateSource(ctx context.Context, sp tools.SourceProvider) error {
+	if t.Cfg.AuthorizedView == "" {
+		return nil
+	}
+	source, err := tools.GetCompatibleSource[compatibleSource](sp, t.Cfg.Source, t.Cfg.Name, t.Cfg.Type)
+	if err != nil {
+		return err
+	}
+	if source.UseClientAuthorization() {
+		return nil
+	}
+	bqClient, _, err := source.RetrieveClientAndService("")
+	if err != nil {
+		return err
+	}
+	dataset, view, err := splitAuthorizedView(t.Cfg.AuthorizedView)
+	if err != nil {
+		return err
+	}
+	md, err := bqClient.Dataset(dataset).Table(view).Metadata(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to get authorized view %q of project %q: %w", t.Cfg.AuthorizedView, bqClient.Project(), err)
+	}
+	if md.Type != bigqueryapi.ViewTable {
+		return fmt.Errorf("authorizedView %q of project %q is a %s, not a view", t.Cfg.AuthorizedView, bqClient.Project(), strings.ToLower(string(md.Type)))
+	}
+	return nil
+}
+
+var _ tools.SourceValidator = Tool{}
+
 func (t Tool) Invoke(ctx context.Context, primitiveMgr tools.SourceProvider, params parameters.ParamValues, accessToken tools.AccessToken) (any, util.ToolboxError) {
 	source, err := tools.GetCompatibleSource[compatibleSource](primitiveMgr, t.Cfg.Source, t.Cfg.Name, t.Cfg.Type)
 	if err != nil {
 		return nil, util.NewClientServerError("source used is not compatible with the tool", http.StatusInternalServerError, err)
 	}
 
+	statement, err := t.Cfg.Variants.Select(ctx, t.Cfg.Statement)
+	if err != nil {
+		return nil, util.NewAgentError("unable to select a variant", err)
+	}
+
 	paramsMap := params.AsMap()
-	newStatement, err := parameters.ResolveTemplateParams(t.Cfg.TemplateParameters, t.Cfg.Statement, paramsMap)
+	newStatement, err := parameters.ResolveTemplateParams(t.Cfg.TemplateParameters, statement, paramsMap)
 	if err != nil {
 		return nil, util.NewAgentError("unable to extract template params", err)
 	}
+	if t.Cfg.AsOfTimestamp {
+		var tbErr util.ToolboxError
+		newStatement, tbErr = injectAsOf(newStatement, params)
+		if tbErr != nil {
+			return nil, tbErr
+		}
+	}
 
 	highLevelParams, lowLevelParams, tbErr := buildQueryParameters(t.Cfg.Parameters, paramsMap, newStatement)
 	if tbErr != nil {
@@ -140,17 +267,63 @@ func (t Tool) Invoke(ctx context.Context, primitiveMgr tools.SourceProvider, par
 		return nil, util.NewClientServerError("failed to retrieve BigQuery client", http.StatusInternalServerError, err)
 	}
 
-	dryRunJob, err := bqutil.DryRunQuery(ctx, restService, bqClient.Project(), bqClient.Location, newStatement, lowLevelParams, connProps, source.GetMaximumBytesBilled())
-	if err != nil {
-		return nil, util.ProcessGcpError(err)
+	// queries of an authorized view run in the dataset of the view
+	var dataset string
+	if t.Cfg.AuthorizedView != "" {
+		dataset, _, _ = splitAuthorizedView(t.Cfg.AuthorizedView)
+	}
+	run := func(statement string) (any, error) {
+		dryRunJob, err := bqutil.DryRunQueryInDataset(ctx, restService, bqClient.Project(), bqClient.Location, dataset, statement, lowLevelParams, connProps, source.GetMaximumBytesBilled())
+		if err != nil {
+			return nil, err
+		}
+		statementType := dryRunJob.Statistics.Query.StatementType
+		return source.RunSQLInDataset(ctx, bqClient, dataset, statement, statementType, highLevelParams, connProps, map[string]string{"mcp-toolbox-tool": resourceType})
+	}
+
+	if !t.Cfg.AllowColumnRedaction {
+		resp, err := run(newStatement)
+		if err != nil {
+			return nil, util.ProcessGcpError(err)
+		}
+		return t.columns.Apply(ctx, resp), nil
 	}
 
-	statementType := dryRunJob.Statistics.Query.StatementType
-	resp, err := source.RunSQL(ctx, bqClient, newStatement, statementType, highLevelParams, connProps, map[string]string{"mcp-toolbox-tool": resourceType})
+	resp, redacted, err := runWithRedaction(newStatement, run)
 	if err != nil {
 		return nil, util.ProcessGcpError(err)
 	}
-	return resp, nil
+	return map[string]any{
+		"result":          t.columns.Apply(ctx, resp),
+		"redactedColumns": redacted,
+	}, nil
+}
+
+// runWithRedaction runs a statement, and each time it is denied access to
+// policy-tagged columns, re-runs it without those columns. It returns the
+// result along with the columns that were excluded from it.
+func runWithRedaction(statement string, run func(string) (any, error)) (any, []string, error) {
+	redacted := []string{}
+	current := statement
+	for {
+		resp, err := run(current)
+		if err == nil {
+			return resp, redacted, nil
+		}
+		added := false
+		for _, c := range bigqueryds.DeniedColumns(err) {
+			if !slices.Contains(redacted, c) {
+				redacted = append(redacted, c)
+				added = true
+			}
+		}
+		if !added {
+			// the error is not a column denial, or redacting the columns did
+			// not help
+			return nil, nil, err
+		}
+		current = bigqueryds.RedactColumns(statement, redacted)
+	}
 }
 
 func buildQueryParameters(paramsMetadata parameters.Parameters, paramsMap map[string]any, statement string) ([]bigqueryapi.QueryParameter, []*bigqueryrestapi.QueryParameter, util.ToolboxError) {
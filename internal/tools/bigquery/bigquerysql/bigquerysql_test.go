@@ -15,7 +15,11 @@
 package bigquerysql
 
 import (
+	"context"
+	"fmt"
+	"net/http"
 	"reflect"
+	"strings"
 	"testing"
 
 	bigqueryapi "cloud.google.com/go/bigquery"
@@ -24,6 +28,7 @@ import (
 	"github.com/googleapis/mcp-toolbox/internal/testutils"
 	"github.com/googleapis/mcp-toolbox/internal/tools"
 	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+	"google.golang.org/api/googleapi"
 )
 
 func TestParseFromYamlBigQuery(t *testing.T) {
@@ -67,6 +72,58 @@ func TestParseFromYamlBigQuery(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "with column redaction",
+			in: `
+            kind: tool
+            name: example_tool
+            type: bigquery-sql
+            source: my-instance
+            description: some description
+            statement: |
+                SELECT * FROM customers;
+            allowColumnRedaction: true
+            `,
+			want: server.ToolConfigs{
+				"example_tool": Config{
+					ConfigBase: tools.ConfigBase{
+						Name:         "example_tool",
+						Description:  "some description",
+						AuthRequired: []string{},
+					},
+					Type:                 "bigquery-sql",
+					Source:               "my-instance",
+					Statement:            "SELECT * FROM customers;\n",
+					AllowColumnRedaction: true,
+				},
+			},
+		},
+		{
+			desc: "with authorized view",
+			in: `
+            kind: tool
+            name: example_tool
+            type: bigquery-sql
+            source: my-instance
+            description: some description
+            statement: |
+                SELECT * FROM customer_summary;
+            authorizedView: reporting.customer_summary
+            `,
+			want: server.ToolConfigs{
+				"example_tool": Config{
+					ConfigBase: tools.ConfigBase{
+						Name:         "example_tool",
+						Description:  "some description",
+						AuthRequired: []string{},
+					},
+					Type:           "bigquery-sql",
+					Source:         "my-instance",
+					Statement:      "SELECT * FROM customer_summary;\n",
+					AuthorizedView: "reporting.customer_summary",
+				},
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -352,3 +409,150 @@ func TestBuildQueryParameters_EdgeCases(t *testing.T) {
 		t.Error("Expected low-level 'opt_map' NullFields to contain 'Value'")
 	}
 }
+
+func TestRunWithRedaction(t *testing.T) {
+	deny := func(columns ...string) error {
+		return &googleapi.Error{
+			Code:    http.StatusForbidden,
+			Message: fmt.Sprintf(`User does not have permission to access policy tag "projects/p/locations/us/taxonomies/1/policyTags/2" on column p.d.t.%s.`, strings.Join(columns, ", p.d.t.")),
+		}
+	}
+	tcs := []struct {
+		desc         string
+		denied       map[string]error
+		want         any
+		wantRedacted []string
+		wantErr      bool
+	}{
+		{
+			desc:         "no denial",
+			want:         "ok",
+			wantRedacted: []string{},
+		},
+		{
+			desc: "denied columns",
+			denied: map[string]error{
+				"SELECT * FROM t": deny("ssn"),
+				"SELECT * EXCEPT (`ssn`) FROM (\nSELECT * FROM t\n)": deny("salary"),
+			},
+			want:         "ok",
+			wantRedacted: []string{"ssn", "salary"},
+		},
+		{
+			desc: "no progress",
+			denied: map[string]error{
+				"SELECT * FROM t": deny("ssn"),
+				"SELECT * EXCEPT (`ssn`) FROM (\nSELECT * FROM t\n)": deny("ssn"),
+			},
+			wantErr: true,
+		},
+		{
+			desc:    "other error",
+			denied:  map[string]error{"SELECT * FROM t": fmt.Errorf("syntax error")},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			run := func(statement string) (any, error) {
+				if err, ok := tc.denied[statement]; ok {
+					return nil, err
+				}
+				return "ok", nil
+			}
+			got, redacted, err := runWithRedaction("SELECT * FROM t", run)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got result %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != tc.want {
+				t.Errorf("unexpected result: got %v, want %v", got, tc.want)
+			}
+			if diff := cmp.Diff(tc.wantRedacted, redacted); diff != "" {
+				t.Errorf("unexpected redacted columns (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSplitAuthorizedView(t *testing.T) {
+	tcs := []struct {
+		view        string
+		wantDataset string
+		wantView    string
+		wantErr     bool
+	}{
+		{view: "reporting.customer_summary", wantDataset: "reporting", wantView: "customer_summary"},
+		{view: "customer_summary", wantErr: true},
+		{view: "project.reporting.customer_summary", wantErr: true},
+		{view: "reporting.customer`summary", wantErr: true},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.view, func(t *testing.T) {
+			dataset, view, err := splitAuthorizedView(tc.view)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %q and %q", dataset, view)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if dataset != tc.wantDataset || view != tc.wantView {
+				t.Errorf("unexpected split: got %q and %q, want %q and %q", dataset, view, tc.wantDataset, tc.wantView)
+			}
+		})
+	}
+}
+
+func TestInjectAsOf(t *testing.T) {
+	statement := "SELECT * FROM orders FOR SYSTEM_TIME AS OF @asOfTimestamp WHERE id = @id"
+	got, err := injectAsOf(statement, parameters.ParamValues{{Name: "id", Value: 1}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := "SELECT * FROM orders FOR SYSTEM_TIME AS OF CURRENT_TIMESTAMP() WHERE id = @id"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	got, err = injectAsOf(statement, parameters.ParamValues{{Name: tools.AsOfParameterName, Value: "2024-01-02T10:04:05-05:00"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := `SELECT * FROM orders FOR SYSTEM_TIME AS OF TIMESTAMP "2024-01-02T15:04:05Z" WHERE id = @id`; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if _, err := injectAsOf(statement, parameters.ParamValues{{Name: tools.AsOfParameterName, Value: "2024-01-02 15:04:05; DROP TABLE orders"}}); err == nil {
+		t.Fatal("expected an error for an invalid timestamp")
+	}
+}
+
+func TestInitializeAsOf(t *testing.T) {
+	cfg := Config{
+		ConfigBase: tools.ConfigBase{Name: "orders", Description: "orders"},
+		AsOfConfig: tools.AsOfConfig{AsOfTimestamp: true},
+		Type:       resourceType,
+		Source:     "bq",
+		Statement:  "SELECT * FROM orders",
+	}
+	if _, err := cfg.Initialize(context.Background()); err == nil || !strings.Contains(err.Error(), "FOR SYSTEM_TIME AS OF @asOfTimestamp") {
+		t.Fatalf("got error %v, want an error for a statement without @asOfTimestamp", err)
+	}
+	cfg.Statement = "SELECT * FROM orders FOR SYSTEM_TIME AS OF @asOfTimestamp"
+	tool, err := cfg.Initialize(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	params, err := tool.GetParameters(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(params) != 1 || params[0].GetName() != tools.AsOfParameterName {
+		t.Fatalf("expected the %s parameter, got %v", tools.AsOfParameterName, params)
+	}
+}
@@ -28,7 +28,7 @@ import (
 const executeSQLType string = "clickhouse-execute-sql"
 
 func init() {
-	if !tools.Register(executeSQLType, newExecuteSQLConfig) {
+	if !tools.Register[compatibleSource](executeSQLType, newExecuteSQLConfig) {
 		panic(fmt.Sprintf("tool type %q already registered", executeSQLType))
 	}
 }
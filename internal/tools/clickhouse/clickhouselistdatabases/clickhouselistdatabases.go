@@ -28,7 +28,7 @@ import (
 const listDatabasesType string = "clickhouse-list-databases"
 
 func init() {
-	if !tools.Register(listDatabasesType, newListDatabasesConfig) {
+	if !tools.Register[compatibleSource](listDatabasesType, newListDatabasesConfig) {
 		panic(fmt.Sprintf("tool type %q already registered", listDatabasesType))
 	}
 }
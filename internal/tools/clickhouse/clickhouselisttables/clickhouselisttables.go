@@ -38,7 +38,7 @@ const databaseKey string = "database"
 var validIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
 
 func init() {
-	if !tools.Register(listTablesType, newListTablesConfig) {
+	if !tools.Register[compatibleSource](listTablesType, newListTablesConfig) {
 		panic(fmt.Sprintf("tool type %q already registered", listTablesType))
 	}
 }
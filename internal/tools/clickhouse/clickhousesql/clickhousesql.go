@@ -29,7 +29,7 @@ import (
 const sqlType string = "clickhouse-sql"
 
 func init() {
-	if !tools.Register(sqlType, newConfig) {
+	if !tools.Register[compatibleSource](sqlType, newConfig) {
 		panic(fmt.Sprintf("tool type %q already registered", sqlType))
 	}
 }
@@ -48,9 +48,11 @@ type compatibleSource interface {
 
 type Config struct {
 	tools.ConfigBase   `yaml:",inline"`
+	tools.ColumnConfig `yaml:",inline"`
 	Type               string                 `yaml:"type" validate:"required"`
 	Source             string                 `yaml:"source" validate:"required"`
 	Statement          string                 `yaml:"statement" validate:"required"`
+	Variants           tools.Variants         `yaml:"variants,omitempty"`
 	Parameters         parameters.Parameters  `yaml:"parameters"`
 	TemplateParameters parameters.Parameters  `yaml:"templateParameters"`
 	Annotations        *tools.ToolAnnotations `yaml:"annotations,omitempty"`
@@ -72,6 +74,15 @@ func (cfg Config) Initialize(context.Context) (tools.Tool, error) {
 		return nil, err
 	}
 
+	if err := cfg.Variants.Validate(cfg.Name); err != nil {
+		return nil, err
+	}
+
+	columns, err := cfg.NewColumnShaper(cfg.Name)
+	if err != nil {
+		return nil, err
+	}
+
 	return Tool{
 		BaseTool: tools.NewBaseTool(
 			cfg,
@@ -79,6 +90,7 @@ func (cfg Config) Initialize(context.Context) (tools.Tool, error) {
 			tools.Manifest{Description: cfg.Description, Parameters: paramManifest, AuthRequired: cfg.AuthRequired},
 			allParameters,
 		),
+		columns: columns,
 	}, nil
 }
 
@@ -86,6 +98,7 @@ var _ tools.Tool = Tool{}
 
 type Tool struct {
 	tools.BaseTool[Config]
+	columns *tools.ColumnShaper
 }
 
 func (t Tool) ToConfig() tools.ToolConfig {
@@ -98,8 +111,13 @@ func (t Tool) Invoke(ctx context.Context, primitiveMgr tools.SourceProvider, par
 		return nil, util.NewClientServerError("source used is not compatible with the tool", http.StatusInternalServerError, err)
 	}
 
+	statement, err := t.Cfg.Variants.Select(ctx, t.Cfg.Statement)
+	if err != nil {
+		return nil, util.NewAgentError("unable to select a variant", err)
+	}
+
 	paramsMap := params.AsMap()
-	newStatement, err := parameters.ResolveTemplateParams(t.Cfg.TemplateParameters, t.Cfg.Statement, paramsMap)
+	newStatement, err := parameters.ResolveTemplateParams(t.Cfg.TemplateParameters, statement, paramsMap)
 	if err != nil {
 		return nil, util.NewAgentError("unable to extract template params", err)
 	}
@@ -113,7 +131,7 @@ func (t Tool) Invoke(ctx context.Context, primitiveMgr tools.SourceProvider, par
 	if err != nil {
 		return nil, util.ProcessGeneralError(err)
 	}
-	return resp, nil
+	return t.columns.Apply(ctx, resp), nil
 }
 
 func (t Tool) EmbedParams(ctx context.Context, paramValues parameters.ParamValues, embeddingModelsMap map[string]embeddingmodels.EmbeddingModel) (parameters.ParamValues, error) {
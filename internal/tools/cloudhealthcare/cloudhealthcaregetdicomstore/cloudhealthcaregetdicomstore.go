@@ -31,7 +31,7 @@ import (
 const resourceType string = "cloud-healthcare-get-dicom-store"
 
 func init() {
-	if !tools.Register(resourceType, newConfig) {
+	if !tools.Register[compatibleSource](resourceType, newConfig) {
 		panic(fmt.Sprintf("tool type %q already registered", resourceType))
 	}
 }
@@ -30,7 +30,7 @@ const resourceType string = "cloud-logging-admin-list-log-names"
 const defaultLimit int = 200
 
 func init() {
-	if !tools.Register(resourceType, newConfig) {
+	if !tools.Register[compatibleSource](resourceType, newConfig) {
 		panic(fmt.Sprintf("tool type %q already registered", resourceType))
 	}
 }
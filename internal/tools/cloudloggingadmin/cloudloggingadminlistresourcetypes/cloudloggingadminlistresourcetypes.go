@@ -28,7 +28,7 @@ import (
 const resourceType string = "cloud-logging-admin-list-resource-types"
 
 func init() {
-	if !tools.Register(resourceType, newConfig) {
+	if !tools.Register[compatibleSource](resourceType, newConfig) {
 		panic(fmt.Sprintf("tool type %q already registered", resourceType))
 	}
 }
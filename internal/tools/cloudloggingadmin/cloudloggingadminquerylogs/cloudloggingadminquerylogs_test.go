@@ -97,7 +97,7 @@ func TestFailParseFromYaml(t *testing.T) {
 			type: cloud-logging-admin-query-logs
 			description: some description
 			`,
-			err: `Key: 'Config.Source' Error:Field validation for 'Source' failed on the 'required' tag`,
+			err: `tools[example_tool].source is required; add a "source" field`,
 		},
 	}
 	for _, tc := range tcs {
@@ -204,7 +204,7 @@ func TestFailParseFromYamlCloudMonitoring(t *testing.T) {
 			type: cloud-monitoring-query-prometheus
 			description: some description
 			`,
-			err: `Key: 'Config.Source' Error:Field validation for 'Source' failed on the 'required' tag`,
+			err: `tools[example_tool].source is required; add a "source" field`,
 		},
 	}
 	for _, tc := range tcs {
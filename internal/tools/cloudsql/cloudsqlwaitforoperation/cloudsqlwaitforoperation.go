@@ -72,7 +72,7 @@ Please refer to the official documentation for guidance on deploying the toolbox
 `
 
 func init() {
-	if !tools.Register(resourceType, newConfig) {
+	if !tools.Register[compatibleSource](resourceType, newConfig) {
 		panic(fmt.Sprintf("tool type %q already registered", resourceType))
 	}
 }
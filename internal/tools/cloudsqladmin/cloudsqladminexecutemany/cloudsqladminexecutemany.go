@@ -28,7 +28,7 @@ import (
 const resourceType string = "cloud-sql-admin-execute-many"
 
 func init() {
-	if !tools.Register(resourceType, newConfig) {
+	if !tools.Register[compatibleSource](resourceType, newConfig) {
 		panic(fmt.Sprintf("tool type %q already registered", resourceType))
 	}
 }
@@ -32,7 +32,7 @@ import (
 const resourceType string = "cockroachdb-sql"
 
 func init() {
-	if !tools.Register(resourceType, newConfig) {
+	if !tools.Register[compatibleSource](resourceType, newConfig) {
 		panic(fmt.Sprintf("tool type %q already registered", resourceType))
 	}
 }
@@ -53,9 +53,11 @@ var _ compatibleSource = &cockroachdb.Source{}
 
 type Config struct {
 	tools.ConfigBase   `yaml:",inline"`
+	tools.ColumnConfig `yaml:",inline"`
 	Type               string                 `yaml:"type" validate:"required"`
 	Source             string                 `yaml:"source" validate:"required"`
 	Statement          string                 `yaml:"statement" validate:"required"`
+	Variants           tools.Variants         `yaml:"variants,omitempty"`
 	Parameters         parameters.Parameters  `yaml:"parameters"`
 	TemplateParameters parameters.Parameters  `yaml:"templateParameters"`
 	Annotations        *tools.ToolAnnotations `yaml:"annotations,omitempty"`
@@ -77,6 +79,15 @@ func (cfg Config) Initialize(context.Context) (tools.Tool, error) {
 		return nil, err
 	}
 
+	if err := cfg.Variants.Validate(cfg.Name); err != nil {
+		return nil, err
+	}
+
+	columns, err := cfg.NewColumnShaper(cfg.Name)
+	if err != nil {
+		return nil, err
+	}
+
 	return Tool{
 		BaseTool: tools.NewBaseTool(
 			cfg,
@@ -84,6 +95,7 @@ func (cfg Config) Initialize(context.Context) (tools.Tool, error) {
 			tools.Manifest{Description: cfg.Description, Parameters: paramManifest, AuthRequired: cfg.AuthRequired},
 			allParameters,
 		),
+		columns: columns,
 	}, nil
 }
 
@@ -91,6 +103,7 @@ var _ tools.Tool = Tool{}
 
 type Tool struct {
 	tools.BaseTool[Config]
+	columns *tools.ColumnShaper
 }
 
 func (t Tool) ToConfig() tools.ToolConfig {
@@ -122,8 +135,13 @@ func (t Tool) Invoke(ctx context.Context, primitiveMgr tools.SourceProvider, par
 		return nil, util.NewClientServerError("source used is not compatible with the tool", http.StatusInternalServerError, err)
 	}
 
+	statement, err := t.Cfg.Variants.Select(ctx, t.Cfg.Statement)
+	if err != nil {
+		return nil, util.NewAgentError("unable to select a variant", err)
+	}
+
 	paramsMap := params.AsMap()
-	newStatement, err := parameters.ResolveTemplateParams(t.Cfg.TemplateParameters, t.Cfg.Statement, paramsMap)
+	newStatement, err := parameters.ResolveTemplateParams(t.Cfg.TemplateParameters, statement, paramsMap)
 	if err != nil {
 		return nil, util.NewAgentError(fmt.Sprintf("unable to resolve template params: %v", err), err)
 	}
@@ -158,5 +176,5 @@ func (t Tool) Invoke(ctx context.Context, primitiveMgr tools.SourceProvider, par
 		return nil, util.ProcessGeneralError(fmt.Errorf("unable to execute query: %w", err))
 	}
 
-	return out, nil
+	return t.columns.Apply(ctx, out), nil
 }
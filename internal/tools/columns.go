@@ -0,0 +1,370 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/orderedmap"
+)
+
+// MaskedValue replaces the values of masked columns.
+const MaskedValue = "****"
+
+// ColumnConfig unnests, renames, projects and masks the result columns of a
+// SQL tool. Tool configs embed it inline.
+type ColumnConfig struct {
+	// FlatMapColumn names a JSON array column, as returned by the source,
+	// whose elements are unwrapped into a result row each.
+	FlatMapColumn string `yaml:"flatMapColumn,omitempty"`
+	// ColumnMapping renames result columns, from the name returned by the
+	// source to the name returned to the agent.
+	ColumnMapping map[string]string `yaml:"columnMapping,omitempty"`
+	// ColumnAliases renames the columns aliased in the statement, from the
+	// alias to the name returned to the agent. Unlike ColumnMapping, aliases
+	// match whatever case the source folds them to.
+	ColumnAliases map[string]string `yaml:"columnAliases,omitempty"`
+	// ProjectColumns restricts the result to the listed columns, in their
+	// renamed form.
+	ProjectColumns []string `yaml:"projectColumns,omitempty"`
+	// MaskColumns lists the columns, in their renamed form, whose values are
+	// replaced by MaskedValue.
+	MaskColumns []string `yaml:"maskColumns,omitempty"`
+}
+
+// NewColumnShaper validates the config and returns the shaper applying it
+// to the results of toolName, or nil if the config is empty.
+func (c ColumnConfig) NewColumnShaper(toolName string) (*ColumnShaper, error) {
+	if c.FlatMapColumn == "" && len(c.ColumnMapping) == 0 && len(c.ColumnAliases) == 0 && len(c.ProjectColumns) == 0 && len(c.MaskColumns) == 0 {
+		return nil, nil
+	}
+	renamed := make(map[string]string, len(c.ColumnMapping))
+	for from, to := range c.ColumnMapping {
+		if from == "" || to == "" {
+			return nil, fmt.Errorf("columnMapping of tool %q cannot map %q to %q: column names cannot be empty", toolName, from, to)
+		}
+		if other, ok := renamed[to]; ok {
+			first, second := min(from, other), max(from, other)
+			return nil, fmt.Errorf("columnMapping of tool %q maps both %q and %q to %q", toolName, first, second, to)
+		}
+		renamed[to] = from
+	}
+	aliases := make(map[string]string, len(c.ColumnAliases))
+	aliasNames := make(map[string]string, len(c.ColumnAliases))
+	for alias, to := range c.ColumnAliases {
+		if alias == "" || to == "" {
+			return nil, fmt.Errorf("columnAliases of tool %q cannot map %q to %q: column names cannot be empty", toolName, alias, to)
+		}
+		key := strings.ToLower(alias)
+		if other, ok := aliasNames[key]; ok {
+			first, second := min(alias, other), max(alias, other)
+			return nil, fmt.Errorf("columnAliases of tool %q lists both %q and %q, which differ only in case", toolName, first, second)
+		}
+		for from := range c.ColumnMapping {
+			if strings.ToLower(from) == key {
+				return nil, fmt.Errorf("column %q of tool %q is renamed by both columnMapping and columnAliases", from, toolName)
+			}
+		}
+		if other, ok := renamed[to]; ok {
+			first, second := min(alias, other), max(alias, other)
+			return nil, fmt.Errorf("columnMapping and columnAliases of tool %q map both %q and %q to %q", toolName, first, second, to)
+		}
+		renamed[to] = alias
+		aliases[key] = to
+		aliasNames[key] = alias
+	}
+	project := make(map[string]bool, len(c.ProjectColumns))
+	for _, name := range c.ProjectColumns {
+		if project[name] {
+			return nil, fmt.Errorf("projectColumns of tool %q lists %q more than once", toolName, name)
+		}
+		project[name] = true
+	}
+	mask := make(map[string]bool, len(c.MaskColumns))
+	for _, name := range c.MaskColumns {
+		mask[name] = true
+	}
+	return &ColumnShaper{toolName: toolName, cfg: c, aliases: aliases, project: project, mask: mask}, nil
+}
+
+// ColumnShaper unnests, renames, projects and masks the columns of result
+// rows.
+//
+// The steps are applied in a fixed order so that the configuration reads
+// predictably:
+//  1. flatMapColumn replaces each row by a row per element of its JSON
+//     array column. The fields of object elements become columns, in place
+//     of the array column and taking precedence over the other columns;
+//     other elements are kept under the name of the array column. Rows
+//     whose array is null or empty are dropped;
+//  2. columnMapping and columnAliases rename the columns, including those
+//     of the unwrapped elements;
+//  3. projectColumns keeps only the listed columns, matched against the
+//     renamed names;
+//  4. maskColumns masks the listed columns, also matched against the
+//     renamed names. A column is thus masked under the name the agent sees,
+//     whatever the source calls it.
+//
+// Columns of the mapping or projection missing from the result are not an
+// error; they are logged once per tool.
+type ColumnShaper struct {
+	toolName string
+	cfg      ColumnConfig
+	aliases  map[string]string
+	project  map[string]bool
+	mask     map[string]bool
+	warnOnce sync.Once
+	// flatMapWarnOnce logs the first value of the flatMapColumn that is not
+	// a JSON array.
+	flatMapWarnOnce sync.Once
+}
+
+// Apply shapes the rows of result, a slice of orderedmap.Row or
+// map[string]any rows. Other results are returned unchanged. A nil shaper
+// returns result unchanged.
+func (s *ColumnShaper) Apply(ctx context.Context, result any) any {
+	if s == nil {
+		return result
+	}
+	rows, ok := result.([]any)
+	if !ok {
+		return result
+	}
+	if s.cfg.FlatMapColumn != "" {
+		rows = s.flatMap(ctx, rows)
+	}
+	shaped := make([]any, len(rows))
+	for i, row := range rows {
+		switch r := row.(type) {
+		case orderedmap.Row:
+			shaped[i] = s.shapeRow(ctx, r)
+		case map[string]any:
+			shaped[i] = s.shapeMap(ctx, r)
+		default:
+			shaped[i] = row
+		}
+	}
+	return shaped
+}
+
+// flatMap replaces each row by a row per element of its flatMapColumn.
+func (s *ColumnShaper) flatMap(ctx context.Context, rows []any) []any {
+	out := make([]any, 0, len(rows))
+	for _, row := range rows {
+		switch r := row.(type) {
+		case orderedmap.Row:
+			i := slices.IndexFunc(r.Columns, func(c orderedmap.Column) bool { return c.Name == s.cfg.FlatMapColumn })
+			if i < 0 {
+				out = append(out, row)
+				continue
+			}
+			elems, ok := s.jsonArray(ctx, r.Columns[i].Value)
+			if !ok {
+				out = append(out, row)
+				continue
+			}
+			for _, elem := range elems {
+				out = append(out, s.flatMapRow(r, i, elem))
+			}
+		case map[string]any:
+			value, present := r[s.cfg.FlatMapColumn]
+			if !present {
+				out = append(out, row)
+				continue
+			}
+			elems, ok := s.jsonArray(ctx, value)
+			if !ok {
+				out = append(out, row)
+				continue
+			}
+			for _, elem := range elems {
+				m := make(map[string]any, len(r))
+				for name, v := range r {
+					if name != s.cfg.FlatMapColumn {
+						m[name] = v
+					}
+				}
+				if fields, ok := elem.(map[string]any); ok {
+					maps.Copy(m, fields)
+				} else {
+					m[s.cfg.FlatMapColumn] = elem
+				}
+				out = append(out, m)
+			}
+		default:
+			out = append(out, row)
+		}
+	}
+	return out
+}
+
+// flatMapRow returns r with its column i replaced by elem: by the fields of
+// elem, in sorted order, if it is an object, or else by elem itself.
+func (s *ColumnShaper) flatMapRow(r orderedmap.Row, i int, elem any) orderedmap.Row {
+	fields, isObject := elem.(map[string]any)
+	out := orderedmap.Row{Columns: make([]orderedmap.Column, 0, len(r.Columns)+len(fields))}
+	for j, col := range r.Columns {
+		switch {
+		case j == i && isObject:
+			for _, name := range slices.Sorted(maps.Keys(fields)) {
+				out.Add(name, fields[name])
+			}
+		case j == i:
+			out.Add(col.Name, elem)
+		default:
+			// the fields of the element take precedence
+			if _, ok := fields[col.Name]; !ok {
+				out.Add(col.Name, col.Value)
+			}
+		}
+	}
+	return out
+}
+
+// jsonArray returns the elements of value, a decoded JSON array or its
+// encoding. Null is an empty array. Other values are logged, once per tool,
+// and reported as not an array.
+func (s *ColumnShaper) jsonArray(ctx context.Context, value any) ([]any, bool) {
+	var raw []byte
+	switch v := value.(type) {
+	case nil:
+		return nil, true
+	case []any:
+		return v, true
+	case string:
+		raw = []byte(v)
+	case []byte:
+		raw = v
+	}
+	var elems []any
+	if raw == nil || json.Unmarshal(raw, &elems) != nil {
+		s.flatMapWarnOnce.Do(func() {
+			if logger, err := util.LoggerFromContext(ctx); err == nil {
+				logger.WarnContext(ctx, fmt.Sprintf("flatMapColumn %q of tool %q is not a JSON array, its rows are returned unchanged", s.cfg.FlatMapColumn, s.toolName))
+			}
+		})
+		return nil, false
+	}
+	return elems, true
+}
+
+func (s *ColumnShaper) shapeRow(ctx context.Context, r orderedmap.Row) orderedmap.Row {
+	names := make([]string, len(r.Columns))
+	for i, col := range r.Columns {
+		names[i] = col.Name
+	}
+	s.warnMissing(ctx, names)
+
+	out := orderedmap.Row{Columns: make([]orderedmap.Column, 0, len(r.Columns))}
+	for _, col := range r.Columns {
+		name := s.rename(col.Name)
+		if s.keep(name) {
+			out.Add(name, s.maskValue(name, col.Value))
+		}
+	}
+	return out
+}
+
+func (s *ColumnShaper) shapeMap(ctx context.Context, r map[string]any) map[string]any {
+	names := make([]string, 0, len(r))
+	for name := range r {
+		names = append(names, name)
+	}
+	s.warnMissing(ctx, names)
+
+	out := make(map[string]any, len(r))
+	for name, value := range r {
+		name = s.rename(name)
+		if s.keep(name) {
+			out[name] = s.maskValue(name, value)
+		}
+	}
+	return out
+}
+
+func (s *ColumnShaper) rename(name string) string {
+	if to, ok := s.cfg.ColumnMapping[name]; ok {
+		return to
+	}
+	if to, ok := s.aliases[strings.ToLower(name)]; ok {
+		return to
+	}
+	return name
+}
+
+func (s *ColumnShaper) keep(name string) bool {
+	return len(s.project) == 0 || s.project[name]
+}
+
+func (s *ColumnShaper) maskValue(name string, value any) any {
+	if s.mask[name] && value != nil {
+		return MaskedValue
+	}
+	return value
+}
+
+// warnMissing logs, once per tool, the mapped and projected columns that are
+// missing from a result row.
+func (s *ColumnShaper) warnMissing(ctx context.Context, names []string) {
+	s.warnOnce.Do(func() {
+		var unmapped, unaliased, unprojected []string
+		renamed := make([]string, len(names))
+		folded := make([]string, len(names))
+		for i, name := range names {
+			renamed[i] = s.rename(name)
+			folded[i] = strings.ToLower(name)
+		}
+		for from := range s.cfg.ColumnMapping {
+			if !slices.Contains(names, from) {
+				unmapped = append(unmapped, from)
+			}
+		}
+		for alias := range s.cfg.ColumnAliases {
+			if !slices.Contains(folded, strings.ToLower(alias)) {
+				unaliased = append(unaliased, alias)
+			}
+		}
+		for _, name := range s.cfg.ProjectColumns {
+			if !slices.Contains(renamed, name) {
+				unprojected = append(unprojected, name)
+			}
+		}
+		if len(unmapped) == 0 && len(unaliased) == 0 && len(unprojected) == 0 {
+			return
+		}
+		logger, err := util.LoggerFromContext(ctx)
+		if err != nil {
+			return
+		}
+		if len(unmapped) > 0 {
+			slices.Sort(unmapped)
+			logger.WarnContext(ctx, fmt.Sprintf("columnMapping of tool %q references columns missing from its result: %s", s.toolName, strings.Join(unmapped, ", ")))
+		}
+		if len(unaliased) > 0 {
+			slices.Sort(unaliased)
+			logger.WarnContext(ctx, fmt.Sprintf("columnAliases of tool %q references aliases missing from its result: %s", s.toolName, strings.Join(unaliased, ", ")))
+		}
+		if len(unprojected) > 0 {
+			logger.WarnContext(ctx, fmt.Sprintf("projectColumns of tool %q lists columns missing from its result: %s", s.toolName, strings.Join(unprojected, ", ")))
+		}
+	})
+}
@@ -0,0 +1,259 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools_test
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/internal/log"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/orderedmap"
+)
+
+func row(kv ...any) orderedmap.Row {
+	var r orderedmap.Row
+	for i := 0; i < len(kv); i += 2 {
+		r.Add(kv[i].(string), kv[i+1])
+	}
+	return r
+}
+
+func TestColumnShaper(t *testing.T) {
+	tcs := []struct {
+		desc string
+		cfg  tools.ColumnConfig
+		in   []any
+		want []any
+	}{
+		{
+			desc: "rename",
+			cfg:  tools.ColumnConfig{ColumnMapping: map[string]string{"usr_nm": "user_name"}},
+			in:   []any{row("id", 1, "usr_nm", "alice")},
+			want: []any{row("id", 1, "user_name", "alice")},
+		},
+		{
+			desc: "project renamed columns",
+			cfg: tools.ColumnConfig{
+				ColumnMapping:  map[string]string{"usr_nm": "user_name"},
+				ProjectColumns: []string{"user_name"},
+			},
+			in:   []any{row("id", 1, "usr_nm", "alice"), map[string]any{"id": 2, "usr_nm": "bob"}},
+			want: []any{row("user_name", "alice"), map[string]any{"user_name": "bob"}},
+		},
+		{
+			desc: "mask matches renamed columns",
+			cfg: tools.ColumnConfig{
+				ColumnMapping: map[string]string{"ssn_raw": "ssn", "email": "contact"},
+				MaskColumns:   []string{"ssn", "email"},
+			},
+			in:   []any{row("ssn_raw", "123-45-6789", "email", "a@example.com", "note", nil)},
+			want: []any{row("ssn", tools.MaskedValue, "contact", "a@example.com", "note", nil)},
+		},
+		{
+			desc: "aliases match folded case",
+			cfg: tools.ColumnConfig{
+				ColumnMapping:  map[string]string{"usr_nm": "user_name"},
+				ColumnAliases:  map[string]string{"TotalRows": "totalRows"},
+				ProjectColumns: []string{"user_name", "totalRows"},
+			},
+			in:   []any{row("usr_nm", "alice", "totalrows", 3), map[string]any{"usr_nm": "bob", "TOTALROWS": 4}},
+			want: []any{row("user_name", "alice", "totalRows", 3), map[string]any{"user_name": "bob", "totalRows": 4}},
+		},
+		{
+			desc: "project missing columns",
+			cfg:  tools.ColumnConfig{ProjectColumns: []string{"id", "missing"}},
+			in:   []any{row("id", 1, "name", "alice")},
+			want: []any{row("id", 1)},
+		},
+		{
+			desc: "flat map objects",
+			cfg:  tools.ColumnConfig{FlatMapColumn: "items"},
+			in: []any{
+				row("order", 1, "items", []any{map[string]any{"sku": "a", "qty": 2}, map[string]any{"sku": "b", "qty": 1}}, "total", 3),
+				row("order", 2, "items", `[{"sku": "c", "qty": 5}]`, "total", 5),
+			},
+			want: []any{
+				row("order", 1, "qty", 2, "sku", "a", "total", 3),
+				row("order", 1, "qty", 1, "sku", "b", "total", 3),
+				row("order", 2, "qty", float64(5), "sku", "c", "total", 5),
+			},
+		},
+		{
+			desc: "flat map drops empty arrays",
+			cfg:  tools.ColumnConfig{FlatMapColumn: "items"},
+			in:   []any{row("order", 1, "items", nil), row("order", 2, "items", []byte("[]")), row("order", 3, "items", []any{"x"})},
+			want: []any{row("order", 3, "items", "x")},
+		},
+		{
+			desc: "flat map fields take precedence",
+			cfg:  tools.ColumnConfig{FlatMapColumn: "items"},
+			in:   []any{map[string]any{"id": 1, "items": []any{map[string]any{"id": 7, "name": "a"}}}, row("id", 2, "items", []any{map[string]any{"id": nil}})},
+			want: []any{map[string]any{"id": 7, "name": "a"}, row("id", nil)},
+		},
+		{
+			desc: "flat map before renaming and projection",
+			cfg: tools.ColumnConfig{
+				FlatMapColumn:  "items",
+				ColumnMapping:  map[string]string{"sku": "product"},
+				ProjectColumns: []string{"order", "product"},
+			},
+			in:   []any{row("order", 1, "items", []any{map[string]any{"sku": "a", "qty": 2}})},
+			want: []any{row("order", 1, "product", "a")},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			s, err := tc.cfg.NewColumnShaper("my-tool")
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			got := s.Apply(context.Background(), tc.in)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("unexpected result (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestColumnShaperWarnsOnce(t *testing.T) {
+	var logs strings.Builder
+	logger, err := log.NewStdLogger(io.Discard, &logs, "info")
+	if err != nil {
+		t.Fatalf("unable to initialize logger: %s", err)
+	}
+	ctx := util.WithLogger(context.Background(), logger)
+
+	cfg := tools.ColumnConfig{
+		ColumnMapping:  map[string]string{"old": "new"},
+		ColumnAliases:  map[string]string{"Total": "total"},
+		ProjectColumns: []string{"id", "missing"},
+	}
+	s, err := cfg.NewColumnShaper("my-tool")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	s.Apply(ctx, []any{row("id", 1)})
+	s.Apply(ctx, []any{row("id", 2)})
+
+	got := logs.String()
+	for _, want := range []string{
+		`columnMapping of tool "my-tool" references columns missing from its result: old`,
+		`columnAliases of tool "my-tool" references aliases missing from its result: Total`,
+		`projectColumns of tool "my-tool" lists columns missing from its result: missing`,
+	} {
+		// the logger quotes messages
+		if n := strings.Count(got, strconv.Quote(want)); n != 1 {
+			t.Errorf("expected %q to be logged once, got %d times in logs: %s", want, n, got)
+		}
+	}
+}
+
+func TestColumnShaperFlatMapNotArray(t *testing.T) {
+	var logs strings.Builder
+	logger, err := log.NewStdLogger(io.Discard, &logs, "info")
+	if err != nil {
+		t.Fatalf("unable to initialize logger: %s", err)
+	}
+	ctx := util.WithLogger(context.Background(), logger)
+
+	s, err := tools.ColumnConfig{FlatMapColumn: "items"}.NewColumnShaper("my-tool")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	in := []any{row("order", 1, "items", `{"sku": "a"}`), row("order", 2, "items", 42)}
+	if diff := cmp.Diff(in, s.Apply(ctx, in)); diff != "" {
+		t.Errorf("unexpected result (-want +got):\n%s", diff)
+	}
+	want := `flatMapColumn "items" of tool "my-tool" is not a JSON array, its rows are returned unchanged`
+	if n := strings.Count(logs.String(), strconv.Quote(want)); n != 1 {
+		t.Errorf("expected %q to be logged once, got %d times in logs: %s", want, n, logs.String())
+	}
+}
+
+func TestColumnShaperNil(t *testing.T) {
+	s, err := tools.ColumnConfig{}.NewColumnShaper("my-tool")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if s != nil {
+		t.Fatalf("expected no shaper for an empty config")
+	}
+	in := []any{row("id", 1)}
+	if diff := cmp.Diff(in, s.Apply(context.Background(), in)); diff != "" {
+		t.Errorf("unexpected result (-want +got):\n%s", diff)
+	}
+}
+
+func TestColumnConfigErrors(t *testing.T) {
+	tcs := []struct {
+		desc string
+		cfg  tools.ColumnConfig
+		err  string
+	}{
+		{
+			desc: "duplicate target",
+			cfg:  tools.ColumnConfig{ColumnMapping: map[string]string{"a": "c", "b": "c"}},
+			err:  `columnMapping of tool "my-tool" maps both "a" and "b" to "c"`,
+		},
+		{
+			desc: "empty name",
+			cfg:  tools.ColumnConfig{ColumnMapping: map[string]string{"a": ""}},
+			err:  `columnMapping of tool "my-tool" cannot map "a" to "": column names cannot be empty`,
+		},
+		{
+			desc: "alias target taken by mapping",
+			cfg: tools.ColumnConfig{
+				ColumnMapping: map[string]string{"a": "c"},
+				ColumnAliases: map[string]string{"b": "c"},
+			},
+			err: `columnMapping and columnAliases of tool "my-tool" map both "a" and "b" to "c"`,
+		},
+		{
+			desc: "column mapped and aliased",
+			cfg: tools.ColumnConfig{
+				ColumnMapping: map[string]string{"total": "a"},
+				ColumnAliases: map[string]string{"Total": "b"},
+			},
+			err: `column "total" of tool "my-tool" is renamed by both columnMapping and columnAliases`,
+		},
+		{
+			desc: "aliases differing in case",
+			cfg:  tools.ColumnConfig{ColumnAliases: map[string]string{"total": "a", "TOTAL": "b"}},
+			err:  `columnAliases of tool "my-tool" lists both "TOTAL" and "total", which differ only in case`,
+		},
+		{
+			desc: "duplicate projection",
+			cfg:  tools.ColumnConfig{ProjectColumns: []string{"a", "a"}},
+			err:  `projectColumns of tool "my-tool" lists "a" more than once`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := tc.cfg.NewColumnShaper("my-tool")
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			if err.Error() != tc.err {
+				t.Errorf("unexpected error: got %q, want %q", err, tc.err)
+			}
+		})
+	}
+}
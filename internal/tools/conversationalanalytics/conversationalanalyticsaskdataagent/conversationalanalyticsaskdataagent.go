@@ -37,7 +37,7 @@ import (
 const resourceType string = "conversational-analytics-ask-data-agent"
 
 func init() {
-	if !tools.Register(resourceType, newConfig) {
+	if !tools.Register[compatibleSource](resourceType, newConfig) {
 		panic(fmt.Sprintf("tool type %q already registered", resourceType))
 	}
 }
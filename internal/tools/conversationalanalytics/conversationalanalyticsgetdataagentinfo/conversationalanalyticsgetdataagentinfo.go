@@ -36,7 +36,7 @@ import (
 const resourceType string = "conversational-analytics-get-data-agent-info"
 
 func init() {
-	if !tools.Register(resourceType, newConfig) {
+	if !tools.Register[compatibleSource](resourceType, newConfig) {
 		panic(fmt.Sprintf("tool type %q already registered", resourceType))
 	}
 }
@@ -35,7 +35,7 @@ import (
 const resourceType string = "conversational-analytics-list-accessible-data-agents"
 
 func init() {
-	if !tools.Register(resourceType, newConfig) {
+	if !tools.Register[compatibleSource](resourceType, newConfig) {
 		panic(fmt.Sprintf("tool type %q already registered", resourceType))
 	}
 }
@@ -15,7 +15,9 @@
 package couchbase
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 
@@ -28,8 +30,16 @@ import (
 
 const resourceType string = "couchbase-sql"
 
+// defaultIDField is the field the document key is injected under.
+const defaultIDField = "_key"
+
+// metaIDAlias is the alias statements select the document key under, e.g.
+// `SELECT META(h).id AS __meta_id, h.* FROM hotel h`. Query results carry no
+// document keys unless the statement selects them.
+const metaIDAlias = "__meta_id"
+
 func init() {
-	if !tools.Register(resourceType, newConfig) {
+	if !tools.Register[compatibleSource](resourceType, newConfig) {
 		panic(fmt.Sprintf("tool type %q already registered", resourceType))
 	}
 }
@@ -55,6 +65,8 @@ type Config struct {
 	Parameters         parameters.Parameters  `yaml:"parameters"`
 	TemplateParameters parameters.Parameters  `yaml:"templateParameters"`
 	Annotations        *tools.ToolAnnotations `yaml:"annotations,omitempty"`
+
+	tools.DocumentIDConfig `yaml:",inline"`
 }
 
 var _ tools.ToolConfig = Config{}
@@ -114,5 +126,44 @@ func (t Tool) Invoke(ctx context.Context, primitiveMgr tools.SourceProvider, par
 	if err != nil {
 		return nil, util.ProcessGeneralError(err)
 	}
-	return resp, nil
+	rows, ok := resp.([]any)
+	if !ok {
+		return resp, nil
+	}
+	if err := injectDocumentKeys(rows, t.Cfg.DocumentIDConfig); err != nil {
+		return nil, util.NewAgentError("error injecting document keys", err)
+	}
+	return rows, nil
+}
+
+// injectDocumentKeys moves the document key selected under metaIDAlias to the
+// configured ID field of each row. Rows that do not select the key are left
+// untouched.
+func injectDocumentKeys(rows []any, cfg tools.DocumentIDConfig) error {
+	field := cfg.IDFieldOrDefault(defaultIDField)
+	for i, row := range rows {
+		raw, ok := row.(json.RawMessage)
+		if !ok || !bytes.Contains(raw, []byte(metaIDAlias)) {
+			continue
+		}
+		var doc map[string]any
+		decoder := json.NewDecoder(bytes.NewReader(raw))
+		decoder.UseNumber()
+		if err := decoder.Decode(&doc); err != nil {
+			// The row is not an object.
+			continue
+		}
+		key, ok := doc[metaIDAlias]
+		if !ok {
+			continue
+		}
+		delete(doc, metaIDAlias)
+		if cfg.IncludeIDEnabled() {
+			if err := tools.InjectDocumentID(doc, field, key); err != nil {
+				return fmt.Errorf("row %d: %w", i, err)
+			}
+		}
+		rows[i] = doc
+	}
+	return nil
 }
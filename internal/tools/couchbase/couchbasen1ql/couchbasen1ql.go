@@ -0,0 +1,151 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package couchbasen1ql
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"text/template"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+const resourceType string = "couchbase-n1ql"
+
+// collectionKey is the template key statements use to reference the
+// configured collection, e.g. `SELECT c.* FROM {{.collection}} c`.
+const collectionKey = "collection"
+
+func init() {
+	if !tools.Register[compatibleSource](resourceType, newConfig) {
+		panic(fmt.Sprintf("tool type %q already registered", resourceType))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{ConfigBase: tools.ConfigBase{Name: name}}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type compatibleSource interface {
+	CouchbaseCollection() string
+	RunN1QL(context.Context, string, map[string]any, bool) (any, error)
+}
+
+type Config struct {
+	tools.ConfigBase `yaml:",inline"`
+	Type             string                 `yaml:"type" validate:"required"`
+	Source           string                 `yaml:"source" validate:"required"`
+	Statement        string                 `yaml:"statement" validate:"required"`
+	Collection       string                 `yaml:"collection"`
+	ReadOnly         bool                   `yaml:"readOnly"`
+	Parameters       parameters.Parameters  `yaml:"parameters"`
+	Annotations      *tools.ToolAnnotations `yaml:"annotations,omitempty"`
+}
+
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigType() string {
+	return resourceType
+}
+
+func (cfg Config) Initialize(context.Context) (tools.Tool, error) {
+	if cfg.Description == "" {
+		return nil, fmt.Errorf("description is required for tool %q", cfg.Name)
+	}
+	if err := parameters.CheckDuplicateParameters(cfg.Parameters); err != nil {
+		return nil, err
+	}
+	statement, err := template.New("statement").Option("missingkey=error").Parse(cfg.Statement)
+	if err != nil {
+		return nil, fmt.Errorf("invalid statement for tool %q: %w", cfg.Name, err)
+	}
+
+	defaultAnnotations := tools.NewDestructiveAnnotations
+	if cfg.ReadOnly {
+		defaultAnnotations = tools.NewReadOnlyAnnotations
+	}
+	return Tool{
+		BaseTool: tools.NewBaseTool(
+			cfg,
+			tools.GetAnnotationsOrDefault(cfg.Annotations, defaultAnnotations),
+			tools.Manifest{Description: cfg.Description, Parameters: cfg.Parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+			cfg.Parameters,
+		),
+		statement: statement,
+	}, nil
+}
+
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	tools.BaseTool[Config]
+	statement *template.Template
+}
+
+func (t Tool) ToConfig() tools.ToolConfig {
+	return t.Cfg
+}
+
+func (t Tool) Invoke(ctx context.Context, primitiveMgr tools.SourceProvider, params parameters.ParamValues, accessToken tools.AccessToken) (any, util.ToolboxError) {
+	source, err := tools.GetCompatibleSource[compatibleSource](primitiveMgr, t.Cfg.Source, t.Cfg.Name, t.Cfg.Type)
+	if err != nil {
+		return nil, util.NewClientServerError("source used is not compatible with the tool", http.StatusInternalServerError, err)
+	}
+
+	collection := t.Cfg.Collection
+	if collection == "" {
+		collection = source.CouchbaseCollection()
+	}
+	statement, err := renderStatement(t.statement, collection)
+	if err != nil {
+		return nil, util.NewClientServerError("unable to render statement", http.StatusInternalServerError, err)
+	}
+
+	resp, err := source.RunN1QL(ctx, statement, params.AsMap(), t.Cfg.ReadOnly)
+	if err != nil {
+		return nil, util.ProcessGeneralError(err)
+	}
+	return resp, nil
+}
+
+// renderStatement substitutes the collection into the statement as a quoted
+// keyspace identifier. Statements that reference the collection fail to
+// render when no collection is configured.
+func renderStatement(statement *template.Template, collection string) (string, error) {
+	data := map[string]any{}
+	if collection != "" {
+		data[collectionKey] = quoteIdentifier(collection)
+	}
+	var out bytes.Buffer
+	if err := statement.Execute(&out, data); err != nil {
+		return "", fmt.Errorf("error rendering statement: %w", err)
+	}
+	return out.String(), nil
+}
+
+// quoteIdentifier escapes name as a N1QL identifier.
+func quoteIdentifier(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
@@ -0,0 +1,173 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package couchbasen1ql_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/internal/server"
+	"github.com/googleapis/mcp-toolbox/internal/sources"
+	"github.com/googleapis/mcp-toolbox/internal/testutils"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/tools/couchbase/couchbasen1ql"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+func TestParseFromYamlCouchbaseN1QL(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			kind: tool
+			name: search_products
+			type: couchbase-n1ql
+			source: my-couchbase-instance
+			description: some tool description
+			collection: products
+			readOnly: true
+			statement: |
+				SELECT p.* FROM {{.collection}} p WHERE p.category = $category;
+			parameters:
+				- name: category
+				  type: string
+				  description: product category
+			`,
+			want: server.ToolConfigs{
+				"search_products": couchbasen1ql.Config{
+					ConfigBase: tools.ConfigBase{
+						Name:         "search_products",
+						AuthRequired: []string{},
+						Description:  "some tool description",
+					},
+					Type:       "couchbase-n1ql",
+					Source:     "my-couchbase-instance",
+					Statement:  "SELECT p.* FROM {{.collection}} p WHERE p.category = $category;\n",
+					Collection: "products",
+					ReadOnly:   true,
+					Parameters: []parameters.Parameter{
+						parameters.NewStringParameter("category", "product category"),
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, _, _, got, _, _, err := server.UnmarshalPrimitiveConfig(ctx, testutils.FormatYaml(tc.in))
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+type fakeSource struct {
+	collection string
+	statement  *string
+	params     *map[string]any
+	readOnly   *bool
+}
+
+func (s fakeSource) SourceType() string             { return "couchbase" }
+func (s fakeSource) ToConfig() sources.SourceConfig { return nil }
+func (s fakeSource) CouchbaseCollection() string    { return s.collection }
+
+func (s fakeSource) RunN1QL(_ context.Context, statement string, params map[string]any, readOnly bool) (any, error) {
+	*s.statement, *s.params, *s.readOnly = statement, params, readOnly
+	return []any{}, nil
+}
+
+type sourceMap map[string]sources.Source
+
+func (m sourceMap) GetSource(name string) (sources.Source, bool) {
+	s, ok := m[name]
+	return s, ok
+}
+
+func TestInvoke(t *testing.T) {
+	cfg := couchbasen1ql.Config{
+		ConfigBase: tools.ConfigBase{Name: "search_products", Description: "search"},
+		Type:       "couchbase-n1ql",
+		Source:     "cb",
+		Statement:  "SELECT p.* FROM {{.collection}} p WHERE p.category = $category",
+		ReadOnly:   true,
+		Parameters: parameters.Parameters{
+			parameters.NewStringParameter("category", "product category"),
+		},
+	}
+	tool, err := cfg.Initialize(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got := tool.GetAnnotations(); got == nil || got.ReadOnlyHint == nil || !*got.ReadOnlyHint {
+		t.Fatalf("expected read-only annotations, got %+v", got)
+	}
+	params := parameters.ParamValues{{Name: "category", Value: "books"}}
+
+	var statement string
+	var named map[string]any
+	var readOnly bool
+	src := fakeSource{collection: "catalog", statement: &statement, params: &named, readOnly: &readOnly}
+	if _, toolErr := tool.Invoke(context.Background(), sourceMap{"cb": src}, params, ""); toolErr != nil {
+		t.Fatalf("unexpected error: %s", toolErr)
+	}
+	if want := "SELECT p.* FROM `catalog` p WHERE p.category = $category"; statement != want {
+		t.Errorf("statement = %q, want %q", statement, want)
+	}
+	if diff := cmp.Diff(map[string]any{"category": "books"}, named); diff != "" {
+		t.Errorf("incorrect named parameters: diff %v", diff)
+	}
+	if !readOnly {
+		t.Errorf("expected read-only query")
+	}
+
+	// The tool's collection overrides the source's.
+	cfg.Collection = "odd`name"
+	tool, err = cfg.Initialize(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, toolErr := tool.Invoke(context.Background(), sourceMap{"cb": src}, params, ""); toolErr != nil {
+		t.Fatalf("unexpected error: %s", toolErr)
+	}
+	if !strings.Contains(statement, "FROM `odd``name` p") {
+		t.Errorf("collection not quoted: %q", statement)
+	}
+
+	// Referencing the collection without one configured fails.
+	cfg.Collection = ""
+	tool, err = cfg.Initialize(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	src.collection = ""
+	if _, toolErr := tool.Invoke(context.Background(), sourceMap{"cb": src}, params, ""); toolErr == nil {
+		t.Fatalf("expected error when no collection is configured")
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package couchbase
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+)
+
+func TestInjectDocumentKeys(t *testing.T) {
+	disabled := false
+	newRows := func() []any {
+		return []any{
+			json.RawMessage(`{"__meta_id":"hotel::1","name":"Ritz","rooms":120}`),
+			json.RawMessage(`{"name":"no key"}`),
+			json.RawMessage(`42`),
+		}
+	}
+	tcs := []struct {
+		desc string
+		cfg  tools.DocumentIDConfig
+		want []any
+	}{
+		{
+			desc: "default field",
+			want: []any{
+				map[string]any{"_key": "hotel::1", "name": "Ritz", "rooms": json.Number("120")},
+				json.RawMessage(`{"name":"no key"}`),
+				json.RawMessage(`42`),
+			},
+		},
+		{
+			desc: "custom field",
+			cfg:  tools.DocumentIDConfig{IDField: "id"},
+			want: []any{
+				map[string]any{"id": "hotel::1", "name": "Ritz", "rooms": json.Number("120")},
+				json.RawMessage(`{"name":"no key"}`),
+				json.RawMessage(`42`),
+			},
+		},
+		{
+			desc: "disabled",
+			cfg:  tools.DocumentIDConfig{IncludeID: &disabled},
+			want: []any{
+				map[string]any{"name": "Ritz", "rooms": json.Number("120")},
+				json.RawMessage(`{"name":"no key"}`),
+				json.RawMessage(`42`),
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			rows := newRows()
+			if err := injectDocumentKeys(rows, tc.cfg); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, rows); diff != "" {
+				t.Errorf("unexpected rows (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestInjectDocumentKeysCollision(t *testing.T) {
+	rows := []any{json.RawMessage(`{"__meta_id":"hotel::1","_key":"other"}`)}
+	err := injectDocumentKeys(rows, tools.DocumentIDConfig{})
+	if err == nil || !strings.Contains(err.Error(), `row 0: document already has a field "_key"`) {
+		t.Fatalf("unexpected error: got %v", err)
+	}
+}
@@ -29,7 +29,7 @@ import (
 const resourceType string = "dataform-compile-local"
 
 func init() {
-	if !tools.Register(resourceType, newConfig) {
+	if !tools.Register[any](resourceType, newConfig) {
 		panic(fmt.Sprintf("tool type %q already registered", resourceType))
 	}
 }
@@ -30,7 +30,7 @@ import (
 const kind = "dataproc-get-job"
 
 func init() {
-	if !tools.Register(kind, newConfig) {
+	if !tools.Register[compatibleSource](kind, newConfig) {
 		panic(fmt.Sprintf("tool kind %q already registered", kind))
 	}
 }
@@ -29,7 +29,7 @@ import (
 const kind = "dataproc-list-clusters"
 
 func init() {
-	if !tools.Register(kind, newConfig) {
+	if !tools.Register[compatibleSource](kind, newConfig) {
 		panic(fmt.Sprintf("tool kind %q already registered", kind))
 	}
 }
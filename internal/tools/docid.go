@@ -0,0 +1,51 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import "fmt"
+
+// DocumentIDConfig configures how a document-returning tool exposes the
+// identifier of each document. Tool configs embed it inline.
+type DocumentIDConfig struct {
+	// IncludeID controls whether the identifier is injected into the
+	// documents. Defaults to true.
+	IncludeID *bool `yaml:"includeId,omitempty"`
+	// IDField is the field the identifier is injected under. The default
+	// depends on the store.
+	IDField string `yaml:"idField,omitempty"`
+}
+
+// IncludeIDEnabled reports whether document identifiers should be injected.
+func (c DocumentIDConfig) IncludeIDEnabled() bool {
+	return c.IncludeID == nil || *c.IncludeID
+}
+
+// IDFieldOrDefault returns the configured ID field, or def if none is set.
+func (c DocumentIDConfig) IDFieldOrDefault(def string) string {
+	if c.IDField == "" {
+		return def
+	}
+	return c.IDField
+}
+
+// InjectDocumentID sets doc[field] to id. It never overwrites an existing
+// field of that name and returns an error instead.
+func InjectDocumentID(doc map[string]any, field string, id any) error {
+	if _, ok := doc[field]; ok {
+		return fmt.Errorf("document already has a field %q; set idField to inject the document ID under a different name", field)
+	}
+	doc[field] = id
+	return nil
+}
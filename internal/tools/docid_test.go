@@ -0,0 +1,66 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+)
+
+func TestDocumentIDConfig(t *testing.T) {
+	disabled := false
+	tcs := []struct {
+		desc        string
+		cfg         tools.DocumentIDConfig
+		wantEnabled bool
+		wantField   string
+	}{
+		{desc: "defaults", cfg: tools.DocumentIDConfig{}, wantEnabled: true, wantField: "_id"},
+		{desc: "custom field", cfg: tools.DocumentIDConfig{IDField: "docId"}, wantEnabled: true, wantField: "docId"},
+		{desc: "disabled", cfg: tools.DocumentIDConfig{IncludeID: &disabled}, wantEnabled: false, wantField: "_id"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := tc.cfg.IncludeIDEnabled(); got != tc.wantEnabled {
+				t.Errorf("unexpected IncludeIDEnabled: got %t, want %t", got, tc.wantEnabled)
+			}
+			if got := tc.cfg.IDFieldOrDefault("_id"); got != tc.wantField {
+				t.Errorf("unexpected IDFieldOrDefault: got %q, want %q", got, tc.wantField)
+			}
+		})
+	}
+}
+
+func TestInjectDocumentID(t *testing.T) {
+	doc := map[string]any{"name": "alice"}
+	if err := tools.InjectDocumentID(doc, "_key", "user::1"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := map[string]any{"name": "alice", "_key": "user::1"}
+	if diff := cmp.Diff(want, doc); diff != "" {
+		t.Errorf("unexpected document (-want +got):\n%s", diff)
+	}
+
+	err := tools.InjectDocumentID(doc, "name", "user::1")
+	if err == nil || !strings.Contains(err.Error(), `document already has a field "name"`) {
+		t.Fatalf("unexpected error: got %v", err)
+	}
+	if doc["name"] != "alice" {
+		t.Errorf("existing field was overwritten: got %v", doc["name"])
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/orderedmap"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+// DryRunResult is what an invocation would run, reported instead of running
+// it.
+type DryRunResult struct {
+	// Statement is the statement of the invocation, with its template
+	// parameters resolved.
+	Statement string `json:"statement"`
+	// Params are the values bound to the placeholders of Statement.
+	Params []any `json:"params"`
+	// Plan is the query plan of Statement as reported by the source, if it
+	// can explain it.
+	Plan any `json:"plan,omitempty"`
+	// Preview is what Statement changes, found by running it in a
+	// transaction that is rolled back, if the tool previews its writes.
+	Preview *DryRunPreview `json:"preview,omitempty"`
+}
+
+// DryRunPreview is the result of running the statement of a dry run in a
+// transaction that is rolled back.
+type DryRunPreview struct {
+	// Rows are the rows the statement returns, such as with a RETURNING
+	// clause.
+	Rows []any `json:"rows"`
+	// RowsAffected is the number of rows the statement inserts, updates or
+	// deletes.
+	RowsAffected int64 `json:"rowsAffected"`
+}
+
+// DryRunner is implemented by tools that can report what an invocation would
+// run without running it, such as for the invocation to be approved first.
+type DryRunner interface {
+	// DryRun resolves the invocation like Invoke, and explains its
+	// statement, but does not run it.
+	DryRun(ctx context.Context, sourceProvider SourceProvider, params parameters.ParamValues, accessToken AccessToken) (*DryRunResult, util.ToolboxError)
+}
+
+// DryRun reports what an invocation of tool would run, or an error if tool
+// cannot report it.
+func DryRun(ctx context.Context, tool Tool, sp SourceProvider, params parameters.ParamValues, token AccessToken) (*DryRunResult, util.ToolboxError) {
+	runner, ok := tool.(DryRunner)
+	if !ok {
+		return nil, util.NewClientServerError(fmt.Sprintf("tool %q does not support dry runs", tool.GetName()), http.StatusBadRequest, nil)
+	}
+	return runner.DryRun(ctx, sp, params, token)
+}
+
+// PlanFromRows returns the plan of the result of an EXPLAIN statement
+// returning it as JSON in the first column of its first row, decoded if the
+// source returns it as text.
+func PlanFromRows(resp any) any {
+	rows, ok := resp.([]any)
+	if !ok || len(rows) == 0 {
+		return nil
+	}
+	var value any
+	switch r := rows[0].(type) {
+	case orderedmap.Row:
+		if len(r.Columns) > 0 {
+			value = r.Columns[0].Value
+		}
+	case map[string]any:
+		// EXPLAIN statements return a single column
+		for _, v := range r {
+			value = v
+		}
+	}
+	var raw []byte
+	switch v := value.(type) {
+	case string:
+		raw = []byte(v)
+	case []byte:
+		raw = v
+	default:
+		return value
+	}
+	var plan any
+	if err := json.Unmarshal(raw, &plan); err != nil {
+		return string(raw)
+	}
+	return plan
+}
+
+// DryRun implements DryRunner. Dry runs are not limited, as they don't run
+// the tool.
+func (t rateLimitedTool) DryRun(ctx context.Context, sp SourceProvider, params parameters.ParamValues, token AccessToken) (*DryRunResult, util.ToolboxError) {
+	return DryRun(ctx, t.Tool, sp, params, token)
+}
+
+// DryRun implements DryRunner.
+func (t transformedTool) DryRun(ctx context.Context, sp SourceProvider, params parameters.ParamValues, token AccessToken) (*DryRunResult, util.ToolboxError) {
+	return DryRun(ctx, t.Tool, sp, params, token)
+}
+
+// DryRun implements DryRunner, against the selected source.
+func (t sourceSelectingTool) DryRun(ctx context.Context, sp SourceProvider, params parameters.ParamValues, token AccessToken) (*DryRunResult, util.ToolboxError) {
+	selected, rest, err := t.selectSource(sp, params)
+	if err != nil {
+		return nil, err
+	}
+	return DryRun(ctx, t.Tool, selected, rest, token)
+}
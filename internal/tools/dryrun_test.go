@@ -0,0 +1,113 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+func TestPlanFromRows(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   any
+		want any
+	}{
+		{
+			desc: "decoded json",
+			in:   []any{row("QUERY PLAN", []any{map[string]any{"Plan": "Seq Scan"}})},
+			want: []any{map[string]any{"Plan": "Seq Scan"}},
+		},
+		{
+			desc: "json text",
+			in:   []any{map[string]any{"EXPLAIN": `{"query_block": {"select_id": 1}}`}},
+			want: map[string]any{"query_block": map[string]any{"select_id": float64(1)}},
+		},
+		{
+			desc: "plain text",
+			in:   []any{row("plan", "Seq Scan on users")},
+			want: "Seq Scan on users",
+		},
+		{
+			desc: "no rows",
+			in:   []any{},
+			want: nil,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, tools.PlanFromRows(tc.in)); diff != "" {
+				t.Errorf("incorrect plan (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// dryRunTool reports its statement in dry runs.
+type dryRunTool struct {
+	tools.Tool
+	cfg tools.ToolConfig
+}
+
+func (t dryRunTool) GetName() string            { return "my-tool" }
+func (t dryRunTool) ToConfig() tools.ToolConfig { return t.cfg }
+
+func (t dryRunTool) DryRun(ctx context.Context, sp tools.SourceProvider, params parameters.ParamValues, token tools.AccessToken) (*tools.DryRunResult, util.ToolboxError) {
+	return &tools.DryRunResult{Statement: "SELECT 1", Params: params.AsSlice()}, nil
+}
+
+func TestDryRunWrapped(t *testing.T) {
+	cfg := transformConfig{ConfigBase: tools.ConfigBase{
+		Transform: &tools.Transform{Limit: 1},
+		RateLimit: &tools.RateLimit{MaxConcurrency: 1},
+	}}
+	toolsMap, err := tools.WrapTransforms(map[string]tools.Tool{"my-tool": dryRunTool{cfg: cfg}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	toolsMap = tools.WrapRateLimits(toolsMap, tools.RateLimit{})
+
+	params := parameters.ParamValues{{Name: "id", Value: 1}}
+	got, tbErr := tools.DryRun(context.Background(), toolsMap["my-tool"], nil, params, "")
+	if tbErr != nil {
+		t.Fatalf("unexpected error: %v", tbErr)
+	}
+	want := &tools.DryRunResult{Statement: "SELECT 1", Params: []any{1}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("incorrect dry run (-want +got):\n%s", diff)
+	}
+}
+
+// namedTool cannot dry run.
+type namedTool struct {
+	tools.Tool
+}
+
+func (t namedTool) GetName() string { return "my-tool" }
+
+func TestDryRunUnsupported(t *testing.T) {
+	_, err := tools.DryRun(context.Background(), namedTool{}, nil, nil, "")
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	if want := `tool "my-tool" does not support dry runs`; err.Error() != want {
+		t.Errorf("got error %q, want %q", err, want)
+	}
+}
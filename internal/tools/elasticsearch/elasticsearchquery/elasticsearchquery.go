@@ -0,0 +1,184 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchquery
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	es "github.com/googleapis/mcp-toolbox/internal/sources/elasticsearch"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+const resourceType string = "elasticsearch-query"
+
+func init() {
+	if !tools.Register[compatibleSource](resourceType, newConfig) {
+		panic(fmt.Sprintf("tool type %q already registered", resourceType))
+	}
+}
+
+type compatibleSource interface {
+	Search(ctx context.Context, indices []string, body string) (any, error)
+}
+
+var _ compatibleSource = &es.Source{}
+
+type Config struct {
+	tools.ConfigBase `yaml:",inline"`
+	Type             string `yaml:"type" validate:"required"`
+	Source           string `yaml:"source" validate:"required"`
+	// Index is the index, alias or pattern searched. Several can be given,
+	// separated by commas. It can use the template parameters, as in
+	// "{{.index}}", in which case AllowedIndices is required.
+	Index string `yaml:"index" validate:"required"`
+	// AllowedIndices are the patterns, such as "logs-*", that every index
+	// searched must match.
+	AllowedIndices []string `yaml:"allowedIndices"`
+	// Query is the Query DSL request body. The parameters are inserted with
+	// {{json .name}}, which encodes them as JSON values.
+	Query              string                 `yaml:"query" validate:"required"`
+	Timeout            int                    `yaml:"timeout"`
+	Parameters         parameters.Parameters  `yaml:"parameters"`
+	TemplateParameters parameters.Parameters  `yaml:"templateParameters"`
+	Annotations        *tools.ToolAnnotations `yaml:"annotations,omitempty"`
+}
+
+var _ tools.ToolConfig = Config{}
+
+func (c Config) ToolConfigType() string {
+	return resourceType
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{ConfigBase: tools.ConfigBase{Name: name}}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Tool struct {
+	tools.BaseTool[Config]
+}
+
+var _ tools.Tool = Tool{}
+
+func (c Config) Initialize(context.Context) (tools.Tool, error) {
+	if c.Description == "" {
+		return nil, fmt.Errorf("description is required for tool %q", c.Name)
+	}
+	if strings.Contains(c.Index, "{{") && len(c.AllowedIndices) == 0 {
+		return nil, fmt.Errorf("tool %q must set allowedIndices to use template parameters in its index", c.Name)
+	}
+	for _, pattern := range c.AllowedIndices {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid pattern %q in allowedIndices of tool %q: %w", pattern, c.Name, err)
+		}
+	}
+	if !strings.Contains(c.Index, "{{") {
+		if err := checkIndices(c.Index, c.AllowedIndices); err != nil {
+			return nil, fmt.Errorf("tool %q: %w", c.Name, err)
+		}
+	}
+
+	allParams, paramManifest, err := parameters.ProcessParameters(c.TemplateParameters, c.Parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	return Tool{
+		BaseTool: tools.NewBaseTool(
+			c,
+			tools.GetAnnotationsOrDefault(c.Annotations, tools.NewReadOnlyAnnotations),
+			tools.Manifest{Description: c.Description, Parameters: paramManifest, AuthRequired: c.AuthRequired},
+			allParams,
+		),
+	}, nil
+}
+
+// checkIndices checks that every comma-separated index of indices matches
+// one of the allowed patterns. Any index is allowed if there are none.
+func checkIndices(indices string, allowed []string) error {
+	if len(allowed) == 0 {
+		return nil
+	}
+	for _, index := range strings.Split(indices, ",") {
+		index = strings.TrimSpace(index)
+		ok := false
+		for _, pattern := range allowed {
+			if match, _ := path.Match(pattern, index); match {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return fmt.Errorf("index %q is not allowed, it must match one of: %s", index, strings.Join(allowed, ", "))
+		}
+	}
+	return nil
+}
+
+func (t Tool) ToConfig() tools.ToolConfig {
+	return t.Cfg
+}
+
+func (t Tool) Invoke(ctx context.Context, primitiveMgr tools.SourceProvider, params parameters.ParamValues, accessToken tools.AccessToken) (any, util.ToolboxError) {
+	source, err := tools.GetCompatibleSource[compatibleSource](primitiveMgr, t.Cfg.Source, t.Cfg.Name, t.Cfg.Type)
+	if err != nil {
+		return nil, util.NewClientServerError("source used is not compatible with the tool", http.StatusInternalServerError, err)
+	}
+
+	paramsMap := params.AsMap()
+	index, err := parameters.PopulateTemplate("ElasticsearchIndex", t.Cfg.Index, paramsMap)
+	if err != nil {
+		return nil, util.NewAgentError("unable to resolve the index", err)
+	}
+	if err := checkIndices(index, t.Cfg.AllowedIndices); err != nil {
+		return nil, util.NewAgentError(err.Error(), nil)
+	}
+	query, err := parameters.PopulateTemplateWithJSON("ElasticsearchQuery", t.Cfg.Query, paramsMap)
+	if err != nil {
+		return nil, util.NewAgentError("unable to populate the query", err)
+	}
+	if !json.Valid([]byte(query)) {
+		return nil, util.NewAgentError(fmt.Sprintf("the query of tool %q is not valid JSON once populated, use {{json .name}} to insert parameters", t.Cfg.Name), nil)
+	}
+
+	timeout := time.Minute
+	if t.Cfg.Timeout > 0 {
+		timeout = time.Duration(t.Cfg.Timeout) * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var indices []string
+	for _, i := range strings.Split(index, ",") {
+		indices = append(indices, strings.TrimSpace(i))
+	}
+	resp, err := source.Search(ctx, indices, query)
+	if err != nil {
+		return nil, util.ProcessGeneralError(err)
+	}
+	return resp, nil
+}
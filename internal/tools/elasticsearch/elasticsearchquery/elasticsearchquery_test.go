@@ -0,0 +1,133 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchquery
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/internal/server"
+	"github.com/googleapis/mcp-toolbox/internal/testutils"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+func TestParseFromYamlElasticsearchQuery(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	in := `
+            kind: tool
+            name: search_orders
+            type: elasticsearch-query
+            source: my-elasticsearch-instance
+            description: Search orders by customer
+            index: "{{.index}}"
+            allowedIndices:
+                - orders-*
+            query: |
+                {"query": {"match": {"customer": {{json .customer}}}}}
+            parameters:
+                - name: customer
+                  type: string
+                  description: Name of the customer
+            templateParameters:
+                - name: index
+                  type: string
+                  description: Index to search
+	`
+	want := server.ToolConfigs{
+		"search_orders": Config{
+			ConfigBase: tools.ConfigBase{
+				Name:         "search_orders",
+				Description:  "Search orders by customer",
+				AuthRequired: []string{},
+			},
+			Type:           "elasticsearch-query",
+			Source:         "my-elasticsearch-instance",
+			Index:          "{{.index}}",
+			AllowedIndices: []string{"orders-*"},
+			Query:          "{\"query\": {\"match\": {\"customer\": {{json .customer}}}}}\n",
+			Parameters: parameters.Parameters{
+				parameters.NewStringParameter("customer", "Name of the customer"),
+			},
+			TemplateParameters: parameters.Parameters{
+				parameters.NewStringParameter("index", "Index to search"),
+			},
+		},
+	}
+	_, _, _, got, _, _, err := server.UnmarshalPrimitiveConfig(ctx, testutils.FormatYaml(in))
+	if err != nil {
+		t.Fatalf("unable to unmarshal: %s", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect parse: diff %v", diff)
+	}
+}
+
+func TestInitializeIndices(t *testing.T) {
+	tcs := []struct {
+		desc    string
+		index   string
+		allowed []string
+		err     string
+	}{
+		{desc: "fixed index", index: "orders-2026"},
+		{desc: "fixed index allowed", index: "orders-2026,orders-2025", allowed: []string{"orders-*"}},
+		{desc: "fixed index not allowed", index: "orders-2026,users", allowed: []string{"orders-*"}, err: `index "users" is not allowed`},
+		{desc: "templated index without allow-list", index: "{{.index}}", err: "must set allowedIndices"},
+		{desc: "templated index", index: "{{.index}}", allowed: []string{"orders-*"}},
+		{desc: "invalid pattern", index: "orders", allowed: []string{"orders-["}, err: "invalid pattern"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := Config{
+				ConfigBase:     tools.ConfigBase{Name: "search", Description: "Search"},
+				Type:           resourceType,
+				Source:         "es",
+				Index:          tc.index,
+				AllowedIndices: tc.allowed,
+				Query:          `{"query": {"match_all": {}}}`,
+			}
+			_, err := cfg.Initialize(context.Background())
+			if tc.err == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("expected error containing %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestCheckIndices(t *testing.T) {
+	allowed := []string{"orders-*", "customers"}
+	for _, index := range []string{"orders-1", "customers", "orders-1, customers"} {
+		if err := checkIndices(index, allowed); err != nil {
+			t.Errorf("expected %q to be allowed, got %s", index, err)
+		}
+	}
+	for _, index := range []string{"*", "_all", "users", "orders-1,users", "customers-old", "-orders-1"} {
+		if err := checkIndices(index, allowed); err == nil {
+			t.Errorf("expected %q to be rejected", index)
+		}
+	}
+}
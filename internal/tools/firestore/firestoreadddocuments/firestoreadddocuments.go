@@ -33,7 +33,7 @@ const documentDataKey string = "documentData"
 const returnDocumentDataKey string = "returnData"
 
 func init() {
-	if !tools.Register(resourceType, newConfig) {
+	if !tools.Register[compatibleSource](resourceType, newConfig) {
 		panic(fmt.Sprintf("tool type %q already registered", resourceType))
 	}
 }
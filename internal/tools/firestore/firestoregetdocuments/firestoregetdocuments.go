@@ -31,7 +31,7 @@ const resourceType string = "firestore-get-documents"
 const documentPathsKey string = "documentPaths"
 
 func init() {
-	if !tools.Register(resourceType, newConfig) {
+	if !tools.Register[compatibleSource](resourceType, newConfig) {
 		panic(fmt.Sprintf("tool type %q already registered", resourceType))
 	}
 }
@@ -54,6 +54,8 @@ type Config struct {
 	Type             string                 `yaml:"type" validate:"required"`
 	Source           string                 `yaml:"source" validate:"required"`
 	Annotations      *tools.ToolAnnotations `yaml:"annotations,omitempty"`
+
+	tools.DocumentIDConfig `yaml:",inline"`
 }
 
 // validate interface
@@ -129,5 +131,8 @@ func (t Tool) Invoke(ctx context.Context, primitiveMgr tools.SourceProvider, par
 	if err != nil {
 		return nil, util.ProcessGcpError(err)
 	}
+	if err := fsUtil.InjectDocumentPaths(resp, t.Cfg.DocumentIDConfig); err != nil {
+		return nil, util.NewAgentError("error injecting document paths", err)
+	}
 	return resp, nil
 }
@@ -31,7 +31,7 @@ const resourceType string = "firestore-list-collections"
 const parentPathKey string = "parentPath"
 
 func init() {
-	if !tools.Register(resourceType, newConfig) {
+	if !tools.Register[compatibleSource](resourceType, newConfig) {
 		panic(fmt.Sprintf("tool type %q already registered", resourceType))
 	}
 }
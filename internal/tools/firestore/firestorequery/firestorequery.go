@@ -37,7 +37,7 @@ const (
 )
 
 func init() {
-	if !tools.Register(resourceType, newConfig) {
+	if !tools.Register[compatibleSource](resourceType, newConfig) {
 		panic(fmt.Sprintf("tool type %q already registered", resourceType))
 	}
 }
@@ -74,6 +74,8 @@ type Config struct {
 	// Parameters for template substitution
 	Parameters  parameters.Parameters  `yaml:"parameters"`
 	Annotations *tools.ToolAnnotations `yaml:"annotations,omitempty"`
+
+	tools.DocumentIDConfig `yaml:",inline"`
 }
 
 // validate interface
@@ -219,6 +221,9 @@ func (t Tool) Invoke(ctx context.Context, primitiveMgr tools.SourceProvider, par
 	if err != nil {
 		return nil, util.ProcessGcpError(err)
 	}
+	if err := fsUtil.InjectDocumentPaths(resp, t.Cfg.DocumentIDConfig); err != nil {
+		return nil, util.NewAgentError("error injecting document paths", err)
+	}
 	return resp, nil
 }
 
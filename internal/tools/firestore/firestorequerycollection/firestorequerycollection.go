@@ -73,7 +73,7 @@ const (
 )
 
 func init() {
-	if !tools.Register(resourceType, newConfig) {
+	if !tools.Register[compatibleSource](resourceType, newConfig) {
 		panic(fmt.Sprintf("tool type %q already registered", resourceType))
 	}
 }
@@ -99,6 +99,8 @@ type Config struct {
 	Type             string                 `yaml:"type" validate:"required"`
 	Source           string                 `yaml:"source" validate:"required"`
 	Annotations      *tools.ToolAnnotations `yaml:"annotations,omitempty"`
+
+	tools.DocumentIDConfig `yaml:",inline"`
 }
 
 // validate interface
@@ -272,6 +274,9 @@ func (t Tool) Invoke(ctx context.Context, primitiveMgr tools.SourceProvider, par
 	if err != nil {
 		return nil, util.ProcessGcpError(err)
 	}
+	if err := fsUtil.InjectDocumentPaths(resp, t.Cfg.DocumentIDConfig); err != nil {
+		return nil, util.NewAgentError("error injecting document paths", err)
+	}
 	return resp, nil
 }
 
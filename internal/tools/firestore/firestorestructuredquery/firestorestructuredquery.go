@@ -0,0 +1,492 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package firestorestructuredquery
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	firestoreapi "cloud.google.com/go/firestore"
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	fsUtil "github.com/googleapis/mcp-toolbox/internal/tools/firestore/util"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+// Constants for tool configuration
+const (
+	resourceType   = "firestore-structured-query"
+	defaultLimit   = 100
+	defaultAnalyze = false
+	// maxFilterConditions bounds the field conditions of a filter, as
+	// Firestore does for disjunctive normal forms.
+	maxFilterConditions = 100
+	// maxFilterDepth bounds the nesting of composite filters.
+	maxFilterDepth = 8
+)
+
+// Parameter keys
+const (
+	collectionPathKey  = "collectionPath"
+	collectionGroupKey = "collectionGroup"
+	filterKey          = "filter"
+	orderByKey         = "orderBy"
+	cursorKey          = "cursor"
+	selectKey          = "select"
+	limitKey           = "limit"
+	analyzeQueryKey    = "analyzeQuery"
+)
+
+// Firestore operators
+var validOperators = map[string]bool{
+	"<":                  true,
+	"<=":                 true,
+	">":                  true,
+	">=":                 true,
+	"==":                 true,
+	"!=":                 true,
+	"array-contains":     true,
+	"array-contains-any": true,
+	"in":                 true,
+	"not-in":             true,
+}
+
+func init() {
+	if !tools.Register[compatibleSource](resourceType, newConfig) {
+		panic(fmt.Sprintf("tool type %q already registered", resourceType))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{ConfigBase: tools.ConfigBase{Name: name}}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+// compatibleSource defines the interface for sources that can provide a Firestore client
+type compatibleSource interface {
+	FirestoreClient() *firestoreapi.Client
+	ExecuteQuery(context.Context, *firestoreapi.Query, bool) (any, error)
+}
+
+// Config represents the configuration for the Firestore structured query tool
+type Config struct {
+	tools.ConfigBase `yaml:",inline"`
+	Type             string                 `yaml:"type" validate:"required"`
+	Source           string                 `yaml:"source" validate:"required"`
+	Annotations      *tools.ToolAnnotations `yaml:"annotations,omitempty"`
+
+	tools.DocumentIDConfig `yaml:",inline"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+// ToolConfigType returns the type of tool configuration
+func (cfg Config) ToolConfigType() string {
+	return resourceType
+}
+
+// Initialize creates a new Tool instance from the configuration
+func (cfg Config) Initialize(context.Context) (tools.Tool, error) {
+	if cfg.Description == "" {
+		return nil, fmt.Errorf("description is required for tool %q", cfg.Name)
+	}
+
+	params := createParameters()
+
+	return Tool{
+		BaseTool: tools.NewBaseTool(
+			cfg,
+			tools.GetAnnotationsOrDefault(cfg.Annotations, tools.NewReadOnlyAnnotations),
+			tools.Manifest{Description: cfg.Description, Parameters: params.Manifest(), AuthRequired: cfg.AuthRequired},
+			params,
+		),
+	}, nil
+}
+
+func (t Tool) ToConfig() tools.ToolConfig {
+	return t.Cfg
+}
+
+// createParameters creates the parameter definitions for the tool
+func createParameters() parameters.Parameters {
+	collectionPathParameter := parameters.NewStringParameter(
+		collectionPathKey,
+		"The relative path to the Firestore collection to query (e.g., 'orders' or 'users/userId/orders'), or the ID of the collections to query when 'collectionGroup' is true (e.g., 'orders').",
+	)
+
+	collectionGroupParameter := parameters.NewBooleanParameter(
+		collectionGroupKey,
+		"If true, queries every collection whose ID is 'collectionPath', wherever it is nested, instead of a single collection.",
+		parameters.WithBooleanDefault(false),
+	)
+
+	filterDescription := `JSON string of the filter of the query. A filter is either a field condition or a composite filter:
+- field condition: {"field": "status", "op": "==", "value": "shipped"}, with op one of "<", "<=", ">", ">=", "==", "!=", "array-contains", "array-contains-any", "in", "not-in"
+- composite filter: {"and": [filters]} or {"or": [filters]}, which may be nested
+Values may be typed as in the Firestore REST API, e.g. {"timestampValue": "2025-01-01T00:00:00Z"}.
+Example: {"and": [{"field": "status", "op": "==", "value": "shipped"}, {"field": "created", "op": ">", "value": {"timestampValue": "2025-01-01T00:00:00Z"}}]}`
+	filterParameter := parameters.NewStringParameter(
+		filterKey,
+		filterDescription,
+		parameters.WithStringRequired(false),
+	)
+
+	orderByParameter := parameters.NewArrayParameter(
+		orderByKey,
+		`Orderings of the results, each a JSON string such as {"field": "created", "direction": "DESCENDING"}. The direction defaults to "ASCENDING", and "__name__" orders by document ID.`,
+		parameters.NewStringParameter("item", "JSON string representation of an ordering"),
+		parameters.WithArrayRequired(false),
+	)
+
+	cursorParameter := parameters.NewStringParameter(
+		cursorKey,
+		`JSON string of the cursors of the query, holding the values of the 'orderBy' fields to start or end the results at, e.g. {"startAfter": ["2025-01-01T00:00:00Z"], "endAt": [...]}. Use at most one of "startAt" and "startAfter", and one of "endAt" and "endBefore".`,
+		parameters.WithStringRequired(false),
+	)
+
+	selectParameter := parameters.NewArrayParameter(
+		selectKey,
+		"The fields of the documents to return. Every field is returned when empty.",
+		parameters.NewStringParameter("item", "A field path"),
+		parameters.WithArrayRequired(false),
+	)
+
+	limitParameter := parameters.NewIntParameter(
+		limitKey,
+		"The maximum number of documents to return",
+		parameters.WithIntDefault(defaultLimit),
+	)
+
+	analyzeQueryParameter := parameters.NewBooleanParameter(
+		analyzeQueryKey,
+		"If true, returns query explain metrics including execution statistics",
+		parameters.WithBooleanDefault(defaultAnalyze),
+	)
+
+	return parameters.Parameters{
+		collectionPathParameter,
+		collectionGroupParameter,
+		filterParameter,
+		orderByParameter,
+		cursorParameter,
+		selectParameter,
+		limitParameter,
+		analyzeQueryParameter,
+	}
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+// Tool represents the Firestore structured query tool
+type Tool struct {
+	tools.BaseTool[Config]
+}
+
+// Filter is a field condition, or a composite of filters.
+type Filter struct {
+	And   []Filter `json:"and,omitempty"`
+	Or    []Filter `json:"or,omitempty"`
+	Field string   `json:"field,omitempty"`
+	Op    string   `json:"op,omitempty"`
+	Value any      `json:"value,omitempty"`
+}
+
+// OrderBy is an ordering of the results.
+type OrderBy struct {
+	Field     string `json:"field"`
+	Direction string `json:"direction"`
+}
+
+// direction returns the Firestore direction of the ordering.
+func (o OrderBy) direction() firestoreapi.Direction {
+	if strings.EqualFold(o.Direction, "DESCENDING") || strings.EqualFold(o.Direction, "DESC") {
+		return firestoreapi.Desc
+	}
+	return firestoreapi.Asc
+}
+
+// Cursor holds the values of the ordering fields the results start or end
+// at.
+type Cursor struct {
+	StartAt    []any `json:"startAt,omitempty"`
+	StartAfter []any `json:"startAfter,omitempty"`
+	EndAt      []any `json:"endAt,omitempty"`
+	EndBefore  []any `json:"endBefore,omitempty"`
+}
+
+// structuredQuery holds the parsed parameters of the tool.
+type structuredQuery struct {
+	CollectionPath  string
+	CollectionGroup bool
+	Filter          *Filter
+	OrderBy         []OrderBy
+	Cursor          Cursor
+	Select          []string
+	Limit           int
+	AnalyzeQuery    bool
+}
+
+// Invoke executes the Firestore query based on the provided parameters
+func (t Tool) Invoke(ctx context.Context, primitiveMgr tools.SourceProvider, params parameters.ParamValues, accessToken tools.AccessToken) (any, util.ToolboxError) {
+	source, err := tools.GetCompatibleSource[compatibleSource](primitiveMgr, t.Cfg.Source, t.Cfg.Name, t.Cfg.Type)
+	if err != nil {
+		return nil, util.NewClientServerError("source used is not compatible with the tool", http.StatusInternalServerError, err)
+	}
+
+	q, err := parseStructuredQuery(params.AsMap())
+	if err != nil {
+		return nil, util.NewAgentError(fmt.Sprintf("failed to parse query parameters: %v", err), err)
+	}
+	query, err := q.build(source.FirestoreClient())
+	if err != nil {
+		return nil, util.NewAgentError(fmt.Sprintf("invalid query: %v", err), err)
+	}
+	resp, err := source.ExecuteQuery(ctx, query, q.AnalyzeQuery)
+	if err != nil {
+		return nil, util.ProcessGcpError(err)
+	}
+	if err := fsUtil.InjectDocumentPaths(resp, t.Cfg.DocumentIDConfig); err != nil {
+		return nil, util.NewAgentError("error injecting document paths", err)
+	}
+	return resp, nil
+}
+
+// parseStructuredQuery extracts and validates the query from the parameter
+// values.
+func parseStructuredQuery(params map[string]any) (*structuredQuery, error) {
+	collectionPath, ok := params[collectionPathKey].(string)
+	if !ok || collectionPath == "" {
+		return nil, fmt.Errorf("invalid or missing '%s' parameter", collectionPathKey)
+	}
+	q := &structuredQuery{CollectionPath: collectionPath, Limit: defaultLimit, AnalyzeQuery: defaultAnalyze}
+	q.CollectionGroup, _ = params[collectionGroupKey].(bool)
+	if q.CollectionGroup {
+		if strings.Contains(collectionPath, "/") {
+			return nil, fmt.Errorf("'%s' must be a collection ID, without '/', when '%s' is true", collectionPathKey, collectionGroupKey)
+		}
+	} else if err := fsUtil.ValidateCollectionPath(collectionPath); err != nil {
+		return nil, fmt.Errorf("invalid collection path: %w", err)
+	}
+
+	if raw, ok := params[filterKey].(string); ok && strings.TrimSpace(raw) != "" {
+		var f Filter
+		if err := json.Unmarshal([]byte(raw), &f); err != nil {
+			return nil, fmt.Errorf("failed to parse filter: %w", err)
+		}
+		conditions := 0
+		if err := f.validate(0, &conditions); err != nil {
+			return nil, fmt.Errorf("invalid filter: %w", err)
+		}
+		q.Filter = &f
+	}
+
+	if raw, ok := params[orderByKey].([]any); ok {
+		for i, item := range raw {
+			s, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("orderBy at index %d is not a string", i)
+			}
+			var o OrderBy
+			if err := json.Unmarshal([]byte(s), &o); err != nil {
+				return nil, fmt.Errorf("failed to parse orderBy at index %d: %w", i, err)
+			}
+			if o.Field == "" {
+				return nil, fmt.Errorf("orderBy at index %d has no field", i)
+			}
+			q.OrderBy = append(q.OrderBy, o)
+		}
+	}
+
+	if raw, ok := params[cursorKey].(string); ok && strings.TrimSpace(raw) != "" {
+		dec := json.NewDecoder(strings.NewReader(raw))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&q.Cursor); err != nil {
+			return nil, fmt.Errorf("failed to parse cursor: %w", err)
+		}
+		if err := q.Cursor.validate(len(q.OrderBy)); err != nil {
+			return nil, fmt.Errorf("invalid cursor: %w", err)
+		}
+	}
+
+	if raw, ok := params[selectKey].([]any); ok {
+		for _, item := range raw {
+			if s, ok := item.(string); ok && s != "" {
+				q.Select = append(q.Select, s)
+			}
+		}
+	}
+	if limit, ok := params[limitKey].(int); ok {
+		if limit <= 0 {
+			return nil, fmt.Errorf("'%s' must be positive", limitKey)
+		}
+		q.Limit = limit
+	}
+	if analyze, ok := params[analyzeQueryKey].(bool); ok {
+		q.AnalyzeQuery = analyze
+	}
+	return q, nil
+}
+
+// validate checks the filter, counting its field conditions into
+// conditions.
+func (f Filter) validate(depth int, conditions *int) error {
+	if depth > maxFilterDepth {
+		return fmt.Errorf("filters may be nested at most %d levels deep", maxFilterDepth)
+	}
+	composite := len(f.And) > 0 || len(f.Or) > 0
+	if composite && (f.Field != "" || f.Op != "" || f.Value != nil) {
+		return fmt.Errorf("a filter is either a composite filter or a field condition, not both")
+	}
+	if len(f.And) > 0 && len(f.Or) > 0 {
+		return fmt.Errorf("a composite filter has either 'and' or 'or', not both")
+	}
+	for _, sub := range f.And {
+		if err := sub.validate(depth+1, conditions); err != nil {
+			return err
+		}
+	}
+	for _, sub := range f.Or {
+		if err := sub.validate(depth+1, conditions); err != nil {
+			return err
+		}
+	}
+	if composite {
+		return nil
+	}
+	if f.Field == "" {
+		return fmt.Errorf("filter field cannot be empty")
+	}
+	if !validOperators[f.Op] {
+		return fmt.Errorf("unsupported operator %q on field '%s'", f.Op, f.Field)
+	}
+	if f.Value == nil {
+		return fmt.Errorf("no value specified for filter on field '%s'", f.Field)
+	}
+	*conditions++
+	if *conditions > maxFilterConditions {
+		return fmt.Errorf("too many filter conditions (maximum: %d)", maxFilterConditions)
+	}
+	return nil
+}
+
+// validate checks the cursor against the number of orderings of the query.
+func (c Cursor) validate(orderings int) error {
+	if len(c.StartAt) > 0 && len(c.StartAfter) > 0 {
+		return fmt.Errorf("use either 'startAt' or 'startAfter'")
+	}
+	if len(c.EndAt) > 0 && len(c.EndBefore) > 0 {
+		return fmt.Errorf("use either 'endAt' or 'endBefore'")
+	}
+	for _, values := range [][]any{c.StartAt, c.StartAfter, c.EndAt, c.EndBefore} {
+		if len(values) > orderings {
+			return fmt.Errorf("a cursor has %d values, but the query has %d orderings", len(values), orderings)
+		}
+	}
+	return nil
+}
+
+// build returns the Firestore query of q.
+func (q *structuredQuery) build(client *firestoreapi.Client) (*firestoreapi.Query, error) {
+	var query firestoreapi.Query
+	if q.CollectionGroup {
+		query = client.CollectionGroup(q.CollectionPath).Query
+	} else {
+		query = client.Collection(q.CollectionPath).Query
+	}
+	if q.Filter != nil {
+		filter, err := q.Filter.entityFilter(client)
+		if err != nil {
+			return nil, err
+		}
+		query = query.WhereEntity(filter)
+	}
+	if len(q.Select) > 0 {
+		query = query.Select(q.Select...)
+	}
+	for _, o := range q.OrderBy {
+		query = query.OrderBy(o.Field, o.direction())
+	}
+	for _, cursor := range []struct {
+		values []any
+		apply  func(firestoreapi.Query, ...any) firestoreapi.Query
+	}{
+		{q.Cursor.StartAt, firestoreapi.Query.StartAt},
+		{q.Cursor.StartAfter, firestoreapi.Query.StartAfter},
+		{q.Cursor.EndAt, firestoreapi.Query.EndAt},
+		{q.Cursor.EndBefore, firestoreapi.Query.EndBefore},
+	} {
+		if len(cursor.values) == 0 {
+			continue
+		}
+		values, err := firestoreValues(cursor.values, client)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cursor value: %w", err)
+		}
+		query = cursor.apply(query, values...)
+	}
+	query = query.Limit(q.Limit)
+	if q.AnalyzeQuery {
+		query = query.WithRunOptions(firestoreapi.ExplainOptions{Analyze: true})
+	}
+	return &query, nil
+}
+
+// entityFilter converts the filter to a Firestore filter.
+func (f Filter) entityFilter(client *firestoreapi.Client) (firestoreapi.EntityFilter, error) {
+	if len(f.And) > 0 || len(f.Or) > 0 {
+		subs := f.And
+		if len(f.Or) > 0 {
+			subs = f.Or
+		}
+		filters := make([]firestoreapi.EntityFilter, 0, len(subs))
+		for _, sub := range subs {
+			converted, err := sub.entityFilter(client)
+			if err != nil {
+				return nil, err
+			}
+			filters = append(filters, converted)
+		}
+		if len(f.And) > 0 {
+			return firestoreapi.AndFilter{Filters: filters}, nil
+		}
+		return firestoreapi.OrFilter{Filters: filters}, nil
+	}
+	value, err := fsUtil.JSONToFirestoreValue(f.Value, client)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for filter on field '%s': %w", f.Field, err)
+	}
+	return firestoreapi.PropertyFilter{Path: f.Field, Operator: f.Op, Value: value}, nil
+}
+
+func firestoreValues(values []any, client *firestoreapi.Client) ([]any, error) {
+	converted := make([]any, len(values))
+	for i, v := range values {
+		c, err := fsUtil.JSONToFirestoreValue(v, client)
+		if err != nil {
+			return nil, err
+		}
+		converted[i] = c
+	}
+	return converted, nil
+}
@@ -0,0 +1,195 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package firestorestructuredquery
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/internal/server"
+	"github.com/googleapis/mcp-toolbox/internal/testutils"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+)
+
+func TestParseFromYamlFirestoreStructuredQuery(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	in := `
+            kind: tool
+            name: query_orders
+            type: firestore-structured-query
+            source: my-firestore-instance
+            description: Query orders with composite filters
+            authRequired:
+                - google-auth-service
+			`
+	want := server.ToolConfigs{
+		"query_orders": Config{
+			ConfigBase: tools.ConfigBase{
+				Name:         "query_orders",
+				Description:  "Query orders with composite filters",
+				AuthRequired: []string{"google-auth-service"},
+			},
+			Type:   "firestore-structured-query",
+			Source: "my-firestore-instance",
+		},
+	}
+	_, _, _, got, _, _, err := server.UnmarshalPrimitiveConfig(ctx, testutils.FormatYaml(in))
+	if err != nil {
+		t.Fatalf("unable to unmarshal: %s", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect parse: diff %v", diff)
+	}
+}
+
+func TestParseStructuredQuery(t *testing.T) {
+	tcs := []struct {
+		desc   string
+		params map[string]any
+		want   *structuredQuery
+	}{
+		{
+			desc:   "collection only",
+			params: map[string]any{"collectionPath": "orders", "limit": 100},
+			want:   &structuredQuery{CollectionPath: "orders", Limit: 100},
+		},
+		{
+			desc: "composite filter with ordering and cursor",
+			params: map[string]any{
+				"collectionPath":  "orders",
+				"collectionGroup": true,
+				"filter":          `{"or": [{"field": "status", "op": "==", "value": "open"}, {"and": [{"field": "total", "op": ">", "value": 10}, {"field": "tags", "op": "array-contains", "value": "gift"}]}]}`,
+				"orderBy":         []any{`{"field": "total", "direction": "DESCENDING"}`, `{"field": "__name__"}`},
+				"cursor":          `{"startAfter": [20, "orders/a"]}`,
+				"select":          []any{"status", "total"},
+				"limit":           10,
+				"analyzeQuery":    true,
+			},
+			want: &structuredQuery{
+				CollectionPath:  "orders",
+				CollectionGroup: true,
+				Filter: &Filter{Or: []Filter{
+					{Field: "status", Op: "==", Value: "open"},
+					{And: []Filter{
+						{Field: "total", Op: ">", Value: float64(10)},
+						{Field: "tags", Op: "array-contains", Value: "gift"},
+					}},
+				}},
+				OrderBy: []OrderBy{
+					{Field: "total", Direction: "DESCENDING"},
+					{Field: "__name__"},
+				},
+				Cursor:       Cursor{StartAfter: []any{float64(20), "orders/a"}},
+				Select:       []string{"status", "total"},
+				Limit:        10,
+				AnalyzeQuery: true,
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := parseStructuredQuery(tc.params)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect query: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestParseStructuredQueryErrors(t *testing.T) {
+	tcs := []struct {
+		desc   string
+		params map[string]any
+		want   string
+	}{
+		{
+			desc:   "missing collection",
+			params: map[string]any{},
+			want:   "invalid or missing 'collectionPath' parameter",
+		},
+		{
+			desc:   "collection group with path",
+			params: map[string]any{"collectionPath": "users/u1/orders", "collectionGroup": true},
+			want:   "must be a collection ID",
+		},
+		{
+			desc:   "unsupported operator",
+			params: map[string]any{"collectionPath": "orders", "filter": `{"field": "a", "op": "like", "value": 1}`},
+			want:   `unsupported operator "like"`,
+		},
+		{
+			desc:   "mixed composite and condition",
+			params: map[string]any{"collectionPath": "orders", "filter": `{"field": "a", "op": "==", "value": 1, "and": [{"field": "b", "op": "==", "value": 2}]}`},
+			want:   "not both",
+		},
+		{
+			desc:   "missing value",
+			params: map[string]any{"collectionPath": "orders", "filter": `{"field": "a", "op": "=="}`},
+			want:   "no value specified",
+		},
+		{
+			desc: "cursor without ordering",
+			params: map[string]any{
+				"collectionPath": "orders",
+				"cursor":         `{"startAt": [1]}`,
+			},
+			want: "query has 0 orderings",
+		},
+		{
+			desc: "conflicting cursors",
+			params: map[string]any{
+				"collectionPath": "orders",
+				"orderBy":        []any{`{"field": "a"}`},
+				"cursor":         `{"startAt": [1], "startAfter": [1]}`,
+			},
+			want: "either 'startAt' or 'startAfter'",
+		},
+		{
+			desc:   "non-positive limit",
+			params: map[string]any{"collectionPath": "orders", "limit": 0},
+			want:   "must be positive",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := parseStructuredQuery(tc.params)
+			if err == nil {
+				t.Fatalf("expected error containing %q, got nil", tc.want)
+			}
+			if !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("expected error containing %q, got %q", tc.want, err)
+			}
+		})
+	}
+}
+
+func TestFilterConditionLimit(t *testing.T) {
+	conditions := make([]Filter, maxFilterConditions+1)
+	for i := range conditions {
+		conditions[i] = Filter{Field: "a", Op: "==", Value: i}
+	}
+	count := 0
+	err := Filter{Or: conditions}.validate(0, &count)
+	if err == nil || !strings.Contains(err.Error(), "too many filter conditions") {
+		t.Fatalf("expected too many conditions error, got %v", err)
+	}
+}
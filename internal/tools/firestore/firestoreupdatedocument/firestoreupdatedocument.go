@@ -35,7 +35,7 @@ const updateMaskKey string = "updateMask"
 const returnDocumentDataKey string = "returnData"
 
 func init() {
-	if !tools.Register(resourceType, newConfig) {
+	if !tools.Register[compatibleSource](resourceType, newConfig) {
 		panic(fmt.Sprintf("tool type %q already registered", resourceType))
 	}
 }
@@ -0,0 +1,73 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+
+	firestoreds "github.com/googleapis/mcp-toolbox/internal/sources/firestore"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+)
+
+// DefaultIDField is the field Firestore tools inject the document path under.
+const DefaultIDField = "_path"
+
+// InjectDocumentPaths injects the path of each document, relative to the
+// database, into the data of the documents in resp. resp is the result of
+// ExecuteQuery or GetDocuments; documents that do not exist are skipped.
+func InjectDocumentPaths(resp any, cfg tools.DocumentIDConfig) error {
+	if !cfg.IncludeIDEnabled() {
+		return nil
+	}
+	field := cfg.IDFieldOrDefault(DefaultIDField)
+	switch resp := resp.(type) {
+	case firestoreds.QueryResponse:
+		return InjectDocumentPaths(resp.Documents, cfg)
+	case []firestoreds.QueryResult:
+		for i := range resp {
+			if resp[i].Data == nil {
+				resp[i].Data = map[string]any{}
+			}
+			if err := tools.InjectDocumentID(resp[i].Data, field, RelativeDocumentPath(resp[i].Path)); err != nil {
+				return fmt.Errorf("document %q: %w", resp[i].Path, err)
+			}
+		}
+	case []any:
+		for _, item := range resp {
+			doc, ok := item.(map[string]any)
+			if !ok {
+				continue
+			}
+			data, ok := doc["data"].(map[string]any)
+			if !ok {
+				continue
+			}
+			path, _ := doc["path"].(string)
+			if err := tools.InjectDocumentID(data, field, RelativeDocumentPath(path)); err != nil {
+				return fmt.Errorf("document %q: %w", path, err)
+			}
+		}
+	}
+	return nil
+}
+
+// RelativeDocumentPath strips the "projects/{project}/databases/{database}/documents/"
+// prefix from an absolute document path.
+func RelativeDocumentPath(path string) string {
+	if loc := absolutePathRegex.FindStringIndex(path); loc != nil {
+		return path[loc[1]:]
+	}
+	return path
+}
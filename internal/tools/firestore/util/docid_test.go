@@ -0,0 +1,83 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	firestoreds "github.com/googleapis/mcp-toolbox/internal/sources/firestore"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+)
+
+func TestInjectDocumentPaths(t *testing.T) {
+	t.Run("query results", func(t *testing.T) {
+		resp := firestoreds.QueryResponse{
+			Documents: []firestoreds.QueryResult{
+				{ID: "alice", Path: "projects/p/databases/(default)/documents/users/alice", Data: map[string]any{"name": "Alice"}},
+				{ID: "empty", Path: "projects/p/databases/(default)/documents/users/empty"},
+			},
+		}
+		if err := InjectDocumentPaths(resp, tools.DocumentIDConfig{}); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		want := []map[string]any{
+			{"name": "Alice", "_path": "users/alice"},
+			{"_path": "users/empty"},
+		}
+		for i, doc := range resp.Documents {
+			if diff := cmp.Diff(want[i], doc.Data); diff != "" {
+				t.Errorf("unexpected data of document %d (-want +got):\n%s", i, diff)
+			}
+		}
+	})
+
+	t.Run("get documents", func(t *testing.T) {
+		resp := []any{
+			map[string]any{"path": "users/alice", "exists": true, "data": map[string]any{"name": "Alice"}},
+			map[string]any{"path": "users/missing", "exists": false},
+		}
+		if err := InjectDocumentPaths(resp, tools.DocumentIDConfig{IDField: "docPath"}); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		want := []any{
+			map[string]any{"path": "users/alice", "exists": true, "data": map[string]any{"name": "Alice", "docPath": "users/alice"}},
+			map[string]any{"path": "users/missing", "exists": false},
+		}
+		if diff := cmp.Diff(want, resp); diff != "" {
+			t.Errorf("unexpected documents (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		disabled := false
+		resp := []firestoreds.QueryResult{{Path: "users/alice", Data: map[string]any{"name": "Alice"}}}
+		if err := InjectDocumentPaths(resp, tools.DocumentIDConfig{IncludeID: &disabled}); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if diff := cmp.Diff(map[string]any{"name": "Alice"}, resp[0].Data); diff != "" {
+			t.Errorf("unexpected data (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("collision", func(t *testing.T) {
+		resp := []firestoreds.QueryResult{{Path: "users/alice", Data: map[string]any{"_path": "elsewhere"}}}
+		err := InjectDocumentPaths(resp, tools.DocumentIDConfig{})
+		if err == nil || !strings.Contains(err.Error(), `document already has a field "_path"`) {
+			t.Fatalf("unexpected error: got %v", err)
+		}
+	})
+}
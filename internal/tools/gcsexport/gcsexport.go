@@ -0,0 +1,114 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package gcsexport holds the configuration and result types shared by the
+// tools that export query results to Cloud Storage objects.
+package gcsexport
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	FormatCSV     = "csv"
+	FormatParquet = "parquet"
+)
+
+// Destination is the Cloud Storage location exports are written to. Tools
+// only ever write objects below Prefix in Bucket; neither can be chosen by
+// the agent.
+type Destination struct {
+	Bucket string `yaml:"bucket" validate:"required"`
+	Prefix string `yaml:"prefix"`
+}
+
+// Validate checks that the destination is usable and that format is one of
+// formats.
+func (d Destination) Validate(format string, formats ...string) error {
+	if d.Bucket == "" {
+		return fmt.Errorf("destination bucket is required")
+	}
+	if strings.ContainsAny(d.Bucket, "/*") {
+		return fmt.Errorf("invalid destination bucket %q", d.Bucket)
+	}
+	if strings.Contains(d.Prefix, "*") {
+		return fmt.Errorf("destination prefix %q must not contain wildcards", d.Prefix)
+	}
+	for _, f := range formats {
+		if f == format {
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported export format %q, must be one of %q", format, formats)
+}
+
+// ObjectName returns a new, unique object name below the destination prefix
+// for an export of toolName. The name carries no extension so that callers
+// can add a shard suffix before it.
+func (d Destination) ObjectName(toolName string, now time.Time) string {
+	prefix := d.Prefix
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	return fmt.Sprintf("%s%s-%s-%s", prefix, toolName, now.UTC().Format("20060102T150405Z"), uuid.NewString()[:8])
+}
+
+// URI returns the gs:// URI of an object in the destination bucket.
+func (d Destination) URI(object string) string {
+	return fmt.Sprintf("gs://%s/%s", d.Bucket, object)
+}
+
+// Extension returns the file extension of an export format.
+func Extension(format string) string {
+	return "." + format
+}
+
+// Result describes a finished export.
+type Result struct {
+	URIs     []string `json:"uris"`
+	Format   string   `json:"format"`
+	RowCount int64    `json:"rowCount"`
+	ByteSize int64    `json:"byteSize"`
+}
+
+// ProgressWriter counts the bytes written through it and calls report each
+// time another interval bytes have been written.
+type ProgressWriter struct {
+	W        io.Writer
+	Interval int64
+	Report   func(written int64)
+
+	written int64
+	next    int64
+}
+
+func (w *ProgressWriter) Write(p []byte) (int, error) {
+	n, err := w.W.Write(p)
+	w.written += int64(n)
+	if w.Report != nil && w.Interval > 0 && w.written >= w.next {
+		w.Report(w.written)
+		w.next = w.written + w.Interval
+	}
+	return n, err
+}
+
+// Written returns the number of bytes written so far.
+func (w *ProgressWriter) Written() int64 {
+	return w.written
+}
@@ -33,7 +33,7 @@ import (
 const resourceType string = "http"
 
 func init() {
-	if !tools.Register(resourceType, newConfig) {
+	if !tools.Register[compatibleSource](resourceType, newConfig) {
 		panic(fmt.Sprintf("tool type %q already registered", resourceType))
 	}
 }
@@ -173,32 +173,65 @@ func getURL(baseURL, path string, pathParams, queryParams parameters.Parameters,
 		return "", fmt.Errorf("error replacing pathParams: %s", err)
 	}
 
+	parsedURL, err := ResolvePath(baseURL, templatedPath.String())
+	if err != nil {
+		return "", err
+	}
+
+	// Get existing query parameters from the URL
+	queryParameters := parsedURL.Query()
+	for key, value := range defaultQueryParams {
+		queryParameters.Add(key, value)
+	}
+	parsedURL.RawQuery = queryParameters.Encode()
+
+	// Set dynamic query parameters
+	query := parsedURL.Query()
+	for _, p := range queryParams {
+		v, ok := paramsMap[p.GetName()]
+		if !ok || v == nil {
+			if !p.GetRequired() {
+				// If the param is not required AND
+				// Not provodid OR provided with a nil value
+				// Omitted from the URL
+				continue
+			}
+			v = ""
+		}
+		query.Add(p.GetName(), fmt.Sprintf("%v", v))
+	}
+	parsedURL.RawQuery = query.Encode()
+	return parsedURL.String(), nil
+}
+
+// ResolvePath resolves a relative request path against baseURL. The path may
+// not override the base host or use dot segments, and the result must stay
+// within the base URL's path.
+func ResolvePath(baseURL, relativePath string) (*url.URL, error) {
 	baseParsedURL, err := url.Parse(baseURL)
 	if err != nil {
-		return "", fmt.Errorf("error parsing base URL: %s", err)
+		return nil, fmt.Errorf("error parsing base URL: %s", err)
 	}
 	if baseParsedURL.Scheme == "" || baseParsedURL.Host == "" {
-		return "", fmt.Errorf("base URL must include scheme and host")
+		return nil, fmt.Errorf("base URL must include scheme and host")
 	}
 
-	relativePath := templatedPath.String()
 	relParsedURL, err := url.Parse(relativePath)
 	if err != nil {
-		return "", fmt.Errorf("error parsing URL path: %s", err)
+		return nil, fmt.Errorf("error parsing URL path: %s", err)
 	}
 	if relParsedURL.Scheme != "" || relParsedURL.Host != "" || relParsedURL.User != nil {
-		return "", fmt.Errorf("path must be relative and cannot override base host")
+		return nil, fmt.Errorf("path must be relative and cannot override base host")
 	}
 
 	// Reject dot segments before resolution
 	for _, segment := range strings.Split(relParsedURL.Path, "/") {
 		if segment == ".." {
-			return "", fmt.Errorf("path cannot contain dot segments (..)")
+			return nil, fmt.Errorf("path cannot contain dot segments (..)")
 		}
 	}
 
 	// Create URL based on BaseURL and Path
-	// Attach query parameters
 	parsedURL := baseParsedURL.ResolveReference(relParsedURL)
 
 	// Verify final path stays within base path scope
@@ -207,34 +240,10 @@ func getURL(baseURL, path string, pathParams, queryParams parameters.Parameters,
 	if basePath != "/" {
 		requiredPrefix := strings.TrimSuffix(basePath, "/") + "/"
 		if finalPath != basePath && !strings.HasPrefix(finalPath, requiredPrefix) {
-			return "", fmt.Errorf("resolved path %q escapes base path %q", finalPath, basePath)
+			return nil, fmt.Errorf("resolved path %q escapes base path %q", finalPath, basePath)
 		}
 	}
-
-	// Get existing query parameters from the URL
-	queryParameters := parsedURL.Query()
-	for key, value := range defaultQueryParams {
-		queryParameters.Add(key, value)
-	}
-	parsedURL.RawQuery = queryParameters.Encode()
-
-	// Set dynamic query parameters
-	query := parsedURL.Query()
-	for _, p := range queryParams {
-		v, ok := paramsMap[p.GetName()]
-		if !ok || v == nil {
-			if !p.GetRequired() {
-				// If the param is not required AND
-				// Not provodid OR provided with a nil value
-				// Omitted from the URL
-				continue
-			}
-			v = ""
-		}
-		query.Add(p.GetName(), fmt.Sprintf("%v", v))
-	}
-	parsedURL.RawQuery = query.Encode()
-	return parsedURL.String(), nil
+	return parsedURL, nil
 }
 
 // Helper function to generate the HTTP headers upon Tool invocation.
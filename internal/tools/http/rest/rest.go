@@ -0,0 +1,292 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"text/template"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	httptool "github.com/googleapis/mcp-toolbox/internal/tools/http"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/jsonpath"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+const resourceType string = "rest"
+
+var allowedMethods = []string{
+	http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut,
+	http.MethodPatch, http.MethodDelete, http.MethodOptions,
+}
+
+func init() {
+	if !tools.Register[compatibleSource](resourceType, newConfig) {
+		panic(fmt.Sprintf("tool type %q already registered", resourceType))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{ConfigBase: tools.ConfigBase{Name: name}}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type compatibleSource interface {
+	HttpDefaultHeaders() map[string]string
+	HttpBaseURL() string
+	HttpQueryParams() map[string]string
+	RunRequest(context.Context, *http.Request) (any, error)
+}
+
+// Config describes a request whose method, path, query, headers and body are
+// Go templates rendered with the tool's parameters.
+type Config struct {
+	tools.ConfigBase `yaml:",inline"`
+	Type             string                 `yaml:"type" validate:"required"`
+	Source           string                 `yaml:"source" validate:"required"`
+	Method           string                 `yaml:"method" validate:"required"`
+	Path             string                 `yaml:"path" validate:"required"`
+	Query            map[string]string      `yaml:"query"`
+	Headers          map[string]string      `yaml:"headers"`
+	Body             string                 `yaml:"body"`
+	Parameters       parameters.Parameters  `yaml:"parameters"`
+	ResponsePath     string                 `yaml:"responsePath"`
+	Annotations      *tools.ToolAnnotations `yaml:"annotations,omitempty"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigType() string {
+	return resourceType
+}
+
+func (cfg Config) Initialize(context.Context) (tools.Tool, error) {
+	if cfg.Description == "" {
+		return nil, fmt.Errorf("description is required for tool %q", cfg.Name)
+	}
+	if err := parameters.CheckDuplicateParameters(cfg.Parameters); err != nil {
+		return nil, err
+	}
+
+	t := Tool{
+		query:   make(map[string]*template.Template, len(cfg.Query)),
+		headers: make(map[string]*template.Template, len(cfg.Headers)),
+	}
+	var err error
+	if t.method, err = parseTemplate("method", cfg.Method); err != nil {
+		return nil, err
+	}
+	if t.path, err = parseTemplate("path", cfg.Path); err != nil {
+		return nil, err
+	}
+	if t.body, err = parseTemplate("body", cfg.Body); err != nil {
+		return nil, err
+	}
+	for k, v := range cfg.Query {
+		if t.query[k], err = parseTemplate("query "+k, v); err != nil {
+			return nil, err
+		}
+	}
+	for k, v := range cfg.Headers {
+		if t.headers[k], err = parseTemplate("header "+k, v); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.ResponsePath != "" {
+		if t.responsePath, err = jsonpath.Compile(cfg.ResponsePath); err != nil {
+			return nil, fmt.Errorf("invalid responsePath for tool %q: %w", cfg.Name, err)
+		}
+	}
+
+	method := strings.ToUpper(cfg.Method)
+	if !strings.Contains(method, "{{") && !slices.Contains(allowedMethods, method) {
+		return nil, fmt.Errorf("unsupported method %q for tool %q", cfg.Method, cfg.Name)
+	}
+	// A statically configured read method makes the tool read-only; anything
+	// else, including a templated method, may modify upstream state.
+	defaultAnnotations := tools.NewDestructiveAnnotations
+	if method == http.MethodGet || method == http.MethodHead {
+		defaultAnnotations = tools.NewReadOnlyAnnotations
+	}
+
+	paramManifest := cfg.Parameters.Manifest()
+	if paramManifest == nil {
+		paramManifest = make([]parameters.ParameterManifest, 0)
+	}
+
+	t.BaseTool = tools.NewBaseTool(
+		cfg,
+		tools.GetAnnotationsOrDefault(cfg.Annotations, defaultAnnotations),
+		tools.Manifest{Description: cfg.Description, Parameters: paramManifest, AuthRequired: cfg.AuthRequired},
+		cfg.Parameters,
+	)
+	return t, nil
+}
+
+var templateFuncs = template.FuncMap{
+	"pathEscape":  url.PathEscape,
+	"queryEscape": url.QueryEscape,
+	"json": func(v any) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+}
+
+func parseTemplate(name, text string) (*template.Template, error) {
+	tmpl, err := template.New(name).Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing %s template: %w", name, err)
+	}
+	return tmpl, nil
+}
+
+func render(tmpl *template.Template, data map[string]any) (string, error) {
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("error rendering %s: %w", tmpl.Name(), err)
+	}
+	return b.String(), nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	tools.BaseTool[Config]
+	method       *template.Template
+	path         *template.Template
+	body         *template.Template
+	query        map[string]*template.Template
+	headers      map[string]*template.Template
+	responsePath *jsonpath.Path
+}
+
+func (t Tool) ToConfig() tools.ToolConfig {
+	return t.Cfg
+}
+
+// buildRequest renders the request templates against the parameter values.
+// Parameters that were not provided render as empty strings, and query
+// parameters or headers that render empty are omitted from the request.
+func (t Tool) buildRequest(ctx context.Context, source compatibleSource, params parameters.ParamValues) (*http.Request, error) {
+	data := params.AsMap()
+	for k, v := range data {
+		if v == nil {
+			data[k] = ""
+		}
+	}
+
+	method, err := render(t.method, data)
+	if err != nil {
+		return nil, err
+	}
+	method = strings.ToUpper(strings.TrimSpace(method))
+	if !slices.Contains(allowedMethods, method) {
+		return nil, fmt.Errorf("unsupported method %q", method)
+	}
+
+	path, err := render(t.path, data)
+	if err != nil {
+		return nil, err
+	}
+	u, err := httptool.ResolvePath(source.HttpBaseURL(), path)
+	if err != nil {
+		return nil, err
+	}
+	query := u.Query()
+	for k, v := range source.HttpQueryParams() {
+		query.Add(k, v)
+	}
+	for _, k := range slices.Sorted(maps.Keys(t.query)) {
+		v, err := render(t.query[k], data)
+		if err != nil {
+			return nil, err
+		}
+		if v != "" {
+			query.Set(k, v)
+		}
+	}
+	u.RawQuery = query.Encode()
+
+	body, err := render(t.body, data)
+	if err != nil {
+		return nil, err
+	}
+	var reader io.Reader
+	if body != "" {
+		reader = strings.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), reader)
+	if err != nil {
+		return nil, err
+	}
+
+	// Tool headers override source headers.
+	for k, v := range source.HttpDefaultHeaders() {
+		req.Header.Set(k, v)
+	}
+	for k, tmpl := range t.headers {
+		v, err := render(tmpl, data)
+		if err != nil {
+			return nil, err
+		}
+		if v != "" {
+			req.Header.Set(k, v)
+		}
+	}
+	return req, nil
+}
+
+func (t Tool) Invoke(ctx context.Context, primitiveMgr tools.SourceProvider, params parameters.ParamValues, accessToken tools.AccessToken) (any, util.ToolboxError) {
+	source, err := tools.GetCompatibleSource[compatibleSource](primitiveMgr, t.Cfg.Source, t.Cfg.Name, t.Cfg.Type)
+	if err != nil {
+		return nil, util.NewClientServerError("source used is not compatible with the tool", http.StatusInternalServerError, err)
+	}
+
+	req, err := t.buildRequest(ctx, source, params)
+	if err != nil {
+		return nil, util.NewAgentError("error building request", err)
+	}
+
+	resp, err := source.RunRequest(ctx, req)
+	if err != nil {
+		return nil, util.ProcessGeneralError(err)
+	}
+	if t.responsePath == nil {
+		return resp, nil
+	}
+	if _, ok := resp.(string); ok {
+		return nil, util.NewAgentError("response is not JSON, cannot apply responsePath", nil)
+	}
+	extracted, err := t.responsePath.Get(resp)
+	if err != nil {
+		return nil, util.NewAgentError("error extracting response", err)
+	}
+	return extracted, nil
+}
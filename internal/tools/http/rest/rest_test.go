@@ -0,0 +1,251 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/internal/server"
+	"github.com/googleapis/mcp-toolbox/internal/sources"
+	"github.com/googleapis/mcp-toolbox/internal/testutils"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+func TestParseFromYamlRest(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	in := `
+	kind: tool
+	name: get_order
+	type: rest
+	source: my-api
+	description: Get an order.
+	method: GET
+	path: /orders/{{ pathEscape .id }}
+	query:
+		expand: "{{ .expand }}"
+	headers:
+		X-Tenant: "{{ .tenant }}"
+	responsePath: $.data
+	parameters:
+		- name: id
+		  type: string
+		  description: The order id.
+	`
+	want := server.ToolConfigs{
+		"get_order": Config{
+			ConfigBase: tools.ConfigBase{
+				Name:         "get_order",
+				Description:  "Get an order.",
+				AuthRequired: []string{},
+			},
+			Type:         resourceType,
+			Source:       "my-api",
+			Method:       "GET",
+			Path:         "/orders/{{ pathEscape .id }}",
+			Query:        map[string]string{"expand": "{{ .expand }}"},
+			Headers:      map[string]string{"X-Tenant": "{{ .tenant }}"},
+			ResponsePath: "$.data",
+			Parameters: parameters.Parameters{
+				parameters.NewStringParameter("id", "The order id."),
+			},
+		},
+	}
+	_, _, _, got, _, _, err := server.UnmarshalPrimitiveConfig(ctx, testutils.FormatYaml(in))
+	if err != nil {
+		t.Fatalf("unable to unmarshal: %s", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect parse (-want +got):\n%s", diff)
+	}
+}
+
+func TestInitializeErrors(t *testing.T) {
+	base := Config{
+		ConfigBase: tools.ConfigBase{Name: "t", Description: "d"},
+		Type:       resourceType,
+		Source:     "s",
+		Method:     "GET",
+		Path:       "/",
+	}
+	tcs := []struct {
+		desc   string
+		modify func(*Config)
+	}{
+		{desc: "bad method", modify: func(c *Config) { c.Method = "FETCH" }},
+		{desc: "bad template", modify: func(c *Config) { c.Path = "/{{ .id" }},
+		{desc: "bad response path", modify: func(c *Config) { c.ResponsePath = "data" }},
+		{desc: "missing description", modify: func(c *Config) { c.Description = "" }},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := base
+			tc.modify(&cfg)
+			if _, err := cfg.Initialize(context.Background()); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
+
+func TestAnnotations(t *testing.T) {
+	for method, wantReadOnly := range map[string]bool{"GET": true, "get": true, "POST": false, "{{ .method }}": false} {
+		cfg := Config{
+			ConfigBase: tools.ConfigBase{Name: "t", Description: "d"},
+			Type:       resourceType,
+			Source:     "s",
+			Method:     method,
+			Path:       "/",
+		}
+		tool, err := cfg.Initialize(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		readOnly := tool.GetAnnotations().ReadOnlyHint
+		if got := readOnly != nil && *readOnly; got != wantReadOnly {
+			t.Errorf("method %q: want read-only %t, got %t", method, wantReadOnly, got)
+		}
+	}
+}
+
+type fakeSource struct {
+	baseURL string
+	client  *http.Client
+}
+
+func (s fakeSource) SourceType() string             { return "http" }
+func (s fakeSource) ToConfig() sources.SourceConfig { return nil }
+func (s fakeSource) HttpDefaultHeaders() map[string]string {
+	return map[string]string{"X-Source": "src"}
+}
+func (s fakeSource) HttpBaseURL() string                { return s.baseURL }
+func (s fakeSource) HttpQueryParams() map[string]string { return map[string]string{"key": "k"} }
+
+func (s fakeSource) RunRequest(ctx context.Context, req *http.Request) (any, error) {
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var data any
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+type sourceMap map[string]sources.Source
+
+func (m sourceMap) GetSource(name string) (sources.Source, bool) {
+	s, ok := m[name]
+	return s, ok
+}
+
+func TestInvoke(t *testing.T) {
+	type captured struct {
+		Method string
+		Path   string
+		Query  string
+		Tenant string
+		Source string
+		Body   string
+	}
+	var got captured
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		got = captured{
+			Method: r.Method,
+			Path:   r.URL.EscapedPath(),
+			Query:  r.URL.RawQuery,
+			Tenant: r.Header.Get("X-Tenant"),
+			Source: r.Header.Get("X-Source"),
+			Body:   string(body),
+		}
+		_, _ = w.Write([]byte(`{"data": {"items": [{"id": "a"}, {"id": "b"}]}}`))
+	}))
+	defer srv.Close()
+
+	cfg := Config{
+		ConfigBase:   tools.ConfigBase{Name: "update_order", Description: "Update an order."},
+		Type:         resourceType,
+		Source:       "my-api",
+		Method:       "{{ .method }}",
+		Path:         "/api/orders/{{ pathEscape .id }}",
+		Query:        map[string]string{"expand": "{{ .expand }}", "fields": "{{ .fields }}"},
+		Headers:      map[string]string{"X-Tenant": "{{ .tenant }}"},
+		Body:         `{"note": {{ json .note }}}`,
+		ResponsePath: "$.data.items[*].id",
+		Parameters: parameters.Parameters{
+			parameters.NewStringParameter("method", "HTTP method."),
+			parameters.NewStringParameter("id", "Order id."),
+			parameters.NewStringParameter("expand", "Expansions.", parameters.WithStringRequired(false)),
+			parameters.NewStringParameter("fields", "Fields.", parameters.WithStringRequired(false)),
+			parameters.NewStringParameter("tenant", "Tenant."),
+			parameters.NewStringParameter("note", "Note."),
+		},
+	}
+	tool, err := cfg.Initialize(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	params := parameters.ParamValues{
+		{Name: "method", Value: "patch"},
+		{Name: "id", Value: "a/b"},
+		{Name: "expand", Value: "lines"},
+		{Name: "fields", Value: nil},
+		{Name: "tenant", Value: "acme"},
+		{Name: "note", Value: `say "hi"`},
+	}
+	src := fakeSource{baseURL: srv.URL + "/api/", client: srv.Client()}
+	res, toolErr := tool.Invoke(context.Background(), sourceMap{"my-api": src}, params, "")
+	if toolErr != nil {
+		t.Fatalf("unexpected error: %s", toolErr)
+	}
+	if diff := cmp.Diff([]any{"a", "b"}, res); diff != "" {
+		t.Errorf("incorrect result (-want +got):\n%s", diff)
+	}
+	want := captured{
+		Method: "PATCH",
+		Path:   "/api/orders/a%2Fb",
+		Query:  "expand=lines&key=k",
+		Tenant: "acme",
+		Source: "src",
+		Body:   `{"note": "say \"hi\""}`,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("incorrect request (-want +got):\n%s", diff)
+	}
+
+	params[0].Value = "TRACE"
+	if _, toolErr := tool.Invoke(context.Background(), sourceMap{"my-api": src}, params, ""); toolErr == nil || !strings.Contains(toolErr.Error(), "unsupported method") {
+		t.Errorf("expected unsupported method error, got %v", toolErr)
+	}
+
+	params[0].Value = "GET"
+	params[1].Value = "../../admin"
+	if _, toolErr := tool.Invoke(context.Background(), sourceMap{"my-api": src}, params, ""); toolErr == nil {
+		t.Error("expected an error for a path escaping the base URL")
+	}
+}
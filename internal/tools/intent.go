@@ -0,0 +1,122 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+// Intents of tools. Invoking a tool with write or admin intent requires an
+// auth token whose permissions claim grants it.
+const (
+	IntentRead  = "read"
+	IntentWrite = "write"
+	IntentAdmin = "admin"
+)
+
+// PermissionsClaim is the claim of auth tokens listing the intents they may
+// invoke tools with, either as a list or as a space-separated string.
+const PermissionsClaim = "permissions"
+
+// GetIntent returns the intent of tool, or "" if it declares none.
+func GetIntent(tool Tool) string {
+	if c, ok := tool.ToConfig().(interface{ GetIntent() string }); ok {
+		return c.GetIntent()
+	}
+	return ""
+}
+
+// IsWrite reports whether tool has write or admin intent.
+func IsWrite(tool Tool) bool {
+	intent := GetIntent(tool)
+	return intent == IntentWrite || intent == IntentAdmin
+}
+
+// CheckIntent returns an error if none of the claims of the verified auth
+// services grants the intent of tool. Tools with read intent or none need no
+// permission, and the admin permission also grants write intent.
+func CheckIntent(tool Tool, claimsFromAuth map[string]map[string]any) util.ToolboxError {
+	if !IsWrite(tool) {
+		return nil
+	}
+	intent := GetIntent(tool)
+	for _, claims := range claimsFromAuth {
+		granted := permissions(claims[PermissionsClaim])
+		if slices.Contains(granted, intent) || (intent == IntentWrite && slices.Contains(granted, IntentAdmin)) {
+			return nil
+		}
+	}
+	return util.NewClientServerError(
+		fmt.Sprintf("tool %q has %s intent: invoking it requires an auth token with %q in its %q claim", tool.GetName(), intent, intent, PermissionsClaim),
+		http.StatusForbidden,
+		nil,
+	)
+}
+
+// MarkWriteIntent returns the tools with every tool of write or admin intent
+// marking the context of its invocations with util.WithWriteInvocation, so
+// that sources don't retry operations that may have written.
+func MarkWriteIntent(toolsMap map[string]Tool) map[string]Tool {
+	wrapped := make(map[string]Tool, len(toolsMap))
+	for name, t := range toolsMap {
+		if IsWrite(t) {
+			t = writeTool{Tool: t}
+		}
+		wrapped[name] = t
+	}
+	return wrapped
+}
+
+// writeTool is a tool whose invocations are marked as writes.
+type writeTool struct {
+	Tool
+}
+
+func (t writeTool) Invoke(ctx context.Context, sp SourceProvider, params parameters.ParamValues, token AccessToken) (any, util.ToolboxError) {
+	return t.Tool.Invoke(util.WithWriteInvocation(ctx), sp, params, token)
+}
+
+// Unwrap implements Wrapper.
+func (t writeTool) Unwrap() Tool { return t.Tool }
+
+// DryRun implements DryRunner.
+func (t writeTool) DryRun(ctx context.Context, sp SourceProvider, params parameters.ParamValues, token AccessToken) (*DryRunResult, util.ToolboxError) {
+	return DryRun(util.WithWriteInvocation(ctx), t.Tool, sp, params, token)
+}
+
+func permissions(claim any) []string {
+	switch v := claim.(type) {
+	case string:
+		return strings.Fields(v)
+	case []any:
+		out := make([]string, 0, len(v))
+		for _, p := range v {
+			if s, ok := p.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	case []string:
+		return v
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+func newIntentTool(intent string) tools.Tool {
+	cfg := stubConfig{ConfigBase: tools.ConfigBase{Name: "update_flight", Intent: intent}}
+	return localizedTool{stubTool{tools.NewBaseTool(cfg, nil, tools.Manifest{}, nil)}}
+}
+
+func TestCheckIntent(t *testing.T) {
+	tcs := []struct {
+		desc    string
+		intent  string
+		claims  map[string]map[string]any
+		wantErr bool
+	}{
+		{desc: "no intent", intent: ""},
+		{desc: "read", intent: tools.IntentRead},
+		{desc: "write without claims", intent: tools.IntentWrite, wantErr: true},
+		{
+			desc:    "write without permissions claim",
+			intent:  tools.IntentWrite,
+			claims:  map[string]map[string]any{"my-google-auth": {"sub": "42"}},
+			wantErr: true,
+		},
+		{
+			desc:   "write with permissions list",
+			intent: tools.IntentWrite,
+			claims: map[string]map[string]any{"my-google-auth": {"permissions": []any{"read", "write"}}},
+		},
+		{
+			desc:   "write with permissions string",
+			intent: tools.IntentWrite,
+			claims: map[string]map[string]any{"my-google-auth": {"permissions": "read write"}},
+		},
+		{
+			desc:   "write with admin permission",
+			intent: tools.IntentWrite,
+			claims: map[string]map[string]any{"my-google-auth": {"permissions": "admin"}},
+		},
+		{
+			desc:   "write granted by another auth service",
+			intent: tools.IntentWrite,
+			claims: map[string]map[string]any{
+				"my-google-auth": {"permissions": "read"},
+				"my-other-auth":  {"permissions": "write"},
+			},
+		},
+		{
+			desc:    "admin with write permission",
+			intent:  tools.IntentAdmin,
+			claims:  map[string]map[string]any{"my-google-auth": {"permissions": "write"}},
+			wantErr: true,
+		},
+		{
+			desc:   "admin with admin permission",
+			intent: tools.IntentAdmin,
+			claims: map[string]map[string]any{"my-google-auth": {"permissions": []any{"admin"}}},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := tools.CheckIntent(newIntentTool(tc.intent), tc.claims)
+			if !tc.wantErr {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			if cse, ok := err.(*util.ClientServerError); !ok || cse.Code != http.StatusForbidden {
+				t.Errorf("expected a forbidden error, got %#v", err)
+			}
+		})
+	}
+}
+
+// writeProbe returns whether its invocation was marked as a write.
+type writeProbe struct {
+	tools.BaseTool[stubConfig]
+}
+
+func (t writeProbe) Invoke(ctx context.Context, _ tools.SourceProvider, _ parameters.ParamValues, _ tools.AccessToken) (any, util.ToolboxError) {
+	return util.WriteInvocationFromContext(ctx), nil
+}
+
+func (t writeProbe) ToConfig() tools.ToolConfig { return t.Cfg }
+
+func TestMarkWriteIntent(t *testing.T) {
+	probe := func(intent string) tools.Tool {
+		cfg := stubConfig{ConfigBase: tools.ConfigBase{Name: "probe", Intent: intent}}
+		return writeProbe{tools.NewBaseTool(cfg, nil, tools.Manifest{}, nil)}
+	}
+	wrapped := tools.MarkWriteIntent(map[string]tools.Tool{
+		"none":  probe(""),
+		"read":  probe(tools.IntentRead),
+		"write": probe(tools.IntentWrite),
+		"admin": probe(tools.IntentAdmin),
+	})
+	for name, want := range map[string]bool{"none": false, "read": false, "write": true, "admin": true} {
+		got, err := wrapped[name].Invoke(context.Background(), nil, nil, "")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if got != want {
+			t.Errorf("tool %q: got write %v, want %v", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Translation is the text of a tool in one locale.
+type Translation struct {
+	Description string `yaml:"description,omitempty"`
+	// Parameters maps the names of parameters to their description.
+	Parameters map[string]string `yaml:"parameters,omitempty"`
+}
+
+// Translations maps locales to the text of a tool in them.
+type Translations map[string]Translation
+
+// lookup returns the translation of locale, or else of its language, so that
+// "fr-CA" falls back to "fr".
+func (ts Translations) lookup(locale string) (Translation, bool) {
+	for _, l := range []string{locale, language(locale)} {
+		for k, t := range ts {
+			if strings.EqualFold(k, l) {
+				return t, true
+			}
+		}
+	}
+	return Translation{}, false
+}
+
+func language(locale string) string {
+	lang, _, _ := strings.Cut(locale, "-")
+	return lang
+}
+
+// Localize returns the text of tool in the first of locales it has a
+// translation for. The last of locales is the default locale: tools are not
+// translated past it, and text a translation lacks falls back to the
+// description of the tool.
+func Localize(tool Tool, locales []string) Translation {
+	text := Translation{Description: tool.GetDescription()}
+	c, ok := tool.ToConfig().(interface{ GetTranslations() Translations })
+	if !ok || len(c.GetTranslations()) == 0 || len(locales) == 0 {
+		return text
+	}
+	defaultLocale := locales[len(locales)-1]
+	for _, l := range locales {
+		t, ok := c.GetTranslations().lookup(l)
+		if ok {
+			if t.Description != "" {
+				text.Description = t.Description
+			}
+			text.Parameters = t.Parameters
+			return text
+		}
+		if strings.EqualFold(language(l), language(defaultLocale)) {
+			return text
+		}
+	}
+	return text
+}
+
+// LocalizeManifest returns m with the descriptions of text.
+func LocalizeManifest(m Manifest, text Translation) Manifest {
+	m.Description = text.Description
+	if len(text.Parameters) == 0 {
+		return m
+	}
+	params := slices.Clone(m.Parameters)
+	for i, p := range params {
+		if desc, ok := text.Parameters[p.Name]; ok {
+			params[i].Description = desc
+		}
+	}
+	m.Parameters = params
+	return m
+}
+
+// RequestLocales returns the locales to serve a request in, most preferred
+// first: the languages of its Accept-Language header by weight, the locale of
+// its toolset, then the default locale.
+func RequestLocales(acceptLanguage, toolsetLocale, defaultLocale string) []string {
+	type weighted struct {
+		locale string
+		q      float64
+	}
+	var accepted []weighted
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag = strings.TrimSpace(tag)
+		if tag == "" || tag == "*" {
+			continue
+		}
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q <= 0 {
+			continue
+		}
+		accepted = append(accepted, weighted{locale: tag, q: q})
+	}
+	slices.SortStableFunc(accepted, func(a, b weighted) int {
+		switch {
+		case a.q > b.q:
+			return -1
+		case a.q < b.q:
+			return 1
+		}
+		return 0
+	})
+
+	locales := make([]string, 0, len(accepted)+2)
+	for _, a := range accepted {
+		locales = append(locales, a.locale)
+	}
+	if toolsetLocale != "" {
+		locales = append(locales, toolsetLocale)
+	}
+	return append(locales, defaultLocale)
+}
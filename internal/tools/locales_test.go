@@ -0,0 +1,155 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+// localizedTool returns its config, translations included, from ToConfig.
+type localizedTool struct {
+	stubTool
+}
+
+func (t localizedTool) ToConfig() tools.ToolConfig { return t.Cfg }
+
+func newLocalizedTool(translations tools.Translations) tools.Tool {
+	cfg := stubConfig{ConfigBase: tools.ConfigBase{
+		Name:         "search_flights",
+		Description:  "Search flights",
+		Translations: translations,
+	}}
+	return localizedTool{stubTool{tools.NewBaseTool(cfg, nil, tools.Manifest{}, nil)}}
+}
+
+func TestRequestLocales(t *testing.T) {
+	tcs := []struct {
+		desc           string
+		acceptLanguage string
+		toolsetLocale  string
+		want           []string
+	}{
+		{desc: "default only", want: []string{"en"}},
+		{desc: "toolset", toolsetLocale: "fr", want: []string{"fr", "en"}},
+		{desc: "header before toolset", acceptLanguage: "de", toolsetLocale: "fr", want: []string{"de", "fr", "en"}},
+		{desc: "weights", acceptLanguage: "en;q=0.5, fr-CA, de;q=0.8", want: []string{"fr-CA", "de", "en", "en"}},
+		{desc: "wildcard and refused", acceptLanguage: "*, it;q=0, es", want: []string{"es", "en"}},
+		{desc: "invalid weight", acceptLanguage: "it;q=abc, es", want: []string{"es", "en"}},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := tools.RequestLocales(tc.acceptLanguage, tc.toolsetLocale, "en")
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("unexpected locales (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLocalize(t *testing.T) {
+	tool := newLocalizedTool(tools.Translations{
+		"en": {Description: "Search flights", Parameters: map[string]string{"city": "Departure city"}},
+		"fr": {Description: "Rechercher des vols", Parameters: map[string]string{"city": "Ville de départ"}},
+		"de": {Parameters: map[string]string{"city": "Abflugort"}},
+	})
+
+	tcs := []struct {
+		desc    string
+		locales []string
+		want    tools.Translation
+	}{
+		{
+			desc:    "default locale",
+			locales: []string{"en"},
+			want:    tools.Translation{Description: "Search flights", Parameters: map[string]string{"city": "Departure city"}},
+		},
+		{
+			desc:    "first translated locale",
+			locales: []string{"it", "fr", "en"},
+			want:    tools.Translation{Description: "Rechercher des vols", Parameters: map[string]string{"city": "Ville de départ"}},
+		},
+		{
+			desc:    "language of a regional locale",
+			locales: []string{"FR-ca", "en"},
+			want:    tools.Translation{Description: "Rechercher des vols", Parameters: map[string]string{"city": "Ville de départ"}},
+		},
+		{
+			desc:    "missing description falls back to the default",
+			locales: []string{"de", "en"},
+			want:    tools.Translation{Description: "Search flights", Parameters: map[string]string{"city": "Abflugort"}},
+		},
+		{
+			desc:    "no translated locale",
+			locales: []string{"it", "en-GB"},
+			want:    tools.Translation{Description: "Search flights", Parameters: map[string]string{"city": "Departure city"}},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := tools.Localize(tool, tc.locales)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("unexpected text (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLocalizeStopsAtDefaultLocale(t *testing.T) {
+	// the description of the tool is in the default locale, which has no
+	// translation, so a request preferring it must not get French
+	tool := newLocalizedTool(tools.Translations{"fr": {Description: "Rechercher des vols"}})
+	got := tools.Localize(tool, []string{"en-US", "fr", "en"})
+	if got.Description != "Search flights" {
+		t.Errorf("unexpected description: %q", got.Description)
+	}
+}
+
+func TestLocalizeWithoutTranslations(t *testing.T) {
+	got := tools.Localize(newLocalizedTool(nil), []string{"fr", "en"})
+	if diff := cmp.Diff(tools.Translation{Description: "Search flights"}, got); diff != "" {
+		t.Errorf("unexpected text (-want +got):\n%s", diff)
+	}
+}
+
+func TestLocalizeManifest(t *testing.T) {
+	m := tools.Manifest{
+		Description: "Search flights",
+		Parameters: []parameters.ParameterManifest{
+			{Name: "city", Type: "string", Description: "Departure city"},
+			{Name: "date", Type: "string", Description: "Departure date"},
+		},
+	}
+	got := tools.LocalizeManifest(m, tools.Translation{
+		Description: "Rechercher des vols",
+		Parameters:  map[string]string{"city": "Ville de départ"},
+	})
+	want := tools.Manifest{
+		Description: "Rechercher des vols",
+		Parameters: []parameters.ParameterManifest{
+			{Name: "city", Type: "string", Description: "Ville de départ"},
+			{Name: "date", Type: "string", Description: "Departure date"},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected manifest (-want +got):\n%s", diff)
+	}
+	if m.Parameters[0].Description != "Departure city" {
+		t.Errorf("expected the manifest to be left unchanged")
+	}
+}
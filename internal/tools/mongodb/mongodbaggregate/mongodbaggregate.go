@@ -18,11 +18,14 @@ import (
 	"fmt"
 	"net/http"
 	"slices"
+	"time"
 
 	"github.com/goccy/go-yaml"
 	"github.com/googleapis/mcp-toolbox/internal/util"
 	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
 
 	"github.com/googleapis/mcp-toolbox/internal/tools"
 )
@@ -30,7 +33,7 @@ import (
 const resourceType string = "mongodb-aggregate"
 
 func init() {
-	if !tools.Register(resourceType, newConfig) {
+	if !tools.Register[compatibleSource](resourceType, newConfig) {
 		panic(fmt.Sprintf("tool type %q already registered", resourceType))
 	}
 }
@@ -40,12 +43,16 @@ func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.T
 	if err := decoder.DecodeContext(ctx, &actual); err != nil {
 		return nil, err
 	}
+	if err := actual.validate(); err != nil {
+		return nil, err
+	}
 	return actual, nil
 }
 
 type compatibleSource interface {
 	MongoClient() *mongo.Client
-	Aggregate(context.Context, string, bool, bool, string, string) ([]any, error)
+	Aggregate(context.Context, string, bool, bool, string, string, *options.AggregateOptionsBuilder) ([]any, error)
+	AggregatePipeline(context.Context, bson.A, string, string, *options.AggregateOptionsBuilder) ([]any, error)
 }
 
 type Config struct {
@@ -54,13 +61,31 @@ type Config struct {
 	Source           string                 `yaml:"source" validate:"required"`
 	Database         string                 `yaml:"database" validate:"required"`
 	Collection       string                 `yaml:"collection" validate:"required"`
-	PipelinePayload  string                 `yaml:"pipelinePayload" validate:"required"`
-	PipelineParams   parameters.Parameters  `yaml:"pipelineParams" validate:"required"`
+	PipelinePayload  string                 `yaml:"pipelinePayload"`
+	Pipeline         Pipeline               `yaml:"pipeline"`
+	PipelineParams   parameters.Parameters  `yaml:"pipelineParams"`
 	Canonical        bool                   `yaml:"canonical"`
 	ReadOnly         bool                   `yaml:"readOnly"`
+	AllowDiskUse     bool                   `yaml:"allowDiskUse"`
+	MaxTimeMS        int64                  `yaml:"maxTimeMS"`
 	Annotations      *tools.ToolAnnotations `yaml:"annotations,omitempty"`
 }
 
+// validate checks that exactly one of pipelinePayload and pipeline is set,
+// and that the placeholders of pipeline match the declared parameters.
+func (cfg Config) validate() error {
+	if (cfg.PipelinePayload == "") == (cfg.Pipeline == nil) {
+		return fmt.Errorf("exactly one of pipelinePayload and pipeline must be set")
+	}
+	if cfg.MaxTimeMS < 0 {
+		return fmt.Errorf("maxTimeMS must not be negative")
+	}
+	if cfg.Pipeline != nil {
+		return cfg.Pipeline.validate(cfg.PipelineParams, cfg.ReadOnly)
+	}
+	return nil
+}
+
 // validate interface
 var _ tools.ToolConfig = Config{}
 
@@ -107,12 +132,37 @@ func (t Tool) Invoke(ctx context.Context, primitiveMgr tools.SourceProvider, par
 		return nil, util.NewClientServerError("source used is not compatible with the tool", http.StatusInternalServerError, err)
 	}
 
+	opts := options.Aggregate()
+	if t.Cfg.AllowDiskUse {
+		opts.SetAllowDiskUse(true)
+	}
+	if t.Cfg.MaxTimeMS > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(t.Cfg.MaxTimeMS)*time.Millisecond)
+		defer cancel()
+	}
+
 	paramsMap := params.AsMap()
+	var resp []any
+	if t.Cfg.Pipeline != nil {
+		pipeline, err := t.Cfg.Pipeline.bind(func(name string) (any, error) {
+			return paramsMap[name], nil
+		})
+		if err != nil {
+			return nil, util.NewAgentError("error binding pipeline", err)
+		}
+		resp, err = source.AggregatePipeline(ctx, pipeline, t.Cfg.Database, t.Cfg.Collection, opts)
+		if err != nil {
+			return nil, util.ProcessGeneralError(err)
+		}
+		return resp, nil
+	}
+
 	pipelineString, err := parameters.PopulateTemplateWithJSON("MongoDBAggregatePipeline", t.Cfg.PipelinePayload, paramsMap)
 	if err != nil {
 		return nil, util.NewAgentError("error populating pipeline", err)
 	}
-	resp, err := source.Aggregate(ctx, pipelineString, t.Cfg.Canonical, t.Cfg.ReadOnly, t.Cfg.Database, t.Cfg.Collection)
+	resp, err = source.Aggregate(ctx, pipelineString, t.Cfg.Canonical, t.Cfg.ReadOnly, t.Cfg.Database, t.Cfg.Collection, opts)
 	if err != nil {
 		return nil, util.ProcessGeneralError(err)
 	}
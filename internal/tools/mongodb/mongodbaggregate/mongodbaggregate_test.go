@@ -22,6 +22,7 @@ import (
 	"github.com/googleapis/mcp-toolbox/internal/tools/mongodb/mongodbaggregate"
 	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
 
+	"github.com/goccy/go-yaml"
 	"github.com/google/go-cmp/cmp"
 	"github.com/googleapis/mcp-toolbox/internal/server"
 	"github.com/googleapis/mcp-toolbox/internal/testutils"
@@ -80,6 +81,66 @@ func TestParseFromYamlMongoQuery(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "structured pipeline",
+			in: `
+            kind: tool
+            name: example_tool
+            type: mongodb-aggregate
+            source: my-instance
+            description: some description
+            database: test_db
+            collection: test_coll
+            readOnly: true
+            allowDiskUse: true
+            maxTimeMS: 5000
+            pipeline:
+                - $match: { region: "{{.region}}", total: { $gte: "{{ .minTotal }}" } }
+                - $group: { _id: "$customer", count: { $sum: 1 } }
+                - $sort: { count: -1, _id: 1 }
+            pipelineParams:
+                - name: region
+                  type: string
+                  description: region to match
+                - name: minTotal
+                  type: float
+                  description: minimum order total
+			`,
+			want: server.ToolConfigs{
+				"example_tool": mongodbaggregate.Config{
+					ConfigBase: tools.ConfigBase{
+						Name:         "example_tool",
+						AuthRequired: []string{},
+						Description:  "some description",
+					},
+					Type:       "mongodb-aggregate",
+					Source:     "my-instance",
+					Database:   "test_db",
+					Collection: "test_coll",
+					Pipeline: mongodbaggregate.Pipeline{
+						yaml.MapSlice{{Key: "$match", Value: yaml.MapSlice{
+							{Key: "region", Value: "{{.region}}"},
+							{Key: "total", Value: yaml.MapSlice{{Key: "$gte", Value: "{{ .minTotal }}"}}},
+						}}},
+						yaml.MapSlice{{Key: "$group", Value: yaml.MapSlice{
+							{Key: "_id", Value: "$customer"},
+							{Key: "count", Value: yaml.MapSlice{{Key: "$sum", Value: uint64(1)}}},
+						}}},
+						yaml.MapSlice{{Key: "$sort", Value: yaml.MapSlice{
+							{Key: "count", Value: int64(-1)},
+							{Key: "_id", Value: uint64(1)},
+						}}},
+					},
+					PipelineParams: parameters.Parameters{
+						parameters.NewStringParameter("region", "region to match"),
+						parameters.NewFloatParameter("minTotal", "minimum order total"),
+					},
+					ReadOnly:     true,
+					AllowDiskUse: true,
+					MaxTimeMS:    5000,
+				},
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -143,6 +204,91 @@ func TestFailParseFromYamlMongoQuery(t *testing.T) {
 			`,
 			err: `unable to parse tool "example_tool" as type "mongodb-aggregate"`,
 		},
+		{
+			desc: "undeclared placeholder",
+			in: `
+            kind: tool
+            name: example_tool
+            type: mongodb-aggregate
+            source: my-instance
+            description: some description
+            database: test_db
+            collection: test_coll
+            pipeline:
+                - $match: { region: "{{.region}}" }
+			`,
+			err: `pipeline placeholder {{.region}} does not match any declared parameter`,
+		},
+		{
+			desc: "unused parameter",
+			in: `
+            kind: tool
+            name: example_tool
+            type: mongodb-aggregate
+            source: my-instance
+            description: some description
+            database: test_db
+            collection: test_coll
+            pipeline:
+                - $match: { region: "east" }
+            pipelineParams:
+                - name: region
+                  type: string
+                  description: region to match
+			`,
+			err: `parameter "region" is not used in the pipeline`,
+		},
+		{
+			desc: "placeholder inside a string",
+			in: `
+            kind: tool
+            name: example_tool
+            type: mongodb-aggregate
+            source: my-instance
+            description: some description
+            database: test_db
+            collection: test_coll
+            pipeline:
+                - $match: { region: "region-{{.region}}" }
+            pipelineParams:
+                - name: region
+                  type: string
+                  description: region to match
+			`,
+			err: `placeholder must be the entire value`,
+		},
+		{
+			desc: "both pipeline and pipelinePayload",
+			in: `
+            kind: tool
+            name: example_tool
+            type: mongodb-aggregate
+            source: my-instance
+            description: some description
+            database: test_db
+            collection: test_coll
+            pipelinePayload: "[]"
+            pipeline:
+                - $match: {}
+			`,
+			err: `exactly one of pipelinePayload and pipeline must be set`,
+		},
+		{
+			desc: "write stage in read-only pipeline",
+			in: `
+            kind: tool
+            name: example_tool
+            type: mongodb-aggregate
+            source: my-instance
+            description: some description
+            database: test_db
+            collection: test_coll
+            readOnly: true
+            pipeline:
+                - $out: target_coll
+			`,
+			err: `$out is not allowed in a read-only pipeline`,
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -0,0 +1,145 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mongodbaggregate
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// placeholderRe matches a value that consists of a single `{{.param}}`
+// placeholder.
+var placeholderRe = regexp.MustCompile(`^\{\{\s*\.([A-Za-z_][A-Za-z0-9_]*)\s*\}\}$`)
+
+// Pipeline is an aggregation pipeline written as a YAML (or JSON) list of
+// stages. The order of keys within the stages is preserved. A string value
+// consisting of a single `{{.param}}` placeholder is replaced by the
+// parameter's value as a typed BSON value.
+type Pipeline []any
+
+// UnmarshalYAML decodes the stages into ordered maps, as the order of keys
+// is significant for stages such as $sort.
+func (p *Pipeline) UnmarshalYAML(b []byte) error {
+	var stages []any
+	if err := yaml.UnmarshalWithOptions(b, &stages, yaml.UseOrderedMap()); err != nil {
+		return fmt.Errorf("pipeline must be a list of stages: %w", err)
+	}
+	*p = stages
+	return nil
+}
+
+// validate checks that every stage is a document, that the placeholders
+// match the declared parameters and, if readOnly is set, that the pipeline
+// does not write to a collection.
+func (p Pipeline) validate(params parameters.Parameters, readOnly bool) error {
+	for i, stage := range p {
+		doc, ok := stage.(yaml.MapSlice)
+		if !ok {
+			return fmt.Errorf("pipeline stage %d must be a document", i)
+		}
+		for _, item := range doc {
+			if op := fmt.Sprint(item.Key); readOnly && (op == "$merge" || op == "$out") {
+				return fmt.Errorf("pipeline stage %d: %s is not allowed in a read-only pipeline", i, op)
+			}
+		}
+	}
+
+	used := map[string]bool{}
+	if _, err := p.bind(func(name string) (any, error) {
+		used[name] = true
+		return nil, nil
+	}); err != nil {
+		return err
+	}
+	declared := map[string]bool{}
+	for _, param := range params {
+		declared[param.GetName()] = true
+	}
+	for _, name := range slices.Sorted(maps.Keys(used)) {
+		if !declared[name] {
+			return fmt.Errorf("pipeline placeholder {{.%s}} does not match any declared parameter", name)
+		}
+	}
+	for _, param := range params {
+		if !used[param.GetName()] {
+			return fmt.Errorf("parameter %q is not used in the pipeline", param.GetName())
+		}
+	}
+	return nil
+}
+
+// bind converts the pipeline to BSON, replacing each placeholder by the value
+// returned by lookup.
+func (p Pipeline) bind(lookup func(name string) (any, error)) (bson.A, error) {
+	v, err := toBSON([]any(p), lookup)
+	if err != nil {
+		return nil, err
+	}
+	return v.(bson.A), nil
+}
+
+func toBSON(v any, lookup func(name string) (any, error)) (any, error) {
+	switch v := v.(type) {
+	case yaml.MapSlice:
+		doc := make(bson.D, 0, len(v))
+		for _, item := range v {
+			key := fmt.Sprint(item.Key)
+			if strings.Contains(key, "{{") {
+				return nil, fmt.Errorf("placeholders are not allowed in keys: %q", key)
+			}
+			value, err := toBSON(item.Value, lookup)
+			if err != nil {
+				return nil, err
+			}
+			doc = append(doc, bson.E{Key: key, Value: value})
+		}
+		return doc, nil
+	case []any:
+		arr := make(bson.A, 0, len(v))
+		for _, elem := range v {
+			value, err := toBSON(elem, lookup)
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, value)
+		}
+		return arr, nil
+	case string:
+		if m := placeholderRe.FindStringSubmatch(v); m != nil {
+			return lookup(m[1])
+		}
+		if strings.Contains(v, "{{") {
+			return nil, fmt.Errorf("placeholder must be the entire value, got %q", v)
+		}
+		return v, nil
+	case uint64:
+		// YAML decodes non-negative integers as uint64, which BSON does not
+		// support.
+		if v > math.MaxInt64 {
+			return nil, fmt.Errorf("integer %d is out of range", v)
+		}
+		return int64(v), nil
+	default:
+		return v, nil
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mongodbcommon
+
+import (
+	"fmt"
+
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+)
+
+// DefaultIDField is the field MongoDB tools return the document ID under.
+const DefaultIDField = "_id"
+
+// InjectDocumentIDs normalizes the `_id` of each document returned as relaxed
+// Extended JSON and moves it under the configured ID field. ObjectIDs are
+// returned as their hex string; other IDs are returned as-is. Documents
+// whose `_id` was projected out are left untouched. If includeId is false,
+// `_id` is removed from the documents.
+func InjectDocumentIDs(docs []any, cfg tools.DocumentIDConfig) error {
+	field := cfg.IDFieldOrDefault(DefaultIDField)
+	for i, d := range docs {
+		doc, ok := d.(map[string]any)
+		if !ok {
+			continue
+		}
+		id, ok := doc["_id"]
+		if !ok {
+			continue
+		}
+		delete(doc, "_id")
+		if !cfg.IncludeIDEnabled() {
+			continue
+		}
+		if err := tools.InjectDocumentID(doc, field, normalizeID(id)); err != nil {
+			return fmt.Errorf("document %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// normalizeID unwraps the Extended JSON representation of an ObjectID.
+func normalizeID(id any) any {
+	if m, ok := id.(map[string]any); ok && len(m) == 1 {
+		if oid, ok := m["$oid"].(string); ok {
+			return oid
+		}
+	}
+	return id
+}
@@ -0,0 +1,85 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mongodbcommon
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+)
+
+func TestInjectDocumentIDs(t *testing.T) {
+	disabled := false
+	newDocs := func() []any {
+		return []any{
+			map[string]any{"_id": map[string]any{"$oid": "65a1b2c3d4e5f60718293a4b"}, "name": "alice"},
+			map[string]any{"_id": int64(7), "name": "bob"},
+			map[string]any{"name": "projected"},
+		}
+	}
+	tcs := []struct {
+		desc string
+		cfg  tools.DocumentIDConfig
+		want []any
+	}{
+		{
+			desc: "default field",
+			want: []any{
+				map[string]any{"_id": "65a1b2c3d4e5f60718293a4b", "name": "alice"},
+				map[string]any{"_id": int64(7), "name": "bob"},
+				map[string]any{"name": "projected"},
+			},
+		},
+		{
+			desc: "custom field",
+			cfg:  tools.DocumentIDConfig{IDField: "id"},
+			want: []any{
+				map[string]any{"id": "65a1b2c3d4e5f60718293a4b", "name": "alice"},
+				map[string]any{"id": int64(7), "name": "bob"},
+				map[string]any{"name": "projected"},
+			},
+		},
+		{
+			desc: "disabled",
+			cfg:  tools.DocumentIDConfig{IncludeID: &disabled},
+			want: []any{
+				map[string]any{"name": "alice"},
+				map[string]any{"name": "bob"},
+				map[string]any{"name": "projected"},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			docs := newDocs()
+			if err := InjectDocumentIDs(docs, tc.cfg); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, docs); diff != "" {
+				t.Errorf("unexpected documents (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestInjectDocumentIDsCollision(t *testing.T) {
+	docs := []any{map[string]any{"_id": "a", "name": "alice"}}
+	err := InjectDocumentIDs(docs, tools.DocumentIDConfig{IDField: "name"})
+	if err == nil || !strings.Contains(err.Error(), `document 0: document already has a field "name"`) {
+		t.Fatalf("unexpected error: got %v", err)
+	}
+}
@@ -27,12 +27,13 @@ import (
 	"go.mongodb.org/mongo-driver/v2/mongo/options"
 
 	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/tools/mongodb/mongodbcommon"
 )
 
 const resourceType string = "mongodb-find"
 
 func init() {
-	if !tools.Register(resourceType, newConfig) {
+	if !tools.Register[compatibleSource](resourceType, newConfig) {
 		panic(fmt.Sprintf("tool type %q already registered", resourceType))
 	}
 }
@@ -64,6 +65,8 @@ type Config struct {
 	SortParams       parameters.Parameters  `yaml:"sortParams"`
 	Limit            int64                  `yaml:"limit"`
 	Annotations      *tools.ToolAnnotations `yaml:"annotations,omitempty"`
+
+	tools.DocumentIDConfig `yaml:",inline"`
 }
 
 // validate interface
@@ -172,5 +175,8 @@ func (t Tool) Invoke(ctx context.Context, primitiveMgr tools.SourceProvider, par
 	if err != nil {
 		return nil, util.ProcessGeneralError(err)
 	}
+	if err := mongodbcommon.InjectDocumentIDs(resp, t.Cfg.DocumentIDConfig); err != nil {
+		return nil, util.NewAgentError("error injecting document IDs", err)
+	}
 	return resp, nil
 }
@@ -32,6 +32,7 @@ func TestParseFromYamlMongoQuery(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unexpected error: %s", err)
 	}
+	includeID := true
 	tcs := []struct {
 		desc string
 		in   string
@@ -83,6 +84,40 @@ func TestParseFromYamlMongoQuery(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "document ID field",
+			in: `
+            kind: tool
+            name: example_tool
+            type: mongodb-find-one
+            source: my-instance
+            description: some description
+            database: test_db
+            collection: test_coll
+            filterPayload: |
+                { name: "alice" }
+            includeId: true
+            idField: id
+			`,
+			want: server.ToolConfigs{
+				"example_tool": mongodbfindone.Config{
+					ConfigBase: tools.ConfigBase{
+						Name:         "example_tool",
+						AuthRequired: []string{},
+						Description:  "some description",
+					},
+					Type:          "mongodb-find-one",
+					Source:        "my-instance",
+					Database:      "test_db",
+					Collection:    "test_coll",
+					FilterPayload: "{ name: \"alice\" }\n",
+					DocumentIDConfig: tools.DocumentIDConfig{
+						IncludeID: &includeID,
+						IDField:   "id",
+					},
+				},
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -30,7 +30,7 @@ const resourceType string = "mongodb-insert-many"
 const paramDataKey = "data"
 
 func init() {
-	if !tools.Register(resourceType, newConfig) {
+	if !tools.Register[compatibleSource](resourceType, newConfig) {
 		panic(fmt.Sprintf("tool type %q already registered", resourceType))
 	}
 }
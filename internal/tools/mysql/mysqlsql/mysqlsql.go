@@ -29,7 +29,7 @@ import (
 const resourceType string = "mysql-sql"
 
 func init() {
-	if !tools.Register(resourceType, newConfig) {
+	if !tools.Register[compatibleSource](resourceType, newConfig) {
 		panic(fmt.Sprintf("tool type %q already registered", resourceType))
 	}
 }
@@ -49,11 +49,14 @@ type compatibleSource interface {
 
 type Config struct {
 	tools.ConfigBase   `yaml:",inline"`
+	tools.ColumnConfig `yaml:",inline"`
 	Type               string                 `yaml:"type" validate:"required"`
 	Source             string                 `yaml:"source" validate:"required"`
 	Statement          string                 `yaml:"statement" validate:"required"`
+	Variants           tools.Variants         `yaml:"variants,omitempty"`
 	Parameters         parameters.Parameters  `yaml:"parameters"`
 	TemplateParameters parameters.Parameters  `yaml:"templateParameters"`
+	ReadOnly           bool                   `yaml:"readOnly"`
 	Annotations        *tools.ToolAnnotations `yaml:"annotations,omitempty"`
 }
 
@@ -74,13 +77,27 @@ func (cfg Config) Initialize(context.Context) (tools.Tool, error) {
 		return nil, err
 	}
 
+	if err := cfg.Variants.Validate(cfg.Name); err != nil {
+		return nil, err
+	}
+
+	columns, err := cfg.NewColumnShaper(cfg.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	defaultAnnotations := tools.NewDestructiveAnnotations
+	if cfg.ReadOnly {
+		defaultAnnotations = tools.NewReadOnlyAnnotations
+	}
 	return Tool{
 		BaseTool: tools.NewBaseTool(
 			cfg,
-			tools.GetAnnotationsOrDefault(cfg.Annotations, tools.NewDestructiveAnnotations),
+			tools.GetAnnotationsOrDefault(cfg.Annotations, defaultAnnotations),
 			tools.Manifest{Description: cfg.Description, Parameters: paramManifest, AuthRequired: cfg.AuthRequired},
 			allParameters,
 		),
+		columns: columns,
 	}, nil
 }
 
@@ -89,31 +106,70 @@ var _ tools.Tool = Tool{}
 
 type Tool struct {
 	tools.BaseTool[Config]
+	columns *tools.ColumnShaper
 }
 
 func (t Tool) Invoke(ctx context.Context, primitiveMgr tools.SourceProvider, params parameters.ParamValues, accessToken tools.AccessToken) (any, util.ToolboxError) {
+	source, statement, sliceParams, tbErr := t.resolve(ctx, primitiveMgr, params)
+	if tbErr != nil {
+		return nil, tbErr
+	}
+	resp, err := source.RunSQL(ctx, statement, sliceParams)
+	if err != nil {
+		return nil, util.ProcessGeneralError(err)
+	}
+	return t.columns.Apply(ctx, resp), nil
+}
+
+// DryRun implements tools.DryRunner, explaining the statement of the
+// invocation without running it.
+func (t Tool) DryRun(ctx context.Context, primitiveMgr tools.SourceProvider, params parameters.ParamValues, accessToken tools.AccessToken) (*tools.DryRunResult, util.ToolboxError) {
+	source, statement, sliceParams, tbErr := t.resolve(ctx, primitiveMgr, params)
+	if tbErr != nil {
+		return nil, tbErr
+	}
+	resp, err := source.RunSQL(ctx, "EXPLAIN FORMAT=JSON "+statement, sliceParams)
+	if err != nil {
+		return nil, util.ProcessGeneralError(err)
+	}
+	return &tools.DryRunResult{Statement: statement, Params: sliceParams, Plan: tools.PlanFromRows(resp)}, nil
+}
+
+var _ tools.DryRunner = Tool{}
+
+// resolve returns the source, statement and parameters of an invocation.
+func (t Tool) resolve(ctx context.Context, primitiveMgr tools.SourceProvider, params parameters.ParamValues) (compatibleSource, string, []any, util.ToolboxError) {
 	source, err := tools.GetCompatibleSource[compatibleSource](primitiveMgr, t.Cfg.Source, t.Cfg.Name, t.Cfg.Type)
 	if err != nil {
-		return nil, util.NewClientServerError("source used is not compatible with the tool", http.StatusInternalServerError, err)
+		return nil, "", nil, util.NewClientServerError("source used is not compatible with the tool", http.StatusInternalServerError, err)
+	}
+
+	statement, err := t.Cfg.Variants.Select(ctx, t.Cfg.Statement)
+	if err != nil {
+		return nil, "", nil, util.NewAgentError("unable to select a variant", err)
 	}
 
 	paramsMap := params.AsMap()
-	newStatement, err := parameters.ResolveTemplateParams(t.Cfg.TemplateParameters, t.Cfg.Statement, paramsMap)
+	newStatement, err := parameters.ResolveTemplateParams(t.Cfg.TemplateParameters, statement, paramsMap)
 	if err != nil {
-		return nil, util.NewAgentError("unable to extract template params", err)
+		return nil, "", nil, util.NewAgentError("unable to extract template params", err)
 	}
 
 	newParams, err := parameters.GetParams(t.Cfg.Parameters, paramsMap)
 	if err != nil {
-		return nil, util.NewAgentError("unable to extract standard params", err)
+		return nil, "", nil, util.NewAgentError("unable to extract standard params", err)
 	}
 
 	sliceParams := newParams.AsSlice()
-	resp, err := source.RunSQL(ctx, newStatement, sliceParams)
-	if err != nil {
-		return nil, util.ProcessGeneralError(err)
+	if err := tools.CheckStatement(source, newStatement); err != nil {
+		return nil, "", nil, err
+	}
+	if t.Cfg.ReadOnly {
+		if err := tools.CheckReadOnly(source, t.Cfg.Name, newStatement); err != nil {
+			return nil, "", nil, err
+		}
 	}
-	return resp, nil
+	return source, newStatement, sliceParams, nil
 }
 
 func (t Tool) ToConfig() tools.ToolConfig {
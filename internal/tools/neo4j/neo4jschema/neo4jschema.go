@@ -36,7 +36,7 @@ const resourceType string = "neo4j-schema"
 
 // init registers the tool with the application's tool registry when the package is initialized.
 func init() {
-	if !tools.Register(resourceType, newConfig) {
+	if !tools.Register[compatibleSource](resourceType, newConfig) {
 		panic(fmt.Sprintf("tool type %q already registered", resourceType))
 	}
 }
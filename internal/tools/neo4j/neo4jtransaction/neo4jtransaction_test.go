@@ -0,0 +1,108 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package neo4jtransaction
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/internal/server"
+	"github.com/googleapis/mcp-toolbox/internal/testutils"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+func TestParseFromYamlNeo4jTransaction(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+            kind: tool
+            name: move_employee
+            type: neo4j-transaction
+            source: my-neo4j-instance
+            description: Moves an employee to another team.
+            statements:
+                - "MATCH (e:Employee {id: $id})-[r:MEMBER_OF]->() DELETE r"
+                - "MATCH (e:Employee {id: $id}), (t:Team {name: $team}) MERGE (e)-[:MEMBER_OF]->(t)"
+            parameters:
+                - name: id
+                  type: string
+                  description: The id of the employee.
+                - name: team
+                  type: string
+                  description: The name of the team.
+			`,
+			want: server.ToolConfigs{
+				"move_employee": Config{
+					ConfigBase: tools.ConfigBase{
+						Name:         "move_employee",
+						Description:  "Moves an employee to another team.",
+						AuthRequired: []string{},
+					},
+					Type:   "neo4j-transaction",
+					Source: "my-neo4j-instance",
+					Statements: []string{
+						"MATCH (e:Employee {id: $id})-[r:MEMBER_OF]->() DELETE r",
+						"MATCH (e:Employee {id: $id}), (t:Team {name: $team}) MERGE (e)-[:MEMBER_OF]->(t)",
+					},
+					Parameters: []parameters.Parameter{
+						parameters.NewStringParameter("id", "The id of the employee."),
+						parameters.NewStringParameter("team", "The name of the team."),
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, _, _, got, _, _, err := server.UnmarshalPrimitiveConfig(ctx, testutils.FormatYaml(tc.in))
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestReadOnly(t *testing.T) {
+	cfg := Config{ConfigBase: tools.ConfigBase{Name: "tx", Description: "A transaction."}, Type: resourceType, Source: "my-neo4j-instance", Statements: []string{"RETURN 1"}}
+	tool, err := cfg.Initialize(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if tool.(Tool).readOnly() {
+		t.Errorf("expected the tool to write by default")
+	}
+	cfg.Annotations = tools.NewReadOnlyAnnotations()
+	tool, err = cfg.Initialize(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !tool.(Tool).readOnly() {
+		t.Errorf("expected the tool annotated as read-only to be read-only")
+	}
+}
@@ -0,0 +1,429 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/googleapis/mcp-toolbox/internal/sources"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+// OutputSchema declares the shape of the results of a tool, either as the
+// columns of its rows or as a JSON schema. Results are validated against it,
+// and it is exposed in the manifests of the tool so that clients can
+// deserialize results into typed structures.
+type OutputSchema struct {
+	// Columns are the columns of each row of the results, which are a list
+	// of rows with no other columns.
+	Columns []OutputColumn `yaml:"columns,omitempty" validate:"dive"`
+	// JSONSchema is the JSON schema of the results. Results are validated
+	// against its type, properties, required, additionalProperties, items
+	// and enum keywords; the other keywords are only exposed to clients.
+	JSONSchema map[string]any `yaml:"jsonSchema,omitempty"`
+}
+
+// OutputColumn is a column of the rows of the results of a tool.
+type OutputColumn struct {
+	Name string `yaml:"name" validate:"required"`
+	// Type is the JSON type of the values of the column.
+	Type        string `yaml:"type" validate:"required,oneof=string integer number boolean object array"`
+	Description string `yaml:"description,omitempty"`
+	// Nullable allows the values of the column to be null.
+	Nullable bool `yaml:"nullable,omitempty"`
+}
+
+// outputSchemaOf returns the output schema of a tool config, or nil if it
+// has none.
+func outputSchemaOf(cfg ToolConfig) *OutputSchema {
+	if c, ok := cfg.(interface{ GetOutputSchema() *OutputSchema }); ok {
+		return c.GetOutputSchema()
+	}
+	return nil
+}
+
+// OutputSchemaOf returns the JSON schema of the results of tool, or nil if
+// it declares no output schema.
+func OutputSchemaOf(tool Tool) map[string]any {
+	s := outputSchemaOf(tool.ToConfig())
+	if s == nil {
+		return nil
+	}
+	return s.Schema()
+}
+
+// MCPOutputSchemaOf returns the output schema of tool as exposed by MCP,
+// which requires an object: the results are the result property of the
+// structured content of the tool calls. It returns nil if tool declares no
+// output schema.
+func MCPOutputSchemaOf(tool Tool) map[string]any {
+	schema := OutputSchemaOf(tool)
+	if schema == nil {
+		return nil
+	}
+	return map[string]any{
+		"type":       "object",
+		"properties": map[string]any{"result": schema},
+		"required":   []string{"result"},
+	}
+}
+
+// Schema returns the JSON schema of the results.
+func (s OutputSchema) Schema() map[string]any {
+	if s.JSONSchema != nil {
+		return maps.Clone(s.JSONSchema)
+	}
+	properties := make(map[string]any, len(s.Columns))
+	required := make([]string, 0, len(s.Columns))
+	for _, c := range s.Columns {
+		property := map[string]any{"type": c.Type}
+		if c.Nullable {
+			property["type"] = []string{c.Type, "null"}
+		}
+		if c.Description != "" {
+			property["description"] = c.Description
+		}
+		properties[c.Name] = property
+		required = append(required, c.Name)
+	}
+	return map[string]any{
+		"type": "array",
+		"items": map[string]any{
+			"type":                 "object",
+			"properties":           properties,
+			"required":             required,
+			"additionalProperties": false,
+		},
+	}
+}
+
+// schemaNode is a compiled JSON schema, holding the keywords results are
+// validated against.
+type schemaNode struct {
+	// types are the allowed JSON types, or any type if empty.
+	types      []string
+	properties map[string]*schemaNode
+	required   []string
+	closed     bool
+	items      *schemaNode
+	// enum holds the JSON encodings of the allowed values.
+	enum []string
+}
+
+var jsonTypes = []string{"string", "integer", "number", "boolean", "object", "array", "null"}
+
+// compile validates the output schema and compiles it.
+func (s OutputSchema) compile(toolName string) (*schemaNode, error) {
+	if (len(s.Columns) > 0) == (s.JSONSchema != nil) {
+		return nil, fmt.Errorf("output schema of tool %q must set exactly one of columns and jsonSchema", toolName)
+	}
+	seen := make(map[string]bool, len(s.Columns))
+	for _, c := range s.Columns {
+		if seen[c.Name] {
+			return nil, fmt.Errorf("output schema of tool %q has several columns named %q", toolName, c.Name)
+		}
+		seen[c.Name] = true
+	}
+	// the schema is normalized through JSON, as YAML decodes numbers and
+	// lists to several types
+	var schema any
+	if err := roundTripJSON(s.Schema(), &schema); err != nil {
+		return nil, fmt.Errorf("invalid output schema of tool %q: %w", toolName, err)
+	}
+	node, err := compileSchema(schema, "")
+	if err != nil {
+		return nil, fmt.Errorf("invalid output schema of tool %q: %w", toolName, err)
+	}
+	return node, nil
+}
+
+func compileSchema(v any, path string) (*schemaNode, error) {
+	m, ok := v.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("schema%s must be an object", path)
+	}
+	n := &schemaNode{}
+	switch t := m["type"].(type) {
+	case nil:
+	case string:
+		n.types = []string{t}
+	case []any:
+		for _, e := range t {
+			s, ok := e.(string)
+			if !ok {
+				return nil, fmt.Errorf("type of schema%s must be a string or a list of strings", path)
+			}
+			n.types = append(n.types, s)
+		}
+	default:
+		return nil, fmt.Errorf("type of schema%s must be a string or a list of strings", path)
+	}
+	for _, t := range n.types {
+		if !slices.Contains(jsonTypes, t) {
+			return nil, fmt.Errorf("schema%s has an unknown type %q", path, t)
+		}
+	}
+	if props, ok := m["properties"]; ok {
+		pm, ok := props.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("properties of schema%s must be an object", path)
+		}
+		n.properties = make(map[string]*schemaNode, len(pm))
+		for name, p := range pm {
+			child, err := compileSchema(p, path+"."+name)
+			if err != nil {
+				return nil, err
+			}
+			n.properties[name] = child
+		}
+	}
+	if req, ok := m["required"]; ok {
+		list, ok := req.([]any)
+		if !ok {
+			return nil, fmt.Errorf("required of schema%s must be a list of strings", path)
+		}
+		for _, e := range list {
+			s, ok := e.(string)
+			if !ok {
+				return nil, fmt.Errorf("required of schema%s must be a list of strings", path)
+			}
+			n.required = append(n.required, s)
+		}
+	}
+	if additional, ok := m["additionalProperties"].(bool); ok {
+		n.closed = !additional
+	}
+	if items, ok := m["items"]; ok {
+		child, err := compileSchema(items, path+"[]")
+		if err != nil {
+			return nil, err
+		}
+		n.items = child
+	}
+	if enum, ok := m["enum"]; ok {
+		list, ok := enum.([]any)
+		if !ok {
+			return nil, fmt.Errorf("enum of schema%s must be a list", path)
+		}
+		for _, e := range list {
+			b, _ := json.Marshal(e)
+			n.enum = append(n.enum, string(b))
+		}
+	}
+	return n, nil
+}
+
+// validate reports the first mismatch of v, a value decoded from JSON with
+// numbers as json.Number, against the schema.
+func (n *schemaNode) validate(v any, path string) error {
+	if len(n.types) > 0 && !slices.ContainsFunc(n.types, func(t string) bool { return hasJSONType(v, t) }) {
+		return fmt.Errorf("value%s is %s, not %s", path, jsonTypeOf(v), strings.Join(n.types, " or "))
+	}
+	if len(n.enum) > 0 {
+		b, _ := json.Marshal(v)
+		if !slices.Contains(n.enum, string(b)) {
+			return fmt.Errorf("value%s %s is not one of %s", path, b, strings.Join(n.enum, ", "))
+		}
+	}
+	switch val := v.(type) {
+	case map[string]any:
+		for _, name := range n.required {
+			if _, ok := val[name]; !ok {
+				return fmt.Errorf("value%s is missing the required property %q", path, name)
+			}
+		}
+		for _, name := range slices.Sorted(maps.Keys(val)) {
+			child, ok := n.properties[name]
+			if !ok {
+				if n.closed {
+					return fmt.Errorf("value%s has the undeclared property %q", path, name)
+				}
+				continue
+			}
+			if err := child.validate(val[name], path+"."+name); err != nil {
+				return err
+			}
+		}
+	case []any:
+		if n.items != nil {
+			for i, e := range val {
+				if err := n.items.validate(e, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func hasJSONType(v any, t string) bool {
+	switch t {
+	case "integer":
+		n, ok := v.(json.Number)
+		return ok && !strings.ContainsAny(n.String(), ".eE")
+	case "number":
+		_, ok := v.(json.Number)
+		return ok
+	}
+	return jsonTypeOf(v) == t
+}
+
+func jsonTypeOf(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case json.Number:
+		return "number"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+// roundTripJSON decodes the JSON encoding of v into out, with numbers as
+// json.Number.
+func roundTripJSON(v any, out *any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	return d.Decode(out)
+}
+
+// WrapOutputSchemas returns the tools with every tool that has an output
+// schema validating its results against it.
+func WrapOutputSchemas(toolsMap map[string]Tool) (map[string]Tool, error) {
+	wrapped := make(map[string]Tool, len(toolsMap))
+	for name, t := range toolsMap {
+		cfg := outputSchemaOf(t.ToConfig())
+		if cfg == nil {
+			wrapped[name] = t
+			continue
+		}
+		schema, err := cfg.compile(name)
+		if err != nil {
+			return nil, err
+		}
+		validated := validatedTool{Tool: t, schema: schema, manifestSchema: cfg.Schema(), rows: len(cfg.Columns) > 0}
+		if streamer, ok := t.(RowStreamer); ok {
+			wrapped[name] = validatedStreamer{validatedTool: validated, streamer: streamer}
+			continue
+		}
+		wrapped[name] = validated
+	}
+	return wrapped, nil
+}
+
+// validatedTool is a tool whose results are validated against its output
+// schema.
+type validatedTool struct {
+	Tool
+	schema         *schemaNode
+	manifestSchema map[string]any
+	// rows reports that the schema declares columns, so that an empty
+	// result is an empty list of rows.
+	rows bool
+}
+
+// Unwrap implements Wrapper.
+func (t validatedTool) Unwrap() Tool { return t.Tool }
+
+func (t validatedTool) Invoke(ctx context.Context, sp SourceProvider, params parameters.ParamValues, token AccessToken) (any, util.ToolboxError) {
+	result, err := t.Tool.Invoke(ctx, sp, params, token)
+	if err != nil {
+		return nil, err
+	}
+	if result == nil && t.rows {
+		result = []any{}
+	}
+	if err := t.validate(result, t.schema); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// DryRun implements DryRunner. Dry runs return no results to validate.
+func (t validatedTool) DryRun(ctx context.Context, sp SourceProvider, params parameters.ParamValues, token AccessToken) (*DryRunResult, util.ToolboxError) {
+	return DryRun(ctx, t.Tool, sp, params, token)
+}
+
+func (t validatedTool) Manifest(srcs map[string]sources.Source) (Manifest, error) {
+	m, err := t.Tool.Manifest(srcs)
+	if err != nil {
+		return m, err
+	}
+	m.OutputSchema = t.manifestSchema
+	return m, nil
+}
+
+func (t validatedTool) StaticManifest() Manifest {
+	m := t.Tool.StaticManifest()
+	m.OutputSchema = t.manifestSchema
+	return m
+}
+
+// validate returns an error if result does not match schema.
+func (t validatedTool) validate(result any, schema *schemaNode) util.ToolboxError {
+	var v any
+	err := roundTripJSON(result, &v)
+	if err == nil {
+		err = schema.validate(v, "")
+	}
+	if err != nil {
+		return util.NewClientServerError(fmt.Sprintf("result of tool %q does not match its output schema: %s", t.GetName(), err), http.StatusInternalServerError, err)
+	}
+	return nil
+}
+
+// validatedStreamer is a tool with an output schema that streams its rows.
+// Each row is validated against the items of the schema.
+type validatedStreamer struct {
+	validatedTool
+	streamer RowStreamer
+}
+
+func (t validatedStreamer) StreamRows(ctx context.Context, sp SourceProvider, params parameters.ParamValues, token AccessToken, emit func(rows []any) error) util.ToolboxError {
+	items := t.schema.items
+	if items == nil {
+		items = &schemaNode{}
+	}
+	var invalid util.ToolboxError
+	err := t.streamer.StreamRows(ctx, sp, params, token, func(rows []any) error {
+		if invalid = t.validate(rows, &schemaNode{items: items}); invalid != nil {
+			return invalid
+		}
+		return emit(rows)
+	})
+	if invalid != nil {
+		return invalid
+	}
+	return err
+}
@@ -0,0 +1,215 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+type resultConfig struct {
+	tools.ConfigBase
+}
+
+func (resultConfig) ToolConfigType() string { return "result" }
+func (resultConfig) Initialize(context.Context) (tools.Tool, error) {
+	return nil, nil
+}
+
+// resultTool returns its result parameter.
+type resultTool struct {
+	tools.BaseTool[resultConfig]
+}
+
+func (t resultTool) Invoke(_ context.Context, _ tools.SourceProvider, params parameters.ParamValues, _ tools.AccessToken) (any, util.ToolboxError) {
+	return params.AsMap()["result"], nil
+}
+
+func (t resultTool) ToConfig() tools.ToolConfig { return t.Cfg }
+
+func newResultTool(schema *tools.OutputSchema) tools.Tool {
+	cfg := resultConfig{ConfigBase: tools.ConfigBase{Name: "result", OutputSchema: schema}}
+	return resultTool{tools.NewBaseTool(cfg, nil, tools.Manifest{Description: "returns its result"}, nil)}
+}
+
+func TestWrapOutputSchemasColumns(t *testing.T) {
+	schema := &tools.OutputSchema{Columns: []tools.OutputColumn{
+		{Name: "id", Type: "integer"},
+		{Name: "name", Type: "string", Nullable: true},
+	}}
+	wrapped, err := tools.WrapOutputSchemas(map[string]tools.Tool{"result": newResultTool(schema)})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tool := wrapped["result"]
+
+	tcs := []struct {
+		desc    string
+		result  any
+		wantErr string
+	}{
+		{
+			desc:   "matching rows",
+			result: []any{row("id", 1, "name", "a"), row("id", int64(2), "name", nil)},
+		},
+		{
+			desc:   "no rows",
+			result: nil,
+		},
+		{
+			desc:    "wrong type",
+			result:  []any{row("id", 1.5, "name", "a")},
+			wantErr: "value[0].id is number, not integer",
+		},
+		{
+			desc:    "missing column",
+			result:  []any{row("id", 1)},
+			wantErr: `value[0] is missing the required property "name"`,
+		},
+		{
+			desc:    "undeclared column",
+			result:  []any{row("id", 1, "name", "a", "email", "a@example.com")},
+			wantErr: `value[0] has the undeclared property "email"`,
+		},
+		{
+			desc:    "not rows",
+			result:  "done",
+			wantErr: "value is string, not array",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			params := parameters.ParamValues{{Name: "result", Value: tc.result}}
+			res, err := tool.Invoke(context.Background(), nil, params, "")
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if res == nil {
+					t.Errorf("expected an empty result to be returned as rows")
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			if !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("unexpected error: got %q, want it to contain %q", err, tc.wantErr)
+			}
+			if got := err.(*util.ClientServerError).Code; got != http.StatusInternalServerError {
+				t.Errorf("unexpected status: got %d, want %d", got, http.StatusInternalServerError)
+			}
+		})
+	}
+
+	want := map[string]any{
+		"type": "array",
+		"items": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"id":   map[string]any{"type": "integer"},
+				"name": map[string]any{"type": []string{"string", "null"}},
+			},
+			"required":             []string{"id", "name"},
+			"additionalProperties": false,
+		},
+	}
+	if diff := cmp.Diff(want, tool.StaticManifest().OutputSchema); diff != "" {
+		t.Errorf("unexpected manifest schema (-want +got):\n%s", diff)
+	}
+	manifest, err := tool.Manifest(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if manifest.Description != "returns its result" || manifest.OutputSchema == nil {
+		t.Errorf("expected the manifest to keep its description and expose the schema, got %+v", manifest)
+	}
+}
+
+func TestWrapOutputSchemasJSONSchema(t *testing.T) {
+	schema := &tools.OutputSchema{JSONSchema: map[string]any{
+		"type":     "object",
+		"required": []any{"status"},
+		"properties": map[string]any{
+			"status": map[string]any{"type": "string", "enum": []any{"ok", "failed"}},
+			"count":  map[string]any{"type": "integer"},
+		},
+	}}
+	wrapped, err := tools.WrapOutputSchemas(map[string]tools.Tool{"result": newResultTool(schema)})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tool := wrapped["result"]
+
+	ok := map[string]any{"status": "ok", "count": uint64(3), "extra": true}
+	if _, err := tool.Invoke(context.Background(), nil, parameters.ParamValues{{Name: "result", Value: ok}}, ""); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	bad := map[string]any{"status": "unknown"}
+	_, err = tool.Invoke(context.Background(), nil, parameters.ParamValues{{Name: "result", Value: bad}}, "")
+	if err == nil || !strings.Contains(err.Error(), `value.status "unknown" is not one of "ok", "failed"`) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestWrapOutputSchemasInvalid(t *testing.T) {
+	tcs := []struct {
+		desc    string
+		schema  tools.OutputSchema
+		wantErr string
+	}{
+		{
+			desc:    "neither columns nor jsonSchema",
+			wantErr: "must set exactly one of columns and jsonSchema",
+		},
+		{
+			desc: "both columns and jsonSchema",
+			schema: tools.OutputSchema{
+				Columns:    []tools.OutputColumn{{Name: "id", Type: "integer"}},
+				JSONSchema: map[string]any{"type": "array"},
+			},
+			wantErr: "must set exactly one of columns and jsonSchema",
+		},
+		{
+			desc: "duplicate columns",
+			schema: tools.OutputSchema{Columns: []tools.OutputColumn{
+				{Name: "id", Type: "integer"},
+				{Name: "id", Type: "string"},
+			}},
+			wantErr: `several columns named "id"`,
+		},
+		{
+			desc:    "unknown type",
+			schema:  tools.OutputSchema{JSONSchema: map[string]any{"items": map[string]any{"type": "text"}}},
+			wantErr: `schema[] has an unknown type "text"`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			schema := tc.schema
+			_, err := tools.WrapOutputSchemas(map[string]tools.Tool{"result": newResultTool(&schema)})
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("unexpected error: got %v, want it to contain %q", err, tc.wantErr)
+			}
+		})
+	}
+}
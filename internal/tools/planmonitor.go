@@ -0,0 +1,24 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+// PlanMonitor is implemented by tools that can monitor the stability of the
+// query plans of their statements.
+type PlanMonitor interface {
+	// ResetPlanHash forgets the stored plan hash, so that the next
+	// invocation stores a new one. It reports false if the tool does not
+	// monitor its query plan.
+	ResetPlanHash() bool
+}
@@ -0,0 +1,277 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgrescopyinsert
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const resourceType string = "postgres-copy-insert"
+
+// Formats of the payload of the tool.
+const (
+	formatRows = "rows"
+	formatCSV  = "csv"
+)
+
+// DefaultMaxPayloadBytes bounds the size of payloads of tools that do not set
+// maxPayloadBytes.
+const DefaultMaxPayloadBytes int64 = 32 << 20
+
+func init() {
+	if !tools.Register[compatibleSource](resourceType, newConfig) {
+		panic(fmt.Sprintf("tool type %q already registered", resourceType))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{ConfigBase: tools.ConfigBase{Name: name}}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type compatibleSource interface {
+	PostgresPool() *pgxpool.Pool
+}
+
+type Config struct {
+	tools.ConfigBase `yaml:",inline"`
+	Type             string   `yaml:"type" validate:"required"`
+	Source           string   `yaml:"source" validate:"required"`
+	Table            string   `yaml:"table" validate:"required"`
+	Columns          []string `yaml:"columns" validate:"required,min=1,unique,dive,required"`
+	// Format is the form of the payload: "rows", an array of objects mapping
+	// columns to values, or "csv", a CSV string with a header row.
+	Format          string `yaml:"format" validate:"omitempty,oneof=rows csv"`
+	MaxPayloadBytes int64  `yaml:"maxPayloadBytes" validate:"omitempty,gte=1"`
+	// UsePostgresCopy streams the rows with the COPY protocol. When false,
+	// each row is bound to its own INSERT, for tables or proxies that do not
+	// support COPY. Defaults to true.
+	UsePostgresCopy *bool                  `yaml:"usePostgresCopy"`
+	Annotations     *tools.ToolAnnotations `yaml:"annotations,omitempty"`
+}
+
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigType() string {
+	return resourceType
+}
+
+func (cfg Config) Initialize(context.Context) (tools.Tool, error) {
+	if cfg.Description == "" {
+		return nil, fmt.Errorf("description is required for tool %q", cfg.Name)
+	}
+	if cfg.Format == "" {
+		cfg.Format = formatRows
+	}
+	if cfg.MaxPayloadBytes == 0 {
+		cfg.MaxPayloadBytes = DefaultMaxPayloadBytes
+	}
+	if cfg.UsePostgresCopy == nil {
+		useCopy := true
+		cfg.UsePostgresCopy = &useCopy
+	}
+
+	columns := strings.Join(cfg.Columns, ", ")
+	var allParameters parameters.Parameters
+	switch cfg.Format {
+	case formatCSV:
+		allParameters = parameters.Parameters{
+			parameters.NewStringParameter(formatCSV, fmt.Sprintf("The rows to insert as CSV. The first line is a header naming the columns of the fields among: %s. Empty fields are NULL.", columns)),
+		}
+	default:
+		allParameters = parameters.Parameters{
+			parameters.NewArrayParameter(formatRows, "The rows to insert.",
+				parameters.NewMapParameter("row", fmt.Sprintf("A row, mapping columns among %s to their value. Missing columns are NULL.", columns), "")),
+		}
+	}
+
+	return Tool{
+		BaseTool: tools.NewBaseTool(
+			cfg,
+			tools.GetAnnotationsOrDefault(cfg.Annotations, tools.NewDestructiveAnnotations),
+			tools.Manifest{Description: cfg.Description, Parameters: allParameters.Manifest(), AuthRequired: cfg.AuthRequired},
+			allParameters,
+		),
+	}, nil
+}
+
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	tools.BaseTool[Config]
+}
+
+// Result is the result of a copy.
+type Result struct {
+	RowCount int64 `json:"rowCount"`
+}
+
+func (t Tool) Invoke(ctx context.Context, primitiveMgr tools.SourceProvider, params parameters.ParamValues, accessToken tools.AccessToken) (any, util.ToolboxError) {
+	source, err := tools.GetCompatibleSource[compatibleSource](primitiveMgr, t.Cfg.Source, t.Cfg.Name, t.Cfg.Type)
+	if err != nil {
+		return nil, util.NewClientServerError("source used is not compatible with the tool", http.StatusInternalServerError, err)
+	}
+
+	rows, err := t.rowReader(params.AsMap())
+	if err != nil {
+		return nil, util.NewAgentError("invalid payload", err)
+	}
+
+	conn, err := source.PostgresPool().Acquire(ctx)
+	if err != nil {
+		return nil, util.NewClientServerError("unable to acquire connection", http.StatusInternalServerError, err)
+	}
+	defer conn.Release()
+
+	table := pgx.Identifier(strings.Split(t.Cfg.Table, "."))
+	oids, err := columnTypes(ctx, conn.Conn(), table, t.Cfg.Columns)
+	if err != nil {
+		return nil, util.ProcessGeneralError(err)
+	}
+
+	src := &copySource{rows: rows, columns: t.Cfg.Columns, oids: oids, m: conn.Conn().TypeMap()}
+	var count int64
+	if *t.Cfg.UsePostgresCopy {
+		count, err = conn.Conn().CopyFrom(ctx, table, t.Cfg.Columns, src)
+	} else {
+		count, err = insertRows(ctx, conn.Conn(), table, t.Cfg.Columns, src)
+	}
+	if src.Err() != nil {
+		// the payload, not the database, failed the copy
+		return nil, util.NewAgentError("unable to convert payload", src.Err())
+	}
+	if err != nil {
+		return nil, util.ProcessGeneralError(err)
+	}
+	return Result{RowCount: count}, nil
+}
+
+// rowReader returns the reader of the payload of params, after checking its
+// size.
+func (t Tool) rowReader(params map[string]any) (rowReader, error) {
+	if t.Cfg.Format == formatCSV {
+		payload, ok := params[formatCSV].(string)
+		if !ok {
+			return nil, fmt.Errorf("parameter %q is not a string", formatCSV)
+		}
+		if err := t.checkSize(int64(len(payload))); err != nil {
+			return nil, err
+		}
+		return newCSVRows(t.Cfg.Columns, payload)
+	}
+
+	items, ok := params[formatRows].([]any)
+	if !ok {
+		return nil, fmt.Errorf("parameter %q is not an array", formatRows)
+	}
+	b, err := json.Marshal(items)
+	if err != nil {
+		return nil, fmt.Errorf("unable to measure payload: %w", err)
+	}
+	if err := t.checkSize(int64(len(b))); err != nil {
+		return nil, err
+	}
+	return &objectRows{columns: t.Cfg.Columns, items: items}, nil
+}
+
+func (t Tool) checkSize(size int64) error {
+	if size > t.Cfg.MaxPayloadBytes {
+		return fmt.Errorf("payload of %d bytes exceeds the limit of %d bytes", size, t.Cfg.MaxPayloadBytes)
+	}
+	return nil
+}
+
+// insertStatement returns the INSERT binding a row to columns of table.
+func insertStatement(table pgx.Identifier, columns []string) string {
+	quoted := make([]string, len(columns))
+	placeholders := make([]string, len(columns))
+	for i, c := range columns {
+		quoted[i] = pgx.Identifier{c}.Sanitize()
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+	}
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", table.Sanitize(), strings.Join(quoted, ", "), strings.Join(placeholders, ", "))
+}
+
+// insertRows inserts the rows of src one INSERT at a time, in a transaction
+// so that either every row or none is inserted, like a copy.
+func insertRows(ctx context.Context, conn *pgx.Conn, table pgx.Identifier, columns []string, src pgx.CopyFromSource) (int64, error) {
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("unable to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	stmt := insertStatement(table, columns)
+	var count int64
+	for src.Next() {
+		values, err := src.Values()
+		if err != nil {
+			return 0, err
+		}
+		if _, err := tx.Exec(ctx, stmt, values...); err != nil {
+			return 0, fmt.Errorf("row %d: %w", count, err)
+		}
+		count++
+	}
+	if err := src.Err(); err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("unable to commit transaction: %w", err)
+	}
+	return count, nil
+}
+
+// columnTypes returns the type OIDs of columns of table.
+func columnTypes(ctx context.Context, conn *pgx.Conn, table pgx.Identifier, columns []string) ([]uint32, error) {
+	quoted := make([]string, len(columns))
+	for i, c := range columns {
+		quoted[i] = pgx.Identifier{c}.Sanitize()
+	}
+	rows, err := conn.Query(ctx, fmt.Sprintf("SELECT %s FROM %s LIMIT 0", strings.Join(quoted, ", "), table.Sanitize()))
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the column types of table %s: %w", table.Sanitize(), err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("unable to get the column types of table %s: %w", table.Sanitize(), err)
+	}
+	fields := rows.FieldDescriptions()
+	oids := make([]uint32, len(fields))
+	for i, f := range fields {
+		oids[i] = f.DataTypeOID
+	}
+	return oids, nil
+}
+
+func (t Tool) ToConfig() tools.ToolConfig {
+	return t.Cfg
+}
@@ -0,0 +1,206 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgrescopyinsert
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var testColumns = []string{"id", "name", "active", "departure"}
+
+var testOIDs = []uint32{pgtype.Int4OID, pgtype.TextOID, pgtype.BoolOID, pgtype.TimestamptzOID}
+
+func newTestSource(rows rowReader) *copySource {
+	return &copySource{rows: rows, columns: testColumns, oids: testOIDs, m: pgtype.NewMap()}
+}
+
+// drain reads all rows of s.
+func drain(s *copySource) [][]any {
+	var out [][]any
+	for s.Next() {
+		values, err := s.Values()
+		if err != nil {
+			return out
+		}
+		out = append(out, values)
+	}
+	return out
+}
+
+func TestCopySourceObjectRows(t *testing.T) {
+	items := []any{
+		map[string]any{"id": json.Number("1"), "name": "Alice", "active": true, "departure": "2026-03-01T10:00:00Z"},
+		map[string]any{"id": 2.0, "name": "Bob"},
+		map[string]any{"id": "3", "active": "false", "name": nil},
+	}
+	s := newTestSource(&objectRows{columns: testColumns, items: items})
+	got := drain(s)
+	if err := s.Err(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := [][]any{
+		{int32(1), "Alice", true, time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)},
+		{int32(2), "Bob", nil, nil},
+		{int32(3), nil, false, nil},
+	}
+	if diff := cmp.Diff(want, got, cmp.Comparer(func(a, b time.Time) bool { return a.Equal(b) })); diff != "" {
+		t.Errorf("unexpected rows (-want +got):\n%s", diff)
+	}
+}
+
+func TestCopySourceCSVRows(t *testing.T) {
+	payload := "name,id,active\n\"Smith, Alice\",1,true\nBob,2,\n"
+	rows, err := newCSVRows(testColumns, payload)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	s := newTestSource(rows)
+	got := drain(s)
+	if err := s.Err(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := [][]any{
+		{int32(1), "Smith, Alice", true, nil},
+		{int32(2), "Bob", nil, nil},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected rows (-want +got):\n%s", diff)
+	}
+}
+
+func TestCopySourceErrors(t *testing.T) {
+	csvSource := func(payload string) *copySource {
+		rows, err := newCSVRows(testColumns, payload)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		return newTestSource(rows)
+	}
+	tcs := []struct {
+		desc     string
+		source   *copySource
+		wantRows int
+		want     string
+	}{
+		{
+			desc: "object conversion",
+			source: newTestSource(&objectRows{columns: testColumns, items: []any{
+				map[string]any{"id": 1},
+				map[string]any{"id": 2, "active": "maybe"},
+				map[string]any{"id": "x"},
+			}}),
+			wantRows: 1,
+			want:     `row 1, column "active": cannot convert "maybe" to bool`,
+		},
+		{
+			desc: "object float to integer",
+			source: newTestSource(&objectRows{columns: testColumns, items: []any{
+				map[string]any{"id": 1.5},
+			}}),
+			want: `row 0, column "id": cannot convert "1.5" to int4`,
+		},
+		{
+			desc:   "object unknown column",
+			source: newTestSource(&objectRows{columns: testColumns, items: []any{map[string]any{"email": "a@b.c"}}}),
+			want:   `row 0: unknown column "email"`,
+		},
+		{
+			desc:   "object not an object",
+			source: newTestSource(&objectRows{columns: testColumns, items: []any{"Alice"}}),
+			want:   `row 0: row is not an object`,
+		},
+		{
+			desc:     "csv conversion",
+			source:   csvSource("id,departure\n1,2026-03-01T10:00:00Z\n2,tomorrow\n"),
+			wantRows: 1,
+			want:     `row 1, column "departure": cannot convert "tomorrow" to timestamptz`,
+		},
+		{
+			desc:   "csv wrong number of fields",
+			source: csvSource("id,name\n1\n"),
+			want:   `row 0: record on line 2: wrong number of fields`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := drain(tc.source)
+			if len(got) != tc.wantRows {
+				t.Errorf("unexpected number of rows before the error: %d", len(got))
+			}
+			err := tc.source.Err()
+			if err == nil || len(err.Error()) < len(tc.want) || err.Error()[:len(tc.want)] != tc.want {
+				t.Fatalf("unexpected error: got %v, want prefix %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestNewCSVRowsErrors(t *testing.T) {
+	tcs := []struct {
+		desc    string
+		payload string
+		want    string
+	}{
+		{desc: "empty", payload: "", want: "csv has no header row"},
+		{desc: "unknown column", payload: "id,email\n", want: `csv header names unknown column "email"`},
+		{desc: "duplicate column", payload: "id,name,id\n", want: `csv header names column "id" more than once`},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := newCSVRows(testColumns, tc.payload)
+			if err == nil || err.Error() != tc.want {
+				t.Fatalf("unexpected error: got %v, want %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestRowReaderPayloadLimit(t *testing.T) {
+	tool := Tool{}
+	tool.Cfg.Columns = testColumns
+	tool.Cfg.MaxPayloadBytes = 10
+
+	tool.Cfg.Format = formatCSV
+	if _, err := tool.rowReader(map[string]any{"csv": "id\n1\n2\n"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	_, err := tool.rowReader(map[string]any{"csv": "id\n1\n2\n3\n4\n"})
+	if err == nil || err.Error() != "payload of 11 bytes exceeds the limit of 10 bytes" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tool.Cfg.Format = formatRows
+	if _, err := tool.rowReader(map[string]any{"rows": []any{map[string]any{"id": 1}}}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	_, err = tool.rowReader(map[string]any{"rows": []any{map[string]any{"id": 10}}})
+	if err == nil || err.Error() != "payload of 11 bytes exceeds the limit of 10 bytes" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestInsertStatement(t *testing.T) {
+	got := insertStatement(pgx.Identifier{"public", "flights"}, testColumns)
+	want := `INSERT INTO "public"."flights" ("id", "name", "active", "departure") VALUES ($1, $2, $3, $4)`
+	if got != want {
+		t.Errorf("unexpected statement:\ngot  %s\nwant %s", got, want)
+	}
+}
@@ -0,0 +1,220 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgrescopyinsert_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/internal/server"
+	"github.com/googleapis/mcp-toolbox/internal/testutils"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/tools/postgres/postgrescopyinsert"
+)
+
+func TestParseFromYamlPostgresCopyInsert(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+            kind: tool
+            name: load_flights
+            type: postgres-copy-insert
+            source: my-pg-instance
+            description: some description
+            table: public.flights
+            columns: [id, airline, departure]
+			`,
+			want: server.ToolConfigs{
+				"load_flights": postgrescopyinsert.Config{
+					ConfigBase: tools.ConfigBase{
+						Name:         "load_flights",
+						Description:  "some description",
+						AuthRequired: []string{},
+					},
+					Type:    "postgres-copy-insert",
+					Source:  "my-pg-instance",
+					Table:   "public.flights",
+					Columns: []string{"id", "airline", "departure"},
+				},
+			},
+		},
+		{
+			desc: "csv with payload limit",
+			in: `
+            kind: tool
+            name: load_flights
+            type: postgres-copy-insert
+            source: my-pg-instance
+            description: some description
+            table: flights
+            columns: [id, airline]
+            format: csv
+            maxPayloadBytes: 1048576
+			`,
+			want: server.ToolConfigs{
+				"load_flights": postgrescopyinsert.Config{
+					ConfigBase: tools.ConfigBase{
+						Name:         "load_flights",
+						Description:  "some description",
+						AuthRequired: []string{},
+					},
+					Type:            "postgres-copy-insert",
+					Source:          "my-pg-instance",
+					Table:           "flights",
+					Columns:         []string{"id", "airline"},
+					Format:          "csv",
+					MaxPayloadBytes: 1048576,
+				},
+			},
+		},
+		{
+			desc: "individual inserts",
+			in: `
+            kind: tool
+            name: load_flights
+            type: postgres-copy-insert
+            source: my-pg-instance
+            description: some description
+            table: flights
+            columns: [id, airline]
+            usePostgresCopy: false
+			`,
+			want: server.ToolConfigs{
+				"load_flights": postgrescopyinsert.Config{
+					ConfigBase: tools.ConfigBase{
+						Name:         "load_flights",
+						Description:  "some description",
+						AuthRequired: []string{},
+					},
+					Type:            "postgres-copy-insert",
+					Source:          "my-pg-instance",
+					Table:           "flights",
+					Columns:         []string{"id", "airline"},
+					UsePostgresCopy: new(bool),
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			// Parse contents
+			_, _, _, got, _, _, err := server.UnmarshalPrimitiveConfig(ctx, testutils.FormatYaml(tc.in))
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestParseFromYamlPostgresCopyInsertFailure(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+	}{
+		{
+			desc: "missing columns",
+			in: `
+            kind: tool
+            name: load_flights
+            type: postgres-copy-insert
+            source: my-pg-instance
+            description: some description
+            table: flights
+			`,
+		},
+		{
+			desc: "duplicate columns",
+			in: `
+            kind: tool
+            name: load_flights
+            type: postgres-copy-insert
+            source: my-pg-instance
+            description: some description
+            table: flights
+            columns: [id, id]
+			`,
+		},
+		{
+			desc: "unknown format",
+			in: `
+            kind: tool
+            name: load_flights
+            type: postgres-copy-insert
+            source: my-pg-instance
+            description: some description
+            table: flights
+            columns: [id]
+            format: parquet
+			`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, _, _, _, _, _, err := server.UnmarshalPrimitiveConfig(ctx, testutils.FormatYaml(tc.in))
+			if err == nil {
+				t.Fatalf("expect parsing to fail")
+			}
+		})
+	}
+}
+
+func TestInitializePostgresCopyInsert(t *testing.T) {
+	tcs := []struct {
+		desc       string
+		format     string
+		wantParams []string
+	}{
+		{desc: "rows by default", wantParams: []string{"rows"}},
+		{desc: "csv", format: "csv", wantParams: []string{"csv"}},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := postgrescopyinsert.Config{
+				ConfigBase: tools.ConfigBase{Name: "load_flights", Description: "some description"},
+				Table:      "flights",
+				Columns:    []string{"id", "airline"},
+				Format:     tc.format,
+			}
+			tool, err := cfg.Initialize(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			var got []string
+			for _, p := range tool.StaticManifest().Parameters {
+				got = append(got, p.Name)
+			}
+			if diff := cmp.Diff(tc.wantParams, got); diff != "" {
+				t.Errorf("unexpected parameters (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
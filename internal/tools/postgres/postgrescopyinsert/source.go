@@ -0,0 +1,204 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgrescopyinsert
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// rowReader reads the raw values of the rows of a payload, in the order of
+// the columns of the tool. Missing values are nil.
+type rowReader interface {
+	read() ([]any, error)
+}
+
+// objectRows reads rows from an array of objects mapping columns to values.
+type objectRows struct {
+	columns []string
+	items   []any
+	next    int
+}
+
+func (r *objectRows) read() ([]any, error) {
+	if r.next >= len(r.items) {
+		return nil, io.EOF
+	}
+	item := r.items[r.next]
+	r.next++
+	obj, ok := item.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("row is not an object")
+	}
+	values := make([]any, len(r.columns))
+	for k, v := range obj {
+		i := slices.Index(r.columns, k)
+		if i < 0 {
+			return nil, fmt.Errorf("unknown column %q", k)
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+// csvRows reads rows from CSV whose header row names the columns of its
+// fields. Empty fields are nil.
+type csvRows struct {
+	r *csv.Reader
+	// positions maps the fields of the CSV to the columns of the tool.
+	positions []int
+	columns   int
+}
+
+func newCSVRows(columns []string, payload string) (*csvRows, error) {
+	r := csv.NewReader(strings.NewReader(payload))
+	r.ReuseRecord = true
+	header, err := r.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("csv has no header row")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to read csv header: %w", err)
+	}
+	positions := make([]int, len(header))
+	for i, name := range header {
+		name = strings.TrimSpace(name)
+		pos := slices.Index(columns, name)
+		if pos < 0 {
+			return nil, fmt.Errorf("csv header names unknown column %q", name)
+		}
+		if slices.Contains(positions[:i], pos) {
+			return nil, fmt.Errorf("csv header names column %q more than once", name)
+		}
+		positions[i] = pos
+	}
+	return &csvRows{r: r, positions: positions, columns: len(columns)}, nil
+}
+
+func (r *csvRows) read() ([]any, error) {
+	record, err := r.r.Read()
+	if err != nil {
+		return nil, err
+	}
+	values := make([]any, r.columns)
+	for i, field := range record {
+		if field != "" {
+			values[r.positions[i]] = field
+		}
+	}
+	return values, nil
+}
+
+// copySource is a pgx.CopyFromSource over the rows of a payload, converting
+// their values to the types of the columns. Errors report the index of the
+// offending row, counting from 0 and excluding the CSV header row.
+type copySource struct {
+	rows    rowReader
+	columns []string
+	oids    []uint32
+	m       *pgtype.Map
+	row     int
+	values  []any
+	err     error
+}
+
+var _ pgx.CopyFromSource = (*copySource)(nil)
+
+func (s *copySource) Next() bool {
+	if s.err != nil {
+		return false
+	}
+	raw, err := s.rows.read()
+	if errors.Is(err, io.EOF) {
+		return false
+	}
+	if err != nil {
+		s.err = fmt.Errorf("row %d: %w", s.row, err)
+		return false
+	}
+	values := make([]any, len(raw))
+	for i, v := range raw {
+		values[i], err = convert(s.m, s.oids[i], v)
+		if err != nil {
+			s.err = fmt.Errorf("row %d, column %q: %w", s.row, s.columns[i], err)
+			return false
+		}
+	}
+	s.values = values
+	s.row++
+	return true
+}
+
+func (s *copySource) Values() ([]any, error) {
+	return s.values, nil
+}
+
+func (s *copySource) Err() error {
+	return s.err
+}
+
+// convert parses the text form of v as the type oid.
+func convert(m *pgtype.Map, oid uint32, v any) (any, error) {
+	if v == nil {
+		return nil, nil
+	}
+	var text string
+	switch x := v.(type) {
+	case string:
+		text = x
+	case json.Number:
+		text = x.String()
+	case bool:
+		text = strconv.FormatBool(x)
+	case int:
+		text = strconv.Itoa(x)
+	case int64:
+		text = strconv.FormatInt(x, 10)
+	case float64:
+		text = strconv.FormatFloat(x, 'f', -1, 64)
+	default:
+		b, err := json.Marshal(x)
+		if err != nil {
+			return nil, fmt.Errorf("unable to encode value: %w", err)
+		}
+		text = string(b)
+	}
+	if oid == pgtype.TimestamptzOID || oid == pgtype.TimestampOID {
+		// the text format of postgres separates dates and times with a space,
+		// while JSON clients send RFC 3339
+		if ts, err := time.Parse(time.RFC3339Nano, text); err == nil {
+			return ts, nil
+		}
+	}
+	t, ok := m.TypeForOID(oid)
+	if !ok {
+		return nil, fmt.Errorf("column type %d is not supported", oid)
+	}
+	value, err := t.Codec.DecodeValue(m, oid, pgtype.TextFormatCode, []byte(text))
+	if err != nil {
+		return nil, fmt.Errorf("cannot convert %q to %s: %w", text, t.Name, err)
+	}
+	return value, nil
+}
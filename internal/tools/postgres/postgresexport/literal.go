@@ -0,0 +1,204 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgresexport
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// inlineParams replaces the $N placeholders of statement with the literal
+// form of params[N-1]. Placeholders inside string literals, quoted
+// identifiers, dollar-quoted strings and comments are left untouched. escape
+// escapes the contents of a string literal.
+func inlineParams(statement string, params []any, escape func(string) (string, error)) (string, error) {
+	var sb strings.Builder
+	for i := 0; i < len(statement); {
+		c := statement[i]
+		switch {
+		case c == '\'' || c == '"':
+			end := quotedEnd(statement, i, c)
+			sb.WriteString(statement[i:end])
+			i = end
+		case c == '-' && strings.HasPrefix(statement[i:], "--"):
+			end := strings.IndexByte(statement[i:], '\n')
+			if end < 0 {
+				end = len(statement) - i
+			}
+			sb.WriteString(statement[i : i+end])
+			i += end
+		case c == '/' && strings.HasPrefix(statement[i:], "/*"):
+			end := strings.Index(statement[i+2:], "*/")
+			if end < 0 {
+				end = len(statement) - i
+			} else {
+				end += 4
+			}
+			sb.WriteString(statement[i : i+end])
+			i += end
+		case c == '$':
+			j := i + 1
+			for j < len(statement) && statement[j] >= '0' && statement[j] <= '9' {
+				j++
+			}
+			if j > i+1 {
+				n, err := strconv.Atoi(statement[i+1 : j])
+				if err != nil || n < 1 || n > len(params) {
+					return "", fmt.Errorf("no value for parameter %s", statement[i:j])
+				}
+				lit, err := literal(params[n-1], escape)
+				if err != nil {
+					return "", fmt.Errorf("parameter %s: %w", statement[i:j], err)
+				}
+				sb.WriteString(lit)
+				i = j
+				continue
+			}
+			if tag, ok := dollarTag(statement[i:]); ok {
+				end := strings.Index(statement[i+len(tag):], tag)
+				if end < 0 {
+					end = len(statement) - i
+				} else {
+					end += 2 * len(tag)
+				}
+				sb.WriteString(statement[i : i+end])
+				i += end
+				continue
+			}
+			sb.WriteByte(c)
+			i++
+		default:
+			sb.WriteByte(c)
+			i++
+		}
+	}
+	return sb.String(), nil
+}
+
+// quotedEnd returns the index just past the string literal or quoted
+// identifier that starts at statement[start]. Doubled quotes are part of the
+// literal, as are backslash escapes in E” strings.
+func quotedEnd(statement string, start int, quote byte) int {
+	escapes := quote == '\'' && start > 0 && (statement[start-1] == 'E' || statement[start-1] == 'e')
+	for i := start + 1; i < len(statement); i++ {
+		switch statement[i] {
+		case '\\':
+			if escapes {
+				i++
+			}
+		case quote:
+			if i+1 < len(statement) && statement[i+1] == quote {
+				i++
+				continue
+			}
+			return i + 1
+		}
+	}
+	return len(statement)
+}
+
+// dollarTag returns the opening tag of the dollar-quoted string s starts
+// with, e.g. "$$" or "$body$".
+func dollarTag(s string) (string, bool) {
+	for i := 1; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '$':
+			return s[:i+1], true
+		case c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (i > 1 && c >= '0' && c <= '9'):
+		default:
+			return "", false
+		}
+	}
+	return "", false
+}
+
+// literal returns v as a Postgres literal.
+func literal(v any, escape func(string) (string, error)) (string, error) {
+	quote := func(s string) (string, error) {
+		escaped, err := escape(s)
+		if err != nil {
+			return "", err
+		}
+		return "'" + escaped + "'", nil
+	}
+
+	switch val := v.(type) {
+	case nil:
+		return "NULL", nil
+	case string:
+		return quote(val)
+	case bool:
+		if val {
+			return "TRUE", nil
+		}
+		return "FALSE", nil
+	case int:
+		return number(strconv.Itoa(val)), nil
+	case int32:
+		return number(strconv.FormatInt(int64(val), 10)), nil
+	case int64:
+		return number(strconv.FormatInt(val, 10)), nil
+	case float32:
+		return float(float64(val), 32), nil
+	case float64:
+		return float(val, 64), nil
+	case map[string]any:
+		b, err := json.Marshal(val)
+		if err != nil {
+			return "", err
+		}
+		return quote(string(b))
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Slice {
+		if rv.Len() == 0 {
+			return "'{}'", nil
+		}
+		items := make([]string, rv.Len())
+		for i := range items {
+			item, err := literal(rv.Index(i).Interface(), escape)
+			if err != nil {
+				return "", err
+			}
+			items[i] = item
+		}
+		return "ARRAY[" + strings.Join(items, ", ") + "]", nil
+	}
+	return "", fmt.Errorf("unsupported value type %T", v)
+}
+
+// number parenthesizes negative numbers so that an inlined value can never
+// start a `--` comment, as in `x-$1`.
+func number(s string) string {
+	if strings.HasPrefix(s, "-") {
+		return "(" + s + ")"
+	}
+	return s
+}
+
+// float returns f as a numeric literal, or as a quoted float8 for the
+// special values that have no literal form.
+func float(f float64, bitSize int) string {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return "'" + strconv.FormatFloat(f, 'g', -1, bitSize) + "'::float8"
+	}
+	return number(strconv.FormatFloat(f, 'g', -1, bitSize))
+}
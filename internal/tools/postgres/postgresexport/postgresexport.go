@@ -0,0 +1,208 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgresexport
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/storage"
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/mcp-toolbox/internal/embeddingmodels"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/tools/gcsexport"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const resourceType string = "postgres-export"
+
+// progressInterval is the number of bytes between two progress notifications.
+const progressInterval = 8 << 20
+
+func init() {
+	if !tools.Register[compatibleSource](resourceType, newConfig) {
+		panic(fmt.Sprintf("tool type %q already registered", resourceType))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{ConfigBase: tools.ConfigBase{Name: name}}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type compatibleSource interface {
+	PostgresPool() *pgxpool.Pool
+}
+
+type Config struct {
+	tools.ConfigBase   `yaml:",inline"`
+	Type               string                 `yaml:"type" validate:"required"`
+	Source             string                 `yaml:"source" validate:"required"`
+	Statement          string                 `yaml:"statement" validate:"required"`
+	Destination        gcsexport.Destination  `yaml:"destination" validate:"required"`
+	Format             string                 `yaml:"format"`
+	Parameters         parameters.Parameters  `yaml:"parameters"`
+	TemplateParameters parameters.Parameters  `yaml:"templateParameters"`
+	Annotations        *tools.ToolAnnotations `yaml:"annotations,omitempty"`
+}
+
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigType() string {
+	return resourceType
+}
+
+func (cfg Config) Initialize(context.Context) (tools.Tool, error) {
+	if cfg.Description == "" {
+		return nil, fmt.Errorf("description is required for tool %q", cfg.Name)
+	}
+	if cfg.Format == "" {
+		cfg.Format = gcsexport.FormatCSV
+	}
+	// COPY can only produce text, csv and binary output.
+	if err := cfg.Destination.Validate(cfg.Format, gcsexport.FormatCSV); err != nil {
+		return nil, fmt.Errorf("invalid configuration for tool %q: %w", cfg.Name, err)
+	}
+	allParameters, paramManifest, err := parameters.ProcessParameters(cfg.TemplateParameters, cfg.Parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	return Tool{
+		BaseTool: tools.NewBaseTool(
+			cfg,
+			tools.GetAnnotationsOrDefault(cfg.Annotations, tools.NewReadOnlyAnnotations),
+			tools.Manifest{Description: cfg.Description, Parameters: paramManifest, AuthRequired: cfg.AuthRequired},
+			allParameters,
+		),
+	}, nil
+}
+
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	tools.BaseTool[Config]
+}
+
+func (t Tool) Invoke(ctx context.Context, primitiveMgr tools.SourceProvider, params parameters.ParamValues, accessToken tools.AccessToken) (any, util.ToolboxError) {
+	source, err := tools.GetCompatibleSource[compatibleSource](primitiveMgr, t.Cfg.Source, t.Cfg.Name, t.Cfg.Type)
+	if err != nil {
+		return nil, util.NewClientServerError("source used is not compatible with the tool", http.StatusInternalServerError, err)
+	}
+
+	paramsMap := params.AsMap()
+	newStatement, err := parameters.ResolveTemplateParams(t.Cfg.TemplateParameters, t.Cfg.Statement, paramsMap)
+	if err != nil {
+		return nil, util.NewAgentError("unable to extract template params", err)
+	}
+
+	newParams, err := parameters.GetParams(t.Cfg.Parameters, paramsMap)
+	if err != nil {
+		return nil, util.NewAgentError("unable to extract standard params", err)
+	}
+
+	conn, err := source.PostgresPool().Acquire(ctx)
+	if err != nil {
+		return nil, util.NewClientServerError("unable to acquire connection", http.StatusInternalServerError, err)
+	}
+	defer conn.Release()
+	pgConn := conn.Conn().PgConn()
+
+	// COPY does not accept bind parameters, so they are inlined as escaped
+	// literals using the escaping rules of the connection.
+	query, err := inlineParams(newStatement, newParams.AsSlice(), pgConn.EscapeString)
+	if err != nil {
+		return nil, util.NewAgentError("unable to bind parameters", err)
+	}
+
+	client, err := storage.NewClient(ctx)
+	if err != nil {
+		return nil, util.NewClientServerError("unable to create Cloud Storage client", http.StatusInternalServerError, err)
+	}
+	defer client.Close()
+
+	object := t.Cfg.Destination.ObjectName(t.Cfg.Name, time.Now()) + gcsexport.Extension(t.Cfg.Format)
+	writerCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	w := client.Bucket(t.Cfg.Destination.Bucket).Object(object).NewWriter(writerCtx)
+	w.ContentType = "text/csv"
+
+	util.ReportProgress(ctx, 0, 0, fmt.Sprintf("exporting to %s", t.Cfg.Destination.URI(object)))
+	rows, size, err := copyToObject(ctx, pgConn, copyStatement(query), w, cancel)
+	if err != nil {
+		return nil, util.ProcessGeneralError(err)
+	}
+	util.ReportProgress(ctx, float64(size), float64(size), fmt.Sprintf("exported %d rows", rows))
+
+	return gcsexport.Result{
+		URIs:     []string{t.Cfg.Destination.URI(object)},
+		Format:   t.Cfg.Format,
+		RowCount: rows,
+		ByteSize: size,
+	}, nil
+}
+
+// copier is the part of *pgconn.PgConn used to stream query results.
+type copier interface {
+	CopyTo(ctx context.Context, w io.Writer, sql string) (pgconn.CommandTag, error)
+}
+
+// copyStatement wraps query in a COPY statement that writes CSV with a header
+// row to the client.
+func copyStatement(query string) string {
+	query = strings.TrimRight(strings.TrimSpace(query), ";")
+	return fmt.Sprintf("COPY (%s) TO STDOUT WITH (FORMAT csv, HEADER true)", query)
+}
+
+// copyToObject streams the output of the COPY statement into w and closes it,
+// returning the number of rows and bytes written. If the copy fails, abort is
+// called before w is closed so that no partial object is committed.
+func copyToObject(ctx context.Context, conn copier, statement string, w io.WriteCloser, abort func()) (int64, int64, error) {
+	pw := &gcsexport.ProgressWriter{
+		W:        w,
+		Interval: progressInterval,
+		Report: func(written int64) {
+			util.ReportProgress(ctx, float64(written), 0, fmt.Sprintf("exported %d bytes", written))
+		},
+	}
+	tag, err := conn.CopyTo(ctx, pw, statement)
+	if err != nil {
+		abort()
+		_ = w.Close()
+		return 0, 0, fmt.Errorf("unable to copy query results: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return 0, 0, fmt.Errorf("unable to write export object: %w", err)
+	}
+	return tag.RowsAffected(), pw.Written(), nil
+}
+
+func (t Tool) EmbedParams(ctx context.Context, paramValues parameters.ParamValues, embeddingModelsMap map[string]embeddingmodels.EmbeddingModel) (parameters.ParamValues, error) {
+	return parameters.EmbedParams(ctx, t.StaticParameters, paramValues, embeddingModelsMap, embeddingmodels.FormatVectorForPgvector)
+}
+
+func (t Tool) ToConfig() tools.ToolConfig {
+	return t.Cfg
+}
@@ -0,0 +1,175 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgresexport
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// fakeCopier writes a fixed COPY output, optionally failing midway.
+type fakeCopier struct {
+	output string
+	rows   int
+	err    error
+	gotSQL string
+}
+
+func (f *fakeCopier) CopyTo(_ context.Context, w io.Writer, sql string) (pgconn.CommandTag, error) {
+	f.gotSQL = sql
+	if _, err := io.WriteString(w, f.output); err != nil {
+		return pgconn.CommandTag{}, err
+	}
+	if f.err != nil {
+		return pgconn.CommandTag{}, f.err
+	}
+	return pgconn.NewCommandTag(fmt.Sprintf("COPY %d", f.rows)), nil
+}
+
+// fakeObject records what was written to it and whether it was committed.
+type fakeObject struct {
+	bytes.Buffer
+	closed  bool
+	aborted bool
+}
+
+func (o *fakeObject) Close() error {
+	o.closed = true
+	if o.aborted {
+		return context.Canceled
+	}
+	return nil
+}
+
+func TestCopyToObject(t *testing.T) {
+	const csv = "id,name\n1,a\n2,b\n3,c\n"
+	copier := &fakeCopier{output: csv, rows: 3}
+	obj := &fakeObject{}
+
+	var reported []float64
+	ctx := util.WithProgressReporter(context.Background(), func(progress, total float64, message string) {
+		reported = append(reported, progress)
+	})
+
+	stmt := copyStatement("SELECT id, name FROM users WHERE org = 'x';\n")
+	rows, size, err := copyToObject(ctx, copier, stmt, obj, func() { obj.aborted = true })
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	wantSQL := "COPY (SELECT id, name FROM users WHERE org = 'x') TO STDOUT WITH (FORMAT csv, HEADER true)"
+	if copier.gotSQL != wantSQL {
+		t.Errorf("incorrect COPY statement: got %q, want %q", copier.gotSQL, wantSQL)
+	}
+	if rows != 3 {
+		t.Errorf("incorrect row count: got %d, want 3", rows)
+	}
+	if size != int64(len(csv)) {
+		t.Errorf("incorrect byte size: got %d, want %d", size, len(csv))
+	}
+	if obj.String() != csv {
+		t.Errorf("incorrect object content: got %q, want %q", obj.String(), csv)
+	}
+	if !obj.closed || obj.aborted {
+		t.Errorf("object should be committed: closed=%t aborted=%t", obj.closed, obj.aborted)
+	}
+	if len(reported) == 0 {
+		t.Errorf("expected progress to be reported")
+	}
+}
+
+func TestCopyToObjectFailure(t *testing.T) {
+	copier := &fakeCopier{output: "id\n1\n", err: errors.New("canceling statement due to statement timeout")}
+	obj := &fakeObject{}
+
+	_, _, err := copyToObject(context.Background(), copier, copyStatement("SELECT 1"), obj, func() { obj.aborted = true })
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+	if !strings.Contains(err.Error(), "statement timeout") {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if !obj.aborted || !obj.closed {
+		t.Errorf("object should be aborted before close: closed=%t aborted=%t", obj.closed, obj.aborted)
+	}
+}
+
+func TestInlineParams(t *testing.T) {
+	escape := func(s string) (string, error) {
+		return strings.ReplaceAll(s, "'", "''"), nil
+	}
+	tcs := []struct {
+		desc   string
+		stmt   string
+		params []any
+		want   string
+	}{
+		{
+			desc:   "scalars",
+			stmt:   "SELECT * FROM t WHERE a = $1 AND b = $2 AND c = $3 AND d IS $4",
+			params: []any{"O'Brien", int64(42), 1.5, nil},
+			want:   "SELECT * FROM t WHERE a = 'O''Brien' AND b = 42 AND c = 1.5 AND d IS NULL",
+		},
+		{
+			desc:   "repeated and out of order",
+			stmt:   "SELECT $2, $1, $2",
+			params: []any{true, "x"},
+			want:   "SELECT 'x', TRUE, 'x'",
+		},
+		{
+			desc:   "negative number after minus",
+			stmt:   "SELECT 10-$1",
+			params: []any{-5},
+			want:   "SELECT 10-(-5)",
+		},
+		{
+			desc:   "arrays",
+			stmt:   "SELECT * FROM t WHERE id = ANY($1) AND tag = ANY($2)",
+			params: []any{[]int64{1, 2}, []any{}},
+			want:   "SELECT * FROM t WHERE id = ANY(ARRAY[1, 2]) AND tag = ANY('{}')",
+		},
+		{
+			desc:   "placeholders in literals, identifiers and comments are kept",
+			stmt:   "SELECT '$1', \"$1\", $$ $1 $$, $fn$ $1 $fn$ -- $1\n, $1 /* $1 */",
+			params: []any{"v"},
+			want:   "SELECT '$1', \"$1\", $$ $1 $$, $fn$ $1 $fn$ -- $1\n, 'v' /* $1 */",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := inlineParams(tc.stmt, tc.params, escape)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != tc.want {
+				t.Errorf("incorrect statement: got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestInlineParamsMissingValue(t *testing.T) {
+	_, err := inlineParams("SELECT $2", []any{"a"}, func(s string) (string, error) { return s, nil })
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+}
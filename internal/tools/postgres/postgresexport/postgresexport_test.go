@@ -0,0 +1,123 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgresexport_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/internal/server"
+	"github.com/googleapis/mcp-toolbox/internal/testutils"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/tools/gcsexport"
+	"github.com/googleapis/mcp-toolbox/internal/tools/postgres/postgresexport"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+func TestParseFromYamlPostgresExport(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+            kind: tool
+            name: export_orders
+            type: postgres-export
+            source: my-pg-instance
+            description: some description
+            statement: |
+                SELECT * FROM orders WHERE region = $1;
+            destination:
+                bucket: my-exports
+                prefix: agents/orders
+            format: csv
+            parameters:
+                - name: region
+                  type: string
+                  description: some description
+			`,
+			want: server.ToolConfigs{
+				"export_orders": postgresexport.Config{
+					ConfigBase: tools.ConfigBase{
+						Name:         "export_orders",
+						Description:  "some description",
+						AuthRequired: []string{},
+					},
+					Type:        "postgres-export",
+					Source:      "my-pg-instance",
+					Statement:   "SELECT * FROM orders WHERE region = $1;\n",
+					Destination: gcsexport.Destination{Bucket: "my-exports", Prefix: "agents/orders"},
+					Format:      "csv",
+					Parameters: []parameters.Parameter{
+						parameters.NewStringParameter("region", "some description"),
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			// Parse contents
+			_, _, _, got, _, _, err := server.UnmarshalPrimitiveConfig(ctx, testutils.FormatYaml(tc.in))
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestInitializePostgresExport(t *testing.T) {
+	tcs := []struct {
+		desc    string
+		cfg     postgresexport.Config
+		wantErr bool
+	}{
+		{
+			desc: "format defaults to csv",
+			cfg:  postgresexport.Config{Destination: gcsexport.Destination{Bucket: "b"}},
+		},
+		{
+			desc:    "parquet is not supported",
+			cfg:     postgresexport.Config{Destination: gcsexport.Destination{Bucket: "b"}, Format: "parquet"},
+			wantErr: true,
+		},
+		{
+			desc:    "wildcard prefix",
+			cfg:     postgresexport.Config{Destination: gcsexport.Destination{Bucket: "b", Prefix: "a/*"}},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			tc.cfg.Name = "export"
+			tc.cfg.Description = "some description"
+			_, err := tc.cfg.Initialize(context.Background())
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("unexpected error: got %v, wantErr %t", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgressql
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"sync"
+
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/orderedmap"
+)
+
+// defaultPlanCheckSampleRate is the fraction of invocations that re-check
+// the query plan of a tool unless planCheckSampleRate is set.
+const defaultPlanCheckSampleRate = 0.01
+
+// runFunc runs a statement with its parameters.
+type runFunc func(ctx context.Context, statement string, params []any) (any, error)
+
+// planMonitor keeps the hash of the query plan of a tool. It is shared by
+// the copies of the tool.
+type planMonitor struct {
+	rate   float64
+	sample func() float64
+
+	mu      sync.Mutex
+	hash    string
+	summary string
+}
+
+func newPlanMonitor(rate *float64) *planMonitor {
+	m := &planMonitor{rate: defaultPlanCheckSampleRate, sample: rand.Float64}
+	if rate != nil {
+		m.rate = *rate
+	}
+	return m
+}
+
+// due reports whether the plan should be checked: always until a hash is
+// stored, then for a sample of the invocations.
+func (m *planMonitor) due() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.hash == "" || m.sample() < m.rate
+}
+
+// record stores the hash of plan. If a different hash was stored, it returns
+// the summary of the previous plan and true.
+func (m *planMonitor) record(plan string) (string, bool) {
+	sum := sha256.Sum256([]byte(plan))
+	hash := hex.EncodeToString(sum[:])
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	old, oldSummary := m.hash, m.summary
+	m.hash, m.summary = hash, summarizePlan(plan)
+	return oldSummary, old != "" && old != hash
+}
+
+// reset forgets the stored hash.
+func (m *planMonitor) reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.hash, m.summary = "", ""
+}
+
+// check explains statement and logs a warning if its plan changed. Failing
+// to explain the statement is logged without failing the invocation.
+func (m *planMonitor) check(ctx context.Context, toolName string, run runFunc, statement string, params []any) {
+	if !m.due() {
+		return
+	}
+	logger, err := util.LoggerFromContext(ctx)
+	if err != nil {
+		return
+	}
+	// Costs are left out so that the hash changes with the shape of the
+	// plan only, not with the estimates of the planner.
+	resp, err := run(ctx, "EXPLAIN (COSTS OFF) "+statement, params)
+	if err != nil {
+		logger.DebugContext(ctx, fmt.Sprintf("unable to explain the statement of tool %q: %s", toolName, err))
+		return
+	}
+	plan := planText(resp)
+	if old, changed := m.record(plan); changed {
+		logger.WarnContext(ctx, fmt.Sprintf("query plan of tool %q changed: old plan: %s; new plan: %s", toolName, old, summarizePlan(plan)))
+	}
+}
+
+// planText joins the lines of the result of an EXPLAIN statement.
+func planText(resp any) string {
+	rows, _ := resp.([]any)
+	lines := make([]string, 0, len(rows))
+	for _, r := range rows {
+		row, ok := r.(orderedmap.Row)
+		if !ok || len(row.Columns) == 0 {
+			continue
+		}
+		lines = append(lines, fmt.Sprint(row.Columns[0].Value))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// summarizePlan returns the nodes of a plan on a single line.
+func summarizePlan(plan string) string {
+	var nodes []string
+	for _, line := range strings.Split(plan, "\n") {
+		line = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "->"))
+		if line != "" {
+			nodes = append(nodes, line)
+		}
+	}
+	return strings.Join(nodes, " > ")
+}
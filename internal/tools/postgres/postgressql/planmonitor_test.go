@@ -0,0 +1,95 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgressql
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/googleapis/mcp-toolbox/internal/log"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/orderedmap"
+)
+
+// explainResult returns the rows of an EXPLAIN statement for lines.
+func explainResult(lines ...string) []any {
+	rows := make([]any, 0, len(lines))
+	for _, l := range lines {
+		row := orderedmap.Row{}
+		row.Add("QUERY PLAN", l)
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func TestPlanMonitor(t *testing.T) {
+	var out bytes.Buffer
+	logger, err := log.NewStdLogger(&out, &out, "DEBUG")
+	if err != nil {
+		t.Fatalf("unable to create logger: %s", err)
+	}
+	ctx := util.WithLogger(context.Background(), logger)
+
+	plan := explainResult("Index Scan using users_pkey on users", "  Index Cond: (id = $1)")
+	var explained []string
+	run := func(_ context.Context, statement string, _ []any) (any, error) {
+		explained = append(explained, statement)
+		return plan, nil
+	}
+	m := newPlanMonitor(nil)
+	sample := 1.0
+	m.sample = func() float64 { return sample }
+
+	m.check(ctx, "get_user", run, "SELECT * FROM users WHERE id = $1", []any{1})
+	if len(explained) != 1 || explained[0] != "EXPLAIN (COSTS OFF) SELECT * FROM users WHERE id = $1" {
+		t.Fatalf("expected the first invocation to explain the statement, got %q", explained)
+	}
+	m.check(ctx, "get_user", run, "SELECT * FROM users WHERE id = $1", []any{1})
+	if len(explained) != 1 {
+		t.Fatalf("expected an unsampled invocation not to explain the statement, got %q", explained)
+	}
+
+	sample = 0
+	m.check(ctx, "get_user", run, "SELECT * FROM users WHERE id = $1", []any{1})
+	if len(explained) != 2 || strings.Contains(out.String(), "changed") {
+		t.Fatalf("expected a sampled invocation with the same plan not to warn, got %q: %s", explained, out.String())
+	}
+
+	plan = explainResult("Seq Scan on users", "  Filter: (id = $1)")
+	m.check(ctx, "get_user", run, "SELECT * FROM users WHERE id = $1", []any{1})
+	want := `changed: old plan: Index Scan using users_pkey on users > Index Cond: (id = $1); new plan: Seq Scan on users > Filter: (id = $1)`
+	if !strings.Contains(out.String(), want) {
+		t.Fatalf("expected warning %q, got: %s", want, out.String())
+	}
+
+	out.Reset()
+	m.reset()
+	sample = 1
+	plan = explainResult("Index Scan using users_pkey on users")
+	m.check(ctx, "get_user", run, "SELECT * FROM users WHERE id = $1", []any{1})
+	if len(explained) != 4 || strings.Contains(out.String(), "changed") {
+		t.Fatalf("expected a reset monitor to store a new plan without warning, got %q: %s", explained, out.String())
+	}
+}
+
+func TestSummarizePlan(t *testing.T) {
+	plan := "Hash Join\n  Hash Cond: (o.user_id = u.id)\n  ->  Seq Scan on orders o\n  ->  Hash\n        ->  Seq Scan on users u"
+	want := "Hash Join > Hash Cond: (o.user_id = u.id) > Seq Scan on orders o > Hash > Seq Scan on users u"
+	if got := summarizePlan(plan); got != want {
+		t.Errorf("unexpected summary: got %q, want %q", got, want)
+	}
+}
@@ -16,6 +16,7 @@ package postgressql
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -23,6 +24,7 @@ import (
 	"github.com/googleapis/mcp-toolbox/internal/embeddingmodels"
 	"github.com/googleapis/mcp-toolbox/internal/tools"
 	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/orderedmap"
 	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -30,7 +32,7 @@ import (
 const resourceType string = "postgres-sql"
 
 func init() {
-	if !tools.Register(resourceType, newConfig) {
+	if !tools.Register[compatibleSource](resourceType, newConfig) {
 		panic(fmt.Sprintf("tool type %q already registered", resourceType))
 	}
 }
@@ -48,16 +50,60 @@ type compatibleSource interface {
 	RunSQL(context.Context, string, []any) (any, error)
 }
 
+// multiDatabaseSource is implemented by sources connected to several
+// databases of an instance.
+type multiDatabaseSource interface {
+	RunSQLOnDatabase(context.Context, string, string, []any) (any, error)
+}
+
+// streamingSource is implemented by sources that can pass the rows of a
+// result as they are read.
+type streamingSource interface {
+	StreamSQL(ctx context.Context, statement string, params []any, emit func(orderedmap.Row) error) error
+}
+
+// errMaxRows stops reading a result once it has maxRows rows.
+var errMaxRows = errors.New("maximum number of rows read")
+
 type Config struct {
 	tools.ConfigBase   `yaml:",inline"`
+	tools.ColumnConfig `yaml:",inline"`
 	Type               string                 `yaml:"type" validate:"required"`
 	Source             string                 `yaml:"source" validate:"required"`
-	Statement          string                 `yaml:"statement" validate:"required"`
+	Statement          string                 `yaml:"statement"`
+	QueryType          string                 `yaml:"queryType,omitempty"`
+	Table              string                 `yaml:"table,omitempty"`
+	PrimaryKey         []string               `yaml:"primaryKey,omitempty"`
+	Database           string                 `yaml:"database,omitempty"`
+	Variants           tools.Variants         `yaml:"variants,omitempty"`
 	Parameters         parameters.Parameters  `yaml:"parameters"`
 	TemplateParameters parameters.Parameters  `yaml:"templateParameters"`
 	Annotations        *tools.ToolAnnotations `yaml:"annotations,omitempty"`
+	// ReadOnly rejects the statements that may write, even if the source
+	// is not read-only.
+	ReadOnly bool `yaml:"readOnly"`
+	// MonitorQueryPlan stores the hash of the plan of the statement on the
+	// first invocation, and warns when a sample of later invocations finds
+	// a different plan.
+	MonitorQueryPlan bool `yaml:"monitorQueryPlan,omitempty"`
+	// PlanCheckSampleRate is the fraction of invocations that re-check the
+	// plan. Defaults to 0.01.
+	PlanCheckSampleRate *float64 `yaml:"planCheckSampleRate,omitempty"`
+	// MaxRows caps the rows of a result; the rows past it are not read.
+	// Zero does not cap results.
+	MaxRows int `yaml:"maxRows,omitempty"`
+	// FetchSize is the number of rows of a streamed result sent at once.
+	// Defaults to 100.
+	FetchSize int `yaml:"fetchSize,omitempty"`
+	// PreviewWrites makes dry runs run the statement in a transaction that
+	// is rolled back, and report the rows it returns and affects.
+	PreviewWrites bool `yaml:"previewWrites,omitempty"`
 }
 
+// defaultFetchSize is the number of rows of a streamed result sent at once
+// when the tool does not set fetchSize.
+const defaultFetchSize = 100
+
 var _ tools.ToolConfig = Config{}
 
 func (cfg Config) ToolConfigType() string {
@@ -73,50 +119,325 @@ func (cfg Config) Initialize(context.Context) (tools.Tool, error) {
 		return nil, err
 	}
 
+	statement, err := cfg.statement()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Variants.Validate(cfg.Name); err != nil {
+		return nil, err
+	}
+
+	columns, err := cfg.NewColumnShaper(cfg.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	var plans *planMonitor
+	if rate := cfg.PlanCheckSampleRate; rate != nil {
+		if !cfg.MonitorQueryPlan {
+			return nil, fmt.Errorf("tool %q: planCheckSampleRate requires monitorQueryPlan", cfg.Name)
+		}
+		if *rate < 0 || *rate > 1 {
+			return nil, fmt.Errorf("tool %q: invalid planCheckSampleRate %v: must be between 0 and 1", cfg.Name, *rate)
+		}
+	}
+	if cfg.MonitorQueryPlan {
+		plans = newPlanMonitor(cfg.PlanCheckSampleRate)
+	}
+	if cfg.MaxRows < 0 {
+		return nil, fmt.Errorf("tool %q: invalid maxRows %d: must not be negative", cfg.Name, cfg.MaxRows)
+	}
+	if cfg.FetchSize < 0 {
+		return nil, fmt.Errorf("tool %q: invalid fetchSize %d: must not be negative", cfg.Name, cfg.FetchSize)
+	}
+	if cfg.PreviewWrites && cfg.Database != "" {
+		return nil, fmt.Errorf("tool %q: previewWrites cannot be used with the database field", cfg.Name)
+	}
+
+	defaultAnnotations := tools.NewDestructiveAnnotations
+	if cfg.ReadOnly {
+		defaultAnnotations = tools.NewReadOnlyAnnotations
+	}
 	return Tool{
 		BaseTool: tools.NewBaseTool(
 			cfg,
-			tools.GetAnnotationsOrDefault(cfg.Annotations, tools.NewDestructiveAnnotations),
+			tools.GetAnnotationsOrDefault(cfg.Annotations, defaultAnnotations),
 			tools.Manifest{Description: cfg.Description, Parameters: paramManifest, AuthRequired: cfg.AuthRequired},
 			allParameters,
 		),
+		columns:   columns,
+		statement: statement,
+		plans:     plans,
 	}, nil
 }
 
+// statement returns the statement of the tool, built from its parameters for
+// the upsert query type.
+func (cfg Config) statement() (string, error) {
+	switch cfg.QueryType {
+	case "":
+		if cfg.Statement == "" {
+			return "", fmt.Errorf("statement is required for tool %q", cfg.Name)
+		}
+		return cfg.Statement, nil
+	case queryTypeUpsert:
+		if cfg.Statement != "" {
+			return "", fmt.Errorf("tool %q: statement cannot be set for queryType %q", cfg.Name, queryTypeUpsert)
+		}
+		if len(cfg.TemplateParameters) > 0 || len(cfg.Variants) > 0 {
+			return "", fmt.Errorf("tool %q: templateParameters and variants cannot be set for queryType %q", cfg.Name, queryTypeUpsert)
+		}
+		if cfg.ReadOnly {
+			return "", fmt.Errorf("tool %q: readOnly cannot be set for queryType %q", cfg.Name, queryTypeUpsert)
+		}
+		stmt, err := buildUpsertStatement(cfg.Table, cfg.PrimaryKey, cfg.Parameters)
+		if err != nil {
+			return "", fmt.Errorf("tool %q: %w", cfg.Name, err)
+		}
+		return stmt, nil
+	default:
+		return "", fmt.Errorf("tool %q: invalid queryType %q: must be %q", cfg.Name, cfg.QueryType, queryTypeUpsert)
+	}
+}
+
 var _ tools.Tool = Tool{}
 
 type Tool struct {
 	tools.BaseTool[Config]
+	columns *tools.ColumnShaper
+	// statement is the statement of the configuration, or the one built
+	// for its query type.
+	statement string
+	// plans monitors the query plan of the statement, if enabled.
+	plans *planMonitor
 }
 
 func (t Tool) Invoke(ctx context.Context, primitiveMgr tools.SourceProvider, params parameters.ParamValues, accessToken tools.AccessToken) (any, util.ToolboxError) {
+	q, tbErr := t.prepare(ctx, primitiveMgr, params)
+	if tbErr != nil {
+		return nil, tbErr
+	}
+	resp, err := t.collect(ctx, q)
+	if err != nil {
+		return nil, util.ProcessGeneralError(err)
+	}
+	return t.columns.Apply(ctx, resp), nil
+}
+
+// StreamRows implements tools.RowStreamer, sending the rows of the result in
+// batches of fetchSize rows. The result is buffered if the source cannot
+// stream it.
+func (t Tool) StreamRows(ctx context.Context, primitiveMgr tools.SourceProvider, params parameters.ParamValues, accessToken tools.AccessToken, emit func(rows []any) error) util.ToolboxError {
+	q, tbErr := t.prepare(ctx, primitiveMgr, params)
+	if tbErr != nil {
+		return tbErr
+	}
+	stream, ok := t.streamSource(q)
+	if !ok {
+		resp, err := t.collect(ctx, q)
+		if err != nil {
+			return util.ProcessGeneralError(err)
+		}
+		resp = t.columns.Apply(ctx, resp)
+		rows, ok := resp.([]any)
+		if !ok {
+			rows = []any{resp}
+		}
+		if err := emit(rows); err != nil {
+			return util.ProcessGeneralError(err)
+		}
+		return nil
+	}
+
+	fetchSize := t.Cfg.FetchSize
+	if fetchSize == 0 {
+		fetchSize = defaultFetchSize
+	}
+	batch := make([]any, 0, fetchSize)
+	err := t.streamRows(ctx, stream, q, func(row any) error {
+		batch = append(batch, t.columns.Apply(ctx, []any{row}).([]any)...)
+		if len(batch) < fetchSize {
+			return nil
+		}
+		err := emit(batch)
+		batch = batch[:0]
+		return err
+	})
+	if err == nil && len(batch) > 0 {
+		err = emit(batch)
+	}
+	if err != nil {
+		return util.ProcessGeneralError(err)
+	}
+	return nil
+}
+
+var _ tools.RowStreamer = Tool{}
+
+// DryRun implements tools.DryRunner, explaining the statement of the
+// invocation without running it. With previewWrites, the statement is also
+// run in a transaction that is rolled back.
+func (t Tool) DryRun(ctx context.Context, primitiveMgr tools.SourceProvider, params parameters.ParamValues, accessToken tools.AccessToken) (*tools.DryRunResult, util.ToolboxError) {
+	q, tbErr := t.resolve(ctx, primitiveMgr, params)
+	if tbErr != nil {
+		return nil, tbErr
+	}
+	// EXPLAIN without ANALYZE plans the statement without running it, even
+	// if it writes.
+	resp, err := q.run(ctx, "EXPLAIN (FORMAT JSON) "+q.statement, q.params)
+	if err != nil {
+		return nil, util.ProcessGeneralError(err)
+	}
+	res := &tools.DryRunResult{Statement: q.statement, Params: q.params, Plan: tools.PlanFromRows(resp)}
+	if t.Cfg.PreviewWrites {
+		if res.Preview, err = preview(ctx, q.source.PostgresPool(), q); err != nil {
+			return nil, util.ProcessGeneralError(err)
+		}
+	}
+	return res, nil
+}
+
+var _ tools.DryRunner = Tool{}
+
+// query is a statement of the tool ready to run on its source.
+type query struct {
+	source    compatibleSource
+	statement string
+	params    []any
+	run       runFunc
+}
+
+// streamSource returns the source of q if it can stream the rows of q,
+// which it cannot on a database other than its default one.
+func (t Tool) streamSource(q query) (streamingSource, bool) {
+	stream, ok := q.source.(streamingSource)
+	return stream, ok && t.Cfg.Database == ""
+}
+
+// collect runs q and returns its result, capped at maxRows rows. Streaming
+// the result stops reading it at maxRows, and reports the progress of long
+// reads.
+func (t Tool) collect(ctx context.Context, q query) (any, error) {
+	if stream, ok := t.streamSource(q); ok {
+		rows := []any{}
+		err := t.streamRows(ctx, stream, q, func(row any) error {
+			rows = append(rows, row)
+			return nil
+		})
+		return rows, err
+	}
+	resp, err := q.run(ctx, q.statement, q.params)
+	if rows, ok := resp.([]any); ok && t.Cfg.MaxRows > 0 && len(rows) > t.Cfg.MaxRows {
+		resp = rows[:t.Cfg.MaxRows]
+	}
+	return resp, err
+}
+
+// prepare resolves the statement and parameters of an invocation, and
+// checks its query plan if monitored.
+func (t Tool) prepare(ctx context.Context, primitiveMgr tools.SourceProvider, params parameters.ParamValues) (query, util.ToolboxError) {
+	q, err := t.resolve(ctx, primitiveMgr, params)
+	if err != nil {
+		return query{}, err
+	}
+	if t.plans != nil {
+		t.plans.check(ctx, t.Cfg.Name, q.run, q.statement, q.params)
+	}
+	return q, nil
+}
+
+// resolve resolves the statement and parameters of an invocation.
+func (t Tool) resolve(ctx context.Context, primitiveMgr tools.SourceProvider, params parameters.ParamValues) (query, util.ToolboxError) {
 	source, err := tools.GetCompatibleSource[compatibleSource](primitiveMgr, t.Cfg.Source, t.Cfg.Name, t.Cfg.Type)
 	if err != nil {
-		return nil, util.NewClientServerError("source used is not compatible with the tool", http.StatusInternalServerError, err)
+		return query{}, util.NewClientServerError("source used is not compatible with the tool", http.StatusInternalServerError, err)
+	}
+
+	statement, err := t.Cfg.Variants.Select(ctx, t.statement)
+	if err != nil {
+		return query{}, util.NewAgentError("unable to select a variant", err)
 	}
 
 	paramsMap := params.AsMap()
-	newStatement, err := parameters.ResolveTemplateParams(t.Cfg.TemplateParameters, t.Cfg.Statement, paramsMap)
+	newStatement, err := parameters.ResolveTemplateParams(t.Cfg.TemplateParameters, statement, paramsMap)
 	if err != nil {
-		return nil, util.NewAgentError("unable to extract template params", err)
+		return query{}, util.NewAgentError("unable to extract template params", err)
 	}
 
 	newParams, err := parameters.GetParams(t.Cfg.Parameters, paramsMap)
 	if err != nil {
-		return nil, util.NewAgentError("unable to extract standard params", err)
+		return query{}, util.NewAgentError("unable to extract standard params", err)
 	}
 	sliceParams := newParams.AsSlice()
-	resp, err := source.RunSQL(ctx, newStatement, sliceParams)
-	if err != nil {
-		return nil, util.ProcessGeneralError(err)
+	if err := tools.CheckStatement(source, newStatement); err != nil {
+		return query{}, err
+	}
+	if t.Cfg.ReadOnly {
+		if err := tools.CheckReadOnly(source, t.Cfg.Name, newStatement); err != nil {
+			return query{}, err
+		}
 	}
-	return resp, nil
+	run := runFunc(source.RunSQL)
+	if t.Cfg.Database != "" {
+		mds, ok := source.(multiDatabaseSource)
+		if !ok {
+			return query{}, util.NewClientServerError(fmt.Sprintf("source %q does not support the database field", t.Cfg.Source), http.StatusInternalServerError, nil)
+		}
+		run = func(ctx context.Context, statement string, params []any) (any, error) {
+			return mds.RunSQLOnDatabase(ctx, t.Cfg.Database, statement, params)
+		}
+	}
+	return query{source: source, statement: newStatement, params: sliceParams, run: run}, nil
+}
+
+// streamRows streams the rows of q from source to emit, stopping at maxRows
+// rows and reporting progress every fetchSize rows.
+func (t Tool) streamRows(ctx context.Context, source streamingSource, q query, emit func(row any) error) error {
+	fetchSize := t.Cfg.FetchSize
+	if fetchSize == 0 {
+		fetchSize = defaultFetchSize
+	}
+	// Canceling the query once maxRows rows are read keeps the source from
+	// reading the rest of the result.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	read := 0
+	err := source.StreamSQL(ctx, q.statement, q.params, func(row orderedmap.Row) error {
+		if err := emit(row); err != nil {
+			return err
+		}
+		read++
+		if read%fetchSize == 0 {
+			util.ReportProgress(ctx, float64(read), 0, fmt.Sprintf("read %d rows", read))
+		}
+		if read == t.Cfg.MaxRows {
+			cancel()
+			return errMaxRows
+		}
+		return nil
+	})
+	if errors.Is(err, errMaxRows) {
+		return nil
+	}
+	return err
 }
 
 func (t Tool) EmbedParams(ctx context.Context, paramValues parameters.ParamValues, embeddingModelsMap map[string]embeddingmodels.EmbeddingModel) (parameters.ParamValues, error) {
 	return parameters.EmbedParams(ctx, t.StaticParameters, paramValues, embeddingModelsMap, embeddingmodels.FormatVectorForPgvector)
 }
 
+// ResetPlanHash implements tools.PlanMonitor.
+func (t Tool) ResetPlanHash() bool {
+	if t.plans == nil {
+		return false
+	}
+	t.plans.reset()
+	return true
+}
+
+var _ tools.PlanMonitor = Tool{}
+
 func (t Tool) ToConfig() tools.ToolConfig {
 	return t.Cfg
 }
@@ -15,6 +15,7 @@
 package postgressql_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -76,6 +77,96 @@ func TestParseFromYamlPostgres(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "with database and coercion",
+			in: `
+            kind: tool
+            name: example_tool
+            type: postgres-sql
+            source: my-pg-instance
+            description: some description
+            statement: SELECT 1;
+            database: reporting
+            coercion: lenient
+			`,
+			want: server.ToolConfigs{
+				"example_tool": postgressql.Config{
+					ConfigBase: tools.ConfigBase{
+						Name:         "example_tool",
+						Description:  "some description",
+						AuthRequired: []string{},
+						Coercion:     "lenient",
+					},
+					Type:      "postgres-sql",
+					Source:    "my-pg-instance",
+					Statement: "SELECT 1;",
+					Database:  "reporting",
+				},
+			},
+		},
+		{
+			desc: "with intent",
+			in: `
+            kind: tool
+            name: example_tool
+            type: postgres-sql
+            source: my-pg-instance
+            description: some description
+            statement: UPDATE flights SET status = $1;
+            intent: write
+			`,
+			want: server.ToolConfigs{
+				"example_tool": postgressql.Config{
+					ConfigBase: tools.ConfigBase{
+						Name:         "example_tool",
+						Description:  "some description",
+						AuthRequired: []string{},
+						Intent:       "write",
+					},
+					Type:      "postgres-sql",
+					Source:    "my-pg-instance",
+					Statement: "UPDATE flights SET status = $1;",
+				},
+			},
+		},
+		{
+			desc: "upsert",
+			in: `
+            kind: tool
+            name: example_tool
+            type: postgres-sql
+            source: my-pg-instance
+            description: some description
+            queryType: upsert
+            table: flights
+            primaryKey: [id]
+            parameters:
+                - name: id
+                  type: integer
+                  description: some description
+                - name: status
+                  type: string
+                  description: some description
+			`,
+			want: server.ToolConfigs{
+				"example_tool": postgressql.Config{
+					ConfigBase: tools.ConfigBase{
+						Name:         "example_tool",
+						Description:  "some description",
+						AuthRequired: []string{},
+					},
+					Type:       "postgres-sql",
+					Source:     "my-pg-instance",
+					QueryType:  "upsert",
+					Table:      "flights",
+					PrimaryKey: []string{"id"},
+					Parameters: []parameters.Parameter{
+						parameters.NewIntParameter("id", "some description"),
+						parameters.NewStringParameter("status", "some description"),
+					},
+				},
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -163,3 +254,96 @@ func TestParseFromYamlWithTemplateParamsPostgres(t *testing.T) {
 	}
 
 }
+
+func TestInitializeStatement(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	params := parameters.Parameters{
+		parameters.NewIntParameter("id", "some description"),
+		parameters.NewStringParameter("status", "some description"),
+	}
+	base := tools.ConfigBase{Name: "example_tool", Description: "some description"}
+	rate, invalidRate := 0.1, 2.0
+	tcs := []struct {
+		desc    string
+		cfg     postgressql.Config
+		wantErr string
+	}{
+		{
+			desc: "statement",
+			cfg:  postgressql.Config{ConfigBase: base, Type: "postgres-sql", Source: "s", Statement: "SELECT 1"},
+		},
+		{
+			desc: "upsert",
+			cfg:  postgressql.Config{ConfigBase: base, Type: "postgres-sql", Source: "s", QueryType: "upsert", Table: "flights", PrimaryKey: []string{"id"}, Parameters: params},
+		},
+		{
+			desc:    "missing statement",
+			cfg:     postgressql.Config{ConfigBase: base, Type: "postgres-sql", Source: "s"},
+			wantErr: "statement is required",
+		},
+		{
+			desc:    "invalid query type",
+			cfg:     postgressql.Config{ConfigBase: base, Type: "postgres-sql", Source: "s", QueryType: "merge", Statement: "SELECT 1"},
+			wantErr: `invalid queryType "merge"`,
+		},
+		{
+			desc:    "upsert with statement",
+			cfg:     postgressql.Config{ConfigBase: base, Type: "postgres-sql", Source: "s", QueryType: "upsert", Statement: "SELECT 1", Table: "flights", PrimaryKey: []string{"id"}, Parameters: params},
+			wantErr: "statement cannot be set",
+		},
+		{
+			desc:    "upsert without primary key",
+			cfg:     postgressql.Config{ConfigBase: base, Type: "postgres-sql", Source: "s", QueryType: "upsert", Table: "flights", Parameters: params},
+			wantErr: "primaryKey is required",
+		},
+		{
+			desc: "read-only",
+			cfg:  postgressql.Config{ConfigBase: base, Type: "postgres-sql", Source: "s", Statement: "SELECT 1", ReadOnly: true},
+		},
+		{
+			desc:    "read-only upsert",
+			cfg:     postgressql.Config{ConfigBase: base, Type: "postgres-sql", Source: "s", QueryType: "upsert", Table: "flights", PrimaryKey: []string{"id"}, Parameters: params, ReadOnly: true},
+			wantErr: "readOnly cannot be set",
+		},
+		{
+			desc: "monitor query plan",
+			cfg:  postgressql.Config{ConfigBase: base, Type: "postgres-sql", Source: "s", Statement: "SELECT 1", MonitorQueryPlan: true, PlanCheckSampleRate: &rate},
+		},
+		{
+			desc:    "sample rate without monitoring",
+			cfg:     postgressql.Config{ConfigBase: base, Type: "postgres-sql", Source: "s", Statement: "SELECT 1", PlanCheckSampleRate: &rate},
+			wantErr: "planCheckSampleRate requires monitorQueryPlan",
+		},
+		{
+			desc:    "invalid sample rate",
+			cfg:     postgressql.Config{ConfigBase: base, Type: "postgres-sql", Source: "s", Statement: "SELECT 1", MonitorQueryPlan: true, PlanCheckSampleRate: &invalidRate},
+			wantErr: "invalid planCheckSampleRate 2",
+		},
+		{
+			desc: "preview writes",
+			cfg:  postgressql.Config{ConfigBase: base, Type: "postgres-sql", Source: "s", Statement: "DELETE FROM flights RETURNING *", PreviewWrites: true},
+		},
+		{
+			desc:    "preview writes on another database",
+			cfg:     postgressql.Config{ConfigBase: base, Type: "postgres-sql", Source: "s", Statement: "DELETE FROM flights RETURNING *", Database: "other", PreviewWrites: true},
+			wantErr: "previewWrites cannot be used with the database field",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := tc.cfg.Initialize(ctx)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgressql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/orderedmap"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// errNoPool is returned when previewing a statement on a source without a
+// connection pool.
+var errNoPool = errors.New("source does not support previewing writes")
+
+// preview runs q in a transaction that is always rolled back, returning the
+// rows it returns and the number of rows it affects.
+func preview(ctx context.Context, pool *pgxpool.Pool, q query) (*tools.DryRunPreview, error) {
+	if pool == nil {
+		return nil, errNoPool
+	}
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to begin transaction: %w", err)
+	}
+	// The transaction is never committed, so nothing the statement does is
+	// kept, but its locks are held until the rollback.
+	defer func() { _ = tx.Rollback(context.WithoutCancel(ctx)) }()
+
+	util.RecordStatement(ctx, q.statement, q.params)
+	rows, err := tx.Query(ctx, q.statement, q.params...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	res := &tools.DryRunPreview{Rows: []any{}}
+	fields := rows.FieldDescriptions()
+	for rows.Next() {
+		values, err := rows.Values()
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse row: %w", err)
+		}
+		row := orderedmap.Row{}
+		for i, f := range fields {
+			row.Add(f.Name, values[i])
+		}
+		res.Rows = append(res.Rows, row)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("unable to execute query: %w", err)
+	}
+	res.RowsAffected = rows.CommandTag().RowsAffected()
+	return res, nil
+}
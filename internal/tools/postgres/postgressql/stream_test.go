@@ -0,0 +1,170 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgressql
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/internal/sources"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util/orderedmap"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// rowSource streams rows numbered from 1 to total, counting the rows read.
+type rowSource struct {
+	total int
+	read  int
+}
+
+func (s *rowSource) SourceType() string             { return "postgres" }
+func (s *rowSource) ToConfig() sources.SourceConfig { return nil }
+func (s *rowSource) PostgresPool() *pgxpool.Pool    { return nil }
+
+func (s *rowSource) RunSQL(ctx context.Context, statement string, params []any) (any, error) {
+	rows := []any{}
+	err := s.StreamSQL(ctx, statement, params, func(row orderedmap.Row) error {
+		rows = append(rows, row)
+		return nil
+	})
+	return rows, err
+}
+
+func (s *rowSource) StreamSQL(ctx context.Context, statement string, params []any, emit func(orderedmap.Row) error) error {
+	for i := 1; i <= s.total; i++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		s.read++
+		row := orderedmap.Row{}
+		row.Add("id", i)
+		if err := emit(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type sourceMap map[string]sources.Source
+
+func (m sourceMap) GetSource(name string) (sources.Source, bool) {
+	s, ok := m[name]
+	return s, ok
+}
+
+func newStreamTool(t *testing.T, maxRows, fetchSize int) Tool {
+	t.Helper()
+	cfg := Config{
+		ConfigBase: tools.ConfigBase{Name: "list_ids", Description: "List ids."},
+		Type:       resourceType,
+		Source:     "my-pg",
+		Statement:  "SELECT id FROM items",
+		MaxRows:    maxRows,
+		FetchSize:  fetchSize,
+	}
+	tool, err := cfg.Initialize(context.Background())
+	if err != nil {
+		t.Fatalf("unable to initialize tool: %s", err)
+	}
+	return tool.(Tool)
+}
+
+func ids(rows []any) []int {
+	var res []int
+	for _, row := range rows {
+		res = append(res, row.(orderedmap.Row).Columns[0].Value.(int))
+	}
+	return res
+}
+
+func TestStreamRows(t *testing.T) {
+	src := &rowSource{total: 7}
+	tool := newStreamTool(t, 0, 3)
+	var batches [][]int
+	err := tool.StreamRows(context.Background(), sourceMap{"my-pg": src}, nil, "", func(rows []any) error {
+		batches = append(batches, ids(rows))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := [][]int{{1, 2, 3}, {4, 5, 6}, {7}}
+	if diff := cmp.Diff(want, batches); diff != "" {
+		t.Errorf("unexpected batches (-want +got):\n%s", diff)
+	}
+}
+
+func TestMaxRows(t *testing.T) {
+	src := &rowSource{total: 100}
+	tool := newStreamTool(t, 5, 2)
+	var got []int
+	err := tool.StreamRows(context.Background(), sourceMap{"my-pg": src}, nil, "", func(rows []any) error {
+		got = append(got, ids(rows)...)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff := cmp.Diff([]int{1, 2, 3, 4, 5}, got); diff != "" {
+		t.Errorf("unexpected streamed rows (-want +got):\n%s", diff)
+	}
+	if src.read != 5 {
+		t.Errorf("read %d rows, want 5", src.read)
+	}
+
+	src = &rowSource{total: 100}
+	res, tbErr := tool.Invoke(context.Background(), sourceMap{"my-pg": src}, nil, "")
+	if tbErr != nil {
+		t.Fatalf("unexpected error: %s", tbErr)
+	}
+	if diff := cmp.Diff([]int{1, 2, 3, 4, 5}, ids(res.([]any))); diff != "" {
+		t.Errorf("unexpected invocation rows (-want +got):\n%s", diff)
+	}
+	if src.read != 5 {
+		t.Errorf("read %d rows, want 5", src.read)
+	}
+}
+
+func TestInitializeRowLimits(t *testing.T) {
+	for _, cfg := range []Config{
+		{ConfigBase: tools.ConfigBase{Name: "t", Description: "d"}, Statement: "SELECT 1", MaxRows: -1},
+		{ConfigBase: tools.ConfigBase{Name: "t", Description: "d"}, Statement: "SELECT 1", FetchSize: -1},
+	} {
+		if _, err := cfg.Initialize(context.Background()); err == nil {
+			t.Errorf("expected an error for maxRows %d and fetchSize %d", cfg.MaxRows, cfg.FetchSize)
+		}
+	}
+}
+
+func TestDryRunPreviewWithoutPool(t *testing.T) {
+	cfg := Config{
+		ConfigBase:    tools.ConfigBase{Name: "delete_items", Description: "Delete items."},
+		Type:          resourceType,
+		Source:        "my-pg",
+		Statement:     "DELETE FROM items RETURNING id",
+		PreviewWrites: true,
+	}
+	tool, err := cfg.Initialize(context.Background())
+	if err != nil {
+		t.Fatalf("unable to initialize tool: %s", err)
+	}
+	_, tbErr := tool.(Tool).DryRun(context.Background(), sourceMap{"my-pg": &rowSource{total: 1}}, nil, "")
+	if tbErr == nil || !errors.Is(tbErr, errNoPool) {
+		t.Fatalf("expected %q, got %v", errNoPool, tbErr)
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgressql
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+	"github.com/jackc/pgx/v5"
+)
+
+// queryTypeUpsert builds the statement of a tool from its parameters, each
+// of which is a column of the table, instead of taking a statement.
+const queryTypeUpsert = "upsert"
+
+// buildUpsertStatement returns an INSERT ... ON CONFLICT statement writing
+// the parameters to the columns of the same name of table, and updating the
+// row whose primary key conflicts. The parameters are bound in order.
+func buildUpsertStatement(table string, primaryKey []string, params parameters.Parameters) (string, error) {
+	if table == "" {
+		return "", fmt.Errorf("table is required for queryType %q", queryTypeUpsert)
+	}
+	if len(primaryKey) == 0 {
+		return "", fmt.Errorf("primaryKey is required for queryType %q", queryTypeUpsert)
+	}
+
+	names := make([]string, len(params))
+	columns := make([]string, len(params))
+	placeholders := make([]string, len(params))
+	for i, p := range params {
+		names[i] = p.GetName()
+		columns[i] = pgx.Identifier{p.GetName()}.Sanitize()
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+	}
+
+	keys := make([]string, len(primaryKey))
+	for i, k := range primaryKey {
+		if !slices.Contains(names, k) {
+			return "", fmt.Errorf("primaryKey column %q is not a parameter", k)
+		}
+		keys[i] = pgx.Identifier{k}.Sanitize()
+	}
+
+	var updates []string
+	for i, name := range names {
+		if slices.Contains(primaryKey, name) {
+			continue
+		}
+		updates = append(updates, fmt.Sprintf("%s = EXCLUDED.%s", columns[i], columns[i]))
+	}
+	action := "DO NOTHING"
+	if len(updates) > 0 {
+		action = "DO UPDATE SET " + strings.Join(updates, ", ")
+	}
+
+	return fmt.Sprintf(
+		"INSERT INTO %s (%s) VALUES (%s) ON CONFLICT (%s) %s RETURNING *",
+		pgx.Identifier(strings.Split(table, ".")).Sanitize(),
+		strings.Join(columns, ", "),
+		strings.Join(placeholders, ", "),
+		strings.Join(keys, ", "),
+		action,
+	), nil
+}
@@ -0,0 +1,92 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgressql
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+func TestBuildUpsertStatement(t *testing.T) {
+	params := parameters.Parameters{
+		parameters.NewIntParameter("id", "Flight id."),
+		parameters.NewStringParameter("airline", "Airline code."),
+		parameters.NewStringParameter("status", "Flight status."),
+	}
+	tcs := []struct {
+		desc       string
+		table      string
+		primaryKey []string
+		params     parameters.Parameters
+		want       string
+	}{
+		{
+			desc:       "single key",
+			table:      "flights",
+			primaryKey: []string{"id"},
+			params:     params,
+			want:       `INSERT INTO "flights" ("id", "airline", "status") VALUES ($1, $2, $3) ON CONFLICT ("id") DO UPDATE SET "airline" = EXCLUDED."airline", "status" = EXCLUDED."status" RETURNING *`,
+		},
+		{
+			desc:       "composite key and schema",
+			table:      "ops.flights",
+			primaryKey: []string{"airline", "id"},
+			params:     params,
+			want:       `INSERT INTO "ops"."flights" ("id", "airline", "status") VALUES ($1, $2, $3) ON CONFLICT ("airline", "id") DO UPDATE SET "status" = EXCLUDED."status" RETURNING *`,
+		},
+		{
+			desc:       "only key columns",
+			table:      "flights",
+			primaryKey: []string{"id"},
+			params:     params[:1],
+			want:       `INSERT INTO "flights" ("id") VALUES ($1) ON CONFLICT ("id") DO NOTHING RETURNING *`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := buildUpsertStatement(tc.table, tc.primaryKey, tc.params)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != tc.want {
+				t.Errorf("unexpected statement:\ngot  %s\nwant %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestBuildUpsertStatementErrors(t *testing.T) {
+	params := parameters.Parameters{parameters.NewIntParameter("id", "Flight id.")}
+	tcs := []struct {
+		desc       string
+		table      string
+		primaryKey []string
+		want       string
+	}{
+		{desc: "missing table", primaryKey: []string{"id"}, want: "table is required"},
+		{desc: "missing primary key", table: "flights", want: "primaryKey is required"},
+		{desc: "unknown primary key", table: "flights", primaryKey: []string{"code"}, want: `primaryKey column "code" is not a parameter`},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := buildUpsertStatement(tc.table, tc.primaryKey, params)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("expected error containing %q, got %v", tc.want, err)
+			}
+		})
+	}
+}
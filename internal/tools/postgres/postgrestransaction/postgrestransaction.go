@@ -0,0 +1,218 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgrestransaction
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/orderedmap"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const resourceType string = "postgres-transaction"
+
+// isolationLevels are the isolation levels a tool may set.
+var isolationLevels = []pgx.TxIsoLevel{pgx.ReadUncommitted, pgx.ReadCommitted, pgx.RepeatableRead, pgx.Serializable}
+
+func init() {
+	if !tools.Register[compatibleSource](resourceType, newConfig) {
+		panic(fmt.Sprintf("tool type %q already registered", resourceType))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{ConfigBase: tools.ConfigBase{Name: name}}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type compatibleSource interface {
+	PostgresPool() *pgxpool.Pool
+}
+
+// Statement is a statement of a transaction.
+type Statement struct {
+	Statement string `yaml:"statement" validate:"required"`
+	// Parameters names the parameters of the tool bound to the placeholders
+	// $1, $2, ... of the statement, in order. Defaults to every parameter of
+	// the tool, in the order they are declared.
+	Parameters []string `yaml:"parameters,omitempty"`
+}
+
+type Config struct {
+	tools.ConfigBase `yaml:",inline"`
+	Type             string      `yaml:"type" validate:"required"`
+	Source           string      `yaml:"source" validate:"required"`
+	Statements       []Statement `yaml:"statements" validate:"required,min=1,dive"`
+	// IsolationLevel is the isolation level of the transaction, such as
+	// "serializable". Defaults to the default of the database.
+	IsolationLevel string                `yaml:"isolationLevel,omitempty"`
+	Parameters     parameters.Parameters `yaml:"parameters"`
+	// ReadOnly rejects the statements that may write, even if the source
+	// is not read-only.
+	ReadOnly    bool                   `yaml:"readOnly"`
+	Annotations *tools.ToolAnnotations `yaml:"annotations,omitempty"`
+}
+
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigType() string {
+	return resourceType
+}
+
+func (cfg Config) Initialize(context.Context) (tools.Tool, error) {
+	if cfg.Description == "" {
+		return nil, fmt.Errorf("description is required for tool %q", cfg.Name)
+	}
+	if cfg.IsolationLevel != "" && !slices.Contains(isolationLevels, pgx.TxIsoLevel(cfg.IsolationLevel)) {
+		return nil, fmt.Errorf("tool %q: invalid isolationLevel %q: must be one of %q", cfg.Name, cfg.IsolationLevel, isolationLevels)
+	}
+	allParameters, paramManifest, err := parameters.ProcessParameters(nil, cfg.Parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	bindings := make([][]string, len(cfg.Statements))
+	for i, s := range cfg.Statements {
+		if s.Parameters == nil {
+			for _, p := range cfg.Parameters {
+				bindings[i] = append(bindings[i], p.GetName())
+			}
+			continue
+		}
+		for _, name := range s.Parameters {
+			if !slices.ContainsFunc(cfg.Parameters, func(p parameters.Parameter) bool { return p.GetName() == name }) {
+				return nil, fmt.Errorf("tool %q: statement %d binds unknown parameter %q", cfg.Name, i, name)
+			}
+		}
+		bindings[i] = s.Parameters
+	}
+
+	defaultAnnotations := tools.NewDestructiveAnnotations
+	if cfg.ReadOnly {
+		defaultAnnotations = tools.NewReadOnlyAnnotations
+	}
+	return Tool{
+		BaseTool: tools.NewBaseTool(
+			cfg,
+			tools.GetAnnotationsOrDefault(cfg.Annotations, defaultAnnotations),
+			tools.Manifest{Description: cfg.Description, Parameters: paramManifest, AuthRequired: cfg.AuthRequired},
+			allParameters,
+		),
+		bindings: bindings,
+	}, nil
+}
+
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	tools.BaseTool[Config]
+	// bindings holds the names of the parameters bound to each statement.
+	bindings [][]string
+}
+
+// StatementResult is the result of a statement of a transaction.
+type StatementResult struct {
+	Rows         []any `json:"rows"`
+	RowsAffected int64 `json:"rowsAffected"`
+}
+
+func (t Tool) Invoke(ctx context.Context, primitiveMgr tools.SourceProvider, params parameters.ParamValues, accessToken tools.AccessToken) (any, util.ToolboxError) {
+	source, err := tools.GetCompatibleSource[compatibleSource](primitiveMgr, t.Cfg.Source, t.Cfg.Name, t.Cfg.Type)
+	if err != nil {
+		return nil, util.NewClientServerError("source used is not compatible with the tool", http.StatusInternalServerError, err)
+	}
+	for _, s := range t.Cfg.Statements {
+		if err := tools.CheckStatement(source, s.Statement); err != nil {
+			return nil, err
+		}
+		if t.Cfg.ReadOnly {
+			if err := tools.CheckReadOnly(source, t.Cfg.Name, s.Statement); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	paramsMap := params.AsMap()
+	tx, err := source.PostgresPool().BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.TxIsoLevel(t.Cfg.IsolationLevel)})
+	if err != nil {
+		return nil, util.ProcessGeneralError(fmt.Errorf("unable to begin transaction: %w", err))
+	}
+	// Rolling back a committed transaction does nothing, so this only rolls
+	// back the transaction when a statement or the commit fails.
+	defer func() { _ = tx.Rollback(context.WithoutCancel(ctx)) }()
+
+	results := make([]StatementResult, len(t.Cfg.Statements))
+	for i, s := range t.Cfg.Statements {
+		args := make([]any, len(t.bindings[i]))
+		for j, name := range t.bindings[i] {
+			args[j] = paramsMap[name]
+		}
+		util.RecordStatement(ctx, s.Statement, args)
+		res, err := runStatement(ctx, tx, s.Statement, args)
+		if err != nil {
+			return nil, util.ProcessGeneralError(fmt.Errorf("statement %d: %w", i, err))
+		}
+		results[i] = res
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, util.ProcessGeneralError(fmt.Errorf("unable to commit transaction: %w", err))
+	}
+	return results, nil
+}
+
+// runStatement runs statement in tx, returning the rows it returns and the
+// number of rows it affected.
+func runStatement(ctx context.Context, tx pgx.Tx, statement string, args []any) (StatementResult, error) {
+	rows, err := tx.Query(ctx, statement, args...)
+	if err != nil {
+		return StatementResult{}, fmt.Errorf("unable to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	res := StatementResult{Rows: []any{}}
+	fields := rows.FieldDescriptions()
+	for rows.Next() {
+		values, err := rows.Values()
+		if err != nil {
+			return StatementResult{}, fmt.Errorf("unable to parse row: %w", err)
+		}
+		row := orderedmap.Row{}
+		for i, f := range fields {
+			row.Add(f.Name, values[i])
+		}
+		res.Rows = append(res.Rows, row)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return StatementResult{}, fmt.Errorf("unable to execute query: %w", err)
+	}
+	res.RowsAffected = rows.CommandTag().RowsAffected()
+	return res, nil
+}
+
+func (t Tool) ToConfig() tools.ToolConfig {
+	return t.Cfg
+}
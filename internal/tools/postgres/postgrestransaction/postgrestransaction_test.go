@@ -0,0 +1,155 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgrestransaction_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/internal/server"
+	"github.com/googleapis/mcp-toolbox/internal/testutils"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/tools/postgres/postgrestransaction"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+func TestParseFromYamlPostgresTransaction(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+            kind: tool
+            name: transfer
+            type: postgres-transaction
+            source: my-pg-instance
+            description: some description
+            isolationLevel: serializable
+            statements:
+              - statement: UPDATE accounts SET balance = balance - $1 WHERE id = $2
+                parameters: [amount, from_id]
+              - statement: UPDATE accounts SET balance = balance + $1 WHERE id = $2
+                parameters: [amount, to_id]
+            parameters:
+              - name: amount
+                type: integer
+                description: the amount
+              - name: from_id
+                type: integer
+                description: the debited account
+              - name: to_id
+                type: integer
+                description: the credited account
+			`,
+			want: server.ToolConfigs{
+				"transfer": postgrestransaction.Config{
+					ConfigBase: tools.ConfigBase{
+						Name:         "transfer",
+						Description:  "some description",
+						AuthRequired: []string{},
+					},
+					Type:           "postgres-transaction",
+					Source:         "my-pg-instance",
+					IsolationLevel: "serializable",
+					Statements: []postgrestransaction.Statement{
+						{Statement: "UPDATE accounts SET balance = balance - $1 WHERE id = $2", Parameters: []string{"amount", "from_id"}},
+						{Statement: "UPDATE accounts SET balance = balance + $1 WHERE id = $2", Parameters: []string{"amount", "to_id"}},
+					},
+					Parameters: []parameters.Parameter{
+						parameters.NewIntParameter("amount", "the amount"),
+						parameters.NewIntParameter("from_id", "the debited account"),
+						parameters.NewIntParameter("to_id", "the credited account"),
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			// Parse contents
+			_, _, _, got, _, _, err := server.UnmarshalPrimitiveConfig(ctx, testutils.FormatYaml(tc.in))
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestParseFromYamlPostgresTransactionFailure(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	in := `
+            kind: tool
+            name: transfer
+            type: postgres-transaction
+            source: my-pg-instance
+            description: some description
+            statements: []
+			`
+	_, _, _, _, _, _, err = server.UnmarshalPrimitiveConfig(ctx, testutils.FormatYaml(in))
+	if err == nil {
+		t.Fatalf("expect parsing to fail")
+	}
+}
+
+func TestInitializePostgresTransactionFailure(t *testing.T) {
+	base := postgrestransaction.Config{
+		ConfigBase: tools.ConfigBase{Name: "transfer", Description: "some description"},
+		Type:       "postgres-transaction",
+		Source:     "my-pg-instance",
+		Statements: []postgrestransaction.Statement{{Statement: "DELETE FROM t WHERE id = $1"}},
+		Parameters: parameters.Parameters{parameters.NewIntParameter("id", "the id")},
+	}
+	tcs := []struct {
+		desc   string
+		modify func(*postgrestransaction.Config)
+	}{
+		{
+			desc:   "unknown isolation level",
+			modify: func(cfg *postgrestransaction.Config) { cfg.IsolationLevel = "snapshot" },
+		},
+		{
+			desc: "unknown parameter",
+			modify: func(cfg *postgrestransaction.Config) {
+				cfg.Statements = []postgrestransaction.Statement{{Statement: "DELETE FROM t WHERE id = $1", Parameters: []string{"name"}}}
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := base
+			tc.modify(&cfg)
+			if _, err := cfg.Initialize(context.Background()); err == nil {
+				t.Fatalf("expect initialization to fail")
+			}
+		})
+	}
+	if _, err := base.Initialize(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
@@ -0,0 +1,266 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgresvectorsearch
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/mcp-toolbox/internal/embeddingmodels"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const resourceType string = "postgres-vector-search"
+
+// The distance metrics of pgvector, by the name used in configurations.
+var distanceOperators = map[string]string{
+	"cosine":        "<=>",
+	"l2":            "<->",
+	"inner_product": "<#>",
+	"l1":            "<+>",
+}
+
+// filterOperators are the comparisons a filter can apply.
+var filterOperators = []string{"=", "!=", "<", "<=", ">", ">=", "LIKE", "ILIKE"}
+
+// The names of the parameter holding the searched vector, as an embedding or
+// as the text embedded by the embedding model of the tool.
+const (
+	embeddingParameter = "embedding"
+	queryParameter     = "query"
+)
+
+// defaultLimit is the number of nearest rows returned when the tool does not
+// set limit.
+const defaultLimit = 10
+
+func init() {
+	if !tools.Register[compatibleSource](resourceType, newConfig) {
+		panic(fmt.Sprintf("tool type %q already registered", resourceType))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{ConfigBase: tools.ConfigBase{Name: name}}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type compatibleSource interface {
+	PostgresPool() *pgxpool.Pool
+	RunSQL(context.Context, string, []any) (any, error)
+}
+
+// Filter restricts the searched rows to the ones whose column compares to the
+// value of a parameter. Filters whose parameter has no value are skipped.
+type Filter struct {
+	Column string `yaml:"column" validate:"required"`
+	// Operator is one of =, !=, <, <=, >, >=, LIKE or ILIKE. Defaults to =.
+	Operator  string `yaml:"operator,omitempty"`
+	Parameter string `yaml:"parameter" validate:"required"`
+}
+
+type Config struct {
+	tools.ConfigBase `yaml:",inline"`
+	Type             string `yaml:"type" validate:"required"`
+	Source           string `yaml:"source" validate:"required"`
+	// Table is the searched table, optionally qualified by its schema.
+	Table string `yaml:"table" validate:"required"`
+	// EmbeddingColumn is the vector column of the table.
+	EmbeddingColumn string `yaml:"embeddingColumn" validate:"required"`
+	// Columns are the columns of the table returned for each row.
+	Columns []string `yaml:"columns" validate:"required,min=1"`
+	// Distance is the distance metric, "cosine", "l2", "inner_product" or
+	// "l1". Defaults to "cosine".
+	Distance string `yaml:"distance,omitempty"`
+	// EmbeddingModel embeds a text query parameter into the searched vector.
+	// Without it, the tool takes the vector as an embedding parameter.
+	EmbeddingModel string `yaml:"embeddingModel,omitempty"`
+	// Limit is the number of nearest rows returned. Defaults to 10.
+	Limit int `yaml:"limit,omitempty"`
+	// Filters restrict the searched rows with the parameters.
+	Filters     []Filter               `yaml:"filters,omitempty"`
+	Parameters  parameters.Parameters  `yaml:"parameters"`
+	Annotations *tools.ToolAnnotations `yaml:"annotations,omitempty"`
+}
+
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigType() string {
+	return resourceType
+}
+
+func (cfg Config) Initialize(context.Context) (tools.Tool, error) {
+	if cfg.Description == "" {
+		return nil, fmt.Errorf("description is required for tool %q", cfg.Name)
+	}
+	if cfg.Distance == "" {
+		cfg.Distance = "cosine"
+	}
+	if _, ok := distanceOperators[cfg.Distance]; !ok {
+		return nil, fmt.Errorf("tool %q: invalid distance %q: must be \"cosine\", \"l2\", \"inner_product\" or \"l1\"", cfg.Name, cfg.Distance)
+	}
+	if cfg.Limit < 0 {
+		return nil, fmt.Errorf("tool %q: invalid limit %d: must not be negative", cfg.Name, cfg.Limit)
+	}
+	if cfg.Limit == 0 {
+		cfg.Limit = defaultLimit
+	}
+
+	var vector parameters.Parameter
+	if cfg.EmbeddingModel != "" {
+		query := parameters.NewStringParameter(queryParameter, "The text to search for similar rows.")
+		query.EmbeddedBy = cfg.EmbeddingModel
+		vector = query
+	} else {
+		vector = parameters.NewArrayParameter(embeddingParameter, "The embedding to search for similar rows.", parameters.NewFloatParameter("value", "A dimension of the embedding."))
+	}
+	allParameters, paramManifest, err := parameters.ProcessParameters(nil, slices.Concat(parameters.Parameters{vector}, cfg.Parameters))
+	if err != nil {
+		return nil, fmt.Errorf("tool %q: %w", cfg.Name, err)
+	}
+
+	cfg.Filters = slices.Clone(cfg.Filters)
+	filtered := make(map[string]bool, len(cfg.Filters))
+	for i, f := range cfg.Filters {
+		op := strings.ToUpper(f.Operator)
+		if op == "" {
+			op = "="
+		}
+		if !slices.Contains(filterOperators, op) {
+			return nil, fmt.Errorf("tool %q: invalid operator %q of the filter on %q: must be one of %s", cfg.Name, f.Operator, f.Column, strings.Join(filterOperators, ", "))
+		}
+		cfg.Filters[i].Operator = op
+		if !slices.ContainsFunc(cfg.Parameters, func(p parameters.Parameter) bool { return p.GetName() == f.Parameter }) {
+			return nil, fmt.Errorf("tool %q: the filter on %q uses parameter %q, which is not declared", cfg.Name, f.Column, f.Parameter)
+		}
+		filtered[f.Parameter] = true
+	}
+	for _, p := range cfg.Parameters {
+		if !filtered[p.GetName()] {
+			return nil, fmt.Errorf("tool %q: parameter %q is not used by any filter", cfg.Name, p.GetName())
+		}
+	}
+
+	return Tool{
+		BaseTool: tools.NewBaseTool(
+			cfg,
+			tools.GetAnnotationsOrDefault(cfg.Annotations, tools.NewReadOnlyAnnotations),
+			tools.Manifest{Description: cfg.Description, Parameters: paramManifest, AuthRequired: cfg.AuthRequired},
+			allParameters,
+		),
+	}, nil
+}
+
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	tools.BaseTool[Config]
+}
+
+// quoteIdentifier quotes a possibly schema-qualified identifier.
+func quoteIdentifier(name string) string {
+	return pgx.Identifier(strings.Split(name, ".")).Sanitize()
+}
+
+// buildStatement returns the KNN query of the tool and its arguments, with
+// the filters whose parameter has a value.
+func (t Tool) buildStatement(vector any, paramsMap map[string]any) (string, []any) {
+	cfg := t.Cfg
+	args := []any{vector}
+	distance := fmt.Sprintf("%s %s $1::vector", quoteIdentifier(cfg.EmbeddingColumn), distanceOperators[cfg.Distance])
+
+	columns := make([]string, 0, len(cfg.Columns)+1)
+	for _, c := range cfg.Columns {
+		columns = append(columns, quoteIdentifier(c))
+	}
+	columns = append(columns, distance+" AS distance")
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "SELECT %s FROM %s", strings.Join(columns, ", "), quoteIdentifier(cfg.Table))
+	var conditions []string
+	for _, f := range cfg.Filters {
+		v, ok := paramsMap[f.Parameter]
+		if !ok || v == nil {
+			continue
+		}
+		args = append(args, v)
+		conditions = append(conditions, fmt.Sprintf("%s %s $%d", quoteIdentifier(f.Column), f.Operator, len(args)))
+	}
+	if len(conditions) > 0 {
+		fmt.Fprintf(&sb, " WHERE %s", strings.Join(conditions, " AND "))
+	}
+	fmt.Fprintf(&sb, " ORDER BY %s LIMIT %d", distance, cfg.Limit)
+	return sb.String(), args
+}
+
+// vectorOf returns the searched vector of an invocation as a pgvector
+// literal.
+func (t Tool) vectorOf(paramsMap map[string]any) (any, error) {
+	if t.Cfg.EmbeddingModel != "" {
+		// EmbedParams replaced the text with its embedding.
+		return paramsMap[queryParameter], nil
+	}
+	values, ok := paramsMap[embeddingParameter].([]any)
+	if !ok || len(values) == 0 {
+		return nil, fmt.Errorf("parameter %q must be a non-empty array of numbers", embeddingParameter)
+	}
+	vector := make([]float32, len(values))
+	for i, v := range values {
+		f, ok := v.(float64)
+		if !ok {
+			return nil, fmt.Errorf("parameter %q must be an array of numbers, got %T", embeddingParameter, v)
+		}
+		vector[i] = float32(f)
+	}
+	return embeddingmodels.FormatVectorForPgvector(vector), nil
+}
+
+func (t Tool) Invoke(ctx context.Context, primitiveMgr tools.SourceProvider, params parameters.ParamValues, accessToken tools.AccessToken) (any, util.ToolboxError) {
+	source, err := tools.GetCompatibleSource[compatibleSource](primitiveMgr, t.Cfg.Source, t.Cfg.Name, t.Cfg.Type)
+	if err != nil {
+		return nil, util.NewClientServerError("source used is not compatible with the tool", http.StatusInternalServerError, err)
+	}
+
+	paramsMap := params.AsMap()
+	vector, err := t.vectorOf(paramsMap)
+	if err != nil {
+		return nil, util.NewAgentError(err.Error(), err)
+	}
+	statement, args := t.buildStatement(vector, paramsMap)
+	resp, err := source.RunSQL(ctx, statement, args)
+	if err != nil {
+		return nil, util.ProcessGeneralError(err)
+	}
+	return resp, nil
+}
+
+func (t Tool) EmbedParams(ctx context.Context, paramValues parameters.ParamValues, embeddingModelsMap map[string]embeddingmodels.EmbeddingModel) (parameters.ParamValues, error) {
+	return parameters.EmbedParams(ctx, t.StaticParameters, paramValues, embeddingModelsMap, embeddingmodels.FormatVectorForPgvector)
+}
+
+func (t Tool) ToConfig() tools.ToolConfig {
+	return t.Cfg
+}
@@ -0,0 +1,157 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgresvectorsearch
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+func newTool(t *testing.T, cfg Config) Tool {
+	t.Helper()
+	cfg.Name, cfg.Type, cfg.Source, cfg.Description = "search", resourceType, "my-pg", "Search."
+	tool, err := cfg.Initialize(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return tool.(Tool)
+}
+
+func TestBuildStatement(t *testing.T) {
+	category := parameters.NewStringParameter("category", "The category.", parameters.WithStringRequired(false))
+	price := parameters.NewFloatParameter("max_price", "The maximum price.", parameters.WithFloatRequired(false))
+	tool := newTool(t, Config{
+		Table:           "shop.products",
+		EmbeddingColumn: "embedding",
+		Columns:         []string{"id", "name"},
+		Distance:        "l2",
+		Limit:           5,
+		Filters: []Filter{
+			{Column: "category", Parameter: "category"},
+			{Column: "price", Operator: "<=", Parameter: "max_price"},
+		},
+		Parameters: parameters.Parameters{category, price},
+	})
+
+	tcs := []struct {
+		desc     string
+		params   map[string]any
+		wantStmt string
+		wantArgs []any
+	}{
+		{
+			desc:     "no filter",
+			params:   map[string]any{"category": nil, "max_price": nil},
+			wantStmt: `SELECT "id", "name", "embedding" <-> $1::vector AS distance FROM "shop"."products" ORDER BY "embedding" <-> $1::vector LIMIT 5`,
+			wantArgs: []any{"[1, 2]"},
+		},
+		{
+			desc:     "filters",
+			params:   map[string]any{"category": "books", "max_price": 20.5},
+			wantStmt: `SELECT "id", "name", "embedding" <-> $1::vector AS distance FROM "shop"."products" WHERE "category" = $2 AND "price" <= $3 ORDER BY "embedding" <-> $1::vector LIMIT 5`,
+			wantArgs: []any{"[1, 2]", "books", 20.5},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			tc.params[embeddingParameter] = []any{1.0, 2.0}
+			vector, err := tool.vectorOf(tc.params)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			stmt, args := tool.buildStatement(vector, tc.params)
+			if stmt != tc.wantStmt {
+				t.Errorf("unexpected statement:\n got %s\nwant %s", stmt, tc.wantStmt)
+			}
+			if diff := cmp.Diff(tc.wantArgs, args); diff != "" {
+				t.Errorf("unexpected arguments: diff %s", diff)
+			}
+		})
+	}
+}
+
+func TestInitializeParameters(t *testing.T) {
+	names := func(tool Tool) []string {
+		var out []string
+		for _, p := range tool.StaticParameters {
+			out = append(out, p.GetName())
+		}
+		return out
+	}
+	embedding := newTool(t, Config{Table: "docs", EmbeddingColumn: "embedding", Columns: []string{"id"}})
+	if got := names(embedding); !cmp.Equal(got, []string{embeddingParameter}) {
+		t.Errorf("unexpected parameters: %v", got)
+	}
+	text := newTool(t, Config{Table: "docs", EmbeddingColumn: "embedding", Columns: []string{"id"}, EmbeddingModel: "my-model"})
+	if got := names(text); !cmp.Equal(got, []string{queryParameter}) {
+		t.Errorf("unexpected parameters: %v", got)
+	}
+	if got := text.StaticParameters[0].GetEmbeddedBy(); got != "my-model" {
+		t.Errorf("expected the query to be embedded by my-model, got %q", got)
+	}
+	if a := text.GetAnnotations(); a == nil || a.ReadOnlyHint == nil || !*a.ReadOnlyHint {
+		t.Errorf("expected the tool to be read-only, got %+v", a)
+	}
+}
+
+func TestInitializeErrors(t *testing.T) {
+	base := func() Config {
+		return Config{
+			ConfigBase:      tools.ConfigBase{Name: "search", Description: "Search."},
+			Type:            resourceType,
+			Source:          "my-pg",
+			Table:           "docs",
+			EmbeddingColumn: "embedding",
+			Columns:         []string{"id"},
+		}
+	}
+	lang := parameters.NewStringParameter("lang", "The language.")
+	tcs := []struct {
+		desc   string
+		modify func(*Config)
+		err    string
+	}{
+		{desc: "invalid distance", modify: func(c *Config) { c.Distance = "hamming" }, err: `invalid distance "hamming"`},
+		{desc: "invalid operator", modify: func(c *Config) {
+			c.Parameters = parameters.Parameters{lang}
+			c.Filters = []Filter{{Column: "lang", Operator: "~", Parameter: "lang"}}
+		}, err: `invalid operator "~"`},
+		{desc: "undeclared parameter", modify: func(c *Config) {
+			c.Filters = []Filter{{Column: "lang", Parameter: "lang"}}
+		}, err: `uses parameter "lang", which is not declared`},
+		{desc: "unused parameter", modify: func(c *Config) {
+			c.Parameters = parameters.Parameters{lang}
+		}, err: `parameter "lang" is not used by any filter`},
+		{desc: "reserved parameter", modify: func(c *Config) {
+			c.Parameters = parameters.Parameters{parameters.NewStringParameter(embeddingParameter, "")}
+			c.Filters = []Filter{{Column: "lang", Parameter: embeddingParameter}}
+		}, err: "Duplicate parameter: embedding"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := base()
+			tc.modify(&cfg)
+			_, err := cfg.Initialize(context.Background())
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("expected error containing %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
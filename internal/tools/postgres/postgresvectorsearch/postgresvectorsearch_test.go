@@ -0,0 +1,134 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgresvectorsearch_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/internal/server"
+	"github.com/googleapis/mcp-toolbox/internal/testutils"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/tools/postgres/postgresvectorsearch"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+func TestParseFromYamlPostgresVectorSearch(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+            kind: tool
+            name: search_products
+            type: postgres-vector-search
+            source: my-pg-instance
+            description: Search for similar products.
+            table: products
+            embeddingColumn: embedding
+            columns:
+                - id
+                - name
+			`,
+			want: server.ToolConfigs{
+				"search_products": postgresvectorsearch.Config{
+					ConfigBase: tools.ConfigBase{
+						Name:         "search_products",
+						Description:  "Search for similar products.",
+						AuthRequired: []string{},
+					},
+					Type:            "postgres-vector-search",
+					Source:          "my-pg-instance",
+					Table:           "products",
+					EmbeddingColumn: "embedding",
+					Columns:         []string{"id", "name"},
+				},
+			},
+		},
+		{
+			desc: "with embedding model and filters",
+			in: `
+            kind: tool
+            name: search_products
+            type: postgres-vector-search
+            source: my-pg-instance
+            description: Search for similar products.
+            table: shop.products
+            embeddingColumn: embedding
+            columns:
+                - id
+            distance: inner_product
+            embeddingModel: my-model
+            limit: 5
+            filters:
+                - column: category
+                  parameter: category
+                - column: price
+                  operator: "<="
+                  parameter: max_price
+            parameters:
+                - name: category
+                  type: string
+                  description: The category of the products.
+                - name: max_price
+                  type: float
+                  description: The maximum price of the products.
+			`,
+			want: server.ToolConfigs{
+				"search_products": postgresvectorsearch.Config{
+					ConfigBase: tools.ConfigBase{
+						Name:         "search_products",
+						Description:  "Search for similar products.",
+						AuthRequired: []string{},
+					},
+					Type:            "postgres-vector-search",
+					Source:          "my-pg-instance",
+					Table:           "shop.products",
+					EmbeddingColumn: "embedding",
+					Columns:         []string{"id"},
+					Distance:        "inner_product",
+					EmbeddingModel:  "my-model",
+					Limit:           5,
+					Filters: []postgresvectorsearch.Filter{
+						{Column: "category", Parameter: "category"},
+						{Column: "price", Operator: "<=", Parameter: "max_price"},
+					},
+					Parameters: parameters.Parameters{
+						parameters.NewStringParameter("category", "The category of the products."),
+						parameters.NewFloatParameter("max_price", "The maximum price of the products."),
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, _, _, got, _, _, err := server.UnmarshalPrimitiveConfig(ctx, testutils.FormatYaml(tc.in))
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
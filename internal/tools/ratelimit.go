@@ -0,0 +1,223 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+	"golang.org/x/time/rate"
+)
+
+// RateLimit caps the invocations of a tool. Invocations over a limit are
+// rejected with a RateLimitedError rather than queued.
+type RateLimit struct {
+	// RequestsPerMinute is the number of invocations allowed per minute.
+	RequestsPerMinute int `yaml:"requestsPerMinute,omitempty" validate:"omitempty,gt=0"`
+	// Burst is the number of invocations allowed at once before they are
+	// paced at RequestsPerMinute. Defaults to 1.
+	Burst int `yaml:"burst,omitempty" validate:"omitempty,gt=0"`
+	// MaxConcurrency is the number of invocations allowed to run at once.
+	MaxConcurrency int `yaml:"maxConcurrency,omitempty" validate:"omitempty,gt=0"`
+}
+
+// withDefaults returns the limits with the ones it doesn't set taken from
+// defaults.
+func (l RateLimit) withDefaults(defaults RateLimit) RateLimit {
+	if l.RequestsPerMinute == 0 {
+		l.RequestsPerMinute = defaults.RequestsPerMinute
+		if l.Burst == 0 {
+			l.Burst = defaults.Burst
+		}
+	}
+	if l.MaxConcurrency == 0 {
+		l.MaxConcurrency = defaults.MaxConcurrency
+	}
+	return l
+}
+
+// ErrorCodeRateLimited is the error code reported to clients whose
+// invocation was rejected by the limits of a tool, so that they can retry it
+// later.
+const ErrorCodeRateLimited = "rate_limited"
+
+// Limits exceeded by rejected invocations.
+const (
+	LimitRequestsPerMinute = "requestsPerMinute"
+	LimitMaxConcurrency    = "maxConcurrency"
+)
+
+// RateLimitedError is returned by the invocations of a tool rejected by its
+// rate limit or its concurrency cap. It is retryable.
+type RateLimitedError struct {
+	Tool string
+	// Limit is the exceeded limit, LimitRequestsPerMinute or
+	// LimitMaxConcurrency.
+	Limit string
+	// RetryAfter is when the invocation may be retried. It is an estimate
+	// for the concurrency cap, which depends on running invocations.
+	RetryAfter time.Duration
+}
+
+var _ util.ToolboxError = &RateLimitedError{}
+
+func (e *RateLimitedError) Error() string {
+	return fmt.Sprintf("tool %q exceeded its %s limit, retry in %ds", e.Tool, e.Limit, e.RetryAfterSeconds())
+}
+
+func (e *RateLimitedError) Category() util.ErrorCategory { return util.CategoryServer }
+
+func (e *RateLimitedError) Unwrap() error { return nil }
+
+// RetryAfterSeconds returns RetryAfter rounded up to whole seconds, as in
+// the Retry-After header.
+func (e *RateLimitedError) RetryAfterSeconds() int {
+	return max(1, int(math.Ceil(e.RetryAfter.Seconds())))
+}
+
+// Data returns the details of the error reported to clients.
+func (e *RateLimitedError) Data() map[string]any {
+	return map[string]any{
+		"code":              ErrorCodeRateLimited,
+		"tool":              e.Tool,
+		"limit":             e.Limit,
+		"retryable":         true,
+		"retryAfterSeconds": e.RetryAfterSeconds(),
+	}
+}
+
+// concurrencyRetryAfter is the retry delay suggested to invocations rejected
+// by a concurrency cap.
+const concurrencyRetryAfter = time.Second
+
+// invocationLimiter enforces the limits of a tool.
+type invocationLimiter struct {
+	tool string
+	// rate is nil without a rate limit.
+	rate *rate.Limiter
+	// mu guards running.
+	mu             sync.Mutex
+	running        int
+	maxConcurrency int
+}
+
+func newInvocationLimiter(tool string, l RateLimit) *invocationLimiter {
+	limiter := &invocationLimiter{tool: tool, maxConcurrency: l.MaxConcurrency}
+	if l.RequestsPerMinute > 0 {
+		limiter.rate = rate.NewLimiter(rate.Limit(float64(l.RequestsPerMinute)/60), max(1, l.Burst))
+	}
+	return limiter
+}
+
+// acquire admits an invocation at now, or rejects it if it exceeds a limit.
+// The returned function must be called once the invocation is done.
+func (l *invocationLimiter) acquire(now time.Time) (func(), *RateLimitedError) {
+	if l.maxConcurrency > 0 {
+		l.mu.Lock()
+		if l.running >= l.maxConcurrency {
+			l.mu.Unlock()
+			return nil, &RateLimitedError{Tool: l.tool, Limit: LimitMaxConcurrency, RetryAfter: concurrencyRetryAfter}
+		}
+		l.running++
+		l.mu.Unlock()
+	}
+	release := func() {
+		if l.maxConcurrency > 0 {
+			l.mu.Lock()
+			l.running--
+			l.mu.Unlock()
+		}
+	}
+	if l.rate != nil {
+		r := l.rate.ReserveN(now, 1)
+		if delay := r.DelayFrom(now); delay > 0 {
+			r.CancelAt(now)
+			release()
+			return nil, &RateLimitedError{Tool: l.tool, Limit: LimitRequestsPerMinute, RetryAfter: delay}
+		}
+	}
+	return release, nil
+}
+
+// rateLimitOf returns the rate limit of a tool config, or nil if it has none.
+func rateLimitOf(cfg ToolConfig) *RateLimit {
+	if c, ok := cfg.(interface{ GetRateLimit() *RateLimit }); ok {
+		return c.GetRateLimit()
+	}
+	return nil
+}
+
+// WrapRateLimits returns the tools with every tool that has a rate limit or
+// a concurrency cap, of its own or from defaults, rejecting the invocations
+// exceeding them.
+func WrapRateLimits(toolsMap map[string]Tool, defaults RateLimit) map[string]Tool {
+	wrapped := make(map[string]Tool, len(toolsMap))
+	for name, t := range toolsMap {
+		var limits RateLimit
+		if l := rateLimitOf(t.ToConfig()); l != nil {
+			limits = *l
+		}
+		limits = limits.withDefaults(defaults)
+		if limits.RequestsPerMinute <= 0 && limits.MaxConcurrency <= 0 {
+			wrapped[name] = t
+			continue
+		}
+		limited := rateLimitedTool{Tool: t, limiter: newInvocationLimiter(name, limits)}
+		if streamer, ok := t.(RowStreamer); ok {
+			wrapped[name] = rateLimitedStreamer{rateLimitedTool: limited, streamer: streamer}
+			continue
+		}
+		wrapped[name] = limited
+	}
+	return wrapped
+}
+
+// rateLimitedTool is a tool whose invocations are limited.
+type rateLimitedTool struct {
+	Tool
+	limiter *invocationLimiter
+}
+
+// Unwrap implements Wrapper.
+func (t rateLimitedTool) Unwrap() Tool { return t.Tool }
+
+func (t rateLimitedTool) Invoke(ctx context.Context, sp SourceProvider, params parameters.ParamValues, token AccessToken) (any, util.ToolboxError) {
+	release, err := t.limiter.acquire(time.Now())
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return t.Tool.Invoke(ctx, sp, params, token)
+}
+
+// rateLimitedStreamer is a rate limited tool that streams its rows.
+type rateLimitedStreamer struct {
+	rateLimitedTool
+	streamer RowStreamer
+}
+
+func (t rateLimitedStreamer) StreamRows(ctx context.Context, sp SourceProvider, params parameters.ParamValues, token AccessToken, emit func(rows []any) error) util.ToolboxError {
+	release, err := t.limiter.acquire(time.Now())
+	if err != nil {
+		return err
+	}
+	defer release()
+	return t.streamer.StreamRows(ctx, sp, params, token, emit)
+}
@@ -0,0 +1,134 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestInvocationLimiterRate(t *testing.T) {
+	l := newInvocationLimiter("my-tool", RateLimit{RequestsPerMinute: 60, Burst: 2})
+	now := time.Now()
+
+	for i := 0; i < 2; i++ {
+		release, err := l.acquire(now)
+		if err != nil {
+			t.Fatalf("invocation %d within the burst was rejected: %v", i, err)
+		}
+		release()
+	}
+	_, err := l.acquire(now)
+	if err == nil {
+		t.Fatalf("invocation past the burst was admitted")
+	}
+	if err.Limit != LimitRequestsPerMinute {
+		t.Errorf("got limit %q, want %q", err.Limit, LimitRequestsPerMinute)
+	}
+	if got := err.RetryAfterSeconds(); got != 1 {
+		t.Errorf("got retry after %ds, want 1s", got)
+	}
+
+	// a rejected invocation doesn't consume the next token
+	if _, err := l.acquire(now.Add(time.Second)); err != nil {
+		t.Fatalf("invocation after a second was rejected: %v", err)
+	}
+}
+
+func TestInvocationLimiterConcurrency(t *testing.T) {
+	l := newInvocationLimiter("my-tool", RateLimit{MaxConcurrency: 2})
+	now := time.Now()
+
+	first, err := l.acquire(now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := l.acquire(now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, err = l.acquire(now)
+	if err == nil {
+		t.Fatalf("invocation past the concurrency cap was admitted")
+	}
+	if err.Limit != LimitMaxConcurrency {
+		t.Errorf("got limit %q, want %q", err.Limit, LimitMaxConcurrency)
+	}
+
+	first()
+	if _, err := l.acquire(now); err != nil {
+		t.Fatalf("invocation after a release was rejected: %v", err)
+	}
+}
+
+func TestInvocationLimiterRateRejectionReleasesSlot(t *testing.T) {
+	l := newInvocationLimiter("my-tool", RateLimit{RequestsPerMinute: 1, MaxConcurrency: 1})
+	now := time.Now()
+
+	release, err := l.acquire(now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	release()
+	if _, err := l.acquire(now); err == nil || err.Limit != LimitRequestsPerMinute {
+		t.Fatalf("got %v, want a %s error", err, LimitRequestsPerMinute)
+	}
+	if _, err := l.acquire(now.Add(time.Minute)); err != nil {
+		t.Fatalf("slot of the rejected invocation was not released: %v", err)
+	}
+}
+
+func TestRateLimitWithDefaults(t *testing.T) {
+	defaults := RateLimit{RequestsPerMinute: 100, Burst: 10, MaxConcurrency: 4}
+	tcs := []struct {
+		desc   string
+		limits RateLimit
+		want   RateLimit
+	}{
+		{
+			desc: "no limits of its own",
+			want: defaults,
+		},
+		{
+			desc:   "own rate",
+			limits: RateLimit{RequestsPerMinute: 5},
+			want:   RateLimit{RequestsPerMinute: 5, MaxConcurrency: 4},
+		},
+		{
+			desc:   "own concurrency",
+			limits: RateLimit{MaxConcurrency: 1},
+			want:   RateLimit{RequestsPerMinute: 100, Burst: 10, MaxConcurrency: 1},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := tc.limits.withDefaults(defaults); got != tc.want {
+				t.Fatalf("got %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRateLimitedErrorData(t *testing.T) {
+	var err error = &RateLimitedError{Tool: "my-tool", Limit: LimitRequestsPerMinute, RetryAfter: 1500 * time.Millisecond}
+	var limitErr *RateLimitedError
+	if !errors.As(err, &limitErr) {
+		t.Fatalf("error is not a RateLimitedError")
+	}
+	data := limitErr.Data()
+	if data["code"] != ErrorCodeRateLimited || data["retryable"] != true || data["retryAfterSeconds"] != 2 {
+		t.Fatalf("unexpected data: %v", data)
+	}
+}
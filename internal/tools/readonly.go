@@ -0,0 +1,75 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+// isReadOnly reports whether a tool is annotated as read-only.
+func isReadOnly(t Tool) bool {
+	a := t.GetAnnotations()
+	return a != nil && a.ReadOnlyHint != nil && *a.ReadOnlyHint
+}
+
+// MarkReadOnly returns the tools with every tool annotated as read-only
+// marking the context of its invocations with util.WithReadOnlyInvocation,
+// so that sources with read replicas can route them away from the primary.
+func MarkReadOnly(toolsMap map[string]Tool) map[string]Tool {
+	wrapped := make(map[string]Tool, len(toolsMap))
+	for name, t := range toolsMap {
+		if !isReadOnly(t) {
+			wrapped[name] = t
+			continue
+		}
+		marked := readOnlyTool{Tool: t}
+		if streamer, ok := t.(RowStreamer); ok {
+			wrapped[name] = readOnlyStreamer{readOnlyTool: marked, streamer: streamer}
+			continue
+		}
+		wrapped[name] = marked
+	}
+	return wrapped
+}
+
+// readOnlyTool is a tool whose invocations are marked as read-only.
+type readOnlyTool struct {
+	Tool
+}
+
+func (t readOnlyTool) Invoke(ctx context.Context, sp SourceProvider, params parameters.ParamValues, token AccessToken) (any, util.ToolboxError) {
+	return t.Tool.Invoke(util.WithReadOnlyInvocation(ctx), sp, params, token)
+}
+
+// Unwrap implements Wrapper.
+func (t readOnlyTool) Unwrap() Tool { return t.Tool }
+
+// DryRun implements DryRunner.
+func (t readOnlyTool) DryRun(ctx context.Context, sp SourceProvider, params parameters.ParamValues, token AccessToken) (*DryRunResult, util.ToolboxError) {
+	return DryRun(util.WithReadOnlyInvocation(ctx), t.Tool, sp, params, token)
+}
+
+// readOnlyStreamer is a read-only tool that streams its rows.
+type readOnlyStreamer struct {
+	readOnlyTool
+	streamer RowStreamer
+}
+
+func (t readOnlyStreamer) StreamRows(ctx context.Context, sp SourceProvider, params parameters.ParamValues, token AccessToken, emit func(rows []any) error) util.ToolboxError {
+	return t.streamer.StreamRows(util.WithReadOnlyInvocation(ctx), sp, params, token, emit)
+}
@@ -0,0 +1,55 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+// readOnlyProbe returns whether its invocation was marked as read-only.
+type readOnlyProbe struct {
+	tools.BaseTool[sleepConfig]
+}
+
+func (t readOnlyProbe) Invoke(ctx context.Context, _ tools.SourceProvider, _ parameters.ParamValues, _ tools.AccessToken) (any, util.ToolboxError) {
+	return util.ReadOnlyInvocationFromContext(ctx), nil
+}
+
+func (t readOnlyProbe) ToConfig() tools.ToolConfig { return t.Cfg }
+
+func TestMarkReadOnly(t *testing.T) {
+	probe := func(annotations *tools.ToolAnnotations) tools.Tool {
+		return readOnlyProbe{tools.NewBaseTool(sleepConfig{}, annotations, tools.Manifest{}, nil)}
+	}
+	wrapped := tools.MarkReadOnly(map[string]tools.Tool{
+		"read":    probe(tools.NewReadOnlyAnnotations()),
+		"write":   probe(tools.NewDestructiveAnnotations()),
+		"unknown": probe(nil),
+	})
+	for name, want := range map[string]bool{"read": true, "write": false, "unknown": false} {
+		got, err := wrapped[name].Invoke(context.Background(), nil, nil, "")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if got != want {
+			t.Errorf("tool %q: got read-only %v, want %v", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisset
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+const resourceType string = "redis-set"
+
+func init() {
+	if !tools.Register[compatibleSource](resourceType, newConfig) {
+		panic(fmt.Sprintf("tool type %q already registered", resourceType))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{ConfigBase: tools.ConfigBase{Name: name}}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type compatibleSource interface {
+	SetKey(ctx context.Context, key, value string, ttl time.Duration) error
+}
+
+type Config struct {
+	tools.ConfigBase `yaml:",inline"`
+	Type             string `yaml:"type" validate:"required"`
+	Source           string `yaml:"source" validate:"required"`
+	// KeyPrefix is prepended to the key passed by the agent, restricting the
+	// tool to the keys under it.
+	KeyPrefix string `yaml:"keyPrefix"`
+	// TTL is how long the keys set by the tool live, such as "1h". Empty
+	// keeps them until they are deleted.
+	TTL         string                 `yaml:"ttl"`
+	Annotations *tools.ToolAnnotations `yaml:"annotations,omitempty"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigType() string {
+	return resourceType
+}
+
+func (cfg Config) Initialize(context.Context) (tools.Tool, error) {
+	var ttl time.Duration
+	if cfg.TTL != "" {
+		var err error
+		ttl, err = time.ParseDuration(cfg.TTL)
+		if err != nil || ttl <= 0 {
+			return nil, fmt.Errorf("invalid ttl %q for tool %q: must be a positive duration", cfg.TTL, cfg.Name)
+		}
+	}
+
+	keyDesc := "The key to set."
+	if cfg.KeyPrefix != "" {
+		keyDesc = fmt.Sprintf("The key to set, without its %q prefix.", cfg.KeyPrefix)
+	}
+	allParameters := parameters.Parameters{
+		parameters.NewStringParameter("key", keyDesc),
+		parameters.NewStringParameter("value", "The string value to set the key to."),
+	}
+
+	if cfg.Description == "" {
+		cfg.Description = "Sets a key of Redis to a string value, replacing its previous value."
+	}
+
+	return Tool{
+		BaseTool: tools.NewBaseTool(
+			cfg,
+			tools.GetAnnotationsOrDefault(cfg.Annotations, tools.NewDestructiveAnnotations),
+			tools.Manifest{Description: cfg.Description, Parameters: allParameters.Manifest(), AuthRequired: cfg.AuthRequired},
+			allParameters,
+		),
+		ttl: ttl,
+	}, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	tools.BaseTool[Config]
+	ttl time.Duration
+}
+
+func (t Tool) ToConfig() tools.ToolConfig {
+	return t.Cfg
+}
+
+func (t Tool) Invoke(ctx context.Context, primitiveMgr tools.SourceProvider, params parameters.ParamValues, accessToken tools.AccessToken) (any, util.ToolboxError) {
+	source, err := tools.GetCompatibleSource[compatibleSource](primitiveMgr, t.Cfg.Source, t.Cfg.Name, t.Cfg.Type)
+	if err != nil {
+		return nil, util.NewClientServerError("source used is not compatible with the tool", http.StatusInternalServerError, err)
+	}
+
+	paramsMap := params.AsMap()
+	key, ok := paramsMap["key"].(string)
+	if !ok || key == "" {
+		return nil, util.NewAgentError("invalid or missing 'key' parameter; expected a non-empty string", nil)
+	}
+	value, ok := paramsMap["value"].(string)
+	if !ok {
+		return nil, util.NewAgentError("invalid or missing 'value' parameter; expected a string", nil)
+	}
+	if err := source.SetKey(ctx, t.Cfg.KeyPrefix+key, value, t.ttl); err != nil {
+		return nil, util.ProcessGeneralError(err)
+	}
+	return "OK", nil
+}
@@ -0,0 +1,84 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisset_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/internal/server"
+	"github.com/googleapis/mcp-toolbox/internal/testutils"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/tools/redis/redisset"
+)
+
+func TestParseFromYamlRedisSet(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			kind: tool
+			name: save_session_note
+			type: redis-set
+			source: my-redis-instance
+			description: Saves a note in the session state.
+			keyPrefix: "session:notes:"
+			ttl: 1h
+			`,
+			want: server.ToolConfigs{
+				"save_session_note": redisset.Config{
+					ConfigBase: tools.ConfigBase{
+						Name:         "save_session_note",
+						Description:  "Saves a note in the session state.",
+						AuthRequired: []string{},
+					},
+					Type:      "redis-set",
+					Source:    "my-redis-instance",
+					KeyPrefix: "session:notes:",
+					TTL:       "1h",
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, _, _, got, _, _, err := server.UnmarshalPrimitiveConfig(ctx, testutils.FormatYaml(tc.in))
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestInitializeInvalidTTL(t *testing.T) {
+	cfg := redisset.Config{ConfigBase: tools.ConfigBase{Name: "save_session_note"}, Type: "redis-set", Source: "my-redis-instance", TTL: "soon"}
+	_, err := cfg.Initialize(context.Background())
+	if err == nil || !strings.Contains(err.Error(), `invalid ttl "soon"`) {
+		t.Fatalf("expected an invalid ttl error, got %v", err)
+	}
+}
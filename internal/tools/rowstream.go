@@ -0,0 +1,50 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+// RowStreamer is implemented by tools that can stream the rows of their
+// result as they are read from the source, rather than buffering the whole
+// result.
+type RowStreamer interface {
+	// StreamRows runs the tool like Invoke, passing the rows of its result
+	// to emit in batches. It stops at the first error emit returns.
+	StreamRows(ctx context.Context, sourceProvider SourceProvider, params parameters.ParamValues, accessToken AccessToken, emit func(rows []any) error) util.ToolboxError
+}
+
+// CountRows returns the number of rows of the result of an invocation, which
+// is the length of a list result and 0 otherwise.
+func CountRows(result any) int {
+	if result == nil {
+		return 0
+	}
+	v := reflect.ValueOf(result)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			// a []byte or json.RawMessage is a single value
+			return 0
+		}
+		return v.Len()
+	}
+	return 0
+}
@@ -0,0 +1,41 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestCountRows(t *testing.T) {
+	tcs := []struct {
+		desc   string
+		result any
+		want   int
+	}{
+		{desc: "nil", result: nil, want: 0},
+		{desc: "rows", result: []any{map[string]any{"id": 1}, map[string]any{"id": 2}}, want: 2},
+		{desc: "typed rows", result: []map[string]any{{"id": 1}}, want: 1},
+		{desc: "object", result: map[string]any{"status": "ok"}, want: 0},
+		{desc: "raw json", result: json.RawMessage(`[1, 2, 3]`), want: 0},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := CountRows(tc.result); got != tc.want {
+				t.Errorf("unexpected row count: got %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
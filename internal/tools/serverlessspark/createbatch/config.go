@@ -35,7 +35,9 @@ func unmarshalProto(data any, m proto.Message) error {
 	return protojson.Unmarshal(jsonData, m)
 }
 
-type compatibleSource interface {
+// CompatibleSource is the interface the source of a create batch tool must
+// implement.
+type CompatibleSource interface {
 	CreateBatch(context.Context, *dataprocpb.Batch) (map[string]any, error)
 }
 
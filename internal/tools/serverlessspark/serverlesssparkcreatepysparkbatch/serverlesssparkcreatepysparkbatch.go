@@ -28,7 +28,7 @@ import (
 const resourceType = "serverless-spark-create-pyspark-batch"
 
 func init() {
-	if !tools.Register(resourceType, newConfig) {
+	if !tools.Register[createbatch.CompatibleSource](resourceType, newConfig) {
 		panic(fmt.Sprintf("tool type %q already registered", resourceType))
 	}
 }
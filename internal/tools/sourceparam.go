@@ -0,0 +1,203 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/googleapis/mcp-toolbox/internal/sources"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+// SourceParameter lets the invocations of a tool select the source it runs
+// against, among an allow-list of sources of the same type as its own, such
+// as the dev and prod clusters of a database.
+type SourceParameter struct {
+	// Name is the name of the parameter selecting the source.
+	Name        string `yaml:"name" validate:"required"`
+	Description string `yaml:"description"`
+	// Sources are the sources that can be selected. The source of the tool,
+	// selected by default, is always allowed.
+	Sources []string `yaml:"sources" validate:"required,min=1"`
+}
+
+// sourceParameterOf returns the source parameter of a tool config, or nil if
+// its source is fixed.
+func sourceParameterOf(cfg ToolConfig) *SourceParameter {
+	if c, ok := cfg.(interface{ GetSourceParameter() *SourceParameter }); ok {
+		return c.GetSourceParameter()
+	}
+	return nil
+}
+
+// selectableSources returns the sources the source parameter of a tool
+// config can select, starting with the source of the tool.
+func selectableSources(source string, sp *SourceParameter) []string {
+	allowed := []string{source}
+	for _, s := range sp.Sources {
+		if !slices.Contains(allowed, s) {
+			allowed = append(allowed, s)
+		}
+	}
+	return allowed
+}
+
+// VerifySourceParameter checks that the sources the source parameter of a
+// tool config can select exist and are of the type of the source of the
+// tool. sourceTypes maps the names of the configured sources to their types.
+func VerifySourceParameter(name string, cfg ToolConfig, sourceTypes map[string]string) error {
+	sp := sourceParameterOf(cfg)
+	if sp == nil {
+		return nil
+	}
+	source, ok := SourceNameOf(cfg)
+	if !ok {
+		return fmt.Errorf("tool %q has no source to select with its sourceParameter", name)
+	}
+	for _, s := range selectableSources(source, sp)[1:] {
+		sourceType, ok := sourceTypes[s]
+		if !ok {
+			return fmt.Errorf("sourceParameter of tool %q references source %q, which does not exist", name, s)
+		}
+		if own, ok := sourceTypes[source]; ok && sourceType != own {
+			return fmt.Errorf("sourceParameter of tool %q references source %q of type %q, must be of type %q like source %q", name, s, sourceType, own, source)
+		}
+	}
+	return nil
+}
+
+// WrapSourceParameters returns the tools with every tool declaring a
+// sourceParameter taking the additional parameter that selects its source.
+func WrapSourceParameters(toolsMap map[string]Tool) (map[string]Tool, error) {
+	wrapped := make(map[string]Tool, len(toolsMap))
+	for name, t := range toolsMap {
+		sp := sourceParameterOf(t.ToConfig())
+		source, ok := SourceNameOf(t.ToConfig())
+		if sp == nil || !ok {
+			wrapped[name] = t
+			continue
+		}
+		if slices.ContainsFunc(t.StaticManifest().Parameters, func(p parameters.ParameterManifest) bool { return p.Name == sp.Name }) {
+			return nil, fmt.Errorf("sourceParameter %q of tool %q has the name of one of its parameters", sp.Name, name)
+		}
+		allowed := selectableSources(source, sp)
+
+		description := sp.Description
+		if description == "" {
+			description = fmt.Sprintf("Source to run the tool against, one of: %s.", strings.Join(allowed, ", "))
+		}
+		// allowed values are matched as regular expressions
+		values := make([]any, len(allowed))
+		for i, s := range allowed {
+			values[i] = "^" + regexp.QuoteMeta(s) + "$"
+		}
+		param := parameters.NewStringParameter(sp.Name, description,
+			parameters.WithStringRequired(false),
+			parameters.WithStringDefault(source),
+			parameters.WithStringAllowedValues(values),
+		)
+		wrapped[name] = sourceSelectingTool{Tool: t, param: param, source: source, allowed: allowed}
+	}
+	return wrapped, nil
+}
+
+// sourceSelectingTool is a tool whose invocations select its source through
+// an additional parameter.
+type sourceSelectingTool struct {
+	Tool
+	param *parameters.StringParameter
+	// source is the configured source of the tool, replaced by the selected
+	// one.
+	source  string
+	allowed []string
+}
+
+// Unwrap implements Wrapper.
+func (t sourceSelectingTool) Unwrap() Tool { return t.Tool }
+
+func (t sourceSelectingTool) GetParameters(srcs map[string]sources.Source) (parameters.Parameters, error) {
+	ps, err := t.Tool.GetParameters(srcs)
+	if err != nil {
+		return nil, err
+	}
+	return append(slices.Clip(ps), t.param), nil
+}
+
+func (t sourceSelectingTool) Manifest(srcs map[string]sources.Source) (Manifest, error) {
+	m, err := t.Tool.Manifest(srcs)
+	if err != nil {
+		return Manifest{}, err
+	}
+	return t.withParam(m), nil
+}
+
+func (t sourceSelectingTool) StaticManifest() Manifest {
+	return t.withParam(t.Tool.StaticManifest())
+}
+
+func (t sourceSelectingTool) withParam(m Manifest) Manifest {
+	m.Parameters = append(slices.Clip(m.Parameters), t.param.Manifest())
+	return m
+}
+
+// Invoke runs the tool against the selected source, without the parameter
+// selecting it.
+func (t sourceSelectingTool) Invoke(ctx context.Context, sp SourceProvider, params parameters.ParamValues, token AccessToken) (any, util.ToolboxError) {
+	selected, rest, err := t.selectSource(sp, params)
+	if err != nil {
+		return nil, err
+	}
+	return t.Tool.Invoke(ctx, selected, rest, token)
+}
+
+// selectSource returns the source provider of the source selected by params,
+// and params without the parameter selecting it.
+func (t sourceSelectingTool) selectSource(sp SourceProvider, params parameters.ParamValues) (SourceProvider, parameters.ParamValues, util.ToolboxError) {
+	selected := t.source
+	rest := make(parameters.ParamValues, 0, len(params))
+	for _, p := range params {
+		if p.Name != t.param.Name {
+			rest = append(rest, p)
+			continue
+		}
+		if s, ok := p.Value.(string); ok && s != "" {
+			selected = s
+		}
+	}
+	if !slices.Contains(t.allowed, selected) {
+		return nil, nil, util.NewClientServerError(fmt.Sprintf("source %q cannot be selected for tool %q", selected, t.GetName()), http.StatusBadRequest, nil)
+	}
+	return selectedSource{SourceProvider: sp, from: t.source, to: selected}, rest, nil
+}
+
+// selectedSource provides the selected source in place of the configured
+// source of a tool.
+type selectedSource struct {
+	SourceProvider
+	from, to string
+}
+
+func (s selectedSource) GetSource(name string) (sources.Source, bool) {
+	if name == s.from {
+		name = s.to
+	}
+	return s.SourceProvider.GetSource(name)
+}
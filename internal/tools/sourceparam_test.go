@@ -0,0 +1,164 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/googleapis/mcp-toolbox/internal/sources"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+type sourcedConfig struct {
+	tools.ConfigBase
+	Source string
+}
+
+func (sourcedConfig) ToolConfigType() string { return "sourced" }
+func (sourcedConfig) Initialize(context.Context) (tools.Tool, error) {
+	return nil, nil
+}
+
+// sourcedTool returns the name of the source it was invoked against and the
+// names of its parameters.
+type sourcedTool struct {
+	tools.BaseTool[sourcedConfig]
+}
+
+func (t sourcedTool) Invoke(_ context.Context, sp tools.SourceProvider, params parameters.ParamValues, _ tools.AccessToken) (any, util.ToolboxError) {
+	src, _ := sp.GetSource(t.Cfg.Source)
+	names := []string{src.(namedSource).name}
+	for _, p := range params {
+		names = append(names, p.Name)
+	}
+	return strings.Join(names, ","), nil
+}
+
+func (t sourcedTool) ToConfig() tools.ToolConfig { return t.Cfg }
+
+type namedSource struct {
+	sources.Source
+	name string
+}
+
+type sourcesByName map[string]sources.Source
+
+func (m sourcesByName) GetSource(name string) (sources.Source, bool) {
+	s, ok := m[name]
+	return s, ok
+}
+
+func newSourcedTool(sp *tools.SourceParameter) tools.Tool {
+	cfg := sourcedConfig{ConfigBase: tools.ConfigBase{Name: "list_flights", SourceParameter: sp}, Source: "dev"}
+	params := parameters.Parameters{parameters.NewStringParameter("airline", "airline")}
+	manifest := tools.Manifest{Parameters: params.Manifest()}
+	return sourcedTool{tools.NewBaseTool(cfg, nil, manifest, params)}
+}
+
+func TestWrapSourceParameters(t *testing.T) {
+	wrapped, err := tools.WrapSourceParameters(map[string]tools.Tool{
+		"list_flights": newSourcedTool(&tools.SourceParameter{Name: "env", Sources: []string{"prod"}}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tool := wrapped["list_flights"]
+
+	params, err := tool.GetParameters(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(params) != 2 || params[1].GetName() != "env" || params[1].GetDefault() != "dev" {
+		t.Fatalf("unexpected parameters: %v", params.Manifest())
+	}
+	if m := tool.StaticManifest(); len(m.Parameters) != 2 || m.Parameters[1].Name != "env" {
+		t.Fatalf("unexpected manifest parameters: %v", m.Parameters)
+	}
+
+	provider := sourcesByName{"dev": namedSource{name: "dev"}, "prod": namedSource{name: "prod"}, "staging": namedSource{name: "staging"}}
+	tcs := []struct {
+		desc    string
+		data    map[string]any
+		want    string
+		wantErr bool
+	}{
+		{desc: "default", data: map[string]any{"airline": "CY"}, want: "dev,airline"},
+		{desc: "selected", data: map[string]any{"airline": "CY", "env": "prod"}, want: "prod,airline"},
+		{desc: "not allowed", data: map[string]any{"airline": "CY", "env": "staging"}, wantErr: true},
+		{desc: "not anchored", data: map[string]any{"airline": "CY", "env": "prod2"}, wantErr: true},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			values, err := parameters.ParseParams(params, tc.data, nil)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			got, toolErr := tool.Invoke(context.Background(), provider, values, "")
+			if toolErr != nil {
+				t.Fatalf("unexpected error: %s", toolErr)
+			}
+			if got != tc.want {
+				t.Fatalf("unexpected result: got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestWrapSourceParametersNameClash(t *testing.T) {
+	_, err := tools.WrapSourceParameters(map[string]tools.Tool{
+		"list_flights": newSourcedTool(&tools.SourceParameter{Name: "airline", Sources: []string{"prod"}}),
+	})
+	if err == nil || !strings.Contains(err.Error(), "has the name of one of its parameters") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestVerifySourceParameter(t *testing.T) {
+	sourceTypes := map[string]string{"dev": "postgres", "prod": "postgres", "warehouse": "bigquery"}
+	tcs := []struct {
+		desc    string
+		sources []string
+		wantErr string
+	}{
+		{desc: "same type", sources: []string{"prod"}},
+		{desc: "missing", sources: []string{"qa"}, wantErr: `references source "qa", which does not exist`},
+		{desc: "other type", sources: []string{"warehouse"}, wantErr: `must be of type "postgres"`},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := sourcedConfig{ConfigBase: tools.ConfigBase{SourceParameter: &tools.SourceParameter{Name: "env", Sources: tc.sources}}, Source: "dev"}
+			err := tools.VerifySourceParameter("list_flights", cfg, sourceTypes)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("unexpected error: got %v, want %q", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package spannercommon holds the options shared by the Spanner tools.
+package spannercommon
+
+import (
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	sppb "cloud.google.com/go/spanner/apiv1/spannerpb"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+// priorities maps the priorities of a config to those of Spanner requests.
+var priorities = map[string]sppb.RequestOptions_Priority{
+	"low":    sppb.RequestOptions_PRIORITY_LOW,
+	"medium": sppb.RequestOptions_PRIORITY_MEDIUM,
+	"high":   sppb.RequestOptions_PRIORITY_HIGH,
+}
+
+// QueryOptions are the staleness, priority and tag of the queries of a tool,
+// inlined in its config.
+type QueryOptions struct {
+	// ExactStaleness reads the data as it was exactly that long ago.
+	ExactStaleness string `yaml:"exactStaleness,omitempty"`
+	// MaxStaleness reads data at most that long old, letting Spanner pick
+	// the most recent data available without waiting.
+	MaxStaleness string `yaml:"maxStaleness,omitempty"`
+	// Priority is the priority of the requests, "low", "medium" or "high".
+	Priority string `yaml:"priority,omitempty" validate:"omitempty,oneof=low medium high"`
+	// RequestTag tags the requests, to find them in the query statistics of
+	// the database.
+	RequestTag string `yaml:"requestTag,omitempty"`
+}
+
+// Validate checks the options of the tool name. Stale reads are only
+// possible in read-only transactions.
+func (o QueryOptions) Validate(name string, readOnly bool) error {
+	if o.ExactStaleness != "" && o.MaxStaleness != "" {
+		return fmt.Errorf("tool %q: exactStaleness and maxStaleness are mutually exclusive", name)
+	}
+	for field, v := range map[string]string{"exactStaleness": o.ExactStaleness, "maxStaleness": o.MaxStaleness} {
+		if v == "" {
+			continue
+		}
+		if !readOnly {
+			return fmt.Errorf("tool %q: %s requires a read-only tool", name, field)
+		}
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("tool %q: invalid %s %q: %w", name, field, v, err)
+		}
+		if d < 0 {
+			return fmt.Errorf("tool %q: %s must not be negative", name, field)
+		}
+	}
+	return nil
+}
+
+// Bound returns the timestamp bound of the read-only transactions of the
+// tool, a strong read unless a staleness is set.
+func (o QueryOptions) Bound() spanner.TimestampBound {
+	if d, err := time.ParseDuration(o.ExactStaleness); err == nil {
+		return spanner.ExactStaleness(d)
+	}
+	if d, err := time.ParseDuration(o.MaxStaleness); err == nil {
+		return spanner.MaxStaleness(d)
+	}
+	return spanner.StrongRead()
+}
+
+// ValidateAsOf checks the asOfTimestamp option of the tool name. Reads at a
+// timestamp are only possible in read-only transactions.
+func ValidateAsOf(name string, asOf tools.AsOfConfig, readOnly bool) error {
+	if asOf.AsOfTimestamp && !readOnly {
+		return fmt.Errorf("tool %q: asOfTimestamp requires a read-only tool", name)
+	}
+	return nil
+}
+
+// BoundOf returns the timestamp bound of an invocation with params: a read
+// at its asOfTimestamp if the caller set one, or else Bound.
+func (o QueryOptions) BoundOf(params parameters.ParamValues) (spanner.TimestampBound, util.ToolboxError) {
+	t, ok, err := tools.AsOf(params, time.Now())
+	if err != nil {
+		return spanner.StrongRead(), err
+	}
+	if ok {
+		return spanner.ReadTimestamp(t), nil
+	}
+	return o.Bound(), nil
+}
+
+// Query returns the options of the queries of the tool. The options left
+// unset keep those of the client.
+func (o QueryOptions) Query() spanner.QueryOptions {
+	return spanner.QueryOptions{Priority: priorities[o.Priority], RequestTag: o.RequestTag}
+}
@@ -0,0 +1,92 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spannercommon
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	sppb "cloud.google.com/go/spanner/apiv1/spannerpb"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+func TestValidate(t *testing.T) {
+	tcs := []struct {
+		desc     string
+		opts     QueryOptions
+		readOnly bool
+		want     string
+	}{
+		{desc: "no options", opts: QueryOptions{}},
+		{desc: "exact staleness", opts: QueryOptions{ExactStaleness: "15s"}, readOnly: true},
+		{desc: "both stalenesses", opts: QueryOptions{ExactStaleness: "15s", MaxStaleness: "10s"}, readOnly: true, want: "mutually exclusive"},
+		{desc: "read-write staleness", opts: QueryOptions{MaxStaleness: "10s"}, want: "requires a read-only tool"},
+		{desc: "invalid staleness", opts: QueryOptions{MaxStaleness: "soon"}, readOnly: true, want: "invalid maxStaleness"},
+		{desc: "negative staleness", opts: QueryOptions{ExactStaleness: "-1s"}, readOnly: true, want: "must not be negative"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := tc.opts.Validate("tool", tc.readOnly)
+			if tc.want == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("got error %v, want error containing %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestBoundAndQuery(t *testing.T) {
+	if got, want := (QueryOptions{}).Bound().String(), spanner.StrongRead().String(); got != want {
+		t.Errorf("got bound %s, want %s", got, want)
+	}
+	if got, want := (QueryOptions{MaxStaleness: "10s"}).Bound().String(), spanner.MaxStaleness(10*time.Second).String(); got != want {
+		t.Errorf("got bound %s, want %s", got, want)
+	}
+	q := QueryOptions{Priority: "low", RequestTag: "agent"}.Query()
+	if q.Priority != sppb.RequestOptions_PRIORITY_LOW || q.RequestTag != "agent" {
+		t.Errorf("unexpected query options: %+v", q)
+	}
+}
+
+func TestAsOf(t *testing.T) {
+	if err := ValidateAsOf("tool", tools.AsOfConfig{AsOfTimestamp: true}, false); err == nil || !strings.Contains(err.Error(), "requires a read-only tool") {
+		t.Fatalf("got error %v, want a read-only error", err)
+	}
+	if err := ValidateAsOf("tool", tools.AsOfConfig{AsOfTimestamp: true}, true); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	opts := QueryOptions{MaxStaleness: "10s"}
+	bound, err := opts.BoundOf(parameters.ParamValues{{Name: tools.AsOfParameterName, Value: nil}})
+	if err != nil || bound.String() != spanner.MaxStaleness(10*time.Second).String() {
+		t.Fatalf("got bound %s, %v, want the bound of the options", bound, err)
+	}
+	at := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+	bound, err = opts.BoundOf(parameters.ParamValues{{Name: tools.AsOfParameterName, Value: at.Format(time.RFC3339)}})
+	if err != nil || bound.String() != spanner.ReadTimestamp(at).String() {
+		t.Fatalf("got bound %s, %v, want a read at %s", bound, err, at)
+	}
+	if _, err := opts.BoundOf(parameters.ParamValues{{Name: tools.AsOfParameterName, Value: "soon"}}); err == nil {
+		t.Fatal("expected an error for an invalid timestamp")
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"cloud.google.com/go/spanner"
 	yaml "github.com/goccy/go-yaml"
 	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/tools/spanner/spannercommon"
 	"github.com/googleapis/mcp-toolbox/internal/util"
 	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
 )
@@ -29,7 +30,7 @@ import (
 const resourceType string = "spanner-execute-sql"
 
 func init() {
-	if !tools.Register(resourceType, newConfig) {
+	if !tools.Register[compatibleSource](resourceType, newConfig) {
 		panic(fmt.Sprintf("tool type %q already registered", resourceType))
 	}
 }
@@ -45,15 +46,17 @@ func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.T
 type compatibleSource interface {
 	SpannerClient() *spanner.Client
 	DatabaseDialect() string
-	RunSQL(context.Context, bool, string, map[string]any) (any, error)
+	RunSQLWithOptions(context.Context, bool, string, map[string]any, spanner.TimestampBound, spanner.QueryOptions) (any, error)
 }
 
 type Config struct {
-	tools.ConfigBase `yaml:",inline"`
-	Type             string                 `yaml:"type" validate:"required"`
-	Source           string                 `yaml:"source" validate:"required"`
-	ReadOnly         bool                   `yaml:"readOnly"`
-	Annotations      *tools.ToolAnnotations `yaml:"annotations,omitempty"`
+	tools.ConfigBase           `yaml:",inline"`
+	spannercommon.QueryOptions `yaml:",inline"`
+	tools.AsOfConfig           `yaml:",inline"`
+	Type                       string                 `yaml:"type" validate:"required"`
+	Source                     string                 `yaml:"source" validate:"required"`
+	ReadOnly                   bool                   `yaml:"readOnly"`
+	Annotations                *tools.ToolAnnotations `yaml:"annotations,omitempty"`
 }
 
 // validate interface
@@ -75,12 +78,22 @@ func (cfg Config) Initialize(context.Context) (tools.Tool, error) {
 	if cfg.ReadOnly {
 		defaultAnnotations = tools.NewReadOnlyAnnotations
 	}
+	if err := cfg.QueryOptions.Validate(cfg.Name, cfg.ReadOnly); err != nil {
+		return nil, err
+	}
+	if err := spannercommon.ValidateAsOf(cfg.Name, cfg.AsOfConfig, cfg.ReadOnly); err != nil {
+		return nil, err
+	}
+	params, manifest, err := cfg.WithAsOfParameter(cfg.Name, params, params.Manifest())
+	if err != nil {
+		return nil, err
+	}
 
 	return Tool{
 		BaseTool: tools.NewBaseTool(
 			cfg,
 			tools.GetAnnotationsOrDefault(cfg.Annotations, defaultAnnotations),
-			tools.Manifest{Description: cfg.Description, Parameters: params.Manifest(), AuthRequired: cfg.AuthRequired},
+			tools.Manifest{Description: cfg.Description, Parameters: manifest, AuthRequired: cfg.AuthRequired},
 			params,
 		),
 	}, nil
@@ -111,7 +124,11 @@ func (t Tool) Invoke(ctx context.Context, primitiveMgr tools.SourceProvider, par
 		return nil, util.NewClientServerError("error getting logger", http.StatusInternalServerError, err)
 	}
 	logger.DebugContext(ctx, fmt.Sprintf("executing `%s` tool query: %s", resourceType, sql))
-	resp, err := source.RunSQL(ctx, t.Cfg.ReadOnly, sql, nil)
+	bound, tbErr := t.Cfg.BoundOf(params)
+	if tbErr != nil {
+		return nil, tbErr
+	}
+	resp, err := source.RunSQLWithOptions(ctx, t.Cfg.ReadOnly, sql, nil, bound, t.Cfg.Query())
 	if err != nil {
 		return nil, util.ProcessGcpError(err)
 	}
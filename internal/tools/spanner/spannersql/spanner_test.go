@@ -21,6 +21,7 @@ import (
 	"github.com/googleapis/mcp-toolbox/internal/server"
 	"github.com/googleapis/mcp-toolbox/internal/testutils"
 	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/tools/spanner/spannercommon"
 	"github.com/googleapis/mcp-toolbox/internal/tools/spanner/spannersql"
 	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
 )
@@ -99,6 +100,71 @@ func TestParseFromYamlSpanner(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "query options",
+			in: `
+            kind: tool
+            name: example_tool
+            type: spanner-sql
+            source: my-pg-instance
+            description: some description
+            readOnly: true
+            maxStaleness: 15s
+            priority: low
+            requestTag: agent
+            statement: |
+                SELECT * FROM SQL_STATEMENT;
+			`,
+			want: server.ToolConfigs{
+				"example_tool": spannersql.Config{
+					ConfigBase: tools.ConfigBase{
+						Name:         "example_tool",
+						Description:  "some description",
+						AuthRequired: []string{},
+					},
+					QueryOptions: spannercommon.QueryOptions{MaxStaleness: "15s", Priority: "low", RequestTag: "agent"},
+					Type:         "spanner-sql",
+					Source:       "my-pg-instance",
+					Statement:    "SELECT * FROM SQL_STATEMENT;\n",
+					ReadOnly:     true,
+				},
+			},
+		},
+		{
+			desc: "graph query",
+			in: `
+            kind: tool
+            name: example_tool
+            type: spanner-sql
+            source: my-spanner-instance
+            description: some description
+            queryLanguage: gql
+            statement: |
+                GRAPH FinGraph
+                MATCH (p:Person {name: @name})-[o:Owns]->(a:Account)
+                RETURN SAFE_TO_JSON(p) AS person, SAFE_TO_JSON(o) AS owns, a.id
+            parameters:
+                - name: name
+                  type: string
+                  description: some description
+			`,
+			want: server.ToolConfigs{
+				"example_tool": spannersql.Config{
+					ConfigBase: tools.ConfigBase{
+						Name:         "example_tool",
+						Description:  "some description",
+						AuthRequired: []string{},
+					},
+					Type:          "spanner-sql",
+					Source:        "my-spanner-instance",
+					QueryLanguage: "gql",
+					Statement:     "GRAPH FinGraph\nMATCH (p:Person {name: @name})-[o:Owns]->(a:Account)\nRETURN SAFE_TO_JSON(p) AS person, SAFE_TO_JSON(o) AS owns, a.id\n",
+					Parameters: []parameters.Parameter{
+						parameters.NewStringParameter("name", "some description"),
+					},
+				},
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
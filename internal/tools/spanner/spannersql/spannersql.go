@@ -23,14 +23,21 @@ import (
 	"cloud.google.com/go/spanner"
 	yaml "github.com/goccy/go-yaml"
 	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/tools/spanner/spannercommon"
 	"github.com/googleapis/mcp-toolbox/internal/util"
 	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
 )
 
 const resourceType string = "spanner-sql"
 
+// Query languages of the statement of a tool.
+const (
+	queryLanguageSQL = "sql"
+	queryLanguageGQL = "gql"
+)
+
 func init() {
-	if !tools.Register(resourceType, newConfig) {
+	if !tools.Register[compatibleSource](resourceType, newConfig) {
 		panic(fmt.Sprintf("tool type %q already registered", resourceType))
 	}
 }
@@ -46,18 +53,24 @@ func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.T
 type compatibleSource interface {
 	SpannerClient() *spanner.Client
 	DatabaseDialect() string
-	RunSQL(context.Context, bool, string, map[string]any) (any, error)
+	RunSQLWithOptions(context.Context, bool, string, map[string]any, spanner.TimestampBound, spanner.QueryOptions) (any, error)
+	RunGQLWithOptions(context.Context, string, map[string]any, spanner.TimestampBound, spanner.QueryOptions) (any, error)
 }
 
 type Config struct {
-	tools.ConfigBase   `yaml:",inline"`
-	Type               string                 `yaml:"type" validate:"required"`
-	Source             string                 `yaml:"source" validate:"required"`
-	Statement          string                 `yaml:"statement" validate:"required"`
-	ReadOnly           bool                   `yaml:"readOnly"`
-	Parameters         parameters.Parameters  `yaml:"parameters"`
-	TemplateParameters parameters.Parameters  `yaml:"templateParameters"`
-	Annotations        *tools.ToolAnnotations `yaml:"annotations,omitempty"`
+	tools.ConfigBase           `yaml:",inline"`
+	tools.ColumnConfig         `yaml:",inline"`
+	spannercommon.QueryOptions `yaml:",inline"`
+	tools.AsOfConfig           `yaml:",inline"`
+	Type                       string                 `yaml:"type" validate:"required"`
+	Source                     string                 `yaml:"source" validate:"required"`
+	Statement                  string                 `yaml:"statement" validate:"required"`
+	Variants                   tools.Variants         `yaml:"variants,omitempty"`
+	ReadOnly                   bool                   `yaml:"readOnly"`
+	QueryLanguage              string                 `yaml:"queryLanguage,omitempty" validate:"omitempty,oneof=sql gql"`
+	Parameters                 parameters.Parameters  `yaml:"parameters"`
+	TemplateParameters         parameters.Parameters  `yaml:"templateParameters"`
+	Annotations                *tools.ToolAnnotations `yaml:"annotations,omitempty"`
 }
 
 // validate interface
@@ -77,10 +90,31 @@ func (cfg Config) Initialize(context.Context) (tools.Tool, error) {
 		return nil, err
 	}
 
+	// graph queries are always read-only
+	readOnly := cfg.ReadOnly || cfg.QueryLanguage == queryLanguageGQL
 	defaultAnnotations := tools.NewDestructiveAnnotations
-	if cfg.ReadOnly {
+	if readOnly {
 		defaultAnnotations = tools.NewReadOnlyAnnotations
 	}
+	if err := cfg.QueryOptions.Validate(cfg.Name, readOnly); err != nil {
+		return nil, err
+	}
+	if err := spannercommon.ValidateAsOf(cfg.Name, cfg.AsOfConfig, readOnly); err != nil {
+		return nil, err
+	}
+	allParameters, paramManifest, err = cfg.WithAsOfParameter(cfg.Name, allParameters, paramManifest)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Variants.Validate(cfg.Name); err != nil {
+		return nil, err
+	}
+
+	columns, err := cfg.NewColumnShaper(cfg.Name)
+	if err != nil {
+		return nil, err
+	}
 
 	return Tool{
 		BaseTool: tools.NewBaseTool(
@@ -89,6 +123,7 @@ func (cfg Config) Initialize(context.Context) (tools.Tool, error) {
 			tools.Manifest{Description: cfg.Description, Parameters: paramManifest, AuthRequired: cfg.AuthRequired},
 			allParameters,
 		),
+		columns: columns,
 	}, nil
 }
 
@@ -97,6 +132,7 @@ var _ tools.Tool = Tool{}
 
 type Tool struct {
 	tools.BaseTool[Config]
+	columns *tools.ColumnShaper
 }
 
 func getMapParams(params parameters.ParamValues, dialect string) (map[string]interface{}, error) {
@@ -116,8 +152,13 @@ func (t Tool) Invoke(ctx context.Context, primitiveMgr tools.SourceProvider, par
 		return nil, util.NewClientServerError("source used is not compatible with the tool", http.StatusInternalServerError, err)
 	}
 
+	statement, err := t.Cfg.Variants.Select(ctx, t.Cfg.Statement)
+	if err != nil {
+		return nil, util.NewAgentError("unable to select a variant", err)
+	}
+
 	paramsMap := params.AsMap()
-	newStatement, err := parameters.ResolveTemplateParams(t.Cfg.TemplateParameters, t.Cfg.Statement, paramsMap)
+	newStatement, err := parameters.ResolveTemplateParams(t.Cfg.TemplateParameters, statement, paramsMap)
 	if err != nil {
 		return nil, util.NewClientServerError(fmt.Sprintf("unable to extract template params: %v", err), http.StatusInternalServerError, err)
 	}
@@ -155,11 +196,20 @@ func (t Tool) Invoke(ctx context.Context, primitiveMgr tools.SourceProvider, par
 		return nil, util.NewAgentError("fail to get map params", err)
 	}
 
-	resp, err := source.RunSQL(ctx, t.Cfg.ReadOnly, newStatement, mapParams)
+	bound, tbErr := t.Cfg.BoundOf(params)
+	if tbErr != nil {
+		return nil, tbErr
+	}
+	var resp any
+	if t.Cfg.QueryLanguage == queryLanguageGQL {
+		resp, err = source.RunGQLWithOptions(ctx, newStatement, mapParams, bound, t.Cfg.Query())
+	} else {
+		resp, err = source.RunSQLWithOptions(ctx, t.Cfg.ReadOnly, newStatement, mapParams, bound, t.Cfg.Query())
+	}
 	if err != nil {
 		return nil, util.ProcessGcpError(err)
 	}
-	return resp, nil
+	return t.columns.Apply(ctx, resp), nil
 }
 
 func (t Tool) ToConfig() tools.ToolConfig {
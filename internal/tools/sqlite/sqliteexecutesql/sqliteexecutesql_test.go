@@ -15,13 +15,19 @@
 package sqliteexecutesql_test
 
 import (
+	"path/filepath"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/googleapis/mcp-toolbox/internal/server"
+	"github.com/googleapis/mcp-toolbox/internal/sources"
+	"github.com/googleapis/mcp-toolbox/internal/sources/sqlite"
 	"github.com/googleapis/mcp-toolbox/internal/testutils"
 	"github.com/googleapis/mcp-toolbox/internal/tools"
 	"github.com/googleapis/mcp-toolbox/internal/tools/sqlite/sqliteexecutesql"
+	"github.com/googleapis/mcp-toolbox/internal/util/orderedmap"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+	"go.opentelemetry.io/otel/trace/noop"
 	_ "modernc.org/sqlite"
 )
 
@@ -74,3 +80,69 @@ func TestParseFromYamlExecuteSql(t *testing.T) {
 	}
 
 }
+
+type sourceProvider map[string]sources.Source
+
+func (p sourceProvider) GetSource(name string) (sources.Source, bool) {
+	s, ok := p[name]
+	return s, ok
+}
+
+func TestInvoke(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+	provider := sourceProvider{}
+	for _, cfg := range []sqlite.Config{
+		{Name: "rw", Type: sqlite.SourceType, Database: dbPath},
+		{Name: "ro", Type: sqlite.SourceType, Database: dbPath, ReadOnly: true},
+	} {
+		src, err := cfg.Initialize(ctx, noop.NewTracerProvider().Tracer("test"))
+		if err != nil {
+			t.Fatalf("unable to initialize source %q: %s", cfg.Name, err)
+		}
+		t.Cleanup(func() { _ = src.(*sqlite.Source).Close() })
+		provider[cfg.Name] = src
+	}
+
+	invoke := func(source, sql string) (any, error) {
+		toolCfg := sqliteexecutesql.Config{
+			ConfigBase: tools.ConfigBase{Name: "execute-sql", Description: "execute sql"},
+			Type:       "sqlite-execute-sql",
+			Source:     source,
+		}
+		tool, err := toolCfg.Initialize(ctx)
+		if err != nil {
+			t.Fatalf("unable to initialize tool: %s", err)
+		}
+		res, toolErr := tool.Invoke(ctx, provider, parameters.ParamValues{{Name: "sql", Value: sql}}, "")
+		if toolErr != nil {
+			return nil, toolErr
+		}
+		return res, nil
+	}
+
+	for _, sql := range []string{
+		"CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT)",
+		"INSERT INTO users (name) VALUES ('Alice')",
+	} {
+		if _, err := invoke("rw", sql); err != nil {
+			t.Fatalf("unable to execute %q: %s", sql, err)
+		}
+	}
+
+	got, err := invoke("ro", "SELECT id, name FROM users")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := []any{orderedmap.Row{Columns: []orderedmap.Column{{Name: "id", Value: int64(1)}, {Name: "name", Value: "Alice"}}}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected result (-want +got):\n%s", diff)
+	}
+
+	if _, err := invoke("ro", "INSERT INTO users (name) VALUES ('Bob')"); err == nil {
+		t.Fatalf("expected a write through a read-only source to fail")
+	}
+}
@@ -15,14 +15,20 @@
 package sqlitesql_test
 
 import (
+	"context"
+	"path/filepath"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/googleapis/mcp-toolbox/internal/server"
+	"github.com/googleapis/mcp-toolbox/internal/sources"
+	"github.com/googleapis/mcp-toolbox/internal/sources/sqlite"
 	"github.com/googleapis/mcp-toolbox/internal/testutils"
 	"github.com/googleapis/mcp-toolbox/internal/tools"
 	"github.com/googleapis/mcp-toolbox/internal/tools/sqlite/sqlitesql"
+	"github.com/googleapis/mcp-toolbox/internal/util/orderedmap"
 	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+	"go.opentelemetry.io/otel/trace/noop"
 	_ "modernc.org/sqlite"
 )
 
@@ -77,6 +83,42 @@ func TestParseFromYamlSQLite(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "column mapping and projection",
+			in: `
+            kind: tool
+            name: example_tool
+            type: sqlite-sql
+            source: my-sqlite-instance
+            description: some description
+            statement: SELECT usr_nm, ssn_raw FROM users;
+            columnMapping:
+                usr_nm: user_name
+                ssn_raw: ssn
+            projectColumns:
+                - user_name
+                - ssn
+            maskColumns:
+                - ssn
+			`,
+			want: server.ToolConfigs{
+				"example_tool": sqlitesql.Config{
+					ConfigBase: tools.ConfigBase{
+						Name:         "example_tool",
+						Description:  "some description",
+						AuthRequired: []string{},
+					},
+					ColumnConfig: tools.ColumnConfig{
+						ColumnMapping:  map[string]string{"usr_nm": "user_name", "ssn_raw": "ssn"},
+						ProjectColumns: []string{"user_name", "ssn"},
+						MaskColumns:    []string{"ssn"},
+					},
+					Type:      "sqlite-sql",
+					Source:    "my-sqlite-instance",
+					Statement: "SELECT usr_nm, ssn_raw FROM users;",
+				},
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -173,3 +215,61 @@ func TestParseFromYamlWithTemplateSqlite(t *testing.T) {
 		})
 	}
 }
+
+type sourceProvider map[string]sources.Source
+
+func (p sourceProvider) GetSource(name string) (sources.Source, bool) {
+	s, ok := p[name]
+	return s, ok
+}
+
+func TestInvoke(t *testing.T) {
+	ctx := context.Background()
+	srcCfg := sqlite.Config{Name: "my-sqlite-db", Type: sqlite.SourceType, Database: filepath.Join(t.TempDir(), "test.db")}
+	src, err := srcCfg.Initialize(ctx, noop.NewTracerProvider().Tracer("test"))
+	if err != nil {
+		t.Fatalf("unable to initialize source: %s", err)
+	}
+	t.Cleanup(func() { _ = src.(*sqlite.Source).Close() })
+	for _, stmt := range []string{
+		"CREATE TABLE hotels (id INTEGER PRIMARY KEY, name TEXT, city TEXT)",
+		"INSERT INTO hotels (name, city) VALUES ('Hilton', 'Basel'), ('Marriott', 'Zurich'), ('Hyatt', 'Basel')",
+	} {
+		if _, err := src.(*sqlite.Source).RunSQL(ctx, stmt, nil); err != nil {
+			t.Fatalf("unable to set up database: %s", err)
+		}
+	}
+
+	toolCfg := sqlitesql.Config{
+		ConfigBase: tools.ConfigBase{Name: "search-hotels", Description: "search hotels by city"},
+		Type:       "sqlite-sql",
+		Source:     "my-sqlite-db",
+		Statement:  "SELECT {{.column}} FROM hotels WHERE city = ? ORDER BY id",
+		Parameters: parameters.Parameters{
+			parameters.NewStringParameter("city", "city of the hotels"),
+		},
+		TemplateParameters: parameters.Parameters{
+			parameters.NewStringParameter("column", "column to return"),
+		},
+	}
+	tool, err := toolCfg.Initialize(ctx)
+	if err != nil {
+		t.Fatalf("unable to initialize tool: %s", err)
+	}
+
+	params := parameters.ParamValues{
+		{Name: "city", Value: "Basel"},
+		{Name: "column", Value: "name"},
+	}
+	got, toolErr := tool.Invoke(ctx, sourceProvider{"my-sqlite-db": src}, params, "")
+	if toolErr != nil {
+		t.Fatalf("unexpected error: %s", toolErr)
+	}
+	want := []any{
+		orderedmap.Row{Columns: []orderedmap.Column{{Name: "name", Value: "Hilton"}}},
+		orderedmap.Row{Columns: []orderedmap.Column{{Name: "name", Value: "Hyatt"}}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected result (-want +got):\n%s", diff)
+	}
+}
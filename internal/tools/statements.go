@@ -0,0 +1,62 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/googleapis/mcp-toolbox/internal/util"
+)
+
+// StatementChecker is implemented by sources that restrict the statements
+// tools may run on them.
+type StatementChecker interface {
+	CheckStatement(statement string) error
+}
+
+// CheckStatement rejects statement if source implements StatementChecker
+// and does not allow it. Tools call it with the final statement, after
+// template parameters are resolved.
+func CheckStatement(source any, statement string) util.ToolboxError {
+	checker, ok := source.(StatementChecker)
+	if !ok {
+		return nil
+	}
+	if err := checker.CheckStatement(statement); err != nil {
+		return util.NewAgentError("statement rejected by source policy", err)
+	}
+	return nil
+}
+
+// ReadOnlyChecker is implemented by sources that can tell whether a statement
+// may write.
+type ReadOnlyChecker interface {
+	CheckReadOnly(tool, statement string) error
+}
+
+// CheckReadOnly rejects statement of the read-only tool named tool if it may
+// write. Unlike CheckStatement, it fails if source cannot check statements,
+// since the tool would otherwise run writes it promised not to.
+func CheckReadOnly(source any, tool, statement string) util.ToolboxError {
+	checker, ok := source.(ReadOnlyChecker)
+	if !ok {
+		return util.NewClientServerError(fmt.Sprintf("source of read-only tool %q cannot check statements", tool), http.StatusInternalServerError, nil)
+	}
+	if err := checker.CheckReadOnly(tool, statement); err != nil {
+		return util.NewAgentError("statement rejected by read-only tool", err)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/googleapis/mcp-toolbox/internal/sources/queryguard"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+)
+
+type guardedSource struct {
+	queryguard.Guard
+}
+
+func TestCheckStatement(t *testing.T) {
+	guard, err := queryguard.Patterns{
+		DenyPatterns: []queryguard.Pattern{{Label: "drop-table", Pattern: `(?i)drop\s+table`}},
+	}.Compile("my-source")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	source := guardedSource{Guard: guard}
+
+	if err := tools.CheckStatement(source, "SELECT 1"); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	err = tools.CheckStatement(source, "drop table flights")
+	var agentErr *util.AgentError
+	if !errors.As(err, &agentErr) {
+		t.Fatalf("expected an agent error, got %v", err)
+	}
+	var policyErr *queryguard.PolicyError
+	if !errors.As(err, &policyErr) || policyErr.Label != "drop-table" {
+		t.Errorf("expected a policy error of pattern drop-table, got %v", err)
+	}
+
+	if err := tools.CheckStatement(struct{}{}, "drop table flights"); err != nil {
+		t.Errorf("expected sources without patterns to allow every statement, got %s", err)
+	}
+}
+
+func TestCheckReadOnly(t *testing.T) {
+	guard, err := queryguard.Patterns{}.Compile("my-source")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	source := guardedSource{Guard: guard.WithReadOnly(false, queryguard.MySQL)}
+
+	if err := tools.CheckReadOnly(source, "my-tool", "SELECT 1"); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	err = tools.CheckReadOnly(source, "my-tool", "UPDATE flights SET gate = 'A1'")
+	var agentErr *util.AgentError
+	if !errors.As(err, &agentErr) {
+		t.Fatalf("expected an agent error, got %v", err)
+	}
+	var writeErr *queryguard.WriteError
+	if !errors.As(err, &writeErr) || writeErr.Tool != "my-tool" {
+		t.Errorf("expected a write error of tool my-tool, got %v", err)
+	}
+
+	var serverErr *util.ClientServerError
+	if err := tools.CheckReadOnly(struct{}{}, "my-tool", "SELECT 1"); !errors.As(err, &serverErr) {
+		t.Errorf("expected sources that cannot check statements to fail, got %v", err)
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+// timeoutOf returns the timeout of a tool config, or an empty string if it
+// has none.
+func timeoutOf(cfg ToolConfig) string {
+	if c, ok := cfg.(interface{ GetTimeout() string }); ok {
+		return c.GetTimeout()
+	}
+	return ""
+}
+
+// WrapTimeouts returns the tools with every tool that has a timeout bounding
+// its invocations with a deadline.
+func WrapTimeouts(toolsMap map[string]Tool) (map[string]Tool, error) {
+	wrapped := make(map[string]Tool, len(toolsMap))
+	for name, t := range toolsMap {
+		timeout := timeoutOf(t.ToConfig())
+		if timeout == "" {
+			wrapped[name] = t
+			continue
+		}
+		d, err := time.ParseDuration(timeout)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid timeout %q of tool %q: must be a positive duration such as \"30s\"", timeout, name)
+		}
+		timed := timedTool{Tool: t, timeout: d}
+		if streamer, ok := t.(RowStreamer); ok {
+			wrapped[name] = timedStreamer{timedTool: timed, streamer: streamer}
+			continue
+		}
+		wrapped[name] = timed
+	}
+	return wrapped, nil
+}
+
+// timedTool is a tool whose invocations are canceled past its timeout.
+type timedTool struct {
+	Tool
+	timeout time.Duration
+}
+
+// Unwrap implements Wrapper.
+func (t timedTool) Unwrap() Tool { return t.Tool }
+
+func (t timedTool) Invoke(ctx context.Context, sp SourceProvider, params parameters.ParamValues, token AccessToken) (any, util.ToolboxError) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	res, err := t.Tool.Invoke(ctx, sp, params, token)
+	if err != nil {
+		return nil, t.timedOut(ctx, err)
+	}
+	return res, nil
+}
+
+// DryRun implements DryRunner.
+func (t timedTool) DryRun(ctx context.Context, sp SourceProvider, params parameters.ParamValues, token AccessToken) (*DryRunResult, util.ToolboxError) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	res, err := DryRun(ctx, t.Tool, sp, params, token)
+	if err != nil {
+		return nil, t.timedOut(ctx, err)
+	}
+	return res, nil
+}
+
+// timedOut returns the error of an invocation failing with ctx, reported as
+// a timeout if the deadline of the tool passed.
+func (t timedTool) timedOut(ctx context.Context, err util.ToolboxError) util.ToolboxError {
+	if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return err
+	}
+	return util.NewClientServerError(fmt.Sprintf("tool %q timed out after %s", t.GetName(), t.timeout), http.StatusGatewayTimeout, err)
+}
+
+// timedStreamer is a tool with a timeout that streams its rows.
+type timedStreamer struct {
+	timedTool
+	streamer RowStreamer
+}
+
+func (t timedStreamer) StreamRows(ctx context.Context, sp SourceProvider, params parameters.ParamValues, token AccessToken, emit func(rows []any) error) util.ToolboxError {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	if err := t.streamer.StreamRows(ctx, sp, params, token, emit); err != nil {
+		return t.timedOut(ctx, err)
+	}
+	return nil
+}
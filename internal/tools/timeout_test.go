@@ -0,0 +1,92 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+type sleepConfig struct {
+	tools.ConfigBase
+}
+
+func (sleepConfig) ToolConfigType() string { return "sleep" }
+func (sleepConfig) Initialize(context.Context) (tools.Tool, error) {
+	return nil, nil
+}
+
+// sleepTool sleeps for its sleep parameter, or until its invocation is
+// canceled.
+type sleepTool struct {
+	tools.BaseTool[sleepConfig]
+}
+
+func (t sleepTool) Invoke(ctx context.Context, _ tools.SourceProvider, params parameters.ParamValues, _ tools.AccessToken) (any, util.ToolboxError) {
+	select {
+	case <-time.After(params.AsMap()["sleep"].(time.Duration)):
+		return "done", nil
+	case <-ctx.Done():
+		return nil, util.ProcessGeneralError(ctx.Err())
+	}
+}
+
+func (t sleepTool) ToConfig() tools.ToolConfig { return t.Cfg }
+
+func newSleepTool(timeout string) tools.Tool {
+	cfg := sleepConfig{ConfigBase: tools.ConfigBase{Name: "sleep", Timeout: timeout}}
+	return sleepTool{tools.NewBaseTool(cfg, nil, tools.Manifest{}, nil)}
+}
+
+func TestWrapTimeouts(t *testing.T) {
+	wrapped, err := tools.WrapTimeouts(map[string]tools.Tool{
+		"sleep":     newSleepTool("50ms"),
+		"unbounded": newSleepTool(""),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	sleep := func(d time.Duration) parameters.ParamValues {
+		return parameters.ParamValues{{Name: "sleep", Value: d}}
+	}
+
+	if res, err := wrapped["sleep"].Invoke(context.Background(), nil, sleep(0), ""); err != nil || res != "done" {
+		t.Errorf("expected an invocation within its timeout to complete, got %v, %v", res, err)
+	}
+	_, err = wrapped["sleep"].Invoke(context.Background(), nil, sleep(time.Minute), "")
+	if err == nil {
+		t.Fatalf("expected an invocation past its timeout to fail")
+	}
+	if got := err.(*util.ClientServerError).Code; got != http.StatusGatewayTimeout {
+		t.Errorf("unexpected status: got %d, want %d", got, http.StatusGatewayTimeout)
+	}
+	if res, err := wrapped["unbounded"].Invoke(context.Background(), nil, sleep(100*time.Millisecond), ""); err != nil || res != "done" {
+		t.Errorf("expected a tool without timeout to complete, got %v, %v", res, err)
+	}
+}
+
+func TestWrapTimeoutsInvalid(t *testing.T) {
+	for _, timeout := range []string{"soon", "-1s", "0s"} {
+		if _, err := tools.WrapTimeouts(map[string]tools.Tool{"sleep": newSleepTool(timeout)}); err == nil {
+			t.Errorf("expected timeout %q to be rejected", timeout)
+		}
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"reflect"
 	"slices"
 	"strings"
 
@@ -35,20 +36,69 @@ type ToolConfigFactory func(ctx context.Context, name string, decoder *yaml.Deco
 
 var toolRegistry = make(map[string]ToolConfigFactory)
 
+// toolSourceInterfaces holds the capability interface the source of each
+// tool type must implement.
+var toolSourceInterfaces = make(map[string]reflect.Type)
+
 // Register allows individual tool packages to register their configuration
 // factory function. This is typically called from an init() function in the
 // tool's package. It associates a 'type' string with a function that can
-// produce the specific ToolConfig type. It returns true if the registration was
-// successful, and false if a tool with the same type was already registered.
-func Register(resourceType string, factory ToolConfigFactory) bool {
+// produce the specific ToolConfig type. S is the interface the tool's source
+// must implement, or any for tools without a source. It returns true if the
+// registration was successful, and false if a tool with the same type was
+// already registered.
+func Register[S any](resourceType string, factory ToolConfigFactory) bool {
 	if _, exists := toolRegistry[resourceType]; exists {
 		// Tool with this type already exists, do not overwrite.
 		return false
 	}
 	toolRegistry[resourceType] = factory
+	toolSourceInterfaces[resourceType] = reflect.TypeFor[S]()
 	return true
 }
 
+// VerifySource checks that the source a tool config references is of a type
+// the tool supports. sourceTypes maps the names of the configured sources to
+// their types; references to sources missing from it are not checked.
+func VerifySource(name string, cfg ToolConfig, sourceTypes map[string]string) error {
+	iface, ok := toolSourceInterfaces[cfg.ToolConfigType()]
+	if !ok || iface.Kind() != reflect.Interface {
+		return nil
+	}
+	sourceName, ok := SourceNameOf(cfg)
+	if !ok {
+		return nil
+	}
+	sourceType, ok := sourceTypes[sourceName]
+	if !ok {
+		return nil
+	}
+	if implements, known := sources.Implements(sourceType, iface); implements || !known {
+		return nil
+	}
+	compatible := "none"
+	if types := sources.TypesImplementing(iface); len(types) > 0 {
+		compatible = strings.Join(types, ", ")
+	}
+	return fmt.Errorf("tool %q of type %q references source %q of type %q, which is not compatible; compatible source types: %s", name, cfg.ToolConfigType(), sourceName, sourceType, compatible)
+}
+
+// SourceNameOf returns the value of the Source field of a tool config.
+func SourceNameOf(cfg ToolConfig) (string, bool) {
+	v := reflect.ValueOf(cfg)
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return "", false
+	}
+	f := v.FieldByName("Source")
+	if !f.IsValid() || f.Kind() != reflect.String || f.String() == "" {
+		return "", false
+	}
+	return f.String(), true
+}
+
 var ErrUnknownToolType = fmt.Errorf("unknown tool type")
 
 // DecodeConfig looks up the registered factory for the given type and uses it
@@ -151,6 +201,11 @@ type Manifest struct {
 	Description  string                         `json:"description"`
 	Parameters   []parameters.ParameterManifest `json:"parameters"`
 	AuthRequired []string                       `json:"authRequired"`
+	// Disabled reports that an administrator disabled the tool at runtime.
+	Disabled bool `json:"disabled,omitempty"`
+	// OutputSchema is the JSON schema of the results of the tool, if it
+	// declares one.
+	OutputSchema map[string]any `json:"outputSchema,omitempty"`
 }
 
 // Helper function that returns if a tool invocation request is authorized
@@ -212,16 +267,135 @@ type ToolMeta interface {
 // configs omit description: and rely on a canned per-tool string), so
 // post-Initialize ConfigBase.Description holds the resolved value.
 type ConfigBase struct {
-	Name           string   `yaml:"name"           validate:"required"`
-	Description    string   `yaml:"description"`
-	AuthRequired   []string `yaml:"authRequired"`
-	ScopesRequired []string `yaml:"scopesRequired"`
+	Name           string    `yaml:"name"           validate:"required"`
+	Description    string    `yaml:"description"`
+	AuthRequired   []string  `yaml:"authRequired"`
+	ScopesRequired []string  `yaml:"scopesRequired"`
+	Examples       []Example `yaml:"examples,omitempty"`
+	// Coercion overrides the server-wide coercion mode of the parameter
+	// values of the tool, "strict" or "lenient".
+	Coercion string `yaml:"coercion,omitempty" validate:"omitempty,oneof=strict lenient"`
+	// Translations are the descriptions of the tool by locale. They are
+	// collected from descriptions declared as maps of locale to text.
+	Translations Translations `yaml:"translations,omitempty"`
+	// Intent is what the tool does to data, "read", "write" or "admin".
+	// Write and admin tools require a permissions claim granting it.
+	Intent string `yaml:"intent,omitempty" validate:"omitempty,oneof=read write admin"`
+	// SLALatencyMs is the p95 latency, in milliseconds, the tool is expected
+	// to stay under. It is used to generate latency alerts.
+	SLALatencyMs int `yaml:"slaLatencyMs,omitempty" validate:"omitempty,gt=0"`
+	// SourceParameter lets invocations select the source of the tool among
+	// an allow-list, through an additional parameter.
+	SourceParameter *SourceParameter `yaml:"sourceParameter,omitempty"`
+	// RateLimit caps how often and how many times at once the tool can be
+	// invoked. It is also used to generate API Gateway quotas.
+	RateLimit *RateLimit `yaml:"rateLimit,omitempty"`
+	// Transform post-processes the results of the tool before they are
+	// returned.
+	Transform *Transform `yaml:"transform,omitempty"`
+	// ResponseFormat is the format of the results of the tool on the /api
+	// endpoints, which is a JSON string by default.
+	ResponseFormat string `yaml:"responseFormat,omitempty" validate:"omitempty,oneof=anthropic-content-blocks"`
+	// SupportedFormats are the output formats the results of the tool can be
+	// negotiated in on the /api endpoints, most preferred first. Defaults to
+	// JSON only.
+	SupportedFormats []string `yaml:"supportedFormats,omitempty" validate:"dive,oneof=json csv markdown parquet arrow ndjson"`
+	// AllowedCIDRs are the IPv4 and IPv6 CIDR blocks the tool may be invoked
+	// from. Empty allows any address.
+	AllowedCIDRs []string `yaml:"allowedCIDRs,omitempty" validate:"dive,cidr"`
+	// ParamAliases map the previous names of renamed parameters to their
+	// current names, for migration guides to report the renames.
+	ParamAliases map[string]string `yaml:"paramAliases,omitempty"`
+	// Timeout bounds how long an invocation of the tool may run, as a
+	// duration such as "30s". Its deadline cancels the statement running on
+	// the source. Empty leaves invocations unbounded.
+	Timeout string `yaml:"timeout,omitempty"`
+	// Cache overrides whether and how long the results of the tool are
+	// cached. By default, the results of read-only tools are cached when the
+	// server has a cache backend.
+	Cache *Cache `yaml:"cache,omitempty"`
+	// RequiresApproval runs an invocation of the tool only once it is
+	// approved by the approval endpoint of the server.
+	RequiresApproval bool `yaml:"requiresApproval,omitempty"`
+	// OutputSchema declares the shape of the results of the tool, which are
+	// validated against it and exposed in its manifests.
+	OutputSchema *OutputSchema `yaml:"outputSchema,omitempty"`
+	// MaxResponseBytes bounds the size of the JSON encoded results of the
+	// tool. Zero leaves them unbounded.
+	MaxResponseBytes int `yaml:"maxResponseBytes,omitempty" validate:"omitempty,gt=0"`
+	// OversizedResponse is what happens to a result larger than
+	// MaxResponseBytes: "truncate" (the default), "fail" or "summarize".
+	OversizedResponse string `yaml:"oversizedResponse,omitempty" validate:"omitempty,oneof=truncate fail summarize"`
+}
+
+// Cache configures the caching of the results of a tool.
+type Cache struct {
+	// Enabled caches the results of the tool, or never caches them if false.
+	// Unset caches them if the tool is read-only and the server has a cache
+	// backend.
+	Enabled *bool `yaml:"enabled,omitempty"`
+	// TTL is how long results are cached, as a duration such as "10m".
+	// Defaults to the TTL of the server.
+	TTL string `yaml:"ttl,omitempty"`
+}
+
+// ResponseFormatAnthropicContentBlocks formats results as an Anthropic
+// tool_result block, with a text content block for each row.
+const ResponseFormatAnthropicContentBlocks = "anthropic-content-blocks"
+
+func (c ConfigBase) GetName() string               { return c.Name }
+func (c ConfigBase) GetDescription() string        { return c.Description }
+func (c ConfigBase) GetAuthRequired() []string     { return c.AuthRequired }
+func (c ConfigBase) GetScopesRequired() []string   { return c.ScopesRequired }
+func (c ConfigBase) GetExamples() []Example        { return c.Examples }
+func (c ConfigBase) GetCoercion() string           { return c.Coercion }
+func (c ConfigBase) GetTranslations() Translations { return c.Translations }
+func (c ConfigBase) GetIntent() string             { return c.Intent }
+func (c ConfigBase) GetSLALatencyMs() int          { return c.SLALatencyMs }
+func (c ConfigBase) GetRateLimit() *RateLimit      { return c.RateLimit }
+func (c ConfigBase) GetTransform() *Transform      { return c.Transform }
+func (c ConfigBase) GetSourceParameter() *SourceParameter {
+	return c.SourceParameter
+}
+func (c ConfigBase) GetResponseFormat() string     { return c.ResponseFormat }
+func (c ConfigBase) GetSupportedFormats() []string { return c.SupportedFormats }
+func (c ConfigBase) GetAllowedCIDRs() []string     { return c.AllowedCIDRs }
+func (c ConfigBase) GetParamAliases() map[string]string {
+	return c.ParamAliases
+}
+func (c ConfigBase) GetTimeout() string        { return c.Timeout }
+func (c ConfigBase) GetCache() *Cache          { return c.Cache }
+func (c ConfigBase) GetRequiresApproval() bool { return c.RequiresApproval }
+func (c ConfigBase) GetOutputSchema() *OutputSchema {
+	return c.OutputSchema
+}
+func (c ConfigBase) GetMaxResponseBytes() int     { return c.MaxResponseBytes }
+func (c ConfigBase) GetOversizedResponse() string { return c.OversizedResponse }
+
+// CoerceParams converts the loosely typed values in data to the declared types
+// of params when tool, or else the server, uses lenient coercion. Strict
+// coercion returns data unchanged.
+func CoerceParams(ctx context.Context, tool Tool, params parameters.Parameters, data map[string]any) map[string]any {
+	mode := util.ParamCoercionFromContext(ctx)
+	if c, ok := tool.ToConfig().(interface{ GetCoercion() string }); ok && c.GetCoercion() != "" {
+		mode = c.GetCoercion()
+	}
+	if mode != parameters.CoercionLenient {
+		return data
+	}
+	return parameters.CoerceParams(ctx, params, data)
 }
 
-func (c ConfigBase) GetName() string             { return c.Name }
-func (c ConfigBase) GetDescription() string      { return c.Description }
-func (c ConfigBase) GetAuthRequired() []string   { return c.AuthRequired }
-func (c ConfigBase) GetScopesRequired() []string { return c.ScopesRequired }
+// Example is a sample set of parameter values for a tool. Examples are not
+// used when invoking tools; they feed offline generators such as
+// `toolbox gen-loadtest` and `toolbox doc`, and `toolbox test` runs them.
+type Example struct {
+	Description string         `yaml:"description,omitempty"`
+	Parameters  map[string]any `yaml:"parameters"`
+	// ExpectedRows are the rows the tool returns for the example. When set,
+	// `toolbox test` fails unless the result of the tool equals them.
+	ExpectedRows []map[string]any `yaml:"expectedRows,omitempty"`
+}
 
 // BaseTool provides default implementations of various methods on the Tool
 // interface. Tools embed BaseTool to drop their boilerplate and override
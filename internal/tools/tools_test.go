@@ -20,6 +20,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/googleapis/mcp-toolbox/internal/embeddingmodels"
+	"github.com/googleapis/mcp-toolbox/internal/sources"
 	"github.com/googleapis/mcp-toolbox/internal/tools"
 	"github.com/googleapis/mcp-toolbox/internal/util"
 	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
@@ -170,3 +171,78 @@ func TestBaseToolEmbedParamsPassthrough(t *testing.T) {
 		t.Errorf("EmbedParams() mismatch (-want +got):\n%s", diff)
 	}
 }
+
+type queryRunner interface {
+	RunQuery(string) (any, error)
+}
+
+type fakeQuerySource struct{}
+
+func (*fakeQuerySource) SourceType() string             { return "fake-query-source" }
+func (*fakeQuerySource) ToConfig() sources.SourceConfig { return nil }
+func (*fakeQuerySource) RunQuery(string) (any, error)   { return nil, nil }
+
+type fakeOtherQuerySource struct{ fakeQuerySource }
+
+func (*fakeOtherQuerySource) SourceType() string { return "fake-other-query-source" }
+
+type fakeKVSource struct{}
+
+func (*fakeKVSource) SourceType() string             { return "fake-kv-source" }
+func (*fakeKVSource) ToConfig() sources.SourceConfig { return nil }
+
+type fakeQueryToolConfig struct {
+	tools.ConfigBase
+	Source string
+}
+
+func (fakeQueryToolConfig) ToolConfigType() string { return "fake-query-tool" }
+func (fakeQueryToolConfig) Initialize(context.Context) (tools.Tool, error) {
+	return nil, nil
+}
+
+func init() {
+	sources.Register[*fakeQuerySource]("fake-query-source", nil)
+	sources.Register[*fakeOtherQuerySource]("fake-other-query-source", nil)
+	sources.Register[*fakeKVSource]("fake-kv-source", nil)
+	tools.Register[queryRunner]("fake-query-tool", nil)
+}
+
+func TestVerifySource(t *testing.T) {
+	sourceTypes := map[string]string{
+		"query":       "fake-query-source",
+		"other-query": "fake-other-query-source",
+		"kv":          "fake-kv-source",
+		"unknown":     "fake-unregistered-source",
+	}
+	tcs := []struct {
+		desc      string
+		source    string
+		wantError string
+	}{
+		{desc: "same type", source: "query"},
+		{desc: "different type implementing the interface", source: "other-query"},
+		{
+			desc:      "incompatible type",
+			source:    "kv",
+			wantError: `tool "my-tool" of type "fake-query-tool" references source "kv" of type "fake-kv-source", which is not compatible; compatible source types: fake-other-query-source, fake-query-source`,
+		},
+		{desc: "unregistered source type", source: "unknown"},
+		{desc: "undefined source", source: "missing"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := fakeQueryToolConfig{Source: tc.source}
+			err := tools.VerifySource("my-tool", cfg, sourceTypes)
+			if tc.wantError == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.wantError {
+				t.Fatalf("unexpected error: got %v, want %q", err, tc.wantError)
+			}
+		})
+	}
+}
@@ -17,20 +17,26 @@ package tools
 import (
 	"fmt"
 	"regexp"
+	"slices"
 
 	"github.com/googleapis/mcp-toolbox/internal/sources"
 )
 
 type ToolsetConfig struct {
-	Name      string   `yaml:"name"`
-	ToolNames []string `yaml:",inline"`
+	Name          string   `yaml:"name"`
+	ToolNames     []string `yaml:",inline"`
+	ResourceNames []string `yaml:"resources"`
+	// Locale is the locale of the tool descriptions served for the toolset,
+	// unless requests ask for another.
+	Locale string `yaml:"locale"`
 }
 
 type Toolset struct {
 	ToolsetConfig
-	Tools       []*Tool         `yaml:",inline"`
-	Manifest    ToolsetManifest `yaml:",inline"`
-	toolNameSet map[string]struct{}
+	Tools           []*Tool         `yaml:",inline"`
+	Manifest        ToolsetManifest `yaml:",inline"`
+	toolNameSet     map[string]struct{}
+	resourceNameSet map[string]struct{}
 }
 
 func (t Toolset) ToConfig() ToolsetConfig {
@@ -53,9 +59,41 @@ func (t Toolset) ContainsTool(name string) bool {
 	return false
 }
 
+// ContainsResource reports whether the toolset exposes a resource with the
+// given name.
+func (t Toolset) ContainsResource(name string) bool {
+	if t.resourceNameSet != nil {
+		_, ok := t.resourceNameSet[name]
+		return ok
+	}
+	return slices.Contains(t.ResourceNames, name)
+}
+
+// Filter returns a copy of the toolset holding only the tools for which keep
+// returns true.
+func (t Toolset) Filter(keep func(name string) bool) Toolset {
+	filtered := t
+	filtered.ToolNames = make([]string, 0, len(t.Tools))
+	filtered.Tools = make([]*Tool, 0, len(t.Tools))
+	filtered.toolNameSet = make(map[string]struct{}, len(t.Tools))
+	for _, tool := range t.Tools {
+		name := (*tool).GetName()
+		if !keep(name) {
+			continue
+		}
+		filtered.ToolNames = append(filtered.ToolNames, name)
+		filtered.Tools = append(filtered.Tools, tool)
+		filtered.toolNameSet[name] = struct{}{}
+	}
+	return filtered
+}
+
 type ToolsetManifest struct {
 	ServerVersion string              `json:"serverVersion"`
 	ToolsManifest map[string]Manifest `json:"tools"`
+	// NextPageToken lists the next page of the tools of a toolset listed
+	// in pages.
+	NextPageToken string `json:"nextPageToken,omitempty"`
 }
 
 // BuildManifest resolves the manifest for every tool in the toolset against the
@@ -81,7 +119,8 @@ func (t ToolsetConfig) Initialize(serverVersion string, toolsMap map[string]Tool
 		Manifest: ToolsetManifest{
 			ServerVersion: serverVersion,
 		},
-		toolNameSet: make(map[string]struct{}, len(t.ToolNames)),
+		toolNameSet:     make(map[string]struct{}, len(t.ToolNames)),
+		resourceNameSet: make(map[string]struct{}, len(t.ResourceNames)),
 	}
 	if !IsValidName(toolset.Name) {
 		return toolset, fmt.Errorf("invalid toolset name: %s", toolset.Name)
@@ -94,6 +133,9 @@ func (t ToolsetConfig) Initialize(serverVersion string, toolsMap map[string]Tool
 		toolset.Tools = append(toolset.Tools, &tool)
 		toolset.toolNameSet[toolName] = struct{}{}
 	}
+	for _, resourceName := range t.ResourceNames {
+		toolset.resourceNameSet[resourceName] = struct{}{}
+	}
 	return toolset, nil
 }
 
@@ -0,0 +1,82 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+
+	"github.com/googleapis/mcp-toolbox/internal/telemetry"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// The phases of a tool invocation, each traced as a child span of the span
+// of the invocation.
+const (
+	PhaseAuthorize   = "authorize"
+	PhaseParseParams = "parse_params"
+	PhaseExecute     = "execute"
+	PhaseMarshal     = "marshal"
+)
+
+// InvocationTrace traces the phases of a tool invocation. A phase lasts until
+// the next one starts or End is called, so that deferring End covers the
+// invocations that return early.
+type InvocationTrace struct {
+	tracer  trace.Tracer
+	parent  trace.Span
+	attrs   []attribute.KeyValue
+	current trace.Span
+}
+
+// TraceInvocation returns the trace of an invocation of tool, whose span is
+// the span of ctx. The span of the invocation and of its phases are
+// attributed the name of the tool and of its source.
+func TraceInvocation(ctx context.Context, tool Tool) *InvocationTrace {
+	parent := trace.SpanFromContext(ctx)
+	tracer := parent.TracerProvider().Tracer(telemetry.TracerName)
+	if instrumentation, err := util.InstrumentationFromContext(ctx); err == nil {
+		tracer = instrumentation.Tracer
+	}
+	attrs := []attribute.KeyValue{attribute.String("gen_ai.tool.name", tool.GetName())}
+	if sourceName, ok := SourceNameOf(tool.ToConfig()); ok {
+		attrs = append(attrs, attribute.String("toolbox.source.name", sourceName))
+	}
+	parent.SetAttributes(attrs...)
+	return &InvocationTrace{tracer: tracer, parent: parent, attrs: attrs}
+}
+
+// Phase ends the current phase and starts the given one, returning ctx with
+// the span of the phase.
+func (t *InvocationTrace) Phase(ctx context.Context, phase string) context.Context {
+	t.End(nil)
+	ctx, t.current = t.tracer.Start(trace.ContextWithSpan(ctx, t.parent), "toolbox/tool/"+phase, trace.WithAttributes(t.attrs...))
+	return ctx
+}
+
+// End ends the current phase, marking it as failed with err if not nil.
+func (t *InvocationTrace) End(err error) {
+	if t.current == nil {
+		return
+	}
+	if err != nil {
+		t.current.RecordError(err)
+		t.current.SetStatus(codes.Error, err.Error())
+	}
+	t.current.End()
+	t.current = nil
+}
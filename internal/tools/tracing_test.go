@@ -0,0 +1,76 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTraceInvocation(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	ctx, invocation := provider.Tracer("test").Start(context.Background(), "invoke")
+
+	cfg := sourcedConfig{ConfigBase: tools.ConfigBase{Name: "my-tool"}, Source: "my-pg"}
+	phases := tools.TraceInvocation(ctx, sourcedTool{tools.NewBaseTool(cfg, nil, tools.Manifest{}, nil)})
+	ctx = phases.Phase(ctx, tools.PhaseAuthorize)
+	ctx = phases.Phase(ctx, tools.PhaseParseParams)
+	_ = phases.Phase(ctx, tools.PhaseExecute)
+	phases.End(errors.New("boom"))
+	phases.End(nil)
+	invocation.End()
+
+	spans := recorder.Ended()
+	wantNames := []string{"toolbox/tool/authorize", "toolbox/tool/parse_params", "toolbox/tool/execute", "invoke"}
+	if len(spans) != len(wantNames) {
+		t.Fatalf("got %d spans, want %d", len(spans), len(wantNames))
+	}
+	wantAttrs := []attribute.KeyValue{
+		attribute.String("gen_ai.tool.name", "my-tool"),
+		attribute.String("toolbox.source.name", "my-pg"),
+	}
+	for i, span := range spans {
+		if span.Name() != wantNames[i] {
+			t.Errorf("span %d: got name %q, want %q", i, span.Name(), wantNames[i])
+		}
+		attrs := attribute.NewSet(span.Attributes()...)
+		for _, want := range wantAttrs {
+			if got, ok := attrs.Value(want.Key); !ok || got != want.Value {
+				t.Errorf("span %q: got %s %v, want %v", span.Name(), want.Key, got.Emit(), want.Value.Emit())
+			}
+		}
+		if span.Name() == "invoke" {
+			continue
+		}
+		// the phases are siblings, children of the span of the invocation
+		if span.Parent().SpanID() != invocation.SpanContext().SpanID() {
+			t.Errorf("span %q is not a child of the span of the invocation", span.Name())
+		}
+	}
+	if spans[2].Status().Code != codes.Error {
+		t.Errorf("got status %v for a failed execution, want an error", spans[2].Status().Code)
+	}
+	if spans[1].Status().Code == codes.Error {
+		t.Errorf("got an error status for a successful phase")
+	}
+}
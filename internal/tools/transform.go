@@ -0,0 +1,402 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/orderedmap"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+// Transform post-processes the results of a tool before they are returned,
+// such as to strip PII fields without rewriting the statement of the tool.
+//
+// The steps are applied to each row of the result, or to the result itself
+// if it is a single object, in a fixed order:
+//  1. limit keeps the first rows;
+//  2. select replaces each row by the listed fields, which are paths such
+//     as .address.city or .tags[0], named after their last key;
+//  3. renameFields renames the fields;
+//  4. dropFields removes the listed fields, by their renamed names;
+//  5. maskFields replaces the values of the listed fields, by their renamed
+//     names, with MaskedValue.
+type Transform struct {
+	// Limit is the maximum number of rows returned.
+	Limit int `yaml:"limit,omitempty" validate:"omitempty,gt=0"`
+	// Select lists the paths of the fields the rows are projected to.
+	Select []string `yaml:"select,omitempty"`
+	// RenameFields renames fields, from their name in the result to the
+	// name returned to the agent.
+	RenameFields map[string]string `yaml:"renameFields,omitempty"`
+	// DropFields lists the fields removed from the rows.
+	DropFields []string `yaml:"dropFields,omitempty"`
+	// MaskFields lists the fields whose values are masked.
+	MaskFields []string `yaml:"maskFields,omitempty"`
+}
+
+// transformOf returns the transform of a tool config, or nil if it has none.
+func transformOf(cfg ToolConfig) *Transform {
+	if c, ok := cfg.(interface{ GetTransform() *Transform }); ok {
+		return c.GetTransform()
+	}
+	return nil
+}
+
+// selectedField is a field of Transform.Select.
+type selectedField struct {
+	name string
+	// path holds the keys, as strings, and the indices, as ints, leading to
+	// the field.
+	path []any
+}
+
+// parseFieldPath parses a path such as .address.city or .tags[0]. The
+// leading dot is optional.
+func parseFieldPath(s string) ([]any, error) {
+	rest := strings.TrimPrefix(strings.TrimSpace(s), ".")
+	var path []any
+	for rest != "" {
+		switch {
+		case rest[0] == '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated index")
+			}
+			i, err := strconv.Atoi(rest[1:end])
+			if err != nil || i < 0 {
+				return nil, fmt.Errorf("invalid index %q", rest[1:end])
+			}
+			path = append(path, i)
+			rest = rest[end+1:]
+		case rest[0] == '.':
+			rest = rest[1:]
+			if rest == "" || rest[0] == '.' || rest[0] == '[' {
+				return nil, fmt.Errorf("empty key")
+			}
+		default:
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			path = append(path, rest[:end])
+			rest = rest[end:]
+		}
+	}
+	if len(path) == 0 {
+		return nil, fmt.Errorf("empty path")
+	}
+	return path, nil
+}
+
+// NewTransformer validates the transform and returns the transformer
+// applying it to the results of toolName.
+func (c Transform) NewTransformer(toolName string) (*Transformer, error) {
+	t := &Transformer{cfg: c, drop: make(map[string]bool), mask: make(map[string]bool)}
+	names := make(map[string]string, len(c.Select))
+	for _, s := range c.Select {
+		path, err := parseFieldPath(s)
+		if err != nil {
+			return nil, fmt.Errorf("select of tool %q has an invalid path %q: %w", toolName, s, err)
+		}
+		name := ""
+		for _, p := range path {
+			if key, ok := p.(string); ok {
+				name = key
+			}
+		}
+		if name == "" {
+			return nil, fmt.Errorf("select of tool %q has a path %q without a key to name the field", toolName, s)
+		}
+		if other, ok := names[name]; ok {
+			return nil, fmt.Errorf("select of tool %q has both %q and %q named %q", toolName, other, s, name)
+		}
+		names[name] = s
+		t.selected = append(t.selected, selectedField{name: name, path: path})
+	}
+	renamed := make(map[string]string, len(c.RenameFields))
+	for from, to := range c.RenameFields {
+		if from == "" || to == "" {
+			return nil, fmt.Errorf("renameFields of tool %q cannot rename %q to %q: field names cannot be empty", toolName, from, to)
+		}
+		if other, ok := renamed[to]; ok {
+			first, second := min(from, other), max(from, other)
+			return nil, fmt.Errorf("renameFields of tool %q renames both %q and %q to %q", toolName, first, second, to)
+		}
+		renamed[to] = from
+	}
+	for _, name := range c.DropFields {
+		t.drop[name] = true
+	}
+	for _, name := range c.MaskFields {
+		t.mask[name] = true
+	}
+	return t, nil
+}
+
+// Transformer applies a Transform to results.
+type Transformer struct {
+	cfg      Transform
+	selected []selectedField
+	drop     map[string]bool
+	mask     map[string]bool
+}
+
+// Apply transforms result, a slice of rows or a single object. Rows of
+// other types are converted through JSON, and kept unchanged if they are
+// not objects. A nil transformer returns result unchanged.
+func (t *Transformer) Apply(result any) any {
+	if t == nil {
+		return result
+	}
+	rows, ok := result.([]any)
+	if !ok {
+		if row, ok := t.transformRow(result); ok {
+			return row
+		}
+		return result
+	}
+	if t.cfg.Limit > 0 && len(rows) > t.cfg.Limit {
+		rows = rows[:t.cfg.Limit]
+	}
+	return t.transformRows(rows)
+}
+
+// transformRows transforms rows, without limiting them.
+func (t *Transformer) transformRows(rows []any) []any {
+	out := make([]any, len(rows))
+	for i, row := range rows {
+		if r, ok := t.transformRow(row); ok {
+			out[i] = r
+		} else {
+			out[i] = row
+		}
+	}
+	return out
+}
+
+// transformRow transforms row, or reports that it is not an object.
+func (t *Transformer) transformRow(row any) (any, bool) {
+	var r orderedmap.Row
+	switch v := row.(type) {
+	case orderedmap.Row:
+		r = v
+	case map[string]any:
+		return t.transformMap(v), true
+	default:
+		m, ok := toJSONValue(row).(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		return t.transformMap(m), true
+	}
+	if len(t.selected) > 0 {
+		selected := orderedmap.Row{Columns: make([]orderedmap.Column, 0, len(t.selected))}
+		for _, f := range t.selected {
+			selected.Add(f.name, lookupPath(r, f.path))
+		}
+		r = selected
+	}
+	out := orderedmap.Row{Columns: make([]orderedmap.Column, 0, len(r.Columns))}
+	for _, col := range r.Columns {
+		name := t.rename(col.Name)
+		if !t.drop[name] {
+			out.Add(name, t.maskValue(name, col.Value))
+		}
+	}
+	return out, true
+}
+
+func (t *Transformer) transformMap(m map[string]any) map[string]any {
+	if len(t.selected) > 0 {
+		selected := make(map[string]any, len(t.selected))
+		for _, f := range t.selected {
+			selected[f.name] = lookupPath(m, f.path)
+		}
+		m = selected
+	}
+	out := make(map[string]any, len(m))
+	for name, value := range m {
+		name = t.rename(name)
+		if !t.drop[name] {
+			out[name] = t.maskValue(name, value)
+		}
+	}
+	return out
+}
+
+func (t *Transformer) rename(name string) string {
+	if to, ok := t.cfg.RenameFields[name]; ok {
+		return to
+	}
+	return name
+}
+
+func (t *Transformer) maskValue(name string, value any) any {
+	if t.mask[name] && value != nil {
+		return MaskedValue
+	}
+	return value
+}
+
+// lookupPath returns the value at path in v, or nil if there is none.
+func lookupPath(v any, path []any) any {
+	for _, p := range path {
+		switch step := p.(type) {
+		case string:
+			switch obj := v.(type) {
+			case orderedmap.Row:
+				v = nil
+				for _, col := range obj.Columns {
+					if col.Name == step {
+						v = col.Value
+						break
+					}
+				}
+			case map[string]any:
+				v = obj[step]
+			default:
+				m, ok := toJSONValue(v).(map[string]any)
+				if !ok {
+					return nil
+				}
+				v = m[step]
+			}
+		case int:
+			arr, ok := v.([]any)
+			if !ok {
+				if arr, ok = toJSONValue(v).([]any); !ok {
+					return nil
+				}
+			}
+			if step >= len(arr) {
+				return nil
+			}
+			v = arr[step]
+		}
+	}
+	return v
+}
+
+// toJSONValue returns v decoded from its JSON encoding, or nil if it cannot
+// be encoded. JSON strings holding an object or an array are decoded too, as
+// returned for JSON columns by some sources.
+func toJSONValue(v any) any {
+	switch s := v.(type) {
+	case nil:
+		return nil
+	case string:
+		var decoded any
+		if trimmed := strings.TrimSpace(s); strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
+			if json.Unmarshal([]byte(trimmed), &decoded) == nil {
+				return decoded
+			}
+		}
+		return s
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	var decoded any
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		return nil
+	}
+	return decoded
+}
+
+// WrapTransforms returns the tools with every tool that has a transform
+// applying it to its results.
+func WrapTransforms(toolsMap map[string]Tool) (map[string]Tool, error) {
+	wrapped := make(map[string]Tool, len(toolsMap))
+	for name, t := range toolsMap {
+		cfg := transformOf(t.ToConfig())
+		if cfg == nil {
+			wrapped[name] = t
+			continue
+		}
+		transformer, err := cfg.NewTransformer(name)
+		if err != nil {
+			return nil, err
+		}
+		transformed := transformedTool{Tool: t, transformer: transformer}
+		if streamer, ok := t.(RowStreamer); ok {
+			wrapped[name] = transformedStreamer{transformedTool: transformed, streamer: streamer}
+			continue
+		}
+		wrapped[name] = transformed
+	}
+	return wrapped, nil
+}
+
+// transformedTool is a tool whose results are transformed.
+type transformedTool struct {
+	Tool
+	transformer *Transformer
+}
+
+// Unwrap implements Wrapper.
+func (t transformedTool) Unwrap() Tool { return t.Tool }
+
+func (t transformedTool) Invoke(ctx context.Context, sp SourceProvider, params parameters.ParamValues, token AccessToken) (any, util.ToolboxError) {
+	result, err := t.Tool.Invoke(ctx, sp, params, token)
+	if err != nil {
+		return nil, err
+	}
+	return t.transformer.Apply(result), nil
+}
+
+// errLimitReached stops streaming the rows of a transformed tool once its
+// limit is reached.
+var errLimitReached = errors.New("transform limit reached")
+
+// transformedStreamer is a transformed tool that streams its rows.
+type transformedStreamer struct {
+	transformedTool
+	streamer RowStreamer
+}
+
+func (t transformedStreamer) StreamRows(ctx context.Context, sp SourceProvider, params parameters.ParamValues, token AccessToken, emit func(rows []any) error) util.ToolboxError {
+	limit := t.transformer.cfg.Limit
+	sent := 0
+	stopped := false
+	err := t.streamer.StreamRows(ctx, sp, params, token, func(rows []any) error {
+		if limit > 0 && sent+len(rows) >= limit {
+			rows = rows[:limit-sent]
+		}
+		sent += len(rows)
+		if len(rows) > 0 {
+			if err := emit(t.transformer.transformRows(rows)); err != nil {
+				return err
+			}
+		}
+		if limit > 0 && sent >= limit {
+			stopped = true
+			return errLimitReached
+		}
+		return nil
+	})
+	// the source may wrap the error stopping it at the limit
+	if err != nil && (stopped || errors.Is(err, errLimitReached)) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,199 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+func TestTransformerApply(t *testing.T) {
+	tcs := []struct {
+		desc      string
+		transform tools.Transform
+		in        any
+		want      any
+	}{
+		{
+			desc:      "rename, drop and mask",
+			transform: tools.Transform{RenameFields: map[string]string{"mail": "email"}, DropFields: []string{"ssn"}, MaskFields: []string{"email"}},
+			in:        []any{row("id", 1, "mail", "a@example.com", "ssn", "123")},
+			want:      []any{row("id", 1, "email", tools.MaskedValue)},
+		},
+		{
+			desc:      "limit",
+			transform: tools.Transform{Limit: 2},
+			in:        []any{row("id", 1), row("id", 2), row("id", 3)},
+			want:      []any{row("id", 1), row("id", 2)},
+		},
+		{
+			desc:      "select paths",
+			transform: tools.Transform{Select: []string{".name", ".address.city", "tags[1]"}},
+			in: []any{row(
+				"name", "Alice",
+				"address", map[string]any{"city": "Paris", "zip": "75001"},
+				"tags", `["a", "b"]`,
+				"ssn", "123",
+			)},
+			want: []any{row("name", "Alice", "city", "Paris", "tags", "b")},
+		},
+		{
+			desc:      "select missing path",
+			transform: tools.Transform{Select: []string{".address.city"}},
+			in:        []any{map[string]any{"name": "Alice"}},
+			want:      []any{map[string]any{"city": nil}},
+		},
+		{
+			desc:      "renamed after select",
+			transform: tools.Transform{Select: []string{".address.city"}, RenameFields: map[string]string{"city": "town"}},
+			in:        []any{map[string]any{"address": map[string]any{"city": "Paris"}}},
+			want:      []any{map[string]any{"town": "Paris"}},
+		},
+		{
+			desc:      "single object",
+			transform: tools.Transform{DropFields: []string{"token"}},
+			in:        map[string]any{"id": 1, "token": "secret"},
+			want:      map[string]any{"id": 1},
+		},
+		{
+			desc:      "struct rows",
+			transform: tools.Transform{MaskFields: []string{"Name"}},
+			in:        []any{struct{ ID, Name string }{"1", "Alice"}},
+			want:      []any{map[string]any{"ID": "1", "Name": tools.MaskedValue}},
+		},
+		{
+			desc:      "other results",
+			transform: tools.Transform{DropFields: []string{"id"}},
+			in:        "done",
+			want:      "done",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			transformer, err := tc.transform.NewTransformer("my-tool")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, transformer.Apply(tc.in)); diff != "" {
+				t.Errorf("incorrect result (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNewTransformerErrors(t *testing.T) {
+	tcs := []struct {
+		desc      string
+		transform tools.Transform
+		want      string
+	}{
+		{
+			desc:      "invalid index",
+			transform: tools.Transform{Select: []string{".tags[x]"}},
+			want:      `select of tool "my-tool" has an invalid path ".tags[x]": invalid index "x"`,
+		},
+		{
+			desc:      "empty key",
+			transform: tools.Transform{Select: []string{".address..city"}},
+			want:      `select of tool "my-tool" has an invalid path ".address..city": empty key`,
+		},
+		{
+			desc:      "no key",
+			transform: tools.Transform{Select: []string{"[0]"}},
+			want:      `select of tool "my-tool" has a path "[0]" without a key to name the field`,
+		},
+		{
+			desc:      "same name",
+			transform: tools.Transform{Select: []string{".home.city", ".work.city"}},
+			want:      `select of tool "my-tool" has both ".home.city" and ".work.city" named "city"`,
+		},
+		{
+			desc:      "same rename",
+			transform: tools.Transform{RenameFields: map[string]string{"a": "c", "b": "c"}},
+			want:      `renameFields of tool "my-tool" renames both "a" and "b" to "c"`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := tc.transform.NewTransformer("my-tool")
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			if err.Error() != tc.want {
+				t.Errorf("got error %q, want %q", err, tc.want)
+			}
+		})
+	}
+}
+
+// streamingTool streams its rows in batches of one.
+type streamingTool struct {
+	tools.Tool
+	cfg  tools.ToolConfig
+	rows []any
+}
+
+func (t streamingTool) ToConfig() tools.ToolConfig { return t.cfg }
+
+func (t streamingTool) StreamRows(ctx context.Context, sp tools.SourceProvider, params parameters.ParamValues, token tools.AccessToken, emit func(rows []any) error) util.ToolboxError {
+	for _, r := range t.rows {
+		if err := emit([]any{r}); err != nil {
+			return util.ProcessGeneralError(err)
+		}
+	}
+	return nil
+}
+
+type transformConfig struct {
+	tools.ToolConfig
+	tools.ConfigBase
+}
+
+func TestWrapTransformsStreamLimit(t *testing.T) {
+	cfg := transformConfig{ConfigBase: tools.ConfigBase{Transform: &tools.Transform{Limit: 2, DropFields: []string{"ssn"}}}}
+	tool := streamingTool{cfg: cfg, rows: []any{row("id", 1, "ssn", "1"), row("id", 2, "ssn", "2"), row("id", 3, "ssn", "3")}}
+	wrapped, err := tools.WrapTransforms(map[string]tools.Tool{"my-tool": tool})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	streamer, ok := wrapped["my-tool"].(tools.RowStreamer)
+	if !ok {
+		t.Fatalf("transformed tool does not stream its rows")
+	}
+	var got []any
+	if err := streamer.StreamRows(context.Background(), nil, nil, "", func(rows []any) error {
+		got = append(got, rows...)
+		return nil
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff([]any{row("id", 1), row("id", 2)}, got); diff != "" {
+		t.Errorf("incorrect rows (-want +got):\n%s", diff)
+	}
+
+	// errors of emit are returned
+	emitErr := errors.New("client gone")
+	err = streamer.StreamRows(context.Background(), nil, nil, "", func(rows []any) error { return emitErr })
+	if err == nil || !strings.Contains(err.Error(), "client gone") {
+		t.Errorf("got error %v, want %v", err, emitErr)
+	}
+}
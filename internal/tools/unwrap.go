@@ -0,0 +1,39 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+// Wrapper is implemented by tools that wrap another tool. Embedding the
+// wrapped tool hides the optional interfaces it implements, which As finds
+// through Unwrap.
+type Wrapper interface {
+	Unwrap() Tool
+}
+
+// As finds the first tool in the chain of tools wrapped by tool that
+// implements T, starting with tool itself.
+func As[T any](tool Tool) (T, bool) {
+	for tool != nil {
+		if t, ok := tool.(T); ok {
+			return t, true
+		}
+		w, ok := tool.(Wrapper)
+		if !ok {
+			break
+		}
+		tool = w.Unwrap()
+	}
+	var zero T
+	return zero, false
+}
@@ -0,0 +1,44 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools_test
+
+import (
+	"testing"
+
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+)
+
+// monitoredTool is a tool monitoring its query plan.
+type monitoredTool struct {
+	stubTool
+}
+
+func (monitoredTool) ResetPlanHash() bool { return true }
+
+func TestAs(t *testing.T) {
+	cfg := stubConfig{ConfigBase: tools.ConfigBase{Name: "monitored"}}
+	monitored := monitoredTool{stubTool{tools.NewBaseTool(cfg, tools.NewReadOnlyAnnotations(), tools.Manifest{}, nil)}}
+	wrapped := tools.MarkReadOnly(tools.WrapRateLimits(map[string]tools.Tool{"monitored": monitored}, tools.RateLimit{MaxConcurrency: 1}))
+	if _, ok := wrapped["monitored"].(tools.PlanMonitor); ok {
+		t.Fatalf("expected the wrappers to hide the plan monitor")
+	}
+	monitor, ok := tools.As[tools.PlanMonitor](wrapped["monitored"])
+	if !ok || !monitor.ResetPlanHash() {
+		t.Fatalf("expected the plan monitor to be found through the wrappers")
+	}
+	if _, ok := tools.As[tools.PlanMonitor](newIntentTool(tools.IntentRead)); ok {
+		t.Errorf("expected no plan monitor for a tool without one")
+	}
+}
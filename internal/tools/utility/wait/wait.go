@@ -28,7 +28,7 @@ import (
 const resourceType string = "wait"
 
 func init() {
-	if !tools.Register(resourceType, newConfig) {
+	if !tools.Register[any](resourceType, newConfig) {
 		panic(fmt.Sprintf("tool type %q already registered", resourceType))
 	}
 }
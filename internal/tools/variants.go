@@ -0,0 +1,105 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+
+	"github.com/googleapis/mcp-toolbox/internal/util"
+)
+
+// VariantWeightTotal is the sum the weights of the variants of a tool must
+// add up to.
+const VariantWeightTotal = 100
+
+// Variant is an alternative statement of a tool, run for a share of the
+// invocations proportional to its weight.
+type Variant struct {
+	Name string `yaml:"name" validate:"required"`
+	// Statement replaces the statement of the tool. Empty runs the statement
+	// of the tool, which makes the variant the control group.
+	Statement string `yaml:"statement"`
+	Weight    int    `yaml:"weight"`
+}
+
+// Variants splits the invocations of a tool between statements.
+type Variants []Variant
+
+// Validate checks the variants of toolName: names are unique and weights
+// are not negative and add up to VariantWeightTotal.
+func (vs Variants) Validate(toolName string) error {
+	if len(vs) == 0 {
+		return nil
+	}
+	names := make(map[string]bool, len(vs))
+	total := 0
+	for _, v := range vs {
+		if names[v.Name] {
+			return fmt.Errorf("variant %q of tool %q is defined more than once", v.Name, toolName)
+		}
+		names[v.Name] = true
+		if v.Weight < 0 {
+			return fmt.Errorf("variant %q of tool %q has a negative weight", v.Name, toolName)
+		}
+		total += v.Weight
+	}
+	if total != VariantWeightTotal {
+		return fmt.Errorf("weights of the variants of tool %q add up to %d, must be %d", toolName, total, VariantWeightTotal)
+	}
+	return nil
+}
+
+// Select returns the statement to run for an invocation: the statement of a
+// variant chosen at random according to the weights, of the variant forced
+// for the invocation, or statement if there are no variants. The selected
+// variant is recorded in the util.ToolVariant of ctx.
+func (vs Variants) Select(ctx context.Context, statement string) (string, error) {
+	if len(vs) == 0 {
+		return statement, nil
+	}
+	tv := util.ToolVariantFromContext(ctx)
+
+	var selected *Variant
+	if tv != nil && tv.Forced != "" {
+		for i := range vs {
+			if vs[i].Name == tv.Forced {
+				selected = &vs[i]
+				break
+			}
+		}
+		if selected == nil {
+			return "", fmt.Errorf("forced variant %q does not exist", tv.Forced)
+		}
+	} else {
+		n := rand.IntN(VariantWeightTotal)
+		for i := range vs {
+			if n < vs[i].Weight {
+				selected = &vs[i]
+				break
+			}
+			n -= vs[i].Weight
+		}
+	}
+
+	if tv != nil {
+		tv.Selected = selected.Name
+	}
+	if selected.Statement == "" {
+		return statement, nil
+	}
+	return selected.Statement, nil
+}
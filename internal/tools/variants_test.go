@@ -0,0 +1,140 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+)
+
+func TestVariantsValidate(t *testing.T) {
+	tcs := []struct {
+		desc     string
+		variants tools.Variants
+		err      string
+	}{
+		{
+			desc: "valid",
+			variants: tools.Variants{
+				{Name: "control", Weight: 90},
+				{Name: "candidate", Statement: "SELECT 2", Weight: 10},
+			},
+		},
+		{
+			desc:     "no variants",
+			variants: nil,
+		},
+		{
+			desc: "weights below 100",
+			variants: tools.Variants{
+				{Name: "control", Weight: 50},
+				{Name: "candidate", Weight: 10},
+			},
+			err: `weights of the variants of tool "my-tool" add up to 60, must be 100`,
+		},
+		{
+			desc: "duplicate name",
+			variants: tools.Variants{
+				{Name: "control", Weight: 50},
+				{Name: "control", Weight: 50},
+			},
+			err: `variant "control" of tool "my-tool" is defined more than once`,
+		},
+		{
+			desc: "negative weight",
+			variants: tools.Variants{
+				{Name: "control", Weight: 110},
+				{Name: "candidate", Weight: -10},
+			},
+			err: `variant "candidate" of tool "my-tool" has a negative weight`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := tc.variants.Validate("my-tool")
+			if tc.err == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.err {
+				t.Fatalf("unexpected error: got %v, want %q", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestVariantsSelect(t *testing.T) {
+	variants := tools.Variants{
+		{Name: "control", Weight: 70},
+		{Name: "candidate", Statement: "SELECT 2", Weight: 30},
+		{Name: "disabled", Statement: "SELECT 3", Weight: 0},
+	}
+
+	t.Run("weighted", func(t *testing.T) {
+		counts := make(map[string]int)
+		for range 10000 {
+			tv := &util.ToolVariant{}
+			statement, err := variants.Select(util.WithToolVariant(context.Background(), tv), "SELECT 1")
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			counts[tv.Selected]++
+			want := map[string]string{"control": "SELECT 1", "candidate": "SELECT 2"}[tv.Selected]
+			if statement != want {
+				t.Fatalf("unexpected statement of variant %q: got %q, want %q", tv.Selected, statement, want)
+			}
+		}
+		if counts["disabled"] != 0 {
+			t.Errorf("expected a variant of weight 0 to never be selected, got %d", counts["disabled"])
+		}
+		if c := counts["candidate"]; c < 2500 || c > 3500 {
+			t.Errorf("unexpected share of candidate variant: got %d of 10000, want about 3000", c)
+		}
+	})
+
+	t.Run("forced", func(t *testing.T) {
+		tv := &util.ToolVariant{Forced: "disabled"}
+		statement, err := variants.Select(util.WithToolVariant(context.Background(), tv), "SELECT 1")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if statement != "SELECT 3" || tv.Selected != "disabled" {
+			t.Errorf("unexpected forced selection: got %q of variant %q", statement, tv.Selected)
+		}
+	})
+
+	t.Run("forced unknown", func(t *testing.T) {
+		tv := &util.ToolVariant{Forced: "unknown"}
+		if _, err := variants.Select(util.WithToolVariant(context.Background(), tv), "SELECT 1"); err == nil {
+			t.Errorf("expected an error for an unknown forced variant")
+		}
+	})
+
+	t.Run("no variants", func(t *testing.T) {
+		tv := &util.ToolVariant{Forced: "control"}
+		statement, err := tools.Variants(nil).Select(util.WithToolVariant(context.Background(), tv), "SELECT 1")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if statement != "SELECT 1" || tv.Selected != "" {
+			t.Errorf("unexpected selection without variants: got %q of variant %q", statement, tv.Selected)
+		}
+	})
+}
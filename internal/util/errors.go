@@ -13,12 +13,18 @@
 package util
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"regexp"
 	"strings"
+	"time"
 
 	"google.golang.org/api/googleapi"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type ErrorCategory string
@@ -85,6 +91,46 @@ func NewClientServerError(msg string, code int, cause error) *ClientServerError
 	return &ClientServerError{Msg: msg, Code: code, Cause: cause}
 }
 
+// ErrSourceBusy matches a SourceBusyError with errors.Is.
+var ErrSourceBusy = errors.New("source busy")
+
+// SourceBusyError reports that every connection of the pool of a source
+// stayed in use until the acquire timeout of the source elapsed.
+type SourceBusyError struct {
+	Source  string
+	Timeout time.Duration
+	// Statistics of the pool when the acquire timed out.
+	Total int32
+	Idle  int32
+	// Acquired counts the connections in use.
+	Acquired int32
+	Max      int32
+}
+
+func (e *SourceBusyError) Error() string {
+	return fmt.Sprintf("source %q is busy: no connection became free within %s (total %d, idle %d, acquired %d, max %d)",
+		e.Source, e.Timeout, e.Total, e.Idle, e.Acquired, e.Max)
+}
+
+func (e *SourceBusyError) Is(target error) bool { return target == ErrSourceBusy }
+
+// ErrResponseTooLarge matches a ResponseTooLargeError with errors.Is.
+var ErrResponseTooLarge = errors.New("response too large")
+
+// ResponseTooLargeError reports a result of a tool larger than the
+// maxResponseBytes of the tool.
+type ResponseTooLargeError struct {
+	// Size is the size of the JSON encoded result, in bytes.
+	Size int
+	Max  int
+}
+
+func (e *ResponseTooLargeError) Error() string {
+	return fmt.Sprintf("result of %d bytes exceeds the limit of %d bytes; narrow the invocation, such as with a filter or a lower limit", e.Size, e.Max)
+}
+
+func (e *ResponseTooLargeError) Is(target error) bool { return target == ErrResponseTooLarge }
+
 // ProcessGcpError catches auth related errors in GCP requests results and return 401/403 error codes
 // Returns AgentError for all other errors
 func ProcessGcpError(err error) ToolboxError {
@@ -115,6 +161,11 @@ func ProcessGeneralError(err error) ToolboxError {
 		return nil
 	}
 
+	// Report exhausted pools distinctly, rather than as a failure of the query
+	if errors.Is(err, ErrSourceBusy) {
+		return NewClientServerError("source is busy", http.StatusServiceUnavailable, err)
+	}
+
 	errStr := err.Error()
 
 	// Check for Unauthorized
@@ -147,3 +198,272 @@ func ProcessGeneralError(err error) ToolboxError {
 	// Default to AgentError for logical failures (task execution failed)
 	return NewAgentError("error processing request", err)
 }
+
+// ErrorCode is the stable, machine-readable classification of a failed tool
+// invocation. Clients use it to decide whether to retry the invocation,
+// reformulate it, or surface the failure to the user.
+type ErrorCode string
+
+const (
+	// ErrorCodeInvalidArgument reports parameter values the tool or the
+	// source rejects.
+	ErrorCodeInvalidArgument ErrorCode = "invalid_argument"
+	// ErrorCodeInvalidQuery reports a statement the source rejects, such as
+	// a syntax error or a reference to an unknown table.
+	ErrorCodeInvalidQuery ErrorCode = "invalid_query"
+	// ErrorCodeConflict reports a violated constraint.
+	ErrorCodeConflict ErrorCode = "conflict"
+	// ErrorCodeAborted reports a transaction the source aborted, such as on
+	// a deadlock or a serialization failure.
+	ErrorCodeAborted          ErrorCode = "aborted"
+	ErrorCodeUnauthenticated  ErrorCode = "unauthenticated"
+	ErrorCodePermissionDenied ErrorCode = "permission_denied"
+	ErrorCodeNotFound         ErrorCode = "not_found"
+	// ErrorCodeResourceExhausted reports an exceeded quota or rate limit.
+	ErrorCodeResourceExhausted ErrorCode = "resource_exhausted"
+	// ErrorCodeUnavailable reports a source that can't be reached or has no
+	// free connection.
+	ErrorCodeUnavailable      ErrorCode = "unavailable"
+	ErrorCodeDeadlineExceeded ErrorCode = "deadline_exceeded"
+	ErrorCodeCanceled         ErrorCode = "canceled"
+	// ErrorCodeResponseTooLarge reports a result larger than the limit of
+	// the tool.
+	ErrorCodeResponseTooLarge ErrorCode = "response_too_large"
+	// ErrorCodeExecutionFailed reports any other failure of the tool the
+	// agent may act on.
+	ErrorCodeExecutionFailed ErrorCode = "execution_failed"
+	// ErrorCodeInternal reports any other failure of the server.
+	ErrorCodeInternal ErrorCode = "internal"
+)
+
+// Retryable reports whether an invocation failing with the code may succeed
+// if retried unchanged.
+func (c ErrorCode) Retryable() bool {
+	switch c {
+	case ErrorCodeAborted, ErrorCodeResourceExhausted, ErrorCodeUnavailable, ErrorCodeDeadlineExceeded:
+		return true
+	}
+	return false
+}
+
+// ErrorInfo is the structured description of a failed tool invocation
+// returned to clients alongside, or in place of, the error text.
+type ErrorInfo struct {
+	Code      ErrorCode
+	Retryable bool
+	// SourceClass is the class of the error reported by the source, such as
+	// a SQLSTATE, a gRPC status code or a Google API error reason. It is
+	// empty if the source reported none.
+	SourceClass string
+	// Message describes the failure without the details of the underlying
+	// error, which may include statements, values or internal addresses.
+	Message string
+}
+
+// Data returns the details of the error reported to clients.
+func (i ErrorInfo) Data() map[string]any {
+	data := map[string]any{
+		"code":      string(i.Code),
+		"retryable": i.Retryable,
+		"message":   i.Message,
+	}
+	if i.SourceClass != "" {
+		data["sourceErrorClass"] = i.SourceClass
+	}
+	return data
+}
+
+// ClientErrorText returns the text of err returned to clients: the message
+// of info if the context redacts error details, the text of err otherwise.
+func ClientErrorText(ctx context.Context, err error, info ErrorInfo) string {
+	if RedactErrorDetailsFromContext(ctx) {
+		return info.Message
+	}
+	return err.Error()
+}
+
+// sqlStateError is implemented by driver errors reporting a SQLSTATE, such
+// as the errors of pgx.
+type sqlStateError interface {
+	SQLState() string
+}
+
+// sqlStatePatterns match the SQLSTATE in the text of driver errors which
+// don't expose it, such as "ERROR: ... (SQLSTATE 42P01)" or
+// "Error 1064 (42000): ...".
+var sqlStatePatterns = []*regexp.Regexp{
+	regexp.MustCompile(`\(SQLSTATE ([0-9A-Z]{5})\)`),
+	regexp.MustCompile(`Error \d+ \(([0-9A-Z]{5})\)`),
+}
+
+// ClassifyError returns the structured description of err, an error
+// returned by the invocation of a tool.
+func ClassifyError(err error) ErrorInfo {
+	if err == nil {
+		return ErrorInfo{}
+	}
+	info := ErrorInfo{Code: ErrorCodeInternal, Message: "internal error"}
+	var agentErr *AgentError
+	var clientServerErr *ClientServerError
+	switch {
+	case errors.As(err, &agentErr):
+		info.Code, info.Message = ErrorCodeExecutionFailed, agentErr.Msg
+	case errors.As(err, &clientServerErr):
+		info.Code, info.Message = httpStatusErrorCode(clientServerErr.Code), clientServerErr.Msg
+	}
+
+	sourceCode, sourceClass := classifySourceError(err)
+	info.SourceClass = sourceClass
+	// The code of the source refines only the generic codes: a status
+	// chosen by the tool takes precedence.
+	if sourceCode != "" && (info.Code == ErrorCodeExecutionFailed || info.Code == ErrorCodeInternal) {
+		info.Code = sourceCode
+	}
+	info.Retryable = info.Code.Retryable()
+	return info
+}
+
+// httpStatusErrorCode returns the error code of an HTTP status.
+func httpStatusErrorCode(code int) ErrorCode {
+	switch code {
+	case http.StatusBadRequest:
+		return ErrorCodeInvalidArgument
+	case http.StatusUnauthorized:
+		return ErrorCodeUnauthenticated
+	case http.StatusForbidden:
+		return ErrorCodePermissionDenied
+	case http.StatusNotFound:
+		return ErrorCodeNotFound
+	case http.StatusConflict:
+		return ErrorCodeConflict
+	case http.StatusTooManyRequests:
+		return ErrorCodeResourceExhausted
+	case http.StatusServiceUnavailable, http.StatusBadGateway:
+		return ErrorCodeUnavailable
+	case http.StatusGatewayTimeout:
+		return ErrorCodeDeadlineExceeded
+	}
+	return ErrorCodeInternal
+}
+
+// classifySourceError returns the error code and class of the error a
+// source reported in the chain of err, or empty strings if there is none.
+func classifySourceError(err error) (ErrorCode, string) {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return ErrorCodeDeadlineExceeded, ""
+	case errors.Is(err, context.Canceled):
+		return ErrorCodeCanceled, ""
+	case errors.Is(err, ErrSourceBusy):
+		return ErrorCodeUnavailable, ""
+	case errors.Is(err, ErrResponseTooLarge):
+		return ErrorCodeResponseTooLarge, ""
+	}
+
+	var stateErr sqlStateError
+	if errors.As(err, &stateErr) && stateErr.SQLState() != "" {
+		return sqlStateErrorCode(stateErr.SQLState()), stateErr.SQLState()
+	}
+	var gErr *googleapi.Error
+	if errors.As(err, &gErr) {
+		return googleAPIErrorCode(gErr)
+	}
+	var grpcErr interface{ GRPCStatus() *status.Status }
+	if errors.As(err, &grpcErr) {
+		c := grpcErr.GRPCStatus().Code()
+		return grpcErrorCode(c), c.String()
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		if netErr.Timeout() {
+			return ErrorCodeDeadlineExceeded, ""
+		}
+		return ErrorCodeUnavailable, ""
+	}
+	for _, pattern := range sqlStatePatterns {
+		if m := pattern.FindStringSubmatch(err.Error()); m != nil {
+			return sqlStateErrorCode(m[1]), m[1]
+		}
+	}
+	return "", ""
+}
+
+// sqlStateErrorCode returns the error code of a SQLSTATE, by its class.
+func sqlStateErrorCode(state string) ErrorCode {
+	switch {
+	case state == "42501":
+		return ErrorCodePermissionDenied
+	case state == "57014":
+		// query_canceled, reported when a statement timeout elapses
+		return ErrorCodeDeadlineExceeded
+	case strings.HasPrefix(state, "42"), strings.HasPrefix(state, "0A"):
+		return ErrorCodeInvalidQuery
+	case strings.HasPrefix(state, "22"):
+		return ErrorCodeInvalidArgument
+	case strings.HasPrefix(state, "23"):
+		return ErrorCodeConflict
+	case strings.HasPrefix(state, "40"):
+		return ErrorCodeAborted
+	case strings.HasPrefix(state, "28"):
+		return ErrorCodeUnauthenticated
+	case strings.HasPrefix(state, "08"), strings.HasPrefix(state, "57"):
+		return ErrorCodeUnavailable
+	case strings.HasPrefix(state, "53"):
+		return ErrorCodeResourceExhausted
+	}
+	return ""
+}
+
+// googleAPIErrorCode returns the error code and class of a Google API
+// error. The class is the reason of the error, or its HTTP status if it
+// has none.
+func googleAPIErrorCode(gErr *googleapi.Error) (ErrorCode, string) {
+	class := fmt.Sprint(gErr.Code)
+	if len(gErr.Errors) > 0 && gErr.Errors[0].Reason != "" {
+		class = gErr.Errors[0].Reason
+	}
+	switch class {
+	case "invalidQuery":
+		return ErrorCodeInvalidQuery, class
+	case "invalid":
+		return ErrorCodeInvalidArgument, class
+	case "rateLimitExceeded", "quotaExceeded":
+		return ErrorCodeResourceExhausted, class
+	case "backendError", "internalError":
+		return ErrorCodeUnavailable, class
+	}
+	code := httpStatusErrorCode(gErr.Code)
+	if code == ErrorCodeInternal {
+		return "", class
+	}
+	return code, class
+}
+
+// grpcErrorCode returns the error code of a gRPC status code.
+func grpcErrorCode(c codes.Code) ErrorCode {
+	switch c {
+	case codes.InvalidArgument, codes.OutOfRange:
+		return ErrorCodeInvalidArgument
+	case codes.FailedPrecondition:
+		return ErrorCodeInvalidQuery
+	case codes.AlreadyExists:
+		return ErrorCodeConflict
+	case codes.Aborted:
+		return ErrorCodeAborted
+	case codes.Unauthenticated:
+		return ErrorCodeUnauthenticated
+	case codes.PermissionDenied:
+		return ErrorCodePermissionDenied
+	case codes.NotFound:
+		return ErrorCodeNotFound
+	case codes.ResourceExhausted:
+		return ErrorCodeResourceExhausted
+	case codes.Unavailable:
+		return ErrorCodeUnavailable
+	case codes.DeadlineExceeded:
+		return ErrorCodeDeadlineExceeded
+	case codes.Canceled:
+		return ErrorCodeCanceled
+	}
+	return ""
+}
@@ -13,11 +13,16 @@
 package util
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	"google.golang.org/api/googleapi"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestProcessGcpErrorTreatsBadRequestAsAgentError(t *testing.T) {
@@ -62,3 +67,114 @@ func TestProcessGcpErrorPreservesAuthFailuresAsServerErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestProcessGeneralErrorReportsBusySources(t *testing.T) {
+	busy := &SourceBusyError{Source: "my-pg-instance", Timeout: time.Second, Total: 1, Acquired: 1, Max: 1}
+	err := ProcessGeneralError(fmt.Errorf("unable to execute query: %w", busy))
+
+	var clientServerErr *ClientServerError
+	if !errors.As(err, &clientServerErr) {
+		t.Fatalf("expected ClientServerError, got %T", err)
+	}
+	if clientServerErr.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected code %d, got %d", http.StatusServiceUnavailable, clientServerErr.Code)
+	}
+	if !errors.Is(err, ErrSourceBusy) {
+		t.Fatalf("expected error to match ErrSourceBusy")
+	}
+	want := `source is busy: unable to execute query: source "my-pg-instance" is busy: no connection became free within 1s (total 1, idle 0, acquired 1, max 1)`
+	if err.Error() != want {
+		t.Fatalf("unexpected error: got %q, want %q", err.Error(), want)
+	}
+}
+
+type fakeSQLStateError struct{ state string }
+
+func (e fakeSQLStateError) Error() string    { return "driver error" }
+func (e fakeSQLStateError) SQLState() string { return e.state }
+
+func TestClassifyError(t *testing.T) {
+	tcs := []struct {
+		desc string
+		err  error
+		want ErrorInfo
+	}{
+		{
+			desc: "agent error without source error",
+			err:  NewAgentError("write mode is 'blocked'", nil),
+			want: ErrorInfo{Code: ErrorCodeExecutionFailed, Message: "write mode is 'blocked'"},
+		},
+		{
+			desc: "syntax error",
+			err:  ProcessGeneralError(fmt.Errorf("unable to execute query: %w", fakeSQLStateError{state: "42601"})),
+			want: ErrorInfo{Code: ErrorCodeInvalidQuery, SourceClass: "42601", Message: "error processing request"},
+		},
+		{
+			desc: "serialization failure",
+			err:  NewAgentError("unable to execute query", fakeSQLStateError{state: "40001"}),
+			want: ErrorInfo{Code: ErrorCodeAborted, Retryable: true, SourceClass: "40001", Message: "unable to execute query"},
+		},
+		{
+			desc: "SQLSTATE in the error text",
+			err:  NewAgentError("unable to execute query", errors.New("Error 1146 (42S02): Table 'db.missing' doesn't exist")),
+			want: ErrorInfo{Code: ErrorCodeInvalidQuery, SourceClass: "42S02", Message: "unable to execute query"},
+		},
+		{
+			desc: "busy source",
+			err:  ProcessGeneralError(&SourceBusyError{Source: "my-pg-instance", Timeout: time.Second}),
+			want: ErrorInfo{Code: ErrorCodeUnavailable, Retryable: true, Message: "source is busy"},
+		},
+		{
+			desc: "response too large",
+			err:  NewAgentError(`result of tool "list_orders" is too large`, &ResponseTooLargeError{Size: 2048, Max: 1024}),
+			want: ErrorInfo{Code: ErrorCodeResponseTooLarge, Message: `result of tool "list_orders" is too large`},
+		},
+		{
+			desc: "deadline",
+			err:  NewAgentError("unable to execute query", fmt.Errorf("query: %w", context.DeadlineExceeded)),
+			want: ErrorInfo{Code: ErrorCodeDeadlineExceeded, Retryable: true, Message: "unable to execute query"},
+		},
+		{
+			desc: "Google API error",
+			err: ProcessGcpError(&googleapi.Error{
+				Code:   http.StatusBadRequest,
+				Errors: []googleapi.ErrorItem{{Reason: "invalidQuery"}},
+			}),
+			want: ErrorInfo{Code: ErrorCodeInvalidQuery, SourceClass: "invalidQuery", Message: "error processing GCP request"},
+		},
+		{
+			desc: "gRPC status",
+			err:  NewAgentError("unable to execute query", status.Error(codes.NotFound, "table not found")),
+			want: ErrorInfo{Code: ErrorCodeNotFound, SourceClass: "NotFound", Message: "unable to execute query"},
+		},
+		{
+			desc: "server error keeps its status",
+			err:  NewClientServerError("failed to access resource", http.StatusForbidden, fakeSQLStateError{state: "42601"}),
+			want: ErrorInfo{Code: ErrorCodePermissionDenied, SourceClass: "42601", Message: "failed to access resource"},
+		},
+		{
+			desc: "unknown error",
+			err:  errors.New("boom"),
+			want: ErrorInfo{Code: ErrorCodeInternal, Message: "internal error"},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := ClassifyError(tc.err); got != tc.want {
+				t.Fatalf("ClassifyError() = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestClientErrorText(t *testing.T) {
+	err := NewAgentError("unable to execute query", fakeSQLStateError{state: "42P01"})
+	info := ClassifyError(err)
+
+	if got := ClientErrorText(context.Background(), err, info); got != "unable to execute query: driver error" {
+		t.Errorf("unexpected error text %q", got)
+	}
+	if got := ClientErrorText(WithRedactErrorDetails(context.Background(), true), err, info); got != "unable to execute query" {
+		t.Errorf("expected redacted error text, got %q", got)
+	}
+}
@@ -0,0 +1,256 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package jsonpath evaluates a subset of JSONPath expressions against
+// decoded JSON values (maps, slices and scalars as produced by
+// encoding/json).
+//
+// Supported syntax: the root `$`, child members (`.name` and `['name']`),
+// array indexes including negative ones (`[0]`, `[-1]`), wildcards (`.*` and
+// `[*]`) and recursive descent (`..name`, `..*`).
+package jsonpath
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type segmentKind int
+
+const (
+	memberSegment segmentKind = iota
+	indexSegment
+	wildcardSegment
+)
+
+type segment struct {
+	kind      segmentKind
+	name      string
+	index     int
+	recursive bool
+}
+
+// Path is a compiled JSONPath expression.
+type Path struct {
+	expr     string
+	segments []segment
+}
+
+// String returns the expression the path was compiled from.
+func (p *Path) String() string {
+	return p.expr
+}
+
+// Definite reports whether the path selects at most one value, that is, it
+// contains no wildcard and no recursive descent.
+func (p *Path) Definite() bool {
+	for _, s := range p.segments {
+		if s.kind == wildcardSegment || s.recursive {
+			return false
+		}
+	}
+	return true
+}
+
+// Compile parses a JSONPath expression.
+func Compile(expr string) (*Path, error) {
+	expr = strings.TrimSpace(expr)
+	if !strings.HasPrefix(expr, "$") {
+		return nil, fmt.Errorf("jsonpath %q must start with $", expr)
+	}
+	p := &Path{expr: expr}
+	rest := expr[1:]
+	for rest != "" {
+		recursive := false
+		switch {
+		case strings.HasPrefix(rest, ".."):
+			recursive = true
+			rest = rest[2:]
+		case rest[0] == '.':
+			rest = rest[1:]
+		case rest[0] == '[':
+		default:
+			return nil, fmt.Errorf("jsonpath %q: unexpected %q", expr, rest)
+		}
+		if rest == "" {
+			return nil, fmt.Errorf("jsonpath %q: trailing dot", expr)
+		}
+
+		var seg segment
+		if rest[0] == '[' {
+			end := closingBracket(rest)
+			if end < 0 {
+				return nil, fmt.Errorf("jsonpath %q: unterminated [", expr)
+			}
+			var err error
+			seg, err = parseBracket(rest[1:end])
+			if err != nil {
+				return nil, fmt.Errorf("jsonpath %q: %w", expr, err)
+			}
+			rest = rest[end+1:]
+		} else {
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			name := rest[:end]
+			if name == "" {
+				return nil, fmt.Errorf("jsonpath %q: empty member name", expr)
+			}
+			if name == "*" {
+				seg = segment{kind: wildcardSegment}
+			} else {
+				seg = segment{kind: memberSegment, name: name}
+			}
+			rest = rest[end:]
+		}
+		seg.recursive = recursive
+		p.segments = append(p.segments, seg)
+	}
+	return p, nil
+}
+
+// closingBracket returns the index of the `]` closing the bracket expression
+// at the start of s, skipping over quoted names.
+func closingBracket(s string) int {
+	var quote byte
+	for i := 1; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0 && c == '\\':
+			i++
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote == 0 && (c == '\'' || c == '"'):
+			quote = c
+		case quote == 0 && c == ']':
+			return i
+		}
+	}
+	return -1
+}
+
+func parseBracket(inner string) (segment, error) {
+	inner = strings.TrimSpace(inner)
+	switch {
+	case inner == "*":
+		return segment{kind: wildcardSegment}, nil
+	case len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') && inner[len(inner)-1] == inner[0]:
+		body := inner[1 : len(inner)-1]
+		var b strings.Builder
+		for i := 0; i < len(body); i++ {
+			if body[i] == '\\' && i+1 < len(body) {
+				i++
+			}
+			b.WriteByte(body[i])
+		}
+		return segment{kind: memberSegment, name: b.String()}, nil
+	default:
+		n, err := strconv.Atoi(inner)
+		if err != nil {
+			return segment{}, fmt.Errorf("invalid bracket expression [%s]", inner)
+		}
+		return segment{kind: indexSegment, index: n}, nil
+	}
+}
+
+// Select returns every value matched by the path, in document order.
+func (p *Path) Select(doc any) []any {
+	nodes := []any{doc}
+	for _, seg := range p.segments {
+		var next []any
+		for _, n := range nodes {
+			if seg.recursive {
+				walk(n, func(v any) {
+					next = seg.apply(v, next)
+				})
+			} else {
+				next = seg.apply(n, next)
+			}
+		}
+		nodes = next
+	}
+	return nodes
+}
+
+// Get evaluates the path against doc. A definite path returns the single
+// matched value, or an error if nothing matches. Any other path returns the
+// matched values as a slice, which may be empty.
+func (p *Path) Get(doc any) (any, error) {
+	matches := p.Select(doc)
+	if !p.Definite() {
+		if matches == nil {
+			matches = []any{}
+		}
+		return matches, nil
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("jsonpath %q matched no value", p.expr)
+	}
+	return matches[0], nil
+}
+
+func (s segment) apply(v any, out []any) []any {
+	switch s.kind {
+	case memberSegment:
+		if m, ok := v.(map[string]any); ok {
+			if child, ok := m[s.name]; ok {
+				out = append(out, child)
+			}
+		}
+	case indexSegment:
+		if a, ok := v.([]any); ok {
+			i := s.index
+			if i < 0 {
+				i += len(a)
+			}
+			if i >= 0 && i < len(a) {
+				out = append(out, a[i])
+			}
+		}
+	case wildcardSegment:
+		out = append(out, children(v)...)
+	}
+	return out
+}
+
+// walk calls fn for v and each of its descendants, depth first.
+func walk(v any, fn func(any)) {
+	fn(v)
+	for _, c := range children(v) {
+		walk(c, fn)
+	}
+}
+
+func children(v any) []any {
+	switch t := v.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(t))
+		for k := range t {
+			keys = append(keys, k)
+		}
+		// Map iteration order is random; sort for deterministic output.
+		slices.Sort(keys)
+		out := make([]any, 0, len(keys))
+		for _, k := range keys {
+			out = append(out, t[k])
+		}
+		return out
+	case []any:
+		return t
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonpath
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const testDoc = `{
+	"data": {
+		"items": [
+			{"id": 1, "name": "a", "tags": ["x"]},
+			{"id": 2, "name": "b", "tags": ["y", "z"]}
+		],
+		"total": 2,
+		"odd.key": "dotted"
+	}
+}`
+
+func TestGet(t *testing.T) {
+	var doc any
+	if err := json.Unmarshal([]byte(testDoc), &doc); err != nil {
+		t.Fatal(err)
+	}
+	tcs := []struct {
+		expr string
+		want any
+	}{
+		{expr: "$", want: doc},
+		{expr: "$.data.total", want: float64(2)},
+		{expr: "$.data.items[0].name", want: "a"},
+		{expr: "$.data.items[-1].id", want: float64(2)},
+		{expr: "$['data']['odd.key']", want: "dotted"},
+		{expr: `$["data"].total`, want: float64(2)},
+		{expr: "$.data.items[*].id", want: []any{float64(1), float64(2)}},
+		{expr: "$.data.items.*.name", want: []any{"a", "b"}},
+		{expr: "$..tags[0]", want: []any{"x", "y"}},
+		{expr: "$..id", want: []any{float64(1), float64(2)}},
+		{expr: "$.data.missing[*]", want: []any{}},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.expr, func(t *testing.T) {
+			p, err := Compile(tc.expr)
+			if err != nil {
+				t.Fatalf("unexpected compile error: %s", err)
+			}
+			got, err := p.Get(doc)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect result (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetNoMatch(t *testing.T) {
+	p, err := Compile("$.data.items[5]")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.Get(map[string]any{"data": map[string]any{"items": []any{}}}); err == nil {
+		t.Fatal("expected an error for a definite path with no match")
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	for _, expr := range []string{"", "data.items", "$.", "$.items[", "$.items[abc]", "$x"} {
+		t.Run(expr, func(t *testing.T) {
+			if _, err := Compile(expr); err == nil {
+				t.Fatalf("expected error compiling %q", expr)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parameters
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/googleapis/mcp-toolbox/internal/util"
+)
+
+// Coercion modes of parameter values. Strict rejects values that do not have
+// the declared type of their parameter; lenient first attempts to convert
+// strings to the declared type.
+const (
+	CoercionStrict  = "strict"
+	CoercionLenient = "lenient"
+)
+
+// CoerceParams returns data with the string values of integer, float and
+// boolean parameters, including the items of arrays of them, converted to
+// the declared type of their parameter. Values that cannot be converted are
+// left as is, for parsing to reject them. data itself is not modified.
+func CoerceParams(ctx context.Context, ps Parameters, data map[string]any) map[string]any {
+	if len(data) == 0 {
+		return data
+	}
+	out := make(map[string]any, len(data))
+	for k, v := range data {
+		out[k] = v
+	}
+	for _, p := range ps {
+		name := p.GetName()
+		v, ok := out[name]
+		if !ok {
+			continue
+		}
+		if coerced, ok := coerceValue(p, v); ok {
+			logCoercion(ctx, name, v, coerced)
+			out[name] = coerced
+		}
+	}
+	return out
+}
+
+// coerceValue converts v to the type of p. It reports false if v needs no
+// conversion or cannot be converted.
+func coerceValue(p Parameter, v any) (any, bool) {
+	if arr, ok := p.(*ArrayParameter); ok {
+		items, ok := v.([]any)
+		if !ok {
+			return nil, false
+		}
+		out := make([]any, len(items))
+		changed := false
+		for i, item := range items {
+			out[i] = item
+			if coerced, ok := coerceValue(arr.GetItems(), item); ok {
+				out[i] = coerced
+				changed = true
+			}
+		}
+		return out, changed
+	}
+
+	s, ok := v.(string)
+	if !ok {
+		return nil, false
+	}
+	s = strings.TrimSpace(s)
+	switch p.GetType() {
+	case TypeInt:
+		i, err := strconv.Atoi(s)
+		if err != nil {
+			return nil, false
+		}
+		return i, true
+	case TypeFloat:
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, false
+		}
+		return f, true
+	case TypeBool:
+		switch strings.ToLower(s) {
+		case "true":
+			return true, true
+		case "false":
+			return false, true
+		}
+	}
+	return nil, false
+}
+
+func logCoercion(ctx context.Context, name string, from, to any) {
+	logger, err := util.LoggerFromContext(ctx)
+	if err != nil {
+		return
+	}
+	logger.DebugContext(ctx, fmt.Sprintf("coerced value of parameter %q from %#v to %#v", name, from, to))
+}
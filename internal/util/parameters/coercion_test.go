@@ -0,0 +1,106 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parameters_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/internal/log"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+func TestCoerceParams(t *testing.T) {
+	params := parameters.Parameters{
+		parameters.NewIntParameter("count", "an integer"),
+		parameters.NewFloatParameter("ratio", "a float"),
+		parameters.NewBooleanParameter("active", "a boolean"),
+		parameters.NewStringParameter("name", "a string"),
+		parameters.NewArrayParameter("ids", "integers", parameters.NewIntParameter("id", "an integer")),
+	}
+
+	tcs := []struct {
+		desc string
+		in   map[string]any
+		want map[string]any
+	}{
+		{
+			desc: "loose values",
+			in:   map[string]any{"count": "42", "ratio": "1.5", "active": "true", "name": "42", "ids": []any{"1", 2}},
+			want: map[string]any{"count": 42, "ratio": 1.5, "active": true, "name": "42", "ids": []any{1, 2}},
+		},
+		{
+			desc: "case and whitespace",
+			in:   map[string]any{"count": " 7 ", "active": "FALSE"},
+			want: map[string]any{"count": 7, "active": false},
+		},
+		{
+			desc: "typed values",
+			in:   map[string]any{"count": 42, "ratio": 1.5, "active": true},
+			want: map[string]any{"count": 42, "ratio": 1.5, "active": true},
+		},
+		{
+			desc: "unconvertible values",
+			in:   map[string]any{"count": "1.5", "ratio": "abc", "active": "yes"},
+			want: map[string]any{"count": "1.5", "ratio": "abc", "active": "yes"},
+		},
+		{
+			desc: "unknown parameters",
+			in:   map[string]any{"other": "42"},
+			want: map[string]any{"other": "42"},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := parameters.CoerceParams(context.Background(), params, tc.in)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("unexpected coercion (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCoerceParamsThenParse(t *testing.T) {
+	params := parameters.Parameters{parameters.NewIntParameter("count", "an integer")}
+	data := map[string]any{"count": "42"}
+
+	// strict parsing rejects the string value
+	if _, err := parameters.ParseParams(params, data, nil); err == nil {
+		t.Fatalf("expected strict parsing to fail")
+	}
+
+	var logs strings.Builder
+	logger, err := log.NewStdLogger(&logs, &logs, "debug")
+	if err != nil {
+		t.Fatalf("unable to initialize logger: %s", err)
+	}
+	ctx := util.WithLogger(context.Background(), logger)
+	got, err := parameters.ParseParams(params, parameters.CoerceParams(ctx, params, data), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(parameters.ParamValues{{Name: "count", Value: 42}}, got); diff != "" {
+		t.Errorf("unexpected params (-want +got):\n%s", diff)
+	}
+	if data["count"] != "42" {
+		t.Errorf("expected the input to be left unchanged, got %v", data["count"])
+	}
+	if !strings.Contains(logs.String(), "coerced value of parameter") {
+		t.Errorf("expected the coercion to be logged, got: %s", logs.String())
+	}
+}
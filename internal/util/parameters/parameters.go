@@ -19,12 +19,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"net/http"
 	"reflect"
 	"regexp"
 	"slices"
+	"strconv"
 	"strings"
 	"text/template"
+	"unicode/utf8"
 
 	embeddingmodels "github.com/googleapis/mcp-toolbox/internal/embeddingmodels"
 	"github.com/googleapis/mcp-toolbox/internal/util"
@@ -122,6 +125,52 @@ func parseFromAuthService(paramAuthServices []ParamAuthService, claimsMap map[st
 	return nil, util.NewClientServerError("missing or invalid authentication header", http.StatusUnauthorized, nil)
 }
 
+// requiredIfNote describes the conditions of requiredIf, such as
+// `Required when "mode" is "range".`
+func requiredIfNote(requiredIf map[string]any) string {
+	return fmt.Sprintf("Required when %s.", requiredIfConditions(requiredIf))
+}
+
+func requiredIfConditions(requiredIf map[string]any) string {
+	names := slices.Sorted(maps.Keys(requiredIf))
+	conds := make([]string, len(names))
+	for i, name := range names {
+		conds[i] = fmt.Sprintf("%q is %s", name, formatRequiredIfValue(requiredIf[name]))
+	}
+	return strings.Join(conds, " and ")
+}
+
+func formatRequiredIfValue(v any) string {
+	if s, ok := v.(string); ok {
+		return strconv.Quote(s)
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// CheckRequiredIf returns an error naming the first parameter of ps that is
+// missing from values although the values of its siblings require it.
+func CheckRequiredIf(ps Parameters, values ParamValues) error {
+	valuesMap := values.AsMap()
+	for _, p := range ps {
+		requiredIf := p.GetRequiredIf()
+		if len(requiredIf) == 0 || valuesMap[p.GetName()] != nil {
+			continue
+		}
+		matches := true
+		for name, want := range requiredIf {
+			got, ok := valuesMap[name]
+			if !ok || got == nil || fmt.Sprintf("%v", got) != fmt.Sprintf("%v", want) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return fmt.Errorf("parameter %q is required when %s", p.GetName(), requiredIfConditions(requiredIf))
+		}
+	}
+	return nil
+}
+
 // CheckParamRequired checks if a parameter is required based on the required and default field.
 func CheckParamRequired(required bool, defaultV any) bool {
 	return required && defaultV == nil
@@ -140,6 +189,12 @@ func ParseParams(ps Parameters, data map[string]any, claimsMap map[string]map[st
 		if sourceParamName != "" {
 			v = data[sourceParamName]
 
+		} else if fc := p.GetFromClaim(); fc != nil {
+			// parse parameter bound to a claim, ignoring any value in data
+			v, err = parseFromAuthService([]ParamAuthService{{Name: fc.AuthService, Field: fc.Claim}}, claimsMap)
+			if err != nil {
+				return nil, util.NewClientServerError(fmt.Sprintf("error parsing parameter %q from claim %q", name, fc.Claim), http.StatusUnauthorized, err)
+			}
 		} else if len(paramAuthServices) == 0 {
 			// parse non auth-required parameter
 			var ok bool
@@ -161,11 +216,18 @@ func ParseParams(ps Parameters, data map[string]any, claimsMap map[string]map[st
 		if v != nil {
 			newV, err = p.Parse(v)
 			if err != nil {
+				if p.GetSensitive() {
+					// parse errors may quote the value
+					return nil, util.NewAgentError(fmt.Sprintf("unable to parse value for sensitive parameter %q", name), nil)
+				}
 				return nil, util.NewAgentError(fmt.Sprintf("unable to parse value for %q", name), err)
 			}
 		}
 		params = append(params, ParamValue{Name: name, Value: newV})
 	}
+	if err := CheckRequiredIf(ps, params); err != nil {
+		return nil, util.NewAgentError(err.Error(), nil)
+	}
 	return params, nil
 }
 
@@ -326,6 +388,10 @@ type Parameter interface {
 	GetAuthServices() []ParamAuthService
 	GetEmbeddedBy() string
 	GetValueFromParam() string
+	GetFromClaim() *ClaimBinding
+	GetRequiredIf() map[string]any
+	GetSuggestions() *ParamSuggestions
+	GetSensitive() bool
 	Parse(any) (any, error)
 	Manifest() ParameterManifest
 	McpManifest() (ParameterMcpManifest, []string)
@@ -374,6 +440,22 @@ func parseParamFromDelayedUnmarshaler(ctx context.Context, u *util.DelayedUnmars
 
 // ParseParameter parses a raw map into a Parameter object based on its "type" field.
 func ParseParameter(ctx context.Context, p map[string]any, paramType string) (Parameter, error) {
+	param, err := parseParameter(ctx, p, paramType)
+	if err != nil {
+		return nil, err
+	}
+	if fc := param.GetFromClaim(); fc != nil {
+		if fc.AuthService == "" || fc.Claim == "" {
+			return nil, fmt.Errorf("parameter %q must specify the 'authService' and 'claim' of 'fromClaim'", param.GetName())
+		}
+		if len(param.GetAuthServices()) > 0 || param.GetValueFromParam() != "" {
+			return nil, fmt.Errorf("parameter %q cannot specify 'fromClaim' with 'authServices' or 'valueFromParam'", param.GetName())
+		}
+	}
+	return param, nil
+}
+
+func parseParameter(ctx context.Context, p map[string]any, paramType string) (Parameter, error) {
 	dec, err := util.NewStrictDecoder(p)
 	if err != nil {
 		return nil, fmt.Errorf("error creating decoder: %w", err)
@@ -384,6 +466,12 @@ func ParseParameter(ctx context.Context, p map[string]any, paramType string) (Pa
 		if err := dec.DecodeContext(ctx, a); err != nil {
 			return nil, fmt.Errorf("unable to parse as %q: %w", paramType, err)
 		}
+		if _, err := regexp.Compile(a.Pattern); err != nil {
+			return nil, fmt.Errorf("invalid pattern of parameter %q: %w", a.Name, err)
+		}
+		if len(a.Identifiers) > 0 && len(a.Enum) > 0 {
+			return nil, fmt.Errorf("parameter %q cannot specify both 'identifiers' and 'enum'", a.Name)
+		}
 		return a, nil
 	case TypeInt:
 		a := &IntParameter{}
@@ -437,7 +525,7 @@ func ParseParameter(ctx context.Context, p map[string]any, paramType string) (Pa
 func (ps Parameters) Manifest() []ParameterManifest {
 	rtn := make([]ParameterManifest, 0, len(ps))
 	for _, p := range ps {
-		if p.GetValueFromParam() != "" {
+		if IsHidden(p) {
 			continue
 		}
 		rtn = append(rtn, p.Manifest())
@@ -466,19 +554,58 @@ type ParameterMcpManifest struct {
 	Items                *ParameterMcpManifest `json:"items,omitempty"`
 	Default              any                   `json:"default,omitempty"`
 	AdditionalProperties any                   `json:"additionalProperties,omitempty"`
+	Enum                 []any                 `json:"enum,omitempty"`
+	Pattern              string                `json:"pattern,omitempty"`
+	MaxLength            *int                  `json:"maxLength,omitempty"`
+	Minimum              any                   `json:"minimum,omitempty"`
+	Maximum              any                   `json:"maximum,omitempty"`
+	MaxItems             *int                  `json:"maxItems,omitempty"`
 }
 
 // CommonParameter are default fields that are emebdding in most Parameter implementations. Embedding this stuct will give the object Name() and Type() functions.
 type CommonParameter struct {
-	Name           string             `yaml:"name" validate:"required"`
-	Type           string             `yaml:"type" validate:"required"`
-	Desc           string             `yaml:"description" validate:"required"`
-	Required       *bool              `yaml:"required"`
-	AllowedValues  []any              `yaml:"allowedValues"`
-	ExcludedValues []any              `yaml:"excludedValues"`
+	Name           string `yaml:"name" validate:"required"`
+	Type           string `yaml:"type" validate:"required"`
+	Desc           string `yaml:"description" validate:"required"`
+	Required       *bool  `yaml:"required"`
+	AllowedValues  []any  `yaml:"allowedValues"`
+	ExcludedValues []any  `yaml:"excludedValues"`
+	// Enum lists the values the parameter may take. Unlike AllowedValues,
+	// values must equal one of them exactly, and they are listed in the
+	// JSON schema of the parameter.
+	Enum           []any              `yaml:"enum"`
 	AuthServices   []ParamAuthService `yaml:"authServices"`
 	EmbeddedBy     string             `yaml:"embeddedBy"`
 	ValueFromParam string             `yaml:"valueFromParam"`
+	// RequiredIf makes the parameter required when every sibling parameter
+	// it names has the given value. The parameter is optional otherwise.
+	RequiredIf map[string]any `yaml:"requiredIf"`
+	// Suggestions names the tool listing suggested values of the parameter.
+	Suggestions *ParamSuggestions `yaml:"suggestions"`
+	// Sensitive redacts the values of the parameter from logs, traces and
+	// audit records. The values are still passed to the tool.
+	Sensitive bool `yaml:"sensitive"`
+	// FromClaim binds the parameter to a claim of the token verified by an
+	// auth service. The parameter is not exposed to clients, so that it
+	// always holds the identity of the end user.
+	FromClaim *ClaimBinding `yaml:"fromClaim"`
+}
+
+// ClaimBinding names the claim of the token verified by an auth service that
+// a parameter is bound to.
+type ClaimBinding struct {
+	// AuthService is the auth service verifying the token.
+	AuthService string `yaml:"authService"`
+	// Claim is the claim holding the value of the parameter, such as "email".
+	Claim string `yaml:"claim"`
+}
+
+// ParamSuggestions configures the suggested values of a parameter, served to
+// tool UIs for autocompletion.
+type ParamSuggestions struct {
+	// Tool is invoked with the prefix typed so far as its "q" parameter,
+	// and returns the suggested values.
+	Tool string `yaml:"suggestionsTool" validate:"required"`
 }
 
 // GetName returns the name specified for the Parameter.
@@ -498,13 +625,41 @@ func (p *CommonParameter) GetType() string {
 
 // GetRequired returns the type specified for the Parameter.
 func (p *CommonParameter) GetRequired() bool {
-	// parameters are defaulted to required
+	// parameters are defaulted to required, unless they are conditionally
+	// required
 	if p.Required == nil {
-		return true
+		return len(p.RequiredIf) == 0
 	}
 	return *p.Required
 }
 
+// GetRequiredIf returns the values of sibling parameters that make the
+// Parameter required.
+func (p *CommonParameter) GetRequiredIf() map[string]any {
+	return p.RequiredIf
+}
+
+// GetSuggestions returns the suggestions configuration of the Parameter, or
+// nil if it has none.
+func (p *CommonParameter) GetSuggestions() *ParamSuggestions {
+	return p.Suggestions
+}
+
+// GetSensitive returns whether the values of the Parameter are redacted
+// from logs, traces and audit records.
+func (p *CommonParameter) GetSensitive() bool {
+	return p.Sensitive
+}
+
+// description returns the description of the Parameter, noting the
+// conditions under which it is required.
+func (p *CommonParameter) description() string {
+	if len(p.RequiredIf) == 0 {
+		return p.Desc
+	}
+	return strings.TrimSpace(p.Desc + " " + requiredIfNote(p.RequiredIf))
+}
+
 // GetAllowedValues returns the allowed values for the Parameter.
 func (p *CommonParameter) GetAllowedValues() []any {
 	return p.AllowedValues
@@ -523,6 +678,21 @@ func (p *CommonParameter) IsAllowedValues(v any) bool {
 	return false
 }
 
+// IsEnumValue checks if the value is one of the enum values. Values are
+// compared by their formatting, as numbers in the configuration may be
+// decoded with a different type than the parsed value.
+func (p *CommonParameter) IsEnumValue(v any) bool {
+	if len(p.Enum) == 0 {
+		return true
+	}
+	for _, e := range p.Enum {
+		if fmt.Sprint(e) == fmt.Sprint(v) {
+			return true
+		}
+	}
+	return false
+}
+
 // GetExcludedValues returns the excluded values for the Parameter.
 func (p *CommonParameter) GetExcludedValues() []any {
 	return p.ExcludedValues
@@ -551,6 +721,18 @@ func (p *CommonParameter) GetValueFromParam() string {
 	return p.ValueFromParam
 }
 
+// GetFromClaim returns the claim the Parameter is bound to, if any.
+func (p *CommonParameter) GetFromClaim() *ClaimBinding {
+	return p.FromClaim
+}
+
+// IsHidden reports whether clients cannot supply the value of a parameter,
+// since it is derived from another parameter or from a claim. Hidden
+// parameters are left out of the manifests and input schemas of tools.
+func IsHidden(p Parameter) bool {
+	return p.GetValueFromParam() != "" || p.GetFromClaim() != nil
+}
+
 // MatchStringOrRegex checks if the input matches the target
 func MatchStringOrRegex(input, target any) bool {
 	targetS, ok := target.(string)
@@ -571,7 +753,8 @@ func (p *CommonParameter) McpManifest() (ParameterMcpManifest, []string) {
 	authServiceNames := getAuthServiceNames(p.AuthServices)
 	return ParameterMcpManifest{
 		Type:        p.Type,
-		Description: p.Desc,
+		Description: p.description(),
+		Enum:        p.Enum,
 	}, authServiceNames
 }
 
@@ -621,6 +804,18 @@ func WithStringDefault(v string) StringParameterOption {
 func WithStringEscape(v string) StringParameterOption {
 	return func(p *StringParameter) { p.Escape = &v }
 }
+func WithStringIdentifiers(v []string) StringParameterOption {
+	return func(p *StringParameter) { p.Identifiers = v }
+}
+func WithStringEnum(v []any) StringParameterOption {
+	return func(p *StringParameter) { p.Enum = v }
+}
+func WithStringPattern(v string) StringParameterOption {
+	return func(p *StringParameter) { p.Pattern = v }
+}
+func WithStringMaxLength(v *int) StringParameterOption {
+	return func(p *StringParameter) { p.MaxLength = v }
+}
 
 func NewStringParameter(name string, desc string, opts ...StringParameterOption) *StringParameter {
 	p := &StringParameter{
@@ -644,6 +839,15 @@ type StringParameter struct {
 	CommonParameter `yaml:",inline"`
 	Default         *string `yaml:"default"`
 	Escape          *string `yaml:"escape"`
+	// Identifiers are the values the parameter may take, such as the names
+	// of the tables a template parameter selects among. They are a strict
+	// allow-list, checked apart from Enum and listed in the description of
+	// the parameter.
+	Identifiers []string `yaml:"identifiers"`
+	// Pattern is a regular expression values must match.
+	Pattern string `yaml:"pattern"`
+	// MaxLength is the maximum number of characters of values.
+	MaxLength *int `yaml:"maxLength"`
 }
 
 // Parse casts the value "v" as a "string".
@@ -658,12 +862,52 @@ func (p *StringParameter) Parse(v any) (any, error) {
 	if p.IsExcludedValues(newV) {
 		return nil, fmt.Errorf("%s is an excluded value", newV)
 	}
+	if !p.IsEnumValue(newV) {
+		return nil, fmt.Errorf("%q is not one of the enum values of parameter %q", newV, p.Name)
+	}
+	if len(p.Identifiers) > 0 && !slices.Contains(p.Identifiers, newV) {
+		return nil, fmt.Errorf("%q is not one of the identifiers of parameter %q", newV, p.Name)
+	}
+	if p.Pattern != "" {
+		re, err := regexp.Compile(p.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern of parameter %q: %w", p.Name, err)
+		}
+		if !re.MatchString(newV) {
+			return nil, fmt.Errorf("%q does not match the pattern %q", newV, p.Pattern)
+		}
+	}
+	if p.MaxLength != nil && utf8.RuneCountInString(newV) > *p.MaxLength {
+		return nil, fmt.Errorf("%q is longer than the maximum length of %d", newV, *p.MaxLength)
+	}
 	if p.Escape != nil {
 		return applyEscape(*p.Escape, newV)
 	}
 	return newV, nil
 }
 
+// description returns the description of the parameter, listing its
+// identifiers for the agent to select among.
+func (p *StringParameter) description() string {
+	d := p.CommonParameter.description()
+	if len(p.Identifiers) == 0 {
+		return d
+	}
+	return strings.TrimSpace(fmt.Sprintf("%s One of: %s.", d, strings.Join(p.Identifiers, ", ")))
+}
+
+// McpManifest returns the MCP manifest for the StringParameter.
+func (p *StringParameter) McpManifest() (ParameterMcpManifest, []string) {
+	m, authServiceNames := p.CommonParameter.McpManifest()
+	m.Description = p.description()
+	for _, id := range p.Identifiers {
+		m.Enum = append(m.Enum, id)
+	}
+	m.Pattern = p.Pattern
+	m.MaxLength = p.MaxLength
+	return m, authServiceNames
+}
+
 func applyEscape(escape, v string) (any, error) {
 	switch escape {
 	case escapeBackticks:
@@ -703,7 +947,7 @@ func (p *StringParameter) Manifest() ParameterManifest {
 		Name:         p.Name,
 		Type:         p.Type,
 		Required:     r,
-		Description:  p.Desc,
+		Description:  p.description(),
 		AuthServices: authServiceNames,
 		Default:      p.GetDefault(),
 	}
@@ -743,6 +987,7 @@ var _ Parameter = &IntParameter{}
 
 func WithIntMinValue(v *int) IntParameterOption { return func(p *IntParameter) { p.MinValue = v } }
 func WithIntMaxValue(v *int) IntParameterOption { return func(p *IntParameter) { p.MaxValue = v } }
+func WithIntEnum(v []any) IntParameterOption    { return func(p *IntParameter) { p.Enum = v } }
 
 // IntParameter is a parameter representing the "int" type.
 type IntParameter struct {
@@ -782,6 +1027,9 @@ func (p *IntParameter) Parse(v any) (any, error) {
 	if p.MaxValue != nil && out > *p.MaxValue {
 		return nil, fmt.Errorf("%d is above the maximum value", out)
 	}
+	if !p.IsEnumValue(out) {
+		return nil, fmt.Errorf("%d is not one of the enum values of parameter %q", out, p.Name)
+	}
 	return out, nil
 }
 
@@ -805,12 +1053,24 @@ func (p *IntParameter) Manifest() ParameterManifest {
 		Name:         p.Name,
 		Type:         p.Type,
 		Required:     r,
-		Description:  p.Desc,
+		Description:  p.description(),
 		AuthServices: authServiceNames,
 		Default:      p.GetDefault(),
 	}
 }
 
+// McpManifest returns the MCP manifest for the IntParameter.
+func (p *IntParameter) McpManifest() (ParameterMcpManifest, []string) {
+	m, authServiceNames := p.CommonParameter.McpManifest()
+	if p.MinValue != nil {
+		m.Minimum = *p.MinValue
+	}
+	if p.MaxValue != nil {
+		m.Maximum = *p.MaxValue
+	}
+	return m, authServiceNames
+}
+
 // NewFloatParameter is a convenience function for initializing a FloatParameter.
 type FloatParameterOption func(*FloatParameter)
 
@@ -853,6 +1113,9 @@ func WithFloatMinValue(v *float64) FloatParameterOption {
 func WithFloatMaxValue(v *float64) FloatParameterOption {
 	return func(p *FloatParameter) { p.MaxValue = v }
 }
+func WithFloatEnum(v []any) FloatParameterOption {
+	return func(p *FloatParameter) { p.Enum = v }
+}
 
 // FloatParameter is a parameter representing the "float" type.
 type FloatParameter struct {
@@ -890,6 +1153,9 @@ func (p *FloatParameter) Parse(v any) (any, error) {
 	if p.MaxValue != nil && out > *p.MaxValue {
 		return nil, fmt.Errorf("%g is above the maximum value", out)
 	}
+	if !p.IsEnumValue(out) {
+		return nil, fmt.Errorf("%g is not one of the enum values of parameter %q", out, p.Name)
+	}
 	return out, nil
 }
 
@@ -913,7 +1179,7 @@ func (p *FloatParameter) Manifest() ParameterManifest {
 		Name:         p.Name,
 		Type:         p.Type,
 		Required:     r,
-		Description:  p.Desc,
+		Description:  p.description(),
 		AuthServices: authServiceNames,
 		Default:      p.GetDefault(),
 	}
@@ -923,10 +1189,18 @@ func (p *FloatParameter) Manifest() ParameterManifest {
 // json schema only allow numeric types of 'integer' and 'number'.
 func (p *FloatParameter) McpManifest() (ParameterMcpManifest, []string) {
 	authServiceNames := getAuthServiceNames(p.AuthServices)
-	return ParameterMcpManifest{
+	m := ParameterMcpManifest{
 		Type:        "number",
-		Description: p.Desc,
-	}, authServiceNames
+		Description: p.description(),
+		Enum:        p.Enum,
+	}
+	if p.MinValue != nil {
+		m.Minimum = *p.MinValue
+	}
+	if p.MaxValue != nil {
+		m.Maximum = *p.MaxValue
+	}
+	return m, authServiceNames
 }
 
 // NewBooleanParameter is a convenience function for initializing a BooleanParameter.
@@ -982,6 +1256,9 @@ func (p *BooleanParameter) Parse(v any) (any, error) {
 	if p.IsExcludedValues(newV) {
 		return nil, fmt.Errorf("%t is an excluded value", newV)
 	}
+	if !p.IsEnumValue(newV) {
+		return nil, fmt.Errorf("%t is not one of the enum values of parameter %q", newV, p.Name)
+	}
 	return newV, nil
 }
 
@@ -1005,7 +1282,7 @@ func (p *BooleanParameter) Manifest() ParameterManifest {
 		Name:         p.Name,
 		Type:         p.Type,
 		Required:     r,
-		Description:  p.Desc,
+		Description:  p.description(),
 		AuthServices: authServiceNames,
 		Default:      p.GetDefault(),
 	}
@@ -1029,6 +1306,9 @@ func WithArrayExcludedValues(v []any) ArrayParameterOption {
 func WithArrayDefault(v []any) ArrayParameterOption {
 	return func(p *ArrayParameter) { p.Default = &v }
 }
+func WithArrayItemsMax(v *int) ArrayParameterOption {
+	return func(p *ArrayParameter) { p.ItemsMax = v }
+}
 
 func NewArrayParameter(name string, desc string, items Parameter, opts ...ArrayParameterOption) *ArrayParameter {
 	p := &ArrayParameter{
@@ -1053,6 +1333,8 @@ type ArrayParameter struct {
 	CommonParameter `yaml:",inline"`
 	Default         *[]any    `yaml:"default"`
 	Items           Parameter `yaml:"items"`
+	// ItemsMax is the maximum number of items of values.
+	ItemsMax *int `yaml:"itemsMax"`
 }
 
 func (p *ArrayParameter) UnmarshalYAML(ctx context.Context, unmarshal func(interface{}) error) error {
@@ -1060,12 +1342,14 @@ func (p *ArrayParameter) UnmarshalYAML(ctx context.Context, unmarshal func(inter
 		CommonParameter `yaml:",inline"`
 		Default         *[]any                  `yaml:"default"`
 		Items           util.DelayedUnmarshaler `yaml:"items"`
+		ItemsMax        *int                    `yaml:"itemsMax"`
 	}
 	if err := unmarshal(&rawItem); err != nil {
 		return err
 	}
 	p.CommonParameter = rawItem.CommonParameter
 	p.Default = rawItem.Default
+	p.ItemsMax = rawItem.ItemsMax
 	i, err := parseParamFromDelayedUnmarshaler(ctx, &rawItem.Items)
 	if err != nil {
 		return fmt.Errorf("unable to parse 'items' field: %w", err)
@@ -1073,6 +1357,9 @@ func (p *ArrayParameter) UnmarshalYAML(ctx context.Context, unmarshal func(inter
 	if i.GetAuthServices() != nil && len(i.GetAuthServices()) != 0 {
 		return fmt.Errorf("nested items should not have auth services")
 	}
+	if i.GetFromClaim() != nil {
+		return fmt.Errorf("nested items should not have a claim")
+	}
 	p.Items = i
 
 	return nil
@@ -1115,6 +1402,9 @@ func (p *ArrayParameter) Parse(v any) (any, error) {
 	if p.IsExcludedValues(arrVal) {
 		return nil, fmt.Errorf("%s is an excluded value", arrVal)
 	}
+	if p.ItemsMax != nil && len(arrVal) > *p.ItemsMax {
+		return nil, fmt.Errorf("%d items are above the maximum of %d items", len(arrVal), *p.ItemsMax)
+	}
 	rtn := make([]any, 0, len(arrVal))
 	for idx, val := range arrVal {
 		val, err := p.Items.Parse(val)
@@ -1153,7 +1443,7 @@ func (p *ArrayParameter) Manifest() ParameterManifest {
 		Name:         p.Name,
 		Type:         p.Type,
 		Required:     r,
-		Description:  p.Desc,
+		Description:  p.description(),
 		AuthServices: authServiceNames,
 		Items:        &items,
 		Default:      p.GetDefault(),
@@ -1167,8 +1457,9 @@ func (p *ArrayParameter) McpManifest() (ParameterMcpManifest, []string) {
 	items, _ := p.Items.McpManifest()
 	return ParameterMcpManifest{
 		Type:        p.Type,
-		Description: p.Desc,
+		Description: p.description(),
 		Items:       &items,
+		MaxItems:    p.ItemsMax,
 	}, authServiceNames
 }
 
@@ -1365,7 +1656,7 @@ func (p *MapParameter) Manifest() ParameterManifest {
 		Name:                 p.Name,
 		Type:                 "object",
 		Required:             r,
-		Description:          p.Desc,
+		Description:          p.description(),
 		AuthServices:         authServiceNames,
 		AdditionalProperties: additionalProperties,
 		Default:              defaultV,
@@ -1390,7 +1681,7 @@ func (p *MapParameter) McpManifest() (ParameterMcpManifest, []string) {
 
 	return ParameterMcpManifest{
 		Type:                 "object",
-		Description:          p.Desc,
+		Description:          p.description(),
 		AdditionalProperties: additionalProperties,
 	}, authServiceNames
 }
@@ -380,6 +380,8 @@ func TestAuthParametersMarshal(t *testing.T) {
 		t.Fatalf("unexpected error: %s", err)
 	}
 	authServices := []parameters.ParamAuthService{{Name: "my-google-auth-service", Field: "user_id"}, {Name: "other-auth-service", Field: "user_id"}}
+	fromClaim := parameters.NewStringParameter("owner", "the end user")
+	fromClaim.FromClaim = &parameters.ClaimBinding{AuthService: "my-google-auth-service", Claim: "email"}
 	tcs := []struct {
 		name string
 		in   []map[string]any
@@ -431,6 +433,21 @@ func TestAuthParametersMarshal(t *testing.T) {
 				parameters.NewStringParameter("my_string", "this param is a string", parameters.WithStringAuth(authServices)),
 			},
 		},
+		{
+			name: "string from claim",
+			in: []map[string]any{
+				{
+					"name":        "owner",
+					"type":        "string",
+					"description": "the end user",
+					"fromClaim": map[string]string{
+						"authService": "my-google-auth-service",
+						"claim":       "email",
+					},
+				},
+			},
+			want: parameters.Parameters{fromClaim},
+		},
 		{
 			name: "int",
 			in: []map[string]any{
@@ -750,6 +767,34 @@ func TestParametersParse(t *testing.T) {
 				"my_string": "bar",
 			},
 		},
+		{
+			name: "string identifier",
+			params: parameters.Parameters{
+				parameters.NewStringParameter("my_table", "this param is a table", parameters.WithStringIdentifiers([]string{"flights", "bookings"})),
+			},
+			in: map[string]any{
+				"my_table": "bookings",
+			},
+			want: parameters.ParamValues{parameters.ParamValue{Name: "my_table", Value: "bookings"}},
+		},
+		{
+			name: "string identifiers match exactly",
+			params: parameters.Parameters{
+				parameters.NewStringParameter("my_table", "this param is a table", parameters.WithStringIdentifiers([]string{"flights"})),
+			},
+			in: map[string]any{
+				"my_table": "flights; DROP TABLE flights",
+			},
+		},
+		{
+			name: "string identifiers exclude enum values",
+			params: parameters.Parameters{
+				parameters.NewStringParameter("my_table", "this param is a table", parameters.WithStringIdentifiers([]string{"flights"}), parameters.WithStringEnum([]any{"bookings"})),
+			},
+			in: map[string]any{
+				"my_table": "bookings",
+			},
+		},
 		{
 			name: "string not allowed regex",
 			params: parameters.Parameters{
@@ -1010,6 +1055,81 @@ func TestParametersParse(t *testing.T) {
 				"my_int": 3,
 			},
 		},
+		{
+			name: "int enum",
+			params: parameters.Parameters{
+				parameters.NewIntParameter("my_int", "this param is an int", parameters.WithIntEnum([]any{uint64(1), uint64(2)})),
+			},
+			in: map[string]any{
+				"my_int": 2,
+			},
+			want: parameters.ParamValues{parameters.ParamValue{Name: "my_int", Value: 2}},
+		},
+		{
+			name: "int enum disallow",
+			params: parameters.Parameters{
+				parameters.NewIntParameter("my_int", "this param is an int", parameters.WithIntEnum([]any{uint64(1), uint64(2)})),
+			},
+			in: map[string]any{
+				"my_int": 3,
+			},
+		},
+		{
+			name: "string pattern",
+			params: parameters.Parameters{
+				parameters.NewStringParameter("my_string", "this param is a string", parameters.WithStringPattern(`^[A-Z]{2}$`)),
+			},
+			in: map[string]any{
+				"my_string": "UA",
+			},
+			want: parameters.ParamValues{parameters.ParamValue{Name: "my_string", Value: "UA"}},
+		},
+		{
+			name: "string pattern disallow",
+			params: parameters.Parameters{
+				parameters.NewStringParameter("my_string", "this param is a string", parameters.WithStringPattern(`^[A-Z]{2}$`)),
+			},
+			in: map[string]any{
+				"my_string": "UAL",
+			},
+		},
+		{
+			name: "string maxLength disallow",
+			params: parameters.Parameters{
+				parameters.NewStringParameter("my_string", "this param is a string", parameters.WithStringMaxLength(&intValue)),
+			},
+			in: map[string]any{
+				"my_string": "abc",
+			},
+		},
+		{
+			name: "string enum disallow",
+			params: parameters.Parameters{
+				parameters.NewStringParameter("my_string", "this param is a string", parameters.WithStringEnum([]any{"a", "b"})),
+			},
+			in: map[string]any{
+				"my_string": "ab",
+			},
+		},
+		{
+			name: "array itemsMax",
+			params: parameters.Parameters{
+				parameters.NewArrayParameter("my_array", "this param is an array", parameters.NewIntParameter("my_int", "int item"), parameters.WithArrayItemsMax(&intValue)),
+			},
+			in: map[string]any{
+				"my_array": []any{1, 2},
+			},
+			want: parameters.ParamValues{parameters.ParamValue{Name: "my_array", Value: []any{1, 2}}},
+		},
+		{
+			name: "array itemsMax disallow",
+			params: parameters.Parameters{
+				parameters.NewArrayParameter("my_array", "this param is an array", parameters.NewIntParameter("my_int", "int item"), parameters.WithArrayItemsMax(&intValue)),
+			},
+			in: map[string]any{
+				"my_array": []any{1, 2, 3},
+			},
+		},
 		{
 			name: "float",
 			params: parameters.Parameters{
@@ -1622,6 +1742,77 @@ func TestAuthParametersParse(t *testing.T) {
 	}
 }
 
+func TestFromClaimParametersParse(t *testing.T) {
+	owner := parameters.NewStringParameter("owner", "the end user")
+	owner.FromClaim = &parameters.ClaimBinding{AuthService: "my-auth", Claim: "email"}
+	params := parameters.Parameters{owner, parameters.NewIntParameter("limit", "the number of rows")}
+	tcs := []struct {
+		name      string
+		in        map[string]any
+		claimsMap map[string]map[string]any
+		want      parameters.ParamValues
+		err       string
+	}{
+		{
+			name:      "claim of the verified token",
+			in:        map[string]any{"limit": 10},
+			claimsMap: map[string]map[string]any{"my-auth": {"email": "alice@example.com"}},
+			want:      parameters.ParamValues{{Name: "owner", Value: "alice@example.com"}, {Name: "limit", Value: 10}},
+		},
+		{
+			name:      "value supplied by the client is ignored",
+			in:        map[string]any{"owner": "bob@example.com", "limit": 10},
+			claimsMap: map[string]map[string]any{"my-auth": {"email": "alice@example.com"}},
+			want:      parameters.ParamValues{{Name: "owner", Value: "alice@example.com"}, {Name: "limit", Value: 10}},
+		},
+		{
+			name:      "claim of the named auth service among two",
+			in:        map[string]any{"limit": 10},
+			claimsMap: map[string]map[string]any{"a-auth": {"email": "bob@example.com"}, "my-auth": {"email": "alice@example.com"}},
+			want:      parameters.ParamValues{{Name: "owner", Value: "alice@example.com"}, {Name: "limit", Value: 10}},
+		},
+		{
+			name:      "token of another auth service",
+			in:        map[string]any{"owner": "bob@example.com", "limit": 10},
+			claimsMap: map[string]map[string]any{"a-auth": {"email": "bob@example.com"}},
+			err:       `error parsing parameter "owner" from claim "email"`,
+		},
+		{
+			name: "no verified token",
+			in:   map[string]any{"owner": "bob@example.com", "limit": 10},
+			err:  `error parsing parameter "owner" from claim "email"`,
+		},
+		{
+			name:      "claim missing",
+			in:        map[string]any{"owner": "bob@example.com", "limit": 10},
+			claimsMap: map[string]map[string]any{"my-auth": {"sub": "123"}},
+			err:       `error parsing parameter "owner" from claim "email"`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := parameters.ParseParams(params, tc.in, tc.claimsMap)
+			if tc.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error from ParseParams: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("ParseParams() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	manifest := params.Manifest()
+	if len(manifest) != 1 || manifest[0].Name != "limit" {
+		t.Errorf("expected the parameter bound to a claim to be left out of the manifest, got %+v", manifest)
+	}
+}
+
 func TestParamValues(t *testing.T) {
 	tcs := []struct {
 		name              string
@@ -1829,6 +2020,7 @@ func TestParamManifest(t *testing.T) {
 }
 
 func TestParamMcpManifest(t *testing.T) {
+	maxLength, minInt, maxInt, maxFloat := 5, 0, 10, 1.5
 	tcs := []struct {
 		name          string
 		in            parameters.Parameter
@@ -1869,7 +2061,41 @@ func TestParamMcpManifest(t *testing.T) {
 			},
 			wantAuthParam: []string{},
 		},
-
+		{
+			name: "string with validation rules",
+			in:   parameters.NewStringParameter("foo-string", "bar", parameters.WithStringEnum([]any{"a", "b"}), parameters.WithStringPattern("^[ab]$"), parameters.WithStringMaxLength(&maxLength)),
+			want: parameters.ParameterMcpManifest{
+				Type:        "string",
+				Description: "bar",
+				Enum:        []any{"a", "b"},
+				Pattern:     "^[ab]$",
+				MaxLength:   &maxLength,
+			},
+			wantAuthParam: []string{},
+		},
+		{
+			name:          "int with range",
+			in:            parameters.NewIntParameter("foo-int", "bar", parameters.WithIntMinValue(&minInt), parameters.WithIntMaxValue(&maxInt)),
+			want:          parameters.ParameterMcpManifest{Type: "integer", Description: "bar", Minimum: 0, Maximum: 10},
+			wantAuthParam: []string{},
+		},
+		{
+			name:          "float with range",
+			in:            parameters.NewFloatParameter("foo-float", "bar", parameters.WithFloatMaxValue(&maxFloat)),
+			want:          parameters.ParameterMcpManifest{Type: "number", Description: "bar", Maximum: 1.5},
+			wantAuthParam: []string{},
+		},
+		{
+			name: "array with itemsMax",
+			in:   parameters.NewArrayParameter("foo-array", "bar", parameters.NewStringParameter("foo-string", "bar"), parameters.WithArrayItemsMax(&maxLength)),
+			want: parameters.ParameterMcpManifest{
+				Type:        "array",
+				Description: "bar",
+				Items:       &parameters.ParameterMcpManifest{Type: "string", Description: "bar"},
+				MaxItems:    &maxLength,
+			},
+			wantAuthParam: []string{},
+		},
 		{
 			name: "map with string values",
 			in:   parameters.NewMapParameter("foo-map", "bar", "string"),
@@ -1923,7 +2149,7 @@ func TestFailParametersUnmarshal(t *testing.T) {
 					"description": "this is a param for string",
 				},
 			},
-			err: "unable to parse as \"string\": Key: 'CommonParameter.Name' Error:Field validation for 'Name' failed on the 'required' tag",
+			err: "unable to parse as \"string\": name is required; add a \"name\" field",
 		},
 		{
 			name: "common parameter missing type",
@@ -1954,7 +2180,32 @@ func TestFailParametersUnmarshal(t *testing.T) {
 					"type": "string",
 				},
 			},
-			err: "unable to parse as \"string\": Key: 'CommonParameter.Desc' Error:Field validation for 'Desc' failed on the 'required' tag",
+			err: "unable to parse as \"string\": description is required; add a \"description\" field",
+		},
+		{
+			name: "string parameter with invalid pattern",
+			in: []map[string]any{
+				{
+					"name":        "my_string",
+					"type":        "string",
+					"description": "this is a param for string",
+					"pattern":     "[a-",
+				},
+			},
+			err: "invalid pattern of parameter \"my_string\"",
+		},
+		{
+			name: "string parameter with identifiers and enum",
+			in: []map[string]any{
+				{
+					"name":        "my_table",
+					"type":        "string",
+					"description": "this param is a table",
+					"identifiers": []string{"flights"},
+					"enum":        []string{"bookings"},
+				},
+			},
+			err: "parameter \"my_table\" cannot specify both 'identifiers' and 'enum'",
 		},
 		{
 			name: "array parameter missing items",
@@ -1980,7 +2231,7 @@ func TestFailParametersUnmarshal(t *testing.T) {
 					},
 				},
 			},
-			err: "unable to parse as \"array\": unable to parse 'items' field: unable to parse as \"string\": Key: 'CommonParameter.Name' Error:Field validation for 'Name' failed on the 'required' tag",
+			err: "unable to parse as \"array\": unable to parse 'items' field: unable to parse as \"string\": name is required; add a \"name\" field",
 		},
 		// --- MODIFIED MAP PARAMETER TEST ---
 		{
@@ -1995,6 +2246,31 @@ func TestFailParametersUnmarshal(t *testing.T) {
 			},
 			err: "unsupported valueType \"not-a-real-type\" for map parameter",
 		},
+		{
+			name: "claim without auth service",
+			in: []map[string]any{
+				{
+					"name":        "owner",
+					"type":        "string",
+					"description": "the end user",
+					"fromClaim":   map[string]any{"claim": "email"},
+				},
+			},
+			err: "parameter \"owner\" must specify the 'authService' and 'claim' of 'fromClaim'",
+		},
+		{
+			name: "claim with auth services",
+			in: []map[string]any{
+				{
+					"name":         "owner",
+					"type":         "string",
+					"description":  "the end user",
+					"fromClaim":    map[string]any{"authService": "my-auth", "claim": "email"},
+					"authServices": []map[string]any{{"name": "my-auth", "field": "email"}},
+				},
+			},
+			err: "parameter \"owner\" cannot specify 'fromClaim' with 'authServices' or 'valueFromParam'",
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.name, func(t *testing.T) {
@@ -2329,6 +2605,37 @@ func TestFailResolveTemplateParameters(t *testing.T) {
 	}
 }
 
+func TestResolveTemplateIdentifiers(t *testing.T) {
+	params := parameters.Parameters{
+		parameters.NewStringParameter("tableName", "Table to select from.", parameters.WithStringIdentifiers([]string{"flights", "Bookings"}), parameters.WithStringEscape("double-quotes")),
+		parameters.NewArrayParameter("columnNames", "Columns to select", parameters.NewStringParameter("column", "A column", parameters.WithStringIdentifiers([]string{"id", "name"}))),
+	}
+	values, err := parameters.ParseParams(params, map[string]any{"tableName": "Bookings", "columnNames": []any{"id", "name"}}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	got, err := parameters.ResolveTemplateParams(params, "SELECT {{array .columnNames}} FROM {{.tableName}}", values.AsMap())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := `SELECT id, name FROM "Bookings"`; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	_, err = parameters.ParseParams(params, map[string]any{"tableName": "flights", "columnNames": []any{"id", "password"}}, nil)
+	if err == nil || !strings.Contains(err.Error(), "not one of the identifiers") {
+		t.Errorf("expected an identifier error, got %v", err)
+	}
+
+	m, _ := params[0].McpManifest()
+	if want := "Table to select from. One of: flights, Bookings."; m.Description != want {
+		t.Errorf("got description %q, want %q", m.Description, want)
+	}
+	if want := []any{"flights", "Bookings"}; !cmp.Equal(m.Enum, want) {
+		t.Errorf("got enum %v, want %v", m.Enum, want)
+	}
+}
+
 func TestCheckParamRequired(t *testing.T) {
 	tcs := []struct {
 		name     string
@@ -2370,3 +2677,61 @@ func TestCheckParamRequired(t *testing.T) {
 		})
 	}
 }
+
+func TestRequiredIf(t *testing.T) {
+	ps := parameters.Parameters{
+		parameters.NewStringParameter("mode", "Search mode."),
+		parameters.NewIntParameter("limit", "Maximum results.", parameters.WithIntRequired(false)),
+		&parameters.StringParameter{CommonParameter: parameters.CommonParameter{
+			Name:       "endDate",
+			Type:       parameters.TypeString,
+			Desc:       "End of the range.",
+			RequiredIf: map[string]any{"mode": "range"},
+		}},
+		&parameters.StringParameter{CommonParameter: parameters.CommonParameter{
+			Name:       "cursor",
+			Type:       parameters.TypeString,
+			Desc:       "Page cursor.",
+			RequiredIf: map[string]any{"mode": "paged", "limit": 10},
+		}},
+	}
+	tcs := []struct {
+		name    string
+		in      map[string]any
+		wantErr string
+	}{
+		{name: "condition met and set", in: map[string]any{"mode": "range", "endDate": "2026-01-31"}},
+		{name: "condition met and missing", in: map[string]any{"mode": "range"}, wantErr: `parameter "endDate" is required when "mode" is "range"`},
+		{name: "condition met and null", in: map[string]any{"mode": "range", "endDate": nil}, wantErr: `parameter "endDate" is required when "mode" is "range"`},
+		{name: "condition not met", in: map[string]any{"mode": "single"}},
+		{name: "all conditions met", in: map[string]any{"mode": "paged", "limit": 10}, wantErr: `parameter "cursor" is required when "limit" is 10 and "mode" is "paged"`},
+		{name: "some conditions met", in: map[string]any{"mode": "paged", "limit": 20}},
+		{name: "condition on missing sibling", in: map[string]any{"mode": "paged"}},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := parameters.ParseParams(ps, tc.in, nil)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.wantErr {
+				t.Fatalf("unexpected error: got %v, want %q", err, tc.wantErr)
+			}
+		})
+	}
+
+	manifest := ps[3].Manifest()
+	if manifest.Required {
+		t.Errorf("expected a conditionally required parameter to be optional in the manifest")
+	}
+	wantDesc := `Page cursor. Required when "limit" is 10 and "mode" is "paged".`
+	if manifest.Description != wantDesc {
+		t.Errorf("unexpected manifest description: got %q, want %q", manifest.Description, wantDesc)
+	}
+	if mcp, _ := ps[3].McpManifest(); mcp.Description != wantDesc {
+		t.Errorf("unexpected MCP manifest description: got %q, want %q", mcp.Description, wantDesc)
+	}
+}
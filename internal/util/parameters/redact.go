@@ -0,0 +1,79 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parameters
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+)
+
+// redactionKey keys the fingerprints of sensitive values. It is random per
+// process, so that fingerprints correlate the invocations of a process
+// without allowing the values to be guessed from a dictionary.
+var redactionKey = func() []byte {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		panic(fmt.Sprintf("unable to generate redaction key: %s", err))
+	}
+	return key
+}()
+
+// RedactValue returns the fingerprint replacing a sensitive value in logs,
+// traces and audit records. Equal values have equal fingerprints.
+func RedactValue(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		b = []byte(fmt.Sprint(v))
+	}
+	mac := hmac.New(sha256.New, redactionKey)
+	mac.Write(b)
+	return "[REDACTED:" + hex.EncodeToString(mac.Sum(nil))[:16] + "]"
+}
+
+// SensitiveNames returns the names of the sensitive parameters.
+func SensitiveNames(ps Parameters) map[string]bool {
+	var names map[string]bool
+	for _, p := range ps {
+		if p.GetSensitive() {
+			if names == nil {
+				names = make(map[string]bool)
+			}
+			names[p.GetName()] = true
+		}
+	}
+	return names
+}
+
+// Redact returns a copy of the values with the values of the sensitive
+// parameters replaced by their fingerprint, for logging. The values passed
+// to the tool are left unchanged.
+func Redact(ps Parameters, values ParamValues) ParamValues {
+	sensitive := SensitiveNames(ps)
+	if len(sensitive) == 0 {
+		return values
+	}
+	redacted := make(ParamValues, len(values))
+	for i, v := range values {
+		redacted[i] = v
+		if sensitive[v.Name] && v.Value != nil {
+			redacted[i].Value = RedactValue(v.Value)
+		}
+	}
+	return redacted
+}
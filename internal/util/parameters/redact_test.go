@@ -0,0 +1,67 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parameters_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+func TestRedact(t *testing.T) {
+	email := parameters.NewStringParameter("email", "the email of the customer")
+	email.Sensitive = true
+	ps := parameters.Parameters{email, parameters.NewIntParameter("limit", "the maximum number of rows")}
+	values := parameters.ParamValues{{Name: "email", Value: "jane@example.com"}, {Name: "limit", Value: 10}}
+
+	redacted := parameters.Redact(ps, values)
+	if values[0].Value != "jane@example.com" {
+		t.Fatalf("Redact modified the values: %v", values)
+	}
+	got := redacted.AsMap()
+	fingerprint, _ := got["email"].(string)
+	if !strings.HasPrefix(fingerprint, "[REDACTED:") || strings.Contains(fingerprint, "jane") {
+		t.Errorf("got email %q, want a fingerprint", got["email"])
+	}
+	if fingerprint != parameters.RedactValue("jane@example.com") {
+		t.Errorf("fingerprints of equal values differ")
+	}
+	if fingerprint == parameters.RedactValue("john@example.com") {
+		t.Errorf("fingerprints of different values are equal")
+	}
+	if got["limit"] != 10 {
+		t.Errorf("got limit %v, want 10", got["limit"])
+	}
+	if names := parameters.SensitiveNames(ps); len(names) != 1 || !names["email"] {
+		t.Errorf("got sensitive names %v, want only email", names)
+	}
+}
+
+func TestParseSensitiveParamError(t *testing.T) {
+	code := parameters.NewIntParameter("code", "the customer code")
+	code.Sensitive = true
+	_, err := parameters.ParseParams(parameters.Parameters{code}, map[string]any{"code": "secret-value"}, nil)
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	if strings.Contains(err.Error(), "secret-value") {
+		t.Errorf("error %q quotes the sensitive value", err)
+	}
+	if _, ok := err.(*util.AgentError); !ok {
+		t.Errorf("got error of type %T, want *util.AgentError", err)
+	}
+}
@@ -20,10 +20,12 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"slices"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"unicode"
 
-	"github.com/go-playground/validator/v10"
 	yaml "github.com/goccy/go-yaml"
 	"github.com/googleapis/mcp-toolbox/internal/log"
 	"github.com/googleapis/mcp-toolbox/internal/telemetry"
@@ -148,6 +150,13 @@ func (rt *UserAgentRoundTripper) RoundTrip(req *http.Request) (*http.Response, e
 }
 
 func NewStrictDecoder(v interface{}) (*yaml.Decoder, error) {
+	return NewStrictDecoderAt(v, "")
+}
+
+// NewStrictDecoderAt returns a strict decoder of v, whose validation errors
+// are ConfigErrors locating the invalid fields under the YAML path, e.g.
+// sources[my-pg].
+func NewStrictDecoderAt(v interface{}, path string) (*yaml.Decoder, error) {
 	b, err := yaml.Marshal(v)
 	if err != nil {
 		return nil, fmt.Errorf("fail to marshal %q: %w", v, err)
@@ -156,7 +165,7 @@ func NewStrictDecoder(v interface{}) (*yaml.Decoder, error) {
 	dec := yaml.NewDecoder(
 		bytes.NewReader(b),
 		yaml.Strict(),
-		yaml.Validator(validator.New()),
+		yaml.Validator(newConfigValidator(path)),
 	)
 	return dec, nil
 }
@@ -233,6 +242,200 @@ func GenAIMetricAttrsFromContext(ctx context.Context) *GenAIMetricAttrs {
 	return nil
 }
 
+// ToolVariant holds the variant of a tool selected for an invocation
+type ToolVariant struct {
+	// Forced is the variant an administrator pinned for the invocation.
+	Forced string
+	// Selected is the variant the tool ran.
+	Selected string
+}
+
+const toolVariantKey contextKey = "toolVariant"
+
+// WithToolVariant adds a ToolVariant to the context
+func WithToolVariant(ctx context.Context, v *ToolVariant) context.Context {
+	return context.WithValue(ctx, toolVariantKey, v)
+}
+
+// ToolVariantFromContext retrieves the ToolVariant from context
+func ToolVariantFromContext(ctx context.Context) *ToolVariant {
+	if v, ok := ctx.Value(toolVariantKey).(*ToolVariant); ok {
+		return v
+	}
+	return nil
+}
+
+// ReplicaLag holds the replication lag of the replica an invocation read from
+type ReplicaLag struct {
+	// Measured is whether the invocation read from a replica whose lag was
+	// measured.
+	Measured bool
+	// Seconds is the replication lag of the replica.
+	Seconds float64
+}
+
+const replicaLagKey contextKey = "replicaLag"
+
+// WithReplicaLag adds a ReplicaLag to the context
+func WithReplicaLag(ctx context.Context, v *ReplicaLag) context.Context {
+	return context.WithValue(ctx, replicaLagKey, v)
+}
+
+// ReplicaLagFromContext retrieves the ReplicaLag from context
+func ReplicaLagFromContext(ctx context.Context) *ReplicaLag {
+	if v, ok := ctx.Value(replicaLagKey).(*ReplicaLag); ok {
+		return v
+	}
+	return nil
+}
+
+// InvocationCost accumulates the cost sources report for the invocations of
+// a request, such as the bytes billed by BigQuery. It is safe for concurrent
+// use.
+type InvocationCost struct {
+	bytesBilled atomic.Int64
+}
+
+// TakeBytesBilled returns the bytes billed recorded since the last call.
+func (c *InvocationCost) TakeBytesBilled() int64 {
+	return c.bytesBilled.Swap(0)
+}
+
+const invocationCostKey contextKey = "invocationCost"
+
+// WithInvocationCost adds an InvocationCost to the context
+func WithInvocationCost(ctx context.Context, c *InvocationCost) context.Context {
+	return context.WithValue(ctx, invocationCostKey, c)
+}
+
+// InvocationCostFromContext retrieves the InvocationCost from context
+func InvocationCostFromContext(ctx context.Context) *InvocationCost {
+	if c, ok := ctx.Value(invocationCostKey).(*InvocationCost); ok {
+		return c
+	}
+	return nil
+}
+
+// RecordBytesBilled records bytes billed by a source in the invocation cost
+// of the context. It is a no-op when the context has no invocation cost.
+func RecordBytesBilled(ctx context.Context, bytes int64) {
+	if c := InvocationCostFromContext(ctx); c != nil {
+		c.bytesBilled.Add(bytes)
+	}
+}
+
+const readOnlyInvocationKey contextKey = "readOnlyInvocation"
+
+// WithReadOnlyInvocation marks the context as the invocation of a tool
+// annotated as read-only, which sources may route to a read replica.
+func WithReadOnlyInvocation(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readOnlyInvocationKey, true)
+}
+
+// ReadOnlyInvocationFromContext reports whether the context is the invocation
+// of a tool annotated as read-only.
+func ReadOnlyInvocationFromContext(ctx context.Context) bool {
+	readOnly, _ := ctx.Value(readOnlyInvocationKey).(bool)
+	return readOnly
+}
+
+const writeInvocationKey contextKey = "writeInvocation"
+
+// WithWriteInvocation marks the context as the invocation of a tool with
+// write or admin intent, whose failed operations sources must not retry.
+func WithWriteInvocation(ctx context.Context) context.Context {
+	return context.WithValue(ctx, writeInvocationKey, true)
+}
+
+// WriteInvocationFromContext reports whether the context is the invocation
+// of a tool with write or admin intent.
+func WriteInvocationFromContext(ctx context.Context) bool {
+	write, _ := ctx.Value(writeInvocationKey).(bool)
+	return write
+}
+
+// ResultPage holds the token of the next page of the result of an invocation
+// whose result was split into pages.
+type ResultPage struct {
+	// NextPageToken reads the rows past the page returned, or is empty if
+	// the result was returned whole.
+	NextPageToken string
+}
+
+const resultPageKey contextKey = "resultPage"
+
+// WithResultPage adds a ResultPage to the context. Results are only split
+// into pages for contexts with one.
+func WithResultPage(ctx context.Context, v *ResultPage) context.Context {
+	return context.WithValue(ctx, resultPageKey, v)
+}
+
+// ResultPageFromContext retrieves the ResultPage from context
+func ResultPageFromContext(ctx context.Context) *ResultPage {
+	if v, ok := ctx.Value(resultPageKey).(*ResultPage); ok {
+		return v
+	}
+	return nil
+}
+
+// ResultPager reads the pages of the results split into pages.
+type ResultPager interface {
+	// Page returns the rows of the page of token, the name of the tool the
+	// result is from, and the token of the next page, if any.
+	Page(token string) (rows []any, toolName string, next string, err error)
+}
+
+const resultPagerKey contextKey = "resultPager"
+
+// WithResultPager adds a ResultPager to the context
+func WithResultPager(ctx context.Context, p ResultPager) context.Context {
+	return context.WithValue(ctx, resultPagerKey, p)
+}
+
+// ResultPagerFromContext retrieves the ResultPager from context
+func ResultPagerFromContext(ctx context.Context) ResultPager {
+	if v, ok := ctx.Value(resultPagerKey).(ResultPager); ok {
+		return v
+	}
+	return nil
+}
+
+const listPageSizeKey contextKey = "listPageSize"
+
+// WithListPageSize adds the number of tools listed at once to the context.
+func WithListPageSize(ctx context.Context, size int) context.Context {
+	return context.WithValue(ctx, listPageSizeKey, size)
+}
+
+// ListPageSizeFromContext retrieves the number of tools listed at once, zero
+// listing every tool at once.
+func ListPageSizeFromContext(ctx context.Context) int {
+	size, _ := ctx.Value(listPageSizeKey).(int)
+	return size
+}
+
+// UsageRecorder records the usage of the tools invoked by a request.
+type UsageRecorder interface {
+	// RecordInvocation records an invocation of a tool, its result or error,
+	// and how long it ran.
+	RecordInvocation(ctx context.Context, toolName string, result any, err error, seconds float64)
+}
+
+const usageRecorderKey contextKey = "usageRecorder"
+
+// WithUsageRecorder adds a UsageRecorder to the context
+func WithUsageRecorder(ctx context.Context, r UsageRecorder) context.Context {
+	return context.WithValue(ctx, usageRecorderKey, r)
+}
+
+// UsageRecorderFromContext retrieves the UsageRecorder from context
+func UsageRecorderFromContext(ctx context.Context) UsageRecorder {
+	if r, ok := ctx.Value(usageRecorderKey).(UsageRecorder); ok {
+		return r
+	}
+	return nil
+}
+
 const authTokenClaimsKey contextKey = "authTokenClaims"
 
 // WithAuthTokenClaims adds auth token claims into the context as a value
@@ -263,6 +466,39 @@ func ClientIPFromContext(ctx context.Context) (string, bool) {
 	return "", false
 }
 
+const sourceIPKey contextKey = "sourceIP"
+
+// WithSourceIP adds the address a request was received from into the
+// context. Unlike the client IP, it is only taken from proxy headers when
+// the server trusts its proxy.
+func WithSourceIP(ctx context.Context, sourceIP string) context.Context {
+	return context.WithValue(ctx, sourceIPKey, sourceIP)
+}
+
+// SourceIPFromContext retrieves the source IP address or returns false if not present
+func SourceIPFromContext(ctx context.Context) (string, bool) {
+	if ip, ok := ctx.Value(sourceIPKey).(string); ok && ip != "" {
+		return ip, true
+	}
+	return "", false
+}
+
+const identityKey contextKey = "identity"
+
+// WithIdentity adds the identity a request was authenticated as, such as the
+// email of an IAP user, into the context.
+func WithIdentity(ctx context.Context, identity string) context.Context {
+	return context.WithValue(ctx, identityKey, identity)
+}
+
+// IdentityFromContext retrieves the identity of a request or returns false if not present
+func IdentityFromContext(ctx context.Context) (string, bool) {
+	if id, ok := ctx.Value(identityKey).(string); ok && id != "" {
+		return id, true
+	}
+	return "", false
+}
+
 // ExtractClientIP retrieves the leftmost client IP from X-Forwarded-For or X-Real-IP header
 func ExtractClientIP(header http.Header) string {
 	if xff := header.Get("X-Forwarded-For"); xff != "" {
@@ -317,6 +553,40 @@ func SQLCommenterEnabledFromContext(ctx context.Context) bool {
 	return false
 }
 
+const paramCoercionKey contextKey = "paramCoercion"
+
+// WithParamCoercion adds the server-wide coercion mode of parameter values to
+// the context
+func WithParamCoercion(ctx context.Context, mode string) context.Context {
+	return context.WithValue(ctx, paramCoercionKey, mode)
+}
+
+// ParamCoercionFromContext retrieves the server-wide coercion mode of
+// parameter values from context
+func ParamCoercionFromContext(ctx context.Context) string {
+	if mode, ok := ctx.Value(paramCoercionKey).(string); ok {
+		return mode
+	}
+	return ""
+}
+
+const redactErrorDetailsKey contextKey = "redactErrorDetails"
+
+// WithRedactErrorDetails adds whether the details of invocation errors are
+// withheld from clients to the context
+func WithRedactErrorDetails(ctx context.Context, redact bool) context.Context {
+	return context.WithValue(ctx, redactErrorDetailsKey, redact)
+}
+
+// RedactErrorDetailsFromContext retrieves whether the details of invocation
+// errors are withheld from clients from context
+func RedactErrorDetailsFromContext(ctx context.Context) bool {
+	if redact, ok := ctx.Value(redactErrorDetailsKey).(bool); ok {
+		return redact
+	}
+	return false
+}
+
 // toolboxVersionKey is the key used to store toolbox version within context
 const toolboxVersionKey contextKey = "toolboxVersion"
 
@@ -349,6 +619,44 @@ func IgnoreUnknownToolsFromContext(ctx context.Context) bool {
 	return false
 }
 
+// DefaultLocale is the locale of tool descriptions when the server does not
+// set one.
+const DefaultLocale = "en"
+
+const defaultLocaleKey contextKey = "defaultLocale"
+
+// WithDefaultLocale adds the default locale of tool descriptions to the context
+func WithDefaultLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, defaultLocaleKey, locale)
+}
+
+// DefaultLocaleFromContext retrieves the default locale of tool descriptions
+// from context, or DefaultLocale if it is not set.
+func DefaultLocaleFromContext(ctx context.Context) string {
+	if locale, ok := ctx.Value(defaultLocaleKey).(string); ok && locale != "" {
+		return locale
+	}
+	return DefaultLocale
+}
+
+// localesKey is the key used to store the locales of a request within context
+const localesKey contextKey = "locales"
+
+// WithLocales adds the locales to serve tool descriptions in, most preferred
+// first, into the context as a value. The last locale is the default locale.
+func WithLocales(ctx context.Context, locales []string) context.Context {
+	return context.WithValue(ctx, localesKey, locales)
+}
+
+// LocalesFromContext retrieves the locales to serve tool descriptions in from
+// context.
+func LocalesFromContext(ctx context.Context) []string {
+	if locales, ok := ctx.Value(localesKey).([]string); ok {
+		return locales
+	}
+	return nil
+}
+
 // urlParamsKey is the key used to store URL parameters within context
 const urlParamsKey contextKey = "urlParams"
 
@@ -365,6 +673,82 @@ func UrlParamsFromContext(ctx context.Context) (map[string]string, bool) {
 	return nil, false
 }
 
+// ProgressReporter delivers a progress notification for the request being
+// processed. total is 0 when the total amount of work is unknown.
+type ProgressReporter func(progress, total float64, message string)
+
+// progressReporterKey is the key used to store the progress reporter within context
+const progressReporterKey contextKey = "progressReporter"
+
+// WithProgressReporter adds a progress reporter into the context as a value
+func WithProgressReporter(ctx context.Context, reporter ProgressReporter) context.Context {
+	return context.WithValue(ctx, progressReporterKey, reporter)
+}
+
+// ReportProgress reports progress for the request being processed. It is a
+// no-op when the client did not ask for progress notifications or the
+// transport cannot deliver them.
+func ReportProgress(ctx context.Context, progress, total float64, message string) {
+	if reporter, ok := ctx.Value(progressReporterKey).(ProgressReporter); ok && reporter != nil {
+		reporter(progress, total, message)
+	}
+}
+
+// ExecutedStatement is a statement a tool ran on its source, with the values
+// of its parameters.
+type ExecutedStatement struct {
+	Statement string `json:"statement"`
+	Params    []any  `json:"params,omitempty"`
+}
+
+// StatementLog collects the statements run by a tool invocation, such as for
+// its audit record. It is safe for concurrent use.
+type StatementLog struct {
+	mu         sync.Mutex
+	statements []ExecutedStatement
+}
+
+// Statements returns the statements recorded so far.
+func (l *StatementLog) Statements() []ExecutedStatement {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return slices.Clone(l.statements)
+}
+
+const statementLogKey contextKey = "statementLog"
+
+// WithStatementLog adds a statement log into the context as a value
+func WithStatementLog(ctx context.Context, l *StatementLog) context.Context {
+	return context.WithValue(ctx, statementLogKey, l)
+}
+
+// RecordStatement records a statement run on a source in the statement log
+// of the context. It is a no-op when the context has no statement log.
+func RecordStatement(ctx context.Context, statement string, params []any) {
+	if l, ok := ctx.Value(statementLogKey).(*StatementLog); ok && l != nil {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.statements = append(l.statements, ExecutedStatement{Statement: statement, Params: params})
+	}
+}
+
+const authServiceClaimsKey contextKey = "authServiceClaims"
+
+// WithAuthServiceClaims adds the claims of the auth services a tool
+// invocation was verified by, keyed by auth service name, into the context.
+func WithAuthServiceClaims(ctx context.Context, claims map[string]map[string]any) context.Context {
+	return context.WithValue(ctx, authServiceClaimsKey, claims)
+}
+
+// AuthServiceClaimsFromContext retrieves the claims of the auth services a
+// tool invocation was verified by.
+func AuthServiceClaimsFromContext(ctx context.Context) map[string]map[string]any {
+	if claims, ok := ctx.Value(authServiceClaimsKey).(map[string]map[string]any); ok {
+		return claims
+	}
+	return nil
+}
+
 // SnakeFromCamelCase converts a camelCase string to snake_case.
 func SnakeFromCamelCase(s string) string {
 	var result strings.Builder
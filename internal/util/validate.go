@@ -0,0 +1,232 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// secretFields are the substrings of the lowercased names of the fields whose
+// values are secret.
+var secretFields = []string{"password", "secret", "token", "apikey", "api_key", "credential", "privatekey"}
+
+// IsSecretField reports whether the values of the configuration field are
+// secret, from its name.
+func IsSecretField(field string) bool {
+	field = strings.ToLower(field)
+	for _, s := range secretFields {
+		if strings.Contains(field, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// ConfigFieldError is an invalid field of a configuration.
+type ConfigFieldError struct {
+	validator.FieldError
+	// Path is the YAML path of the field, e.g. sources[my-pg].project.
+	Path string
+	// Value is the invalid value, masked if the field is secret.
+	Value string
+	// Problem describes why the value is invalid.
+	Problem string
+	// Suggestion describes how to fix the value.
+	Suggestion string
+}
+
+func (e ConfigFieldError) Error() string {
+	if e.Tag() == "required" {
+		return fmt.Sprintf("%s %s; %s", e.Path, e.Problem, e.Suggestion)
+	}
+	return fmt.Sprintf("%s has invalid value %q: %s; %s", e.Path, e.Value, e.Problem, e.Suggestion)
+}
+
+// ConfigErrors are the invalid fields of a configuration.
+type ConfigErrors []ConfigFieldError
+
+func (e ConfigErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, fe := range e {
+		msgs[i] = fe.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// configValidationError wraps ConfigErrors in a type that is not a slice, as
+// the YAML decoder rewrites slices of field errors with the position and
+// source of the field in the re-marshaled configuration, which differ from
+// those of the file.
+type configValidationError struct {
+	errs ConfigErrors
+}
+
+func (e configValidationError) Error() string {
+	return e.errs.Error()
+}
+
+func (e configValidationError) Unwrap() error {
+	return e.errs
+}
+
+// configValidator validates configurations, reporting their invalid fields
+// by YAML path under its path.
+type configValidator struct {
+	validate *validator.Validate
+	path     string
+}
+
+func newConfigValidator(path string) configValidator {
+	return configValidator{validate: validator.New(), path: path}
+}
+
+// Struct validates s, returning an error wrapping ConfigErrors for its
+// invalid fields.
+func (c configValidator) Struct(s any) error {
+	err := c.validate.Struct(s)
+	var verrs validator.ValidationErrors
+	if !errors.As(err, &verrs) {
+		return err
+	}
+	t := reflect.TypeOf(s)
+	out := make(ConfigErrors, len(verrs))
+	for i, fe := range verrs {
+		path := yamlPath(t, fe.StructNamespace())
+		if path == "" {
+			path = fe.Field()
+		}
+		if c.path != "" {
+			path = c.path + "." + path
+		}
+		value := fmt.Sprint(fe.Value())
+		if IsSecretField(fe.StructField()) {
+			value = "****"
+		}
+		problem, suggestion := describeFieldError(fe, path)
+		out[i] = ConfigFieldError{FieldError: fe, Path: path, Value: value, Problem: problem, Suggestion: suggestion}
+	}
+	return configValidationError{errs: out}
+}
+
+// yamlPath returns the YAML path of the field of t at the namespace of Go
+// field names, such as Config.ConfigBase.Items[0], skipping inline fields.
+func yamlPath(t reflect.Type, namespace string) string {
+	segments := strings.Split(namespace, ".")
+	var path []string
+	for _, segment := range segments[1:] {
+		name, index, _ := strings.Cut(segment, "[")
+		if index != "" {
+			index = "[" + index
+		}
+		for t != nil && t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		if t == nil || t.Kind() != reflect.Struct {
+			return ""
+		}
+		field, ok := t.FieldByName(name)
+		if !ok {
+			return ""
+		}
+		yamlName, opts, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if yamlName == "" && !strings.Contains(opts, "inline") {
+			yamlName = field.Name
+		}
+		if yamlName != "" {
+			path = append(path, yamlName+index)
+		}
+		t = field.Type
+		if index != "" {
+			for t.Kind() == reflect.Pointer {
+				t = t.Elem()
+			}
+			if k := t.Kind(); k == reflect.Slice || k == reflect.Array || k == reflect.Map {
+				t = t.Elem()
+			}
+		}
+	}
+	return strings.Join(path, ".")
+}
+
+// describeFieldError returns why the value of the field at path is invalid,
+// and how to fix it.
+func describeFieldError(fe validator.FieldError, path string) (problem, suggestion string) {
+	param := fe.Param()
+	switch fe.Tag() {
+	case "required":
+		field := path[strings.LastIndex(path, ".")+1:]
+		if i := strings.Index(field, "["); i > 0 {
+			field = field[:i]
+		}
+		return "is required", fmt.Sprintf("add a %q field", field)
+	case "oneof":
+		allowed := strings.Fields(param)
+		if closest := closestMatch(fmt.Sprint(fe.Value()), allowed); closest != "" {
+			return "must be one of: " + strings.Join(allowed, ", "), fmt.Sprintf("did you mean %q?", closest)
+		}
+		return "must be one of: " + strings.Join(allowed, ", "), "set it to one of these values"
+	case "gte", "min":
+		return "must be at least " + param, fmt.Sprintf("set it to %s or more", param)
+	case "gt":
+		return "must be greater than " + param, fmt.Sprintf("set it to more than %s", param)
+	case "lte", "max":
+		return "must be at most " + param, fmt.Sprintf("set it to %s or less", param)
+	case "lt":
+		return "must be less than " + param, fmt.Sprintf("set it to less than %s", param)
+	case "cidr", "cidrv4", "cidrv6":
+		return "must be a CIDR block", `write it as an address and a prefix length, e.g. "10.0.0.0/8"`
+	case "url", "http_url":
+		return "must be a URL", `include its scheme, e.g. "https://example.com"`
+	default:
+		return fmt.Sprintf("fails the %q validation", fe.Tag()), "see the documentation of the field for its valid values"
+	}
+}
+
+// closestMatch returns the allowed value within two edits of value, if any.
+func closestMatch(value string, allowed []string) string {
+	best, bestDistance := "", 3
+	for _, a := range allowed {
+		if d := editDistance(strings.ToLower(value), strings.ToLower(a)); d < bestDistance {
+			best, bestDistance = a, d
+		}
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,138 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/googleapis/mcp-toolbox/internal/util"
+)
+
+type ValidatedBase struct {
+	Name     string `yaml:"name" validate:"required"`
+	Coercion string `yaml:"coercion" validate:"omitempty,oneof=strict lenient"`
+}
+
+type validatedItem struct {
+	Address string `yaml:"address" validate:"cidr"`
+}
+
+type validatedConfig struct {
+	ValidatedBase `yaml:",inline"`
+	Project       string          `yaml:"project" validate:"required"`
+	Password      string          `yaml:"password" validate:"omitempty,min=8"`
+	Timeout       int             `yaml:"connectTimeout" validate:"gte=1"`
+	Items         []validatedItem `yaml:"items" validate:"dive"`
+}
+
+func TestConfigErrors(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   map[string]any
+		want []string
+	}{
+		{
+			desc: "required",
+			in:   map[string]any{"name": "n", "connectTimeout": 1},
+			want: []string{`sources[my-pg].project is required; add a "project" field`},
+		},
+		{
+			desc: "required inline field",
+			in:   map[string]any{"project": "p", "connectTimeout": 1},
+			want: []string{`sources[my-pg].name is required; add a "name" field`},
+		},
+		{
+			desc: "oneof with close match",
+			in:   map[string]any{"name": "n", "project": "p", "connectTimeout": 1, "coercion": "lenint"},
+			want: []string{`sources[my-pg].coercion has invalid value "lenint": must be one of: strict, lenient; did you mean "lenient"?`},
+		},
+		{
+			desc: "oneof without close match",
+			in:   map[string]any{"name": "n", "project": "p", "connectTimeout": 1, "coercion": "loose"},
+			want: []string{`sources[my-pg].coercion has invalid value "loose": must be one of: strict, lenient; set it to one of these values`},
+		},
+		{
+			desc: "secret value masked",
+			in:   map[string]any{"name": "n", "project": "p", "connectTimeout": 1, "password": "short"},
+			want: []string{`sources[my-pg].password has invalid value "****": must be at least 8; set it to 8 or more`},
+		},
+		{
+			desc: "several errors",
+			in:   map[string]any{"name": "n", "connectTimeout": 0},
+			want: []string{
+				`sources[my-pg].project is required; add a "project" field`,
+				`sources[my-pg].connectTimeout has invalid value "0": must be at least 1; set it to 1 or more`,
+			},
+		},
+		{
+			desc: "nested field",
+			in:   map[string]any{"name": "n", "project": "p", "connectTimeout": 1, "items": []any{map[string]any{"address": "10.0.0.0/8"}, map[string]any{"address": "10.0.0.1"}}},
+			// nested structs are validated on their own while decoding, so
+			// their paths are relative to the struct
+			want: []string{`address has invalid value "10.0.0.1": must be a CIDR block; write it as an address and a prefix length, e.g. "10.0.0.0/8"`},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			dec, err := util.NewStrictDecoderAt(tc.in, "sources[my-pg]")
+			if err != nil {
+				t.Fatalf("unable to create decoder: %s", err)
+			}
+			var cfg validatedConfig
+			err = dec.Decode(&cfg)
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			for _, want := range tc.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error %q does not contain %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestConfigErrorsFields(t *testing.T) {
+	dec, err := util.NewStrictDecoder(map[string]any{"name": "n", "connectTimeout": 1})
+	if err != nil {
+		t.Fatalf("unable to create decoder: %s", err)
+	}
+	var cfg validatedConfig
+	err = dec.Decode(&cfg)
+	var errs util.ConfigErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected ConfigErrors, got %T: %s", err, err)
+	}
+	if len(errs) != 1 || errs[0].Path != "project" || errs[0].Tag() != "required" {
+		t.Errorf("unexpected errors: %#v", errs)
+	}
+}
+
+func TestIsSecretField(t *testing.T) {
+	for field, want := range map[string]bool{
+		"password":       true,
+		"apiKey":         true,
+		"clientSecret":   true,
+		"credentialPath": true,
+		"host":           false,
+		"user":           false,
+	} {
+		if got := util.IsSecretField(field); got != want {
+			t.Errorf("unexpected secrecy of %q: got %t, want %t", field, got, want)
+		}
+	}
+}
@@ -0,0 +1,644 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: toolbox/v1/toolbox.proto
+
+package toolboxv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ListToolsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The name of the toolset. The default toolset, holding every tool, is
+	// used if empty.
+	Toolset       string `protobuf:"bytes,1,opt,name=toolset,proto3" json:"toolset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListToolsRequest) Reset() {
+	*x = ListToolsRequest{}
+	mi := &file_toolbox_v1_toolbox_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListToolsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListToolsRequest) ProtoMessage() {}
+
+func (x *ListToolsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_toolbox_v1_toolbox_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListToolsRequest.ProtoReflect.Descriptor instead.
+func (*ListToolsRequest) Descriptor() ([]byte, []int) {
+	return file_toolbox_v1_toolbox_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ListToolsRequest) GetToolset() string {
+	if x != nil {
+		return x.Toolset
+	}
+	return ""
+}
+
+type ListToolsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The version of the Toolbox server.
+	ServerVersion string `protobuf:"bytes,1,opt,name=server_version,json=serverVersion,proto3" json:"server_version,omitempty"`
+	// The tools of the toolset, in name order.
+	Tools         []*Tool `protobuf:"bytes,2,rep,name=tools,proto3" json:"tools,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListToolsResponse) Reset() {
+	*x = ListToolsResponse{}
+	mi := &file_toolbox_v1_toolbox_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListToolsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListToolsResponse) ProtoMessage() {}
+
+func (x *ListToolsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_toolbox_v1_toolbox_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListToolsResponse.ProtoReflect.Descriptor instead.
+func (*ListToolsResponse) Descriptor() ([]byte, []int) {
+	return file_toolbox_v1_toolbox_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListToolsResponse) GetServerVersion() string {
+	if x != nil {
+		return x.ServerVersion
+	}
+	return ""
+}
+
+func (x *ListToolsResponse) GetTools() []*Tool {
+	if x != nil {
+		return x.Tools
+	}
+	return nil
+}
+
+type Tool struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Name        string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Parameters  []*Parameter           `protobuf:"bytes,3,rep,name=parameters,proto3" json:"parameters,omitempty"`
+	// The auth services of which one must have verified a token of the
+	// request for the tool to be invoked.
+	AuthRequired []string `protobuf:"bytes,4,rep,name=auth_required,json=authRequired,proto3" json:"auth_required,omitempty"`
+	// Whether an administrator disabled the tool at runtime.
+	Disabled      bool `protobuf:"varint,5,opt,name=disabled,proto3" json:"disabled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Tool) Reset() {
+	*x = Tool{}
+	mi := &file_toolbox_v1_toolbox_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Tool) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tool) ProtoMessage() {}
+
+func (x *Tool) ProtoReflect() protoreflect.Message {
+	mi := &file_toolbox_v1_toolbox_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tool.ProtoReflect.Descriptor instead.
+func (*Tool) Descriptor() ([]byte, []int) {
+	return file_toolbox_v1_toolbox_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Tool) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Tool) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Tool) GetParameters() []*Parameter {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+func (x *Tool) GetAuthRequired() []string {
+	if x != nil {
+		return x.AuthRequired
+	}
+	return nil
+}
+
+func (x *Tool) GetDisabled() bool {
+	if x != nil {
+		return x.Disabled
+	}
+	return false
+}
+
+type Parameter struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// One of "string", "integer", "float", "boolean", "array" or "map".
+	Type        string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Description string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Required    bool   `protobuf:"varint,4,opt,name=required,proto3" json:"required,omitempty"`
+	// The auth services the value of the parameter is read from, instead of
+	// the request.
+	AuthServices []string `protobuf:"bytes,5,rep,name=auth_services,json=authServices,proto3" json:"auth_services,omitempty"`
+	// The parameter describing the items of an array parameter.
+	Items         *Parameter      `protobuf:"bytes,6,opt,name=items,proto3" json:"items,omitempty"`
+	Default       *structpb.Value `protobuf:"bytes,7,opt,name=default,proto3" json:"default,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Parameter) Reset() {
+	*x = Parameter{}
+	mi := &file_toolbox_v1_toolbox_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Parameter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Parameter) ProtoMessage() {}
+
+func (x *Parameter) ProtoReflect() protoreflect.Message {
+	mi := &file_toolbox_v1_toolbox_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Parameter.ProtoReflect.Descriptor instead.
+func (*Parameter) Descriptor() ([]byte, []int) {
+	return file_toolbox_v1_toolbox_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Parameter) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Parameter) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Parameter) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Parameter) GetRequired() bool {
+	if x != nil {
+		return x.Required
+	}
+	return false
+}
+
+func (x *Parameter) GetAuthServices() []string {
+	if x != nil {
+		return x.AuthServices
+	}
+	return nil
+}
+
+func (x *Parameter) GetItems() *Parameter {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *Parameter) GetDefault() *structpb.Value {
+	if x != nil {
+		return x.Default
+	}
+	return nil
+}
+
+type InvokeToolRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The name of the tool.
+	Tool string `protobuf:"bytes,1,opt,name=tool,proto3" json:"tool,omitempty"`
+	// The parameters of the invocation, by name.
+	Params        *structpb.Struct `protobuf:"bytes,2,opt,name=params,proto3" json:"params,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InvokeToolRequest) Reset() {
+	*x = InvokeToolRequest{}
+	mi := &file_toolbox_v1_toolbox_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InvokeToolRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvokeToolRequest) ProtoMessage() {}
+
+func (x *InvokeToolRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_toolbox_v1_toolbox_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvokeToolRequest.ProtoReflect.Descriptor instead.
+func (*InvokeToolRequest) Descriptor() ([]byte, []int) {
+	return file_toolbox_v1_toolbox_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *InvokeToolRequest) GetTool() string {
+	if x != nil {
+		return x.Tool
+	}
+	return ""
+}
+
+func (x *InvokeToolRequest) GetParams() *structpb.Struct {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+type InvokeToolResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The result of the invocation. Unset if error is.
+	Result *structpb.Value `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	// An error for the agent to act on, such as an invalid parameter value or
+	// a failed query. Errors of the server are returned as gRPC statuses
+	// instead.
+	Error         string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InvokeToolResponse) Reset() {
+	*x = InvokeToolResponse{}
+	mi := &file_toolbox_v1_toolbox_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InvokeToolResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvokeToolResponse) ProtoMessage() {}
+
+func (x *InvokeToolResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_toolbox_v1_toolbox_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvokeToolResponse.ProtoReflect.Descriptor instead.
+func (*InvokeToolResponse) Descriptor() ([]byte, []int) {
+	return file_toolbox_v1_toolbox_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *InvokeToolResponse) GetResult() *structpb.Value {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *InvokeToolResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type StreamInvokeToolResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Response:
+	//
+	//	*StreamInvokeToolResponse_Row
+	//	*StreamInvokeToolResponse_Result
+	//	*StreamInvokeToolResponse_Error
+	Response      isStreamInvokeToolResponse_Response `protobuf_oneof:"response"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamInvokeToolResponse) Reset() {
+	*x = StreamInvokeToolResponse{}
+	mi := &file_toolbox_v1_toolbox_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamInvokeToolResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamInvokeToolResponse) ProtoMessage() {}
+
+func (x *StreamInvokeToolResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_toolbox_v1_toolbox_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamInvokeToolResponse.ProtoReflect.Descriptor instead.
+func (*StreamInvokeToolResponse) Descriptor() ([]byte, []int) {
+	return file_toolbox_v1_toolbox_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *StreamInvokeToolResponse) GetResponse() isStreamInvokeToolResponse_Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *StreamInvokeToolResponse) GetRow() *structpb.Value {
+	if x != nil {
+		if x, ok := x.Response.(*StreamInvokeToolResponse_Row); ok {
+			return x.Row
+		}
+	}
+	return nil
+}
+
+func (x *StreamInvokeToolResponse) GetResult() *structpb.Value {
+	if x != nil {
+		if x, ok := x.Response.(*StreamInvokeToolResponse_Result); ok {
+			return x.Result
+		}
+	}
+	return nil
+}
+
+func (x *StreamInvokeToolResponse) GetError() string {
+	if x != nil {
+		if x, ok := x.Response.(*StreamInvokeToolResponse_Error); ok {
+			return x.Error
+		}
+	}
+	return ""
+}
+
+type isStreamInvokeToolResponse_Response interface {
+	isStreamInvokeToolResponse_Response()
+}
+
+type StreamInvokeToolResponse_Row struct {
+	// A row of the result of a tool that supports streaming.
+	Row *structpb.Value `protobuf:"bytes,1,opt,name=row,proto3,oneof"`
+}
+
+type StreamInvokeToolResponse_Result struct {
+	// The whole result of a tool that does not support streaming.
+	Result *structpb.Value `protobuf:"bytes,2,opt,name=result,proto3,oneof"`
+}
+
+type StreamInvokeToolResponse_Error struct {
+	// An error for the agent to act on, as in InvokeToolResponse. It ends
+	// the stream.
+	Error string `protobuf:"bytes,3,opt,name=error,proto3,oneof"`
+}
+
+func (*StreamInvokeToolResponse_Row) isStreamInvokeToolResponse_Response() {}
+
+func (*StreamInvokeToolResponse_Result) isStreamInvokeToolResponse_Response() {}
+
+func (*StreamInvokeToolResponse_Error) isStreamInvokeToolResponse_Response() {}
+
+var File_toolbox_v1_toolbox_proto protoreflect.FileDescriptor
+
+const file_toolbox_v1_toolbox_proto_rawDesc = "" +
+	"\n" +
+	"\x18toolbox/v1/toolbox.proto\x12\n" +
+	"toolbox.v1\x1a\x1cgoogle/protobuf/struct.proto\",\n" +
+	"\x10ListToolsRequest\x12\x18\n" +
+	"\atoolset\x18\x01 \x01(\tR\atoolset\"b\n" +
+	"\x11ListToolsResponse\x12%\n" +
+	"\x0eserver_version\x18\x01 \x01(\tR\rserverVersion\x12&\n" +
+	"\x05tools\x18\x02 \x03(\v2\x10.toolbox.v1.ToolR\x05tools\"\xb4\x01\n" +
+	"\x04Tool\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x125\n" +
+	"\n" +
+	"parameters\x18\x03 \x03(\v2\x15.toolbox.v1.ParameterR\n" +
+	"parameters\x12#\n" +
+	"\rauth_required\x18\x04 \x03(\tR\fauthRequired\x12\x1a\n" +
+	"\bdisabled\x18\x05 \x01(\bR\bdisabled\"\xf5\x01\n" +
+	"\tParameter\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1a\n" +
+	"\brequired\x18\x04 \x01(\bR\brequired\x12#\n" +
+	"\rauth_services\x18\x05 \x03(\tR\fauthServices\x12+\n" +
+	"\x05items\x18\x06 \x01(\v2\x15.toolbox.v1.ParameterR\x05items\x120\n" +
+	"\adefault\x18\a \x01(\v2\x16.google.protobuf.ValueR\adefault\"X\n" +
+	"\x11InvokeToolRequest\x12\x12\n" +
+	"\x04tool\x18\x01 \x01(\tR\x04tool\x12/\n" +
+	"\x06params\x18\x02 \x01(\v2\x17.google.protobuf.StructR\x06params\"Z\n" +
+	"\x12InvokeToolResponse\x12.\n" +
+	"\x06result\x18\x01 \x01(\v2\x16.google.protobuf.ValueR\x06result\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"\x9c\x01\n" +
+	"\x18StreamInvokeToolResponse\x12*\n" +
+	"\x03row\x18\x01 \x01(\v2\x16.google.protobuf.ValueH\x00R\x03row\x120\n" +
+	"\x06result\x18\x02 \x01(\v2\x16.google.protobuf.ValueH\x00R\x06result\x12\x16\n" +
+	"\x05error\x18\x03 \x01(\tH\x00R\x05errorB\n" +
+	"\n" +
+	"\bresponse2\x82\x02\n" +
+	"\x0eToolboxService\x12H\n" +
+	"\tListTools\x12\x1c.toolbox.v1.ListToolsRequest\x1a\x1d.toolbox.v1.ListToolsResponse\x12K\n" +
+	"\n" +
+	"InvokeTool\x12\x1d.toolbox.v1.InvokeToolRequest\x1a\x1e.toolbox.v1.InvokeToolResponse\x12Y\n" +
+	"\x10StreamInvokeTool\x12\x1d.toolbox.v1.InvokeToolRequest\x1a$.toolbox.v1.StreamInvokeToolResponse0\x01BAZ?github.com/googleapis/mcp-toolbox/pkg/grpc/toolbox/v1;toolboxv1b\x06proto3"
+
+var (
+	file_toolbox_v1_toolbox_proto_rawDescOnce sync.Once
+	file_toolbox_v1_toolbox_proto_rawDescData []byte
+)
+
+func file_toolbox_v1_toolbox_proto_rawDescGZIP() []byte {
+	file_toolbox_v1_toolbox_proto_rawDescOnce.Do(func() {
+		file_toolbox_v1_toolbox_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_toolbox_v1_toolbox_proto_rawDesc), len(file_toolbox_v1_toolbox_proto_rawDesc)))
+	})
+	return file_toolbox_v1_toolbox_proto_rawDescData
+}
+
+var file_toolbox_v1_toolbox_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_toolbox_v1_toolbox_proto_goTypes = []any{
+	(*ListToolsRequest)(nil),         // 0: toolbox.v1.ListToolsRequest
+	(*ListToolsResponse)(nil),        // 1: toolbox.v1.ListToolsResponse
+	(*Tool)(nil),                     // 2: toolbox.v1.Tool
+	(*Parameter)(nil),                // 3: toolbox.v1.Parameter
+	(*InvokeToolRequest)(nil),        // 4: toolbox.v1.InvokeToolRequest
+	(*InvokeToolResponse)(nil),       // 5: toolbox.v1.InvokeToolResponse
+	(*StreamInvokeToolResponse)(nil), // 6: toolbox.v1.StreamInvokeToolResponse
+	(*structpb.Value)(nil),           // 7: google.protobuf.Value
+	(*structpb.Struct)(nil),          // 8: google.protobuf.Struct
+}
+var file_toolbox_v1_toolbox_proto_depIdxs = []int32{
+	2,  // 0: toolbox.v1.ListToolsResponse.tools:type_name -> toolbox.v1.Tool
+	3,  // 1: toolbox.v1.Tool.parameters:type_name -> toolbox.v1.Parameter
+	3,  // 2: toolbox.v1.Parameter.items:type_name -> toolbox.v1.Parameter
+	7,  // 3: toolbox.v1.Parameter.default:type_name -> google.protobuf.Value
+	8,  // 4: toolbox.v1.InvokeToolRequest.params:type_name -> google.protobuf.Struct
+	7,  // 5: toolbox.v1.InvokeToolResponse.result:type_name -> google.protobuf.Value
+	7,  // 6: toolbox.v1.StreamInvokeToolResponse.row:type_name -> google.protobuf.Value
+	7,  // 7: toolbox.v1.StreamInvokeToolResponse.result:type_name -> google.protobuf.Value
+	0,  // 8: toolbox.v1.ToolboxService.ListTools:input_type -> toolbox.v1.ListToolsRequest
+	4,  // 9: toolbox.v1.ToolboxService.InvokeTool:input_type -> toolbox.v1.InvokeToolRequest
+	4,  // 10: toolbox.v1.ToolboxService.StreamInvokeTool:input_type -> toolbox.v1.InvokeToolRequest
+	1,  // 11: toolbox.v1.ToolboxService.ListTools:output_type -> toolbox.v1.ListToolsResponse
+	5,  // 12: toolbox.v1.ToolboxService.InvokeTool:output_type -> toolbox.v1.InvokeToolResponse
+	6,  // 13: toolbox.v1.ToolboxService.StreamInvokeTool:output_type -> toolbox.v1.StreamInvokeToolResponse
+	11, // [11:14] is the sub-list for method output_type
+	8,  // [8:11] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_toolbox_v1_toolbox_proto_init() }
+func file_toolbox_v1_toolbox_proto_init() {
+	if File_toolbox_v1_toolbox_proto != nil {
+		return
+	}
+	file_toolbox_v1_toolbox_proto_msgTypes[6].OneofWrappers = []any{
+		(*StreamInvokeToolResponse_Row)(nil),
+		(*StreamInvokeToolResponse_Result)(nil),
+		(*StreamInvokeToolResponse_Error)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_toolbox_v1_toolbox_proto_rawDesc), len(file_toolbox_v1_toolbox_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_toolbox_v1_toolbox_proto_goTypes,
+		DependencyIndexes: file_toolbox_v1_toolbox_proto_depIdxs,
+		MessageInfos:      file_toolbox_v1_toolbox_proto_msgTypes,
+	}.Build()
+	File_toolbox_v1_toolbox_proto = out.File
+	file_toolbox_v1_toolbox_proto_goTypes = nil
+	file_toolbox_v1_toolbox_proto_depIdxs = nil
+}
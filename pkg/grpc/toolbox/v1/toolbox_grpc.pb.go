@@ -0,0 +1,239 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: toolbox/v1/toolbox.proto
+
+package toolboxv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ToolboxService_ListTools_FullMethodName        = "/toolbox.v1.ToolboxService/ListTools"
+	ToolboxService_InvokeTool_FullMethodName       = "/toolbox.v1.ToolboxService/InvokeTool"
+	ToolboxService_StreamInvokeTool_FullMethodName = "/toolbox.v1.ToolboxService/StreamInvokeTool"
+)
+
+// ToolboxServiceClient is the client API for ToolboxService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ToolboxService lists the tools of a Toolbox server and invokes them. It
+// mirrors the HTTP API served under /api.
+//
+// Auth tokens are sent as request metadata, under the same names as the
+// headers of the HTTP API: "<authService>_token" for the auth services of
+// the tools and "authorization" for client OAuth.
+type ToolboxServiceClient interface {
+	// ListTools returns the tools of a toolset.
+	ListTools(ctx context.Context, in *ListToolsRequest, opts ...grpc.CallOption) (*ListToolsResponse, error)
+	// InvokeTool invokes a tool and returns its result.
+	InvokeTool(ctx context.Context, in *InvokeToolRequest, opts ...grpc.CallOption) (*InvokeToolResponse, error)
+	// StreamInvokeTool invokes a tool and streams its result. Tools that
+	// support streaming send one response per row, the others a single
+	// response holding the whole result.
+	StreamInvokeTool(ctx context.Context, in *InvokeToolRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamInvokeToolResponse], error)
+}
+
+type toolboxServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewToolboxServiceClient(cc grpc.ClientConnInterface) ToolboxServiceClient {
+	return &toolboxServiceClient{cc}
+}
+
+func (c *toolboxServiceClient) ListTools(ctx context.Context, in *ListToolsRequest, opts ...grpc.CallOption) (*ListToolsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListToolsResponse)
+	err := c.cc.Invoke(ctx, ToolboxService_ListTools_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toolboxServiceClient) InvokeTool(ctx context.Context, in *InvokeToolRequest, opts ...grpc.CallOption) (*InvokeToolResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(InvokeToolResponse)
+	err := c.cc.Invoke(ctx, ToolboxService_InvokeTool_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toolboxServiceClient) StreamInvokeTool(ctx context.Context, in *InvokeToolRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamInvokeToolResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ToolboxService_ServiceDesc.Streams[0], ToolboxService_StreamInvokeTool_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[InvokeToolRequest, StreamInvokeToolResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ToolboxService_StreamInvokeToolClient = grpc.ServerStreamingClient[StreamInvokeToolResponse]
+
+// ToolboxServiceServer is the server API for ToolboxService service.
+// All implementations must embed UnimplementedToolboxServiceServer
+// for forward compatibility.
+//
+// ToolboxService lists the tools of a Toolbox server and invokes them. It
+// mirrors the HTTP API served under /api.
+//
+// Auth tokens are sent as request metadata, under the same names as the
+// headers of the HTTP API: "<authService>_token" for the auth services of
+// the tools and "authorization" for client OAuth.
+type ToolboxServiceServer interface {
+	// ListTools returns the tools of a toolset.
+	ListTools(context.Context, *ListToolsRequest) (*ListToolsResponse, error)
+	// InvokeTool invokes a tool and returns its result.
+	InvokeTool(context.Context, *InvokeToolRequest) (*InvokeToolResponse, error)
+	// StreamInvokeTool invokes a tool and streams its result. Tools that
+	// support streaming send one response per row, the others a single
+	// response holding the whole result.
+	StreamInvokeTool(*InvokeToolRequest, grpc.ServerStreamingServer[StreamInvokeToolResponse]) error
+	mustEmbedUnimplementedToolboxServiceServer()
+}
+
+// UnimplementedToolboxServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedToolboxServiceServer struct{}
+
+func (UnimplementedToolboxServiceServer) ListTools(context.Context, *ListToolsRequest) (*ListToolsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTools not implemented")
+}
+func (UnimplementedToolboxServiceServer) InvokeTool(context.Context, *InvokeToolRequest) (*InvokeToolResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InvokeTool not implemented")
+}
+func (UnimplementedToolboxServiceServer) StreamInvokeTool(*InvokeToolRequest, grpc.ServerStreamingServer[StreamInvokeToolResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamInvokeTool not implemented")
+}
+func (UnimplementedToolboxServiceServer) mustEmbedUnimplementedToolboxServiceServer() {}
+func (UnimplementedToolboxServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeToolboxServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ToolboxServiceServer will
+// result in compilation errors.
+type UnsafeToolboxServiceServer interface {
+	mustEmbedUnimplementedToolboxServiceServer()
+}
+
+func RegisterToolboxServiceServer(s grpc.ServiceRegistrar, srv ToolboxServiceServer) {
+	// If the following call pancis, it indicates UnimplementedToolboxServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ToolboxService_ServiceDesc, srv)
+}
+
+func _ToolboxService_ListTools_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListToolsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToolboxServiceServer).ListTools(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ToolboxService_ListTools_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToolboxServiceServer).ListTools(ctx, req.(*ListToolsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToolboxService_InvokeTool_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InvokeToolRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToolboxServiceServer).InvokeTool(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ToolboxService_InvokeTool_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToolboxServiceServer).InvokeTool(ctx, req.(*InvokeToolRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ToolboxService_StreamInvokeTool_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(InvokeToolRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ToolboxServiceServer).StreamInvokeTool(m, &grpc.GenericServerStream[InvokeToolRequest, StreamInvokeToolResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ToolboxService_StreamInvokeToolServer = grpc.ServerStreamingServer[StreamInvokeToolResponse]
+
+// ToolboxService_ServiceDesc is the grpc.ServiceDesc for ToolboxService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ToolboxService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "toolbox.v1.ToolboxService",
+	HandlerType: (*ToolboxServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListTools",
+			Handler:    _ToolboxService_ListTools_Handler,
+		},
+		{
+			MethodName: "InvokeTool",
+			Handler:    _ToolboxService_InvokeTool_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamInvokeTool",
+			Handler:       _ToolboxService_StreamInvokeTool_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "toolbox/v1/toolbox.proto",
+}
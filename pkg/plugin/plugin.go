@@ -0,0 +1,207 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package plugin implements the protocol between Toolbox and the external
+// programs of "plugin" sources, so that tools for databases and services that
+// Toolbox does not support can be added without forking it.
+//
+// Toolbox starts the program of a plugin source and exchanges JSON messages
+// with it, one per line: requests on the program's stdin and responses on its
+// stdout. Requests carry an increasing ID, which the response repeats, and may
+// be answered in any order. The program exits when its stdin is closed.
+//
+// The methods are:
+//
+//   - "initialize", sent once after the start with InitializeParams, and
+//     answered with InitializeResult.
+//   - "invoke", sent for each invocation of a "plugin" tool with
+//     InvokeParams, and answered with the result of the tool.
+//   - "ping", sent to check the health of the program, and answered with a
+//     null result.
+//
+// Programs written in Go implement a Handler and call Serve.
+package plugin
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// ProtocolVersion is the version of the protocol implemented by this package.
+const ProtocolVersion = 1
+
+const (
+	MethodInitialize = "initialize"
+	MethodInvoke     = "invoke"
+	MethodPing       = "ping"
+)
+
+// maxMessageSize is the size limit of a single message.
+const maxMessageSize = 64 << 20
+
+// Request is a request sent by Toolbox to the plugin program.
+type Request struct {
+	ID     uint64          `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params,omitempty"`
+}
+
+// Response is the answer of the plugin program to the request with the same
+// ID. Exactly one of Result and Error is set.
+type Response struct {
+	ID     uint64          `json:"id"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  *Error          `json:"error,omitempty"`
+}
+
+// Error is an error returned by the plugin program.
+type Error struct {
+	Message string `json:"message"`
+	// Agent reports that the error is caused by the parameters of the
+	// invocation, so that it is returned to the agent to correct them rather
+	// than reported as a server error.
+	Agent bool `json:"agent,omitempty"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// AgentError returns an error caused by the parameters of an invocation.
+func AgentError(format string, a ...any) error {
+	return &Error{Message: fmt.Sprintf(format, a...), Agent: true}
+}
+
+// InitializeParams are the parameters of the "initialize" method.
+type InitializeParams struct {
+	ProtocolVersion int `json:"protocolVersion"`
+	// Source is the name of the plugin source.
+	Source string `json:"source"`
+	// Settings are the settings of the plugin source, as configured.
+	Settings map[string]any `json:"settings,omitempty"`
+}
+
+// InitializeResult is the result of the "initialize" method.
+type InitializeResult struct {
+	// ProtocolVersion is the version of the protocol implemented by the
+	// program, which must match the version of Toolbox.
+	ProtocolVersion int `json:"protocolVersion"`
+}
+
+// InvokeParams are the parameters of the "invoke" method.
+type InvokeParams struct {
+	// Tool is the name of the invoked tool.
+	Tool string `json:"tool"`
+	// Operation is the operation of the tool, as configured.
+	Operation string `json:"operation"`
+	// Settings are the settings of the tool, as configured.
+	Settings map[string]any `json:"settings,omitempty"`
+	// Params are the values of the parameters of the invocation.
+	Params map[string]any `json:"params"`
+}
+
+// Handler implements the methods of a plugin program.
+type Handler interface {
+	// Initialize is called once, before any invocation.
+	Initialize(ctx context.Context, params InitializeParams) error
+	// Invoke runs an invocation of a tool and returns its result, which
+	// must be encodable as JSON. Invoke may be called concurrently.
+	Invoke(ctx context.Context, params InvokeParams) (any, error)
+}
+
+// Serve reads the requests of Toolbox from r and writes the responses of h to
+// w until r is closed, typically with os.Stdin and os.Stdout. Invocations run
+// concurrently, and are cancelled when Serve returns.
+func Serve(ctx context.Context, h Handler, r io.Reader, w io.Writer) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	enc := json.NewEncoder(w)
+	respond := func(id uint64, result any, err error) {
+		resp := Response{ID: id}
+		if err == nil {
+			resp.Result, err = json.Marshal(result)
+		}
+		if err != nil {
+			var pluginErr *Error
+			if !errors.As(err, &pluginErr) {
+				pluginErr = &Error{Message: err.Error()}
+			}
+			resp.Result = nil
+			resp.Error = pluginErr
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		// Write errors surface as a closed stdin on the next read.
+		_ = enc.Encode(resp)
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxMessageSize)
+	initialized := false
+	for scanner.Scan() {
+		var req Request
+		if err := json.Unmarshal(scanner.Bytes(), &req); err != nil {
+			wg.Wait()
+			return fmt.Errorf("invalid request: %w", err)
+		}
+		switch req.Method {
+		case MethodInitialize:
+			var params InitializeParams
+			if err := json.Unmarshal(req.Params, &params); err != nil {
+				respond(req.ID, nil, err)
+				continue
+			}
+			if params.ProtocolVersion != ProtocolVersion {
+				respond(req.ID, nil, fmt.Errorf("unsupported protocol version %d, want %d", params.ProtocolVersion, ProtocolVersion))
+				continue
+			}
+			err := h.Initialize(ctx, params)
+			initialized = err == nil
+			respond(req.ID, InitializeResult{ProtocolVersion: ProtocolVersion}, err)
+		case MethodInvoke:
+			if !initialized {
+				respond(req.ID, nil, errors.New("plugin is not initialized"))
+				continue
+			}
+			var params InvokeParams
+			if err := json.Unmarshal(req.Params, &params); err != nil {
+				respond(req.ID, nil, err)
+				continue
+			}
+			wg.Add(1)
+			go func(id uint64) {
+				defer wg.Done()
+				result, err := h.Invoke(ctx, params)
+				respond(id, result, err)
+			}(req.ID)
+		case MethodPing:
+			respond(req.ID, nil, nil)
+		default:
+			respond(req.ID, nil, fmt.Errorf("unknown method %q", req.Method))
+		}
+	}
+	cancel()
+	wg.Wait()
+	return scanner.Err()
+}
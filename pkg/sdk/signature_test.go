@@ -0,0 +1,58 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk_test
+
+import (
+	"testing"
+
+	"github.com/googleapis/mcp-toolbox/pkg/sdk"
+)
+
+func TestVerifySignature(t *testing.T) {
+	key := []byte("my-key")
+	body := []byte(`{"result":"[]"}`)
+	signature := sdk.Sign(key, body)
+
+	tcs := []struct {
+		desc      string
+		key       []byte
+		body      []byte
+		signature string
+		want      bool
+	}{
+		{desc: "valid", key: key, body: body, signature: signature, want: true},
+		{desc: "tampered body", key: key, body: []byte(`{"result":"[1]"}`), signature: signature},
+		{desc: "wrong key", key: []byte("other-key"), body: body, signature: signature},
+		{desc: "missing prefix", key: key, body: body, signature: signature[len("sha256="):]},
+		{desc: "not hex", key: key, body: body, signature: "sha256=zz"},
+		{desc: "empty", key: key, body: body},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := sdk.VerifySignature(tc.key, tc.body, tc.signature); got != tc.want {
+				t.Errorf("unexpected result: got %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSign(t *testing.T) {
+	// HMAC-SHA256 of "The quick brown fox jumps over the lazy dog" with key
+	// "key".
+	want := "sha256=f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8"
+	if got := sdk.Sign([]byte("key"), []byte("The quick brown fox jumps over the lazy dog")); got != want {
+		t.Errorf("unexpected signature: got %s, want %s", got, want)
+	}
+}
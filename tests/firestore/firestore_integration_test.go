@@ -1201,6 +1201,13 @@ func runFirestoreGetDocumentsTest(t *testing.T, docPath1, docPath2 string) {
 			wantRegex:   `"name":"Alice".*"name":"Bob"`,
 			isErr:       false,
 		},
+		{
+			name:        "get document with its path injected",
+			api:         "http://127.0.0.1:5000/api/tool/firestore-get-docs/invoke",
+			requestBody: bytes.NewBuffer([]byte(fmt.Sprintf(`{"documentPaths": ["%s"]}`, docPath1))),
+			wantRegex:   fmt.Sprintf(`"_path":"%s"`, regexp.QuoteMeta(docPath1)),
+			isErr:       false,
+		},
 		{
 			name:        "get non-existent document",
 			api:         "http://127.0.0.1:5000/api/tool/firestore-get-docs/invoke",
@@ -381,6 +381,22 @@ func runToolAggregateInvokeTest(t *testing.T, aggregate1Want string, aggregateMa
 			want:          aggregateManyWant,
 			isErr:         false,
 		},
+		{
+			name:          "invoke my-pipeline-aggregate-tool",
+			api:           "http://127.0.0.1:5000/api/tool/my-pipeline-aggregate-tool/invoke",
+			requestHeader: map[string]string{},
+			requestBody:   bytes.NewBuffer([]byte(`{ "name" : "ToBeAggregated", "minId" : 0 }`)),
+			want:          `[{"_id":"ToBeAggregated","count":2,"total":1001}]`,
+			isErr:         false,
+		},
+		{
+			name:          "invoke my-pipeline-aggregate-tool with a typed integer",
+			api:           "http://127.0.0.1:5000/api/tool/my-pipeline-aggregate-tool/invoke",
+			requestHeader: map[string]string{},
+			requestBody:   bytes.NewBuffer([]byte(`{ "name" : "ToBeAggregated", "minId" : 501 }`)),
+			want:          `[{"_id":"ToBeAggregated","count":1,"total":501}]`,
+			isErr:         false,
+		},
 		{
 			name:          "invoke my-read-only-aggregate-tool",
 			api:           "http://127.0.0.1:5000/api/tool/my-read-only-aggregate-tool/invoke",
@@ -740,6 +756,40 @@ func getMongoDBToolsConfig(sourceConfig map[string]any, toolType string) map[str
 				},
 				"database": MongoDbDatabase,
 			},
+			"my-pipeline-aggregate-tool": map[string]any{
+				"type":         "mongodb-aggregate",
+				"source":       "my-instance",
+				"description":  "Tool to test a structured aggregation pipeline.",
+				"authRequired": []string{},
+				"collection":   "test_collection",
+				"readOnly":     true,
+				"allowDiskUse": true,
+				"maxTimeMS":    10000,
+				"pipeline": []any{
+					map[string]any{"$match": map[string]any{
+						"name": "{{.name}}",
+						"id":   map[string]any{"$gte": "{{.minId}}"},
+					}},
+					map[string]any{"$group": map[string]any{
+						"_id":   "$name",
+						"count": map[string]any{"$sum": 1},
+						"total": map[string]any{"$sum": "$id"},
+					}},
+				},
+				"pipelineParams": []map[string]any{
+					{
+						"name":        "name",
+						"type":        "string",
+						"description": "user name",
+					},
+					{
+						"name":        "minId",
+						"type":        "integer",
+						"description": "minimum id",
+					},
+				},
+				"database": MongoDbDatabase,
+			},
 			"my-read-only-aggregate-tool": map[string]any{
 				"type":            "mongodb-aggregate",
 				"source":          "my-instance",
@@ -16,6 +16,7 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/url"
 	"os"
@@ -25,9 +26,16 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/googleapis/mcp-toolbox/internal/sources"
+	"github.com/googleapis/mcp-toolbox/internal/sources/postgres"
 	"github.com/googleapis/mcp-toolbox/internal/testutils"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/tools/postgres/postgrescopyinsert"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
 	"github.com/googleapis/mcp-toolbox/tests"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/otel/trace/noop"
 )
 
 var (
@@ -180,3 +188,134 @@ func TestPostgres(t *testing.T) {
 	tests.RunPostgresListStoredProcedureTest(t, ctx, pool)
 	tests.RunSemanticSearchToolInvokeTest(t, "[]", "", "The quick brown fox")
 }
+
+func TestPostgresPoolExhaustion(t *testing.T) {
+	getPostgresVars(t)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	cfg := postgres.Config{
+		Name:           "my-pg-instance",
+		Type:           PostgresSourceType,
+		Host:           PostgresHost,
+		Port:           PostgresPort,
+		Database:       PostgresDatabase,
+		User:           PostgresUser,
+		Password:       PostgresPass,
+		QueryParams:    map[string]string{"pool_max_conns": "1"},
+		AcquireTimeout: "1s",
+	}
+	source, err := cfg.Initialize(ctx, noop.NewTracerProvider().Tracer(""))
+	if err != nil {
+		t.Fatalf("unable to initialize source: %s", err)
+	}
+	defer source.(*postgres.Source).Close()
+
+	// two slow invocations compete for the only connection of the pool
+	errs := make(chan error, 2)
+	for range 2 {
+		go func() {
+			_, err := source.(*postgres.Source).RunSQL(ctx, "SELECT pg_sleep(3)", nil)
+			errs <- err
+		}()
+	}
+	var busy []error
+	for range 2 {
+		if err := <-errs; err != nil {
+			busy = append(busy, err)
+		}
+	}
+	if len(busy) != 1 {
+		t.Fatalf("expected exactly one invocation to fail, got errors %v", busy)
+	}
+	var busyErr *util.SourceBusyError
+	if !errors.As(busy[0], &busyErr) {
+		t.Fatalf("expected a SourceBusyError, got %v", busy[0])
+	}
+	if busyErr.Max != 1 || busyErr.Acquired != 1 || busyErr.Idle != 0 {
+		t.Errorf("unexpected pool statistics: %+v", busyErr)
+	}
+	if !errors.Is(util.ProcessGeneralError(busy[0]), util.ErrSourceBusy) {
+		t.Errorf("expected the processed error to match ErrSourceBusy")
+	}
+}
+
+// sourceProvider provides a single source to tools invoked directly.
+type sourceProvider struct {
+	source sources.Source
+}
+
+func (p sourceProvider) GetSource(string) (sources.Source, bool) {
+	return p.source, true
+}
+
+func TestPostgresCopyInsertThroughput(t *testing.T) {
+	getPostgresVars(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	cfg := postgres.Config{
+		Name:     "my-pg-instance",
+		Type:     PostgresSourceType,
+		Host:     PostgresHost,
+		Port:     PostgresPort,
+		Database: PostgresDatabase,
+		User:     PostgresUser,
+		Password: PostgresPass,
+	}
+	source, err := cfg.Initialize(ctx, noop.NewTracerProvider().Tracer(""))
+	if err != nil {
+		t.Fatalf("unable to initialize source: %s", err)
+	}
+	pgSource := source.(*postgres.Source)
+	defer pgSource.Close()
+
+	table := "copy_insert_" + strings.ReplaceAll(uuid.New().String(), "-", "")
+	if _, err := pgSource.PostgresPool().Exec(ctx, fmt.Sprintf("CREATE TABLE %s (id INT PRIMARY KEY, name TEXT)", table)); err != nil {
+		t.Fatalf("unable to create table: %s", err)
+	}
+	defer func() {
+		_, _ = pgSource.PostgresPool().Exec(context.Background(), fmt.Sprintf("DROP TABLE %s", table))
+	}()
+
+	const rowCount = 10000
+	rows := make([]any, rowCount)
+	for i := range rows {
+		rows[i] = map[string]any{"id": i, "name": fmt.Sprintf("row-%d", i)}
+	}
+
+	load := func(useCopy bool) time.Duration {
+		toolCfg := postgrescopyinsert.Config{
+			ConfigBase:      tools.ConfigBase{Name: "load_rows", Description: "Load rows."},
+			Type:            "postgres-copy-insert",
+			Source:          "my-pg-instance",
+			Table:           table,
+			Columns:         []string{"id", "name"},
+			UsePostgresCopy: &useCopy,
+		}
+		tool, err := toolCfg.Initialize(ctx)
+		if err != nil {
+			t.Fatalf("unable to initialize tool: %s", err)
+		}
+		start := time.Now()
+		res, toolErr := tool.Invoke(ctx, sourceProvider{source}, parameters.ParamValues{{Name: "rows", Value: rows}}, "")
+		elapsed := time.Since(start)
+		if toolErr != nil {
+			t.Fatalf("unable to load rows with usePostgresCopy %t: %s", useCopy, toolErr)
+		}
+		if got := res.(postgrescopyinsert.Result).RowCount; got != rowCount {
+			t.Fatalf("unexpected row count with usePostgresCopy %t: got %d, want %d", useCopy, got, rowCount)
+		}
+		if _, err := pgSource.PostgresPool().Exec(ctx, fmt.Sprintf("TRUNCATE %s", table)); err != nil {
+			t.Fatalf("unable to truncate table: %s", err)
+		}
+		return elapsed
+	}
+
+	inserts := load(false)
+	copies := load(true)
+	t.Logf("%d rows: %s with INSERT, %s with COPY", rowCount, inserts, copies)
+	if copies*5 > inserts {
+		t.Errorf("expected COPY to be at least 5x faster than INSERT, got %s and %s", copies, inserts)
+	}
+}
@@ -264,7 +264,7 @@ func getScyllaDBWants() (string, string, string, string, string, string) {
 	selectIdNameWant := "[{\"id\":3,\"name\":\"Alice\"}]"
 	selectIdNullWant := "[{\"id\":4,\"name\":\"\"}]"
 	selectArrayParamWant := "[{\"id\":1,\"name\":\"Sid\"},{\"id\":3,\"name\":\"Alice\"}]"
-	mcpMyFailToolWant := "{\"jsonrpc\":\"2.0\",\"id\":\"invoke-fail-tool\",\"result\":{\"content\":[{\"type\":\"text\",\"text\":\"error processing request: failed to execute ScyllaDB query: line 1:0 no viable alternative at input 'SELEC' (potentially executed: false)\"}],\"isError\":true}}"
+	mcpMyFailToolWant := "\"content\":[{\"type\":\"text\",\"text\":\"error processing request: failed to execute ScyllaDB query: line 1:0 no viable alternative at input 'SELEC' (potentially executed: false)\"}],\"isError\":true"
 	mcpMyToolIdWant := "{\"jsonrpc\":\"2.0\",\"id\":\"my-tool\",\"result\":{\"content\":[{\"type\":\"text\",\"text\":\"[{\\\"id\\\":3,\\\"name\\\":\\\"Alice\\\"}]\"}]}}"
 	return selectIdNameWant, selectIdNullWant, selectArrayParamWant, mcpMyFailToolWant, "nil", mcpMyToolIdWant
 }